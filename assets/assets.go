@@ -0,0 +1,34 @@
+// Package assets embeds the default banners shipped in this directory, so a
+// binary started without them on disk still draws its menus. Files on disk
+// always take precedence.
+package assets
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed banners/*.txt banners/*.md
+var files embed.FS
+
+// Banners holds the default banner files by file name, e.g. "main_anon.txt"
+var Banners fs.FS
+
+func init() {
+	banners, err := fs.Sub(files, "banners")
+	if err != nil {
+		panic(err)
+	}
+	Banners = banners
+}
+
+// Banner returns the default content of a banner by name, e.g. "main_anon".
+// Banners written in markdown, such as "credits", are .md files.
+func Banner(name string) ([]byte, bool) {
+	for _, ext := range []string{".txt", ".md"} {
+		if content, err := fs.ReadFile(Banners, name+ext); err == nil {
+			return content, true
+		}
+	}
+	return nil, false
+}
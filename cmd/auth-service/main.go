@@ -2,25 +2,27 @@ package main
 
 import (
 	"context"
-	"crypto/rand"
-	"encoding/hex"
 	"flag"
 	"fmt"
-	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"github.com/dungeongate/internal/auth"
-	"github.com/dungeongate/internal/user"
 	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/buildinfo"
 	"github.com/dungeongate/pkg/config"
 	"github.com/dungeongate/pkg/database"
-	"github.com/dungeongate/pkg/encryption"
+	"github.com/dungeongate/pkg/healthcheck"
+	"github.com/dungeongate/pkg/interceptors"
+	"github.com/dungeongate/pkg/leader"
+	"github.com/dungeongate/pkg/listener"
 	"github.com/dungeongate/pkg/logging"
 	"github.com/dungeongate/pkg/metrics"
+	"github.com/dungeongate/pkg/ratelimit"
+	"github.com/dungeongate/pkg/scheduler"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
@@ -53,9 +55,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// `auth-service healthcheck` probes the running service for containers
+	if flag.Arg(0) == "healthcheck" {
+		os.Exit(healthcheck.Run("auth-service", healthTarget(cfg), os.Stdout, os.Stderr))
+	}
+
 	// Initialize standardized logging
-	logger := logging.NewLoggerBasic("auth-service", cfg.Logging.Level, cfg.Logging.Format, cfg.Logging.Output)
+	logger := logging.NewLogger("auth-service", logging.FromConfig(cfg.Logging))
+	logging.ReopenOnHangup(logger)
 	logger.Info("Starting DungeonGate Auth Service")
+	config.LogEnvExpansions(logger, cfg.EnvExpansions)
 
 	// Initialize metrics registry
 	metricsRegistry := metrics.NewRegistry("auth-service", version, buildTime, gitCommit, logger)
@@ -78,58 +87,61 @@ func main() {
 	}
 	defer db.Close()
 
-	// Setup encryption
-	encryptor, err := encryption.New(&config.EncryptionConfig{
-		Enabled:             true,
-		Algorithm:           "AES-256-GCM",
-		KeyRotationInterval: "24h",
-	})
+	// Setup auth service
+	authService, err := auth.NewServiceFromConfig(db, cfg, logger)
 	if err != nil {
-		logger.Error("Failed to initialize encryption", "error", err)
+		logger.Error("Failed to create auth service", "error", err)
 		os.Exit(1)
 	}
+	build := buildinfo.New("auth-service", version, buildTime, gitCommit)
+	authService.SetBuild(build)
 
-	// Setup user service
-	sessionCfg := config.GetDefaultDevelopmentConfig()
-	userService, err := user.NewService(db, cfg, sessionCfg)
+	// Setup context for graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Run background jobs such as clearing expired account locks
+	var jobOverrides map[string]*config.JobConfig
+	var election *config.LeaderElectionConfig
+	if cfg.Server != nil {
+		jobOverrides = cfg.Server.Jobs
+		election = cfg.Server.LeaderElection
+	}
+	jobs := scheduler.New(jobOverrides, logger)
+	jobs.SetRecorder(metricsRegistry)
+	elector, err := leader.New(election, db.Writer(), auth.LeaseName, logger)
 	if err != nil {
-		logger.Error("Failed to create user service", "error", err)
+		logger.Error("Failed to set up leader election", "error", err)
 		os.Exit(1)
 	}
-
-	// Generate JWT secret if not provided
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		logger.Info("JWT_SECRET not set, generating random secret (not recommended for production)")
-		secretBytes := make([]byte, 32)
-		if _, err := rand.Read(secretBytes); err != nil {
-			logger.Error("Failed to generate JWT secret", "error", err)
-			os.Exit(1)
-		}
-		jwtSecret = hex.EncodeToString(secretBytes)
+	if elector != nil {
+		jobs.SetLeader(elector)
 	}
-
-	// Setup auth service
-	authConfig := &auth.Config{
-		JWTSecret:              jwtSecret,
-		JWTIssuer:              "dungeongate-auth",
-		AccessTokenExpiration:  15 * time.Minute,
-		RefreshTokenExpiration: 7 * 24 * time.Hour,
-		MaxLoginAttempts:       3,
-		LockoutDuration:        15 * time.Minute,
+	if err := authService.AddJobs(jobs); err != nil {
+		logger.Error("Failed to schedule background jobs", "error", err)
+		os.Exit(1)
 	}
+	jobsDone := make(chan struct{})
+	go func() {
+		jobs.Run(ctx)
+		close(jobsDone)
+	}()
 
-	authService := auth.NewService(db, userService, *encryptor, authConfig, logger)
-
-	// Setup context for graceful shutdown
-	_, cancel := context.WithCancel(context.Background())
-	defer cancel()
+	// Reread the feature flags other replicas set at runtime
+	go authService.RunFeatureFlags(ctx)
 
-	// Setup gRPC server with metrics interceptors
-	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(metricsRegistry.UnaryServerInterceptor()),
-		grpc.StreamInterceptor(metricsRegistry.StreamServerInterceptor()),
-	)
+	// Setup gRPC server with recovery, logging, metrics, service auth and rate limits
+	interceptorOptions := interceptors.Options{Logger: logger, Metrics: metricsRegistry}
+	if cfg.Server != nil {
+		interceptorOptions.AuthToken = cfg.Server.AuthToken
+		rateLimits, err := ratelimit.NewPolicy(cfg.Server.RateLimits)
+		if err != nil {
+			logger.Error("Failed to configure rate limits", "error", err)
+			os.Exit(1)
+		}
+		interceptorOptions.RateLimits = rateLimits
+	}
+	grpcServer := grpc.NewServer(interceptors.ServerOptions(interceptorOptions)...)
 	proto.RegisterAuthServiceServer(grpcServer, authService)
 	reflection.Register(grpcServer)
 
@@ -141,46 +153,68 @@ func main() {
 		grpcPort = cfg.Server.Port // fallback to main port
 	}
 
+	// Unix and systemd sockets replace the TCP listeners when configured
+	var listenConfig *config.ListenConfig
+	if cfg.Server != nil {
+		listenConfig = cfg.Server.Listen
+	}
+	listeners, err := listener.New(listenConfig)
+	if err != nil {
+		logger.Error("Failed to set up listeners", "error", err)
+		os.Exit(1)
+	}
+
 	// Start gRPC server
-	grpcListener, err := net.Listen("tcp", fmt.Sprintf(":%d", grpcPort))
+	grpcListener, err := listeners.Listen(listener.GRPC, fmt.Sprintf(":%d", grpcPort))
 	if err != nil {
 		logger.Error("Failed to listen on gRPC port", "port", grpcPort, "error", err)
 		os.Exit(1)
 	}
 
 	go func() {
-		logger.Info("Starting Auth Service gRPC server", "port", grpcPort)
+		logger.Info("Starting Auth Service gRPC server", "address", grpcListener.Addr().String())
 		if err := grpcServer.Serve(grpcListener); err != nil {
 			logger.Info("gRPC server error", "error", err)
 		}
 	}()
 
 	// Get HTTP port from config or use default
-	httpPort := 8081 // default port
-	if cfg.Server != nil && cfg.Server.Port > 0 {
-		httpPort = cfg.Server.Port
-	}
+	httpPort := getHTTPPort(cfg)
 
 	// Setup HTTP server for health checks
 	mux := http.NewServeMux()
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status":"healthy","service":"auth-service","version":"%s"}`, version)
+		fmt.Fprintf(w, `{"status":"healthy","service":"auth-service","version":"%s","build_time":"%s","git_commit":"%s"}`, version, buildTime, gitCommit)
 	})
+	mux.Handle(buildinfo.Path, build.Handler())
+	if interceptorOptions.AuthToken != "" {
+		mux.Handle(logging.LevelPath, apiauth.RequireServiceToken(interceptorOptions.AuthToken, logging.LevelHandler(logging.LevelsOf(logger), logger)))
+	} else {
+		logger.Warn("Log level endpoint disabled: server.auth_token is not set")
+	}
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotImplemented)
 		fmt.Fprintf(w, "Auth Service - gRPC API available on port %d", grpcPort)
 	})
 
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%d", httpPort),
-		Handler: metricsRegistry.HTTPMiddleware()(mux),
+		Addr:         fmt.Sprintf(":%d", httpPort),
+		Handler:      logging.RequestIDMiddleware(metricsRegistry.HTTPMiddleware()(interceptorOptions.RateLimits.Middleware(mux))),
+		ReadTimeout:  cfg.Server.GetTimeouts().GetHTTPRead(),
+		WriteTimeout: cfg.Server.GetTimeouts().GetHTTPWrite(),
+	}
+
+	httpListener, err := listeners.Listen(listener.HTTP, httpServer.Addr)
+	if err != nil {
+		logger.Error("Failed to listen on HTTP port", "port", httpPort, "error", err)
+		os.Exit(1)
 	}
 
 	go func() {
-		logger.Info("Starting Auth Service HTTP server", "port", httpPort)
-		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		logger.Info("Starting Auth Service HTTP server", "address", httpListener.Addr().String())
+		if err := httpServer.Serve(httpListener); err != nil && err != http.ErrServerClosed {
 			logger.Info("HTTP server error", "error", err)
 		}
 	}()
@@ -192,11 +226,12 @@ func main() {
 	<-sigChan
 	logger.Info("Shutting down gracefully...")
 
-	// Shutdown gRPC server
+	// Shutdown gRPC server, ending banner watch streams first
+	authService.StopBannerWatches()
 	grpcServer.GracefulStop()
 
 	// Shutdown HTTP server
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), cfg.Server.GetTimeouts().GetShutdownDrain())
 	defer shutdownCancel()
 
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
@@ -210,8 +245,27 @@ func main() {
 		}
 	}
 
-	// Cancel context
+	// Cancel context and wait for running jobs
 	cancel()
+	<-jobsDone
 
 	logger.Info("Auth Service stopped")
 }
+
+// getHTTPPort returns the HTTP port from config or default
+func getHTTPPort(cfg *config.UserServiceConfig) int {
+	if cfg.Server != nil && cfg.Server.Port > 0 {
+		return cfg.Server.Port
+	}
+	return 8081 // Default port
+}
+
+// healthTarget returns the endpoints `healthcheck` probes. The service has no
+// gRPC health service, so only HTTP is checked.
+func healthTarget(cfg *config.UserServiceConfig) healthcheck.Target {
+	var listenConfig *config.ListenConfig
+	if cfg.Server != nil {
+		listenConfig = cfg.Server.Listen
+	}
+	return healthcheck.TargetOf(listenConfig, getHTTPPort(cfg), 0)
+}
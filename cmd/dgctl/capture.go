@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dungeongate/pkg/iocapture"
+)
+
+// capturePath is where the game service serves I/O captures
+const capturePath = "/admin/io-capture"
+
+// captureInfo is a capture as the game service describes it
+type captureInfo struct {
+	SessionID string    `json:"session_id"`
+	Admin     string    `json:"admin"`
+	Reason    string    `json:"reason"`
+	File      string    `json:"file"`
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Events    int       `json:"events"`
+}
+
+// runCapture starts, stops, lists or decrypts I/O captures
+func runCapture(args []string) error {
+	fs := flag.NewFlagSet("capture", flag.ExitOnError)
+	service := addServiceFlags(fs, "http://localhost:8086")
+	session := fs.String("session", "", "Session ID to capture")
+	duration := fs.Duration("for", 5*time.Minute, "How long to capture (at most the service's io_capture.max_duration)")
+	adminToken := fs.String("admin-token", os.Getenv("DUNGEONGATE_ADMIN_TOKEN"), "Access token of the admin capturing, who the audit log names (default $DUNGEONGATE_ADMIN_TOKEN)")
+	reason := fs.String("reason", "", "Why, for the audit log")
+	key := fs.String("key", os.Getenv("DUNGEONGATE_CAPTURE_KEY"), "Capture key, to decrypt (default $DUNGEONGATE_CAPTURE_KEY)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl capture [flags] list\n")
+		fmt.Fprintf(os.Stderr, "  dgctl capture [flags] -session ID [-for 5m] -reason TEXT start\n")
+		fmt.Fprintf(os.Stderr, "  dgctl capture [flags] -session ID stop\n")
+		fmt.Fprintf(os.Stderr, "  dgctl capture -key KEY decrypt FILE\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	client := service.client()
+	client.adminToken = *adminToken
+	switch fs.Arg(0) {
+	case "", "list":
+		var resp struct {
+			Captures []captureInfo `json:"captures"`
+		}
+		if err := client.do(http.MethodGet, capturePath, nil, &resp); err != nil {
+			return err
+		}
+		printCaptures(resp.Captures)
+	case "start":
+		if *session == "" {
+			return errors.New("-session is required")
+		}
+		req := map[string]string{"session_id": *session, "reason": *reason, "duration": duration.String()}
+		var info captureInfo
+		if err := client.do(http.MethodPost, capturePath, req, &info); err != nil {
+			return err
+		}
+		fmt.Printf("Capturing session %s until %s to %s\n", info.SessionID, info.ExpiresAt.Local().Format(time.TimeOnly), info.File)
+	case "stop":
+		if *session == "" {
+			return errors.New("-session is required")
+		}
+		path := capturePath + "?" + url.Values{"session_id": {*session}}.Encode()
+		var info captureInfo
+		if err := client.do(http.MethodDelete, path, nil, &info); err != nil {
+			return err
+		}
+		fmt.Printf("Stopped capturing session %s: %d events in %s\n", info.SessionID, info.Events, info.File)
+	case "decrypt":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		return decryptCapture(fs.Arg(1), *key)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// printCaptures prints the running captures
+func printCaptures(captures []captureInfo) {
+	if len(captures) == 0 {
+		fmt.Println("No captures running")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SESSION\tADMIN\tEVENTS\tENDS IN\tREASON")
+	for _, c := range captures {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", c.SessionID, c.Admin, c.Events, time.Until(c.ExpiresAt).Round(time.Second), c.Reason)
+	}
+	w.Flush()
+}
+
+// decryptCapture prints the events of a capture file, one per line, with
+// the time since the previous event and since the last input, which shows
+// how long the game took to respond to a keystroke
+func decryptCapture(path, keyText string) error {
+	key, err := iocapture.ParseKey(keyText)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open capture: %w", err)
+	}
+	defer file.Close()
+	reader, err := iocapture.NewReader(file, key)
+	if err != nil {
+		return err
+	}
+
+	var previous, lastInput time.Time
+	for {
+		event, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		sincePrevious, sinceInput := "-", "-"
+		if !previous.IsZero() {
+			sincePrevious = event.Time.Sub(previous).String()
+		}
+		if event.Direction == iocapture.Output && !lastInput.IsZero() {
+			sinceInput = event.Time.Sub(lastInput).String()
+		}
+		fmt.Printf("%s %-6s +%-12s input+%-12s %5d %s\n",
+			event.Time.Format("15:04:05.000000"), event.Direction, sincePrevious, sinceInput, len(event.Data), strconv.Quote(string(event.Data)))
+
+		previous = event.Time
+		if event.Direction == iocapture.Input {
+			lastInput = event.Time
+		}
+	}
+}
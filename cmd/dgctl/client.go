@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// serviceFlags are the flags locating the admin endpoints of a service
+type serviceFlags struct {
+	addr  *string
+	token *string
+}
+
+// addServiceFlags adds the flags locating a service to fs, by default the
+// one at defaultAddr
+func addServiceFlags(fs *flag.FlagSet, defaultAddr string) serviceFlags {
+	return serviceFlags{
+		addr:  fs.String("addr", defaultAddr, "HTTP address of the service"),
+		token: fs.String("token", os.Getenv("DUNGEONGATE_SERVICE_TOKEN"), "Service token (default $DUNGEONGATE_SERVICE_TOKEN)"),
+	}
+}
+
+// adminClient calls the admin endpoints of a service
+type adminClient struct {
+	base  string
+	token string
+	// adminToken is the access token of the admin calling endpoints that
+	// name who called them
+	adminToken string
+	http       *http.Client
+}
+
+// client returns a client for the service the flags locate
+func (f serviceFlags) client() *adminClient {
+	base := strings.TrimRight(*f.addr, "/")
+	if !strings.Contains(base, "://") {
+		base = "http://" + base
+	}
+	return &adminClient{base: base, token: *f.token, http: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// do sends a request with an optional JSON body and decodes the JSON
+// response into out. Problem responses are returned as errors.
+func (c *adminClient) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = strings.NewReader(string(data))
+	}
+
+	req, err := http.NewRequest(method, c.base+path, reader)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.adminToken != "" {
+		req.Header.Set(apiauth.AdminTokenHeader, c.adminToken)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach %s: %w", c.base, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var problem apierror.Problem
+		if err := json.NewDecoder(resp.Body).Decode(&problem); err == nil && problem.Detail != "" {
+			return fmt.Errorf("%s (%s)", problem.Detail, problem.Code)
+		}
+		return fmt.Errorf("request failed: %s", resp.Status)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
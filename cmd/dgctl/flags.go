@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/interceptors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// runFlags lists, checks, sets and deletes feature flags through the auth
+// service's admin RPCs
+func runFlags(args []string) error {
+	fs := flag.NewFlagSet("flags", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8082", "gRPC address of the auth service")
+	token := fs.String("token", os.Getenv("DUNGEONGATE_SERVICE_TOKEN"), "Service token (default $DUNGEONGATE_SERVICE_TOKEN)")
+	adminToken := fs.String("admin-token", os.Getenv("DUNGEONGATE_ADMIN_TOKEN"), "Access token of an admin user (default $DUNGEONGATE_ADMIN_TOKEN)")
+	enable := fs.Bool("enable", false, "Turn the flag on for everyone")
+	users := fs.String("users", "", "Comma-separated usernames to turn the flag on for")
+	roles := fs.String("roles", "", "Comma-separated roles (admin, moderator, beta) to turn the flag on for")
+	percentage := fs.Int("percentage", 0, "Percentage of signed-in users to turn the flag on for, 0 to 100")
+	description := fs.String("description", "", "What the flag turns on")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl flags [flags] [list]\n")
+		fmt.Fprintf(os.Stderr, "  dgctl flags [flags] check USER\n")
+		fmt.Fprintf(os.Stderr, "  dgctl flags [flags] [-enable] [-users U,...] [-roles R,...] [-percentage N] set NAME\n")
+		fmt.Fprintf(os.Stderr, "  dgctl flags [flags] delete NAME\n\n")
+		fmt.Fprintf(os.Stderr, "set stores a flag in place of any configured flag of its name, in every\n")
+		fmt.Fprintf(os.Stderr, "service once it rereads the stored flags; delete returns to the configured flag.\n")
+		fmt.Fprintf(os.Stderr, "A flag set with none of -enable, -users, -roles and -percentage is off for everyone.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *adminToken == "" {
+		return errors.New("-admin-token is required")
+	}
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(*token)...)
+	conn, err := grpc.NewClient(*addr, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	defer conn.Close()
+	client := proto.NewAuthServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch fs.Arg(0) {
+	case "", "list":
+		resp, err := client.ListFeatureFlags(ctx, &proto.FeatureFlagRequest{AdminToken: *adminToken})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		printFeatureFlags(resp.Flags)
+	case "check":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		resp, err := client.ListFeatureFlags(ctx, &proto.FeatureFlagRequest{AdminToken: *adminToken, Username: fs.Arg(1)})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		if len(resp.EnabledForUser) == 0 {
+			fmt.Printf("No feature flags are on for %s\n", fs.Arg(1))
+			return nil
+		}
+		fmt.Printf("Feature flags on for %s:\n", fs.Arg(1))
+		for _, name := range resp.EnabledForUser {
+			fmt.Printf("  %s\n", name)
+		}
+	case "set":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		resp, err := client.SetFeatureFlag(ctx, &proto.SetFeatureFlagRequest{
+			AdminToken: *adminToken,
+			Flag: &proto.FeatureFlag{
+				Name:        fs.Arg(1),
+				Description: *description,
+				Enabled:     *enable,
+				Users:       splitFlagList(*users),
+				Roles:       splitFlagList(*roles),
+				Percentage:  int32(*percentage),
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		fmt.Println(resp.Message)
+	case "delete":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		resp, err := client.DeleteFeatureFlag(ctx, &proto.FeatureFlagRequest{AdminToken: *adminToken, Name: fs.Arg(1)})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		fmt.Println(resp.Message)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// printFeatureFlags prints the flags the auth service evaluates and who each
+// is on for
+func printFeatureFlags(flags []*proto.FeatureFlag) {
+	if len(flags) == 0 {
+		fmt.Println("No feature flags")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FLAG\tON FOR\tSOURCE\tUPDATED\tDESCRIPTION")
+	for _, pbFlag := range flags {
+		featureFlag := featureflags.Flag{Name: pbFlag.Name, FeatureFlag: *featureflags.FromProto(pbFlag)}
+		source, updated := "config", ""
+		if pbFlag.Overridden {
+			source = "runtime"
+			if pbFlag.UpdatedAt != nil {
+				updated = fmt.Sprintf("%s by %s", pbFlag.UpdatedAt.AsTime().Local().Format(time.DateTime), pbFlag.UpdatedBy)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", featureFlag.Name, featureFlag.Rollout(), source, updated, featureFlag.Description)
+	}
+	w.Flush()
+}
+
+// splitFlagList splits a comma-separated list, dropping empty items
+func splitFlagList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/interceptors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// runGames lists games and applies, deletes and exports the game
+// definitions kept in the game service database
+func runGames(args []string) error {
+	fs := flag.NewFlagSet("games", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:50051", "gRPC address of the game service")
+	token := fs.String("token", os.Getenv("DUNGEONGATE_SERVICE_TOKEN"), "Service token (default $DUNGEONGATE_SERVICE_TOKEN)")
+	all := fs.Bool("all", false, "Export the configured games as well as those defined in the database")
+	output := fs.String("o", "", "File to export to (default stdout)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl games [flags] list\n")
+		fmt.Fprintf(os.Stderr, "  dgctl games [flags] apply FILE\n")
+		fmt.Fprintf(os.Stderr, "  dgctl games [flags] delete ID\n")
+		fmt.Fprintf(os.Stderr, "  dgctl games [flags] [-all] [-o FILE] export\n\n")
+		fmt.Fprintf(os.Stderr, "apply creates or overrides a game from FILE, one entry of the games list of\n")
+		fmt.Fprintf(os.Stderr, "game-service.yaml. delete removes a definition from the database, bringing\n")
+		fmt.Fprintf(os.Stderr, "back the configured game it overrode.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(*token)...)
+	conn, err := grpc.NewClient(*addr, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to game service: %w", err)
+	}
+	defer conn.Close()
+	client := games_pb.NewGameServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	switch fs.Arg(0) {
+	case "", "list":
+		resp, err := client.ListGames(ctx, &games_pb.ListGamesRequest{})
+		if err != nil {
+			return err
+		}
+		printGames(resp.Games)
+	case "apply":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		data, err := readImportFile(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		// Check the definition here first, for errors naming the file
+		game, err := config.ParseGameDefinition(data, fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		created, err := client.CreateGame(ctx, &games_pb.CreateGameRequest{Definition: string(data)})
+		if status.Code(err) == codes.AlreadyExists {
+			_, err = client.UpdateGame(ctx, &games_pb.UpdateGameRequest{GameId: game.ID, Definition: string(data)})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Updated game %s\n", game.ID)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Created game %s\n", created.Game.Id)
+	case "delete":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		if _, err := client.DeleteGame(ctx, &games_pb.DeleteGameRequest{GameId: fs.Arg(1)}); err != nil {
+			return err
+		}
+		fmt.Printf("Deleted the database definition of %s\n", fs.Arg(1))
+	case "export":
+		resp, err := client.ExportGames(ctx, &games_pb.ExportGamesRequest{IncludeConfigured: *all})
+		if err != nil {
+			return err
+		}
+		if *output == "" {
+			_, err = os.Stdout.WriteString(resp.Yaml)
+			return err
+		}
+		if err := os.WriteFile(*output, []byte(resp.Yaml), 0600); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d games to %s\n", resp.Count, *output)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// printGames prints the games with where each is defined
+func printGames(games []*games_pb.Game) {
+	if len(games) == 0 {
+		fmt.Println("No games")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tSTATUS\tDEFINED IN")
+	for _, g := range games {
+		source := "-"
+		switch g.Source {
+		case games_pb.GameSource_GAME_SOURCE_CONFIG:
+			source = "config"
+		case games_pb.GameSource_GAME_SOURCE_DATABASE:
+			source = "database"
+		}
+		gameStatus := strings.ToLower(strings.TrimPrefix(g.Status.String(), "GAME_STATUS_"))
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", g.Id, g.Name, gameStatus, source)
+	}
+	w.Flush()
+}
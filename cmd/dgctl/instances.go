@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/interceptors"
+	"github.com/dungeongate/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// runInstances lists the session service replicas that reported to the
+// auth service, with the cluster's totals, or shows one of them
+func runInstances(args []string) error {
+	fs := flag.NewFlagSet("instances", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8082", "gRPC address of the auth service")
+	token := fs.String("token", os.Getenv("DUNGEONGATE_SERVICE_TOKEN"), "Service token (default $DUNGEONGATE_SERVICE_TOKEN)")
+	adminToken := fs.String("admin-token", os.Getenv("DUNGEONGATE_ADMIN_TOKEN"), "Access token of an admin user (default $DUNGEONGATE_ADMIN_TOKEN)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl instances [flags] [list]\n")
+		fmt.Fprintf(os.Stderr, "  dgctl instances [flags] show INSTANCE\n\n")
+		fmt.Fprintf(os.Stderr, "Lists the session service replicas that reported in the last 90 seconds.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *adminToken == "" {
+		return errors.New("-admin-token is required")
+	}
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(*token)...)
+	conn, err := grpc.NewClient(*addr, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	defer conn.Close()
+	client := proto.NewAuthServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	resp, err := client.ListSessionInstances(ctx, &proto.ListSessionInstancesRequest{AdminToken: *adminToken})
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+
+	switch fs.Arg(0) {
+	case "", "list":
+		printInstances(resp.Instances, resp.Totals)
+	case "show":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		for _, instance := range resp.Instances {
+			if instance.InstanceId == fs.Arg(1) {
+				printInstance(instance)
+				return nil
+			}
+		}
+		return fmt.Errorf("instance %q has not reported recently", fs.Arg(1))
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// printInstances prints the replicas and, when there are several, their totals
+func printInstances(instances []*proto.SessionInstance, totals *proto.SessionInstance) {
+	if len(instances) == 0 {
+		fmt.Println("No session service instances reported recently")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "INSTANCE\tVERSION\tACTIVE\tMAX\tGAMES\tPEAK\tREJECTED\tUP\tREPORTED")
+	for _, instance := range instances {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s ago\n", instance.InstanceId, instance.Version,
+			instance.ConnectionsActive, instance.MaxConnections, instance.GameSessions, instance.ConnectionsPeak,
+			instance.ConnectionsRejected, time.Since(instance.StartedAt.AsTime()).Round(time.Minute),
+			time.Since(instance.ReportedAt.AsTime()).Round(time.Second))
+	}
+	if totals != nil && len(instances) > 1 {
+		fmt.Fprintf(w, "(cluster)\t\t%d\t%d\t%d\t\t%d\t\t\n", totals.ConnectionsActive, totals.MaxConnections,
+			totals.GameSessions, totals.ConnectionsRejected)
+	}
+	w.Flush()
+}
+
+// printInstance prints everything a replica reported
+func printInstance(instance *proto.SessionInstance) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Instance:\t%s\n", instance.InstanceId)
+	fmt.Fprintf(w, "Version:\t%s\n", instance.Version)
+	fmt.Fprintf(w, "Started:\t%s\n", instance.StartedAt.AsTime().Local().Format(time.DateTime))
+	fmt.Fprintf(w, "Reported:\t%s\n", instance.ReportedAt.AsTime().Local().Format(time.DateTime))
+	fmt.Fprintf(w, "Connections open:\t%d of %d\n", instance.ConnectionsActive, instance.MaxConnections)
+	fmt.Fprintf(w, "Connections peak:\t%d\n", instance.ConnectionsPeak)
+	fmt.Fprintf(w, "Connections since start:\t%d\n", instance.ConnectionsTotal)
+	fmt.Fprintf(w, "Connections refused:\t%d\n", instance.ConnectionsRejected)
+	fmt.Fprintf(w, "Game sessions:\t%d\n", instance.GameSessions)
+	fmt.Fprintf(w, "Metrics selector:\t{%s=%q}\n", metrics.InstanceLabel, instance.InstanceId)
+	w.Flush()
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dungeongate/pkg/logging"
+)
+
+// runLogLevel shows the log levels of a service, sets one, or reverts one
+// with "reset"
+func runLogLevel(args []string) error {
+	fs := flag.NewFlagSet("log-level", flag.ExitOnError)
+	service := addServiceFlags(fs, "http://localhost:8083")
+	component := fs.String("component", "", "Component to change, such as pty, ssh or menu (default the whole service)")
+	duration := fs.Duration("for", 0, "How long the level lasts (default the longest the service allows)")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: dgctl log-level [flags] [debug|info|warn|error|reset]\n\n")
+		fmt.Fprintf(os.Stderr, "Without a level, shows the levels of the service.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() > 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	client := service.client()
+	var status logging.LevelStatus
+	switch level := fs.Arg(0); level {
+	case "":
+		if err := client.do(http.MethodGet, logging.LevelPath, nil, &status); err != nil {
+			return err
+		}
+	case "reset":
+		path := logging.LevelPath + "?" + url.Values{logging.ComponentKey: {*component}}.Encode()
+		if err := client.do(http.MethodDelete, path, nil, &status); err != nil {
+			return err
+		}
+	default:
+		if _, err := logging.ParseLevel(level); err != nil {
+			return err
+		}
+		req := logging.LevelRequest{Component: *component, Level: level}
+		if *duration > 0 {
+			req.Duration = duration.String()
+		}
+		if err := client.do(http.MethodPut, logging.LevelPath, req, &status); err != nil {
+			return err
+		}
+	}
+
+	printLevels(status)
+	return nil
+}
+
+// printLevels prints the levels of a service
+func printLevels(status logging.LevelStatus) {
+	fmt.Printf("Configured level: %s (changes revert after at most %s)\n", status.Configured, status.RevertAfter)
+	if len(status.Overrides) == 0 {
+		fmt.Println("No levels changed at runtime")
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tLEVEL\tREVERTS IN")
+	for _, override := range status.Overrides {
+		component := override.Component
+		if component == "" {
+			component = "(service)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", component, override.Level, time.Until(override.ExpiresAt).Round(time.Second))
+	}
+	w.Flush()
+}
@@ -0,0 +1,72 @@
+// Command dgctl administers running DungeonGate services
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+var (
+	version   string = "dev"
+	buildTime string = "unknown"
+	gitCommit string = "unknown"
+)
+
+// command is a dgctl subcommand, run with the arguments following its name
+type command struct {
+	summary string
+	run     func(args []string) error
+}
+
+var commands = map[string]command{
+	"capture":   {"Capture a game session's terminal I/O to debug it", runCapture},
+	"flags":     {"List, check, set and delete feature flags", runFlags},
+	"games":     {"List games and apply, delete and export database game definitions", runGames},
+	"instances": {"List the session service replicas and the cluster's totals", runInstances},
+	"log-level": {"Show or change the log level of a running service", runLogLevel},
+	"scores":    {"Check, repair and merge game score files on this host", runScores},
+	"users":     {"List, lock, grant roles to, rename, export and import user accounts", runUsers},
+	"user-dirs": {"Check and repair users' game directories and save links on this host", runUserDirs},
+	"version":   {"Show version information", runVersion},
+}
+
+func main() {
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "-help" || os.Args[1] == "help" {
+		usage()
+		return
+	}
+
+	cmd, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown command %q\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// usage lists the subcommands
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: dgctl <command> [flags]\n\nCommands:\n")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  %-12s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintf(os.Stderr, "\nRun 'dgctl <command> -h' for the flags of a command.\n")
+}
+
+func runVersion(args []string) error {
+	fmt.Printf("DungeonGate Control\n")
+	fmt.Printf("Version: %s\n", version)
+	fmt.Printf("Build Time: %s\n", buildTime)
+	fmt.Printf("Git Commit: %s\n", gitCommit)
+	return nil
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/dungeongate/internal/games/shared"
+)
+
+// runScores checks, repairs and merges game score files on this host
+func runScores(args []string) error {
+	fs := flag.NewFlagSet("scores", flag.ExitOnError)
+	format := fs.String("format", "auto", "Score file format: auto, xlog, record or logfile")
+	output := fs.String("o", "", "File to write merged scores to (merge)")
+	lockTimeout := fs.Duration("lock-timeout", 30*time.Second, "How long to wait for games to release the score lock")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl scores [flags] check FILE...\n")
+		fmt.Fprintf(os.Stderr, "  dgctl scores [flags] repair FILE...\n")
+		fmt.Fprintf(os.Stderr, "  dgctl scores [flags] -o OUTPUT merge FILE...\n\n")
+		fmt.Fprintf(os.Stderr, "check reports malformed, interleaved, truncated and duplicate entries.\n")
+		fmt.Fprintf(os.Stderr, "repair rewrites files with their whole entries, keeping each original beside\n")
+		fmt.Fprintf(os.Stderr, "it as FILE.corrupt-TIME.\n")
+		fmt.Fprintf(os.Stderr, "merge combines files, such as those of replicas with their own data\n")
+		fmt.Fprintf(os.Stderr, "directories, into OUTPUT, which may be one of them.\n")
+		fmt.Fprintf(os.Stderr, "Files are read and written under the score lock games take, so games can keep\n")
+		fmt.Fprintf(os.Stderr, "running. Run it as the user the game service runs as.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.NArg() < 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	files := fs.Args()[1:]
+
+	switch fs.Arg(0) {
+	case "check", "repair":
+		return checkScores(files, *format, *lockTimeout, fs.Arg(0) == "repair")
+	case "merge":
+		if *output == "" {
+			return fmt.Errorf("merge needs -o OUTPUT")
+		}
+		return mergeScores(*output, files, *format, *lockTimeout)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// checkScores reports the issues in score files, repairing them if asked
+func checkScores(files []string, formatName string, lockTimeout time.Duration, repair bool) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tLINE\tPROBLEM\tTEXT")
+	found := 0
+	var backups []string
+	for _, path := range files {
+		format, err := shared.ParseScoreFormat(formatName, path)
+		if err != nil {
+			return err
+		}
+		var issues []shared.ScoreIssue
+		if repair {
+			var backup string
+			issues, backup, err = repairScores(path, format, lockTimeout)
+			if backup != "" {
+				backups = append(backups, backup)
+			}
+		} else {
+			issues, err = readScores(path, format, lockTimeout)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		found += len(issues)
+		for _, issue := range issues {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", path, issue.Line, issue.Problem, quoteScoreText(issue.Text))
+		}
+	}
+	if found == 0 {
+		fmt.Println("No issues found")
+		return nil
+	}
+	w.Flush()
+
+	if repair {
+		for _, backup := range backups {
+			fmt.Printf("Kept original as %s\n", backup)
+		}
+		return nil
+	}
+	return fmt.Errorf("%d score file issues", found)
+}
+
+// readScores reads a score file under its directory's score lock
+func readScores(path string, format shared.ScoreFormat, lockTimeout time.Duration) ([]shared.ScoreIssue, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	unlock, err := shared.LockScores(ctx, filepath.Dir(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock scores: %w", err)
+	}
+	defer unlock()
+
+	file, err := shared.ReadScoreFile(path, format)
+	if err != nil {
+		return nil, err
+	}
+	return file.Issues, nil
+}
+
+// repairScores repairs a score file
+func repairScores(path string, format shared.ScoreFormat, lockTimeout time.Duration) ([]shared.ScoreIssue, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	return shared.RepairScoreFile(ctx, path, format)
+}
+
+// mergeScores merges score files into output
+func mergeScores(output string, files []string, formatName string, lockTimeout time.Duration) error {
+	format, err := shared.ParseScoreFormat(formatName, output)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), lockTimeout)
+	defer cancel()
+	count, issues, err := shared.MergeScoreFiles(ctx, output, format, files)
+	if err != nil {
+		return err
+	}
+
+	if len(issues) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "LINE\tPROBLEM\tTEXT")
+		for _, issue := range issues {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", issue.Line, issue.Problem, quoteScoreText(issue.Text))
+		}
+		w.Flush()
+	}
+	fmt.Printf("Merged %d entries into %s, leaving out %d unreadable lines\n", count, output, len(issues))
+	return nil
+}
+
+// quoteScoreText quotes the start of a score file line for a table; xlog
+// fields are separated by tabs
+func quoteScoreText(text string) string {
+	const limit = 60
+	if len(text) > limit {
+		text = text[:limit] + "..."
+	}
+	return strconv.Quote(text)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	gameconfig "github.com/dungeongate/internal/games/config"
+)
+
+// runUserDirs checks and repairs users' game directories on this host
+func runUserDirs(args []string) error {
+	fs := flag.NewFlagSet("user-dirs", flag.ExitOnError)
+	baseDir := fs.String("base-dir", "/var/lib/dungeongate/games", "Game data directory holding the users/ trees")
+	noConfig := fs.Bool("no-config", false, "Do not expect the default game configuration")
+	noPermissions := fs.Bool("no-permissions", false, "Do not check directory permissions")
+	verbose := fs.Bool("v", false, "Log each repair")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl user-dirs [flags] check USER_ID...\n")
+		fmt.Fprintf(os.Stderr, "  dgctl user-dirs [flags] repair USER_ID...\n")
+		fmt.Fprintf(os.Stderr, "  dgctl user-dirs [flags] verify-links SAVE_DIR\n\n")
+		fmt.Fprintf(os.Stderr, "check compares users' directory trees with the layout provisioning creates.\n")
+		fmt.Fprintf(os.Stderr, "repair creates what is missing, resets permissions and removes staging left by\n")
+		fmt.Fprintf(os.Stderr, "interrupted provisioning; files in the way of a directory are left alone.\n")
+		fmt.Fprintf(os.Stderr, "verify-links reports dangling, misdirected and escaping links in a system save\n")
+		fmt.Fprintf(os.Stderr, "directory, and entries named for a user that are not links.\n")
+		fmt.Fprintf(os.Stderr, "Run it on the game service host, as the user the game service runs as.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+	if fs.Arg(0) == "verify-links" && fs.NArg() == 2 {
+		return verifySaveLinks(*baseDir, fs.Arg(1))
+	}
+	if fs.NArg() < 2 || (fs.Arg(0) != "check" && fs.Arg(0) != "repair") {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	userIDs := make([]int, 0, fs.NArg()-1)
+	for _, arg := range fs.Args()[1:] {
+		userID, err := strconv.Atoi(arg)
+		if err != nil || userID <= 0 {
+			return fmt.Errorf("invalid user ID %q", arg)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	logOutput := io.Discard
+	if *verbose {
+		logOutput = os.Stderr
+	}
+	manager := gameconfig.NewGameDirectoryManager(*baseDir, log.New(logOutput, "", 0))
+	options := &gameconfig.GameSetupOptions{
+		CreateUserDirs:    true,
+		CopyDefaultConfig: !*noConfig,
+		SetPermissions:    !*noPermissions,
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USER\tPATH\tPROBLEM\tFIXED")
+	found, unresolved := 0, 0
+	var repairErr error
+	for _, userID := range userIDs {
+		var issues []gameconfig.LayoutIssue
+		var err error
+		if fs.Arg(0) == "repair" {
+			issues, err = manager.RepairUserDirs(userID, options)
+			if err != nil && repairErr == nil {
+				repairErr = err
+			}
+		} else {
+			issues, err = manager.CheckUserDirs(userID, options)
+			if err != nil {
+				return err
+			}
+		}
+		found += len(issues)
+		for _, issue := range issues {
+			fixed := "no"
+			if issue.Fixed {
+				fixed = "yes"
+			} else {
+				unresolved++
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", userID, issue.Path, issue.Problem, fixed)
+		}
+	}
+	switch {
+	case found > 0:
+		w.Flush()
+	case repairErr == nil:
+		fmt.Println("No issues found")
+	}
+
+	if repairErr != nil {
+		return repairErr
+	}
+	if unresolved > 0 {
+		return fmt.Errorf("%d of %d issues unresolved", unresolved, found)
+	}
+	return nil
+}
+
+// verifySaveLinks audits the links in a system save directory
+func verifySaveLinks(baseDir, saveDir string) error {
+	manager := gameconfig.NewGameDirectoryManager(baseDir, log.New(io.Discard, "", 0))
+	issues, err := manager.VerifySaveLinks(saveDir)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Println("No issues found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tTARGET\tPROBLEM")
+	for _, issue := range issues {
+		target := issue.Target
+		if target == "" {
+			target = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", issue.Path, target, issue.Problem)
+	}
+	w.Flush()
+	return fmt.Errorf("%d suspicious save directory entries", len(issues))
+}
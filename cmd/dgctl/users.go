@@ -0,0 +1,411 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/interceptors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// userFilterFlags are the flags selecting user accounts
+type userFilterFlags struct {
+	flag            *string
+	role            *string
+	createdAfter    *string
+	createdBefore   *string
+	lastLoginAfter  *string
+	lastLoginBefore *string
+	locked          *bool
+}
+
+// addUserFilterFlags adds the flags selecting user accounts to fs
+func addUserFilterFlags(fs *flag.FlagSet) userFilterFlags {
+	return userFilterFlags{
+		flag:            fs.String("flag", "", "Only users with this moderation flag"),
+		role:            fs.String("role", "", "Only users with this role (admin, moderator or beta)"),
+		createdAfter:    fs.String("created-after", "", "Only users created on or after this date or RFC 3339 time"),
+		createdBefore:   fs.String("created-before", "", "Only users created before this date or RFC 3339 time"),
+		lastLoginAfter:  fs.String("last-login-after", "", "Only users who last logged in on or after this date or time"),
+		lastLoginBefore: fs.String("last-login-before", "", "Only users who last logged in before this date or time, or never"),
+		locked:          fs.Bool("locked", false, "Only locked users"),
+	}
+}
+
+// filter returns the filter the flags describe, and whether any is set
+func (f userFilterFlags) filter() (*proto.UserFilter, bool, error) {
+	filter := &proto.UserFilter{
+		ModerationFlag: *f.flag,
+		Role:           *f.role,
+		LockedOnly:     *f.locked,
+	}
+	set := *f.flag != "" || *f.role != "" || *f.locked
+
+	times := []struct {
+		name  string
+		value string
+		dst   **timestamppb.Timestamp
+	}{
+		{"created-after", *f.createdAfter, &filter.CreatedAfter},
+		{"created-before", *f.createdBefore, &filter.CreatedBefore},
+		{"last-login-after", *f.lastLoginAfter, &filter.LastLoginAfter},
+		{"last-login-before", *f.lastLoginBefore, &filter.LastLoginBefore},
+	}
+	for _, t := range times {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := parseFilterTime(t.value)
+		if err != nil {
+			return nil, false, fmt.Errorf("-%s must be a date or RFC 3339 time", t.name)
+		}
+		*t.dst = timestamppb.New(parsed)
+		set = true
+	}
+	return filter, set, nil
+}
+
+// parseFilterTime parses an RFC 3339 time or a YYYY-MM-DD date, which is the
+// start of that day
+func parseFilterTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
+
+// runUsers lists, locks, unlocks, grants roles to, renames, exports and
+// imports user accounts, and encrypts their personal data, through the auth
+// service's admin RPCs
+func runUsers(args []string) error {
+	fs := flag.NewFlagSet("users", flag.ExitOnError)
+	addr := fs.String("addr", "localhost:8082", "gRPC address of the auth service")
+	token := fs.String("token", os.Getenv("DUNGEONGATE_SERVICE_TOKEN"), "Service token (default $DUNGEONGATE_SERVICE_TOKEN)")
+	adminToken := fs.String("admin-token", os.Getenv("DUNGEONGATE_ADMIN_TOKEN"), "Access token of an admin user (default $DUNGEONGATE_ADMIN_TOKEN)")
+	filters := addUserFilterFlags(fs)
+	limit := fs.Int("limit", 50, "Users to list, 0 for all")
+	offset := fs.Int("offset", 0, "Users to skip when listing")
+	reason := fs.String("reason", "", "Why, for the audit log")
+	lockFor := fs.Duration("for", 0, "How long to lock for; 0 locks until unlocked")
+	hashes := fs.Bool("hashes", false, "Include password hashes and salts in the export")
+	output := fs.String("o", "", "File to export to (default stdout)")
+	dryRun := fs.Bool("dry-run", false, "Check an import, or count the users to encrypt, without changing anything")
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage:\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] list\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] lock|unlock [USER...]\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] grant|revoke ROLE [USER...]\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] rename USER NEWNAME\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] names USER\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] [-hashes] [-o FILE] export\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] [-dry-run] import FILE\n")
+		fmt.Fprintf(os.Stderr, "  dgctl users [flags] [-dry-run] encrypt\n\n")
+		fmt.Fprintf(os.Stderr, "lock, unlock, grant and revoke apply to the users named, or else to every\n")
+		fmt.Fprintf(os.Stderr, "user the filter flags select.\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if *adminToken == "" {
+		return errors.New("-admin-token is required")
+	}
+	filter, filtered, err := filters.filter()
+	if err != nil {
+		return err
+	}
+
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(*token)...)
+	conn, err := grpc.NewClient(*addr, dialOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	defer conn.Close()
+	client := proto.NewAuthServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	// targets returns the users named on the command line, or else those the
+	// filter selects
+	targets := func(names []string) ([]string, error) {
+		if len(names) > 0 {
+			return names, nil
+		}
+		if !filtered {
+			return nil, errors.New("name the users or select them with filter flags")
+		}
+		resp, err := client.ListUsers(ctx, &proto.ListUsersRequest{AdminToken: *adminToken, Filter: filter})
+		if err != nil {
+			return nil, err
+		}
+		if !resp.Success {
+			return nil, errors.New(resp.Error)
+		}
+		for _, u := range resp.Users {
+			names = append(names, u.Username)
+		}
+		if len(names) == 0 {
+			return nil, errors.New("no users match the filter")
+		}
+		return names, nil
+	}
+
+	switch fs.Arg(0) {
+	case "", "list":
+		resp, err := client.ListUsers(ctx, &proto.ListUsersRequest{
+			AdminToken: *adminToken,
+			Filter:     filter,
+			Limit:      int32(*limit),
+			Offset:     int32(*offset),
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		printUsers(resp.Users, int(resp.TotalCount), *offset)
+	case "lock", "unlock":
+		names, err := targets(fs.Args()[1:])
+		if err != nil {
+			return err
+		}
+		req := &proto.BulkLockRequest{
+			AdminToken: *adminToken,
+			Usernames:  names,
+			Locked:     fs.Arg(0) == "lock",
+			Reason:     *reason,
+		}
+		if req.Locked && *lockFor > 0 {
+			req.LockedUntil = timestamppb.New(time.Now().Add(*lockFor))
+		}
+		resp, err := client.SetAccountsLocked(ctx, req)
+		if err != nil {
+			return err
+		}
+		format := "Locked %d users"
+		if !req.Locked {
+			format = "Unlocked %d users"
+		}
+		return printBulkResult(format, resp)
+	case "grant", "revoke":
+		if fs.NArg() < 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		names, err := targets(fs.Args()[2:])
+		if err != nil {
+			return err
+		}
+		resp, err := client.SetUsersRole(ctx, &proto.BulkRoleRequest{
+			AdminToken: *adminToken,
+			Usernames:  names,
+			Role:       fs.Arg(1),
+			Grant:      fs.Arg(0) == "grant",
+		})
+		if err != nil {
+			return err
+		}
+		format := "Granted " + fs.Arg(1) + " to %d users"
+		if fs.Arg(0) == "revoke" {
+			format = "Revoked " + fs.Arg(1) + " from %d users"
+		}
+		return printBulkResult(format, resp)
+	case "rename":
+		if fs.NArg() != 3 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		resp, err := client.RenameUser(ctx, &proto.RenameUserRequest{
+			AdminToken:     *adminToken,
+			TargetUsername: fs.Arg(1),
+			NewUsername:    fs.Arg(2),
+			Reason:         *reason,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		fmt.Println(resp.Message)
+	case "names":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		resp, err := client.GetUsernameHistory(ctx, &proto.AdminActionRequest{AdminToken: *adminToken, TargetUsername: fs.Arg(1)})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		printUsernameHistory(resp.Changes)
+	case "export":
+		resp, err := client.ExportUsers(ctx, &proto.ExportUsersRequest{
+			AdminToken:            *adminToken,
+			Filter:                filter,
+			IncludePasswordHashes: *hashes,
+		})
+		if err != nil {
+			return err
+		}
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		if *output == "" {
+			_, err = os.Stdout.Write(resp.Csv)
+			return err
+		}
+		if err := os.WriteFile(*output, resp.Csv, 0600); err != nil {
+			return fmt.Errorf("failed to write export: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Exported %d users to %s\n", resp.Count, *output)
+	case "import":
+		if fs.NArg() != 2 {
+			fs.Usage()
+			os.Exit(2)
+		}
+		data, err := readImportFile(fs.Arg(1))
+		if err != nil {
+			return err
+		}
+		resp, err := client.ImportUsers(ctx, &proto.ImportUsersRequest{AdminToken: *adminToken, Csv: data, DryRun: *dryRun})
+		if err != nil {
+			return err
+		}
+		printImport(resp.Users, *dryRun)
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+		if !*dryRun {
+			fmt.Printf("Created %d of %d users\n", resp.Created, len(resp.Users))
+		}
+	case "encrypt":
+		resp, err := client.EncryptPersonalData(ctx, &proto.EncryptPersonalDataRequest{AdminToken: *adminToken, DryRun: *dryRun})
+		if err != nil {
+			return err
+		}
+		verb := "Encrypted"
+		if *dryRun {
+			verb = "Would encrypt"
+		}
+		fmt.Printf("%s the data of %d unencrypted and %d rotated users, of %d\n",
+			verb, resp.UsersEncrypted, resp.UsersReencrypted, resp.UsersScanned)
+		if !resp.Success {
+			return errors.New(resp.Error)
+		}
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return nil
+}
+
+// readImportFile reads the CSV to import, from stdin for "-"
+func readImportFile(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import: %w", err)
+	}
+	return data, nil
+}
+
+// printUsers prints a page of user accounts
+func printUsers(users []*proto.UserSummary, total, offset int) {
+	if len(users) == 0 {
+		fmt.Println("No users")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tROLES\tCREATED\tLAST LOGIN\tLOCKED\tEMAIL")
+	for _, u := range users {
+		lastLogin := "never"
+		if u.LastLogin != nil {
+			lastLogin = u.LastLogin.AsTime().Local().Format(time.DateOnly)
+		}
+		locked := "-"
+		if u.Locked {
+			locked = "yes"
+			if u.LockedUntil != nil {
+				locked = "until " + u.LockedUntil.AsTime().Local().Format(time.DateTime)
+			}
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", u.Username, strings.Join(u.Roles, ","),
+			u.CreatedAt.AsTime().Local().Format(time.DateOnly), lastLogin, locked, u.Email)
+	}
+	w.Flush()
+	if offset > 0 || len(users) < total {
+		fmt.Printf("\nShowing %d-%d of %d users\n", offset+1, offset+len(users), total)
+	}
+}
+
+// printBulkResult reports the result of a bulk action with format, e.g.
+// "Locked %d users", returning an error if any user failed
+func printBulkResult(format string, resp *proto.BulkAdminActionResponse) error {
+	if !resp.Success {
+		return errors.New(resp.Error)
+	}
+	for _, failure := range resp.Failures {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", failure.Username, failure.Error)
+	}
+	fmt.Printf(format+"\n", resp.Updated)
+	if len(resp.Failures) > 0 {
+		return fmt.Errorf("%d users failed", len(resp.Failures))
+	}
+	return nil
+}
+
+// printUsernameHistory prints an account's renames, newest first
+func printUsernameHistory(changes []*proto.UsernameChange) {
+	if len(changes) == 0 {
+		fmt.Println("Never renamed")
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CHANGED	FROM	TO	BY	RESERVED UNTIL")
+	for _, c := range changes {
+		reserved := "-"
+		if c.ReservedUntil != nil {
+			reserved = c.ReservedUntil.AsTime().Local().Format(time.DateTime)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", c.ChangedAt.AsTime().Local().Format(time.DateTime),
+			c.OldUsername, c.NewUsername, c.ChangedBy, reserved)
+	}
+	w.Flush()
+}
+
+// printImport prints each imported row with its error or temporary password
+func printImport(users []*proto.ImportedUser, dryRun bool) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "LINE\tUSERNAME\tRESULT")
+	for _, u := range users {
+		result := "created"
+		if dryRun {
+			result = "ok"
+		}
+		switch {
+		case u.Error != "":
+			result = "error: " + u.Error
+		case u.TemporaryPassword != "":
+			result += ", temporary password " + u.TemporaryPassword
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", u.Line, u.Username, result)
+	}
+	w.Flush()
+}
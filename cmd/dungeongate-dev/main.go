@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/platform"
+)
+
+var (
+	version   string = "dev"
+	buildTime string = "unknown"
+	gitCommit string = "unknown"
+)
+
+// stopTimeout is how long a service gets to exit after SIGTERM before it is killed
+const stopTimeout = 10 * time.Second
+
+// service is one DungeonGate service run by the launcher
+type service struct {
+	name   string
+	binary string
+	config string
+	port   int // Port that accepts connections once the service is ready
+
+	cmd  *exec.Cmd
+	done chan struct{}
+	err  error
+}
+
+func main() {
+	var (
+		binDir        = flag.String("bin-dir", "bin", "Directory holding the service binaries")
+		authConfig    = flag.String("auth-config", "configs/auth-service.yaml", "Auth service configuration file")
+		gameConfig    = flag.String("game-config", "configs/game-service.yaml", "Game service configuration file")
+		sessionConfig = flag.String("session-config", "configs/session-service.yaml", "Session service configuration file")
+		readyTimeout  = flag.Duration("ready-timeout", 30*time.Second, "How long to wait for each service to start listening")
+		showVersion   = flag.Bool("version", false, "Show version information")
+	)
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("DungeonGate Dev Launcher\n")
+		fmt.Printf("Version: %s\n", version)
+		fmt.Printf("Build Time: %s\n", buildTime)
+		fmt.Printf("Git Commit: %s\n", gitCommit)
+		return
+	}
+
+	host := platform.Detect()
+	fmt.Printf("DungeonGate dev mode on %s/%s\n", host.OS, runtime.GOARCH)
+	if !host.Namespaces || !host.Cgroups {
+		fmt.Printf("WARNING: namespaces supported: %t, cgroups supported: %t; games run without isolation\n",
+			host.Namespaces, host.Cgroups)
+	}
+
+	// The game service config reads its engine mode from this variable
+	os.Setenv("DUNGEONGATE_GAME_ENGINE_MODE", "dev")
+
+	services, err := loadServices(*binDir, *authConfig, *gameConfig, *sessionConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	exitCode := run(ctx, services, *readyTimeout)
+	os.Exit(exitCode)
+}
+
+// loadServices reads each service's configuration to find the port that
+// signals it is ready. Services are returned in startup order.
+func loadServices(binDir, authConfig, gameConfig, sessionConfig string) ([]*service, error) {
+	authCfg, err := config.LoadUserServiceConfig(authConfig)
+	if err != nil {
+		return nil, fmt.Errorf("auth service: %w", err)
+	}
+	authPort := 8082
+	if authCfg.Server != nil && authCfg.Server.GRPCPort > 0 {
+		authPort = authCfg.Server.GRPCPort
+	} else if authCfg.Server != nil && authCfg.Server.Port > 0 {
+		authPort = authCfg.Server.Port
+	}
+
+	gameCfg, err := config.LoadGameServiceConfig(gameConfig)
+	if err != nil {
+		return nil, fmt.Errorf("game service: %w", err)
+	}
+	gamePort := 50051
+	if gameCfg.Server != nil && gameCfg.Server.GRPCPort > 0 {
+		gamePort = gameCfg.Server.GRPCPort
+	}
+
+	sessionCfg, err := config.LoadSessionServiceConfig(sessionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("session service: %w", err)
+	}
+
+	return []*service{
+		{name: "auth", binary: binaryPath(binDir, "dungeongate-auth-service"), config: authConfig, port: authPort},
+		{name: "game", binary: binaryPath(binDir, "dungeongate-game-service"), config: gameConfig, port: gamePort},
+		{name: "session", binary: binaryPath(binDir, "dungeongate-session-service"), config: sessionConfig, port: sessionCfg.SSH.Port},
+	}, nil
+}
+
+// binaryPath returns the path of a service binary, with .exe on Windows
+func binaryPath(binDir, name string) string {
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(binDir, name)
+}
+
+// run starts the services in order and keeps them running until the context
+// is cancelled or one of them exits, then stops the rest in reverse order
+func run(ctx context.Context, services []*service, readyTimeout time.Duration) int {
+	var started []*service
+	defer func() {
+		for i := len(started) - 1; i >= 0; i-- {
+			started[i].stop()
+		}
+	}()
+
+	exited := make(chan *service, len(services))
+	for _, svc := range services {
+		if err := svc.start(exited); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to start %s service: %v\n", svc.name, err)
+			return 1
+		}
+		started = append(started, svc)
+
+		if err := svc.waitReady(ctx, readyTimeout); err != nil {
+			fmt.Fprintf(os.Stderr, "%s service did not become ready: %v\n", svc.name, err)
+			return 1
+		}
+		fmt.Printf("%s service ready on port %d\n", svc.name, svc.port)
+	}
+
+	fmt.Println("All services running, press Ctrl+C to stop")
+
+	select {
+	case <-ctx.Done():
+		fmt.Println("Shutting down...")
+		return 0
+	case svc := <-exited:
+		fmt.Fprintf(os.Stderr, "%s service exited unexpectedly: %v\n", svc.name, svc.err)
+		return 1
+	}
+}
+
+// start launches the service with its output prefixed by the service name
+func (s *service) start(exited chan<- *service) error {
+	s.cmd = exec.Command(s.binary, "-config="+s.config)
+	s.cmd.Env = os.Environ()
+
+	stdout, err := s.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := s.cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := s.cmd.Start(); err != nil {
+		return err
+	}
+
+	var output sync.WaitGroup
+	output.Add(2)
+	go s.prefixOutput(&output, stdout, os.Stdout)
+	go s.prefixOutput(&output, stderr, os.Stderr)
+
+	s.done = make(chan struct{})
+	go func() {
+		output.Wait()
+		s.err = s.cmd.Wait()
+		close(s.done)
+		exited <- s
+	}()
+
+	return nil
+}
+
+// prefixOutput copies the service's output line by line, tagged with its name
+func (s *service) prefixOutput(wg *sync.WaitGroup, r io.Reader, w io.Writer) {
+	defer wg.Done()
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fmt.Fprintf(w, "[%-7s] %s\n", s.name, scanner.Text())
+	}
+}
+
+// waitReady waits until the service accepts connections on its port
+func (s *service) waitReady(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	addr := fmt.Sprintf("localhost:%d", s.port)
+
+	for {
+		conn, err := net.DialTimeout("tcp", addr, time.Second)
+		if err == nil {
+			conn.Close()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.done:
+			return fmt.Errorf("exited: %v", s.err)
+		case <-time.After(250 * time.Millisecond):
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %s", addr)
+		}
+	}
+}
+
+// stop asks the service to shut down, killing it if it does not exit in time
+func (s *service) stop() {
+	select {
+	case <-s.done:
+		return
+	default:
+	}
+
+	// Windows cannot deliver SIGTERM, so the service is killed outright
+	if err := s.cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		s.cmd.Process.Kill()
+	}
+
+	select {
+	case <-s.done:
+	case <-time.After(stopTimeout):
+		fmt.Fprintf(os.Stderr, "%s service did not stop within %v, killing it\n", s.name, stopTimeout)
+		s.cmd.Process.Kill()
+		<-s.done
+	}
+}
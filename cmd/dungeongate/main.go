@@ -0,0 +1,314 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dungeongate/internal/auth"
+	"github.com/dungeongate/internal/games/app"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/internal/session"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/capabilities"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/database"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/healthcheck"
+	"github.com/dungeongate/pkg/interceptors"
+	"github.com/dungeongate/pkg/leader"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/metrics"
+	"github.com/dungeongate/pkg/ratelimit"
+	"github.com/dungeongate/pkg/scheduler"
+)
+
+var (
+	version   string = "dev"
+	buildTime string = "unknown"
+	gitCommit string = "unknown"
+)
+
+const serviceName = "dungeongate"
+
+// In-process service addresses; the passthrough resolver hands the name after
+// the slashes to inProcessDialer unchanged
+const (
+	authServiceTarget = "passthrough:///auth-service"
+	gameServiceTarget = "passthrough:///game-service"
+)
+
+// bufconnSize is the in-memory buffer of each in-process gRPC connection
+const bufconnSize = 1024 * 1024
+
+func main() {
+	var (
+		configFile  = flag.String("config", "configs/dungeongate.yaml", "Path to configuration file")
+		showVersion = flag.Bool("version", false, "Show version information")
+	)
+	flag.Parse()
+
+	if *showVersion {
+		fmt.Printf("DungeonGate (all-in-one)\n")
+		fmt.Printf("Version: %s\n", version)
+		fmt.Printf("Build Time: %s\n", buildTime)
+		fmt.Printf("Git Commit: %s\n", gitCommit)
+		return
+	}
+
+	cfg, err := loadConfig(*configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// `dungeongate healthcheck` probes the running session service, the only
+	// one listening outside the process
+	if flag.Arg(0) == "healthcheck" {
+		sessionConfig := session.NewConfig(cfg.Session, buildinfo.New("session-service", version, buildTime, gitCommit))
+		target := healthcheck.TargetOf(sessionConfig.Listen, sessionConfig.HTTP.Port, sessionConfig.GRPC.Port)
+		os.Exit(healthcheck.Run(serviceName, target, os.Stdout, os.Stderr))
+	}
+
+	logger := logging.NewLogger(serviceName, logging.FromConfig(cfg.Logging))
+	logging.ReopenOnHangup(logger)
+	logger.Info("Starting DungeonGate (all-in-one)", "version", version)
+	config.LogEnvExpansions(logger, cfg.EnvExpansions)
+
+	// One registry holds the metrics of every service
+	metricsRegistry := metrics.NewRegistry(serviceName, version, buildTime, gitCommit, logger)
+	if cfg.Metrics != nil && cfg.Metrics.Enabled {
+		go func() {
+			if err := metricsRegistry.StartMetricsServer(cfg.Metrics.Port); err != nil {
+				logger.Error("Failed to start metrics server", "error", err)
+			}
+		}()
+		logger.Info("Metrics server starting", "port", cfg.Metrics.Port)
+	}
+
+	db, err := database.NewConnection(cfg.Auth.Database)
+	if err != nil {
+		logger.Error("Failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Auth service, which hosts the user service
+	authLogger := logger.With("component", "auth-service")
+	authService, err := auth.NewServiceFromConfig(db, cfg.Auth, authLogger)
+	if err != nil {
+		logger.Error("Failed to create auth service", "error", err)
+		os.Exit(1)
+	}
+	authService.SetBuild(buildinfo.New("auth-service", version, buildTime, gitCommit))
+	authInterceptors := interceptors.Options{Logger: authLogger, Metrics: metricsRegistry}
+	if cfg.Auth.Server != nil {
+		authInterceptors.AuthToken = cfg.Auth.Server.AuthToken
+		if authInterceptors.RateLimits, err = ratelimit.NewPolicy(cfg.Auth.Server.RateLimits); err != nil {
+			logger.Error("Failed to configure auth service rate limits", "error", err)
+			os.Exit(1)
+		}
+	}
+	authServer := grpc.NewServer(interceptors.ServerOptions(authInterceptors)...)
+	proto.RegisterAuthServiceServer(authServer, authService)
+	authListener := bufconn.Listen(bufconnSize)
+	go serveInProcess(authServer, authListener, authLogger)
+
+	// Game service
+	gameLogger := logger.With("component", "game-service")
+	if err := app.InitializeSharedData(context.Background(), cfg.Game, gameLogger); err != nil {
+		logger.Error("Failed to initialize shared game data", "error", err)
+		os.Exit(1)
+	}
+	gameServices := app.NewServices(gameLogger)
+	gameRateLimits, err := ratelimit.NewPolicy(cfg.Game.Server.RateLimits)
+	if err != nil {
+		logger.Error("Failed to configure game service rate limits", "error", err)
+		os.Exit(1)
+	}
+	gameServer := grpc.NewServer(interceptors.ServerOptions(interceptors.Options{
+		Logger:        gameLogger,
+		Metrics:       metricsRegistry,
+		AuthToken:     cfg.Game.Server.AuthToken,
+		RateLimits:    gameRateLimits,
+		MinAPIVersion: capabilities.MinAPIVersion,
+	})...)
+	gameGRPC := app.RegisterGRPC(gameServer, cfg.Game, gameServices, metricsRegistry, gameLogger)
+	gameGRPC.SetBuild(buildinfo.New("game-service", version, buildTime, gitCommit))
+	if err := gameGRPC.SetGameDefinitions(context.Background(), repository.NewSQLGameDefinitionRepository(db)); err != nil {
+		logger.Error("Failed to load game definitions", "error", err)
+		os.Exit(1)
+	}
+	gameListener := bufconn.Listen(bufconnSize)
+	go serveInProcess(gameServer, gameListener, gameLogger)
+
+	// The game service tells players about crashed games through the auth service
+	notifyConn, err := grpc.Dial(authServiceTarget, append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(inProcessDialer(map[string]*bufconn.Listener{"auth-service": authListener})),
+	}, append(interceptors.WithServiceToken(authInterceptors.AuthToken), interceptors.WithRequestID()...)...)...)
+	if err != nil {
+		logger.Error("Failed to connect game service to auth service", "error", err)
+		os.Exit(1)
+	}
+	defer notifyConn.Close()
+	gameGRPC.SetNotifier(proto.NewAuthServiceClient(notifyConn))
+
+	// The game service reads the feature flags set at runtime from the auth service
+	gameFlags := featureflags.New(cfg.Game.FeatureFlags, featureflags.NewAuthStore(proto.NewAuthServiceClient(notifyConn)), gameLogger)
+	gameGRPC.SetFeatureFlags(gameFlags)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go gameFlags.Run(ctx)
+
+	// Run the background jobs of the auth and game services
+	gameJobs, err := app.NewScheduler(cfg.Game, gameServices, db.Writer(), metricsRegistry, gameLogger)
+	if err != nil {
+		logger.Error("Failed to schedule game service jobs", "error", err)
+		os.Exit(1)
+	}
+	var authJobOverrides map[string]*config.JobConfig
+	var authElection *config.LeaderElectionConfig
+	if cfg.Auth.Server != nil {
+		authJobOverrides = cfg.Auth.Server.Jobs
+		authElection = cfg.Auth.Server.LeaderElection
+	}
+	authJobs := scheduler.New(authJobOverrides, authLogger)
+	authJobs.SetRecorder(metricsRegistry)
+	authElector, err := leader.New(authElection, db.Writer(), auth.LeaseName, authLogger)
+	if err != nil {
+		logger.Error("Failed to set up auth service leader election", "error", err)
+		os.Exit(1)
+	}
+	if authElector != nil {
+		authJobs.SetLeader(authElector)
+	}
+	if err := authService.AddJobs(authJobs); err != nil {
+		logger.Error("Failed to schedule auth service jobs", "error", err)
+		os.Exit(1)
+	}
+	jobsDone := make(chan struct{})
+	go func() {
+		var wg sync.WaitGroup
+		for _, jobs := range []*scheduler.Scheduler{authJobs, gameJobs} {
+			wg.Add(1)
+			go func(jobs *scheduler.Scheduler) {
+				defer wg.Done()
+				jobs.Run(ctx)
+			}(jobs)
+		}
+		wg.Wait()
+		close(jobsDone)
+	}()
+
+	// Session service, reaching auth and game over the in-memory listeners
+	sessionConfig := session.NewConfig(cfg.Session, buildinfo.New("session-service", version, buildTime, gitCommit))
+	sessionConfig.AuthService.Address = authServiceTarget
+	sessionConfig.GameService.Address = gameServiceTarget
+	sessionConfig.DialOptions = []grpc.DialOption{
+		grpc.WithContextDialer(inProcessDialer(map[string]*bufconn.Listener{
+			"auth-service": authListener,
+			"game-service": gameListener,
+		})),
+	}
+
+	sessionService, err := session.New(sessionConfig, logger.With("component", "session-service"), metricsRegistry)
+	if err != nil {
+		logger.Error("Failed to create session service", "error", err)
+		os.Exit(1)
+	}
+	if err := sessionService.Start(); err != nil {
+		logger.Error("Failed to start session service", "error", err)
+		os.Exit(1)
+	}
+
+	logger.Info("DungeonGate started",
+		"ssh_port", sessionConfig.SSH.Port,
+		"http_port", sessionConfig.HTTP.Port,
+		"grpc_port", sessionConfig.GRPC.Port,
+	)
+
+	// Wait for interrupt signal
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	<-sigChan
+	logger.Info("Shutting down gracefully...")
+
+	// Stop accepting players first, then the services behind them
+	if err := sessionService.Stop(); err != nil {
+		logger.Error("Error during session service shutdown", "error", err)
+	}
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), app.ShutdownTimeout(cfg.Game))
+	if err := gameGRPC.Shutdown(drainCtx); err != nil {
+		logger.Error("Error draining game service", "error", err)
+	}
+	drainCancel()
+	gameServer.GracefulStop()
+	authService.StopBannerWatches()
+	authServer.GracefulStop()
+
+	// Wait for running jobs, then flush the session cache
+	cancel()
+	<-jobsDone
+	gameServices.SessionCache.Close()
+
+	if cfg.Metrics != nil && cfg.Metrics.Enabled {
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		if err := metricsRegistry.StopMetricsServer(shutdownCtx); err != nil {
+			logger.Error("Error stopping metrics server", "error", err)
+		}
+	}
+
+	logger.Info("DungeonGate stopped")
+}
+
+// serveInProcess serves a gRPC server on an in-memory listener
+func serveInProcess(server *grpc.Server, listener *bufconn.Listener, logger *slog.Logger) {
+	if err := server.Serve(listener); err != nil {
+		logger.Error("In-process gRPC server failed", "error", err)
+	}
+}
+
+// inProcessDialer connects gRPC clients to the in-memory listener of the
+// service they address
+func inProcessDialer(listeners map[string]*bufconn.Listener) func(context.Context, string) (net.Conn, error) {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		listener, ok := listeners[addr]
+		if !ok {
+			return nil, fmt.Errorf("no in-process service at %s", addr)
+		}
+		return listener.DialContext(ctx)
+	}
+}
+
+// loadConfig loads the configuration file. When the default file is missing
+// the server starts with built-in defaults, so a bare binary runs a demo
+// server; a file named with -config must exist.
+func loadConfig(configFile string) (*config.DungeonGateConfig, error) {
+	explicit := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "config" {
+			explicit = true
+		}
+	})
+	if _, err := os.Stat(configFile); os.IsNotExist(err) && !explicit {
+		fmt.Fprintf(os.Stderr, "Warning: No configuration file found, using defaults\n")
+		return config.DefaultDungeonGateConfig()
+	}
+	return config.LoadDungeonGateConfig(configFile)
+}
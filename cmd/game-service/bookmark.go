@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// sessionsPrefix is followed by a session ID and what of the session to get
+const sessionsPrefix = "/api/v1/sessions/"
+
+// bookmarkJSON is the REST representation of a bookmark
+type bookmarkJSON struct {
+	ID                string    `json:"id"`
+	CreatedByUserID   int       `json:"created_by_user_id"`
+	CreatedByUsername string    `json:"created_by_username"`
+	OffsetMS          int64     `json:"offset_ms"`
+	Note              string    `json:"note,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+// handleSessionBookmarksAPI lists the bookmarks of a session's recording:
+// GET /api/v1/sessions/{id}/bookmarks. Each bookmark's offset_ms is where
+// playback jumps to, from the start of the recording. A private recording's
+// bookmarks are listed to its player alone.
+func handleSessionBookmarksAPI(sessionService *application.SessionService, bookmarks *application.BookmarkService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sessionService == nil || bookmarks == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Session service not initialized"))
+			return
+		}
+		sessionID, rest, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, sessionsPrefix), "/")
+		if sessionID == "" || rest != "bookmarks" {
+			apierror.WriteProblem(w, r, apierror.New(apierror.NotFound, "Not found"))
+			return
+		}
+		if r.Method != http.MethodGet {
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+
+		session, err := sessionService.GetGameSession(r.Context(), sessionID)
+		if err != nil {
+			apierror.WriteProblem(w, r, domain.ErrSessionNotFound)
+			return
+		}
+		var viewerID int
+		if principal, ok := apiauth.FromContext(r.Context()); ok && principal.User != nil {
+			viewerID, _ = strconv.Atoi(principal.User.Id)
+		}
+
+		result := []bookmarkJSON{}
+		if session.CanViewRecording(domain.NewUserID(viewerID)) {
+			list, err := bookmarks.ListBookmarks(r.Context(), sessionID)
+			if err != nil {
+				apierror.WriteProblem(w, r, err)
+				return
+			}
+			for _, bookmark := range list {
+				result = append(result, bookmarkJSON{
+					ID:                bookmark.ID().String(),
+					CreatedByUserID:   bookmark.CreatedBy().Int(),
+					CreatedByUsername: bookmark.CreatedByName(),
+					OffsetMS:          bookmark.Offset().Milliseconds(),
+					Note:              bookmark.Note(),
+					CreatedAt:         bookmark.CreatedAt(),
+				})
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"session_id": sessionID,
+			"bookmarks":  result,
+			"count":      len(result),
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	grpc_service "github.com/dungeongate/internal/games/infrastructure/grpc"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// capturePath is where admins start and stop I/O captures
+const capturePath = "/admin/io-capture"
+
+// captureRequest starts a capture
+type captureRequest struct {
+	SessionID string `json:"session_id"`
+	Reason    string `json:"reason"`
+	// Duration is how long to capture, such as "5m"; empty means the longest
+	// the service allows
+	Duration string `json:"duration"`
+}
+
+// handleCaptureAPI handles I/O capture requests from the admin signed in by
+// apiauth.RequireAdmin: GET lists running captures, POST starts one from a
+// captureRequest, and DELETE ?session_id=S stops one
+func handleCaptureAPI(captures *grpc_service.IOCaptures) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := apiauth.FromContext(r.Context())
+		if !ok {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unauthenticated, "Authentication required"))
+			return
+		}
+		admin := principal.User.Username
+
+		var (
+			result any
+			err    error
+		)
+		switch r.Method {
+		case http.MethodGet:
+			result = map[string]any{"captures": captures.List()}
+
+		case http.MethodPost:
+			var req captureRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "invalid capture request body"))
+				return
+			}
+			var duration time.Duration
+			if req.Duration != "" {
+				if duration, err = time.ParseDuration(req.Duration); err != nil || duration <= 0 {
+					apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "duration must be a positive duration such as 5m"))
+					return
+				}
+			}
+			result, err = captures.Start(r.Context(), req.SessionID, admin, req.Reason, duration)
+
+		case http.MethodDelete:
+			result, err = captures.Stop(r.Context(), r.URL.Query().Get("session_id"), admin)
+
+		default:
+			err = apierror.New(apierror.MethodNotAllowed, "Method not allowed")
+		}
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		json.NewEncoder(w).Encode(result)
+	}
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+const (
+	// publicEventsPath streams the public feed as server-sent events
+	publicEventsPath = "/api/v1/public/events"
+	// publicGamesPath lists the games being played that are open to spectators
+	publicGamesPath = "/api/v1/public/games"
+	// publicLeaderboardPath ranks the players of a game
+	publicLeaderboardPath = "/api/v1/public/leaderboard"
+
+	// leaderboardCacheFactor is how many times longer than the games
+	// listing leaderboards may be cached, as they change slowly
+	leaderboardCacheFactor = 6
+	// maxPublicLeaderboard bounds the entries of a public leaderboard
+	maxPublicLeaderboard = 100
+	// publicEventsKeepAlive is how often an idle event stream gets a
+	// comment, so proxies don't close it
+	publicEventsKeepAlive = 15 * time.Second
+)
+
+// publicGameJSON is a game being played, as the public feed shows it
+type publicGameJSON struct {
+	SessionID   string    `json:"session_id"`
+	GameID      string    `json:"game_id"`
+	Username    string    `json:"username"`
+	StartTime   time.Time `json:"start_time"`
+	IdleSeconds int64     `json:"idle_seconds"`
+	Spectators  int       `json:"spectators"`
+	StatusLine  string    `json:"status_line,omitempty"`
+}
+
+// publicLeaderboardEntryJSON is a ranked player
+type publicLeaderboardEntryJSON struct {
+	Rank                  int       `json:"rank"`
+	Username              string    `json:"username"`
+	GamesPlayed           int       `json:"games_played"`
+	PlayTimeSeconds       int64     `json:"play_time_seconds"`
+	LongestSessionSeconds int64     `json:"longest_session_seconds"`
+	LastPlayed            time.Time `json:"last_played"`
+}
+
+// publicCORS lets the pages of allowedOrigins read the public feed, every
+// site's when none are configured or "*" is
+func publicCORS(allowedOrigins []string, next http.HandlerFunc) http.HandlerFunc {
+	anyOrigin := len(allowedOrigins) == 0 || slices.Contains(allowedOrigins, "*")
+	return func(w http.ResponseWriter, r *http.Request) {
+		if anyOrigin {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		} else {
+			w.Header().Add("Vary", "Origin")
+			if origin := r.Header.Get("Origin"); slices.Contains(allowedOrigins, origin) {
+				w.Header().Set("Access-Control-Allow-Origin", origin)
+			}
+		}
+
+		if r.Method == http.MethodOptions {
+			w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+			w.Header().Set("Access-Control-Allow-Headers", "Last-Event-ID")
+			w.Header().Set("Access-Control-Max-Age", "86400")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		if r.Method != http.MethodGet {
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handlePublicEvents streams the public feed as server-sent events:
+// GET /api/v1/public/events. Each event's name is its type and its data the
+// event as JSON. Clients that reconnect with Last-Event-ID, or
+// ?last_event_id=N, first get the recent events they missed.
+func handlePublicEvents(feed *application.Feed) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lastEventID := r.Header.Get("Last-Event-ID")
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get("last_event_id")
+		}
+		after, _ := strconv.ParseInt(lastEventID, 10, 64)
+
+		// The stream outlives the server's write timeout
+		rc := http.NewResponseController(w)
+		rc.SetWriteDeadline(time.Time{})
+
+		missed, events, unsubscribe := feed.Subscribe(after)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "retry: 5000\n\n")
+		for _, event := range missed {
+			writePublicEvent(w, event)
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(publicEventsKeepAlive)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case event, ok := <-events:
+				if !ok {
+					// Fell behind; the client reconnects with Last-Event-ID
+					return
+				}
+				writePublicEvent(w, event)
+			case <-keepAlive.C:
+				fmt.Fprint(w, ": keep-alive\n\n")
+			}
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writePublicEvent writes an event of the public feed as a server-sent event
+func writePublicEvent(w http.ResponseWriter, event application.FeedEvent) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+}
+
+// handlePublicGames lists the games being played that are open to
+// spectators: GET /api/v1/public/games[?game_id=G]
+func handlePublicGames(sessionService *application.SessionService, maxAge time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sessionService == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Session service not initialized"))
+			return
+		}
+
+		sessions, err := sessionService.ListActiveSessions(r.Context())
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		gameID := r.URL.Query().Get("game_id")
+		now := time.Now()
+		games := make([]publicGameJSON, 0, len(sessions))
+		for _, session := range sessions {
+			if !session.CanSpectate() || (gameID != "" && session.GameID().String() != gameID) {
+				continue
+			}
+			games = append(games, publicGameJSON{
+				SessionID:   session.ID().String(),
+				GameID:      session.GameID().String(),
+				Username:    session.Username(),
+				StartTime:   session.StartTime(),
+				IdleSeconds: int64(now.Sub(session.LastActivity()).Seconds()),
+				Spectators:  len(session.Spectators()),
+				StatusLine:  session.StatusLine(),
+			})
+		}
+
+		writePublicJSON(w, maxAge, map[string]interface{}{
+			"games": games,
+			"count": len(games),
+		})
+	}
+}
+
+// handlePublicLeaderboard ranks the players of a game:
+// GET /api/v1/public/leaderboard?game_id=G[&sort_by=play_time|games][&limit=N]
+func handlePublicLeaderboard(sessionService *application.SessionService, maxAge time.Duration) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sessionService == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Session service not initialized"))
+			return
+		}
+
+		query := r.URL.Query()
+		gameID := query.Get("game_id")
+		if gameID == "" {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "game_id is required"))
+			return
+		}
+		sortBy, err := domain.ParseLeaderboardSort(query.Get("sort_by"))
+		if err != nil {
+			apierror.WriteProblem(w, r, apierror.Wrap(apierror.InvalidRequest, err, "sort_by must be play_time or games"))
+			return
+		}
+		limit := 20
+		if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+			limit = min(l, maxPublicLeaderboard)
+		}
+
+		entries, total, err := sessionService.Leaderboard(r.Context(), gameID, nil, sortBy, limit)
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		result := make([]publicLeaderboardEntryJSON, len(entries))
+		for i, entry := range entries {
+			result[i] = publicLeaderboardEntryJSON{
+				Rank:                  entry.Rank,
+				Username:              entry.Username,
+				GamesPlayed:           entry.GamesPlayed,
+				PlayTimeSeconds:       int64(entry.PlayTime.Seconds()),
+				LongestSessionSeconds: int64(entry.LongestSession.Seconds()),
+				LastPlayed:            entry.LastPlayed,
+			}
+		}
+
+		writePublicJSON(w, maxAge, map[string]interface{}{
+			"game_id": gameID,
+			"sort_by": string(sortBy),
+			"entries": result,
+			"total":   total,
+		})
+	}
+}
+
+// writePublicJSON writes a public listing that browsers and proxies may
+// cache for maxAge
+func writePublicJSON(w http.ResponseWriter, maxAge time.Duration, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// historyPath lists a player's finished sessions
+const historyPath = "/api/v1/sessions/history"
+
+// historySessionJSON is the REST representation of a finished session
+type historySessionJSON struct {
+	ID              string         `json:"id"`
+	GameID          string         `json:"game_id"`
+	GameVersion     string         `json:"game_version,omitempty"`
+	Status          string         `json:"status"`
+	StartTime       time.Time      `json:"start_time"`
+	EndTime         *time.Time     `json:"end_time,omitempty"`
+	DurationSeconds int64          `json:"duration_seconds"`
+	Outcome         *outcomeJSON   `json:"outcome,omitempty"`
+	Recording       *recordingJSON `json:"recording,omitempty"`
+	DumplogURL      string         `json:"dumplog_url,omitempty"`
+}
+
+// outcomeJSON is how a game ended
+type outcomeJSON struct {
+	Result    string `json:"result"`
+	Death     string `json:"death,omitempty"`
+	Points    int64  `json:"points"`
+	Turns     int64  `json:"turns"`
+	Character string `json:"character,omitempty"`
+}
+
+// recordingJSON describes a session's recording
+type recordingJSON struct {
+	Format   string `json:"format"`
+	FilePath string `json:"file_path"`
+	FileSize int64  `json:"file_size"`
+	Private  bool   `json:"private"`
+	// BookmarksURL lists the moments marked while the game was played
+	BookmarksURL string `json:"bookmarks_url"`
+}
+
+// handleSessionHistoryAPI lists finished sessions:
+// GET /api/v1/sessions/history[?user_id=N][&game_id=G][&since=T][&until=T][&limit=N][&offset=N].
+// user_id defaults to the authenticated user; since and until are RFC 3339
+// times or YYYY-MM-DD dates, and an until date includes that day.
+func handleSessionHistoryAPI(sessionService *application.SessionService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if sessionService == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Session service not initialized"))
+			return
+		}
+		if r.Method != http.MethodGet {
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+
+		query := r.URL.Query()
+		var viewerID int
+		if principal, ok := apiauth.FromContext(r.Context()); ok && principal.User != nil {
+			viewerID, _ = strconv.Atoi(principal.User.Id)
+		}
+		userID := viewerID
+		if value := query.Get("user_id"); value != "" {
+			id, err := strconv.Atoi(value)
+			if err != nil || id <= 0 {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id must be a positive number"))
+				return
+			}
+			userID = id
+		}
+		if userID <= 0 {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id is required"))
+			return
+		}
+
+		filter := domain.HistoryFilter{GameID: query.Get("game_id"), Limit: 20}
+		var err error
+		if filter.Since, err = parseHistoryTime(query.Get("since"), false); err != nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "since must be a date or RFC 3339 time"))
+			return
+		}
+		if filter.Until, err = parseHistoryTime(query.Get("until"), true); err != nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "until must be a date or RFC 3339 time"))
+			return
+		}
+		if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+			filter.Limit = l
+		}
+		if o, err := strconv.Atoi(query.Get("offset")); err == nil && o > 0 {
+			filter.Offset = o
+		}
+
+		sessions, total, err := sessionService.ListSessionHistory(r.Context(), userID, filter)
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		result := make([]historySessionJSON, len(sessions))
+		for i, session := range sessions {
+			result[i] = historySessionToJSON(session, domain.NewUserID(viewerID))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"sessions": result,
+			"count":    len(result),
+			"total":    total,
+			"offset":   filter.Offset,
+		})
+	}
+}
+
+// historySessionToJSON converts a finished session, leaving out a private
+// recording unless viewer is its player
+func historySessionToJSON(session *domain.GameSession, viewer domain.UserID) historySessionJSON {
+	entry := historySessionJSON{
+		ID:              session.ID().String(),
+		GameID:          session.GameID().String(),
+		GameVersion:     session.GameVersion(),
+		Status:          string(session.Status()),
+		StartTime:       session.StartTime(),
+		EndTime:         session.EndTime(),
+		DurationSeconds: int64(session.Duration().Seconds()),
+	}
+	if outcome := session.Outcome(); outcome != nil {
+		entry.Outcome = &outcomeJSON{
+			Result:    string(outcome.Result),
+			Death:     outcome.Death,
+			Points:    outcome.Points,
+			Turns:     outcome.Turns,
+			Character: outcome.Character,
+		}
+	}
+	if recording := session.RecordingInfo(); recording != nil && recording.Enabled && session.CanViewRecording(viewer) {
+		entry.Recording = &recordingJSON{
+			Format:   recording.Format,
+			FilePath: recording.FilePath,
+			FileSize: recording.FileSize,
+			Private:  recording.Private,
+
+			BookmarksURL: sessionsPrefix + session.ID().String() + "/bookmarks",
+		}
+	}
+	if session.DumplogID() != nil {
+		entry.DumplogURL = "/api/v1/dumplogs/" + session.DumplogID().String()
+	}
+	return entry
+}
+
+// parseHistoryTime parses a history date filter; empty means unbounded. A
+// date is the start of that day, or the end of it for endOfDay.
+func parseHistoryTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.ParseInLocation("2006-01-02", value, time.Local)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log/slog"
@@ -9,21 +10,32 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/health"
-	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/credentials/insecure"
 
+	"github.com/dungeongate/internal/games/app"
 	"github.com/dungeongate/internal/games/application"
 	grpc_service "github.com/dungeongate/internal/games/infrastructure/grpc"
 	"github.com/dungeongate/internal/games/infrastructure/repository"
-	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/capabilities"
 	"github.com/dungeongate/pkg/config"
 	"github.com/dungeongate/pkg/database"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/healthcheck"
+	"github.com/dungeongate/pkg/interceptors"
+	"github.com/dungeongate/pkg/listener"
 	"github.com/dungeongate/pkg/logging"
 	"github.com/dungeongate/pkg/metrics"
+	"github.com/dungeongate/pkg/ratelimit"
 )
 
 var (
@@ -58,37 +70,24 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Initialize logging with configuration
-	level := "info"
-	format := "text"
-	output := "stdout"
-	if cfg.Logging != nil {
-		if cfg.Logging.Level != "" {
-			level = cfg.Logging.Level
-		}
-		if cfg.Logging.Format != "" {
-			format = cfg.Logging.Format
-		}
-		if cfg.Logging.Output != "" {
-			output = cfg.Logging.Output
+	// `game-service healthcheck` probes the running service for containers
+	if flag.Arg(0) == "healthcheck" {
+		var listenConfig *config.ListenConfig
+		if cfg.Server != nil {
+			listenConfig = cfg.Server.Listen
 		}
+		os.Exit(healthcheck.Run(serviceName, healthcheck.TargetOf(listenConfig, getHTTPPort(cfg), getGRPCPort(cfg)), os.Stdout, os.Stderr))
 	}
-	logger = logging.NewLoggerBasic(serviceName, level, format, output)
+
+	// Initialize logging with configuration
+	logger = logging.NewLogger(serviceName, logging.FromConfig(cfg.Logging))
+	logging.ReopenOnHangup(logger)
 	logger.Info("Starting Game Service", "version", version)
+	config.LogEnvExpansions(logger, cfg.EnvExpansions)
 
 	// Initialize metrics registry
 	metricsRegistry := metrics.NewRegistry(serviceName, version, buildTime, gitCommit, logger)
 
-	// Start metrics server if enabled
-	if cfg.Metrics != nil && cfg.Metrics.Enabled {
-		go func() {
-			if err := metricsRegistry.StartMetricsServer(cfg.Metrics.Port); err != nil {
-				logger.Error("Failed to start metrics server", "error", err)
-			}
-		}()
-		logger.Info("Metrics server starting", "port", cfg.Metrics.Port)
-	}
-
 	// Initialize database
 	db, err := initializeDatabase(cfg)
 	if err != nil {
@@ -97,37 +96,169 @@ func main() {
 	}
 	defer db.Close()
 
+	// Initialize shared game data, upgrading it for new game binaries
+	if err := app.InitializeSharedData(context.Background(), cfg, logger); err != nil {
+		logger.Error("Failed to initialize shared game data", "error", err)
+		os.Exit(1)
+	}
+
 	// Initialize application services
-	appServices := initializeApplicationServices(db, metricsRegistry)
+	appServices := app.NewServices(logger)
+
+	// Rate limits shared by the gRPC and HTTP servers
+	rateLimits, err := ratelimit.NewPolicy(cfg.Server.RateLimits)
+	if err != nil {
+		logger.Error("Failed to configure rate limits", "error", err)
+		os.Exit(1)
+	}
 
-	// Initialize gRPC server
-	grpcServer := initializeGRPCServer(cfg, appServices, metricsRegistry)
+	// Initialize gRPC server with recovery, logging, metrics, service auth and rate limits
+	grpcServer := grpc.NewServer(interceptors.ServerOptions(interceptors.Options{
+		Logger:        logger,
+		Metrics:       metricsRegistry,
+		AuthToken:     cfg.Server.AuthToken,
+		RateLimits:    rateLimits,
+		MinAPIVersion: capabilities.MinAPIVersion,
+	})...)
+	grpcServices := app.RegisterGRPC(grpcServer, cfg, appServices, metricsRegistry, logger)
+	build := buildinfo.New(serviceName, version, buildTime, gitCommit)
+	grpcServices.SetBuild(build)
+	if err := grpcServices.SetGameDefinitions(context.Background(), repository.NewSQLGameDefinitionRepository(db)); err != nil {
+		logger.Error("Failed to load game definitions", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize REST API authentication and the HTTP server
+	apiAuth, authConn, err := initializeAPIAuth(cfg)
+	if err != nil {
+		logger.Error("Failed to initialize API authentication", "error", err)
+		os.Exit(1)
+	}
+	if authConn != nil {
+		defer authConn.Close()
+	}
+	httpServer := initializeHTTPServer(cfg, appServices, grpcServices.Captures(), apiAuth, rateLimits)
 
-	// Initialize HTTP server
-	httpServer := initializeHTTPServer(cfg, appServices)
+	// Tell players about their crashed games
+	notifyConn, err := initializeNotifications(cfg)
+	if err != nil {
+		logger.Error("Failed to initialize notifications", "error", err)
+		os.Exit(1)
+	}
+	if notifyConn != nil {
+		defer notifyConn.Close()
+		grpcServices.SetNotifier(authv1.NewAuthServiceClient(notifyConn))
+	}
+
+	// Evaluate feature flags for players, rereading those set at runtime
+	flags, flagsConn, err := initializeFeatureFlags(cfg)
+	if err != nil {
+		logger.Error("Failed to initialize feature flags", "error", err)
+		os.Exit(1)
+	}
+	if flagsConn != nil {
+		defer flagsConn.Close()
+	}
+	grpcServices.SetFeatureFlags(flags)
 
 	// Start servers
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	go flags.Run(ctx)
+
+	// Take over the games of the instance this one replaces, then listen for
+	// the instance that will replace this one
+	var takeovers <-chan *net.UnixConn
+	if socketPath := app.HandoffSocket(cfg); socketPath != "" {
+		takeoverCtx, takeoverCancel := context.WithTimeout(ctx, app.ShutdownTimeout(cfg)+handoffGrace)
+		adopted, err := grpcServices.TakeOver(takeoverCtx, socketPath)
+		takeoverCancel()
+		if err != nil {
+			logger.Error("Failed to take over running games", "error", err)
+		} else if adopted > 0 {
+			logger.Info("Adopted running games from previous instance", "count", adopted)
+		}
+
+		takeovers, err = grpcServices.ListenForTakeover(ctx, socketPath, logger)
+		if err != nil {
+			logger.Error("Failed to listen for takeover", "error", err)
+		}
+	}
+
+	// Start metrics server if enabled, after any takeover freed the port
+	if cfg.Metrics != nil && cfg.Metrics.Enabled {
+		go func() {
+			if err := metricsRegistry.StartMetricsServer(cfg.Metrics.Port); err != nil {
+				logger.Error("Failed to start metrics server", "error", err)
+			}
+		}()
+		logger.Info("Metrics server starting", "port", cfg.Metrics.Port)
+	}
+
+	// Unix and systemd sockets replace the TCP listeners when configured
+	listeners, err := listener.New(cfg.Server.Listen)
+	if err != nil {
+		logger.Error("Failed to set up listeners", "error", err)
+		os.Exit(1)
+	}
 
 	// Start gRPC server
+	grpcDone := make(chan struct{})
 	go func() {
-		if err := startGRPCServer(ctx, cfg, grpcServer); err != nil {
+		if err := startGRPCServer(ctx, cfg, grpcServer, listeners); err != nil {
 			logger.Error("gRPC server failed", "error", err)
 			os.Exit(1)
 		}
+		close(grpcDone)
 	}()
 
 	// Start HTTP server
+	httpDone := make(chan struct{})
 	go func() {
-		if err := startHTTPServer(ctx, cfg, httpServer); err != nil {
+		if err := startHTTPServer(ctx, cfg, httpServer, listeners); err != nil {
 			logger.Error("HTTP server failed", "error", err)
 			os.Exit(1)
 		}
+		close(httpDone)
 	}()
 
-	// Wait for shutdown signal
-	waitForShutdown(ctx, cancel, grpcServer, httpServer, metricsRegistry, cfg)
+	// Publish game starts and ends to the public feed
+	if appServices.Feed != nil {
+		go appServices.Feed.Run(ctx)
+	}
+
+	// Run background jobs: persisting write-behind session changes, pruning
+	// temp files, stale locks, expired recordings and old logs
+	jobs, err := app.NewScheduler(cfg, appServices, db.Writer(), metricsRegistry, logger)
+	if err != nil {
+		logger.Error("Failed to schedule background jobs", "error", err)
+		os.Exit(1)
+	}
+	jobsDone := make(chan struct{})
+	go func() {
+		jobs.Run(ctx)
+		close(jobsDone)
+	}()
+
+	// Wait for a shutdown signal or a takeover
+	takeover := waitForShutdown(cancel, grpcServices, metricsRegistry, cfg, takeovers)
+
+	// Wait for the servers and jobs to stop, then flush the session cache
+	<-grpcDone
+	<-httpDone
+	<-jobsDone
+	appServices.SessionCache.Close()
+
+	// With the ports free, pass the running games to the new instance
+	if takeover != nil {
+		adopted, err := grpcServices.HandOff(takeover)
+		if err != nil {
+			logger.Error("Failed to hand over running games", "error", err)
+		} else {
+			logger.Info("Handed over running games", "count", adopted)
+		}
+	}
+	logger.Info("Game service shutdown complete")
 }
 
 // loadConfig loads the service configuration
@@ -179,104 +310,99 @@ func initializeDatabase(cfg *config.GameServiceConfig) (*database.Connection, er
 	return db, nil
 }
 
-// initializeDefaultGames adds default games to the repository for development
-func initializeDefaultGames(gameService *application.GameService) {
-	ctx := context.Background()
-
-	// Add NetHack as a default game
-	nethackReq := &application.CreateGameRequest{
-		ID:               "nethack",
-		Name:             "NetHack",
-		ShortName:        "nh",
-		Description:      "The classic dungeon exploration game",
-		Category:         "roguelike",
-		Tags:             []string{"roguelike", "classic", "dungeon"},
-		Version:          "3.6.7",
-		Difficulty:       7,
-		BinaryPath:       "/opt/homebrew/bin/nethack",
-		BinaryArgs:       []string{"-u", "${USERNAME}"},
-		WorkingDirectory: "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
-		Environment: map[string]string{
-			"TERM":           "xterm-256color",
-			"USER":           "${USERNAME}",
-			"HOME":           "/opt/homebrew/Cellar/nethack/3.6.7/libexec/${USERNAME}",
-			"HACKDIR":        "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
-			"NETHACKDIR":     "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
-			"NETHACKOPTIONS": "@/opt/homebrew/Cellar/nethack/3.6.7/libexec/${USERNAME}.nethackrc",
-		},
-		CPULimit:                 "500m",
-		MemoryLimit:              "256Mi",
-		DiskLimit:                "1Gi",
-		TimeoutSeconds:           14400, // 4 hours
-		RunAsUser:                1000,
-		RunAsGroup:               1000,
-		ReadOnlyRootFilesystem:   false,
-		AllowPrivilegeEscalation: false,
-		NetworkIsolated:          true,
-		BlockInternet:            true,
-	}
-
-	// Try to create the game, ignore errors if it already exists
-	gameService.CreateGame(ctx, nethackReq)
-}
-
-// ApplicationServices holds all application services
-type ApplicationServices struct {
-	GameService    *application.GameService
-	SessionService *application.SessionService
-}
-
-// initializeApplicationServices initializes all application services
-func initializeApplicationServices(db *database.Connection, metricsRegistry *metrics.Registry) *ApplicationServices {
-	// Initialize stub repositories for development
-	gameRepo := repository.NewStubGameRepository()
-	sessionRepo := repository.NewStubSessionRepository()
-	saveRepo := repository.NewStubSaveRepository()
-	eventRepo := repository.NewStubEventRepository()
+// initializeAPIAuth connects to the auth service REST API callers are checked
+// against. It returns no middleware when API authentication is disabled.
+func initializeAPIAuth(cfg *config.GameServiceConfig) (*apiauth.Middleware, *grpc.ClientConn, error) {
+	if cfg.APIAuth == nil || !cfg.APIAuth.Enabled {
+		logger.Warn("REST API authentication is disabled, the API is open to anyone")
+		return nil, nil, nil
+	}
+	if cfg.APIAuth.AuthService == "" {
+		return nil, nil, fmt.Errorf("api_auth.auth_service is required")
+	}
 
-	// Create unit of work
-	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(cfg.APIAuth.ServiceToken)...)
+	dialOpts = append(dialOpts, interceptors.WithRequestID()...)
+	dialOpts = append(dialOpts, interceptors.WithDialTimeout(cfg.Server.GetTimeouts().GetGRPCDial())...)
+	conn, err := grpc.Dial(cfg.APIAuth.AuthService, dialOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
 
-	// Initialize application services
-	gameService := application.NewGameService(gameRepo, sessionRepo, saveRepo, eventRepo, uow)
-	sessionService := application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow)
+	rateLimit := cfg.APIAuth.RequestsPerMinute()
+	logger.Info("REST API authentication enabled", "auth_service", cfg.APIAuth.AuthService, "rate_limit", rateLimit)
+	return apiauth.New(authv1.NewAuthServiceClient(conn), rateLimit, logger), conn, nil
+}
 
-	// Add default games for development
-	initializeDefaultGames(gameService)
+// initializeNotifications connects to the auth service players are notified
+// through, returning nil when notifications are disabled
+func initializeNotifications(cfg *config.GameServiceConfig) (*grpc.ClientConn, error) {
+	if cfg.Notifications == nil || !cfg.Notifications.Enabled {
+		return nil, nil
+	}
+	if cfg.Notifications.AuthService == "" {
+		return nil, fmt.Errorf("notifications.auth_service is required")
+	}
 
-	return &ApplicationServices{
-		GameService:    gameService,
-		SessionService: sessionService,
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(cfg.Notifications.ServiceToken)...)
+	dialOpts = append(dialOpts, interceptors.WithRequestID()...)
+	dialOpts = append(dialOpts, interceptors.WithDialTimeout(cfg.Server.GetTimeouts().GetGRPCDial())...)
+	conn, err := grpc.Dial(cfg.Notifications.AuthService, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
 	}
-}
 
-// initializeGRPCServer initializes the gRPC server
-func initializeGRPCServer(cfg *config.GameServiceConfig, appServices *ApplicationServices, metricsRegistry *metrics.Registry) *grpc.Server {
-	server := grpc.NewServer()
+	logger.Info("Player notifications enabled", "auth_service", cfg.Notifications.AuthService)
+	return conn, nil
+}
 
-	// Register health check service
-	healthServer := health.NewServer()
-	grpc_health_v1.RegisterHealthServer(server, healthServer)
-	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+// initializeFeatureFlags returns the configured feature flags, reading those
+// set at runtime from the auth service when feature_flags.auth_service is set
+func initializeFeatureFlags(cfg *config.GameServiceConfig) (*featureflags.Flags, *grpc.ClientConn, error) {
+	if cfg.FeatureFlags == nil || cfg.FeatureFlags.AuthService == "" {
+		return featureflags.New(cfg.FeatureFlags, nil, logger), nil, nil
+	}
 
-	// Register game service with slog logger
-	gameServiceServer := grpc_service.NewGameServiceServer(cfg, appServices.GameService, appServices.SessionService, logger)
-	games_pb.RegisterGameServiceServer(server, gameServiceServer)
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())},
+		interceptors.WithServiceToken(cfg.FeatureFlags.ServiceToken)...)
+	dialOpts = append(dialOpts, interceptors.WithRequestID()...)
+	dialOpts = append(dialOpts, interceptors.WithDialTimeout(cfg.Server.GetTimeouts().GetGRPCDial())...)
+	conn, err := grpc.Dial(cfg.FeatureFlags.AuthService, dialOpts...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
 
-	return server
+	logger.Info("Reading feature flags set at runtime", "auth_service", cfg.FeatureFlags.AuthService, "refresh_interval", cfg.FeatureFlags.GetRefreshInterval())
+	store := featureflags.NewAuthStore(authv1.NewAuthServiceClient(conn))
+	return featureflags.New(cfg.FeatureFlags, store, logger), conn, nil
 }
 
-// initializeHTTPServer initializes the HTTP server
-func initializeHTTPServer(cfg *config.GameServiceConfig, appServices *ApplicationServices) *http.Server {
+// initializeHTTPServer initializes the HTTP server. The REST API is protected
+// by apiAuth when it is set and rate limited by rateLimits, which see the
+// authenticated caller; health and metrics stay open.
+func initializeHTTPServer(cfg *config.GameServiceConfig, appServices *app.Services, captures *grpc_service.IOCaptures, apiAuth *apiauth.Middleware, rateLimits *ratelimit.Policy) *http.Server {
 	mux := http.NewServeMux()
 
+	protect := func(resource string, handler http.HandlerFunc) http.Handler {
+		limited := rateLimits.Middleware(handler)
+		if apiAuth == nil {
+			return limited
+		}
+		return apiAuth.Require(resource, limited)
+	}
+
 	// Health check endpoint
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status": "healthy", "service": "%s", "version": "%s"}`, serviceName, version)
+		fmt.Fprintf(w, `{"status": "healthy", "service": "%s", "version": "%s", "build_time": "%s", "git_commit": "%s"}`, serviceName, version, buildTime, gitCommit)
 	})
 
+	// Build of this game service
+	mux.Handle(buildinfo.Path, buildinfo.New(serviceName, version, buildTime, gitCommit).Handler())
+
 	// Metrics endpoint
 	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
 		// TODO: Implement Prometheus metrics
@@ -288,14 +414,53 @@ func initializeHTTPServer(cfg *config.GameServiceConfig, appServices *Applicatio
 	})
 
 	// Game management endpoints (REST API)
-	mux.HandleFunc("/api/v1/games", handleGamesAPI(appServices.GameService))
-	mux.HandleFunc("/api/v1/sessions", handleSessionsAPI(appServices.SessionService))
+	mux.Handle("/api/v1/games", protect("games", handleGamesAPI(appServices.GameService)))
+	mux.Handle("/api/v1/sessions", protect("sessions", handleSessionsAPI(appServices.SessionService)))
+	mux.Handle(historyPath, protect("sessions", handleSessionHistoryAPI(appServices.SessionService)))
+	mux.Handle(sessionsPrefix, protect("sessions", handleSessionBookmarksAPI(appServices.SessionService, appServices.BookmarkService)))
+	mux.Handle("/api/v1/dumplogs", protect("dumplogs", handleDumplogsAPI(appServices.DumplogService)))
+	mux.Handle("/api/v1/dumplogs/", protect("dumplogs", handleDumplogsAPI(appServices.DumplogService)))
+	mux.Handle(screenshotsPath, protect("screenshots", handleScreenshotsAPI(appServices.ScreenshotService)))
+	mux.Handle(screenshotsPath+"/", protect("screenshots", handleScreenshotsAPI(appServices.ScreenshotService)))
+
+	// Shared screenshots are public while their links last, but rate limited
+	mux.Handle(application.ScreenshotSharePath, rateLimits.Middleware(handleSharedScreenshots(appServices.ScreenshotService)))
+
+	// The public feed needs no credentials, but is rate limited
+	if feed := cfg.PublicFeed; feed != nil && feed.Enabled {
+		public := func(handler http.HandlerFunc) http.Handler {
+			return rateLimits.Middleware(publicCORS(feed.AllowedOrigins, handler))
+		}
+		mux.Handle(publicEventsPath, public(handlePublicEvents(appServices.Feed)))
+		mux.Handle(publicGamesPath, public(handlePublicGames(appServices.SessionService, feed.GetCacheMaxAge())))
+		mux.Handle(publicLeaderboardPath, public(handlePublicLeaderboard(appServices.SessionService, feed.GetCacheMaxAge()*leaderboardCacheFactor)))
+	}
+
+	// Admin endpoints, for operators holding the service token; without one
+	// they are not served
+	admin := func(handler http.Handler) http.Handler {
+		return apiauth.RequireServiceToken(cfg.Server.AuthToken, handler)
+	}
+	if cfg.Server.AuthToken != "" {
+		mux.Handle(logging.LevelPath, admin(logging.LevelHandler(logging.LevelsOf(logger), logger)))
+	}
+
+	// Captures record everything a player types, so they also need an admin
+	// to sign in, who the audit log names
+	switch {
+	case cfg.Server.AuthToken == "":
+		logger.Warn("Admin endpoints disabled: server.auth_token is not set")
+	case apiAuth == nil:
+		logger.Warn("I/O capture endpoint disabled: api_auth is not enabled to sign admins in")
+	default:
+		mux.Handle(capturePath, admin(apiAuth.RequireAdmin(handleCaptureAPI(captures))))
+	}
 
 	return &http.Server{
 		Addr:         fmt.Sprintf(":%d", getHTTPPort(cfg)),
-		Handler:      mux,
-		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		Handler:      logging.RequestIDMiddleware(mux),
+		ReadTimeout:  cfg.Server.GetTimeouts().GetHTTPRead(),
+		WriteTimeout: cfg.Server.GetTimeouts().GetHTTPWrite(),
 		IdleTimeout:  60 * time.Second,
 	}
 }
@@ -317,14 +482,14 @@ func getGRPCPort(cfg *config.GameServiceConfig) int {
 }
 
 // startGRPCServer starts the gRPC server
-func startGRPCServer(ctx context.Context, cfg *config.GameServiceConfig, server *grpc.Server) error {
+func startGRPCServer(ctx context.Context, cfg *config.GameServiceConfig, server *grpc.Server, listeners *listener.Listeners) error {
 	addr := fmt.Sprintf(":%d", getGRPCPort(cfg))
-	listener, err := net.Listen("tcp", addr)
+	ln, err := listeners.Listen(listener.GRPC, addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	logger.Info("gRPC server starting", "address", addr)
+	logger.Info("gRPC server starting", "address", ln.Addr().String())
 
 	go func() {
 		<-ctx.Done()
@@ -332,7 +497,7 @@ func startGRPCServer(ctx context.Context, cfg *config.GameServiceConfig, server
 		server.GracefulStop()
 	}()
 
-	if err := server.Serve(listener); err != nil {
+	if err := server.Serve(ln); err != nil {
 		return fmt.Errorf("gRPC server failed: %w", err)
 	}
 
@@ -340,8 +505,12 @@ func startGRPCServer(ctx context.Context, cfg *config.GameServiceConfig, server
 }
 
 // startHTTPServer starts the HTTP server
-func startHTTPServer(ctx context.Context, cfg *config.GameServiceConfig, server *http.Server) error {
-	logger.Info("HTTP server starting", "address", server.Addr)
+func startHTTPServer(ctx context.Context, cfg *config.GameServiceConfig, server *http.Server, listeners *listener.Listeners) error {
+	ln, err := listeners.Listen(listener.HTTP, server.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", server.Addr, err)
+	}
+	logger.Info("HTTP server starting", "address", ln.Addr().String())
 
 	go func() {
 		<-ctx.Done()
@@ -355,21 +524,44 @@ func startHTTPServer(ctx context.Context, cfg *config.GameServiceConfig, server
 		}
 	}()
 
-	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := server.Serve(ln); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("HTTP server failed: %w", err)
 	}
 
 	return nil
 }
 
-// waitForShutdown waits for shutdown signals and gracefully shuts down servers
-func waitForShutdown(ctx context.Context, cancel context.CancelFunc, grpcServer *grpc.Server, httpServer *http.Server, metricsRegistry *metrics.Registry, cfg *config.GameServiceConfig) {
+// handoffGrace is how much longer than the shutdown timeout a new instance
+// waits for the one it replaces to hand over its games
+const handoffGrace = 30 * time.Second
+
+// waitForShutdown waits for a shutdown signal or a takeover request, drains
+// the game service and then stops the servers. It returns once they have been
+// told to stop, with the takeover connection if a newer instance asked for one.
+func waitForShutdown(cancel context.CancelFunc, grpcServices *app.GRPCServices, metricsRegistry *metrics.Registry, cfg *config.GameServiceConfig, takeovers <-chan *net.UnixConn) *net.UnixConn {
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-	<-sigChan
-	logger.Info("Shutdown signal received, starting graceful shutdown...")
+	// Refuse new games and give players until the deadline to leave theirs.
+	// A takeover detaches them at once: the new instance adopts their games.
+	timeout := app.ShutdownTimeout(cfg)
+	var takeover *net.UnixConn
+	select {
+	case <-sigChan:
+		logger.Info("Shutdown signal received, starting graceful shutdown...")
+	case takeover = <-takeovers:
+		logger.Info("Takeover requested, handing games to the new instance...")
+		timeout = 0
+	}
 
+	logger.Info("Draining game sessions", "timeout", timeout)
+	drainCtx, drainCancel := context.WithTimeout(context.Background(), timeout)
+	if err := grpcServices.Shutdown(drainCtx); err != nil {
+		logger.Error("Error draining game service", "error", err)
+	}
+	drainCancel()
+
+	// Stops the gRPC and HTTP servers and the background workers
 	cancel()
 
 	// Stop metrics server
@@ -380,10 +572,7 @@ func waitForShutdown(ctx context.Context, cancel context.CancelFunc, grpcServer
 			logger.Error("Error stopping metrics server", "error", err)
 		}
 	}
-
-	// Wait a bit for servers to shut down gracefully
-	time.Sleep(2 * time.Second)
-	logger.Info("Game service shutdown complete")
+	return takeover
 }
 
 // HTTP API handlers
@@ -392,7 +581,7 @@ func waitForShutdown(ctx context.Context, cancel context.CancelFunc, grpcServer
 func handleGamesAPI(gameService *application.GameService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if gameService == nil {
-			http.Error(w, "Game service not initialized", http.StatusServiceUnavailable)
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Game service not initialized"))
 			return
 		}
 
@@ -401,7 +590,7 @@ func handleGamesAPI(gameService *application.GameService) http.HandlerFunc {
 			// List games
 			games, err := gameService.ListEnabledGames(r.Context())
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				apierror.WriteProblem(w, r, err)
 				return
 			}
 
@@ -412,19 +601,35 @@ func handleGamesAPI(gameService *application.GameService) http.HandlerFunc {
 		case http.MethodPost:
 			// Create game
 			// TODO: Parse request body and create game
-			http.Error(w, "Not implemented", http.StatusNotImplemented)
+			apierror.WriteProblem(w, r, apierror.New(apierror.NotImplemented, "Not implemented"))
 
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
 		}
 	}
 }
 
+// activeSessionJSON is the REST representation of a running session
+type activeSessionJSON struct {
+	ID             string    `json:"id"`
+	UserID         int       `json:"user_id"`
+	Username       string    `json:"username"`
+	GameID         string    `json:"game_id"`
+	GameVersion    string    `json:"game_version,omitempty"`
+	Status         string    `json:"status"`
+	StartTime      time.Time `json:"start_time"`
+	LastActivity   time.Time `json:"last_activity"`
+	SpectatorCount int       `json:"spectator_count"`
+	// StatusLine is what the game's screen says of the game, e.g.
+	// "Dlvl:12 HP:45/60 XL:8", when its adapter reads one
+	StatusLine string `json:"status_line,omitempty"`
+}
+
 // handleSessionsAPI handles session-related API requests
 func handleSessionsAPI(sessionService *application.SessionService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if sessionService == nil {
-			http.Error(w, "Session service not initialized", http.StatusServiceUnavailable)
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Session service not initialized"))
 			return
 		}
 
@@ -433,20 +638,136 @@ func handleSessionsAPI(sessionService *application.SessionService) http.HandlerF
 			// List sessions
 			sessions, err := sessionService.ListActiveSessions(r.Context())
 			if err != nil {
-				http.Error(w, err.Error(), http.StatusInternalServerError)
+				apierror.WriteProblem(w, r, err)
 				return
 			}
 
+			result := make([]activeSessionJSON, len(sessions))
+			for i, session := range sessions {
+				result[i] = activeSessionJSON{
+					ID:             session.ID().String(),
+					UserID:         session.UserID().Int(),
+					Username:       session.Username(),
+					GameID:         session.GameID().String(),
+					GameVersion:    session.GameVersion(),
+					Status:         string(session.Status()),
+					StartTime:      session.StartTime(),
+					LastActivity:   session.LastActivity(),
+					SpectatorCount: session.SpectatorCount(),
+					StatusLine:     session.StatusLine(),
+				}
+			}
+
 			w.Header().Set("Content-Type", "application/json")
-			fmt.Fprintf(w, `{"sessions": [], "count": %d}`, len(sessions))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"sessions": result,
+				"count":    len(result),
+			})
 
 		case http.MethodPost:
 			// Start session
 			// TODO: Parse request body and start session
-			http.Error(w, "Not implemented", http.StatusNotImplemented)
+			apierror.WriteProblem(w, r, apierror.New(apierror.NotImplemented, "Not implemented"))
 
 		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
 		}
 	}
 }
+
+// dumplogJSON is the REST representation of a dumplog
+type dumplogJSON struct {
+	ID         string    `json:"id"`
+	SessionID  string    `json:"session_id"`
+	UserID     int       `json:"user_id"`
+	Username   string    `json:"username"`
+	GameID     string    `json:"game_id"`
+	Size       int64     `json:"size"`
+	Truncated  bool      `json:"truncated"`
+	CapturedAt time.Time `json:"captured_at"`
+}
+
+// handleDumplogsAPI handles dumplog API requests:
+// GET /api/v1/dumplogs[?user_id=N][&game_id=G][&limit=N] lists dumplogs,
+// GET /api/v1/dumplogs/{id} returns the dumplog text. Signed in users only
+// see their own dumplogs; admins see any.
+func handleDumplogsAPI(dumplogService *application.DumplogService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if dumplogService == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Dumplog service not initialized"))
+			return
+		}
+		if r.Method != http.MethodGet {
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+
+		// Signed in users read their own dumplogs, and admins any
+		viewerID, signedIn := screenshotViewer(r)
+		admin := false
+		if principal, ok := apiauth.FromContext(r.Context()); ok && principal.User != nil {
+			admin = principal.User.IsAdmin
+		}
+
+		// Single dumplog content
+		if id := strings.TrimPrefix(r.URL.Path, "/api/v1/dumplogs/"); id != r.URL.Path && id != "" {
+			dumplog, err := dumplogService.GetDumplogFor(r.Context(), id, viewerID, admin || !signedIn)
+			if err != nil {
+				apierror.WriteProblem(w, r, err)
+				return
+			}
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			w.Write(dumplog.Content())
+			return
+		}
+
+		// List a user's dumplogs, by default the signed in user's
+		userID := viewerID
+		if value := r.URL.Query().Get("user_id"); value != "" {
+			id, err := strconv.Atoi(value)
+			if err != nil || id <= 0 {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id must be a positive number"))
+				return
+			}
+			if signedIn && !admin && id != viewerID {
+				apierror.WriteProblem(w, r, apierror.New(apierror.PermissionDenied, "Dumplogs of other users are not listed"))
+				return
+			}
+			userID = id
+		}
+		if userID <= 0 {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id is required"))
+			return
+		}
+		limit := 10
+		if l, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && l > 0 {
+			limit = l
+		}
+
+		dumplogs, err := dumplogService.ListRecentDumplogs(r.Context(), userID, r.URL.Query().Get("game_id"), limit)
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		result := make([]dumplogJSON, len(dumplogs))
+		for i, dumplog := range dumplogs {
+			result[i] = dumplogJSON{
+				ID:         dumplog.ID().String(),
+				SessionID:  dumplog.SessionID().String(),
+				UserID:     dumplog.UserID().Int(),
+				Username:   dumplog.Username(),
+				GameID:     dumplog.GameID().String(),
+				Size:       dumplog.Size(),
+				Truncated:  dumplog.Truncated(),
+				CapturedAt: dumplog.CapturedAt(),
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"dumplogs": result,
+			"count":    len(result),
+		})
+	}
+}
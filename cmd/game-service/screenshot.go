@@ -0,0 +1,248 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// screenshotsPath lists screenshots and serves them to signed in users
+const screenshotsPath = "/api/v1/screenshots"
+
+// screenshotJSON is the REST representation of a screenshot
+type screenshotJSON struct {
+	ID              string     `json:"id"`
+	SessionID       string     `json:"session_id"`
+	UserID          int        `json:"user_id"`
+	Username        string     `json:"username"`
+	GameID          string     `json:"game_id"`
+	TakenByUserID   int        `json:"taken_by_user_id"`
+	TakenByUsername string     `json:"taken_by_username"`
+	Cols            int        `json:"cols"`
+	Rows            int        `json:"rows"`
+	Size            int64      `json:"size"`
+	TakenAt         time.Time  `json:"taken_at"`
+	ShareURL        string     `json:"share_url,omitempty"`
+	ShareExpiresAt  *time.Time `json:"share_expires_at,omitempty"`
+}
+
+// screenshotContentTypes are the content types and file extensions of the
+// screenshot formats
+var screenshotContentTypes = map[domain.ScreenshotFormat][2]string{
+	domain.ScreenshotText: {"text/plain; charset=utf-8", "txt"},
+	domain.ScreenshotANSI: {"text/plain; charset=utf-8", "ans"},
+	domain.ScreenshotHTML: {"text/html; charset=utf-8", "html"},
+}
+
+// handleScreenshotsAPI handles screenshot API requests:
+// GET /api/v1/screenshots[?user_id=N][&session_id=S][&limit=N] lists the
+// screenshots a user took or that were taken of their games, user_id
+// defaulting to the authenticated user;
+// GET /api/v1/screenshots/{id} describes one, and with ?format=text|ansi|html
+// downloads it; POST /api/v1/screenshots/{id}/share makes a public link.
+// Signed in users only see their own screenshots and those of their games.
+func handleScreenshotsAPI(screenshots *application.ScreenshotService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if screenshots == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Screenshots are not enabled"))
+			return
+		}
+		viewerID, signedIn := screenshotViewer(r)
+
+		rest := strings.Trim(strings.TrimPrefix(r.URL.Path, screenshotsPath), "/")
+		if rest == "" {
+			if r.Method != http.MethodGet {
+				apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+				return
+			}
+			listScreenshots(w, r, screenshots, viewerID)
+			return
+		}
+
+		id, action, _ := strings.Cut(rest, "/")
+		switch {
+		case action == "" && r.Method == http.MethodGet:
+			screenshot, err := screenshots.GetScreenshot(r.Context(), id)
+			if err == nil && signedIn && !screenshot.CanView(domain.NewUserID(viewerID)) {
+				err = domain.ErrScreenshotNotFound
+			}
+			if err != nil {
+				apierror.WriteProblem(w, r, err)
+				return
+			}
+			if value := r.URL.Query().Get("format"); value != "" {
+				format, ok := domain.ParseScreenshotFormat(value)
+				if !ok {
+					apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "format must be text, ansi or html"))
+					return
+				}
+				writeScreenshot(w, screenshot, format, true)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(screenshotToJSON(screenshots, screenshot))
+
+		case action == "share" && r.Method == http.MethodPost:
+			if !signedIn {
+				apierror.WriteProblem(w, r, apierror.New(apierror.Unauthenticated, "Sharing screenshots needs API authentication"))
+				return
+			}
+			screenshot, err := screenshots.ShareScreenshot(r.Context(), id, viewerID)
+			if err != nil {
+				apierror.WriteProblem(w, r, err)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(screenshotToJSON(screenshots, screenshot))
+
+		case action == "" || action == "share":
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+
+		default:
+			apierror.WriteProblem(w, r, apierror.New(apierror.NotFound, "Not found"))
+		}
+	}
+}
+
+// listScreenshots writes the screenshots a user took or that were taken of
+// their games
+func listScreenshots(w http.ResponseWriter, r *http.Request, screenshots *application.ScreenshotService, viewerID int) {
+	query := r.URL.Query()
+	userID := viewerID
+	if value := query.Get("user_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id must be a positive number"))
+			return
+		}
+		if viewerID > 0 && id != viewerID {
+			apierror.WriteProblem(w, r, apierror.New(apierror.PermissionDenied, "Screenshots of other users are not listed"))
+			return
+		}
+		userID = id
+	}
+	if userID <= 0 {
+		apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "user_id is required"))
+		return
+	}
+	limit := 20
+	if l, err := strconv.Atoi(query.Get("limit")); err == nil && l > 0 {
+		limit = l
+	}
+
+	list, err := screenshots.ListScreenshots(r.Context(), userID, query.Get("session_id"), limit)
+	if err != nil {
+		apierror.WriteProblem(w, r, err)
+		return
+	}
+	result := make([]screenshotJSON, len(list))
+	for i, screenshot := range list {
+		result[i] = screenshotToJSON(screenshots, screenshot)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"screenshots": result,
+		"count":       len(result),
+	})
+}
+
+// handleSharedScreenshots serves screenshots through their public links:
+// GET /shared/screenshots/{token}[?format=text|ansi]. The link works for
+// anyone until it expires; without a format the screenshot is a web page.
+func handleSharedScreenshots(screenshots *application.ScreenshotService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if screenshots == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.NotFound, "Screenshot not found"))
+			return
+		}
+		if r.Method != http.MethodGet {
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+
+		token := strings.TrimPrefix(r.URL.Path, application.ScreenshotSharePath)
+		screenshot, err := screenshots.GetSharedScreenshot(r.Context(), token)
+		if err != nil {
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		format := domain.ScreenshotHTML
+		if value := r.URL.Query().Get("format"); value != "" {
+			var ok bool
+			if format, ok = domain.ParseScreenshotFormat(value); !ok {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "format must be text, ansi or html"))
+				return
+			}
+		}
+		if format != domain.ScreenshotHTML {
+			writeScreenshot(w, screenshot, format, false)
+			return
+		}
+
+		title := html.EscapeString(fmt.Sprintf("%s playing %s", screenshot.Username(), screenshot.GameID().String()))
+		w.Header().Set("Content-Type", screenshotContentTypes[format][0])
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprintf(w, "<!DOCTYPE html>\n<html><head><meta charset=\"utf-8\"><title>%s</title></head>\n"+
+			"<body style=\"background:#1c1c1c;color:#e5e5e5;font-family:sans-serif\">\n<h1 style=\"font-size:1.2em\">%s</h1>\n%s\n"+
+			"<p>Taken %s</p>\n</body></html>\n",
+			title, title, screenshot.Content(domain.ScreenshotHTML), screenshot.TakenAt().UTC().Format("2006-01-02 15:04 MST"))
+	}
+}
+
+// writeScreenshot writes a screenshot in a format, as a file to save when
+// download is set
+func writeScreenshot(w http.ResponseWriter, screenshot *domain.Screenshot, format domain.ScreenshotFormat, download bool) {
+	content := screenshotContentTypes[format]
+	w.Header().Set("Content-Type", content[0])
+	if download {
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", screenshot.ID().String()+"."+content[1]))
+	}
+	w.Write([]byte(screenshot.Content(format)))
+}
+
+// screenshotViewer returns the signed in user of a request, or false when
+// the REST API is open
+func screenshotViewer(r *http.Request) (int, bool) {
+	principal, ok := apiauth.FromContext(r.Context())
+	if !ok || principal.User == nil {
+		return 0, false
+	}
+	id, _ := strconv.Atoi(principal.User.Id)
+	return id, true
+}
+
+// screenshotToJSON converts a screenshot without its renderings
+func screenshotToJSON(screenshots *application.ScreenshotService, screenshot *domain.Screenshot) screenshotJSON {
+	cols, rows := screenshot.Size()
+	entry := screenshotJSON{
+		ID:              screenshot.ID().String(),
+		SessionID:       screenshot.SessionID().String(),
+		UserID:          screenshot.UserID().Int(),
+		Username:        screenshot.Username(),
+		GameID:          screenshot.GameID().String(),
+		TakenByUserID:   screenshot.TakenBy().Int(),
+		TakenByUsername: screenshot.TakenByName(),
+		Cols:            cols,
+		Rows:            rows,
+		Size:            screenshot.Bytes(),
+		TakenAt:         screenshot.TakenAt(),
+	}
+	if url := screenshots.ShareURL(screenshot); url != "" {
+		expires := screenshot.ShareExpiresAt()
+		entry.ShareURL = url
+		entry.ShareExpiresAt = &expires
+	}
+	return entry
+}
@@ -11,7 +11,9 @@ import (
 	"time"
 
 	"github.com/dungeongate/internal/session"
+	"github.com/dungeongate/pkg/buildinfo"
 	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/healthcheck"
 	"github.com/dungeongate/pkg/logging"
 	"github.com/dungeongate/pkg/metrics"
 )
@@ -24,24 +26,9 @@ var (
 
 // setupLogger creates a configured slog logger
 func setupLogger(cfg *config.SessionServiceConfig) *slog.Logger {
-	// Set defaults if values are empty
-	level := "info"
-	format := "text"
-	output := "stdout"
-
-	if cfg.Logging != nil {
-		if cfg.Logging.Level != "" {
-			level = cfg.Logging.Level
-		}
-		if cfg.Logging.Format != "" {
-			format = cfg.Logging.Format
-		}
-		if cfg.Logging.Output != "" {
-			output = cfg.Logging.Output
-		}
-	}
-
-	return logging.NewLoggerBasic("session-service", level, format, output)
+	logger := logging.NewLogger("session-service", logging.FromConfig(cfg.Logging))
+	logging.ReopenOnHangup(logger)
+	return logger
 }
 
 func main() {
@@ -66,8 +53,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// `session-service healthcheck` probes the running service for containers
+	if flag.Arg(0) == "healthcheck" {
+		sessionConfig := session.NewConfig(cfg, buildinfo.New("session-service", version, buildTime, gitCommit))
+		target := healthcheck.TargetOf(sessionConfig.Listen, sessionConfig.HTTP.Port, sessionConfig.GRPC.Port)
+		os.Exit(healthcheck.Run("session-service", target, os.Stdout, os.Stderr))
+	}
+
 	// Setup structured logging based on configuration
 	logger := setupLogger(cfg)
+	config.LogEnvExpansions(logger, cfg.EnvExpansions)
 
 	// Initialize metrics registry
 	metricsRegistry := metrics.NewRegistry("session-service", version, buildTime, gitCommit, logger)
@@ -83,76 +78,7 @@ func main() {
 	}
 
 	// Convert to session config format
-	sessionConfig := &session.Config{
-		GameService: struct {
-			Address string `yaml:"address" default:"localhost:50051"`
-		}{
-			Address: cfg.Services.GameService,
-		},
-		AuthService: struct {
-			Address string `yaml:"address" default:"localhost:8082"`
-		}{
-			Address: cfg.Services.AuthService,
-		},
-		SSH: struct {
-			Address         string `yaml:"address" default:"0.0.0.0"`
-			Port            int    `yaml:"port" default:"2222"`
-			IdleTimeout     string `yaml:"idle_timeout" default:"1h"`
-			HostKey         string `yaml:"host_key" default:""`
-			PasswordAuth    bool   `yaml:"password_auth" default:"true"`
-			PublicKeyAuth   bool   `yaml:"public_key_auth" default:"false"`
-			AllowAnonymous  bool   `yaml:"allow_anonymous" default:"true"`
-			AllowedUsername string `yaml:"allowed_username" default:"dungeongate"`
-			SSHPassword     string `yaml:"ssh_password" default:""`
-		}{
-			Address:         cfg.SSH.Host,
-			Port:            cfg.SSH.Port,
-			IdleTimeout:     "1h",
-			HostKey:         cfg.SSH.HostKeyPath,
-			PasswordAuth:    cfg.SSH.Auth.PasswordAuth,
-			PublicKeyAuth:   cfg.SSH.Auth.PublicKeyAuth,
-			AllowAnonymous:  cfg.SSH.Auth.AllowAnonymous,
-			AllowedUsername: cfg.SSH.Auth.AllowedUsername,
-			SSHPassword:     cfg.SSH.Auth.SSHPassword,
-		},
-		HTTP: struct {
-			Address string `yaml:"address" default:"0.0.0.0"`
-			Port    int    `yaml:"port" default:"8083"`
-		}{
-			Address: "0.0.0.0",
-			Port:    cfg.Server.Port,
-		},
-		GRPC: struct {
-			Address string `yaml:"address" default:"0.0.0.0"`
-			Port    int    `yaml:"port" default:"9093"`
-		}{
-			Address: "0.0.0.0",
-			Port:    cfg.Server.Port + 1000, // Use different port for gRPC
-		},
-		MaxConnections: 1000,
-		MaxPTYs:        500,
-		Version:        version,
-	}
-
-	// Set idle retry interval if available
-	if cfg.SessionManagement != nil && cfg.SessionManagement.Heartbeat != nil {
-		if interval, err := time.ParseDuration(cfg.SessionManagement.Heartbeat.IdleRetryInterval); err == nil {
-			sessionConfig.IdleRetryInterval = interval
-		} else {
-			sessionConfig.IdleRetryInterval = 5 * time.Second
-		}
-	} else {
-		sessionConfig.IdleRetryInterval = 5 * time.Second
-	}
-
-	// Set banner configuration if available
-	if cfg.Menu != nil && cfg.Menu.Banners != nil {
-		sessionConfig.Menu.Banners.MainAnon = cfg.Menu.Banners.MainAnon
-		sessionConfig.Menu.Banners.MainUser = cfg.Menu.Banners.MainUser
-		sessionConfig.Menu.Banners.MainAdmin = cfg.Menu.Banners.MainAdmin
-		sessionConfig.Menu.Banners.WatchMenu = cfg.Menu.Banners.WatchMenu
-		sessionConfig.Menu.Banners.ServiceUnavailable = cfg.Menu.Banners.ServiceUnavailable
-	}
+	sessionConfig := session.NewConfig(cfg, buildinfo.New("session-service", version, buildTime, gitCommit))
 
 	// Create stateless session service
 	sessionService, err := session.New(sessionConfig, logger, metricsRegistry)
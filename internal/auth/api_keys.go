@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// CreateAPIKey creates a scoped API key for the token user. Support staff
+// impersonating a user cannot create keys in their name.
+func (s *Service) CreateAPIKey(ctx context.Context, req *proto.CreateAPIKeyRequest) (*proto.CreateAPIKeyResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.CreateAPIKeyResponse{Success: false, Error: errMsg}, err
+	}
+
+	apiKey, key, err := s.userSvc.CreateAPIKey(ctx, userID, req.Name, req.Scopes, int(req.RateLimit))
+	if err != nil {
+		return &proto.CreateAPIKeyResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to create API key: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("API key created",
+		"audit", true,
+		"username", owner.Username,
+		"key_id", apiKey.ID,
+		"prefix", apiKey.Prefix,
+		"scopes", apiKey.Scopes,
+	)
+
+	return &proto.CreateAPIKeyResponse{
+		Success: true,
+		Key:     key,
+		ApiKey:  convertAPIKeyToProto(apiKey),
+	}, nil
+}
+
+// ListAPIKeys lists the token user's API keys
+func (s *Service) ListAPIKeys(ctx context.Context, req *proto.ListAPIKeysRequest) (*proto.ListAPIKeysResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if owner == nil {
+		return &proto.ListAPIKeysResponse{Success: false, Error: errMsg}, err
+	}
+
+	keys, err := s.userSvc.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return &proto.ListAPIKeysResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list API keys: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListAPIKeysResponse{Success: true}
+	for _, key := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, convertAPIKeyToProto(key))
+	}
+	return resp, nil
+}
+
+// RevokeAPIKey revokes one of the token user's API keys
+func (s *Service) RevokeAPIKey(ctx context.Context, req *proto.RevokeAPIKeyRequest) (*proto.AdminActionResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.RevokeAPIKey(ctx, userID, int(req.KeyId)); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to revoke API key: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("API key revoked", "audit", true, "username", owner.Username, "key_id", req.KeyId)
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Revoked API key %d", req.KeyId),
+	}, nil
+}
+
+// ValidateAPIKey returns the owner and scopes of an active API key. Keys of
+// inactive accounts stop working with the account.
+func (s *Service) ValidateAPIKey(ctx context.Context, req *proto.ValidateAPIKeyRequest) (*proto.ValidateAPIKeyResponse, error) {
+	if req.Key == "" {
+		return &proto.ValidateAPIKeyResponse{Valid: false, Error: "API key is required"}, nil
+	}
+
+	apiKey, err := s.userSvc.LookupAPIKey(ctx, req.Key)
+	if errors.Is(err, user.ErrAPIKeyNotFound) {
+		return &proto.ValidateAPIKeyResponse{Valid: false, Error: "Invalid API key"}, nil
+	}
+	if err != nil {
+		return &proto.ValidateAPIKeyResponse{
+			Valid: false,
+			Error: fmt.Sprintf("Failed to validate API key: %v", err),
+		}, nil
+	}
+
+	owner, err := s.userSvc.GetUserByID(ctx, apiKey.UserID)
+	if err != nil {
+		return &proto.ValidateAPIKeyResponse{Valid: false, Error: "User not found"}, nil
+	}
+	if !owner.IsActive {
+		return &proto.ValidateAPIKeyResponse{Valid: false, Error: "User account is inactive"}, nil
+	}
+
+	return &proto.ValidateAPIKeyResponse{
+		Valid:  true,
+		User:   s.convertUserToProto(ctx, owner),
+		ApiKey: convertAPIKeyToProto(apiKey),
+	}, nil
+}
+
+// convertAPIKeyToProto converts an API key to its proto form
+func convertAPIKeyToProto(key *user.APIKey) *proto.APIKey {
+	pbKey := &proto.APIKey{
+		Id:        int32(key.ID),
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		Scopes:    key.Scopes,
+		RateLimit: int32(key.RateLimit),
+		CreatedAt: timestampProto(key.CreatedAt),
+	}
+	if key.LastUsedAt != nil {
+		pbKey.LastUsedAt = timestampProto(*key.LastUsedAt)
+	}
+	if key.RevokedAt != nil {
+		pbKey.RevokedAt = timestampProto(*key.RevokedAt)
+	}
+	return pbKey
+}
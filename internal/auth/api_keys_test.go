@@ -0,0 +1,74 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_APIKeys(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	owner := registerTestUser(t, service, "scripter")
+	other := registerTestUser(t, service, "bystander")
+
+	invalid, err := service.CreateAPIKey(ctx, &proto.CreateAPIKeyRequest{
+		AccessToken: owner.AccessToken, Name: "bot", Scopes: []string{"games:delete"},
+	})
+	require.NoError(t, err)
+	assert.False(t, invalid.Success)
+
+	created, err := service.CreateAPIKey(ctx, &proto.CreateAPIKeyRequest{
+		AccessToken: owner.AccessToken,
+		Name:        "bot",
+		Scopes:      []string{user.ScopeGamesRead, user.ScopeDumplogsRead},
+		RateLimit:   30,
+	})
+	require.NoError(t, err)
+	require.True(t, created.Success, created.Error)
+	assert.True(t, strings.HasPrefix(created.Key, user.APIKeyPrefix))
+	assert.True(t, strings.HasPrefix(created.Key, created.ApiKey.Prefix))
+	assert.Equal(t, int32(30), created.ApiKey.RateLimit)
+
+	validated, err := service.ValidateAPIKey(ctx, &proto.ValidateAPIKeyRequest{Key: created.Key})
+	require.NoError(t, err)
+	require.True(t, validated.Valid, validated.Error)
+	assert.Equal(t, "scripter", validated.User.Username)
+	assert.Equal(t, []string{user.ScopeGamesRead, user.ScopeDumplogsRead}, validated.ApiKey.Scopes)
+	assert.NotNil(t, validated.ApiKey.LastUsedAt)
+
+	wrong, err := service.ValidateAPIKey(ctx, &proto.ValidateAPIKeyRequest{Key: created.Key + "0"})
+	require.NoError(t, err)
+	assert.False(t, wrong.Valid)
+
+	listed, err := service.ListAPIKeys(ctx, &proto.ListAPIKeysRequest{AccessToken: owner.AccessToken})
+	require.NoError(t, err)
+	require.True(t, listed.Success, listed.Error)
+	require.Len(t, listed.ApiKeys, 1)
+	assert.Equal(t, "bot", listed.ApiKeys[0].Name)
+
+	// Keys can only be revoked by their owner
+	stolen, err := service.RevokeAPIKey(ctx, &proto.RevokeAPIKeyRequest{AccessToken: other.AccessToken, KeyId: created.ApiKey.Id})
+	require.NoError(t, err)
+	assert.False(t, stolen.Success)
+
+	revoked, err := service.RevokeAPIKey(ctx, &proto.RevokeAPIKeyRequest{AccessToken: owner.AccessToken, KeyId: created.ApiKey.Id})
+	require.NoError(t, err)
+	require.True(t, revoked.Success, revoked.Error)
+
+	validated, err = service.ValidateAPIKey(ctx, &proto.ValidateAPIKeyRequest{Key: created.Key})
+	require.NoError(t, err)
+	assert.False(t, validated.Valid)
+
+	listed, err = service.ListAPIKeys(ctx, &proto.ListAPIKeysRequest{AccessToken: owner.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.ApiKeys, 1)
+	assert.NotNil(t, listed.ApiKeys[0].RevokedAt)
+}
@@ -0,0 +1,342 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/banner"
+	"google.golang.org/grpc/metadata"
+)
+
+// bannerHub fans banner changes out to the session services watching them
+type bannerHub struct {
+	mu       sync.Mutex
+	watchers map[*bannerWatcher]struct{}
+	closed   chan struct{}
+	once     sync.Once
+}
+
+// bannerWatcher holds the banner updates a watcher has not sent yet. A slow
+// watcher is not sent every update, only the latest of each banner.
+type bannerWatcher struct {
+	mu      sync.Mutex
+	pending []*proto.BannerUpdate
+	ready   chan struct{}
+}
+
+func newBannerHub() *bannerHub {
+	return &bannerHub{
+		watchers: make(map[*bannerWatcher]struct{}),
+		closed:   make(chan struct{}),
+	}
+}
+
+// close ends every watch stream
+func (h *bannerHub) close() {
+	h.once.Do(func() { close(h.closed) })
+}
+
+// subscribe registers a watcher; the returned function unregisters it
+func (h *bannerHub) subscribe() (*bannerWatcher, func()) {
+	watcher := &bannerWatcher{ready: make(chan struct{}, 1)}
+
+	h.mu.Lock()
+	h.watchers[watcher] = struct{}{}
+	h.mu.Unlock()
+
+	return watcher, func() {
+		h.mu.Lock()
+		delete(h.watchers, watcher)
+		h.mu.Unlock()
+	}
+}
+
+// publish queues an update for every watcher, returning how many there are
+func (h *bannerHub) publish(update *proto.BannerUpdate) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for watcher := range h.watchers {
+		watcher.add(update)
+	}
+	return len(h.watchers)
+}
+
+// add queues an update, replacing one for the same banner that was not sent
+func (w *bannerWatcher) add(update *proto.BannerUpdate) {
+	w.mu.Lock()
+	w.pending = slices.DeleteFunc(w.pending, func(pending *proto.BannerUpdate) bool {
+		return pending.Name == update.Name
+	})
+	w.pending = append(w.pending, update)
+	w.mu.Unlock()
+
+	select {
+	case w.ready <- struct{}{}:
+	default:
+	}
+}
+
+// take returns the queued updates in the order their banners last changed
+func (w *bannerWatcher) take() []*proto.BannerUpdate {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	updates := w.pending
+	w.pending = nil
+	return updates
+}
+
+// ListBanners lists the banner names and the templates overridden at runtime (admin only)
+func (s *Service) ListBanners(ctx context.Context, req *proto.BannerRequest) (*proto.ListBannersResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ListBannersResponse{Success: false, Error: errMsg}, err
+	}
+
+	templates, err := s.userSvc.ListBannerTemplates(ctx)
+	if err != nil {
+		return &proto.ListBannersResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list banners: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListBannersResponse{
+		Success: true,
+		Names:   banner.Names,
+	}
+	for _, template := range templates {
+		resp.Banners = append(resp.Banners, convertBannerTemplateToProto(template))
+	}
+
+	return resp, nil
+}
+
+// GetBanner returns the runtime template of a banner, if any (admin only)
+func (s *Service) GetBanner(ctx context.Context, req *proto.BannerRequest) (*proto.GetBannerResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.GetBannerResponse{Success: false, Error: errMsg}, err
+	}
+
+	if !banner.IsValidName(req.Name) {
+		return &proto.GetBannerResponse{Success: false, Error: fmt.Sprintf("Unknown banner '%s'", req.Name)}, nil
+	}
+
+	template, err := s.userSvc.GetBannerTemplate(ctx, req.Name)
+	if err != nil {
+		return &proto.GetBannerResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to get banner: %v", err),
+		}, nil
+	}
+
+	if template == nil {
+		return &proto.GetBannerResponse{Success: true, Overridden: false}, nil
+	}
+
+	return &proto.GetBannerResponse{
+		Success:    true,
+		Overridden: true,
+		Banner:     convertBannerTemplateToProto(template),
+	}, nil
+}
+
+// UpdateBanner validates and stores a banner template and pushes it to session services (admin only)
+func (s *Service) UpdateBanner(ctx context.Context, req *proto.UpdateBannerRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := banner.ValidateTemplate(req.Name, req.Content); err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	template, err := s.userSvc.SaveBannerTemplate(ctx, req.Name, req.Content, admin.Username)
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to update banner: %v", err),
+		}, nil
+	}
+
+	delivered := s.bannerWatchers.publish(&proto.BannerUpdate{
+		Name:      template.Name,
+		Content:   template.Content,
+		UpdatedAt: timestampProto(template.UpdatedAt),
+	})
+
+	s.logger.Info("Banner updated by admin",
+		"audit", true,
+		"admin_user", admin.Username,
+		"banner", req.Name,
+		"size", len(req.Content),
+		"session_services", delivered,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Banner '%s' updated and sent to %d session service(s)", req.Name, delivered),
+	}, nil
+}
+
+// ResetBanner removes a banner's runtime template so session services use their banner file again (admin only)
+func (s *Service) ResetBanner(ctx context.Context, req *proto.BannerRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if !banner.IsValidName(req.Name) {
+		return &proto.AdminActionResponse{Success: false, Error: fmt.Sprintf("Unknown banner '%s'", req.Name)}, nil
+	}
+
+	if err := s.userSvc.DeleteBannerTemplate(ctx, req.Name); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to reset banner: %v", err),
+		}, nil
+	}
+
+	delivered := s.bannerWatchers.publish(&proto.BannerUpdate{
+		Name:    req.Name,
+		Cleared: true,
+	})
+
+	s.logger.Info("Banner reset by admin",
+		"audit", true,
+		"admin_user", admin.Username,
+		"banner", req.Name,
+		"session_services", delivered,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Banner '%s' reset to its file on %d session service(s)", req.Name, delivered),
+	}, nil
+}
+
+// PreviewBanner validates and renders a banner template without storing it (admin only)
+func (s *Service) PreviewBanner(ctx context.Context, req *proto.PreviewBannerRequest) (*proto.PreviewBannerResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.PreviewBannerResponse{Success: false, Error: errMsg}, err
+	}
+
+	username := req.Username
+	if username == "" {
+		username = admin.Username
+	}
+
+	rendered, err := banner.Preview(req.Name, req.Content, username, !req.AsciiOnly)
+	if err != nil {
+		return &proto.PreviewBannerResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	return &proto.PreviewBannerResponse{Success: true, Rendered: rendered}, nil
+}
+
+// WatchBanners streams the stored banner templates followed by every change
+// made through this auth service instance
+func (s *Service) WatchBanners(req *proto.WatchBannersRequest, stream proto.AuthService_WatchBannersServer) error {
+	ctx := stream.Context()
+
+	// Subscribe before reading the snapshot so no change is missed in between
+	watcher, unsubscribe := s.bannerWatchers.subscribe()
+	defer unsubscribe()
+
+	// Headers tell the watcher it is subscribed and a snapshot follows
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	templates, err := s.userSvc.ListBannerTemplates(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list banner templates: %w", err)
+	}
+	for _, template := range templates {
+		if err := stream.Send(&proto.BannerUpdate{
+			Name:      template.Name,
+			Content:   template.Content,
+			UpdatedAt: timestampProto(template.UpdatedAt),
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.logger.Info("Session service watching banners", "instance_id", req.InstanceId, "templates", len(templates))
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info("Session service stopped watching banners", "instance_id", req.InstanceId)
+			return nil
+		case <-s.bannerWatchers.closed:
+			return nil
+		case <-watcher.ready:
+			for _, update := range watcher.take() {
+				if err := stream.Send(update); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// StopBannerWatches ends the WatchBanners streams so a graceful server stop
+// does not wait on them. Session services reconnect to another instance.
+func (s *Service) StopBannerWatches() {
+	s.bannerWatchers.close()
+}
+
+// authorizeAdmin validates a token and checks that its user is an admin. On
+// failure the user is nil and the message is suitable for the response.
+func (s *Service) authorizeAdmin(ctx context.Context, token string) (*user.User, string, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: token,
+	})
+	if err != nil {
+		return nil, "Failed to validate admin token", err
+	}
+
+	if !validateResp.Valid {
+		return nil, "Invalid admin token", nil
+	}
+
+	if isImpersonation(validateResp.User) {
+		return nil, errImpersonationReadOnly, nil
+	}
+
+	userIDInt, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return nil, "Invalid admin user ID", nil
+	}
+
+	admin, err := s.userSvc.GetUserByID(ctx, userIDInt)
+	if err != nil {
+		return nil, "Admin user not found", nil
+	}
+
+	if !admin.IsAdmin() {
+		return nil, "Insufficient privileges - admin access required", nil
+	}
+
+	return admin, "", nil
+}
+
+// convertBannerTemplateToProto converts a stored banner template to its proto form
+func convertBannerTemplateToProto(template *user.BannerTemplate) *proto.BannerTemplate {
+	return &proto.BannerTemplate{
+		Name:      template.Name,
+		Content:   template.Content,
+		UpdatedBy: template.UpdatedBy,
+		UpdatedAt: timestampProto(template.UpdatedAt),
+	}
+}
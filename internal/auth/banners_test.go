@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Banners_UpdateAndReset(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bannerboss")
+	player := registerTestUser(t, service, "player")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bannerboss"))
+
+	watcher, unsubscribe := service.bannerWatchers.subscribe()
+	defer unsubscribe()
+
+	denied, err := service.UpdateBanner(ctx, &proto.UpdateBannerRequest{
+		AdminToken: player.AccessToken,
+		Name:       "main_anon",
+		Content:    "Welcome to $SERVERID",
+	})
+	require.NoError(t, err)
+	assert.False(t, denied.Success)
+
+	invalid, err := service.UpdateBanner(ctx, &proto.UpdateBannerRequest{
+		AdminToken: admin.AccessToken,
+		Name:       "main_anon",
+		Content:    "Welcome $PLAYERNAME",
+	})
+	require.NoError(t, err)
+	assert.False(t, invalid.Success)
+	assert.Contains(t, invalid.Error, "$PLAYERNAME")
+
+	updated, err := service.UpdateBanner(ctx, &proto.UpdateBannerRequest{
+		AdminToken: admin.AccessToken,
+		Name:       "main_anon",
+		Content:    "Welcome to $SERVERID",
+	})
+	require.NoError(t, err)
+	require.True(t, updated.Success, updated.Error)
+
+	<-watcher.ready
+	updates := watcher.take()
+	require.Len(t, updates, 1)
+	assert.Equal(t, "main_anon", updates[0].Name)
+	assert.Equal(t, "Welcome to $SERVERID", updates[0].Content)
+
+	got, err := service.GetBanner(ctx, &proto.BannerRequest{AdminToken: admin.AccessToken, Name: "main_anon"})
+	require.NoError(t, err)
+	require.True(t, got.Overridden)
+	assert.Equal(t, "bannerboss", got.Banner.UpdatedBy)
+
+	reset, err := service.ResetBanner(ctx, &proto.BannerRequest{AdminToken: admin.AccessToken, Name: "main_anon"})
+	require.NoError(t, err)
+	require.True(t, reset.Success, reset.Error)
+	<-watcher.ready
+	updates = watcher.take()
+	require.Len(t, updates, 1)
+	assert.True(t, updates[0].Cleared)
+
+	got, err = service.GetBanner(ctx, &proto.BannerRequest{AdminToken: admin.AccessToken, Name: "main_anon"})
+	require.NoError(t, err)
+	assert.False(t, got.Overridden)
+}
+
+func TestBannerHub_CoalescesUpdates(t *testing.T) {
+	hub := newBannerHub()
+	watcher, unsubscribe := hub.subscribe()
+	defer unsubscribe()
+
+	// A watcher that falls behind is sent the latest update of each banner
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, 1, hub.publish(&proto.BannerUpdate{Name: "main_anon", Content: fmt.Sprintf("v%d", i)}))
+	}
+	hub.publish(&proto.BannerUpdate{Name: "motd", Content: "hello"})
+	hub.publish(&proto.BannerUpdate{Name: "main_anon", Cleared: true})
+
+	<-watcher.ready
+	updates := watcher.take()
+	require.Len(t, updates, 2)
+	assert.Equal(t, "motd", updates[0].Name)
+	assert.Equal(t, "main_anon", updates[1].Name)
+	assert.True(t, updates[1].Cleared)
+	assert.Empty(t, watcher.take())
+}
+
+func TestService_PreviewBanner(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bannerboss")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bannerboss"))
+
+	resp, err := service.PreviewBanner(ctx, &proto.PreviewBannerRequest{
+		AdminToken: admin.AccessToken,
+		Name:       "main_user",
+		Content:    "Hello $USERNAME\n→ play",
+		AsciiOnly:  true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, "Hello bannerboss\r\n-> play\r\n", resp.Rendered)
+}
@@ -0,0 +1,248 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// ListUsers lists the user accounts matching a filter (admin only)
+func (s *Service) ListUsers(ctx context.Context, req *proto.ListUsersRequest) (*proto.ListUsersResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ListUsersResponse{Success: false, Error: errMsg}, err
+	}
+
+	filter, err := userFilterFromProto(req.Filter)
+	if err != nil {
+		return &proto.ListUsersResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	users, total, err := s.userSvc.ListUsers(ctx, filter, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return &proto.ListUsersResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list users: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListUsersResponse{Success: true, TotalCount: int32(total)}
+	for _, u := range users {
+		resp.Users = append(resp.Users, convertUserSummaryToProto(u))
+	}
+	return resp, nil
+}
+
+// SetAccountsLocked locks or unlocks several user accounts (admin only)
+func (s *Service) SetAccountsLocked(ctx context.Context, req *proto.BulkLockRequest) (*proto.BulkAdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.BulkAdminActionResponse{Success: false, Error: errMsg}, err
+	}
+	if len(req.Usernames) == 0 {
+		return &proto.BulkAdminActionResponse{Success: false, Error: "No usernames given"}, nil
+	}
+
+	var until *time.Time
+	if req.Locked && req.LockedUntil != nil {
+		t := req.LockedUntil.AsTime()
+		if !t.After(time.Now()) {
+			return &proto.BulkAdminActionResponse{Success: false, Error: "Lock end must be in the future"}, nil
+		}
+		until = &t
+	}
+
+	resp := &proto.BulkAdminActionResponse{Success: true}
+	for _, username := range req.Usernames {
+		if req.Locked && username == admin.Username {
+			resp.Failures = append(resp.Failures, &proto.BulkActionFailure{
+				Username: username,
+				Error:    "cannot lock your own account",
+			})
+			continue
+		}
+		if err := s.userSvc.SetAccountLocked(ctx, username, req.Locked, until, admin.Username, req.Reason); err != nil {
+			resp.Failures = append(resp.Failures, &proto.BulkActionFailure{Username: username, Error: err.Error()})
+			continue
+		}
+		resp.Updated++
+	}
+
+	s.logger.Info("Admin changed account locks",
+		"audit", true,
+		"admin", admin.Username,
+		"locked", req.Locked,
+		"updated", resp.Updated,
+		"failed", len(resp.Failures),
+		"reason", req.Reason,
+	)
+	return resp, nil
+}
+
+// SetUsersRole grants or revokes a role on several user accounts (admin only)
+func (s *Service) SetUsersRole(ctx context.Context, req *proto.BulkRoleRequest) (*proto.BulkAdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.BulkAdminActionResponse{Success: false, Error: errMsg}, err
+	}
+	if len(req.Usernames) == 0 {
+		return &proto.BulkAdminActionResponse{Success: false, Error: "No usernames given"}, nil
+	}
+
+	role, err := user.ParseRole(req.Role)
+	if err != nil {
+		return &proto.BulkAdminActionResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	resp := &proto.BulkAdminActionResponse{Success: true}
+	for _, username := range req.Usernames {
+		if err := s.userSvc.SetUserRole(ctx, username, role, req.Grant, admin.Username); err != nil {
+			resp.Failures = append(resp.Failures, &proto.BulkActionFailure{Username: username, Error: err.Error()})
+			continue
+		}
+		resp.Updated++
+	}
+
+	s.logger.Info("Admin changed user roles",
+		"audit", true,
+		"admin", admin.Username,
+		"role", req.Role,
+		"grant", req.Grant,
+		"updated", resp.Updated,
+		"failed", len(resp.Failures),
+	)
+	return resp, nil
+}
+
+// ExportUsers writes the user accounts matching a filter as CSV (admin only)
+func (s *Service) ExportUsers(ctx context.Context, req *proto.ExportUsersRequest) (*proto.ExportUsersResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ExportUsersResponse{Success: false, Error: errMsg}, err
+	}
+
+	filter, err := userFilterFromProto(req.Filter)
+	if err != nil {
+		return &proto.ExportUsersResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	users, _, err := s.userSvc.ListUsers(ctx, filter, 0, 0)
+	if err != nil {
+		return &proto.ExportUsersResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list users: %v", err),
+		}, nil
+	}
+
+	var buf bytes.Buffer
+	if err := user.WriteUsersCSV(&buf, users, req.IncludePasswordHashes); err != nil {
+		return &proto.ExportUsersResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to export users: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Admin exported users",
+		"audit", true,
+		"admin", admin.Username,
+		"count", len(users),
+		"include_password_hashes", req.IncludePasswordHashes,
+	)
+	return &proto.ExportUsersResponse{Success: true, Csv: buf.Bytes(), Count: int32(len(users))}, nil
+}
+
+// ImportUsers creates user accounts from CSV (admin only)
+func (s *Service) ImportUsers(ctx context.Context, req *proto.ImportUsersRequest) (*proto.ImportUsersResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ImportUsersResponse{Success: false, Error: errMsg}, err
+	}
+
+	results, err := s.userSvc.ImportUsersCSV(ctx, bytes.NewReader(req.Csv), admin.Username, req.DryRun)
+	resp := &proto.ImportUsersResponse{Success: err == nil}
+	if err != nil {
+		resp.Error = fmt.Sprintf("Failed to import users: %v", err)
+	}
+	for _, result := range results {
+		imported := &proto.ImportedUser{
+			Line:              int32(result.Line),
+			Username:          result.Username,
+			TemporaryPassword: result.TemporaryPassword,
+		}
+		if result.Err != nil {
+			imported.Error = result.Err.Error()
+		} else if !req.DryRun {
+			resp.Created++
+		}
+		resp.Users = append(resp.Users, imported)
+	}
+
+	s.logger.Info("Admin imported users",
+		"audit", true,
+		"admin", admin.Username,
+		"created", resp.Created,
+		"rows", len(results),
+		"dry_run", req.DryRun,
+	)
+	return resp, nil
+}
+
+// userFilterFromProto converts a user filter; a nil filter matches everyone
+func userFilterFromProto(f *proto.UserFilter) (user.UserFilter, error) {
+	var filter user.UserFilter
+	if f == nil {
+		return filter, nil
+	}
+
+	if f.ModerationFlag != "" {
+		flag, err := user.ParseModerationFlag(f.ModerationFlag)
+		if err != nil {
+			return filter, err
+		}
+		filter.ModerationFlag = flag
+	}
+	if f.Role != "" {
+		role, err := user.ParseRole(f.Role)
+		if err != nil {
+			return filter, err
+		}
+		filter.Role = role
+	}
+	if f.CreatedAfter != nil {
+		filter.CreatedAfter = f.CreatedAfter.AsTime()
+	}
+	if f.CreatedBefore != nil {
+		filter.CreatedBefore = f.CreatedBefore.AsTime()
+	}
+	if f.LastLoginAfter != nil {
+		filter.LastLoginAfter = f.LastLoginAfter.AsTime()
+	}
+	if f.LastLoginBefore != nil {
+		filter.LastLoginBefore = f.LastLoginBefore.AsTime()
+	}
+	filter.LockedOnly = f.LockedOnly
+	return filter, nil
+}
+
+// convertUserSummaryToProto converts a user to its admin listing form
+func convertUserSummaryToProto(u *user.User) *proto.UserSummary {
+	summary := &proto.UserSummary{
+		Username:   u.Username,
+		Email:      u.Email,
+		Roles:      user.RoleNames(u.Flags),
+		CreatedAt:  timestampProto(u.CreatedAt),
+		LoginCount: int32(u.LoginCount),
+		Locked:     u.IsLocked(),
+	}
+	if u.LastLogin != nil {
+		summary.LastLogin = timestampProto(*u.LastLogin)
+	}
+	if summary.Locked && u.LockedUntil != nil {
+		summary.LockedUntil = timestampProto(*u.LockedUntil)
+	}
+	return summary
+}
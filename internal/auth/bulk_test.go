@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// usernamesOf returns the usernames of a user listing
+func usernamesOf(users []*proto.UserSummary) []string {
+	names := make([]string, 0, len(users))
+	for _, u := range users {
+		names = append(names, u.Username)
+	}
+	return names
+}
+
+func TestService_ListUsers_Filters(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	registerTestUser(t, service, "bob")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+	require.NoError(t, service.userSvc.SetModerationFlag(ctx, "bob", "warned", "bossman", "rude"))
+
+	resp, err := service.ListUsers(ctx, &proto.ListUsersRequest{AdminToken: admin.AccessToken})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Subset(t, usernamesOf(resp.Users), []string{"alice", "bob", "bossman"})
+
+	resp, err = service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{ModerationFlag: "warned"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob"}, usernamesOf(resp.Users))
+
+	resp, err = service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{Role: "admin"},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, usernamesOf(resp.Users), "bossman")
+	assert.NotContains(t, usernamesOf(resp.Users), "alice")
+
+	resp, err = service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{CreatedAfter: timestamppb.New(time.Now().Add(time.Hour))},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Users)
+	assert.Zero(t, resp.TotalCount)
+
+	resp, err = service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{Role: "wizard"},
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+}
+
+func TestService_ListUsers_RequiresAdmin(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	player := registerTestUser(t, service, "player")
+
+	resp, err := service.ListUsers(context.Background(), &proto.ListUsersRequest{AdminToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "admin access required")
+}
+
+func TestService_SetAccountsLocked(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	registerTestUser(t, service, "bob")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	resp, err := service.SetAccountsLocked(ctx, &proto.BulkLockRequest{
+		AdminToken: admin.AccessToken,
+		Usernames:  []string{"alice", "bob", "nobody", "bossman"},
+		Locked:     true,
+		Reason:     "botting",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, int32(2), resp.Updated)
+	require.Len(t, resp.Failures, 2)
+	assert.Equal(t, "nobody", resp.Failures[0].Username)
+	assert.Equal(t, "bossman", resp.Failures[1].Username)
+
+	// A lock without an end holds until an admin unlocks the account
+	login, err := service.Login(ctx, &proto.LoginRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	assert.False(t, login.Success)
+
+	listed, err := service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{LockedOnly: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, usernamesOf(listed.Users))
+	assert.Nil(t, listed.Users[0].LockedUntil)
+
+	resp, err = service.SetAccountsLocked(ctx, &proto.BulkLockRequest{
+		AdminToken: admin.AccessToken,
+		Usernames:  []string{"alice", "bob"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Updated)
+
+	login, err = service.Login(ctx, &proto.LoginRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	assert.True(t, login.Success, login.Error)
+}
+
+func TestService_SetUsersRole(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	resp, err := service.SetUsersRole(ctx, &proto.BulkRoleRequest{
+		AdminToken: admin.AccessToken,
+		Usernames:  []string{"alice"},
+		Role:       "moderator",
+		Grant:      true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, int32(1), resp.Updated)
+
+	alice, err := service.userSvc.GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.True(t, alice.IsModerator())
+	assert.False(t, alice.IsAdmin())
+
+	resp, err = service.SetUsersRole(ctx, &proto.BulkRoleRequest{
+		AdminToken: admin.AccessToken,
+		Usernames:  []string{"alice"},
+		Role:       "moderator",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), resp.Updated)
+	alice, err = service.userSvc.GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.False(t, alice.IsModerator())
+
+	// Revoking admin from everyone leaves the last admin in place
+	admins, err := service.ListUsers(ctx, &proto.ListUsersRequest{
+		AdminToken: admin.AccessToken,
+		Filter:     &proto.UserFilter{Role: "admin"},
+	})
+	require.NoError(t, err)
+	resp, err = service.SetUsersRole(ctx, &proto.BulkRoleRequest{
+		AdminToken: admin.AccessToken,
+		Usernames:  usernamesOf(admins.Users),
+		Role:       "admin",
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, resp.Failures)
+	assert.Contains(t, resp.Failures[len(resp.Failures)-1].Error, "last admin")
+}
+
+func TestService_ExportUsers(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	resp, err := service.ExportUsers(ctx, &proto.ExportUsersRequest{AdminToken: admin.AccessToken})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	lines := strings.Split(strings.TrimSpace(string(resp.Csv)), "\n")
+	assert.Equal(t, "username,email,roles,created_at,last_login,locked", lines[0])
+	assert.NotContains(t, string(resp.Csv), "password_hash")
+
+	alice, err := service.userSvc.GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.NotContains(t, string(resp.Csv), alice.PasswordHash)
+
+	resp, err = service.ExportUsers(ctx, &proto.ExportUsersRequest{
+		AdminToken:            admin.AccessToken,
+		Filter:                &proto.UserFilter{Role: "admin"},
+		IncludePasswordHashes: true,
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(resp.Csv), "password_hash,salt")
+	assert.NotContains(t, string(resp.Csv), "alice")
+}
+
+func TestService_ImportUsers(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	// Accounts exported with their hashes keep their passwords
+	exported, err := service.ExportUsers(ctx, &proto.ExportUsersRequest{
+		AdminToken:            admin.AccessToken,
+		Filter:                &proto.UserFilter{CreatedAfter: timestamppb.New(time.Now().Add(-time.Hour))},
+		IncludePasswordHashes: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, service.userSvc.DeleteUserAccount(ctx, "alice"))
+
+	csv := string(exported.Csv) +
+		"carol,carol@example.com,beta,,,false,,\n" +
+		"bad name,,,,,,,\n"
+	csv = strings.Replace(csv, "username,email,roles,created_at,last_login,locked,password_hash,salt",
+		"username,email,roles,created_at,last_login,locked,password_hash,salt,password", 1)
+	csv += "dave,,,,,,,,davepass1\n"
+
+	dry, err := service.ImportUsers(ctx, &proto.ImportUsersRequest{AdminToken: admin.AccessToken, Csv: []byte(csv), DryRun: true})
+	require.NoError(t, err)
+	require.True(t, dry.Success, dry.Error)
+	assert.Zero(t, dry.Created)
+	_, err = service.userSvc.GetUserByUsername(ctx, "carol")
+	assert.Error(t, err)
+
+	resp, err := service.ImportUsers(ctx, &proto.ImportUsersRequest{AdminToken: admin.AccessToken, Csv: []byte(csv)})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, int32(3), resp.Created)
+
+	results := make(map[string]*proto.ImportedUser)
+	for _, u := range resp.Users {
+		results[u.Username] = u
+	}
+	assert.Contains(t, results["bossman"].Error, "already exists")
+	assert.NotEmpty(t, results["bad name"].Error)
+	assert.Empty(t, results["alice"].TemporaryPassword)
+	assert.NotEmpty(t, results["carol"].TemporaryPassword)
+	assert.Empty(t, results["dave"].TemporaryPassword)
+
+	login, err := service.Login(ctx, &proto.LoginRequest{Username: "alice", Password: "testpass123"})
+	require.NoError(t, err)
+	assert.True(t, login.Success, login.Error)
+
+	carol, err := service.userSvc.GetUserByUsername(ctx, "carol")
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", carol.Email)
+	login, err = service.Login(ctx, &proto.LoginRequest{Username: "carol", Password: results["carol"].TemporaryPassword})
+	require.NoError(t, err)
+	assert.True(t, login.Success, login.Error)
+
+	login, err = service.Login(ctx, &proto.LoginRequest{Username: "dave", Password: "davepass1"})
+	require.NoError(t, err)
+	assert.True(t, login.Success, login.Error)
+}
@@ -0,0 +1,235 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+const (
+	// challengeTTL is how long a challenge may be answered
+	challengeTTL = 2 * time.Minute
+	// challengePassTTL is how long a passed challenge's token may be used to log in
+	challengePassTTL = 5 * time.Minute
+	// maxPendingChallenges bounds the challenges and passes held at once
+	maxPendingChallenges = 10000
+)
+
+// challengeWords are the words text challenges are made of
+var challengeWords = []string{
+	"amulet", "altar", "dragon", "fountain", "lantern", "potion",
+	"scroll", "throne", "wand", "unicorn", "gnome", "ration",
+}
+
+// numberWords spell the numbers math challenges may use
+var numberWords = []string{
+	"zero", "one", "two", "three", "four", "five", "six", "seven", "eight",
+	"nine", "ten", "eleven", "twelve",
+}
+
+// pendingChallenge is a challenge waiting for its answer
+type pendingChallenge struct {
+	answer    string
+	clientIP  string
+	username  string
+	expiresAt time.Time
+}
+
+// challengePass is a passed challenge, waiting to be used to log in
+type challengePass struct {
+	clientIP  string
+	username  string
+	expiresAt time.Time
+}
+
+// challengeStore holds the challenges issued to clients that tripped brute
+// force protection, so people sharing an address with an attacker, or whose
+// account an attacker locked out, can still log in. Challenges and passes
+// are only held in memory and each is used once.
+type challengeStore struct {
+	mu      sync.Mutex
+	pending map[string]*pendingChallenge
+	passes  map[string]*challengePass
+	now     func() time.Time
+}
+
+// newChallengeStore creates an empty challenge store
+func newChallengeStore() *challengeStore {
+	return &challengeStore{
+		pending: make(map[string]*pendingChallenge),
+		passes:  make(map[string]*challengePass),
+		now:     time.Now,
+	}
+}
+
+// issue creates a challenge and returns its ID and question
+func (c *challengeStore) issue(clientIP, username string) (string, string, time.Time, error) {
+	question, answer, err := newChallenge()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	id, err := randomChallengeID()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.now()
+	c.prune(now)
+	if len(c.pending)+len(c.passes) >= maxPendingChallenges {
+		return "", "", time.Time{}, fmt.Errorf("too many challenges pending")
+	}
+	expiresAt := now.Add(challengeTTL)
+	c.pending[id] = &pendingChallenge{answer: answer, clientIP: clientIP, username: username, expiresAt: expiresAt}
+	return id, question, expiresAt, nil
+}
+
+// answer checks the one answer a challenge takes, returning a pass token
+// when it is right
+func (c *challengeStore) answer(id, answer, clientIP string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	challenge, ok := c.pending[id]
+	if !ok {
+		return "", false
+	}
+	delete(c.pending, id)
+	now := c.now()
+	if now.After(challenge.expiresAt) || challenge.clientIP != clientIP ||
+		!strings.EqualFold(strings.TrimSpace(answer), challenge.answer) {
+		return "", false
+	}
+
+	token, err := randomChallengeID()
+	if err != nil {
+		return "", false
+	}
+	c.passes[token] = &challengePass{clientIP: clientIP, username: challenge.username, expiresAt: now.Add(challengePassTTL)}
+	return token, true
+}
+
+// redeem uses up a pass, reporting whether it lets username log in from
+// clientIP. Passes of challenges issued for no account let any account in.
+func (c *challengeStore) redeem(token, username, clientIP string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	pass, ok := c.passes[token]
+	if !ok {
+		return false
+	}
+	delete(c.passes, token)
+	return !c.now().After(pass.expiresAt) && pass.clientIP == clientIP &&
+		(pass.username == "" || pass.username == username)
+}
+
+// prune drops expired challenges and passes. Called with mu held.
+func (c *challengeStore) prune(now time.Time) {
+	for id, challenge := range c.pending {
+		if now.After(challenge.expiresAt) {
+			delete(c.pending, id)
+		}
+	}
+	for token, pass := range c.passes {
+		if now.After(pass.expiresAt) {
+			delete(c.passes, token)
+		}
+	}
+}
+
+// randomChallengeID returns an unguessable challenge ID or pass token
+func randomChallengeID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", fmt.Errorf("failed to generate challenge ID: %w", err)
+	}
+	return hex.EncodeToString(id), nil
+}
+
+// randomInt returns a random number in [0, n)
+func randomInt(n int) (int, error) {
+	v, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate challenge: %w", err)
+	}
+	return int(v.Int64()), nil
+}
+
+// newChallenge makes up a question a person answers at a glance: a sum with
+// a number spelled out, or a word to type backwards
+func newChallenge() (string, string, error) {
+	kind, err := randomInt(2)
+	if err != nil {
+		return "", "", err
+	}
+	if kind == 1 {
+		i, err := randomInt(len(challengeWords))
+		if err != nil {
+			return "", "", err
+		}
+		word := challengeWords[i]
+		reversed := []byte(word)
+		for l, r := 0, len(reversed)-1; l < r; l, r = l+1, r-1 {
+			reversed[l], reversed[r] = reversed[r], reversed[l]
+		}
+		return fmt.Sprintf("Type the word %q backwards:", strings.ToUpper(word)), string(reversed), nil
+	}
+
+	a, err := randomInt(len(numberWords))
+	if err != nil {
+		return "", "", err
+	}
+	b, err := randomInt(10)
+	if err != nil {
+		return "", "", err
+	}
+	b++
+	if a >= b {
+		return fmt.Sprintf("What is %s minus %d? Answer in digits:", numberWords[a], b), strconv.Itoa(a - b), nil
+	}
+	return fmt.Sprintf("What is %s plus %d? Answer in digits:", numberWords[a], b), strconv.Itoa(a + b), nil
+}
+
+// IssueChallenge poses a question for a client that tripped brute force
+// protection. The session service asks it over SSH keyboard-interactive
+// authentication.
+func (s *Service) IssueChallenge(ctx context.Context, req *proto.IssueChallengeRequest) (*proto.IssueChallengeResponse, error) {
+	if req.ClientIp == "" {
+		return &proto.IssueChallengeResponse{Success: false, Error: "Client IP is required"}, nil
+	}
+
+	id, question, expiresAt, err := s.challenges.issue(req.ClientIp, req.Username)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to issue challenge", "client_ip", req.ClientIp, "error", err)
+		return &proto.IssueChallengeResponse{Success: false, Error: "Failed to issue challenge"}, nil
+	}
+
+	return &proto.IssueChallengeResponse{
+		Success:     true,
+		ChallengeId: id,
+		Question:    question,
+		ExpiresAt:   expiresAt.Unix(),
+	}, nil
+}
+
+// AnswerChallenge checks the answer to a challenge. A right answer returns
+// a token that lets an account locked out after failed logins log in once,
+// with its password, from the address the challenge was issued to.
+func (s *Service) AnswerChallenge(ctx context.Context, req *proto.AnswerChallengeRequest) (*proto.AnswerChallengeResponse, error) {
+	token, passed := s.challenges.answer(req.ChallengeId, req.Answer, req.ClientIp)
+	s.logger.InfoContext(ctx, "Challenge answered", "client_ip", req.ClientIp, "passed", passed)
+	if !passed {
+		return &proto.AnswerChallengeResponse{Passed: false, Error: "Wrong answer"}, nil
+	}
+	return &proto.AnswerChallengeResponse{Passed: true, ChallengeToken: token}, nil
+}
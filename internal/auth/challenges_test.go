@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewChallenge(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		question, answer, err := newChallenge()
+		require.NoError(t, err)
+		assert.NotEmpty(t, question)
+		assert.NotEmpty(t, answer)
+		assert.NotContains(t, answer, "-", "math answers are never negative")
+	}
+}
+
+func TestChallengeStore(t *testing.T) {
+	store := newChallengeStore()
+
+	id, question, _, err := store.issue("192.0.2.7", "traveller")
+	require.NoError(t, err)
+	assert.NotEmpty(t, question)
+	answer := store.pending[id].answer
+
+	_, passed := store.answer(id, answer, "198.51.100.1")
+	assert.False(t, passed, "challenges are answered from the address they were issued to")
+	_, passed = store.answer(id, answer, "192.0.2.7")
+	assert.False(t, passed, "challenges take one answer")
+
+	id, _, _, err = store.issue("192.0.2.7", "traveller")
+	require.NoError(t, err)
+	token, passed := store.answer(id, " "+store.pending[id].answer+" ", "192.0.2.7")
+	require.True(t, passed)
+	assert.False(t, store.redeem(token, "stranger", "192.0.2.7"), "passes are bound to the account challenged")
+
+	id, _, _, err = store.issue("192.0.2.7", "")
+	require.NoError(t, err)
+	token, passed = store.answer(id, store.pending[id].answer, "192.0.2.7")
+	require.True(t, passed)
+	assert.True(t, store.redeem(token, "stranger", "192.0.2.7"))
+	assert.False(t, store.redeem(token, "stranger", "192.0.2.7"), "passes are used once")
+
+	// Expired challenges are not answered
+	id, _, _, err = store.issue("192.0.2.7", "")
+	require.NoError(t, err)
+	store.now = func() time.Time { return time.Now().Add(challengeTTL + time.Second) }
+	_, passed = store.answer(id, store.pending[id].answer, "192.0.2.7")
+	assert.False(t, passed)
+}
+
+func TestService_LoginPastLockoutWithChallenge(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	registerTestUser(t, service, "traveller")
+	login := func(password, challengeToken string) *proto.LoginResponse {
+		resp, err := service.Login(ctx, &proto.LoginRequest{
+			Username: "traveller", Password: password, ClientIp: "192.0.2.7", ChallengeToken: challengeToken,
+		})
+		require.NoError(t, err)
+		return resp
+	}
+
+	// An attacker guessing passwords locks the account out
+	var resp *proto.LoginResponse
+	for i := 0; i < 10; i++ {
+		if resp = login("wrongpass", ""); resp.ChallengeRequired {
+			break
+		}
+	}
+	require.True(t, resp.ChallengeRequired)
+	resp = login("testpass123", "")
+	assert.False(t, resp.Success)
+	assert.True(t, resp.ChallengeRequired)
+
+	// Its owner passes a challenge and logs in past the lockout
+	issued, err := service.IssueChallenge(ctx, &proto.IssueChallengeRequest{ClientIp: "192.0.2.7", Username: "traveller"})
+	require.NoError(t, err)
+	require.True(t, issued.Success, issued.Error)
+	assert.NotEmpty(t, issued.Question)
+
+	wrong, err := service.AnswerChallenge(ctx, &proto.AnswerChallengeRequest{ChallengeId: issued.ChallengeId, Answer: "not it", ClientIp: "192.0.2.7"})
+	require.NoError(t, err)
+	assert.False(t, wrong.Passed)
+
+	issued, err = service.IssueChallenge(ctx, &proto.IssueChallengeRequest{ClientIp: "192.0.2.7", Username: "traveller"})
+	require.NoError(t, err)
+	answered, err := service.AnswerChallenge(ctx, &proto.AnswerChallengeRequest{
+		ChallengeId: issued.ChallengeId, Answer: service.challenges.pending[issued.ChallengeId].answer, ClientIp: "192.0.2.7",
+	})
+	require.NoError(t, err)
+	require.True(t, answered.Passed)
+
+	resp = login("testpass123", answered.ChallengeToken)
+	assert.True(t, resp.Success, resp.Error)
+	assert.True(t, login("testpass123", "").Success, "logging in clears the lockout")
+}
@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// deviceClaim names the device a token was issued to, both in the token
+// and in the user metadata returned by ValidateToken
+const deviceClaim = "device_id"
+
+// recordDevice records the device a user logged in from and returns the
+// device claim for their tokens, "" when the login told of no device. The
+// user hears of logins from new devices through the notification center;
+// the first device recorded for them is taken as known. Failing to record
+// a device does not fail the login.
+func (s *Service) recordDevice(ctx context.Context, loggedIn *user.User, clientDevice *proto.ClientDevice, clientIP string) string {
+	if clientDevice == nil || (clientDevice.KeyFingerprint == "" && clientDevice.ClientVersion == "") {
+		return ""
+	}
+
+	device, created, err := s.userSvc.RecordDevice(ctx, loggedIn.ID, clientDevice.KeyFingerprint, clientDevice.ClientVersion, clientIP)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to record device", "username", loggedIn.Username, "error", err)
+		return ""
+	}
+	if !created {
+		return strconv.Itoa(device.ID)
+	}
+
+	s.logger.InfoContext(ctx, "Login from new device",
+		"audit", true,
+		"username", loggedIn.Username,
+		"device_id", device.ID,
+		"key_fingerprint", device.KeyFingerprint,
+		"client_version", device.ClientVersion,
+		"client_ip", clientIP,
+	)
+	devices, err := s.userSvc.ListDevices(ctx, loggedIn.ID)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to list devices", "username", loggedIn.Username, "error", err)
+	}
+	if len(devices) > 1 {
+		s.notify(ctx, loggedIn.Username, user.NotificationNewDevice, "Login from a new device", newDeviceNotice(device))
+	}
+	return strconv.Itoa(device.ID)
+}
+
+// newDeviceNotice tells a user what is known of a new device
+func newDeviceNotice(device *user.Device) string {
+	var notice strings.Builder
+	fmt.Fprintf(&notice, "Your account was logged in to from a device not seen before, at %s.\n", device.FirstSeenAt.UTC().Format(time.RFC1123))
+	if device.ClientVersion != "" {
+		fmt.Fprintf(&notice, "Client: %s\n", device.ClientVersion)
+	}
+	if device.KeyFingerprint != "" {
+		fmt.Fprintf(&notice, "Key: %s\n", device.KeyFingerprint)
+	}
+	if device.LastIP != "" {
+		fmt.Fprintf(&notice, "Address: %s\n", device.LastIP)
+	}
+	notice.WriteString("If this was not you, revoke the device under Profile > Devices and change your password.")
+	return notice.String()
+}
+
+// deviceRevoked reports whether the device a token was issued to has been
+// revoked. Tokens issued to no device are never revoked this way.
+func (s *Service) deviceRevoked(ctx context.Context, userID int, claims *proto.TokenClaims) bool {
+	claim := claims.Metadata[deviceClaim]
+	if claim == "" {
+		return false
+	}
+	deviceID, err := strconv.Atoi(claim)
+	if err != nil {
+		return true
+	}
+	device, err := s.userSvc.GetDevice(ctx, userID, deviceID)
+	return err != nil || device.RevokedAt != nil
+}
+
+// ListDevices lists the devices the token user has logged in from
+func (s *Service) ListDevices(ctx context.Context, req *proto.ListDevicesRequest) (*proto.ListDevicesResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if owner == nil {
+		return &proto.ListDevicesResponse{Success: false, Error: errMsg}, err
+	}
+
+	devices, err := s.userSvc.ListDevices(ctx, userID)
+	if err != nil {
+		return &proto.ListDevicesResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list devices: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListDevicesResponse{Success: true}
+	for _, device := range devices {
+		pbDevice := convertDeviceToProto(device)
+		pbDevice.Current = owner.Metadata[deviceClaim] == strconv.Itoa(device.ID)
+		resp.Devices = append(resp.Devices, pbDevice)
+	}
+	return resp, nil
+}
+
+// RevokeDevice revokes one of the token user's devices. The tokens issued
+// to it stop working, so it has to log in again, and as a new device.
+func (s *Service) RevokeDevice(ctx context.Context, req *proto.RevokeDeviceRequest) (*proto.AdminActionResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.RevokeDevice(ctx, userID, int(req.DeviceId)); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to revoke device: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Device revoked", "audit", true, "username", owner.Username, "device_id", req.DeviceId)
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Revoked device %d", req.DeviceId),
+	}, nil
+}
+
+// convertDeviceToProto converts a device to its proto form
+func convertDeviceToProto(device *user.Device) *proto.Device {
+	pbDevice := &proto.Device{
+		Id:             int32(device.ID),
+		KeyFingerprint: device.KeyFingerprint,
+		ClientVersion:  device.ClientVersion,
+		LastIp:         device.LastIP,
+		FirstSeenAt:    timestampProto(device.FirstSeenAt),
+		LastSeenAt:     timestampProto(device.LastSeenAt),
+	}
+	if device.RevokedAt != nil {
+		pbDevice.RevokedAt = timestampProto(*device.RevokedAt)
+	}
+	return pbDevice
+}
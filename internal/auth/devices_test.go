@@ -0,0 +1,95 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Devices(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	registerTestUser(t, service, "traveller")
+	login := func(device *proto.ClientDevice) *proto.LoginResponse {
+		resp, err := service.Login(ctx, &proto.LoginRequest{
+			Username: "traveller", Password: "testpass123", ClientIp: "192.0.2.7", Device: device,
+		})
+		require.NoError(t, err)
+		require.True(t, resp.Success, resp.Error)
+		return resp
+	}
+	newDeviceNotices := func(token string) int {
+		listed, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: token})
+		require.NoError(t, err)
+		count := 0
+		for _, notification := range listed.Notifications {
+			if notification.Kind == user.NotificationNewDevice {
+				count++
+			}
+		}
+		return count
+	}
+
+	laptop := &proto.ClientDevice{KeyFingerprint: "SHA256:laptopkey", ClientVersion: "SSH-2.0-OpenSSH_9.6"}
+	first := login(laptop)
+	assert.Zero(t, newDeviceNotices(first.AccessToken), "the first device is taken as known")
+
+	// A client upgrade keeps the device its key names
+	laptop.ClientVersion = "SSH-2.0-OpenSSH_9.7"
+	again := login(laptop)
+	assert.Zero(t, newDeviceNotices(again.AccessToken))
+
+	phone := login(&proto.ClientDevice{ClientVersion: "SSH-2.0-PuTTY_Release_0.81"})
+	assert.Equal(t, 1, newDeviceNotices(phone.AccessToken))
+
+	listed, err := service.ListDevices(ctx, &proto.ListDevicesRequest{AccessToken: phone.AccessToken})
+	require.NoError(t, err)
+	require.True(t, listed.Success, listed.Error)
+	require.Len(t, listed.Devices, 2)
+	assert.Equal(t, "SSH-2.0-PuTTY_Release_0.81", listed.Devices[0].ClientVersion)
+	assert.True(t, listed.Devices[0].Current)
+	assert.Equal(t, "SSH-2.0-OpenSSH_9.7", listed.Devices[1].ClientVersion)
+	assert.Equal(t, "192.0.2.7", listed.Devices[1].LastIp)
+	assert.False(t, listed.Devices[1].Current)
+
+	// Revoking a device logs it out
+	other := registerTestUser(t, service, "stranger")
+	stolen, err := service.RevokeDevice(ctx, &proto.RevokeDeviceRequest{AccessToken: other.AccessToken, DeviceId: listed.Devices[0].Id})
+	require.NoError(t, err)
+	assert.False(t, stolen.Success)
+
+	revoked, err := service.RevokeDevice(ctx, &proto.RevokeDeviceRequest{AccessToken: first.AccessToken, DeviceId: listed.Devices[0].Id})
+	require.NoError(t, err)
+	require.True(t, revoked.Success, revoked.Error)
+
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: phone.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, validated.Valid)
+	refreshed, err := service.RefreshToken(ctx, &proto.RefreshTokenRequest{RefreshToken: phone.RefreshToken})
+	require.NoError(t, err)
+	assert.False(t, refreshed.Success)
+
+	validated, err = service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: first.AccessToken})
+	require.NoError(t, err)
+	assert.True(t, validated.Valid, "other devices stay logged in")
+
+	// Logging in again from a revoked device makes it a new one
+	returned := login(&proto.ClientDevice{ClientVersion: "SSH-2.0-PuTTY_Release_0.81"})
+	assert.Equal(t, 2, newDeviceNotices(returned.AccessToken))
+	listed, err = service.ListDevices(ctx, &proto.ListDevicesRequest{AccessToken: returned.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.Devices, 3)
+
+	// Logins telling of no device are not tied to one
+	bare := login(nil)
+	validated, err = service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: bare.AccessToken})
+	require.NoError(t, err)
+	assert.True(t, validated.Valid)
+	assert.Empty(t, validated.User.Metadata[deviceClaim])
+}
@@ -0,0 +1,185 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// flagStore reads the feature flags set at runtime from the user database
+type flagStore struct {
+	userSvc *user.Service
+}
+
+// FeatureFlagOverrides returns the stored feature flags, by name
+func (s flagStore) FeatureFlagOverrides(ctx context.Context) (map[string]*config.FeatureFlag, error) {
+	stored, err := s.userSvc.ListFeatureFlags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	overrides := make(map[string]*config.FeatureFlag, len(stored))
+	for _, flag := range stored {
+		overrides[flag.Name] = &flag.FeatureFlag
+	}
+	return overrides, nil
+}
+
+// SetFeatureFlags sets the feature flags configured for this auth service;
+// those stored at runtime replace them
+func (s *Service) SetFeatureFlags(cfg *config.FeatureFlagsConfig) {
+	s.flags = featureflags.New(cfg, flagStore{userSvc: s.userSvc}, s.logger)
+}
+
+// RunFeatureFlags rereads the stored feature flags, which other auth service
+// replicas may change, until ctx is done
+func (s *Service) RunFeatureFlags(ctx context.Context) {
+	s.flags.Run(ctx)
+}
+
+// ListFeatureFlags lists the feature flags this auth service evaluates, and
+// which are on for a user (admin only)
+func (s *Service) ListFeatureFlags(ctx context.Context, req *proto.FeatureFlagRequest) (*proto.ListFeatureFlagsResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ListFeatureFlagsResponse{Success: false, Error: errMsg}, err
+	}
+
+	// Show changes made through other replicas right away
+	if err := s.flags.Refresh(ctx); err != nil {
+		return &proto.ListFeatureFlagsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list feature flags: %v", err),
+		}, nil
+	}
+
+	stored, err := s.userSvc.ListFeatureFlags(ctx)
+	if err != nil {
+		return &proto.ListFeatureFlagsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list feature flags: %v", err),
+		}, nil
+	}
+	updates := make(map[string]*user.FeatureFlag, len(stored))
+	for _, flag := range stored {
+		updates[flag.Name] = flag
+	}
+
+	resp := &proto.ListFeatureFlagsResponse{Success: true}
+	for _, flag := range s.flags.List() {
+		pbFlag := featureflags.ToProto(flag)
+		if update := updates[flag.Name]; flag.Overridden && update != nil {
+			pbFlag.UpdatedBy = update.UpdatedBy
+			pbFlag.UpdatedAt = timestampProto(update.UpdatedAt)
+		}
+		resp.Flags = append(resp.Flags, pbFlag)
+	}
+
+	if req.Username != "" {
+		userObj, err := s.userSvc.GetUserByUsername(ctx, req.Username)
+		if err != nil {
+			return &proto.ListFeatureFlagsResponse{Success: false, Error: fmt.Sprintf("User '%s' not found", req.Username)}, nil
+		}
+		subject := featureflags.SubjectOf(s.convertUserToProto(ctx, userObj))
+		resp.EnabledForUser = s.flags.EnabledFor(subject)
+	}
+
+	return resp, nil
+}
+
+// SetFeatureFlag stores a feature flag, replacing any configured flag of its
+// name in every service once they reread the stored flags (admin only)
+func (s *Service) SetFeatureFlag(ctx context.Context, req *proto.SetFeatureFlagRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if req.Flag == nil {
+		return &proto.AdminActionResponse{Success: false, Error: "Feature flag is required"}, nil
+	}
+	flag := featureflags.FromProto(req.Flag)
+	if err := config.ValidateFeatureFlag(req.Flag.Name, flag); err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	if err := s.userSvc.SaveFeatureFlag(ctx, req.Flag.Name, flag, admin.Username); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to set feature flag: %v", err),
+		}, nil
+	}
+	if err := s.flags.Refresh(ctx); err != nil {
+		s.logger.WarnContext(ctx, "Failed to reread feature flags", "error", err)
+	}
+
+	s.logger.Info("Feature flag set by admin",
+		"audit", true,
+		"admin_user", admin.Username,
+		"flag", req.Flag.Name,
+		"enabled", flag.Enabled,
+		"users", flag.Users,
+		"roles", flag.Roles,
+		"percentage", flag.Percentage,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Feature flag '%s' set; services apply it within their refresh interval", req.Flag.Name),
+	}, nil
+}
+
+// DeleteFeatureFlag removes a stored feature flag so services use their
+// configured flag again (admin only)
+func (s *Service) DeleteFeatureFlag(ctx context.Context, req *proto.FeatureFlagRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.DeleteFeatureFlag(ctx, req.Name); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to delete feature flag: %v", err),
+		}, nil
+	}
+	if err := s.flags.Refresh(ctx); err != nil {
+		s.logger.WarnContext(ctx, "Failed to reread feature flags", "error", err)
+	}
+
+	s.logger.Info("Feature flag deleted by admin",
+		"audit", true,
+		"admin_user", admin.Username,
+		"flag", req.Name,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Feature flag '%s' deleted; services return to its configured flag within their refresh interval", req.Name),
+	}, nil
+}
+
+// GetFeatureFlagOverrides returns the stored feature flags, which session and
+// game services reread periodically
+func (s *Service) GetFeatureFlagOverrides(ctx context.Context, req *emptypb.Empty) (*proto.FeatureFlagOverridesResponse, error) {
+	stored, err := s.userSvc.ListFeatureFlags(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	resp := &proto.FeatureFlagOverridesResponse{}
+	for _, flag := range stored {
+		resp.Flags = append(resp.Flags, featureflags.ToProto(featureflags.Flag{
+			Name:        flag.Name,
+			FeatureFlag: flag.FeatureFlag,
+			Overridden:  true,
+		}))
+	}
+	return resp, nil
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestService_FeatureFlags_SetAndDelete(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "flagboss")
+	player := registerTestUser(t, service, "player")
+	registerTestUser(t, service, "tester")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "flagboss"))
+	require.NoError(t, service.userSvc.SetUserRole(ctx, "tester", user.UserFlagBeta, true, "flagboss"))
+
+	service.SetFeatureFlags(&config.FeatureFlagsConfig{Flags: map[string]*config.FeatureFlag{
+		"new_menu_engine":       {Description: "New menus", Roles: []string{"admin"}},
+		"multiplexed_streaming": {Enabled: true},
+	}})
+
+	denied, err := service.SetFeatureFlag(ctx, &proto.SetFeatureFlagRequest{
+		AdminToken: player.AccessToken,
+		Flag:       &proto.FeatureFlag{Name: "new_menu_engine", Enabled: true},
+	})
+	require.NoError(t, err)
+	assert.False(t, denied.Success)
+
+	invalid, err := service.SetFeatureFlag(ctx, &proto.SetFeatureFlagRequest{
+		AdminToken: admin.AccessToken,
+		Flag:       &proto.FeatureFlag{Name: "new_menu_engine", Percentage: 150},
+	})
+	require.NoError(t, err)
+	assert.False(t, invalid.Success)
+	assert.Contains(t, invalid.Error, "percentage")
+
+	// The stored flag replaces the configured one
+	set, err := service.SetFeatureFlag(ctx, &proto.SetFeatureFlagRequest{
+		AdminToken: admin.AccessToken,
+		Flag:       &proto.FeatureFlag{Name: "new_menu_engine", Roles: []string{"beta"}, Users: []string{"player"}},
+	})
+	require.NoError(t, err)
+	require.True(t, set.Success, set.Error)
+
+	list, err := service.ListFeatureFlags(ctx, &proto.FeatureFlagRequest{AdminToken: admin.AccessToken, Username: "tester"})
+	require.NoError(t, err)
+	require.True(t, list.Success, list.Error)
+	require.Len(t, list.Flags, 2)
+	assert.Equal(t, "multiplexed_streaming", list.Flags[0].Name)
+	assert.False(t, list.Flags[0].Overridden)
+	assert.Equal(t, "new_menu_engine", list.Flags[1].Name)
+	assert.True(t, list.Flags[1].Overridden)
+	assert.Equal(t, "flagboss", list.Flags[1].UpdatedBy)
+	assert.Equal(t, []string{"multiplexed_streaming", "new_menu_engine"}, list.EnabledForUser)
+
+	adminFlags, err := service.ListFeatureFlags(ctx, &proto.FeatureFlagRequest{AdminToken: admin.AccessToken, Username: "flagboss"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"multiplexed_streaming"}, adminFlags.EnabledForUser)
+
+	overrides, err := service.GetFeatureFlagOverrides(ctx, &emptypb.Empty{})
+	require.NoError(t, err)
+	require.Len(t, overrides.Flags, 1)
+	assert.Equal(t, []string{"player"}, overrides.Flags[0].Users)
+	assert.Equal(t, []string{"beta"}, overrides.Flags[0].Roles)
+
+	// Deleting the stored flag returns to the configured one
+	deleted, err := service.DeleteFeatureFlag(ctx, &proto.FeatureFlagRequest{AdminToken: admin.AccessToken, Name: "new_menu_engine"})
+	require.NoError(t, err)
+	require.True(t, deleted.Success, deleted.Error)
+
+	adminFlags, err = service.ListFeatureFlags(ctx, &proto.FeatureFlagRequest{AdminToken: admin.AccessToken, Username: "flagboss"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"multiplexed_streaming", "new_menu_engine"}, adminFlags.EnabledForUser)
+
+	again, err := service.DeleteFeatureFlag(ctx, &proto.FeatureFlagRequest{AdminToken: admin.AccessToken, Name: "new_menu_engine"})
+	require.NoError(t, err)
+	assert.False(t, again.Success)
+}
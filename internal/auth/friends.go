@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// AddFriend puts a user on the token user's friend list
+func (s *Service) AddFriend(ctx context.Context, req *proto.FriendRequest) (*proto.FriendsResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.FriendsResponse{Success: false, Error: errMsg}, err
+	}
+
+	friend, err := s.userSvc.AddFriend(ctx, userID, req.Username)
+	if err != nil {
+		return &proto.FriendsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to add friend: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Friend added", "username", owner.Username, "friend", friend.Username)
+	return s.friendsResponse(ctx, userID)
+}
+
+// RemoveFriend takes a user off the token user's friend list
+func (s *Service) RemoveFriend(ctx context.Context, req *proto.FriendRequest) (*proto.FriendsResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.FriendsResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.RemoveFriend(ctx, userID, req.Username); err != nil {
+		msg := fmt.Sprintf("Failed to remove friend: %v", err)
+		if errors.Is(err, user.ErrNotFriends) {
+			msg = fmt.Sprintf("%s is not on your friend list", req.Username)
+		}
+		return &proto.FriendsResponse{Success: false, Error: msg}, nil
+	}
+
+	s.logger.Info("Friend removed", "username", owner.Username, "friend", req.Username)
+	return s.friendsResponse(ctx, userID)
+}
+
+// ListFriends lists the token user's friends
+func (s *Service) ListFriends(ctx context.Context, req *proto.FriendRequest) (*proto.FriendsResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if owner == nil {
+		return &proto.FriendsResponse{Success: false, Error: errMsg}, err
+	}
+
+	return s.friendsResponse(ctx, userID)
+}
+
+// NotifyFriends leaves a notification for the users who have a player as a
+// friend and turned friend notifications on, on behalf of another service
+// such as the game service reporting a started game. Players who hid their
+// online status are not announced.
+func (s *Service) NotifyFriends(ctx context.Context, req *proto.NotifyUserRequest) (*proto.AdminActionResponse, error) {
+	player, err := s.userSvc.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: "User not found"}, nil
+	}
+	profile, err := s.userSvc.GetUserProfile(ctx, player.ID)
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to notify friends: %v", err),
+		}, nil
+	}
+	if !profile.ShowOnlineStatus {
+		return &proto.AdminActionResponse{
+			Success: true,
+			Message: fmt.Sprintf("%s is hidden; no friends notified", player.Username),
+		}, nil
+	}
+	sent, err := s.userSvc.NotifyFriendsOf(ctx, player.ID, req.Kind, req.Title, req.Body)
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to notify friends: %v", err),
+		}, nil
+	}
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Notified %d friends of %s", sent, player.Username),
+	}, nil
+}
+
+// friendsResponse returns the user's friend list
+func (s *Service) friendsResponse(ctx context.Context, userID int) (*proto.FriendsResponse, error) {
+	friends, err := s.userSvc.ListFriends(ctx, userID)
+	if err != nil {
+		return &proto.FriendsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list friends: %v", err),
+		}, nil
+	}
+
+	resp := &proto.FriendsResponse{Success: true}
+	for _, friend := range friends {
+		resp.Friends = append(resp.Friends, &proto.Friend{
+			Username: friend.Username,
+			AddedAt:  timestampProto(friend.AddedAt),
+		})
+	}
+	return resp, nil
+}
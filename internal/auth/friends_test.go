@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Friends(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	fan := registerTestUser(t, service, "fan")
+	quiet := registerTestUser(t, service, "quiet")
+	hero := registerTestUser(t, service, "hero")
+
+	for _, token := range []string{fan.AccessToken, quiet.AccessToken} {
+		added, err := service.AddFriend(ctx, &proto.FriendRequest{AccessToken: token, Username: "hero"})
+		require.NoError(t, err)
+		require.True(t, added.Success, added.Error)
+		require.Len(t, added.Friends, 1)
+		assert.Equal(t, "hero", added.Friends[0].Username)
+	}
+
+	again, err := service.AddFriend(ctx, &proto.FriendRequest{AccessToken: fan.AccessToken, Username: "hero"})
+	require.NoError(t, err)
+	assert.False(t, again.Success, "a friend is only listed once")
+	self, err := service.AddFriend(ctx, &proto.FriendRequest{AccessToken: fan.AccessToken, Username: "fan"})
+	require.NoError(t, err)
+	assert.False(t, self.Success)
+	missing, err := service.AddFriend(ctx, &proto.FriendRequest{AccessToken: fan.AccessToken, Username: "nobody"})
+	require.NoError(t, err)
+	assert.False(t, missing.Success)
+
+	// Only friends who opted in are told the player started a game
+	optIn, err := service.SetUserPreference(ctx, &proto.SetUserPreferenceRequest{
+		AccessToken: fan.AccessToken,
+		Key:         user.FriendNotificationsPreference,
+		Value:       "true",
+	})
+	require.NoError(t, err)
+	require.True(t, optIn.Success, optIn.Error)
+
+	notified, err := service.NotifyFriends(ctx, &proto.NotifyUserRequest{
+		Username: "hero",
+		Kind:     user.NotificationFriendPlaying,
+		Title:    "Your friend hero just started NetHack",
+	})
+	require.NoError(t, err)
+	require.True(t, notified.Success, notified.Error)
+	assert.Equal(t, "Notified 1 friends of hero", notified.Message)
+
+	// Nor are they told about a player who hid their online status
+	hidden, err := service.SetShowOnlineStatus(ctx, &proto.SetShowOnlineStatusRequest{
+		AccessToken: hero.AccessToken,
+		Show:        false,
+	})
+	require.NoError(t, err)
+	require.True(t, hidden.Success, hidden.Error)
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: hero.AccessToken})
+	require.NoError(t, err)
+	assert.Equal(t, "false", validated.User.Metadata["show_online_status"])
+	notified, err = service.NotifyFriends(ctx, &proto.NotifyUserRequest{
+		Username: "hero",
+		Kind:     user.NotificationFriendPlaying,
+		Title:    "Your friend hero just started NetHack",
+	})
+	require.NoError(t, err)
+	require.True(t, notified.Success, notified.Error)
+
+	fanNotes, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: fan.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, fanNotes.Notifications, 1)
+	assert.Equal(t, user.NotificationFriendPlaying, fanNotes.Notifications[0].Kind)
+	quietNotes, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: quiet.AccessToken})
+	require.NoError(t, err)
+	assert.Empty(t, quietNotes.Notifications)
+
+	removed, err := service.RemoveFriend(ctx, &proto.FriendRequest{AccessToken: fan.AccessToken, Username: "hero"})
+	require.NoError(t, err)
+	require.True(t, removed.Success, removed.Error)
+	assert.Empty(t, removed.Friends)
+	removedAgain, err := service.RemoveFriend(ctx, &proto.FriendRequest{AccessToken: fan.AccessToken, Username: "hero"})
+	require.NoError(t, err)
+	assert.False(t, removedAgain.Success)
+
+	listed, err := service.ListFriends(ctx, &proto.FriendRequest{AccessToken: quiet.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.Friends, 1)
+	assert.Equal(t, "hero", listed.Friends[0].Username)
+}
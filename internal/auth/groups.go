@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// CreateGroup creates a group owned by the token user
+func (s *Service) CreateGroup(ctx context.Context, req *proto.CreateGroupRequest) (*proto.GroupResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if member == nil {
+		return &proto.GroupResponse{Success: false, Error: errMsg}, err
+	}
+
+	group, err := s.userSvc.CreateGroup(ctx, userID, req.Name, req.Description)
+	if err != nil {
+		return &proto.GroupResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to create group: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Group created", "group", group.Name, "owner", member.Username)
+	return s.groupResponse(ctx, group)
+}
+
+// JoinGroup adds the token user to a group
+func (s *Service) JoinGroup(ctx context.Context, req *proto.GroupRequest) (*proto.GroupResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if member == nil {
+		return &proto.GroupResponse{Success: false, Error: errMsg}, err
+	}
+
+	group, err := s.userSvc.JoinGroup(ctx, userID, req.Name)
+	if err != nil {
+		return &proto.GroupResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to join group: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("User joined group", "group", group.Name, "username", member.Username)
+	return s.groupResponse(ctx, group)
+}
+
+// LeaveGroup removes the token user from their group
+func (s *Service) LeaveGroup(ctx context.Context, req *proto.GroupRequest) (*proto.GroupResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if member == nil {
+		return &proto.GroupResponse{Success: false, Error: errMsg}, err
+	}
+
+	group, err := s.userSvc.LeaveGroup(ctx, userID)
+	if err != nil {
+		return &proto.GroupResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to leave group: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("User left group", "group", group.Name, "username", member.Username)
+	return &proto.GroupResponse{
+		Success: true,
+		Group:   convertGroupToProto(group, nil),
+	}, nil
+}
+
+// GetGroup returns a group and its roster; without a name, the token user's group
+func (s *Service) GetGroup(ctx context.Context, req *proto.GroupRequest) (*proto.GroupResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if member == nil {
+		return &proto.GroupResponse{Success: false, Error: errMsg}, err
+	}
+
+	var group *user.Group
+	if req.Name == "" {
+		group, err = s.userSvc.GetUserGroup(ctx, userID)
+	} else {
+		group, err = s.userSvc.GetGroup(ctx, req.Name)
+	}
+	if errors.Is(err, user.ErrNotInGroup) {
+		return &proto.GroupResponse{Success: true}, nil
+	}
+	if err != nil {
+		return &proto.GroupResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+
+	return s.groupResponse(ctx, group)
+}
+
+// ListGroups lists every group, largest first
+func (s *Service) ListGroups(ctx context.Context, req *proto.GroupRequest) (*proto.ListGroupsResponse, error) {
+	member, _, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if member == nil {
+		return &proto.ListGroupsResponse{Success: false, Error: errMsg}, err
+	}
+
+	groups, err := s.userSvc.ListGroups(ctx)
+	if err != nil {
+		return &proto.ListGroupsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list groups: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListGroupsResponse{Success: true}
+	for _, group := range groups {
+		resp.Groups = append(resp.Groups, convertGroupToProto(group, nil))
+	}
+	return resp, nil
+}
+
+// groupResponse returns a group together with its roster
+func (s *Service) groupResponse(ctx context.Context, group *user.Group) (*proto.GroupResponse, error) {
+	members, err := s.userSvc.ListGroupMembers(ctx, group.ID)
+	if err != nil {
+		return &proto.GroupResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load group members: %v", err),
+		}, nil
+	}
+	return &proto.GroupResponse{
+		Success: true,
+		Group:   convertGroupToProto(group, members),
+	}, nil
+}
+
+// authorizeUser validates a user's own token for an operation. Changes are
+// refused to impersonation tokens. On failure the user is nil and the message
+// is suitable for the response.
+func (s *Service) authorizeUser(ctx context.Context, token string, change bool) (*proto.User, int, string, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: token,
+	})
+	if err != nil {
+		return nil, 0, "Failed to validate token", err
+	}
+
+	if !validateResp.Valid {
+		return nil, 0, validateResp.Error, nil
+	}
+
+	if change && isImpersonation(validateResp.User) {
+		return nil, 0, errImpersonationReadOnly, nil
+	}
+
+	userID, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return nil, 0, "Invalid user ID", nil
+	}
+
+	return validateResp.User, userID, "", nil
+}
+
+// convertGroupToProto converts a group and, when given, its members to their proto form
+func convertGroupToProto(group *user.Group, members []*user.GroupMember) *proto.Group {
+	pbGroup := &proto.Group{
+		Name:        group.Name,
+		Description: group.Description,
+		Owner:       group.Owner,
+		MemberCount: int32(group.MemberCount),
+		CreatedAt:   timestampProto(group.CreatedAt),
+	}
+	for _, member := range members {
+		pbGroup.Members = append(pbGroup.Members, &proto.GroupMember{
+			UserId:   strconv.Itoa(member.UserID),
+			Username: member.Username,
+			JoinedAt: timestampProto(member.JoinedAt),
+		})
+	}
+	return pbGroup
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Groups(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	founder := registerTestUser(t, service, "founder")
+	recruit := registerTestUser(t, service, "recruit")
+
+	created, err := service.CreateGroup(ctx, &proto.CreateGroupRequest{
+		AccessToken: founder.AccessToken,
+		Name:        "Valkyries",
+		Description: "Ascension or bust",
+	})
+	require.NoError(t, err)
+	require.True(t, created.Success, created.Error)
+	assert.Equal(t, "founder", created.Group.Owner)
+	require.Len(t, created.Group.Members, 1)
+
+	// Names are unique regardless of case
+	dup, err := service.CreateGroup(ctx, &proto.CreateGroupRequest{AccessToken: recruit.AccessToken, Name: "valkyries"})
+	require.NoError(t, err)
+	assert.False(t, dup.Success)
+
+	joined, err := service.JoinGroup(ctx, &proto.GroupRequest{AccessToken: recruit.AccessToken, Name: "valkyries"})
+	require.NoError(t, err)
+	require.True(t, joined.Success, joined.Error)
+	assert.Equal(t, int32(2), joined.Group.MemberCount)
+	require.Len(t, joined.Group.Members, 2)
+	assert.Equal(t, "recruit", joined.Group.Members[1].Username)
+
+	// One group at a time
+	second, err := service.CreateGroup(ctx, &proto.CreateGroupRequest{AccessToken: recruit.AccessToken, Name: "Samurai"})
+	require.NoError(t, err)
+	assert.False(t, second.Success)
+
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: recruit.AccessToken})
+	require.NoError(t, err)
+	assert.Equal(t, "Valkyries", validated.User.Metadata["group"])
+
+	// The owner leaving hands the group over
+	left, err := service.LeaveGroup(ctx, &proto.GroupRequest{AccessToken: founder.AccessToken})
+	require.NoError(t, err)
+	require.True(t, left.Success, left.Error)
+	mine, err := service.GetGroup(ctx, &proto.GroupRequest{AccessToken: recruit.AccessToken})
+	require.NoError(t, err)
+	require.NotNil(t, mine.Group)
+	assert.Equal(t, "recruit", mine.Group.Owner)
+
+	none, err := service.GetGroup(ctx, &proto.GroupRequest{AccessToken: founder.AccessToken})
+	require.NoError(t, err)
+	assert.True(t, none.Success)
+	assert.Nil(t, none.Group)
+
+	// The last member leaving deletes the group
+	_, err = service.LeaveGroup(ctx, &proto.GroupRequest{AccessToken: recruit.AccessToken})
+	require.NoError(t, err)
+	listed, err := service.ListGroups(ctx, &proto.GroupRequest{AccessToken: founder.AccessToken})
+	require.NoError(t, err)
+	assert.Empty(t, listed.Groups)
+}
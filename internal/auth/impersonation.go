@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+const (
+	// impersonatedByClaim names the admin acting as the token user, both in
+	// the token and in the user metadata returned by ValidateToken
+	impersonatedByClaim = "impersonated_by"
+
+	// defaultImpersonationDuration is how long an impersonation token lasts when none is asked for
+	defaultImpersonationDuration = 15 * time.Minute
+	// maxImpersonationDuration caps how long an impersonation token may last
+	maxImpersonationDuration = time.Hour
+
+	// errImpersonationReadOnly is returned for changes attempted with an impersonation token
+	errImpersonationReadOnly = "Not allowed while impersonating a user"
+)
+
+// isImpersonation reports whether a validated token user is being impersonated
+func isImpersonation(u *proto.User) bool {
+	return u != nil && u.Metadata[impersonatedByClaim] != ""
+}
+
+// ImpersonateUser issues a short-lived access token for a user so support can
+// see the menus exactly as the user does (admin only). The token cannot be
+// refreshed and cannot change the user's password, preferences or moderate.
+// Every impersonation is written to the moderation audit log and shown to
+// the user the next time they log in.
+func (s *Service) ImpersonateUser(ctx context.Context, req *proto.ImpersonateUserRequest) (*proto.ImpersonateUserResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ImpersonateUserResponse{Success: false, Error: errMsg}, err
+	}
+
+	if req.Reason == "" {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   "A reason is required to impersonate a user",
+		}, nil
+	}
+
+	target, err := s.userSvc.GetUserByUsername(ctx, req.TargetUsername)
+	if err != nil {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   fmt.Sprintf("User not found: %s", req.TargetUsername),
+		}, nil
+	}
+	if target.ID == admin.ID || target.IsModerator() {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   "Admins and moderators cannot be impersonated",
+		}, nil
+	}
+	if !target.IsActive {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   "User account is inactive",
+		}, nil
+	}
+
+	duration := time.Duration(req.DurationSeconds) * time.Second
+	if duration <= 0 {
+		duration = defaultImpersonationDuration
+	}
+	if duration > maxImpersonationDuration {
+		duration = maxImpersonationDuration
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(duration)
+	accessToken, err := s.createToken(&proto.TokenClaims{
+		UserId:    strconv.Itoa(target.ID),
+		Username:  target.Username,
+		Email:     target.Email,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+		NotBefore: now.Unix(),
+		Issuer:    s.jwtIssuer,
+		Metadata:  map[string]string{impersonatedByClaim: admin.Username},
+	})
+	if err != nil {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   "Failed to create impersonation token",
+		}, fmt.Errorf("failed to create impersonation token: %w", err)
+	}
+
+	// Never hand out a token that the user would not hear about
+	if err := s.userSvc.RecordImpersonation(ctx, admin.Username, target, req.Reason, expiresAt); err != nil {
+		return &proto.ImpersonateUserResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to record impersonation: %v", err),
+		}, nil
+	}
+
+	s.logger.Warn("Admin impersonating user",
+		"audit", true,
+		"admin_user", admin.Username,
+		"target_user", target.Username,
+		"reason", req.Reason,
+		"expires_at", expiresAt.UTC(),
+	)
+
+	return &proto.ImpersonateUserResponse{
+		Success:     true,
+		AccessToken: accessToken,
+		ExpiresAt:   expiresAt.Unix(),
+	}, nil
+}
+
+// ListAccountAccess lists the times support staff impersonated the token
+// user, newest first, and marks them seen unless support is the one looking
+func (s *Service) ListAccountAccess(ctx context.Context, req *proto.ListAccountAccessRequest) (*proto.ListAccountAccessResponse, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: req.AccessToken,
+	})
+	if err != nil {
+		return &proto.ListAccountAccessResponse{
+			Success: false,
+			Error:   "Failed to validate token",
+		}, err
+	}
+
+	if !validateResp.Valid {
+		return &proto.ListAccountAccessResponse{
+			Success: false,
+			Error:   validateResp.Error,
+		}, nil
+	}
+
+	userID, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return &proto.ListAccountAccessResponse{
+			Success: false,
+			Error:   "Invalid user ID",
+		}, nil
+	}
+
+	entries, err := s.userSvc.ListAccountAccess(ctx, userID)
+	if err != nil {
+		return &proto.ListAccountAccessResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load account access: %v", err),
+		}, nil
+	}
+
+	if !isImpersonation(validateResp.User) {
+		if err := s.userSvc.MarkAccountAccessSeen(ctx, userID); err != nil {
+			s.logger.Warn("Failed to mark account access seen", "error", err, "username", validateResp.User.Username)
+		}
+	}
+
+	resp := &proto.ListAccountAccessResponse{Success: true}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &proto.AccountAccess{
+			AdminUsername: entry.AdminUsername,
+			Reason:        entry.Reason,
+			CreatedAt:     timestampProto(entry.CreatedAt),
+			ExpiresAt:     timestampProto(entry.ExpiresAt),
+			Seen:          entry.Seen,
+		})
+	}
+	return resp, nil
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ImpersonateUser(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "support")
+	player := registerTestUser(t, service, "player")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "support"))
+
+	// A reason is required
+	resp, err := service.ImpersonateUser(ctx, &proto.ImpersonateUserRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "player",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	// Players cannot impersonate anyone
+	resp, err = service.ImpersonateUser(ctx, &proto.ImpersonateUserRequest{
+		AdminToken:     player.AccessToken,
+		TargetUsername: "support",
+		Reason:         "curious",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	resp, err = service.ImpersonateUser(ctx, &proto.ImpersonateUserRequest{
+		AdminToken:      admin.AccessToken,
+		TargetUsername:  "player",
+		Reason:          "menu shows no games",
+		DurationSeconds: int32((24 * time.Hour).Seconds()),
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.LessOrEqual(t, resp.ExpiresAt, time.Now().Add(maxImpersonationDuration).Unix())
+
+	// The token acts as the player and says who is behind it
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: resp.AccessToken})
+	require.NoError(t, err)
+	require.True(t, validated.Valid, validated.Error)
+	assert.Equal(t, "player", validated.User.Username)
+	assert.Equal(t, "support", validated.User.Metadata["impersonated_by"])
+	assert.Equal(t, "1", validated.User.Metadata["unseen_account_access"])
+
+	// but cannot change anything, nor be refreshed into a regular token
+	pref, err := service.SetUserPreference(ctx, &proto.SetUserPreferenceRequest{AccessToken: resp.AccessToken, Key: "recording", Value: "off"})
+	require.NoError(t, err)
+	assert.False(t, pref.Success)
+	refreshed, err := service.RefreshToken(ctx, &proto.RefreshTokenRequest{RefreshToken: resp.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, refreshed.Success)
+
+	// Support looking at the history does not mark it seen
+	access, err := service.ListAccountAccess(ctx, &proto.ListAccountAccessRequest{AccessToken: resp.AccessToken})
+	require.NoError(t, err)
+	require.True(t, access.Success, access.Error)
+	require.Len(t, access.Entries, 1)
+
+	// The player sees the access once
+	access, err = service.ListAccountAccess(ctx, &proto.ListAccountAccessRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, access.Entries, 1)
+	assert.Equal(t, "support", access.Entries[0].AdminUsername)
+	assert.Equal(t, "menu shows no games", access.Entries[0].Reason)
+	assert.False(t, access.Entries[0].Seen)
+
+	validated, err = service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.Empty(t, validated.User.Metadata["unseen_account_access"])
+	assert.Empty(t, validated.User.Metadata["impersonated_by"])
+}
@@ -0,0 +1,163 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// sessionInstanceStaleAfter is how long a session service replica that
+	// stopped reporting is still counted. Replicas report every 30 seconds.
+	sessionInstanceStaleAfter = 90 * time.Second
+	// sessionInstanceForgetAfter is how long a replica that stopped
+	// reporting is kept before it is removed from the registry
+	sessionInstanceForgetAfter = 24 * time.Hour
+)
+
+// ReportSessionInstance records the load of a session service replica in
+// the shared instance registry, which admin statistics sum over
+func (s *Service) ReportSessionInstance(ctx context.Context, req *proto.ReportSessionInstanceRequest) (*emptypb.Empty, error) {
+	if req.Instance == nil || req.Instance.InstanceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "instance ID is required")
+	}
+
+	instance := convertSessionInstanceFromProto(req.Instance)
+	instance.ReportedAt = time.Now()
+	if err := s.userSvc.ReportSessionInstance(ctx, instance); err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	if forgotten, err := s.userSvc.ForgetSessionInstances(ctx, instance.ReportedAt.Add(-sessionInstanceForgetAfter)); err != nil {
+		s.logger.WarnContext(ctx, "Failed to forget session instances", "error", err)
+	} else if forgotten > 0 {
+		s.logger.InfoContext(ctx, "Forgot session instances that stopped reporting", "count", forgotten)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// ListSessionInstances lists the session service replicas that reported
+// recently, with their sums (admin only)
+func (s *Service) ListSessionInstances(ctx context.Context, req *proto.ListSessionInstancesRequest) (*proto.ListSessionInstancesResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.ListSessionInstancesResponse{Success: false, Error: errMsg}, err
+	}
+
+	instances, err := s.liveSessionInstances(ctx)
+	if err != nil {
+		return &proto.ListSessionInstancesResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list session instances: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListSessionInstancesResponse{Success: true}
+	for _, instance := range instances {
+		resp.Instances = append(resp.Instances, convertSessionInstanceToProto(instance))
+	}
+	resp.Totals = convertSessionInstanceToProto(sumSessionInstances(instances))
+	return resp, nil
+}
+
+// CountSessionLogins records a login starting or ending on a session service
+// replica, so concurrent session limits hold across the replicas
+func (s *Service) CountSessionLogins(ctx context.Context, req *proto.CountSessionLoginsRequest) (*proto.CountSessionLoginsResponse, error) {
+	if req.InstanceId == "" || req.Username == "" {
+		return nil, status.Error(codes.InvalidArgument, "instance ID and username are required")
+	}
+
+	logins, err := s.userSvc.AddSessionLogins(ctx, req.InstanceId, req.Username, int(req.Delta), time.Now().Add(-sessionInstanceStaleAfter))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+	return &proto.CountSessionLoginsResponse{Logins: int32(logins)}, nil
+}
+
+// liveSessionInstances returns the replicas that reported recently
+func (s *Service) liveSessionInstances(ctx context.Context) ([]*user.SessionInstance, error) {
+	return s.userSvc.ListSessionInstances(ctx, time.Now().Add(-sessionInstanceStaleAfter))
+}
+
+// sumSessionInstances sums the load of replicas. The sum started when the
+// earliest replica did and was reported when the latest one last reported.
+func sumSessionInstances(instances []*user.SessionInstance) *user.SessionInstance {
+	totals := &user.SessionInstance{}
+	for _, instance := range instances {
+		totals.ConnectionsActive += instance.ConnectionsActive
+		totals.ConnectionsTotal += instance.ConnectionsTotal
+		totals.ConnectionsPeak += instance.ConnectionsPeak
+		totals.MaxConnections += instance.MaxConnections
+		totals.GameSessions += instance.GameSessions
+		totals.ConnectionsRejected += instance.ConnectionsRejected
+		if totals.StartedAt.IsZero() || instance.StartedAt.Before(totals.StartedAt) {
+			totals.StartedAt = instance.StartedAt
+		}
+		if instance.ReportedAt.After(totals.ReportedAt) {
+			totals.ReportedAt = instance.ReportedAt
+		}
+	}
+	return totals
+}
+
+// clusterStatistics adds the sums over the session service replicas to the
+// server statistics
+func (s *Service) clusterStatistics(ctx context.Context, stats map[string]string) {
+	instances, err := s.liveSessionInstances(ctx)
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to list session instances", "error", err)
+		return
+	}
+
+	totals := sumSessionInstances(instances)
+	stats["cluster_session_instances"] = fmt.Sprintf("%d", len(instances))
+	stats["cluster_connections_active"] = fmt.Sprintf("%d", totals.ConnectionsActive)
+	stats["cluster_connections_max"] = fmt.Sprintf("%d", totals.MaxConnections)
+	stats["cluster_game_sessions"] = fmt.Sprintf("%d", totals.GameSessions)
+	stats["cluster_connections_total"] = fmt.Sprintf("%d", totals.ConnectionsTotal)
+}
+
+// convertSessionInstanceToProto converts a session instance to its proto form
+func convertSessionInstanceToProto(instance *user.SessionInstance) *proto.SessionInstance {
+	pbInstance := &proto.SessionInstance{
+		InstanceId:          instance.ID,
+		Version:             instance.Version,
+		ConnectionsActive:   int32(instance.ConnectionsActive),
+		ConnectionsTotal:    instance.ConnectionsTotal,
+		ConnectionsPeak:     int32(instance.ConnectionsPeak),
+		MaxConnections:      int32(instance.MaxConnections),
+		GameSessions:        int32(instance.GameSessions),
+		ConnectionsRejected: instance.ConnectionsRejected,
+	}
+	if !instance.StartedAt.IsZero() {
+		pbInstance.StartedAt = timestampProto(instance.StartedAt)
+	}
+	if !instance.ReportedAt.IsZero() {
+		pbInstance.ReportedAt = timestampProto(instance.ReportedAt)
+	}
+	return pbInstance
+}
+
+// convertSessionInstanceFromProto converts a reported session instance
+func convertSessionInstanceFromProto(pbInstance *proto.SessionInstance) *user.SessionInstance {
+	instance := &user.SessionInstance{
+		ID:                  pbInstance.InstanceId,
+		Version:             pbInstance.Version,
+		ConnectionsActive:   int(pbInstance.ConnectionsActive),
+		ConnectionsTotal:    pbInstance.ConnectionsTotal,
+		ConnectionsPeak:     int(pbInstance.ConnectionsPeak),
+		MaxConnections:      int(pbInstance.MaxConnections),
+		GameSessions:        int(pbInstance.GameSessions),
+		ConnectionsRejected: pbInstance.ConnectionsRejected,
+	}
+	if pbInstance.StartedAt != nil {
+		instance.StartedAt = pbInstance.StartedAt.AsTime()
+	}
+	return instance
+}
@@ -0,0 +1,101 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestService_SessionInstances(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	started := time.Now().Add(-time.Hour)
+	report := func(id string, active, games int32) {
+		_, err := service.ReportSessionInstance(ctx, &proto.ReportSessionInstanceRequest{Instance: &proto.SessionInstance{
+			InstanceId:        id,
+			Version:           "1.2.3",
+			ConnectionsActive: active,
+			ConnectionsTotal:  100,
+			MaxConnections:    50,
+			GameSessions:      games,
+			StartedAt:         timestamppb.New(started),
+		}})
+		require.NoError(t, err)
+	}
+
+	_, err := service.ReportSessionInstance(ctx, &proto.ReportSessionInstanceRequest{Instance: &proto.SessionInstance{}})
+	assert.Error(t, err, "reports name their instance")
+
+	report("session-0", 10, 4)
+	report("session-1", 3, 1)
+	report("session-0", 12, 5)
+
+	// Replicas that stopped reporting are not counted
+	require.NoError(t, service.userSvc.ReportSessionInstance(ctx, &user.SessionInstance{
+		ID: "session-gone", ConnectionsActive: 40, StartedAt: started, ReportedAt: time.Now().Add(-time.Hour),
+	}))
+
+	admin := registerTestUser(t, service, "opsadmin")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "opsadmin"))
+	player := registerTestUser(t, service, "player")
+
+	denied, err := service.ListSessionInstances(ctx, &proto.ListSessionInstancesRequest{AdminToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, denied.Success)
+
+	listed, err := service.ListSessionInstances(ctx, &proto.ListSessionInstancesRequest{AdminToken: admin.AccessToken})
+	require.NoError(t, err)
+	require.True(t, listed.Success, listed.Error)
+	require.Len(t, listed.Instances, 2)
+	assert.Equal(t, "session-0", listed.Instances[0].InstanceId)
+	assert.Equal(t, int32(12), listed.Instances[0].ConnectionsActive)
+	assert.Equal(t, int32(15), listed.Totals.ConnectionsActive)
+	assert.Equal(t, int32(100), listed.Totals.MaxConnections)
+	assert.Equal(t, int32(6), listed.Totals.GameSessions)
+	assert.Equal(t, int64(200), listed.Totals.ConnectionsTotal)
+
+	stats, err := service.GetServerStatistics(ctx, &proto.ServerStatsRequest{AdminToken: admin.AccessToken})
+	require.NoError(t, err)
+	require.True(t, stats.Success, stats.Error)
+	assert.Equal(t, "2", stats.Stats["cluster_session_instances"])
+	assert.Equal(t, "15", stats.Stats["cluster_connections_active"])
+}
+
+func TestService_CountSessionLogins(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	count := func(instance string, delta int32) int32 {
+		resp, err := service.CountSessionLogins(ctx, &proto.CountSessionLoginsRequest{
+			InstanceId: instance, Username: "player", Delta: delta,
+		})
+		require.NoError(t, err)
+		return resp.Logins
+	}
+
+	_, err := service.CountSessionLogins(ctx, &proto.CountSessionLoginsRequest{Username: "player", Delta: 1})
+	assert.Error(t, err, "logins name their instance")
+
+	// Logins count across the replicas
+	assert.Equal(t, int32(1), count("session-0", 1))
+	assert.Equal(t, int32(2), count("session-1", 1))
+	assert.Equal(t, int32(3), count("session-0", 1))
+	assert.Equal(t, int32(2), count("session-1", -1))
+	assert.Equal(t, int32(2), count("session-1", -1), "ending a login that was not counted changes nothing")
+
+	// A restarted replica's logins have ended
+	_, err = service.ReportSessionInstance(ctx, &proto.ReportSessionInstanceRequest{Instance: &proto.SessionInstance{
+		InstanceId: "session-0", StartedAt: timestamppb.Now(),
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), count("session-1", 1))
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dungeongate/pkg/retention"
+	"github.com/dungeongate/pkg/scheduler"
+)
+
+const (
+	// LockExpiryJob is the name of the job clearing expired account locks
+	LockExpiryJob = "lock_expiry"
+	// RetentionJob is the name of the job purging inactive accounts and old
+	// audit logs
+	RetentionJob = "retention"
+	// retentionService names the auth service in retention reports
+	retentionService = "auth"
+	// lockExpiryInterval is how often expired account locks are cleared
+	lockExpiryInterval = 5 * time.Minute
+	// LeaseName is the default lease auth service replicas compete for
+	LeaseName = "auth-service-jobs"
+)
+
+// AddJobs schedules the auth service's background jobs. They change shared
+// state, so they run on the leader only.
+func (s *Service) AddJobs(jobs *scheduler.Scheduler) error {
+	err := jobs.Add(scheduler.Job{
+		Name:      LockExpiryJob,
+		Schedule:  scheduler.Every(lockExpiryInterval),
+		Jitter:    30 * time.Second,
+		Singleton: true,
+		Run:       s.unlockExpiredAccounts,
+	})
+	if err != nil || s.retention == nil {
+		return err
+	}
+	return jobs.Add(scheduler.Job{
+		Name:      RetentionJob,
+		Schedule:  scheduler.Every(s.retention.Interval()),
+		Jitter:    time.Minute,
+		Singleton: true,
+		Run:       s.enforceRetention,
+	})
+}
+
+// SetRetention sets how long inactive accounts and audit logs are kept; nil
+// keeps them forever
+func (s *Service) SetRetention(policy *retention.Policy) {
+	s.retention = policy
+}
+
+// enforceRetention purges the accounts inactive for longer than their
+// retention period and the audit log entries older than theirs, except
+// those of users under legal hold
+func (s *Service) enforceRetention(ctx context.Context) error {
+	policy := s.retention
+	report := policy.NewReport(retentionService)
+
+	if before, ok := policy.Cutoff(retention.InactiveAccounts); ok {
+		users, err := s.userSvc.InactiveAccounts(ctx, before)
+		if err != nil {
+			report.Fail(err)
+		}
+		for _, u := range users {
+			if policy.UserHeld(u.Username) {
+				report.Hold(retention.InactiveAccounts)
+				continue
+			}
+			if !report.DryRun {
+				if err := s.userSvc.PurgeAccount(ctx, u.ID); err != nil {
+					report.Fail(fmt.Errorf("failed to purge user %d: %w", u.ID, err))
+					continue
+				}
+			}
+			// Purged users are reported by ID, not by their personal data
+			report.Purge(retention.InactiveAccounts, strconv.Itoa(u.ID))
+		}
+	}
+
+	if before, ok := policy.Cutoff(retention.AuditLogs); ok {
+		purged, err := s.userSvc.PurgeAuditLogs(ctx, before, policy.HeldUsers(), report.DryRun)
+		if err != nil {
+			report.Fail(err)
+		}
+		report.PurgeCount(retention.AuditLogs, purged)
+	}
+
+	return policy.Finish(report, s.logger)
+}
+
+// unlockExpiredAccounts clears the account locks that have run out
+func (s *Service) unlockExpiredAccounts(ctx context.Context) error {
+	unlocked, err := s.userSvc.UnlockExpiredAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	if unlocked > 0 {
+		s.logger.Info("Expired account locks cleared", "audit", true, "accounts", unlocked)
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/retention"
+	"github.com/dungeongate/pkg/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_UnlockExpiredAccounts(t *testing.T) {
+	service, db, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	registerTestUser(t, service, "expired")
+	registerTestUser(t, service, "locked")
+
+	lock := func(username string, until time.Time) {
+		_, err := db.Writer().Exec(`UPDATE users SET account_locked = TRUE, locked_until = ?, failed_login_attempts = 3 WHERE username = ?`, until, username)
+		require.NoError(t, err)
+	}
+	lock("expired", time.Now().Add(-time.Minute))
+	lock("locked", time.Now().Add(time.Hour))
+
+	require.NoError(t, service.unlockExpiredAccounts(ctx))
+
+	expired, err := service.userSvc.GetUserByUsername(ctx, "expired")
+	require.NoError(t, err)
+	assert.False(t, expired.AccountLocked)
+	assert.Nil(t, expired.LockedUntil)
+	assert.Zero(t, expired.FailedLoginAttempts)
+
+	locked, err := service.userSvc.GetUserByUsername(ctx, "locked")
+	require.NoError(t, err)
+	assert.True(t, locked.AccountLocked)
+}
+
+func TestService_AddJobs(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	jobs := scheduler.New(nil, slog.Default())
+	require.NoError(t, service.AddJobs(jobs))
+	assert.Equal(t, []string{LockExpiryJob}, jobs.Jobs())
+}
+
+func TestService_EnforceRetention(t *testing.T) {
+	service, db, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	registerTestUser(t, service, "active")
+	registerTestUser(t, service, "dormant")
+	registerTestUser(t, service, "held")
+	longAgo := time.Now().Add(-3 * 365 * 24 * time.Hour).UTC()
+	for _, username := range []string{"dormant", "held", "admin"} {
+		_, err := db.Writer().Exec(`UPDATE users SET last_login = ? WHERE username = ?`, longAgo, username)
+		require.NoError(t, err)
+	}
+	dormant, err := service.userSvc.GetUserByUsername(ctx, "dormant")
+	require.NoError(t, err)
+	require.NoError(t, service.userSvc.SetPreference(ctx, dormant.ID, "theme", "dark"))
+
+	for _, target := range []string{"active", "held"} {
+		_, err := db.Writer().Exec(`INSERT INTO moderation_audit_log (actor, target_username, action, created_at) VALUES ('admin', ?, 'warn', ?)`,
+			target, longAgo)
+		require.NoError(t, err)
+	}
+
+	policy, err := retention.NewPolicy(&config.RetentionConfig{
+		Enabled:    true,
+		LegalHolds: &config.LegalHoldConfig{Users: []string{"Held"}},
+	})
+	require.NoError(t, err)
+	service.SetRetention(policy)
+	require.NoError(t, service.enforceRetention(ctx))
+
+	_, err = service.userSvc.GetUserByUsername(ctx, "dormant")
+	assert.Error(t, err)
+	var preferences int
+	require.NoError(t, db.Writer().QueryRow(`SELECT COUNT(*) FROM user_preferences WHERE user_id = ?`, dormant.ID).Scan(&preferences))
+	assert.Zero(t, preferences, "the data kept with an account goes with it")
+
+	for _, username := range []string{"active", "held", "admin"} {
+		_, err := service.userSvc.GetUserByUsername(ctx, username)
+		assert.NoError(t, err, username)
+	}
+
+	var entries []string
+	rows, err := db.Writer().Query(`SELECT target_username FROM moderation_audit_log`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var target string
+		require.NoError(t, rows.Scan(&target))
+		entries = append(entries, target)
+	}
+	assert.Equal(t, []string{"held"}, entries)
+}
+
+func TestService_AddJobs_Retention(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	policy, err := retention.NewPolicy(&config.RetentionConfig{Enabled: true})
+	require.NoError(t, err)
+	service.SetRetention(policy)
+
+	jobs := scheduler.New(nil, slog.Default())
+	require.NoError(t, service.AddJobs(jobs))
+	assert.ElementsMatch(t, []string{LockExpiryJob, RetentionJob}, jobs.Jobs())
+}
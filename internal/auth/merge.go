@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// MergeAccounts merges another account into the token user's account. The
+// caller proves they own the other account with its password or with an
+// access token issued to it. The other account is deactivated; its game
+// data is keyed by merged_user_id and is merged by the game service.
+func (s *Service) MergeAccounts(ctx context.Context, req *proto.MergeAccountsRequest) (*proto.MergeAccountsResponse, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: req.AccessToken,
+	})
+	if err != nil {
+		return &proto.MergeAccountsResponse{
+			Success: false,
+			Error:   "Failed to validate token",
+		}, err
+	}
+
+	if !validateResp.Valid {
+		return &proto.MergeAccountsResponse{
+			Success: false,
+			Error:   validateResp.Error,
+		}, nil
+	}
+
+	if isImpersonation(validateResp.User) {
+		return &proto.MergeAccountsResponse{
+			Success: false,
+			Error:   errImpersonationReadOnly,
+		}, nil
+	}
+
+	survivorID, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return &proto.MergeAccountsResponse{
+			Success: false,
+			Error:   "Invalid user ID",
+		}, nil
+	}
+
+	other, verifiedBy, errMsg := s.verifyMergeOwnership(ctx, req)
+	if other == nil {
+		return &proto.MergeAccountsResponse{Success: false, Error: errMsg}, nil
+	}
+
+	survivor := validateResp.User
+	summary, err := s.userSvc.MergeAccounts(ctx, &user.MergeRequest{
+		SurvivorID: survivorID,
+		MergedID:   other.ID,
+		MergedBy:   survivor.Username,
+		VerifiedBy: verifiedBy,
+		DryRun:     req.DryRun,
+	})
+	if err != nil {
+		return &proto.MergeAccountsResponse{
+			Success: false,
+			Error:   "Account merge failed: " + err.Error(),
+		}, nil
+	}
+
+	if !req.DryRun {
+		s.logger.InfoContext(ctx, "Accounts merged",
+			"audit", true,
+			"user_id", survivorID,
+			"username", survivor.Username,
+			"merged_user_id", other.ID,
+			"merged_username", other.Username,
+			"verified_by", verifiedBy,
+		)
+	}
+
+	return &proto.MergeAccountsResponse{
+		Success: true,
+		Summary: &proto.AccountMergeSummary{
+			Preferences:        int32(summary.Preferences),
+			ApiKeys:            int32(summary.APIKeys),
+			Notifications:      int32(summary.Notifications),
+			Notes:              int32(summary.Notes),
+			ModerationFlags:    int32(summary.ModerationFlags),
+			AccessLog:          int32(summary.AccessLog),
+			OwnedGroups:        int32(summary.OwnedGroups),
+			Group:              summary.Group,
+			SkippedPreferences: int32(summary.SkippedPreferences),
+		},
+		MergedUserId:   int32(other.ID),
+		MergedUsername: other.Username,
+	}, nil
+}
+
+// verifyMergeOwnership identifies the account to merge and checks the caller
+// owns it, returning the account and how ownership was proven, or an error
+// message
+func (s *Service) verifyMergeOwnership(ctx context.Context, req *proto.MergeAccountsRequest) (*user.User, string, string) {
+	if req.OtherAccessToken != "" {
+		otherResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+			AccessToken: req.OtherAccessToken,
+		})
+		if err != nil || !otherResp.Valid {
+			return nil, "", "The other account's token is not valid"
+		}
+		// Support staff acting as a user cannot give their account away
+		if isImpersonation(otherResp.User) {
+			return nil, "", errImpersonationReadOnly
+		}
+		if req.OtherUsername != "" && !strings.EqualFold(req.OtherUsername, otherResp.User.Username) {
+			return nil, "", "The other account's token belongs to a different user"
+		}
+		otherID, err := strconv.Atoi(otherResp.User.Id)
+		if err != nil {
+			return nil, "", "Invalid user ID"
+		}
+		other, err := s.userSvc.GetUserByID(ctx, otherID)
+		if err != nil {
+			return nil, "", "The other account could not be loaded"
+		}
+		return other, user.MergeVerifiedByToken, ""
+	}
+
+	if req.OtherUsername == "" || req.OtherPassword == "" {
+		return nil, "", "The other account's username and password, or a token issued to it, are required"
+	}
+	other, err := s.userSvc.AuthenticateUser(ctx, req.OtherUsername, req.OtherPassword)
+	if err != nil {
+		return nil, "", "The other account's username or password is incorrect"
+	}
+	return other, user.MergeVerifiedByPassword, ""
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_MergeAccounts(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	main := registerTestUser(t, service, "main")
+	alt := registerTestUser(t, service, "alt")
+
+	for _, pref := range []*proto.SetUserPreferenceRequest{
+		{AccessToken: main.AccessToken, Key: "recording", Value: "on"},
+		{AccessToken: alt.AccessToken, Key: "recording", Value: "off"},
+		{AccessToken: alt.AccessToken, Key: "theme", Value: "dark"},
+	} {
+		resp, err := service.SetUserPreference(ctx, pref)
+		require.NoError(t, err)
+		require.True(t, resp.Success, resp.Error)
+	}
+
+	// Ownership of the other account has to be proven
+	resp, err := service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:   main.AccessToken,
+		OtherUsername: "alt",
+		OtherPassword: "wrongpass",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	resp, err = service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:   main.AccessToken,
+		OtherUsername: "alt",
+		OtherPassword: "testpass123",
+		DryRun:        true,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, int32(1), resp.Summary.Preferences)
+	assert.Equal(t, int32(1), resp.Summary.SkippedPreferences)
+	assert.Equal(t, "alt", resp.MergedUsername)
+
+	// A dry run changes nothing
+	login, err := service.Login(ctx, &proto.LoginRequest{Username: "alt", Password: "testpass123"})
+	require.NoError(t, err)
+	require.True(t, login.Success, login.Error)
+
+	resp, err = service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:   main.AccessToken,
+		OtherUsername: "alt",
+		OtherPassword: "testpass123",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, alt.User.Id, strconv.Itoa(int(resp.MergedUserId)))
+
+	// The survivor keeps its own value and gains the rest
+	mainID, err := strconv.Atoi(main.User.Id)
+	require.NoError(t, err)
+	prefs, err := service.userSvc.GetPreferences(ctx, mainID)
+	require.NoError(t, err)
+	assert.Equal(t, "on", prefs["recording"])
+	assert.Equal(t, "dark", prefs["theme"])
+
+	// The merged account can no longer be used
+	login, err = service.Login(ctx, &proto.LoginRequest{Username: "alt", Password: "testpass123"})
+	require.NoError(t, err)
+	assert.False(t, login.Success)
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: alt.AccessToken})
+	require.NoError(t, err)
+	assert.False(t, validated.Valid)
+}
+
+func TestService_MergeAccounts_ByToken(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	main := registerTestUser(t, service, "main")
+	alt := registerTestUser(t, service, "alt")
+	registerTestUser(t, service, "other")
+
+	// The token must belong to the named account
+	resp, err := service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:      main.AccessToken,
+		OtherUsername:    "other",
+		OtherAccessToken: alt.AccessToken,
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	// An account cannot be merged into itself
+	resp, err = service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:      main.AccessToken,
+		OtherAccessToken: main.AccessToken,
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	resp, err = service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:      main.AccessToken,
+		OtherAccessToken: alt.AccessToken,
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, "alt", resp.MergedUsername)
+}
+
+func TestService_MergeAccounts_AdminIntoPlayer(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	player := registerTestUser(t, service, "player")
+	registerTestUser(t, service, "bossman")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	resp, err := service.MergeAccounts(ctx, &proto.MergeAccountsRequest{
+		AccessToken:   player.AccessToken,
+		OtherUsername: "bossman",
+		OtherPassword: "testpass123",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "admin")
+}
@@ -0,0 +1,224 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// AddUserNote attaches a note to a user account (moderator only)
+func (s *Service) AddUserNote(ctx context.Context, req *proto.AddUserNoteRequest) (*proto.AdminActionResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.AddUserNote(ctx, req.TargetUsername, moderator.Username, req.Note); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to add note: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Moderator added user note",
+		"audit", true,
+		"moderator", moderator.Username,
+		"target_user", req.TargetUsername,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Note added to '%s'", req.TargetUsername),
+	}, nil
+}
+
+// GetUserModeration returns the notes and moderation flags on a user account (moderator only)
+func (s *Service) GetUserModeration(ctx context.Context, req *proto.AdminActionRequest) (*proto.GetUserModerationResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.GetUserModerationResponse{Success: false, Error: errMsg}, err
+	}
+
+	target, err := s.userSvc.GetUserByUsername(ctx, req.TargetUsername)
+	if err != nil {
+		return &proto.GetUserModerationResponse{
+			Success: false,
+			Error:   fmt.Sprintf("User '%s' not found", req.TargetUsername),
+		}, nil
+	}
+
+	notes, err := s.userSvc.ListUserNotes(ctx, target.Username)
+	if err != nil {
+		return &proto.GetUserModerationResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load notes: %v", err),
+		}, nil
+	}
+
+	flags, err := s.userSvc.GetModerationFlags(ctx, target.ID)
+	if err != nil {
+		return &proto.GetUserModerationResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load moderation flags: %v", err),
+		}, nil
+	}
+
+	resp := &proto.GetUserModerationResponse{
+		Success: true,
+		Flags:   convertModerationFlagsToProto(flags),
+	}
+	for _, note := range notes {
+		resp.Notes = append(resp.Notes, &proto.UserNote{
+			Id:        int32(note.ID),
+			Author:    note.Author,
+			Note:      note.Note,
+			CreatedAt: timestampProto(note.CreatedAt),
+		})
+	}
+
+	return resp, nil
+}
+
+// SetUserModerationFlag sets a moderation flag on a user account (moderator only)
+func (s *Service) SetUserModerationFlag(ctx context.Context, req *proto.ModerationFlagRequest) (*proto.AdminActionResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	flag, err := user.ParseModerationFlag(req.Flag)
+	if err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	if err := s.userSvc.SetModerationFlag(ctx, req.TargetUsername, flag, moderator.Username, req.Reason); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to set moderation flag: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Moderator set user moderation flag",
+		"audit", true,
+		"moderator", moderator.Username,
+		"target_user", req.TargetUsername,
+		"flag", flag,
+		"reason", req.Reason,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("User '%s' flagged as %s", req.TargetUsername, flag),
+	}, nil
+}
+
+// ClearUserModerationFlag clears a moderation flag from a user account (moderator only)
+func (s *Service) ClearUserModerationFlag(ctx context.Context, req *proto.ModerationFlagRequest) (*proto.AdminActionResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	flag, err := user.ParseModerationFlag(req.Flag)
+	if err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	if err := s.userSvc.ClearModerationFlag(ctx, req.TargetUsername, flag, moderator.Username); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to clear moderation flag: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Moderator cleared user moderation flag",
+		"audit", true,
+		"moderator", moderator.Username,
+		"target_user", req.TargetUsername,
+		"flag", flag,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Cleared %s from '%s'", flag, req.TargetUsername),
+	}, nil
+}
+
+// ListUsersByModerationFlag lists the users with a moderation flag set (moderator only)
+func (s *Service) ListUsersByModerationFlag(ctx context.Context, req *proto.ListUsersByModerationFlagRequest) (*proto.ListUsersByModerationFlagResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.ListUsersByModerationFlagResponse{Success: false, Error: errMsg}, err
+	}
+
+	flag, err := user.ParseModerationFlag(req.Flag)
+	if err != nil {
+		return &proto.ListUsersByModerationFlagResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	flagged, err := s.userSvc.ListUsersByModerationFlag(ctx, flag)
+	if err != nil {
+		return &proto.ListUsersByModerationFlagResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list flagged users: %v", err),
+		}, nil
+	}
+
+	return &proto.ListUsersByModerationFlagResponse{
+		Success: true,
+		Users:   convertModerationFlagsToProto(flagged),
+	}, nil
+}
+
+// authorizeModerator validates a token and checks that its user is a moderator
+// or admin. On failure the user is nil and the message is suitable for the response.
+func (s *Service) authorizeModerator(ctx context.Context, token string) (*user.User, string, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: token,
+	})
+	if err != nil {
+		return nil, "Failed to validate moderator token", err
+	}
+
+	if !validateResp.Valid {
+		return nil, "Invalid moderator token", nil
+	}
+
+	if isImpersonation(validateResp.User) {
+		return nil, errImpersonationReadOnly, nil
+	}
+
+	userIDInt, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return nil, "Invalid moderator user ID", nil
+	}
+
+	moderator, err := s.userSvc.GetUserByID(ctx, userIDInt)
+	if err != nil {
+		return nil, "Moderator user not found", nil
+	}
+
+	if !moderator.IsModerator() {
+		return nil, "Insufficient privileges - moderator access required", nil
+	}
+
+	return moderator, "", nil
+}
+
+// convertModerationFlagsToProto converts moderation flags to their proto form
+func convertModerationFlagsToProto(flags []*user.UserModerationFlag) []*proto.UserModerationFlag {
+	result := make([]*proto.UserModerationFlag, 0, len(flags))
+	for _, flag := range flags {
+		result = append(result, &proto.UserModerationFlag{
+			Username:  flag.Username,
+			Flag:      string(flag.Flag),
+			Reason:    flag.Reason,
+			SetBy:     flag.SetBy,
+			CreatedAt: timestampProto(flag.CreatedAt),
+		})
+	}
+	return result
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerTestUser(t *testing.T, service *Service, username string) *proto.RegisterResponse {
+	resp, err := service.Register(context.Background(), &proto.RegisterRequest{
+		Username: username,
+		Password: "testpass123",
+		Email:    username + "@example.com",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	return resp
+}
+
+func TestService_Moderation_NotesAndFlags(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	moderator := registerTestUser(t, service, "modone")
+	registerTestUser(t, service, "troll")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "modone"))
+
+	noteResp, err := service.AddUserNote(ctx, &proto.AddUserNoteRequest{
+		AdminToken:     moderator.AccessToken,
+		TargetUsername: "troll",
+		Note:           "Spamming the spectator chat",
+	})
+	require.NoError(t, err)
+	assert.True(t, noteResp.Success, noteResp.Error)
+
+	flagResp, err := service.SetUserModerationFlag(ctx, &proto.ModerationFlagRequest{
+		AdminToken:     moderator.AccessToken,
+		TargetUsername: "troll",
+		Flag:           "warned",
+		Reason:         "spam",
+	})
+	require.NoError(t, err)
+	assert.True(t, flagResp.Success, flagResp.Error)
+
+	record, err := service.GetUserModeration(ctx, &proto.AdminActionRequest{
+		AdminToken:     moderator.AccessToken,
+		TargetUsername: "troll",
+	})
+	require.NoError(t, err)
+	require.True(t, record.Success, record.Error)
+	require.Len(t, record.Notes, 1)
+	assert.Equal(t, "modone", record.Notes[0].Author)
+	require.Len(t, record.Flags, 1)
+	assert.Equal(t, "warned", record.Flags[0].Flag)
+	assert.Equal(t, "spam", record.Flags[0].Reason)
+
+	listed, err := service.ListUsersByModerationFlag(ctx, &proto.ListUsersByModerationFlagRequest{
+		AdminToken: moderator.AccessToken,
+		Flag:       "warned",
+	})
+	require.NoError(t, err)
+	require.Len(t, listed.Users, 1)
+	assert.Equal(t, "troll", listed.Users[0].Username)
+
+	clearResp, err := service.ClearUserModerationFlag(ctx, &proto.ModerationFlagRequest{
+		AdminToken:     moderator.AccessToken,
+		TargetUsername: "troll",
+		Flag:           "warned",
+	})
+	require.NoError(t, err)
+	assert.True(t, clearResp.Success, clearResp.Error)
+
+	listed, err = service.ListUsersByModerationFlag(ctx, &proto.ListUsersByModerationFlagRequest{
+		AdminToken: moderator.AccessToken,
+		Flag:       "warned",
+	})
+	require.NoError(t, err)
+	assert.Empty(t, listed.Users)
+}
+
+func TestService_Moderation_RequiresModerator(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	player := registerTestUser(t, service, "player")
+	registerTestUser(t, service, "other")
+
+	resp, err := service.SetUserModerationFlag(ctx, &proto.ModerationFlagRequest{
+		AdminToken:     player.AccessToken,
+		TargetUsername: "other",
+		Flag:           "warned",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "moderator access required")
+}
+
+func TestService_Moderation_RestrictedExposedInUserInfo(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	player := registerTestUser(t, service, "player")
+	require.NoError(t, service.userSvc.SetModerationFlag(ctx, "player", "restricted", "admin", "griefing"))
+	require.NoError(t, service.userSvc.SetModerationFlag(ctx, "player", "warned", "admin", "spam"))
+
+	validateResp, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.True(t, validateResp.Valid)
+	assert.Equal(t, "true", validateResp.User.Metadata["restricted"])
+	assert.NotContains(t, validateResp.User.Metadata, "warned")
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// ListNotifications lists the token user's notifications and how many are unread
+func (s *Service) ListNotifications(ctx context.Context, req *proto.ListNotificationsRequest) (*proto.ListNotificationsResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if owner == nil {
+		return &proto.ListNotificationsResponse{Success: false, Error: errMsg}, err
+	}
+
+	notifications, err := s.userSvc.ListNotifications(ctx, userID, req.UnreadOnly, int(req.Limit))
+	if err != nil {
+		return &proto.ListNotificationsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to list notifications: %v", err),
+		}, nil
+	}
+
+	return s.notificationsResponse(ctx, userID, notifications)
+}
+
+// MarkNotificationsRead marks some or all of the token user's notifications as
+// read and returns how many remain unread. Support staff impersonating a user
+// leave their notifications unread.
+func (s *Service) MarkNotificationsRead(ctx context.Context, req *proto.MarkNotificationsReadRequest) (*proto.ListNotificationsResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.ListNotificationsResponse{Success: false, Error: errMsg}, err
+	}
+
+	ids := make([]int, 0, len(req.Ids))
+	for _, id := range req.Ids {
+		ids = append(ids, int(id))
+	}
+	if _, err := s.userSvc.MarkNotificationsRead(ctx, userID, ids); err != nil {
+		return &proto.ListNotificationsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to mark notifications read: %v", err),
+		}, nil
+	}
+
+	return s.notificationsResponse(ctx, userID, nil)
+}
+
+// notificationsResponse returns notifications with the user's unread count
+func (s *Service) notificationsResponse(ctx context.Context, userID int, notifications []*user.Notification) (*proto.ListNotificationsResponse, error) {
+	unread, err := s.userSvc.CountUnreadNotifications(ctx, userID)
+	if err != nil {
+		return &proto.ListNotificationsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to count notifications: %v", err),
+		}, nil
+	}
+
+	resp := &proto.ListNotificationsResponse{Success: true, UnreadCount: int32(unread)}
+	for _, notification := range notifications {
+		resp.Notifications = append(resp.Notifications, convertNotificationToProto(notification))
+	}
+	return resp, nil
+}
+
+// SendNotification leaves an announcement for a user, or for every active
+// user when no user is named (admin only)
+func (s *Service) SendNotification(ctx context.Context, req *proto.SendNotificationRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	if req.TargetUsername == "" {
+		sent, err := s.userSvc.BroadcastNotification(ctx, user.NotificationAnnouncement, req.Title, req.Body)
+		if err != nil {
+			return &proto.AdminActionResponse{
+				Success: false,
+				Error:   fmt.Sprintf("Failed to send notification: %v", err),
+			}, nil
+		}
+
+		s.logger.Info("Notification broadcast", "audit", true, "admin_user", admin.Username, "title", req.Title, "recipients", sent)
+		return &proto.AdminActionResponse{
+			Success: true,
+			Message: fmt.Sprintf("Sent the notification to %d users", sent),
+		}, nil
+	}
+
+	target, err := s.userSvc.GetUserByUsername(ctx, req.TargetUsername)
+	if err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: "User not found"}, nil
+	}
+	if _, err := s.userSvc.CreateNotification(ctx, target.ID, user.NotificationAnnouncement, req.Title, req.Body); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to send notification: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Notification sent", "audit", true, "admin_user", admin.Username, "target_user", target.Username, "title", req.Title)
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Sent the notification to %s", target.Username),
+	}, nil
+}
+
+// NotifyUser leaves a notification for a user on behalf of another service,
+// such as the game service reporting a crashed game. Only services holding
+// server.auth_token can reach it when one is set.
+func (s *Service) NotifyUser(ctx context.Context, req *proto.NotifyUserRequest) (*proto.AdminActionResponse, error) {
+	target, err := s.userSvc.GetUserByUsername(ctx, req.Username)
+	if err != nil {
+		return &proto.AdminActionResponse{Success: false, Error: "User not found"}, nil
+	}
+	if _, err := s.userSvc.CreateNotification(ctx, target.ID, req.Kind, req.Title, req.Body); err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to notify user: %v", err),
+		}, nil
+	}
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Notified %s", target.Username),
+	}, nil
+}
+
+// notify leaves a notification for a user, logging rather than failing the
+// action that caused it
+func (s *Service) notify(ctx context.Context, username, kind, title, body string) {
+	target, err := s.userSvc.GetUserByUsername(ctx, username)
+	if err == nil {
+		_, err = s.userSvc.CreateNotification(ctx, target.ID, kind, title, body)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to notify user", "error", err, "username", username, "kind", kind)
+	}
+}
+
+// convertNotificationToProto converts a notification to its proto form
+func convertNotificationToProto(notification *user.Notification) *proto.Notification {
+	pbNotification := &proto.Notification{
+		Id:        int32(notification.ID),
+		Kind:      notification.Kind,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		CreatedAt: timestampProto(notification.CreatedAt),
+	}
+	if notification.ReadAt != nil {
+		pbNotification.ReadAt = timestampProto(*notification.ReadAt)
+	}
+	return pbNotification
+}
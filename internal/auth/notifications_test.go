@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Notifications(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	player := registerTestUser(t, service, "crasher")
+	registerTestUser(t, service, "lurker")
+
+	notified, err := service.NotifyUser(ctx, &proto.NotifyUserRequest{
+		Username: "crasher",
+		Kind:     user.NotificationGameCrashed,
+		Title:    "Your NetHack game crashed",
+		Body:     "The game was killed by segmentation fault.",
+	})
+	require.NoError(t, err)
+	require.True(t, notified.Success, notified.Error)
+
+	missing, err := service.NotifyUser(ctx, &proto.NotifyUserRequest{Username: "nobody", Kind: user.NotificationGameCrashed, Title: "Crashed"})
+	require.NoError(t, err)
+	assert.False(t, missing.Success)
+
+	listed, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.True(t, listed.Success, listed.Error)
+	require.Len(t, listed.Notifications, 1)
+	assert.Equal(t, int32(1), listed.UnreadCount)
+	assert.Equal(t, user.NotificationGameCrashed, listed.Notifications[0].Kind)
+	assert.Equal(t, "Your NetHack game crashed", listed.Notifications[0].Title)
+	assert.Nil(t, listed.Notifications[0].ReadAt)
+
+	marked, err := service.MarkNotificationsRead(ctx, &proto.MarkNotificationsReadRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.True(t, marked.Success, marked.Error)
+	assert.Equal(t, int32(0), marked.UnreadCount)
+
+	unread, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: player.AccessToken, UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Empty(t, unread.Notifications)
+
+	listed, err = service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.Notifications, 1)
+	assert.NotNil(t, listed.Notifications[0].ReadAt)
+}
+
+func TestService_SendNotification(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "organizer")
+	player := registerTestUser(t, service, "entrant")
+	registerTestUser(t, service, "spectator")
+
+	// Only admins may send notifications
+	denied, err := service.SendNotification(ctx, &proto.SendNotificationRequest{
+		AdminToken: player.AccessToken, Title: "Tournament starts in 1 hour",
+	})
+	require.NoError(t, err)
+	assert.False(t, denied.Success)
+
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "organizer"))
+
+	direct, err := service.SendNotification(ctx, &proto.SendNotificationRequest{
+		AdminToken: admin.AccessToken, TargetUsername: "entrant", Title: "Your entry was accepted",
+	})
+	require.NoError(t, err)
+	require.True(t, direct.Success, direct.Error)
+
+	broadcast, err := service.SendNotification(ctx, &proto.SendNotificationRequest{
+		AdminToken: admin.AccessToken, Title: "Tournament starts in 1 hour", Body: "Sign up from the main menu.",
+	})
+	require.NoError(t, err)
+	require.True(t, broadcast.Success, broadcast.Error)
+	// The three players and the fallback admin account
+	assert.Contains(t, broadcast.Message, "4 users")
+
+	listed, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.Notifications, 2)
+	assert.Equal(t, int32(2), listed.UnreadCount)
+	assert.Equal(t, "Tournament starts in 1 hour", listed.Notifications[0].Title)
+	assert.Equal(t, user.NotificationAnnouncement, listed.Notifications[0].Kind)
+
+	// Marking one notification leaves the others unread
+	marked, err := service.MarkNotificationsRead(ctx, &proto.MarkNotificationsReadRequest{
+		AccessToken: player.AccessToken, Ids: []int32{listed.Notifications[1].Id},
+	})
+	require.NoError(t, err)
+	require.True(t, marked.Success, marked.Error)
+	assert.Equal(t, int32(1), marked.UnreadCount)
+}
+
+func TestService_PromoteUserToAdmin_Notifies(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "founder")
+	promoted := registerTestUser(t, service, "helper")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "founder"))
+
+	resp, err := service.PromoteUserToAdmin(ctx, &proto.AdminActionRequest{AdminToken: admin.AccessToken, TargetUsername: "helper"})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+
+	listed, err := service.ListNotifications(ctx, &proto.ListNotificationsRequest{AccessToken: promoted.AccessToken})
+	require.NoError(t, err)
+	require.Len(t, listed.Notifications, 1)
+	assert.Equal(t, user.NotificationPromoted, listed.Notifications[0].Kind)
+	assert.Contains(t, listed.Notifications[0].Body, "founder")
+}
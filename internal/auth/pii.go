@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// EncryptPersonalData encrypts the personal data stored unencrypted or with a
+// key that is no longer primary (admin only)
+func (s *Service) EncryptPersonalData(ctx context.Context, req *proto.EncryptPersonalDataRequest) (*proto.EncryptPersonalDataResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.EncryptPersonalDataResponse{Success: false, Error: errMsg}, err
+	}
+
+	migration, err := s.userSvc.EncryptPersonalData(ctx, req.DryRun)
+	resp := &proto.EncryptPersonalDataResponse{Success: err == nil}
+	if err != nil {
+		resp.Error = fmt.Sprintf("Failed to encrypt personal data: %v", err)
+	}
+	if migration != nil {
+		resp.UsersScanned = int32(migration.Scanned)
+		resp.UsersEncrypted = int32(migration.Encrypted)
+		resp.UsersReencrypted = int32(migration.Reencrypted)
+	}
+
+	s.logger.Info("Admin encrypted personal data",
+		"audit", true,
+		"admin", admin.Username,
+		"scanned", resp.UsersScanned,
+		"encrypted", resp.UsersEncrypted,
+		"reencrypted", resp.UsersReencrypted,
+		"dry_run", req.DryRun,
+	)
+	return resp, nil
+}
@@ -0,0 +1,174 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// renameConfig returns the authentication configuration governing renames,
+// with the defaults when the service has none
+func (s *Service) renameConfig() *config.AuthConfig {
+	if s.sessionLimits == nil {
+		return &config.AuthConfig{}
+	}
+	return s.sessionLimits
+}
+
+// ChangeUsername renames the token user's account, when self-service renames
+// are enabled. Existing tokens stay valid and report the new name.
+func (s *Service) ChangeUsername(ctx context.Context, req *proto.ChangeUsernameRequest) (*proto.ChangeUsernameResponse, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: req.AccessToken,
+	})
+	if err != nil {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   "Failed to validate token",
+		}, err
+	}
+
+	if !validateResp.Valid {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   validateResp.Error,
+		}, nil
+	}
+
+	if isImpersonation(validateResp.User) {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   errImpersonationReadOnly,
+		}, nil
+	}
+
+	cfg := s.renameConfig()
+	if !cfg.UsernameSelfService() {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   "Username changes are made by admins on this server",
+		}, nil
+	}
+
+	oldUsername := validateResp.User.Username
+	current, err := s.userSvc.AuthenticateUser(ctx, oldUsername, req.Password)
+	if err != nil {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   "Password verification failed",
+		}, nil
+	}
+
+	renamed, err := s.userSvc.RenameUser(ctx, &user.RenameRequest{
+		UserID:        current.ID,
+		NewUsername:   req.NewUsername,
+		ChangedBy:     oldUsername,
+		ReuseCooldown: cfg.UsernameReuseCooldown(),
+		MinInterval:   cfg.UsernameMinInterval(),
+	})
+	if err != nil {
+		return &proto.ChangeUsernameResponse{
+			Success: false,
+			Error:   "Username change failed: " + err.Error(),
+		}, nil
+	}
+
+	s.logger.InfoContext(ctx, "Username changed",
+		"audit", true,
+		"user_id", renamed.ID,
+		"old_username", oldUsername,
+		"new_username", renamed.Username,
+	)
+
+	return &proto.ChangeUsernameResponse{
+		Success: true,
+		User:    s.convertUserToProto(ctx, renamed),
+	}, nil
+}
+
+// RenameUser renames a user account (admin only). Admins are not held to
+// the interval between renames, but the old name is still reserved.
+func (s *Service) RenameUser(ctx context.Context, req *proto.RenameUserRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	target, err := s.userSvc.GetUserByUsername(ctx, req.TargetUsername)
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("User '%s' not found", req.TargetUsername),
+		}, nil
+	}
+
+	renamed, err := s.userSvc.RenameUser(ctx, &user.RenameRequest{
+		UserID:        target.ID,
+		NewUsername:   req.NewUsername,
+		ChangedBy:     admin.Username,
+		Reason:        req.Reason,
+		ReuseCooldown: s.renameConfig().UsernameReuseCooldown(),
+	})
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to rename user: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Admin renamed user",
+		"audit", true,
+		"admin", admin.Username,
+		"user_id", renamed.ID,
+		"old_username", target.Username,
+		"new_username", renamed.Username,
+		"reason", req.Reason,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("User '%s' renamed to '%s'", target.Username, renamed.Username),
+	}, nil
+}
+
+// GetUsernameHistory lists the past usernames of a user account (moderator only)
+func (s *Service) GetUsernameHistory(ctx context.Context, req *proto.AdminActionRequest) (*proto.GetUsernameHistoryResponse, error) {
+	moderator, errMsg, err := s.authorizeModerator(ctx, req.AdminToken)
+	if moderator == nil {
+		return &proto.GetUsernameHistoryResponse{Success: false, Error: errMsg}, err
+	}
+
+	target, err := s.userSvc.GetUserByUsername(ctx, req.TargetUsername)
+	if err != nil {
+		return &proto.GetUsernameHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("User '%s' not found", req.TargetUsername),
+		}, nil
+	}
+
+	history, err := s.userSvc.UsernameHistory(ctx, target.ID)
+	if err != nil {
+		return &proto.GetUsernameHistoryResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load username history: %v", err),
+		}, nil
+	}
+
+	resp := &proto.GetUsernameHistoryResponse{Success: true}
+	for _, change := range history {
+		entry := &proto.UsernameChange{
+			OldUsername: change.OldUsername,
+			NewUsername: change.NewUsername,
+			ChangedBy:   change.ChangedBy,
+			ChangedAt:   timestampProto(change.ChangedAt),
+		}
+		if change.ReservedUntil != nil {
+			entry.ReservedUntil = timestampProto(*change.ReservedUntil)
+		}
+		resp.Changes = append(resp.Changes, entry)
+	}
+	return resp, nil
+}
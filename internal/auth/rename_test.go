@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ChangeUsername(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	alice := registerTestUser(t, service, "alice")
+	registerTestUser(t, service, "bob")
+
+	// Self-service renames are off by default
+	resp, err := service.ChangeUsername(ctx, &proto.ChangeUsernameRequest{
+		AccessToken: alice.AccessToken,
+		NewUsername: "alicia",
+		Password:    "testpass123",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	service.sessionLimits = &config.AuthConfig{
+		UsernameChange: &config.UsernameChangeConfig{SelfService: true},
+	}
+
+	resp, err = service.ChangeUsername(ctx, &proto.ChangeUsernameRequest{
+		AccessToken: alice.AccessToken,
+		NewUsername: "alicia",
+		Password:    "wrongpass",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+
+	resp, err = service.ChangeUsername(ctx, &proto.ChangeUsernameRequest{
+		AccessToken: alice.AccessToken,
+		NewUsername: "BOB",
+		Password:    "testpass123",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "already taken")
+
+	resp, err = service.ChangeUsername(ctx, &proto.ChangeUsernameRequest{
+		AccessToken: alice.AccessToken,
+		NewUsername: "alicia",
+		Password:    "testpass123",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+	assert.Equal(t, "alicia", resp.User.Username)
+	assert.Equal(t, alice.User.Id, resp.User.Id)
+
+	// The existing token follows the account to its new name
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: alice.AccessToken})
+	require.NoError(t, err)
+	require.True(t, validated.Valid)
+	assert.Equal(t, "alicia", validated.User.Username)
+
+	login, err := service.Login(ctx, &proto.LoginRequest{Username: "alicia", Password: "testpass123"})
+	require.NoError(t, err)
+	assert.True(t, login.Success, login.Error)
+
+	// A second rename has to wait for the minimum interval
+	resp, err = service.ChangeUsername(ctx, &proto.ChangeUsernameRequest{
+		AccessToken: alice.AccessToken,
+		NewUsername: "ali",
+		Password:    "testpass123",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "changed recently")
+}
+
+func TestService_RenameUser_ReservesOldName(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "bossman")
+	registerTestUser(t, service, "alice")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "bossman"))
+
+	resp, err := service.RenameUser(ctx, &proto.RenameUserRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "alice",
+		NewUsername:    "alicia",
+		Reason:         "requested by email",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+
+	// Admins are not held to the minimum interval
+	resp, err = service.RenameUser(ctx, &proto.RenameUserRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "alicia",
+		NewUsername:    "ali",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+
+	// Nobody else may take an abandoned name during the cooldown
+	register, err := service.Register(ctx, &proto.RegisterRequest{
+		Username: "Alice",
+		Password: "testpass123",
+	})
+	require.NoError(t, err)
+	assert.False(t, register.Success)
+
+	registerTestUser(t, service, "carol")
+	resp, err = service.RenameUser(ctx, &proto.RenameUserRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "carol",
+		NewUsername:    "alicia",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "reserved")
+
+	// ...but its former owner may take it back
+	resp, err = service.RenameUser(ctx, &proto.RenameUserRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "ali",
+		NewUsername:    "alice",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+
+	history, err := service.GetUsernameHistory(ctx, &proto.AdminActionRequest{
+		AdminToken:     admin.AccessToken,
+		TargetUsername: "alice",
+	})
+	require.NoError(t, err)
+	require.True(t, history.Success, history.Error)
+	require.Len(t, history.Changes, 3)
+	assert.Equal(t, "ali", history.Changes[0].OldUsername)
+	assert.Equal(t, "alice", history.Changes[2].OldUsername)
+	assert.Equal(t, "bossman", history.Changes[2].ChangedBy)
+	assert.NotNil(t, history.Changes[2].ReservedUntil)
+}
+
+func TestService_RenameUser_RequiresAdmin(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	player := registerTestUser(t, service, "player")
+	registerTestUser(t, service, "victim")
+
+	resp, err := service.RenameUser(context.Background(), &proto.RenameUserRequest{
+		AdminToken:     player.AccessToken,
+		TargetUsername: "victim",
+		NewUsername:    "renamed",
+	})
+	require.NoError(t, err)
+	assert.False(t, resp.Success)
+	assert.Contains(t, resp.Error, "admin access required")
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strconv"
@@ -9,8 +10,13 @@ import (
 
 	"github.com/dungeongate/internal/user"
 	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/config"
 	"github.com/dungeongate/pkg/database"
 	"github.com/dungeongate/pkg/encryption"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/retention"
 	"github.com/golang-jwt/jwt/v5"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -35,6 +41,24 @@ type Service struct {
 	// Rate limiting
 	maxLoginAttempts int
 	lockoutDuration  time.Duration
+
+	// Session services watching for banner changes
+	bannerWatchers *bannerHub
+
+	// Concurrent login limits and play-time budgets reported to session services
+	sessionLimits *config.AuthConfig
+
+	// Build reported by Health and Version
+	build buildinfo.Info
+
+	// Challenges posed to clients that tripped brute force protection
+	challenges *challengeStore
+
+	// How long inactive accounts and audit logs are kept; nil keeps them
+	retention *retention.Policy
+
+	// Feature flags, configured and stored at runtime
+	flags *featureflags.Flags
 }
 
 // Config holds the configuration for the Auth service
@@ -45,6 +69,9 @@ type Config struct {
 	RefreshTokenExpiration time.Duration `yaml:"refresh_token_expiration"`
 	MaxLoginAttempts       int           `yaml:"max_login_attempts"`
 	LockoutDuration        time.Duration `yaml:"lockout_duration"`
+
+	// SessionLimits holds the concurrent login limits; nil means unlimited
+	SessionLimits *config.AuthConfig `yaml:"-"`
 }
 
 // NewService creates a new Auth service
@@ -66,7 +93,7 @@ func NewService(db *database.Connection, userSvc *user.Service, encryptor encryp
 		config.JWTIssuer = "dungeongate"
 	}
 
-	return &Service{
+	service := &Service{
 		db:                     db,
 		userSvc:                userSvc,
 		encryptor:              encryptor,
@@ -77,7 +104,12 @@ func NewService(db *database.Connection, userSvc *user.Service, encryptor encryp
 		refreshTokenExpiration: config.RefreshTokenExpiration,
 		maxLoginAttempts:       config.MaxLoginAttempts,
 		lockoutDuration:        config.LockoutDuration,
+		bannerWatchers:         newBannerHub(),
+		sessionLimits:          config.SessionLimits,
+		challenges:             newChallengeStore(),
 	}
+	service.SetFeatureFlags(nil)
+	return service
 }
 
 // Login authenticates a user and returns tokens
@@ -86,7 +118,7 @@ func (s *Service) Login(ctx context.Context, req *proto.LoginRequest) (*proto.Lo
 		return &proto.LoginResponse{
 			Success:   false,
 			Error:     "Username and password are required",
-			ErrorCode: "invalid_request",
+			ErrorCode: string(apierror.InvalidRequest),
 		}, nil
 	}
 
@@ -106,43 +138,47 @@ func (s *Service) Login(ctx context.Context, req *proto.LoginRequest) (*proto.Lo
 		return &proto.LoginResponse{
 			Success:           false,
 			Error:             "Account is temporarily locked due to too many failed login attempts",
-			ErrorCode:         "account_locked",
+			ErrorCode:         string(apierror.AccountLocked),
 			RetryAfterSeconds: attemptsResp.LockedUntil - time.Now().Unix(),
 		}, nil
 	}
 
-	// Authenticate user
-	authenticatedUser, err := s.userSvc.AuthenticateUser(ctx, req.Username, req.Password)
+	// Authenticate user. A passed challenge lets a login past a lockout
+	// after failed logins, which may be an attacker's doing.
+	authenticate := s.userSvc.AuthenticateUser
+	if req.ChallengeToken != "" && s.challenges.redeem(req.ChallengeToken, req.Username, req.ClientIp) {
+		authenticate = s.userSvc.AuthenticateUserPastLockout
+	}
+	authenticatedUser, err := authenticate(ctx, req.Username, req.Password)
 	if err != nil {
 		// Determine error type and increment failed attempts
-		var errorCode string
-		switch err.Error() {
-		case "username_not_found":
-			errorCode = "user_not_found"
-		case "invalid_password":
-			errorCode = "invalid_credentials"
-		case "account_locked":
-			errorCode = "account_locked"
+		errorCode := apierror.CodeOf(err)
+		switch errorCode {
+		case apierror.UserNotFound, apierror.InvalidCredentials, apierror.AccountLocked:
 		default:
-			errorCode = "authentication_failed"
+			errorCode = apierror.AuthenticationFailed
 		}
 
 		// Increment failed login attempts
 		s.incrementFailedLoginAttempts(ctx, req.Username, req.ClientIp)
+		s.logger.WarnContext(ctx, "Login failed", "username", req.Username, "client_ip", req.ClientIp, "error_code", errorCode)
 
 		return &proto.LoginResponse{
 			Success:           false,
 			Error:             "Invalid credentials",
-			ErrorCode:         errorCode,
+			ErrorCode:         string(errorCode),
 			RemainingAttempts: attemptsResp.RemainingAttempts - 1,
+			ChallengeRequired: errors.Is(err, user.ErrLockedOut),
 		}, nil
 	}
 
 	// Reset failed login attempts on successful login
 	s.resetFailedLoginAttempts(ctx, req.Username, req.ClientIp)
+	s.logger.InfoContext(ctx, "User logged in", "username", req.Username, "client_ip", req.ClientIp)
 
-	// Generate tokens
-	accessToken, refreshToken, err := s.generateTokens(authenticatedUser)
+	// Generate tokens for the device logged in from
+	deviceID := s.recordDevice(ctx, authenticatedUser, req.Device, req.ClientIp)
+	accessToken, refreshToken, err := s.generateTokens(authenticatedUser, deviceID)
 	if err != nil {
 		return &proto.LoginResponse{
 			Success: false,
@@ -151,7 +187,7 @@ func (s *Service) Login(ctx context.Context, req *proto.LoginRequest) (*proto.Lo
 	}
 
 	// Convert user to proto
-	protoUser := s.convertUserToProto(authenticatedUser)
+	protoUser := s.convertUserToProto(ctx, authenticatedUser)
 
 	return &proto.LoginResponse{
 		Success:               true,
@@ -184,7 +220,7 @@ func (s *Service) RefreshToken(ctx context.Context, req *proto.RefreshTokenReque
 
 	// Parse and validate refresh token
 	claims, err := s.parseToken(req.RefreshToken)
-	if err != nil {
+	if err != nil || claims.Metadata[impersonatedByClaim] != "" {
 		return &proto.RefreshTokenResponse{
 			Success: false,
 			Error:   "Invalid refresh token",
@@ -207,8 +243,16 @@ func (s *Service) RefreshToken(ctx context.Context, req *proto.RefreshTokenReque
 		}, nil
 	}
 
+	// A revoked device has to log in again
+	if s.deviceRevoked(ctx, userIDInt, claims) {
+		return &proto.RefreshTokenResponse{
+			Success: false,
+			Error:   "Device has been revoked",
+		}, nil
+	}
+
 	// Generate new tokens
-	accessToken, refreshToken, err := s.generateTokens(user)
+	accessToken, refreshToken, err := s.generateTokens(user, claims.Metadata[deviceClaim])
 	if err != nil {
 		return &proto.RefreshTokenResponse{
 			Success: false,
@@ -275,8 +319,22 @@ func (s *Service) ValidateToken(ctx context.Context, req *proto.ValidateTokenReq
 		}, nil
 	}
 
+	// Tokens of revoked devices stop working
+	if s.deviceRevoked(ctx, userIDInt, claims) {
+		return &proto.ValidateTokenResponse{
+			Valid: false,
+			Error: "Device has been revoked",
+		}, nil
+	}
+
 	// Convert user to proto
-	protoUser := s.convertUserToProto(user)
+	protoUser := s.convertUserToProto(ctx, user)
+	if impersonator := claims.Metadata[impersonatedByClaim]; impersonator != "" {
+		protoUser.Metadata[impersonatedByClaim] = impersonator
+	}
+	if deviceID := claims.Metadata[deviceClaim]; deviceID != "" {
+		protoUser.Metadata[deviceClaim] = deviceID
+	}
 
 	return &proto.ValidateTokenResponse{
 		Valid:     true,
@@ -330,23 +388,99 @@ func (s *Service) ChangePassword(ctx context.Context, req *proto.ChangePasswordR
 		}, nil
 	}
 
+	if isImpersonation(validateResp.User) {
+		return &proto.ChangePasswordResponse{
+			Success: false,
+			Error:   errImpersonationReadOnly,
+		}, nil
+	}
+
 	// Change password using user service
 	err = s.userSvc.ChangePassword(ctx, validateResp.User.Username, req.CurrentPassword, req.NewPassword)
 	if err != nil {
-		s.logger.Error("Password change failed", "error", err, "username", validateResp.User.Username)
+		s.logger.ErrorContext(ctx, "Password change failed", "error", err, "username", validateResp.User.Username)
 		return &proto.ChangePasswordResponse{
 			Success: false,
 			Error:   "Password change failed: " + err.Error(),
 		}, nil
 	}
 
-	s.logger.Info("Password changed successfully", "username", validateResp.User.Username)
+	s.logger.InfoContext(ctx, "Password changed successfully", "username", validateResp.User.Username)
 
 	return &proto.ChangePasswordResponse{
 		Success: true,
 	}, nil
 }
 
+// SetUserPreference stores one of the token user's preferences
+func (s *Service) SetUserPreference(ctx context.Context, req *proto.SetUserPreferenceRequest) (*proto.SetUserPreferenceResponse, error) {
+	validateResp, err := s.ValidateToken(ctx, &proto.ValidateTokenRequest{
+		AccessToken: req.AccessToken,
+	})
+	if err != nil {
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   "Failed to validate token",
+		}, err
+	}
+
+	if !validateResp.Valid {
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   validateResp.Error,
+		}, nil
+	}
+
+	if isImpersonation(validateResp.User) {
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   errImpersonationReadOnly,
+		}, nil
+	}
+
+	userID, err := strconv.Atoi(validateResp.User.Id)
+	if err != nil {
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   "Invalid user ID",
+		}, nil
+	}
+
+	if err := s.userSvc.SetPreference(ctx, userID, req.Key, req.Value); err != nil {
+		s.logger.ErrorContext(ctx, "Preference update failed", "error", err, "username", validateResp.User.Username, "key", req.Key)
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   "Preference update failed: " + err.Error(),
+		}, nil
+	}
+
+	s.logger.InfoContext(ctx, "User preference updated", "username", validateResp.User.Username, "key", req.Key)
+
+	return &proto.SetUserPreferenceResponse{
+		Success: true,
+	}, nil
+}
+
+// SetShowOnlineStatus sets whether the token user appears in the who list
+// and in their friends' notifications
+func (s *Service) SetShowOnlineStatus(ctx context.Context, req *proto.SetShowOnlineStatusRequest) (*proto.SetUserPreferenceResponse, error) {
+	owner, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if owner == nil {
+		return &proto.SetUserPreferenceResponse{Success: false, Error: errMsg}, err
+	}
+
+	if err := s.userSvc.SetShowOnlineStatus(ctx, userID, req.Show); err != nil {
+		s.logger.ErrorContext(ctx, "Online status update failed", "error", err, "username", owner.Username)
+		return &proto.SetUserPreferenceResponse{
+			Success: false,
+			Error:   "Online status update failed: " + err.Error(),
+		}, nil
+	}
+
+	s.logger.InfoContext(ctx, "User online status updated", "username", owner.Username, "show", req.Show)
+	return &proto.SetUserPreferenceResponse{Success: true}, nil
+}
+
 // ResetPassword initiates password reset flow
 func (s *Service) ResetPassword(ctx context.Context, req *proto.ResetPasswordRequest) (*proto.ResetPasswordResponse, error) {
 	// This would typically send an email with a reset token
@@ -384,7 +518,7 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 		return &proto.RegisterResponse{
 			Success:   false,
 			Error:     "Username and password are required",
-			ErrorCode: "invalid_request",
+			ErrorCode: string(apierror.InvalidRequest),
 		}, nil
 	}
 
@@ -397,6 +531,7 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 		PasswordConfirm: req.Password, // Set same as password
 		Email:           req.Email,
 		AcceptTerms:     true,
+		TermsVersion:    req.TermsVersion,
 		Source:          "api",
 		IPAddress:       req.ClientIp,
 		UserAgent:       req.UserAgent,
@@ -405,17 +540,17 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 	// Create user via user service
 	regResp, err := s.userSvc.RegisterUser(ctx, regReq)
 	if err != nil {
-		s.logger.Error("Failed to register user", "error", err, "username", req.Username)
+		s.logger.ErrorContext(ctx, "Failed to register user", "error", err, "username", req.Username)
 		return &proto.RegisterResponse{
 			Success:   false,
 			Error:     "Registration failed",
-			ErrorCode: "registration_failed",
+			ErrorCode: string(apierror.RegistrationFailed),
 		}, nil
 	}
 
 	if !regResp.Success {
 		// Map validation errors to registration error codes
-		var errorCode string = "registration_failed"
+		errorCode := apierror.RegistrationFailed
 		var errorMessage string = regResp.Message
 
 		if len(regResp.Errors) > 0 {
@@ -425,33 +560,33 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 
 			switch firstError.Code {
 			case "USERNAME_EXISTS":
-				errorCode = "username_taken"
+				errorCode = apierror.UsernameTaken
 			case "USERNAME_INVALID_CHARS":
-				errorCode = "invalid_username"
+				errorCode = apierror.InvalidUsername
 			case "EMAIL_INVALID":
-				errorCode = "invalid_email"
+				errorCode = apierror.InvalidEmail
 			case "PASSWORD_TOO_SHORT":
-				errorCode = "invalid_password"
+				errorCode = apierror.InvalidPassword
 			case "PASSWORD_REQUIRED":
-				errorCode = "invalid_password"
+				errorCode = apierror.InvalidPassword
 			default:
-				errorCode = "registration_failed"
+				errorCode = apierror.RegistrationFailed
 			}
 		}
 
 		return &proto.RegisterResponse{
 			Success:   false,
 			Error:     errorMessage,
-			ErrorCode: errorCode,
+			ErrorCode: string(errorCode),
 		}, nil
 	}
 
 	userObj := regResp.User
 
 	// Generate tokens for the new user
-	accessToken, refreshToken, err := s.generateTokens(userObj)
+	accessToken, refreshToken, err := s.generateTokens(userObj, "")
 	if err != nil {
-		s.logger.Error("Failed to generate tokens for new user", "error", err, "username", req.Username)
+		s.logger.ErrorContext(ctx, "Failed to generate tokens for new user", "error", err, "username", req.Username)
 		return &proto.RegisterResponse{
 			Success: false,
 			Error:   "Failed to generate authentication tokens",
@@ -459,7 +594,7 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 	}
 
 	// Convert user to proto
-	protoUser := s.convertUserToProto(userObj)
+	protoUser := s.convertUserToProto(ctx, userObj)
 
 	return &proto.RegisterResponse{
 		Success:               true,
@@ -471,18 +606,36 @@ func (s *Service) Register(ctx context.Context, req *proto.RegisterRequest) (*pr
 	}, nil
 }
 
+// SetBuild sets the build reported by Health and Version, as shown on the
+// credits and server statistics screens
+func (s *Service) SetBuild(build buildinfo.Info) {
+	s.build = build
+}
+
 // Health returns the health status of the service
 func (s *Service) Health(ctx context.Context, req *emptypb.Empty) (*proto.HealthResponse, error) {
+	build := s.build
+	if build.Version == "" {
+		build.Version = "unknown"
+	}
 	return &proto.HealthResponse{
-		Status: "healthy",
-		Details: map[string]string{
-			"service": "auth",
-			"version": "1.0.0",
-		},
+		Status:    "healthy",
+		Details:   build.Details(map[string]string{"service": "auth"}),
 		Timestamp: timestampProto(time.Now()),
 	}, nil
 }
 
+// Version returns the build of the service
+func (s *Service) Version(ctx context.Context, req *emptypb.Empty) (*proto.VersionResponse, error) {
+	return &proto.VersionResponse{
+		Service:   s.build.Service,
+		Version:   s.build.Version,
+		BuildTime: s.build.BuildTime,
+		GitCommit: s.build.GitCommit,
+		GoVersion: s.build.GoVersion,
+	}, nil
+}
+
 // Admin Management Methods
 
 // UnlockUserAccount unlocks a user account (admin only)
@@ -538,7 +691,7 @@ func (s *Service) UnlockUserAccount(ctx context.Context, req *proto.AdminActionR
 		}, nil
 	}
 
-	s.logger.Info("User account unlocked by admin",
+	s.logger.InfoContext(ctx, "User account unlocked by admin",
 		"admin_user", adminUser.Username,
 		"target_user", req.TargetUsername,
 	)
@@ -602,7 +755,7 @@ func (s *Service) DeleteUserAccount(ctx context.Context, req *proto.AdminActionR
 		}, nil
 	}
 
-	s.logger.Info("User account deleted by admin",
+	s.logger.InfoContext(ctx, "User account deleted by admin",
 		"admin_user", adminUser.Username,
 		"target_user", req.TargetUsername,
 	)
@@ -666,7 +819,7 @@ func (s *Service) ResetUserPassword(ctx context.Context, req *proto.ResetPasswor
 		}, nil
 	}
 
-	s.logger.Info("User password reset by admin",
+	s.logger.InfoContext(ctx, "User password reset by admin",
 		"admin_user", adminUser.Username,
 		"target_user", req.TargetUsername,
 	)
@@ -730,10 +883,12 @@ func (s *Service) PromoteUserToAdmin(ctx context.Context, req *proto.AdminAction
 		}, nil
 	}
 
-	s.logger.Info("User promoted to admin",
+	s.logger.InfoContext(ctx, "User promoted to admin",
 		"admin_user", adminUser.Username,
 		"target_user", req.TargetUsername,
 	)
+	s.notify(ctx, req.TargetUsername, user.NotificationPromoted, "You were promoted to admin",
+		fmt.Sprintf("%s gave you admin access. The admin tools are on your main menu from your next login.", adminUser.Username))
 
 	return &proto.AdminActionResponse{
 		Success: true,
@@ -799,6 +954,7 @@ func (s *Service) GetServerStatistics(ctx context.Context, req *proto.ServerStat
 	for key, value := range stats {
 		stringStats[key] = fmt.Sprintf("%v", value)
 	}
+	s.clusterStatistics(ctx, stringStats)
 
 	return &proto.ServerStatsResponse{
 		Success: true,
@@ -808,8 +964,12 @@ func (s *Service) GetServerStatistics(ctx context.Context, req *proto.ServerStat
 
 // Private helper methods
 
-func (s *Service) generateTokens(user *user.User) (string, string, error) {
+func (s *Service) generateTokens(user *user.User, deviceID string) (string, string, error) {
 	now := time.Now()
+	var metadata map[string]string
+	if deviceID != "" {
+		metadata = map[string]string{deviceClaim: deviceID}
+	}
 
 	// Generate access token
 	accessClaims := &proto.TokenClaims{
@@ -820,6 +980,7 @@ func (s *Service) generateTokens(user *user.User) (string, string, error) {
 		ExpiresAt: now.Add(s.accessTokenExpiration).Unix(),
 		NotBefore: now.Unix(),
 		Issuer:    s.jwtIssuer,
+		Metadata:  metadata,
 	}
 
 	accessToken, err := s.createToken(accessClaims)
@@ -836,6 +997,7 @@ func (s *Service) generateTokens(user *user.User) (string, string, error) {
 		ExpiresAt: now.Add(s.refreshTokenExpiration).Unix(),
 		NotBefore: now.Unix(),
 		Issuer:    s.jwtIssuer,
+		Metadata:  metadata,
 	}
 
 	refreshToken, err := s.createToken(refreshClaims)
@@ -847,7 +1009,7 @@ func (s *Service) generateTokens(user *user.User) (string, string, error) {
 }
 
 func (s *Service) createToken(claims *proto.TokenClaims) (string, error) {
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	mapClaims := jwt.MapClaims{
 		"user_id":  claims.UserId,
 		"username": claims.Username,
 		"email":    claims.Email,
@@ -855,7 +1017,14 @@ func (s *Service) createToken(claims *proto.TokenClaims) (string, error) {
 		"exp":      claims.ExpiresAt,
 		"nbf":      claims.NotBefore,
 		"iss":      claims.Issuer,
-	})
+	}
+	if impersonator := claims.Metadata[impersonatedByClaim]; impersonator != "" {
+		mapClaims[impersonatedByClaim] = impersonator
+	}
+	if deviceID := claims.Metadata[deviceClaim]; deviceID != "" {
+		mapClaims[deviceClaim] = deviceID
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, mapClaims)
 
 	return token.SignedString(s.jwtSecret)
 }
@@ -877,7 +1046,7 @@ func (s *Service) parseToken(tokenString string) (*proto.TokenClaims, error) {
 		return nil, fmt.Errorf("invalid token")
 	}
 
-	return &proto.TokenClaims{
+	tokenClaims := &proto.TokenClaims{
 		UserId:    claims["user_id"].(string),
 		Username:  claims["username"].(string),
 		Email:     claims["email"].(string),
@@ -885,10 +1054,19 @@ func (s *Service) parseToken(tokenString string) (*proto.TokenClaims, error) {
 		ExpiresAt: int64(claims["exp"].(float64)),
 		NotBefore: int64(claims["nbf"].(float64)),
 		Issuer:    claims["iss"].(string),
-	}, nil
+	}
+	for _, name := range []string{impersonatedByClaim, deviceClaim} {
+		if value, ok := claims[name].(string); ok && value != "" {
+			if tokenClaims.Metadata == nil {
+				tokenClaims.Metadata = make(map[string]string)
+			}
+			tokenClaims.Metadata[name] = value
+		}
+	}
+	return tokenClaims, nil
 }
 
-func (s *Service) convertUserToProto(userObj *user.User) *proto.User {
+func (s *Service) convertUserToProto(ctx context.Context, userObj *user.User) *proto.User {
 	var lastLogin *timestamppb.Timestamp
 	if userObj.LastLogin != nil {
 		lastLogin = timestampProto(*userObj.LastLogin)
@@ -913,9 +1091,124 @@ func (s *Service) convertUserToProto(userObj *user.User) *proto.User {
 	}
 	protoUser.Metadata["require_password_change"] = strconv.FormatBool(userObj.RequirePasswordChange)
 
+	// Add spectator permission and online status from the user's profile
+	allowSpectators, showOnlineStatus := true, true
+	if s.userSvc != nil {
+		if profile, err := s.userSvc.GetUserProfile(ctx, userObj.ID); err == nil {
+			allowSpectators = profile.AllowSpectators
+			showOnlineStatus = profile.ShowOnlineStatus
+		} else {
+			s.logger.WarnContext(ctx, "Failed to load user profile", "error", err, "user_id", userObj.ID)
+		}
+	}
+	protoUser.Metadata["allow_spectators"] = strconv.FormatBool(allowSpectators)
+	protoUser.Metadata["show_online_status"] = strconv.FormatBool(showOnlineStatus)
+
+	// Preferences are passed as pref.<key>, e.g. pref.game_version.nethack
+	if s.userSvc != nil {
+		preferences, err := s.userSvc.GetPreferences(ctx, userObj.ID)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load user preferences", "error", err, "user_id", userObj.ID)
+		}
+		for key, value := range preferences {
+			protoUser.Metadata["pref."+key] = value
+		}
+	}
+
+	// Session services scope leaderboards to the user's group
+	if s.userSvc != nil {
+		if group, err := s.userSvc.GetUserGroup(ctx, userObj.ID); err == nil {
+			protoUser.Metadata["group"] = group.Name
+		} else if !errors.Is(err, user.ErrNotInGroup) {
+			s.logger.WarnContext(ctx, "Failed to load user group", "error", err, "user_id", userObj.ID)
+		}
+	}
+
+	// Session services tell users about support access they have not seen yet
+	if s.userSvc != nil {
+		if unseen, err := s.userSvc.CountUnseenAccountAccess(ctx, userObj.ID); err == nil && unseen > 0 {
+			protoUser.Metadata["unseen_account_access"] = strconv.Itoa(unseen)
+		} else if err != nil {
+			s.logger.WarnContext(ctx, "Failed to count account access", "error", err, "user_id", userObj.ID)
+		}
+	}
+
+	// Session services block the menu until the current terms are accepted
+	if s.userSvc != nil {
+		if pending, err := s.userSvc.PendingTerms(ctx, userObj.ID); err == nil && pending != nil {
+			protoUser.Metadata["terms_pending"] = pending.Version
+		} else if err != nil {
+			s.logger.WarnContext(ctx, "Failed to check terms acceptance", "error", err, "user_id", userObj.ID)
+		}
+	}
+
+	// Session services enforce the concurrent login limit
+	if s.sessionLimits != nil {
+		limit, policy := s.sessionLimits.SessionLimitFor(userObj.Username)
+		protoUser.Metadata["max_concurrent_sessions"] = strconv.Itoa(limit)
+		protoUser.Metadata["multi_login_policy"] = policy
+	}
+
+	// Session services offer self-service renames only when they are enabled
+	protoUser.Metadata["username_self_service"] = strconv.FormatBool(s.renameConfig().UsernameSelfService())
+
+	if userObj.IsModerator() {
+		protoUser.Roles = append(protoUser.Roles, "moderator")
+	}
+	if userObj.Flags&user.UserFlagBeta != 0 {
+		protoUser.Roles = append(protoUser.Roles, "beta")
+	}
+
+	// Only the restricted flag is exposed; the others are for moderators
+	if s.userSvc != nil {
+		restricted, err := s.userSvc.HasModerationFlag(ctx, userObj.ID, user.ModerationFlagRestricted)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load moderation flags", "error", err, "user_id", userObj.ID)
+		}
+		protoUser.Metadata["restricted"] = strconv.FormatBool(restricted)
+	}
+
+	// Session services pass play-time budgets on to the game service
+	s.addPlayTimeLimit(ctx, userObj, protoUser)
+
 	return protoUser
 }
 
+// addPlayTimeLimit reports the user's play-time budget as play_time_daily
+// and play_time_weekly in seconds, with play_time_warn, unless the user has
+// none or a moderator exempted them
+func (s *Service) addPlayTimeLimit(ctx context.Context, userObj *user.User, protoUser *proto.User) {
+	if s.sessionLimits == nil || s.sessionLimits.PlayTime == nil {
+		return
+	}
+	if s.userSvc != nil {
+		exempt, err := s.userSvc.HasModerationFlag(ctx, userObj.ID, user.ModerationFlagPlayTimeExempt)
+		if err != nil {
+			s.logger.WarnContext(ctx, "Failed to load moderation flags", "error", err, "user_id", userObj.ID)
+		}
+		if exempt {
+			return
+		}
+	}
+
+	// Limits are configured by role and by group
+	roles := append([]string(nil), protoUser.Roles...)
+	if protoUser.IsAdmin {
+		roles = append(roles, "admin")
+	}
+	if group := protoUser.Metadata["group"]; group != "" {
+		roles = append(roles, group)
+	}
+
+	daily, weekly := s.sessionLimits.PlayTimeLimitFor(userObj.Username, roles)
+	if daily <= 0 && weekly <= 0 {
+		return
+	}
+	protoUser.Metadata["play_time_daily"] = strconv.Itoa(int(daily.Seconds()))
+	protoUser.Metadata["play_time_weekly"] = strconv.Itoa(int(weekly.Seconds()))
+	protoUser.Metadata["play_time_warn"] = strconv.Itoa(int(s.sessionLimits.PlayTimeWarning().Seconds()))
+}
+
 func (s *Service) incrementFailedLoginAttempts(ctx context.Context, username, clientIP string) {
 	// This would increment failed login attempts in the database
 	// For now, just log it
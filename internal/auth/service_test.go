@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strconv"
 	"testing"
 	"time"
 
@@ -111,6 +112,32 @@ func TestService_Register_Success(t *testing.T) {
 	assert.NotEmpty(t, resp.User.Id)
 }
 
+func TestService_Register_RecordsTermsVersion(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	resp, err := service.Register(ctx, &proto.RegisterRequest{
+		Username:     "termsuser",
+		Password:     "testpass123",
+		TermsVersion: "2024-01",
+	})
+	require.NoError(t, err)
+	require.True(t, resp.Success, resp.Error)
+
+	userID, err := strconv.Atoi(resp.User.Id)
+	require.NoError(t, err)
+
+	acceptedAt, err := service.userSvc.TermsAcceptedAt(ctx, userID, "2024-01")
+	require.NoError(t, err)
+	assert.False(t, acceptedAt.IsZero())
+
+	acceptedAt, err = service.userSvc.TermsAcceptedAt(ctx, userID, "2025-01")
+	require.NoError(t, err)
+	assert.True(t, acceptedAt.IsZero())
+}
+
 func TestService_Register_MissingUsername(t *testing.T) {
 	service, _, cleanup := setupTestService(t)
 	defer cleanup()
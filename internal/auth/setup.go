@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/dungeongate/internal/user"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/database"
+	"github.com/dungeongate/pkg/encryption"
+	"github.com/dungeongate/pkg/retention"
+)
+
+// NewServiceFromConfig creates the Auth service and the user service beneath
+// it from the auth service configuration. The JWT secret is read from
+// JWT_SECRET, or generated when unset.
+func NewServiceFromConfig(db *database.Connection, cfg *config.UserServiceConfig, logger *slog.Logger) (*Service, error) {
+	// Setup encryption
+	encryptor, err := encryption.New(&config.EncryptionConfig{
+		Enabled:             true,
+		Algorithm:           "AES-256-GCM",
+		KeyRotationInterval: "24h",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+
+	// Setup user service
+	sessionCfg := config.GetDefaultDevelopmentConfig()
+	userService, err := user.NewService(db, cfg, sessionCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user service: %w", err)
+	}
+
+	retentionPolicy, err := retention.NewPolicy(cfg.Retention)
+	if err != nil {
+		return nil, fmt.Errorf("invalid retention config: %w", err)
+	}
+
+	// Generate JWT secret if not provided
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		logger.Info("JWT_SECRET not set, generating random secret (not recommended for production)")
+		secretBytes := make([]byte, 32)
+		if _, err := rand.Read(secretBytes); err != nil {
+			return nil, fmt.Errorf("failed to generate JWT secret: %w", err)
+		}
+		jwtSecret = hex.EncodeToString(secretBytes)
+	}
+
+	authConfig := &Config{
+		JWTSecret:              jwtSecret,
+		JWTIssuer:              "dungeongate-auth",
+		AccessTokenExpiration:  15 * time.Minute,
+		RefreshTokenExpiration: 7 * 24 * time.Hour,
+		MaxLoginAttempts:       3,
+		LockoutDuration:        15 * time.Minute,
+		SessionLimits:          cfg.Authentication,
+	}
+
+	service := NewService(db, userService, *encryptor, authConfig, logger)
+	service.SetRetention(retentionPolicy)
+	service.SetFeatureFlags(cfg.FeatureFlags)
+	return service, nil
+}
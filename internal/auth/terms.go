@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dungeongate/internal/user"
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// GetTerms returns a published version of the terms of service, the current
+// one when no version is asked for. Anyone may read the terms, including
+// players who are still registering.
+func (s *Service) GetTerms(ctx context.Context, req *proto.TermsRequest) (*proto.TermsResponse, error) {
+	var terms *user.TermsVersion
+	var err error
+	if req.Version == "" {
+		terms, err = s.userSvc.CurrentTerms(ctx)
+	} else {
+		terms, err = s.userSvc.GetTerms(ctx, req.Version)
+	}
+
+	if errors.Is(err, user.ErrNoTerms) {
+		if req.Version == "" {
+			return &proto.TermsResponse{Success: true}, nil
+		}
+		return &proto.TermsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Terms version not found: %s", req.Version),
+		}, nil
+	}
+	if err != nil {
+		return &proto.TermsResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load terms: %v", err),
+		}, nil
+	}
+
+	return &proto.TermsResponse{
+		Success: true,
+		Terms:   convertTermsToProto(terms),
+	}, nil
+}
+
+// PublishTerms publishes a new version of the terms of service (admin only).
+// Every user who has not accepted it is asked to at their next login.
+func (s *Service) PublishTerms(ctx context.Context, req *proto.PublishTermsRequest) (*proto.AdminActionResponse, error) {
+	admin, errMsg, err := s.authorizeAdmin(ctx, req.AdminToken)
+	if admin == nil {
+		return &proto.AdminActionResponse{Success: false, Error: errMsg}, err
+	}
+
+	terms, err := s.userSvc.PublishTerms(ctx, strings.TrimSpace(req.Version), req.Content, admin.Username)
+	if err != nil {
+		return &proto.AdminActionResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to publish terms: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("Admin published terms of service",
+		"audit", true,
+		"admin_user", admin.Username,
+		"version", terms.Version,
+	)
+
+	return &proto.AdminActionResponse{
+		Success: true,
+		Message: fmt.Sprintf("Published terms version %s", terms.Version),
+	}, nil
+}
+
+// AcceptTerms records that the token user accepted a published version of
+// the terms of service
+func (s *Service) AcceptTerms(ctx context.Context, req *proto.AcceptTermsRequest) (*proto.TermsStatusResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, true)
+	if member == nil {
+		return &proto.TermsStatusResponse{Success: false, Error: errMsg}, err
+	}
+
+	if _, err := s.userSvc.GetTerms(ctx, req.Version); err != nil {
+		return &proto.TermsStatusResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Terms version not found: %s", req.Version),
+		}, nil
+	}
+
+	if err := s.userSvc.AcceptTerms(ctx, userID, req.Version); err != nil {
+		return &proto.TermsStatusResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to accept terms: %v", err),
+		}, nil
+	}
+
+	s.logger.Info("User accepted terms of service", "audit", true, "username", member.Username, "version", req.Version)
+	return s.termsStatus(ctx, userID)
+}
+
+// GetTermsStatus reports whether a user accepted the current terms of
+// service and lists every acceptance. Moderators may look up other users.
+func (s *Service) GetTermsStatus(ctx context.Context, req *proto.TermsStatusRequest) (*proto.TermsStatusResponse, error) {
+	member, userID, errMsg, err := s.authorizeUser(ctx, req.AccessToken, false)
+	if member == nil {
+		return &proto.TermsStatusResponse{Success: false, Error: errMsg}, err
+	}
+
+	if req.Username != "" && !strings.EqualFold(req.Username, member.Username) {
+		moderator, errMsg, err := s.authorizeModerator(ctx, req.AccessToken)
+		if moderator == nil {
+			return &proto.TermsStatusResponse{Success: false, Error: errMsg}, err
+		}
+		target, err := s.userSvc.GetUserByUsername(ctx, req.Username)
+		if err != nil {
+			return &proto.TermsStatusResponse{
+				Success: false,
+				Error:   fmt.Sprintf("User not found: %s", req.Username),
+			}, nil
+		}
+		userID = target.ID
+	}
+
+	return s.termsStatus(ctx, userID)
+}
+
+// termsStatus builds a user's terms acceptance state
+func (s *Service) termsStatus(ctx context.Context, userID int) (*proto.TermsStatusResponse, error) {
+	acceptances, err := s.userSvc.ListTermsAcceptances(ctx, userID)
+	if err != nil {
+		return &proto.TermsStatusResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load terms acceptance: %v", err),
+		}, nil
+	}
+
+	resp := &proto.TermsStatusResponse{Success: true, Accepted: true}
+	current, err := s.userSvc.CurrentTerms(ctx)
+	switch {
+	case errors.Is(err, user.ErrNoTerms):
+	case err != nil:
+		return &proto.TermsStatusResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to load terms: %v", err),
+		}, nil
+	default:
+		resp.CurrentVersion = current.Version
+		resp.Accepted = false
+	}
+
+	for _, acceptance := range acceptances {
+		if acceptance.Version == resp.CurrentVersion {
+			resp.Accepted = true
+		}
+		resp.Acceptances = append(resp.Acceptances, &proto.TermsAcceptance{
+			Version:    acceptance.Version,
+			AcceptedAt: timestampProto(acceptance.AcceptedAt),
+		})
+	}
+	return resp, nil
+}
+
+// convertTermsToProto converts published terms to their proto form
+func convertTermsToProto(terms *user.TermsVersion) *proto.Terms {
+	return &proto.Terms{
+		Version:     terms.Version,
+		Content:     terms.Content,
+		PublishedAt: timestampProto(terms.PublishedAt),
+	}
+}
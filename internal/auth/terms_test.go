@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	proto "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Terms(t *testing.T) {
+	service, _, cleanup := setupTestService(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	admin := registerTestUser(t, service, "termsadmin")
+	player := registerTestUser(t, service, "player")
+	require.NoError(t, service.userSvc.PromoteUserToAdmin(ctx, "termsadmin"))
+
+	// Nothing to accept until terms are published
+	none, err := service.GetTerms(ctx, &proto.TermsRequest{})
+	require.NoError(t, err)
+	require.True(t, none.Success, none.Error)
+	assert.Nil(t, none.Terms)
+
+	status, err := service.GetTermsStatus(ctx, &proto.TermsStatusRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.True(t, status.Accepted)
+
+	denied, err := service.PublishTerms(ctx, &proto.PublishTermsRequest{AdminToken: player.AccessToken, Version: "v1", Content: "Be nice."})
+	require.NoError(t, err)
+	assert.False(t, denied.Success)
+
+	published, err := service.PublishTerms(ctx, &proto.PublishTermsRequest{AdminToken: admin.AccessToken, Version: "v1", Content: "Be nice."})
+	require.NoError(t, err)
+	require.True(t, published.Success, published.Error)
+
+	current, err := service.GetTerms(ctx, &proto.TermsRequest{})
+	require.NoError(t, err)
+	require.NotNil(t, current.Terms)
+	assert.Equal(t, "v1", current.Terms.Version)
+	assert.Equal(t, "Be nice.", current.Terms.Content)
+
+	validated, err := service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.Equal(t, "v1", validated.User.Metadata["terms_pending"])
+
+	accepted, err := service.AcceptTerms(ctx, &proto.AcceptTermsRequest{AccessToken: player.AccessToken, Version: "v1"})
+	require.NoError(t, err)
+	require.True(t, accepted.Success, accepted.Error)
+	assert.True(t, accepted.Accepted)
+	require.Len(t, accepted.Acceptances, 1)
+
+	validated, err = service.ValidateToken(ctx, &proto.ValidateTokenRequest{AccessToken: player.AccessToken})
+	require.NoError(t, err)
+	assert.Empty(t, validated.User.Metadata["terms_pending"])
+
+	// A new version must be accepted again
+	_, err = service.PublishTerms(ctx, &proto.PublishTermsRequest{AdminToken: admin.AccessToken, Version: "v2", Content: "Be nicer."})
+	require.NoError(t, err)
+	status, err = service.GetTermsStatus(ctx, &proto.TermsStatusRequest{AccessToken: admin.AccessToken, Username: "player"})
+	require.NoError(t, err)
+	require.True(t, status.Success, status.Error)
+	assert.Equal(t, "v2", status.CurrentVersion)
+	assert.False(t, status.Accepted)
+	require.Len(t, status.Acceptances, 1)
+	assert.Equal(t, "v1", status.Acceptances[0].Version)
+
+	// Players cannot look up each other
+	other, err := service.GetTermsStatus(ctx, &proto.TermsStatusRequest{AccessToken: player.AccessToken, Username: "termsadmin"})
+	require.NoError(t, err)
+	assert.False(t, other.Success)
+
+	unknown, err := service.AcceptTerms(ctx, &proto.AcceptTermsRequest{AccessToken: player.AccessToken, Version: "v9"})
+	require.NoError(t, err)
+	assert.False(t, unknown.Success)
+
+	duplicate, err := service.PublishTerms(ctx, &proto.PublishTermsRequest{AdminToken: admin.AccessToken, Version: "v2", Content: "Changed."})
+	require.NoError(t, err)
+	assert.False(t, duplicate.Success)
+}
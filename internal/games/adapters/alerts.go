@@ -0,0 +1,120 @@
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/config"
+)
+
+// defaultAlertMessage is the banner of an alert rule without a message
+const defaultAlertMessage = "$PLAYER: $STATUS"
+
+// StatusAlerter is implemented by adapters that raise alerts from their
+// game's status, configured under settings.status_line.alerts
+type StatusAlerter interface {
+	// StatusAlerts returns the alerts the status on the bottom rows of the
+	// screen meets, and false when the rows show no status
+	StatusAlerts(rows []string, player string) ([]domain.StatusAlert, bool)
+}
+
+// alertRule is a compiled status_line.alerts rule
+type alertRule struct {
+	name           string
+	hpBelowPercent int
+	depthAtLeast   int
+	level          *regexp.Regexp
+	conditions     []string
+	message        string
+}
+
+// newAlertRules compiles a game's alert rules
+func newAlertRules(configs []config.StatusAlertConfig) ([]alertRule, error) {
+	rules := make([]alertRule, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, cfg := range configs {
+		if cfg.Name == "" {
+			return nil, fmt.Errorf("status_line.alerts: every alert needs a name")
+		}
+		if seen[cfg.Name] {
+			return nil, fmt.Errorf("status_line.alerts: alert %q is defined twice", cfg.Name)
+		}
+		seen[cfg.Name] = true
+		if cfg.HPBelowPercent == 0 && cfg.DepthAtLeast == 0 && cfg.Level == "" && len(cfg.Conditions) == 0 {
+			return nil, fmt.Errorf("status_line.alerts: alert %q sets no condition", cfg.Name)
+		}
+		if cfg.HPBelowPercent < 0 || cfg.HPBelowPercent > 100 {
+			return nil, fmt.Errorf("status_line.alerts: alert %q has hp_below_percent %d, outside 1 to 100", cfg.Name, cfg.HPBelowPercent)
+		}
+
+		rule := alertRule{
+			name:           cfg.Name,
+			hpBelowPercent: cfg.HPBelowPercent,
+			depthAtLeast:   cfg.DepthAtLeast,
+			conditions:     cfg.Conditions,
+			message:        cfg.Message,
+		}
+		if rule.message == "" {
+			rule.message = defaultAlertMessage
+		}
+		if cfg.Level != "" {
+			level, err := regexp.Compile(cfg.Level)
+			if err != nil {
+				return nil, fmt.Errorf("status_line.alerts: alert %q has an invalid level: %w", cfg.Name, err)
+			}
+			rule.level = level
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// matches reports whether the status meets every condition the rule sets
+func (r alertRule) matches(status NetHackStatus) bool {
+	if r.hpBelowPercent > 0 && (status.HPMax <= 0 || status.HP*100 >= r.hpBelowPercent*status.HPMax) {
+		return false
+	}
+	if r.depthAtLeast > 0 && status.Depth < r.depthAtLeast {
+		return false
+	}
+	if r.level != nil && !r.level.MatchString(status.Level) {
+		return false
+	}
+	if len(r.conditions) > 0 && !slices.ContainsFunc(r.conditions, func(condition string) bool {
+		return slices.Contains(status.Conditions, condition)
+	}) {
+		return false
+	}
+	return true
+}
+
+// alert builds the alert the rule raises for a player's status
+func (r alertRule) alert(player string, status NetHackStatus) domain.StatusAlert {
+	statusLine := status.String()
+	message := strings.NewReplacer(
+		"$PLAYER", player,
+		"$STATUS", statusLine,
+		"$LEVEL", status.Level,
+		"$DEPTH", strconv.Itoa(status.Depth),
+		"$HPMAX", strconv.Itoa(status.HPMax),
+		"$HP", strconv.Itoa(status.HP),
+		"$XL", strconv.Itoa(status.XL),
+		"$TURN", strconv.Itoa(status.Turn),
+	).Replace(r.message)
+	return domain.StatusAlert{Rule: r.name, Message: message, StatusLine: statusLine}
+}
+
+// checkAlerts returns the alerts a status meets
+func checkAlerts(rules []alertRule, player string, status NetHackStatus) []domain.StatusAlert {
+	var met []domain.StatusAlert
+	for _, rule := range rules {
+		if rule.matches(status) {
+			met = append(met, rule.alert(player, status))
+		}
+	}
+	return met
+}
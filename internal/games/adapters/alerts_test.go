@@ -0,0 +1,63 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestStatusAlerts(t *testing.T) {
+	nethack := NewNetHackAdapter(nil)
+	require.NoError(t, nethack.Configure(statusLineConfig("nethack", &config.StatusLineConfig{
+		Enabled: true,
+		Alerts: []config.StatusAlertConfig{
+			{Name: "low_hp", HPBelowPercent: 20, Message: "$PLAYER is down to $HP of $HPMAX HP on $LEVEL!"},
+			{Name: "gehennom", DepthAtLeast: 30},
+			{Name: "deadly", Conditions: []string{"Stone", "Slime"}},
+			{Name: "astral", Level: "Astral Plane"},
+		},
+	})))
+
+	alerts, ok := nethack.StatusAlerts([]string{"Dlvl:31 $:0 HP:9(60) Pw:1(1) AC:-2 Xp:14/99999 T:40000 Slime"}, "agent")
+	require.True(t, ok)
+	require.Len(t, alerts, 3)
+	assert.Equal(t, "low_hp", alerts[0].Rule)
+	assert.Equal(t, "agent is down to 9 of 60 HP on Dlvl:31!", alerts[0].Message)
+	assert.Equal(t, "gehennom", alerts[1].Rule)
+	assert.Equal(t, "agent: Dlvl:31 HP:9/60 XL:14 Slime", alerts[1].Message)
+	assert.Equal(t, "Dlvl:31 HP:9/60 XL:14 Slime", alerts[1].StatusLine)
+	assert.Equal(t, "deadly", alerts[2].Rule)
+
+	alerts, ok = nethack.StatusAlerts([]string{"Astral Plane $:0 HP:120(120) Pw:5(80) AC:-12 Xp:30 T:50000"}, "agent")
+	require.True(t, ok)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "astral", alerts[0].Rule)
+
+	// Rows without a status raise nothing and say so
+	_, ok = nethack.StatusAlerts([]string{"--More--"}, "agent")
+	assert.False(t, ok)
+}
+
+func TestNewAlertRules_Invalid(t *testing.T) {
+	for name, alert := range map[string]config.StatusAlertConfig{
+		"no name":      {HPBelowPercent: 20},
+		"no condition": {Name: "empty"},
+		"bad percent":  {Name: "low_hp", HPBelowPercent: 120},
+		"bad level":    {Name: "level", Level: "("},
+	} {
+		_, err := newAlertRules([]config.StatusAlertConfig{alert})
+		assert.Error(t, err, name)
+	}
+	_, err := newAlertRules([]config.StatusAlertConfig{{Name: "a", DepthAtLeast: 1}, {Name: "a", DepthAtLeast: 2}})
+	assert.Error(t, err, "duplicate names")
+
+	// Alerts need NetHack's status
+	game := NewDefaultAdapter("crawl")
+	assert.Error(t, game.Configure(statusLineConfig("crawl", &config.StatusLineConfig{
+		Enabled: true,
+		Alerts:  []config.StatusAlertConfig{{Name: "deep", DepthAtLeast: 10}},
+	})))
+}
@@ -2,6 +2,7 @@ package adapters
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
 
 	"github.com/dungeongate/internal/games/domain"
@@ -11,6 +12,7 @@ import (
 // DefaultAdapter provides a basic implementation for games that don't need special handling
 type DefaultAdapter struct {
 	gameID string
+	status *statusRule
 }
 
 // NewDefaultAdapter creates a new default adapter
@@ -25,9 +27,17 @@ func (a *DefaultAdapter) GetGameID() string {
 	return a.gameID
 }
 
-// Configure does nothing for default adapters
+// Configure reads the game's status line rule; default adapters need no
+// other configuration
 func (a *DefaultAdapter) Configure(config *config.GameConfig) error {
-	// Default adapter doesn't need configuration
+	status, err := newStatusRule(config)
+	if err != nil {
+		return err
+	}
+	if status != nil && len(status.alerts) > 0 {
+		return fmt.Errorf("status_line.alerts are only raised for NetHack")
+	}
+	a.status = status
 	return nil
 }
 
@@ -69,3 +79,20 @@ func (a *DefaultAdapter) SetupGameEnvironment(session *domain.GameSession) error
 func (a *DefaultAdapter) CleanupGameEnvironment(session *domain.GameSession) error {
 	return nil
 }
+
+// ParseStatus applies the configured status line pattern; without one the
+// game's status is not known
+func (a *DefaultAdapter) ParseStatus(rows []string) string {
+	if a.status == nil || a.status.pattern == nil {
+		return ""
+	}
+	return a.status.apply(rows)
+}
+
+// CheckSaveCompatibility requires saves to come from the same game version
+func (a *DefaultAdapter) CheckSaveCompatibility(saveVersion, gameVersion string) error {
+	if saveVersion == "" || gameVersion == "" || saveVersion == gameVersion {
+		return nil
+	}
+	return fmt.Errorf("save from %s version %s cannot be loaded by version %s", a.gameID, saveVersion, gameVersion)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
+	"sync"
 
 	"github.com/dungeongate/internal/games/domain"
 	"github.com/dungeongate/pkg/config"
@@ -37,17 +38,42 @@ type GameAdapter interface {
 
 	// CleanupGameEnvironment performs any post-game cleanup
 	CleanupGameEnvironment(session *domain.GameSession) error
+
+	// CheckSaveCompatibility returns an error if a save written by saveVersion
+	// of the game cannot be loaded by gameVersion. Empty versions are unknown
+	// and not checked.
+	CheckSaveCompatibility(saveVersion, gameVersion string) error
+
+	// ParseStatus summarises the game's status, such as "Dlvl:12 HP:45/60",
+	// from the bottom rows of its screen. It returns "" when the game's
+	// status is not read or the screen does not show it.
+	ParseStatus(rows []string) string
 }
 
 // GameAdapterRegistry manages game adapters
 type GameAdapterRegistry struct {
+	mu       sync.RWMutex
 	adapters map[string]GameAdapter
+	// versioned holds adapters configured for an additional installed
+	// version of a game, keyed by versionKey
+	versioned map[string]GameAdapter
+	// hooks holds the setup and cleanup hooks of games that have any
+	hooks map[string]GameHooks
+}
+
+// GameHooks are the scripts configured to run around a game's sessions,
+// shared by all its versions
+type GameHooks struct {
+	Setup   *config.GameHookConfig
+	Cleanup *config.GameHookConfig
 }
 
 // NewGameAdapterRegistry creates a new adapter registry
 func NewGameAdapterRegistry() *GameAdapterRegistry {
 	registry := &GameAdapterRegistry{
-		adapters: make(map[string]GameAdapter),
+		adapters:  make(map[string]GameAdapter),
+		versioned: make(map[string]GameAdapter),
+		hooks:     make(map[string]GameHooks),
 	}
 
 	// Register built-in adapters (without configuration)
@@ -59,7 +85,9 @@ func NewGameAdapterRegistry() *GameAdapterRegistry {
 // NewGameAdapterRegistryWithConfig creates a new adapter registry with configuration
 func NewGameAdapterRegistryWithConfig(gameConfigs []*config.GameConfig) (*GameAdapterRegistry, error) {
 	registry := &GameAdapterRegistry{
-		adapters: make(map[string]GameAdapter),
+		adapters:  make(map[string]GameAdapter),
+		versioned: make(map[string]GameAdapter),
+		hooks:     make(map[string]GameHooks),
 	}
 
 	// Create a map of configs by game ID for easy lookup
@@ -70,34 +98,138 @@ func NewGameAdapterRegistryWithConfig(gameConfigs []*config.GameConfig) (*GameAd
 		}
 	}
 
+	for id, gameConfig := range configMap {
+		hooks := GameHooks{Setup: gameConfig.GetSetupOptions().Hook, Cleanup: gameConfig.GetCleanupOptions().Hook}
+		if hooks.Setup != nil || hooks.Cleanup != nil {
+			registry.hooks[id] = hooks
+		}
+	}
+
+	// Games adapted by plugins use them in place of any built-in adapter
+	plugged := make(map[string]bool)
+	for id, gameConfig := range configMap {
+		if gameConfig.Adapter == nil || gameConfig.Adapter.Plugin == nil {
+			continue
+		}
+		plugged[id] = true
+		adapter := NewPluginAdapter(id, gameConfig.Adapter.Plugin)
+		if err := adapter.Configure(gameConfig); err != nil {
+			return nil, fmt.Errorf("failed to configure %s plugin adapter: %w", id, err)
+		}
+		registry.Register(adapter)
+
+		for _, version := range gameConfig.Versions {
+			versionConfig, err := gameConfig.ForVersion(version.Version)
+			if err != nil {
+				return nil, err
+			}
+			adapter := NewPluginAdapter(id, gameConfig.Adapter.Plugin)
+			if err := adapter.Configure(versionConfig); err != nil {
+				return nil, fmt.Errorf("failed to configure %s %s plugin adapter: %w", id, version.Version, err)
+			}
+			registry.versioned[versionKey(id, version.Version)] = adapter
+		}
+	}
+
 	// Register and configure built-in adapters
-	if nethackConfig, exists := configMap["nethack"]; exists {
+	if nethackConfig, exists := configMap["nethack"]; exists && !plugged["nethack"] {
 		adapter := NewNetHackAdapter(nil)
 		if err := adapter.Configure(nethackConfig); err != nil {
 			return nil, fmt.Errorf("failed to configure NetHack adapter: %w", err)
 		}
 		registry.Register(adapter)
+
+		for _, version := range nethackConfig.Versions {
+			versionConfig, err := nethackConfig.ForVersion(version.Version)
+			if err != nil {
+				return nil, err
+			}
+			adapter := NewNetHackAdapter(nil)
+			if err := adapter.Configure(versionConfig); err != nil {
+				return nil, fmt.Errorf("failed to configure NetHack %s adapter: %w", version.Version, err)
+			}
+			registry.versioned[versionKey("nethack", version.Version)] = adapter
+		}
+	}
+
+	// Other games get a default adapter configured to read their status line
+	for id, gameConfig := range configMap {
+		if id == "nethack" || plugged[id] || gameConfig.Settings == nil || gameConfig.Settings.StatusLine == nil {
+			continue
+		}
+		adapter := NewDefaultAdapter(id)
+		if err := adapter.Configure(gameConfig); err != nil {
+			return nil, fmt.Errorf("failed to configure %s adapter: %w", id, err)
+		}
+		registry.Register(adapter)
 	}
 
 	return registry, nil
 }
 
+// Reconfigure replaces the adapters with those configured for gameConfigs,
+// for games defined while the service runs. Games already running keep the
+// adapter they started with.
+func (r *GameAdapterRegistry) Reconfigure(gameConfigs []*config.GameConfig) error {
+	configured, err := NewGameAdapterRegistryWithConfig(gameConfigs)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.adapters = configured.adapters
+	r.versioned = configured.versioned
+	r.hooks = configured.hooks
+	return nil
+}
+
 // Register registers a new game adapter
 func (r *GameAdapterRegistry) Register(adapter GameAdapter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.adapters[adapter.GetGameID()] = adapter
 }
 
 // GetAdapter returns the adapter for a specific game ID
 func (r *GameAdapterRegistry) GetAdapter(gameID string) GameAdapter {
-	if adapter, exists := r.adapters[gameID]; exists {
+	r.mu.RLock()
+	adapter, exists := r.adapters[gameID]
+	r.mu.RUnlock()
+	if exists {
 		return adapter
 	}
 	// Return default adapter if none found
 	return NewDefaultAdapter(gameID)
 }
 
+// GetAdapterForVersion returns the adapter for an installed version of a game,
+// falling back to the game's adapter
+func (r *GameAdapterRegistry) GetAdapterForVersion(gameID, version string) GameAdapter {
+	r.mu.RLock()
+	adapter, exists := r.versioned[versionKey(gameID, version)]
+	r.mu.RUnlock()
+	if exists {
+		return adapter
+	}
+	return r.GetAdapter(gameID)
+}
+
+// GetHooks returns the hooks configured for a game
+func (r *GameAdapterRegistry) GetHooks(gameID string) GameHooks {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hooks[gameID]
+}
+
+// versionKey identifies a version of a game in the registry
+func versionKey(gameID, version string) string {
+	return gameID + "@" + version
+}
+
 // HasAdapter checks if an adapter exists for the given game ID
 func (r *GameAdapterRegistry) HasAdapter(gameID string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	_, exists := r.adapters[gameID]
 	return exists
 }
@@ -13,9 +13,14 @@ import (
 	"github.com/dungeongate/pkg/config"
 )
 
+// UserHomesDir holds the per-player home directories, named user_<id>, that
+// NetHack's user paths are relative to
+const UserHomesDir = "/tmp/nethack-users"
+
 // NetHackAdapter handles NetHack-specific setup and configuration
 type NetHackAdapter struct {
 	config *config.GameConfig
+	status *statusRule
 	logger *slog.Logger
 }
 
@@ -43,7 +48,12 @@ func (a *NetHackAdapter) Configure(gameConfig *config.GameConfig) error {
 	if gameConfig.ID != "nethack" {
 		return fmt.Errorf("invalid game ID: expected 'nethack', got '%s'", gameConfig.ID)
 	}
+	status, err := newStatusRule(gameConfig)
+	if err != nil {
+		return err
+	}
 	a.config = gameConfig
+	a.status = status
 	return nil
 }
 
@@ -60,18 +70,21 @@ func (a *NetHackAdapter) PrepareCommand(ctx context.Context, session *domain.Gam
 	args := []string{"-u", username}
 
 	// Enhanced environment for NetHack with configuration-driven paths
-	homeDir := fmt.Sprintf("/tmp/nethack-users/%s", username)
+	homeDir := filepath.Join(UserHomesDir, username)
 	userGameDir := fmt.Sprintf("%s/%s", homeDir, a.config.Paths.User.BaseDir)
 
-	// Get system path from configuration
-	systemPath := "/opt/homebrew/Cellar/nethack/3.6.7/libexec" // Default fallback
+	// Get system path from configuration, falling back to the working
+	// directory and then to the directory holding the binary
+	systemPath := filepath.Dir(gamePath)
 	if a.config.Paths != nil && a.config.Paths.System != nil && a.config.Paths.System.SysConfFile != "" {
 		systemPath = filepath.Dir(a.config.Paths.System.SysConfFile)
+	} else if a.config.Binary != nil && a.config.Binary.WorkingDirectory != "" {
+		systemPath = a.config.Binary.WorkingDirectory
 	}
 
-	env := append(os.Environ(),
-		// Terminal configuration - use basic xterm for better compatibility
-		fmt.Sprintf("TERM=%s", "xterm"),
+	// The base environment holds the negotiated terminal type and locale
+	env := append(os.Environ(), baseEnv...)
+	env = append(env,
 		fmt.Sprintf("USER=%s", username),
 		fmt.Sprintf("LOGNAME=%s", username),
 		fmt.Sprintf("HOME=%s", homeDir),
@@ -190,7 +203,7 @@ func (a *NetHackAdapter) SetupGameEnvironment(session *domain.GameSession) error
 	}
 
 	username := fmt.Sprintf("user_%d", session.UserID().Int())
-	homeDir := fmt.Sprintf("/tmp/nethack-users/%s", username)
+	homeDir := filepath.Join(UserHomesDir, username)
 
 	// Create all required NetHack directories using configuration
 	directories := []string{
@@ -225,3 +238,53 @@ func (a *NetHackAdapter) CleanupGameEnvironment(session *domain.GameSession) err
 	a.logger.Debug("NetHack cleanup completed", "session_id", session.ID().String())
 	return nil
 }
+
+// CheckSaveCompatibility accepts saves from the same NetHack release series.
+// NetHack refuses saves written by another major or minor version (a 3.6.6
+// save loads in 3.6.7 but not in 3.7.0).
+func (a *NetHackAdapter) CheckSaveCompatibility(saveVersion, gameVersion string) error {
+	if saveVersion == "" || gameVersion == "" {
+		return nil
+	}
+	if releaseSeries(saveVersion) != releaseSeries(gameVersion) {
+		return fmt.Errorf("NetHack %s save cannot be loaded by NetHack %s", saveVersion, gameVersion)
+	}
+	return nil
+}
+
+// releaseSeries returns the major.minor part of a version such as "3.6.7"
+func releaseSeries(version string) string {
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	if len(parts) < 2 {
+		return parts[0]
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// ParseStatus reads NetHack's status lines, or applies the configured
+// pattern when there is one
+func (a *NetHackAdapter) ParseStatus(rows []string) string {
+	if a.status == nil {
+		return ""
+	}
+	if a.status.pattern != nil {
+		return a.status.apply(rows)
+	}
+	status, ok := ParseNetHackStatus(a.status.bottom(rows))
+	if !ok {
+		return ""
+	}
+	return status.String()
+}
+
+// StatusAlerts returns the configured alerts NetHack's status lines meet
+func (a *NetHackAdapter) StatusAlerts(rows []string, player string) ([]domain.StatusAlert, bool) {
+	if a.status == nil || len(a.status.alerts) == 0 {
+		return nil, false
+	}
+	status, ok := ParseNetHackStatus(a.status.bottom(rows))
+	if !ok {
+		return nil, false
+	}
+	return checkAlerts(a.status.alerts, player, status), true
+}
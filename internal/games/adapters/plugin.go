@@ -0,0 +1,491 @@
+package adapters
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/adapterplugin"
+	adapterv1 "github.com/dungeongate/pkg/api/games/adapter/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// pluginClient is the connection to one adapter plugin, shared by every game
+// and version it adapts. A launched plugin starts on first use, and again
+// on the next use after it exits.
+type pluginClient struct {
+	config *config.AdapterPluginConfig
+	logger *slog.Logger
+
+	mu     sync.Mutex
+	conn   *grpc.ClientConn
+	client adapterv1.GameAdapterPluginClient
+	cmd    *exec.Cmd
+	// generation counts connections, so adapters configure each plugin
+	// process they reach
+	generation int
+}
+
+// plugins holds the adapter plugins of the game service by endpoint, so
+// registries rebuilt as games are redefined reuse them
+var plugins = struct {
+	sync.Mutex
+	clients map[string]*pluginClient
+}{clients: make(map[string]*pluginClient)}
+
+// sharedPlugin returns the client of the plugin a game is configured with
+func sharedPlugin(pluginConfig *config.AdapterPluginConfig) *pluginClient {
+	key := "address:" + pluginConfig.Address
+	if pluginConfig.Command != "" {
+		key = "command:" + strings.Join(append([]string{pluginConfig.Command}, pluginConfig.Args...), "\x00")
+	}
+
+	plugins.Lock()
+	defer plugins.Unlock()
+	if client, ok := plugins.clients[key]; ok {
+		return client
+	}
+	client := &pluginClient{
+		config: pluginConfig,
+		logger: slog.Default().With("component", "adapter-plugin", "plugin", pluginConfig.Command+pluginConfig.Address),
+	}
+	plugins.clients[key] = client
+	return client
+}
+
+// ClosePlugins stops the adapter plugins the game service launched and
+// closes its connections to the others
+func ClosePlugins() {
+	plugins.Lock()
+	defer plugins.Unlock()
+	for key, client := range plugins.clients {
+		client.close()
+		delete(plugins.clients, key)
+	}
+}
+
+// connect returns the plugin's client and connection generation, launching
+// or dialing the plugin if it is not connected
+func (c *pluginClient) connect(ctx context.Context) (adapterv1.GameAdapterPluginClient, int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil {
+		return c.client, c.generation, nil
+	}
+
+	target := c.config.Address
+	var cmd *exec.Cmd
+	if c.config.Command != "" {
+		var handshake adapterplugin.Handshake
+		var err error
+		cmd, handshake, err = c.launch(ctx)
+		if err != nil {
+			return nil, 0, err
+		}
+		target = handshake.Address
+		if handshake.Network == "unix" {
+			target = "unix://" + handshake.Address
+		}
+	}
+
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		if cmd != nil {
+			stopProcess(cmd)
+		}
+		return nil, 0, fmt.Errorf("failed to connect to adapter plugin: %w", err)
+	}
+	c.conn, c.client, c.cmd = conn, adapterv1.NewGameAdapterPluginClient(conn), cmd
+	c.generation++
+	if cmd != nil {
+		go c.wait(cmd)
+	}
+	c.logger.Info("Connected to adapter plugin", "target", target)
+	return c.client, c.generation, nil
+}
+
+// launch starts the plugin command and reads its handshake
+func (c *pluginClient) launch(ctx context.Context) (*exec.Cmd, adapterplugin.Handshake, error) {
+	cmd := exec.Command(c.config.Command, c.config.Args...)
+	cmd.Env = append(os.Environ(), adapterplugin.MagicCookieKey+"="+adapterplugin.MagicCookieValue)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, adapterplugin.Handshake{}, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, adapterplugin.Handshake{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, adapterplugin.Handshake{}, fmt.Errorf("failed to launch adapter plugin: %w", err)
+	}
+	go c.logOutput(stderr)
+
+	lines := make(chan string, 1)
+	go func() {
+		reader := bufio.NewReader(stdout)
+		line, _ := reader.ReadString('\n')
+		lines <- line
+		c.logOutput(reader)
+	}()
+
+	var line string
+	select {
+	case line = <-lines:
+	case <-ctx.Done():
+		stopProcess(cmd)
+		return nil, adapterplugin.Handshake{}, fmt.Errorf("adapter plugin did not start: %w", ctx.Err())
+	}
+	handshake, err := adapterplugin.ParseHandshake(line)
+	if err != nil {
+		stopProcess(cmd)
+		return nil, adapterplugin.Handshake{}, err
+	}
+	return cmd, handshake, nil
+}
+
+// logOutput logs what the plugin writes besides its handshake
+func (c *pluginClient) logOutput(output io.Reader) {
+	scanner := bufio.NewScanner(output)
+	for scanner.Scan() {
+		c.logger.Info("Adapter plugin: " + scanner.Text())
+	}
+}
+
+// wait forgets a launched plugin when it exits, so the next call starts it
+// again
+func (c *pluginClient) wait(cmd *exec.Cmd) {
+	err := cmd.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd != cmd {
+		return
+	}
+	c.logger.Warn("Adapter plugin exited", "error", err)
+	c.conn.Close()
+	c.conn, c.client, c.cmd = nil, nil, nil
+}
+
+// close disconnects from the plugin, stopping it if it was launched
+func (c *pluginClient) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn != nil {
+		c.conn.Close()
+	}
+	if c.cmd != nil {
+		stopProcess(c.cmd)
+	}
+	c.conn, c.client, c.cmd = nil, nil, nil
+}
+
+// stopProcess asks a plugin to stop, killing it if it cannot be asked
+func stopProcess(cmd *exec.Cmd) {
+	if err := cmd.Process.Signal(syscall.SIGTERM); err != nil {
+		cmd.Process.Kill()
+	}
+}
+
+// PluginAdapter adapts a game through an external adapter plugin. Calls the
+// plugin leaves unimplemented get the default adapter's behaviour. The
+// plugin is only reached once a game needs it, so configuring adapters, as
+// validating game definitions does, never launches one.
+type PluginAdapter struct {
+	gameID  string
+	plugin  *pluginClient
+	timeout time.Duration
+	logger  *slog.Logger
+	// fallback handles the calls the plugin does not
+	fallback *DefaultAdapter
+
+	mu         sync.Mutex
+	version    string
+	configYAML []byte
+	// generation is the plugin connection last configured, 0 for none
+	generation int
+	info       *adapterv1.ConfigureResponse
+}
+
+// NewPluginAdapter creates an adapter for a game served by a plugin
+func NewPluginAdapter(gameID string, pluginConfig *config.AdapterPluginConfig) *PluginAdapter {
+	plugin := sharedPlugin(pluginConfig)
+	return &PluginAdapter{
+		gameID:   gameID,
+		plugin:   plugin,
+		timeout:  pluginConfig.GetTimeout(),
+		logger:   plugin.logger.With("game_id", gameID),
+		fallback: NewDefaultAdapter(gameID),
+	}
+}
+
+// GetGameID returns the game ID this adapter handles
+func (a *PluginAdapter) GetGameID() string {
+	return a.gameID
+}
+
+// Configure keeps the game's configuration for the plugin, which receives
+// it when first reached
+func (a *PluginAdapter) Configure(gameConfig *config.GameConfig) error {
+	if gameConfig == nil {
+		return fmt.Errorf("configuration cannot be nil")
+	}
+	if gameConfig.ID != a.gameID {
+		return fmt.Errorf("invalid game ID: expected '%s', got '%s'", a.gameID, gameConfig.ID)
+	}
+	configYAML, err := yaml.Marshal(gameConfig)
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration for the adapter plugin: %w", err)
+	}
+	if err := a.fallback.Configure(gameConfig); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.version, a.configYAML = gameConfig.Version, configYAML
+	a.generation, a.info = 0, nil
+	return nil
+}
+
+// ready returns the plugin's client, configuring the game with the plugin
+// process first if it has not been
+func (a *PluginAdapter) ready(ctx context.Context) (adapterv1.GameAdapterPluginClient, *adapterv1.ConfigureResponse, error) {
+	client, generation, err := a.plugin.connect(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.generation == generation {
+		return client, a.info, nil
+	}
+	resp, err := client.Configure(ctx, &adapterv1.ConfigureRequest{Game: a.game(), ConfigYaml: a.configYAML})
+	if status.Code(err) == codes.Unimplemented {
+		resp, err = &adapterv1.ConfigureResponse{}, nil
+	}
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("adapter plugin failed to configure %s: %w", a.gameID, err)
+	}
+	a.generation, a.info = generation, resp
+	return client, resp, nil
+}
+
+// game identifies the adapted game to the plugin
+func (a *PluginAdapter) game() *adapterv1.Game {
+	return &adapterv1.Game{Id: a.gameID, Version: a.version}
+}
+
+// call returns a context bounding one call to the plugin
+func (a *PluginAdapter) call(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, a.timeout)
+}
+
+// PrepareCommand runs the command the plugin returns
+func (a *PluginAdapter) PrepareCommand(ctx context.Context, session *domain.GameSession, gamePath string, baseArgs []string, baseEnv []string) (*exec.Cmd, error) {
+	ctx, cancel := a.call(ctx)
+	defer cancel()
+	client, _, err := a.ready(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.PrepareCommand(ctx, &adapterv1.PrepareCommandRequest{
+		Game:     a.game(),
+		Session:  pluginSession(session),
+		GamePath: gamePath,
+		Args:     baseArgs,
+		Env:      baseEnv,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return a.fallback.PrepareCommand(ctx, session, gamePath, baseArgs, baseEnv)
+	}
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("adapter plugin failed to prepare %s: %w", a.gameID, err)
+	}
+
+	path := resp.Path
+	if path == "" {
+		path = gamePath
+	}
+	// Like the default adapter, the command is not bound to ctx so the game
+	// outlives the call that started it
+	cmd := exec.Command(path, resp.Args...)
+	cmd.Env = resp.Env
+	cmd.Dir = resp.Dir
+	return cmd, nil
+}
+
+// GetInitialInput returns the input the plugin sends the game once it starts
+func (a *PluginAdapter) GetInitialInput() []byte {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	_, info, err := a.ready(ctx)
+	if err != nil {
+		a.logger.Warn("Adapter plugin unavailable for initial input", "error", err)
+		return nil
+	}
+	if len(info.InitialInput) == 0 {
+		return nil
+	}
+	return info.InitialInput
+}
+
+// ProcessOutput passes output through the plugin when it asked to see it,
+// showing it unchanged when the plugin fails
+func (a *PluginAdapter) ProcessOutput(data []byte) []byte {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	client, info, err := a.ready(ctx)
+	if err != nil || !info.ProcessesOutput {
+		return data
+	}
+	resp, err := client.ProcessOutput(ctx, &adapterv1.OutputRequest{Game: a.game(), Data: data})
+	if err != nil {
+		a.logger.Debug("Adapter plugin failed to process output", "error", err)
+		return data
+	}
+	return resp.Data
+}
+
+// IsGameReady asks the plugin when it checks readiness; otherwise, or when
+// the plugin fails, the game is ready
+func (a *PluginAdapter) IsGameReady(output []byte) bool {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	client, info, err := a.ready(ctx)
+	if err != nil || !info.ChecksReady {
+		return true
+	}
+	resp, err := client.IsGameReady(ctx, &adapterv1.OutputRequest{Game: a.game(), Data: output})
+	if err != nil {
+		return true
+	}
+	return resp.Ready
+}
+
+// GetRequiredFiles returns the files the plugin needs for the game
+func (a *PluginAdapter) GetRequiredFiles() []string {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	_, info, err := a.ready(ctx)
+	if err != nil {
+		return nil
+	}
+	return info.RequiredFiles
+}
+
+// SetupGameEnvironment has the plugin prepare for a session
+func (a *PluginAdapter) SetupGameEnvironment(session *domain.GameSession) error {
+	return a.sessionCall(session, "set up", adapterv1.GameAdapterPluginClient.SetupGameEnvironment)
+}
+
+// CleanupGameEnvironment has the plugin clean up after a session
+func (a *PluginAdapter) CleanupGameEnvironment(session *domain.GameSession) error {
+	return a.sessionCall(session, "clean up", adapterv1.GameAdapterPluginClient.CleanupGameEnvironment)
+}
+
+// sessionCall makes a call about a session that the plugin may leave
+// unimplemented
+func (a *PluginAdapter) sessionCall(session *domain.GameSession, what string,
+	rpc func(adapterv1.GameAdapterPluginClient, context.Context, *adapterv1.SessionRequest, ...grpc.CallOption) (*adapterv1.AdapterResponse, error)) error {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	client, _, err := a.ready(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := rpc(client, ctx, &adapterv1.SessionRequest{Game: a.game(), Session: pluginSession(session)})
+	if status.Code(err) == codes.Unimplemented {
+		return nil
+	}
+	if err == nil && resp.Error != "" {
+		err = errors.New(resp.Error)
+	}
+	if err != nil {
+		return fmt.Errorf("adapter plugin failed to %s %s: %w", what, a.gameID, err)
+	}
+	return nil
+}
+
+// CheckSaveCompatibility asks the plugin whether a save can be loaded
+func (a *PluginAdapter) CheckSaveCompatibility(saveVersion, gameVersion string) error {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	client, _, err := a.ready(ctx)
+	if err != nil {
+		return err
+	}
+	resp, err := client.CheckSaveCompatibility(ctx, &adapterv1.SaveCompatibilityRequest{
+		Game:        a.game(),
+		SaveVersion: saveVersion,
+		GameVersion: gameVersion,
+	})
+	if status.Code(err) == codes.Unimplemented {
+		return a.fallback.CheckSaveCompatibility(saveVersion, gameVersion)
+	}
+	if err != nil {
+		return fmt.Errorf("adapter plugin failed to check the save: %w", err)
+	}
+	if resp.Error != "" {
+		return errors.New(resp.Error)
+	}
+	return nil
+}
+
+// ParseStatus has the plugin read the game's status, or applies the
+// configured status line pattern when the plugin does not
+func (a *PluginAdapter) ParseStatus(rows []string) string {
+	ctx, cancel := a.call(context.Background())
+	defer cancel()
+	client, _, err := a.ready(ctx)
+	if err != nil {
+		return ""
+	}
+	resp, err := client.ParseStatus(ctx, &adapterv1.ParseStatusRequest{Game: a.game(), Rows: rows})
+	if status.Code(err) == codes.Unimplemented {
+		return a.fallback.ParseStatus(rows)
+	}
+	if err != nil {
+		a.logger.Debug("Adapter plugin failed to parse status", "error", err)
+		return ""
+	}
+	return resp.Status
+}
+
+// pluginSession describes a session to the plugin
+func pluginSession(session *domain.GameSession) *adapterv1.Session {
+	size := session.TerminalSize()
+	return &adapterv1.Session{
+		Id:             session.ID().String(),
+		UserId:         int32(session.UserID().Int()),
+		Username:       session.Username(),
+		TerminalWidth:  int32(size.Width),
+		TerminalHeight: int32(size.Height),
+		TermType:       session.TerminalType(),
+		Locale:         session.Locale(),
+	}
+}
@@ -0,0 +1,156 @@
+package adapters
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/adapterplugin"
+	adapterv1 "github.com/dungeongate/pkg/api/games/adapter/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// fakePlugin adapts a game by shouting its output, leaving save and status
+// handling to the default adapter
+type fakePlugin struct {
+	adapterv1.UnimplementedGameAdapterPluginServer
+}
+
+func (fakePlugin) Configure(_ context.Context, req *adapterv1.ConfigureRequest) (*adapterv1.ConfigureResponse, error) {
+	if !bytes.Contains(req.ConfigYaml, []byte("id: "+req.Game.Id)) {
+		return &adapterv1.ConfigureResponse{Error: "configuration of another game"}, nil
+	}
+	return &adapterv1.ConfigureResponse{InitialInput: []byte("y"), ProcessesOutput: true}, nil
+}
+
+func (fakePlugin) PrepareCommand(_ context.Context, req *adapterv1.PrepareCommandRequest) (*adapterv1.PrepareCommandResponse, error) {
+	return &adapterv1.PrepareCommandResponse{
+		Args: append([]string{"-u", req.Session.Username}, req.Args...),
+		Env:  append(req.Env, "GAME_VERSION="+req.Game.Version),
+		Dir:  "/var/games/" + req.Game.Id,
+	}, nil
+}
+
+func (fakePlugin) ProcessOutput(_ context.Context, req *adapterv1.OutputRequest) (*adapterv1.OutputResponse, error) {
+	return &adapterv1.OutputResponse{Data: bytes.ToUpper(req.Data)}, nil
+}
+
+// TestPluginProcess is the plugin launched by TestPluginAdapter_Command
+func TestPluginProcess(t *testing.T) {
+	if os.Getenv(adapterplugin.MagicCookieKey) == "" {
+		t.Skip("only run as a launched plugin")
+	}
+	if err := adapterplugin.Serve(fakePlugin{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pluginGameConfig(plugin *config.AdapterPluginConfig) *config.GameConfig {
+	return &config.GameConfig{
+		ID:      "brogue",
+		Enabled: true,
+		Version: "1.12",
+		Adapter: &config.GameAdapterConfig{Plugin: plugin},
+		Settings: &config.GameSettings{StatusLine: &config.StatusLineConfig{
+			Enabled: true,
+			Pattern: `Depth: (?P<depth>\d+)`,
+			Format:  "Depth ${depth}",
+		}},
+	}
+}
+
+func testPluginSession() *domain.GameSession {
+	return domain.NewGameSession(
+		domain.NewSessionID("session-1"),
+		domain.NewUserID(7),
+		"player",
+		domain.NewGameID("brogue"),
+		domain.GameConfig{},
+		domain.TerminalSize{Width: 80, Height: 24},
+	)
+}
+
+func assertPluginAdapter(t *testing.T, registry *GameAdapterRegistry) {
+	adapter := registry.GetAdapter("brogue")
+	require.IsType(t, &PluginAdapter{}, adapter)
+
+	cmd, err := adapter.PrepareCommand(context.Background(), testPluginSession(), "/usr/games/brogue", []string{"--seed", "1"}, []string{"TERM=xterm"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/usr/games/brogue", "-u", "player", "--seed", "1"}, cmd.Args)
+	assert.Equal(t, []string{"TERM=xterm", "GAME_VERSION=1.12"}, cmd.Env)
+	assert.Equal(t, "/var/games/brogue", cmd.Dir)
+
+	assert.Equal(t, []byte("y"), adapter.GetInitialInput())
+	assert.Equal(t, []byte("HELLO"), adapter.ProcessOutput([]byte("hello")))
+	assert.True(t, adapter.IsGameReady(nil), "readiness is only asked when the plugin checks it")
+	assert.NoError(t, adapter.SetupGameEnvironment(testPluginSession()), "unimplemented setup is skipped")
+
+	// Calls the plugin leaves unimplemented get the default adapter's
+	assert.Equal(t, "Depth 3", adapter.ParseStatus([]string{"Depth: 3"}))
+	assert.NoError(t, adapter.CheckSaveCompatibility("1.12", "1.12"))
+}
+
+func TestPluginAdapter_Address(t *testing.T) {
+	t.Cleanup(ClosePlugins)
+	socket := filepath.Join(t.TempDir(), "plugin.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	adapterv1.RegisterGameAdapterPluginServer(server, fakePlugin{})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	registry, err := NewGameAdapterRegistryWithConfig([]*config.GameConfig{
+		pluginGameConfig(&config.AdapterPluginConfig{Address: "unix://" + socket}),
+	})
+	require.NoError(t, err)
+	assertPluginAdapter(t, registry)
+}
+
+func TestPluginAdapter_Command(t *testing.T) {
+	t.Cleanup(ClosePlugins)
+	registry, err := NewGameAdapterRegistryWithConfig([]*config.GameConfig{
+		pluginGameConfig(&config.AdapterPluginConfig{Command: os.Args[0], Args: []string{"-test.run=^TestPluginProcess$"}}),
+	})
+	require.NoError(t, err)
+	assertPluginAdapter(t, registry)
+
+	// A plugin that exits is launched again on the next call
+	client := sharedPlugin(&config.AdapterPluginConfig{Command: os.Args[0], Args: []string{"-test.run=^TestPluginProcess$"}})
+	client.mu.Lock()
+	cmd := client.cmd
+	client.mu.Unlock()
+	require.NotNil(t, cmd)
+	require.NoError(t, cmd.Process.Signal(syscall.SIGTERM))
+	assert.Eventually(t, func() bool {
+		client.mu.Lock()
+		defer client.mu.Unlock()
+		return client.cmd == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assertPluginAdapter(t, registry)
+}
+
+func TestPluginAdapter_Unavailable(t *testing.T) {
+	t.Cleanup(ClosePlugins)
+	// Configuring never launches the plugin, so games validate without it
+	registry, err := NewGameAdapterRegistryWithConfig([]*config.GameConfig{
+		pluginGameConfig(&config.AdapterPluginConfig{Command: filepath.Join(t.TempDir(), "missing")}),
+	})
+	require.NoError(t, err)
+
+	adapter := registry.GetAdapter("brogue")
+	_, err = adapter.PrepareCommand(context.Background(), testPluginSession(), "/usr/games/brogue", nil, nil)
+	assert.Error(t, err)
+	assert.Equal(t, []byte("hello"), adapter.ProcessOutput([]byte("hello")), "output passes through an unavailable plugin")
+	assert.True(t, adapter.IsGameReady(nil))
+}
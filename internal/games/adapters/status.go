@@ -0,0 +1,194 @@
+package adapters
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+const (
+	// MaxStatusRows is how many rows at the bottom of a game's screen are
+	// read for its status
+	MaxStatusRows = 3
+	// defaultStatusRows is how many of them a status line rule reads by default
+	defaultStatusRows = 2
+	// maxStatusLineLength bounds a status line, in characters, so it fits
+	// next to the session in the watch menu
+	maxStatusLineLength = 60
+)
+
+// statusRule reads a game's status off the bottom rows of its screen, as
+// configured under settings.status_line
+type statusRule struct {
+	rows    int
+	pattern *regexp.Regexp // nil leaves parsing to the adapter
+	format  string
+	alerts  []alertRule
+}
+
+// newStatusRule compiles a game's status line rule; it returns nil when the
+// game's status is not read
+func newStatusRule(gameConfig *config.GameConfig) (*statusRule, error) {
+	if gameConfig == nil || gameConfig.Settings == nil || gameConfig.Settings.StatusLine == nil || !gameConfig.Settings.StatusLine.Enabled {
+		return nil, nil
+	}
+	cfg := gameConfig.Settings.StatusLine
+
+	rule := &statusRule{rows: cfg.Rows, format: cfg.Format}
+	if rule.rows <= 0 {
+		rule.rows = defaultStatusRows
+	}
+	if rule.rows > MaxStatusRows {
+		return nil, fmt.Errorf("status_line.rows is %d, at most %d rows are read", cfg.Rows, MaxStatusRows)
+	}
+	if cfg.Pattern != "" {
+		pattern, err := regexp.Compile(cfg.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid status_line.pattern: %w", err)
+		}
+		rule.pattern = pattern
+	}
+	alerts, err := newAlertRules(cfg.Alerts)
+	if err != nil {
+		return nil, err
+	}
+	rule.alerts = alerts
+	return rule, nil
+}
+
+// bottom returns the rows the rule reads
+func (r *statusRule) bottom(rows []string) []string {
+	if len(rows) > r.rows {
+		return rows[len(rows)-r.rows:]
+	}
+	return rows
+}
+
+// apply builds the status from the pattern, "" when it does not match
+func (r *statusRule) apply(rows []string) string {
+	text := strings.Join(r.bottom(rows), "\n")
+	match := r.pattern.FindStringSubmatchIndex(text)
+	if match == nil {
+		return ""
+	}
+	if r.format == "" {
+		return cleanStatusLine(text[match[0]:match[1]])
+	}
+	return cleanStatusLine(string(r.pattern.ExpandString(nil, r.format, text, match)))
+}
+
+// cleanStatusLine collapses the spacing of a status line and bounds its length
+func cleanStatusLine(status string) string {
+	status = strings.Join(strings.Fields(status), " ")
+	if utf8.RuneCountInString(status) > maxStatusLineLength {
+		status = string([]rune(status)[:maxStatusLineLength])
+	}
+	return status
+}
+
+// NetHackStatus is what NetHack's status lines show
+type NetHackStatus struct {
+	// Level is "Dlvl:12", or the name of a level without a depth such as
+	// "Astral Plane"
+	Level string
+	// Depth is the dungeon level, 0 when the level has none
+	Depth      int
+	Gold       int
+	HP, HPMax  int
+	Pw, PwMax  int
+	AC         int
+	XL         int
+	Turn       int
+	Conditions []string
+}
+
+var (
+	nethackLevel = regexp.MustCompile(`Dlvl:\s*(\d+)|Home \d+|Fort Ludios|End Game|Astral Plane|(?:Earth|Air|Fire|Water) Plane`)
+	nethackGold  = regexp.MustCompile(`\$:\s*(\d+)`)
+	nethackHP    = regexp.MustCompile(`HP:\s*(-?\d+)\((\d+)\)`)
+	nethackPw    = regexp.MustCompile(`Pw:\s*(\d+)\((\d+)\)`)
+	nethackAC    = regexp.MustCompile(`AC:\s*(-?\d+)`)
+	nethackXL    = regexp.MustCompile(`(?:Xp|XL|Exp|HD):\s*(\d+)`)
+	nethackTurn  = regexp.MustCompile(`\bT:\s*(\d+)`)
+)
+
+// nethackConditions are the hunger, encumbrance and status conditions the
+// status lines may end with
+var nethackConditions = []string{
+	"Satiated", "Hungry", "Weak", "Fainting", "Fainted",
+	"Burdened", "Stressed", "Strained", "Overtaxed", "Overloaded",
+	"Stone", "Slime", "Strngl", "FoodPois", "TermIll", "Ill",
+	"Blind", "Deaf", "Stun", "Conf", "Hallu", "Lev", "Fly", "Ride",
+}
+
+// ParseNetHackStatus reads NetHack's status lines, such as
+// "Dlvl:12 $:120 HP:45(60) Pw:10(22) AC:3 Xp:8/1204 T:8123 Hungry", from
+// the bottom rows of its screen. It reports false when they are not shown,
+// as in menus and the character creation screens.
+func ParseNetHackStatus(rows []string) (NetHackStatus, bool) {
+	text := strings.Join(rows, "\n")
+	hp := nethackHP.FindStringSubmatch(text)
+	if hp == nil {
+		return NetHackStatus{}, false
+	}
+
+	var status NetHackStatus
+	status.HP, _ = strconv.Atoi(hp[1])
+	status.HPMax, _ = strconv.Atoi(hp[2])
+	if level := nethackLevel.FindStringSubmatch(text); level != nil {
+		status.Level = level[0]
+		if level[1] != "" {
+			status.Depth, _ = strconv.Atoi(level[1])
+			status.Level = "Dlvl:" + level[1]
+		}
+	}
+	if pw := nethackPw.FindStringSubmatch(text); pw != nil {
+		status.Pw, _ = strconv.Atoi(pw[1])
+		status.PwMax, _ = strconv.Atoi(pw[2])
+	}
+	status.Gold = submatchInt(nethackGold, text)
+	status.AC = submatchInt(nethackAC, text)
+	status.XL = submatchInt(nethackXL, text)
+	status.Turn = submatchInt(nethackTurn, text)
+
+	words := make(map[string]bool)
+	for _, word := range strings.Fields(text) {
+		words[word] = true
+	}
+	for _, condition := range nethackConditions {
+		if words[condition] {
+			status.Conditions = append(status.Conditions, condition)
+		}
+	}
+	return status, true
+}
+
+// submatchInt returns the number a pattern's first group matched, 0 when it
+// did not match
+func submatchInt(pattern *regexp.Regexp, text string) int {
+	match := pattern.FindStringSubmatch(text)
+	if match == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(match[1])
+	return n
+}
+
+// String summarises the status for the watch menu, e.g.
+// "Dlvl:12 HP:45/60 XL:8 Hungry"
+func (s NetHackStatus) String() string {
+	parts := make([]string, 0, 4+len(s.Conditions))
+	if s.Level != "" {
+		parts = append(parts, s.Level)
+	}
+	parts = append(parts, fmt.Sprintf("HP:%d/%d", s.HP, s.HPMax))
+	if s.XL > 0 {
+		parts = append(parts, fmt.Sprintf("XL:%d", s.XL))
+	}
+	parts = append(parts, s.Conditions...)
+	return cleanStatusLine(strings.Join(parts, " "))
+}
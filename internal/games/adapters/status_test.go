@@ -0,0 +1,74 @@
+package adapters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func statusLineConfig(id string, statusLine *config.StatusLineConfig) *config.GameConfig {
+	return &config.GameConfig{ID: id, Settings: &config.GameSettings{StatusLine: statusLine}}
+}
+
+func TestParseNetHackStatus(t *testing.T) {
+	status, ok := ParseNetHackStatus([]string{
+		"Agent the Evoker              St:10 Dx:14 Co:16 In:19 Wi:12 Ch:9 Neutral",
+		"Dlvl:12 $:120 HP:45(60) Pw:10(22) AC:3 Xp:8/1204 T:8123 Hungry Burdened",
+	})
+	require.True(t, ok)
+	assert.Equal(t, 12, status.Depth)
+	assert.Equal(t, 120, status.Gold)
+	assert.Equal(t, 45, status.HP)
+	assert.Equal(t, 60, status.HPMax)
+	assert.Equal(t, 22, status.PwMax)
+	assert.Equal(t, 3, status.AC)
+	assert.Equal(t, 8, status.XL)
+	assert.Equal(t, 8123, status.Turn)
+	assert.Equal(t, []string{"Hungry", "Burdened"}, status.Conditions)
+	assert.Equal(t, "Dlvl:12 HP:45/60 XL:8 Hungry Burdened", status.String())
+
+	// Levels without a depth keep their name
+	status, ok = ParseNetHackStatus([]string{"Astral Plane $:0 HP:-2(120) Pw:5(80) AC:-12 HD:9 T:50000"})
+	require.True(t, ok)
+	assert.Equal(t, "Astral Plane", status.Level)
+	assert.Equal(t, 0, status.Depth)
+	assert.Equal(t, -2, status.HP)
+	assert.Equal(t, -12, status.AC)
+
+	// Menus hide the status lines
+	_, ok = ParseNetHackStatus([]string{"Shall I pick a character's race, role, gender and alignment for you? [ynaq]"})
+	assert.False(t, ok)
+}
+
+func TestParseStatus(t *testing.T) {
+	rows := []string{
+		"",
+		"Agent the Stripling           St:18/02 Dx:14 Co:18 In:8 Wi:9 Ch:7 Lawful",
+		"Dlvl:1 $:0 HP:16(16) Pw:1(1) AC:6 Xp:1/0 T:1",
+	}
+
+	nethack := NewNetHackAdapter(nil)
+	require.NoError(t, nethack.Configure(statusLineConfig("nethack", nil)))
+	assert.Empty(t, nethack.ParseStatus(rows), "status is only read when enabled")
+	require.NoError(t, nethack.Configure(statusLineConfig("nethack", &config.StatusLineConfig{Enabled: true})))
+	assert.Equal(t, "Dlvl:1 HP:16/16 XL:1", nethack.ParseStatus(rows))
+
+	// Other games say where their status is
+	game := NewDefaultAdapter("crawl")
+	require.NoError(t, game.Configure(statusLineConfig("crawl", &config.StatusLineConfig{Enabled: true})))
+	assert.Empty(t, game.ParseStatus(rows))
+	require.NoError(t, game.Configure(statusLineConfig("crawl", &config.StatusLineConfig{
+		Enabled: true,
+		Rows:    1,
+		Pattern: `Dlvl:(?P<dlvl>\d+).*HP:(?P<hp>\d+)\((?P<hpmax>\d+)\)`,
+		Format:  "Depth ${dlvl}, ${hp} of ${hpmax} HP",
+	})))
+	assert.Equal(t, "Depth 1, 16 of 16 HP", game.ParseStatus(rows))
+	assert.Empty(t, game.ParseStatus([]string{"--More--"}))
+
+	assert.Error(t, game.Configure(statusLineConfig("crawl", &config.StatusLineConfig{Enabled: true, Pattern: "("})))
+	assert.Error(t, game.Configure(statusLineConfig("crawl", &config.StatusLineConfig{Enabled: true, Rows: 4})))
+}
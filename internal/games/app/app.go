@@ -0,0 +1,419 @@
+// Package app wires the game service together: repositories, application
+// services and the gRPC server. It is shared by cmd/game-service and the
+// all-in-one cmd/dungeongate binary.
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/dungeongate/internal/games/adapters"
+	"github.com/dungeongate/internal/games/application"
+	grpc_service "github.com/dungeongate/internal/games/infrastructure/grpc"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/internal/games/shared"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/leader"
+	"github.com/dungeongate/pkg/metrics"
+	"github.com/dungeongate/pkg/retention"
+	"github.com/dungeongate/pkg/scheduler"
+)
+
+// SessionCacheFlushInterval is how often write-behind session changes are persisted
+const SessionCacheFlushInterval = 5 * time.Second
+
+// Services holds the game service's application services
+type Services struct {
+	GameService     *application.GameService
+	SessionService  *application.SessionService
+	DumplogService  *application.DumplogService
+	SaveService     *application.SaveService
+	SessionCache    *application.SessionCache
+	CleanupService  *application.CleanupService
+	BookmarkService *application.BookmarkService
+	// ScreenshotService is nil unless screenshots are enabled
+	ScreenshotService *application.ScreenshotService
+	// Feed is nil unless the public feed is enabled
+	Feed *application.Feed
+}
+
+// NewServices creates the application services on top of the development
+// stub repositories
+func NewServices(logger *slog.Logger) *Services {
+	// Initialize stub repositories for development
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	dumplogRepo := repository.NewStubDumplogRepository()
+	bookmarkRepo := repository.NewStubBookmarkRepository()
+
+	// Create unit of work
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	// Initialize application services
+	gameService := application.NewGameService(gameRepo, sessionRepo, saveRepo, eventRepo, uow)
+	sessionService := application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow)
+	dumplogService := application.NewDumplogService(dumplogRepo, sessionRepo)
+	saveService := application.NewSaveService(saveRepo, logger)
+	cleanupService := application.NewCleanupService(sessionRepo, saveRepo, eventRepo, logger)
+	bookmarkService := application.NewBookmarkService(bookmarkRepo)
+
+	// Serve active session reads from memory
+	sessionCache := application.NewSessionCache(sessionRepo, logger)
+	if err := sessionCache.Warm(context.Background()); err != nil {
+		logger.Warn("Failed to warm session cache", "error", err)
+	}
+	sessionService.SetSessionCache(sessionCache)
+
+	// Add default games for development
+	addDefaultGames(gameService)
+
+	return &Services{
+		GameService:     gameService,
+		SessionService:  sessionService,
+		DumplogService:  dumplogService,
+		SaveService:     saveService,
+		SessionCache:    sessionCache,
+		CleanupService:  cleanupService,
+		BookmarkService: bookmarkService,
+	}
+}
+
+// SessionCacheFlushJob is the name of the job persisting write-behind
+// session changes
+const SessionCacheFlushJob = "session_cache_flush"
+
+// StorageCleanupJob is the name of the storage cleanup scheduled job
+const StorageCleanupJob = "storage_cleanup"
+
+// RetentionJob is the name of the scheduled job purging data past its
+// retention period
+const RetentionJob = "retention"
+
+// GameLeaseName is the default lease game service replicas compete for
+const GameLeaseName = "game-service-jobs"
+
+// NewScheduler creates the scheduler of the game service's background jobs,
+// which flushes the session cache, runs storage cleanup when
+// storage.cleanup is enabled, and retention when retention is. Storage is shared by replicas, so these run on the
+// leader only, elected through db when server.leader_election is enabled.
+// Run the scheduler to start the jobs.
+func NewScheduler(cfg *config.GameServiceConfig, services *Services, db *sql.DB, metricsRegistry *metrics.Registry, logger *slog.Logger) (*scheduler.Scheduler, error) {
+	var overrides map[string]*config.JobConfig
+	var election *config.LeaderElectionConfig
+	if cfg.Server != nil {
+		overrides = cfg.Server.Jobs
+		election = cfg.Server.LeaderElection
+	}
+	jobs := scheduler.New(overrides, logger)
+	if metricsRegistry != nil {
+		jobs.SetRecorder(metricsRegistry)
+	}
+	elector, err := leader.New(election, db, GameLeaseName, logger)
+	if err != nil {
+		return nil, err
+	}
+	if elector != nil {
+		jobs.SetLeader(elector)
+	}
+
+	// Each replica persists the changes to the sessions it runs
+	err = jobs.Add(scheduler.Job{
+		Name:     SessionCacheFlushJob,
+		Schedule: scheduler.Every(SessionCacheFlushInterval),
+		Run:      services.SessionCache.Flush,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if interval, ok := ConfigureStorageCleanup(cfg, services.CleanupService, metricsRegistry); ok {
+		err := jobs.Add(scheduler.Job{
+			Name:      StorageCleanupJob,
+			Schedule:  scheduler.Every(interval),
+			Singleton: true,
+			Run:       services.CleanupService.RunStorageCleanup,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	interval, ok, err := ConfigureRetention(cfg, services.CleanupService)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		err := jobs.Add(scheduler.Job{
+			Name:      RetentionJob,
+			Schedule:  scheduler.Every(interval),
+			Jitter:    time.Minute,
+			Singleton: true,
+			Run:       services.CleanupService.RunRetention,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return jobs, nil
+}
+
+// ConfigureRetention applies the retention policy to the cleanup service and
+// returns how often to enforce it, or false when retention is disabled
+func ConfigureRetention(cfg *config.GameServiceConfig, cleanupService *application.CleanupService) (time.Duration, bool, error) {
+	policy, err := retention.NewPolicy(cfg.Retention)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid retention config: %w", err)
+	}
+	if policy == nil {
+		return 0, false, nil
+	}
+
+	var gameDataPath string
+	if cfg.Storage != nil {
+		gameDataPath = cfg.Storage.GameDataPath
+	}
+	cleanupService.SetRetention(&application.RetentionOptions{
+		Policy:         policy,
+		RecordingDirs:  []string{application.RecordingDir},
+		TranscriptDirs: []string{cfg.IOCapture.GetDirectory(gameDataPath)},
+	})
+	return policy.Interval(), true, nil
+}
+
+// ConfigureStorageCleanup applies storage.cleanup to the cleanup service and
+// returns how often to run it, or false when cleanup is disabled
+func ConfigureStorageCleanup(cfg *config.GameServiceConfig, cleanupService *application.CleanupService, metricsRegistry *metrics.Registry) (time.Duration, bool) {
+	if cfg.Storage == nil || cfg.Storage.Cleanup == nil || !cfg.Storage.Cleanup.Enabled {
+		return 0, false
+	}
+	cleanup := cfg.Storage.Cleanup
+
+	opts := &application.StorageCleanupOptions{
+		MaxAge:             config.ParseDuration(cleanup.MaxAge, 7*24*time.Hour),
+		DeleteEmptyDirs:    cleanup.DeleteEmptyDirs,
+		PreserveRecordings: cleanup.PreserveRecordings,
+		DryRun:             cleanup.DryRun,
+		UserHomesDir:       adapters.UserHomesDir,
+	}
+	// Recordings are kept for their retention period when retention is on
+	if cfg.Retention == nil || !cfg.Retention.Enabled {
+		opts.RecordingDirs = []string{application.RecordingDir}
+	}
+	if cfg.Storage.TempPath != "" {
+		opts.TempDirs = append(opts.TempDirs, cfg.Storage.TempPath)
+	}
+	if cfg.Storage.LogPath != "" {
+		opts.LogDirs = append(opts.LogDirs, cfg.Storage.LogPath)
+	}
+	for _, game := range cfg.Games {
+		if lockDir := game.GetUserPaths().LockDir; lockDir != "" {
+			opts.LockDirs = append(opts.LockDirs, lockDir)
+		}
+	}
+
+	cleanupService.SetStorageCleanup(opts, func(report *application.StorageCleanupReport) {
+		gameMetrics := metricsRegistry.GameService
+		if gameMetrics == nil {
+			return
+		}
+
+		dryRun := strconv.FormatBool(report.DryRun)
+		status := "success"
+		if len(report.Errors) > 0 {
+			status = "error"
+		}
+		gameMetrics.StorageCleanupRuns.WithLabelValues(status, dryRun).Inc()
+		for category, count := range report.FilesRemoved {
+			gameMetrics.StorageCleanupFilesRemoved.WithLabelValues(category, dryRun).Add(float64(count))
+		}
+		for category, size := range report.BytesReclaimed {
+			gameMetrics.StorageCleanupBytesReclaimed.WithLabelValues(category, dryRun).Add(float64(size))
+		}
+	})
+
+	return config.ParseDuration(cleanup.Interval, time.Hour), true
+}
+
+// InitializeSharedData initializes the shared data directories of the
+// enabled games with setup.initialize_shared and a data directory, running
+// their upgrades when a game's binary or version changed. Every game is
+// tried; the errors are returned together.
+func InitializeSharedData(ctx context.Context, cfg *config.GameServiceConfig, logger *slog.Logger) error {
+	manager := shared.NewManager(logger)
+	var errs []error
+	for _, game := range cfg.Games {
+		if game == nil || !game.Enabled || !game.GetSetupOptions().InitializeShared || shared.DataDirectory(game) == "" {
+			continue
+		}
+		if _, err := manager.Initialize(ctx, game); err != nil {
+			errs = append(errs, fmt.Errorf("game %s: %w", game.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DefaultShutdownTimeout is how long shutdown waits for game streams to end
+// when server.shutdown_timeout is not set
+const DefaultShutdownTimeout = 30 * time.Second
+
+// GRPCServices are the services RegisterGRPC put on a gRPC server
+type GRPCServices struct {
+	health       *health.Server
+	game         *grpc_service.GameServiceServer
+	snapshotPath string
+}
+
+// Shutdown reports the service as not serving, so the session service stops
+// sending players, then drains the game service within ctx and snapshots the
+// games still running, and stops its adapter plugins. Stop the gRPC server
+// afterwards.
+func (s *GRPCServices) Shutdown(ctx context.Context) error {
+	s.health.Shutdown()
+	defer adapters.ClosePlugins()
+	return s.game.Shutdown(ctx, s.snapshotPath)
+}
+
+// SetNotifier sets where players are told about their crashed games
+func (s *GRPCServices) SetNotifier(notifier grpc_service.Notifier) {
+	s.game.SetNotifier(notifier)
+}
+
+// SetBuild sets the build reported to session services
+func (s *GRPCServices) SetBuild(build buildinfo.Info) {
+	s.game.SetBuild(build)
+}
+
+// SetFeatureFlags sets the feature flags evaluated for players
+func (s *GRPCServices) SetFeatureFlags(flags *featureflags.Flags) {
+	s.game.SetFeatureFlags(flags)
+}
+
+// SetGameDefinitions puts the games defined in the database in play over the
+// configured games, and keeps games defined through the API there
+func (s *GRPCServices) SetGameDefinitions(ctx context.Context, store grpc_service.GameDefinitionStore) error {
+	return s.game.SetGameDefinitions(ctx, store)
+}
+
+// Captures returns the I/O captures of the game service, nil when disabled
+func (s *GRPCServices) Captures() *grpc_service.IOCaptures {
+	return s.game.Captures()
+}
+
+// ShutdownTimeout returns server.timeouts.shutdown_drain, or else the older
+// server.shutdown_timeout, or DefaultShutdownTimeout
+func ShutdownTimeout(cfg *config.GameServiceConfig) time.Duration {
+	if cfg.Server == nil {
+		return DefaultShutdownTimeout
+	}
+	if timeouts := cfg.Server.Timeouts; timeouts != nil && timeouts.ShutdownDrain != "" {
+		return timeouts.GetShutdownDrain()
+	}
+	return config.ParseDuration(cfg.Server.ShutdownTimeout, DefaultShutdownTimeout)
+}
+
+// RegisterGRPC registers the health and game services on a gRPC server.
+// Panics recovered in game PTY goroutines are counted when metricsRegistry is set.
+func RegisterGRPC(server *grpc.Server, cfg *config.GameServiceConfig, services *Services, metricsRegistry *metrics.Registry, logger *slog.Logger) *GRPCServices {
+	// Register health check service
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+
+	// Keep saves with the rest of the game data
+	if cfg.Storage != nil && cfg.Storage.GameDataPath != "" {
+		services.SaveService.SetSaveDir(filepath.Join(cfg.Storage.GameDataPath, "saves"))
+	}
+	if cfg.Storage != nil && cfg.Storage.SaveBundles != nil {
+		bundles := cfg.Storage.SaveBundles
+		services.SaveService.SetBundleOptions(application.SaveBundleOptions{
+			ServerName:    bundles.ServerName,
+			SigningKey:    bundles.SigningKey,
+			TrustedKeys:   bundles.TrustedKeys,
+			AllowUnsigned: bundles.AllowUnsigned,
+		})
+	}
+
+	// Register game service with slog logger
+	gameServiceServer := grpc_service.NewGameServiceServer(cfg, services.GameService, services.SessionService, services.DumplogService, services.SaveService, logger)
+	if metricsRegistry != nil {
+		gameServiceServer.SetPanicRecorder(metricsRegistry)
+		gameServiceServer.SetHangRecorder(metricsRegistry)
+	}
+	gameServiceServer.SetBookmarkService(services.BookmarkService)
+	if screenshots := cfg.Screenshots; screenshots != nil && screenshots.Enabled {
+		services.ScreenshotService = application.NewScreenshotService(repository.NewStubScreenshotRepository())
+		services.ScreenshotService.SetOptions(application.ScreenshotOptions{
+			MaxPerSession: screenshots.MaxPerSession,
+			ShareTTL:      config.ParseDuration(screenshots.ShareTTL, 0),
+			PublicURL:     screenshots.PublicURL,
+		})
+		gameServiceServer.SetScreenshotService(services.ScreenshotService)
+	}
+	if feed := cfg.PublicFeed; feed != nil && feed.Enabled {
+		services.Feed = application.NewFeed(services.SessionService, logger)
+		gameServiceServer.SetFeed(services.Feed)
+	}
+	games_pb.RegisterGameServiceServer(server, gameServiceServer)
+
+	registered := &GRPCServices{health: healthServer, game: gameServiceServer}
+	if cfg.Storage != nil && cfg.Storage.GameDataPath != "" {
+		registered.snapshotPath = filepath.Join(cfg.Storage.GameDataPath, application.SessionSnapshotFile)
+	}
+	return registered
+}
+
+// addDefaultGames adds default games to the repository for development
+func addDefaultGames(gameService *application.GameService) {
+	ctx := context.Background()
+
+	// Add NetHack as a default game
+	nethackReq := &application.CreateGameRequest{
+		ID:               "nethack",
+		Name:             "NetHack",
+		ShortName:        "nh",
+		Description:      "The classic dungeon exploration game",
+		Category:         "roguelike",
+		Tags:             []string{"roguelike", "classic", "dungeon"},
+		Version:          "3.6.7",
+		Difficulty:       7,
+		BinaryPath:       "/opt/homebrew/bin/nethack",
+		BinaryArgs:       []string{"-u", "${USERNAME}"},
+		WorkingDirectory: "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
+		Environment: map[string]string{
+			"TERM":           "xterm-256color",
+			"USER":           "${USERNAME}",
+			"HOME":           "/opt/homebrew/Cellar/nethack/3.6.7/libexec/${USERNAME}",
+			"HACKDIR":        "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
+			"NETHACKDIR":     "/opt/homebrew/Cellar/nethack/3.6.7/libexec",
+			"NETHACKOPTIONS": "@/opt/homebrew/Cellar/nethack/3.6.7/libexec/${USERNAME}.nethackrc",
+		},
+		CPULimit:                 "500m",
+		MemoryLimit:              "256Mi",
+		DiskLimit:                "1Gi",
+		TimeoutSeconds:           14400, // 4 hours
+		RunAsUser:                1000,
+		RunAsGroup:               1000,
+		ReadOnlyRootFilesystem:   false,
+		AllowPrivilegeEscalation: false,
+		NetworkIsolated:          true,
+		BlockInternet:            true,
+	}
+
+	// Try to create the game, ignore errors if it already exists
+	gameService.CreateGame(ctx, nethackReq)
+}
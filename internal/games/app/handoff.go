@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"syscall"
+
+	grpc_service "github.com/dungeongate/internal/games/infrastructure/grpc"
+	"github.com/dungeongate/pkg/config"
+)
+
+// HandoffSocket returns game_engine.handoff's socket, or "" when handoff is disabled
+func HandoffSocket(cfg *config.GameServiceConfig) string {
+	if cfg.GameEngine == nil {
+		return ""
+	}
+	return cfg.GameEngine.Handoff.SocketPath()
+}
+
+// TakeOver adopts the running games of the instance listening on socketPath,
+// which shuts down to hand them over. Call it before starting the servers,
+// since the old instance frees its ports first. No instance listening is not
+// an error and adopts nothing.
+func (s *GRPCServices) TakeOver(ctx context.Context, socketPath string) (int, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "unix", socketPath)
+	if errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to reach running game service: %w", err)
+	}
+	defer conn.Close()
+
+	return s.game.TakeOver(ctx, conn.(*net.UnixConn))
+}
+
+// ListenForTakeover accepts a takeover request from a newer instance on
+// socketPath. The connection is delivered on the returned channel once the
+// request is read; the receiver should shut down and then call HandOff.
+// Listening stops after the first request or when ctx is done.
+func (s *GRPCServices) ListenForTakeover(ctx context.Context, socketPath string, logger *slog.Logger) (<-chan *net.UnixConn, error) {
+	// A socket left behind by an earlier instance is no longer served
+	if err := os.Remove(socketPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove stale handoff socket: %w", err)
+	}
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for takeover: %w", err)
+	}
+	if err := os.Chmod(socketPath, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict handoff socket: %w", err)
+	}
+	// The next instance replaces the socket file before this one exits, so
+	// closing must not remove it
+	listener.SetUnlinkOnClose(false)
+
+	takeovers := make(chan *net.UnixConn, 1)
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	go func() {
+		defer listener.Close()
+		for {
+			conn, err := listener.AcceptUnix()
+			if err != nil {
+				return
+			}
+			if err := grpc_service.ReadHandoffRequest(conn); err != nil {
+				logger.Warn("Ignoring handoff connection", "error", err)
+				conn.Close()
+				continue
+			}
+			logger.Info("Newer game service instance is taking over")
+			takeovers <- conn
+			return
+		}
+	}()
+	return takeovers, nil
+}
+
+// HandOff passes the running games to the instance that requested a takeover
+// and closes its connection. Call it after Shutdown and after the servers stopped.
+func (s *GRPCServices) HandOff(conn *net.UnixConn) (int, error) {
+	defer conn.Close()
+	return s.game.HandOff(conn)
+}
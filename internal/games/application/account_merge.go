@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// ErrUserPlaying is returned when merging a user who has a game running
+var ErrUserPlaying = apierror.New(apierror.ActiveSessionExists, "user has a game running")
+
+// UserMergeSummary counts the game data merging one user into another
+// moves, or in a dry run would move
+type UserMergeSummary struct {
+	// Sessions are finished sessions, which carry their outcomes and
+	// recordings and so the merged user's history and leaderboard standing
+	Sessions   int
+	Recordings int
+	Dumplogs   int
+	Saves      int
+	// SkippedSaves are saves left with the merged user, because the
+	// surviving user has a save for the same game or they failed verification
+	SkippedSaves int
+}
+
+// MergeUserSessions gives a user's finished sessions to another user. It
+// fails without moving anything while the user has a game running.
+func (s *SessionService) MergeUserSessions(ctx context.Context, fromID, toID int, toUsername string, dryRun bool, summary *UserMergeSummary) error {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, domain.NewUserID(fromID))
+	if err != nil {
+		return fmt.Errorf("failed to find user sessions: %w", err)
+	}
+	for _, session := range sessions {
+		if !session.Status().IsFinal() {
+			return ErrUserPlaying
+		}
+	}
+
+	for _, session := range sessions {
+		summary.Sessions++
+		if recording := session.RecordingInfo(); recording != nil && recording.Enabled {
+			summary.Recordings++
+		}
+		if dryRun {
+			continue
+		}
+		if err := session.TransferTo(domain.NewUserID(toID), toUsername); err != nil {
+			return err
+		}
+		if err := s.sessionRepo.Save(ctx, session); err != nil {
+			return fmt.Errorf("failed to save merged session: %w", err)
+		}
+	}
+	return nil
+}
+
+// MergeUserDumplogs gives a user's dumplogs to another user
+func (s *DumplogService) MergeUserDumplogs(ctx context.Context, fromID, toID int, toUsername string, dryRun bool, summary *UserMergeSummary) error {
+	dumplogs, err := s.dumplogRepo.FindRecentByUser(ctx, domain.NewUserID(fromID), nil, 0)
+	if err != nil {
+		return fmt.Errorf("failed to find user dumplogs: %w", err)
+	}
+	for _, dumplog := range dumplogs {
+		summary.Dumplogs++
+		if dryRun {
+			continue
+		}
+		dumplog.TransferTo(domain.NewUserID(toID), toUsername)
+		if err := s.dumplogRepo.Save(ctx, dumplog); err != nil {
+			return fmt.Errorf("failed to save merged dumplog: %w", err)
+		}
+	}
+	return nil
+}
+
+// MergeUserSaves moves a user's active saves to another user, who resumes
+// them the next time they start those games. The moved saves are archived.
+func (s *SaveService) MergeUserSaves(ctx context.Context, fromID, toID int, dryRun bool, summary *UserMergeSummary) error {
+	saves, err := s.saveRepo.FindByUser(ctx, domain.NewUserID(fromID))
+	if err != nil {
+		return fmt.Errorf("failed to list saves: %w", err)
+	}
+
+	for _, save := range saves {
+		if !save.IsActive() {
+			continue
+		}
+		gameID := save.GameID().String()
+		if _, err := s.CurrentSave(ctx, toID, gameID); err == nil {
+			summary.SkippedSaves++
+			continue
+		}
+		if err := s.VerifySave(ctx, save); err != nil {
+			s.logger.Warn("Not merging save that failed verification", "error", err, "save_id", save.ID().String())
+			summary.SkippedSaves++
+			continue
+		}
+
+		summary.Saves++
+		if dryRun {
+			continue
+		}
+
+		data, err := os.ReadFile(save.FilePath())
+		if err != nil {
+			return fmt.Errorf("failed to read save file: %w", err)
+		}
+		moved, err := s.StoreSave(ctx, toID, gameID, data, save.Metadata())
+		if err != nil {
+			return err
+		}
+		save.Archive()
+		if err := s.saveRepo.Save(ctx, save); err != nil {
+			return fmt.Errorf("failed to archive merged save: %w", err)
+		}
+
+		s.logger.Info("Merged game save",
+			"save_id", save.ID().String(),
+			"new_save_id", moved.ID().String(),
+			"from_user_id", fromID,
+			"to_user_id", toID,
+			"game_id", gameID)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func TestSessionService_MergeUserSessions(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := repository.NewStubSessionRepository()
+	service := NewSessionService(sessionRepo, nil, nil, nil, nil)
+
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	// Nothing moves while the game is running
+	summary := &UserMergeSummary{}
+	err := service.MergeUserSessions(ctx, 42, 7, "survivor", false, summary)
+	assert.ErrorIs(t, err, ErrUserPlaying)
+
+	require.NoError(t, session.End(nil, nil))
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	summary = &UserMergeSummary{}
+	require.NoError(t, service.MergeUserSessions(ctx, 42, 7, "survivor", true, summary))
+	assert.Equal(t, 1, summary.Sessions)
+	moved, err := sessionRepo.FindByUserID(ctx, domain.NewUserID(7))
+	require.NoError(t, err)
+	assert.Empty(t, moved)
+
+	summary = &UserMergeSummary{}
+	require.NoError(t, service.MergeUserSessions(ctx, 42, 7, "survivor", false, summary))
+	assert.Equal(t, 1, summary.Sessions)
+
+	moved, err = sessionRepo.FindByUserID(ctx, domain.NewUserID(7))
+	require.NoError(t, err)
+	require.Len(t, moved, 1)
+	assert.Equal(t, "survivor", moved[0].Username())
+	left, err := sessionRepo.FindByUserID(ctx, domain.NewUserID(42))
+	require.NoError(t, err)
+	assert.Empty(t, left)
+}
+
+func TestSaveService_MergeUserSaves(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+
+	_, err := service.StoreSave(ctx, 42, "nethack", []byte("merged nethack"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	_, err = service.StoreSave(ctx, 42, "crawl", []byte("merged crawl"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	_, err = service.StoreSave(ctx, 7, "nethack", []byte("survivor nethack"), domain.SaveMetadata{})
+	require.NoError(t, err)
+
+	summary := &UserMergeSummary{}
+	require.NoError(t, service.MergeUserSaves(ctx, 42, 7, false, summary))
+	assert.Equal(t, 1, summary.Saves)
+	assert.Equal(t, 1, summary.SkippedSaves)
+
+	// The survivor keeps their own nethack save and gains the crawl one
+	current, err := service.CurrentSave(ctx, 7, "nethack")
+	require.NoError(t, err)
+	data, err := os.ReadFile(current.FilePath())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("survivor nethack"), data)
+
+	current, err = service.CurrentSave(ctx, 7, "crawl")
+	require.NoError(t, err)
+	data, err = os.ReadFile(current.FilePath())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("merged crawl"), data)
+
+	_, err = service.CurrentSave(ctx, 42, "crawl")
+	assert.Error(t, err)
+	_, err = service.CurrentSave(ctx, 42, "nethack")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+const (
+	// maxBookmarksPerSession caps a session's bookmarks, so a stuck key cannot
+	// fill the store
+	maxBookmarksPerSession = 100
+	// maxBookmarkNoteLength bounds a bookmark's note, in characters
+	maxBookmarkNoteLength = 80
+)
+
+// AddBookmarkRequest is a moment of a live session marked by its player or
+// a spectator
+type AddBookmarkRequest struct {
+	CreatedBy     int
+	CreatedByName string
+	Note          string
+}
+
+// BookmarkService keeps the bookmarks players and spectators drop in live
+// sessions, so the moments can be found again in the recording
+type BookmarkService struct {
+	bookmarkRepo domain.BookmarkRepository
+	now          func() time.Time
+}
+
+// NewBookmarkService creates a new bookmark service
+func NewBookmarkService(bookmarkRepo domain.BookmarkRepository) *BookmarkService {
+	return &BookmarkService{
+		bookmarkRepo: bookmarkRepo,
+		now:          time.Now,
+	}
+}
+
+// AddBookmark bookmarks the present moment of a recorded session
+func (s *BookmarkService) AddBookmark(ctx context.Context, session *domain.GameSession, req AddBookmarkRequest) (*domain.Bookmark, error) {
+	if req.CreatedBy <= 0 {
+		return nil, apierror.New(apierror.InvalidRequest, "bookmarks are dropped by signed in users")
+	}
+	if recording := session.RecordingInfo(); recording == nil || !recording.Enabled {
+		return nil, domain.ErrSessionNotRecorded
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxBookmarkNoteLength {
+		return nil, apierror.New(apierror.InvalidRequest, fmt.Sprintf("note is longer than %d characters", maxBookmarkNoteLength))
+	}
+
+	dropped, err := s.bookmarkRepo.FindBySession(ctx, session.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count bookmarks: %w", err)
+	}
+	if len(dropped) >= maxBookmarksPerSession {
+		return nil, domain.ErrBookmarkLimit
+	}
+
+	bookmark := domain.NewBookmark(domain.NewBookmarkID(generateBookmarkID()), session,
+		domain.NewUserID(req.CreatedBy), req.CreatedByName, note, s.now())
+	if err := s.bookmarkRepo.Save(ctx, bookmark); err != nil {
+		return nil, fmt.Errorf("failed to save bookmark: %w", err)
+	}
+	return bookmark, nil
+}
+
+// ListBookmarks returns a session's bookmarks in recording order; they are
+// timed by the game service as they are dropped, so that is the order they
+// were stored in
+func (s *BookmarkService) ListBookmarks(ctx context.Context, sessionID string) ([]*domain.Bookmark, error) {
+	bookmarks, err := s.bookmarkRepo.FindBySession(ctx, domain.NewSessionID(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+	return bookmarks, nil
+}
+
+// generateBookmarkID generates a unique bookmark ID
+func generateBookmarkID() string {
+	return fmt.Sprintf("bookmark_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+func TestBookmarkService_AddAndList(t *testing.T) {
+	ctx := context.Background()
+	service := NewBookmarkService(repository.NewStubBookmarkRepository())
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+
+	// Only recorded sessions have a recording to jump into
+	_, err := service.AddBookmark(ctx, session, AddBookmarkRequest{CreatedBy: 42, CreatedByName: "player"})
+	assert.ErrorIs(t, err, domain.ErrSessionNotRecorded)
+
+	session.EnableRecording("/tmp/session_dumplog.ttyrec", "ttyrec", false)
+	start := session.RecordingInfo().StartTime
+	now := start.Add(90 * time.Second)
+	service.now = func() time.Time { return now }
+
+	own, err := service.AddBookmark(ctx, session, AddBookmarkRequest{CreatedBy: 42, CreatedByName: "player"})
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, own.Offset())
+	assert.False(t, own.BySpectator())
+
+	now = start.Add(5 * time.Minute)
+	watched, err := service.AddBookmark(ctx, session, AddBookmarkRequest{CreatedBy: 7, CreatedByName: "watcher", Note: "  that was amazing "})
+	require.NoError(t, err)
+	assert.Equal(t, "that was amazing", watched.Note())
+	assert.True(t, watched.BySpectator())
+
+	_, err = service.AddBookmark(ctx, session, AddBookmarkRequest{CreatedBy: 7, Note: strings.Repeat("x", maxBookmarkNoteLength+1)})
+	assert.Equal(t, apierror.InvalidRequest, apierror.CodeOf(err))
+	_, err = service.AddBookmark(ctx, session, AddBookmarkRequest{})
+	assert.Equal(t, apierror.InvalidRequest, apierror.CodeOf(err))
+
+	bookmarks, err := service.ListBookmarks(ctx, "session_dumplog")
+	require.NoError(t, err)
+	require.Len(t, bookmarks, 2)
+	assert.Equal(t, own.ID(), bookmarks[0].ID())
+	assert.Equal(t, watched.ID(), bookmarks[1].ID())
+}
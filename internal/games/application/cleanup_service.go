@@ -19,6 +19,10 @@ type CleanupService struct {
 	saveRepo    domain.SaveRepository
 	eventRepo   domain.EventRepository
 	logger      *slog.Logger
+
+	storageOpts     *StorageCleanupOptions
+	storageReporter func(*StorageCleanupReport)
+	retentionOpts   *RetentionOptions
 }
 
 // NewCleanupService creates a new cleanup service
@@ -77,7 +81,9 @@ func (s *CleanupService) CleanupOrphanedProcesses(ctx context.Context) error {
 					session.ID().String(), processInfo.PID)
 
 				// Mark session as crashed
-				session.Fail("Process terminated unexpectedly")
+				if err := session.Fail("Process terminated unexpectedly"); err != nil {
+					continue
+				}
 
 				// Update session in database
 				err := s.sessionRepo.Save(ctx, session)
@@ -229,34 +235,6 @@ func (s *CleanupService) BackupAndCleanupOldSaves(ctx context.Context, userID in
 	return nil
 }
 
-// StartPeriodicCleanup starts background cleanup processes
-func (s *CleanupService) StartPeriodicCleanup(ctx context.Context, interval time.Duration) {
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-
-	s.logger.Info("Starting periodic cleanup", "interval", interval)
-
-	for {
-		select {
-		case <-ctx.Done():
-			s.logger.Info("Stopping periodic cleanup")
-			return
-		case <-ticker.C:
-			// Cleanup expired sessions (older than 24 hours)
-			err := s.CleanupExpiredSessions(ctx, 24*time.Hour)
-			if err != nil {
-				s.logger.Info("Error during session cleanup", "error", err)
-			}
-
-			// Find and cleanup orphaned processes
-			err = s.CleanupOrphanedProcesses(ctx)
-			if err != nil {
-				s.logger.Info("Error during orphaned process cleanup", "error", err)
-			}
-		}
-	}
-}
-
 // isProcessRunning checks if a process with the given PID is still running
 func (s *CleanupService) isProcessRunning(pid int) bool {
 	process, err := os.FindProcess(pid)
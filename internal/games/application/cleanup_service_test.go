@@ -164,38 +164,6 @@ func TestCleanupService_BackupAndCleanupOldSaves(t *testing.T) {
 	saveRepo.AssertExpectations(t)
 }
 
-func TestCleanupService_PeriodicCleanup(t *testing.T) {
-	sessionRepo := &MockSessionRepository{}
-	saveRepo := &MockSaveRepository{}
-	eventRepo := &MockEventRepository{}
-	logger := logging.NewLoggerBasic("test", "debug", "text", "stdout")
-
-	cleanupService := NewCleanupService(sessionRepo, saveRepo, eventRepo, logger)
-
-	// Create a context that will be cancelled to stop the periodic cleanup
-	ctx, cancel := context.WithCancel(context.Background())
-
-	// Set up mock expectations for periodic operations
-	sessionRepo.On("DeleteExpiredSessions", ctx, 24*time.Hour).Return(1, nil).Maybe()
-	sessionRepo.On("FindByStatus", ctx, domain.SessionStatusActive).Return([]*domain.GameSession{}, nil).Maybe()
-	sessionRepo.On("FindByStatus", ctx, domain.SessionStatusStarting).Return([]*domain.GameSession{}, nil).Maybe()
-
-	// Start periodic cleanup with very short interval for testing
-	go cleanupService.StartPeriodicCleanup(ctx, 10*time.Millisecond)
-
-	// Let it run for a short time
-	time.Sleep(50 * time.Millisecond)
-
-	// Cancel context to stop cleanup
-	cancel()
-
-	// Give it time to stop
-	time.Sleep(10 * time.Millisecond)
-
-	// The test passes if no panics occurred and cleanup methods were called
-	t.Log("Periodic cleanup test completed successfully")
-}
-
 // Helper functions for creating test objects
 
 func createMockSessionWithProcess(userID int, gameID string, pid int) *domain.GameSession {
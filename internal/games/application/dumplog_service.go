@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// defaultDumplogMaxSize caps stored dumplogs when no limit is configured
+const defaultDumplogMaxSize = 1024 * 1024
+
+// dumplogClockSkew allows for dumplog files written just before the session start was recorded
+const dumplogClockSkew = 5 * time.Second
+
+// DumplogService provides capture and retrieval of end-of-game dumplogs
+type DumplogService struct {
+	dumplogRepo domain.DumplogRepository
+	sessionRepo domain.SessionRepository
+}
+
+// NewDumplogService creates a new dumplog service
+func NewDumplogService(dumplogRepo domain.DumplogRepository, sessionRepo domain.SessionRepository) *DumplogService {
+	return &DumplogService{
+		dumplogRepo: dumplogRepo,
+		sessionRepo: sessionRepo,
+	}
+}
+
+// CaptureDumplog finds the dumplog a finished session wrote, stores it and links
+// it to the session record. It returns nil without error when no dumplog exists.
+func (s *DumplogService) CaptureDumplog(ctx context.Context, session *domain.GameSession, pathPattern string, maxSize int64) (*domain.Dumplog, error) {
+	if pathPattern == "" {
+		return nil, fmt.Errorf("dumplog path pattern is empty")
+	}
+	if maxSize <= 0 {
+		maxSize = defaultDumplogMaxSize
+	}
+
+	path, err := findDumplogFile(ExpandDumplogPattern(pathPattern, session), session.StartTime().Add(-dumplogClockSkew))
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return nil, nil
+	}
+
+	content, truncated, err := readDumplog(path, maxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	dumplog := domain.NewDumplog(domain.NewDumplogID(generateDumplogID()), session, path, content, truncated)
+	if err := s.dumplogRepo.Save(ctx, dumplog); err != nil {
+		return nil, fmt.Errorf("failed to save dumplog: %w", err)
+	}
+
+	session.AttachDumplog(dumplog.ID())
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to link dumplog to session: %w", err)
+	}
+
+	return dumplog, nil
+}
+
+// ListRecentDumplogs returns a user's most recent dumplogs, newest first
+func (s *DumplogService) ListRecentDumplogs(ctx context.Context, userID int, gameID string, limit int) ([]*domain.Dumplog, error) {
+	var game *domain.GameID
+	if gameID != "" {
+		id := domain.NewGameID(gameID)
+		game = &id
+	}
+
+	dumplogs, err := s.dumplogRepo.FindRecentByUser(ctx, domain.NewUserID(userID), game, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dumplogs: %w", err)
+	}
+	return dumplogs, nil
+}
+
+// GetDumplog returns a dumplog by ID
+func (s *DumplogService) GetDumplog(ctx context.Context, dumplogID string) (*domain.Dumplog, error) {
+	dumplog, err := s.dumplogRepo.FindByID(ctx, domain.NewDumplogID(dumplogID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find dumplog: %w", err)
+	}
+	return dumplog, nil
+}
+
+// GetDumplogFor returns a dumplog a user may read: their own, or any for
+// admins. Other users' dumplogs are not found, so their IDs are not revealed.
+func (s *DumplogService) GetDumplogFor(ctx context.Context, dumplogID string, viewerID int, admin bool) (*domain.Dumplog, error) {
+	dumplog, err := s.dumplogRepo.FindByID(ctx, domain.NewDumplogID(dumplogID))
+	if err != nil || dumplog == nil {
+		return nil, domain.ErrDumplogNotFound
+	}
+	if !admin && !dumplog.CanView(domain.NewUserID(viewerID)) {
+		return nil, domain.ErrDumplogNotFound
+	}
+	return dumplog, nil
+}
+
+// ExpandDumplogPattern substitutes session placeholders in a dumplog path pattern
+func ExpandDumplogPattern(pattern string, session *domain.GameSession) string {
+	replacer := strings.NewReplacer(
+		"${USER_ID}", strconv.Itoa(session.UserID().Int()),
+		"${USERNAME}", session.Username(),
+		"${SESSION_ID}", session.ID().String(),
+		"${GAME_ID}", session.GameID().String(),
+	)
+	return replacer.Replace(pattern)
+}
+
+// findDumplogFile returns the newest file matching pattern modified after since,
+// or an empty path when there is none
+func findDumplogFile(pattern string, since time.Time) (string, error) {
+	matches, err := filepath.Glob(pattern)
+	if err != nil {
+		return "", fmt.Errorf("invalid dumplog path pattern: %w", err)
+	}
+
+	var newest string
+	var newestTime time.Time
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		if info.ModTime().Before(since) {
+			continue
+		}
+		if newest == "" || info.ModTime().After(newestTime) {
+			newest = match
+			newestTime = info.ModTime()
+		}
+	}
+	return newest, nil
+}
+
+// readDumplog reads up to maxSize bytes of a dumplog file
+func readDumplog(path string, maxSize int64) ([]byte, bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open dumplog: %w", err)
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read dumplog: %w", err)
+	}
+	if int64(len(content)) > maxSize {
+		return content[:maxSize], true, nil
+	}
+	return content, false, nil
+}
+
+// generateDumplogID generates a unique dumplog ID
+func generateDumplogID() string {
+	return fmt.Sprintf("dumplog_%d", time.Now().UnixNano())
+}
@@ -0,0 +1,82 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func TestDumplogService_CaptureDumplog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+
+	// An old dumplog from a previous game must not be picked up
+	oldPath := filepath.Join(dir, "user_42", "old.txt")
+	require.NoError(t, os.MkdirAll(filepath.Dir(oldPath), 0755))
+	require.NoError(t, os.WriteFile(oldPath, []byte("old game"), 0644))
+	past := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(oldPath, past, past))
+
+	newPath := filepath.Join(dir, "user_42", "new.txt")
+	require.NoError(t, os.WriteFile(newPath, []byte("You die... 0123456789"), 0644))
+
+	sessionRepo := new(MockSessionRepository)
+	sessionRepo.On("Save", ctx, session).Return(nil)
+	dumplogRepo := repository.NewStubDumplogRepository()
+	service := NewDumplogService(dumplogRepo, sessionRepo)
+
+	dumplog, err := service.CaptureDumplog(ctx, session, filepath.Join(dir, "user_${USER_ID}", "*.txt"), 10)
+	require.NoError(t, err)
+	require.NotNil(t, dumplog)
+
+	assert.Equal(t, newPath, dumplog.SourcePath())
+	assert.Equal(t, []byte("You die..."), dumplog.Content())
+	assert.True(t, dumplog.Truncated())
+	require.NotNil(t, session.DumplogID())
+	assert.Equal(t, dumplog.ID(), *session.DumplogID())
+	sessionRepo.AssertCalled(t, "Save", ctx, mock.Anything)
+
+	dumplogs, err := service.ListRecentDumplogs(ctx, 42, "", 5)
+	require.NoError(t, err)
+	assert.Len(t, dumplogs, 1)
+}
+
+func TestDumplogService_CaptureDumplog_NoFile(t *testing.T) {
+	service := NewDumplogService(repository.NewStubDumplogRepository(), new(MockSessionRepository))
+
+	dumplog, err := service.CaptureDumplog(context.Background(), createMockSessionWithID("session_dumplog", 42, "nethack"), filepath.Join(t.TempDir(), "*.txt"), 0)
+	assert.NoError(t, err)
+	assert.Nil(t, dumplog)
+}
+
+func TestDumplogService_GetDumplogFor_OtherUsers(t *testing.T) {
+	ctx := context.Background()
+	dumplogRepo := repository.NewStubDumplogRepository()
+	dumplog := domain.NewDumplog(domain.NewDumplogID("dumplog_1"), createMockSessionWithID("session_dumplog", 42, "nethack"), "/tmp/x.txt", []byte("You die..."), false)
+	require.NoError(t, dumplogRepo.Save(ctx, dumplog))
+	service := NewDumplogService(dumplogRepo, new(MockSessionRepository))
+
+	found, err := service.GetDumplogFor(ctx, "dumplog_1", 42, false)
+	require.NoError(t, err)
+	assert.Equal(t, dumplog.ID(), found.ID())
+
+	// Another user gets the same answer as for a dumplog that does not exist
+	_, err = service.GetDumplogFor(ctx, "dumplog_1", 7, false)
+	assert.ErrorIs(t, err, domain.ErrDumplogNotFound)
+	_, err = service.GetDumplogFor(ctx, "missing", 7, false)
+	assert.ErrorIs(t, err, domain.ErrDumplogNotFound)
+
+	// Admins read any dumplog
+	_, err = service.GetDumplogFor(ctx, "dumplog_1", 7, true)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,215 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// Public feed event types
+const (
+	FeedSessionStart = "session.start"
+	FeedSessionEnd   = "session.end"
+	FeedSessionAlert = "session.alert"
+)
+
+const (
+	// feedHistory is how many recent events are kept for subscribers that
+	// reconnect, so they pick up where they left off
+	feedHistory = 256
+	// feedSubscriberBuffer is how many events a subscriber may fall behind
+	// before it is dropped and has to reconnect
+	feedSubscriberBuffer = 64
+)
+
+// FeedEvent is an event of the public feed community sites embed
+type FeedEvent struct {
+	ID        int64     `json:"id"`
+	Type      string    `json:"type"`
+	SessionID string    `json:"session_id"`
+	GameID    string    `json:"game_id"`
+	Username  string    `json:"username"`
+	Time      time.Time `json:"time"`
+	// DurationSeconds is how long an ended session lasted
+	DurationSeconds int64 `json:"duration_seconds,omitempty"`
+	// Rule and Message describe an alert, such as the player's HP running low
+	Rule       string `json:"rule,omitempty"`
+	Message    string `json:"message,omitempty"`
+	StatusLine string `json:"status_line,omitempty"`
+}
+
+// feedSession is what the feed last saw of a session
+type feedSession struct {
+	gameID    string
+	username  string
+	startTime time.Time
+}
+
+// Feed publishes the starts and ends of the games open to spectators, and
+// the alerts they raise, to the public feed. Games closed to spectators are
+// never mentioned.
+type Feed struct {
+	sessions *SessionService
+	logger   *slog.Logger
+
+	mu               sync.Mutex
+	seen             map[string]feedSession
+	recent           []FeedEvent
+	nextID           int64
+	subscribers      map[int]chan FeedEvent
+	nextSubscriberID int
+}
+
+// NewFeed creates a feed of the sessions of sessionService
+func NewFeed(sessionService *SessionService, logger *slog.Logger) *Feed {
+	return &Feed{
+		sessions:    sessionService,
+		logger:      logger,
+		seen:        make(map[string]feedSession),
+		subscribers: make(map[int]chan FeedEvent),
+	}
+}
+
+// Run publishes session starts and ends as the session cache changes, until
+// ctx is cancelled. Sessions already running when it starts are not
+// announced.
+func (f *Feed) Run(ctx context.Context) {
+	updates, unsubscribe := f.sessions.SubscribeSessions()
+	defer unsubscribe()
+	if updates == nil {
+		f.logger.Warn("Public feed has no session events without the session cache")
+		return
+	}
+
+	f.sync(ctx, false)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-updates:
+			f.sync(ctx, true)
+		}
+	}
+}
+
+// sync diffs the spectatable sessions against those last seen, publishing
+// the differences when announce is set
+func (f *Feed) sync(ctx context.Context, announce bool) {
+	sessions, err := f.sessions.ListActiveSessions(ctx)
+	if err != nil {
+		f.logger.Warn("Failed to list sessions for public feed", "error", err)
+		return
+	}
+
+	now := time.Now()
+	current := make(map[string]bool, len(sessions))
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, session := range sessions {
+		if !session.CanSpectate() {
+			continue
+		}
+		id := session.ID().String()
+		current[id] = true
+		if _, ok := f.seen[id]; ok {
+			continue
+		}
+		seen := feedSession{gameID: session.GameID().String(), username: session.Username(), startTime: session.StartTime()}
+		f.seen[id] = seen
+		if announce {
+			f.publish(FeedEvent{Type: FeedSessionStart, SessionID: id, GameID: seen.gameID, Username: seen.username, Time: now})
+		}
+	}
+
+	for id, seen := range f.seen {
+		if current[id] {
+			continue
+		}
+		delete(f.seen, id)
+		if announce {
+			f.publish(FeedEvent{
+				Type:            FeedSessionEnd,
+				SessionID:       id,
+				GameID:          seen.gameID,
+				Username:        seen.username,
+				Time:            now,
+				DurationSeconds: int64(now.Sub(seen.startTime).Seconds()),
+			})
+		}
+	}
+}
+
+// SessionAlert publishes an alert a game's status raised, when the game is
+// open to spectators
+func (f *Feed) SessionAlert(session *domain.GameSession, alert domain.StatusAlert) {
+	if !session.CanSpectate() {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.publish(FeedEvent{
+		Type:       FeedSessionAlert,
+		SessionID:  session.ID().String(),
+		GameID:     session.GameID().String(),
+		Username:   session.Username(),
+		Time:       time.Now(),
+		Rule:       alert.Rule,
+		Message:    alert.Message,
+		StatusLine: alert.StatusLine,
+	})
+}
+
+// Subscribe returns the kept events after lastEventID, a channel receiving
+// events from then on and a function that cancels the subscription. The
+// channel is closed when the subscriber falls too far behind.
+func (f *Feed) Subscribe(lastEventID int64) ([]FeedEvent, <-chan FeedEvent, func()) {
+	ch := make(chan FeedEvent, feedSubscriberBuffer)
+
+	f.mu.Lock()
+	var missed []FeedEvent
+	if lastEventID > 0 {
+		for _, event := range f.recent {
+			if event.ID > lastEventID {
+				missed = append(missed, event)
+			}
+		}
+	}
+	id := f.nextSubscriberID
+	f.nextSubscriberID++
+	f.subscribers[id] = ch
+	f.mu.Unlock()
+
+	return missed, ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		if _, ok := f.subscribers[id]; ok {
+			delete(f.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+// publish numbers an event, keeps it and sends it to subscribers. Called
+// with mu held.
+func (f *Feed) publish(event FeedEvent) {
+	f.nextID++
+	event.ID = f.nextID
+	f.recent = append(f.recent, event)
+	if len(f.recent) > feedHistory {
+		f.recent = f.recent[len(f.recent)-feedHistory:]
+	}
+
+	for id, ch := range f.subscribers {
+		select {
+		case ch <- event:
+		default:
+			f.logger.Debug("Dropped slow public feed subscriber")
+			delete(f.subscribers, id)
+			close(ch)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func TestFeed_PublishesSpectatableSessions(t *testing.T) {
+	cache := NewSessionCache(repository.NewStubSessionRepository(), slog.Default())
+	service := NewSessionService(repository.NewStubSessionRepository(), nil, nil, nil, nil)
+	service.SetSessionCache(cache)
+	feed := NewFeed(service, slog.Default())
+	ctx := context.Background()
+
+	// Sessions running before the feed starts are not announced
+	running := createMockSessionWithID("session_running", 1, "nethack")
+	running.EnableStreaming("ssh", false)
+	cache.Put(running)
+	feed.sync(ctx, false)
+
+	_, events, unsubscribe := feed.Subscribe(0)
+	defer unsubscribe()
+
+	watched := createMockSessionWithID("session_watched", 2, "nethack")
+	watched.EnableStreaming("ssh", false)
+	private := createMockSessionWithID("session_private", 3, "nethack")
+	cache.Put(watched)
+	cache.Put(private)
+	feed.sync(ctx, true)
+
+	start := <-events
+	assert.Equal(t, FeedSessionStart, start.Type)
+	assert.Equal(t, "session_watched", start.SessionID)
+	assert.Equal(t, "nethack", start.GameID)
+
+	feed.SessionAlert(watched, domain.StatusAlert{Rule: "low_hp", Message: "player is low on HP"})
+	feed.SessionAlert(private, domain.StatusAlert{Rule: "low_hp", Message: "player is low on HP"})
+	alert := <-events
+	assert.Equal(t, FeedSessionAlert, alert.Type)
+	assert.Equal(t, "low_hp", alert.Rule)
+
+	watched.End(nil, nil)
+	cache.Put(watched)
+	private.End(nil, nil)
+	cache.Put(private)
+	feed.sync(ctx, true)
+
+	end := <-events
+	assert.Equal(t, FeedSessionEnd, end.Type)
+	assert.Equal(t, "session_watched", end.SessionID)
+	assert.Empty(t, events, "sessions closed to spectators are never mentioned")
+
+	// Reconnecting subscribers get the events they missed
+	missed, _, cancel := feed.Subscribe(start.ID)
+	defer cancel()
+	require.Len(t, missed, 2)
+	assert.Equal(t, alert.ID, missed[0].ID)
+	assert.Equal(t, end.ID, missed[1].ID)
+}
+
+func TestFeed_DropsSlowSubscribers(t *testing.T) {
+	service := NewSessionService(repository.NewStubSessionRepository(), nil, nil, nil, nil)
+	feed := NewFeed(service, slog.Default())
+	_, events, unsubscribe := feed.Subscribe(0)
+	defer unsubscribe()
+
+	session := createMockSessionWithID("session_a", 1, "nethack")
+	session.EnableStreaming("ssh", false)
+	for i := 0; i <= feedSubscriberBuffer; i++ {
+		feed.SessionAlert(session, domain.StatusAlert{Rule: "low_hp"})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	assert.Equal(t, feedSubscriberBuffer, received, "the channel closes once the subscriber falls behind")
+}
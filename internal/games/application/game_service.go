@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -63,7 +64,46 @@ func (s *GameService) CreateGame(ctx context.Context, req *CreateGameRequest) (*
 		return nil, fmt.Errorf("game with ID %s already exists", req.ID)
 	}
 
-	// Create game domain object
+	metadata, config := gameFromRequest(req)
+	game := domain.NewGame(id, metadata, config)
+
+	// Save the game
+	if err := s.gameRepo.Save(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return game, nil
+}
+
+// DefineGame creates a game from a definition, or replaces the metadata and
+// configuration of the game with its ID, keeping the game's status and
+// statistics
+func (s *GameService) DefineGame(ctx context.Context, req *CreateGameRequest) (*domain.Game, error) {
+	if req.ID == "" {
+		return nil, fmt.Errorf("game ID is required")
+	}
+	metadata, config := gameFromRequest(req)
+
+	id := domain.NewGameID(req.ID)
+	game, err := s.gameRepo.FindByID(ctx, id)
+	switch {
+	case errors.Is(err, domain.ErrGameNotFound):
+		game = domain.NewGame(id, metadata, config)
+	case err != nil:
+		return nil, fmt.Errorf("failed to find game: %w", err)
+	default:
+		game.UpdateMetadata(metadata)
+		game.UpdateConfig(config)
+	}
+
+	if err := s.saveGame(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to save game: %w", err)
+	}
+	return game, nil
+}
+
+// gameFromRequest returns the metadata and configuration of a game to create
+func gameFromRequest(req *CreateGameRequest) (domain.GameMetadata, domain.GameConfig) {
 	metadata := domain.GameMetadata{
 		Name:        req.Name,
 		ShortName:   req.ShortName,
@@ -102,14 +142,7 @@ func (s *GameService) CreateGame(ctx context.Context, req *CreateGameRequest) (*
 		},
 	}
 
-	game := domain.NewGame(id, metadata, config)
-
-	// Save the game
-	if err := s.gameRepo.Save(ctx, game); err != nil {
-		return nil, fmt.Errorf("failed to save game: %w", err)
-	}
-
-	return game, nil
+	return metadata, config
 }
 
 // UpdateGame updates an existing game
@@ -170,13 +203,8 @@ func (s *GameService) UpdateGame(ctx context.Context, gameID string, req *Update
 
 	// Update status if provided
 	if req.Status != nil {
-		switch *req.Status {
-		case "enabled":
-			game.Enable()
-		case "disabled":
-			game.Disable()
-		case "maintenance":
-			game.SetMaintenance()
+		if err := game.SetStatus(domain.GameStatus(*req.Status), ""); err != nil {
+			return nil, err
 		}
 	}
 
@@ -184,6 +212,7 @@ func (s *GameService) UpdateGame(ctx context.Context, gameID string, req *Update
 	if err := s.gameRepo.Save(ctx, game); err != nil {
 		return nil, fmt.Errorf("failed to update game: %w", err)
 	}
+	saveStatusEvents(ctx, s.eventRepo, game.PullEvents())
 
 	return game, nil
 }
@@ -215,8 +244,10 @@ func (s *GameService) EnableGame(ctx context.Context, gameID string) error {
 		return fmt.Errorf("game not found: %w", err)
 	}
 
-	game.Enable()
-	return s.gameRepo.Save(ctx, game)
+	if err := game.Enable(); err != nil {
+		return err
+	}
+	return s.saveGame(ctx, game)
 }
 
 // DisableGame disables a game
@@ -228,8 +259,37 @@ func (s *GameService) DisableGame(ctx context.Context, gameID string) error {
 		return fmt.Errorf("game not found: %w", err)
 	}
 
-	game.Disable()
-	return s.gameRepo.Save(ctx, game)
+	if err := game.Disable(); err != nil {
+		return err
+	}
+	return s.saveGame(ctx, game)
+}
+
+// SetGameStatus moves a game to status, giving players message as the
+// reason it cannot be played. Running sessions are not affected; only new
+// sessions are refused.
+func (s *GameService) SetGameStatus(ctx context.Context, gameID string, status domain.GameStatus, message string) (*domain.Game, error) {
+	game, err := s.gameRepo.FindByID(ctx, domain.NewGameID(gameID))
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
+	}
+
+	if err := game.SetStatus(status, message); err != nil {
+		return nil, err
+	}
+	if err := s.saveGame(ctx, game); err != nil {
+		return nil, fmt.Errorf("failed to update game: %w", err)
+	}
+	return game, nil
+}
+
+// saveGame stores a game and its status changes
+func (s *GameService) saveGame(ctx context.Context, game *domain.Game) error {
+	if err := s.gameRepo.Save(ctx, game); err != nil {
+		return err
+	}
+	saveStatusEvents(ctx, s.eventRepo, game.PullEvents())
+	return nil
 }
 
 // GetGameStatistics retrieves game statistics
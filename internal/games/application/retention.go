@@ -0,0 +1,143 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/retention"
+)
+
+// retentionService names the game service in retention reports
+const retentionService = "game"
+
+// RetentionOptions configures the retention the cleanup service enforces
+type RetentionOptions struct {
+	Policy *retention.Policy
+	// RecordingDirs hold ttyrec recordings named <session ID>.ttyrec
+	RecordingDirs []string
+	// TranscriptDirs hold I/O captures named <session ID>-<time>.dgcap
+	TranscriptDirs []string
+}
+
+// SetRetention enables purging recordings and session transcripts as the
+// retention policy says
+func (s *CleanupService) SetRetention(opts *RetentionOptions) {
+	s.retentionOpts = opts
+}
+
+// EnforceRetention purges the recordings and session transcripts that are
+// older than their retention period, except those of sessions and users
+// under legal hold. Problems with individual files are collected in the
+// report rather than stopping the run.
+func (s *CleanupService) EnforceRetention(ctx context.Context) (*retention.Report, error) {
+	opts := s.retentionOpts
+	if opts == nil || opts.Policy == nil {
+		return nil, fmt.Errorf("retention is not configured")
+	}
+
+	report := opts.Policy.NewReport(retentionService)
+	for _, dir := range opts.RecordingDirs {
+		s.purgeExpiredFiles(ctx, report, retention.Recordings, dir, recordingSessionID)
+	}
+	for _, dir := range opts.TranscriptDirs {
+		s.purgeExpiredFiles(ctx, report, retention.SessionTranscripts, dir, transcriptSessionID)
+	}
+	return report, nil
+}
+
+// RunRetention enforces retention once and reports the result; it is run as
+// the retention scheduled job
+func (s *CleanupService) RunRetention(ctx context.Context) error {
+	report, err := s.EnforceRetention(ctx)
+	if err != nil {
+		return fmt.Errorf("retention failed: %w", err)
+	}
+	return s.retentionOpts.Policy.Finish(report, s.logger)
+}
+
+// recordingSessionID returns the session a recording file belongs to
+func recordingSessionID(name string) (string, bool) {
+	return strings.CutSuffix(name, ".ttyrec")
+}
+
+// transcriptSessionID returns the session an I/O capture file belongs to
+func transcriptSessionID(name string) (string, bool) {
+	base, ok := strings.CutSuffix(name, ".dgcap")
+	if !ok {
+		return "", false
+	}
+	i := strings.LastIndex(base, "-")
+	if i <= 0 {
+		return "", false
+	}
+	return base[:i], true
+}
+
+// purgeExpiredFiles removes the files under root older than the category's
+// retention period whose session, which sessionOf reads from their name, is
+// not under legal hold. Files sessionOf doesn't recognize are left alone.
+func (s *CleanupService) purgeExpiredFiles(ctx context.Context, report *retention.Report, category, root string, sessionOf func(name string) (string, bool)) {
+	policy := s.retentionOpts.Policy
+	cutoff, ok := policy.Cutoff(category)
+	if !ok || root == "" {
+		return
+	}
+	checkUsers := len(policy.HeldUsers()) > 0
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			report.Fail(err)
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		sessionID, ok := sessionOf(d.Name())
+		if !ok {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			report.Fail(err)
+			return nil
+		}
+		if !info.ModTime().Before(cutoff) {
+			return nil
+		}
+
+		if policy.SessionHeld(sessionID) || (checkUsers && s.sessionUserHeld(ctx, policy, sessionID)) {
+			report.Hold(category)
+			return nil
+		}
+		if !report.DryRun {
+			if err := os.Remove(path); err != nil {
+				report.Fail(err)
+				return nil
+			}
+		}
+		s.logger.Debug("Purged expired file", "category", category, "path", path, "dry_run", report.DryRun)
+		report.Purge(category, d.Name())
+		return nil
+	})
+	if err != nil {
+		report.Fail(err)
+	}
+}
+
+// sessionUserHeld reports whether the player of a session is under legal
+// hold. Sessions no longer on record are held by their ID only.
+func (s *CleanupService) sessionUserHeld(ctx context.Context, policy *retention.Policy, sessionID string) bool {
+	session, err := s.sessionRepo.FindByID(ctx, domain.NewSessionID(sessionID))
+	if err != nil || session == nil {
+		return false
+	}
+	return policy.UserHeld(session.Username())
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/retention"
+)
+
+func setupRetention(t *testing.T, dryRun bool) (*CleanupService, string) {
+	root := t.TempDir()
+	ctx := context.Background()
+
+	sessionRepo := repository.NewStubSessionRepository()
+	held := createMockSession(1, "nethack")
+	held.End(nil, nil)
+	require.NoError(t, sessionRepo.Save(ctx, held))
+
+	recordings := filepath.Join(root, "recordings")
+	writeAgedFile(t, filepath.Join(recordings, "session_old.ttyrec"), 100, 40*24*time.Hour)
+	writeAgedFile(t, filepath.Join(recordings, "session_fresh.ttyrec"), 100, 24*time.Hour)
+	writeAgedFile(t, filepath.Join(recordings, "session_held.ttyrec"), 100, 40*24*time.Hour)
+	writeAgedFile(t, filepath.Join(recordings, held.ID().String()+".ttyrec"), 100, 40*24*time.Hour)
+	writeAgedFile(t, filepath.Join(recordings, "notes.txt"), 100, 40*24*time.Hour)
+	captures := filepath.Join(root, "captures")
+	writeAgedFile(t, filepath.Join(captures, "session-a-20260101T000000Z.dgcap"), 100, 8*24*time.Hour)
+	writeAgedFile(t, filepath.Join(captures, "session-b-20260101T000000Z.dgcap"), 100, 6*24*time.Hour)
+
+	policy, err := retention.NewPolicy(&config.RetentionConfig{
+		Enabled: true,
+		DryRun:  dryRun,
+		LegalHolds: &config.LegalHoldConfig{
+			Users:    []string{"testuser"},
+			Sessions: []string{"session_held"},
+		},
+	})
+	require.NoError(t, err)
+
+	logger := logging.NewLoggerBasic("test", "debug", "text", "stdout")
+	cleanupService := NewCleanupService(sessionRepo, &MockSaveRepository{}, &MockEventRepository{}, logger)
+	cleanupService.SetRetention(&RetentionOptions{
+		Policy:         policy,
+		RecordingDirs:  []string{recordings},
+		TranscriptDirs: []string{captures},
+	})
+	return cleanupService, root
+}
+
+func TestCleanupService_EnforceRetention(t *testing.T) {
+	cleanupService, root := setupRetention(t, false)
+
+	report, err := cleanupService.EnforceRetention(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+
+	assert.Equal(t, map[string]int{retention.Recordings: 1, retention.SessionTranscripts: 1}, report.Purged)
+	assert.Equal(t, []string{"session_old.ttyrec"}, report.Items[retention.Recordings])
+	assert.Equal(t, map[string]int{retention.Recordings: 2}, report.Held, "held by session and by user")
+
+	assert.NoFileExists(t, filepath.Join(root, "recordings", "session_old.ttyrec"))
+	assert.FileExists(t, filepath.Join(root, "recordings", "session_fresh.ttyrec"))
+	assert.FileExists(t, filepath.Join(root, "recordings", "session_held.ttyrec"))
+	assert.FileExists(t, filepath.Join(root, "recordings", "notes.txt"))
+	assert.NoFileExists(t, filepath.Join(root, "captures", "session-a-20260101T000000Z.dgcap"))
+	assert.FileExists(t, filepath.Join(root, "captures", "session-b-20260101T000000Z.dgcap"))
+}
+
+func TestCleanupService_EnforceRetention_DryRun(t *testing.T) {
+	cleanupService, root := setupRetention(t, true)
+
+	report, err := cleanupService.EnforceRetention(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Purged[retention.Recordings])
+	assert.FileExists(t, filepath.Join(root, "recordings", "session_old.ttyrec"))
+	assert.FileExists(t, filepath.Join(root, "captures", "session-a-20260101T000000Z.dgcap"))
+}
+
+func TestTranscriptSessionID(t *testing.T) {
+	id, ok := transcriptSessionID("3f2c-aa-20260101T000000Z.dgcap")
+	assert.True(t, ok)
+	assert.Equal(t, "3f2c-aa", id)
+
+	_, ok = transcriptSessionID("session.ttyrec")
+	assert.False(t, ok)
+}
@@ -0,0 +1,254 @@
+package application
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// saveBundleFormat is the current version of the save bundle format
+const saveBundleFormat = 1
+
+// maxSaveBundleSize bounds the size of an imported bundle
+const maxSaveBundleSize = 64 * 1024 * 1024
+
+// ErrSaveBundleUntrusted is returned when an imported bundle has no signature
+// from a trusted server
+var ErrSaveBundleUntrusted = errors.New("save bundle is not signed by a trusted server")
+
+// ErrSaveBundleNotOwned is returned when a user imports a bundle of another
+// user's save
+var ErrSaveBundleNotOwned = apierror.New(apierror.PermissionDenied, "save bundle belongs to another user")
+
+// ErrSaveBundleOutdated is returned when a bundle was exported before the
+// user's current save last changed, so importing it would lose progress
+var ErrSaveBundleOutdated = apierror.New(apierror.Conflict, "save bundle is older than the current save")
+
+// SaveBundleOptions configures export and import of portable save bundles
+type SaveBundleOptions struct {
+	// ServerName identifies this server in exported bundles
+	ServerName string
+	// SigningKey signs exported bundles; export is refused without one
+	SigningKey string
+	// TrustedKeys maps the names of other servers to the keys their bundles are signed with
+	TrustedKeys map[string]string
+	// AllowUnsigned accepts bundles that are unsigned or from unknown servers
+	AllowUnsigned bool
+}
+
+// SaveBundle is a save packaged for moving between servers
+type SaveBundle struct {
+	Format      int                `json:"format"`
+	Server      string             `json:"server"`
+	UserID      int                `json:"user_id"`
+	Username    string             `json:"username,omitempty"`
+	GameID      string             `json:"game_id"`
+	GameVersion string             `json:"game_version"`
+	Checksum    string             `json:"checksum"`
+	Metadata    SaveBundleMetadata `json:"metadata"`
+	Data        []byte             `json:"data"`
+	ExportedAt  time.Time          `json:"exported_at"`
+
+	// Signed reports whether the bundle's signature was verified; it is not serialized
+	Signed bool `json:"-"`
+}
+
+// BundleImporter is who a bundle is imported for
+type BundleImporter struct {
+	UserID   int
+	Username string
+	// Admin lets the bundle of any user be imported, as when an admin moves
+	// a save between accounts
+	Admin bool
+}
+
+// SaveBundleMetadata carries the save metadata in a bundle
+type SaveBundleMetadata struct {
+	Character       string            `json:"character,omitempty"`
+	Level           int               `json:"level,omitempty"`
+	Score           int               `json:"score,omitempty"`
+	PlayTimeSeconds int64             `json:"play_time_seconds,omitempty"`
+	Location        string            `json:"location,omitempty"`
+	CustomFields    map[string]string `json:"custom_fields,omitempty"`
+}
+
+// signedSaveBundle is the serialized form of a bundle: the bundle JSON and
+// an HMAC-SHA256 of it
+type signedSaveBundle struct {
+	Bundle    json.RawMessage `json:"bundle"`
+	Signature string          `json:"signature,omitempty"`
+}
+
+// SetBundleOptions configures save bundles
+func (s *SaveService) SetBundleOptions(opts SaveBundleOptions) {
+	s.bundles = opts
+}
+
+// ExportSave packages a save into a signed bundle. gameVersion is recorded
+// when the save's metadata does not name the version that wrote it. Load the
+// save with LoadSave first so a corrupt save is never exported.
+func (s *SaveService) ExportSave(save *domain.GameSave, username, gameVersion string) ([]byte, *SaveBundle, error) {
+	if s.bundles.SigningKey == "" {
+		return nil, nil, fmt.Errorf("save export is not configured: no signing key")
+	}
+
+	metadata := save.Metadata()
+	if metadata.GameVersion != "" {
+		gameVersion = metadata.GameVersion
+	}
+
+	bundle := &SaveBundle{
+		Format:      saveBundleFormat,
+		Server:      s.bundles.ServerName,
+		UserID:      save.UserID().Int(),
+		Username:    username,
+		GameID:      save.GameID().String(),
+		GameVersion: gameVersion,
+		Checksum:    save.Checksum(),
+		Metadata: SaveBundleMetadata{
+			Character:       metadata.Character,
+			Level:           metadata.Level,
+			Score:           metadata.Score,
+			PlayTimeSeconds: int64(metadata.PlayTime.Seconds()),
+			Location:        metadata.Location,
+			CustomFields:    metadata.CustomFields,
+		},
+		Data:       save.Data(),
+		ExportedAt: time.Now().UTC(),
+	}
+
+	body, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode save bundle: %w", err)
+	}
+	encoded, err := json.Marshal(signedSaveBundle{
+		Bundle:    body,
+		Signature: signBundle(s.bundles.SigningKey, body),
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode save bundle: %w", err)
+	}
+
+	bundle.Signed = true
+	s.logger.Info("Exported save bundle", "save_id", save.ID().String(), "user_id", bundle.UserID, "game_id", bundle.GameID)
+	return encoded, bundle, nil
+}
+
+// OpenBundle decodes a save bundle and checks its signature and checksum
+func (s *SaveService) OpenBundle(data []byte) (*SaveBundle, error) {
+	if len(data) > maxSaveBundleSize {
+		return nil, fmt.Errorf("save bundle is larger than %d bytes", maxSaveBundleSize)
+	}
+
+	var envelope signedSaveBundle
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return nil, fmt.Errorf("invalid save bundle: %w", err)
+	}
+
+	var bundle SaveBundle
+	if err := json.Unmarshal(envelope.Bundle, &bundle); err != nil {
+		return nil, fmt.Errorf("invalid save bundle: %w", err)
+	}
+	if bundle.Format != saveBundleFormat {
+		return nil, fmt.Errorf("unsupported save bundle format %d", bundle.Format)
+	}
+	if bundle.GameID == "" || len(bundle.Data) == 0 {
+		return nil, fmt.Errorf("save bundle has no game or data")
+	}
+
+	if key := s.bundleKey(bundle.Server); key != "" && envelope.Signature != "" {
+		if !hmac.Equal([]byte(signBundle(key, envelope.Bundle)), []byte(envelope.Signature)) {
+			return nil, fmt.Errorf("save bundle signature does not match")
+		}
+		bundle.Signed = true
+	}
+	if !bundle.Signed && !s.bundles.AllowUnsigned {
+		return nil, ErrSaveBundleUntrusted
+	}
+
+	if bundle.Checksum != "" && bundle.Checksum != domain.SaveChecksum(bundle.Data) {
+		return nil, fmt.Errorf("save bundle data does not match its checksum")
+	}
+
+	return &bundle, nil
+}
+
+// ImportBundle stores the save in an opened bundle as the importer's save
+// for its game. The bundle must be the importer's own, unless they are an
+// admin, and not older than their current save, which is kept as a backup.
+func (s *SaveService) ImportBundle(ctx context.Context, importer BundleImporter, bundle *SaveBundle) (*domain.GameSave, error) {
+	if !importer.Admin && !s.bundleOwnedBy(bundle, importer) {
+		return nil, ErrSaveBundleNotOwned
+	}
+	existing, err := s.saveRepo.FindByUserAndGame(ctx, domain.NewUserID(importer.UserID), domain.NewGameID(bundle.GameID))
+	if err == nil && existing != nil && existing.IsActive() && existing.UpdatedAt().After(bundle.ExportedAt) {
+		return nil, ErrSaveBundleOutdated
+	}
+
+	userID := importer.UserID
+	customFields := make(map[string]string, len(bundle.Metadata.CustomFields)+1)
+	for key, value := range bundle.Metadata.CustomFields {
+		customFields[key] = value
+	}
+	if bundle.Server != "" {
+		customFields["imported_from"] = bundle.Server
+	}
+
+	save, err := s.StoreSave(ctx, userID, bundle.GameID, bundle.Data, domain.SaveMetadata{
+		GameVersion:  bundle.GameVersion,
+		Character:    bundle.Metadata.Character,
+		Level:        bundle.Metadata.Level,
+		Score:        bundle.Metadata.Score,
+		PlayTime:     time.Duration(bundle.Metadata.PlayTimeSeconds) * time.Second,
+		Location:     bundle.Metadata.Location,
+		CustomFields: customFields,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Imported save bundle",
+		"save_id", save.ID().String(),
+		"user_id", userID,
+		"game_id", bundle.GameID,
+		"server", bundle.Server,
+		"signed", bundle.Signed)
+	return save, nil
+}
+
+// bundleOwnedBy reports whether a bundle holds the importer's save. Bundles
+// from this server name their owner's user ID; those from other servers can
+// only be matched by username.
+func (s *SaveService) bundleOwnedBy(bundle *SaveBundle, importer BundleImporter) bool {
+	if bundle.Server == s.bundles.ServerName {
+		return bundle.UserID == importer.UserID
+	}
+	return bundle.Username != "" && strings.EqualFold(bundle.Username, importer.Username)
+}
+
+// bundleKey returns the key bundles from a server are signed with
+func (s *SaveService) bundleKey(server string) string {
+	if key, ok := s.bundles.TrustedKeys[server]; ok {
+		return key
+	}
+	if server == s.bundles.ServerName {
+		return s.bundles.SigningKey
+	}
+	return ""
+}
+
+// signBundle returns the hex HMAC-SHA256 of a bundle
+func signBundle(key string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+func TestSaveService_BundleRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	source := newTestSaveService(t)
+	source.SetBundleOptions(SaveBundleOptions{ServerName: "old-server", SigningKey: "old-key"})
+	save, err := source.StoreSave(ctx, 42, "nethack", []byte("portable save"), domain.SaveMetadata{GameVersion: "3.6.6", Character: "Hero"})
+	require.NoError(t, err)
+
+	data, bundle, err := source.ExportSave(save, "player", "3.6.7")
+	require.NoError(t, err)
+	assert.Equal(t, "3.6.6", bundle.GameVersion)
+
+	target := newTestSaveService(t)
+	target.SetBundleOptions(SaveBundleOptions{ServerName: "new-server", TrustedKeys: map[string]string{"old-server": "old-key"}})
+
+	opened, err := target.OpenBundle(data)
+	require.NoError(t, err)
+	assert.True(t, opened.Signed)
+	assert.Equal(t, "player", opened.Username)
+
+	imported, err := target.ImportBundle(ctx, BundleImporter{UserID: 7, Username: "Player"}, opened)
+	require.NoError(t, err)
+	assert.Equal(t, 7, imported.UserID().Int())
+	assert.Equal(t, save.Checksum(), imported.Checksum())
+	assert.Equal(t, "Hero", imported.Metadata().Character)
+	assert.Equal(t, "old-server", imported.Metadata().CustomFields["imported_from"])
+}
+
+func TestSaveService_OpenBundleRejectsUntrusted(t *testing.T) {
+	ctx := context.Background()
+
+	source := newTestSaveService(t)
+	source.SetBundleOptions(SaveBundleOptions{ServerName: "old-server", SigningKey: "old-key"})
+	save, err := source.StoreSave(ctx, 42, "nethack", []byte("portable save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	data, _, err := source.ExportSave(save, "", "")
+	require.NoError(t, err)
+
+	// Unknown server
+	target := newTestSaveService(t)
+	_, err = target.OpenBundle(data)
+	assert.ErrorIs(t, err, ErrSaveBundleUntrusted)
+
+	// Wrong key
+	target.SetBundleOptions(SaveBundleOptions{TrustedKeys: map[string]string{"old-server": "other-key"}})
+	_, err = target.OpenBundle(data)
+	assert.Error(t, err)
+
+	// Accepted unsigned, but not with altered data
+	target.SetBundleOptions(SaveBundleOptions{AllowUnsigned: true})
+	opened, err := target.OpenBundle(data)
+	require.NoError(t, err)
+	assert.False(t, opened.Signed)
+
+	opened.Data = []byte("altered save")
+	body, err := json.Marshal(opened)
+	require.NoError(t, err)
+	tampered, err := json.Marshal(signedSaveBundle{Bundle: body})
+	require.NoError(t, err)
+	_, err = target.OpenBundle(tampered)
+	assert.Error(t, err)
+}
+
+func TestSaveService_ImportBundleOwner(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+	service.SetBundleOptions(SaveBundleOptions{ServerName: "here", SigningKey: "key", TrustedKeys: map[string]string{"there": "other-key"}})
+
+	save, err := service.StoreSave(ctx, 42, "nethack", []byte("own save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	data, _, err := service.ExportSave(save, "player", "")
+	require.NoError(t, err)
+	bundle, err := service.OpenBundle(data)
+	require.NoError(t, err)
+
+	// On this server the owner is matched by user ID, not by a username
+	// another account may have taken since
+	_, err = service.ImportBundle(ctx, BundleImporter{UserID: 7, Username: "player"}, bundle)
+	assert.ErrorIs(t, err, ErrSaveBundleNotOwned)
+
+	// Bundles from other servers are matched by username
+	bundle.Server = "there"
+	_, err = service.ImportBundle(ctx, BundleImporter{UserID: 7, Username: "mallory"}, bundle)
+	assert.ErrorIs(t, err, ErrSaveBundleNotOwned)
+	bundle.Username = ""
+	_, err = service.ImportBundle(ctx, BundleImporter{UserID: 7, Username: ""}, bundle)
+	assert.ErrorIs(t, err, ErrSaveBundleNotOwned)
+
+	// Admins import for anyone
+	imported, err := service.ImportBundle(ctx, BundleImporter{UserID: 7, Admin: true}, bundle)
+	require.NoError(t, err)
+	assert.Equal(t, 7, imported.UserID().Int())
+}
+
+func TestSaveService_ImportBundleRejectsOlderThanSave(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+	service.SetBundleOptions(SaveBundleOptions{ServerName: "here", SigningKey: "key"})
+
+	save, err := service.StoreSave(ctx, 42, "nethack", []byte("turn 100"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	data, _, err := service.ExportSave(save, "player", "")
+	require.NoError(t, err)
+	bundle, err := service.OpenBundle(data)
+	require.NoError(t, err)
+
+	// Re-importing the bundle exported from the current save is harmless
+	_, err = service.ImportBundle(ctx, BundleImporter{UserID: 42}, bundle)
+	require.NoError(t, err)
+
+	// Once the game goes on, the bundle would lose progress
+	_, err = service.StoreSave(ctx, 42, "nethack", []byte("turn 500"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	_, err = service.ImportBundle(ctx, BundleImporter{UserID: 42}, bundle)
+	assert.ErrorIs(t, err, ErrSaveBundleOutdated)
+}
+
+func TestSaveService_ExportRequiresSigningKey(t *testing.T) {
+	service := newTestSaveService(t)
+	save, err := service.StoreSave(context.Background(), 42, "nethack", []byte("save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+
+	_, _, err = service.ExportSave(save, "", "")
+	assert.Error(t, err)
+}
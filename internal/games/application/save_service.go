@@ -0,0 +1,238 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/google/uuid"
+)
+
+// SaveService stores game saves and checks their integrity. Every save file
+// is checksummed when written and verified when loaded or listed; a save that
+// fails verification is marked corrupt and restored from its newest backup
+// that still verifies.
+type SaveService struct {
+	saveRepo domain.SaveRepository
+	saveDir  string
+	bundles  SaveBundleOptions
+	logger   *slog.Logger
+}
+
+// NewSaveService creates a new save service
+func NewSaveService(saveRepo domain.SaveRepository, logger *slog.Logger) *SaveService {
+	return &SaveService{
+		saveRepo: saveRepo,
+		saveDir:  filepath.Join(os.TempDir(), "dungeongate", "saves"),
+		logger:   logger,
+	}
+}
+
+// SetSaveDir sets the directory save files are written under
+func (s *SaveService) SetSaveDir(dir string) {
+	s.saveDir = dir
+}
+
+// StoreSave writes a user's save for a game. The previous version of an
+// existing save is kept as a backup to fall back to if the new one is found
+// corrupt.
+func (s *SaveService) StoreSave(ctx context.Context, userID int, gameID string, data []byte, metadata domain.SaveMetadata) (*domain.GameSave, error) {
+	userIDDomain := domain.NewUserID(userID)
+	gameIDDomain := domain.NewGameID(gameID)
+
+	existing, err := s.saveRepo.FindByUserAndGame(ctx, userIDDomain, gameIDDomain)
+	if err == nil && existing != nil {
+		if existing.IsActive() {
+			return s.updateSave(ctx, existing, data, metadata)
+		}
+		if existing.Status() == domain.SaveStatusCorrupt {
+			// Keep the corrupt save for inspection and start a new one
+			existing.Archive()
+			if err := s.saveRepo.Save(ctx, existing); err != nil {
+				return nil, fmt.Errorf("failed to archive corrupt save: %w", err)
+			}
+		}
+	}
+
+	saveID := domain.NewSaveID(uuid.New().String())
+	userSaveDir := filepath.Join(s.saveDir, fmt.Sprintf("user_%d", userID), gameID)
+	if err := os.MkdirAll(userSaveDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create user save directory: %w", err)
+	}
+
+	filePath := filepath.Join(userSaveDir, fmt.Sprintf("save_%s.dat", saveID.String()))
+	if err := writeSaveFile(filePath, data); err != nil {
+		return nil, err
+	}
+
+	save := domain.NewGameSave(saveID, userIDDomain, gameIDDomain, data, filePath, metadata)
+	if err := s.saveRepo.Save(ctx, save); err != nil {
+		return nil, fmt.Errorf("failed to save to database: %w", err)
+	}
+
+	s.logger.Info("Stored game save",
+		"save_id", saveID.String(),
+		"user_id", userID,
+		"game_id", gameID,
+		"checksum", save.Checksum())
+
+	return save, nil
+}
+
+// updateSave replaces the data of an existing save, moving the old file to
+// the backup the save records for it
+func (s *SaveService) updateSave(ctx context.Context, save *domain.GameSave, data []byte, metadata domain.SaveMetadata) (*domain.GameSave, error) {
+	if err := save.UpdateData(data, metadata); err != nil {
+		return nil, err
+	}
+
+	backups := save.Backups()
+	backup := backups[len(backups)-1]
+	if err := os.Rename(save.FilePath(), backup.FilePath); err != nil && !os.IsNotExist(err) {
+		s.logger.Warn("Failed to keep previous save as backup", "error", err, "save_id", save.ID().String())
+	}
+
+	if err := writeSaveFile(save.FilePath(), data); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveRepo.SaveBackup(ctx, save.ID(), backup); err != nil {
+		return nil, fmt.Errorf("failed to record save backup: %w", err)
+	}
+	if err := s.saveRepo.Save(ctx, save); err != nil {
+		return nil, fmt.Errorf("failed to save to database: %w", err)
+	}
+
+	s.logger.Info("Updated game save",
+		"save_id", save.ID().String(),
+		"backup_id", backup.ID,
+		"checksum", save.Checksum())
+
+	return save, nil
+}
+
+// LoadSave returns a user's save after verifying its file. It returns
+// domain.ErrSaveCorrupt when the save and all of its backups fail verification.
+func (s *SaveService) LoadSave(ctx context.Context, userID int, saveID string) (*domain.GameSave, error) {
+	save, err := s.saveRepo.FindByID(ctx, domain.NewSaveID(saveID))
+	if err != nil || save.UserID().Int() != userID {
+		return nil, domain.ErrSaveNotFound
+	}
+
+	switch save.Status() {
+	case domain.SaveStatusArchived, domain.SaveStatusDeleted:
+		return nil, domain.ErrSaveNotFound
+	}
+
+	if err := s.VerifySave(ctx, save); err != nil {
+		return nil, err
+	}
+	return save, nil
+}
+
+// CurrentSave returns the user's active save for a game, the one the game
+// resumes when next started. It returns domain.ErrSaveNotFound when there is none.
+func (s *SaveService) CurrentSave(ctx context.Context, userID int, gameID string) (*domain.GameSave, error) {
+	save, err := s.saveRepo.FindByUserAndGame(ctx, domain.NewUserID(userID), domain.NewGameID(gameID))
+	if err != nil || save == nil || !save.IsActive() {
+		return nil, domain.ErrSaveNotFound
+	}
+	return save, nil
+}
+
+// ListSaves returns a user's saves, optionally for one game and in one status.
+// Active saves are verified first, so corrupt ones are reported as such.
+func (s *SaveService) ListSaves(ctx context.Context, userID int, gameID string, status domain.SaveStatus) ([]*domain.GameSave, error) {
+	saves, err := s.saveRepo.FindByUser(ctx, domain.NewUserID(userID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list saves: %w", err)
+	}
+
+	var result []*domain.GameSave
+	for _, save := range saves {
+		if gameID != "" && save.GameID().String() != gameID {
+			continue
+		}
+		if save.Status() == domain.SaveStatusDeleted && status != domain.SaveStatusDeleted {
+			continue
+		}
+		if save.IsActive() {
+			if err := s.VerifySave(ctx, save); err != nil {
+				s.logger.Warn("Save failed verification", "error", err, "save_id", save.ID().String())
+			}
+		}
+		if status != "" && save.Status() != status {
+			continue
+		}
+		result = append(result, save)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].UpdatedAt().After(result[j].UpdatedAt())
+	})
+	return result, nil
+}
+
+// VerifySave checks a save file against its checksum. A corrupt save is
+// restored from its newest backup that verifies; if none does, the save stays
+// marked corrupt and domain.ErrSaveCorrupt is returned.
+func (s *SaveService) VerifySave(ctx context.Context, save *domain.GameSave) error {
+	data, err := os.ReadFile(save.FilePath())
+	if err != nil {
+		s.logger.Warn("Failed to read save file", "error", err, "save_id", save.ID().String())
+		save.MarkCorrupt()
+	} else if save.CheckIntegrity(data) {
+		return nil
+	}
+
+	s.logger.Warn("Save file failed checksum verification",
+		"save_id", save.ID().String(),
+		"user_id", save.UserID().Int(),
+		"game_id", save.GameID().String(),
+		"file_path", save.FilePath())
+
+	backups := save.Backups()
+	for i := len(backups) - 1; i >= 0; i-- {
+		backup := backups[i]
+		backupData, err := os.ReadFile(backup.FilePath)
+		if err != nil {
+			continue
+		}
+		if err := save.Restore(backup.ID, backupData); err != nil {
+			s.logger.Warn("Save backup failed checksum verification", "save_id", save.ID().String(), "backup_id", backup.ID)
+			continue
+		}
+		if err := writeSaveFile(save.FilePath(), backupData); err != nil {
+			return err
+		}
+		if err := s.saveRepo.Save(ctx, save); err != nil {
+			return fmt.Errorf("failed to save to database: %w", err)
+		}
+
+		s.logger.Warn("Restored corrupt save from backup", "save_id", save.ID().String(), "backup_id", backup.ID)
+		return nil
+	}
+
+	if err := s.saveRepo.Save(ctx, save); err != nil {
+		return fmt.Errorf("failed to save to database: %w", err)
+	}
+	return domain.ErrSaveCorrupt
+}
+
+// writeSaveFile writes a save file through a temporary file so a crash never
+// leaves it half written
+func writeSaveFile(path string, data []byte) error {
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write save file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func newTestSaveService(t *testing.T) *SaveService {
+	service := NewSaveService(repository.NewStubSaveRepository(), slog.Default())
+	service.SetSaveDir(t.TempDir())
+	return service
+}
+
+func TestSaveService_StoreAndLoad(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+
+	save, err := service.StoreSave(ctx, 42, "nethack", []byte("first save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	assert.Len(t, save.Checksum(), 64)
+
+	onDisk, err := os.ReadFile(save.FilePath())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first save"), onDisk)
+
+	loaded, err := service.LoadSave(ctx, 42, save.ID().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.SaveStatusActive, loaded.Status())
+	assert.False(t, loaded.VerifiedAt().IsZero())
+
+	// Another user's save is not visible
+	_, err = service.LoadSave(ctx, 7, save.ID().String())
+	assert.ErrorIs(t, err, domain.ErrSaveNotFound)
+}
+
+func TestSaveService_FallsBackToBackup(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+
+	save, err := service.StoreSave(ctx, 42, "nethack", []byte("first save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	updated, err := service.StoreSave(ctx, 42, "nethack", []byte("second save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	assert.Equal(t, save.ID(), updated.ID())
+	require.Len(t, updated.Backups(), 1)
+
+	// Damage the current save file
+	require.NoError(t, os.WriteFile(save.FilePath(), []byte("second sav3"), 0644))
+
+	loaded, err := service.LoadSave(ctx, 42, save.ID().String())
+	require.NoError(t, err)
+	assert.Equal(t, domain.SaveStatusActive, loaded.Status())
+	assert.Equal(t, []byte("first save"), loaded.Data())
+
+	onDisk, err := os.ReadFile(save.FilePath())
+	require.NoError(t, err)
+	assert.Equal(t, []byte("first save"), onDisk)
+}
+
+func TestSaveService_CorruptWithoutBackup(t *testing.T) {
+	ctx := context.Background()
+	service := newTestSaveService(t)
+
+	save, err := service.StoreSave(ctx, 42, "nethack", []byte("only save"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(save.FilePath(), []byte("0nly save"), 0644))
+
+	_, err = service.LoadSave(ctx, 42, save.ID().String())
+	assert.ErrorIs(t, err, domain.ErrSaveCorrupt)
+
+	corrupt, err := service.ListSaves(ctx, 42, "nethack", domain.SaveStatusCorrupt)
+	require.NoError(t, err)
+	require.Len(t, corrupt, 1)
+	assert.Equal(t, save.ID(), corrupt[0].ID())
+
+	// A new save replaces the corrupt one, which is archived
+	fresh, err := service.StoreSave(ctx, 42, "nethack", []byte("new game"), domain.SaveMetadata{})
+	require.NoError(t, err)
+	assert.NotEqual(t, save.ID(), fresh.ID())
+	assert.Equal(t, domain.SaveStatusArchived, save.Status())
+}
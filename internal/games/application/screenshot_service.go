@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+const (
+	// ScreenshotSharePath is where the HTTP API serves shared screenshots,
+	// followed by the share token
+	ScreenshotSharePath = "/shared/screenshots/"
+
+	// defaultScreenshotsPerSession caps a session's screenshots when no limit is configured
+	defaultScreenshotsPerSession = 20
+	// defaultScreenshotShareTTL is how long share links work when no TTL is configured
+	defaultScreenshotShareTTL = 24 * time.Hour
+	// maxScreenshotBytes bounds the renderings of one screenshot; a full
+	// 256-colour HTML screen of a large terminal stays well under it
+	maxScreenshotBytes = 2 * 1024 * 1024
+)
+
+// ScreenshotOptions configures the screenshot service
+type ScreenshotOptions struct {
+	// MaxPerSession is how many screenshots a session keeps
+	MaxPerSession int
+	// ShareTTL is how long a share link works
+	ShareTTL time.Duration
+	// PublicURL is where the HTTP API is reached from outside; share links
+	// are only a path without it
+	PublicURL string
+}
+
+// TakeScreenshotRequest is a screen captured by the session service
+type TakeScreenshotRequest struct {
+	TakenBy     int
+	TakenByName string
+	Cols, Rows  int
+	Renderings  map[domain.ScreenshotFormat]string
+}
+
+// ScreenshotService stores the screenshots players and spectators take of
+// games and shares them through public links
+type ScreenshotService struct {
+	screenshotRepo domain.ScreenshotRepository
+	options        ScreenshotOptions
+	now            func() time.Time
+}
+
+// NewScreenshotService creates a new screenshot service
+func NewScreenshotService(screenshotRepo domain.ScreenshotRepository) *ScreenshotService {
+	return &ScreenshotService{
+		screenshotRepo: screenshotRepo,
+		options: ScreenshotOptions{
+			MaxPerSession: defaultScreenshotsPerSession,
+			ShareTTL:      defaultScreenshotShareTTL,
+		},
+		now: time.Now,
+	}
+}
+
+// SetOptions configures the service; zero limits keep the defaults
+func (s *ScreenshotService) SetOptions(options ScreenshotOptions) {
+	if options.MaxPerSession <= 0 {
+		options.MaxPerSession = defaultScreenshotsPerSession
+	}
+	if options.ShareTTL <= 0 {
+		options.ShareTTL = defaultScreenshotShareTTL
+	}
+	options.PublicURL = strings.TrimSuffix(options.PublicURL, "/")
+	s.options = options
+}
+
+// TakeScreenshot stores a screenshot of a session
+func (s *ScreenshotService) TakeScreenshot(ctx context.Context, session *domain.GameSession, req TakeScreenshotRequest) (*domain.Screenshot, error) {
+	if req.TakenBy <= 0 {
+		return nil, apierror.New(apierror.InvalidRequest, "screenshots are taken by signed in users")
+	}
+	if req.Renderings[domain.ScreenshotText] == "" && req.Renderings[domain.ScreenshotANSI] == "" && req.Renderings[domain.ScreenshotHTML] == "" {
+		return nil, apierror.New(apierror.InvalidRequest, "screenshot is empty")
+	}
+	var size int
+	for _, rendering := range req.Renderings {
+		size += len(rendering)
+	}
+	if size > maxScreenshotBytes {
+		return nil, apierror.New(apierror.InvalidRequest, fmt.Sprintf("screenshot is larger than %d bytes", maxScreenshotBytes))
+	}
+
+	taken, err := s.screenshotRepo.FindBySession(ctx, session.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to count screenshots: %w", err)
+	}
+	if len(taken) >= s.options.MaxPerSession {
+		return nil, domain.ErrScreenshotLimit
+	}
+
+	screenshot := domain.NewScreenshot(domain.NewScreenshotID(generateScreenshotID()), session,
+		domain.NewUserID(req.TakenBy), req.TakenByName, req.Cols, req.Rows, req.Renderings)
+	if err := s.screenshotRepo.Save(ctx, screenshot); err != nil {
+		return nil, fmt.Errorf("failed to save screenshot: %w", err)
+	}
+	return screenshot, nil
+}
+
+// ListScreenshots returns the screenshots a user took or that were taken of
+// their games, newest first, optionally only those of one session
+func (s *ScreenshotService) ListScreenshots(ctx context.Context, userID int, sessionID string, limit int) ([]*domain.Screenshot, error) {
+	viewer := domain.NewUserID(userID)
+	if sessionID == "" {
+		screenshots, err := s.screenshotRepo.FindRecentByUser(ctx, viewer, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list screenshots: %w", err)
+		}
+		return screenshots, nil
+	}
+
+	taken, err := s.screenshotRepo.FindBySession(ctx, domain.NewSessionID(sessionID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list screenshots: %w", err)
+	}
+	var screenshots []*domain.Screenshot
+	for i := len(taken) - 1; i >= 0; i-- {
+		if !taken[i].CanView(viewer) {
+			continue
+		}
+		screenshots = append(screenshots, taken[i])
+		if limit > 0 && len(screenshots) >= limit {
+			break
+		}
+	}
+	return screenshots, nil
+}
+
+// GetScreenshot returns a screenshot by ID
+func (s *ScreenshotService) GetScreenshot(ctx context.Context, screenshotID string) (*domain.Screenshot, error) {
+	return s.screenshotRepo.FindByID(ctx, domain.NewScreenshotID(screenshotID))
+}
+
+// ShareScreenshot gives a screenshot a public link for the configured TTL.
+// Only the player and whoever took it may share it; sharing it again makes
+// a new link.
+func (s *ScreenshotService) ShareScreenshot(ctx context.Context, screenshotID string, userID int) (*domain.Screenshot, error) {
+	screenshot, err := s.GetScreenshot(ctx, screenshotID)
+	if err != nil {
+		return nil, err
+	}
+	if !screenshot.CanView(domain.NewUserID(userID)) {
+		return nil, apierror.New(apierror.PermissionDenied, "only the player and whoever took a screenshot may share it")
+	}
+
+	token, err := generateShareToken()
+	if err != nil {
+		return nil, err
+	}
+	screenshot.Share(token, s.now().Add(s.options.ShareTTL))
+	if err := s.screenshotRepo.Save(ctx, screenshot); err != nil {
+		return nil, fmt.Errorf("failed to share screenshot: %w", err)
+	}
+	return screenshot, nil
+}
+
+// GetSharedScreenshot returns the screenshot a public link is to, as long as
+// the link has not expired
+func (s *ScreenshotService) GetSharedScreenshot(ctx context.Context, token string) (*domain.Screenshot, error) {
+	screenshot, err := s.screenshotRepo.FindByShareToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !screenshot.SharedAt(s.now()) {
+		return nil, domain.ErrScreenshotNotFound
+	}
+	return screenshot, nil
+}
+
+// ShareURL returns the public link of a shared screenshot, "" when it is not
+// shared
+func (s *ScreenshotService) ShareURL(screenshot *domain.Screenshot) string {
+	if !screenshot.SharedAt(s.now()) {
+		return ""
+	}
+	return s.options.PublicURL + ScreenshotSharePath + screenshot.ShareToken()
+}
+
+// generateScreenshotID generates a unique screenshot ID
+func generateScreenshotID() string {
+	return fmt.Sprintf("screenshot_%d", time.Now().UnixNano())
+}
+
+// generateShareToken generates an unguessable share link token
+func generateShareToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("failed to generate share token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+func newScreenshotRequest(takenBy int, name string) TakeScreenshotRequest {
+	return TakeScreenshotRequest{
+		TakenBy:     takenBy,
+		TakenByName: name,
+		Cols:        80,
+		Rows:        24,
+		Renderings: map[domain.ScreenshotFormat]string{
+			domain.ScreenshotText: "Dlvl:1\n",
+			domain.ScreenshotANSI: "\x1b[0;1mDlvl:1\x1b[0m\n",
+			domain.ScreenshotHTML: "<pre>Dlvl:1</pre>",
+		},
+	}
+}
+
+func TestScreenshotService_TakeAndList(t *testing.T) {
+	ctx := context.Background()
+	service := NewScreenshotService(repository.NewStubScreenshotRepository())
+	service.SetOptions(ScreenshotOptions{MaxPerSession: 2})
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+
+	own, err := service.TakeScreenshot(ctx, session, newScreenshotRequest(42, "player"))
+	require.NoError(t, err)
+	assert.False(t, own.BySpectator())
+	assert.Equal(t, "Dlvl:1\n", own.Content(domain.ScreenshotText))
+
+	watched, err := service.TakeScreenshot(ctx, session, newScreenshotRequest(7, "watcher"))
+	require.NoError(t, err)
+	assert.True(t, watched.BySpectator())
+
+	_, err = service.TakeScreenshot(ctx, session, newScreenshotRequest(42, "player"))
+	assert.Equal(t, apierror.RateLimited, apierror.CodeOf(err))
+
+	// The player sees every screenshot of their game, spectators their own
+	screenshots, err := service.ListScreenshots(ctx, 42, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, screenshots, 2)
+	screenshots, err = service.ListScreenshots(ctx, 7, "session_dumplog", 10)
+	require.NoError(t, err)
+	require.Len(t, screenshots, 1)
+	assert.Equal(t, watched.ID(), screenshots[0].ID())
+	screenshots, err = service.ListScreenshots(ctx, 8, "session_dumplog", 10)
+	require.NoError(t, err)
+	assert.Empty(t, screenshots)
+
+	_, err = service.TakeScreenshot(ctx, createMockSessionWithID("session_dumplog", 42, "nethack"), TakeScreenshotRequest{TakenBy: 42})
+	assert.Equal(t, apierror.InvalidRequest, apierror.CodeOf(err))
+}
+
+func TestScreenshotService_Share(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+	service := NewScreenshotService(repository.NewStubScreenshotRepository())
+	service.SetOptions(ScreenshotOptions{ShareTTL: time.Hour, PublicURL: "https://dg.example.org/"})
+	service.now = func() time.Time { return now }
+
+	screenshot, err := service.TakeScreenshot(ctx, createMockSessionWithID("session_dumplog", 42, "nethack"), newScreenshotRequest(7, "watcher"))
+	require.NoError(t, err)
+	assert.Empty(t, service.ShareURL(screenshot))
+
+	_, err = service.ShareScreenshot(ctx, screenshot.ID().String(), 8)
+	assert.Equal(t, apierror.PermissionDenied, apierror.CodeOf(err))
+
+	shared, err := service.ShareScreenshot(ctx, screenshot.ID().String(), 42)
+	require.NoError(t, err)
+	assert.Equal(t, "https://dg.example.org/shared/screenshots/"+shared.ShareToken(), service.ShareURL(shared))
+	assert.Len(t, shared.ShareToken(), 32)
+
+	found, err := service.GetSharedScreenshot(ctx, shared.ShareToken())
+	require.NoError(t, err)
+	assert.Equal(t, screenshot.ID(), found.ID())
+
+	// The link stops working once it expires
+	now = now.Add(time.Hour)
+	_, err = service.GetSharedScreenshot(ctx, shared.ShareToken())
+	assert.ErrorIs(t, err, domain.ErrScreenshotNotFound)
+	_, err = service.GetSharedScreenshot(ctx, "")
+	assert.ErrorIs(t, err, domain.ErrScreenshotNotFound)
+}
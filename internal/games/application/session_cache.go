@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// SessionCache keeps an in-memory view of active sessions so hot read paths
+// (watch menus, session listings) are served without a repository read per
+// request. It is updated as sessions change and notifies subscribers so they
+// can refresh instead of polling. Changes that only touch volatile fields,
+// such as the spectator list, are persisted behind the cache by Run.
+type SessionCache struct {
+	sessionRepo domain.SessionRepository
+	logger      *slog.Logger
+
+	mu               sync.RWMutex
+	active           map[string]*domain.GameSession
+	dirty            map[string]*domain.GameSession
+	version          uint64
+	subscribers      map[int]chan uint64
+	nextSubscriberID int
+}
+
+// NewSessionCache creates a new session cache backed by the session repository
+func NewSessionCache(sessionRepo domain.SessionRepository, logger *slog.Logger) *SessionCache {
+	return &SessionCache{
+		sessionRepo: sessionRepo,
+		logger:      logger,
+		active:      make(map[string]*domain.GameSession),
+		dirty:       make(map[string]*domain.GameSession),
+		subscribers: make(map[int]chan uint64),
+	}
+}
+
+// Warm loads the currently active sessions from the repository
+func (c *SessionCache) Warm(ctx context.Context) error {
+	sessions, err := c.sessionRepo.FindActive(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load active sessions: %w", err)
+	}
+
+	c.mu.Lock()
+	for _, session := range sessions {
+		c.active[session.ID().String()] = session
+	}
+	c.mu.Unlock()
+
+	c.notify()
+	return nil
+}
+
+// Put records the current state of a session. Sessions that are no longer
+// active are dropped from the view.
+func (c *SessionCache) Put(session *domain.GameSession) {
+	id := session.ID().String()
+
+	c.mu.Lock()
+	if session.IsActive() {
+		c.active[id] = session
+	} else {
+		delete(c.active, id)
+	}
+	c.mu.Unlock()
+
+	c.notify()
+}
+
+// MarkDirty records a session change and queues it for write-behind persistence
+func (c *SessionCache) MarkDirty(session *domain.GameSession) {
+	c.mu.Lock()
+	c.dirty[session.ID().String()] = session
+	c.mu.Unlock()
+
+	c.Put(session)
+}
+
+// Get returns an active session from the cache
+func (c *SessionCache) Get(sessionID string) (*domain.GameSession, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	session, ok := c.active[sessionID]
+	return session, ok
+}
+
+// Active returns the active sessions ordered by start time
+func (c *SessionCache) Active() []*domain.GameSession {
+	c.mu.RLock()
+	sessions := make([]*domain.GameSession, 0, len(c.active))
+	for _, session := range c.active {
+		sessions = append(sessions, session)
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartTime().Before(sessions[j].StartTime())
+	})
+	return sessions
+}
+
+// Version returns a counter that increases on every change to the view
+func (c *SessionCache) Version() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.version
+}
+
+// Subscribe returns a channel that receives the view version after changes,
+// and a function that cancels the subscription. Notifications are coalesced:
+// a slow subscriber sees the latest version rather than every change.
+func (c *SessionCache) Subscribe() (<-chan uint64, func()) {
+	ch := make(chan uint64, 1)
+
+	c.mu.Lock()
+	id := c.nextSubscriberID
+	c.nextSubscriberID++
+	c.subscribers[id] = ch
+	c.mu.Unlock()
+
+	return ch, func() {
+		c.mu.Lock()
+		delete(c.subscribers, id)
+		c.mu.Unlock()
+	}
+}
+
+// Flush persists all sessions queued by MarkDirty. A session another writer
+// saved in the meantime is dropped rather than requeued, since retrying the
+// stale copy could never succeed.
+func (c *SessionCache) Flush(ctx context.Context) error {
+	c.mu.Lock()
+	pending := c.dirty
+	c.dirty = make(map[string]*domain.GameSession)
+	c.mu.Unlock()
+
+	var firstErr error
+	for id, session := range pending {
+		if err := c.sessionRepo.Save(ctx, session); err != nil {
+			if errors.Is(err, domain.ErrConflict) {
+				c.logger.Warn("Dropped stale session change", "session_id", id, "error", err)
+				continue
+			}
+			// Requeue unless a newer change is already waiting
+			c.mu.Lock()
+			if _, ok := c.dirty[id]; !ok {
+				c.dirty[id] = session
+			}
+			c.mu.Unlock()
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to persist session %s: %w", id, err)
+			}
+		}
+	}
+	return firstErr
+}
+
+// Close performs the final flush as the service stops, once the scheduled
+// flushes have ended
+func (c *SessionCache) Close() {
+	flushCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := c.Flush(flushCtx); err != nil {
+		c.logger.Error("Failed to flush session cache on shutdown", "error", err)
+	}
+}
+
+// notify bumps the view version and wakes subscribers
+func (c *SessionCache) notify() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	for _, ch := range c.subscribers {
+		// Drop a stale pending notification so the latest version is delivered
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- c.version
+	}
+}
@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func TestSessionCache_PutAndRemove(t *testing.T) {
+	cache := NewSessionCache(repository.NewStubSessionRepository(), slog.Default())
+	updates, unsubscribe := cache.Subscribe()
+	defer unsubscribe()
+
+	session := createMockSessionWithID("session_a", 1, "nethack")
+	cache.Put(session)
+
+	assert.Equal(t, uint64(1), <-updates)
+	cached, ok := cache.Get("session_a")
+	require.True(t, ok)
+	assert.Same(t, session, cached)
+	assert.Len(t, cache.Active(), 1)
+
+	// Ended sessions leave the active view
+	session.End(nil, nil)
+	cache.Put(session)
+
+	assert.Equal(t, uint64(2), <-updates)
+	_, ok = cache.Get("session_a")
+	assert.False(t, ok)
+	assert.Empty(t, cache.Active())
+}
+
+func TestSessionCache_CoalescesNotifications(t *testing.T) {
+	cache := NewSessionCache(repository.NewStubSessionRepository(), slog.Default())
+	updates, unsubscribe := cache.Subscribe()
+	defer unsubscribe()
+
+	cache.Put(createMockSessionWithID("session_a", 1, "nethack"))
+	cache.Put(createMockSessionWithID("session_b", 2, "nethack"))
+	cache.Put(createMockSessionWithID("session_c", 3, "nethack"))
+
+	// A slow subscriber only sees the latest version
+	assert.Equal(t, uint64(3), <-updates)
+	select {
+	case v := <-updates:
+		t.Fatalf("unexpected extra notification %d", v)
+	default:
+	}
+}
+
+func TestSessionCache_WriteBehind(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := repository.NewStubSessionRepository()
+	cache := NewSessionCache(sessionRepo, slog.Default())
+
+	session := createMockSessionWithID("session_a", 1, "nethack")
+	cache.MarkDirty(session)
+
+	// Visible in the cache immediately, persisted only on flush
+	_, ok := cache.Get("session_a")
+	assert.True(t, ok)
+	_, err := sessionRepo.FindByID(ctx, session.ID())
+	assert.Error(t, err)
+
+	require.NoError(t, cache.Flush(ctx))
+	stored, err := sessionRepo.FindByID(ctx, session.ID())
+	require.NoError(t, err)
+	assert.Equal(t, session.ID(), stored.ID())
+}
+
+func TestSessionCache_FlushDropsStaleSessions(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := repository.NewStubSessionRepository()
+	cache := NewSessionCache(sessionRepo, slog.Default())
+
+	// Another writer saved the session after the cache's copy was loaded
+	require.NoError(t, sessionRepo.Save(ctx, createMockSessionWithID("session_a", 1, "nethack")))
+	stale := createMockSessionWithID("session_a", 1, "nethack")
+	cache.MarkDirty(stale)
+
+	require.NoError(t, cache.Flush(ctx))
+	require.NoError(t, cache.Flush(ctx), "the stale change is not retried")
+}
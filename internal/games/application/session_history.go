@@ -0,0 +1,104 @@
+package application
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// xlogTailSize is how much of the end of an xlogfile is searched for a
+// finished session's entry. Games append one line per game, so the entry is
+// near the end.
+const xlogTailSize = 256 * 1024
+
+// ListSessionHistory returns a user's finished sessions matching filter,
+// newest first, and how many matched before paging
+func (s *SessionService) ListSessionHistory(ctx context.Context, userID int, filter domain.HistoryFilter) ([]*domain.GameSession, int, error) {
+	sessions, err := s.sessionRepo.FindByUserID(ctx, domain.NewUserID(userID))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find user sessions: %w", err)
+	}
+	history, total := domain.SessionHistory(sessions, filter)
+	return history, total, nil
+}
+
+// CaptureOutcome reads how a finished session's game ended from the game's
+// xlogfile and records it with the session. It returns nil without error
+// when the game wrote no entry for the session, e.g. after saving.
+func (s *SessionService) CaptureOutcome(ctx context.Context, session *domain.GameSession, xlogPath string) (*domain.GameOutcome, error) {
+	entry, err := findXlogEntry(xlogPath, session)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	outcome := entry.Outcome()
+	session.RecordOutcome(outcome)
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to record game outcome: %w", err)
+	}
+	return &outcome, nil
+}
+
+// findXlogEntry returns the last xlogfile entry written for the session's
+// player since the session started, or nil if there is none. The NetHack
+// adapter plays as user_<id> rather than the username, so either matches.
+func findXlogEntry(path string, session *domain.GameSession) (domain.XlogEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to open xlogfile: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat xlogfile: %w", err)
+	}
+	offset := info.Size() - xlogTailSize
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to read xlogfile: %w", err)
+	}
+
+	since := session.StartTime().Add(-dumplogClockSkew)
+	gameName := fmt.Sprintf("user_%d", session.UserID().Int())
+	var found domain.XlogEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 4096), 64*1024)
+	first := offset > 0
+	for scanner.Scan() {
+		// The first line of a tail is usually cut short
+		if first {
+			first = false
+			continue
+		}
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		entry := domain.ParseXlogEntry(line)
+		if name := entry.Name(); name != session.Username() && name != gameName {
+			continue
+		}
+		if end := entry.EndTime(); !end.IsZero() && end.Before(since) {
+			continue
+		}
+		found = entry
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read xlogfile: %w", err)
+	}
+	return found, nil
+}
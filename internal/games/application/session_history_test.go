@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+)
+
+func TestSessionService_CaptureOutcome(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := repository.NewStubSessionRepository()
+	service := NewSessionService(sessionRepo, nil, nil, nil, nil)
+
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+	require.NoError(t, session.End(nil, nil))
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	// A previous game of the same player and a game of someone else come first
+	xlog := fmt.Sprintf("name=testuser\tdeath=quit\tendtime=%d\n", time.Now().Add(-48*time.Hour).Unix()) +
+		fmt.Sprintf("name=testuser\tdeath=killed by a newt\tpoints=42\tturns=300\tendtime=%d\n", time.Now().Unix()) +
+		fmt.Sprintf("name=someone\tdeath=ascended\tendtime=%d\n", time.Now().Unix())
+	path := filepath.Join(t.TempDir(), "xlogfile")
+	require.NoError(t, os.WriteFile(path, []byte(xlog), 0644))
+
+	outcome, err := service.CaptureOutcome(ctx, session, path)
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.Equal(t, domain.GameResultDied, outcome.Result)
+	assert.Equal(t, "killed by a newt", outcome.Death)
+	assert.Equal(t, int64(42), outcome.Points)
+	assert.Equal(t, outcome, session.Outcome())
+
+	history, total, err := service.ListSessionHistory(ctx, 42, domain.HistoryFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, history, 1)
+	assert.Equal(t, domain.GameResultDied, history[0].Outcome().Result)
+}
+
+func TestSessionService_CaptureOutcome_NoEntry(t *testing.T) {
+	ctx := context.Background()
+	service := NewSessionService(repository.NewStubSessionRepository(), nil, nil, nil, nil)
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+
+	// A saved game writes no xlog entry
+	path := filepath.Join(t.TempDir(), "xlogfile")
+	require.NoError(t, os.WriteFile(path, []byte("name=someone\tdeath=quit\n"), 0644))
+	outcome, err := service.CaptureOutcome(ctx, session, path)
+	require.NoError(t, err)
+	assert.Nil(t, outcome)
+
+	outcome, err = service.CaptureOutcome(ctx, session, filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Nil(t, outcome)
+	assert.Nil(t, session.Outcome())
+}
+
+func TestSessionService_CaptureOutcome_GameName(t *testing.T) {
+	ctx := context.Background()
+	service := NewSessionService(repository.NewStubSessionRepository(), nil, nil, nil, nil)
+	session := createMockSessionWithID("session_dumplog", 42, "nethack")
+
+	// The NetHack adapter plays as user_<id>
+	xlog := fmt.Sprintf("name=user_42\tdeath=ascended\tendtime=%d\n", time.Now().Unix())
+	path := filepath.Join(t.TempDir(), "xlogfile")
+	require.NoError(t, os.WriteFile(path, []byte(xlog), 0644))
+
+	outcome, err := service.CaptureOutcome(ctx, session, path)
+	require.NoError(t, err)
+	require.NotNil(t, outcome)
+	assert.Equal(t, domain.GameResultAscended, outcome.Result)
+}
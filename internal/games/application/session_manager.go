@@ -21,6 +21,7 @@ type SessionManager struct {
 	saveRepo    domain.SaveRepository
 	gameRepo    domain.GameRepository
 	eventRepo   domain.EventRepository
+	saves       *SaveService
 	logger      *slog.Logger
 	gameDataDir string
 }
@@ -34,11 +35,15 @@ func NewSessionManager(
 	gameDataDir string,
 	logger *slog.Logger,
 ) *SessionManager {
+	saves := NewSaveService(saveRepo, logger)
+	saves.SetSaveDir(filepath.Join(gameDataDir, "saves"))
+
 	return &SessionManager{
 		sessionRepo: sessionRepo,
 		saveRepo:    saveRepo,
 		gameRepo:    gameRepo,
 		eventRepo:   eventRepo,
+		saves:       saves,
 		gameDataDir: gameDataDir,
 		logger:      logger,
 	}
@@ -70,10 +75,14 @@ func (sm *SessionManager) StartGameSession(ctx context.Context, userID int, game
 
 	// Check for existing save
 	existingSave, err := sm.saveRepo.FindByUserAndGame(ctx, userIDDomain, gameIDDomain)
-	if err == nil && existingSave != nil {
+	if err == nil && existingSave != nil && existingSave.IsActive() {
 		sm.logger.Info("Found existing save for user", "user_id", userID, "game_id", gameID)
-		// Load save data into session working directory
-		err = sm.prepareSaveEnvironment(session, existingSave)
+		// Only load a save that verifies, possibly after falling back to a backup
+		err = sm.saves.VerifySave(ctx, existingSave)
+		if err == nil {
+			// Load save data into session working directory
+			err = sm.prepareSaveEnvironment(session, existingSave)
+		}
 		if err != nil {
 			sm.logger.Error("Failed to prepare save environment", "error", err)
 		}
@@ -94,11 +103,15 @@ func (sm *SessionManager) StartGameSession(ctx context.Context, userID int, game
 	}
 
 	// Update session with process info
-	session.Start(domain.ProcessInfo{
+	if err := session.Start(domain.ProcessInfo{
 		PID:         cmd.Process.Pid,
 		ContainerID: "",
 		PodName:     "",
-	})
+	}); err != nil {
+		cmd.Process.Kill()
+		os.RemoveAll(sessionDir)
+		return nil, err
+	}
 
 	// Save session to database
 	err = sm.sessionRepo.Save(ctx, session)
@@ -149,7 +162,7 @@ func (sm *SessionManager) EndGameSession(ctx context.Context, sessionID string)
 
 	// Check if session is active
 	if !session.IsActive() {
-		return fmt.Errorf("session is not active")
+		return domain.ErrSessionNotActive
 	}
 
 	// Send termination signal to process
@@ -174,13 +187,16 @@ func (sm *SessionManager) EndGameSession(ctx context.Context, sessionID string)
 	}
 
 	// Mark session as ended
-	session.End(nil, nil)
+	if err := session.End(nil, nil); err != nil {
+		return err
+	}
 
 	// Update session in database
 	err = sm.sessionRepo.Save(ctx, session)
 	if err != nil {
 		return fmt.Errorf("failed to update session: %w", err)
 	}
+	saveStatusEvents(ctx, sm.eventRepo, session.PullEvents())
 
 	// Record session end event
 	event := &domain.GameEvent{
@@ -279,14 +295,18 @@ func (sm *SessionManager) handleGameProcessExit(ctx context.Context, session *do
 		sm.logger.Error("Failed to create save from session", "session_id", session.ID().String(), "error", err)
 	}
 
-	// Mark session as ended
-	session.End(exitCode, signal)
+	// Mark session as ended; stopping it may already have done so
+	if err := session.End(exitCode, signal); err != nil {
+		sm.logger.Debug("Session already over when its game exited", "session_id", session.ID().String(), "error", err)
+		return
+	}
 
 	// Update session in database
 	err = sm.sessionRepo.Save(ctx, session)
 	if err != nil {
 		sm.logger.Error("Failed to update session after process exit", "error", err)
 	}
+	saveStatusEvents(ctx, sm.eventRepo, session.PullEvents())
 
 	// Record process exit event
 	event := &domain.GameEvent{
@@ -328,32 +348,9 @@ func (sm *SessionManager) createSaveFromSession(ctx context.Context, session *do
 		return fmt.Errorf("failed to read save file: %w", err)
 	}
 
-	// Create permanent save directory
-	userSaveDir := filepath.Join(sm.gameDataDir, "saves",
-		fmt.Sprintf("user_%d", session.UserID().Int()),
-		session.GameID().String())
-	err = os.MkdirAll(userSaveDir, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create user save directory: %w", err)
-	}
-
-	// Copy save to permanent location
-	permanentSavePath := filepath.Join(userSaveDir, fmt.Sprintf("save_%s.dat", session.ID().String()))
-	err = os.WriteFile(permanentSavePath, saveData, 0644)
-	if err != nil {
-		return fmt.Errorf("failed to write permanent save file: %w", err)
-	}
-
-	// Create save domain object
-	saveID := domain.NewSaveID(uuid.New().String())
-	save := domain.NewGameSave(
-		saveID,
-		session.UserID(),
-		session.GameID(),
-		saveData,
-		permanentSavePath,
+	save, err := sm.saves.StoreSave(ctx, session.UserID().Int(), session.GameID().String(), saveData,
 		domain.SaveMetadata{
-			GameVersion: "1.0",    // Would come from game
+			GameVersion: session.GameVersion(),
 			Character:   "Player", // Would parse from save data
 			Level:       1,        // Would parse from save data
 			Score:       0,        // Would parse from save data
@@ -361,14 +358,11 @@ func (sm *SessionManager) createSaveFromSession(ctx context.Context, session *do
 			Location:    "Dungeon", // Would parse from save data
 		},
 	)
-
-	// Save to database
-	err = sm.saveRepo.Save(ctx, save)
 	if err != nil {
-		return fmt.Errorf("failed to save to database: %w", err)
+		return err
 	}
 
-	sm.logger.Info("Created save for session", "save_id", saveID.String(), "session_id", session.ID().String())
+	sm.logger.Info("Created save for session", "save_id", save.ID().String(), "session_id", session.ID().String())
 
 	return nil
 }
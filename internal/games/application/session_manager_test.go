@@ -420,6 +420,7 @@ func TestSessionManager_CreateSaveFromSession(t *testing.T) {
 	require.NoError(t, err)
 
 	// Set up mock expectations
+	saveRepo.On("FindByUserAndGame", ctx, session.UserID(), session.GameID()).Return(nil, fmt.Errorf("not found"))
 	saveRepo.On("Save", ctx, mock.AnythingOfType("*domain.GameSave")).Return(nil)
 
 	// Test creating save from session
@@ -467,6 +468,7 @@ func TestSessionManager_EndGameSession(t *testing.T) {
 
 	// Set up mock expectations
 	sessionRepo.On("FindByID", ctx, domain.NewSessionID(sessionID)).Return(session, nil)
+	saveRepo.On("FindByUserAndGame", ctx, session.UserID(), session.GameID()).Return(nil, fmt.Errorf("not found"))
 	saveRepo.On("Save", ctx, mock.AnythingOfType("*domain.GameSave")).Return(nil)
 	sessionRepo.On("Save", ctx, mock.AnythingOfType("*domain.GameSession")).Return(nil)
 	eventRepo.On("SaveEvent", ctx, mock.AnythingOfType("*domain.GameEvent")).Return(nil)
@@ -552,7 +554,11 @@ func createMockSave(userID int, gameID, filePath string, data []byte) *domain.Ga
 }
 
 func createMockSession(userID int, gameID string) *domain.GameSession {
-	sessionID := domain.NewSessionID(uuid.New().String())
+	return createMockSessionWithID(uuid.New().String(), userID, gameID)
+}
+
+func createMockSessionWithID(id string, userID int, gameID string) *domain.GameSession {
+	sessionID := domain.NewSessionID(id)
 	userIDDomain := domain.NewUserID(userID)
 	gameIDDomain := domain.NewGameID(gameID)
 	terminalSize := domain.TerminalSize{Width: 80, Height: 24}
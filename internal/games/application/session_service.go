@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os/exec"
 	"time"
@@ -9,6 +10,9 @@ import (
 	"github.com/dungeongate/internal/games/domain"
 )
 
+// RecordingDir is where session ttyrec recordings are written
+const RecordingDir = "/var/lib/dungeongate/recordings"
+
 // SessionService provides application-level session management operations
 type SessionService struct {
 	sessionRepo domain.SessionRepository
@@ -16,6 +20,7 @@ type SessionService struct {
 	saveRepo    domain.SaveRepository
 	eventRepo   domain.EventRepository
 	uow         domain.UnitOfWork
+	cache       *SessionCache
 }
 
 // NewSessionService creates a new session service
@@ -35,6 +40,11 @@ func NewSessionService(
 	}
 }
 
+// SetSessionCache serves active session reads from cache and keeps it up to date
+func (s *SessionService) SetSessionCache(cache *SessionCache) {
+	s.cache = cache
+}
+
 // StartGameSession starts a new game session
 func (s *SessionService) StartGameSession(ctx context.Context, req *StartSessionRequest) (*domain.GameSession, error) {
 	// Validate request
@@ -49,8 +59,8 @@ func (s *SessionService) StartGameSession(ctx context.Context, req *StartSession
 		return nil, fmt.Errorf("game not found: %w", err)
 	}
 
-	if !game.CanStart() {
-		return nil, fmt.Errorf("game %s is not available for play", req.GameID)
+	if err := game.UnavailableError(); err != nil {
+		return nil, err
 	}
 
 	// Check if user already has an active session for this game
@@ -62,19 +72,21 @@ func (s *SessionService) StartGameSession(ctx context.Context, req *StartSession
 
 	for _, session := range activeSessions {
 		if session.GameID() == gameID {
-			return nil, fmt.Errorf("user already has an active session for game %s", req.GameID)
+			return nil, fmt.Errorf("%w: %s", domain.ErrActiveSessionExists, req.GameID)
 		}
 	}
 
-	// Begin transaction
-	if err := s.uow.Begin(ctx); err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
-	}
-	defer func() {
+	// A player whose play-time budget is used up may not start another game
+	var playTimeLeft time.Duration
+	if !req.PlayTimeLimit.IsZero() {
+		history, err := s.sessionRepo.FindByUserID(ctx, userID)
 		if err != nil {
-			s.uow.Rollback(ctx)
+			return nil, fmt.Errorf("failed to check play time: %w", err)
+		}
+		if playTimeLeft, err = req.PlayTimeLimit.Check(history, time.Now()); err != nil {
+			return nil, err
 		}
-	}()
+	}
 
 	// Create session
 	sessionID := domain.NewSessionID(generateSessionID())
@@ -91,11 +103,22 @@ func (s *SessionService) StartGameSession(ctx context.Context, req *StartSession
 		game.Config(),
 		terminalSize,
 	)
+	session.SetTerminalType(req.TerminalType)
+	if req.Locale != "" {
+		session.SetLocale(req.Locale)
+	}
+	if req.GameVersion != "" {
+		session.SetGameVersion(req.GameVersion)
+	}
+	session.SetClientIP(req.ClientIP)
+	if playTimeLeft > 0 {
+		session.SetPlayTimeEndsAt(time.Now().Add(playTimeLeft))
+	}
 
 	// Enable recording if requested
 	if req.EnableRecording {
-		recordingPath := fmt.Sprintf("/var/lib/dungeongate/recordings/%s.ttyrec", sessionID.String())
-		session.EnableRecording(recordingPath, "ttyrec")
+		recordingPath := fmt.Sprintf("%s/%s.ttyrec", RecordingDir, sessionID.String())
+		session.EnableRecording(recordingPath, "ttyrec", req.RecordingPrivate)
 	}
 
 	// Enable streaming if requested
@@ -109,43 +132,91 @@ func (s *SessionService) StartGameSession(ctx context.Context, req *StartSession
 		return nil, fmt.Errorf("failed to start game process: %w", err)
 	}
 
-	session.Start(processInfo)
-
-	// Save session
-	if err := s.uow.Sessions().Save(ctx, session); err != nil {
-		return nil, fmt.Errorf("failed to save session: %w", err)
+	if err := session.Start(processInfo); err != nil {
+		s.stopGameProcess(ctx, session)
+		return nil, err
 	}
 
-	// Update game statistics
-	game.IncrementPlayCount()
-	if err := s.uow.Games().Save(ctx, game); err != nil {
-		return nil, fmt.Errorf("failed to update game statistics: %w", err)
-	}
+	// Record the session, the game's play count and the start event together
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.uow.Sessions().Save(ctx, session); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
 
-	// Publish session start event
-	event := &domain.GameEvent{
-		ID:        generateEventID(),
-		Type:      domain.GameEventTypeSessionStart,
-		GameID:    req.GameID,
-		SessionID: sessionID.String(),
-		UserID:    req.UserID,
-		Data: map[string]interface{}{
-			"username":      req.Username,
-			"terminal_size": fmt.Sprintf("%dx%d", req.TerminalWidth, req.TerminalHeight),
-		},
-		Timestamp: time.Now(),
+		game.IncrementPlayCount()
+		if err := s.uow.Games().Save(ctx, game); err != nil {
+			return fmt.Errorf("failed to update game statistics: %w", err)
+		}
+
+		event := &domain.GameEvent{
+			ID:        generateEventID(),
+			Type:      domain.GameEventTypeSessionStart,
+			GameID:    req.GameID,
+			SessionID: sessionID.String(),
+			UserID:    req.UserID,
+			Data: map[string]interface{}{
+				"username":      req.Username,
+				"terminal_size": fmt.Sprintf("%dx%d", req.TerminalWidth, req.TerminalHeight),
+			},
+			Timestamp: time.Now(),
+		}
+		if err := s.uow.Events().SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to save session start event: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		// Nothing records the game, so do not leave it running
+		s.stopGameProcess(ctx, session)
+		return nil, err
 	}
 
-	if err := s.uow.Events().SaveEvent(ctx, event); err != nil {
-		// Log but don't fail the session start
-		// log error
+	s.SessionChanged(session)
+
+	return session, nil
+}
+
+// AdoptSession records a game taken over from another game service instance
+// as an active session, keeping its ID so players can reconnect to it
+func (s *SessionService) AdoptSession(ctx context.Context, snapshot SessionSnapshot) (*domain.GameSession, error) {
+	gameID := domain.NewGameID(snapshot.GameID)
+	game, err := s.gameRepo.FindByID(ctx, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game not found: %w", err)
 	}
 
-	// Commit transaction
-	if err := s.uow.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	session := domain.NewGameSession(
+		domain.NewSessionID(snapshot.SessionID),
+		domain.NewUserID(snapshot.UserID),
+		snapshot.Username,
+		gameID,
+		game.Config(),
+		domain.TerminalSize{Width: snapshot.Cols, Height: snapshot.Rows},
+	)
+	if snapshot.TerminalType != "" {
+		session.SetTerminalType(snapshot.TerminalType)
+	}
+	if snapshot.Locale != "" {
+		session.SetLocale(snapshot.Locale)
+	}
+	session.SetClientIP(snapshot.ClientIP)
+	if snapshot.GameVersion != "" {
+		session.SetGameVersion(snapshot.GameVersion)
+	}
+	if snapshot.Streaming {
+		session.EnableStreaming("grpc", false)
+	}
+	if snapshot.PlayTimeEndsAt != nil {
+		session.SetPlayTimeEndsAt(*snapshot.PlayTimeEndsAt)
+	}
+	if err := session.Adopt(domain.ProcessInfo{PID: snapshot.PID}, snapshot.StartedAt); err != nil {
+		return nil, err
 	}
 
+	if err := s.sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	s.SessionChanged(session)
 	return session, nil
 }
 
@@ -160,18 +231,13 @@ func (s *SessionService) StopGameSession(ctx context.Context, sessionID string,
 	}
 
 	if !session.IsActive() {
-		return fmt.Errorf("session is not active")
+		return domain.ErrSessionNotActive
 	}
 
-	// Begin transaction
-	if err := s.uow.Begin(ctx); err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+	// The game saves as it is stopped
+	if err := session.BeginEnding(); err != nil {
+		return err
 	}
-	defer func() {
-		if err != nil {
-			s.uow.Rollback(ctx)
-		}
-	}()
 
 	// Stop the game process
 	if err := s.stopGameProcess(ctx, session); err != nil {
@@ -179,68 +245,136 @@ func (s *SessionService) StopGameSession(ctx context.Context, sessionID string,
 	}
 
 	// End the session
-	session.End(nil, nil)
-
-	// Save session
-	if err := s.uow.Sessions().Save(ctx, session); err != nil {
-		return fmt.Errorf("failed to save session: %w", err)
+	if err := session.End(nil, nil); err != nil {
+		return err
 	}
 
-	// Publish session end event
-	event := &domain.GameEvent{
-		ID:        generateEventID(),
-		Type:      domain.GameEventTypeSessionEnd,
-		GameID:    session.GameID().String(),
-		SessionID: sessionID,
-		UserID:    session.UserID().Int(),
-		Data: map[string]interface{}{
-			"reason":   reason,
-			"duration": session.Duration().String(),
-		},
-		Timestamp: time.Now(),
-	}
+	// Record the ended session, the end event and the play time on the
+	// user's save together
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.uow.Sessions().Save(ctx, session); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+
+		event := &domain.GameEvent{
+			ID:        generateEventID(),
+			Type:      domain.GameEventTypeSessionEnd,
+			GameID:    session.GameID().String(),
+			SessionID: sessionID,
+			UserID:    session.UserID().Int(),
+			Data: map[string]interface{}{
+				"reason":   reason,
+				"duration": session.Duration().String(),
+			},
+			Timestamp: time.Now(),
+		}
+		if err := s.uow.Events().SaveEvent(ctx, event); err != nil {
+			return fmt.Errorf("failed to save session end event: %w", err)
+		}
 
-	if err := s.uow.Events().SaveEvent(ctx, event); err != nil {
-		// Log but don't fail
+		return s.recordSavePlayTime(ctx, session)
+	})
+	if err != nil {
+		return err
 	}
 
-	// Commit transaction
-	return s.uow.Commit(ctx)
+	s.SessionChanged(session)
+	return nil
 }
 
 // GetGameSession retrieves a game session
 func (s *SessionService) GetGameSession(ctx context.Context, sessionID string) (*domain.GameSession, error) {
+	if s.cache != nil {
+		if session, ok := s.cache.Get(sessionID); ok {
+			return session, nil
+		}
+	}
 	id := domain.NewSessionID(sessionID)
 	return s.sessionRepo.FindByID(ctx, id)
 }
 
 // ListActiveSessions lists all active sessions
 func (s *SessionService) ListActiveSessions(ctx context.Context) ([]*domain.GameSession, error) {
+	if s.cache != nil {
+		return s.cache.Active(), nil
+	}
 	return s.sessionRepo.FindActive(ctx)
 }
 
+// SessionChanged refreshes cached views after a session was modified, including
+// changes made outside this service such as a game process exiting, and
+// stores its status changes
+func (s *SessionService) SessionChanged(session *domain.GameSession) {
+	if s.cache != nil {
+		s.cache.Put(session)
+	}
+	saveStatusEvents(context.Background(), s.eventRepo, session.PullEvents())
+}
+
+// saveStatusEvents stores the status changes of a game or session. The
+// changes have already been made, so failing to log them is not an error.
+func saveStatusEvents(ctx context.Context, eventRepo domain.EventRepository, events []*domain.GameEvent) {
+	if eventRepo == nil {
+		return
+	}
+	for _, event := range events {
+		event.ID = generateEventID()
+		eventRepo.SaveEvent(ctx, event)
+	}
+}
+
+// SubscribeSessions returns a channel that is signalled whenever the set of
+// active sessions changes, and a function that cancels the subscription. It
+// returns a nil channel when no session cache is configured.
+func (s *SessionService) SubscribeSessions() (<-chan uint64, func()) {
+	if s.cache == nil {
+		return nil, func() {}
+	}
+	return s.cache.Subscribe()
+}
+
 // ListUserSessions lists sessions for a specific user
 func (s *SessionService) ListUserSessions(ctx context.Context, userID int) ([]*domain.GameSession, error) {
 	id := domain.NewUserID(userID)
 	return s.sessionRepo.FindByUserID(ctx, id)
 }
 
-// AddSpectator adds a spectator to a session
-func (s *SessionService) AddSpectator(ctx context.Context, sessionID string, spectatorUserID int, spectatorUsername string) error {
-	id := domain.NewSessionID(sessionID)
+// Leaderboard ranks the players of a game; see domain.BuildLeaderboard.
+// userIDs restricts it to those players, such as the members of a group.
+func (s *SessionService) Leaderboard(ctx context.Context, gameID string, userIDs []int, sortBy domain.LeaderboardSort, limit int) ([]domain.LeaderboardEntry, int, error) {
+	sessions, err := s.sessionRepo.FindByGameID(ctx, domain.NewGameID(gameID))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to find game sessions: %w", err)
+	}
 
-	session, err := s.sessionRepo.FindByID(ctx, id)
+	players := make([]domain.UserID, len(userIDs))
+	for i, userID := range userIDs {
+		players[i] = domain.NewUserID(userID)
+	}
+	entries, total := domain.BuildLeaderboard(sessions, players, sortBy, limit)
+	return entries, total, nil
+}
+
+// AddSpectator adds a spectator to a session. limit returns the most
+// spectators the session's game allows, zero for no limit; nil lets the
+// spectator join a full session.
+func (s *SessionService) AddSpectator(ctx context.Context, sessionID string, spectatorUserID int, spectatorUsername string, limit func(gameID string) int) error {
+	session, err := s.GetGameSession(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
 	}
 
+	maxSpectators := 0
+	if limit != nil {
+		maxSpectators = limit(session.GameID().String())
+	}
 	spectatorID := domain.NewUserID(spectatorUserID)
-	if err := session.AddSpectator(spectatorID, spectatorUsername); err != nil {
+	if err := session.AddSpectator(spectatorID, spectatorUsername, maxSpectators); err != nil {
 		return err
 	}
 
 	// Save session
-	if err := s.sessionRepo.Save(ctx, session); err != nil {
+	if err := s.saveSpectatorChange(ctx, session); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
@@ -262,11 +396,10 @@ func (s *SessionService) AddSpectator(ctx context.Context, sessionID string, spe
 	return nil
 }
 
-// RemoveSpectator removes a spectator from a session
-func (s *SessionService) RemoveSpectator(ctx context.Context, sessionID string, spectatorUserID int) error {
-	id := domain.NewSessionID(sessionID)
-
-	session, err := s.sessionRepo.FindByID(ctx, id)
+// RemoveSpectator removes a spectator from a session. reason says why they
+// left, such as "idle" for a spectator evicted for idling.
+func (s *SessionService) RemoveSpectator(ctx context.Context, sessionID string, spectatorUserID int, reason string) error {
+	session, err := s.GetGameSession(ctx, sessionID)
 	if err != nil {
 		return fmt.Errorf("session not found: %w", err)
 	}
@@ -274,9 +407,87 @@ func (s *SessionService) RemoveSpectator(ctx context.Context, sessionID string,
 	spectatorID := domain.NewUserID(spectatorUserID)
 	session.RemoveSpectator(spectatorID)
 
+	if err := s.saveSpectatorChange(ctx, session); err != nil {
+		return err
+	}
+
+	// Publish spectator leave event
+	data := map[string]interface{}{}
+	if reason != "" {
+		data["reason"] = reason
+	}
+	event := &domain.GameEvent{
+		ID:        generateEventID(),
+		Type:      domain.GameEventTypeSpectatorLeave,
+		GameID:    session.GameID().String(),
+		SessionID: sessionID,
+		UserID:    spectatorUserID,
+		Data:      data,
+		Timestamp: time.Now(),
+	}
+
+	s.eventRepo.SaveEvent(ctx, event)
+
+	return nil
+}
+
+// saveSpectatorChange persists a spectator list change. Spectators come and go
+// frequently, so with a cache the write is deferred to the cache's flush loop.
+func (s *SessionService) saveSpectatorChange(ctx context.Context, session *domain.GameSession) error {
+	if s.cache != nil {
+		s.cache.MarkDirty(session)
+		return nil
+	}
 	return s.sessionRepo.Save(ctx, session)
 }
 
+// inTransaction runs fn in a unit of work transaction, committing when it
+// returns nil and rolling back otherwise
+func (s *SessionService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, err := s.uow.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(txCtx); err != nil {
+		s.uow.Rollback(txCtx)
+		return err
+	}
+	if err := s.uow.Commit(txCtx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// recordSavePlayTime adds an ended session's play time to the metadata of
+// the user's save for the game, when there is one
+func (s *SessionService) recordSavePlayTime(ctx context.Context, session *domain.GameSession) error {
+	save, err := s.uow.Saves().FindByUserAndGame(ctx, session.UserID(), session.GameID())
+	if errors.Is(err, domain.ErrSaveNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find save: %w", err)
+	}
+
+	metadata := save.Metadata()
+	metadata.PlayTime += session.Duration()
+	if version := session.GameVersion(); version != "" {
+		metadata.GameVersion = version
+	}
+	customFields := make(map[string]string, len(metadata.CustomFields)+1)
+	for key, value := range metadata.CustomFields {
+		customFields[key] = value
+	}
+	customFields["last_session_id"] = session.ID().String()
+	metadata.CustomFields = customFields
+	save.UpdateMetadata(metadata)
+
+	if err := s.uow.Saves().Save(ctx, save); err != nil {
+		return fmt.Errorf("failed to update save metadata: %w", err)
+	}
+	return nil
+}
+
 // startGameProcess starts the actual game process
 func (s *SessionService) startGameProcess(ctx context.Context, session *domain.GameSession, game *domain.Game) (domain.ProcessInfo, error) {
 	config := game.Config()
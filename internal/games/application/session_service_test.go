@@ -0,0 +1,177 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// failingSaveRepository fails to look saves up
+type failingSaveRepository struct {
+	domain.SaveRepository
+}
+
+func (r failingSaveRepository) FindByUserAndGame(ctx context.Context, userID domain.UserID, gameID domain.GameID) (*domain.GameSave, error) {
+	return nil, errors.New("database is locked")
+}
+
+func newStopTestService(saveRepo domain.SaveRepository) (*SessionService, *repository.StubSessionRepository, *repository.StubEventRepository) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+	return NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow), sessionRepo, eventRepo
+}
+
+func newStopTestSession(t *testing.T, sessionRepo domain.SessionRepository) *domain.GameSession {
+	session := createMockSessionWithID("session_stop", 7, "nethack")
+	session.SetGameVersion("3.7.0")
+	require.NoError(t, sessionRepo.Save(context.Background(), session))
+	return session
+}
+
+// eventsOfType keeps the events of one type
+func eventsOfType(events []*domain.GameEvent, eventType domain.GameEventType) []*domain.GameEvent {
+	var matching []*domain.GameEvent
+	for _, event := range events {
+		if event.Type == eventType {
+			matching = append(matching, event)
+		}
+	}
+	return matching
+}
+
+func newStopTestSave(t *testing.T, saveRepo domain.SaveRepository) *domain.GameSave {
+	save := domain.NewGameSave(domain.NewSaveID("save_1"), domain.NewUserID(7), domain.NewGameID("nethack"),
+		[]byte("save"), "/saves/7/nethack", domain.SaveMetadata{PlayTime: time.Hour})
+	require.NoError(t, saveRepo.Save(context.Background(), save))
+	return save
+}
+
+func TestSessionService_StopGameSession_RecordsPlayTime(t *testing.T) {
+	saveRepo := repository.NewStubSaveRepository()
+	service, sessionRepo, eventRepo := newStopTestService(saveRepo)
+	ctx := context.Background()
+
+	newStopTestSession(t, sessionRepo)
+	newStopTestSave(t, saveRepo)
+
+	require.NoError(t, service.StopGameSession(ctx, "session_stop", "user_quit"))
+
+	stored, err := sessionRepo.FindByID(ctx, domain.NewSessionID("session_stop"))
+	require.NoError(t, err)
+	assert.False(t, stored.IsActive())
+
+	events, err := eventRepo.FindEventsBySession(ctx, domain.NewSessionID("session_stop"))
+	require.NoError(t, err)
+	endEvents := eventsOfType(events, domain.GameEventTypeSessionEnd)
+	require.Len(t, endEvents, 1)
+	assert.Equal(t, "user_quit", endEvents[0].Data["reason"])
+
+	// The session passed through ending while its save was flushed
+	var statuses []interface{}
+	for _, event := range eventsOfType(events, domain.GameEventTypeSessionStatus) {
+		statuses = append(statuses, event.Data["to"])
+	}
+	assert.Equal(t, []interface{}{"active", "ending", "ended"}, statuses)
+
+	save, err := saveRepo.FindByUserAndGame(ctx, domain.NewUserID(7), domain.NewGameID("nethack"))
+	require.NoError(t, err)
+	assert.Greater(t, save.Metadata().PlayTime, time.Hour)
+	assert.Equal(t, "3.7.0", save.Metadata().GameVersion)
+	assert.Equal(t, "session_stop", save.Metadata().CustomFields["last_session_id"])
+}
+
+func TestSessionService_StopGameSession_WithoutSave(t *testing.T) {
+	service, sessionRepo, eventRepo := newStopTestService(repository.NewStubSaveRepository())
+	ctx := context.Background()
+	newStopTestSession(t, sessionRepo)
+
+	require.NoError(t, service.StopGameSession(ctx, "session_stop", "user_quit"))
+
+	events, err := eventRepo.FindEventsBySession(ctx, domain.NewSessionID("session_stop"))
+	require.NoError(t, err)
+	assert.Len(t, eventsOfType(events, domain.GameEventTypeSessionEnd), 1)
+}
+
+func TestSessionService_StopGameSession_IsAtomic(t *testing.T) {
+	service, sessionRepo, eventRepo := newStopTestService(failingSaveRepository{repository.NewStubSaveRepository()})
+	ctx := context.Background()
+	newStopTestSession(t, sessionRepo)
+
+	err := service.StopGameSession(ctx, "session_stop", "user_quit")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to find save")
+
+	// The end event written before the failure was rolled back with it
+	events, err := eventRepo.FindEventsBySession(ctx, domain.NewSessionID("session_stop"))
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestSessionService_StartGameSession_GameInMaintenance(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+	gameService := NewGameService(gameRepo, sessionRepo, saveRepo, eventRepo, uow)
+	sessionService := NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow)
+	ctx := context.Background()
+
+	require.NoError(t, gameRepo.Save(ctx, domain.NewGame(domain.NewGameID("nethack"), domain.GameMetadata{Name: "NetHack"}, domain.GameConfig{})))
+	running := newStopTestSession(t, sessionRepo)
+
+	game, err := gameService.SetGameStatus(ctx, "nethack", domain.GameStatusMaintenance, "upgrading to 3.7")
+	require.NoError(t, err)
+	assert.Equal(t, domain.GameStatusMaintenance, game.Status())
+
+	_, err = sessionService.StartGameSession(ctx, &StartSessionRequest{
+		UserID: 8, Username: "newcomer", GameID: "nethack", TerminalWidth: 80, TerminalHeight: 24,
+	})
+	require.ErrorIs(t, err, domain.ErrGameUnavailable)
+	assert.Equal(t, apierror.GameUnavailable, apierror.CodeOf(err))
+	assert.Equal(t, "NetHack is down for maintenance: upgrading to 3.7", apierror.MessageOf(err))
+
+	// Games already being played carry on
+	stored, err := sessionRepo.FindByID(ctx, running.ID())
+	require.NoError(t, err)
+	assert.True(t, stored.IsActive())
+
+	events, err := eventRepo.FindEventsByGame(ctx, domain.NewGameID("nethack"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, eventsOfType(events, domain.GameEventTypeGameStatus))
+}
+
+func TestSessionService_StartGameSession_PlayTimeUsedUp(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+	sessionService := NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow)
+	ctx := context.Background()
+
+	require.NoError(t, gameRepo.Save(ctx, domain.NewGame(domain.NewGameID("nethack"), domain.GameMetadata{Name: "NetHack"}, domain.GameConfig{})))
+
+	// Half an hour of another game, still running
+	played := domain.NewGameSession(domain.NewSessionID("session_played"), domain.NewUserID(7), "player",
+		domain.NewGameID("dcss"), domain.GameConfig{}, domain.TerminalSize{Width: 80, Height: 24})
+	require.NoError(t, played.Adopt(domain.ProcessInfo{PID: 2}, time.Now().Add(-30*time.Minute)))
+	require.NoError(t, sessionRepo.Save(ctx, played))
+
+	_, err := sessionService.StartGameSession(ctx, &StartSessionRequest{
+		UserID: 7, Username: "player", GameID: "nethack", TerminalWidth: 80, TerminalHeight: 24,
+		PlayTimeLimit: domain.PlayTimeLimit{Daily: 20 * time.Minute},
+	})
+	require.Error(t, err)
+	assert.Equal(t, apierror.PlayTimeExceeded, apierror.CodeOf(err))
+}
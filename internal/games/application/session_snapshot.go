@@ -0,0 +1,106 @@
+package application
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// SessionSnapshotFile is the file, under the game data path, listing the games
+// that were running when the game service last shut down
+const SessionSnapshotFile = "session-snapshot.json"
+
+// SessionSnapshot describes a game running at shutdown, with enough detail for
+// a new game service instance to find its process and adopt it
+type SessionSnapshot struct {
+	SessionID    string    `json:"session_id"`
+	UserID       int       `json:"user_id"`
+	Username     string    `json:"username"`
+	GameID       string    `json:"game_id"`
+	GameVersion  string    `json:"game_version,omitempty"`
+	PID          int       `json:"pid"`
+	TerminalType string    `json:"terminal_type,omitempty"`
+	Locale       string    `json:"locale,omitempty"`
+	ClientIP     string    `json:"client_ip,omitempty"`
+	Streaming    bool      `json:"streaming,omitempty"`
+	Cols         int       `json:"cols"`
+	Rows         int       `json:"rows"`
+	StartedAt    time.Time `json:"started_at"`
+	DetachedAt   time.Time `json:"detached_at"`
+	// PlayTimeEndsAt is when the player's play-time budget runs out
+	PlayTimeEndsAt *time.Time `json:"play_time_ends_at,omitempty"`
+}
+
+// SnapshotSessions describes the active sessions for a snapshot
+func SnapshotSessions(sessions []*domain.GameSession, now time.Time) []SessionSnapshot {
+	snapshots := make([]SessionSnapshot, 0, len(sessions))
+	for _, session := range sessions {
+		if !session.IsActive() {
+			continue
+		}
+		size := session.TerminalSize()
+		snapshots = append(snapshots, SessionSnapshot{
+			SessionID:    session.ID().String(),
+			UserID:       session.UserID().Int(),
+			Username:     session.Username(),
+			GameID:       session.GameID().String(),
+			GameVersion:  session.GameVersion(),
+			PID:          session.ProcessInfo().PID,
+			TerminalType: session.TerminalType(),
+			Locale:       session.Locale(),
+			ClientIP:     session.ClientIP(),
+			Streaming:    session.StreamingInfo() != nil && session.StreamingInfo().Enabled,
+			Cols:         size.Width,
+			Rows:         size.Height,
+			StartedAt:    session.StartTime(),
+			DetachedAt:   now,
+
+			PlayTimeEndsAt: session.PlayTimeEndsAt(),
+		})
+	}
+	return snapshots
+}
+
+// WriteSessionSnapshot writes a snapshot through a temporary file so a crash
+// during shutdown never leaves it half written
+func WriteSessionSnapshot(path string, snapshots []SessionSnapshot) error {
+	data, err := json.MarshalIndent(snapshots, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write session snapshot: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to write session snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSessionSnapshot reads a snapshot written at shutdown. A missing file is
+// not an error and returns no sessions.
+func ReadSessionSnapshot(path string) ([]SessionSnapshot, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session snapshot: %w", err)
+	}
+
+	var snapshots []SessionSnapshot
+	if err := json.Unmarshal(data, &snapshots); err != nil {
+		return nil, fmt.Errorf("failed to decode session snapshot: %w", err)
+	}
+	return snapshots, nil
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+func TestSessionSnapshot_RoundTrip(t *testing.T) {
+	running := createMockSessionWithID("session_a", 7, "nethack")
+	running.SetGameVersion("3.6.7")
+	running.SetClientIP("192.0.2.10")
+	ended := createMockSessionWithID("session_b", 8, "nethack")
+	ended.End(nil, nil)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	snapshots := SnapshotSessions([]*domain.GameSession{running, ended}, now)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "session_a", snapshots[0].SessionID)
+	assert.Equal(t, 7, snapshots[0].UserID)
+	assert.Equal(t, "3.6.7", snapshots[0].GameVersion)
+	assert.Equal(t, "192.0.2.10", snapshots[0].ClientIP)
+	assert.Equal(t, 12345, snapshots[0].PID)
+	assert.Equal(t, 80, snapshots[0].Cols)
+
+	path := filepath.Join(t.TempDir(), "data", SessionSnapshotFile)
+	require.NoError(t, WriteSessionSnapshot(path, snapshots))
+
+	read, err := ReadSessionSnapshot(path)
+	require.NoError(t, err)
+	require.Len(t, read, 1)
+	assert.Equal(t, snapshots[0].SessionID, read[0].SessionID)
+	assert.True(t, now.Equal(read[0].DetachedAt))
+
+	missing, err := ReadSessionSnapshot(filepath.Join(t.TempDir(), "none.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+}
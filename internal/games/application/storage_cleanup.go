@@ -0,0 +1,242 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// Storage cleanup categories, used as metric labels
+const (
+	StorageCategoryTemp       = "temp"
+	StorageCategoryLocks      = "locks"
+	StorageCategoryRecordings = "recordings"
+	StorageCategoryLogs       = "logs"
+)
+
+// staleLockGrace protects lock files of games that are still starting up and
+// not yet visible as active sessions
+const staleLockGrace = time.Minute
+
+// userHomePrefix prefixes the per-player home directory names under UserHomesDir
+const userHomePrefix = "user_"
+
+// StorageCleanupOptions configures what the storage cleanup prunes
+type StorageCleanupOptions struct {
+	MaxAge             time.Duration // Files older than this are removed
+	DeleteEmptyDirs    bool          // Remove directories left empty by pruning
+	PreserveRecordings bool          // Never remove recordings
+	DryRun             bool          // Report what would be removed without deleting
+
+	TempDirs      []string // Temporary files older than MaxAge are removed
+	LogDirs       []string // Log files older than MaxAge are removed
+	RecordingDirs []string // Recordings older than MaxAge are removed
+
+	// UserHomesDir holds per-player homes named user_<id>. Lock files under
+	// LockDirs, relative to each home, are removed when the player has no
+	// running game.
+	UserHomesDir string
+	LockDirs     []string
+}
+
+// StorageCleanupReport describes what one storage cleanup run removed, or
+// would have removed in dry-run mode
+type StorageCleanupReport struct {
+	DryRun         bool
+	FilesRemoved   map[string]int
+	BytesReclaimed map[string]int64
+	Errors         []error
+}
+
+// newStorageCleanupReport creates an empty report
+func newStorageCleanupReport(dryRun bool) *StorageCleanupReport {
+	return &StorageCleanupReport{
+		DryRun:         dryRun,
+		FilesRemoved:   make(map[string]int),
+		BytesReclaimed: make(map[string]int64),
+	}
+}
+
+// TotalBytesReclaimed sums the bytes reclaimed across categories
+func (r *StorageCleanupReport) TotalBytesReclaimed() int64 {
+	var total int64
+	for _, n := range r.BytesReclaimed {
+		total += n
+	}
+	return total
+}
+
+// SetStorageCleanup enables storage pruning with the given options. The
+// reporter, if set, is called with the result of every run.
+func (s *CleanupService) SetStorageCleanup(opts *StorageCleanupOptions, reporter func(*StorageCleanupReport)) {
+	s.storageOpts = opts
+	s.storageReporter = reporter
+}
+
+// CleanupStorage prunes temp files, stale lock files, expired recordings and
+// old logs. Problems with individual files or directories are collected in the
+// report rather than stopping the run.
+func (s *CleanupService) CleanupStorage(ctx context.Context) (*StorageCleanupReport, error) {
+	opts := s.storageOpts
+	if opts == nil {
+		return nil, fmt.Errorf("storage cleanup is not configured")
+	}
+
+	report := newStorageCleanupReport(opts.DryRun)
+	cutoff := time.Now().Add(-opts.MaxAge)
+	olderThanCutoff := func(info fs.FileInfo) bool { return info.ModTime().Before(cutoff) }
+
+	for _, dir := range opts.TempDirs {
+		s.pruneDir(report, StorageCategoryTemp, dir, olderThanCutoff)
+	}
+	for _, dir := range opts.LogDirs {
+		s.pruneDir(report, StorageCategoryLogs, dir, olderThanCutoff)
+	}
+	if !opts.PreserveRecordings {
+		for _, dir := range opts.RecordingDirs {
+			s.pruneDir(report, StorageCategoryRecordings, dir, olderThanCutoff)
+		}
+	}
+
+	if opts.UserHomesDir != "" && len(opts.LockDirs) > 0 {
+		if err := s.pruneStaleLocks(ctx, report); err != nil {
+			report.Errors = append(report.Errors, err)
+		}
+	}
+
+	return report, nil
+}
+
+// RunStorageCleanup prunes storage once and reports the result; it is run as
+// the storage_cleanup scheduled job
+func (s *CleanupService) RunStorageCleanup(ctx context.Context) error {
+	report, err := s.CleanupStorage(ctx)
+	if err != nil {
+		return fmt.Errorf("storage cleanup failed: %w", err)
+	}
+
+	for _, cleanupErr := range report.Errors {
+		s.logger.Warn("Storage cleanup problem", "error", cleanupErr)
+	}
+	s.logger.Info("Storage cleanup finished",
+		"dry_run", report.DryRun,
+		"max_age", s.storageOpts.MaxAge,
+		"files", report.FilesRemoved,
+		"bytes_reclaimed", report.TotalBytesReclaimed())
+
+	if s.storageReporter != nil {
+		s.storageReporter(report)
+	}
+	return nil
+}
+
+// pruneStaleLocks removes the lock files of players with no running game
+func (s *CleanupService) pruneStaleLocks(ctx context.Context, report *StorageCleanupReport) error {
+	playing := make(map[int]bool)
+	for _, status := range []domain.SessionStatus{domain.SessionStatusActive, domain.SessionStatusStarting} {
+		sessions, err := s.sessionRepo.FindByStatus(ctx, status)
+		if err != nil {
+			return fmt.Errorf("failed to find running sessions: %w", err)
+		}
+		for _, session := range sessions {
+			playing[session.UserID().Int()] = true
+		}
+	}
+
+	homes, err := os.ReadDir(s.storageOpts.UserHomesDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to read user homes: %w", err)
+	}
+
+	graceCutoff := time.Now().Add(-staleLockGrace)
+	for _, home := range homes {
+		idText, ok := strings.CutPrefix(home.Name(), userHomePrefix)
+		if !ok || !home.IsDir() {
+			continue
+		}
+		if userID, err := strconv.Atoi(idText); err != nil || playing[userID] {
+			continue
+		}
+		for _, lockDir := range s.storageOpts.LockDirs {
+			dir := filepath.Join(s.storageOpts.UserHomesDir, home.Name(), lockDir)
+			s.pruneDir(report, StorageCategoryLocks, dir, func(info fs.FileInfo) bool {
+				return info.ModTime().Before(graceCutoff)
+			})
+		}
+	}
+
+	return nil
+}
+
+// pruneDir removes the regular files under root that match expired, then the
+// directories left empty if configured. The root itself is always kept.
+func (s *CleanupService) pruneDir(report *StorageCleanupReport, category, root string, expired func(fs.FileInfo) bool) {
+	if root == "" {
+		return
+	}
+
+	var dirs []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			report.Errors = append(report.Errors, err)
+			return nil
+		}
+		if d.IsDir() {
+			if path != root {
+				dirs = append(dirs, path)
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			report.Errors = append(report.Errors, err)
+			return nil
+		}
+		if !expired(info) {
+			return nil
+		}
+
+		if !report.DryRun {
+			if err := os.Remove(path); err != nil {
+				report.Errors = append(report.Errors, err)
+				return nil
+			}
+		}
+		s.logger.Debug("Pruned file", "category", category, "path", path, "size", info.Size(), "dry_run", report.DryRun)
+		report.FilesRemoved[category]++
+		report.BytesReclaimed[category] += info.Size()
+		return nil
+	})
+	if err != nil {
+		report.Errors = append(report.Errors, err)
+	}
+
+	if !s.storageOpts.DeleteEmptyDirs || report.DryRun {
+		return
+	}
+	// Deepest first so parents emptied by their children are removed too
+	for i := len(dirs) - 1; i >= 0; i-- {
+		if entries, err := os.ReadDir(dirs[i]); err == nil && len(entries) == 0 {
+			if err := os.Remove(dirs[i]); err != nil {
+				report.Errors = append(report.Errors, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/logging"
+)
+
+// writeAgedFile creates a file of the given size whose modification time is age ago
+func writeAgedFile(t *testing.T, path string, size int, age time.Duration) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+	modTime := time.Now().Add(-age)
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func setupStorageCleanup(t *testing.T, dryRun bool) (*CleanupService, string) {
+	root := t.TempDir()
+
+	writeAgedFile(t, filepath.Join(root, "tmp", "old", "level.0"), 100, 10*24*time.Hour)
+	writeAgedFile(t, filepath.Join(root, "tmp", "fresh.tmp"), 50, time.Hour)
+	writeAgedFile(t, filepath.Join(root, "logs", "game.log.1"), 200, 8*24*time.Hour)
+	writeAgedFile(t, filepath.Join(root, "recordings", "old.ttyrec"), 400, 30*24*time.Hour)
+	// user_1 is still playing, user_2 left a lock behind
+	writeAgedFile(t, filepath.Join(root, "homes", "user_1", "locks", "1wizard.0"), 10, time.Hour)
+	writeAgedFile(t, filepath.Join(root, "homes", "user_2", "locks", "2wizard.0"), 10, time.Hour)
+
+	active := createMockSession(1, "nethack")
+	active.Start(domain.ProcessInfo{PID: 1234})
+
+	sessionRepo := &MockSessionRepository{}
+	sessionRepo.On("FindByStatus", context.Background(), domain.SessionStatusActive).Return([]*domain.GameSession{active}, nil)
+	sessionRepo.On("FindByStatus", context.Background(), domain.SessionStatusStarting).Return([]*domain.GameSession{}, nil)
+
+	logger := logging.NewLoggerBasic("test", "debug", "text", "stdout")
+	cleanupService := NewCleanupService(sessionRepo, &MockSaveRepository{}, &MockEventRepository{}, logger)
+	cleanupService.SetStorageCleanup(&StorageCleanupOptions{
+		MaxAge:             7 * 24 * time.Hour,
+		DeleteEmptyDirs:    true,
+		PreserveRecordings: true,
+		DryRun:             dryRun,
+		TempDirs:           []string{filepath.Join(root, "tmp")},
+		LogDirs:            []string{filepath.Join(root, "logs")},
+		RecordingDirs:      []string{filepath.Join(root, "recordings")},
+		UserHomesDir:       filepath.Join(root, "homes"),
+		LockDirs:           []string{"locks"},
+	}, nil)
+
+	return cleanupService, root
+}
+
+func TestCleanupService_CleanupStorage(t *testing.T) {
+	cleanupService, root := setupStorageCleanup(t, false)
+
+	report, err := cleanupService.CleanupStorage(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, report.Errors)
+
+	assert.Equal(t, map[string]int{
+		StorageCategoryTemp:  1,
+		StorageCategoryLogs:  1,
+		StorageCategoryLocks: 1,
+	}, report.FilesRemoved)
+	assert.Equal(t, int64(310), report.TotalBytesReclaimed())
+
+	assert.NoDirExists(t, filepath.Join(root, "tmp", "old"))
+	assert.DirExists(t, filepath.Join(root, "tmp"))
+	assert.FileExists(t, filepath.Join(root, "tmp", "fresh.tmp"))
+	assert.NoFileExists(t, filepath.Join(root, "logs", "game.log.1"))
+	assert.FileExists(t, filepath.Join(root, "recordings", "old.ttyrec"))
+	assert.FileExists(t, filepath.Join(root, "homes", "user_1", "locks", "1wizard.0"))
+	assert.NoFileExists(t, filepath.Join(root, "homes", "user_2", "locks", "2wizard.0"))
+}
+
+func TestCleanupService_CleanupStorage_DryRun(t *testing.T) {
+	cleanupService, root := setupStorageCleanup(t, true)
+
+	report, err := cleanupService.CleanupStorage(context.Background())
+	require.NoError(t, err)
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, int64(310), report.TotalBytesReclaimed())
+	assert.FileExists(t, filepath.Join(root, "tmp", "old", "level.0"))
+	assert.FileExists(t, filepath.Join(root, "logs", "game.log.1"))
+	assert.FileExists(t, filepath.Join(root, "homes", "user_2", "locks", "2wizard.0"))
+}
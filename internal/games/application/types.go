@@ -1,5 +1,7 @@
 package application
 
+import "github.com/dungeongate/internal/games/domain"
+
 // CreateGameRequest represents a request to create a new game
 type CreateGameRequest struct {
 	// Identity
@@ -95,13 +97,24 @@ type StartSessionRequest struct {
 	UserID   int    `json:"user_id"`
 	Username string `json:"username"`
 	GameID   string `json:"game_id"`
+	// GameVersion is the installed version to start, empty for the default
+	GameVersion string `json:"game_version"`
 
 	// Terminal configuration
-	TerminalWidth  int `json:"terminal_width"`
-	TerminalHeight int `json:"terminal_height"`
+	TerminalWidth  int    `json:"terminal_width"`
+	TerminalHeight int    `json:"terminal_height"`
+	TerminalType   string `json:"terminal_type"`
+	Locale         string `json:"locale"`
+
+	// ClientIP is the player's address
+	ClientIP string `json:"client_ip"`
+
+	// PlayTimeLimit is the player's play-time budget; zero for none
+	PlayTimeLimit domain.PlayTimeLimit `json:"-"`
 
 	// Session features
 	EnableRecording  bool `json:"enable_recording"`
+	RecordingPrivate bool `json:"recording_private"` // Only the player may view the recording
 	EnableStreaming  bool `json:"enable_streaming"`
 	EnableEncryption bool `json:"enable_encryption"`
 }
@@ -248,6 +261,7 @@ type RecordingResponse struct {
 	StartTime  string `json:"start_time"`
 	FileSize   int64  `json:"file_size"`
 	Compressed bool   `json:"compressed"`
+	Private    bool   `json:"private"`
 }
 
 // StreamingResponse represents streaming info in API responses
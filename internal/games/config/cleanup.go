@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -38,11 +39,13 @@ func (gm *GameDirectoryManager) CleanupGame(gameID string, options *GameCleanupO
 		}
 	}
 
-	// Cleanup save symlinks
+	// Cleanup save symlinks; links kept are no longer tracked
 	if options.CleanupSaveLinks {
 		if err := gm.cleanupSaveSymlinks(gameID); err != nil {
 			return fmt.Errorf("failed to cleanup save symlinks: %w", err)
 		}
+	} else {
+		delete(gm.SaveLinks, gameID)
 	}
 
 	// Validate cleanup completion
@@ -196,37 +199,28 @@ func (gm *GameDirectoryManager) copyFile(src, dst string) error {
 	return destFile.Chmod(sourceInfo.Mode())
 }
 
-// cleanupSaveSymlinks removes save symlinks for a game
+// cleanupSaveSymlinks removes the save link made for a game, unless
+// another active game of the same user still uses it
 func (gm *GameDirectoryManager) cleanupSaveSymlinks(gameID string) error {
-	// Find and remove symlinks created for this game
-	systemPaths, err := gm.pathDetector.DetectNetHackPaths()
-	if err != nil {
-		return fmt.Errorf("failed to detect system paths: %w", err)
+	link, exists := gm.SaveLinks[gameID]
+	if !exists {
+		return nil
 	}
-
-	if systemPaths.SysConfFile != "" {
-		systemSaveDir := filepath.Join(filepath.Dir(systemPaths.SysConfFile), "save")
-
-		// List all symlinks in the system save directory
-		entries, err := os.ReadDir(systemSaveDir)
-		if err != nil {
-			return fmt.Errorf("failed to read system save directory: %w", err)
-		}
-
-		// Remove symlinks that match the game pattern
-		for _, entry := range entries {
-			if entry.Type()&os.ModeSymlink != 0 {
-				linkPath := filepath.Join(systemSaveDir, entry.Name())
-				if strings.Contains(entry.Name(), gameID) {
-					if err := os.Remove(linkPath); err != nil {
-						return fmt.Errorf("failed to remove symlink %s: %w", linkPath, err)
-					}
-					gm.logger.Printf("Removed symlink: %s", linkPath)
-				}
-			}
+	for otherID, other := range gm.SaveLinks {
+		if otherID != gameID && other == link {
+			delete(gm.SaveLinks, gameID)
+			return nil
 		}
 	}
 
+	userID, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(link), saveLinkPrefix))
+	if err != nil {
+		return fmt.Errorf("invalid save link %s", link)
+	}
+	if err := gm.RemoveSaveLink(filepath.Dir(link), userID); err != nil {
+		return err
+	}
+	delete(gm.SaveLinks, gameID)
 	return nil
 }
 
@@ -259,23 +253,19 @@ func (gm *GameDirectoryManager) validateCleanupCompletion(gameID string) error {
 func (gm *GameDirectoryManager) findRemainingSymlinks(gameID string) ([]string, error) {
 	var symlinks []string
 
-	systemPaths, err := gm.pathDetector.DetectNetHackPaths()
+	link, exists := gm.SaveLinks[gameID]
+	if !exists {
+		return symlinks, nil
+	}
+	info, err := os.Lstat(link)
 	if err != nil {
+		if os.IsNotExist(err) {
+			return symlinks, nil
+		}
 		return symlinks, err
 	}
-
-	if systemPaths.SysConfFile != "" {
-		systemSaveDir := filepath.Join(filepath.Dir(systemPaths.SysConfFile), "save")
-		entries, err := os.ReadDir(systemSaveDir)
-		if err != nil {
-			return symlinks, err
-		}
-
-		for _, entry := range entries {
-			if entry.Type()&os.ModeSymlink != 0 && strings.Contains(entry.Name(), gameID) {
-				symlinks = append(symlinks, filepath.Join(systemSaveDir, entry.Name()))
-			}
-		}
+	if info.Mode()&os.ModeSymlink != 0 {
+		symlinks = append(symlinks, link)
 	}
 
 	return symlinks, nil
@@ -76,6 +76,21 @@ func (m *Manager) GetUserDirs(userID int) *UserGameDirs {
 	return m.directoryManager.GetUserDirs(userID)
 }
 
+// CheckUserDirs compares a user's directories with the layout setup creates
+func (m *Manager) CheckUserDirs(userID int, options *GameSetupOptions) ([]LayoutIssue, error) {
+	return m.directoryManager.CheckUserDirs(userID, options)
+}
+
+// RepairUserDirs reconciles a user's directories with the layout setup creates
+func (m *Manager) RepairUserDirs(userID int, options *GameSetupOptions) ([]LayoutIssue, error) {
+	return m.directoryManager.RepairUserDirs(userID, options)
+}
+
+// VerifySaveLinks audits the save links in a system save directory
+func (m *Manager) VerifySaveLinks(linkDir string) ([]SaveLinkIssue, error) {
+	return m.directoryManager.VerifySaveLinks(linkDir)
+}
+
 // CleanupExpiredTempDirs removes expired temporary directories
 func (m *Manager) CleanupExpiredTempDirs(maxAge time.Duration) error {
 	return m.directoryManager.CleanupExpiredTempDirs(maxAge)
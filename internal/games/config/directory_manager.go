@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"sync"
 )
 
@@ -13,6 +14,7 @@ type GameDirectoryManager struct {
 	BaseDir      string                   // Base directory for all game data
 	UserDirs     map[string]*UserGameDirs // Per-user directory structure
 	TempDirs     map[string]string        // Temporary directories for active games
+	SaveLinks    map[string]string        // Save links made for active games
 	CleanupQueue []string                 // Directories pending cleanup
 	pathDetector *PathDetector            // Path detector for system paths
 	mutex        sync.RWMutex             // Protects concurrent access
@@ -25,6 +27,7 @@ func NewGameDirectoryManager(baseDir string, logger *log.Logger) *GameDirectoryM
 		BaseDir:      baseDir,
 		UserDirs:     make(map[string]*UserGameDirs),
 		TempDirs:     make(map[string]string),
+		SaveLinks:    make(map[string]string),
 		CleanupQueue: make([]string, 0),
 		pathDetector: NewPathDetector(),
 		logger:       logger,
@@ -42,17 +45,7 @@ func (gm *GameDirectoryManager) GetUserDirs(userID int) *UserGameDirs {
 	}
 
 	// Create new user directory structure
-	baseDir := filepath.Join(gm.BaseDir, "users", fmt.Sprintf("%d", userID))
-	userDirs := &UserGameDirs{
-		UserID:     userID,
-		BaseDir:    baseDir,
-		SaveDir:    filepath.Join(baseDir, "saves"),
-		ConfigDir:  filepath.Join(baseDir, "config"),
-		BonesDir:   filepath.Join(baseDir, "bones"),
-		LevelDir:   filepath.Join(baseDir, "levels"),
-		LockDir:    filepath.Join(baseDir, "locks"),
-		TroubleDir: filepath.Join(baseDir, "trouble"),
-	}
+	userDirs := userDirsAt(userID, filepath.Join(gm.BaseDir, "users", strconv.Itoa(userID)))
 
 	gm.UserDirs[userKey] = userDirs
 	return userDirs
@@ -72,10 +65,11 @@ func (gm *GameDirectoryManager) SetupGamePaths(userID int, gameID string, option
 		}
 	}
 
-	// Create user directories if requested
+	// Create user directories, with their default config and permissions,
+	// if requested
 	if options.CreateUserDirs {
-		if err := gm.createUserDirectories(userDirs); err != nil {
-			return nil, fmt.Errorf("failed to create user directories: %w", err)
+		if err := gm.ProvisionUser(userID, options); err != nil {
+			return nil, fmt.Errorf("failed to provision user directories: %w", err)
 		}
 	}
 
@@ -104,21 +98,22 @@ func (gm *GameDirectoryManager) SetupGamePaths(userID int, gameID string, option
 	}
 
 	// Create save directory symlinks if requested
+	linkDir := saveLinkDir(paths.SysConfDir)
 	if options.CreateSaveLinks {
-		if err := gm.createSaveSymlinks(paths, userDirs); err != nil {
+		if err := gm.CreateSaveLink(linkDir, userID); err != nil {
 			return nil, fmt.Errorf("failed to create save symlinks: %w", err)
 		}
 	}
 
 	// Copy default config if requested
-	if options.CopyDefaultConfig {
+	if options.CopyDefaultConfig && !options.CreateUserDirs {
 		if err := gm.copyDefaultConfig(userDirs); err != nil {
 			return nil, fmt.Errorf("failed to copy default config: %w", err)
 		}
 	}
 
 	// Set permissions if requested
-	if options.SetPermissions {
+	if options.SetPermissions && !options.CreateUserDirs {
 		if err := gm.setPermissions(userDirs); err != nil {
 			return nil, fmt.Errorf("failed to set permissions: %w", err)
 		}
@@ -127,6 +122,9 @@ func (gm *GameDirectoryManager) SetupGamePaths(userID int, gameID string, option
 	// Register for cleanup
 	gm.mutex.Lock()
 	gm.TempDirs[gameID] = tempDir
+	if options.CreateSaveLinks {
+		gm.SaveLinks[gameID] = filepath.Join(linkDir, saveLinkName(userID))
+	}
 	gm.mutex.Unlock()
 
 	return paths, nil
@@ -134,17 +132,7 @@ func (gm *GameDirectoryManager) SetupGamePaths(userID int, gameID string, option
 
 // createUserDirectories creates all user directories
 func (gm *GameDirectoryManager) createUserDirectories(userDirs *UserGameDirs) error {
-	dirs := []string{
-		userDirs.BaseDir,
-		userDirs.SaveDir,
-		userDirs.ConfigDir,
-		userDirs.BonesDir,
-		userDirs.LevelDir,
-		userDirs.LockDir,
-		userDirs.TroubleDir,
-	}
-
-	for _, dir := range dirs {
+	for _, dir := range userDirs.directories() {
 		if err := os.MkdirAll(dir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory %s: %w", dir, err)
 		}
@@ -185,31 +173,14 @@ func (gm *GameDirectoryManager) getSystemPath(systemPaths *NetHackSystemPaths, p
 	return defaultValue
 }
 
-// createSaveSymlinks creates symlinks for save directories
-func (gm *GameDirectoryManager) createSaveSymlinks(paths *NetHackPaths, userDirs *UserGameDirs) error {
-	// Create symlink from system save directory to user save directory
-	systemSaveDir := filepath.Join(filepath.Dir(paths.SysConfDir), "save")
-	userSaveLink := filepath.Join(systemSaveDir, fmt.Sprintf("user_%d", userDirs.UserID))
-
-	// Remove existing symlink if it exists
-	if _, err := os.Lstat(userSaveLink); err == nil {
-		if err := os.Remove(userSaveLink); err != nil {
-			return fmt.Errorf("failed to remove existing symlink: %w", err)
-		}
-	}
-
-	// Create new symlink
-	if err := os.Symlink(userDirs.SaveDir, userSaveLink); err != nil {
-		return fmt.Errorf("failed to create save symlink: %w", err)
-	}
-
-	return nil
-}
+// defaultConfigName is the default configuration copied to a user's config
+// directory
+const defaultConfigName = ".nethackrc"
 
 // copyDefaultConfig copies default configuration files
 func (gm *GameDirectoryManager) copyDefaultConfig(userDirs *UserGameDirs) error {
 	// Create a basic .nethackrc file
-	nethackrcPath := filepath.Join(userDirs.ConfigDir, ".nethackrc")
+	nethackrcPath := filepath.Join(userDirs.ConfigDir, defaultConfigName)
 
 	// Check if file already exists
 	if _, err := os.Stat(nethackrcPath); err == nil {
@@ -238,18 +209,8 @@ OPTIONS=windowtype:tty
 
 // setPermissions sets appropriate permissions on directories
 func (gm *GameDirectoryManager) setPermissions(userDirs *UserGameDirs) error {
-	dirs := []string{
-		userDirs.BaseDir,
-		userDirs.SaveDir,
-		userDirs.ConfigDir,
-		userDirs.BonesDir,
-		userDirs.LevelDir,
-		userDirs.LockDir,
-		userDirs.TroubleDir,
-	}
-
-	for _, dir := range dirs {
-		if err := os.Chmod(dir, 0755); err != nil {
+	for _, dir := range userDirs.directories() {
+		if err := os.Chmod(dir, userDirMode); err != nil {
 			return fmt.Errorf("failed to set permissions on %s: %w", dir, err)
 		}
 	}
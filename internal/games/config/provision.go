@@ -0,0 +1,225 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// stagingPrefix starts the names of the directories user trees are built in
+// before being renamed into place
+const stagingPrefix = ".staging-"
+
+// userDirMode is the mode of provisioned user directories
+const userDirMode os.FileMode = 0755
+
+// Layout problems found by CheckUserDirs
+const (
+	IssueMissing       = "missing"        // A directory does not exist
+	IssueNotDirectory  = "not_directory"  // Something other than a directory is in the way
+	IssuePermissions   = "permissions"    // A directory's mode is not userDirMode
+	IssueMissingConfig = "missing_config" // The default game configuration was not copied
+	IssueStaleStaging  = "stale_staging"  // An interrupted provisioning left its staging directory
+)
+
+// LayoutIssue is a difference between a user's directory tree and the
+// layout provisioning creates
+type LayoutIssue struct {
+	Path    string `json:"path"`
+	Problem string `json:"problem"`
+	// Fixed is set by RepairUserDirs once the issue is reconciled
+	Fixed bool `json:"fixed"`
+}
+
+// userDirsAt returns the directory structure of a user's tree rooted at
+// baseDir
+func userDirsAt(userID int, baseDir string) *UserGameDirs {
+	return &UserGameDirs{
+		UserID:     userID,
+		BaseDir:    baseDir,
+		SaveDir:    filepath.Join(baseDir, "saves"),
+		ConfigDir:  filepath.Join(baseDir, "config"),
+		BonesDir:   filepath.Join(baseDir, "bones"),
+		LevelDir:   filepath.Join(baseDir, "levels"),
+		LockDir:    filepath.Join(baseDir, "locks"),
+		TroubleDir: filepath.Join(baseDir, "trouble"),
+	}
+}
+
+// directories lists a user's tree, the base directory first
+func (dirs *UserGameDirs) directories() []string {
+	return []string{
+		dirs.BaseDir,
+		dirs.SaveDir,
+		dirs.ConfigDir,
+		dirs.BonesDir,
+		dirs.LevelDir,
+		dirs.LockDir,
+		dirs.TroubleDir,
+	}
+}
+
+// ProvisionUser creates a user's directory tree, with the default game
+// configuration and permissions options ask for, all or nothing: the tree
+// is built in a staging directory beside it and renamed into place, and the
+// staging directory is removed if any step fails. A tree that already
+// exists is repaired instead, so provisioning can be repeated safely.
+func (gm *GameDirectoryManager) ProvisionUser(userID int, options *GameSetupOptions) error {
+	userDirs := gm.GetUserDirs(userID)
+	if _, err := os.Lstat(userDirs.BaseDir); err == nil {
+		return gm.repairProvisioned(userID, options)
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to check user directory: %w", err)
+	}
+
+	parent := filepath.Dir(userDirs.BaseDir)
+	if err := os.MkdirAll(parent, userDirMode); err != nil {
+		return fmt.Errorf("failed to create %s: %w", parent, err)
+	}
+	staging, err := os.MkdirTemp(parent, stagingName(userID))
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	if err := gm.buildUserTree(userDirsAt(userID, staging), options); err != nil {
+		os.RemoveAll(staging)
+		return err
+	}
+
+	if err := os.Rename(staging, userDirs.BaseDir); err != nil {
+		os.RemoveAll(staging)
+		// Another provisioning of the same user finished first
+		if _, statErr := os.Lstat(userDirs.BaseDir); statErr == nil {
+			return gm.repairProvisioned(userID, options)
+		}
+		return fmt.Errorf("failed to move user directory into place: %w", err)
+	}
+	gm.logger.Printf("Provisioned directories for user %d: %s", userID, userDirs.BaseDir)
+	return nil
+}
+
+// stagingName is the prefix of a user's staging directories
+func stagingName(userID int) string {
+	return stagingPrefix + strconv.Itoa(userID) + "-"
+}
+
+// buildUserTree creates a user's tree in a staging directory
+func (gm *GameDirectoryManager) buildUserTree(staged *UserGameDirs, options *GameSetupOptions) error {
+	// MkdirTemp creates the staging directory private to the service
+	if err := os.Chmod(staged.BaseDir, userDirMode); err != nil {
+		return fmt.Errorf("failed to set permissions on staging directory: %w", err)
+	}
+	if err := gm.createUserDirectories(staged); err != nil {
+		return fmt.Errorf("failed to create user directories: %w", err)
+	}
+	if options.CopyDefaultConfig {
+		if err := gm.copyDefaultConfig(staged); err != nil {
+			return fmt.Errorf("failed to copy default config: %w", err)
+		}
+	}
+	if options.SetPermissions {
+		if err := gm.setPermissions(staged); err != nil {
+			return fmt.Errorf("failed to set permissions: %w", err)
+		}
+	}
+	return nil
+}
+
+// repairProvisioned repairs an existing tree for ProvisionUser, failing if
+// it cannot be made whole
+func (gm *GameDirectoryManager) repairProvisioned(userID int, options *GameSetupOptions) error {
+	issues, err := gm.RepairUserDirs(userID, options)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if !issue.Fixed {
+			return fmt.Errorf("user directory %s is %s", issue.Path, strings.ReplaceAll(issue.Problem, "_", " "))
+		}
+	}
+	return nil
+}
+
+// CheckUserDirs compares a user's directory tree with the layout
+// ProvisionUser creates with options, returning the differences. A user
+// never provisioned has every directory missing.
+func (gm *GameDirectoryManager) CheckUserDirs(userID int, options *GameSetupOptions) ([]LayoutIssue, error) {
+	userDirs := gm.GetUserDirs(userID)
+	var issues []LayoutIssue
+
+	for _, dir := range userDirs.directories() {
+		info, err := os.Lstat(dir)
+		switch {
+		case os.IsNotExist(err):
+			issues = append(issues, LayoutIssue{Path: dir, Problem: IssueMissing})
+		case err != nil:
+			return nil, fmt.Errorf("failed to check %s: %w", dir, err)
+		case !info.IsDir():
+			issues = append(issues, LayoutIssue{Path: dir, Problem: IssueNotDirectory})
+		case options.SetPermissions && info.Mode().Perm() != userDirMode:
+			issues = append(issues, LayoutIssue{Path: dir, Problem: IssuePermissions})
+		}
+	}
+
+	if options.CopyDefaultConfig {
+		path := filepath.Join(userDirs.ConfigDir, defaultConfigName)
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			issues = append(issues, LayoutIssue{Path: path, Problem: IssueMissingConfig})
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to check %s: %w", path, err)
+		}
+	}
+
+	staging, err := filepath.Glob(filepath.Join(filepath.Dir(userDirs.BaseDir), stagingName(userID)+"*"))
+	if err != nil {
+		return nil, err
+	}
+	for _, dir := range staging {
+		issues = append(issues, LayoutIssue{Path: dir, Problem: IssueStaleStaging})
+	}
+	return issues, nil
+}
+
+// RepairUserDirs reconciles a user's directory tree with the layout
+// ProvisionUser creates with options: missing directories and the default
+// configuration are created, permissions reset and stale staging
+// directories removed. Whatever is in the way of a directory is left for an
+// operator to look at, so no game data is lost. It returns the issues found,
+// marking those fixed.
+func (gm *GameDirectoryManager) RepairUserDirs(userID int, options *GameSetupOptions) ([]LayoutIssue, error) {
+	issues, err := gm.CheckUserDirs(userID, options)
+	if err != nil {
+		return nil, err
+	}
+
+	userDirs := gm.GetUserDirs(userID)
+	var errs []error
+	for i := range issues {
+		issue := &issues[i]
+		switch issue.Problem {
+		case IssueMissing:
+			err = os.Mkdir(issue.Path, userDirMode)
+			if os.IsExist(err) {
+				err = nil
+			}
+		case IssuePermissions:
+			err = os.Chmod(issue.Path, userDirMode)
+		case IssueMissingConfig:
+			// The config directory is created first when it was missing too
+			err = gm.copyDefaultConfig(userDirs)
+		case IssueStaleStaging:
+			err = os.RemoveAll(issue.Path)
+		default:
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to repair %s: %w", issue.Path, err))
+			continue
+		}
+		issue.Fixed = true
+		gm.logger.Printf("Repaired %s (%s) for user %d", issue.Path, issue.Problem, userID)
+	}
+	return issues, errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func provisionOptions() *GameSetupOptions {
+	return &GameSetupOptions{CreateUserDirs: true, CopyDefaultConfig: true, SetPermissions: true}
+}
+
+func TestProvisionUser(t *testing.T) {
+	manager := NewGameDirectoryManager(t.TempDir(), log.New(io.Discard, "", 0))
+	userDirs := manager.GetUserDirs(42)
+
+	if err := manager.ProvisionUser(42, provisionOptions()); err != nil {
+		t.Fatalf("ProvisionUser failed: %v", err)
+	}
+	for _, dir := range userDirs.directories() {
+		info, err := os.Stat(dir)
+		if err != nil || !info.IsDir() {
+			t.Errorf("Expected directory %s to be provisioned", dir)
+		} else if info.Mode().Perm() != userDirMode {
+			t.Errorf("Expected %s to have mode %v, got %v", dir, userDirMode, info.Mode().Perm())
+		}
+	}
+	if _, err := os.Stat(filepath.Join(userDirs.ConfigDir, defaultConfigName)); err != nil {
+		t.Errorf("Expected default config to be copied: %v", err)
+	}
+
+	// Provisioning again changes nothing and leaves no staging behind
+	if err := manager.ProvisionUser(42, provisionOptions()); err != nil {
+		t.Fatalf("Repeated ProvisionUser failed: %v", err)
+	}
+	issues, err := manager.CheckUserDirs(42, provisionOptions())
+	if err != nil {
+		t.Fatalf("CheckUserDirs failed: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("Expected a provisioned tree to have no issues, got %+v", issues)
+	}
+}
+
+func TestRepairUserDirs(t *testing.T) {
+	manager := NewGameDirectoryManager(t.TempDir(), log.New(io.Discard, "", 0))
+	userDirs := manager.GetUserDirs(7)
+
+	// A half-created tree: some directories, a file in the way of another,
+	// loose permissions and the staging directory of an interrupted run
+	for _, dir := range []string{userDirs.SaveDir, userDirs.ConfigDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(userDirs.BaseDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(userDirs.LockDir, []byte("lock"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	staging := filepath.Join(filepath.Dir(userDirs.BaseDir), stagingName(7)+"123")
+	if err := os.Mkdir(staging, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err := manager.CheckUserDirs(7, provisionOptions())
+	if err != nil {
+		t.Fatalf("CheckUserDirs failed: %v", err)
+	}
+	problems := make(map[string]string)
+	for _, issue := range issues {
+		problems[issue.Path] = issue.Problem
+	}
+	expected := map[string]string{
+		userDirs.SaveDir:    IssuePermissions,
+		userDirs.ConfigDir:  IssuePermissions,
+		userDirs.BonesDir:   IssueMissing,
+		userDirs.LevelDir:   IssueMissing,
+		userDirs.LockDir:    IssueNotDirectory,
+		userDirs.TroubleDir: IssueMissing,
+		filepath.Join(userDirs.ConfigDir, defaultConfigName): IssueMissingConfig,
+		staging: IssueStaleStaging,
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("Expected issues %v, got %v", expected, problems)
+	}
+	for path, problem := range expected {
+		if problems[path] != problem {
+			t.Errorf("Expected %s to be %s, got %q", path, problem, problems[path])
+		}
+	}
+
+	// Everything but the file in the way is repaired
+	if err := manager.ProvisionUser(7, provisionOptions()); err == nil || !strings.Contains(err.Error(), "not directory") {
+		t.Errorf("Expected provisioning to report the file in the way, got %v", err)
+	}
+	if data, err := os.ReadFile(userDirs.LockDir); err != nil || string(data) != "lock" {
+		t.Errorf("Expected the file in the way to be kept")
+	}
+	if _, err := os.Stat(staging); !os.IsNotExist(err) {
+		t.Errorf("Expected the stale staging directory to be removed")
+	}
+
+	if err := os.Remove(userDirs.LockDir); err != nil {
+		t.Fatal(err)
+	}
+	issues, err = manager.RepairUserDirs(7, provisionOptions())
+	if err != nil {
+		t.Fatalf("RepairUserDirs failed: %v", err)
+	}
+	if len(issues) != 1 || issues[0].Path != userDirs.LockDir || !issues[0].Fixed {
+		t.Errorf("Expected only the lock directory to be repaired, got %+v", issues)
+	}
+}
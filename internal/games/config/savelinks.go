@@ -0,0 +1,243 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// saveLinkPrefix starts the names of the links from a system save directory
+// to users' save directories
+const saveLinkPrefix = "user_"
+
+// Save link problems found by VerifySaveLinks
+const (
+	LinkDangling    = "dangling"           // The link's target does not exist
+	LinkEscapes     = "escapes_users_root" // The link resolves outside the users' directories
+	LinkWrongTarget = "wrong_target"       // The link points at another directory than its user's saves
+	LinkNotSymlink  = "not_symlink"        // An entry named for a user is not a symlink
+	LinkUnknown     = "unknown"            // A symlink not named for a user
+)
+
+// SaveLinkIssue is a suspicious entry in a system save directory
+type SaveLinkIssue struct {
+	Path    string `json:"path"`
+	Target  string `json:"target,omitempty"`
+	Problem string `json:"problem"`
+}
+
+// saveLinkName is the name of a user's link in a system save directory
+func saveLinkName(userID int) string {
+	return saveLinkPrefix + strconv.Itoa(userID)
+}
+
+// saveLinkDir is the system save directory beside a sysconf file
+func saveLinkDir(sysConf string) string {
+	return filepath.Join(filepath.Dir(sysConf), "save")
+}
+
+// usersRoot is the directory holding every user's tree
+func (gm *GameDirectoryManager) usersRoot() (string, error) {
+	return filepath.Abs(filepath.Join(gm.BaseDir, "users"))
+}
+
+// within reports whether path is root or below it, comparing cleaned paths
+func within(root, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// saveLinkTarget returns the save directory a user's link points at, after
+// checking it is a real directory within the users' directories: neither
+// the user's tree nor its save directory may be a symlink, and the target
+// must resolve below the resolved users' root
+func (gm *GameDirectoryManager) saveLinkTarget(userID int) (string, error) {
+	root, err := gm.usersRoot()
+	if err != nil {
+		return "", err
+	}
+	userDirs := userDirsAt(userID, filepath.Join(root, strconv.Itoa(userID)))
+	if !within(root, userDirs.SaveDir) {
+		return "", fmt.Errorf("save directory %s is outside %s", userDirs.SaveDir, root)
+	}
+
+	for _, dir := range []string{userDirs.BaseDir, userDirs.SaveDir} {
+		info, err := os.Lstat(dir)
+		if err != nil {
+			return "", err
+		}
+		if !info.IsDir() {
+			return "", fmt.Errorf("%s is not a directory", dir)
+		}
+	}
+
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := filepath.EvalSymlinks(userDirs.SaveDir)
+	if err != nil {
+		return "", err
+	}
+	if !within(resolvedRoot, resolved) {
+		return "", fmt.Errorf("save directory %s resolves outside %s", userDirs.SaveDir, resolvedRoot)
+	}
+	return userDirs.SaveDir, nil
+}
+
+// CreateSaveLink links a user's entry in a system save directory to their
+// save directory. The target is checked to stay within the users'
+// directories, the save directory is opened without following symlinks and
+// anything but an existing symlink in the link's place is refused. The link
+// is made under a temporary name and renamed over the old one, so it is
+// never missing or half made.
+func (gm *GameDirectoryManager) CreateSaveLink(linkDir string, userID int) error {
+	target, err := gm.saveLinkTarget(userID)
+	if err != nil {
+		return fmt.Errorf("invalid save link target: %w", err)
+	}
+
+	dir, err := openLinkDir(linkDir)
+	if err != nil {
+		return fmt.Errorf("failed to open save directory: %w", err)
+	}
+	defer dir.Close()
+
+	name := saveLinkName(userID)
+	mode, err := dir.lstat(name)
+	switch {
+	case err == nil && mode != os.ModeSymlink:
+		return fmt.Errorf("%s is not a symlink, refusing to replace it", filepath.Join(linkDir, name))
+	case err == nil:
+		if current, err := dir.readlink(name); err == nil && current == target {
+			return nil
+		}
+	case !errors.Is(err, fs.ErrNotExist):
+		return err
+	}
+
+	temp := fmt.Sprintf(".%s-%d-%d", name, os.Getpid(), time.Now().UnixNano())
+	if err := dir.symlink(target, temp); err != nil {
+		return err
+	}
+	if err := dir.rename(temp, name); err != nil {
+		dir.remove(temp)
+		return err
+	}
+	gm.logger.Printf("Linked %s to %s", filepath.Join(linkDir, name), target)
+	return nil
+}
+
+// RemoveSaveLink removes a user's entry from a system save directory,
+// refusing anything that is not a symlink so a save file is never removed
+// in its place. A link already gone is not an error.
+func (gm *GameDirectoryManager) RemoveSaveLink(linkDir string, userID int) error {
+	dir, err := openLinkDir(linkDir)
+	if err != nil {
+		return fmt.Errorf("failed to open save directory: %w", err)
+	}
+	defer dir.Close()
+
+	name := saveLinkName(userID)
+	mode, err := dir.lstat(name)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	case mode != os.ModeSymlink:
+		return fmt.Errorf("%s is not a symlink, refusing to remove it", filepath.Join(linkDir, name))
+	}
+	if err := dir.remove(name); err != nil {
+		return err
+	}
+	gm.logger.Printf("Removed save link %s", filepath.Join(linkDir, name))
+	return nil
+}
+
+// VerifySaveLinks audits a system save directory, returning entries named
+// for a user that are not symlinks, links that dangle, point at another
+// directory than their user's saves or resolve outside the users'
+// directories, and symlinks not named for a user. Other entries, such as
+// the game's own save files, are not reported.
+func (gm *GameDirectoryManager) VerifySaveLinks(linkDir string) ([]SaveLinkIssue, error) {
+	root, err := gm.usersRoot()
+	if err != nil {
+		return nil, err
+	}
+	resolvedRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		resolvedRoot = root
+	}
+
+	dir, err := openLinkDir(linkDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open save directory: %w", err)
+	}
+	defer dir.Close()
+	names, err := dir.names()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var issues []SaveLinkIssue
+	for _, name := range names {
+		mode, err := dir.lstat(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		path := filepath.Join(linkDir, name)
+		named := strings.HasPrefix(name, saveLinkPrefix)
+		if mode != os.ModeSymlink {
+			if named {
+				issues = append(issues, SaveLinkIssue{Path: path, Problem: LinkNotSymlink})
+			}
+			continue
+		}
+
+		target, err := dir.readlink(name)
+		if err != nil {
+			return nil, err
+		}
+		issue := SaveLinkIssue{Path: path, Target: target}
+		resolvedTarget := target
+		if !filepath.IsAbs(resolvedTarget) {
+			resolvedTarget = filepath.Join(linkDir, resolvedTarget)
+		}
+		userID, err := strconv.Atoi(strings.TrimPrefix(name, saveLinkPrefix))
+		switch {
+		case !named || err != nil || userID <= 0:
+			issue.Problem = LinkUnknown
+		case !within(root, filepath.Clean(resolvedTarget)):
+			issue.Problem = LinkEscapes
+		default:
+			resolved, err := filepath.EvalSymlinks(resolvedTarget)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				issue.Problem = LinkDangling
+			case err != nil:
+				return nil, err
+			case !within(resolvedRoot, resolved):
+				issue.Problem = LinkEscapes
+			case filepath.Clean(resolvedTarget) != userDirsAt(userID, filepath.Join(root, strconv.Itoa(userID))).SaveDir:
+				issue.Problem = LinkWrongTarget
+			default:
+				continue
+			}
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
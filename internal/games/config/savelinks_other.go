@@ -0,0 +1,73 @@
+//go:build !unix
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// linkDir is a directory save links are made in. Without the *at system
+// calls its entries are changed by path, after checking the directory is
+// not a symlink.
+type linkDir struct {
+	path string
+}
+
+// openLinkDir opens a directory for save links, refusing a symlink
+func openLinkDir(path string) (*linkDir, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", path)
+	}
+	return &linkDir{path: path}, nil
+}
+
+func (d *linkDir) Close() error {
+	return nil
+}
+
+// lstat returns the type of an entry without following a symlink
+func (d *linkDir) lstat(name string) (os.FileMode, error) {
+	info, err := os.Lstat(filepath.Join(d.path, name))
+	if err != nil {
+		return 0, err
+	}
+	return info.Mode().Type() & (os.ModeSymlink | os.ModeDir), nil
+}
+
+// readlink returns the target of a symlink
+func (d *linkDir) readlink(name string) (string, error) {
+	return os.Readlink(filepath.Join(d.path, name))
+}
+
+func (d *linkDir) symlink(target, name string) error {
+	return os.Symlink(target, filepath.Join(d.path, name))
+}
+
+// rename replaces to with from
+func (d *linkDir) rename(from, to string) error {
+	return os.Rename(filepath.Join(d.path, from), filepath.Join(d.path, to))
+}
+
+// remove removes an entry that is not a directory
+func (d *linkDir) remove(name string) error {
+	return os.Remove(filepath.Join(d.path, name))
+}
+
+// names lists the directory's entries
+func (d *linkDir) names() ([]string, error) {
+	entries, err := os.ReadDir(d.path)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(entries))
+	for i, entry := range entries {
+		names[i] = entry.Name()
+	}
+	return names, nil
+}
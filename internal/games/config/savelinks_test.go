@@ -0,0 +1,210 @@
+//go:build unix
+
+package config
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSaveLinkFixture(t *testing.T) (*GameDirectoryManager, string) {
+	t.Helper()
+	manager := NewGameDirectoryManager(t.TempDir(), log.New(io.Discard, "", 0))
+	linkDir := filepath.Join(t.TempDir(), "save")
+	if err := os.Mkdir(linkDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return manager, linkDir
+}
+
+func TestCreateSaveLink(t *testing.T) {
+	manager, linkDir := newSaveLinkFixture(t)
+	if err := manager.ProvisionUser(3, provisionOptions()); err != nil {
+		t.Fatal(err)
+	}
+	saveDir := manager.GetUserDirs(3).SaveDir
+	link := filepath.Join(linkDir, saveLinkName(3))
+
+	if err := manager.CreateSaveLink(linkDir, 3); err != nil {
+		t.Fatalf("CreateSaveLink failed: %v", err)
+	}
+	if target, err := os.Readlink(link); err != nil || target != saveDir {
+		t.Errorf("Expected link to %s, got %q (%v)", saveDir, target, err)
+	}
+
+	// A stale link is replaced; creating again is a no-op
+	os.Remove(link)
+	os.Symlink("/tmp", link)
+	for i := 0; i < 2; i++ {
+		if err := manager.CreateSaveLink(linkDir, 3); err != nil {
+			t.Fatalf("CreateSaveLink failed: %v", err)
+		}
+	}
+	if target, _ := os.Readlink(link); target != saveDir {
+		t.Errorf("Expected stale link to be replaced, got %q", target)
+	}
+	entries, _ := os.ReadDir(linkDir)
+	if len(entries) != 1 {
+		t.Errorf("Expected only the link in the save directory, got %d entries", len(entries))
+	}
+
+	if err := manager.RemoveSaveLink(linkDir, 3); err != nil {
+		t.Fatalf("RemoveSaveLink failed: %v", err)
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("Expected link to be removed")
+	}
+	if err := manager.RemoveSaveLink(linkDir, 3); err != nil {
+		t.Errorf("Expected removing a missing link to succeed, got %v", err)
+	}
+}
+
+func TestCreateSaveLink_Refuses(t *testing.T) {
+	manager, linkDir := newSaveLinkFixture(t)
+	outside := t.TempDir()
+
+	// A user never provisioned has no save directory to link to
+	if err := manager.CreateSaveLink(linkDir, 4); err == nil {
+		t.Error("Expected a missing save directory to be refused")
+	}
+
+	// A save directory swapped for a symlink out of the users' root
+	if err := manager.ProvisionUser(5, provisionOptions()); err != nil {
+		t.Fatal(err)
+	}
+	saveDir := manager.GetUserDirs(5).SaveDir
+	os.Remove(saveDir)
+	if err := os.Symlink(outside, saveDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.CreateSaveLink(linkDir, 5); err == nil {
+		t.Error("Expected a symlinked save directory to be refused")
+	}
+
+	// A save file in the link's place is neither replaced nor removed
+	if err := manager.ProvisionUser(6, provisionOptions()); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(linkDir, saveLinkName(6))
+	if err := os.WriteFile(file, []byte("save"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.CreateSaveLink(linkDir, 6); err == nil {
+		t.Error("Expected a file in the link's place to be refused")
+	}
+	if err := manager.RemoveSaveLink(linkDir, 6); err == nil {
+		t.Error("Expected removing a file in the link's place to be refused")
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "save" {
+		t.Error("Expected the file in the link's place to be kept")
+	}
+
+	// A link directory that is itself a symlink
+	swapped := filepath.Join(t.TempDir(), "save")
+	if err := os.Symlink(outside, swapped); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.CreateSaveLink(swapped, 6); err == nil {
+		t.Error("Expected a symlinked save directory to be refused")
+	}
+	if entries, _ := os.ReadDir(outside); len(entries) != 0 {
+		t.Errorf("Expected nothing to be created through the symlink, got %d entries", len(entries))
+	}
+}
+
+func TestVerifySaveLinks(t *testing.T) {
+	manager, linkDir := newSaveLinkFixture(t)
+	for _, userID := range []int{1, 2} {
+		if err := manager.ProvisionUser(userID, provisionOptions()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := manager.CreateSaveLink(linkDir, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	root := filepath.Join(manager.BaseDir, "users")
+	entries := map[string]string{
+		"user_2":   manager.GetUserDirs(1).SaveDir,
+		"user_3":   filepath.Join(root, "3", "saves"),
+		"user_4":   "../../etc",
+		"user_5":   "/etc",
+		"backdoor": "/",
+	}
+	for name, target := range entries {
+		if err := os.Symlink(target, filepath.Join(linkDir, name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// A user's save directory pointing out of the users' root
+	escape := filepath.Join(root, "2", "escape")
+	if err := os.Symlink(t.TempDir(), escape); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(escape, filepath.Join(linkDir, "user_6")); err != nil {
+		t.Fatal(err)
+	}
+	// Save files are not links and are left alone, unless named for a user
+	os.WriteFile(filepath.Join(linkDir, "1000wizard.gz"), nil, 0644)
+	os.Mkdir(filepath.Join(linkDir, "user_7"), 0755)
+
+	issues, err := manager.VerifySaveLinks(linkDir)
+	if err != nil {
+		t.Fatalf("VerifySaveLinks failed: %v", err)
+	}
+	problems := make(map[string]string)
+	for _, issue := range issues {
+		problems[filepath.Base(issue.Path)] = issue.Problem
+	}
+	expected := map[string]string{
+		"user_2":   LinkWrongTarget,
+		"user_3":   LinkDangling,
+		"user_4":   LinkEscapes,
+		"user_5":   LinkEscapes,
+		"user_6":   LinkEscapes,
+		"user_7":   LinkNotSymlink,
+		"backdoor": LinkUnknown,
+	}
+	if len(problems) != len(expected) {
+		t.Errorf("Expected issues %v, got %v", expected, problems)
+	}
+	for name, problem := range expected {
+		if problems[name] != problem {
+			t.Errorf("Expected %s to be %s, got %q", name, problem, problems[name])
+		}
+	}
+}
+
+func TestCleanupGame_SaveLinks(t *testing.T) {
+	manager, linkDir := newSaveLinkFixture(t)
+	if err := manager.ProvisionUser(8, provisionOptions()); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.CreateSaveLink(linkDir, 8); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(linkDir, saveLinkName(8))
+	manager.SaveLinks["game-1"] = link
+	manager.SaveLinks["game-2"] = link
+
+	// The link stays while another game of the user is active
+	cleanup := &GameCleanupOptions{CleanupSaveLinks: true, ValidateCleanup: true}
+	if err := manager.CleanupGame("game-1", cleanup); err != nil {
+		t.Fatalf("CleanupGame failed: %v", err)
+	}
+	if _, err := os.Lstat(link); err != nil {
+		t.Errorf("Expected link to be kept for the other game: %v", err)
+	}
+	if err := manager.CleanupGame("game-2", cleanup); err != nil {
+		t.Fatalf("CleanupGame failed: %v", err)
+	}
+	if _, err := os.Lstat(link); !os.IsNotExist(err) {
+		t.Errorf("Expected link to be removed with the last game")
+	}
+	if len(manager.SaveLinks) != 0 {
+		t.Errorf("Expected no save links to be tracked, got %v", manager.SaveLinks)
+	}
+}
@@ -0,0 +1,96 @@
+//go:build unix
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"golang.org/x/sys/unix"
+)
+
+// linkDir is a directory save links are made in. It is opened without
+// following symlinks and its entries are changed relative to it, so a
+// directory swapped for a symlink, before or while links are made, cannot
+// redirect them elsewhere.
+type linkDir struct {
+	file *os.File
+}
+
+// openLinkDir opens a directory for save links, refusing a symlink
+func openLinkDir(path string) (*linkDir, error) {
+	fd, err := unix.Open(path, unix.O_RDONLY|unix.O_DIRECTORY|unix.O_NOFOLLOW|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return nil, &os.PathError{Op: "open", Path: path, Err: err}
+	}
+	return &linkDir{file: os.NewFile(uintptr(fd), path)}, nil
+}
+
+func (d *linkDir) Close() error {
+	return d.file.Close()
+}
+
+func (d *linkDir) fd() int {
+	return int(d.file.Fd())
+}
+
+func (d *linkDir) pathError(op, name string, err error) error {
+	return &os.PathError{Op: op, Path: filepath.Join(d.file.Name(), name), Err: err}
+}
+
+// lstat returns the type of an entry without following a symlink
+func (d *linkDir) lstat(name string) (os.FileMode, error) {
+	var st unix.Stat_t
+	if err := unix.Fstatat(d.fd(), name, &st, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return 0, d.pathError("lstat", name, err)
+	}
+	switch st.Mode & unix.S_IFMT {
+	case unix.S_IFLNK:
+		return os.ModeSymlink, nil
+	case unix.S_IFDIR:
+		return os.ModeDir, nil
+	}
+	return 0, nil
+}
+
+// readlink returns the target of a symlink
+func (d *linkDir) readlink(name string) (string, error) {
+	for size := 256; ; size *= 2 {
+		buf := make([]byte, size)
+		n, err := unix.Readlinkat(d.fd(), name, buf)
+		if err != nil {
+			return "", d.pathError("readlink", name, err)
+		}
+		if n < size {
+			return string(buf[:n]), nil
+		}
+	}
+}
+
+func (d *linkDir) symlink(target, name string) error {
+	if err := unix.Symlinkat(target, d.fd(), name); err != nil {
+		return d.pathError("symlink", name, err)
+	}
+	return nil
+}
+
+// rename replaces to with from, atomically
+func (d *linkDir) rename(from, to string) error {
+	if err := unix.Renameat(d.fd(), from, d.fd(), to); err != nil {
+		return d.pathError("rename", from, err)
+	}
+	return nil
+}
+
+// remove removes an entry that is not a directory
+func (d *linkDir) remove(name string) error {
+	if err := unix.Unlinkat(d.fd(), name, 0); err != nil {
+		return d.pathError("remove", name, err)
+	}
+	return nil
+}
+
+// names lists the directory's entries
+func (d *linkDir) names() ([]string, error) {
+	return d.file.Readdirnames(-1)
+}
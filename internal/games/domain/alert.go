@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// GameEventTypeSessionAlert records an alert raised by a game's status, such
+// as the player's HP running low
+const GameEventTypeSessionAlert GameEventType = "game.session.alert"
+
+// StatusAlert is an alert rule a game's status meets
+type StatusAlert struct {
+	// Rule names the rule, such as "low_hp"
+	Rule string
+	// Message is the banner shown to spectators
+	Message string
+	// StatusLine is the status that met the rule
+	StatusLine string
+}
+
+// RaiseAlerts takes the alerts the game's status meets now and returns those
+// it did not meet before, recording an event for each. An alert is raised
+// again only once the status has stopped meeting it.
+func (s *GameSession) RaiseAlerts(met []StatusAlert) []StatusAlert {
+	var raised []StatusAlert
+	rules := make([]string, 0, len(met))
+	now := time.Now()
+	for _, alert := range met {
+		rules = append(rules, alert.Rule)
+		if slices.Contains(s.alerts, alert.Rule) {
+			continue
+		}
+		raised = append(raised, alert)
+		s.events = append(s.events, &GameEvent{
+			Type:      GameEventTypeSessionAlert,
+			GameID:    s.gameID.String(),
+			SessionID: s.id.String(),
+			UserID:    s.userID.Int(),
+			Data: map[string]interface{}{
+				"rule":        alert.Rule,
+				"message":     alert.Message,
+				"status_line": alert.StatusLine,
+				"username":    s.username,
+			},
+			Timestamp: now,
+		})
+	}
+	s.alerts = rules
+	return raised
+}
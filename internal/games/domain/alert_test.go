@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameSession_RaiseAlerts(t *testing.T) {
+	session := NewGameSession(NewSessionID("session_a"), NewUserID(1), "agent", NewGameID("nethack"), GameConfig{}, TerminalSize{Width: 80, Height: 24})
+	session.PullEvents()
+	lowHP := StatusAlert{Rule: "low_hp", Message: "agent is low on HP", StatusLine: "Dlvl:3 HP:2/16"}
+	deep := StatusAlert{Rule: "deep", Message: "agent is deep", StatusLine: "Dlvl:30 HP:2/16"}
+
+	assert.Equal(t, []StatusAlert{lowHP}, session.RaiseAlerts([]StatusAlert{lowHP}))
+	// An alert still met is not raised again
+	assert.Empty(t, session.RaiseAlerts([]StatusAlert{lowHP}))
+	assert.Equal(t, []StatusAlert{deep}, session.RaiseAlerts([]StatusAlert{lowHP, deep}))
+	// Once no longer met, it is raised the next time it is
+	assert.Empty(t, session.RaiseAlerts(nil))
+	assert.Equal(t, []StatusAlert{lowHP}, session.RaiseAlerts([]StatusAlert{lowHP}))
+
+	events := session.PullEvents()
+	require.Len(t, events, 3)
+	assert.Equal(t, GameEventTypeSessionAlert, events[0].Type)
+	assert.Equal(t, "session_a", events[0].SessionID)
+	assert.Equal(t, "low_hp", events[0].Data["rule"])
+	assert.Equal(t, "agent is low on HP", events[0].Data["message"])
+	assert.Equal(t, "deep", events[1].Data["rule"])
+}
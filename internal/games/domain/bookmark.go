@@ -0,0 +1,119 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+var (
+	// ErrBookmarkLimit is returned when a session has as many bookmarks as it
+	// keeps
+	ErrBookmarkLimit = apierror.New(apierror.RateLimited, "session has as many bookmarks as it keeps")
+	// ErrSessionNotRecorded is returned when bookmarking a session that is not
+	// recorded, as there is nothing to jump to later
+	ErrSessionNotRecorded = apierror.New(apierror.InvalidRequest, "session is not recorded")
+)
+
+// BookmarkID represents a unique bookmark identifier
+type BookmarkID struct {
+	value string
+}
+
+// NewBookmarkID creates a new bookmark ID
+func NewBookmarkID(value string) BookmarkID {
+	return BookmarkID{value: value}
+}
+
+// String returns the string representation of the bookmark ID
+func (id BookmarkID) String() string {
+	return id.value
+}
+
+// Bookmark marks a moment of a live session by its player or a spectator,
+// kept as an offset into the session's recording
+type Bookmark struct {
+	// Identity
+	id        BookmarkID
+	sessionID SessionID
+	userID    UserID
+
+	// Who dropped it; a spectator unless it is the player
+	createdBy     UserID
+	createdByName string
+
+	// The moment, from the start of the recording
+	offset time.Duration
+	note   string
+
+	// Audit
+	createdAt time.Time
+}
+
+// NewBookmark creates a bookmark of a session at the moment at, measured
+// from the start of its recording
+func NewBookmark(id BookmarkID, session *GameSession, createdBy UserID, createdByName, note string, at time.Time) *Bookmark {
+	start := session.StartTime()
+	if recording := session.RecordingInfo(); recording != nil && !recording.StartTime.IsZero() {
+		start = recording.StartTime
+	}
+	offset := at.Sub(start)
+	if offset < 0 {
+		offset = 0
+	}
+	return &Bookmark{
+		id:            id,
+		sessionID:     session.ID(),
+		userID:        session.UserID(),
+		createdBy:     createdBy,
+		createdByName: createdByName,
+		offset:        offset,
+		note:          note,
+		createdAt:     at,
+	}
+}
+
+// ID returns the bookmark's ID
+func (b *Bookmark) ID() BookmarkID {
+	return b.id
+}
+
+// SessionID returns the bookmarked session
+func (b *Bookmark) SessionID() SessionID {
+	return b.sessionID
+}
+
+// UserID returns the player's user ID
+func (b *Bookmark) UserID() UserID {
+	return b.userID
+}
+
+// CreatedBy returns who dropped the bookmark
+func (b *Bookmark) CreatedBy() UserID {
+	return b.createdBy
+}
+
+// CreatedByName returns the username of who dropped the bookmark
+func (b *Bookmark) CreatedByName() string {
+	return b.createdByName
+}
+
+// BySpectator reports whether a spectator dropped the bookmark
+func (b *Bookmark) BySpectator() bool {
+	return b.createdBy != b.userID
+}
+
+// Offset returns how far into the recording the bookmarked moment is
+func (b *Bookmark) Offset() time.Duration {
+	return b.offset
+}
+
+// Note returns what the bookmark says about the moment, if anything
+func (b *Bookmark) Note() string {
+	return b.note
+}
+
+// CreatedAt returns when the bookmark was dropped
+func (b *Bookmark) CreatedAt() time.Time {
+	return b.createdAt
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// ErrDumplogNotFound is returned for dumplogs that do not exist or that the
+// viewer may not read
+var ErrDumplogNotFound = apierror.New(apierror.NotFound, "dumplog not found")
+
+// Dumplog represents the end-of-game dump a game wrote for a session
+type Dumplog struct {
+	// Identity
+	id        DumplogID
+	sessionID SessionID
+	userID    UserID
+	gameID    GameID
+
+	// Content
+	username   string
+	content    []byte
+	sourcePath string
+	truncated  bool
+
+	// Audit
+	capturedAt time.Time
+}
+
+// DumplogID represents a unique dumplog identifier
+type DumplogID struct {
+	value string
+}
+
+// NewDumplogID creates a new dumplog ID
+func NewDumplogID(value string) DumplogID {
+	return DumplogID{value: value}
+}
+
+// String returns the string representation of the dumplog ID
+func (id DumplogID) String() string {
+	return id.value
+}
+
+// NewDumplog creates a dumplog captured from the given session
+func NewDumplog(id DumplogID, session *GameSession, sourcePath string, content []byte, truncated bool) *Dumplog {
+	return &Dumplog{
+		id:         id,
+		sessionID:  session.ID(),
+		userID:     session.UserID(),
+		gameID:     session.GameID(),
+		username:   session.Username(),
+		content:    content,
+		sourcePath: sourcePath,
+		truncated:  truncated,
+		capturedAt: time.Now(),
+	}
+}
+
+// ID returns the dumplog's ID
+func (d *Dumplog) ID() DumplogID {
+	return d.id
+}
+
+// SessionID returns the session the dumplog was captured from
+func (d *Dumplog) SessionID() SessionID {
+	return d.sessionID
+}
+
+// UserID returns the player's user ID
+func (d *Dumplog) UserID() UserID {
+	return d.userID
+}
+
+// CanView reports whether a user may read the dumplog: only its player can
+func (d *Dumplog) CanView(viewerID UserID) bool {
+	return viewerID == d.userID
+}
+
+// GameID returns the game ID
+func (d *Dumplog) GameID() GameID {
+	return d.gameID
+}
+
+// Username returns the player's username
+func (d *Dumplog) Username() string {
+	return d.username
+}
+
+// Content returns the dumplog text
+func (d *Dumplog) Content() []byte {
+	return d.content
+}
+
+// Size returns the stored content size in bytes
+func (d *Dumplog) Size() int64 {
+	return int64(len(d.content))
+}
+
+// SourcePath returns the file the dumplog was read from
+func (d *Dumplog) SourcePath() string {
+	return d.sourcePath
+}
+
+// Truncated reports whether the content was cut to the configured maximum size
+func (d *Dumplog) Truncated() bool {
+	return d.truncated
+}
+
+// CapturedAt returns when the dumplog was captured
+func (d *Dumplog) CapturedAt() time.Time {
+	return d.capturedAt
+}
+
+// TransferTo gives the dumplog to another user, as when their accounts are
+// merged
+func (d *Dumplog) TransferTo(userID UserID, username string) {
+	d.userID = userID
+	d.username = username
+}
@@ -1,7 +1,17 @@
 package domain
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+var (
+	// ErrGameNotFound is returned when a game is not configured
+	ErrGameNotFound = apierror.New(apierror.GameNotFound, "game not found")
+	// ErrGameUnavailable is returned when a game exists but cannot be started
+	ErrGameUnavailable = apierror.New(apierror.GameUnavailable, "game is not available for play")
 )
 
 // Game aggregate root represents a game configuration and management
@@ -15,8 +25,12 @@ type Game struct {
 	config GameConfig
 
 	// State
-	status     GameStatus
-	statistics GameStatistics
+	status        GameStatus
+	statusMessage string
+	statistics    GameStatistics
+
+	// Status changes not yet stored, see PullEvents
+	events []*GameEvent
 
 	// Audit
 	createdAt time.Time
@@ -90,7 +104,8 @@ type NetworkConfig struct {
 	BlockInternet  bool
 }
 
-// GameStatus represents the current status of a game
+// GameStatus represents the current status of a game. Only enabled games
+// can be started; see CanTransitionTo for the allowed changes.
 type GameStatus string
 
 const (
@@ -152,21 +167,71 @@ func (g *Game) Statistics() GameStatistics {
 }
 
 // Enable enables the game
-func (g *Game) Enable() {
-	g.status = GameStatusEnabled
-	g.updatedAt = time.Now()
+func (g *Game) Enable() error {
+	return g.transition(GameStatusEnabled)
 }
 
 // Disable disables the game
-func (g *Game) Disable() {
-	g.status = GameStatusDisabled
-	g.updatedAt = time.Now()
+func (g *Game) Disable() error {
+	return g.transition(GameStatusDisabled)
 }
 
 // SetMaintenance sets the game to maintenance mode
-func (g *Game) SetMaintenance() {
-	g.status = GameStatusMaintenance
-	g.updatedAt = time.Now()
+func (g *Game) SetMaintenance() error {
+	return g.transition(GameStatusMaintenance)
+}
+
+// Deprecate marks the game as deprecated, keeping it out of new play
+func (g *Game) Deprecate() error {
+	return g.transition(GameStatusDeprecated)
+}
+
+// SetStatus moves the game to status, one of the GameStatus values. message
+// tells players why a game that is not enabled cannot be played; it is
+// dropped when the game is enabled.
+func (g *Game) SetStatus(status GameStatus, message string) error {
+	if _, ok := gameTransitions[status]; !ok {
+		return fmt.Errorf("%w: unknown game status %q", ErrInvalidTransition, status)
+	}
+	if err := g.transition(status); err != nil {
+		return err
+	}
+	if status != GameStatusEnabled && message != g.statusMessage {
+		g.statusMessage = message
+		g.updatedAt = time.Now()
+	}
+	return nil
+}
+
+// StatusMessage returns why the game cannot be played, as set with SetStatus
+func (g *Game) StatusMessage() string {
+	return g.statusMessage
+}
+
+// UnavailableError returns the error telling a player why the game cannot be
+// started, or nil when it can
+func (g *Game) UnavailableError() error {
+	if g.CanStart() {
+		return nil
+	}
+
+	name := g.metadata.Name
+	if name == "" {
+		name = g.id.String()
+	}
+	var message string
+	switch g.status {
+	case GameStatusMaintenance:
+		message = name + " is down for maintenance"
+	case GameStatusDeprecated:
+		message = name + " is no longer available"
+	default:
+		message = name + " is disabled"
+	}
+	if g.statusMessage != "" {
+		message += ": " + g.statusMessage
+	}
+	return apierror.Wrap(apierror.GameUnavailable, ErrGameUnavailable, message)
 }
 
 // UpdateConfig updates the game's configuration
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// HistoryFilter selects sessions for a player's session history
+type HistoryFilter struct {
+	// GameID restricts the history to one game; empty means every game
+	GameID string
+	// Since and Until bound when the sessions started; zero means unbounded
+	Since time.Time
+	Until time.Time
+	// Offset and Limit page through the matching sessions; a zero Limit
+	// returns all of them
+	Offset int
+	Limit  int
+}
+
+// SessionHistory returns the finished sessions matching filter, newest
+// first, and how many matched before paging
+func SessionHistory(sessions []*GameSession, filter HistoryFilter) ([]*GameSession, int) {
+	var matched []*GameSession
+	for _, session := range sessions {
+		if !session.Status().IsFinal() {
+			continue
+		}
+		if filter.GameID != "" && session.GameID().String() != filter.GameID {
+			continue
+		}
+		if !filter.Since.IsZero() && session.StartTime().Before(filter.Since) {
+			continue
+		}
+		if !filter.Until.IsZero() && !session.StartTime().Before(filter.Until) {
+			continue
+		}
+		matched = append(matched, session)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].StartTime().After(matched[j].StartTime())
+	})
+
+	total := len(matched)
+	if filter.Offset >= total {
+		return nil, total
+	}
+	if filter.Offset > 0 {
+		matched = matched[filter.Offset:]
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionHistory(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	lastWeek := sessionPlayed(t, now.Add(-7*24*time.Hour), now.Add(-7*24*time.Hour+time.Hour))
+	yesterday := sessionPlayed(t, now.Add(-24*time.Hour), now.Add(-23*time.Hour))
+	today := sessionPlayed(t, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	running := sessionPlayed(t, now.Add(-30*time.Minute), time.Time{})
+	other := NewGameSession(NewSessionID("session_other"), NewUserID(1), "player", NewGameID("dcss"), GameConfig{}, TerminalSize{Width: 80, Height: 24})
+	require.NoError(t, other.Adopt(ProcessInfo{PID: 1}, now.Add(-3*time.Hour)))
+	require.NoError(t, other.Crash(nil, nil))
+	sessions := []*GameSession{yesterday, running, lastWeek, other, today}
+
+	// Finished sessions only, newest first
+	history, total := SessionHistory(sessions, HistoryFilter{})
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []*GameSession{today, other, yesterday, lastWeek}, history)
+
+	history, total = SessionHistory(sessions, HistoryFilter{GameID: "nethack"})
+	assert.Equal(t, 3, total)
+	assert.Equal(t, []*GameSession{today, yesterday, lastWeek}, history)
+
+	history, total = SessionHistory(sessions, HistoryFilter{Since: now.Add(-48 * time.Hour), Until: now.Add(-2 * time.Hour)})
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []*GameSession{other, yesterday}, history)
+
+	// Paging
+	history, total = SessionHistory(sessions, HistoryFilter{Offset: 1, Limit: 2})
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []*GameSession{other, yesterday}, history)
+	history, total = SessionHistory(sessions, HistoryFilter{Offset: 4, Limit: 2})
+	assert.Equal(t, 4, total)
+	assert.Empty(t, history)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// LeaderboardSort is what a leaderboard ranks players by
+type LeaderboardSort string
+
+const (
+	// LeaderboardByPlayTime ranks players by their total time in the game
+	LeaderboardByPlayTime LeaderboardSort = "play_time"
+	// LeaderboardByGames ranks players by how many games they have played
+	LeaderboardByGames LeaderboardSort = "games"
+)
+
+// ParseLeaderboardSort validates a leaderboard ordering; empty means by play time
+func ParseLeaderboardSort(value string) (LeaderboardSort, error) {
+	switch LeaderboardSort(value) {
+	case "", LeaderboardByPlayTime:
+		return LeaderboardByPlayTime, nil
+	case LeaderboardByGames:
+		return LeaderboardByGames, nil
+	default:
+		return "", fmt.Errorf("unknown leaderboard sort: %s", value)
+	}
+}
+
+// LeaderboardEntry summarises one player's sessions of a game
+type LeaderboardEntry struct {
+	Rank           int
+	UserID         UserID
+	Username       string
+	GamesPlayed    int
+	PlayTime       time.Duration
+	LongestSession time.Duration
+	LastPlayed     time.Time
+}
+
+// BuildLeaderboard ranks the players of a game's sessions. When players is
+// non-empty only those users are ranked, which is how group leaderboards are
+// scoped. Sessions that failed to start are not counted. It returns at most
+// limit entries (all of them when limit is zero) and how many players were ranked.
+func BuildLeaderboard(sessions []*GameSession, players []UserID, sortBy LeaderboardSort, limit int) ([]LeaderboardEntry, int) {
+	var allowed map[UserID]bool
+	if len(players) > 0 {
+		allowed = make(map[UserID]bool, len(players))
+		for _, player := range players {
+			allowed[player] = true
+		}
+	}
+
+	byUser := make(map[UserID]*LeaderboardEntry)
+	for _, session := range sessions {
+		if session.Status() == SessionStatusFailed || (allowed != nil && !allowed[session.UserID()]) {
+			continue
+		}
+		entry, ok := byUser[session.UserID()]
+		if !ok {
+			entry = &LeaderboardEntry{UserID: session.UserID(), Username: session.Username()}
+			byUser[session.UserID()] = entry
+		}
+		duration := session.Duration()
+		entry.GamesPlayed++
+		entry.PlayTime += duration
+		if duration > entry.LongestSession {
+			entry.LongestSession = duration
+		}
+		if session.StartTime().After(entry.LastPlayed) {
+			entry.LastPlayed = session.StartTime()
+		}
+	}
+
+	entries := make([]LeaderboardEntry, 0, len(byUser))
+	for _, entry := range byUser {
+		entries = append(entries, *entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if sortBy == LeaderboardByGames && a.GamesPlayed != b.GamesPlayed {
+			return a.GamesPlayed > b.GamesPlayed
+		}
+		if a.PlayTime != b.PlayTime {
+			return a.PlayTime > b.PlayTime
+		}
+		if a.GamesPlayed != b.GamesPlayed {
+			return a.GamesPlayed > b.GamesPlayed
+		}
+		return a.Username < b.Username
+	})
+
+	total := len(entries)
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	for i := range entries {
+		entries[i].Rank = i + 1
+	}
+	return entries, total
+}
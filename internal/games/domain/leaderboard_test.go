@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func playedSession(id string, userID int, username string, played time.Duration) *GameSession {
+	session := NewGameSession(NewSessionID(id), NewUserID(userID), username, NewGameID("nethack"), GameConfig{}, TerminalSize{Width: 80, Height: 24})
+	session.Adopt(ProcessInfo{PID: 1}, time.Now().Add(-played))
+	session.End(nil, nil)
+	return session
+}
+
+func TestBuildLeaderboard(t *testing.T) {
+	sessions := []*GameSession{
+		playedSession("a1", 1, "alice", 2*time.Hour),
+		playedSession("b1", 2, "bob", time.Hour),
+		playedSession("b2", 2, "bob", time.Hour),
+		playedSession("b3", 2, "bob", 30*time.Minute),
+		playedSession("c1", 3, "carol", 10*time.Minute),
+	}
+	failed := NewGameSession(NewSessionID("c2"), NewUserID(3), "carol", NewGameID("nethack"), GameConfig{}, TerminalSize{})
+	failed.Fail("no such binary")
+	sessions = append(sessions, failed)
+
+	entries, total := BuildLeaderboard(sessions, nil, LeaderboardByPlayTime, 0)
+	require.Equal(t, 3, total)
+	assert.Equal(t, "bob", entries[0].Username)
+	assert.Equal(t, 3, entries[0].GamesPlayed)
+	assert.InDelta(t, time.Hour.Seconds(), entries[0].LongestSession.Seconds(), 1)
+	assert.Equal(t, "alice", entries[1].Username)
+	assert.Equal(t, 1, entries[2].GamesPlayed, "failed sessions are not counted")
+
+	// Scoped to a group, ranked by games, top one only
+	entries, total = BuildLeaderboard(sessions, []UserID{NewUserID(1), NewUserID(3)}, LeaderboardByGames, 1)
+	assert.Equal(t, 2, total)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "alice", entries[0].Username)
+	assert.Equal(t, 1, entries[0].Rank)
+}
+
+func TestParseLeaderboardSort(t *testing.T) {
+	sortBy, err := ParseLeaderboardSort("")
+	require.NoError(t, err)
+	assert.Equal(t, LeaderboardByPlayTime, sortBy)
+
+	_, err = ParseLeaderboardSort("score")
+	assert.Error(t, err)
+}
@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GameResult is how a finished game ended
+type GameResult string
+
+const (
+	// GameResultDied means the character died
+	GameResultDied GameResult = "died"
+	// GameResultQuit means the player quit the game
+	GameResultQuit GameResult = "quit"
+	// GameResultEscaped means the character left the dungeon alive
+	GameResultEscaped GameResult = "escaped"
+	// GameResultAscended means the character won the game
+	GameResultAscended GameResult = "ascended"
+)
+
+// GameOutcome is how a game ended, as the game wrote it to its xlogfile
+type GameOutcome struct {
+	Result GameResult
+	// Death is the game's own description, e.g. "killed by a jackal"
+	Death  string
+	Points int64
+	Turns  int64
+	// Character is the role, race, gender and alignment, e.g. "Val-Hum-Fem-Law"
+	Character string
+}
+
+// XlogEntry is one line of a NetHack-style xlogfile
+type XlogEntry map[string]string
+
+// ParseXlogEntry parses an xlogfile line of name=value fields. Fields are
+// separated by tabs, or by colons in files written by older games.
+func ParseXlogEntry(line string) XlogEntry {
+	line = strings.TrimRight(line, "\r\n")
+	separator := ":"
+	if strings.Contains(line, "\t") {
+		separator = "\t"
+	}
+
+	entry := make(XlogEntry)
+	for _, field := range strings.Split(line, separator) {
+		name, value, ok := strings.Cut(field, "=")
+		if ok && name != "" {
+			entry[name] = value
+		}
+	}
+	return entry
+}
+
+// Name returns the player name the entry was written for
+func (e XlogEntry) Name() string {
+	return e["name"]
+}
+
+// EndTime returns when the game ended, or the zero time if the entry does
+// not say
+func (e XlogEntry) EndTime() time.Time {
+	seconds, err := strconv.ParseInt(e["endtime"], 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
+
+// Outcome returns how the game of this entry ended
+func (e XlogEntry) Outcome() GameOutcome {
+	outcome := GameOutcome{
+		Result: xlogResult(e["death"]),
+		Death:  e["death"],
+	}
+	outcome.Points, _ = strconv.ParseInt(e["points"], 10, 64)
+	outcome.Turns, _ = strconv.ParseInt(e["turns"], 10, 64)
+
+	var character []string
+	for _, field := range []string{"role", "race", "gender", "align"} {
+		if value := e[field]; value != "" {
+			character = append(character, value)
+		}
+	}
+	outcome.Character = strings.Join(character, "-")
+	return outcome
+}
+
+// xlogResult classifies the death field of an xlog entry. Anything other
+// than a quit, escape or ascension is a death.
+func xlogResult(death string) GameResult {
+	switch {
+	case death == "ascended":
+		return GameResultAscended
+	case death == "quit":
+		return GameResultQuit
+	case strings.HasPrefix(death, "escaped"):
+		return GameResultEscaped
+	default:
+		return GameResultDied
+	}
+}
+
+// Outcome returns how the session's game ended, or nil if it is still
+// running or the game did not say
+func (s *GameSession) Outcome() *GameOutcome {
+	return s.outcome
+}
+
+// RecordOutcome records how the session's game ended
+func (s *GameSession) RecordOutcome(outcome GameOutcome) {
+	s.outcome = &outcome
+	s.touch(time.Now())
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseXlogEntry(t *testing.T) {
+	entry := ParseXlogEntry("version=3.6.7\tpoints=1234\tturns=2500\tname=player\trole=Val\trace=Hum\tgender=Fem\talign=Law\tdeath=killed by a jackal\tendtime=1791990000\n")
+	assert.Equal(t, "player", entry.Name())
+	assert.Equal(t, time.Unix(1791990000, 0), entry.EndTime())
+	assert.Equal(t, GameOutcome{
+		Result:    GameResultDied,
+		Death:     "killed by a jackal",
+		Points:    1234,
+		Turns:     2500,
+		Character: "Val-Hum-Fem-Law",
+	}, entry.Outcome())
+
+	// Older games separate fields with colons
+	entry = ParseXlogEntry("version=3.4.3:points=10:name=old:death=quit")
+	assert.Equal(t, "old", entry.Name())
+	assert.True(t, entry.EndTime().IsZero())
+	assert.Equal(t, GameResultQuit, entry.Outcome().Result)
+}
+
+func TestXlogResult(t *testing.T) {
+	assert.Equal(t, GameResultAscended, xlogResult("ascended"))
+	assert.Equal(t, GameResultQuit, xlogResult("quit"))
+	assert.Equal(t, GameResultEscaped, xlogResult("escaped"))
+	assert.Equal(t, GameResultEscaped, xlogResult("escaped (in celestial disgrace)"))
+	assert.Equal(t, GameResultDied, xlogResult("starved to death"))
+	assert.Equal(t, GameResultDied, xlogResult(""))
+}
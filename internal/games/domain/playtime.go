@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// PlayTimeLimit is how long a player may play in a day and in a week. Days
+// start at midnight and weeks on Monday, in the server's time zone. Zero
+// means no limit.
+type PlayTimeLimit struct {
+	Daily  time.Duration
+	Weekly time.Duration
+}
+
+// IsZero reports whether no limit applies
+func (l PlayTimeLimit) IsZero() bool {
+	return l.Daily <= 0 && l.Weekly <= 0
+}
+
+// Check returns how much longer a player with these sessions may play, or
+// an error naming the limit that is used up. remaining is zero when no limit
+// applies.
+func (l PlayTimeLimit) Check(sessions []*GameSession, now time.Time) (time.Duration, error) {
+	var remaining time.Duration
+	limits := []struct {
+		limit time.Duration
+		since time.Time
+		name  string
+		reset string
+	}{
+		{l.Daily, startOfDay(now), "daily", "at midnight"},
+		{l.Weekly, startOfWeek(now), "weekly", "on Monday"},
+	}
+	for _, budget := range limits {
+		if budget.limit <= 0 {
+			continue
+		}
+		left := budget.limit - PlayTimeSince(sessions, budget.since, now)
+		if left <= 0 {
+			return 0, apierror.New(apierror.PlayTimeExceeded, fmt.Sprintf(
+				"You have used your %s play time of %s; it resets %s", budget.name, formatBudget(budget.limit), budget.reset))
+		}
+		if remaining == 0 || left < remaining {
+			remaining = left
+		}
+	}
+	return remaining, nil
+}
+
+// PlayTimeSince adds up the time the sessions were played between since and
+// now. Games that crashed count for as long as they ran.
+func PlayTimeSince(sessions []*GameSession, since, now time.Time) time.Duration {
+	var total time.Duration
+	for _, session := range sessions {
+		start := session.StartTime()
+		if start.Before(since) {
+			start = since
+		}
+		end := now
+		if session.EndTime() != nil && session.EndTime().Before(now) {
+			end = *session.EndTime()
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// startOfDay returns the midnight that began t's day
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday midnight that began t's week
+func startOfWeek(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return startOfDay(t).AddDate(0, 0, -daysSinceMonday)
+}
+
+// formatBudget formats a limit for players, e.g. "2h", "1h30m" or "45m"
+func formatBudget(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d.Hours()), int(d.Minutes())%60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// sessionPlayed is a session played from start to end, or still running when end is zero
+func sessionPlayed(t *testing.T, start, end time.Time) *GameSession {
+	session := newTestSession()
+	require.NoError(t, session.Adopt(ProcessInfo{PID: 1234}, start))
+	if !end.IsZero() {
+		require.NoError(t, session.End(nil, nil))
+		session.endTime = &end
+	}
+	return session
+}
+
+func TestPlayTimeSince(t *testing.T) {
+	// Wednesday afternoon
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	sessions := []*GameSession{
+		// Across midnight: only the hour after it counts today
+		sessionPlayed(t, now.Add(-16*time.Hour), now.Add(-14*time.Hour)),
+		sessionPlayed(t, now.Add(-3*time.Hour), now.Add(-2*time.Hour)),
+		// Still running
+		sessionPlayed(t, now.Add(-30*time.Minute), time.Time{}),
+	}
+
+	assert.Equal(t, 2*time.Hour+30*time.Minute, PlayTimeSince(sessions, startOfDay(now), now))
+	assert.Equal(t, 3*time.Hour+30*time.Minute, PlayTimeSince(sessions, startOfWeek(now), now))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), startOfWeek(now))
+}
+
+func TestPlayTimeLimit_Check(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 0, 0, 0, time.UTC)
+	sessions := []*GameSession{sessionPlayed(t, now.Add(-90*time.Minute), now.Add(-30*time.Minute))}
+
+	remaining, err := PlayTimeLimit{}.Check(sessions, now)
+	require.NoError(t, err)
+	assert.Zero(t, remaining)
+
+	remaining, err = PlayTimeLimit{Daily: 2 * time.Hour, Weekly: 10 * time.Hour}.Check(sessions, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, remaining)
+
+	// The tighter limit wins
+	remaining, err = PlayTimeLimit{Daily: 2 * time.Hour, Weekly: 90 * time.Minute}.Check(sessions, now)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, remaining)
+
+	_, err = PlayTimeLimit{Daily: time.Hour}.Check(sessions, now)
+	assert.Equal(t, apierror.PlayTimeExceeded, apierror.CodeOf(err))
+	assert.Equal(t, "You have used your daily play time of 1h; it resets at midnight", apierror.MessageOf(err))
+}
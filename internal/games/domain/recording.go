@@ -0,0 +1,79 @@
+package domain
+
+import "strings"
+
+// RecordingPrivacy is a player's choice about recordings of their games
+type RecordingPrivacy string
+
+const (
+	// RecordingPublic records games for anyone to view; the default
+	RecordingPublic RecordingPrivacy = "public"
+	// RecordingPrivate records games for the player alone to view
+	RecordingPrivate RecordingPrivacy = "private"
+	// RecordingOff does not record the player's games
+	RecordingOff RecordingPrivacy = "off"
+)
+
+// ParseRecordingPrivacy parses a recording privacy setting, falling back to
+// RecordingPublic for empty or unknown values
+func ParseRecordingPrivacy(value string) RecordingPrivacy {
+	switch privacy := RecordingPrivacy(strings.ToLower(strings.TrimSpace(value))); privacy {
+	case RecordingPrivate, RecordingOff:
+		return privacy
+	default:
+		return RecordingPublic
+	}
+}
+
+// RecordingPolicy is a game's server-side rule for recording, which may
+// override what players choose
+type RecordingPolicy string
+
+const (
+	// RecordingPolicyPlayer follows each player's privacy setting; the default
+	RecordingPolicyPlayer RecordingPolicy = "player"
+	// RecordingPolicyAlways records every game publicly, such as for tournaments
+	RecordingPolicyAlways RecordingPolicy = "always"
+	// RecordingPolicyNever records no games
+	RecordingPolicyNever RecordingPolicy = "never"
+)
+
+// IsValidRecordingPolicy reports whether policy is a known policy; empty
+// means RecordingPolicyPlayer
+func IsValidRecordingPolicy(policy string) bool {
+	switch RecordingPolicy(policy) {
+	case "", RecordingPolicyPlayer, RecordingPolicyAlways, RecordingPolicyNever:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResolveRecording decides whether a game is recorded and whether the
+// recording is restricted to its player, from the game's policy and the
+// player's privacy setting
+func ResolveRecording(policy RecordingPolicy, privacy RecordingPrivacy) (record, private bool) {
+	switch policy {
+	case RecordingPolicyAlways:
+		return true, false
+	case RecordingPolicyNever:
+		return false, false
+	}
+	switch privacy {
+	case RecordingOff:
+		return false, false
+	case RecordingPrivate:
+		return true, true
+	default:
+		return true, false
+	}
+}
+
+// CanViewRecording reports whether viewerID may see the recording of a
+// session; private recordings are visible to their player alone
+func (s *GameSession) CanViewRecording(viewerID UserID) bool {
+	if s.recording == nil {
+		return false
+	}
+	return !s.recording.Private || s.userID == viewerID
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRecordingPrivacy(t *testing.T) {
+	assert.Equal(t, RecordingPrivate, ParseRecordingPrivacy("private"))
+	assert.Equal(t, RecordingOff, ParseRecordingPrivacy(" OFF "))
+	assert.Equal(t, RecordingPublic, ParseRecordingPrivacy(""))
+	assert.Equal(t, RecordingPublic, ParseRecordingPrivacy("secret"))
+}
+
+func TestResolveRecording(t *testing.T) {
+	tests := []struct {
+		policy      RecordingPolicy
+		privacy     RecordingPrivacy
+		wantRecord  bool
+		wantPrivate bool
+	}{
+		{RecordingPolicyPlayer, RecordingPublic, true, false},
+		{RecordingPolicyPlayer, RecordingPrivate, true, true},
+		{RecordingPolicyPlayer, RecordingOff, false, false},
+		{RecordingPolicyAlways, RecordingOff, true, false},
+		{RecordingPolicyAlways, RecordingPrivate, true, false},
+		{RecordingPolicyNever, RecordingPublic, false, false},
+	}
+	for _, tt := range tests {
+		record, private := ResolveRecording(tt.policy, tt.privacy)
+		assert.Equal(t, tt.wantRecord, record, "%s/%s", tt.policy, tt.privacy)
+		assert.Equal(t, tt.wantPrivate, private, "%s/%s", tt.policy, tt.privacy)
+	}
+}
+
+func TestGameSession_CanViewRecording(t *testing.T) {
+	session := newTestSession()
+	assert.False(t, session.CanViewRecording(NewUserID(1)))
+
+	session.EnableRecording("/tmp/session_test.ttyrec", "ttyrec", false)
+	assert.True(t, session.CanViewRecording(NewUserID(2)))
+
+	session.EnableRecording("/tmp/session_test.ttyrec", "ttyrec", true)
+	assert.True(t, session.CanViewRecording(NewUserID(1)))
+	assert.False(t, session.CanViewRecording(NewUserID(2)))
+	assert.False(t, session.CanViewRecording(NewUserID(0)))
+}
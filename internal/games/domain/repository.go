@@ -2,9 +2,47 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
+
+	"github.com/dungeongate/pkg/apierror"
 )
 
+// ErrNoTransaction is returned when committing a context that carries no
+// transaction of the unit of work
+var ErrNoTransaction = errors.New("no transaction in progress")
+
+// ErrConflict matches a ConflictError with errors.Is
+var ErrConflict = apierror.New(apierror.Conflict, "concurrent modification")
+
+// ConflictError is returned when saving an aggregate that another writer
+// changed since it was loaded. The caller should reload it and apply its
+// change again.
+type ConflictError struct {
+	// Aggregate names the kind of aggregate, such as "session" or "save"
+	Aggregate string
+	ID        string
+	// Expected is the version the writer loaded, Actual the version stored
+	Expected int64
+	Actual   int64
+}
+
+// Error implements error
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s %s was modified concurrently (expected version %d, found %d)", e.Aggregate, e.ID, e.Expected, e.Actual)
+}
+
+// Is reports whether target is ErrConflict
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+// ErrorCode implements apierror.Coder
+func (e *ConflictError) ErrorCode() apierror.Code {
+	return apierror.Conflict
+}
+
 // GameRepository defines the interface for game persistence
 type GameRepository interface {
 	// Game CRUD operations
@@ -81,6 +119,39 @@ type SaveRepository interface {
 	CleanupDeletedSaves(ctx context.Context, maxAge time.Duration) (int, error)
 }
 
+// DumplogRepository defines the interface for dumplog persistence
+type DumplogRepository interface {
+	Save(ctx context.Context, dumplog *Dumplog) error
+	FindByID(ctx context.Context, id DumplogID) (*Dumplog, error)
+	FindBySession(ctx context.Context, sessionID SessionID) (*Dumplog, error)
+
+	// FindRecentByUser returns the user's newest dumplogs first, optionally
+	// restricted to one game when gameID is non-nil
+	FindRecentByUser(ctx context.Context, userID UserID, gameID *GameID, limit int) ([]*Dumplog, error)
+}
+
+// ScreenshotRepository defines the interface for screenshot persistence
+type ScreenshotRepository interface {
+	Save(ctx context.Context, screenshot *Screenshot) error
+	FindByID(ctx context.Context, id ScreenshotID) (*Screenshot, error)
+	FindByShareToken(ctx context.Context, token string) (*Screenshot, error)
+
+	// FindBySession returns a session's screenshots, oldest first
+	FindBySession(ctx context.Context, sessionID SessionID) ([]*Screenshot, error)
+	// FindRecentByUser returns the screenshots a user took or that were
+	// taken of their games, newest first
+	FindRecentByUser(ctx context.Context, userID UserID, limit int) ([]*Screenshot, error)
+}
+
+// BookmarkRepository defines the interface for bookmark persistence
+type BookmarkRepository interface {
+	Save(ctx context.Context, bookmark *Bookmark) error
+
+	// FindBySession returns a session's bookmarks in the order they were
+	// dropped
+	FindBySession(ctx context.Context, sessionID SessionID) ([]*Bookmark, error)
+}
+
 // GameEvent represents a game-related event (defined here to avoid circular imports)
 type GameEvent struct {
 	ID        string                 `json:"id"`
@@ -104,6 +175,8 @@ const (
 	GameEventTypeGameLoad       GameEventType = "game.load"
 	GameEventTypeSpectatorJoin  GameEventType = "game.spectator.join"
 	GameEventTypeSpectatorLeave GameEventType = "game.spectator.leave"
+	GameEventTypeGameStatus     GameEventType = "game.status"
+	GameEventTypeSessionStatus  GameEventType = "game.session.status"
 )
 
 // EventRepository defines the interface for game event persistence
@@ -131,10 +204,14 @@ type EventFilters struct {
 	Offset    int
 }
 
-// UnitOfWork defines a unit of work pattern for transactional operations
+// UnitOfWork defines a unit of work pattern for transactional operations.
+// Begin returns a context carrying the transaction; repository calls made
+// with it join the transaction, which Commit or Rollback with the same
+// context ends. Begin with a context already in a transaction nests a new
+// one, whose rollback undoes only its own changes.
 type UnitOfWork interface {
 	// Transaction management
-	Begin(ctx context.Context) error
+	Begin(ctx context.Context) (context.Context, error)
 	Commit(ctx context.Context) error
 	Rollback(ctx context.Context) error
 
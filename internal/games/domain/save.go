@@ -2,8 +2,18 @@ package domain
 
 import (
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+var (
+	// ErrSaveNotFound is returned when a save does not exist or belongs to another user
+	ErrSaveNotFound = apierror.New(apierror.SaveNotFound, "save not found")
+	// ErrSaveCorrupt is returned when a save file fails its checksum and no backup verifies
+	ErrSaveCorrupt = apierror.New(apierror.SaveCorrupt, "save is corrupt and no intact backup exists")
 )
 
 // GameSave aggregate root represents a game save file
@@ -29,8 +39,13 @@ type GameSave struct {
 	backups []SaveBackup
 
 	// Audit
-	createdAt time.Time
-	updatedAt time.Time
+	createdAt  time.Time
+	updatedAt  time.Time
+	verifiedAt time.Time
+
+	// version is the stored version the save was loaded at, zero until
+	// first saved
+	version int64
 }
 
 // SaveID represents a unique save identifier
@@ -222,7 +237,7 @@ func (s *GameSave) Restore(backupID string, data []byte) error {
 	}
 
 	// Verify backup integrity
-	if calculateChecksum(data) != backup.Checksum {
+	if !checksumMatches(backup.Checksum, data) {
 		return fmt.Errorf("backup checksum mismatch")
 	}
 
@@ -232,13 +247,31 @@ func (s *GameSave) Restore(backupID string, data []byte) error {
 	s.fileSize = backup.FileSize
 	s.status = SaveStatusActive
 	s.updatedAt = time.Now()
+	s.verifiedAt = s.updatedAt
 
 	return nil
 }
 
 // Verify verifies the integrity of the save
 func (s *GameSave) Verify() bool {
-	return calculateChecksum(s.data) == s.checksum
+	return checksumMatches(s.checksum, s.data)
+}
+
+// CheckIntegrity compares the contents of the save file with the checksum
+// taken when the save was written, marking the save corrupt on a mismatch
+func (s *GameSave) CheckIntegrity(fileData []byte) bool {
+	s.verifiedAt = time.Now()
+	if checksumMatches(s.checksum, fileData) {
+		return true
+	}
+	s.MarkCorrupt()
+	return false
+}
+
+// VerifiedAt returns when the save file was last checked, or the zero time
+// if it has not been
+func (s *GameSave) VerifiedAt() time.Time {
+	return s.verifiedAt
 }
 
 // CreateBackup creates a manual backup
@@ -287,13 +320,44 @@ func (s *GameSave) CreatedAt() time.Time {
 	return s.createdAt
 }
 
+// Version returns the stored version the save was loaded at. Saving a save
+// whose stored version has moved on fails with a ConflictError.
+func (s *GameSave) Version() int64 {
+	return s.version
+}
+
+// SetVersion records the stored version of the save; repositories call it
+// when loading and saving
+func (s *GameSave) SetVersion(version int64) {
+	s.version = version
+}
+
 // UpdatedAt returns when the save was last updated
 func (s *GameSave) UpdatedAt() time.Time {
 	return s.updatedAt
 }
 
+// legacyChecksumLength is the length of checksums stored before full
+// SHA-256 digests were kept
+const legacyChecksumLength = 16
+
 // calculateChecksum calculates the SHA256 checksum of data
 func calculateChecksum(data []byte) string {
 	hash := sha256.Sum256(data)
-	return fmt.Sprintf("%x", hash)[:16] // Use first 16 characters for display
+	return hex.EncodeToString(hash[:])
+}
+
+// SaveChecksum returns the checksum a save records for its data
+func SaveChecksum(data []byte) string {
+	return calculateChecksum(data)
+}
+
+// checksumMatches reports whether data has the given checksum. Truncated
+// checksums from older saves are compared by prefix.
+func checksumMatches(checksum string, data []byte) bool {
+	actual := calculateChecksum(data)
+	if len(checksum) == legacyChecksumLength {
+		return actual[:legacyChecksumLength] == checksum
+	}
+	return actual == checksum
 }
@@ -179,6 +179,40 @@ func TestGameSave_Restore(t *testing.T) {
 	assert.Equal(t, SaveStatusActive, save.Status())
 }
 
+func TestGameSave_CheckIntegrity(t *testing.T) {
+	save := createTestSave()
+	assert.Len(t, save.Checksum(), 64)
+	assert.True(t, save.VerifiedAt().IsZero())
+
+	// Matching file contents
+	assert.True(t, save.CheckIntegrity([]byte("test save data")))
+	assert.True(t, save.IsActive())
+	assert.False(t, save.VerifiedAt().IsZero())
+
+	// Altered file contents
+	assert.False(t, save.CheckIntegrity([]byte("test save dat4")))
+	assert.Equal(t, SaveStatusCorrupt, save.Status())
+}
+
+func TestChecksumMatches_LegacyChecksum(t *testing.T) {
+	data := []byte("test save data")
+	legacy := calculateChecksum(data)[:legacyChecksumLength]
+
+	assert.True(t, checksumMatches(legacy, data))
+	assert.False(t, checksumMatches(legacy, []byte("other data")))
+}
+
+func TestGameSave_RestoreRejectsCorruptBackup(t *testing.T) {
+	save := createTestSave()
+	require.NoError(t, save.UpdateData([]byte("modified data"), SaveMetadata{}))
+	save.MarkCorrupt()
+
+	backup := save.Backups()[0]
+	err := save.Restore(backup.ID, []byte("damaged backup"))
+	assert.Error(t, err)
+	assert.Equal(t, SaveStatusCorrupt, save.Status())
+}
+
 // Helper function to create a test save
 func createTestSave() *GameSave {
 	saveID := NewSaveID(uuid.New().String())
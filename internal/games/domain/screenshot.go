@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+var (
+	// ErrScreenshotNotFound is returned for unknown screenshots and share
+	// links that expired
+	ErrScreenshotNotFound = apierror.New(apierror.NotFound, "screenshot not found")
+	// ErrScreenshotLimit is returned when a session has as many screenshots as
+	// it keeps
+	ErrScreenshotLimit = apierror.New(apierror.RateLimited, "session has as many screenshots as it keeps")
+)
+
+// ScreenshotFormat is a rendering of a screenshot
+type ScreenshotFormat string
+
+const (
+	// ScreenshotText is plain text, with line drawing in Unicode
+	ScreenshotText ScreenshotFormat = "text"
+	// ScreenshotANSI is text with the colours and attributes of the game
+	ScreenshotANSI ScreenshotFormat = "ansi"
+	// ScreenshotHTML is a pre element with the colours as inline styles
+	ScreenshotHTML ScreenshotFormat = "html"
+)
+
+// ParseScreenshotFormat parses a screenshot format, reporting false for
+// unknown formats
+func ParseScreenshotFormat(value string) (ScreenshotFormat, bool) {
+	switch format := ScreenshotFormat(strings.ToLower(strings.TrimSpace(value))); format {
+	case ScreenshotText, ScreenshotANSI, ScreenshotHTML:
+		return format, true
+	default:
+		return "", false
+	}
+}
+
+// ScreenshotID represents a unique screenshot identifier
+type ScreenshotID struct {
+	value string
+}
+
+// NewScreenshotID creates a new screenshot ID
+func NewScreenshotID(value string) ScreenshotID {
+	return ScreenshotID{value: value}
+}
+
+// String returns the string representation of the screenshot ID
+func (id ScreenshotID) String() string {
+	return id.value
+}
+
+// Screenshot is the screen of a game captured by its player or a spectator
+type Screenshot struct {
+	// Identity
+	id        ScreenshotID
+	sessionID SessionID
+	userID    UserID
+	gameID    GameID
+
+	// Who took it; a spectator unless it is the player
+	username    string
+	takenBy     UserID
+	takenByName string
+
+	// Content
+	cols, rows int
+	renderings map[ScreenshotFormat]string
+
+	// Public link, while shared
+	shareToken     string
+	shareExpiresAt time.Time
+
+	// Audit
+	takenAt time.Time
+}
+
+// NewScreenshot creates a screenshot of a session taken by takenBy, with the
+// screen rendered in each format
+func NewScreenshot(id ScreenshotID, session *GameSession, takenBy UserID, takenByName string, cols, rows int, renderings map[ScreenshotFormat]string) *Screenshot {
+	return &Screenshot{
+		id:          id,
+		sessionID:   session.ID(),
+		userID:      session.UserID(),
+		gameID:      session.GameID(),
+		username:    session.Username(),
+		takenBy:     takenBy,
+		takenByName: takenByName,
+		cols:        cols,
+		rows:        rows,
+		renderings:  renderings,
+		takenAt:     time.Now(),
+	}
+}
+
+// ID returns the screenshot's ID
+func (s *Screenshot) ID() ScreenshotID {
+	return s.id
+}
+
+// SessionID returns the session the screenshot was taken of
+func (s *Screenshot) SessionID() SessionID {
+	return s.sessionID
+}
+
+// UserID returns the player's user ID
+func (s *Screenshot) UserID() UserID {
+	return s.userID
+}
+
+// GameID returns the game ID
+func (s *Screenshot) GameID() GameID {
+	return s.gameID
+}
+
+// Username returns the player's username
+func (s *Screenshot) Username() string {
+	return s.username
+}
+
+// TakenBy returns who took the screenshot
+func (s *Screenshot) TakenBy() UserID {
+	return s.takenBy
+}
+
+// TakenByName returns the username of who took the screenshot
+func (s *Screenshot) TakenByName() string {
+	return s.takenByName
+}
+
+// BySpectator reports whether a spectator took the screenshot
+func (s *Screenshot) BySpectator() bool {
+	return s.takenBy != s.userID
+}
+
+// Size returns the screen size in columns and rows
+func (s *Screenshot) Size() (int, int) {
+	return s.cols, s.rows
+}
+
+// Content returns the screenshot in a format
+func (s *Screenshot) Content(format ScreenshotFormat) string {
+	return s.renderings[format]
+}
+
+// Bytes returns the stored size of every rendering
+func (s *Screenshot) Bytes() int64 {
+	var size int64
+	for _, rendering := range s.renderings {
+		size += int64(len(rendering))
+	}
+	return size
+}
+
+// TakenAt returns when the screenshot was taken
+func (s *Screenshot) TakenAt() time.Time {
+	return s.takenAt
+}
+
+// CanView reports whether a user may see the screenshot: the player and
+// whoever took it. Anyone may see it through its public link.
+func (s *Screenshot) CanView(viewerID UserID) bool {
+	return viewerID == s.userID || viewerID == s.takenBy
+}
+
+// Share gives the screenshot a public link until expiresAt, replacing any
+// link it had
+func (s *Screenshot) Share(token string, expiresAt time.Time) {
+	s.shareToken = token
+	s.shareExpiresAt = expiresAt
+}
+
+// ShareToken returns the token of the screenshot's public link, "" when it
+// was never shared
+func (s *Screenshot) ShareToken() string {
+	return s.shareToken
+}
+
+// ShareExpiresAt returns when the public link stops working
+func (s *Screenshot) ShareExpiresAt() time.Time {
+	return s.shareExpiresAt
+}
+
+// SharedAt reports whether the public link works at now
+func (s *Screenshot) SharedAt(now time.Time) bool {
+	return s.shareToken != "" && now.Before(s.shareExpiresAt)
+}
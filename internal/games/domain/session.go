@@ -2,7 +2,25 @@ package domain
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/termtype"
+)
+
+var (
+	// ErrSessionNotFound is returned when a session does not exist
+	ErrSessionNotFound = apierror.New(apierror.SessionNotFound, "session not found")
+	// ErrSessionNotActive is returned when an operation needs a running session
+	ErrSessionNotActive = apierror.New(apierror.SessionNotActive, "session is not active")
+	// ErrActiveSessionExists is returned when a user starts a game they are already playing
+	ErrActiveSessionExists = apierror.New(apierror.ActiveSessionExists, "user already has an active session for this game")
+	// ErrSpectatingNotAllowed is returned when a session does not accept spectators
+	ErrSpectatingNotAllowed = apierror.New(apierror.SpectatingNotAllowed, "session does not allow spectators")
+	// ErrSessionFull is returned when a session has as many spectators as its game allows
+	ErrSessionFull = apierror.New(apierror.SessionFull, "session has as many spectators as it allows")
 )
 
 // GameSession aggregate root represents an active game session
@@ -20,16 +38,43 @@ type GameSession struct {
 	processInfo ProcessInfo
 
 	// Session state
-	status       SessionStatus
-	startTime    time.Time
-	endTime      *time.Time
+	status    SessionStatus
+	startTime time.Time
+	endTime   *time.Time
+
+	// activityMu guards lastActivity and updatedAt, which the game's input
+	// goroutine bumps while others read them
+	activityMu   sync.RWMutex
 	lastActivity time.Time
 
 	// Terminal configuration
 	terminalSize TerminalSize
+	terminalType string
+	locale       string
 	encoding     string
 
+	// gameVersion is the installed version of the game being played, empty
+	// for the game's only version
+	gameVersion string
+
+	// clientIP is the player's address, kept for admins and audit logs
+	clientIP string
+
+	// playTimeEndsAt is when the player's play-time budget runs out, nil
+	// when they have none
+	playTimeEndsAt *time.Time
+
+	// outcome is how the game ended, from its xlogfile
+	outcome *GameOutcome
+
+	// statusLine is what the game's screen says of the game, such as
+	// "Dlvl:12 HP:45/60 XL:8", empty until its adapter reads one
+	statusLine string
+	// alerts are the rules the game's status last met, see RaiseAlerts
+	alerts []string
+
 	// Features
+	dumplogID  *DumplogID
 	recording  *RecordingInfo
 	streaming  *StreamingInfo
 	spectators []SpectatorInfo
@@ -37,6 +82,13 @@ type GameSession struct {
 	// Audit
 	createdAt time.Time
 	updatedAt time.Time
+
+	// version is the stored version the session was loaded at, zero until
+	// first saved
+	version int64
+
+	// Status changes not yet stored, see PullEvents
+	events []*GameEvent
 }
 
 // SessionID represents a unique session identifier
@@ -78,16 +130,23 @@ type ProcessInfo struct {
 	Signal      *string
 }
 
-// SessionStatus represents the current status of a session
+// SessionStatus represents the current status of a session; see
+// CanTransitionTo for the allowed changes
 type SessionStatus string
 
 const (
+	// SessionStatusStarting is a session whose game is being launched
 	SessionStatusStarting SessionStatus = "starting"
-	SessionStatusActive   SessionStatus = "active"
-	SessionStatusPaused   SessionStatus = "paused"
-	SessionStatusEnding   SessionStatus = "ending"
-	SessionStatusEnded    SessionStatus = "ended"
-	SessionStatusFailed   SessionStatus = "failed"
+	// SessionStatusActive is a session whose game is running
+	SessionStatusActive SessionStatus = "active"
+	// SessionStatusPaused is a running game set aside by its player
+	SessionStatusPaused SessionStatus = "paused"
+	// SessionStatusEnding is a game saving before it exits
+	SessionStatusEnding SessionStatus = "ending"
+	// SessionStatusEnded is a game that exited normally
+	SessionStatusEnded SessionStatus = "ended"
+	// SessionStatusFailed is a game that crashed or never started
+	SessionStatusFailed SessionStatus = "failed"
 )
 
 // TerminalSize represents terminal dimensions
@@ -109,6 +168,7 @@ type RecordingInfo struct {
 	StartTime  time.Time
 	FileSize   int64
 	Compressed bool
+	Private    bool // Only the player may view the recording
 }
 
 // StreamingInfo contains session streaming information
@@ -149,6 +209,7 @@ func NewGameSession(
 		startTime:    now,
 		lastActivity: now,
 		terminalSize: terminalSize,
+		terminalType: termtype.Default,
 		encoding:     "utf-8",
 		spectators:   make([]SpectatorInfo, 0),
 		createdAt:    now,
@@ -210,38 +271,93 @@ func (s *GameSession) CanSpectate() bool {
 }
 
 // Start activates the session
-func (s *GameSession) Start(processInfo ProcessInfo) {
-	s.status = SessionStatusActive
+func (s *GameSession) Start(processInfo ProcessInfo) error {
+	now := time.Now()
+	if err := s.transition(SessionStatusActive, now); err != nil {
+		return err
+	}
 	s.processInfo = processInfo
-	s.lastActivity = time.Now()
-	s.updatedAt = time.Now()
+	s.markActive(now)
+	return nil
 }
 
-// Pause pauses the session
-func (s *GameSession) Pause() error {
-	if s.status != SessionStatusActive {
-		return fmt.Errorf("cannot pause session in status: %s", s.status)
+// SetProcessInfo replaces the process of a running session, once its game
+// has been launched
+func (s *GameSession) SetProcessInfo(processInfo ProcessInfo) {
+	s.processInfo = processInfo
+	s.touch(time.Now())
+}
+
+// Adopt activates a session taken over from another game service instance,
+// keeping the time its game was originally started
+func (s *GameSession) Adopt(processInfo ProcessInfo, startTime time.Time) error {
+	if err := s.Start(processInfo); err != nil {
+		return err
+	}
+	s.startTime = startTime
+	return nil
+}
+
+// Restore sets the status of a session loaded from storage, which is not a
+// transition and records no event
+func (s *GameSession) Restore(status SessionStatus, endTime *time.Time) {
+	s.status = status
+	s.endTime = endTime
+}
+
+// TransferTo gives a finished session, with its outcome and recording, to
+// another user, as when their accounts are merged. A running session cannot
+// change hands.
+func (s *GameSession) TransferTo(userID UserID, username string) error {
+	if !s.status.IsFinal() {
+		return fmt.Errorf("session %s has not finished", s.id.String())
 	}
-	s.status = SessionStatusPaused
-	s.updatedAt = time.Now()
+	s.userID = userID
+	s.username = username
 	return nil
 }
 
+// Pause pauses the session
+func (s *GameSession) Pause() error {
+	return s.transition(SessionStatusPaused, time.Now())
+}
+
 // Resume resumes the session
 func (s *GameSession) Resume() error {
-	if s.status != SessionStatusPaused {
-		return fmt.Errorf("cannot resume session in status: %s", s.status)
+	now := time.Now()
+	if err := s.transition(SessionStatusActive, now); err != nil {
+		return err
 	}
-	s.status = SessionStatusActive
-	s.lastActivity = time.Now()
-	s.updatedAt = time.Now()
+	s.markActive(now)
 	return nil
 }
 
+// BeginEnding marks a running game as saving on its way out
+func (s *GameSession) BeginEnding() error {
+	return s.transition(SessionStatusEnding, time.Now())
+}
+
 // End ends the session
-func (s *GameSession) End(exitCode *int, signal *string) {
-	s.status = SessionStatusEnded
+func (s *GameSession) End(exitCode *int, signal *string) error {
+	return s.finish(SessionStatusEnded, exitCode, signal)
+}
+
+// Crash ends the session of a game that died, e.g. from a segfault
+func (s *GameSession) Crash(exitCode *int, signal *string) error {
+	return s.finish(SessionStatusFailed, exitCode, signal)
+}
+
+// Fail marks the session as failed
+func (s *GameSession) Fail(reason string) error {
+	return s.finish(SessionStatusFailed, nil, nil)
+}
+
+// finish moves the session to a final status with how its game exited
+func (s *GameSession) finish(status SessionStatus, exitCode *int, signal *string) error {
 	now := time.Now()
+	if err := s.transition(status, now); err != nil {
+		return err
+	}
 	s.endTime = &now
 	if exitCode != nil {
 		s.processInfo.ExitCode = exitCode
@@ -249,32 +365,43 @@ func (s *GameSession) End(exitCode *int, signal *string) {
 	if signal != nil {
 		s.processInfo.Signal = signal
 	}
-	s.updatedAt = now
+	return nil
 }
 
-// Fail marks the session as failed
-func (s *GameSession) Fail(reason string) {
-	s.status = SessionStatusFailed
+// UpdateActivity updates the last activity time
+func (s *GameSession) UpdateActivity() {
 	now := time.Now()
-	s.endTime = &now
+	s.activityMu.Lock()
+	s.lastActivity = now
 	s.updatedAt = now
+	s.activityMu.Unlock()
 }
 
-// UpdateActivity updates the last activity time
-func (s *GameSession) UpdateActivity() {
-	s.lastActivity = time.Now()
-	s.updatedAt = time.Now()
+// markActive records activity at the given time
+func (s *GameSession) markActive(at time.Time) {
+	s.activityMu.Lock()
+	s.lastActivity = at
+	s.activityMu.Unlock()
 }
 
-// EnableRecording enables session recording
-func (s *GameSession) EnableRecording(filePath, format string) {
+// touch records that the session was updated at the given time
+func (s *GameSession) touch(at time.Time) {
+	s.activityMu.Lock()
+	s.updatedAt = at
+	s.activityMu.Unlock()
+}
+
+// EnableRecording enables session recording; a private recording is only
+// visible to the player
+func (s *GameSession) EnableRecording(filePath, format string, private bool) {
 	s.recording = &RecordingInfo{
 		Enabled:   true,
 		FilePath:  filePath,
 		Format:    format,
 		StartTime: time.Now(),
+		Private:   private,
 	}
-	s.updatedAt = time.Now()
+	s.touch(time.Now())
 }
 
 // EnableStreaming enables session streaming
@@ -284,13 +411,17 @@ func (s *GameSession) EnableStreaming(protocol string, encrypted bool) {
 		Protocol:  protocol,
 		Encrypted: encrypted,
 	}
-	s.updatedAt = time.Now()
+	s.touch(time.Now())
 }
 
-// AddSpectator adds a spectator to the session
-func (s *GameSession) AddSpectator(userID UserID, username string) error {
+// AddSpectator adds a spectator to the session. With a limit above zero,
+// a session with that many active spectators is full.
+func (s *GameSession) AddSpectator(userID UserID, username string, limit int) error {
 	if !s.CanSpectate() {
-		return fmt.Errorf("session does not allow spectators")
+		return ErrSpectatingNotAllowed
+	}
+	if limit > 0 && s.SpectatorCount() >= limit {
+		return ErrSessionFull
 	}
 
 	// Check if spectator already exists
@@ -307,7 +438,7 @@ func (s *GameSession) AddSpectator(userID UserID, username string) error {
 		IsActive: true,
 	}
 	s.spectators = append(s.spectators, spectator)
-	s.updatedAt = time.Now()
+	s.touch(time.Now())
 	return nil
 }
 
@@ -319,7 +450,7 @@ func (s *GameSession) RemoveSpectator(userID UserID) {
 			break
 		}
 	}
-	s.updatedAt = time.Now()
+	s.touch(time.Now())
 }
 
 // Spectators returns the list of spectators
@@ -327,6 +458,16 @@ func (s *GameSession) Spectators() []SpectatorInfo {
 	return s.spectators
 }
 
+// IsSpectator reports whether a user is actively watching the session
+func (s *GameSession) IsSpectator(userID UserID) bool {
+	for _, spectator := range s.spectators {
+		if spectator.UserID == userID && spectator.IsActive {
+			return true
+		}
+	}
+	return false
+}
+
 // SpectatorCount returns the number of active spectators
 func (s *GameSession) SpectatorCount() int {
 	count := 0
@@ -346,7 +487,105 @@ func (s *GameSession) TerminalSize() TerminalSize {
 // UpdateTerminalSize updates the terminal size
 func (s *GameSession) UpdateTerminalSize(size TerminalSize) {
 	s.terminalSize = size
-	s.updatedAt = time.Now()
+	s.touch(time.Now())
+}
+
+// TerminalType returns the TERM value negotiated for the session
+func (s *GameSession) TerminalType() string {
+	return s.terminalType
+}
+
+// SetTerminalType sets the TERM value for the session, keeping the
+// default when an empty value is given
+func (s *GameSession) SetTerminalType(termType string) {
+	if termType == "" {
+		termType = termtype.Default
+	}
+	s.terminalType = termType
+	s.touch(time.Now())
+}
+
+// Locale returns the locale the game runs with, empty when none was chosen
+func (s *GameSession) Locale() string {
+	return s.locale
+}
+
+// SetLocale sets the session's locale and derives the output encoding from it
+func (s *GameSession) SetLocale(locale string) {
+	s.locale = locale
+	s.encoding = EncodingForLocale(locale)
+	s.touch(time.Now())
+}
+
+// GameVersion returns the installed version of the game being played
+func (s *GameSession) GameVersion() string {
+	return s.gameVersion
+}
+
+// SetGameVersion records which installed version of the game is played
+func (s *GameSession) SetGameVersion(version string) {
+	s.gameVersion = version
+	s.touch(time.Now())
+}
+
+// ClientIP returns the address the player connected from, empty if unknown
+func (s *GameSession) ClientIP() string {
+	return s.clientIP
+}
+
+// SetClientIP records the address the player connected from
+func (s *GameSession) SetClientIP(ip string) {
+	s.clientIP = ip
+	s.touch(time.Now())
+}
+
+// PlayTimeEndsAt returns when the player's play-time budget runs out, nil
+// when they have none
+func (s *GameSession) PlayTimeEndsAt() *time.Time {
+	return s.playTimeEndsAt
+}
+
+// SetPlayTimeEndsAt records when the player's play-time budget runs out.
+// The game is not stopped then; the player is warned.
+func (s *GameSession) SetPlayTimeEndsAt(at time.Time) {
+	s.playTimeEndsAt = &at
+	s.touch(time.Now())
+}
+
+// EncodingForLocale returns the character encoding implied by a locale name.
+// Locales without an explicit codeset are treated as UTF-8, except C/POSIX.
+func EncodingForLocale(locale string) string {
+	name := locale
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		codeset := strings.ToLower(strings.ReplaceAll(name[i+1:], "-", ""))
+		switch codeset {
+		case "utf8":
+			return "utf-8"
+		case "iso88591", "latin1":
+			return "iso-8859-1"
+		default:
+			return codeset
+		}
+	}
+	switch name {
+	case "C", "POSIX":
+		return "ascii"
+	}
+	return "utf-8"
+}
+
+// DumplogID returns the ID of the dumplog captured when the session ended, if any
+func (s *GameSession) DumplogID() *DumplogID {
+	return s.dumplogID
+}
+
+// AttachDumplog records the dumplog captured for this session
+func (s *GameSession) AttachDumplog(id DumplogID) {
+	s.dumplogID = &id
+	s.touch(time.Now())
 }
 
 // ProcessInfo returns the process information
@@ -369,16 +608,48 @@ func (s *GameSession) CreatedAt() time.Time {
 	return s.createdAt
 }
 
+// Version returns the stored version the session was loaded at. Saving a
+// session whose stored version has moved on fails with a ConflictError.
+func (s *GameSession) Version() int64 {
+	return s.version
+}
+
+// SetVersion records the stored version of the session; repositories call
+// it when loading and saving
+func (s *GameSession) SetVersion(version int64) {
+	s.version = version
+}
+
 // UpdatedAt returns when the session was last updated
 func (s *GameSession) UpdatedAt() time.Time {
+	s.activityMu.RLock()
+	defer s.activityMu.RUnlock()
 	return s.updatedAt
 }
 
 // LastActivity returns when the session was last active
 func (s *GameSession) LastActivity() time.Time {
+	s.activityMu.RLock()
+	defer s.activityMu.RUnlock()
 	return s.lastActivity
 }
 
+// StatusLine returns what the game's screen last said of the game, empty
+// when its adapter has not read one
+func (s *GameSession) StatusLine() string {
+	return s.statusLine
+}
+
+// SetStatusLine records the game's status line, reporting whether it changed
+func (s *GameSession) SetStatusLine(line string) bool {
+	if line == s.statusLine {
+		return false
+	}
+	s.statusLine = line
+	s.touch(time.Now())
+	return true
+}
+
 // Encoding returns the session's encoding
 func (s *GameSession) Encoding() string {
 	return s.encoding
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestSession() *GameSession {
+	return NewGameSession(
+		NewSessionID("session_test"),
+		NewUserID(1),
+		"player",
+		NewGameID("nethack"),
+		GameConfig{},
+		TerminalSize{Width: 80, Height: 24},
+	)
+}
+
+func TestGameSession_AddSpectator_StreamingEnabled(t *testing.T) {
+	session := newTestSession()
+	session.EnableStreaming("grpc", false)
+	session.Start(ProcessInfo{PID: 1234})
+
+	require.True(t, session.CanSpectate())
+	require.NoError(t, session.AddSpectator(NewUserID(2), "watcher", 0))
+	assert.Equal(t, 1, session.SpectatorCount())
+
+	// Joining twice is rejected
+	assert.Error(t, session.AddSpectator(NewUserID(2), "watcher", 0))
+}
+
+func TestGameSession_AddSpectator_Limit(t *testing.T) {
+	session := newTestSession()
+	session.EnableStreaming("grpc", false)
+	session.Start(ProcessInfo{PID: 1234})
+
+	require.NoError(t, session.AddSpectator(NewUserID(2), "first", 2))
+	require.NoError(t, session.AddSpectator(NewUserID(3), "second", 2))
+	err := session.AddSpectator(NewUserID(4), "third", 2)
+	assert.ErrorIs(t, err, ErrSessionFull)
+	assert.Equal(t, 2, session.SpectatorCount())
+
+	// A place frees up when a spectator leaves
+	session.RemoveSpectator(NewUserID(2))
+	assert.NoError(t, session.AddSpectator(NewUserID(4), "third", 2))
+}
+
+func TestGameSession_AddSpectator_SpectatingDisabled(t *testing.T) {
+	session := newTestSession()
+	session.Start(ProcessInfo{PID: 1234})
+
+	// Streaming is never enabled when the player opted out of spectators
+	assert.False(t, session.CanSpectate())
+	err := session.AddSpectator(NewUserID(2), "watcher", 0)
+	assert.ErrorIs(t, err, ErrSpectatingNotAllowed)
+	assert.Equal(t, 0, session.SpectatorCount())
+}
+
+func TestGameSession_SetLocale(t *testing.T) {
+	session := newTestSession()
+	assert.Equal(t, "", session.Locale())
+	assert.Equal(t, "utf-8", session.Encoding())
+
+	session.SetLocale("de_DE.ISO-8859-1")
+	assert.Equal(t, "iso-8859-1", session.Encoding())
+
+	session.SetLocale("C")
+	assert.Equal(t, "ascii", session.Encoding())
+
+	session.SetLocale("en_US.utf8")
+	assert.Equal(t, "utf-8", session.Encoding())
+}
+
+func TestGameSession_UpdateActivityConcurrent(t *testing.T) {
+	session := newTestSession()
+	before := session.LastActivity()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			session.UpdateActivity()
+		}()
+		go func() {
+			defer wg.Done()
+			_ = session.LastActivity()
+			_ = session.UpdatedAt()
+		}()
+	}
+	wg.Wait()
+
+	assert.False(t, session.LastActivity().Before(before))
+	assert.Equal(t, session.LastActivity(), session.UpdatedAt())
+}
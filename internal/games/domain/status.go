@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// ErrInvalidTransition is returned when a game or session cannot move from
+// its current status to the one requested
+var ErrInvalidTransition = apierror.New(apierror.Conflict, "invalid status transition")
+
+// gameTransitions lists the statuses a game may move to from each status.
+// Deprecated games can still be disabled or brought back.
+var gameTransitions = map[GameStatus][]GameStatus{
+	GameStatusEnabled:     {GameStatusDisabled, GameStatusMaintenance, GameStatusDeprecated},
+	GameStatusDisabled:    {GameStatusEnabled, GameStatusMaintenance, GameStatusDeprecated},
+	GameStatusMaintenance: {GameStatusEnabled, GameStatusDisabled, GameStatusDeprecated},
+	GameStatusDeprecated:  {GameStatusEnabled, GameStatusDisabled},
+}
+
+// sessionTransitions lists the statuses a session may move to from each
+// status. Ended and failed sessions are final.
+var sessionTransitions = map[SessionStatus][]SessionStatus{
+	SessionStatusStarting: {SessionStatusActive, SessionStatusEnded, SessionStatusFailed},
+	SessionStatusActive:   {SessionStatusPaused, SessionStatusEnding, SessionStatusEnded, SessionStatusFailed},
+	SessionStatusPaused:   {SessionStatusActive, SessionStatusEnding, SessionStatusEnded, SessionStatusFailed},
+	SessionStatusEnding:   {SessionStatusEnded, SessionStatusFailed},
+}
+
+// CanTransitionTo reports whether a game may move from s to status
+func (s GameStatus) CanTransitionTo(status GameStatus) bool {
+	return allowed(gameTransitions[s], status)
+}
+
+// CanTransitionTo reports whether a session may move from s to status
+func (s SessionStatus) CanTransitionTo(status SessionStatus) bool {
+	return allowed(sessionTransitions[s], status)
+}
+
+// IsFinal reports whether a session in this status is over
+func (s SessionStatus) IsFinal() bool {
+	return s == SessionStatusEnded || s == SessionStatusFailed
+}
+
+func allowed[T comparable](next []T, status T) bool {
+	for _, candidate := range next {
+		if candidate == status {
+			return true
+		}
+	}
+	return false
+}
+
+// statusEvent records a status change for the event log
+func statusEvent(eventType GameEventType, gameID, sessionID string, userID int, from, to string, at time.Time) *GameEvent {
+	return &GameEvent{
+		Type:      eventType,
+		GameID:    gameID,
+		SessionID: sessionID,
+		UserID:    userID,
+		Data: map[string]interface{}{
+			"from": from,
+			"to":   to,
+		},
+		Timestamp: at,
+	}
+}
+
+// transition moves the game to status, recording the change and dropping
+// the message of the old status. Moving to the current status does nothing.
+func (g *Game) transition(status GameStatus) error {
+	if g.status == status {
+		return nil
+	}
+	if !g.status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: game %s from %s to %s", ErrInvalidTransition, g.id, g.status, status)
+	}
+
+	now := time.Now()
+	g.events = append(g.events, statusEvent(GameEventTypeGameStatus, g.id.String(), "", 0, string(g.status), string(status), now))
+	g.status = status
+	g.statusMessage = ""
+	g.updatedAt = now
+	return nil
+}
+
+// PullEvents returns the status changes recorded since the last call, for
+// the caller to store
+func (g *Game) PullEvents() []*GameEvent {
+	events := g.events
+	g.events = nil
+	return events
+}
+
+// transition moves the session to status, recording the change
+func (s *GameSession) transition(status SessionStatus, at time.Time) error {
+	if !s.status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: session %s from %s to %s", ErrInvalidTransition, s.id, s.status, status)
+	}
+
+	s.events = append(s.events, statusEvent(GameEventTypeSessionStatus, s.gameID.String(), s.id.String(), s.userID.Int(), string(s.status), string(status), at))
+	s.status = status
+	s.touch(at)
+	return nil
+}
+
+// PullEvents returns the status changes recorded since the last call, for
+// the caller to store
+func (s *GameSession) PullEvents() []*GameEvent {
+	events := s.events
+	s.events = nil
+	return events
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+func TestGameSession_StatusLifecycle(t *testing.T) {
+	session := newTestSession()
+	require.NoError(t, session.Start(ProcessInfo{PID: 1234}))
+	require.NoError(t, session.Pause())
+	require.NoError(t, session.Resume())
+	require.NoError(t, session.BeginEnding())
+	require.NoError(t, session.End(nil, nil))
+	assert.Equal(t, SessionStatusEnded, session.Status())
+	assert.True(t, session.Status().IsFinal())
+	assert.NotNil(t, session.EndTime())
+
+	var trail []interface{}
+	for _, event := range session.PullEvents() {
+		assert.Equal(t, GameEventTypeSessionStatus, event.Type)
+		assert.Equal(t, "session_test", event.SessionID)
+		trail = append(trail, event.Data["to"])
+	}
+	assert.Equal(t, []interface{}{"active", "paused", "active", "ending", "ended"}, trail)
+	assert.Empty(t, session.PullEvents())
+}
+
+func TestGameSession_InvalidTransitions(t *testing.T) {
+	session := newTestSession()
+
+	// A session that never started cannot pause or begin saving
+	assert.ErrorIs(t, session.Pause(), ErrInvalidTransition)
+	assert.ErrorIs(t, session.BeginEnding(), ErrInvalidTransition)
+
+	require.NoError(t, session.Start(ProcessInfo{PID: 1234}))
+	signal := "SIGSEGV"
+	require.NoError(t, session.Crash(nil, &signal))
+	assert.Equal(t, SessionStatusFailed, session.Status())
+
+	// Final statuses stay final
+	assert.ErrorIs(t, session.End(nil, nil), ErrInvalidTransition)
+	assert.ErrorIs(t, session.Start(ProcessInfo{PID: 1235}), ErrInvalidTransition)
+	assert.ErrorIs(t, session.Fail("again"), ErrInvalidTransition)
+	assert.Equal(t, SessionStatusFailed, session.Status())
+}
+
+func TestGameSession_Restore(t *testing.T) {
+	session := newTestSession()
+	session.Restore(SessionStatusEnded, nil)
+	assert.Equal(t, SessionStatusEnded, session.Status())
+	assert.Empty(t, session.PullEvents())
+}
+
+func TestGame_StatusTransitions(t *testing.T) {
+	game := NewGame(NewGameID("nethack"), GameMetadata{Name: "NetHack"}, GameConfig{})
+	require.Equal(t, GameStatusEnabled, game.Status())
+
+	require.NoError(t, game.SetMaintenance())
+	require.NoError(t, game.Enable())
+	require.NoError(t, game.Deprecate())
+	assert.ErrorIs(t, game.SetMaintenance(), ErrInvalidTransition)
+	assert.Equal(t, GameStatusDeprecated, game.Status())
+
+	// Setting the current status again records nothing
+	require.NoError(t, game.SetStatus(GameStatusDeprecated, ""))
+	assert.Error(t, game.SetStatus(GameStatus("retired"), ""))
+
+	events := game.PullEvents()
+	require.Len(t, events, 3)
+	assert.Equal(t, GameEventTypeGameStatus, events[0].Type)
+	assert.Equal(t, "nethack", events[0].GameID)
+	assert.Equal(t, "enabled", events[0].Data["from"])
+	assert.Equal(t, "maintenance", events[0].Data["to"])
+	assert.Equal(t, "deprecated", events[2].Data["to"])
+}
+
+func TestGame_UnavailableError(t *testing.T) {
+	game := NewGame(NewGameID("nethack"), GameMetadata{Name: "NetHack"}, GameConfig{})
+	assert.NoError(t, game.UnavailableError())
+
+	require.NoError(t, game.SetStatus(GameStatusMaintenance, "back at 18:00 UTC"))
+	assert.Equal(t, "back at 18:00 UTC", game.StatusMessage())
+	err := game.UnavailableError()
+	assert.ErrorIs(t, err, ErrGameUnavailable)
+	assert.Equal(t, "NetHack is down for maintenance: back at 18:00 UTC", apierror.MessageOf(err))
+
+	// The message goes with the status it was given for
+	require.NoError(t, game.Disable())
+	assert.Empty(t, game.StatusMessage())
+	assert.Equal(t, "NetHack is disabled", apierror.MessageOf(game.UnavailableError()))
+
+	require.NoError(t, game.SetStatus(GameStatusEnabled, "ignored"))
+	assert.Empty(t, game.StatusMessage())
+}
@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/exec"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/dungeongate/pkg/config"
@@ -159,10 +158,9 @@ func (s *Service) startGameProcess(session *GameSession, game *Game) (*exec.Cmd,
 	// Create game process command
 	cmd := exec.Command(game.Binary.Path, game.Binary.Args...)
 
-	// Set up process attributes for isolation
-	cmd.SysProcAttr = &syscall.SysProcAttr{
-		Setsid: true, // Create new session
-	}
+	// Set up process attributes for isolation, dropping privileges to the
+	// game user when chrooted
+	cmd.SysProcAttr = gameProcAttr(s.config.GameEngine.Chroot != nil)
 
 	// Set working directory
 	if game.Binary.WorkingDirectory != "" {
@@ -177,15 +175,6 @@ func (s *Service) startGameProcess(session *GameSession, game *Game) (*exec.Cmd,
 		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", key, expandedValue))
 	}
 
-	// Set up user and group
-	if s.config.GameEngine.Chroot != nil {
-		// Drop privileges - use configured user/group
-		cmd.SysProcAttr.Credential = &syscall.Credential{
-			Uid: 1000, // game user
-			Gid: 1000, // game group
-		}
-	}
-
 	// Start the process
 	if err := cmd.Start(); err != nil {
 		return nil, fmt.Errorf("failed to start game process: %w", err)
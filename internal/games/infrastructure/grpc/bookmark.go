@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// SetBookmarkService lets players and spectators bookmark moments of
+// recorded games
+func (s *GameServiceServer) SetBookmarkService(bookmarks *application.BookmarkService) {
+	s.bookmarks = bookmarks
+}
+
+// AddBookmark bookmarks the present moment of a live game. The player and
+// the session's spectators may drop bookmarks.
+func (s *GameServiceServer) AddBookmark(ctx context.Context, req *games_pb.AddBookmarkRequest) (*games_pb.AddBookmarkResponse, error) {
+	if s.bookmarks == nil {
+		return nil, status.Error(codes.Unavailable, "bookmarks are not enabled")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, apierror.Status(domain.ErrSessionNotFound, "session not found").Err()
+	}
+	if !session.IsActive() {
+		return nil, status.Error(codes.FailedPrecondition, "bookmarks are dropped while the game is running")
+	}
+	createdBy := domain.NewUserID(int(req.UserId))
+	if session.UserID() != createdBy && !session.IsSpectator(createdBy) {
+		return nil, status.Error(codes.PermissionDenied, "only the player and spectators may bookmark a game")
+	}
+
+	bookmark, err := s.bookmarks.AddBookmark(ctx, session, application.AddBookmarkRequest{
+		CreatedBy:     int(req.UserId),
+		CreatedByName: req.Username,
+		Note:          req.Note,
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to add bookmark", "session_id", req.SessionId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Status(err, "failed to add bookmark").Err()
+	}
+
+	s.logger.InfoContext(ctx, "Bookmark added",
+		"bookmark_id", bookmark.ID().String(),
+		"session_id", req.SessionId,
+		"created_by", req.Username,
+		"offset", bookmark.Offset(),
+		"spectator", bookmark.BySpectator())
+	return &games_pb.AddBookmarkResponse{Bookmark: domainBookmarkToPb(bookmark)}, nil
+}
+
+// ListBookmarks lists a session's bookmarks in recording order. The
+// bookmarks of a private recording are listed to its player alone.
+func (s *GameServiceServer) ListBookmarks(ctx context.Context, req *games_pb.ListBookmarksRequest) (*games_pb.ListBookmarksResponse, error) {
+	if s.bookmarks == nil {
+		return nil, status.Error(codes.Unavailable, "bookmarks are not enabled")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, apierror.Status(domain.ErrSessionNotFound, "session not found").Err()
+	}
+	// Others are not told a private recording has bookmarks
+	if session.RecordingInfo() != nil && !session.CanViewRecording(domain.NewUserID(int(req.ViewerId))) {
+		return &games_pb.ListBookmarksResponse{}, nil
+	}
+
+	bookmarks, err := s.bookmarks.ListBookmarks(ctx, req.SessionId)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list bookmarks", "session_id", req.SessionId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list bookmarks")
+	}
+
+	pbBookmarks := make([]*games_pb.Bookmark, len(bookmarks))
+	for i, bookmark := range bookmarks {
+		pbBookmarks[i] = domainBookmarkToPb(bookmark)
+	}
+	return &games_pb.ListBookmarksResponse{Bookmarks: pbBookmarks}, nil
+}
+
+// domainBookmarkToPb converts a domain Bookmark to protobuf
+func domainBookmarkToPb(bookmark *domain.Bookmark) *games_pb.Bookmark {
+	return &games_pb.Bookmark{
+		Id:                bookmark.ID().String(),
+		SessionId:         bookmark.SessionID().String(),
+		UserId:            int32(bookmark.UserID().Int()),
+		CreatedByUserId:   int32(bookmark.CreatedBy().Int()),
+		CreatedByUsername: bookmark.CreatedByName(),
+		OffsetMs:          bookmark.Offset().Milliseconds(),
+		Note:              bookmark.Note(),
+		CreatedAt:         timestamppb.New(bookmark.CreatedAt()),
+	}
+}
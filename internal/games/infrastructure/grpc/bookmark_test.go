@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+)
+
+func TestBookmarks(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	ctx := context.Background()
+	session := createMockSessionWithID("session_a", 1, "nethack")
+	session.EnableStreaming("grpc", false)
+	session.EnableRecording("/tmp/session_a.ttyrec", "ttyrec", true)
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	_, err := server.AddBookmark(ctx, &games_pb.AddBookmarkRequest{SessionId: "session_a", UserId: 1})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	server.SetBookmarkService(application.NewBookmarkService(repository.NewStubBookmarkRepository()))
+
+	// Only the player and spectators drop bookmarks
+	_, err = server.AddBookmark(ctx, &games_pb.AddBookmarkRequest{SessionId: "session_a", UserId: 2, Username: "watcher"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 2, SpectatorUsername: "watcher"})
+	require.NoError(t, err)
+	added, err := server.AddBookmark(ctx, &games_pb.AddBookmarkRequest{SessionId: "session_a", UserId: 2, Username: "watcher", Note: "that was amazing"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), added.Bookmark.UserId)
+	assert.Equal(t, "watcher", added.Bookmark.CreatedByUsername)
+	assert.GreaterOrEqual(t, added.Bookmark.OffsetMs, int64(0))
+
+	_, err = server.AddBookmark(ctx, &games_pb.AddBookmarkRequest{SessionId: "session_missing", UserId: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The recording is private, so only its player sees the bookmarks
+	list, err := server.ListBookmarks(ctx, &games_pb.ListBookmarksRequest{SessionId: "session_a", ViewerId: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Bookmarks, 1)
+	assert.Equal(t, "that was amazing", list.Bookmarks[0].Note)
+	list, err = server.ListBookmarks(ctx, &games_pb.ListBookmarksRequest{SessionId: "session_a", ViewerId: 2})
+	require.NoError(t, err)
+	assert.Empty(t, list.Bookmarks)
+}
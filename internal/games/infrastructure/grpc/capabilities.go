@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+// SetBuild sets the build reported to session services by GetCapabilities,
+// Version and Health
+func (s *GameServiceServer) SetBuild(build buildinfo.Info) {
+	s.build = build
+}
+
+// Version returns the build of this game service
+func (s *GameServiceServer) Version(ctx context.Context, req *emptypb.Empty) (*games_pb.VersionResponse, error) {
+	return &games_pb.VersionResponse{
+		Service:   s.build.Service,
+		Version:   s.build.Version,
+		BuildTime: s.build.BuildTime,
+		GitCommit: s.build.GitCommit,
+		GoVersion: s.build.GoVersion,
+	}, nil
+}
+
+// GetCapabilities negotiates the games API version with a session service
+// and reports the optional features this game service offers
+func (s *GameServiceServer) GetCapabilities(ctx context.Context, req *games_pb.GetCapabilitiesRequest) (*games_pb.GetCapabilitiesResponse, error) {
+	version, err := capabilities.Negotiate(int(req.ApiVersion), capabilities.MinAPIVersion, capabilities.APIVersion)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	s.logger.InfoContext(ctx, "Negotiated games API version", "api_version", version, "requested", req.ApiVersion, "client_version", req.ClientVersion)
+
+	return &games_pb.GetCapabilitiesResponse{
+		ApiVersion:    int32(version),
+		MinApiVersion: capabilities.MinAPIVersion,
+		MaxApiVersion: capabilities.APIVersion,
+		Features:      s.features().List(),
+		ServerVersion: s.build.Version,
+	}, nil
+}
+
+// features returns the optional features this game service offers. Players
+// can always resume a game left running and spectators always share the
+// player's stream; games are recorded unless no game's policy allows it, and
+// screenshots are taken when they are enabled. Bookmarks need recordings,
+// and status lines are read when a game has a status_line rule.
+func (s *GameServiceServer) features() capabilities.Set {
+	features := capabilities.NewSet(capabilities.Resume, capabilities.Multiplexing)
+	if s.screenshots != nil {
+		features[capabilities.Screenshots] = true
+	}
+	for _, gameConfig := range s.games() {
+		if recordingPolicy(gameConfig) != domain.RecordingPolicyNever {
+			features[capabilities.Recording] = true
+			break
+		}
+	}
+	if s.bookmarks != nil && features[capabilities.Recording] {
+		features[capabilities.Bookmarks] = true
+	}
+	for _, gameConfig := range s.games() {
+		if gameConfig.Settings != nil && gameConfig.Settings.StatusLine != nil && gameConfig.Settings.StatusLine.Enabled {
+			features[capabilities.StatusLine] = true
+			break
+		}
+	}
+	if s.ptyManager != nil && s.ptyManager.CgroupsEnabled() {
+		features[capabilities.ResourceUsage] = true
+	}
+	return features
+}
@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/capabilities"
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestGetCapabilities(t *testing.T) {
+	noRecording := &config.GameSettings{Recording: &config.RecordingConfig{Enabled: false}}
+	server := &GameServiceServer{
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		gameConfigs: []*config.GameConfig{{ID: "nethack", Settings: noRecording}},
+	}
+	server.SetBuild(buildinfo.New("game-service", "1.2.3", "2026-01-02T03:04:05Z", "abc1234"))
+
+	// A newer caller is served the newest version the game service speaks
+	resp, err := server.GetCapabilities(context.Background(), &games_pb.GetCapabilitiesRequest{ApiVersion: capabilities.APIVersion + 1})
+	require.NoError(t, err)
+	assert.Equal(t, int32(capabilities.APIVersion), resp.ApiVersion)
+	assert.Equal(t, int32(capabilities.MinAPIVersion), resp.MinApiVersion)
+	assert.Equal(t, []string{capabilities.Multiplexing, capabilities.Resume}, resp.Features)
+	assert.Equal(t, "1.2.3", resp.ServerVersion)
+
+	version, err := server.Version(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "game-service", version.Service)
+	assert.Equal(t, "abc1234", version.GitCommit)
+	health, err := server.Health(context.Background(), &emptypb.Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", health.Details["version"])
+
+	// Recording is offered once any game may be recorded
+	server.gameConfigs = append(server.gameConfigs, &config.GameConfig{ID: "crawl"})
+	resp, err = server.GetCapabilities(context.Background(), &games_pb.GetCapabilitiesRequest{ApiVersion: capabilities.APIVersion2})
+	require.NoError(t, err)
+	assert.Equal(t, int32(capabilities.APIVersion2), resp.ApiVersion)
+	assert.Contains(t, resp.Features, capabilities.Recording)
+
+	_, err = server.GetCapabilities(context.Background(), &games_pb.GetCapabilitiesRequest{ApiVersion: 1})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
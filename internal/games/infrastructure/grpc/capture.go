@@ -0,0 +1,259 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/iocapture"
+)
+
+// defaultCaptureMaxDuration is how long a capture may run when
+// io_capture.max_duration is not set
+const defaultCaptureMaxDuration = 30 * time.Minute
+
+// captureSessionID matches the session IDs capture files may be named after
+var captureSessionID = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// IOCaptures are the running captures of session I/O. An admin starts one
+// for a session for a few minutes; until it ends, every keystroke the player
+// sends and every output chunk they are sent is written, with its time, to
+// an encrypted file (see iocapture). Captures hold everything the player
+// types, so each start and end is audit logged.
+type IOCaptures struct {
+	dir         string
+	key         []byte
+	maxDuration time.Duration
+	exists      func(sessionID string) bool
+	logger      *slog.Logger
+	now         func() time.Time
+
+	mu       sync.RWMutex
+	captures map[string]*ioCapture
+}
+
+// ioCapture is a running capture
+type ioCapture struct {
+	info  CaptureInfo
+	timer *time.Timer
+
+	// Input and output are recorded from different goroutines
+	mu     sync.Mutex
+	file   *os.File
+	writer *iocapture.Writer
+	err    error
+}
+
+// CaptureInfo describes a capture
+type CaptureInfo struct {
+	SessionID string    `json:"session_id"`
+	Admin     string    `json:"admin"`
+	Reason    string    `json:"reason,omitempty"`
+	File      string    `json:"file"`
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Events    int       `json:"events"`
+}
+
+// NewIOCaptures sets up captures as configured, with exists telling whether
+// a session has a running game. It returns nil when captures are disabled.
+func NewIOCaptures(cfg *config.IOCaptureConfig, gameDataPath string, exists func(sessionID string) bool, logger *slog.Logger) (*IOCaptures, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	key, err := iocapture.ParseKey(cfg.Key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid io_capture.key: %w", err)
+	}
+	return &IOCaptures{
+		dir:         cfg.GetDirectory(gameDataPath),
+		key:         key,
+		maxDuration: config.ParseDuration(cfg.MaxDuration, defaultCaptureMaxDuration),
+		exists:      exists,
+		logger:      logger,
+		now:         time.Now,
+		captures:    make(map[string]*ioCapture),
+	}, nil
+}
+
+// Start captures the I/O of a session for duration, capped at the configured
+// maximum; a duration that is not positive means the maximum. admin names who
+// asked for the capture and reason why, for the audit log.
+func (c *IOCaptures) Start(ctx context.Context, sessionID, admin, reason string, duration time.Duration) (CaptureInfo, error) {
+	if c == nil {
+		return CaptureInfo{}, apierror.New(apierror.Unavailable, "I/O capture is not enabled")
+	}
+	if admin == "" {
+		return CaptureInfo{}, apierror.New(apierror.InvalidRequest, "admin is required")
+	}
+	if !captureSessionID.MatchString(sessionID) || (c.exists != nil && !c.exists(sessionID)) {
+		return CaptureInfo{}, apierror.New(apierror.SessionNotFound, "no running game for session")
+	}
+	if duration <= 0 || duration > c.maxDuration {
+		duration = c.maxDuration
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if existing, ok := c.captures[sessionID]; ok {
+		return existing.info, apierror.New(apierror.AlreadyExists, "session is already being captured")
+	}
+
+	now := c.now()
+	if err := os.MkdirAll(c.dir, 0700); err != nil {
+		return CaptureInfo{}, fmt.Errorf("failed to create capture directory: %w", err)
+	}
+	path := filepath.Join(c.dir, fmt.Sprintf("%s-%s.dgcap", sessionID, now.UTC().Format("20060102T150405Z")))
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return CaptureInfo{}, fmt.Errorf("failed to create capture file: %w", err)
+	}
+	writer, err := iocapture.NewWriter(file, c.key)
+	if err != nil {
+		file.Close()
+		os.Remove(path)
+		return CaptureInfo{}, err
+	}
+
+	capture := &ioCapture{
+		info: CaptureInfo{
+			SessionID: sessionID,
+			Admin:     admin,
+			Reason:    reason,
+			File:      path,
+			StartedAt: now,
+			ExpiresAt: now.Add(duration),
+		},
+		file:   file,
+		writer: writer,
+	}
+	capture.timer = time.AfterFunc(duration, func() { c.expire(capture) })
+	c.captures[sessionID] = capture
+
+	c.logger.WarnContext(ctx, "I/O capture started",
+		"audit", true,
+		"admin", admin,
+		"session_id", sessionID,
+		"reason", reason,
+		"file", path,
+		"expires_at", capture.info.ExpiresAt,
+	)
+	return capture.info, nil
+}
+
+// Stop ends the capture of a session early. admin names who stopped it.
+func (c *IOCaptures) Stop(ctx context.Context, sessionID, admin string) (CaptureInfo, error) {
+	if c == nil {
+		return CaptureInfo{}, apierror.New(apierror.Unavailable, "I/O capture is not enabled")
+	}
+	c.mu.Lock()
+	capture, ok := c.captures[sessionID]
+	if ok {
+		delete(c.captures, sessionID)
+	}
+	c.mu.Unlock()
+	if !ok {
+		return CaptureInfo{}, apierror.New(apierror.NotFound, "session is not being captured")
+	}
+
+	capture.timer.Stop()
+	info := capture.finish()
+	c.logger.WarnContext(ctx, "I/O capture stopped", "audit", true, "admin", admin, "session_id", sessionID, "file", info.File, "events", info.Events)
+	return info, nil
+}
+
+// List describes the running captures
+func (c *IOCaptures) List() []CaptureInfo {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	infos := make([]CaptureInfo, 0, len(c.captures))
+	for _, capture := range c.captures {
+		capture.mu.Lock()
+		infos = append(infos, capture.info)
+		capture.mu.Unlock()
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].StartedAt.Before(infos[j].StartedAt) })
+	return infos
+}
+
+// StopAll ends every capture, as the service shuts down
+func (c *IOCaptures) StopAll() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	captures := c.captures
+	c.captures = make(map[string]*ioCapture)
+	c.mu.Unlock()
+
+	for _, capture := range captures {
+		capture.timer.Stop()
+		info := capture.finish()
+		c.logger.Warn("I/O capture stopped at shutdown", "audit", true, "session_id", info.SessionID, "file", info.File, "events", info.Events)
+	}
+}
+
+// record writes data passing through a session to its capture, if any
+func (c *IOCaptures) record(sessionID string, direction iocapture.Direction, data []byte) {
+	if c == nil {
+		return
+	}
+	c.mu.RLock()
+	capture := c.captures[sessionID]
+	c.mu.RUnlock()
+	if capture == nil {
+		return
+	}
+
+	capture.mu.Lock()
+	defer capture.mu.Unlock()
+	if capture.writer == nil || capture.err != nil {
+		return
+	}
+	if capture.err = capture.writer.Write(iocapture.Event{Time: c.now(), Direction: direction, Data: data}); capture.err != nil {
+		c.logger.Error("Failed to write I/O capture, capture stopped", "session_id", sessionID, "error", capture.err)
+		return
+	}
+	capture.info.Events++
+}
+
+// expire ends a capture once its time is up, unless it was already stopped
+func (c *IOCaptures) expire(capture *ioCapture) {
+	c.mu.Lock()
+	current := c.captures[capture.info.SessionID] == capture
+	if current {
+		delete(c.captures, capture.info.SessionID)
+	}
+	c.mu.Unlock()
+	if !current {
+		return
+	}
+
+	info := capture.finish()
+	c.logger.Warn("I/O capture expired", "audit", true, "session_id", info.SessionID, "file", info.File, "events", info.Events)
+}
+
+// finish closes the capture file and returns the final description
+func (ic *ioCapture) finish() CaptureInfo {
+	ic.mu.Lock()
+	defer ic.mu.Unlock()
+
+	if ic.file != nil {
+		ic.file.Close()
+		ic.file, ic.writer = nil, nil
+	}
+	return ic.info
+}
@@ -0,0 +1,119 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/iocapture"
+)
+
+func newTestCaptures(t *testing.T, maxDuration string) (*IOCaptures, []byte, *bytes.Buffer) {
+	key := bytes.Repeat([]byte{1}, iocapture.KeySize)
+	var logs bytes.Buffer
+	captures, err := NewIOCaptures(&config.IOCaptureConfig{
+		Enabled:     true,
+		Directory:   t.TempDir(),
+		Key:         hex.EncodeToString(key),
+		MaxDuration: maxDuration,
+	}, "", func(sessionID string) bool { return sessionID == "running" }, slog.New(slog.NewTextHandler(&logs, nil)))
+	require.NoError(t, err)
+	return captures, key, &logs
+}
+
+func TestIOCaptures_CapturesUntilStopped(t *testing.T) {
+	captures, key, logs := newTestCaptures(t, "")
+	ctx := context.Background()
+
+	captures.record("running", iocapture.Input, []byte("before"))
+	info, err := captures.Start(ctx, "running", "alice", "slow redraws", 5*time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "audit=true")
+
+	captures.record("running", iocapture.Input, []byte("j"))
+	captures.record("running", iocapture.Output, []byte("\x1b[3;4H@"))
+	captures.record("other", iocapture.Input, []byte("x"))
+	require.Len(t, captures.List(), 1)
+	assert.Equal(t, 2, captures.List()[0].Events)
+
+	info, err = captures.Stop(ctx, "running", "alice")
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Events)
+	assert.Empty(t, captures.List())
+	captures.record("running", iocapture.Input, []byte("after"))
+
+	file, err := os.Open(info.File)
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := iocapture.NewReader(file, key)
+	require.NoError(t, err)
+	var events []iocapture.Event
+	for {
+		event, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	require.Len(t, events, 2)
+	assert.Equal(t, iocapture.Input, events[0].Direction)
+	assert.Equal(t, []byte("j"), events[0].Data)
+	assert.Equal(t, iocapture.Output, events[1].Direction)
+}
+
+func TestIOCaptures_Refusals(t *testing.T) {
+	captures, _, _ := newTestCaptures(t, "")
+	ctx := context.Background()
+
+	_, err := captures.Start(ctx, "missing", "alice", "", 0)
+	assert.Equal(t, apierror.SessionNotFound, apierror.CodeOf(err))
+	_, err = captures.Start(ctx, "../running", "alice", "", 0)
+	assert.Equal(t, apierror.SessionNotFound, apierror.CodeOf(err))
+	_, err = captures.Start(ctx, "running", "", "", 0)
+	assert.Equal(t, apierror.InvalidRequest, apierror.CodeOf(err))
+
+	_, err = captures.Start(ctx, "running", "alice", "", 0)
+	require.NoError(t, err)
+	_, err = captures.Start(ctx, "running", "bob", "", 0)
+	assert.Equal(t, apierror.AlreadyExists, apierror.CodeOf(err))
+	captures.StopAll()
+
+	_, err = captures.Stop(ctx, "running", "alice")
+	assert.Equal(t, apierror.NotFound, apierror.CodeOf(err))
+
+	var disabled *IOCaptures
+	_, err = disabled.Start(ctx, "running", "alice", "", 0)
+	assert.Equal(t, apierror.Unavailable, apierror.CodeOf(err))
+	disabled.record("running", iocapture.Input, []byte("x"))
+}
+
+func TestIOCaptures_Expire(t *testing.T) {
+	captures, _, _ := newTestCaptures(t, "50ms")
+
+	// Durations are capped at the configured maximum
+	info, err := captures.Start(context.Background(), "running", "alice", "", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, 50*time.Millisecond, info.ExpiresAt.Sub(info.StartedAt))
+
+	require.Eventually(t, func() bool { return len(captures.List()) == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestNewIOCaptures(t *testing.T) {
+	captures, err := NewIOCaptures(nil, "", nil, slog.Default())
+	assert.NoError(t, err)
+	assert.Nil(t, captures)
+
+	_, err = NewIOCaptures(&config.IOCaptureConfig{Enabled: true, Key: "short"}, "", nil, slog.Default())
+	assert.Error(t, err)
+}
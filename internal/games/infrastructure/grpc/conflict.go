@@ -0,0 +1,31 @@
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// conflictRetryDelay is how long clients are told to wait before retrying an
+// update that lost a race with another writer
+const conflictRetryDelay = 100 * time.Millisecond
+
+// conflictError converts an update that lost a race with another writer to
+// an ABORTED status with a RetryInfo detail, telling the client to retry the
+// whole call. It returns nil for any other error.
+func conflictError(err error) error {
+	var conflict *domain.ConflictError
+	if !errors.As(err, &conflict) {
+		return nil
+	}
+	st := apierror.Status(apierror.New(apierror.Conflict, conflict.Aggregate+" was modified concurrently, retry the request"), "")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(conflictRetryDelay)}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
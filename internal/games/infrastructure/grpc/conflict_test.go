@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+func TestConflictError(t *testing.T) {
+	assert.NoError(t, conflictError(errors.New("disk full")))
+
+	conflict := &domain.ConflictError{Aggregate: "session", ID: "abc", Expected: 1, Actual: 2}
+	err := conflictError(fmt.Errorf("failed to save session: %w", conflict))
+	require.Error(t, err)
+
+	st := status.Convert(err)
+	assert.Equal(t, codes.Aborted, st.Code())
+	require.Len(t, st.Details(), 2)
+	retry, ok := st.Details()[1].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Equal(t, conflictRetryDelay, retry.RetryDelay.AsDuration())
+	assert.Equal(t, apierror.Conflict, apierror.CodeOf(err))
+
+	assert.Equal(t, codes.Aborted, status.Code(saveError(conflict)))
+}
@@ -0,0 +1,299 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/adapters"
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+)
+
+// GameDefinitionStore keeps the games admins create or override through the
+// API. Its definitions take the place of the configured games with the same
+// ID.
+type GameDefinitionStore interface {
+	// List returns the stored definitions
+	List(ctx context.Context) ([]*config.GameConfig, error)
+	// Save stores a game's definition, replacing any stored before
+	Save(ctx context.Context, game *config.GameConfig) error
+	// Delete removes a game's definition and reports whether there was one
+	Delete(ctx context.Context, gameID string) (bool, error)
+}
+
+// SetGameDefinitions puts the games defined in store in play over the
+// configured games, and keeps the games defined through the API there.
+// Without a store, games are only configured.
+func (s *GameServiceServer) SetGameDefinitions(ctx context.Context, store GameDefinitionStore) error {
+	definitions, err := store.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load game definitions: %w", err)
+	}
+
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+	defined := make(map[string]*config.GameConfig, len(definitions))
+	for _, game := range definitions {
+		defined[game.ID] = game
+	}
+	games := mergeDefinitions(s.fileGames, defined)
+	if err := s.adapters.Reconfigure(games); err != nil {
+		return fmt.Errorf("failed to configure game adapters: %w", err)
+	}
+	for _, game := range definitions {
+		if _, err := s.gameService.DefineGame(ctx, gameDefinitionRequest(game)); err != nil {
+			return fmt.Errorf("failed to define game %s: %w", game.ID, err)
+		}
+	}
+
+	s.definitions = store
+	s.defined = defined
+	s.gameConfigs = games
+	if len(definitions) > 0 {
+		s.logger.Info("Loaded game definitions from the database", "count", len(definitions))
+	}
+	return nil
+}
+
+// CreateGame defines a game the configuration does not have. The definition
+// is kept in the database.
+func (s *GameServiceServer) CreateGame(ctx context.Context, req *games_pb.CreateGameRequest) (*games_pb.CreateGameResponse, error) {
+	game, err := s.requestDefinition(req.Definition)
+	if err != nil {
+		return nil, err
+	}
+	if s.gameConfig(game.ID) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "game %s already exists; update it to override its definition", game.ID)
+	}
+
+	domainGame, err := s.storeDefinition(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Game created", "audit", true, "game_id", game.ID)
+	return &games_pb.CreateGameResponse{Game: s.domainGameToPb(domainGame)}, nil
+}
+
+// UpdateGame replaces the definition of a game. The new definition is kept
+// in the database and overrides the configured one until it is deleted.
+func (s *GameServiceServer) UpdateGame(ctx context.Context, req *games_pb.UpdateGameRequest) (*games_pb.UpdateGameResponse, error) {
+	if req.GameId == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+	game, err := s.requestDefinition(req.Definition)
+	if err != nil {
+		return nil, err
+	}
+	if game.ID != req.GameId {
+		return nil, status.Errorf(codes.InvalidArgument, "definition is of game %s, not %s", game.ID, req.GameId)
+	}
+	if s.gameConfig(game.ID) == nil {
+		return nil, domain.ErrGameNotFound
+	}
+
+	domainGame, err := s.storeDefinition(ctx, game)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.InfoContext(ctx, "Game definition updated", "audit", true, "game_id", game.ID)
+	return &games_pb.UpdateGameResponse{Game: s.domainGameToPb(domainGame)}, nil
+}
+
+// DeleteGame deletes the database definition of a game. A configured game it
+// overrode comes back into play; otherwise the game is removed, unless it
+// is being played.
+func (s *GameServiceServer) DeleteGame(ctx context.Context, req *games_pb.DeleteGameRequest) (*games_pb.DeleteGameResponse, error) {
+	if err := s.checkDefinitions(); err != nil {
+		return nil, err
+	}
+	if req.GameId == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+	if _, ok := s.defined[req.GameId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "game %s is not defined in the database; configured games are removed from the configuration file", req.GameId)
+	}
+	defined := maps.Clone(s.defined)
+	delete(defined, req.GameId)
+	games := mergeDefinitions(s.fileGames, defined)
+
+	configured := findGame(games, req.GameId)
+	if configured == nil {
+		if err := s.gameService.DeleteGame(ctx, req.GameId); err != nil && !errors.Is(err, domain.ErrGameNotFound) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	if _, err := s.definitions.Delete(ctx, req.GameId); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.putInPlay(defined, games)
+	if configured != nil {
+		if _, err := s.gameService.DefineGame(ctx, gameDefinitionRequest(configured)); err != nil {
+			return nil, apierror.Status(err, "failed to restore configured game").Err()
+		}
+	}
+
+	s.logger.InfoContext(ctx, "Game definition deleted", "audit", true, "game_id", req.GameId, "configured", configured != nil)
+	return &games_pb.DeleteGameResponse{Success: true}, nil
+}
+
+// ExportGames writes the games defined in the database, and optionally the
+// configured ones, as the games list of the configuration file. Configured
+// games are written as loaded, with environment variables expanded.
+func (s *GameServiceServer) ExportGames(ctx context.Context, req *games_pb.ExportGamesRequest) (*games_pb.ExportGamesResponse, error) {
+	s.gamesMu.RLock()
+	games := s.gameConfigs
+	if !req.IncludeConfigured {
+		games = mergeDefinitions(nil, s.defined)
+	}
+	s.gamesMu.RUnlock()
+
+	data, err := config.ExportGames(games)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &games_pb.ExportGamesResponse{Yaml: string(data), Count: int32(len(games))}, nil
+}
+
+// games returns the games in play
+func (s *GameServiceServer) games() []*config.GameConfig {
+	s.gamesMu.RLock()
+	defer s.gamesMu.RUnlock()
+	return s.gameConfigs
+}
+
+// gameSource reports where the definition of a game comes from
+func (s *GameServiceServer) gameSource(gameID string) games_pb.GameSource {
+	s.gamesMu.RLock()
+	defer s.gamesMu.RUnlock()
+	if _, ok := s.defined[gameID]; ok {
+		return games_pb.GameSource_GAME_SOURCE_DATABASE
+	}
+	if findGame(s.fileGames, gameID) != nil {
+		return games_pb.GameSource_GAME_SOURCE_CONFIG
+	}
+	return games_pb.GameSource_GAME_SOURCE_UNSPECIFIED
+}
+
+// checkDefinitions refuses to define games without a database to keep them in
+func (s *GameServiceServer) checkDefinitions() error {
+	if s.gameService == nil {
+		return status.Error(codes.Unavailable, "game service not available")
+	}
+	s.gamesMu.RLock()
+	defer s.gamesMu.RUnlock()
+	if s.definitions == nil {
+		return status.Error(codes.FailedPrecondition, "games are only configured; defining games needs the game service database")
+	}
+	return nil
+}
+
+// requestDefinition parses and validates the definition of a create or
+// update request as loading the configuration does
+func (s *GameServiceServer) requestDefinition(definition string) (*config.GameConfig, error) {
+	if err := s.checkDefinitions(); err != nil {
+		return nil, err
+	}
+	if definition == "" {
+		return nil, status.Error(codes.InvalidArgument, "definition is required: the game's YAML as in the games list of the configuration file")
+	}
+	game, err := config.ParseGameDefinition([]byte(definition), "definition")
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return game, nil
+}
+
+// storeDefinition keeps a game's definition in the database and puts it in
+// play. Games already running keep the definition they started with.
+func (s *GameServiceServer) storeDefinition(ctx context.Context, game *config.GameConfig) (*domain.Game, error) {
+	s.gamesMu.Lock()
+	defer s.gamesMu.Unlock()
+
+	defined := maps.Clone(s.defined)
+	if defined == nil {
+		defined = make(map[string]*config.GameConfig)
+	}
+	defined[game.ID] = game
+	games := mergeDefinitions(s.fileGames, defined)
+
+	// The game's adapter must accept the definition before it is stored
+	if _, err := adapters.NewGameAdapterRegistryWithConfig(games); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := s.definitions.Save(ctx, game); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	s.putInPlay(defined, games)
+
+	domainGame, err := s.gameService.DefineGame(ctx, gameDefinitionRequest(game))
+	if err != nil {
+		return nil, apierror.Status(err, "failed to define game").Err()
+	}
+	return domainGame, nil
+}
+
+// putInPlay makes games, with the definitions of defined, the games in play.
+// The caller holds gamesMu.
+func (s *GameServiceServer) putInPlay(defined map[string]*config.GameConfig, games []*config.GameConfig) {
+	if err := s.adapters.Reconfigure(games); err != nil {
+		s.logger.Error("Failed to reconfigure game adapters", "error", err)
+	}
+	s.defined = defined
+	s.gameConfigs = games
+}
+
+// mergeDefinitions returns the configured games with the defined ones in
+// their place, followed by the other defined games
+func mergeDefinitions(configured []*config.GameConfig, defined map[string]*config.GameConfig) []*config.GameConfig {
+	definitions := make([]*config.GameConfig, 0, len(defined))
+	for _, game := range defined {
+		definitions = append(definitions, game)
+	}
+	sort.Slice(definitions, func(i, j int) bool { return definitions[i].ID < definitions[j].ID })
+	return config.MergeGameDefinitions(configured, definitions)
+}
+
+// findGame returns the game with an ID, or nil
+func findGame(games []*config.GameConfig, gameID string) *config.GameConfig {
+	for _, game := range games {
+		if game.ID == gameID {
+			return game
+		}
+	}
+	return nil
+}
+
+// gameDefinitionRequest describes a game definition to the game service,
+// which lists the games players choose from
+func gameDefinitionRequest(game *config.GameConfig) *application.CreateGameRequest {
+	req := &application.CreateGameRequest{
+		ID:          game.ID,
+		Name:        game.Name,
+		ShortName:   game.ShortName,
+		Version:     game.Version,
+		Environment: game.Environment,
+	}
+	if game.Binary != nil {
+		req.BinaryPath = game.Binary.Path
+		req.BinaryArgs = game.Binary.Args
+		req.WorkingDirectory = game.Binary.WorkingDirectory
+	}
+	if game.Resources != nil {
+		req.CPULimit = game.Resources.CPULimit
+		req.MemoryLimit = game.Resources.MemoryLimit
+		req.DiskLimit = game.Resources.DiskLimit
+	}
+	return req
+}
@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/dungeongate/internal/games/adapters"
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/config"
+)
+
+// memoryDefinitionStore keeps game definitions in memory
+type memoryDefinitionStore struct {
+	games map[string]*config.GameConfig
+}
+
+func (m *memoryDefinitionStore) List(ctx context.Context) ([]*config.GameConfig, error) {
+	games := make([]*config.GameConfig, 0, len(m.games))
+	for _, game := range m.games {
+		games = append(games, game)
+	}
+	sort.Slice(games, func(i, j int) bool { return games[i].ID < games[j].ID })
+	return games, nil
+}
+
+func (m *memoryDefinitionStore) Save(ctx context.Context, game *config.GameConfig) error {
+	m.games[game.ID] = game
+	return nil
+}
+
+func (m *memoryDefinitionStore) Delete(ctx context.Context, gameID string) (bool, error) {
+	_, ok := m.games[gameID]
+	delete(m.games, gameID)
+	return ok, nil
+}
+
+func definitionTestGame(id, name string) string {
+	return "id: " + id + "\nname: " + name + "\nenabled: true\nbinary:\n  path: /usr/games/" + id + "\npaths:\n  auto_detect: true\n"
+}
+
+func newDefinitionTestServer(t *testing.T, store *memoryDefinitionStore) *GameServiceServer {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	configured := []*config.GameConfig{{
+		ID:      "angband",
+		Name:    "Angband",
+		Enabled: true,
+		Binary:  &config.BinaryConfig{Path: "/usr/games/angband"},
+		Paths:   &config.GamePathsConfig{AutoDetect: true},
+	}}
+	server := &GameServiceServer{
+		gameService: application.NewGameService(gameRepo, sessionRepo, saveRepo, eventRepo, uow),
+		adapters:    adapters.NewGameAdapterRegistry(),
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		fileGames:   configured,
+		gameConfigs: configured,
+	}
+	require.NoError(t, server.SetGameDefinitions(context.Background(), store))
+	return server
+}
+
+func TestGameDefinitions_CreateUpdateDelete(t *testing.T) {
+	store := &memoryDefinitionStore{games: map[string]*config.GameConfig{}}
+	server := newDefinitionTestServer(t, store)
+	ctx := context.Background()
+
+	created, err := server.CreateGame(ctx, &games_pb.CreateGameRequest{Definition: definitionTestGame("crawl", "Crawl")})
+	require.NoError(t, err)
+	assert.Equal(t, "Crawl", created.Game.Name)
+	assert.Equal(t, games_pb.GameSource_GAME_SOURCE_DATABASE, created.Game.Source)
+	assert.Contains(t, store.games, "crawl")
+	assert.NotNil(t, server.gameConfig("crawl"), "the game can be started at once")
+
+	_, err = server.CreateGame(ctx, &games_pb.CreateGameRequest{Definition: definitionTestGame("angband", "Angband")})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// A definition in the database overrides the configured one
+	updated, err := server.UpdateGame(ctx, &games_pb.UpdateGameRequest{GameId: "angband", Definition: definitionTestGame("angband", "Angband 4.2")})
+	require.NoError(t, err)
+	assert.Equal(t, "Angband 4.2", updated.Game.Name)
+	assert.Equal(t, "Angband 4.2", server.gameConfig("angband").Name)
+
+	// Deleting it brings the configured game back
+	_, err = server.DeleteGame(ctx, &games_pb.DeleteGameRequest{GameId: "angband"})
+	require.NoError(t, err)
+	assert.Equal(t, "Angband", server.gameConfig("angband").Name)
+	game, err := server.GetGame(ctx, &games_pb.GetGameRequest{GameId: "angband"})
+	require.NoError(t, err)
+	assert.Equal(t, "Angband", game.Game.Name)
+	assert.Equal(t, games_pb.GameSource_GAME_SOURCE_CONFIG, game.Game.Source)
+
+	// Configured games cannot be deleted through the API
+	_, err = server.DeleteGame(ctx, &games_pb.DeleteGameRequest{GameId: "angband"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// A game only the database defined is removed
+	_, err = server.DeleteGame(ctx, &games_pb.DeleteGameRequest{GameId: "crawl"})
+	require.NoError(t, err)
+	assert.Nil(t, server.gameConfig("crawl"))
+	_, err = server.GetGame(ctx, &games_pb.GetGameRequest{GameId: "crawl"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestGameDefinitions_Validation(t *testing.T) {
+	server := newDefinitionTestServer(t, &memoryDefinitionStore{games: map[string]*config.GameConfig{}})
+	ctx := context.Background()
+
+	for _, definition := range []string{
+		"",
+		"id: crawl\nname: Crawl\n",
+		definitionTestGame("crawl", "Crawl") + "unknown_setting: true\n",
+	} {
+		_, err := server.CreateGame(ctx, &games_pb.CreateGameRequest{Definition: definition})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), definition)
+	}
+
+	_, err := server.UpdateGame(ctx, &games_pb.UpdateGameRequest{GameId: "angband", Definition: definitionTestGame("crawl", "Crawl")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "the definition must be of the game updated")
+	_, err = server.UpdateGame(ctx, &games_pb.UpdateGameRequest{GameId: "crawl", Definition: definitionTestGame("crawl", "Crawl")})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// Without a database games are only configured
+	server.definitions = nil
+	_, err = server.CreateGame(ctx, &games_pb.CreateGameRequest{Definition: definitionTestGame("crawl", "Crawl")})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestGameDefinitions_LoadAndExport(t *testing.T) {
+	crawl, err := config.ParseGameDefinition([]byte(definitionTestGame("crawl", "Crawl")), "crawl")
+	require.NoError(t, err)
+	server := newDefinitionTestServer(t, &memoryDefinitionStore{games: map[string]*config.GameConfig{"crawl": crawl}})
+	ctx := context.Background()
+
+	// Stored definitions are in play from the start
+	games, err := server.ListGames(ctx, &games_pb.ListGamesRequest{})
+	require.NoError(t, err)
+	require.Len(t, games.Games, 1)
+	assert.Equal(t, "crawl", games.Games[0].Id)
+
+	exported, err := server.ExportGames(ctx, &games_pb.ExportGamesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), exported.Count)
+	var cfg struct {
+		Games []*config.GameConfig `yaml:"games"`
+	}
+	require.NoError(t, yaml.Unmarshal([]byte(exported.Yaml), &cfg))
+	assert.Equal(t, []*config.GameConfig{crawl}, cfg.Games)
+
+	exported, err = server.ExportGames(ctx, &games_pb.ExportGamesRequest{IncludeConfigured: true})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), exported.Count)
+	assert.Contains(t, exported.Yaml, "id: angband")
+}
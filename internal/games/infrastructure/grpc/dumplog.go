@@ -0,0 +1,110 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/config"
+)
+
+// defaultDumplogListLimit is used when ListDumplogs is called without a limit
+const defaultDumplogListLimit = 10
+
+// ListDumplogs lists a user's most recent dumplogs without their content
+func (s *GameServiceServer) ListDumplogs(ctx context.Context, req *games_pb.ListDumplogsRequest) (*games_pb.ListDumplogsResponse, error) {
+	if s.dumplogService == nil {
+		return nil, status.Error(codes.Unavailable, "dumplog service not available")
+	}
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultDumplogListLimit
+	}
+
+	dumplogs, err := s.dumplogService.ListRecentDumplogs(ctx, int(req.UserId), req.GameId, limit)
+	if err != nil {
+		s.logger.Error("Failed to list dumplogs", "user_id", req.UserId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list dumplogs")
+	}
+
+	pbDumplogs := make([]*games_pb.Dumplog, len(dumplogs))
+	for i, dumplog := range dumplogs {
+		pbDumplogs[i] = domainDumplogToPb(dumplog, false)
+	}
+
+	return &games_pb.ListDumplogsResponse{
+		Dumplogs: pbDumplogs,
+	}, nil
+}
+
+// GetDumplog returns a single dumplog including its content
+func (s *GameServiceServer) GetDumplog(ctx context.Context, req *games_pb.GetDumplogRequest) (*games_pb.GetDumplogResponse, error) {
+	if s.dumplogService == nil {
+		return nil, status.Error(codes.Unavailable, "dumplog service not available")
+	}
+	if req.DumplogId == "" {
+		return nil, status.Error(codes.InvalidArgument, "dumplog_id is required")
+	}
+
+	dumplog, err := s.dumplogService.GetDumplog(ctx, req.DumplogId)
+	if err != nil {
+		return nil, status.Error(codes.NotFound, "dumplog not found")
+	}
+
+	return &games_pb.GetDumplogResponse{
+		Dumplog: domainDumplogToPb(dumplog, true),
+	}, nil
+}
+
+// captureDumplog stores the dumplog of a finished session when the game has capture enabled
+func (s *GameServiceServer) captureDumplog(session *domain.GameSession, gameConfig *config.GameConfig) {
+	if s.dumplogService == nil || gameConfig.Settings == nil || gameConfig.Settings.Dumplog == nil {
+		return
+	}
+	dumplogConfig := gameConfig.Settings.Dumplog
+	if !dumplogConfig.Enabled || dumplogConfig.PathPattern == "" {
+		return
+	}
+
+	dumplog, err := s.dumplogService.CaptureDumplog(context.Background(), session, dumplogConfig.PathPattern, int64(dumplogConfig.MaxSizeKB)*1024)
+	if err != nil {
+		s.logger.Warn("Failed to capture dumplog", "session_id", session.ID().String(), "error", err)
+		return
+	}
+	if dumplog == nil {
+		s.logger.Debug("No dumplog found for session", "session_id", session.ID().String())
+		return
+	}
+
+	s.logger.Info("Captured dumplog",
+		"session_id", session.ID().String(),
+		"dumplog_id", dumplog.ID().String(),
+		"path", dumplog.SourcePath(),
+		"size", dumplog.Size(),
+		"truncated", dumplog.Truncated())
+}
+
+// domainDumplogToPb converts a domain Dumplog to protobuf, optionally including its content
+func domainDumplogToPb(dumplog *domain.Dumplog, withContent bool) *games_pb.Dumplog {
+	pbDumplog := &games_pb.Dumplog{
+		Id:         dumplog.ID().String(),
+		SessionId:  dumplog.SessionID().String(),
+		UserId:     int32(dumplog.UserID().Int()),
+		Username:   dumplog.Username(),
+		GameId:     dumplog.GameID().String(),
+		Size:       dumplog.Size(),
+		CapturedAt: timestamppb.New(dumplog.CapturedAt()),
+	}
+	if withContent {
+		pbDumplog.Content = dumplog.Content()
+	}
+	return pbDumplog
+}
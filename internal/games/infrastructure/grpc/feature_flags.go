@@ -0,0 +1,28 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/featureflags"
+)
+
+// SetFeatureFlags sets the feature flags evaluated for the players starting games
+func (s *GameServiceServer) SetFeatureFlags(flags *featureflags.Flags) {
+	s.flags = flags
+}
+
+// logPlayerFlags logs the feature flags on for the player of a new game, so
+// problems with games can be traced to the features being rolled out
+func (s *GameServiceServer) logPlayerFlags(ctx context.Context, session *domain.GameSession, req *games_pb.StartGameSessionRequest) {
+	flags := s.flags.EnabledFor(featureflags.Subject{
+		UserID:   strconv.Itoa(int(req.UserId)),
+		Username: req.Username,
+		Roles:    req.Roles,
+	})
+	if len(flags) > 0 {
+		s.logger.InfoContext(ctx, "Feature flags on for game session", "session_id", session.ID().String(), "game_id", req.GameId, "feature_flags", flags)
+	}
+}
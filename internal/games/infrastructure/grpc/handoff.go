@@ -0,0 +1,147 @@
+package grpc
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/infrastructure/pty"
+)
+
+// Lines exchanged around a handoff: the new instance asks for the games, the
+// old one sends them, and the new one confirms it adopted them
+const (
+	handoffRequest = "takeover"
+	handoffAck     = "adopted"
+)
+
+// ReadHandoffRequest reads the request a new instance sends when it connects
+// to take over, so the caller knows to shut down and then call HandOff
+func ReadHandoffRequest(conn *net.UnixConn) error {
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return fmt.Errorf("failed to read takeover request: %w", err)
+	}
+	if strings.TrimSpace(line) != handoffRequest {
+		return fmt.Errorf("unexpected takeover request %q", strings.TrimSpace(line))
+	}
+	return nil
+}
+
+// HandOff passes the PTY of every running game to the instance taking over on
+// conn, along with the session details it needs to adopt them. Call it after
+// Shutdown, once this instance no longer serves players. It returns how many
+// games the new instance confirmed it adopted.
+func (s *GameServiceServer) HandOff(conn *net.UnixConn) (int, error) {
+	sessions, err := s.sessionService.ListActiveSessions(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active sessions: %w", err)
+	}
+	snapshots := make(map[string]application.SessionSnapshot)
+	for _, snapshot := range application.SnapshotSessions(sessions, time.Now()) {
+		snapshots[snapshot.SessionID] = snapshot
+	}
+
+	sent := 0
+	for _, handoff := range s.ptyManager.HandoffPTYs() {
+		snapshot, ok := snapshots[handoff.SessionID]
+		if !ok {
+			continue
+		}
+		snapshot.PID = handoff.PID
+		payload, err := json.Marshal(snapshot)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode session %s: %w", handoff.SessionID, err)
+		}
+		if err := pty.WriteHandoff(conn, payload, handoff.File); err != nil {
+			return 0, err
+		}
+		sent++
+	}
+	if err := pty.WriteHandoff(conn, nil, nil); err != nil {
+		return 0, fmt.Errorf("failed to end handoff: %w", err)
+	}
+
+	// The new instance holds its own copies of the PTYs once it answers
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return 0, fmt.Errorf("no confirmation for %d handed off games: %w", sent, err)
+	}
+	var adopted int
+	if _, err := fmt.Sscanf(line, handoffAck+" %d", &adopted); err != nil {
+		return 0, fmt.Errorf("unexpected handoff confirmation %q", strings.TrimSpace(line))
+	}
+	return adopted, nil
+}
+
+// TakeOver asks the instance on conn to hand over its running games and
+// adopts them: each keeps its session ID, so players resume it from the menu.
+// Games that cannot be adopted are logged and skipped.
+func (s *GameServiceServer) TakeOver(ctx context.Context, conn *net.UnixConn) (int, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	if _, err := io.WriteString(conn, handoffRequest+"\n"); err != nil {
+		return 0, fmt.Errorf("failed to request takeover: %w", err)
+	}
+
+	adopted := 0
+	for {
+		payload, file, err := pty.ReadHandoff(conn)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return adopted, err
+		}
+
+		var snapshot application.SessionSnapshot
+		if err := json.Unmarshal(payload, &snapshot); err != nil {
+			file.Close()
+			return adopted, fmt.Errorf("failed to decode handed off session: %w", err)
+		}
+		if err := s.adoptSession(ctx, snapshot, file); err != nil {
+			s.logger.Warn("Failed to adopt game", "session_id", snapshot.SessionID, "error", err)
+			file.Close()
+			continue
+		}
+		adopted++
+	}
+
+	if _, err := fmt.Fprintf(conn, "%s %d\n", handoffAck, adopted); err != nil {
+		return adopted, fmt.Errorf("failed to confirm takeover: %w", err)
+	}
+	return adopted, nil
+}
+
+// adoptSession records a handed off game as a session and attaches its PTY
+func (s *GameServiceServer) adoptSession(ctx context.Context, snapshot application.SessionSnapshot, ptmx *os.File) error {
+	gameConfig := s.gameConfig(snapshot.GameID)
+	if gameConfig == nil {
+		return fmt.Errorf("game %s is not configured", snapshot.GameID)
+	}
+	gameConfig, err := gameConfig.ForVersion(snapshot.GameVersion)
+	if err != nil {
+		return err
+	}
+
+	session, err := s.sessionService.AdoptSession(ctx, snapshot)
+	if err != nil {
+		return err
+	}
+	if _, err := s.ptyManager.AdoptPTY(session, ptmx, snapshot.PID, s.processExitCallback(gameConfig)); err != nil {
+		if endErr := session.End(nil, nil); endErr == nil {
+			s.sessionService.SessionChanged(session)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/config"
+)
+
+// defaultHistoryLimit is used when ListSessionHistory is called without a limit
+const defaultHistoryLimit = 20
+
+// ListSessionHistory lists a user's finished sessions, newest first
+func (s *GameServiceServer) ListSessionHistory(ctx context.Context, req *games_pb.ListSessionHistoryRequest) (*games_pb.ListSessionHistoryResponse, error) {
+	if s.sessionService == nil {
+		return nil, status.Error(codes.Unavailable, "session service not available")
+	}
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	filter := domain.HistoryFilter{
+		GameID: req.GameId,
+		Offset: int(req.Offset),
+		Limit:  int(req.Limit),
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultHistoryLimit
+	}
+	if req.Since != nil {
+		filter.Since = req.Since.AsTime()
+	}
+	if req.Until != nil {
+		filter.Until = req.Until.AsTime()
+	}
+
+	sessions, total, err := s.sessionService.ListSessionHistory(ctx, int(req.UserId), filter)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list session history", "user_id", req.UserId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list session history")
+	}
+
+	pbSessions := make([]*games_pb.GameSession, len(sessions))
+	for i, session := range sessions {
+		pbSessions[i] = s.domainSessionToPb(session)
+		hidePrivateRecording(pbSessions[i], session, req.ViewerId)
+	}
+
+	return &games_pb.ListSessionHistoryResponse{
+		Sessions:   pbSessions,
+		TotalCount: int32(total),
+	}, nil
+}
+
+// captureOutcome records how a finished session's game ended when the game
+// has an xlogfile configured
+func (s *GameServiceServer) captureOutcome(session *domain.GameSession, gameConfig *config.GameConfig) {
+	if s.sessionService == nil || gameConfig.Settings == nil || gameConfig.Settings.Xlog == nil {
+		return
+	}
+	xlogConfig := gameConfig.Settings.Xlog
+	if !xlogConfig.Enabled || xlogConfig.Path == "" {
+		return
+	}
+
+	outcome, err := s.sessionService.CaptureOutcome(context.Background(), session, xlogConfig.Path)
+	if err != nil {
+		s.logger.Warn("Failed to capture game outcome", "session_id", session.ID().String(), "error", err)
+		return
+	}
+	if outcome == nil {
+		s.logger.Debug("No xlog entry found for session", "session_id", session.ID().String())
+		return
+	}
+
+	s.logger.Info("Captured game outcome",
+		"session_id", session.ID().String(),
+		"result", outcome.Result,
+		"points", outcome.Points)
+}
@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+const (
+	// idempotencyTTL is how long the result of a keyed request is replayed
+	idempotencyTTL = time.Hour
+	// idempotencySweepInterval is how often expired results are dropped
+	idempotencySweepInterval = time.Minute
+	// idempotencyKeyField is the request field carrying the key
+	idempotencyKeyField = "idempotency_key"
+	// replayedHeader is set on responses replayed for a repeated key
+	replayedHeader = "idempotent-replayed"
+)
+
+// idempotentRequest is a request that may carry an idempotency key
+type idempotentRequest interface {
+	proto.Message
+	GetIdempotencyKey() string
+}
+
+// idempotencyStore remembers the results of keyed requests so a client
+// retrying after a lost response gets the original result, rather than the
+// request running again. Only successful results are kept: a failed request
+// may be retried with the same key.
+type idempotencyStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu        sync.Mutex
+	calls     map[string]*idempotentCall
+	lastSweep time.Time
+}
+
+// idempotentCall is a keyed request, running until done is closed
+type idempotentCall struct {
+	fingerprint []byte
+	done        chan struct{}
+	resp        proto.Message
+	err         error
+	expires     time.Time
+}
+
+// newIdempotencyStore creates a store keeping results for ttl
+func newIdempotencyStore(ttl time.Duration) *idempotencyStore {
+	return &idempotencyStore{
+		ttl:   ttl,
+		now:   time.Now,
+		calls: make(map[string]*idempotentCall),
+	}
+}
+
+// idempotent runs call for req, or returns the result of the earlier request
+// with the same idempotency key. A repeat arriving while the first is still
+// running waits for it. Reusing a key for a different request is an error.
+// Requests without a key run every time; only callers speaking games API
+// version 2 may send them.
+func idempotent[Resp proto.Message](ctx context.Context, store *idempotencyStore, req idempotentRequest, call func() (Resp, error)) (resp Resp, err error) {
+	var zero Resp
+	key := req.GetIdempotencyKey()
+	if key == "" && capabilities.APIVersionFrom(ctx) >= capabilities.APIVersion3 {
+		return zero, status.Error(codes.InvalidArgument, "idempotency_key is required from games API version 3")
+	}
+	if store == nil || key == "" {
+		// Callers speaking version 2 predate keys and are served without one
+		return call()
+	}
+	// Keys are scoped to the method, named by its request type
+	key = string(req.ProtoReflect().Descriptor().FullName()) + "/" + key
+	fingerprint, err := requestFingerprint(req)
+	if err != nil {
+		return zero, status.Error(codes.Internal, "failed to fingerprint request")
+	}
+
+	existing, owner := store.claim(key, fingerprint)
+	if !owner {
+		if !bytes.Equal(existing.fingerprint, fingerprint) {
+			return zero, status.Error(codes.InvalidArgument, "idempotency key was already used for a different request")
+		}
+		select {
+		case <-existing.done:
+		case <-ctx.Done():
+			return zero, status.FromContextError(ctx.Err()).Err()
+		}
+		if existing.err != nil {
+			return zero, existing.err
+		}
+		grpc.SetHeader(ctx, metadata.Pairs(replayedHeader, "true"))
+		return existing.resp.(Resp), nil
+	}
+
+	// A panicking call is finished as failed, so repeats do not wait forever
+	err = status.Error(codes.Internal, "request failed")
+	defer func() { store.finish(key, existing, resp, err) }()
+	return call()
+}
+
+// claim returns the call for key, reporting whether the caller must run it
+func (s *idempotencyStore) claim(key string, fingerprint []byte) (*idempotentCall, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) >= idempotencySweepInterval {
+		s.sweep(now)
+	}
+	if existing, ok := s.calls[key]; ok && (existing.expires.IsZero() || now.Before(existing.expires)) {
+		return existing, false
+	}
+	call := &idempotentCall{fingerprint: fingerprint, done: make(chan struct{})}
+	s.calls[key] = call
+	return call, true
+}
+
+// finish records the result of a call. A failed call is forgotten so the
+// request can be retried, though repeats already waiting see its error.
+func (s *idempotencyStore) finish(key string, call *idempotentCall, resp proto.Message, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	call.resp, call.err = resp, err
+	if err != nil {
+		delete(s.calls, key)
+	} else {
+		call.expires = s.now().Add(s.ttl)
+	}
+	close(call.done)
+}
+
+// sweep drops expired results; s.mu must be held
+func (s *idempotencyStore) sweep(now time.Time) {
+	for key, call := range s.calls {
+		if !call.expires.IsZero() && !now.Before(call.expires) {
+			delete(s.calls, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// requestFingerprint hashes a request without its idempotency key, to tell
+// a retry from a different request reusing the key
+func requestFingerprint(req proto.Message) ([]byte, error) {
+	clone := proto.Clone(req)
+	msg := clone.ProtoReflect()
+	if field := msg.Descriptor().Fields().ByName(idempotencyKeyField); field != nil {
+		msg.Clear(field)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(clone)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(data)
+	return sum[:], nil
+}
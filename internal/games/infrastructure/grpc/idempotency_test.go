@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+func TestIdempotent_ReplaysResult(t *testing.T) {
+	store := newIdempotencyStore(time.Hour)
+	ctx := context.Background()
+
+	var starts atomic.Int32
+	start := func() (*games_pb.StartGameSessionResponse, error) {
+		n := starts.Add(1)
+		return &games_pb.StartGameSessionResponse{Session: &games_pb.GameSession{Id: fmt.Sprintf("session-%d", n)}}, nil
+	}
+	req := &games_pb.StartGameSessionRequest{UserId: 1, GameId: "nethack", IdempotencyKey: "key-1"}
+
+	first, err := idempotent(ctx, store, req, start)
+	require.NoError(t, err)
+	again, err := idempotent(ctx, store, req, start)
+	require.NoError(t, err)
+	assert.Equal(t, first.Session.Id, again.Session.Id)
+	assert.Equal(t, int32(1), starts.Load())
+
+	// Callers speaking version 3 must send a key
+	_, err = idempotent(capabilities.WithAPIVersion(ctx, capabilities.APIVersion3), store, &games_pb.StartGameSessionRequest{UserId: 1, GameId: "nethack"}, start)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Requests without a key, or with another, run again
+	_, err = idempotent(ctx, store, &games_pb.StartGameSessionRequest{UserId: 1, GameId: "nethack"}, start)
+	require.NoError(t, err)
+	_, err = idempotent(ctx, store, &games_pb.StartGameSessionRequest{UserId: 1, GameId: "nethack", IdempotencyKey: "key-2"}, start)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), starts.Load())
+
+	// Reusing a key for a different request is refused
+	_, err = idempotent(ctx, store, &games_pb.StartGameSessionRequest{UserId: 2, GameId: "nethack", IdempotencyKey: "key-1"}, start)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Keys are scoped to the method
+	_, err = idempotent(ctx, store, &games_pb.StopGameSessionRequest{SessionId: "a", IdempotencyKey: "key-1"}, func() (*games_pb.StopGameSessionResponse, error) {
+		return &games_pb.StopGameSessionResponse{Success: true}, nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestIdempotent_ConcurrentRepeatsWait(t *testing.T) {
+	store := newIdempotencyStore(time.Hour)
+	ctx := context.Background()
+	req := &games_pb.StopGameSessionRequest{SessionId: "a", IdempotencyKey: "key"}
+
+	release := make(chan struct{})
+	var stops atomic.Int32
+	stop := func() (*games_pb.StopGameSessionResponse, error) {
+		stops.Add(1)
+		<-release
+		return &games_pb.StopGameSessionResponse{Success: true}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := idempotent(ctx, store, req, stop)
+			assert.NoError(t, err)
+			assert.True(t, resp.Success)
+		}()
+	}
+	require.Eventually(t, func() bool { return stops.Load() == 1 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), stops.Load())
+}
+
+func TestIdempotent_FailuresAndExpiry(t *testing.T) {
+	store := newIdempotencyStore(time.Hour)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+	ctx := context.Background()
+	req := &games_pb.SaveGameRequest{UserId: 1, GameId: "nethack", IdempotencyKey: "key"}
+
+	var saves atomic.Int32
+	fail := true
+	save := func() (*games_pb.SaveGameResponse, error) {
+		saves.Add(1)
+		if fail {
+			return nil, errors.New("disk full")
+		}
+		return &games_pb.SaveGameResponse{}, nil
+	}
+
+	// A failed request is not remembered
+	_, err := idempotent(ctx, store, req, save)
+	assert.Error(t, err)
+	fail = false
+	_, err = idempotent(ctx, store, req, save)
+	require.NoError(t, err)
+	_, err = idempotent(ctx, store, req, save)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), saves.Load())
+
+	// A result is replayed until it expires
+	now = now.Add(2 * time.Hour)
+	_, err = idempotent(ctx, store, req, save)
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), saves.Load())
+}
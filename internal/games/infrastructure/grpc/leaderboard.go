@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// defaultLeaderboardLimit is used when GetLeaderboard is called without a limit
+const defaultLeaderboardLimit = 10
+
+// GetLeaderboard ranks the players of a game, optionally only the given users
+func (s *GameServiceServer) GetLeaderboard(ctx context.Context, req *games_pb.GetLeaderboardRequest) (*games_pb.GetLeaderboardResponse, error) {
+	if req.GameId == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+	sortBy, err := domain.ParseLeaderboardSort(req.SortBy)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultLeaderboardLimit
+	}
+	userIDs := make([]int, len(req.UserIds))
+	for i, userID := range req.UserIds {
+		userIDs[i] = int(userID)
+	}
+
+	entries, total, err := s.sessionService.Leaderboard(ctx, req.GameId, userIDs, sortBy, limit)
+	if err != nil {
+		s.logger.Error("Failed to build leaderboard", "game_id", req.GameId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to build leaderboard")
+	}
+
+	resp := &games_pb.GetLeaderboardResponse{TotalPlayers: int32(total)}
+	for _, entry := range entries {
+		resp.Entries = append(resp.Entries, &games_pb.LeaderboardEntry{
+			Rank:                  int32(entry.Rank),
+			UserId:                int32(entry.UserID.Int()),
+			Username:              entry.Username,
+			GamesPlayed:           int32(entry.GamesPlayed),
+			TotalPlayTimeSeconds:  int64(entry.PlayTime.Seconds()),
+			LongestSessionSeconds: int64(entry.LongestSession.Seconds()),
+			LastPlayed:            timestamppb.New(entry.LastPlayed),
+		})
+	}
+	return resp, nil
+}
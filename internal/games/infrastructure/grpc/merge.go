@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/application"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// MergeUserData moves a user's finished sessions, dumplogs and saves to the
+// user their account was merged into. Sessions go first, so a user with a
+// game running is refused before anything moves.
+func (s *GameServiceServer) MergeUserData(ctx context.Context, req *games_pb.MergeUserDataRequest) (*games_pb.MergeUserDataResponse, error) {
+	if s.sessionService == nil {
+		return nil, status.Error(codes.Unavailable, "session service not available")
+	}
+	if req.FromUserId <= 0 || req.ToUserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "from_user_id and to_user_id are required")
+	}
+	if req.FromUserId == req.ToUserId {
+		return nil, status.Error(codes.InvalidArgument, "cannot merge a user into itself")
+	}
+
+	from, to := int(req.FromUserId), int(req.ToUserId)
+	var summary application.UserMergeSummary
+	if err := s.sessionService.MergeUserSessions(ctx, from, to, req.ToUsername, req.DryRun, &summary); err != nil {
+		s.logger.WarnContext(ctx, "Failed to merge user sessions", "from_user_id", from, "to_user_id", to, "error", err)
+		return nil, apierror.Status(err, "failed to merge sessions").Err()
+	}
+	if s.dumplogService != nil {
+		if err := s.dumplogService.MergeUserDumplogs(ctx, from, to, req.ToUsername, req.DryRun, &summary); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to merge user dumplogs", "from_user_id", from, "to_user_id", to, "error", err)
+			return nil, status.Error(codes.Internal, "failed to merge dumplogs")
+		}
+	}
+	if s.saveService != nil {
+		if err := s.saveService.MergeUserSaves(ctx, from, to, req.DryRun, &summary); err != nil {
+			s.logger.ErrorContext(ctx, "Failed to merge user saves", "from_user_id", from, "to_user_id", to, "error", err)
+			return nil, status.Error(codes.Internal, "failed to merge saves")
+		}
+	}
+
+	if !req.DryRun {
+		s.logger.InfoContext(ctx, "Merged user game data",
+			"audit", true,
+			"from_user_id", from,
+			"to_user_id", to,
+			"sessions", summary.Sessions,
+			"dumplogs", summary.Dumplogs,
+			"saves", summary.Saves,
+			"skipped_saves", summary.SkippedSaves)
+	}
+
+	return &games_pb.MergeUserDataResponse{
+		Sessions:     int32(summary.Sessions),
+		Recordings:   int32(summary.Recordings),
+		Dumplogs:     int32(summary.Dumplogs),
+		Saves:        int32(summary.Saves),
+		SkippedSaves: int32(summary.SkippedSaves),
+	}, nil
+}
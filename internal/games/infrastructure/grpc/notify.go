@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/dungeongate/internal/games/domain"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+const (
+	// notificationGameCrashed is the kind of notification left for a crashed
+	// game, as the auth service names it
+	notificationGameCrashed = "game_crashed"
+	// notificationFriendPlaying is the kind of notification left for the
+	// friends of a player starting a game
+	notificationFriendPlaying = "friend_playing"
+	// notifyTimeout bounds a call to the auth service
+	notifyTimeout = 5 * time.Second
+)
+
+// crashSignals are the signals a game dies from when it crashes, rather than
+// being stopped or hung up on
+var crashSignals = map[string]bool{
+	syscall.SIGSEGV.String(): true,
+	syscall.SIGBUS.String():  true,
+	syscall.SIGILL.String():  true,
+	syscall.SIGFPE.String():  true,
+	syscall.SIGABRT.String(): true,
+}
+
+// Notifier leaves notifications for players; authv1.AuthServiceClient
+// implements it
+type Notifier interface {
+	NotifyUser(ctx context.Context, in *authv1.NotifyUserRequest, opts ...grpc.CallOption) (*authv1.AdminActionResponse, error)
+	NotifyFriends(ctx context.Context, in *authv1.NotifyUserRequest, opts ...grpc.CallOption) (*authv1.AdminActionResponse, error)
+}
+
+// SetNotifier sets where players are told about their crashed games and
+// their friends' new games
+func (s *GameServiceServer) SetNotifier(notifier Notifier) {
+	s.notifier = notifier
+}
+
+// notifyCrash tells a player their game crashed when it died from a crash
+// signal
+func (s *GameServiceServer) notifyCrash(session *domain.GameSession, gameConfig *config.GameConfig, signal *string) {
+	if s.notifier == nil || signal == nil || !crashSignals[*signal] {
+		return
+	}
+
+	req := &authv1.NotifyUserRequest{
+		Username: session.Username(),
+		Kind:     notificationGameCrashed,
+		Title:    fmt.Sprintf("Your %s game crashed", gameName(session, gameConfig)),
+		Body: fmt.Sprintf("The game was killed by %s at %s. Please tell an admin if it keeps happening.",
+			*signal, time.Now().UTC().Format("2006-01-02 15:04 MST")),
+	}
+	s.sendNotification(session, "Failed to notify player of crashed game", s.notifier.NotifyUser, req)
+}
+
+// notifyFriendsStarted tells the friends of a player who asked to hear about
+// it that the player started a game
+func (s *GameServiceServer) notifyFriendsStarted(session *domain.GameSession, gameConfig *config.GameConfig) {
+	if s.notifier == nil {
+		return
+	}
+
+	req := &authv1.NotifyUserRequest{
+		Username: session.Username(),
+		Kind:     notificationFriendPlaying,
+		Title:    fmt.Sprintf("Your friend %s just started %s", session.Username(), gameName(session, gameConfig)),
+		Body:     "Watch them from the watch menu while the game lasts.",
+	}
+	s.sendNotification(session, "Failed to notify friends of started game", s.notifier.NotifyFriends, req)
+}
+
+// sendNotification sends a notification in the background so a slow auth
+// service never holds up the game
+func (s *GameServiceServer) sendNotification(session *domain.GameSession, failure string,
+	send func(context.Context, *authv1.NotifyUserRequest, ...grpc.CallOption) (*authv1.AdminActionResponse, error),
+	req *authv1.NotifyUserRequest) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), notifyTimeout)
+		defer cancel()
+
+		resp, err := send(ctx, req)
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("%s", resp.Error)
+		}
+		if err != nil {
+			s.logger.Warn(failure, "error", err, "session_id", session.ID().String(), "username", req.Username)
+		}
+	}()
+}
+
+// gameName returns the name players know a session's game by
+func gameName(session *domain.GameSession, gameConfig *config.GameConfig) string {
+	if gameConfig != nil && gameConfig.Name != "" {
+		return gameConfig.Name
+	}
+	return session.GameID().String()
+}
@@ -0,0 +1,78 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// fakeNotifier records the notifications sent through it
+type fakeNotifier struct {
+	requests chan *authv1.NotifyUserRequest
+}
+
+func (f *fakeNotifier) NotifyUser(ctx context.Context, in *authv1.NotifyUserRequest, opts ...grpc.CallOption) (*authv1.AdminActionResponse, error) {
+	f.requests <- in
+	return &authv1.AdminActionResponse{Success: true}, nil
+}
+
+func (f *fakeNotifier) NotifyFriends(ctx context.Context, in *authv1.NotifyUserRequest, opts ...grpc.CallOption) (*authv1.AdminActionResponse, error) {
+	f.requests <- in
+	return &authv1.AdminActionResponse{Success: true}, nil
+}
+
+func TestNotifyCrash(t *testing.T) {
+	notifier := &fakeNotifier{requests: make(chan *authv1.NotifyUserRequest, 4)}
+	server := &GameServiceServer{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	server.SetNotifier(notifier)
+	session := createMockSessionWithID("crashed", 7, "nethack")
+	gameConfig := &config.GameConfig{ID: "nethack", Name: "NetHack"}
+
+	// Games stopped by the player or the server are not crashes
+	hangup := syscall.SIGHUP.String()
+	server.notifyCrash(session, gameConfig, nil)
+	server.notifyCrash(session, gameConfig, &hangup)
+
+	segfault := syscall.SIGSEGV.String()
+	server.notifyCrash(session, gameConfig, &segfault)
+
+	select {
+	case req := <-notifier.requests:
+		assert.Equal(t, "testuser", req.Username)
+		assert.Equal(t, notificationGameCrashed, req.Kind)
+		assert.Equal(t, "Your NetHack game crashed", req.Title)
+		assert.Contains(t, req.Body, segfault)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timed out waiting for the crash notification")
+	}
+	assert.Empty(t, notifier.requests)
+}
+
+func TestNotifyFriendsStarted(t *testing.T) {
+	notifier := &fakeNotifier{requests: make(chan *authv1.NotifyUserRequest, 4)}
+	server := &GameServiceServer{logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+	server.notifyFriendsStarted(createMockSessionWithID("started", 7, "nethack"), nil)
+
+	server.SetNotifier(notifier)
+	server.notifyFriendsStarted(createMockSessionWithID("started", 7, "nethack"), &config.GameConfig{ID: "nethack", Name: "NetHack"})
+
+	select {
+	case req := <-notifier.requests:
+		assert.Equal(t, "testuser", req.Username)
+		assert.Equal(t, notificationFriendPlaying, req.Kind)
+		assert.Equal(t, "Your friend testuser just started NetHack", req.Title)
+	case <-time.After(2 * time.Second):
+		require.Fail(t, "timed out waiting for the friend notification")
+	}
+	assert.Empty(t, notifier.requests)
+}
@@ -0,0 +1,39 @@
+package grpc
+
+import (
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/config"
+)
+
+// recordingPolicy returns the game's recording policy; games with recording
+// disabled record nothing
+func recordingPolicy(gameConfig *config.GameConfig) domain.RecordingPolicy {
+	if gameConfig.Settings == nil || gameConfig.Settings.Recording == nil {
+		return domain.RecordingPolicyPlayer
+	}
+	recording := gameConfig.Settings.Recording
+	if !recording.Enabled {
+		return domain.RecordingPolicyNever
+	}
+	if recording.Policy == "" {
+		return domain.RecordingPolicyPlayer
+	}
+	return domain.RecordingPolicy(recording.Policy)
+}
+
+// hidePrivateRecording leaves a private recording out of a session shown to
+// anyone but its player. A zero viewerID is an anonymous viewer.
+func hidePrivateRecording(pbSession *games_pb.GameSession, session *domain.GameSession, viewerID int32) {
+	if session.RecordingInfo() != nil && !session.CanViewRecording(domain.NewUserID(int(viewerID))) {
+		pbSession.Recording = nil
+	}
+}
+
+// publicSessionToPb converts a session for broadcast to every subscriber,
+// without private recordings
+func (s *GameServiceServer) publicSessionToPb(session *domain.GameSession) *games_pb.GameSession {
+	pbSession := s.domainSessionToPb(session)
+	hidePrivateRecording(pbSession, session, 0)
+	return pbSession
+}
@@ -0,0 +1,68 @@
+package grpc
+
+import (
+	"cmp"
+	"slices"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// sessionUsageKeys are the ListGameSessions sort_by values and the usage
+// each sorts by
+var sessionUsageKeys = map[string]func(*games_pb.ResourceUsage) float64{
+	"cpu":  func(u *games_pb.ResourceUsage) float64 { return u.CpuSeconds },
+	"rss":  func(u *games_pb.ResourceUsage) float64 { return float64(u.RssBytes) },
+	"pids": func(u *games_pb.ResourceUsage) float64 { return float64(u.Pids) },
+	"io":   func(u *games_pb.ResourceUsage) float64 { return float64(u.IoReadBytes + u.IoWriteBytes) },
+}
+
+// resourceUsage returns what a running game's processes have used, nil when
+// the game is not running here or not in a cgroup
+func (s *GameServiceServer) resourceUsage(sessionID string) *games_pb.ResourceUsage {
+	if s.ptyManager == nil {
+		return nil
+	}
+	ptySession, err := s.ptyManager.GetPTY(sessionID)
+	if err != nil {
+		return nil
+	}
+	usage, ok := ptySession.ResourceUsage()
+	if !ok {
+		return nil
+	}
+	return &games_pb.ResourceUsage{
+		CpuSeconds:   usage.CPUSeconds,
+		RssBytes:     usage.RSSBytes,
+		Pids:         usage.PIDs,
+		IoReadBytes:  usage.IOReadBytes,
+		IoWriteBytes: usage.IOWriteBytes,
+	}
+}
+
+// sortSessionsByUsage orders sessions by the usage sortBy names, heaviest
+// first. Sessions without usage come last in their original order; an empty
+// sortBy keeps the order.
+func sortSessionsByUsage(sessions []*games_pb.GameSession, sortBy string) error {
+	if sortBy == "" {
+		return nil
+	}
+	key, ok := sessionUsageKeys[sortBy]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown sort_by %q: use cpu, rss, pids or io", sortBy)
+	}
+	slices.SortStableFunc(sessions, func(a, b *games_pb.GameSession) int {
+		switch {
+		case a.ResourceUsage == nil && b.ResourceUsage == nil:
+			return 0
+		case a.ResourceUsage == nil:
+			return 1
+		case b.ResourceUsage == nil:
+			return -1
+		}
+		return cmp.Compare(key(b.ResourceUsage), key(a.ResourceUsage))
+	})
+	return nil
+}
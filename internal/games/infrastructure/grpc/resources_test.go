@@ -0,0 +1,81 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/infrastructure/pty"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+func TestSortSessionsByUsage(t *testing.T) {
+	sessions := []*games_pb.GameSession{
+		{Id: "idle"},
+		{Id: "light", ResourceUsage: &games_pb.ResourceUsage{CpuSeconds: 1.5, RssBytes: 4 << 20, Pids: 1, IoWriteBytes: 100}},
+		{Id: "untracked"},
+		{Id: "heavy", ResourceUsage: &games_pb.ResourceUsage{CpuSeconds: 90, RssBytes: 2 << 20, Pids: 3, IoReadBytes: 50}},
+	}
+	ids := func() []string {
+		var ids []string
+		for _, session := range sessions {
+			ids = append(ids, session.Id)
+		}
+		return ids
+	}
+
+	require.NoError(t, sortSessionsByUsage(sessions, ""))
+	assert.Equal(t, []string{"idle", "light", "untracked", "heavy"}, ids())
+
+	require.NoError(t, sortSessionsByUsage(sessions, "cpu"))
+	assert.Equal(t, []string{"heavy", "light", "idle", "untracked"}, ids())
+
+	require.NoError(t, sortSessionsByUsage(sessions, "rss"))
+	assert.Equal(t, []string{"light", "heavy", "idle", "untracked"}, ids())
+
+	require.NoError(t, sortSessionsByUsage(sessions, "pids"))
+	assert.Equal(t, []string{"heavy", "light", "idle", "untracked"}, ids())
+
+	require.NoError(t, sortSessionsByUsage(sessions, "io"))
+	assert.Equal(t, []string{"light", "heavy", "idle", "untracked"}, ids())
+
+	err := sortSessionsByUsage(sessions, "disk")
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestListGameSessions_SortByUsage(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		ptyManager:     pty.NewPTYManager(slog.New(slog.NewTextHandler(io.Discard, nil))),
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	ctx := context.Background()
+	require.NoError(t, sessionRepo.Save(ctx, createMockSessionWithID("session_a", 1, "nethack")))
+
+	// Games without a PTY here report no usage
+	resp, err := server.ListGameSessions(ctx, &games_pb.ListGameSessionsRequest{SortBy: "cpu"})
+	require.NoError(t, err)
+	require.Len(t, resp.Sessions, 1)
+	assert.Nil(t, resp.Sessions[0].ResourceUsage)
+
+	_, err = server.ListGameSessions(ctx, &games_pb.ListGameSessionsRequest{SortBy: "memory"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Without cgroups usage is not accounted
+	assert.False(t, server.features().Has(capabilities.ResourceUsage))
+}
@@ -0,0 +1,328 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+)
+
+// storeSave stores save data uploaded with SaveGame
+func (s *GameServiceServer) storeSave(ctx context.Context, req *games_pb.SaveGameRequest) (*games_pb.SaveGameResponse, error) {
+	if s.saveService == nil {
+		return nil, status.Error(codes.Unavailable, "save service not available")
+	}
+
+	save, err := s.saveService.StoreSave(ctx, int(req.UserId), req.GameId, req.Data, pbSaveMetadataToDomain(req.Metadata))
+	if err != nil {
+		if aborted := conflictError(err); aborted != nil {
+			s.logger.InfoContext(ctx, "Save changed while storing", "user_id", req.UserId, "game_id", req.GameId, "error", err)
+			return nil, aborted
+		}
+		s.logger.ErrorContext(ctx, "Failed to store save", "user_id", req.UserId, "game_id", req.GameId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to store save")
+	}
+
+	return &games_pb.SaveGameResponse{
+		Save: domainSaveToPb(save, false),
+	}, nil
+}
+
+// LoadGame returns a save and its data after verifying its checksum. A
+// corrupt save is transparently restored from its newest intact backup.
+func (s *GameServiceServer) LoadGame(ctx context.Context, req *games_pb.LoadGameRequest) (*games_pb.LoadGameResponse, error) {
+	if s.saveService == nil {
+		return nil, status.Error(codes.Unavailable, "save service not available")
+	}
+
+	if req.SaveId == "" {
+		return nil, status.Error(codes.InvalidArgument, "save_id is required")
+	}
+
+	save, err := s.saveService.LoadSave(ctx, int(req.UserId), req.SaveId)
+	if err != nil {
+		if !errors.Is(err, domain.ErrSaveNotFound) && !errors.Is(err, domain.ErrSaveCorrupt) {
+			s.logger.ErrorContext(ctx, "Failed to load save", "save_id", req.SaveId, "error", err)
+		}
+		return nil, saveError(err)
+	}
+
+	return &games_pb.LoadGameResponse{
+		Save: domainSaveToPb(save, true),
+	}, nil
+}
+
+// ListSaves lists a user's saves without their data. Each save's status
+// reflects a fresh checksum verification of its file.
+func (s *GameServiceServer) ListSaves(ctx context.Context, req *games_pb.ListSavesRequest) (*games_pb.ListSavesResponse, error) {
+	if s.saveService == nil {
+		return nil, status.Error(codes.Unavailable, "save service not available")
+	}
+
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	saves, err := s.saveService.ListSaves(ctx, int(req.UserId), req.GameId, pbSaveStatusToDomain(req.Status))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list saves", "user_id", req.UserId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list saves")
+	}
+
+	total := len(saves)
+	if offset := int(req.Offset); offset > 0 {
+		if offset > len(saves) {
+			offset = len(saves)
+		}
+		saves = saves[offset:]
+	}
+	if limit := int(req.Limit); limit > 0 && limit < len(saves) {
+		saves = saves[:limit]
+	}
+
+	pbSaves := make([]*games_pb.GameSave, len(saves))
+	for i, save := range saves {
+		pbSaves[i] = domainSaveToPb(save, false)
+	}
+
+	return &games_pb.ListSavesResponse{
+		Saves:      pbSaves,
+		TotalCount: int32(total),
+	}, nil
+}
+
+// ExportSave packages a user's save into a signed bundle that can be imported
+// by another server
+func (s *GameServiceServer) ExportSave(ctx context.Context, req *games_pb.ExportSaveRequest) (*games_pb.ExportSaveResponse, error) {
+	if s.saveService == nil {
+		return nil, status.Error(codes.Unavailable, "save service not available")
+	}
+
+	if req.UserId <= 0 || req.SaveId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id and save_id are required")
+	}
+
+	save, err := s.saveService.LoadSave(ctx, int(req.UserId), req.SaveId)
+	if err != nil {
+		return nil, saveError(err)
+	}
+
+	data, bundle, err := s.saveService.ExportSave(save, req.Username, s.installedVersion(save.GameID().String()))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to export save", "save_id", req.SaveId, "error", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &games_pb.ExportSaveResponse{
+		Bundle:      data,
+		GameId:      bundle.GameID,
+		GameVersion: bundle.GameVersion,
+	}, nil
+}
+
+// ImportSave stores a save bundle as the user's save for its game, after
+// checking its signature, its owner, that it is not older than the user's
+// save and that the installed game can load it
+func (s *GameServiceServer) ImportSave(ctx context.Context, req *games_pb.ImportSaveRequest) (*games_pb.ImportSaveResponse, error) {
+	if s.saveService == nil {
+		return nil, status.Error(codes.Unavailable, "save service not available")
+	}
+
+	if req.UserId <= 0 || len(req.Bundle) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id and bundle are required")
+	}
+
+	bundle, err := s.saveService.OpenBundle(req.Bundle)
+	if errors.Is(err, application.ErrSaveBundleUntrusted) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	gameConfig := s.gameConfig(bundle.GameID)
+	if gameConfig == nil {
+		return nil, apierror.New(apierror.GameNotFound, "game not found: "+bundle.GameID)
+	}
+	if err := s.compatibleVersion(gameConfig, bundle.GameVersion); err != nil {
+		return nil, apierror.New(apierror.SaveIncompatible, err.Error())
+	}
+
+	importer := application.BundleImporter{UserID: int(req.UserId), Username: req.Username, Admin: req.Admin}
+	save, err := s.saveService.ImportBundle(ctx, importer, bundle)
+	if err != nil {
+		if errors.Is(err, application.ErrSaveBundleNotOwned) || errors.Is(err, application.ErrSaveBundleOutdated) {
+			s.logger.WarnContext(ctx, "Save bundle import refused", "user_id", req.UserId, "game_id", bundle.GameID,
+				"bundle_server", bundle.Server, "bundle_user", bundle.Username, "error", err)
+			return nil, err
+		}
+		if aborted := conflictError(err); aborted != nil {
+			s.logger.InfoContext(ctx, "Save changed while importing", "user_id", req.UserId, "game_id", bundle.GameID, "error", err)
+			return nil, aborted
+		}
+		s.logger.ErrorContext(ctx, "Failed to import save", "user_id", req.UserId, "game_id", bundle.GameID, "error", err)
+		return nil, status.Error(codes.Internal, "failed to import save")
+	}
+
+	return &games_pb.ImportSaveResponse{
+		Save:         domainSaveToPb(save, false),
+		SourceServer: bundle.Server,
+		Signed:       bundle.Signed,
+	}, nil
+}
+
+// saveError converts a save lookup error to a gRPC status
+func saveError(err error) error {
+	if aborted := conflictError(err); aborted != nil {
+		return aborted
+	}
+	return apierror.Status(err, "failed to load save").Err()
+}
+
+// gameConfig returns the configuration of a game, or nil if it is not configured
+func (s *GameServiceServer) gameConfig(gameID string) *config.GameConfig {
+	return findGame(s.games(), gameID)
+}
+
+// installedVersion returns the default version of a game, if known
+func (s *GameServiceServer) installedVersion(gameID string) string {
+	if cfg := s.gameConfig(gameID); cfg != nil {
+		return cfg.DefaultVersion()
+	}
+	return ""
+}
+
+// compatibleVersion returns an error if no installed version of a game can
+// load a save written by saveVersion
+func (s *GameServiceServer) compatibleVersion(gameConfig *config.GameConfig, saveVersion string) error {
+	var err error
+	for _, version := range gameConfig.AvailableVersions() {
+		adapter := s.adapters.GetAdapterForVersion(gameConfig.ID, version)
+		if err = adapter.CheckSaveCompatibility(saveVersion, version); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// checkSaveCompatibility refuses to start a version of a game that cannot
+// load the user's current save for it
+func (s *GameServiceServer) checkSaveCompatibility(ctx context.Context, userID int, gameID, version string) error {
+	if s.saveService == nil {
+		return nil
+	}
+
+	save, err := s.saveService.CurrentSave(ctx, userID, gameID)
+	if err != nil {
+		return nil
+	}
+
+	saveVersion := save.Metadata().GameVersion
+	adapter := s.adapters.GetAdapterForVersion(gameID, version)
+	if err := adapter.CheckSaveCompatibility(saveVersion, version); err != nil {
+		return apierror.New(apierror.SaveIncompatible, fmt.Sprintf("saved game needs version %s: %v", saveVersion, err))
+	}
+	return nil
+}
+
+// domainSaveToPb converts a domain GameSave to protobuf, optionally including its data
+func domainSaveToPb(save *domain.GameSave, withData bool) *games_pb.GameSave {
+	metadata := save.Metadata()
+	pbSave := &games_pb.GameSave{
+		Id:       save.ID().String(),
+		UserId:   int32(save.UserID().Int()),
+		GameId:   save.GameID().String(),
+		Status:   domainSaveStatusToPb(save.Status()),
+		Checksum: save.Checksum(),
+		FilePath: save.FilePath(),
+		FileSize: save.FileSize(),
+		Metadata: &games_pb.SaveMetadata{
+			GameVersion:     metadata.GameVersion,
+			Character:       metadata.Character,
+			Level:           int32(metadata.Level),
+			Score:           int32(metadata.Score),
+			PlayTimeSeconds: int64(metadata.PlayTime.Seconds()),
+			Location:        metadata.Location,
+			CustomFields:    metadata.CustomFields,
+		},
+		CreatedAt: timestamppb.New(save.CreatedAt()),
+		UpdatedAt: timestamppb.New(save.UpdatedAt()),
+	}
+	if !save.VerifiedAt().IsZero() {
+		pbSave.VerifiedAt = timestamppb.New(save.VerifiedAt())
+	}
+	if withData {
+		pbSave.Data = save.Data()
+	}
+
+	for _, backup := range save.Backups() {
+		pbSave.Backups = append(pbSave.Backups, &games_pb.SaveBackup{
+			Id:        backup.ID,
+			FilePath:  backup.FilePath,
+			CreatedAt: timestamppb.New(backup.CreatedAt),
+			FileSize:  backup.FileSize,
+			Checksum:  backup.Checksum,
+		})
+	}
+
+	return pbSave
+}
+
+// pbSaveMetadataToDomain converts protobuf SaveMetadata to the domain type
+func pbSaveMetadataToDomain(metadata *games_pb.SaveMetadata) domain.SaveMetadata {
+	if metadata == nil {
+		return domain.SaveMetadata{}
+	}
+	return domain.SaveMetadata{
+		GameVersion:  metadata.GameVersion,
+		Character:    metadata.Character,
+		Level:        int(metadata.Level),
+		Score:        int(metadata.Score),
+		PlayTime:     time.Duration(metadata.PlayTimeSeconds) * time.Second,
+		Location:     metadata.Location,
+		CustomFields: metadata.CustomFields,
+	}
+}
+
+// domainSaveStatusToPb converts domain SaveStatus to protobuf SaveStatus
+func domainSaveStatusToPb(status domain.SaveStatus) games_pb.SaveStatus {
+	switch status {
+	case domain.SaveStatusActive:
+		return games_pb.SaveStatus_SAVE_STATUS_ACTIVE
+	case domain.SaveStatusCorrupt:
+		return games_pb.SaveStatus_SAVE_STATUS_CORRUPT
+	case domain.SaveStatusArchived:
+		return games_pb.SaveStatus_SAVE_STATUS_ARCHIVED
+	case domain.SaveStatusDeleted:
+		return games_pb.SaveStatus_SAVE_STATUS_DELETED
+	default:
+		return games_pb.SaveStatus_SAVE_STATUS_UNSPECIFIED
+	}
+}
+
+// pbSaveStatusToDomain converts protobuf SaveStatus to the domain type, with
+// unspecified matching any status
+func pbSaveStatusToDomain(status games_pb.SaveStatus) domain.SaveStatus {
+	switch status {
+	case games_pb.SaveStatus_SAVE_STATUS_ACTIVE:
+		return domain.SaveStatusActive
+	case games_pb.SaveStatus_SAVE_STATUS_CORRUPT:
+		return domain.SaveStatusCorrupt
+	case games_pb.SaveStatus_SAVE_STATUS_ARCHIVED:
+		return domain.SaveStatusArchived
+	case games_pb.SaveStatus_SAVE_STATUS_DELETED:
+		return domain.SaveStatusDeleted
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// defaultScreenshotListLimit is used when ListScreenshots is called without a limit
+const defaultScreenshotListLimit = 20
+
+// SetScreenshotService lets players and spectators take screenshots of games
+func (s *GameServiceServer) SetScreenshotService(screenshots *application.ScreenshotService) {
+	s.screenshots = screenshots
+}
+
+// TakeScreenshot stores a screen captured by the session service. The
+// player and the session's spectators may take screenshots of a game.
+func (s *GameServiceServer) TakeScreenshot(ctx context.Context, req *games_pb.TakeScreenshotRequest) (*games_pb.TakeScreenshotResponse, error) {
+	if s.screenshots == nil {
+		return nil, status.Error(codes.Unavailable, "screenshots are not enabled")
+	}
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+
+	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, apierror.Status(domain.ErrSessionNotFound, "session not found").Err()
+	}
+	takenBy := domain.NewUserID(int(req.UserId))
+	if session.UserID() != takenBy && !session.IsSpectator(takenBy) {
+		return nil, status.Error(codes.PermissionDenied, "only the player and spectators may take screenshots of a game")
+	}
+
+	screenshot, err := s.screenshots.TakeScreenshot(ctx, session, application.TakeScreenshotRequest{
+		TakenBy:     int(req.UserId),
+		TakenByName: req.Username,
+		Cols:        int(req.Cols),
+		Rows:        int(req.Rows),
+		Renderings: map[domain.ScreenshotFormat]string{
+			domain.ScreenshotText: req.Text,
+			domain.ScreenshotANSI: req.Ansi,
+			domain.ScreenshotHTML: req.Html,
+		},
+	})
+	if err != nil {
+		s.logger.WarnContext(ctx, "Failed to take screenshot", "session_id", req.SessionId, "user_id", req.UserId, "error", err)
+		return nil, apierror.Status(err, "failed to take screenshot").Err()
+	}
+
+	s.logger.InfoContext(ctx, "Screenshot taken",
+		"screenshot_id", screenshot.ID().String(),
+		"session_id", req.SessionId,
+		"taken_by", req.Username,
+		"spectator", screenshot.BySpectator())
+	return &games_pb.TakeScreenshotResponse{Screenshot: s.domainScreenshotToPb(screenshot, false)}, nil
+}
+
+// ListScreenshots lists the screenshots a user took or that were taken of
+// their games, without their renderings
+func (s *GameServiceServer) ListScreenshots(ctx context.Context, req *games_pb.ListScreenshotsRequest) (*games_pb.ListScreenshotsResponse, error) {
+	if s.screenshots == nil {
+		return nil, status.Error(codes.Unavailable, "screenshots are not enabled")
+	}
+	if req.UserId <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultScreenshotListLimit
+	}
+	screenshots, err := s.screenshots.ListScreenshots(ctx, int(req.UserId), req.SessionId, limit)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list screenshots", "user_id", req.UserId, "error", err)
+		return nil, status.Error(codes.Internal, "failed to list screenshots")
+	}
+
+	pbScreenshots := make([]*games_pb.Screenshot, len(screenshots))
+	for i, screenshot := range screenshots {
+		pbScreenshots[i] = s.domainScreenshotToPb(screenshot, false)
+	}
+	return &games_pb.ListScreenshotsResponse{Screenshots: pbScreenshots}, nil
+}
+
+// GetScreenshot returns a screenshot with its renderings to the player or
+// whoever took it
+func (s *GameServiceServer) GetScreenshot(ctx context.Context, req *games_pb.GetScreenshotRequest) (*games_pb.GetScreenshotResponse, error) {
+	if s.screenshots == nil {
+		return nil, status.Error(codes.Unavailable, "screenshots are not enabled")
+	}
+	if req.ScreenshotId == "" {
+		return nil, status.Error(codes.InvalidArgument, "screenshot_id is required")
+	}
+
+	screenshot, err := s.screenshots.GetScreenshot(ctx, req.ScreenshotId)
+	if err != nil {
+		return nil, apierror.Status(err, "failed to get screenshot").Err()
+	}
+	// Others are not told the screenshot exists
+	if !screenshot.CanView(domain.NewUserID(int(req.UserId))) {
+		return nil, apierror.Status(domain.ErrScreenshotNotFound, "screenshot not found").Err()
+	}
+	return &games_pb.GetScreenshotResponse{Screenshot: s.domainScreenshotToPb(screenshot, true)}, nil
+}
+
+// ShareScreenshot gives a screenshot a short-lived public link
+func (s *GameServiceServer) ShareScreenshot(ctx context.Context, req *games_pb.ShareScreenshotRequest) (*games_pb.ShareScreenshotResponse, error) {
+	if s.screenshots == nil {
+		return nil, status.Error(codes.Unavailable, "screenshots are not enabled")
+	}
+	if req.ScreenshotId == "" {
+		return nil, status.Error(codes.InvalidArgument, "screenshot_id is required")
+	}
+
+	screenshot, err := s.screenshots.ShareScreenshot(ctx, req.ScreenshotId, int(req.UserId))
+	if err != nil {
+		return nil, apierror.Status(err, "failed to share screenshot").Err()
+	}
+	s.logger.InfoContext(ctx, "Screenshot shared",
+		"screenshot_id", req.ScreenshotId,
+		"user_id", req.UserId,
+		"expires_at", screenshot.ShareExpiresAt())
+	return &games_pb.ShareScreenshotResponse{Screenshot: s.domainScreenshotToPb(screenshot, false)}, nil
+}
+
+// domainScreenshotToPb converts a domain Screenshot to protobuf, optionally
+// including its renderings
+func (s *GameServiceServer) domainScreenshotToPb(screenshot *domain.Screenshot, withContent bool) *games_pb.Screenshot {
+	cols, rows := screenshot.Size()
+	pbScreenshot := &games_pb.Screenshot{
+		Id:              screenshot.ID().String(),
+		SessionId:       screenshot.SessionID().String(),
+		UserId:          int32(screenshot.UserID().Int()),
+		Username:        screenshot.Username(),
+		GameId:          screenshot.GameID().String(),
+		TakenByUserId:   int32(screenshot.TakenBy().Int()),
+		TakenByUsername: screenshot.TakenByName(),
+		Cols:            int32(cols),
+		Rows:            int32(rows),
+		Size:            screenshot.Bytes(),
+		TakenAt:         timestamppb.New(screenshot.TakenAt()),
+	}
+	if url := s.screenshots.ShareURL(screenshot); url != "" {
+		pbScreenshot.ShareUrl = url
+		pbScreenshot.ShareExpiresAt = timestamppb.New(screenshot.ShareExpiresAt())
+	}
+	if withContent {
+		pbScreenshot.Text = screenshot.Content(domain.ScreenshotText)
+		pbScreenshot.Ansi = screenshot.Content(domain.ScreenshotANSI)
+		pbScreenshot.Html = screenshot.Content(domain.ScreenshotHTML)
+	}
+	return pbScreenshot
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+func TestScreenshots(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	ctx := context.Background()
+	session := createMockSessionWithID("session_a", 1, "nethack")
+	session.EnableStreaming("grpc", false)
+	require.NoError(t, sessionRepo.Save(ctx, session))
+
+	_, err := server.TakeScreenshot(ctx, &games_pb.TakeScreenshotRequest{SessionId: "session_a", UserId: 1, Text: "@"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.NotContains(t, server.features(), capabilities.Screenshots)
+
+	screenshots := application.NewScreenshotService(repository.NewStubScreenshotRepository())
+	screenshots.SetOptions(application.ScreenshotOptions{PublicURL: "https://dg.example.org"})
+	server.SetScreenshotService(screenshots)
+	assert.True(t, server.features().Has(capabilities.Screenshots))
+
+	// Only the player and spectators take screenshots
+	_, err = server.TakeScreenshot(ctx, &games_pb.TakeScreenshotRequest{SessionId: "session_a", UserId: 2, Username: "watcher", Text: "@"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 2, SpectatorUsername: "watcher"})
+	require.NoError(t, err)
+	taken, err := server.TakeScreenshot(ctx, &games_pb.TakeScreenshotRequest{SessionId: "session_a", UserId: 2, Username: "watcher", Cols: 80, Rows: 24, Text: "@", Html: "<pre>@</pre>"})
+	require.NoError(t, err)
+	assert.Equal(t, "testuser", taken.Screenshot.Username)
+	assert.Equal(t, "watcher", taken.Screenshot.TakenByUsername)
+	assert.Empty(t, taken.Screenshot.Text, "renderings are only sent by GetScreenshot")
+
+	_, err = server.TakeScreenshot(ctx, &games_pb.TakeScreenshotRequest{SessionId: "session_missing", UserId: 1, Text: "@"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	// The player sees the screenshots of their game
+	list, err := server.ListScreenshots(ctx, &games_pb.ListScreenshotsRequest{UserId: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Screenshots, 1)
+
+	got, err := server.GetScreenshot(ctx, &games_pb.GetScreenshotRequest{ScreenshotId: taken.Screenshot.Id, UserId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "<pre>@</pre>", got.Screenshot.Html)
+	_, err = server.GetScreenshot(ctx, &games_pb.GetScreenshotRequest{ScreenshotId: taken.Screenshot.Id, UserId: 3})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	shared, err := server.ShareScreenshot(ctx, &games_pb.ShareScreenshotRequest{ScreenshotId: taken.Screenshot.Id, UserId: 2})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(shared.Screenshot.ShareUrl, "https://dg.example.org/shared/screenshots/"))
+	assert.NotNil(t, shared.Screenshot.ShareExpiresAt)
+	_, err = server.ShareScreenshot(ctx, &games_pb.ShareScreenshotRequest{ScreenshotId: taken.Screenshot.Id, UserId: 3})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
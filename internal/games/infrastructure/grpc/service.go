@@ -2,9 +2,14 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"os/exec"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -16,7 +21,12 @@ import (
 	"github.com/dungeongate/internal/games/domain"
 	"github.com/dungeongate/internal/games/infrastructure/pty"
 	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/buildinfo"
 	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/termtype"
 )
 
 // GameServiceServer implements the gRPC GameService interface
@@ -24,14 +34,35 @@ type GameServiceServer struct {
 	games_pb.UnimplementedGameServiceServer
 	gameService    *application.GameService
 	sessionService *application.SessionService
+	dumplogService *application.DumplogService
+	saveService    *application.SaveService
+	screenshots    *application.ScreenshotService // Nil when screenshots are disabled
+	bookmarks      *application.BookmarkService
+	feed           *application.Feed // Nil when the public feed is disabled
 	ptyManager     *pty.PTYManager
+	adapters       *adapters.GameAdapterRegistry
 	streamHandler  *StreamHandler
+	captures       *IOCaptures
 	logger         *slog.Logger
-	gameConfigs    []*config.GameConfig
+	notifier       Notifier
+	idempotency    *idempotencyStore
+	build          buildinfo.Info // Build reported by GetCapabilities, Version and Health
+	flags          *featureflags.Flags
+
+	// Set by Shutdown; no new games are started once it is
+	draining atomic.Bool
+
+	// gamesMu guards the games: those configured, those defined in the
+	// database, and the two merged into the games in play
+	gamesMu     sync.RWMutex
+	fileGames   []*config.GameConfig
+	defined     map[string]*config.GameConfig
+	definitions GameDefinitionStore
+	gameConfigs []*config.GameConfig
 }
 
 // NewGameServiceServer creates a new GameServiceServer
-func NewGameServiceServer(cfg *config.GameServiceConfig, gameService *application.GameService, sessionService *application.SessionService, logger *slog.Logger) *GameServiceServer {
+func NewGameServiceServer(cfg *config.GameServiceConfig, gameService *application.GameService, sessionService *application.SessionService, dumplogService *application.DumplogService, saveService *application.SaveService, logger *slog.Logger) *GameServiceServer {
 	// Create adapter registry with configuration
 	adapterRegistry, err := adapters.NewGameAdapterRegistryWithConfig(cfg.Games)
 	if err != nil {
@@ -40,19 +71,62 @@ func NewGameServiceServer(cfg *config.GameServiceConfig, gameService *applicatio
 	}
 
 	// Create PTY manager with configured adapters
-	ptyManager := pty.NewPTYManagerWithAdapters(logger, adapterRegistry)
+	ptyManager := pty.NewPTYManagerWithAdapters(logger.With(logging.ComponentKey, "pty"), adapterRegistry)
+	if cfg.GameEngine != nil {
+		ptyManager.ConfigureIsolation(cfg.GameEngine.Isolation, cfg.GameEngine.IsDevMode())
+	}
 	streamHandler := NewStreamHandler(ptyManager, logger)
+	if cfg.GameEngine != nil {
+		ptyManager.ConfigureHangDetection(cfg.GameEngine.HangDetection, func(sessionID string, degraded bool, reason string) {
+			streamHandler.Health(sessionID, degraded, reason)
+		})
+	}
+
+	// Admins may capture a session's I/O to debug it
+	var gameDataPath string
+	if cfg.Storage != nil {
+		gameDataPath = cfg.Storage.GameDataPath
+	}
+	captures, err := NewIOCaptures(cfg.IOCapture, gameDataPath, func(sessionID string) bool {
+		_, err := ptyManager.GetPTY(sessionID)
+		return err == nil
+	}, logger)
+	if err != nil {
+		logger.Error("Failed to set up I/O capture, capture disabled", "error", err)
+	}
+	streamHandler.captures = captures
 
 	return &GameServiceServer{
 		gameService:    gameService,
 		sessionService: sessionService,
+		dumplogService: dumplogService,
+		saveService:    saveService,
 		ptyManager:     ptyManager,
+		adapters:       adapterRegistry,
 		streamHandler:  streamHandler,
+		captures:       captures,
 		logger:         logger,
+		fileGames:      cfg.Games,
 		gameConfigs:    cfg.Games,
+		idempotency:    newIdempotencyStore(idempotencyTTL),
 	}
 }
 
+// Captures returns the I/O captures of the service, nil when disabled
+func (s *GameServiceServer) Captures() *IOCaptures {
+	return s.captures
+}
+
+// SetHangRecorder sets where games that stop responding are counted
+func (s *GameServiceServer) SetHangRecorder(recorder pty.HangRecorder) {
+	s.ptyManager.SetHangRecorder(recorder)
+}
+
+// SetPanicRecorder sets where panics recovered in game PTY goroutines are counted
+func (s *GameServiceServer) SetPanicRecorder(recorder pty.PanicRecorder) {
+	s.ptyManager.SetPanicRecorder(recorder)
+}
+
 // AddSpectator adds a spectator to a game session
 func (s *GameServiceServer) AddSpectator(ctx context.Context, req *games_pb.AddSpectatorRequest) (*games_pb.AddSpectatorResponse, error) {
 	if req.SessionId == "" {
@@ -76,10 +150,42 @@ func (s *GameServiceServer) AddSpectator(ctx context.Context, req *games_pb.AddS
 		}, status.Error(codes.InvalidArgument, "spectator_username is required")
 	}
 
-	// Add spectator through session service
-	err := s.sessionService.AddSpectator(ctx, req.SessionId, int(req.SpectatorUserId), req.SpectatorUsername)
+	// Add spectator through session service, within the stricter of the
+	// game's limit and the session service's; admins may join full sessions
+	limit := func(gameID string) int {
+		limit := s.spectatorLimit(gameID)
+		if req.MaxSpectators > 0 && (limit == 0 || int(req.MaxSpectators) < limit) {
+			limit = int(req.MaxSpectators)
+		}
+		return limit
+	}
+	if req.BypassLimit {
+		limit = nil
+	}
+	err := s.sessionService.AddSpectator(ctx, req.SessionId, int(req.SpectatorUserId), req.SpectatorUsername, limit)
 	if err != nil {
-		s.logger.Error("Failed to add spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
+		if errors.Is(err, domain.ErrSpectatingNotAllowed) {
+			s.logger.InfoContext(ctx, "Spectator rejected", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId)
+			return &games_pb.AddSpectatorResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, domain.ErrSpectatingNotAllowed
+		}
+		if errors.Is(err, domain.ErrSessionFull) {
+			s.logger.InfoContext(ctx, "Spectator rejected from full session", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId)
+			return &games_pb.AddSpectatorResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, domain.ErrSessionFull
+		}
+		if aborted := conflictError(err); aborted != nil {
+			s.logger.InfoContext(ctx, "Session changed while adding spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
+			return &games_pb.AddSpectatorResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, aborted
+		}
+		s.logger.ErrorContext(ctx, "Failed to add spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
 		return &games_pb.AddSpectatorResponse{
 			Success: false,
 			Error:   err.Error(),
@@ -89,7 +195,7 @@ func (s *GameServiceServer) AddSpectator(ctx context.Context, req *games_pb.AddS
 	// Get updated session info to return spectator details
 	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
 	if err != nil {
-		s.logger.Error("Failed to get session after adding spectator", "session_id", req.SessionId, "error", err)
+		s.logger.ErrorContext(ctx, "Failed to get session after adding spectator", "session_id", req.SessionId, "error", err)
 		return &games_pb.AddSpectatorResponse{
 			Success: false,
 			Error:   "failed to get updated session info",
@@ -110,7 +216,7 @@ func (s *GameServiceServer) AddSpectator(ctx context.Context, req *games_pb.AddS
 		}
 	}
 
-	s.logger.Info("Spectator added successfully", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "spectator_username", req.SpectatorUsername)
+	s.logger.InfoContext(ctx, "Spectator added successfully", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "spectator_username", req.SpectatorUsername, "bypass_limit", req.BypassLimit)
 
 	return &games_pb.AddSpectatorResponse{
 		Success:   true,
@@ -118,6 +224,26 @@ func (s *GameServiceServer) AddSpectator(ctx context.Context, req *games_pb.AddS
 	}, nil
 }
 
+// spectatorLimit returns the most spectators a game's sessions allow, from
+// the game's spectating settings; zero for no limit
+func (s *GameServiceServer) spectatorLimit(gameID string) int {
+	game := s.gameConfig(gameID)
+	if game == nil || game.Settings == nil || game.Settings.Spectating == nil {
+		return 0
+	}
+	return max(game.Settings.Spectating.MaxSpectatorsPerSession, 0)
+}
+
+// spectatorTimeout returns how long a game's spectators may watch without
+// input, from the game's spectating settings; zero for no limit
+func (s *GameServiceServer) spectatorTimeout(gameID string) time.Duration {
+	game := s.gameConfig(gameID)
+	if game == nil || game.Settings == nil || game.Settings.Spectating == nil {
+		return 0
+	}
+	return config.ParseDuration(game.Settings.Spectating.SpectatorTimeout, 0)
+}
+
 // RemoveSpectator removes a spectator from a game session
 func (s *GameServiceServer) RemoveSpectator(ctx context.Context, req *games_pb.RemoveSpectatorRequest) (*games_pb.RemoveSpectatorResponse, error) {
 	if req.SessionId == "" {
@@ -135,16 +261,23 @@ func (s *GameServiceServer) RemoveSpectator(ctx context.Context, req *games_pb.R
 	}
 
 	// Remove spectator through session service
-	err := s.sessionService.RemoveSpectator(ctx, req.SessionId, int(req.SpectatorUserId))
+	err := s.sessionService.RemoveSpectator(ctx, req.SessionId, int(req.SpectatorUserId), req.Reason)
 	if err != nil {
-		s.logger.Error("Failed to remove spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
+		if aborted := conflictError(err); aborted != nil {
+			s.logger.InfoContext(ctx, "Session changed while removing spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
+			return &games_pb.RemoveSpectatorResponse{
+				Success: false,
+				Error:   err.Error(),
+			}, aborted
+		}
+		s.logger.ErrorContext(ctx, "Failed to remove spectator", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "error", err)
 		return &games_pb.RemoveSpectatorResponse{
 			Success: false,
 			Error:   err.Error(),
 		}, status.Error(codes.Internal, "failed to remove spectator")
 	}
 
-	s.logger.Info("Spectator removed successfully", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId)
+	s.logger.InfoContext(ctx, "Spectator removed successfully", "session_id", req.SessionId, "spectator_user_id", req.SpectatorUserId, "reason", req.Reason)
 
 	return &games_pb.RemoveSpectatorResponse{
 		Success: true,
@@ -154,11 +287,8 @@ func (s *GameServiceServer) RemoveSpectator(ctx context.Context, req *games_pb.R
 // Health implements the health check endpoint
 func (s *GameServiceServer) Health(ctx context.Context, req *emptypb.Empty) (*games_pb.HealthResponse, error) {
 	return &games_pb.HealthResponse{
-		Status: "healthy",
-		Details: map[string]string{
-			"service": "game-service",
-			"version": "v1",
-		},
+		Status:  "healthy",
+		Details: s.build.Details(map[string]string{"service": "game-service"}),
 	}, nil
 }
 
@@ -169,27 +299,21 @@ func (s *GameServiceServer) ListGames(ctx context.Context, req *games_pb.ListGam
 	}
 
 	// Get games from the application service
-	domainGames, err := s.gameService.ListEnabledGames(ctx)
+	domainGames, err := s.gameService.ListGames(ctx)
 	if err != nil {
 		return nil, status.Error(codes.Internal, "failed to list games: "+err.Error())
 	}
 
-	// Convert domain games to protobuf games
+	// Games in maintenance are listed with their status so players can see
+	// why they cannot be started; disabled games are hidden
 	games := make([]*games_pb.Game, 0, len(domainGames))
 	for _, domainGame := range domainGames {
-		game := &games_pb.Game{
-			Id:          domainGame.ID().String(),
-			Name:        domainGame.Metadata().Name,
-			ShortName:   domainGame.Metadata().ShortName,
-			Description: domainGame.Metadata().Description,
-			Category:    domainGame.Metadata().Category,
-			Tags:        domainGame.Metadata().Tags,
-			Version:     domainGame.Metadata().Version,
-			Difficulty:  int32(domainGame.Metadata().Difficulty),
-			Status:      games_pb.GameStatus_GAME_STATUS_ENABLED, // TODO: Convert domain status
+		if domainGame.Status() == domain.GameStatusDisabled || (req.EnabledOnly && !domainGame.CanStart()) {
+			continue
 		}
-		games = append(games, game)
+		games = append(games, s.domainGameToPb(domainGame))
 	}
+	sort.Slice(games, func(i, j int) bool { return games[i].Id < games[j].Id })
 
 	return &games_pb.ListGamesResponse{
 		Games:      games,
@@ -210,66 +334,84 @@ func (s *GameServiceServer) GetGame(ctx context.Context, req *games_pb.GetGameRe
 	// Get game from the application service
 	game, err := s.gameService.GetGame(ctx, req.GameId)
 	if err != nil {
-		s.logger.Error("Failed to get game", "game_id", req.GameId, "error", err)
-		return nil, status.Error(codes.NotFound, "game not found")
-	}
-
-	// Convert domain game to protobuf
-	pbGame := &games_pb.Game{
-		Id:          game.ID().String(),
-		Name:        game.Metadata().Name,
-		ShortName:   game.Metadata().ShortName,
-		Description: game.Metadata().Description,
-		Category:    game.Metadata().Category,
-		Tags:        game.Metadata().Tags,
-		Version:     game.Metadata().Version,
-		Difficulty:  int32(game.Metadata().Difficulty),
-		Status:      games_pb.GameStatus_GAME_STATUS_ENABLED,
+		s.logger.ErrorContext(ctx, "Failed to get game", "game_id", req.GameId, "error", err)
+		return nil, apierror.Status(err, "failed to get game").Err()
 	}
 
 	return &games_pb.GetGameResponse{
-		Game: pbGame,
+		Game: s.domainGameToPb(game),
 	}, nil
 }
 
-// CreateGame creates a new game
-func (s *GameServiceServer) CreateGame(ctx context.Context, req *games_pb.CreateGameRequest) (*games_pb.CreateGameResponse, error) {
-	if s.gameService == nil {
-		return nil, status.Error(codes.Unavailable, "game service not available")
-	}
-
-	// This is a read-only service focused on playing existing games
-	// Game creation should be handled through configuration files
-	return nil, status.Error(codes.Unimplemented, "game creation not supported - use configuration files")
+// domainGameToPb converts a domain game to protobuf
+func (s *GameServiceServer) domainGameToPb(game *domain.Game) *games_pb.Game {
+	pbGame := &games_pb.Game{
+		Id:            game.ID().String(),
+		Name:          game.Metadata().Name,
+		ShortName:     game.Metadata().ShortName,
+		Description:   game.Metadata().Description,
+		Category:      game.Metadata().Category,
+		Tags:          game.Metadata().Tags,
+		Version:       game.Metadata().Version,
+		Difficulty:    int32(game.Metadata().Difficulty),
+		Status:        gameStatusToPb(game.Status()),
+		StatusMessage: game.StatusMessage(),
+		Source:        s.gameSource(game.ID().String()),
+	}
+	s.setGameVersions(pbGame)
+	return pbGame
 }
 
-// UpdateGame updates an existing game
-func (s *GameServiceServer) UpdateGame(ctx context.Context, req *games_pb.UpdateGameRequest) (*games_pb.UpdateGameResponse, error) {
-	if s.gameService == nil {
-		return nil, status.Error(codes.Unavailable, "game service not available")
+// setGameVersions fills in the installed versions of a configured game
+func (s *GameServiceServer) setGameVersions(game *games_pb.Game) {
+	cfg := s.gameConfig(game.Id)
+	if cfg == nil {
+		return
 	}
-
-	// This is a read-only service focused on playing existing games
-	// Game updates should be handled through configuration files
-	return nil, status.Error(codes.Unimplemented, "game updates not supported - use configuration files")
+	game.Versions = cfg.AvailableVersions()
+	game.DefaultVersion = cfg.DefaultVersion()
 }
 
-// DeleteGame deletes a game
-func (s *GameServiceServer) DeleteGame(ctx context.Context, req *games_pb.DeleteGameRequest) (*games_pb.DeleteGameResponse, error) {
+// SetGameStatus takes a game out of play, for maintenance for instance, or
+// puts it back. Players starting the game are told the message; sessions
+// already running are left alone.
+func (s *GameServiceServer) SetGameStatus(ctx context.Context, req *games_pb.SetGameStatusRequest) (*games_pb.SetGameStatusResponse, error) {
 	if s.gameService == nil {
 		return nil, status.Error(codes.Unavailable, "game service not available")
 	}
+	if req.GameId == "" {
+		return nil, status.Error(codes.InvalidArgument, "game_id is required")
+	}
+	gameStatus, ok := gameStatusFromPb(req.Status)
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "status is required")
+	}
+
+	game, err := s.gameService.SetGameStatus(ctx, req.GameId, gameStatus, req.Message)
+	if err != nil {
+		return nil, apierror.Status(err, "failed to set game status").Err()
+	}
+	s.logger.InfoContext(ctx, "Game status changed", "audit", true, "game_id", req.GameId, "status", gameStatus, "message", req.Message)
 
-	// This is a read-only service focused on playing existing games
-	// Game deletion should be handled through configuration files
-	return nil, status.Error(codes.Unimplemented, "game deletion not supported - use configuration files")
+	return &games_pb.SetGameStatusResponse{Game: s.domainGameToPb(game)}, nil
 }
 
-// StartGameSession starts a new game session
+// StartGameSession starts a new game session. A retry carrying the same
+// idempotency key returns the session the first request started.
 func (s *GameServiceServer) StartGameSession(ctx context.Context, req *games_pb.StartGameSessionRequest) (*games_pb.StartGameSessionResponse, error) {
+	return idempotent(ctx, s.idempotency, req, func() (*games_pb.StartGameSessionResponse, error) {
+		return s.startGameSession(ctx, req)
+	})
+}
+
+// startGameSession starts a game session for StartGameSession
+func (s *GameServiceServer) startGameSession(ctx context.Context, req *games_pb.StartGameSessionRequest) (*games_pb.StartGameSessionResponse, error) {
 	if s.sessionService == nil {
 		return nil, status.Error(codes.Unavailable, "session service not available")
 	}
+	if s.draining.Load() {
+		return nil, errShuttingDown
+	}
 
 	// Validate request
 	if req == nil {
@@ -290,43 +432,72 @@ func (s *GameServiceServer) StartGameSession(ctx context.Context, req *games_pb.
 	if req.TerminalSize.Width <= 0 || req.TerminalSize.Height <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "terminal_size must have positive width and height")
 	}
+	if req.TermType != "" && termtype.Sanitize(req.TermType) != req.TermType {
+		return nil, status.Error(codes.InvalidArgument, "term_type contains invalid characters")
+	}
+	if req.Locale != "" && termtype.SanitizeLocale(req.Locale) != req.Locale {
+		return nil, status.Error(codes.InvalidArgument, "locale is not a valid locale name")
+	}
+
+	// Get game configuration
+	gameConfig := s.gameConfig(req.GameId)
+	if gameConfig == nil {
+		return nil, domain.ErrGameNotFound
+	}
+
+	// Resolve the installed version to start
+	version := req.Version
+	if version == "" {
+		version = gameConfig.DefaultVersion()
+	}
+	gameConfig, err := gameConfig.ForVersion(version)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// A save only resumes in a version of the game that can read it
+	if err := s.checkSaveCompatibility(ctx, int(req.UserId), req.GameId, gameConfig.Version); err != nil {
+		return nil, err
+	}
+
+	// The game's recording policy may override the player's privacy setting
+	record, recordingPrivate := domain.ResolveRecording(recordingPolicy(gameConfig), domain.ParseRecordingPrivacy(req.RecordingPrivacy))
 
 	// Convert protobuf request to application request
 	appReq := &application.StartSessionRequest{
 		UserID:           int(req.UserId),
 		Username:         req.Username,
 		GameID:           req.GameId,
+		GameVersion:      gameConfig.Version,
 		TerminalWidth:    int(req.TerminalSize.Width),
 		TerminalHeight:   int(req.TerminalSize.Height),
-		EnableRecording:  req.EnableRecording,
+		TerminalType:     req.TermType,
+		Locale:           req.Locale,
+		ClientIP:         req.ClientIp,
+		EnableRecording:  req.EnableRecording && record,
+		RecordingPrivate: recordingPrivate,
 		EnableStreaming:  req.EnableStreaming,
 		EnableEncryption: req.EnableEncryption,
 	}
+	if limit := req.PlayTimeLimit; limit != nil {
+		appReq.PlayTimeLimit = domain.PlayTimeLimit{
+			Daily:  time.Duration(limit.DailySeconds) * time.Second,
+			Weekly: time.Duration(limit.WeeklySeconds) * time.Second,
+		}
+	}
 
 	// Call the application service
 	session, err := s.sessionService.StartGameSession(ctx, appReq)
 	if err != nil {
-		// Map domain errors to appropriate gRPC codes
-		switch {
-		case err.Error() == "game not found":
-			return nil, status.Error(codes.NotFound, "game not found")
-		case err.Error() == "user already has active session":
-			return nil, status.Error(codes.AlreadyExists, "user already has active session for this game")
-		default:
-			return nil, status.Error(codes.Internal, "failed to start game session: "+err.Error())
+		if apierror.CodeOf(err) == apierror.Internal {
+			s.logger.ErrorContext(ctx, "Failed to start game session", "user_id", req.UserId, "game_id", req.GameId, "error", err)
 		}
+		return nil, apierror.Status(err, "failed to start game session").Err()
 	}
 
-	// Get game configuration
-	var gameConfig *config.GameConfig
-	for _, cfg := range s.gameConfigs {
-		if cfg.ID == req.GameId {
-			gameConfig = cfg
-			break
-		}
-	}
-	if gameConfig == nil {
-		return nil, status.Error(codes.NotFound, "game configuration not found")
+	// Fall back to the game's configured locale when the client did not send one
+	if session.Locale() == "" && gameConfig.Settings != nil && gameConfig.Settings.Locale != "" {
+		session.SetLocale(gameConfig.Settings.Locale)
 	}
 
 	// Use the configured game path
@@ -336,7 +507,37 @@ func (s *GameServiceServer) StartGameSession(ctx context.Context, req *games_pb.
 	gameEnv := []string{}
 
 	// Create callback to handle process exit
-	processExitCallback := func(exitSession *domain.GameSession, exitCode *int, processErr error) {
+	processExitCallback := s.processExitCallback(gameConfig)
+
+	// Use a detached context for PTY creation so the process doesn't get killed when the gRPC call completes
+	// The NetHack process should live independently of the initial gRPC request
+	detachedCtx := context.Background()
+	ptySession, err := s.ptyManager.CreatePTYWithCallback(detachedCtx, session, gamePath, gameArgs, gameEnv, processExitCallback)
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to create PTY", "error", err, "session_id", session.ID().String())
+		// TODO: Clean up the session in the database
+		return nil, status.Error(codes.Internal, "failed to create PTY: "+err.Error())
+	}
+
+	// The session is active; record the process actually running the game
+	session.SetProcessInfo(domain.ProcessInfo{
+		PID: ptySession.Cmd.Process.Pid,
+	})
+
+	s.notifyFriendsStarted(session, gameConfig)
+	s.logPlayerFlags(ctx, session, req)
+
+	// Convert domain session to protobuf response
+	pbSession := s.domainSessionToPb(session)
+
+	return &games_pb.StartGameSessionResponse{
+		Session: pbSession,
+	}, nil
+}
+
+// processExitCallback returns the callback that ends a session when its game process exits
+func (s *GameServiceServer) processExitCallback(gameConfig *config.GameConfig) pty.ProcessExitCallback {
+	return func(exitSession *domain.GameSession, exitCode *int, processErr error) {
 		// Handle session cleanup when process exits
 		s.logger.Info("Game process exited", "session_id", exitSession.ID().String(), "exit_code", exitCode)
 
@@ -354,34 +555,34 @@ func (s *GameServiceServer) StartGameSession(ctx context.Context, req *games_pb.
 				}
 			}
 		}
-		exitSession.End(exitCode, signal)
-	}
+		end := exitSession.End
+		if signal != nil && crashSignals[*signal] {
+			end = exitSession.Crash
+		}
+		if err := end(exitCode, signal); err != nil {
+			// Stopping a session ends it before its game exits
+			s.logger.Debug("Session already over when its game exited", "session_id", exitSession.ID().String(), "error", err)
+		} else {
+			s.sessionService.SessionChanged(exitSession)
+		}
 
-	// Use a detached context for PTY creation so the process doesn't get killed when the gRPC call completes
-	// The NetHack process should live independently of the initial gRPC request
-	detachedCtx := context.Background()
-	_, err = s.ptyManager.CreatePTYWithCallback(detachedCtx, session, gamePath, gameArgs, gameEnv, processExitCallback)
-	if err != nil {
-		s.logger.Error("Failed to create PTY", "error", err, "session_id", session.ID().String())
-		// TODO: Clean up the session in the database
-		return nil, status.Error(codes.Internal, "failed to create PTY: "+err.Error())
+		// Keep how the game ended and its end-of-game dump with the session record
+		s.captureOutcome(exitSession, gameConfig)
+		s.captureDumplog(exitSession, gameConfig)
+		s.notifyCrash(exitSession, gameConfig, signal)
 	}
+}
 
-	// Update session status to active
-	session.Start(domain.ProcessInfo{
-		PID: 0, // TODO: Get actual PID from PTY
+// StopGameSession stops a game session. A retry carrying the same
+// idempotency key returns the original result.
+func (s *GameServiceServer) StopGameSession(ctx context.Context, req *games_pb.StopGameSessionRequest) (*games_pb.StopGameSessionResponse, error) {
+	return idempotent(ctx, s.idempotency, req, func() (*games_pb.StopGameSessionResponse, error) {
+		return s.stopGameSession(ctx, req)
 	})
-
-	// Convert domain session to protobuf response
-	pbSession := s.domainSessionToPb(session)
-
-	return &games_pb.StartGameSessionResponse{
-		Session: pbSession,
-	}, nil
 }
 
-// StopGameSession stops a game session
-func (s *GameServiceServer) StopGameSession(ctx context.Context, req *games_pb.StopGameSessionRequest) (*games_pb.StopGameSessionResponse, error) {
+// stopGameSession stops a game session for StopGameSession
+func (s *GameServiceServer) stopGameSession(ctx context.Context, req *games_pb.StopGameSessionRequest) (*games_pb.StopGameSessionResponse, error) {
 	if s.sessionService == nil {
 		return nil, status.Error(codes.Unavailable, "session service not available")
 	}
@@ -389,14 +590,26 @@ func (s *GameServiceServer) StopGameSession(ctx context.Context, req *games_pb.S
 	// Stop the session through the session service
 	err := s.sessionService.StopGameSession(ctx, req.SessionId, req.Reason)
 	if err != nil {
-		s.logger.Error("Failed to stop game session", "error", err, "session_id", req.SessionId)
+		if aborted := conflictError(err); aborted != nil {
+			s.logger.InfoContext(ctx, "Session changed while stopping", "session_id", req.SessionId, "error", err)
+			return nil, aborted
+		}
+		s.logger.ErrorContext(ctx, "Failed to stop game session", "error", err, "session_id", req.SessionId)
 		return nil, status.Error(codes.Internal, "failed to stop session: "+err.Error())
 	}
 
+	// A forced stop also ends the game process, which closing the PTY keeps alive
+	if req.Force {
+		if ptySession, err := s.ptyManager.GetPTY(req.SessionId); err == nil {
+			s.logger.InfoContext(ctx, "Terminating game process", "session_id", req.SessionId, "reason", req.Reason)
+			go ptySession.ForceTerminate()
+		}
+	}
+
 	// Stop the PTY if it exists
 	err = s.ptyManager.ClosePTY(req.SessionId)
 	if err != nil {
-		s.logger.Warn("Failed to close PTY", "error", err, "session_id", req.SessionId)
+		s.logger.WarnContext(ctx, "Failed to close PTY", "error", err, "session_id", req.SessionId)
 		// Don't return error for PTY cleanup failure, session is already stopped
 	}
 
@@ -418,12 +631,13 @@ func (s *GameServiceServer) GetGameSession(ctx context.Context, req *games_pb.Ge
 	// Get the session from the session service
 	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
 	if err != nil {
-		s.logger.Error("Failed to get game session", "session_id", req.SessionId, "error", err)
-		return nil, status.Error(codes.NotFound, "session not found")
+		s.logger.ErrorContext(ctx, "Failed to get game session", "session_id", req.SessionId, "error", err)
+		return nil, apierror.Status(err, "failed to get game session").Err()
 	}
 
 	// Convert domain session to protobuf
 	pbSession := s.domainSessionToPb(session)
+	hidePrivateRecording(pbSession, session, req.ViewerId)
 
 	return &games_pb.GetGameSessionResponse{
 		Session: pbSession,
@@ -452,7 +666,7 @@ func (s *GameServiceServer) ListGameSessions(ctx context.Context, req *games_pb.
 	}
 
 	if err != nil {
-		s.logger.Error("Failed to list game sessions", "error", err)
+		s.logger.ErrorContext(ctx, "Failed to list game sessions", "error", err)
 		return nil, status.Error(codes.Internal, "failed to list game sessions")
 	}
 
@@ -460,6 +674,10 @@ func (s *GameServiceServer) ListGameSessions(ctx context.Context, req *games_pb.
 	pbSessions := make([]*games_pb.GameSession, len(sessions))
 	for i, session := range sessions {
 		pbSessions[i] = s.domainSessionToPb(session)
+		hidePrivateRecording(pbSessions[i], session, req.ViewerId)
+	}
+	if err := sortSessionsByUsage(pbSessions, req.SortBy); err != nil {
+		return nil, err
 	}
 
 	return &games_pb.ListGameSessionsResponse{
@@ -468,8 +686,16 @@ func (s *GameServiceServer) ListGameSessions(ctx context.Context, req *games_pb.
 	}, nil
 }
 
-// SaveGame saves game data
+// SaveGame saves game data. A retry carrying the same idempotency key
+// returns the original result.
 func (s *GameServiceServer) SaveGame(ctx context.Context, req *games_pb.SaveGameRequest) (*games_pb.SaveGameResponse, error) {
+	return idempotent(ctx, s.idempotency, req, func() (*games_pb.SaveGameResponse, error) {
+		return s.saveGame(ctx, req)
+	})
+}
+
+// saveGame saves game data for SaveGame
+func (s *GameServiceServer) saveGame(ctx context.Context, req *games_pb.SaveGameRequest) (*games_pb.SaveGameResponse, error) {
 	if s.gameService == nil {
 		return nil, status.Error(codes.Unavailable, "game service not available")
 	}
@@ -482,23 +708,37 @@ func (s *GameServiceServer) SaveGame(ctx context.Context, req *games_pb.SaveGame
 		return nil, status.Error(codes.InvalidArgument, "game_id is required")
 	}
 
-	// For now, games auto-save through their native mechanisms
-	// Manual save triggering will be implemented in future versions
-	return nil, status.Error(codes.Unimplemented, "manual save triggering will be implemented in future versions")
-}
+	if len(req.Data) > 0 {
+		return s.storeSave(ctx, req)
+	}
 
-// LoadGame loads game data
-func (s *GameServiceServer) LoadGame(ctx context.Context, req *games_pb.LoadGameRequest) (*games_pb.LoadGameResponse, error) {
-	if s.gameService == nil {
-		return nil, status.Error(codes.Unavailable, "game service not available")
+	// Without data, trigger a save of the user's running game. Games save
+	// through their native mechanisms, which for NetHack means on hangup;
+	// the game then exits and its save is picked up as usual.
+	sessions, err := s.sessionService.ListUserSessions(ctx, int(req.UserId))
+	if err != nil {
+		s.logger.ErrorContext(ctx, "Failed to list user sessions", "error", err, "user_id", req.UserId)
+		return nil, status.Error(codes.Internal, "failed to list sessions")
 	}
 
-	if req.SaveId == "" {
-		return nil, status.Error(codes.InvalidArgument, "save_id is required")
+	for _, session := range sessions {
+		if !session.IsActive() || session.GameID().String() != req.GameId {
+			continue
+		}
+
+		ptySession, err := s.ptyManager.GetPTY(session.ID().String())
+		if err != nil {
+			continue
+		}
+		if err := ptySession.Hangup(); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+
+		s.logger.InfoContext(ctx, "Triggered game save", "session_id", session.ID().String(), "user_id", req.UserId, "game_id", req.GameId)
+		return &games_pb.SaveGameResponse{}, nil
 	}
 
-	// Load functionality will be implemented when save management is added
-	return nil, status.Error(codes.Unimplemented, "save loading will be implemented in future versions")
+	return nil, status.Error(codes.NotFound, "no running game to save")
 }
 
 // DeleteSave deletes a game save
@@ -515,19 +755,6 @@ func (s *GameServiceServer) DeleteSave(ctx context.Context, req *games_pb.Delete
 	return nil, status.Error(codes.Unimplemented, "save deletion will be implemented in future versions")
 }
 
-// ListSaves lists game saves
-func (s *GameServiceServer) ListSaves(ctx context.Context, req *games_pb.ListSavesRequest) (*games_pb.ListSavesResponse, error) {
-	if s.gameService == nil {
-		return nil, status.Error(codes.Unavailable, "game service not available")
-	}
-
-	// Return empty list for now - save management will be implemented in future versions
-	return &games_pb.ListSavesResponse{
-		Saves:      []*games_pb.GameSave{},
-		TotalCount: 0,
-	}, nil
-}
-
 // domainSessionToPb converts a domain GameSession to protobuf GameSession
 func (s *GameServiceServer) domainSessionToPb(session *domain.GameSession) *games_pb.GameSession {
 	if session == nil {
@@ -546,13 +773,38 @@ func (s *GameServiceServer) domainSessionToPb(session *domain.GameSession) *game
 			Width:  int32(session.TerminalSize().Width),
 			Height: int32(session.TerminalSize().Height),
 		},
-		Encoding: session.Encoding(),
+		Encoding:       session.Encoding(),
+		GameVersion:    session.GameVersion(),
+		ClientIp:       session.ClientIP(),
+		SpectatorCount: int32(session.SpectatorCount()),
+		MaxSpectators:  int32(s.spectatorLimit(session.GameID().String())),
+		StatusLine:     session.StatusLine(),
+
+		SpectatorTimeoutSeconds: int64(s.spectatorTimeout(session.GameID().String()).Seconds()),
+	}
+	if session.IsActive() {
+		pbSession.ResourceUsage = s.resourceUsage(session.ID().String())
 	}
 
 	// Set end time if session has ended
 	if session.EndTime() != nil {
 		pbSession.EndTime = timestamppb.New(*session.EndTime())
 	}
+	if session.PlayTimeEndsAt() != nil {
+		pbSession.PlayTimeEndsAt = timestamppb.New(*session.PlayTimeEndsAt())
+	}
+	if outcome := session.Outcome(); outcome != nil {
+		pbSession.Outcome = &games_pb.GameOutcome{
+			Result:    string(outcome.Result),
+			Death:     outcome.Death,
+			Points:    outcome.Points,
+			Turns:     outcome.Turns,
+			Character: outcome.Character,
+		}
+	}
+	if session.DumplogID() != nil {
+		pbSession.DumplogId = session.DumplogID().String()
+	}
 
 	// Set process info if available
 	if session.ProcessInfo().PID != 0 {
@@ -578,6 +830,7 @@ func (s *GameServiceServer) domainSessionToPb(session *domain.GameSession) *game
 			StartTime:  timestamppb.New(session.RecordingInfo().StartTime),
 			FileSize:   session.RecordingInfo().FileSize,
 			Compressed: session.RecordingInfo().Compressed,
+			Private:    session.RecordingInfo().Private,
 		}
 	}
 
@@ -610,6 +863,39 @@ func (s *GameServiceServer) domainSessionToPb(session *domain.GameSession) *game
 	return pbSession
 }
 
+// gameStatusToPb converts domain GameStatus to protobuf GameStatus
+func gameStatusToPb(status domain.GameStatus) games_pb.GameStatus {
+	switch status {
+	case domain.GameStatusEnabled:
+		return games_pb.GameStatus_GAME_STATUS_ENABLED
+	case domain.GameStatusDisabled:
+		return games_pb.GameStatus_GAME_STATUS_DISABLED
+	case domain.GameStatusMaintenance:
+		return games_pb.GameStatus_GAME_STATUS_MAINTENANCE
+	case domain.GameStatusDeprecated:
+		return games_pb.GameStatus_GAME_STATUS_DEPRECATED
+	default:
+		return games_pb.GameStatus_GAME_STATUS_UNSPECIFIED
+	}
+}
+
+// gameStatusFromPb converts protobuf GameStatus to domain GameStatus; ok is
+// false for an unspecified status
+func gameStatusFromPb(status games_pb.GameStatus) (domain.GameStatus, bool) {
+	switch status {
+	case games_pb.GameStatus_GAME_STATUS_ENABLED:
+		return domain.GameStatusEnabled, true
+	case games_pb.GameStatus_GAME_STATUS_DISABLED:
+		return domain.GameStatusDisabled, true
+	case games_pb.GameStatus_GAME_STATUS_MAINTENANCE:
+		return domain.GameStatusMaintenance, true
+	case games_pb.GameStatus_GAME_STATUS_DEPRECATED:
+		return domain.GameStatusDeprecated, true
+	default:
+		return "", false
+	}
+}
+
 // domainStatusToPb converts domain SessionStatus to protobuf SessionStatus
 func (s *GameServiceServer) domainStatusToPb(status domain.SessionStatus) games_pb.SessionStatus {
 	switch status {
@@ -651,14 +937,14 @@ func (s *GameServiceServer) ResizeTerminal(ctx context.Context, req *games_pb.Re
 	// Resize the PTY
 	err := s.ptyManager.ResizePTY(req.SessionId, uint16(req.NewSize.Height), uint16(req.NewSize.Width))
 	if err != nil {
-		s.logger.Error("Failed to resize PTY", "error", err, "session_id", req.SessionId)
+		s.logger.ErrorContext(ctx, "Failed to resize PTY", "error", err, "session_id", req.SessionId)
 		return &games_pb.ResizeTerminalResponse{
 			Success: false,
 			Error:   err.Error(),
 		}, nil
 	}
 
-	s.logger.Info("Resized PTY", "session_id", req.SessionId, "rows", req.NewSize.Height, "cols", req.NewSize.Width)
+	s.logger.InfoContext(ctx, "Resized PTY", "session_id", req.SessionId, "rows", req.NewSize.Height, "cols", req.NewSize.Width)
 
 	return &games_pb.ResizeTerminalResponse{
 		Success: true,
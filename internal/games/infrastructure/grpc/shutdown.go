@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// errShuttingDown refuses new games and streams while the service drains
+var errShuttingDown = apierror.New(apierror.ShuttingDown, "game service is shutting down")
+
+// shutdownReason is shown to players detached because the game service is stopping
+const shutdownReason = "Game service is restarting. Choose your game again from the menu in a moment to continue."
+
+// Shutdown stops the game service taking new work and hands running games
+// over to the next instance: new games and streams are refused, open streams
+// get until ctx is done to end before their players are detached, and the
+// games still running are written to snapshotPath for adoption. An empty
+// snapshotPath skips the snapshot.
+func (s *GameServiceServer) Shutdown(ctx context.Context, snapshotPath string) error {
+	s.draining.Store(true)
+
+	if detached := s.streamHandler.Drain(ctx, shutdownReason); detached > 0 {
+		s.logger.Info("Detached players from running games", "count", detached)
+	}
+	s.captures.StopAll()
+
+	if snapshotPath == "" || s.sessionService == nil {
+		return nil
+	}
+
+	// Drain may have used up ctx, so the snapshot gets a context of its own
+	sessions, err := s.sessionService.ListActiveSessions(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to list active sessions: %w", err)
+	}
+	snapshots := application.SnapshotSessions(sessions, time.Now())
+	if err := application.WriteSessionSnapshot(snapshotPath, snapshots); err != nil {
+		return err
+	}
+	s.logger.Info("Wrote session snapshot", "path", snapshotPath, "sessions", len(snapshots))
+	return nil
+}
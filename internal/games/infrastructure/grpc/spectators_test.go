@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestAddSpectator_Limit(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	games := []*config.GameConfig{{
+		ID: "nethack",
+		Settings: &config.GameSettings{
+			Spectating: &config.SpectatingConfig{Enabled: true, MaxSpectatorsPerSession: 1},
+		},
+	}}
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		gameConfigs:    games,
+	}
+	ctx := context.Background()
+	watched := createMockSessionWithID("session_a", 1, "nethack")
+	watched.EnableStreaming("grpc", false)
+	require.NoError(t, sessionRepo.Save(ctx, watched))
+
+	resp, err := server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 2, SpectatorUsername: "first"})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	_, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 3, SpectatorUsername: "second"})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, apierror.SessionFull, apierror.CodeOf(err))
+
+	// Admins join full sessions
+	resp, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 4, SpectatorUsername: "admin", BypassLimit: true})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	session, err := server.GetGameSession(ctx, &games_pb.GetGameSessionRequest{SessionId: "session_a"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), session.Session.SpectatorCount)
+	assert.Equal(t, int32(1), session.Session.MaxSpectators)
+
+	// The session service's limit applies when it is stricter
+	stricter := createMockSessionWithID("session_b", 1, "nethack")
+	stricter.EnableStreaming("grpc", false)
+	require.NoError(t, sessionRepo.Save(ctx, stricter))
+	server.gameConfigs = nil
+	_, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_b", SpectatorUserId: 2, SpectatorUsername: "first", MaxSpectators: 1})
+	require.NoError(t, err)
+	_, err = server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_b", SpectatorUserId: 3, SpectatorUsername: "second", MaxSpectators: 1})
+	assert.Equal(t, apierror.SessionFull, apierror.CodeOf(err))
+}
+
+func TestRemoveSpectator_RecordsReason(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	games := []*config.GameConfig{{
+		ID: "nethack",
+		Settings: &config.GameSettings{
+			Spectating: &config.SpectatingConfig{Enabled: true, SpectatorTimeout: "30m"},
+		},
+	}}
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		gameConfigs:    games,
+	}
+	ctx := context.Background()
+	watched := createMockSessionWithID("session_a", 1, "nethack")
+	watched.EnableStreaming("grpc", false)
+	require.NoError(t, sessionRepo.Save(ctx, watched))
+
+	_, err := server.AddSpectator(ctx, &games_pb.AddSpectatorRequest{SessionId: "session_a", SpectatorUserId: 2, SpectatorUsername: "watcher"})
+	require.NoError(t, err)
+	session, err := server.GetGameSession(ctx, &games_pb.GetGameSessionRequest{SessionId: "session_a"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(30*60), session.Session.SpectatorTimeoutSeconds)
+
+	_, err = server.RemoveSpectator(ctx, &games_pb.RemoveSpectatorRequest{SessionId: "session_a", SpectatorUserId: 2, Reason: "idle"})
+	require.NoError(t, err)
+
+	events, err := eventRepo.FindEventsBySession(ctx, domain.NewSessionID("session_a"))
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, domain.GameEventTypeSpectatorLeave, events[1].Type)
+	assert.Equal(t, "idle", events[1].Data["reason"])
+}
@@ -0,0 +1,75 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/adapters"
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// SetFeed publishes the alerts games raise to the public feed
+func (s *GameServiceServer) SetFeed(feed *application.Feed) {
+	s.feed = feed
+}
+
+// UpdateStatusLine reads a running game's status off the bottom rows of its
+// player's screen with the game's adapter. A changed status line is pushed
+// to subscribers, so the watch menu and listings follow the game; rows that
+// show no status, as in menus, keep the last one. Alerts the status starts
+// meeting are shown to spectators and logged as events.
+func (s *GameServiceServer) UpdateStatusLine(ctx context.Context, req *games_pb.UpdateStatusLineRequest) (*games_pb.UpdateStatusLineResponse, error) {
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "session_id is required")
+	}
+	if len(req.ScreenRows) > adapters.MaxStatusRows {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d screen rows are read", adapters.MaxStatusRows)
+	}
+
+	session, err := s.sessionService.GetGameSession(ctx, req.SessionId)
+	if err != nil {
+		return nil, apierror.Status(domain.ErrSessionNotFound, "session not found").Err()
+	}
+	if session.UserID() != domain.NewUserID(int(req.UserId)) {
+		return nil, status.Error(codes.PermissionDenied, "only the player's screen is read")
+	}
+	if !session.IsActive() {
+		return nil, status.Error(codes.FailedPrecondition, "status lines are read while the game is running")
+	}
+
+	adapter := s.adapters.GetAdapterForVersion(session.GameID().String(), session.GameVersion())
+	changed := false
+	if statusLine := adapter.ParseStatus(req.ScreenRows); statusLine != "" && session.SetStatusLine(statusLine) {
+		s.logger.DebugContext(ctx, "Status line changed", "session_id", req.SessionId, "status_line", statusLine)
+		changed = true
+	}
+	if alerter, ok := adapter.(adapters.StatusAlerter); ok {
+		if met, ok := alerter.StatusAlerts(req.ScreenRows, session.Username()); ok {
+			for _, alert := range session.RaiseAlerts(met) {
+				told := 0
+				if s.streamHandler != nil {
+					told = s.streamHandler.Alert(req.SessionId, alert)
+				}
+				if s.feed != nil {
+					s.feed.SessionAlert(session, alert)
+				}
+				s.logger.InfoContext(ctx, "Status alert raised",
+					"session_id", req.SessionId,
+					"username", session.Username(),
+					"rule", alert.Rule,
+					"status_line", alert.StatusLine,
+					"spectators", told)
+				changed = true
+			}
+		}
+	}
+	if changed {
+		s.sessionService.SessionChanged(session)
+	}
+	return &games_pb.UpdateStatusLineResponse{StatusLine: session.StatusLine()}, nil
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/adapters"
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestUpdateStatusLine(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	games := []*config.GameConfig{{
+		ID:       "nethack",
+		Enabled:  true,
+		Settings: &config.GameSettings{StatusLine: &config.StatusLineConfig{Enabled: true}},
+	}}
+	registry, err := adapters.NewGameAdapterRegistryWithConfig(games)
+	require.NoError(t, err)
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		adapters:       registry,
+		logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		gameConfigs:    games,
+	}
+	assert.True(t, server.features().Has(capabilities.StatusLine))
+	ctx := context.Background()
+	require.NoError(t, sessionRepo.Save(ctx, createMockSessionWithID("session_a", 1, "nethack")))
+
+	rows := []string{
+		"Agent the Stripling           St:18/02 Dx:14 Co:18 In:8 Wi:9 Ch:7 Lawful",
+		"Dlvl:3 $:42 HP:12(16) Pw:1(1) AC:6 Xp:2/25 T:410 Hungry",
+	}
+	resp, err := server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 1, ScreenRows: rows})
+	require.NoError(t, err)
+	assert.Equal(t, "Dlvl:3 HP:12/16 XL:2 Hungry", resp.StatusLine)
+
+	// A menu over the status lines keeps the last status
+	resp, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 1, ScreenRows: []string{"(end)"}})
+	require.NoError(t, err)
+	assert.Equal(t, "Dlvl:3 HP:12/16 XL:2 Hungry", resp.StatusLine)
+
+	session, err := server.GetGameSession(ctx, &games_pb.GetGameSessionRequest{SessionId: "session_a"})
+	require.NoError(t, err)
+	assert.Equal(t, "Dlvl:3 HP:12/16 XL:2 Hungry", session.Session.StatusLine)
+
+	// Only the player's screen is read
+	_, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 2, ScreenRows: rows})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	_, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_missing", UserId: 1, ScreenRows: rows})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	_, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 1, ScreenRows: make([]string, adapters.MaxStatusRows+1)})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestUpdateStatusLine_Alerts(t *testing.T) {
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+
+	games := []*config.GameConfig{{
+		ID:      "nethack",
+		Enabled: true,
+		Settings: &config.GameSettings{StatusLine: &config.StatusLineConfig{
+			Enabled: true,
+			Alerts:  []config.StatusAlertConfig{{Name: "low_hp", HPBelowPercent: 20, Message: "$PLAYER is down to $HP HP"}},
+		}},
+	}}
+	registry, err := adapters.NewGameAdapterRegistryWithConfig(games)
+	require.NoError(t, err)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := &GameServiceServer{
+		sessionService: application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow),
+		adapters:       registry,
+		streamHandler:  NewStreamHandler(nil, logger),
+		logger:         logger,
+		gameConfigs:    games,
+	}
+	ctx := context.Background()
+	require.NoError(t, sessionRepo.Save(ctx, createMockSessionWithID("session_a", 1, "nethack")))
+	spectator := &StreamSession{
+		sessionID: "session_a",
+		closeChan: make(chan struct{}),
+		spectator: true,
+		events:    make(chan *games_pb.PTYEvent, 1),
+		alerts:    make(chan *games_pb.PTYEvent, alertBuffer),
+	}
+	server.streamHandler.register(spectator)
+
+	lowHP := []string{"Dlvl:3 $:42 HP:2(16) Pw:1(1) AC:6 Xp:2/25 T:410"}
+	_, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 1, ScreenRows: lowHP})
+	require.NoError(t, err)
+	select {
+	case alert := <-spectator.alerts:
+		assert.Equal(t, games_pb.PTYEventType_PTY_EVENT_ALERT, alert.Type)
+		assert.Equal(t, "testuser is down to 2 HP", alert.Message)
+		assert.Equal(t, "low_hp", alert.Metadata["rule"])
+	default:
+		t.Fatal("spectator was not alerted")
+	}
+
+	// The alert is raised once while HP stays low
+	_, err = server.UpdateStatusLine(ctx, &games_pb.UpdateStatusLineRequest{SessionId: "session_a", UserId: 1, ScreenRows: lowHP})
+	require.NoError(t, err)
+	assert.Empty(t, spectator.alerts)
+
+	events, err := eventRepo.FindEventsBySession(ctx, domain.NewSessionID("session_a"))
+	require.NoError(t, err)
+	var alerts []*domain.GameEvent
+	for _, event := range events {
+		if event.Type == domain.GameEventTypeSessionAlert {
+			alerts = append(alerts, event)
+		}
+	}
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "low_hp", alerts[0].Data["rule"])
+}
@@ -1,14 +1,18 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/dungeongate/internal/games"
+	"github.com/dungeongate/internal/games/domain"
 	"github.com/dungeongate/internal/games/infrastructure/pty"
 	games_pb "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/iocapture"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -19,6 +23,19 @@ type StreamHandler struct {
 	sessions   map[string]*StreamSession
 	mu         sync.RWMutex
 	logger     *slog.Logger
+	captures   *IOCaptures
+
+	// Spectator streams by session, kept apart from the player's stream in
+	// sessions so they can be told when the player leaves
+	spectators map[string]map[*StreamSession]struct{}
+
+	// How long a running game must be without a player before its spectators
+	// are told, so a quick reconnect goes unnoticed
+	playerGoneGrace time.Duration
+
+	// Set by Drain; new streams are refused and open ones counted in active
+	draining bool
+	active   sync.WaitGroup
 }
 
 // StreamSession represents an active streaming session
@@ -28,6 +45,17 @@ type StreamSession struct {
 	stream     games_pb.GameService_StreamGameIOServer
 	closeChan  chan struct{}
 	closeOnce  sync.Once
+
+	// Sent to the client when the game service ends the stream, see Detach
+	reason string
+
+	// Set for spectator streams, which end with an event from events when
+	// the player leaves
+	spectator bool
+	events    chan *games_pb.PTYEvent
+	// alerts raised by the game's status, shown to spectators as banners, and
+	// changes in the game's health, see Health
+	alerts chan *games_pb.PTYEvent
 }
 
 // GRPCSpectatorConnection implements SpectatorConnection for gRPC streams
@@ -92,9 +120,11 @@ func (c *GRPCSpectatorConnection) IsConnected() bool {
 // NewStreamHandler creates a new stream handler
 func NewStreamHandler(ptyManager *pty.PTYManager, logger *slog.Logger) *StreamHandler {
 	return &StreamHandler{
-		ptyManager: ptyManager,
-		sessions:   make(map[string]*StreamSession),
-		logger:     logger,
+		ptyManager:      ptyManager,
+		sessions:        make(map[string]*StreamSession),
+		spectators:      make(map[string]map[*StreamSession]struct{}),
+		playerGoneGrace: defaultPlayerGoneGrace,
+		logger:          logger,
 	}
 }
 
@@ -119,6 +149,15 @@ func (h *StreamHandler) HandleStream(stream games_pb.GameService_StreamGameIOSer
 		return status.Error(codes.InvalidArgument, "session_id is required")
 	}
 
+	h.mu.Lock()
+	if h.draining {
+		h.mu.Unlock()
+		return errShuttingDown
+	}
+	h.active.Add(1)
+	h.mu.Unlock()
+	defer h.active.Done()
+
 	// Get the PTY session
 	ptySession, err := h.ptyManager.GetPTY(sessionID)
 	if err != nil {
@@ -143,17 +182,16 @@ func (h *StreamHandler) HandleStream(stream games_pb.GameService_StreamGameIOSer
 		ptySession: ptySession,
 		stream:     stream,
 		closeChan:  make(chan struct{}),
+		spectator:  connectReq.Spectator,
+		events:     make(chan *games_pb.PTYEvent, 1),
+		alerts:     make(chan *games_pb.PTYEvent, alertBuffer),
 	}
 
 	// Register the stream session
-	h.mu.Lock()
-	h.sessions[sessionID] = streamSession
-	h.mu.Unlock()
+	h.register(streamSession)
 
 	defer func() {
-		h.mu.Lock()
-		delete(h.sessions, sessionID)
-		h.mu.Unlock()
+		h.unregister(streamSession)
 		streamSession.Close()
 	}()
 
@@ -237,11 +275,15 @@ func (h *StreamHandler) HandleStream(stream games_pb.GameService_StreamGameIOSer
 	// Wait for either goroutine to finish
 	err = <-errChan
 
+	// Closing first settles whether Drain detached the stream, and why
+	streamSession.Close()
+
 	// Send disconnect response if possible
 	stream.Send(&games_pb.GameIOResponse{
 		Response: &games_pb.GameIOResponse_Disconnected{
 			Disconnected: &games_pb.DisconnectPTYResponse{
 				Success: true,
+				Reason:  streamSession.reason,
 			},
 		},
 	})
@@ -270,6 +312,7 @@ func (h *StreamHandler) handlePTYOutput(session *StreamSession) error {
 			}
 
 			h.logger.Debug("Sending bytes to stream for session", "session_id", session.sessionID, "bytes", len(data), "data", string(data))
+			h.captures.record(session.sessionID, iocapture.Output, data)
 			// Send output to stream
 			if err := session.stream.Send(&games_pb.GameIOResponse{
 				Response: &games_pb.GameIOResponse_Output{
@@ -286,25 +329,29 @@ func (h *StreamHandler) handlePTYOutput(session *StreamSession) error {
 			h.logger.Debug("Successfully sent to stream for session", "session_id", session.sessionID)
 
 		case err := <-errorChan:
-			// Get exit code if available
-			exitCode, _ := session.ptySession.GetExitCode()
+			h.sendExitEvent(session, fmt.Sprintf("Process exited: %v", err))
+			return err
+
+		case <-session.ptySession.Exited():
+			// Only one stream receives the error; the rest learn of the exit here
+			h.sendExitEvent(session, "Process exited")
+			return io.EOF
 
-			// Send process exit event
+		case event := <-session.events:
 			if err := session.stream.Send(&games_pb.GameIOResponse{
-				Response: &games_pb.GameIOResponse_Event{
-					Event: &games_pb.PTYEvent{
-						SessionId: session.sessionID,
-						Type:      games_pb.PTYEventType_PTY_EVENT_PROCESS_EXIT,
-						Message:   fmt.Sprintf("Process exited: %v", err),
-						Metadata: map[string]string{
-							"exit_code": fmt.Sprintf("%d", exitCode),
-						},
-					},
-				},
+				Response: &games_pb.GameIOResponse_Event{Event: event},
 			}); err != nil {
-				h.logger.Error("Failed to send exit event", "error", err, "session_id", session.sessionID)
+				h.logger.Error("Failed to send event to spectator", "error", err, "session_id", session.sessionID, "type", event.Type)
+			}
+			return io.EOF
+
+		case alert := <-session.alerts:
+			if err := session.stream.Send(&games_pb.GameIOResponse{
+				Response: &games_pb.GameIOResponse_Event{Event: alert},
+			}); err != nil {
+				h.logger.Error("Failed to send alert", "error", err, "session_id", session.sessionID)
+				return err
 			}
-			return err
 
 		case <-session.closeChan:
 			return nil
@@ -312,6 +359,167 @@ func (h *StreamHandler) handlePTYOutput(session *StreamSession) error {
 	}
 }
 
+// sendExitEvent tells the client of a stream that the game process exited
+func (h *StreamHandler) sendExitEvent(session *StreamSession, message string) {
+	// Get exit code if available
+	exitCode, _ := session.ptySession.GetExitCode()
+
+	if err := session.stream.Send(&games_pb.GameIOResponse{
+		Response: &games_pb.GameIOResponse_Event{
+			Event: &games_pb.PTYEvent{
+				SessionId: session.sessionID,
+				Type:      games_pb.PTYEventType_PTY_EVENT_PROCESS_EXIT,
+				Message:   message,
+				Metadata: map[string]string{
+					"exit_code": fmt.Sprintf("%d", exitCode),
+				},
+			},
+		},
+	}); err != nil {
+		h.logger.Error("Failed to send exit event", "error", err, "session_id", session.sessionID)
+	}
+}
+
+// defaultPlayerGoneGrace is how long spectators wait for a player who left a
+// running game to come back before they are told
+const defaultPlayerGoneGrace = 2 * time.Second
+
+// register adds a stream to the player or spectator streams of its session
+func (h *StreamHandler) register(session *StreamSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !session.spectator {
+		h.sessions[session.sessionID] = session
+		return
+	}
+	if h.spectators[session.sessionID] == nil {
+		h.spectators[session.sessionID] = make(map[*StreamSession]struct{})
+	}
+	h.spectators[session.sessionID][session] = struct{}{}
+}
+
+// unregister removes a stream once it has ended. When it was the player's,
+// the spectators are told after playerGoneGrace unless the player is back.
+func (h *StreamHandler) unregister(session *StreamSession) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if session.spectator {
+		delete(h.spectators[session.sessionID], session)
+		if len(h.spectators[session.sessionID]) == 0 {
+			delete(h.spectators, session.sessionID)
+		}
+		return
+	}
+
+	// A reconnect may already have replaced this stream
+	if h.sessions[session.sessionID] != session {
+		return
+	}
+	delete(h.sessions, session.sessionID)
+	time.AfterFunc(h.playerGoneGrace, func() {
+		h.playerGone(session.sessionID, session.ptySession)
+	})
+}
+
+// playerGone ends the spectator streams of a game whose player has left
+// while it keeps running. Spectators of a game that ended hear of the exit
+// instead.
+func (h *StreamHandler) playerGone(sessionID string, ptySession *pty.PTYSession) {
+	select {
+	case <-ptySession.Exited():
+		return
+	default:
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, back := h.sessions[sessionID]; back {
+		return
+	}
+	for spectator := range h.spectators[sessionID] {
+		select {
+		case spectator.events <- &games_pb.PTYEvent{
+			SessionId: sessionID,
+			Type:      games_pb.PTYEventType_PTY_EVENT_PLAYER_DISCONNECTED,
+			Message:   "The player disconnected",
+		}:
+		default:
+		}
+	}
+	if len(h.spectators[sessionID]) > 0 {
+		h.logger.Info("Told spectators the player disconnected", "session_id", sessionID, "spectators", len(h.spectators[sessionID]))
+	}
+}
+
+// alertBuffer is how many alerts wait for a slow spectator before more are
+// dropped
+const alertBuffer = 4
+
+// Alert shows an alert raised by a game's status to the game's spectators,
+// returning how many were told
+func (h *StreamHandler) Alert(sessionID string, alert domain.StatusAlert) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	told := 0
+	for spectator := range h.spectators[sessionID] {
+		select {
+		case spectator.alerts <- &games_pb.PTYEvent{
+			SessionId: sessionID,
+			Type:      games_pb.PTYEventType_PTY_EVENT_ALERT,
+			Message:   alert.Message,
+			Metadata: map[string]string{
+				"rule":        alert.Rule,
+				"status_line": alert.StatusLine,
+			},
+		}:
+			told++
+		default:
+		}
+	}
+	return told
+}
+
+// Health tells the player and spectators of a game that it stopped
+// responding, for the given reason, or is responding again. It is the PTY
+// manager's health callback and returns how many streams were told.
+func (h *StreamHandler) Health(sessionID string, degraded bool, reason string) int {
+	event := &games_pb.PTYEvent{
+		SessionId: sessionID,
+		Type:      games_pb.PTYEventType_PTY_EVENT_RECOVERED,
+		Message:   "The game is responding again",
+	}
+	if degraded {
+		event.Type = games_pb.PTYEventType_PTY_EVENT_DEGRADED
+		event.Message = "The game is not responding"
+		event.Metadata = map[string]string{"reason": reason}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	streams := make([]*StreamSession, 0, len(h.spectators[sessionID])+1)
+	if player, ok := h.sessions[sessionID]; ok {
+		streams = append(streams, player)
+	}
+	for spectator := range h.spectators[sessionID] {
+		streams = append(streams, spectator)
+	}
+
+	told := 0
+	for _, stream := range streams {
+		select {
+		case stream.alerts <- event:
+			told++
+		default:
+		}
+	}
+	return told
+}
+
 // handleStreamInput reads from stream and sends to PTY
 func (h *StreamHandler) handleStreamInput(session *StreamSession) error {
 	for {
@@ -329,6 +537,7 @@ func (h *StreamHandler) handleStreamInput(session *StreamSession) error {
 		switch reqType := req.Request.(type) {
 		case *games_pb.GameIORequest_Input:
 			// Send input to PTY
+			h.captures.record(session.sessionID, iocapture.Input, reqType.Input.Data)
 			if err := session.ptySession.SendInput(reqType.Input.Data); err != nil {
 				h.logger.Error("Failed to send input to PTY", "error", err, "session_id", session.sessionID)
 				return err
@@ -345,6 +554,59 @@ func (h *StreamHandler) handleStreamInput(session *StreamSession) error {
 	}
 }
 
+// Drain refuses new streams and waits for open ones to end. Streams still open
+// when ctx is done are detached with the given reason: the player is returned
+// to the menu and the game keeps running. It returns how many were detached.
+func (h *StreamHandler) Drain(ctx context.Context, reason string) int {
+	h.mu.Lock()
+	h.draining = true
+	h.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		h.active.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-drained:
+		return 0
+	case <-ctx.Done():
+	}
+
+	h.mu.Lock()
+	detached := len(h.sessions)
+	for _, session := range h.sessions {
+		session.Detach(reason)
+	}
+	// Spectators go too, but are not counted
+	for _, spectators := range h.spectators {
+		for session := range spectators {
+			session.Detach(reason)
+		}
+	}
+	h.mu.Unlock()
+
+	// Detached streams only need to send their disconnect response
+	select {
+	case <-drained:
+	case <-time.After(streamDetachGrace):
+		h.logger.Warn("Streams still open after detaching", "count", detached)
+	}
+	return detached
+}
+
+// streamDetachGrace is how long Drain waits for detached streams to close
+const streamDetachGrace = 5 * time.Second
+
+// Detach ends the stream with a reason shown to the player, leaving the game running
+func (s *StreamSession) Detach(reason string) {
+	s.closeOnce.Do(func() {
+		s.reason = reason
+		close(s.closeChan)
+	})
+}
+
 // Close closes a stream session
 func (s *StreamSession) Close() {
 	s.closeOnce.Do(func() {
@@ -1,11 +1,14 @@
 package grpc
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/dungeongate/internal/games/infrastructure/pty"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +75,109 @@ func TestStreamHandler_Sessions_Management(t *testing.T) {
 	// Should be empty again
 	assert.Empty(t, handler.sessions)
 }
+
+func TestStreamHandler_Drain(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewStreamHandler(pty.NewPTYManager(logger), logger)
+
+	// A stream that only ends once it is detached
+	streamSession := &StreamSession{
+		sessionID: "test-session",
+		closeChan: make(chan struct{}),
+	}
+	handler.sessions["test-session"] = streamSession
+	handler.active.Add(1)
+	go func() {
+		<-streamSession.closeChan
+		handler.active.Done()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 1, handler.Drain(ctx, "restarting"))
+	assert.Equal(t, "restarting", streamSession.reason)
+	assert.True(t, handler.draining)
+
+	// Nothing left to wait for
+	assert.Equal(t, 0, handler.Drain(context.Background(), "restarting"))
+}
+
+func TestStreamHandler_PlayerGone(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewStreamHandler(pty.NewPTYManager(logger), logger)
+	handler.playerGoneGrace = time.Millisecond
+
+	ptySession := &pty.PTYSession{}
+	newStream := func(spectator bool) *StreamSession {
+		return &StreamSession{
+			sessionID:  "test-session",
+			ptySession: ptySession,
+			closeChan:  make(chan struct{}),
+			spectator:  spectator,
+			events:     make(chan *games_pb.PTYEvent, 1),
+		}
+	}
+
+	player := newStream(false)
+	spectator := newStream(true)
+	handler.register(player)
+	handler.register(spectator)
+	assert.Equal(t, player, handler.sessions["test-session"])
+	assert.Contains(t, handler.spectators["test-session"], spectator)
+
+	// The spectator is told once the player has been gone for the grace period
+	handler.unregister(player)
+	select {
+	case event := <-spectator.events:
+		assert.Equal(t, games_pb.PTYEventType_PTY_EVENT_PLAYER_DISCONNECTED, event.Type)
+	case <-time.After(time.Second):
+		t.Fatal("spectator was not told the player disconnected")
+	}
+
+	// A player who reconnects in time goes unnoticed
+	handler.playerGoneGrace = 20 * time.Millisecond
+	handler.register(player)
+	handler.unregister(player)
+	handler.register(newStream(false))
+	select {
+	case event := <-spectator.events:
+		t.Fatalf("unexpected event %v", event.Type)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	handler.unregister(spectator)
+	assert.Empty(t, handler.spectators)
+}
+
+func TestStreamHandler_Health(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	handler := NewStreamHandler(pty.NewPTYManager(logger), logger)
+
+	newStream := func(spectator bool) *StreamSession {
+		return &StreamSession{
+			sessionID: "test-session",
+			closeChan: make(chan struct{}),
+			spectator: spectator,
+			events:    make(chan *games_pb.PTYEvent, 1),
+			alerts:    make(chan *games_pb.PTYEvent, alertBuffer),
+		}
+	}
+	player := newStream(false)
+	spectator := newStream(true)
+	handler.register(player)
+	handler.register(spectator)
+
+	// The player and spectators are told the game stopped responding
+	assert.Equal(t, 2, handler.Health("test-session", true, pty.HangSilent))
+	for _, stream := range []*StreamSession{player, spectator} {
+		event := <-stream.alerts
+		assert.Equal(t, games_pb.PTYEventType_PTY_EVENT_DEGRADED, event.Type)
+		assert.Equal(t, pty.HangSilent, event.Metadata["reason"])
+	}
+
+	assert.Equal(t, 2, handler.Health("test-session", false, ""))
+	assert.Equal(t, games_pb.PTYEventType_PTY_EVENT_RECOVERED, (<-player.alerts).Type)
+
+	// Other games' streams hear nothing
+	assert.Equal(t, 0, handler.Health("other-session", true, pty.HangWriteTimeout))
+}
@@ -0,0 +1,188 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/internal/games/domain"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// defaultIdleUpdateInterval is how often subscribers get idle time refreshes
+const defaultIdleUpdateInterval = 10 * time.Second
+
+// minIdleUpdateInterval bounds how often a subscriber may ask for idle refreshes
+const minIdleUpdateInterval = time.Second
+
+// sessionIdleThreshold matches the point at which clients start showing idle time
+const sessionIdleThreshold = 30 * time.Second
+
+// sentSession is what a subscriber was last told about a session
+type sentSession struct {
+	lastActivity time.Time
+	spectators   int
+	statusLine   string
+}
+
+// SubscribeGameSessions streams the current active sessions as ADDED updates
+// followed by SYNCED, then pushes ADDED, UPDATED and REMOVED updates as sessions
+// change and IDLE updates on an interval while idle times are counting
+func (s *GameServiceServer) SubscribeGameSessions(req *games_pb.SubscribeGameSessionsRequest, stream games_pb.GameService_SubscribeGameSessionsServer) error {
+	if s.sessionService == nil {
+		return status.Error(codes.Unavailable, "session service not available")
+	}
+
+	// Subscribe before the initial sync so no change is missed in between
+	updates, unsubscribe := s.sessionService.SubscribeSessions()
+	defer unsubscribe()
+	if updates == nil {
+		return status.Error(codes.Unimplemented, "session subscriptions require the session cache")
+	}
+
+	idleInterval := defaultIdleUpdateInterval
+	if req.IdleIntervalSeconds > 0 {
+		idleInterval = time.Duration(req.IdleIntervalSeconds) * time.Second
+		if idleInterval < minIdleUpdateInterval {
+			idleInterval = minIdleUpdateInterval
+		}
+	}
+	idleTicker := time.NewTicker(idleInterval)
+	defer idleTicker.Stop()
+
+	ctx := stream.Context()
+	sent := make(map[string]sentSession)
+
+	if err := s.sendSessionChanges(ctx, stream, req, sent); err != nil {
+		return err
+	}
+	if err := stream.Send(&games_pb.GameSessionUpdate{Type: games_pb.SessionUpdateType_SESSION_UPDATE_SYNCED}); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-updates:
+			if err := s.sendSessionChanges(ctx, stream, req, sent); err != nil {
+				return err
+			}
+		case <-idleTicker.C:
+			if err := s.sendIdleUpdates(ctx, stream, req, sent); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribedSessions returns the active sessions a subscription covers
+func (s *GameServiceServer) subscribedSessions(ctx context.Context, req *games_pb.SubscribeGameSessionsRequest) ([]*domain.GameSession, error) {
+	sessions, err := s.sessionService.ListActiveSessions(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list sessions for subscriber", "error", err)
+		return nil, status.Error(codes.Internal, "failed to list game sessions")
+	}
+	if !req.SpectatableOnly {
+		return sessions, nil
+	}
+
+	spectatable := make([]*domain.GameSession, 0, len(sessions))
+	for _, session := range sessions {
+		if session.CanSpectate() {
+			spectatable = append(spectatable, session)
+		}
+	}
+	return spectatable, nil
+}
+
+// sendSessionChanges diffs the active sessions against what the subscriber has
+// seen and sends the differences
+func (s *GameServiceServer) sendSessionChanges(ctx context.Context, stream games_pb.GameService_SubscribeGameSessionsServer, req *games_pb.SubscribeGameSessionsRequest, sent map[string]sentSession) error {
+	sessions, err := s.subscribedSessions(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	current := make(map[string]bool, len(sessions))
+	for _, session := range sessions {
+		id := session.ID().String()
+		current[id] = true
+
+		state := sentSession{
+			lastActivity: session.LastActivity(),
+			spectators:   len(session.Spectators()),
+			statusLine:   session.StatusLine(),
+		}
+		previous, seen := sent[id]
+		if seen && previous == state {
+			continue
+		}
+
+		updateType := games_pb.SessionUpdateType_SESSION_UPDATE_ADDED
+		if seen {
+			updateType = games_pb.SessionUpdateType_SESSION_UPDATE_UPDATED
+		}
+		if err := stream.Send(&games_pb.GameSessionUpdate{
+			Type:      updateType,
+			SessionId: id,
+			Session:   s.publicSessionToPb(session),
+		}); err != nil {
+			return err
+		}
+		sent[id] = state
+	}
+
+	for id := range sent {
+		if current[id] {
+			continue
+		}
+		if err := stream.Send(&games_pb.GameSessionUpdate{
+			Type:      games_pb.SessionUpdateType_SESSION_UPDATE_REMOVED,
+			SessionId: id,
+		}); err != nil {
+			return err
+		}
+		delete(sent, id)
+	}
+
+	return nil
+}
+
+// sendIdleUpdates refreshes sessions whose activity changed or whose idle time
+// is on display and counting
+func (s *GameServiceServer) sendIdleUpdates(ctx context.Context, stream games_pb.GameService_SubscribeGameSessionsServer, req *games_pb.SubscribeGameSessionsRequest, sent map[string]sentSession) error {
+	sessions, err := s.subscribedSessions(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, session := range sessions {
+		id := session.ID().String()
+		previous, seen := sent[id]
+		if !seen {
+			// Picked up by the next change notification
+			continue
+		}
+
+		lastActivity := session.LastActivity()
+		if lastActivity.Equal(previous.lastActivity) && now.Sub(lastActivity) < sessionIdleThreshold {
+			continue
+		}
+
+		if err := stream.Send(&games_pb.GameSessionUpdate{
+			Type:      games_pb.SessionUpdateType_SESSION_UPDATE_IDLE,
+			SessionId: id,
+			Session:   s.publicSessionToPb(session),
+		}); err != nil {
+			return err
+		}
+		previous.lastActivity = lastActivity
+		sent[id] = previous
+	}
+
+	return nil
+}
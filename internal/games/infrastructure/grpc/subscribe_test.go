@@ -0,0 +1,104 @@
+package grpc
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dungeongate/internal/games/application"
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/internal/games/infrastructure/repository"
+	games_pb "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// fakeSubscribeStream records updates sent by SubscribeGameSessions
+type fakeSubscribeStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *games_pb.GameSessionUpdate
+}
+
+func (f *fakeSubscribeStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeSubscribeStream) Send(update *games_pb.GameSessionUpdate) error {
+	f.updates <- update
+	return nil
+}
+
+func createMockSessionWithID(id string, userID int, gameID string) *domain.GameSession {
+	sessionID := domain.NewSessionID(id)
+	userIDDomain := domain.NewUserID(userID)
+	gameIDDomain := domain.NewGameID(gameID)
+	terminalSize := domain.TerminalSize{Width: 80, Height: 24}
+	session := domain.NewGameSession(sessionID, userIDDomain, "testuser", gameIDDomain, domain.GameConfig{}, terminalSize)
+	// Start the session to make it active
+	session.Start(domain.ProcessInfo{PID: 12345, PodName: "test-pod"})
+	return session
+}
+
+func nextUpdate(t *testing.T, updates <-chan *games_pb.GameSessionUpdate) *games_pb.GameSessionUpdate {
+	t.Helper()
+	select {
+	case update := <-updates:
+		return update
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for session update")
+		return nil
+	}
+}
+
+func TestSubscribeGameSessions_PushesChanges(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelError}))
+	gameRepo := repository.NewStubGameRepository()
+	sessionRepo := repository.NewStubSessionRepository()
+	saveRepo := repository.NewStubSaveRepository()
+	eventRepo := repository.NewStubEventRepository()
+	uow := repository.NewStubUnitOfWork(gameRepo, sessionRepo, saveRepo, eventRepo)
+	sessionService := application.NewSessionService(sessionRepo, gameRepo, saveRepo, eventRepo, uow)
+	sessionService.SetSessionCache(application.NewSessionCache(sessionRepo, logger))
+
+	server := &GameServiceServer{sessionService: sessionService, logger: logger}
+
+	first := createMockSessionWithID("session_a", 1, "nethack")
+	first.EnableStreaming("grpc", false)
+	sessionService.SessionChanged(first)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := &fakeSubscribeStream{ctx: ctx, updates: make(chan *games_pb.GameSessionUpdate, 16)}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.SubscribeGameSessions(&games_pb.SubscribeGameSessionsRequest{SpectatableOnly: true}, stream)
+	}()
+
+	update := nextUpdate(t, stream.updates)
+	assert.Equal(t, games_pb.SessionUpdateType_SESSION_UPDATE_ADDED, update.Type)
+	assert.Equal(t, "session_a", update.SessionId)
+	require.NotNil(t, update.Session)
+	assert.Equal(t, games_pb.SessionUpdateType_SESSION_UPDATE_SYNCED, nextUpdate(t, stream.updates).Type)
+
+	second := createMockSessionWithID("session_b", 2, "nethack")
+	second.EnableStreaming("grpc", false)
+	sessionService.SessionChanged(second)
+	update = nextUpdate(t, stream.updates)
+	assert.Equal(t, games_pb.SessionUpdateType_SESSION_UPDATE_ADDED, update.Type)
+	assert.Equal(t, "session_b", update.SessionId)
+
+	first.End(nil, nil)
+	sessionService.SessionChanged(first)
+	update = nextUpdate(t, stream.updates)
+	assert.Equal(t, games_pb.SessionUpdateType_SESSION_UPDATE_REMOVED, update.Type)
+	assert.Equal(t, "session_a", update.SessionId)
+	assert.Nil(t, update.Session)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,266 @@
+package pty
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// cgroupControllers are the cgroup v2 controllers game limits and usage need
+var cgroupControllers = []string{"cpu", "memory", "pids", "io"}
+
+// cgroupCPUPeriod is the cpu.max period in microseconds
+const cgroupCPUPeriod = 100000
+
+// ResourceUsage is what a game's processes have used, read from the game's
+// cgroup
+type ResourceUsage struct {
+	CPUSeconds   float64
+	RSSBytes     uint64
+	PIDs         uint64
+	IOReadBytes  uint64
+	IOWriteBytes uint64
+}
+
+// cgroupSetting is a value written to a cgroup interface file
+type cgroupSetting struct {
+	file  string
+	value string
+}
+
+// cgroupLimits returns the cgroup settings for cfg's limits. A limit that
+// cannot be parsed is an error.
+func cgroupLimits(cfg *config.CgroupConfig) ([]cgroupSetting, error) {
+	var settings []cgroupSetting
+	if cfg.CPULimit != "" {
+		cpu, err := resource.ParseQuantity(cfg.CPULimit)
+		if err != nil || cpu.MilliValue() <= 0 {
+			return nil, fmt.Errorf("invalid cpu_limit %q", cfg.CPULimit)
+		}
+		quota := cpu.MilliValue() * cgroupCPUPeriod / 1000
+		settings = append(settings, cgroupSetting{"cpu.max", fmt.Sprintf("%d %d", quota, cgroupCPUPeriod)})
+	}
+	if cfg.MemoryLimit != "" {
+		memory, err := resource.ParseQuantity(cfg.MemoryLimit)
+		if err != nil || memory.Value() <= 0 {
+			return nil, fmt.Errorf("invalid memory_limit %q", cfg.MemoryLimit)
+		}
+		settings = append(settings, cgroupSetting{"memory.max", strconv.FormatInt(memory.Value(), 10)})
+	}
+	if cfg.PidsLimit > 0 {
+		settings = append(settings, cgroupSetting{"pids.max", strconv.Itoa(cfg.PidsLimit)})
+	}
+	return settings, nil
+}
+
+// prepareCgroupParent creates the cgroup games are placed under and enables
+// the controllers their limits and usage need. The parent's own parent must
+// be on a cgroup v2 hierarchy; controllers that cannot be enabled are left
+// off, and the usage they report is then zero.
+func prepareCgroupParent(path string) error {
+	if _, err := os.Stat(filepath.Join(filepath.Dir(path), "cgroup.controllers")); err != nil {
+		return fmt.Errorf("%s is not on a cgroup v2 hierarchy", filepath.Dir(path))
+	}
+	if err := os.MkdirAll(path, 0755); err != nil {
+		return fmt.Errorf("failed to create cgroup %s: %w", path, err)
+	}
+	for _, dir := range []string{filepath.Dir(path), path} {
+		for _, controller := range cgroupControllers {
+			_ = writeCgroupFile(dir, "cgroup.subtree_control", "+"+controller)
+		}
+	}
+	return nil
+}
+
+// configureCgroups places games in cgroups under cfg's cgroup path, which
+// limits them and accounts their usage. Hosts without cgroup v2 are skipped
+// with a warning.
+func (m *PTYManager) configureCgroups(cfg *config.CgroupConfig) {
+	limits, err := cgroupLimits(cfg)
+	if err != nil {
+		m.logger.Warn("Invalid cgroup limits, skipping cgroups", "error", err)
+		return
+	}
+	parent := cfg.GetCgroupPath()
+	if err := prepareCgroupParent(parent); err != nil {
+		m.logger.Warn("Cgroups unavailable, skipping resource limits and accounting", "error", err)
+		return
+	}
+	m.cgroupParent = parent
+	m.cgroupLimits = limits
+	m.logger.Info("Games run in cgroups", "path", parent)
+}
+
+// CgroupsEnabled reports whether games run in cgroups, and so whether their
+// resource usage is accounted
+func (m *PTYManager) CgroupsEnabled() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.cgroupParent != ""
+}
+
+// writeCgroupFile writes an existing cgroup interface file
+func writeCgroupFile(dir, name, value string) error {
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(value)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// gameCgroup is the cgroup a session's game processes run in
+type gameCgroup struct {
+	path string
+
+	mu sync.Mutex
+	// Usage when the cgroup was removed, nil while it exists
+	final *ResourceUsage
+}
+
+// newGameCgroup creates the cgroup of a session's game under parent and
+// applies limits. A limit the host cannot apply is logged and skipped.
+func newGameCgroup(parent, sessionID string, limits []cgroupSetting, logger *slog.Logger) (*gameCgroup, error) {
+	path := filepath.Join(parent, sessionID)
+	if err := os.Mkdir(path, 0755); err != nil && !os.IsExist(err) {
+		return nil, fmt.Errorf("failed to create cgroup %s: %w", path, err)
+	}
+	for _, limit := range limits {
+		if err := writeCgroupFile(path, limit.file, limit.value); err != nil {
+			logger.Warn("Failed to set cgroup limit", "file", limit.file, "value", limit.value, "error", err)
+		}
+	}
+	return &gameCgroup{path: path}, nil
+}
+
+// openGameCgroup returns a session's existing cgroup, nil when it has none.
+// Adopted games are still in the cgroup the previous instance created.
+func openGameCgroup(parent, sessionID string) *gameCgroup {
+	path := filepath.Join(parent, sessionID)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return nil
+	}
+	return &gameCgroup{path: path}
+}
+
+// usage reads the game's resource usage, or returns the usage it had when
+// its cgroup was removed. Counters of controllers that are not enabled are
+// zero.
+func (c *gameCgroup) usage() (ResourceUsage, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.final != nil {
+		return *c.final, nil
+	}
+	return readCgroupUsage(c.path)
+}
+
+// remove records the game's final usage and removes its cgroup, which the
+// kernel only allows once its processes have exited
+func (c *gameCgroup) remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.final != nil {
+		return nil
+	}
+	usage, err := readCgroupUsage(c.path)
+	if err != nil {
+		return err
+	}
+	c.final = &usage
+	return os.Remove(c.path)
+}
+
+// readCgroupUsage reads the usage counters of the cgroup at path
+func readCgroupUsage(path string) (ResourceUsage, error) {
+	var usage ResourceUsage
+
+	// cpu.stat is always present, whichever controllers are enabled
+	cpu, err := os.ReadFile(filepath.Join(path, "cpu.stat"))
+	if err != nil {
+		return usage, fmt.Errorf("failed to read cgroup usage: %w", err)
+	}
+	usage.CPUSeconds = float64(cgroupStat(cpu, "usage_usec")) / 1e6
+
+	if memory, err := os.ReadFile(filepath.Join(path, "memory.stat")); err == nil {
+		usage.RSSBytes = cgroupStat(memory, "anon")
+	}
+	if pids, err := os.ReadFile(filepath.Join(path, "pids.current")); err == nil {
+		usage.PIDs, _ = strconv.ParseUint(strings.TrimSpace(string(pids)), 10, 64)
+	}
+	if io, err := os.ReadFile(filepath.Join(path, "io.stat")); err == nil {
+		usage.IOReadBytes, usage.IOWriteBytes = cgroupIOBytes(io)
+	}
+	return usage, nil
+}
+
+// cgroupStat returns the value of key in a flat-keyed file such as cpu.stat
+func cgroupStat(data []byte, key string) uint64 {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == key {
+			n, _ := strconv.ParseUint(value, 10, 64)
+			return n
+		}
+	}
+	return 0
+}
+
+// cgroupIOBytes sums the bytes read and written over the devices in io.stat
+func cgroupIOBytes(data []byte) (read, written uint64) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		for _, field := range strings.Fields(scanner.Text()) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			n, _ := strconv.ParseUint(value, 10, 64)
+			switch key {
+			case "rbytes":
+				read += n
+			case "wbytes":
+				written += n
+			}
+		}
+	}
+	return read, written
+}
+
+// ResourceUsage returns the game's resource usage, false when the game is
+// not in a cgroup, see ConfigureIsolation
+func (s *PTYSession) ResourceUsage() (ResourceUsage, bool) {
+	if s.cgroup == nil {
+		return ResourceUsage{}, false
+	}
+	usage, err := s.cgroup.usage()
+	if err != nil {
+		s.logger.Debug("Failed to read game resource usage", "error", err)
+		return ResourceUsage{}, false
+	}
+	return usage, true
+}
+
+// removeCgroup removes the game's cgroup once its processes have exited
+func (s *PTYSession) removeCgroup() {
+	if s.cgroup == nil {
+		return
+	}
+	if err := s.cgroup.remove(); err != nil {
+		s.logger.Warn("Failed to remove game cgroup", "path", s.cgroup.path, "error", err)
+	}
+}
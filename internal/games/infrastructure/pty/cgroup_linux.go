@@ -0,0 +1,25 @@
+//go:build linux
+
+package pty
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// placeInCgroup starts the command directly in the game's cgroup, so none of
+// its processes run outside it. The returned function closes the cgroup
+// directory once the command has started.
+func placeInCgroup(cmd *exec.Cmd, cgroup *gameCgroup) (func(), error) {
+	dir, err := os.Open(cgroup.path)
+	if err != nil {
+		return nil, err
+	}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = int(dir.Fd())
+	return func() { dir.Close() }, nil
+}
@@ -0,0 +1,11 @@
+//go:build !linux
+
+package pty
+
+import "os/exec"
+
+// placeInCgroup is a no-op; cgroups only exist on Linux and
+// ConfigureIsolation never enables them elsewhere
+func placeInCgroup(cmd *exec.Cmd, cgroup *gameCgroup) (func(), error) {
+	return func() {}, nil
+}
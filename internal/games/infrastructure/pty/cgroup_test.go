@@ -0,0 +1,109 @@
+package pty
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestCgroupLimits(t *testing.T) {
+	settings, err := cgroupLimits(&config.CgroupConfig{CPULimit: "500m", MemoryLimit: "256Mi", PidsLimit: 50})
+	require.NoError(t, err)
+	assert.Equal(t, []cgroupSetting{
+		{"cpu.max", "50000 100000"},
+		{"memory.max", "268435456"},
+		{"pids.max", "50"},
+	}, settings)
+
+	settings, err = cgroupLimits(&config.CgroupConfig{CPULimit: "2"})
+	require.NoError(t, err)
+	assert.Equal(t, []cgroupSetting{{"cpu.max", "200000 100000"}}, settings)
+
+	// No limits only accounts usage
+	settings, err = cgroupLimits(&config.CgroupConfig{})
+	require.NoError(t, err)
+	assert.Empty(t, settings)
+
+	_, err = cgroupLimits(&config.CgroupConfig{MemoryLimit: "lots"})
+	assert.Error(t, err)
+}
+
+func TestPrepareCgroupParent(t *testing.T) {
+	root := t.TempDir()
+
+	// Not a cgroup v2 hierarchy
+	assert.Error(t, prepareCgroupParent(filepath.Join(root, "dungeongate")))
+
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory pids io\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "cgroup.subtree_control"), nil, 0644))
+	require.NoError(t, prepareCgroupParent(filepath.Join(root, "dungeongate")))
+	assert.DirExists(t, filepath.Join(root, "dungeongate"))
+
+	control, err := os.ReadFile(filepath.Join(root, "cgroup.subtree_control"))
+	require.NoError(t, err)
+	assert.Equal(t, "+io", string(control), "each controller is enabled with its own write")
+}
+
+func TestGameCgroup(t *testing.T) {
+	parent := t.TempDir()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// The kernel creates a cgroup's interface files; here the test writes them
+	cgroup, err := newGameCgroup(parent, "session-1", []cgroupSetting{{"pids.max", "50"}}, logger)
+	require.NoError(t, err)
+	files := map[string]string{
+		"pids.max":     "max\n",
+		"cpu.stat":     "usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000\n",
+		"memory.stat":  "anon 1048576\nfile 4096\n",
+		"pids.current": "3\n",
+		"io.stat":      "8:0 rbytes=1000 wbytes=200 rios=3 wios=1\n8:16 rbytes=24 wbytes=0 rios=1 wios=0\n",
+	}
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(cgroup.path, name), []byte(content), 0644))
+	}
+	cgroup, err = newGameCgroup(parent, "session-1", []cgroupSetting{{"pids.max", "50"}, {"cpu.max", "50000 100000"}}, logger)
+	require.NoError(t, err, "limits the host cannot apply are skipped")
+	pidsMax, err := os.ReadFile(filepath.Join(cgroup.path, "pids.max"))
+	require.NoError(t, err)
+	assert.Equal(t, "50", string(pidsMax))
+	assert.NoFileExists(t, filepath.Join(cgroup.path, "cpu.max"))
+
+	session := &PTYSession{cgroup: cgroup, logger: logger}
+	usage, ok := session.ResourceUsage()
+	require.True(t, ok)
+	assert.Equal(t, ResourceUsage{
+		CPUSeconds:   2.5,
+		RSSBytes:     1048576,
+		PIDs:         3,
+		IOReadBytes:  1024,
+		IOWriteBytes: 200,
+	}, usage)
+
+	// Usage is kept once the cgroup is gone
+	for name := range files {
+		require.NoError(t, os.Remove(filepath.Join(cgroup.path, name)))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(cgroup.path, "cpu.stat"), []byte("usage_usec 3000000\n"), 0644))
+	// A real cgroup directory is removed with its interface files in it
+	require.Error(t, cgroup.remove())
+	require.NoError(t, os.Remove(filepath.Join(cgroup.path, "cpu.stat")))
+	require.NoError(t, os.Remove(cgroup.path))
+	usage, ok = session.ResourceUsage()
+	require.True(t, ok)
+	assert.Equal(t, ResourceUsage{CPUSeconds: 3}, usage)
+
+	// Only adopted games with a cgroup get one
+	assert.Nil(t, openGameCgroup(parent, "session-1"))
+	require.NoError(t, os.Mkdir(filepath.Join(parent, "session-2"), 0755))
+	assert.NotNil(t, openGameCgroup(parent, "session-2"))
+
+	_, ok = (&PTYSession{}).ResourceUsage()
+	assert.False(t, ok)
+}
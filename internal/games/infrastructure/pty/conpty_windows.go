@@ -0,0 +1,224 @@
+//go:build windows
+
+package pty
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"github.com/creack/pty"
+	"golang.org/x/sys/windows"
+)
+
+// conPTY is a Windows pseudo console. The game reads its input from one pipe
+// and writes its output, as VT sequences, to another.
+type conPTY struct {
+	console windows.Handle
+	input   *os.File // written by us, read by the game
+	output  *os.File // written by the game, read by us
+
+	closeOnce sync.Once
+}
+
+// startTerminal starts cmd attached to a new pseudo console of the given
+// size. Only cmd's Path, Args, Env and Dir are used; the process is created
+// directly, so cmd.Process is set but cmd.Wait cannot be used, see wait.
+func startTerminal(cmd *exec.Cmd, size *pty.Winsize) (Terminal, error) {
+	var inputRead, inputWrite, outputRead, outputWrite windows.Handle
+	if err := windows.CreatePipe(&inputRead, &inputWrite, nil, 0); err != nil {
+		return nil, fmt.Errorf("failed to create input pipe: %w", err)
+	}
+	if err := windows.CreatePipe(&outputRead, &outputWrite, nil, 0); err != nil {
+		windows.CloseHandle(inputRead)
+		windows.CloseHandle(inputWrite)
+		return nil, fmt.Errorf("failed to create output pipe: %w", err)
+	}
+
+	c := &conPTY{
+		input:  os.NewFile(uintptr(inputWrite), "conpty-input"),
+		output: os.NewFile(uintptr(outputRead), "conpty-output"),
+	}
+	err := windows.CreatePseudoConsole(consoleSize(size), inputRead, outputWrite, 0, &c.console)
+	// The pseudo console keeps its own handles to the game's ends of the pipes
+	windows.CloseHandle(inputRead)
+	windows.CloseHandle(outputWrite)
+	if err != nil {
+		c.input.Close()
+		c.output.Close()
+		return nil, fmt.Errorf("failed to create pseudo console: %w", err)
+	}
+
+	process, err := c.startProcess(cmd)
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+	cmd.Process = process
+	return c, nil
+}
+
+// startProcess creates the game process attached to the pseudo console
+func (c *conPTY) startProcess(cmd *exec.Cmd) (*os.Process, error) {
+	attributes, err := windows.NewProcThreadAttributeList(1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create process attributes: %w", err)
+	}
+	defer attributes.Delete()
+	// The attribute's value is the console handle itself, not a pointer to it
+	if err := attributes.Update(windows.PROC_THREAD_ATTRIBUTE_PSEUDOCONSOLE, *(*unsafe.Pointer)(unsafe.Pointer(&c.console)), unsafe.Sizeof(c.console)); err != nil {
+		return nil, fmt.Errorf("failed to attach pseudo console: %w", err)
+	}
+
+	startup := &windows.StartupInfoEx{ProcThreadAttributeList: attributes.List()}
+	startup.Cb = uint32(unsafe.Sizeof(*startup))
+	// Without empty standard handles a game would inherit the service's
+	// redirected ones instead of using the pseudo console
+	startup.Flags = windows.STARTF_USESTDHANDLES
+
+	args := cmd.Args
+	if len(args) == 0 {
+		args = []string{cmd.Path}
+	}
+	appName, err := windows.UTF16PtrFromString(cmd.Path)
+	if err != nil {
+		return nil, err
+	}
+	commandLine, err := windows.UTF16PtrFromString(windows.ComposeCommandLine(args))
+	if err != nil {
+		return nil, err
+	}
+	var dir *uint16
+	if cmd.Dir != "" {
+		if dir, err = windows.UTF16PtrFromString(cmd.Dir); err != nil {
+			return nil, err
+		}
+	}
+	env, err := environmentBlock(cmd.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	var info windows.ProcessInformation
+	flags := uint32(windows.EXTENDED_STARTUPINFO_PRESENT | windows.CREATE_UNICODE_ENVIRONMENT)
+	if err := windows.CreateProcess(appName, commandLine, nil, nil, false, flags, env, dir, &startup.StartupInfo, &info); err != nil {
+		return nil, fmt.Errorf("failed to start %s: %w", cmd.Path, err)
+	}
+	defer windows.CloseHandle(info.Process)
+	windows.CloseHandle(info.Thread)
+
+	// Opened while info.Process is held, so the process ID cannot be reused
+	return os.FindProcess(int(info.ProcessId))
+}
+
+// environmentBlock returns env as a CreateProcess environment block. Like
+// exec.Cmd, a nil env is the service's own environment and SYSTEMROOT is
+// always passed, since many Windows programs fail without it.
+func environmentBlock(env []string) (*uint16, error) {
+	if env == nil {
+		env = os.Environ()
+	}
+	hasSystemRoot := false
+	for _, kv := range env {
+		if name, _, _ := strings.Cut(kv, "="); strings.EqualFold(name, "SYSTEMROOT") {
+			hasSystemRoot = true
+		}
+	}
+	if !hasSystemRoot {
+		if root := os.Getenv("SYSTEMROOT"); root != "" {
+			env = append(env, "SYSTEMROOT="+root)
+		}
+	}
+
+	var block []uint16
+	for _, kv := range env {
+		encoded, err := windows.UTF16FromString(kv)
+		if err != nil {
+			return nil, fmt.Errorf("invalid environment variable: %w", err)
+		}
+		block = append(block, encoded...)
+	}
+	// An empty block still needs its two terminating NULs
+	if len(block) == 0 {
+		block = append(block, 0)
+	}
+	block = append(block, 0)
+	return &block[0], nil
+}
+
+// consoleSize converts a window size to a pseudo console size
+func consoleSize(size *pty.Winsize) windows.Coord {
+	return windows.Coord{X: int16(size.Cols), Y: int16(size.Rows)}
+}
+
+// Read reads the game's output
+func (c *conPTY) Read(p []byte) (int, error) {
+	return c.output.Read(p)
+}
+
+// Write writes input to the game
+func (c *conPTY) Write(p []byte) (int, error) {
+	return c.input.Write(p)
+}
+
+// Resize sets the pseudo console's size; the game gets a
+// WINDOW_BUFFER_SIZE_EVENT as a Unix game gets SIGWINCH
+func (c *conPTY) Resize(size *pty.Winsize) error {
+	return windows.ResizePseudoConsole(c.console, consoleSize(size))
+}
+
+// Close closes the pseudo console, which sends the game CTRL_CLOSE_EVENT,
+// then the pipes. Output is still read while the console closes, since
+// closing waits for it to be drained.
+func (c *conPTY) Close() error {
+	c.closeOnce.Do(func() {
+		windows.ClosePseudoConsole(c.console)
+		c.input.Close()
+		c.output.Close()
+	})
+	return nil
+}
+
+// wait waits for the game process to exit. The process was not started by
+// exec.Cmd, so the process is waited for directly. Its pseudo console is then
+// closed: unlike a PTY it outlives the game and output would never end.
+func (s *PTYSession) wait() error {
+	state, err := s.Cmd.Process.Wait()
+	if err != nil {
+		return err
+	}
+	s.Cmd.ProcessState = state
+	s.PTY.Close()
+	if !state.Success() {
+		return &exec.ExitError{ProcessState: state}
+	}
+	return nil
+}
+
+// processAlive reports whether a process is still running. Windows has no
+// signal 0, so the process is asked whether it has exited.
+func processAlive(process *os.Process) bool {
+	handle, err := windows.OpenProcess(windows.SYNCHRONIZE, false, uint32(process.Pid))
+	if err != nil {
+		return false
+	}
+	defer windows.CloseHandle(handle)
+	event, err := windows.WaitForSingleObject(handle, 0)
+	return err == nil && event == uint32(windows.WAIT_TIMEOUT)
+}
+
+// hangup closes the game's pseudo console. Console programs get
+// CTRL_CLOSE_EVENT, Windows' counterpart of SIGHUP.
+func (s *PTYSession) hangup() error {
+	return s.PTY.Close()
+}
+
+// terminate asks the game to exit. Windows has no SIGTERM for console
+// programs, so it is hung up; ForceTerminate kills it if it is still
+// running after its grace period.
+func (s *PTYSession) terminate() error {
+	return s.hangup()
+}
@@ -0,0 +1,55 @@
+//go:build windows
+
+package pty
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf16"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows"
+)
+
+// decodeBlock splits an environment block into its variables
+func decodeBlock(block *uint16) []string {
+	var vars []string
+	for p := unsafe.Pointer(block); ; {
+		s := windows.UTF16PtrToString((*uint16)(p))
+		if s == "" {
+			return vars
+		}
+		vars = append(vars, s)
+		// Each variable ends with a NUL
+		p = unsafe.Add(p, (len(utf16.Encode([]rune(s)))+1)*2)
+	}
+}
+
+func TestEnvironmentBlock(t *testing.T) {
+	t.Setenv("SYSTEMROOT", `C:\Windows`)
+
+	block, err := environmentBlock([]string{"TERM=xterm-256color", "HOME=C:\\games"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"TERM=xterm-256color", "HOME=C:\\games", `SYSTEMROOT=C:\Windows`}, decodeBlock(block))
+
+	// SYSTEMROOT is not passed twice, whatever its case
+	block, err = environmentBlock([]string{"SystemRoot=D:\\Windows"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"SystemRoot=D:\\Windows"}, decodeBlock(block))
+
+	_, err = environmentBlock([]string{"BAD=\x00"})
+	assert.Error(t, err)
+}
+
+func TestEnvironmentBlock_Inherited(t *testing.T) {
+	t.Setenv("DUNGEONGATE_TEST", "1")
+	block, err := environmentBlock(nil)
+	require.NoError(t, err)
+	found := false
+	for _, kv := range decodeBlock(block) {
+		found = found || strings.EqualFold(kv, "DUNGEONGATE_TEST=1")
+	}
+	assert.True(t, found)
+}
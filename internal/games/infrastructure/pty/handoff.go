@@ -0,0 +1,132 @@
+package pty
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+
+	"github.com/dungeongate/internal/games"
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// adoptedPollInterval is how often an adopted game's process is checked,
+// since only its original parent can wait for it
+const adoptedPollInterval = time.Second
+
+// HandoffPTY is the PTY of a running game, passed to the game service
+// instance taking over from this one
+type HandoffPTY struct {
+	SessionID string
+	PID       int
+	File      *os.File
+}
+
+// HandoffPTYs lists the PTYs of the games still running
+func (m *PTYManager) HandoffPTYs() []HandoffPTY {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	ptys := make([]HandoffPTY, 0, len(m.sessions))
+	for sessionID, session := range m.sessions {
+		if session.PTY == nil || session.Cmd == nil || session.Cmd.Process == nil || session.Cmd.ProcessState != nil {
+			continue
+		}
+		// Pseudo consoles cannot be passed to another process
+		file, ok := session.PTY.(*ptyFile)
+		if !ok {
+			continue
+		}
+		ptys = append(ptys, HandoffPTY{
+			SessionID: sessionID,
+			PID:       session.Cmd.Process.Pid,
+			File:      file.File,
+		})
+	}
+	return ptys
+}
+
+// AdoptPTY takes over a game started by another game service instance, given
+// the PTY master it handed over and the game's process ID. The game carries on
+// as if this manager had started it, except that its exit code is unknown.
+func (m *PTYManager) AdoptPTY(session *domain.GameSession, ptmx *os.File, pid int, onExit ProcessExitCallback) (*PTYSession, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	sessionID := session.ID().String()
+	if _, exists := m.sessions[sessionID]; exists {
+		return nil, fmt.Errorf("PTY already exists for session %s", sessionID)
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find game process %d: %w", pid, err)
+	}
+	if err := process.Signal(syscall.Signal(0)); err != nil {
+		return nil, fmt.Errorf("game process %d is not running: %w", pid, err)
+	}
+
+	size := &pty.Winsize{
+		Rows: uint16(session.TerminalSize().Height),
+		Cols: uint16(session.TerminalSize().Width),
+	}
+	ptySession := &PTYSession{
+		SessionID:         sessionID,
+		PTY:               &ptyFile{ptmx},
+		Cmd:               &exec.Cmd{Process: process},
+		Size:              size,
+		inputChan:         make(chan []byte, 100),
+		outputChan:        make(chan []byte, 100),
+		errorChan:         make(chan error, 1),
+		closeChan:         make(chan struct{}),
+		exitChan:          make(chan struct{}),
+		adapter:           m.adapters.GetAdapterForVersion(session.GameID().String(), session.GameVersion()),
+		session:           session,
+		onExit:            onExit,
+		logger:            m.logger.With(slog.String("session_id", sessionID)),
+		streamManager:     games.NewStreamManagerWithSize(int(size.Rows), int(size.Cols)),
+		outputSubscribers: make(map[string]chan []byte),
+		panicRecorder:     m.panicRecorder,
+		hang:              m.newHangWatch(sessionID, session.GameID().String(), m.logger.With(slog.String("session_id", sessionID))),
+		cleanupHook:       m.adapters.GetHooks(session.GameID().String()).Cleanup,
+		namespaces:        m.namespaces,
+		adopted:           true,
+	}
+	if m.cgroupParent != "" {
+		ptySession.cgroup = openGameCgroup(m.cgroupParent, sessionID)
+	}
+
+	go ptySession.handleInput()
+	go ptySession.handleOutput()
+	go ptySession.waitForExit()
+	go ptySession.watchForHang()
+	ptySession.streamManager.Start()
+
+	m.sessions[sessionID] = ptySession
+	m.logger.Info("Adopted PTY for session", "session_id", sessionID, "pid", pid)
+	return ptySession, nil
+}
+
+// waitForAdoptedExit polls an adopted game's process until it exits
+func (s *PTYSession) waitForAdoptedExit() {
+	ticker := time.NewTicker(adoptedPollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if !processAlive(s.Cmd.Process) {
+			break
+		}
+	}
+
+	s.logger.Info("Adopted game process exited", "pid", s.Cmd.Process.Pid)
+	s.runCleanupHook()
+	s.removeCgroup()
+	if s.onExit != nil {
+		s.onExit(s.session, nil, nil)
+	}
+	close(s.exitChan)
+}
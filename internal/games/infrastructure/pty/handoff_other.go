@@ -0,0 +1,22 @@
+//go:build !unix
+
+package pty
+
+import (
+	"errors"
+	"net"
+	"os"
+)
+
+// errHandoffUnsupported is returned where PTYs cannot be passed between processes
+var errHandoffUnsupported = errors.New("PTY handoff is not supported on this platform")
+
+// WriteHandoff is unsupported; file descriptors can only be passed on Unix
+func WriteHandoff(conn *net.UnixConn, payload []byte, file *os.File) error {
+	return errHandoffUnsupported
+}
+
+// ReadHandoff is unsupported; file descriptors can only be passed on Unix
+func ReadHandoff(conn *net.UnixConn) ([]byte, *os.File, error) {
+	return nil, nil, errHandoffUnsupported
+}
@@ -0,0 +1,59 @@
+//go:build unix
+
+package pty
+
+import (
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unixPair(t *testing.T) (*net.UnixConn, *net.UnixConn) {
+	fds, err := syscall.Socketpair(syscall.AF_UNIX, syscall.SOCK_STREAM, 0)
+	require.NoError(t, err)
+
+	conn := func(fd int) *net.UnixConn {
+		file := os.NewFile(uintptr(fd), "handoff")
+		defer file.Close()
+		c, err := net.FileConn(file)
+		require.NoError(t, err)
+		t.Cleanup(func() { c.Close() })
+		return c.(*net.UnixConn)
+	}
+	return conn(fds[0]), conn(fds[1])
+}
+
+func TestHandoff_PassesFileDescriptors(t *testing.T) {
+	sender, receiver := unixPair(t)
+
+	reader, writer, err := os.Pipe()
+	require.NoError(t, err)
+	defer reader.Close()
+
+	go func() {
+		WriteHandoff(sender, []byte(`{"session_id":"a"}`), writer)
+		WriteHandoff(sender, nil, nil)
+		writer.Close()
+	}()
+
+	payload, file, err := ReadHandoff(receiver)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"session_id":"a"}`, string(payload))
+
+	// The received descriptor is a working copy of the sender's pipe
+	_, err = file.Write([]byte("hello"))
+	require.NoError(t, err)
+	file.Close()
+	buf := make([]byte, 5)
+	_, err = io.ReadFull(reader, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+
+	_, _, err = ReadHandoff(receiver)
+	assert.ErrorIs(t, err, io.EOF)
+}
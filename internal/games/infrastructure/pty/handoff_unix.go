@@ -0,0 +1,82 @@
+//go:build unix
+
+package pty
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+)
+
+// WriteHandoff sends one PTY to the instance taking over: a length-prefixed
+// payload describing the game, with the PTY master passed as SCM_RIGHTS on the
+// length prefix. A nil file ends the handoff.
+func WriteHandoff(conn *net.UnixConn, payload []byte, file *os.File) error {
+	header := make([]byte, 4)
+	if file == nil {
+		_, err := conn.Write(header)
+		return err
+	}
+
+	binary.BigEndian.PutUint32(header, uint32(len(payload)))
+	rights := syscall.UnixRights(int(file.Fd()))
+	if _, _, err := conn.WriteMsgUnix(header, rights, nil); err != nil {
+		return fmt.Errorf("failed to send PTY: %w", err)
+	}
+	if _, err := conn.Write(payload); err != nil {
+		return fmt.Errorf("failed to send PTY details: %w", err)
+	}
+	return nil
+}
+
+// ReadHandoff receives one PTY sent by WriteHandoff. It returns io.EOF once
+// the sender has handed over all of its PTYs.
+func ReadHandoff(conn *net.UnixConn) ([]byte, *os.File, error) {
+	header := make([]byte, 4)
+	oob := make([]byte, syscall.CmsgSpace(4))
+	n, oobn, _, _, err := conn.ReadMsgUnix(header, oob)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to receive PTY: %w", err)
+	}
+	if _, err := io.ReadFull(conn, header[n:]); err != nil {
+		return nil, nil, fmt.Errorf("failed to receive PTY: %w", err)
+	}
+
+	length := binary.BigEndian.Uint32(header)
+	if length == 0 {
+		return nil, nil, io.EOF
+	}
+
+	file, err := parseRights(oob[:oobn])
+	if err != nil {
+		return nil, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		file.Close()
+		return nil, nil, fmt.Errorf("failed to receive PTY details: %w", err)
+	}
+	return payload, file, nil
+}
+
+// parseRights extracts the single file descriptor passed with a message
+func parseRights(oob []byte) (*os.File, error) {
+	messages, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse PTY handoff: %w", err)
+	}
+	for _, message := range messages {
+		fds, err := syscall.ParseUnixRights(&message)
+		if err != nil || len(fds) == 0 {
+			continue
+		}
+		for _, extra := range fds[1:] {
+			syscall.Close(extra)
+		}
+		return os.NewFile(uintptr(fds[0]), "ptmx"), nil
+	}
+	return nil, fmt.Errorf("PTY handoff carried no file descriptor")
+}
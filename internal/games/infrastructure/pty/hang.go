@@ -0,0 +1,225 @@
+package pty
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// Why a session was marked degraded
+const (
+	// HangSilent: the player typed but the game printed nothing for the threshold
+	HangSilent = "silent"
+	// HangWriteTimeout: writing input to the game blocked for the write timeout
+	HangWriteTimeout = "write_timeout"
+)
+
+// HangRecorder counts hung games; metrics.Registry implements it
+type HangRecorder interface {
+	RecordSessionHang(gameID, reason string)
+	RecordSessionHangEnded(gameID string)
+	RecordHungSessionKill(gameID string)
+}
+
+// HealthCallback is told when a session is marked degraded, with the reason,
+// and when a degraded session responds again
+type HealthCallback func(sessionID string, degraded bool, reason string)
+
+// ConfigureHangDetection enables hung game detection as cfg sets it, telling
+// onHealth of sessions that stop or start responding. It applies to PTYs
+// created afterwards.
+func (m *PTYManager) ConfigureHangDetection(cfg *config.HangDetectionConfig, onHealth HealthCallback) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hangDetection = nil
+	if cfg != nil && cfg.Enabled {
+		m.hangDetection = cfg
+	}
+	m.onHealth = onHealth
+}
+
+// SetHangRecorder sets where hung games are counted. It applies to PTYs
+// created afterwards.
+func (m *PTYManager) SetHangRecorder(recorder HangRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.hangRecorder = recorder
+}
+
+// newHangWatch returns the hang watch of a new session, nil when hang
+// detection is off. The caller holds m.mu.
+func (m *PTYManager) newHangWatch(sessionID, gameID string, logger *slog.Logger) *hangWatch {
+	if m.hangDetection == nil {
+		return nil
+	}
+	return &hangWatch{
+		sessionID:    sessionID,
+		gameID:       gameID,
+		threshold:    m.hangDetection.GetThreshold(),
+		writeTimeout: m.hangDetection.GetWriteTimeout(),
+		onHealth:     m.onHealth,
+		recorder:     m.hangRecorder,
+		logger:       logger,
+	}
+}
+
+// hangWatch puts deadlines on a session's PTY reads and writes. The PTY is
+// in blocking mode, where os.File deadlines have no effect, so the deadlines
+// are checked by watch instead of interrupting the read or write. A nil
+// hangWatch watches nothing.
+type hangWatch struct {
+	sessionID    string
+	gameID       string
+	threshold    time.Duration
+	writeTimeout time.Duration
+	onHealth     HealthCallback
+	recorder     HangRecorder
+	logger       *slog.Logger
+
+	mu sync.Mutex
+	// When the player first typed since the game last printed anything
+	pendingSince time.Time
+	// When the write in progress started, zero between writes
+	writingSince time.Time
+	degraded     bool
+}
+
+// writing records that input is being written to the game
+func (w *hangWatch) writing(now time.Time) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writingSince = now
+	if w.pendingSince.IsZero() {
+		w.pendingSince = now
+	}
+}
+
+// written records that the write in progress finished
+func (w *hangWatch) written() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.writingSince = time.Time{}
+}
+
+// output records that the game printed something, which ends a hang
+func (w *hangWatch) output() {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	w.pendingSince = time.Time{}
+	recovered := w.degraded
+	w.degraded = false
+	w.mu.Unlock()
+
+	if recovered {
+		w.logger.Info("Game is responding again")
+		if w.recorder != nil {
+			w.recorder.RecordSessionHangEnded(w.gameID)
+		}
+		if w.onHealth != nil {
+			w.onHealth(w.sessionID, false, "")
+		}
+	}
+}
+
+// isDegraded reports whether the game has been marked degraded
+func (w *hangWatch) isDegraded() bool {
+	if w == nil {
+		return false
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.degraded
+}
+
+// check marks the game degraded when a deadline has passed at now
+func (w *hangWatch) check(now time.Time) {
+	w.mu.Lock()
+	reason := ""
+	switch {
+	case w.degraded:
+	case !w.writingSince.IsZero() && now.Sub(w.writingSince) >= w.writeTimeout:
+		reason = HangWriteTimeout
+	case !w.pendingSince.IsZero() && now.Sub(w.pendingSince) >= w.threshold:
+		reason = HangSilent
+	}
+	if reason == "" {
+		w.mu.Unlock()
+		return
+	}
+	w.degraded = true
+	w.mu.Unlock()
+
+	w.logger.Warn("Game is not responding", "reason", reason, "game_id", w.gameID)
+	if w.recorder != nil {
+		w.recorder.RecordSessionHang(w.gameID, reason)
+	}
+	if w.onHealth != nil {
+		w.onHealth(w.sessionID, true, reason)
+	}
+}
+
+// watch checks the deadlines until the session closes or the game exits. A
+// game still degraded then is no longer counted as hung.
+func (w *hangWatch) watch(closed, exited <-chan struct{}) {
+	if w == nil {
+		return
+	}
+
+	ticker := time.NewTicker(min(w.threshold, w.writeTimeout) / 4)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			w.check(now)
+		case <-closed:
+			w.stop()
+			return
+		case <-exited:
+			w.stop()
+			return
+		}
+	}
+}
+
+// killed counts a degraded game being force-killed
+func (w *hangWatch) killed() {
+	if w == nil || w.recorder == nil || !w.isDegraded() {
+		return
+	}
+	w.logger.Info("Force-killing game that is not responding", "game_id", w.gameID)
+	w.recorder.RecordHungSessionKill(w.gameID)
+}
+
+// stop stops counting the game as hung once it is no longer watched
+func (w *hangWatch) stop() {
+	w.mu.Lock()
+	degraded := w.degraded
+	w.degraded = false
+	w.mu.Unlock()
+
+	if degraded && w.recorder != nil {
+		w.recorder.RecordSessionHangEnded(w.gameID)
+	}
+}
+
+// watchForHang runs the session's hang watch
+func (s *PTYSession) watchForHang() {
+	defer s.recoverPanic("pty_hang")
+	s.hang.watch(s.closeChan, s.exitChan)
+}
+
+// Degraded reports whether the game has stopped responding, see
+// ConfigureHangDetection
+func (s *PTYSession) Degraded() bool {
+	return s.hang.isDegraded()
+}
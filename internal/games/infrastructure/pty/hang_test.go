@@ -0,0 +1,128 @@
+package pty
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type hangRecorder struct {
+	hangs []string
+	ended int
+	kills int
+}
+
+func (r *hangRecorder) RecordSessionHang(gameID, reason string) {
+	r.hangs = append(r.hangs, gameID+"/"+reason)
+}
+
+func (r *hangRecorder) RecordSessionHangEnded(string) {
+	r.ended++
+}
+
+func (r *hangRecorder) RecordHungSessionKill(string) {
+	r.kills++
+}
+
+type healthChange struct {
+	degraded bool
+	reason   string
+}
+
+func newTestHangWatch(recorder *hangRecorder, changes *[]healthChange) *hangWatch {
+	return &hangWatch{
+		sessionID:    "test-session",
+		gameID:       "nethack",
+		threshold:    30 * time.Second,
+		writeTimeout: 10 * time.Second,
+		recorder:     recorder,
+		onHealth: func(sessionID string, degraded bool, reason string) {
+			*changes = append(*changes, healthChange{degraded, reason})
+		},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+}
+
+func TestHangWatch_Silent(t *testing.T) {
+	recorder := &hangRecorder{}
+	var changes []healthChange
+	w := newTestHangWatch(recorder, &changes)
+	start := time.Now()
+
+	// A game may stay silent while the player does nothing
+	w.check(start.Add(time.Hour))
+	assert.False(t, w.isDegraded())
+
+	w.writing(start)
+	w.written()
+	w.check(start.Add(29 * time.Second))
+	assert.False(t, w.isDegraded())
+
+	// More input does not move the deadline
+	w.writing(start.Add(20 * time.Second))
+	w.written()
+	w.check(start.Add(30 * time.Second))
+	assert.True(t, w.isDegraded())
+	w.check(start.Add(time.Minute))
+	assert.Equal(t, []string{"nethack/silent"}, recorder.hangs)
+
+	// Output ends the hang
+	w.output()
+	assert.False(t, w.isDegraded())
+	assert.Equal(t, 1, recorder.ended)
+	assert.Equal(t, []healthChange{{true, HangSilent}, {false, ""}}, changes)
+}
+
+func TestHangWatch_WriteTimeout(t *testing.T) {
+	recorder := &hangRecorder{}
+	var changes []healthChange
+	w := newTestHangWatch(recorder, &changes)
+	start := time.Now()
+
+	w.writing(start)
+	w.check(start.Add(10 * time.Second))
+	assert.True(t, w.isDegraded())
+	assert.Equal(t, []healthChange{{true, HangWriteTimeout}}, changes)
+
+	w.killed()
+	assert.Equal(t, 1, recorder.kills)
+
+	// A game no longer watched is no longer counted as hung
+	w.stop()
+	assert.Equal(t, 1, recorder.ended)
+	w.output()
+	assert.Equal(t, 1, recorder.ended)
+
+	// Only degraded games count as hung kills
+	w.killed()
+	assert.Equal(t, 1, recorder.kills)
+}
+
+func TestHangWatch_Disabled(t *testing.T) {
+	var w *hangWatch
+	assert.NotPanics(t, func() {
+		w.writing(time.Now())
+		w.written()
+		w.output()
+		w.killed()
+		w.watch(nil, nil)
+	})
+	assert.False(t, (&PTYSession{}).Degraded())
+}
+
+func TestPTYSession_SendInputWhileDegraded(t *testing.T) {
+	session := &PTYSession{
+		inputChan: make(chan []byte, 1),
+		closeChan: make(chan struct{}),
+		hang:      &hangWatch{degraded: true},
+		logger:    slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+
+	// Input to a hung game is dropped rather than blocking once the buffer is full
+	assert.NoError(t, session.SendInput([]byte("a")))
+	assert.NoError(t, session.SendInput([]byte("b")))
+	assert.Equal(t, []byte("a"), <-session.inputChan)
+}
@@ -0,0 +1,129 @@
+package pty
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/config"
+)
+
+// hookPath is the PATH hooks run with; nothing else of the game service's
+// environment reaches them
+const hookPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// hookOutputLimit is how much of a hook's output is kept for the log
+const hookOutputLimit = 4096
+
+// Hook stages, passed to hooks as DUNGEONGATE_HOOK
+const (
+	hookSetup   = "setup"
+	hookCleanup = "cleanup"
+)
+
+// hookEnv returns the environment of a session's hook: a fixed PATH, the
+// session's details and the hook's configured variables, which may
+// override them
+func hookEnv(stage string, hook *config.GameHookConfig, session *domain.GameSession) []string {
+	env := []string{
+		"PATH=" + hookPath,
+		"DUNGEONGATE_HOOK=" + stage,
+		"DUNGEONGATE_SESSION_ID=" + session.ID().String(),
+		"DUNGEONGATE_GAME_ID=" + session.GameID().String(),
+		"DUNGEONGATE_GAME_VERSION=" + session.GameVersion(),
+		"DUNGEONGATE_USER_ID=" + strconv.Itoa(session.UserID().Int()),
+		"DUNGEONGATE_USERNAME=" + session.Username(),
+	}
+	keys := make([]string, 0, len(hook.Environment))
+	for key := range hook.Environment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, key+"="+hook.Environment[key])
+	}
+	return env
+}
+
+// hookOutput keeps the first hookOutputLimit bytes a hook writes
+type hookOutput struct {
+	data      []byte
+	truncated bool
+}
+
+func (o *hookOutput) Write(p []byte) (int, error) {
+	if room := hookOutputLimit - len(o.data); room < len(p) {
+		o.data = append(o.data, p[:max(room, 0)]...)
+		o.truncated = true
+		return len(p), nil
+	}
+	o.data = append(o.data, p...)
+	return len(p), nil
+}
+
+func (o *hookOutput) String() string {
+	out := strings.TrimSpace(string(o.data))
+	if o.truncated {
+		out += " [truncated]"
+	}
+	return out
+}
+
+// runHook runs a session's setup or cleanup hook with the game's
+// namespaces, and in the game's cgroup when it has one. The hook runs in
+// dir unless it names its own working directory, and is killed once its
+// timeout passes.
+func runHook(stage string, hook *config.GameHookConfig, session *domain.GameSession, dir string, namespaces *config.NamespaceConfig, cgroup *gameCgroup, logger *slog.Logger) error {
+	timeout := hook.GetTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, hook.Command, hook.Args...)
+	cmd.Env = hookEnv(stage, hook, session)
+	cmd.Dir = dir
+	if hook.WorkingDirectory != "" {
+		cmd.Dir = hook.WorkingDirectory
+	}
+	output := &hookOutput{}
+	cmd.Stdout = output
+	cmd.Stderr = output
+	// Children left holding the output open must not outlive the timeout
+	cmd.WaitDelay = time.Second
+	if namespaces != nil {
+		applyNamespaces(cmd, namespaces)
+	}
+	if cgroup != nil {
+		closeCgroup, err := placeInCgroup(cmd, cgroup)
+		if err != nil {
+			return fmt.Errorf("failed to open cgroup for %s hook: %w", stage, err)
+		}
+		defer closeCgroup()
+	}
+
+	start := time.Now()
+	err := cmd.Run()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		logger.Warn("Game hook failed", "stage", stage, "command", hook.Command, "error", err, "output", output.String())
+		return fmt.Errorf("%s hook failed: %w", stage, err)
+	}
+	logger.Info("Game hook ran", "stage", stage, "command", hook.Command, "duration", time.Since(start), "output", output.String())
+	return nil
+}
+
+// runCleanupHook runs the game's cleanup hook once its game has exited
+func (s *PTYSession) runCleanupHook() {
+	if s.cleanupHook == nil {
+		return
+	}
+	_ = runHook(hookCleanup, s.cleanupHook, s.session, s.Cmd.Dir, s.namespaces, s.cgroup, s.logger)
+}
@@ -0,0 +1,87 @@
+//go:build unix
+
+package pty
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/config"
+)
+
+func hookTestSession() *domain.GameSession {
+	return domain.NewGameSession(
+		domain.NewSessionID("session-1"),
+		domain.NewUserID(7),
+		"player",
+		domain.NewGameID("brogue"),
+		domain.GameConfig{},
+		domain.TerminalSize{Width: 80, Height: 24},
+	)
+}
+
+func TestHookEnv(t *testing.T) {
+	hook := &config.GameHookConfig{Environment: map[string]string{"SEED": "42", "BROGUE_DATA": "/usr/share/brogue"}}
+	assert.Equal(t, []string{
+		"PATH=" + hookPath,
+		"DUNGEONGATE_HOOK=setup",
+		"DUNGEONGATE_SESSION_ID=session-1",
+		"DUNGEONGATE_GAME_ID=brogue",
+		"DUNGEONGATE_GAME_VERSION=",
+		"DUNGEONGATE_USER_ID=7",
+		"DUNGEONGATE_USERNAME=player",
+		"BROGUE_DATA=/usr/share/brogue",
+		"SEED=42",
+	}, hookEnv(hookSetup, hook, hookTestSession()))
+}
+
+func TestRunHook(t *testing.T) {
+	t.Setenv("DUNGEONGATE_SECRET", "hunter2")
+	dir := t.TempDir()
+	hook := &config.GameHookConfig{
+		Command: "/bin/sh",
+		Args:    []string{"-c", `env > env.txt; echo prepared`},
+	}
+
+	require.NoError(t, runHook(hookSetup, hook, hookTestSession(), dir, nil, nil, slog.Default()))
+	env, err := os.ReadFile(filepath.Join(dir, "env.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(env), "DUNGEONGATE_USERNAME=player")
+	assert.NotContains(t, string(env), "hunter2", "hooks do not inherit the service's environment")
+
+	// The hook's own working directory wins
+	other := t.TempDir()
+	hook.WorkingDirectory = other
+	require.NoError(t, runHook(hookCleanup, hook, hookTestSession(), dir, nil, nil, slog.Default()))
+	assert.FileExists(t, filepath.Join(other, "env.txt"))
+
+	hook.Args = []string{"-c", "echo broken >&2; exit 3"}
+	err = runHook(hookSetup, hook, hookTestSession(), dir, nil, nil, slog.Default())
+	assert.ErrorContains(t, err, "setup hook failed")
+}
+
+func TestRunHook_Timeout(t *testing.T) {
+	hook := &config.GameHookConfig{Command: "/bin/sh", Args: []string{"-c", "sleep 10"}, Timeout: "100ms"}
+	start := time.Now()
+	err := runHook(hookSetup, hook, hookTestSession(), t.TempDir(), nil, nil, slog.Default())
+	assert.ErrorContains(t, err, "timed out after 100ms")
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestHookOutput(t *testing.T) {
+	output := &hookOutput{}
+	output.Write([]byte("  ok\n"))
+	assert.Equal(t, "ok", output.String())
+
+	output.Write([]byte(strings.Repeat("x", hookOutputLimit)))
+	assert.Len(t, output.data, hookOutputLimit)
+	assert.True(t, strings.HasSuffix(output.String(), " [truncated]"))
+}
@@ -0,0 +1,69 @@
+package pty
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// fallbackLocales are tried, in order, when the requested locale is not installed
+var fallbackLocales = []string{"C.UTF-8", "en_US.UTF-8", "C"}
+
+var (
+	installedLocalesOnce sync.Once
+	installedLocales     map[string]bool
+)
+
+// listInstalledLocales returns the locales known to the host, keyed by their
+// normalized name, or nil when they cannot be listed
+func listInstalledLocales() map[string]bool {
+	installedLocalesOnce.Do(func() {
+		out, err := exec.Command("locale", "-a").Output()
+		if err != nil {
+			return
+		}
+		installedLocales = parseLocaleList(string(out))
+	})
+	return installedLocales
+}
+
+// parseLocaleList parses the output of `locale -a`
+func parseLocaleList(output string) map[string]bool {
+	locales := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		if name := strings.TrimSpace(line); name != "" {
+			locales[normalizeLocale(name)] = true
+		}
+	}
+	// C and POSIX are always available even if not listed
+	locales[normalizeLocale("C")] = true
+	locales[normalizeLocale("POSIX")] = true
+	return locales
+}
+
+// normalizeLocale folds the codeset spelling so en_US.UTF-8 matches en_US.utf8
+func normalizeLocale(locale string) string {
+	name, modifier, _ := strings.Cut(locale, "@")
+	if lang, codeset, ok := strings.Cut(name, "."); ok {
+		codeset = strings.ToLower(strings.ReplaceAll(codeset, "-", ""))
+		name = lang + "." + codeset
+	}
+	if modifier != "" {
+		name += "@" + modifier
+	}
+	return name
+}
+
+// resolveLocale returns the requested locale if it is installed, otherwise the
+// first installed fallback. An empty or unverifiable locale is returned as-is.
+func resolveLocale(installed map[string]bool, locale string) string {
+	if locale == "" || installed == nil || installed[normalizeLocale(locale)] {
+		return locale
+	}
+	for _, fallback := range fallbackLocales {
+		if installed[normalizeLocale(fallback)] {
+			return fallback
+		}
+	}
+	return locale
+}
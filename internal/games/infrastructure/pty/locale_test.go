@@ -0,0 +1,19 @@
+package pty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveLocale(t *testing.T) {
+	installed := parseLocaleList("C.utf8\nen_US.utf8\nde_DE@euro\n")
+
+	assert.Equal(t, "en_US.UTF-8", resolveLocale(installed, "en_US.UTF-8"))
+	assert.Equal(t, "de_DE@euro", resolveLocale(installed, "de_DE@euro"))
+	assert.Equal(t, "C.UTF-8", resolveLocale(installed, "ja_JP.UTF-8"))
+	assert.Equal(t, "", resolveLocale(installed, ""))
+
+	// Without a locale list the request cannot be checked and is kept
+	assert.Equal(t, "ja_JP.UTF-8", resolveLocale(nil, "ja_JP.UTF-8"))
+}
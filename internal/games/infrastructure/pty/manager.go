@@ -9,13 +9,13 @@ import (
 	"os/exec"
 	"strings"
 	"sync"
-	"syscall"
 	"time"
 
 	"github.com/creack/pty"
 	"github.com/dungeongate/internal/games"
 	"github.com/dungeongate/internal/games/adapters"
 	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/config"
 )
 
 // PTYManager manages PTY instances for game sessions
@@ -24,12 +24,27 @@ type PTYManager struct {
 	mu       sync.RWMutex
 	logger   *slog.Logger
 	adapters *adapters.GameAdapterRegistry
+
+	// Isolation applied to game processes, see ConfigureIsolation
+	devMode    bool
+	namespaces *config.NamespaceConfig
+	// Games' cgroups are created here, empty when cgroups are off
+	cgroupParent string
+	cgroupLimits []cgroupSetting
+
+	// Counts panics recovered in PTY goroutines, see SetPanicRecorder
+	panicRecorder PanicRecorder
+
+	// Hung game detection, see ConfigureHangDetection and SetHangRecorder
+	hangDetection *config.HangDetectionConfig
+	onHealth      HealthCallback
+	hangRecorder  HangRecorder
 }
 
 // PTYSession represents a PTY session for a game
 type PTYSession struct {
 	SessionID     string
-	PTY           *os.File
+	PTY           Terminal
 	Cmd           *exec.Cmd
 	Size          *pty.Winsize
 	inputChan     chan []byte
@@ -37,12 +52,22 @@ type PTYSession struct {
 	errorChan     chan error
 	closeChan     chan struct{}
 	closeOnce     sync.Once
+	exitChan      chan struct{}
 	mu            sync.Mutex
 	adapter       adapters.GameAdapter
 	session       *domain.GameSession
 	onExit        ProcessExitCallback
 	logger        *slog.Logger
 	streamManager *games.StreamManager
+	panicRecorder PanicRecorder
+	hang          *hangWatch
+	cgroup        *gameCgroup
+	// The game's cleanup hook, run with namespaces once the game exits
+	cleanupHook *config.GameHookConfig
+	namespaces  *config.NamespaceConfig
+
+	// Set for games taken over from another instance, see AdoptPTY
+	adopted bool
 
 	// Output subscribers for direct PTY streaming
 	outputSubscribers map[string]chan []byte
@@ -89,10 +114,43 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 
 	// Get the appropriate game adapter
 	gameID := session.GameID().String()
-	adapter := m.adapters.GetAdapter(gameID)
+	adapter := m.adapters.GetAdapterForVersion(gameID, session.GameVersion())
 
 	m.logger.Debug("Using adapter for game", "game_id", gameID, "adapter_type", fmt.Sprintf("%T", adapter))
 
+	// Make sure the negotiated terminal type is one this host has terminfo for
+	termType := resolveTerminalType(terminfoSearchPath(), session.TerminalType())
+	if termType != session.TerminalType() {
+		m.logger.Info("Terminal type not available on host, falling back",
+			"session_id", sessionID, "requested", session.TerminalType(), "using", termType)
+		session.SetTerminalType(termType)
+	}
+	env = append(env, "TERM="+session.TerminalType())
+
+	// Only pass a locale the host (or container image) actually has installed.
+	// Adapters take LANG and LC_ALL from the environment built here.
+	if session.Locale() != "" {
+		locale := resolveLocale(listInstalledLocales(), session.Locale())
+		if locale != session.Locale() {
+			m.logger.Info("Locale not installed on host, falling back",
+				"session_id", sessionID, "requested", session.Locale(), "using", locale)
+			session.SetLocale(locale)
+		}
+		env = append(env, "LANG="+session.Locale(), "LC_ALL="+session.Locale())
+	}
+
+	// Dev configs may name a binary path from another install layout
+	if m.devMode {
+		resolved, err := ResolveGameBinary(gamePath)
+		if err != nil {
+			return nil, err
+		}
+		if resolved != gamePath {
+			m.logger.Info("Dev mode: resolved game binary on PATH", "configured", gamePath, "using", resolved)
+			gamePath = resolved
+		}
+	}
+
 	// Setup game environment using adapter
 	if err := adapter.SetupGameEnvironment(session); err != nil {
 		return nil, fmt.Errorf("failed to setup game environment: %w", err)
@@ -103,6 +161,43 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare command: %w", err)
 	}
+	if m.namespaces != nil {
+		applyNamespaces(cmd, m.namespaces)
+	}
+	var cgroup *gameCgroup
+	if m.cgroupParent != "" {
+		cgroup, err = newGameCgroup(m.cgroupParent, sessionID, m.cgroupLimits, m.logger)
+		if err != nil {
+			return nil, err
+		}
+		closeCgroup, err := placeInCgroup(cmd, cgroup)
+		if err != nil {
+			cgroup.remove()
+			return nil, fmt.Errorf("failed to open cgroup: %w", err)
+		}
+		defer closeCgroup()
+	}
+
+	// The setup hook may take a while, so other sessions are not held up
+	// meanwhile
+	hooks := m.adapters.GetHooks(gameID)
+	if hooks.Setup != nil {
+		namespaces := m.namespaces
+		m.mu.Unlock()
+		err := runHook(hookSetup, hooks.Setup, session, cmd.Dir, namespaces, cgroup, m.logger.With(slog.String("session_id", sessionID)))
+		m.mu.Lock()
+		if err == nil {
+			if _, exists := m.sessions[sessionID]; exists {
+				err = fmt.Errorf("PTY already exists for session %s", sessionID)
+			}
+		}
+		if err != nil {
+			if cgroup != nil {
+				cgroup.remove()
+			}
+			return nil, err
+		}
+	}
 
 	// Set up PTY with enhanced terminal attributes
 	size := &pty.Winsize{
@@ -131,20 +226,26 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 	// Check if the binary exists
 	if _, err := os.Stat(cmd.Path); err != nil {
 		m.logger.Debug("Binary not found at path", "path", cmd.Path, "error", err)
+		if cgroup != nil {
+			cgroup.remove()
+		}
 		return nil, fmt.Errorf("game binary not found at %s: %w", cmd.Path, err)
 	}
 
-	// Try standard pty.Start first, which might work better on macOS
+	// A PTY on Unix, a pseudo console on Windows
 	startTime := time.Now()
-	ptmx, err := pty.Start(cmd)
+	terminal, err := startTerminal(cmd, size)
 	if err != nil {
 		m.logger.Error("Failed to start PTY", "error", err)
+		if cgroup != nil {
+			cgroup.remove()
+		}
 		return nil, fmt.Errorf("failed to start PTY: %w", err)
 	}
 	m.logger.Debug("PTY.Start took", "duration", time.Since(startTime))
 
 	// Set the window size after starting
-	if err := pty.Setsize(ptmx, size); err != nil {
+	if err := terminal.Resize(size); err != nil {
 		m.logger.Warn("Failed to set initial PTY size", "error", err)
 	}
 
@@ -152,10 +253,8 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 
 	// Check if process is still alive immediately after starting
 	time.Sleep(100 * time.Millisecond)
-	// Send signal 0 to check if process exists without affecting it
-	err = cmd.Process.Signal(syscall.Signal(0))
-	if err != nil {
-		m.logger.Debug("Process not found after 100ms", "error", err)
+	if !processAlive(cmd.Process) {
+		m.logger.Debug("Process not found after 100ms")
 	} else {
 		m.logger.Debug("Process still running after 100ms")
 	}
@@ -163,24 +262,30 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 	// Create PTY session
 	ptySession := &PTYSession{
 		SessionID:         sessionID,
-		PTY:               ptmx,
+		PTY:               terminal,
 		Cmd:               cmd,
 		Size:              size, // Use the size we already created
 		inputChan:         make(chan []byte, 100),
 		outputChan:        make(chan []byte, 100),
 		errorChan:         make(chan error, 1),
 		closeChan:         make(chan struct{}),
+		exitChan:          make(chan struct{}),
 		adapter:           adapter,
 		session:           session,
 		onExit:            onExit,
 		logger:            m.logger.With(slog.String("session_id", sessionID)),
 		streamManager:     games.NewStreamManagerWithSize(int(size.Rows), int(size.Cols)),
 		outputSubscribers: make(map[string]chan []byte),
+		panicRecorder:     m.panicRecorder,
+		hang:              m.newHangWatch(sessionID, session.GameID().String(), m.logger.With(slog.String("session_id", sessionID))),
+		cgroup:            cgroup,
+		cleanupHook:       hooks.Cleanup,
+		namespaces:        m.namespaces,
 	}
 
 	// Set initial terminal size
 	m.logger.Debug("Setting terminal size", "cols", ptySession.Size.Cols, "rows", ptySession.Size.Rows)
-	if err := pty.Setsize(ptmx, ptySession.Size); err != nil {
+	if err := terminal.Resize(ptySession.Size); err != nil {
 		m.logger.Warn("Failed to set initial PTY size", "error", err, "session_id", sessionID)
 	}
 
@@ -188,6 +293,7 @@ func (m *PTYManager) CreatePTYWithCallback(ctx context.Context, session *domain.
 	go ptySession.handleInput()
 	go ptySession.handleOutput()
 	go ptySession.waitForExit()
+	go ptySession.watchForHang()
 
 	// Start the stream manager
 	if ptySession.streamManager != nil {
@@ -262,16 +368,25 @@ func (m *PTYManager) ResizePTY(sessionID string, rows, cols uint16) error {
 
 // handleInput reads from inputChan and writes to PTY
 func (s *PTYSession) handleInput() {
+	defer s.recoverPanic("pty_input")
+
 	for {
 		select {
 		case data := <-s.inputChan:
-			if _, err := s.PTY.Write(data); err != nil {
+			s.hang.writing(time.Now())
+			_, err := s.PTY.Write(data)
+			s.hang.written()
+			if err != nil {
 				select {
 				case s.errorChan <- err:
 				default:
 				}
 				return
 			}
+			// Player input resets the idle time shown to spectators
+			if s.session != nil {
+				s.session.UpdateActivity()
+			}
 		case <-s.closeChan:
 			return
 		}
@@ -280,6 +395,8 @@ func (s *PTYSession) handleInput() {
 
 // handleOutput reads from PTY and writes to outputChan
 func (s *PTYSession) handleOutput() {
+	defer s.recoverPanic("pty_output")
+
 	buffer := make([]byte, 4096)
 	for {
 		n, err := s.PTY.Read(buffer)
@@ -295,6 +412,7 @@ func (s *PTYSession) handleOutput() {
 		}
 
 		if n > 0 {
+			s.hang.output()
 			s.logger.Debug("PTY received bytes for session", "session_id", s.SessionID, "bytes", n, "data", string(buffer[:n]))
 			rawData := make([]byte, n)
 			copy(rawData, buffer[:n])
@@ -309,6 +427,7 @@ func (s *PTYSession) handleOutput() {
 				streamData := make([]byte, len(processedData))
 				copy(streamData, processedData)
 				go func(data []byte) {
+					defer s.recoverPanic("pty_stream")
 					s.streamManager.SendFrame(data)
 				}(streamData)
 			}
@@ -338,12 +457,18 @@ func (s *PTYSession) handleOutput() {
 
 // waitForExit waits for the command to exit
 func (s *PTYSession) waitForExit() {
+	defer s.recoverPanic("pty_wait")
+
+	if s.adopted {
+		s.waitForAdoptedExit()
+		return
+	}
+
 	s.logger.Debug("STARTING waitForExit for session", "session_id", s.SessionID, "pid", s.Cmd.Process.Pid)
 
 	// Check process status before waiting
-	err := s.Cmd.Process.Signal(syscall.Signal(0))
-	if err != nil {
-		s.logger.Error("CRITICAL: Process already dead before Wait()", "pid", s.Cmd.Process.Pid, "error", err)
+	if !processAlive(s.Cmd.Process) {
+		s.logger.Error("CRITICAL: Process already dead before Wait()", "pid", s.Cmd.Process.Pid)
 	} else {
 		s.logger.Debug("Process confirmed alive and healthy before Wait()", "pid", s.Cmd.Process.Pid)
 	}
@@ -353,16 +478,17 @@ func (s *PTYSession) waitForExit() {
 	time.Sleep(1 * time.Second)
 
 	// Check again after delay
-	err = s.Cmd.Process.Signal(syscall.Signal(0))
-	if err != nil {
-		s.logger.Error("CRITICAL: Process died during 1-second wait", "pid", s.Cmd.Process.Pid, "error", err)
+	if !processAlive(s.Cmd.Process) {
+		s.logger.Error("CRITICAL: Process died during 1-second wait", "pid", s.Cmd.Process.Pid)
 	} else {
 		s.logger.Debug("Process still alive after 1-second delay", "pid", s.Cmd.Process.Pid)
 	}
 
 	s.logger.Debug("About to call Cmd.Wait() for session", "session_id", s.SessionID, "pid", s.Cmd.Process.Pid)
-	err = s.Cmd.Wait()
+	err := s.wait()
 	s.logger.Debug("Cmd.Wait() returned for session", "session_id", s.SessionID, "pid", s.Cmd.Process.Pid, "error", err)
+	s.runCleanupHook()
+	s.removeCgroup()
 
 	var exitCode *int
 	if err != nil {
@@ -391,12 +517,26 @@ func (s *PTYSession) waitForExit() {
 		s.logger.Debug("Calling onExit callback for session", "session_id", s.SessionID)
 		s.onExit(s.session, exitCode, err)
 	}
+	close(s.exitChan)
 
 	s.logger.Debug("waitForExit completed for session", "session_id", s.SessionID, "process_state", s.Cmd.ProcessState)
 }
 
-// SendInput sends input to the PTY
+// SendInput sends input to the PTY. Input to a degraded game is dropped
+// once the input buffer is full, so a hung game cannot block the player's
+// stream and its menu.
 func (s *PTYSession) SendInput(data []byte) error {
+	if s.Degraded() {
+		select {
+		case s.inputChan <- data:
+		case <-s.closeChan:
+			return fmt.Errorf("PTY session is closed")
+		default:
+			s.logger.Debug("Dropped input to a game that is not responding", "bytes", len(data))
+		}
+		return nil
+	}
+
 	select {
 	case s.inputChan <- data:
 		return nil
@@ -415,6 +555,12 @@ func (s *PTYSession) GetError() <-chan error {
 	return s.errorChan
 }
 
+// Exited returns a channel closed once the game process has exited and its
+// session has ended. Unlike GetError, every caller sees it.
+func (s *PTYSession) Exited() <-chan struct{} {
+	return s.exitChan
+}
+
 // Resize resizes the PTY
 func (s *PTYSession) Resize(rows, cols uint16) error {
 	s.mu.Lock()
@@ -422,7 +568,7 @@ func (s *PTYSession) Resize(rows, cols uint16) error {
 
 	s.Size.Rows = rows
 	s.Size.Cols = cols
-	return pty.Setsize(s.PTY, s.Size)
+	return s.PTY.Resize(s.Size)
 }
 
 // Close closes the PTY session WITHOUT terminating the process
@@ -459,23 +605,35 @@ func (s *PTYSession) Close() {
 	})
 }
 
+// Hangup sends SIGHUP to the game process, or on Windows closes its pseudo
+// console. Games such as NetHack save and exit on hangup, as they do when a
+// player's terminal disconnects.
+func (s *PTYSession) Hangup() error {
+	if s.Cmd == nil || s.Cmd.Process == nil || s.Cmd.ProcessState != nil {
+		return fmt.Errorf("game process is not running")
+	}
+	return s.hangup()
+}
+
 // ForceTerminate forcefully terminates the game process (for explicit user quit)
 func (s *PTYSession) ForceTerminate() {
 	s.logger.Debug("ForceTerminate() called for session", "session_id", s.SessionID)
 
 	if s.Cmd != nil && s.Cmd.Process != nil && s.Cmd.ProcessState == nil {
 		s.logger.Debug("Force terminating process for session", "pid", s.Cmd.Process.Pid, "session_id", s.SessionID)
+		s.hang.killed()
 
-		// Send SIGTERM first
-		s.Cmd.Process.Signal(syscall.SIGTERM)
+		// Send SIGTERM first (on Windows, close the pseudo console)
+		s.terminate()
 
 		// Give it 5 seconds to exit gracefully
 		time.Sleep(5 * time.Second)
 
-		// Force kill if still running
-		if s.Cmd.ProcessState == nil {
+		// Force kill if still running. Adopted games never get a ProcessState,
+		// so check the process itself too.
+		if s.Cmd.ProcessState == nil && processAlive(s.Cmd.Process) {
 			s.logger.Debug("Sending SIGKILL to process for session", "pid", s.Cmd.Process.Pid, "session_id", s.SessionID)
-			s.Cmd.Process.Signal(syscall.SIGKILL)
+			s.Cmd.Process.Kill()
 		}
 	}
 
@@ -524,6 +682,8 @@ func (s *PTYSession) UnsubscribeFromOutput(subscriptionID string) {
 
 // sendInitialInput sends any initial input required by the game
 func (s *PTYSession) sendInitialInput() {
+	defer s.recoverPanic("pty_initial_input")
+
 	// Wait a moment for the game to start
 	select {
 	case <-s.closeChan:
@@ -0,0 +1,38 @@
+//go:build linux
+
+package pty
+
+import (
+	"os/exec"
+	"syscall"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// applyNamespaces starts the command in the configured Linux namespaces
+func applyNamespaces(cmd *exec.Cmd, namespaces *config.NamespaceConfig) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+
+	var flags uintptr
+	if namespaces.PID {
+		flags |= syscall.CLONE_NEWPID
+	}
+	if namespaces.Network {
+		flags |= syscall.CLONE_NEWNET
+	}
+	if namespaces.Mount {
+		flags |= syscall.CLONE_NEWNS
+	}
+	if namespaces.UTS {
+		flags |= syscall.CLONE_NEWUTS
+	}
+	if namespaces.IPC {
+		flags |= syscall.CLONE_NEWIPC
+	}
+	if namespaces.User {
+		flags |= syscall.CLONE_NEWUSER
+	}
+	cmd.SysProcAttr.Cloneflags |= flags
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+
+package pty
+
+import (
+	"os/exec"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// applyNamespaces is a no-op; namespaces only exist on Linux and
+// ConfigureIsolation never enables them elsewhere
+func applyNamespaces(cmd *exec.Cmd, namespaces *config.NamespaceConfig) {}
@@ -0,0 +1,70 @@
+package pty
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/platform"
+)
+
+// ConfigureIsolation decides which of the configured isolation features the
+// manager applies to game processes. Features the platform cannot provide, or
+// all of them in dev mode, are skipped with a warning rather than failing game
+// starts.
+func (m *PTYManager) ConfigureIsolation(isolation *config.IsolationConfig, devMode bool) {
+	m.devMode = devMode
+	m.namespaces = nil
+	m.cgroupParent = ""
+	m.cgroupLimits = nil
+
+	host := platform.Detect()
+	m.logger.Info("Game process isolation",
+		"os", host.OS,
+		"dev_mode", devMode,
+		"namespaces_supported", host.Namespaces,
+		"cgroups_supported", host.Cgroups)
+
+	if isolation == nil {
+		return
+	}
+
+	if isolation.Namespaces != nil && isolation.Namespaces.Enabled() {
+		switch {
+		case devMode:
+			m.logger.Warn("Dev mode: skipping namespace isolation, games run as plain child processes")
+		case !host.Namespaces:
+			m.logger.Warn("Namespace isolation is not supported on this platform, skipping", "os", host.OS)
+		default:
+			m.namespaces = isolation.Namespaces
+		}
+	}
+
+	if isolation.Cgroups != nil && isolation.Cgroups.Enabled {
+		switch {
+		case devMode:
+			m.logger.Warn("Dev mode: skipping cgroup resource limits")
+		case !host.Cgroups:
+			m.logger.Warn("Cgroups are not supported on this platform, skipping resource limits", "os", host.OS)
+		default:
+			m.configureCgroups(isolation.Cgroups)
+		}
+	}
+}
+
+// ResolveGameBinary returns the configured binary path if it exists, and
+// otherwise looks the binary's name up on PATH. This lets development configs
+// written for one install layout (e.g. Homebrew) work on another.
+func ResolveGameBinary(path string) (string, error) {
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	resolved, err := exec.LookPath(filepath.Base(path))
+	if err != nil {
+		return "", fmt.Errorf("game binary not found at %s or on PATH: %w", path, err)
+	}
+	return resolved, nil
+}
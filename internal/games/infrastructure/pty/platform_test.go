@@ -0,0 +1,30 @@
+package pty
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGameBinary(t *testing.T) {
+	binDir := t.TempDir()
+	binary := filepath.Join(binDir, "fakehack")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\n"), 0755))
+
+	// An existing configured path is used as-is
+	resolved, err := ResolveGameBinary(binary)
+	require.NoError(t, err)
+	assert.Equal(t, binary, resolved)
+
+	// A missing path falls back to PATH lookup by name
+	t.Setenv("PATH", binDir)
+	resolved, err = ResolveGameBinary("/opt/homebrew/bin/fakehack")
+	require.NoError(t, err)
+	assert.Equal(t, binary, resolved)
+
+	_, err = ResolveGameBinary("/opt/homebrew/bin/nosuchgame")
+	assert.Error(t, err)
+}
@@ -0,0 +1,35 @@
+package pty
+
+import "runtime/debug"
+
+// PanicRecorder counts recovered panics; metrics.Registry implements it
+type PanicRecorder interface {
+	RecordPanic(component string)
+}
+
+// SetPanicRecorder sets where panics recovered in PTY goroutines are counted.
+// It applies to PTYs created afterwards.
+func (m *PTYManager) SetPanicRecorder(recorder PanicRecorder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.panicRecorder = recorder
+}
+
+// recoverPanic must be deferred directly by each of a session's goroutines.
+// A panic is logged with its stack and counted, then the game is terminated
+// so only this session ends and the game service keeps running.
+func (s *PTYSession) recoverPanic(component string) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	s.logger.Error("Recovered panic in PTY goroutine",
+		"component", component,
+		"panic", r,
+		"stack", string(debug.Stack()))
+	if s.panicRecorder != nil {
+		s.panicRecorder.RecordPanic(component)
+	}
+	go s.ForceTerminate()
+}
@@ -0,0 +1,38 @@
+package pty
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type countingRecorder struct {
+	components []string
+}
+
+func (r *countingRecorder) RecordPanic(component string) {
+	r.components = append(r.components, component)
+}
+
+func TestPTYSession_RecoverPanic(t *testing.T) {
+	recorder := &countingRecorder{}
+	session := &PTYSession{
+		SessionID:     "test-session",
+		logger:        slog.New(slog.NewTextHandler(io.Discard, nil)),
+		panicRecorder: recorder,
+	}
+
+	assert.NotPanics(t, func() {
+		defer session.recoverPanic("pty_output")
+		panic("boom")
+	})
+	assert.Equal(t, []string{"pty_output"}, recorder.components)
+
+	// Nothing is recorded when the goroutine returns normally
+	func() {
+		defer session.recoverPanic("pty_input")
+	}()
+	assert.Len(t, recorder.components, 1)
+}
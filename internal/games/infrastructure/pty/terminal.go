@@ -0,0 +1,27 @@
+package pty
+
+import (
+	"io"
+	"os"
+
+	"github.com/creack/pty"
+)
+
+// Terminal is the terminal a game runs in: a PTY master on Unix or a pseudo
+// console (ConPTY) on Windows. Reads return the game's output and writes are
+// its input.
+type Terminal interface {
+	io.ReadWriteCloser
+	// Resize sets the terminal size the game sees
+	Resize(size *pty.Winsize) error
+}
+
+// ptyFile is the master of a Unix PTY
+type ptyFile struct {
+	*os.File
+}
+
+// Resize sets the PTY's window size, which signals SIGWINCH to the game
+func (f *ptyFile) Resize(size *pty.Winsize) error {
+	return pty.Setsize(f.File, size)
+}
@@ -0,0 +1,42 @@
+//go:build !windows
+
+package pty
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/creack/pty"
+)
+
+// startTerminal starts cmd on a new PTY. The caller sets its size once
+// started, as pty.Start works better with NetHack on macOS than starting
+// with attributes.
+func startTerminal(cmd *exec.Cmd, _ *pty.Winsize) (Terminal, error) {
+	ptmx, err := pty.Start(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return &ptyFile{ptmx}, nil
+}
+
+// wait waits for the game process to exit
+func (s *PTYSession) wait() error {
+	return s.Cmd.Wait()
+}
+
+// processAlive reports whether a process is still running
+func processAlive(process *os.Process) bool {
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// hangup sends SIGHUP to the game process
+func (s *PTYSession) hangup() error {
+	return s.Cmd.Process.Signal(syscall.SIGHUP)
+}
+
+// terminate sends SIGTERM to the game process
+func (s *PTYSession) terminate() error {
+	return s.Cmd.Process.Signal(syscall.SIGTERM)
+}
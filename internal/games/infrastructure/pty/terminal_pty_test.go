@@ -0,0 +1,52 @@
+//go:build !windows
+
+package pty
+
+import (
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/creack/pty"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartTerminal(t *testing.T) {
+	cmd := exec.Command("sh", "-c", "sleep 0.2; stty size")
+	terminal, err := startTerminal(cmd, &pty.Winsize{Rows: 24, Cols: 80})
+	require.NoError(t, err)
+	defer terminal.Close()
+	require.NoError(t, terminal.Resize(&pty.Winsize{Rows: 30, Cols: 100}))
+	assert.True(t, processAlive(cmd.Process))
+
+	// The PTY reports EIO rather than EOF once the game exits
+	output, _ := io.ReadAll(terminal)
+	assert.Contains(t, string(output), "30 100")
+
+	session := &PTYSession{Cmd: cmd, PTY: terminal}
+	assert.NoError(t, session.wait())
+	assert.False(t, processAlive(cmd.Process))
+}
+
+func TestPTYSession_Hangup(t *testing.T) {
+	cmd := exec.Command("sleep", "10")
+	terminal, err := startTerminal(cmd, &pty.Winsize{Rows: 24, Cols: 80})
+	require.NoError(t, err)
+	defer terminal.Close()
+
+	session := &PTYSession{Cmd: cmd, PTY: terminal}
+	require.NoError(t, session.Hangup())
+
+	exited := make(chan error, 1)
+	go func() { exited <- session.wait() }()
+	select {
+	case err := <-exited:
+		var exitErr *exec.ExitError
+		assert.ErrorAs(t, err, &exitErr)
+	case <-time.After(5 * time.Second):
+		t.Fatal("game did not exit on hangup")
+	}
+	assert.Error(t, session.Hangup())
+}
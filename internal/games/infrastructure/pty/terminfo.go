@@ -0,0 +1,87 @@
+package pty
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// terminfoDirs lists the standard terminfo database locations
+var terminfoDirs = []string{
+	"/etc/terminfo",
+	"/lib/terminfo",
+	"/usr/share/terminfo",
+	"/usr/lib/terminfo",
+	"/usr/share/lib/terminfo",
+}
+
+// terminfoSearchPath returns the directories searched for terminfo entries,
+// honouring TERMINFO and TERMINFO_DIRS like ncurses does
+func terminfoSearchPath() []string {
+	var dirs []string
+	if dir := os.Getenv("TERMINFO"); dir != "" {
+		dirs = append(dirs, dir)
+	}
+	if home := os.Getenv("HOME"); home != "" {
+		dirs = append(dirs, filepath.Join(home, ".terminfo"))
+	}
+	for _, dir := range strings.Split(os.Getenv("TERMINFO_DIRS"), ":") {
+		if dir != "" {
+			dirs = append(dirs, dir)
+		}
+	}
+	return append(dirs, terminfoDirs...)
+}
+
+// hasTerminfo reports whether a terminfo entry exists for the terminal type.
+// The second return value is false when no terminfo database was found at all,
+// in which case the lookup result is meaningless.
+func hasTerminfo(dirs []string, termType string) (found bool, searched bool) {
+	if termType == "" {
+		return false, false
+	}
+	for _, dir := range dirs {
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			continue
+		}
+		searched = true
+
+		// Linux uses the first letter, macOS the hex code of the first letter
+		candidates := []string{
+			filepath.Join(dir, termType[:1], termType),
+			filepath.Join(dir, hexPrefix(termType[0]), termType),
+		}
+		for _, path := range candidates {
+			if _, err := os.Stat(path); err == nil {
+				return true, true
+			}
+		}
+	}
+	return false, searched
+}
+
+// hexPrefix returns the two-digit hex directory name used by some terminfo layouts
+func hexPrefix(b byte) string {
+	const digits = "0123456789abcdef"
+	return string([]byte{digits[b>>4], digits[b&0x0f]})
+}
+
+// resolveTerminalType makes sure the game gets a TERM value the host can
+// describe, degrading to a more common entry when the terminfo is missing
+func resolveTerminalType(dirs []string, termType string) string {
+	found, searched := hasTerminfo(dirs, termType)
+	if found || !searched {
+		return termType
+	}
+
+	fallbacks := []string{"xterm", "vt100"}
+	if strings.Contains(termType, "256color") {
+		fallbacks = append([]string{"xterm-256color"}, fallbacks...)
+	}
+	for _, fallback := range fallbacks {
+		if ok, _ := hasTerminfo(dirs, fallback); ok {
+			return fallback
+		}
+	}
+	return termType
+}
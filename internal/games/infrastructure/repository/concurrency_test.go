@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+func TestStubSessionRepository_OptimisticConcurrency(t *testing.T) {
+	repo := NewStubSessionRepository()
+	ctx := context.Background()
+	id := uuid.New().String()
+
+	session := createMockSessionWithID(id, 1, "1")
+	require.NoError(t, repo.Save(ctx, session))
+	assert.Equal(t, int64(1), session.Version())
+
+	// Two writers load the session at the same version
+	first := createMockSessionWithID(id, 1, "1")
+	first.SetVersion(1)
+	second := createMockSessionWithID(id, 1, "1")
+	second.SetVersion(1)
+
+	require.NoError(t, repo.Save(ctx, first))
+	assert.Equal(t, int64(2), first.Version())
+
+	err := repo.Save(ctx, second)
+	require.ErrorIs(t, err, domain.ErrConflict)
+	var conflict *domain.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "session", conflict.Aggregate)
+	assert.Equal(t, int64(1), conflict.Expected)
+	assert.Equal(t, int64(2), conflict.Actual)
+
+	// A new session does not replace a stored one
+	assert.ErrorIs(t, repo.Save(ctx, createMockSessionWithID(id, 1, "1")), domain.ErrConflict)
+
+	// Saving the loaded session again moves it on
+	require.NoError(t, repo.Save(ctx, first))
+	assert.Equal(t, int64(3), first.Version())
+}
+
+func TestStubSaveRepository_OptimisticConcurrency(t *testing.T) {
+	repo := NewStubSaveRepository()
+	ctx := context.Background()
+	id := domain.NewSaveID(uuid.New().String())
+	newSave := func() *domain.GameSave {
+		return domain.NewGameSave(id, domain.NewUserID(1), domain.NewGameID("1"), []byte("data"), "/tmp/save.dat", domain.SaveMetadata{})
+	}
+
+	require.NoError(t, repo.Save(ctx, newSave()))
+
+	stale := newSave()
+	stale.SetVersion(1)
+	current := newSave()
+	current.SetVersion(1)
+	require.NoError(t, repo.Save(ctx, current))
+
+	err := repo.Save(ctx, stale)
+	assert.ErrorIs(t, err, domain.ErrConflict)
+	var conflict *domain.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, "save", conflict.Aggregate)
+}
+
+func TestSessionRepository_UpdateConflict(t *testing.T) {
+	db, _, cleanup := setupSQLiteDB(t)
+	defer cleanup()
+
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+	id := uuid.New().String()
+
+	session := createMockSessionWithID(id, 1, "1")
+	require.NoError(t, repo.Save(ctx, session))
+	assert.Equal(t, int64(1), session.Version())
+
+	first, err := repo.FindByID(ctx, uuid.MustParse(id))
+	require.NoError(t, err)
+	second, err := repo.FindByID(ctx, uuid.MustParse(id))
+	require.NoError(t, err)
+
+	first.Start(domain.ProcessInfo{PID: 4242})
+	require.NoError(t, repo.Save(ctx, first))
+	assert.Equal(t, int64(2), first.Version())
+
+	second.End(nil, nil)
+	err = repo.Save(ctx, second)
+	require.ErrorIs(t, err, domain.ErrConflict)
+	var conflict *domain.ConflictError
+	require.True(t, errors.As(err, &conflict))
+	assert.Equal(t, int64(1), conflict.Expected)
+	assert.Equal(t, int64(2), conflict.Actual)
+
+	// A session that is gone is not a conflict
+	require.NoError(t, repo.Delete(ctx, uuid.MustParse(id)))
+	err = repo.Update(ctx, first)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrConflict)
+}
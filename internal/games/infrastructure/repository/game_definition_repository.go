@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/database"
+)
+
+// createGameDefinitionTable creates the table of game definitions, one row
+// per game, each holding the game's YAML as in the games list of the game
+// service configuration
+const createGameDefinitionTable = `
+	CREATE TABLE IF NOT EXISTS game_definitions (
+		id VARCHAR(100) PRIMARY KEY,
+		definition TEXT NOT NULL,
+		updated_at TIMESTAMP NOT NULL
+	)`
+
+// SQLGameDefinitionRepository stores the game definitions admins create or
+// override through the API
+type SQLGameDefinitionRepository struct {
+	db *database.Connection
+
+	mu         sync.Mutex
+	tableReady bool
+}
+
+// NewSQLGameDefinitionRepository creates a game definition repository in db
+func NewSQLGameDefinitionRepository(db *database.Connection) *SQLGameDefinitionRepository {
+	return &SQLGameDefinitionRepository{db: db}
+}
+
+// List returns the stored definitions by ID. Each is validated as the
+// configuration file is, so one edited in the database by hand is refused.
+func (r *SQLGameDefinitionRepository) List(ctx context.Context) ([]*config.GameConfig, error) {
+	if err := r.ensureTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `SELECT id, definition FROM game_definitions ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query game definitions: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*config.GameConfig
+	for rows.Next() {
+		var id, definition string
+		if err := rows.Scan(&id, &definition); err != nil {
+			return nil, fmt.Errorf("failed to scan game definition: %w", err)
+		}
+		game, err := config.ParseGameDefinition([]byte(definition), "game_definitions/"+id)
+		if err != nil {
+			return nil, err
+		}
+		if game.ID != id {
+			return nil, fmt.Errorf("game definition %s defines game %s", id, game.ID)
+		}
+		games = append(games, game)
+	}
+	return games, rows.Err()
+}
+
+// Save stores a game's definition, replacing any stored before
+func (r *SQLGameDefinitionRepository) Save(ctx context.Context, game *config.GameConfig) error {
+	if err := r.ensureTable(ctx); err != nil {
+		return err
+	}
+
+	definition, err := config.MarshalGameDefinition(game)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(ctx, `
+		INSERT INTO game_definitions (id, definition, updated_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET definition = EXCLUDED.definition, updated_at = EXCLUDED.updated_at
+	`, game.ID, string(definition), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to save game definition: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a game's definition and reports whether there was one
+func (r *SQLGameDefinitionRepository) Delete(ctx context.Context, gameID string) (bool, error) {
+	if err := r.ensureTable(ctx); err != nil {
+		return false, err
+	}
+
+	result, err := r.db.ExecContext(ctx, `DELETE FROM game_definitions WHERE id = $1`, gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete game definition: %w", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+// ensureTable creates the table of game definitions on first use
+func (r *SQLGameDefinitionRepository) ensureTable(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.tableReady {
+		return nil
+	}
+	if _, err := r.db.ExecContext(ctx, createGameDefinitionTable); err != nil {
+		return fmt.Errorf("failed to create game definition table: %w", err)
+	}
+	r.tableReady = true
+	return nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func newDefinitionTestGame(id, name string) *config.GameConfig {
+	return &config.GameConfig{
+		ID:      id,
+		Name:    name,
+		Enabled: true,
+		Binary:  &config.BinaryConfig{Path: "/usr/games/" + id},
+		Paths:   &config.GamePathsConfig{AutoDetect: true},
+	}
+}
+
+func TestSQLGameDefinitionRepository(t *testing.T) {
+	db, _, cleanup := setupSQLiteDB(t)
+	defer cleanup()
+
+	repo := NewSQLGameDefinitionRepository(db)
+	ctx := context.Background()
+
+	games, err := repo.List(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, games)
+
+	require.NoError(t, repo.Save(ctx, newDefinitionTestGame("zork", "Zork")))
+	require.NoError(t, repo.Save(ctx, newDefinitionTestGame("crawl", "Crawl")))
+	require.NoError(t, repo.Save(ctx, newDefinitionTestGame("crawl", "Dungeon Crawl Stone Soup")))
+
+	games, err = repo.List(ctx)
+	require.NoError(t, err)
+	require.Len(t, games, 2)
+	assert.Equal(t, newDefinitionTestGame("crawl", "Dungeon Crawl Stone Soup"), games[0], "saving again replaces the definition")
+	assert.Equal(t, "zork", games[1].ID)
+
+	deleted, err := repo.Delete(ctx, "zork")
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	deleted, err = repo.Delete(ctx, "zork")
+	require.NoError(t, err)
+	assert.False(t, deleted)
+
+	// A definition broken by hand is refused rather than run
+	_, err = db.ExecContext(ctx, `UPDATE game_definitions SET definition = $1 WHERE id = $2`, "id: crawl\nname: Crawl\n", "crawl")
+	require.NoError(t, err)
+	_, err = repo.List(ctx)
+	assert.ErrorContains(t, err, "validation failed")
+}
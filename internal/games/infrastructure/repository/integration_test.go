@@ -178,7 +178,8 @@ func createTestTablesSQLite(db *database.Connection) error {
 		terminal_width INTEGER DEFAULT 80,
 		terminal_height INTEGER DEFAULT 24,
 		resource_usage TEXT DEFAULT '{}',
-		metadata TEXT DEFAULT '{}'
+		metadata TEXT DEFAULT '{}',
+		version INTEGER NOT NULL DEFAULT 1
 	);
 
 	-- Game saves table
@@ -266,7 +267,8 @@ func createTestTablesPostgreSQL(db *database.Connection) error {
 		terminal_width INTEGER DEFAULT 80,
 		terminal_height INTEGER DEFAULT 24,
 		resource_usage JSONB DEFAULT '{}',
-		metadata JSONB DEFAULT '{}'
+		metadata JSONB DEFAULT '{}',
+		version INTEGER NOT NULL DEFAULT 1
 	);
 
 	-- Game saves table
@@ -356,6 +358,11 @@ func TestSessionRepository_Integration_CRUD(t *testing.T) {
 		updatedSession, err := repo.FindByID(ctx, uuid.MustParse(sessionID.String()))
 		require.NoError(t, err)
 		assert.Equal(t, 12345, updatedSession.ProcessInfo().PID)
+		assert.Equal(t, int64(2), updatedSession.Version())
+
+		// A session loaded before the update is stale
+		err = repo.Update(ctx, session)
+		assert.ErrorIs(t, err, domain.ErrConflict)
 	}
 
 	// Test FindByUserID
@@ -397,7 +404,7 @@ func TestSessionRepository_Integration_DeleteExpiredSessions(t *testing.T) {
 
 	sessionQuery := convertQueryParams(`
 		INSERT INTO game_sessions (id, game_id, user_id, status, ended_at)
-		VALUES ($1, '1', 1, 'stopped', $2)
+		VALUES ($1, '1', 1, 'ended', $2)
 	`, dbType)
 
 	_, err := db.DB(database.QueryTypeWrite).ExecContext(ctx, sessionQuery, oldSessionID.String(), oldEndTime)
@@ -465,6 +472,10 @@ func TestSaveRepository_Integration_CRUD(t *testing.T) {
 	} else {
 		err = repo.Update(ctx, save)
 		require.NoError(t, err)
+
+		// The save found before the update is stale
+		err = repo.Update(ctx, foundSave)
+		assert.ErrorIs(t, err, domain.ErrConflict)
 	}
 
 	// Test FindByUserAndGame
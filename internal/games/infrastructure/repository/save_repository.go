@@ -50,7 +50,7 @@ func (r *PostgreSQLSaveRepository) Create(ctx context.Context, save *domain.Game
 		save.FilePath(),
 		save.FileSize(),
 		save.Checksum(),
-		1, // version - would need to be added to domain
+		1,
 		save.IsActive(),
 		metadata,
 		save.CreatedAt(),
@@ -61,9 +61,12 @@ func (r *PostgreSQLSaveRepository) Create(ctx context.Context, save *domain.Game
 		return fmt.Errorf("failed to create game save: %w", err)
 	}
 
+	save.SetVersion(1)
 	return nil
 }
 
+// Update stores the changes to a save. It fails with a domain.ConflictError
+// when the stored save has moved on from the version save was loaded at.
 func (r *PostgreSQLSaveRepository) Update(ctx context.Context, save *domain.GameSave) error {
 	metadata, err := json.Marshal(save.Metadata())
 	if err != nil {
@@ -76,31 +79,32 @@ func (r *PostgreSQLSaveRepository) Update(ctx context.Context, save *domain.Game
 		saveName = save.Metadata().Character
 	}
 
+	// Placeholders are numbered in order so SQLite binds them correctly too
 	query := `
 		UPDATE game_saves SET
-			name = $2,
-			description = $3,
-			file_path = $4,
-			file_size = $5,
-			checksum = $6,
-			version = $7,
-			is_active = $8,
-			metadata = $9,
-			updated_at = $10
-		WHERE id = $1
+			name = $1,
+			description = $2,
+			file_path = $3,
+			file_size = $4,
+			checksum = $5,
+			version = version + 1,
+			is_active = $6,
+			metadata = $7,
+			updated_at = $8
+		WHERE id = $9 AND version = $10
 	`
 
 	result, err := r.db.ExecContext(ctx, query,
-		save.ID().String(),
 		saveName,
 		"", // description - would need to be added to domain
 		save.FilePath(),
 		save.FileSize(),
 		save.Checksum(),
-		1, // version - would need to be added to domain
 		save.IsActive(),
 		metadata,
 		time.Now(),
+		save.ID().String(),
+		save.Version(),
 	)
 
 	if err != nil {
@@ -113,12 +117,27 @@ func (r *PostgreSQLSaveRepository) Update(ctx context.Context, save *domain.Game
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("game save not found: %s", save.ID().String())
+		return r.updateMissed(ctx, save)
 	}
 
+	save.SetVersion(save.Version() + 1)
 	return nil
 }
 
+// updateMissed explains an update that matched no row: the save is gone, or
+// another writer saved it since it was loaded
+func (r *PostgreSQLSaveRepository) updateMissed(ctx context.Context, save *domain.GameSave) error {
+	var stored int64
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM game_saves WHERE id = $1`, save.ID().String()).Scan(&stored)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", domain.ErrSaveNotFound, save.ID().String())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check game save version: %w", err)
+	}
+	return &domain.ConflictError{Aggregate: "save", ID: save.ID().String(), Expected: save.Version(), Actual: stored}
+}
+
 func (r *PostgreSQLSaveRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM game_saves WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id.String())
@@ -132,7 +151,7 @@ func (r *PostgreSQLSaveRepository) Delete(ctx context.Context, id uuid.UUID) err
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("game save not found: %s", id.String())
+		return fmt.Errorf("%w: %s", domain.ErrSaveNotFound, id.String())
 	}
 
 	return nil
@@ -344,7 +363,7 @@ func (r *PostgreSQLSaveRepository) scanSave(row *sql.Row) (*domain.GameSave, err
 	var sessionID, name, description sql.NullString
 	var filePath, checksum string
 	var fileSize int64
-	var version int
+	var version int64
 	var isActive bool
 	var metadata []byte
 	var createdAt, updatedAt time.Time
@@ -368,12 +387,12 @@ func (r *PostgreSQLSaveRepository) scanSave(row *sql.Row) (*domain.GameSave, err
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("game save not found")
+			return nil, domain.ErrSaveNotFound
 		}
 		return nil, fmt.Errorf("failed to scan game save: %w", err)
 	}
 
-	return r.buildDomainSave(saveID, userID, gameID, filePath, fileSize, checksum, metadata, createdAt, updatedAt)
+	return r.buildDomainSave(saveID, userID, gameID, filePath, fileSize, checksum, metadata, createdAt, updatedAt, version)
 }
 
 func (r *PostgreSQLSaveRepository) scanSaveFromRows(rows *sql.Rows) (*domain.GameSave, error) {
@@ -382,7 +401,7 @@ func (r *PostgreSQLSaveRepository) scanSaveFromRows(rows *sql.Rows) (*domain.Gam
 	var sessionID, name, description sql.NullString
 	var filePath, checksum string
 	var fileSize int64
-	var version int
+	var version int64
 	var isActive bool
 	var metadata []byte
 	var createdAt, updatedAt time.Time
@@ -408,10 +427,10 @@ func (r *PostgreSQLSaveRepository) scanSaveFromRows(rows *sql.Rows) (*domain.Gam
 		return nil, fmt.Errorf("failed to scan game save: %w", err)
 	}
 
-	return r.buildDomainSave(saveID, userID, gameID, filePath, fileSize, checksum, metadata, createdAt, updatedAt)
+	return r.buildDomainSave(saveID, userID, gameID, filePath, fileSize, checksum, metadata, createdAt, updatedAt, version)
 }
 
-func (r *PostgreSQLSaveRepository) buildDomainSave(saveID string, userID int, gameID, filePath string, fileSize int64, checksum string, metadataBytes []byte, createdAt, updatedAt time.Time) (*domain.GameSave, error) {
+func (r *PostgreSQLSaveRepository) buildDomainSave(saveID string, userID int, gameID, filePath string, fileSize int64, checksum string, metadataBytes []byte, createdAt, updatedAt time.Time, version int64) (*domain.GameSave, error) {
 	// Parse metadata
 	var metadata domain.SaveMetadata
 	if err := json.Unmarshal(metadataBytes, &metadata); err != nil {
@@ -430,5 +449,6 @@ func (r *PostgreSQLSaveRepository) buildDomainSave(saveID string, userID int, ga
 		metadata,
 	)
 
+	save.SetVersion(version)
 	return save, nil
 }
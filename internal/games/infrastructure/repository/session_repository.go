@@ -20,6 +20,9 @@ func NewPostgreSQLSessionRepository(db *database.Connection) *PostgreSQLSessionR
 	return &PostgreSQLSessionRepository{db: db}
 }
 
+// Save creates the session or updates it. An update fails with a
+// domain.ConflictError when the stored session has moved on from the
+// version session was loaded at.
 func (r *PostgreSQLSessionRepository) Save(ctx context.Context, session *domain.GameSession) error {
 	// Check if session exists, if so update, otherwise create
 	existing, err := r.FindByID(ctx, uuid.MustParse(session.ID().String()))
@@ -78,11 +81,11 @@ func (r *PostgreSQLSessionRepository) Create(ctx context.Context, session *domai
 		INSERT INTO game_sessions (
 			id, game_id, user_id, status, process_id, pod_id,
 			started_at, ended_at, last_activity, terminal_width, terminal_height,
-			resource_usage, metadata
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+			resource_usage, metadata, version
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, 1)
 	`
 
-	_, err = r.db.DB(database.QueryTypeWrite).ExecContext(ctx, query,
+	_, err = r.db.ExecContext(ctx, query,
 		session.ID().String(),
 		session.GameID().String(),
 		session.UserID().Int(),
@@ -102,6 +105,7 @@ func (r *PostgreSQLSessionRepository) Create(ctx context.Context, session *domai
 		return fmt.Errorf("failed to create game session: %w", err)
 	}
 
+	session.SetVersion(1)
 	return nil
 }
 
@@ -145,22 +149,23 @@ func (r *PostgreSQLSessionRepository) Update(ctx context.Context, session *domai
 		return fmt.Errorf("failed to marshal resource usage: %w", err)
 	}
 
+	// Placeholders are numbered in order so SQLite binds them correctly too
 	query := `
 		UPDATE game_sessions SET
-			status = $2,
-			process_id = $3,
-			pod_id = $4,
-			ended_at = $5,
-			last_activity = $6,
-			terminal_width = $7,
-			terminal_height = $8,
-			resource_usage = $9,
-			metadata = $10
-		WHERE id = $1
+			status = $1,
+			process_id = $2,
+			pod_id = $3,
+			ended_at = $4,
+			last_activity = $5,
+			terminal_width = $6,
+			terminal_height = $7,
+			resource_usage = $8,
+			metadata = $9,
+			version = version + 1
+		WHERE id = $10 AND version = $11
 	`
 
-	result, err := r.db.DB(database.QueryTypeWrite).ExecContext(ctx, query,
-		session.ID().String(),
+	result, err := r.db.ExecContext(ctx, query,
 		string(session.Status()),
 		processID,
 		podID,
@@ -170,6 +175,8 @@ func (r *PostgreSQLSessionRepository) Update(ctx context.Context, session *domai
 		session.TerminalSize().Height,
 		resourceUsageBytes,
 		metadataBytes,
+		session.ID().String(),
+		session.Version(),
 	)
 
 	if err != nil {
@@ -182,12 +189,27 @@ func (r *PostgreSQLSessionRepository) Update(ctx context.Context, session *domai
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("game session not found: %s", session.ID().String())
+		return r.updateMissed(ctx, session)
 	}
 
+	session.SetVersion(session.Version() + 1)
 	return nil
 }
 
+// updateMissed explains an update that matched no row: the session is gone,
+// or another writer saved it since it was loaded
+func (r *PostgreSQLSessionRepository) updateMissed(ctx context.Context, session *domain.GameSession) error {
+	var stored int64
+	err := r.db.QueryRowContext(ctx, `SELECT version FROM game_sessions WHERE id = $1`, session.ID().String()).Scan(&stored)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", domain.ErrSessionNotFound, session.ID().String())
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check game session version: %w", err)
+	}
+	return &domain.ConflictError{Aggregate: "session", ID: session.ID().String(), Expected: session.Version(), Actual: stored}
+}
+
 func (r *PostgreSQLSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `DELETE FROM game_sessions WHERE id = $1`
 	result, err := r.db.ExecContext(ctx, query, id.String())
@@ -201,7 +223,7 @@ func (r *PostgreSQLSessionRepository) Delete(ctx context.Context, id uuid.UUID)
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("game session not found: %s", id.String())
+		return fmt.Errorf("%w: %s", domain.ErrSessionNotFound, id.String())
 	}
 
 	return nil
@@ -211,12 +233,12 @@ func (r *PostgreSQLSessionRepository) FindByID(ctx context.Context, id uuid.UUID
 	query := `
 		SELECT id, game_id, user_id, status, process_id, pod_id,
 			started_at, ended_at, last_activity, terminal_width, terminal_height,
-			resource_usage, metadata
+			resource_usage, metadata, version
 		FROM game_sessions
 		WHERE id = $1
 	`
 
-	row := r.db.DB(database.QueryTypeRead).QueryRowContext(ctx, query, id.String())
+	row := r.db.QueryRowContext(ctx, query, id.String())
 	return r.scanSession(row)
 }
 
@@ -224,7 +246,7 @@ func (r *PostgreSQLSessionRepository) FindByUserID(ctx context.Context, userID i
 	query := `
 		SELECT id, game_id, user_id, status, process_id, pod_id,
 			started_at, ended_at, last_activity, terminal_width, terminal_height,
-			resource_usage, metadata
+			resource_usage, metadata, version
 		FROM game_sessions
 		WHERE user_id = $1
 		ORDER BY started_at DESC
@@ -256,9 +278,9 @@ func (r *PostgreSQLSessionRepository) FindActiveByUserID(ctx context.Context, us
 	query := `
 		SELECT id, game_id, user_id, status, process_id, pod_id,
 			started_at, ended_at, last_activity, terminal_width, terminal_height,
-			resource_usage, metadata
+			resource_usage, metadata, version
 		FROM game_sessions
-		WHERE user_id = $1 AND status IN ('starting', 'running', 'paused')
+		WHERE user_id = $1 AND status IN ('starting', 'active', 'paused')
 		ORDER BY last_activity DESC
 	`
 
@@ -288,7 +310,7 @@ func (r *PostgreSQLSessionRepository) FindByStatus(ctx context.Context, status s
 	query := `
 		SELECT id, game_id, user_id, status, process_id, pod_id,
 			started_at, ended_at, last_activity, terminal_width, terminal_height,
-			resource_usage, metadata
+			resource_usage, metadata, version
 		FROM game_sessions
 		WHERE status = $1
 		ORDER BY last_activity DESC
@@ -329,7 +351,7 @@ func (r *PostgreSQLSessionRepository) DeleteExpiredSessions(ctx context.Context,
 	expirationTime := time.Now().Add(-maxAge)
 	query := `
 		DELETE FROM game_sessions 
-		WHERE status IN ('stopped', 'crashed', 'terminated') 
+		WHERE status IN ('ended', 'failed')
 		AND ended_at < $1
 	`
 
@@ -356,6 +378,7 @@ func (r *PostgreSQLSessionRepository) scanSession(row *sql.Row) (*domain.GameSes
 	var endedAt sql.NullTime
 	var terminalWidth, terminalHeight int
 	var resourceUsage, metadata []byte
+	var version int64
 
 	err := row.Scan(
 		&sessionID,
@@ -371,16 +394,17 @@ func (r *PostgreSQLSessionRepository) scanSession(row *sql.Row) (*domain.GameSes
 		&terminalHeight,
 		&resourceUsage,
 		&metadata,
+		&version,
 	)
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("game session not found")
+			return nil, domain.ErrSessionNotFound
 		}
 		return nil, fmt.Errorf("failed to scan game session: %w", err)
 	}
 
-	return r.buildDomainSession(sessionID, gameID, userID, status, processID, podID, startedAt, endedAt, terminalWidth, terminalHeight)
+	return r.buildDomainSession(sessionID, gameID, userID, status, processID, podID, startedAt, endedAt, terminalWidth, terminalHeight, version)
 }
 
 func (r *PostgreSQLSessionRepository) scanSessionFromRows(rows *sql.Rows) (*domain.GameSession, error) {
@@ -393,6 +417,7 @@ func (r *PostgreSQLSessionRepository) scanSessionFromRows(rows *sql.Rows) (*doma
 	var endedAt sql.NullTime
 	var terminalWidth, terminalHeight int
 	var resourceUsage, metadata []byte
+	var version int64
 
 	err := rows.Scan(
 		&sessionID,
@@ -408,16 +433,17 @@ func (r *PostgreSQLSessionRepository) scanSessionFromRows(rows *sql.Rows) (*doma
 		&terminalHeight,
 		&resourceUsage,
 		&metadata,
+		&version,
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to scan game session: %w", err)
 	}
 
-	return r.buildDomainSession(sessionID, gameID, userID, status, processID, podID, startedAt, endedAt, terminalWidth, terminalHeight)
+	return r.buildDomainSession(sessionID, gameID, userID, status, processID, podID, startedAt, endedAt, terminalWidth, terminalHeight, version)
 }
 
-func (r *PostgreSQLSessionRepository) buildDomainSession(sessionID, gameID string, userID int, status string, processID sql.NullInt32, podID sql.NullString, startedAt time.Time, endedAt sql.NullTime, terminalWidth, terminalHeight int) (*domain.GameSession, error) {
+func (r *PostgreSQLSessionRepository) buildDomainSession(sessionID, gameID string, userID int, status string, processID sql.NullInt32, podID sql.NullString, startedAt time.Time, endedAt sql.NullTime, terminalWidth, terminalHeight int, version int64) (*domain.GameSession, error) {
 	// Build domain session - this is a simplified reconstruction
 	// In a real implementation, you'd need to store more domain data or reconstruct it properly
 	session := domain.NewGameSession(
@@ -436,16 +462,19 @@ func (r *PostgreSQLSessionRepository) buildDomainSession(sessionID, gameID strin
 		if podID.Valid {
 			pod = podID.String
 		}
-		session.Start(domain.ProcessInfo{
+		session.SetProcessInfo(domain.ProcessInfo{
 			PID:     pid,
 			PodName: pod,
 		})
 	}
 
-	// Apply end time if available
+	// Loading is not a transition, so restore the stored status as is
+	var endTime *time.Time
 	if endedAt.Valid {
-		session.End(nil, nil)
+		endTime = &endedAt.Time
 	}
+	session.Restore(domain.SessionStatus(status), endTime)
 
+	session.SetVersion(version)
 	return session, nil
 }
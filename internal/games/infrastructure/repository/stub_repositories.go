@@ -37,7 +37,7 @@ func (r *StubGameRepository) FindByID(ctx context.Context, id domain.GameID) (*d
 
 	game, exists := r.games[id.String()]
 	if !exists {
-		return nil, fmt.Errorf("game not found")
+		return nil, domain.ErrGameNotFound
 	}
 	return game, nil
 }
@@ -52,7 +52,7 @@ func (r *StubGameRepository) FindByName(ctx context.Context, name string) (*doma
 			return game, nil
 		}
 	}
-	return nil, fmt.Errorf("game not found")
+	return nil, domain.ErrGameNotFound
 }
 
 // FindAll implements GameRepository
@@ -184,11 +184,22 @@ func NewStubSessionRepository() *StubSessionRepository {
 	}
 }
 
-// Save implements SessionRepository
+// Save implements SessionRepository. It fails with a domain.ConflictError
+// when the stored session has moved on from the version session was loaded at.
 func (r *StubSessionRepository) Save(ctx context.Context, session *domain.GameSession) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.sessions[session.ID().String()] = session
+
+	id := session.ID().String()
+	var stored int64
+	if existing, ok := r.sessions[id]; ok {
+		stored = existing.Version()
+	}
+	if err := checkVersion("session", id, session.Version(), stored); err != nil {
+		return err
+	}
+	session.SetVersion(stored + 1)
+	r.sessions[id] = session
 	return nil
 }
 
@@ -199,7 +210,7 @@ func (r *StubSessionRepository) FindByID(ctx context.Context, id domain.SessionI
 
 	session, exists := r.sessions[id.String()]
 	if !exists {
-		return nil, fmt.Errorf("session not found")
+		return nil, domain.ErrSessionNotFound
 	}
 	return session, nil
 }
@@ -391,11 +402,31 @@ func NewStubSaveRepository() *StubSaveRepository {
 	}
 }
 
-// Save implements SaveRepository
+// Save implements SaveRepository. It fails with a domain.ConflictError when
+// the stored save has moved on from the version save was loaded at.
 func (r *StubSaveRepository) Save(ctx context.Context, save *domain.GameSave) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.saves[save.ID().String()] = save
+
+	id := save.ID().String()
+	var stored int64
+	if existing, ok := r.saves[id]; ok {
+		stored = existing.Version()
+	}
+	if err := checkVersion("save", id, save.Version(), stored); err != nil {
+		return err
+	}
+	save.SetVersion(stored + 1)
+	r.saves[id] = save
+	return nil
+}
+
+// checkVersion returns a domain.ConflictError unless a write based on the
+// expected version may replace the stored one. Nothing stored is version 0.
+func checkVersion(aggregate, id string, expected, stored int64) error {
+	if expected != stored {
+		return &domain.ConflictError{Aggregate: aggregate, ID: id, Expected: expected, Actual: stored}
+	}
 	return nil
 }
 
@@ -406,7 +437,7 @@ func (r *StubSaveRepository) FindByID(ctx context.Context, id domain.SaveID) (*d
 
 	save, exists := r.saves[id.String()]
 	if !exists {
-		return nil, fmt.Errorf("save not found")
+		return nil, domain.ErrSaveNotFound
 	}
 	return save, nil
 }
@@ -417,11 +448,16 @@ func (r *StubSaveRepository) FindByUserAndGame(ctx context.Context, userID domai
 	defer r.mu.RUnlock()
 
 	for _, save := range r.saves {
-		if save.UserID() == userID && save.GameID() == gameID {
-			return save, nil
+		if save.UserID() != userID || save.GameID() != gameID {
+			continue
+		}
+		// Archived and deleted saves are superseded by the user's current one
+		if save.Status() == domain.SaveStatusArchived || save.Status() == domain.SaveStatusDeleted {
+			continue
 		}
+		return save, nil
 	}
-	return nil, fmt.Errorf("save not found")
+	return nil, domain.ErrSaveNotFound
 }
 
 // FindByUser implements SaveRepository
@@ -666,65 +702,211 @@ func (r *StubEventRepository) DeleteOldEvents(ctx context.Context, maxAge time.D
 	return count, nil
 }
 
-// StubUnitOfWork provides an in-memory implementation of UnitOfWork for development
-type StubUnitOfWork struct {
-	gameRepo    domain.GameRepository
-	sessionRepo domain.SessionRepository
-	saveRepo    domain.SaveRepository
-	eventRepo   domain.EventRepository
+// StubDumplogRepository provides an in-memory implementation of DumplogRepository for development
+type StubDumplogRepository struct {
+	mu       sync.RWMutex
+	dumplogs []*domain.Dumplog
 }
 
-// NewStubUnitOfWork creates a new in-memory unit of work
-func NewStubUnitOfWork(
-	gameRepo domain.GameRepository,
-	sessionRepo domain.SessionRepository,
-	saveRepo domain.SaveRepository,
-	eventRepo domain.EventRepository,
-) *StubUnitOfWork {
-	return &StubUnitOfWork{
-		gameRepo:    gameRepo,
-		sessionRepo: sessionRepo,
-		saveRepo:    saveRepo,
-		eventRepo:   eventRepo,
+// NewStubDumplogRepository creates a new in-memory dumplog repository
+func NewStubDumplogRepository() *StubDumplogRepository {
+	return &StubDumplogRepository{
+		dumplogs: make([]*domain.Dumplog, 0),
 	}
 }
 
-// Begin implements UnitOfWork
-func (u *StubUnitOfWork) Begin(ctx context.Context) error {
-	// Stub implementation - no actual transaction
+// Save implements DumplogRepository
+func (r *StubDumplogRepository) Save(ctx context.Context, dumplog *domain.Dumplog) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.dumplogs {
+		if existing.ID() == dumplog.ID() {
+			r.dumplogs[i] = dumplog
+			return nil
+		}
+	}
+	r.dumplogs = append(r.dumplogs, dumplog)
 	return nil
 }
 
-// Commit implements UnitOfWork
-func (u *StubUnitOfWork) Commit(ctx context.Context) error {
-	// Stub implementation - no actual transaction
-	return nil
+// FindByID implements DumplogRepository
+func (r *StubDumplogRepository) FindByID(ctx context.Context, id domain.DumplogID) (*domain.Dumplog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, dumplog := range r.dumplogs {
+		if dumplog.ID() == id {
+			return dumplog, nil
+		}
+	}
+	return nil, fmt.Errorf("dumplog not found")
 }
 
-// Rollback implements UnitOfWork
-func (u *StubUnitOfWork) Rollback(ctx context.Context) error {
-	// Stub implementation - no actual transaction
+// FindBySession implements DumplogRepository
+func (r *StubDumplogRepository) FindBySession(ctx context.Context, sessionID domain.SessionID) (*domain.Dumplog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, dumplog := range r.dumplogs {
+		if dumplog.SessionID() == sessionID {
+			return dumplog, nil
+		}
+	}
+	return nil, fmt.Errorf("dumplog not found")
+}
+
+// FindRecentByUser implements DumplogRepository
+func (r *StubDumplogRepository) FindRecentByUser(ctx context.Context, userID domain.UserID, gameID *domain.GameID, limit int) ([]*domain.Dumplog, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Dumplogs are appended in capture order, so walk backwards for newest first
+	var dumplogs []*domain.Dumplog
+	for i := len(r.dumplogs) - 1; i >= 0; i-- {
+		dumplog := r.dumplogs[i]
+		if dumplog.UserID() != userID {
+			continue
+		}
+		if gameID != nil && dumplog.GameID() != *gameID {
+			continue
+		}
+		dumplogs = append(dumplogs, dumplog)
+		if limit > 0 && len(dumplogs) >= limit {
+			break
+		}
+	}
+	return dumplogs, nil
+}
+
+// StubScreenshotRepository provides an in-memory implementation of ScreenshotRepository for development
+type StubScreenshotRepository struct {
+	mu          sync.RWMutex
+	screenshots []*domain.Screenshot
+}
+
+// NewStubScreenshotRepository creates a new in-memory screenshot repository
+func NewStubScreenshotRepository() *StubScreenshotRepository {
+	return &StubScreenshotRepository{
+		screenshots: make([]*domain.Screenshot, 0),
+	}
+}
+
+// Save implements ScreenshotRepository
+func (r *StubScreenshotRepository) Save(ctx context.Context, screenshot *domain.Screenshot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.screenshots {
+		if existing.ID() == screenshot.ID() {
+			r.screenshots[i] = screenshot
+			return nil
+		}
+	}
+	r.screenshots = append(r.screenshots, screenshot)
 	return nil
 }
 
-// Games implements UnitOfWork
-func (u *StubUnitOfWork) Games() domain.GameRepository {
-	return u.gameRepo
+// FindByID implements ScreenshotRepository
+func (r *StubScreenshotRepository) FindByID(ctx context.Context, id domain.ScreenshotID) (*domain.Screenshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, screenshot := range r.screenshots {
+		if screenshot.ID() == id {
+			return screenshot, nil
+		}
+	}
+	return nil, domain.ErrScreenshotNotFound
 }
 
-// Sessions implements UnitOfWork
-func (u *StubUnitOfWork) Sessions() domain.SessionRepository {
-	return u.sessionRepo
+// FindByShareToken implements ScreenshotRepository
+func (r *StubScreenshotRepository) FindByShareToken(ctx context.Context, token string) (*domain.Screenshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, screenshot := range r.screenshots {
+		if token != "" && screenshot.ShareToken() == token {
+			return screenshot, nil
+		}
+	}
+	return nil, domain.ErrScreenshotNotFound
 }
 
-// Saves implements UnitOfWork
-func (u *StubUnitOfWork) Saves() domain.SaveRepository {
-	return u.saveRepo
+// FindBySession implements ScreenshotRepository
+func (r *StubScreenshotRepository) FindBySession(ctx context.Context, sessionID domain.SessionID) ([]*domain.Screenshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var screenshots []*domain.Screenshot
+	for _, screenshot := range r.screenshots {
+		if screenshot.SessionID() == sessionID {
+			screenshots = append(screenshots, screenshot)
+		}
+	}
+	return screenshots, nil
 }
 
-// Events implements UnitOfWork
-func (u *StubUnitOfWork) Events() domain.EventRepository {
-	return u.eventRepo
+// FindRecentByUser implements ScreenshotRepository
+func (r *StubScreenshotRepository) FindRecentByUser(ctx context.Context, userID domain.UserID, limit int) ([]*domain.Screenshot, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Screenshots are appended as they are taken, so walk backwards for newest first
+	var screenshots []*domain.Screenshot
+	for i := len(r.screenshots) - 1; i >= 0; i-- {
+		screenshot := r.screenshots[i]
+		if screenshot.UserID() != userID && screenshot.TakenBy() != userID {
+			continue
+		}
+		screenshots = append(screenshots, screenshot)
+		if limit > 0 && len(screenshots) >= limit {
+			break
+		}
+	}
+	return screenshots, nil
+}
+
+// StubBookmarkRepository provides an in-memory implementation of BookmarkRepository for development
+type StubBookmarkRepository struct {
+	mu        sync.RWMutex
+	bookmarks []*domain.Bookmark
+}
+
+// NewStubBookmarkRepository creates a new in-memory bookmark repository
+func NewStubBookmarkRepository() *StubBookmarkRepository {
+	return &StubBookmarkRepository{
+		bookmarks: make([]*domain.Bookmark, 0),
+	}
+}
+
+// Save implements BookmarkRepository
+func (r *StubBookmarkRepository) Save(ctx context.Context, bookmark *domain.Bookmark) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, existing := range r.bookmarks {
+		if existing.ID() == bookmark.ID() {
+			r.bookmarks[i] = bookmark
+			return nil
+		}
+	}
+	r.bookmarks = append(r.bookmarks, bookmark)
+	return nil
+}
+
+// FindBySession implements BookmarkRepository
+func (r *StubBookmarkRepository) FindBySession(ctx context.Context, sessionID domain.SessionID) ([]*domain.Bookmark, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var bookmarks []*domain.Bookmark
+	for _, bookmark := range r.bookmarks {
+		if bookmark.SessionID() == sessionID {
+			bookmarks = append(bookmarks, bookmark)
+		}
+	}
+	return bookmarks, nil
 }
 
 // Helper functions
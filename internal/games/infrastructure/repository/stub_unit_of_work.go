@@ -0,0 +1,223 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+// StubUnitOfWork provides an in-memory implementation of UnitOfWork for
+// development. Writes made through its repositories within a transaction
+// are held back until the transaction commits and dropped if it rolls back;
+// reads in the transaction do not see them yet. Bulk cleanups apply at once.
+type StubUnitOfWork struct {
+	gameRepo    domain.GameRepository
+	sessionRepo domain.SessionRepository
+	saveRepo    domain.SaveRepository
+	eventRepo   domain.EventRepository
+}
+
+// NewStubUnitOfWork creates a new in-memory unit of work
+func NewStubUnitOfWork(
+	gameRepo domain.GameRepository,
+	sessionRepo domain.SessionRepository,
+	saveRepo domain.SaveRepository,
+	eventRepo domain.EventRepository,
+) *StubUnitOfWork {
+	return &StubUnitOfWork{
+		gameRepo:    gameRepo,
+		sessionRepo: sessionRepo,
+		saveRepo:    saveRepo,
+		eventRepo:   eventRepo,
+	}
+}
+
+// stubTx is an in-memory transaction: the writes to apply on commit
+type stubTx struct {
+	parent *stubTx
+
+	mu     sync.Mutex
+	writes []func(ctx context.Context) error
+	done   bool
+}
+
+// stubTxKey is the context key of the in-memory transaction in progress
+type stubTxKey struct{}
+
+// stubTxFrom returns the unfinished transaction ctx carries, or nil
+func stubTxFrom(ctx context.Context) *stubTx {
+	tx, _ := ctx.Value(stubTxKey{}).(*stubTx)
+	if tx == nil || tx.isDone() {
+		return nil
+	}
+	return tx
+}
+
+// hold keeps a write back until commit, reporting false once the
+// transaction has finished
+func (t *stubTx) hold(write func(ctx context.Context) error) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return false
+	}
+	t.writes = append(t.writes, write)
+	return true
+}
+
+// finish marks the transaction done and returns its writes, or false when
+// it had already finished
+func (t *stubTx) finish() ([]func(ctx context.Context) error, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return nil, false
+	}
+	t.done = true
+	return t.writes, true
+}
+
+// isDone reports whether the transaction, or one enclosing it, has finished
+func (t *stubTx) isDone() bool {
+	for tx := t; tx != nil; tx = tx.parent {
+		tx.mu.Lock()
+		done := tx.done
+		tx.mu.Unlock()
+		if done {
+			return true
+		}
+	}
+	return false
+}
+
+// inStubTx holds write back when ctx carries a transaction, and applies it
+// at once otherwise
+func inStubTx(ctx context.Context, write func(ctx context.Context) error) error {
+	if tx := stubTxFrom(ctx); tx != nil && tx.hold(write) {
+		return nil
+	}
+	return write(ctx)
+}
+
+// Begin implements UnitOfWork
+func (u *StubUnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	return context.WithValue(ctx, stubTxKey{}, &stubTx{parent: stubTxFrom(ctx)}), nil
+}
+
+// Commit implements UnitOfWork. A nested transaction hands its writes to the
+// enclosing one; the outermost applies them.
+func (u *StubUnitOfWork) Commit(ctx context.Context) error {
+	tx := stubTxFrom(ctx)
+	if tx == nil {
+		return domain.ErrNoTransaction
+	}
+	writes, ok := tx.finish()
+	if !ok {
+		return domain.ErrNoTransaction
+	}
+	if tx.parent != nil {
+		for _, write := range writes {
+			if !tx.parent.hold(write) {
+				return domain.ErrNoTransaction
+			}
+		}
+		return nil
+	}
+
+	for _, write := range writes {
+		if err := write(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rollback implements UnitOfWork. Rolling back a finished transaction does
+// nothing, so it may be deferred.
+func (u *StubUnitOfWork) Rollback(ctx context.Context) error {
+	if tx := stubTxFrom(ctx); tx != nil {
+		tx.finish()
+	}
+	return nil
+}
+
+// Games implements UnitOfWork
+func (u *StubUnitOfWork) Games() domain.GameRepository {
+	return stubTxGames{u.gameRepo}
+}
+
+// Sessions implements UnitOfWork
+func (u *StubUnitOfWork) Sessions() domain.SessionRepository {
+	return stubTxSessions{u.sessionRepo}
+}
+
+// Saves implements UnitOfWork
+func (u *StubUnitOfWork) Saves() domain.SaveRepository {
+	return stubTxSaves{u.saveRepo}
+}
+
+// Events implements UnitOfWork
+func (u *StubUnitOfWork) Events() domain.EventRepository {
+	return stubTxEvents{u.eventRepo}
+}
+
+// stubTxGames holds game writes back until the transaction commits
+type stubTxGames struct {
+	domain.GameRepository
+}
+
+func (r stubTxGames) Save(ctx context.Context, game *domain.Game) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.GameRepository.Save(ctx, game) })
+}
+
+func (r stubTxGames) Delete(ctx context.Context, id domain.GameID) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.GameRepository.Delete(ctx, id) })
+}
+
+func (r stubTxGames) UpdateStatistics(ctx context.Context, id domain.GameID, stats domain.GameStatistics) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.GameRepository.UpdateStatistics(ctx, id, stats) })
+}
+
+// stubTxSessions holds session writes back until the transaction commits
+type stubTxSessions struct {
+	domain.SessionRepository
+}
+
+func (r stubTxSessions) Save(ctx context.Context, session *domain.GameSession) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SessionRepository.Save(ctx, session) })
+}
+
+func (r stubTxSessions) Delete(ctx context.Context, id domain.SessionID) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SessionRepository.Delete(ctx, id) })
+}
+
+// stubTxSaves holds save writes back until the transaction commits
+type stubTxSaves struct {
+	domain.SaveRepository
+}
+
+func (r stubTxSaves) Save(ctx context.Context, save *domain.GameSave) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SaveRepository.Save(ctx, save) })
+}
+
+func (r stubTxSaves) Delete(ctx context.Context, id domain.SaveID) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SaveRepository.Delete(ctx, id) })
+}
+
+func (r stubTxSaves) SaveBackup(ctx context.Context, saveID domain.SaveID, backup domain.SaveBackup) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SaveRepository.SaveBackup(ctx, saveID, backup) })
+}
+
+func (r stubTxSaves) DeleteBackup(ctx context.Context, saveID domain.SaveID, backupID string) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.SaveRepository.DeleteBackup(ctx, saveID, backupID) })
+}
+
+// stubTxEvents holds events back until the transaction commits
+type stubTxEvents struct {
+	domain.EventRepository
+}
+
+func (r stubTxEvents) SaveEvent(ctx context.Context, event *domain.GameEvent) error {
+	return inStubTx(ctx, func(ctx context.Context) error { return r.EventRepository.SaveEvent(ctx, event) })
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/dungeongate/internal/games/domain"
+	"github.com/dungeongate/pkg/database"
+)
+
+// SQLUnitOfWork implements UnitOfWork with database transactions. Its
+// repositories join a transaction by querying through the same
+// database.Connection with the context Begin returned.
+type SQLUnitOfWork struct {
+	db          *database.Connection
+	gameRepo    domain.GameRepository
+	sessionRepo domain.SessionRepository
+	saveRepo    domain.SaveRepository
+	eventRepo   domain.EventRepository
+}
+
+// NewSQLUnitOfWork creates a unit of work over db
+func NewSQLUnitOfWork(
+	db *database.Connection,
+	gameRepo domain.GameRepository,
+	sessionRepo domain.SessionRepository,
+	saveRepo domain.SaveRepository,
+	eventRepo domain.EventRepository,
+) *SQLUnitOfWork {
+	return &SQLUnitOfWork{
+		db:          db,
+		gameRepo:    gameRepo,
+		sessionRepo: sessionRepo,
+		saveRepo:    saveRepo,
+		eventRepo:   eventRepo,
+	}
+}
+
+// Begin implements UnitOfWork. Within a transaction it sets a savepoint.
+func (u *SQLUnitOfWork) Begin(ctx context.Context) (context.Context, error) {
+	txCtx, _, err := u.db.Begin(ctx)
+	return txCtx, err
+}
+
+// Commit implements UnitOfWork
+func (u *SQLUnitOfWork) Commit(ctx context.Context) error {
+	tx := database.TxFromContext(ctx)
+	if tx == nil {
+		return domain.ErrNoTransaction
+	}
+	return tx.Commit()
+}
+
+// Rollback implements UnitOfWork. Rolling back a finished transaction does
+// nothing, so it may be deferred.
+func (u *SQLUnitOfWork) Rollback(ctx context.Context) error {
+	if tx := database.TxFromContext(ctx); tx != nil {
+		return tx.Rollback()
+	}
+	return nil
+}
+
+// Games implements UnitOfWork
+func (u *SQLUnitOfWork) Games() domain.GameRepository {
+	return u.gameRepo
+}
+
+// Sessions implements UnitOfWork
+func (u *SQLUnitOfWork) Sessions() domain.SessionRepository {
+	return u.sessionRepo
+}
+
+// Saves implements UnitOfWork
+func (u *SQLUnitOfWork) Saves() domain.SaveRepository {
+	return u.saveRepo
+}
+
+// Events implements UnitOfWork
+func (u *SQLUnitOfWork) Events() domain.EventRepository {
+	return u.eventRepo
+}
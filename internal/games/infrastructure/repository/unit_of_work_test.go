@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/games/domain"
+)
+
+func createMockSessionWithID(id string, userID int, gameID string) *domain.GameSession {
+	sessionID := domain.NewSessionID(id)
+	userIDDomain := domain.NewUserID(userID)
+	gameIDDomain := domain.NewGameID(gameID)
+	terminalSize := domain.TerminalSize{Width: 80, Height: 24}
+	session := domain.NewGameSession(sessionID, userIDDomain, "testuser", gameIDDomain, domain.GameConfig{}, terminalSize)
+	// Start the session to make it active
+	session.Start(domain.ProcessInfo{PID: 12345, PodName: "test-pod"})
+	return session
+}
+
+func TestSQLUnitOfWork_Transactions(t *testing.T) {
+	db, _, cleanup := setupSQLiteDB(t)
+	defer cleanup()
+
+	uow := NewSQLUnitOfWork(db, nil, nil, nil, nil)
+	repo := NewPostgreSQLSessionRepository(db)
+	ctx := context.Background()
+
+	exists := func(id string) bool {
+		_, err := repo.FindByID(ctx, uuid.MustParse(id))
+		return err == nil
+	}
+
+	// Rolled back
+	dropped := uuid.New().String()
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(txCtx, createMockSessionWithID(dropped, 1, "1")))
+	_, err = repo.FindByID(txCtx, uuid.MustParse(dropped))
+	require.NoError(t, err, "the transaction sees its own changes")
+	require.NoError(t, uow.Rollback(txCtx))
+	assert.False(t, exists(dropped))
+
+	// Committed, with a nested transaction rolled back
+	kept := uuid.New().String()
+	nestedDropped := uuid.New().String()
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(txCtx, createMockSessionWithID(kept, 1, "1")))
+
+	nestedCtx, err := uow.Begin(txCtx)
+	require.NoError(t, err)
+	require.NoError(t, repo.Create(nestedCtx, createMockSessionWithID(nestedDropped, 1, "1")))
+	require.NoError(t, uow.Rollback(nestedCtx))
+
+	require.NoError(t, uow.Commit(txCtx))
+	assert.NoError(t, uow.Rollback(txCtx), "rolling back after commit does nothing")
+	assert.True(t, exists(kept))
+	assert.False(t, exists(nestedDropped))
+
+	assert.ErrorIs(t, uow.Commit(ctx), domain.ErrNoTransaction)
+}
+
+func TestStubUnitOfWork_Transactions(t *testing.T) {
+	sessions := NewStubSessionRepository()
+	events := NewStubEventRepository()
+	uow := NewStubUnitOfWork(NewStubGameRepository(), sessions, NewStubSaveRepository(), events)
+	ctx := context.Background()
+
+	exists := func(id string) bool {
+		_, err := sessions.FindByID(ctx, domain.NewSessionID(id))
+		return err == nil
+	}
+
+	// Writes are held back until commit
+	txCtx, err := uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, uow.Sessions().Save(txCtx, createMockSessionWithID("kept", 1, "1")))
+	require.NoError(t, uow.Events().SaveEvent(txCtx, &domain.GameEvent{ID: "event_1", SessionID: "kept"}))
+	assert.False(t, exists("kept"))
+
+	nestedCtx, err := uow.Begin(txCtx)
+	require.NoError(t, err)
+	require.NoError(t, uow.Sessions().Save(nestedCtx, createMockSessionWithID("nested-dropped", 1, "1")))
+	require.NoError(t, uow.Rollback(nestedCtx))
+
+	nestedCtx, err = uow.Begin(txCtx)
+	require.NoError(t, err)
+	require.NoError(t, uow.Sessions().Save(nestedCtx, createMockSessionWithID("nested-kept", 1, "1")))
+	require.NoError(t, uow.Commit(nestedCtx))
+	assert.False(t, exists("nested-kept"), "a nested commit waits for the enclosing transaction")
+
+	require.NoError(t, uow.Commit(txCtx))
+	assert.True(t, exists("kept"))
+	assert.True(t, exists("nested-kept"))
+	assert.False(t, exists("nested-dropped"))
+	sessionEvents, err := events.FindEventsBySession(ctx, domain.NewSessionID("kept"))
+	require.NoError(t, err)
+	assert.Len(t, sessionEvents, 1)
+
+	// Rolled back writes are dropped
+	txCtx, err = uow.Begin(ctx)
+	require.NoError(t, err)
+	require.NoError(t, uow.Sessions().Save(txCtx, createMockSessionWithID("dropped", 1, "1")))
+	require.NoError(t, uow.Rollback(txCtx))
+	assert.False(t, exists("dropped"))
+	assert.ErrorIs(t, uow.Commit(txCtx), domain.ErrNoTransaction)
+
+	// Outside a transaction writes apply at once
+	require.NoError(t, uow.Sessions().Save(ctx, createMockSessionWithID("direct", 1, "1")))
+	assert.True(t, exists("direct"))
+}
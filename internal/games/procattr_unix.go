@@ -0,0 +1,18 @@
+//go:build !windows
+
+package games
+
+import "syscall"
+
+// gameProcAttr starts a game in a new session, as the game user when
+// dropPrivileges is set
+func gameProcAttr(dropPrivileges bool) *syscall.SysProcAttr {
+	attr := &syscall.SysProcAttr{Setsid: true}
+	if dropPrivileges {
+		attr.Credential = &syscall.Credential{
+			Uid: 1000, // game user
+			Gid: 1000, // game group
+		}
+	}
+	return attr
+}
@@ -0,0 +1,12 @@
+//go:build windows
+
+package games
+
+import "syscall"
+
+// gameProcAttr starts a game in a new process group, Windows' nearest
+// counterpart of a new session. Windows processes cannot be started as
+// another user this way, so dropPrivileges is ignored.
+func gameProcAttr(dropPrivileges bool) *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{CreationFlags: syscall.CREATE_NEW_PROCESS_GROUP}
+}
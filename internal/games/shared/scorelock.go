@@ -0,0 +1,79 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// RecordFile is the score file NetHack's score locks are named after
+	RecordFile = "record"
+	// scoreLockSuffix makes the name of NetHack's lock on a file
+	scoreLockSuffix = "_lock"
+	// staleScoreLockAge is how old a score lock must be to be left by a game
+	// that crashed while writing scores; games hold it for a moment
+	staleScoreLockAge = 10 * time.Minute
+)
+
+// scoreLockPath is the lock NetHack takes on a data directory's scores
+func scoreLockPath(dataDir string) string {
+	return filepath.Join(dataDir, RecordFile+scoreLockSuffix)
+}
+
+// LockScores locks the score files of a data directory the way NetHack does
+// while it writes them: record_lock is made as a hard link to record (or
+// created exclusively when there is no record yet), and the record file is
+// locked with fcntl for games built to use it. It retries until ctx is done
+// and breaks a lock left over staleScoreLockAge by a crashed game.
+func LockScores(ctx context.Context, dataDir string) (func(), error) {
+	record := filepath.Join(dataDir, RecordFile)
+	lock := scoreLockPath(dataDir)
+	for {
+		err := os.Link(record, lock)
+		if errors.Is(err, fs.ErrNotExist) {
+			var f *os.File
+			if f, err = os.OpenFile(lock, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644); err == nil {
+				f.Close()
+			}
+		}
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+		if removeStaleScoreLock(dataDir) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+
+	unlockRecord, err := lockRecord(ctx, record)
+	if err != nil {
+		os.Remove(lock)
+		return nil, err
+	}
+	return func() {
+		unlockRecord()
+		os.Remove(lock)
+	}, nil
+}
+
+// removeStaleScoreLock removes a data directory's score lock when a crashed
+// game left it, reporting whether it did
+func removeStaleScoreLock(dataDir string) bool {
+	lock := scoreLockPath(dataDir)
+	taken, err := lockTime(lock)
+	if err != nil || time.Since(taken) < staleScoreLockAge {
+		return false
+	}
+	return os.Remove(lock) == nil
+}
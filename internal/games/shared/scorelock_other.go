@@ -0,0 +1,28 @@
+//go:build !unix
+
+package shared
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// lockRecord does nothing where games cannot lock with fcntl
+func lockRecord(ctx context.Context, path string) (func(), error) {
+	return func() {}, nil
+}
+
+// keepOwner does nothing where files have no numeric owner
+func keepOwner(f *os.File, info os.FileInfo) error {
+	return nil
+}
+
+// lockTime is when a score lock was taken
+func lockTime(path string) (time.Time, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
@@ -0,0 +1,67 @@
+//go:build unix
+
+package shared
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// lockRecord takes an fcntl write lock on a record file, as games built to
+// lock with fcntl do, retrying until ctx is done. A missing record file
+// needs no lock.
+func lockRecord(ctx context.Context, path string) (func(), error) {
+	f, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return func() {}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	lock := unix.Flock_t{Type: unix.F_WRLCK, Whence: 0}
+	for {
+		err := unix.FcntlFlock(f.Fd(), unix.F_SETLK, &lock)
+		if err == nil {
+			// Closing the file releases the lock
+			return func() { f.Close() }, nil
+		}
+		if !errors.Is(err, unix.EAGAIN) && !errors.Is(err, unix.EACCES) && !errors.Is(err, unix.EINTR) {
+			f.Close()
+			return nil, &os.PathError{Op: "fcntl", Path: path, Err: err}
+		}
+		select {
+		case <-ctx.Done():
+			f.Close()
+			return nil, ctx.Err()
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// keepOwner gives a replacement file the owner of the file it replaces
+func keepOwner(f *os.File, info os.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	if err := f.Chown(int(stat.Uid), int(stat.Gid)); err != nil && !errors.Is(err, unix.EPERM) {
+		return err
+	}
+	return nil
+}
+
+// lockTime is when a score lock was taken: its change time, which linking
+// it sets, as a lock linked to the record shares the record's modification
+// time
+func lockTime(path string) (time.Time, error) {
+	var stat unix.Stat_t
+	if err := unix.Lstat(path, &stat); err != nil {
+		return time.Time{}, &os.PathError{Op: "lstat", Path: path, Err: err}
+	}
+	return time.Unix(stat.Ctim.Unix()), nil
+}
@@ -0,0 +1,358 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScoreFormat is the layout of a score file
+type ScoreFormat string
+
+const (
+	// ScoreFormatXlog is an xlogfile: a line of name=value fields per game,
+	// in the order games ended
+	ScoreFormatXlog ScoreFormat = "xlog"
+	// ScoreFormatRecord is NetHack's high score list, best score first
+	ScoreFormatRecord ScoreFormat = "record"
+	// ScoreFormatLogfile has the record's layout, in the order games ended
+	ScoreFormatLogfile ScoreFormat = "logfile"
+)
+
+// Score file problems found by ReadScoreFile
+const (
+	ScoreMalformed   = "malformed"   // A line is not an entry
+	ScoreInterleaved = "interleaved" // Entries, or parts of them, run together on one line
+	ScoreTruncated   = "truncated"   // The last entry was cut short
+	ScoreDuplicate   = "duplicate"   // An entry appears more than once
+)
+
+var (
+	// xlogStart finds where xlogfile entries, which start with their
+	// version, begin within a line
+	xlogStart = regexp.MustCompile(`version=\d`)
+	// recordStart finds where record entries begin within a line: the
+	// version, whose major number is a single digit so an entry appended to
+	// a cut short number is told apart, the points and game details, the
+	// dates, the uid and the character
+	recordStart = regexp.MustCompile(`\d \d+ \d+ -?\d+ -?\d+ -?\d+ -?\d+ -?\d+ -?\d+ -?\d+ \d+ \d+ -?\d+ \S+ \S+ \S+ \S+ [^ ,]+,`)
+)
+
+// ParseScoreFormat parses a format name, or tells the format from the file
+// name when it is empty or "auto"
+func ParseScoreFormat(name, path string) (ScoreFormat, error) {
+	switch ScoreFormat(name) {
+	case ScoreFormatXlog, ScoreFormatRecord, ScoreFormatLogfile:
+		return ScoreFormat(name), nil
+	case "", "auto":
+	default:
+		return "", fmt.Errorf("unknown score file format %q: must be xlog, record or logfile", name)
+	}
+
+	base := filepath.Base(path)
+	switch {
+	case strings.HasPrefix(base, "xlogfile"), strings.HasSuffix(base, ".xlog"):
+		return ScoreFormatXlog, nil
+	case strings.HasPrefix(base, "record"):
+		return ScoreFormatRecord, nil
+	case strings.HasPrefix(base, "logfile"):
+		return ScoreFormatLogfile, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %s; set it", path)
+}
+
+// ScoreEntry is one game in a score file
+type ScoreEntry struct {
+	Line   string
+	Points int64
+	// Ended orders entries by when their game ended: the xlogfile's endtime
+	// or the record's death date
+	Ended int64
+}
+
+// ScoreIssue is a problem found in a score file
+type ScoreIssue struct {
+	Line    int    `json:"line"`
+	Problem string `json:"problem"`
+	Text    string `json:"text"`
+}
+
+// ScoreFile is the entries read from a score file and the problems found
+type ScoreFile struct {
+	Format  ScoreFormat
+	Entries []ScoreEntry
+	Issues  []ScoreIssue
+}
+
+// ReadScoreFile reads a score file, keeping the entries that parse. Lines
+// where a write was cut short and the next game's entry appended to it are
+// split, keeping the whole entries. Take LockScores first if games may be
+// writing the file.
+func ReadScoreFile(path string, format ScoreFormat) (*ScoreFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseScores(data, format), nil
+}
+
+// parseScores parses the contents of a score file
+func parseScores(data []byte, format ScoreFormat) *ScoreFile {
+	file := &ScoreFile{Format: format}
+	seen := make(map[string]bool)
+	start := xlogStart
+	if format != ScoreFormatXlog {
+		start = recordStart
+	}
+
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		// Only the last line can lack its newline
+		unterminated := i == len(lines)-1
+
+		segments := splitEntries(line, start)
+		if len(segments) > 1 {
+			file.Issues = append(file.Issues, ScoreIssue{Line: i + 1, Problem: ScoreInterleaved, Text: line})
+		}
+		for _, segment := range segments {
+			entry, ok := parseScoreEntry(segment, format)
+			switch {
+			case !ok && len(segments) > 1:
+				// The cut short part of an interleaved line
+			case !ok && unterminated:
+				file.Issues = append(file.Issues, ScoreIssue{Line: i + 1, Problem: ScoreTruncated, Text: segment})
+			case !ok:
+				file.Issues = append(file.Issues, ScoreIssue{Line: i + 1, Problem: ScoreMalformed, Text: segment})
+			case seen[entry.Line]:
+				file.Issues = append(file.Issues, ScoreIssue{Line: i + 1, Problem: ScoreDuplicate, Text: segment})
+			default:
+				seen[entry.Line] = true
+				file.Entries = append(file.Entries, entry)
+			}
+		}
+	}
+	return file
+}
+
+// splitEntries splits a line where entries start within it. A start after
+// a field separator is a field of the entry, not another entry.
+func splitEntries(line string, start *regexp.Regexp) []string {
+	var matches [][]int
+	for _, match := range start.FindAllStringIndex(line, -1) {
+		if match[0] == 0 || (line[match[0]-1] != '\t' && line[match[0]-1] != ':') {
+			matches = append(matches, match)
+		}
+	}
+	if len(matches) == 0 || (len(matches) == 1 && matches[0][0] == 0) {
+		return []string{line}
+	}
+	var segments []string
+	if matches[0][0] > 0 {
+		segments = append(segments, line[:matches[0][0]])
+	}
+	for i, match := range matches {
+		end := len(line)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		segments = append(segments, line[match[0]:end])
+	}
+	return segments
+}
+
+// parseScoreEntry parses one entry of a score file
+func parseScoreEntry(line string, format ScoreFormat) (ScoreEntry, bool) {
+	if format == ScoreFormatXlog {
+		return parseXlogEntry(line)
+	}
+	return parseRecordEntry(line)
+}
+
+// parseXlogEntry parses an xlogfile entry, which needs at least the player,
+// how the game ended and its points
+func parseXlogEntry(line string) (ScoreEntry, bool) {
+	separator := ":"
+	if strings.Contains(line, "\t") {
+		separator = "\t"
+	}
+	fields := make(map[string]string)
+	for _, field := range strings.Split(line, separator) {
+		name, value, ok := strings.Cut(field, "=")
+		if !ok || name == "" {
+			return ScoreEntry{}, false
+		}
+		if _, repeated := fields[name]; repeated {
+			return ScoreEntry{}, false
+		}
+		fields[name] = value
+	}
+	points, err := strconv.ParseInt(fields["points"], 10, 64)
+	if err != nil || fields["name"] == "" || fields["death"] == "" {
+		return ScoreEntry{}, false
+	}
+	entry := ScoreEntry{Line: line, Points: points}
+	if ended, ok := fields["endtime"]; ok {
+		if entry.Ended, err = strconv.ParseInt(ended, 10, 64); err != nil {
+			return ScoreEntry{}, false
+		}
+	}
+	return entry, true
+}
+
+// parseRecordEntry parses a record or logfile entry: the version, points,
+// dungeon, levels, hit points, deaths, death and birth dates and uid, the
+// character, then the player and how the game ended
+func parseRecordEntry(line string) (ScoreEntry, bool) {
+	fields := strings.SplitN(line, " ", 18)
+	if len(fields) != 18 {
+		return ScoreEntry{}, false
+	}
+	numbers := make([]int64, 13)
+	for i := range numbers {
+		n, err := strconv.ParseInt(fields[i], 10, 64)
+		if err != nil {
+			return ScoreEntry{}, false
+		}
+		numbers[i] = n
+	}
+	for _, field := range fields[13:17] {
+		if field == "" {
+			return ScoreEntry{}, false
+		}
+	}
+	name, death, ok := strings.Cut(fields[17], ",")
+	if !ok || name == "" || death == "" {
+		return ScoreEntry{}, false
+	}
+	return ScoreEntry{Line: line, Points: numbers[3], Ended: numbers[10]}, true
+}
+
+// sortScores orders entries as their format keeps them: the record by
+// points, best first, and logs by when games ended. Entries that tie keep
+// their order.
+func sortScores(entries []ScoreEntry, format ScoreFormat) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if format == ScoreFormatRecord {
+			return entries[i].Points > entries[j].Points
+		}
+		return entries[i].Ended < entries[j].Ended
+	})
+}
+
+// RepairScoreFile rewrites a score file with its whole entries, dropping
+// malformed, truncated and duplicate lines and splitting interleaved ones,
+// under LockScores of the file's directory. The original is kept beside it
+// as <name>.corrupt-<time>, whose path is returned. A file without issues
+// is left alone.
+func RepairScoreFile(ctx context.Context, path string, format ScoreFormat) ([]ScoreIssue, string, error) {
+	unlock, err := LockScores(ctx, filepath.Dir(path))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to lock scores: %w", err)
+	}
+	defer unlock()
+
+	file, err := ReadScoreFile(path, format)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(file.Issues) == 0 {
+		return nil, "", nil
+	}
+
+	backup := fmt.Sprintf("%s.corrupt-%s", path, time.Now().UTC().Format("20060102T150405Z"))
+	if err := os.Link(path, backup); err != nil {
+		return nil, "", fmt.Errorf("failed to keep the original: %w", err)
+	}
+	if err := writeScores(path, file.Entries); err != nil {
+		return nil, "", err
+	}
+	return file.Issues, backup, nil
+}
+
+// MergeScoreFiles merges the entries of score files, such as those kept by
+// replicas with their own data directories, into output, which may be one
+// of them. Entries are deduplicated and ordered as the format keeps them;
+// what cannot be read is left out and returned. Output is written under
+// LockScores of its directory.
+func MergeScoreFiles(ctx context.Context, output string, format ScoreFormat, inputs []string) (int, []ScoreIssue, error) {
+	unlock, err := LockScores(ctx, filepath.Dir(output))
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to lock scores: %w", err)
+	}
+	defer unlock()
+
+	var entries []ScoreEntry
+	var issues []ScoreIssue
+	seen := make(map[string]bool)
+	for _, input := range inputs {
+		file, err := ReadScoreFile(input, format)
+		if err != nil {
+			return 0, nil, err
+		}
+		for _, entry := range file.Entries {
+			if !seen[entry.Line] {
+				seen[entry.Line] = true
+				entries = append(entries, entry)
+			}
+		}
+		for _, issue := range file.Issues {
+			if issue.Problem != ScoreDuplicate {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	sortScores(entries, format)
+	if err := writeScores(output, entries); err != nil {
+		return 0, nil, err
+	}
+	return len(entries), issues, nil
+}
+
+// writeScores replaces a score file with entries, keeping its mode and owner
+func writeScores(path string, entries []ScoreEntry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		buf.WriteString(entry.Line)
+		buf.WriteByte('\n')
+	}
+
+	mode := defaultScoreFileMode
+	info, err := os.Stat(path)
+	if err == nil {
+		mode = info.Mode().Perm()
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if info != nil {
+		if err := keepOwner(tmp, info); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,177 @@
+//go:build unix
+
+package shared
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	xlogA = "version=3.6.7\tpoints=120\tname=user_1\tdeath=killed by a jackal\tendtime=1700000100"
+	xlogB = "version=3.6.7\tpoints=5000\tname=user_2\tdeath=ascended\tendtime=1700000200"
+	xlogC = "version=3.6.7\tpoints=40\tname=user_3\tdeath=quit\tendtime=1700000300"
+
+	recordA = "3 6 7 120 0 1 1 0 12 1 20231114 20231114 1000 Val Hum Fem Law user_1,killed by a jackal"
+	recordB = "3 6 7 5000 0 50 50 80 90 1 20231115 20231101 1000 Wiz Elf Mal Cha user_2,ascended"
+)
+
+func TestParseScoreFormat(t *testing.T) {
+	for path, want := range map[string]ScoreFormat{
+		"/var/games/nethack/xlogfile": ScoreFormatXlog,
+		"/var/games/nethack/record":   ScoreFormatRecord,
+		"logfile.bak":                 ScoreFormatLogfile,
+	} {
+		format, err := ParseScoreFormat("auto", path)
+		require.NoError(t, err)
+		assert.Equal(t, want, format, path)
+	}
+	_, err := ParseScoreFormat("", "scores.txt")
+	assert.Error(t, err)
+	_, err = ParseScoreFormat("csv", "record")
+	assert.Error(t, err)
+}
+
+func TestParseScores_Xlog(t *testing.T) {
+	// A write cut short with the next game's entry appended to it, a
+	// duplicate, garbage and a last entry without its newline
+	data := xlogA + "\n" +
+		"version=3.6.7\tpoints=9" + xlogB + "\n" +
+		xlogA + "\n" +
+		"not an entry\n" +
+		"version=3.6.7\tpoints=1"
+	file := parseScores([]byte(data), ScoreFormatXlog)
+
+	lines := make([]string, len(file.Entries))
+	for i, entry := range file.Entries {
+		lines[i] = entry.Line
+	}
+	assert.Equal(t, []string{xlogA, xlogB}, lines)
+
+	problems := make([]string, len(file.Issues))
+	for i, issue := range file.Issues {
+		problems[i] = issue.Problem
+	}
+	assert.Equal(t, []string{ScoreInterleaved, ScoreDuplicate, ScoreMalformed, ScoreTruncated}, problems)
+	assert.Equal(t, 2, file.Issues[0].Line)
+}
+
+func TestParseScores_XlogFieldOrder(t *testing.T) {
+	// Version as a later field is not another entry
+	line := "name=user_1\tversion=3.6.7\tpoints=10\tdeath=quit"
+	file := parseScores([]byte(line+"\n"), ScoreFormatXlog)
+	assert.Empty(t, file.Issues)
+	require.Len(t, file.Entries, 1)
+	assert.Equal(t, line, file.Entries[0].Line)
+}
+
+func TestParseScores_Record(t *testing.T) {
+	data := recordB + "\n" + "3 6 7 80 0 1 1 0 12 1 2023" + recordA + "\n" + "3 6 7 x\n"
+	file := parseScores([]byte(data), ScoreFormatRecord)
+	require.Len(t, file.Entries, 2)
+	assert.Equal(t, recordB, file.Entries[0].Line)
+	assert.Equal(t, int64(5000), file.Entries[0].Points)
+	assert.Equal(t, recordA, file.Entries[1].Line)
+	assert.Equal(t, int64(20231114), file.Entries[1].Ended)
+	require.Len(t, file.Issues, 2)
+	assert.Equal(t, ScoreInterleaved, file.Issues[0].Problem)
+	assert.Equal(t, ScoreMalformed, file.Issues[1].Problem)
+}
+
+func TestRepairScoreFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "xlogfile")
+	corrupt := xlogA + "\nversion=3.6.7\tpoi" + xlogB + "\n"
+	require.NoError(t, os.WriteFile(path, []byte(corrupt), 0660))
+	require.NoError(t, os.Chmod(path, 0660))
+
+	issues, backup, err := RepairScoreFile(context.Background(), path, ScoreFormatXlog)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, ScoreInterleaved, issues[0].Problem)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, xlogA+"\n"+xlogB+"\n", string(data))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+	original, err := os.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, corrupt, string(original))
+	_, err = os.Stat(scoreLockPath(dir))
+	assert.True(t, os.IsNotExist(err), "the score lock is released")
+
+	// A sound file is left alone
+	issues, backup, err = RepairScoreFile(context.Background(), path, ScoreFormatXlog)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+	assert.Empty(t, backup)
+}
+
+func TestMergeScoreFiles(t *testing.T) {
+	dir := t.TempDir()
+	first := filepath.Join(dir, "record")
+	second := filepath.Join(t.TempDir(), "record")
+	require.NoError(t, os.WriteFile(first, []byte(recordA+"\n"), 0664))
+	require.NoError(t, os.WriteFile(second, []byte(recordB+"\n"+recordA+"\ngarbage\n"), 0664))
+
+	count, issues, err := MergeScoreFiles(context.Background(), first, ScoreFormatRecord, []string{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, issues, 1)
+	assert.Equal(t, ScoreMalformed, issues[0].Problem)
+
+	data, err := os.ReadFile(first)
+	require.NoError(t, err)
+	assert.Equal(t, recordB+"\n"+recordA+"\n", string(data), "the record is ordered best first")
+
+	// Logs are ordered by when games ended
+	xlog := filepath.Join(dir, "xlogfile")
+	require.NoError(t, os.WriteFile(xlog, []byte(xlogC+"\n"+xlogA+"\n"), 0664))
+	other := filepath.Join(t.TempDir(), "xlogfile")
+	require.NoError(t, os.WriteFile(other, []byte(xlogB+"\n"), 0664))
+	_, _, err = MergeScoreFiles(context.Background(), xlog, ScoreFormatXlog, []string{xlog, other})
+	require.NoError(t, err)
+	data, err = os.ReadFile(xlog)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Join([]string{xlogA, xlogB, xlogC}, "\n")+"\n", string(data))
+}
+
+func TestLockScores(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, RecordFile), nil, 0664))
+
+	unlock, err := LockScores(context.Background(), dir)
+	require.NoError(t, err)
+	// The lock is a link to the record, as NetHack makes it
+	lockInfo, err := os.Stat(scoreLockPath(dir))
+	require.NoError(t, err)
+	recordInfo, err := os.Stat(filepath.Join(dir, RecordFile))
+	require.NoError(t, err)
+	assert.True(t, os.SameFile(lockInfo, recordInfo))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockPollInterval)
+	defer cancel()
+	_, err = LockScores(ctx, dir)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	unlock()
+
+	// A lock just taken on a record last written long ago is not stale
+	old := time.Now().Add(-2 * staleScoreLockAge)
+	require.NoError(t, os.Chtimes(filepath.Join(dir, RecordFile), old, old))
+	require.NoError(t, os.Link(filepath.Join(dir, RecordFile), scoreLockPath(dir)))
+	assert.False(t, removeStaleScoreLock(dir))
+	require.NoError(t, os.Remove(scoreLockPath(dir)))
+
+	unlock, err = LockScores(context.Background(), dir)
+	require.NoError(t, err)
+	unlock()
+}
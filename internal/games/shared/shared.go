@@ -0,0 +1,353 @@
+// Package shared initializes the data a game shares between players and
+// replicas: its data directory with the data and score files in it, and the
+// upgrades run when the game's binary or version changes. Replicas sharing
+// the data directory take turns through a lock file in it, so each upgrade
+// runs once.
+package shared
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+const (
+	// StateFile records, in the data directory, the binary the shared data
+	// was last initialized or upgraded for
+	StateFile = ".dungeongate-shared.json"
+	// BackupDir holds the score files saved before each upgrade
+	BackupDir = "upgrade-backups"
+
+	lockFile = ".dungeongate-shared.lock"
+
+	defaultDataDirMode   os.FileMode = 0755
+	defaultScoreFileMode os.FileMode = 0664
+
+	// lockWait is how long to wait for another replica's initialization on
+	// top of its upgrade steps' timeouts
+	lockWait = time.Minute
+	// lockPollInterval is how often a held lock is retried
+	lockPollInterval = 100 * time.Millisecond
+)
+
+// State is the binary a game's shared data was initialized or upgraded for
+type State struct {
+	Version       string     `json:"version"`
+	BinarySHA256  string     `json:"binary_sha256"`
+	InitializedAt time.Time  `json:"initialized_at"`
+	UpgradedAt    *time.Time `json:"upgraded_at,omitempty"`
+	// Steps are the upgrade steps last run
+	Steps []string `json:"steps,omitempty"`
+}
+
+// changed reports whether the shared data was initialized for another binary
+func (s *State) changed(version, sum string) bool {
+	return s.Version != version || s.BinarySHA256 != sum
+}
+
+// Result reports what Initialize did for a game
+type Result struct {
+	DataDir string
+	// Created lists the directories and score files created
+	Created []string
+	// Missing lists the shared files not in the data directory
+	Missing []string
+	// Previous is the state found, nil when the data was never initialized
+	Previous *State
+	Current  *State
+	Upgraded bool
+}
+
+// Manager initializes games' shared data
+type Manager struct {
+	logger *slog.Logger
+}
+
+// NewManager creates a shared data manager
+func NewManager(logger *slog.Logger) *Manager {
+	return &Manager{logger: logger.With("component", "shared-data")}
+}
+
+// DataDirectory returns the shared data directory of a game, empty when it
+// has none to initialize
+func DataDirectory(game *config.GameConfig) string {
+	if game.Files == nil {
+		return ""
+	}
+	return game.Files.DataDirectory
+}
+
+// Initialize prepares a game's shared data directory under the lock shared
+// by replicas: the directory and score files are created with the binary's
+// owner and the configured modes, and missing shared files are reported.
+// When the binary or version changed since the last initialization, the
+// score files are backed up and the upgrade steps run in order; a failed
+// step restores the backup and leaves the upgrade to be retried.
+func (m *Manager) Initialize(ctx context.Context, game *config.GameConfig) (*Result, error) {
+	dataDir := DataDirectory(game)
+	if dataDir == "" {
+		return nil, fmt.Errorf("game %s has no data directory", game.ID)
+	}
+	var steps []*config.GameUpgradeStep
+	if game.Setup != nil {
+		steps = game.Setup.Upgrade
+	}
+
+	var permissions config.PermissionsConfig
+	if game.Files.Permissions != nil {
+		permissions = *game.Files.Permissions
+	}
+	dirMode, err := parseMode(permissions.DataDirectory, defaultDataDirMode)
+	if err != nil {
+		return nil, err
+	}
+	scoreMode, err := parseMode(permissions.ScoreFiles, defaultScoreFileMode)
+	if err != nil {
+		return nil, err
+	}
+	var binary config.BinaryConfig
+	if game.Binary != nil {
+		binary = *game.Binary
+	}
+	uid, gid, err := lookupOwner(binary.User, binary.Group)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{DataDir: dataDir}
+	if _, err := os.Stat(dataDir); os.IsNotExist(err) {
+		result.Created = append(result.Created, dataDir)
+	}
+	if err := os.MkdirAll(dataDir, dirMode); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	wait := lockWait
+	for _, step := range steps {
+		wait += step.GetTimeout()
+	}
+	lockCtx, cancel := context.WithTimeout(ctx, wait)
+	defer cancel()
+	unlock, err := lockDir(lockCtx, filepath.Join(dataDir, lockFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock shared data: %w", err)
+	}
+	defer unlock()
+
+	if err := setOwnerAndMode(dataDir, uid, gid, dirMode); err != nil {
+		return nil, err
+	}
+
+	sum, err := binarySum(binary.Path)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := readState(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	result.Previous = previous
+
+	now := time.Now().UTC()
+	current := &State{Version: game.Version, BinarySHA256: sum, InitializedAt: now}
+	switch {
+	case previous == nil:
+	case previous.changed(game.Version, sum):
+		current.InitializedAt = previous.InitializedAt
+		if err := m.upgrade(ctx, game, steps, previous, current); err != nil {
+			return nil, err
+		}
+		current.UpgradedAt = &now
+		for _, step := range steps {
+			current.Steps = append(current.Steps, step.Name)
+		}
+		result.Upgraded = true
+	default:
+		current = previous
+	}
+
+	created, err := ensureScoreFiles(dataDir, game.Files.ScoreFiles, uid, gid, scoreMode)
+	result.Created = append(result.Created, created...)
+	if err != nil {
+		return nil, err
+	}
+	// Games wait on a score lock left by one that crashed rather than break it
+	if removeStaleScoreLock(dataDir) {
+		m.logger.Warn("Removed stale score lock", "game_id", game.ID, "path", scoreLockPath(dataDir))
+	}
+
+	if current != previous {
+		if err := writeState(dataDir, current); err != nil {
+			return nil, err
+		}
+	}
+	result.Current = current
+
+	// The game may find its data elsewhere, such as through HACKDIR
+	for _, name := range game.Files.SharedFiles {
+		if _, err := os.Stat(filepath.Join(dataDir, name)); err != nil {
+			result.Missing = append(result.Missing, name)
+		}
+	}
+	if len(result.Missing) > 0 {
+		m.logger.Warn("Shared game files missing from data directory",
+			"game_id", game.ID,
+			"data_dir", dataDir,
+			"files", result.Missing)
+	}
+
+	m.logger.Info("Shared game data initialized",
+		"game_id", game.ID,
+		"data_dir", dataDir,
+		"version", current.Version,
+		"upgraded", result.Upgraded,
+		"created", len(result.Created))
+	return result, nil
+}
+
+// parseMode parses an octal file mode, returning fallback for an empty one
+func parseMode(value string, fallback os.FileMode) (os.FileMode, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	mode, err := strconv.ParseUint(value, 8, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid file mode %q: %w", value, err)
+	}
+	return os.FileMode(mode), nil
+}
+
+// lookupOwner resolves a user and group name to their IDs; unset names are -1
+// so ownership is left as it is
+func lookupOwner(userName, groupName string) (int, int, error) {
+	uid, gid := -1, -1
+	if userName != "" {
+		u, err := user.Lookup(userName)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to look up user %s: %w", userName, err)
+		}
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return 0, 0, fmt.Errorf("user %s has no numeric ID", userName)
+		}
+	}
+	if groupName != "" {
+		g, err := user.LookupGroup(groupName)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to look up group %s: %w", groupName, err)
+		}
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return 0, 0, fmt.Errorf("group %s has no numeric ID", groupName)
+		}
+	}
+	return uid, gid, nil
+}
+
+// setOwnerAndMode gives path its owner and mode
+func setOwnerAndMode(path string, uid, gid int, mode os.FileMode) error {
+	if uid >= 0 || gid >= 0 {
+		if err := os.Chown(path, uid, gid); err != nil {
+			return fmt.Errorf("failed to set owner of %s: %w", path, err)
+		}
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		return fmt.Errorf("failed to set mode of %s: %w", path, err)
+	}
+	return nil
+}
+
+// binarySum returns the SHA-256 of a game binary, empty when it has none
+func binarySum(path string) (string, error) {
+	if path == "" {
+		return "", nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read game binary: %w", err)
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", fmt.Errorf("failed to read game binary: %w", err)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// readState returns the state recorded in a data directory, nil when it was
+// never initialized
+func readState(dataDir string) (*State, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, StateFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read shared data state: %w", err)
+	}
+	var state State
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("invalid shared data state %s: %w", filepath.Join(dataDir, StateFile), err)
+	}
+	return &state, nil
+}
+
+// writeState replaces the state of a data directory atomically
+func writeState(dataDir string, state *State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(filepath.Join(dataDir, StateFile), data, 0644)
+}
+
+// writeFileAtomic writes a file beside path and renames it into place
+func writeFileAtomic(path string, data []byte, mode os.FileMode) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Chmod(mode); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// ensureScoreFiles creates the missing score files empty and gives them all
+// their owner and mode, returning those created
+func ensureScoreFiles(dataDir string, names []string, uid, gid int, mode os.FileMode) ([]string, error) {
+	var created []string
+	for _, name := range names {
+		path := filepath.Join(dataDir, name)
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, mode)
+		switch {
+		case err == nil:
+			f.Close()
+			created = append(created, path)
+		case !errors.Is(err, fs.ErrExist):
+			return created, fmt.Errorf("failed to create score file: %w", err)
+		}
+		if err := setOwnerAndMode(path, uid, gid, mode); err != nil {
+			return created, err
+		}
+	}
+	return created, nil
+}
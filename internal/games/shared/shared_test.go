@@ -0,0 +1,214 @@
+//go:build unix
+
+package shared
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func newTestGame(t *testing.T) *config.GameConfig {
+	t.Helper()
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "game")
+	require.NoError(t, os.WriteFile(binary, []byte("v1"), 0755))
+	dataDir := filepath.Join(dir, "data")
+	require.NoError(t, os.Mkdir(dataDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "nhdat"), []byte("data"), 0644))
+
+	return &config.GameConfig{
+		ID:      "nethack",
+		Version: "3.6.7",
+		Binary:  &config.BinaryConfig{Path: binary},
+		Files: &config.FilesConfig{
+			DataDirectory: dataDir,
+			SharedFiles:   []string{"nhdat"},
+			ScoreFiles:    []string{"record", "xlogfile"},
+			Permissions:   &config.PermissionsConfig{DataDirectory: "0750", ScoreFiles: "0660"},
+		},
+		Setup: &config.GameSetupOptions{InitializeShared: true},
+	}
+}
+
+func newTestManager() *Manager {
+	return NewManager(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// upgradeScript writes a step appending its versions to the record file,
+// failing when fail is set
+func upgradeScript(t *testing.T, fail bool) string {
+	t.Helper()
+	script := "#!/bin/sh\necho \"$DUNGEONGATE_OLD_VERSION->$DUNGEONGATE_NEW_VERSION $MARK\" >> record\n"
+	if fail {
+		script += "echo broken >&2\nexit 3\n"
+	}
+	path := filepath.Join(t.TempDir(), "upgrade.sh")
+	require.NoError(t, os.WriteFile(path, []byte(script), 0755))
+	return path
+}
+
+func TestInitialize(t *testing.T) {
+	game := newTestGame(t)
+	dataDir := game.Files.DataDirectory
+
+	result, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	assert.Nil(t, result.Previous)
+	assert.False(t, result.Upgraded)
+	assert.ElementsMatch(t, []string{filepath.Join(dataDir, "record"), filepath.Join(dataDir, "xlogfile")}, result.Created)
+
+	info, err := os.Stat(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0750), info.Mode().Perm())
+	info, err = os.Stat(filepath.Join(dataDir, "record"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0660), info.Mode().Perm())
+
+	state, err := readState(dataDir)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, "3.6.7", state.Version)
+	assert.NotEmpty(t, state.BinarySHA256)
+
+	// Nothing changed: the state is kept and no upgrade runs
+	result, err = newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	assert.False(t, result.Upgraded)
+	assert.Empty(t, result.Created)
+	assert.Equal(t, state.InitializedAt, result.Current.InitializedAt)
+}
+
+func TestInitialize_MissingSharedFile(t *testing.T) {
+	game := newTestGame(t)
+	game.Files.SharedFiles = append(game.Files.SharedFiles, "license")
+
+	result, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"license"}, result.Missing)
+}
+
+func TestInitialize_Upgrade(t *testing.T) {
+	game := newTestGame(t)
+	dataDir := game.Files.DataDirectory
+	_, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "record"), []byte("score\n"), 0660))
+
+	// A new binary of the same version is an upgrade too
+	require.NoError(t, os.WriteFile(game.Binary.Path, []byte("v2"), 0755))
+	game.Version = "3.7.0"
+	game.Setup.Upgrade = []*config.GameUpgradeStep{{
+		Name:        "record",
+		Command:     upgradeScript(t, false),
+		Environment: map[string]string{"MARK": "ok"},
+	}}
+
+	result, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	assert.True(t, result.Upgraded)
+	assert.Equal(t, "3.6.7", result.Previous.Version)
+	assert.Equal(t, []string{"record"}, result.Current.Steps)
+	assert.NotNil(t, result.Current.UpgradedAt)
+
+	data, err := os.ReadFile(filepath.Join(dataDir, "record"))
+	require.NoError(t, err)
+	assert.Equal(t, "score\n3.6.7->3.7.0 ok\n", string(data))
+
+	backups, err := filepath.Glob(filepath.Join(dataDir, BackupDir, "3.6.7-*", "record"))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	data, err = os.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "score\n", string(data))
+}
+
+func TestInitialize_FailedUpgrade(t *testing.T) {
+	game := newTestGame(t)
+	dataDir := game.Files.DataDirectory
+	_, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dataDir, "record"), []byte("score\n"), 0660))
+
+	game.Version = "3.7.0"
+	game.Setup.Upgrade = []*config.GameUpgradeStep{{Name: "record", Command: upgradeScript(t, true)}}
+	_, err = newTestManager().Initialize(context.Background(), game)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "upgrade step record failed")
+	assert.Contains(t, err.Error(), "broken")
+
+	// The score files are restored and the upgrade is left to be retried
+	data, err := os.ReadFile(filepath.Join(dataDir, "record"))
+	require.NoError(t, err)
+	assert.Equal(t, "score\n", string(data))
+	state, err := readState(dataDir)
+	require.NoError(t, err)
+	assert.Equal(t, "3.6.7", state.Version)
+
+	game.Setup.Upgrade[0].Command = upgradeScript(t, false)
+	result, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+	assert.True(t, result.Upgraded)
+}
+
+func TestInitialize_UpgradesOnce(t *testing.T) {
+	game := newTestGame(t)
+	dataDir := game.Files.DataDirectory
+	_, err := newTestManager().Initialize(context.Background(), game)
+	require.NoError(t, err)
+
+	game.Version = "3.7.0"
+	game.Setup.Upgrade = []*config.GameUpgradeStep{{Name: "record", Command: upgradeScript(t, false)}}
+
+	// Replicas starting together: one upgrades, the others find it done
+	var wg sync.WaitGroup
+	upgraded := make(chan bool, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := newTestManager().Initialize(context.Background(), game)
+			if assert.NoError(t, err) {
+				upgraded <- result.Upgraded
+			}
+		}()
+	}
+	wg.Wait()
+	close(upgraded)
+
+	count := 0
+	for u := range upgraded {
+		if u {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+	data, err := os.ReadFile(filepath.Join(dataDir, "record"))
+	require.NoError(t, err)
+	assert.Equal(t, "3.6.7->3.7.0 \n", string(data))
+}
+
+func TestLockDir_Timeout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), lockFile)
+	unlock, err := lockDir(context.Background(), path)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*lockPollInterval)
+	defer cancel()
+	_, err = lockDir(ctx, path)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	unlock()
+	unlock, err = lockDir(context.Background(), path)
+	require.NoError(t, err)
+	unlock()
+}
@@ -0,0 +1,150 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// upgradePath is the PATH upgrade steps run with; nothing else of the game
+// service's environment reaches them
+const upgradePath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+// upgradeOutputLimit is how much of a failed step's output is reported
+const upgradeOutputLimit = 4096
+
+// upgrade backs up a game's score files and runs its upgrade steps from the
+// previous state to the current one, restoring the backup when a step fails
+func (m *Manager) upgrade(ctx context.Context, game *config.GameConfig, steps []*config.GameUpgradeStep, previous, current *State) error {
+	dataDir := DataDirectory(game)
+	backup, err := backupScoreFiles(dataDir, game.Files.ScoreFiles, previous.Version)
+	if err != nil {
+		return fmt.Errorf("failed to back up score files: %w", err)
+	}
+	m.logger.Info("Upgrading shared game data",
+		"game_id", game.ID,
+		"from_version", previous.Version,
+		"to_version", current.Version,
+		"steps", len(steps),
+		"backup", backup)
+
+	for _, step := range steps {
+		start := time.Now()
+		if err := runStep(ctx, game.ID, dataDir, step, previous, current); err != nil {
+			if restoreErr := restoreScoreFiles(dataDir, backup, game.Files.ScoreFiles); restoreErr != nil {
+				err = errors.Join(err, fmt.Errorf("failed to restore score files from %s: %w", backup, restoreErr))
+			}
+			return fmt.Errorf("upgrade step %s failed: %w", step.Name, err)
+		}
+		m.logger.Info("Upgrade step completed",
+			"game_id", game.ID,
+			"step", step.Name,
+			"duration", time.Since(start))
+	}
+	return nil
+}
+
+// runStep runs one upgrade step in the data directory
+func runStep(ctx context.Context, gameID, dataDir string, step *config.GameUpgradeStep, previous, current *State) error {
+	ctx, cancel := context.WithTimeout(ctx, step.GetTimeout())
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, step.Command, step.Args...)
+	cmd.Dir = dataDir
+	cmd.Env = []string{
+		"PATH=" + upgradePath,
+		"DUNGEONGATE_GAME_ID=" + gameID,
+		"DUNGEONGATE_DATA_DIR=" + dataDir,
+		"DUNGEONGATE_UPGRADE_STEP=" + step.Name,
+		"DUNGEONGATE_OLD_VERSION=" + previous.Version,
+		"DUNGEONGATE_NEW_VERSION=" + current.Version,
+		"DUNGEONGATE_OLD_BINARY_SHA256=" + previous.BinarySHA256,
+		"DUNGEONGATE_NEW_BINARY_SHA256=" + current.BinarySHA256,
+	}
+	keys := make([]string, 0, len(step.Environment))
+	for key := range step.Environment {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		cmd.Env = append(cmd.Env, key+"="+step.Environment[key])
+	}
+	cmd.WaitDelay = time.Second
+
+	output, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		err = fmt.Errorf("timed out after %s", step.GetTimeout())
+	}
+	if err != nil {
+		if len(output) > upgradeOutputLimit {
+			output = output[:upgradeOutputLimit]
+		}
+		if text := strings.TrimSpace(string(output)); text != "" {
+			return fmt.Errorf("%w: %s", err, text)
+		}
+	}
+	return err
+}
+
+// backupScoreFiles copies the score files that exist into a new directory
+// under BackupDir, returning it
+func backupScoreFiles(dataDir string, names []string, version string) (string, error) {
+	if version == "" {
+		version = "unversioned"
+	}
+	name := fmt.Sprintf("%s-%s", version, time.Now().UTC().Format("20060102T150405Z"))
+	backup := filepath.Join(dataDir, BackupDir, name)
+	if err := os.MkdirAll(backup, 0700); err != nil {
+		return "", err
+	}
+	for _, file := range names {
+		data, err := os.ReadFile(filepath.Join(dataDir, file))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(backup, file), data, 0600); err != nil {
+			return "", err
+		}
+	}
+	return backup, nil
+}
+
+// restoreScoreFiles puts the score files of a backup back in place, removing
+// those that did not exist when it was made
+func restoreScoreFiles(dataDir, backup string, names []string) error {
+	var errs []error
+	for _, file := range names {
+		path := filepath.Join(dataDir, file)
+		info, statErr := os.Stat(path)
+		data, err := os.ReadFile(filepath.Join(backup, file))
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				errs = append(errs, err)
+			}
+			continue
+		case err != nil:
+			errs = append(errs, err)
+			continue
+		}
+		mode := defaultScoreFileMode
+		if statErr == nil {
+			mode = info.Mode().Perm()
+		}
+		if err := writeFileAtomic(path, data, mode); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
 )
@@ -16,11 +18,15 @@ type AuthClient struct {
 	conn   *grpc.ClientConn
 	client authv1.AuthServiceClient
 	logger *slog.Logger
+	// Set when conn belongs to a Pool, which closes it
+	pooled bool
 }
 
-// NewAuthClient creates a new Auth Service client
-func NewAuthClient(address string, logger *slog.Logger) (*AuthClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewAuthClient creates a new Auth Service client. Extra dial options can
+// route the connection elsewhere, e.g. to an in-process server.
+func NewAuthClient(address string, logger *slog.Logger, opts ...grpc.DialOption) (*AuthClient, error) {
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
 	}
@@ -34,9 +40,25 @@ func NewAuthClient(address string, logger *slog.Logger) (*AuthClient, error) {
 	}, nil
 }
 
-// Close closes the client connection
+// NewPooledAuthClient creates an Auth Service client on pool's connection
+// to the auth service at address
+func NewPooledAuthClient(pool *Pool, address string, logger *slog.Logger) (*AuthClient, error) {
+	conn, err := pool.Conn("auth", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthClient{
+		conn:   conn,
+		client: authv1.NewAuthServiceClient(conn),
+		logger: logger,
+		pooled: true,
+	}, nil
+}
+
+// Close closes the client connection, unless it belongs to a Pool
 func (c *AuthClient) Close() error {
-	if c.conn != nil {
+	if c.conn != nil && !c.pooled {
 		return c.conn.Close()
 	}
 	return nil
@@ -56,11 +78,22 @@ func (c *AuthClient) ValidateToken(ctx context.Context, token string) (*authv1.V
 	return resp, nil
 }
 
-// Login authenticates a user with username/password
-func (c *AuthClient) Login(ctx context.Context, username, password string) (*authv1.LoginResponse, error) {
+// Login authenticates a user with username/password. clientIP is the
+// player's address, used for login attempt tracking and audit logs; device,
+// when set, is the device logged in from, which the tokens are tied to.
+func (c *AuthClient) Login(ctx context.Context, username, password, clientIP string, device *authv1.ClientDevice) (*authv1.LoginResponse, error) {
+	return c.LoginWithChallenge(ctx, username, password, clientIP, device, "")
+}
+
+// LoginWithChallenge authenticates a user who passed a brute force
+// challenge, whose token lets the login past a lockout after failed logins
+func (c *AuthClient) LoginWithChallenge(ctx context.Context, username, password, clientIP string, device *authv1.ClientDevice, challengeToken string) (*authv1.LoginResponse, error) {
 	req := &authv1.LoginRequest{
-		Username: username,
-		Password: password,
+		Username:       username,
+		Password:       password,
+		ClientIp:       clientIP,
+		Device:         device,
+		ChallengeToken: challengeToken,
 	}
 
 	resp, err := c.client.Login(ctx, req)
@@ -85,12 +118,14 @@ func (c *AuthClient) GetUserInfo(ctx context.Context, token string) (*authv1.Get
 	return resp, nil
 }
 
-// Register creates a new user account
-func (c *AuthClient) Register(ctx context.Context, username, password, email string) (*authv1.RegisterResponse, error) {
+// Register creates a new user account from the player at clientIP
+func (c *AuthClient) Register(ctx context.Context, username, password, email, clientIP, termsVersion string) (*authv1.RegisterResponse, error) {
 	req := &authv1.RegisterRequest{
-		Username: username,
-		Password: password,
-		Email:    email,
+		Username:     username,
+		Password:     password,
+		Email:        email,
+		ClientIp:     clientIP,
+		TermsVersion: termsVersion,
 	}
 
 	resp, err := c.client.Register(ctx, req)
@@ -111,8 +146,86 @@ func (c *AuthClient) ChangePassword(ctx context.Context, req *authv1.ChangePassw
 	return resp, nil
 }
 
+// ChangeUsername renames the token user's account, confirmed with their password
+func (c *AuthClient) ChangeUsername(ctx context.Context, accessToken, newUsername, password string) (*authv1.ChangeUsernameResponse, error) {
+	req := &authv1.ChangeUsernameRequest{
+		AccessToken: accessToken,
+		NewUsername: newUsername,
+		Password:    password,
+	}
+
+	resp, err := c.client.ChangeUsername(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change username: %w", err)
+	}
+
+	return resp, nil
+}
+
+// MergeAccounts merges the account otherUsername into the token user's
+// account, proving ownership with its password
+func (c *AuthClient) MergeAccounts(ctx context.Context, accessToken, otherUsername, otherPassword string, dryRun bool) (*authv1.MergeAccountsResponse, error) {
+	req := &authv1.MergeAccountsRequest{
+		AccessToken:   accessToken,
+		OtherUsername: otherUsername,
+		OtherPassword: otherPassword,
+		DryRun:        dryRun,
+	}
+
+	resp, err := c.client.MergeAccounts(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge accounts: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SetUserPreference stores one of the token user's preferences; an empty value removes it
+func (c *AuthClient) SetUserPreference(ctx context.Context, accessToken, key, value string) (*authv1.SetUserPreferenceResponse, error) {
+	req := &authv1.SetUserPreferenceRequest{
+		AccessToken: accessToken,
+		Key:         key,
+		Value:       value,
+	}
+
+	resp, err := c.client.SetUserPreference(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set user preference: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SetShowOnlineStatus sets whether the token user appears in the who list
+func (c *AuthClient) SetShowOnlineStatus(ctx context.Context, accessToken string, show bool) (*authv1.SetUserPreferenceResponse, error) {
+	req := &authv1.SetShowOnlineStatusRequest{
+		AccessToken: accessToken,
+		Show:        show,
+	}
+
+	resp, err := c.client.SetShowOnlineStatus(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set online status: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ServiceVersion asks the auth service for its build
+func (c *AuthClient) ServiceVersion(ctx context.Context) (string, error) {
+	resp, err := c.client.Health(ctx, &emptypb.Empty{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get auth service health: %w", err)
+	}
+	return resp.Details["version"], nil
+}
+
 // IsHealthy checks if the auth service is available and healthy
 func (c *AuthClient) IsHealthy(ctx context.Context) bool {
+	if unreachable(c.conn) {
+		return false
+	}
+
 	// Use a simple ping mechanism - try to call an endpoint that should always be available
 	// We'll use ValidateToken with an empty token which should return an error but indicates service is up
 	_, err := c.ValidateToken(ctx, "")
@@ -210,6 +323,476 @@ func (c *AuthClient) GetServerStatistics(ctx context.Context, adminToken string)
 	return resp, nil
 }
 
+// ImpersonateUser gets a short-lived token to act as a user for support (admin only)
+func (c *AuthClient) ImpersonateUser(ctx context.Context, adminToken, targetUsername, reason string, duration time.Duration) (*authv1.ImpersonateUserResponse, error) {
+	req := &authv1.ImpersonateUserRequest{
+		AdminToken:      adminToken,
+		TargetUsername:  targetUsername,
+		Reason:          reason,
+		DurationSeconds: int32(duration / time.Second),
+	}
+
+	resp, err := c.client.ImpersonateUser(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate user: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListAccountAccess lists the times support staff used the token user's account
+func (c *AuthClient) ListAccountAccess(ctx context.Context, accessToken string) (*authv1.ListAccountAccessResponse, error) {
+	req := &authv1.ListAccountAccessRequest{
+		AccessToken: accessToken,
+	}
+
+	resp, err := c.client.ListAccountAccess(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account access: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Terms of Service Functions
+
+// GetTerms returns a published version of the terms of service; an empty version asks for the current one
+func (c *AuthClient) GetTerms(ctx context.Context, version string) (*authv1.TermsResponse, error) {
+	resp, err := c.client.GetTerms(ctx, &authv1.TermsRequest{Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get terms: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AcceptTerms records that the token user accepted a version of the terms
+func (c *AuthClient) AcceptTerms(ctx context.Context, accessToken, version string) (*authv1.TermsStatusResponse, error) {
+	resp, err := c.client.AcceptTerms(ctx, &authv1.AcceptTermsRequest{AccessToken: accessToken, Version: version})
+	if err != nil {
+		return nil, fmt.Errorf("failed to accept terms: %w", err)
+	}
+
+	return resp, nil
+}
+
+// API Key Functions
+
+// CreateAPIKey creates a scoped API key for the token user
+func (c *AuthClient) CreateAPIKey(ctx context.Context, accessToken, name string, scopes []string) (*authv1.CreateAPIKeyResponse, error) {
+	req := &authv1.CreateAPIKeyRequest{
+		AccessToken: accessToken,
+		Name:        name,
+		Scopes:      scopes,
+	}
+
+	resp, err := c.client.CreateAPIKey(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListAPIKeys lists the token user's API keys
+func (c *AuthClient) ListAPIKeys(ctx context.Context, accessToken string) (*authv1.ListAPIKeysResponse, error) {
+	resp, err := c.client.ListAPIKeys(ctx, &authv1.ListAPIKeysRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RevokeAPIKey revokes one of the token user's API keys
+func (c *AuthClient) RevokeAPIKey(ctx context.Context, accessToken string, keyID int32) (*authv1.AdminActionResponse, error) {
+	resp, err := c.client.RevokeAPIKey(ctx, &authv1.RevokeAPIKeyRequest{AccessToken: accessToken, KeyId: keyID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Device Functions
+
+// ListDevices lists the devices the token user has logged in from
+func (c *AuthClient) ListDevices(ctx context.Context, accessToken string) (*authv1.ListDevicesResponse, error) {
+	resp, err := c.client.ListDevices(ctx, &authv1.ListDevicesRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list devices: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RevokeDevice revokes one of the token user's devices
+func (c *AuthClient) RevokeDevice(ctx context.Context, accessToken string, deviceID int32) (*authv1.AdminActionResponse, error) {
+	resp, err := c.client.RevokeDevice(ctx, &authv1.RevokeDeviceRequest{AccessToken: accessToken, DeviceId: deviceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revoke device: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Session Instance Functions
+
+// ReportSessionInstance reports this replica's load to the shared instance registry
+func (c *AuthClient) ReportSessionInstance(ctx context.Context, instance *authv1.SessionInstance) error {
+	if _, err := c.client.ReportSessionInstance(ctx, &authv1.ReportSessionInstanceRequest{Instance: instance}); err != nil {
+		return fmt.Errorf("failed to report session instance: %w", err)
+	}
+
+	return nil
+}
+
+// CountSessionLogins records a login starting (delta 1) or ending (delta -1) on
+// a replica and returns the user's logins across the replicas
+func (c *AuthClient) CountSessionLogins(ctx context.Context, instanceID, username string, delta int) (int, error) {
+	resp, err := c.client.CountSessionLogins(ctx, &authv1.CountSessionLoginsRequest{
+		InstanceId: instanceID,
+		Username:   username,
+		Delta:      int32(delta),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count session logins: %w", err)
+	}
+
+	return int(resp.Logins), nil
+}
+
+// ListSessionInstances lists the session service replicas with their sums (admin only)
+func (c *AuthClient) ListSessionInstances(ctx context.Context, adminToken string) (*authv1.ListSessionInstancesResponse, error) {
+	resp, err := c.client.ListSessionInstances(ctx, &authv1.ListSessionInstancesRequest{AdminToken: adminToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session instances: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Challenge Functions
+
+// IssueChallenge asks the auth service for a challenge to pose to a client
+// that tripped brute force protection
+func (c *AuthClient) IssueChallenge(ctx context.Context, clientIP, username string) (*authv1.IssueChallengeResponse, error) {
+	resp, err := c.client.IssueChallenge(ctx, &authv1.IssueChallengeRequest{ClientIp: clientIP, Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue challenge: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AnswerChallenge checks a client's answer to a challenge
+func (c *AuthClient) AnswerChallenge(ctx context.Context, challengeID, answer, clientIP string) (*authv1.AnswerChallengeResponse, error) {
+	req := &authv1.AnswerChallengeRequest{
+		ChallengeId: challengeID,
+		Answer:      answer,
+		ClientIp:    clientIP,
+	}
+
+	resp, err := c.client.AnswerChallenge(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to answer challenge: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Notification Functions
+
+// ListNotifications lists the token user's notifications, newest first
+func (c *AuthClient) ListNotifications(ctx context.Context, accessToken string, unreadOnly bool, limit int32) (*authv1.ListNotificationsResponse, error) {
+	req := &authv1.ListNotificationsRequest{
+		AccessToken: accessToken,
+		UnreadOnly:  unreadOnly,
+		Limit:       limit,
+	}
+
+	resp, err := c.client.ListNotifications(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return resp, nil
+}
+
+// MarkNotificationsRead marks the token user's notifications read, all of them when ids is empty
+func (c *AuthClient) MarkNotificationsRead(ctx context.Context, accessToken string, ids []int32) (*authv1.ListNotificationsResponse, error) {
+	resp, err := c.client.MarkNotificationsRead(ctx, &authv1.MarkNotificationsReadRequest{AccessToken: accessToken, Ids: ids})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SendNotification leaves an announcement for a user, or every user when username is empty (admin only)
+func (c *AuthClient) SendNotification(ctx context.Context, adminToken, username, title, body string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.SendNotificationRequest{
+		AdminToken:     adminToken,
+		TargetUsername: username,
+		Title:          title,
+		Body:           body,
+	}
+
+	resp, err := c.client.SendNotification(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send notification: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Group Functions
+
+// CreateGroup creates a group owned by the token user
+func (c *AuthClient) CreateGroup(ctx context.Context, accessToken, name, description string) (*authv1.GroupResponse, error) {
+	req := &authv1.CreateGroupRequest{
+		AccessToken: accessToken,
+		Name:        name,
+		Description: description,
+	}
+
+	resp, err := c.client.CreateGroup(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+
+	return resp, nil
+}
+
+// JoinGroup adds the token user to a group
+func (c *AuthClient) JoinGroup(ctx context.Context, accessToken, name string) (*authv1.GroupResponse, error) {
+	resp, err := c.client.JoinGroup(ctx, &authv1.GroupRequest{AccessToken: accessToken, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to join group: %w", err)
+	}
+
+	return resp, nil
+}
+
+// LeaveGroup removes the token user from their group
+func (c *AuthClient) LeaveGroup(ctx context.Context, accessToken string) (*authv1.GroupResponse, error) {
+	resp, err := c.client.LeaveGroup(ctx, &authv1.GroupRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to leave group: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetGroup returns a group and its roster; an empty name returns the token user's group
+func (c *AuthClient) GetGroup(ctx context.Context, accessToken, name string) (*authv1.GroupResponse, error) {
+	resp, err := c.client.GetGroup(ctx, &authv1.GroupRequest{AccessToken: accessToken, Name: name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListGroups lists every group, largest first
+func (c *AuthClient) ListGroups(ctx context.Context, accessToken string) (*authv1.ListGroupsResponse, error) {
+	resp, err := c.client.ListGroups(ctx, &authv1.GroupRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list groups: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Friend Functions
+
+// AddFriend puts a user on the token user's friend list
+func (c *AuthClient) AddFriend(ctx context.Context, accessToken, username string) (*authv1.FriendsResponse, error) {
+	resp, err := c.client.AddFriend(ctx, &authv1.FriendRequest{AccessToken: accessToken, Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add friend: %w", err)
+	}
+
+	return resp, nil
+}
+
+// RemoveFriend takes a user off the token user's friend list
+func (c *AuthClient) RemoveFriend(ctx context.Context, accessToken, username string) (*authv1.FriendsResponse, error) {
+	resp, err := c.client.RemoveFriend(ctx, &authv1.FriendRequest{AccessToken: accessToken, Username: username})
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove friend: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListFriends lists the token user's friends
+func (c *AuthClient) ListFriends(ctx context.Context, accessToken string) (*authv1.FriendsResponse, error) {
+	resp, err := c.client.ListFriends(ctx, &authv1.FriendRequest{AccessToken: accessToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list friends: %w", err)
+	}
+
+	return resp, nil
+}
+
+// Moderation Functions
+
+// AddUserNote attaches a note to a user account (moderator only)
+func (c *AuthClient) AddUserNote(ctx context.Context, moderatorToken, targetUsername, note string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.AddUserNoteRequest{
+		AdminToken:     moderatorToken,
+		TargetUsername: targetUsername,
+		Note:           note,
+	}
+
+	resp, err := c.client.AddUserNote(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add user note: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetUserModeration returns the notes and moderation flags on a user account (moderator only)
+func (c *AuthClient) GetUserModeration(ctx context.Context, moderatorToken, targetUsername string) (*authv1.GetUserModerationResponse, error) {
+	req := &authv1.AdminActionRequest{
+		AdminToken:     moderatorToken,
+		TargetUsername: targetUsername,
+	}
+
+	resp, err := c.client.GetUserModeration(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user moderation record: %w", err)
+	}
+
+	return resp, nil
+}
+
+// SetUserModerationFlag sets a moderation flag on a user account (moderator only)
+func (c *AuthClient) SetUserModerationFlag(ctx context.Context, moderatorToken, targetUsername, flag, reason string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.ModerationFlagRequest{
+		AdminToken:     moderatorToken,
+		TargetUsername: targetUsername,
+		Flag:           flag,
+		Reason:         reason,
+	}
+
+	resp, err := c.client.SetUserModerationFlag(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set moderation flag: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ClearUserModerationFlag clears a moderation flag from a user account (moderator only)
+func (c *AuthClient) ClearUserModerationFlag(ctx context.Context, moderatorToken, targetUsername, flag string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.ModerationFlagRequest{
+		AdminToken:     moderatorToken,
+		TargetUsername: targetUsername,
+		Flag:           flag,
+	}
+
+	resp, err := c.client.ClearUserModerationFlag(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear moderation flag: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListUsersByModerationFlag lists the users with a moderation flag set (moderator only)
+func (c *AuthClient) ListUsersByModerationFlag(ctx context.Context, moderatorToken, flag string) (*authv1.ListUsersByModerationFlagResponse, error) {
+	req := &authv1.ListUsersByModerationFlagRequest{
+		AdminToken: moderatorToken,
+		Flag:       flag,
+	}
+
+	resp, err := c.client.ListUsersByModerationFlag(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list flagged users: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ListBanners lists the banner names and their runtime templates (admin only)
+func (c *AuthClient) ListBanners(ctx context.Context, adminToken string) (*authv1.ListBannersResponse, error) {
+	resp, err := c.client.ListBanners(ctx, &authv1.BannerRequest{AdminToken: adminToken})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list banners: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetBanner returns the runtime template of a banner (admin only)
+func (c *AuthClient) GetBanner(ctx context.Context, adminToken, name string) (*authv1.GetBannerResponse, error) {
+	req := &authv1.BannerRequest{
+		AdminToken: adminToken,
+		Name:       name,
+	}
+
+	resp, err := c.client.GetBanner(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banner: %w", err)
+	}
+
+	return resp, nil
+}
+
+// UpdateBanner replaces a banner template on every session service (admin only)
+func (c *AuthClient) UpdateBanner(ctx context.Context, adminToken, name, content string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.UpdateBannerRequest{
+		AdminToken: adminToken,
+		Name:       name,
+		Content:    content,
+	}
+
+	resp, err := c.client.UpdateBanner(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update banner: %w", err)
+	}
+
+	return resp, nil
+}
+
+// ResetBanner restores a banner to its file on every session service (admin only)
+func (c *AuthClient) ResetBanner(ctx context.Context, adminToken, name string) (*authv1.AdminActionResponse, error) {
+	req := &authv1.BannerRequest{
+		AdminToken: adminToken,
+		Name:       name,
+	}
+
+	resp, err := c.client.ResetBanner(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reset banner: %w", err)
+	}
+
+	return resp, nil
+}
+
+// PreviewBanner renders a banner template without storing it (admin only)
+func (c *AuthClient) PreviewBanner(ctx context.Context, req *authv1.PreviewBannerRequest) (*authv1.PreviewBannerResponse, error) {
+	resp, err := c.client.PreviewBanner(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview banner: %w", err)
+	}
+
+	return resp, nil
+}
+
+// WatchBanners opens a stream of the runtime banner templates and their changes
+func (c *AuthClient) WatchBanners(ctx context.Context, instanceID string) (authv1.AuthService_WatchBannersClient, error) {
+	stream, err := c.client.WatchBanners(ctx, &authv1.WatchBannersRequest{InstanceId: instanceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch banners: %w", err)
+	}
+
+	return stream, nil
+}
+
 // containsSubstring checks if a string contains a substring
 func containsSubstring(str, substr string) bool {
 	for i := 0; i <= len(str)-len(substr); i++ {
@@ -89,7 +89,7 @@ func TestAuthClientLogin(t *testing.T) {
 	defer cancel()
 
 	// Test with invalid credentials
-	resp, err := client.Login(ctx, "invalid-user", "invalid-password")
+	resp, err := client.Login(ctx, "invalid-user", "invalid-password", "127.0.0.1", nil)
 
 	// The auth service returns a response with Success=false for invalid credentials
 	assert.NoError(t, err)
@@ -247,7 +247,7 @@ func TestAuthClientLoginVariations(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			resp, err := client.Login(ctx, tc.username, tc.password)
+			resp, err := client.Login(ctx, tc.username, tc.password, "127.0.0.1", nil)
 
 			// All should fail since we don't have valid users
 			assert.Error(t, err)
@@ -276,7 +276,7 @@ func TestAuthClientIntegration(t *testing.T) {
 
 	// Test workflow: try to login with test credentials
 	// This will fail unless we have a real auth service with test data
-	resp, err := client.Login(ctx, "testuser", "testpass")
+	resp, err := client.Login(ctx, "testuser", "testpass", "127.0.0.1", nil)
 	if err != nil {
 		t.Logf("Failed to login (expected): %v", err)
 		return
@@ -334,6 +334,6 @@ func BenchmarkAuthClientLogin(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = client.Login(ctx, "testuser", "testpass")
+		_, _ = client.Login(ctx, "testuser", "testpass", "127.0.0.1", nil)
 	}
 }
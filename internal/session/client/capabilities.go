@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+// capabilitiesTTL is how long negotiated capabilities are used before they
+// are asked for again, so a game service upgraded in place is noticed
+const capabilitiesTTL = time.Minute
+
+// SetVersion sets the session service build reported to the game service
+func (c *GameClient) SetVersion(version string) {
+	c.version = version
+}
+
+// APIVersion returns the games API version sent with each call: the
+// negotiated one, or the newest this build speaks before negotiating
+func (c *GameClient) APIVersion() int {
+	if version := c.apiVersion.Load(); version > 0 {
+		return int(version)
+	}
+	return capabilities.APIVersion
+}
+
+// Capabilities returns what the game service supports, negotiating the API
+// version when it has not been recently. A game service that predates
+// versioning speaks version 2 with the legacy features; one that cannot be
+// reached is assumed to, without remembering it.
+func (c *GameClient) Capabilities(ctx context.Context) *capabilities.Capabilities {
+	c.capsMu.Lock()
+	defer c.capsMu.Unlock()
+	if c.caps != nil && time.Since(c.capsAt) < capabilitiesTTL {
+		return c.caps
+	}
+
+	legacy := &capabilities.Capabilities{APIVersion: capabilities.APIVersion2, Features: capabilities.Legacy()}
+	resp, err := c.client.GetCapabilities(ctx, &gamev2.GetCapabilitiesRequest{
+		ApiVersion:    capabilities.APIVersion,
+		ClientVersion: c.version,
+	})
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		c.logger.InfoContext(ctx, "Game service predates API versioning, using version 2")
+		c.setCapabilities(legacy)
+	case err != nil:
+		c.logger.WarnContext(ctx, "Failed to get game service capabilities, assuming version 2", "error", err)
+		return legacy
+	default:
+		negotiated := &capabilities.Capabilities{
+			APIVersion:    int(resp.ApiVersion),
+			Features:      capabilities.NewSet(resp.Features...),
+			ServerVersion: resp.ServerVersion,
+		}
+		if c.caps == nil || c.caps.APIVersion != negotiated.APIVersion || c.caps.ServerVersion != negotiated.ServerVersion {
+			c.logger.InfoContext(ctx, "Negotiated games API version", "api_version", negotiated.APIVersion, "features", resp.Features, "game_service_version", resp.ServerVersion)
+		}
+		c.setCapabilities(negotiated)
+	}
+	return c.caps
+}
+
+// setCapabilities remembers negotiated capabilities; the caller holds capsMu
+func (c *GameClient) setCapabilities(caps *capabilities.Capabilities) {
+	c.caps, c.capsAt = caps, time.Now()
+	c.apiVersion.Store(int32(caps.APIVersion))
+}
+
+// Supports reports whether the game service offers feature
+func (c *GameClient) Supports(ctx context.Context, feature string) bool {
+	return c.Capabilities(ctx).Supports(feature)
+}
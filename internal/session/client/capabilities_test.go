@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+// capabilitiesService answers GetCapabilities with resp or err
+type capabilitiesService struct {
+	gamev2.GameServiceClient
+	resp  *gamev2.GetCapabilitiesResponse
+	err   error
+	calls int
+}
+
+func (s *capabilitiesService) GetCapabilities(ctx context.Context, req *gamev2.GetCapabilitiesRequest, opts ...grpc.CallOption) (*gamev2.GetCapabilitiesResponse, error) {
+	s.calls++
+	return s.resp, s.err
+}
+
+func TestGameClientCapabilities(t *testing.T) {
+	service := &capabilitiesService{resp: &gamev2.GetCapabilitiesResponse{
+		ApiVersion:    capabilities.APIVersion3,
+		Features:      []string{capabilities.Resume},
+		ServerVersion: "1.2.3",
+	}}
+	client := &GameClient{client: service, logger: slog.Default()}
+	assert.Equal(t, capabilities.APIVersion, client.APIVersion(), "the newest version is sent before negotiating")
+
+	caps := client.Capabilities(context.Background())
+	assert.Equal(t, capabilities.APIVersion3, caps.APIVersion)
+	assert.Equal(t, "1.2.3", caps.ServerVersion)
+	assert.True(t, client.Supports(context.Background(), capabilities.Resume))
+	assert.False(t, client.Supports(context.Background(), capabilities.Recording))
+	assert.Equal(t, 1, service.calls, "capabilities are remembered")
+}
+
+func TestGameClientCapabilities_LegacyGameService(t *testing.T) {
+	// A game service that predates versioning speaks version 2
+	service := &capabilitiesService{err: status.Error(codes.Unimplemented, "unknown method GetCapabilities")}
+	client := &GameClient{client: service, logger: slog.Default()}
+	caps := client.Capabilities(context.Background())
+	assert.Equal(t, capabilities.APIVersion2, caps.APIVersion)
+	assert.True(t, caps.Supports(capabilities.Multiplexing))
+	assert.Equal(t, capabilities.APIVersion2, client.APIVersion())
+	client.Capabilities(context.Background())
+	assert.Equal(t, 1, service.calls)
+
+	// One that cannot be reached is asked again next time
+	service = &capabilitiesService{err: errors.New("connection refused")}
+	client = &GameClient{client: service, logger: slog.Default()}
+	assert.Equal(t, capabilities.APIVersion2, client.Capabilities(context.Background()).APIVersion)
+	client.Capabilities(context.Background())
+	assert.Equal(t, 2, service.calls)
+}
@@ -0,0 +1,21 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+)
+
+// FeatureFlagOverrides asks the auth service for the feature flags set at
+// runtime, by name
+func (c *AuthClient) FeatureFlagOverrides(ctx context.Context) (map[string]*config.FeatureFlag, error) {
+	resp, err := c.client.GetFeatureFlagOverrides(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
+	return featureflags.OverridesFromProto(resp), nil
+}
@@ -4,15 +4,34 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/dungeongate/internal/session/realm"
 	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+	"github.com/dungeongate/pkg/interceptors"
 )
 
+// mutationAttempts is how many times a call that changes a session or save
+// is tried before giving up
+const mutationAttempts = 3
+
+// mutationBackoff is the wait before retrying such a call, times the attempt,
+// unless the game service asked for another
+const mutationBackoff = 100 * time.Millisecond
+
 // SessionInfo represents basic session information
 type SessionInfo struct {
 	ID           string                 `json:"id"`
@@ -23,6 +42,10 @@ type SessionInfo struct {
 	CreatedAt    time.Time              `json:"created_at"`
 	LastActivity time.Time              `json:"last_activity"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+
+	// PlayTimeEndsAt is when the player's play-time budget runs out, or nil
+	// without a budget
+	PlayTimeEndsAt *time.Time `json:"play_time_ends_at,omitempty"`
 }
 
 // GameClient provides stateless access to Game Service
@@ -30,60 +53,103 @@ type GameClient struct {
 	conn   *grpc.ClientConn
 	client gamev2.GameServiceClient
 	logger *slog.Logger
+	// Set when conn belongs to a Pool, which closes it
+	pooled bool
+
+	// Negotiated with the game service by Capabilities
+	version    string
+	apiVersion atomic.Int32
+	capsMu     sync.Mutex
+	caps       *capabilities.Capabilities
+	capsAt     time.Time
+
+	// Most spectators of any session, on top of each game's limit; 0 for none
+	maxSpectators int
 }
 
-// NewGameClient creates a new Game Service client
-func NewGameClient(address string, logger *slog.Logger) (*GameClient, error) {
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewGameClient creates a new Game Service client. Extra dial options can
+// route the connection elsewhere, e.g. to an in-process server.
+func NewGameClient(address string, logger *slog.Logger, opts ...grpc.DialOption) (*GameClient, error) {
+	c := &GameClient{logger: logger}
+	dialOpts := append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	dialOpts = append(dialOpts, interceptors.WithAPIVersion(c.APIVersion)...)
+	conn, err := grpc.NewClient(address, dialOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to game service: %w", err)
 	}
 
-	client := gamev2.NewGameServiceClient(conn)
+	c.conn = conn
+	c.client = gamev2.NewGameServiceClient(conn)
+	return c, nil
+}
 
-	return &GameClient{
-		conn:   conn,
-		client: client,
-		logger: logger,
-	}, nil
+// NewPooledGameClient creates a Game Service client on pool's connection
+// to the game service at address
+func NewPooledGameClient(pool *Pool, address string, logger *slog.Logger) (*GameClient, error) {
+	c := &GameClient{logger: logger, pooled: true}
+	conn, err := pool.Conn("game", address, interceptors.WithAPIVersion(c.APIVersion)...)
+	if err != nil {
+		return nil, err
+	}
+
+	c.conn = conn
+	c.client = gamev2.NewGameServiceClient(conn)
+	return c, nil
 }
 
-// Close closes the client connection
+// Close closes the client connection, unless it belongs to a Pool
 func (c *GameClient) Close() error {
-	if c.conn != nil {
+	if c.conn != nil && !c.pooled {
 		return c.conn.Close()
 	}
 	return nil
 }
 
-// StartGameSession starts a new game session
-func (c *GameClient) StartGameSession(ctx context.Context, userID int32, username, gameID string, terminalCols, terminalRows int) (*SessionInfo, error) {
+// StartGameSession starts a new game session, streaming it to spectators only when allowSpectators is set.
+// An empty version starts the game's default version. recordingPrivacy is the player's recording setting,
+// which the game's recording policy may override. roles are the player's roles, for the game service's
+// feature flags.
+func (c *GameClient) StartGameSession(ctx context.Context, userID int32, username string, roles []string, gameID, version string, terminalCols, terminalRows int, termType, locale, clientIP, recordingPrivacy string, allowSpectators bool, playTimeLimit *gamev2.PlayTimeLimit) (*SessionInfo, error) {
+	if !realm.FromContext(ctx).AllowsGame(gameID) {
+		return nil, fmt.Errorf("failed to start game session: game %s is not offered in this realm", gameID)
+	}
 	req := &gamev2.StartGameSessionRequest{
 		UserId:   userID,
 		Username: username,
+		Roles:    roles,
 		GameId:   gameID,
+		Version:  version,
 		TerminalSize: &gamev2.TerminalSize{
 			Width:  int32(terminalCols),
 			Height: int32(terminalRows),
 		},
+		TermType:         termType,
+		Locale:           locale,
+		ClientIp:         clientIP,
+		RecordingPrivacy: recordingPrivacy,
 		EnableRecording:  true,
-		EnableStreaming:  true,
+		EnableStreaming:  allowSpectators,
 		EnableEncryption: false,
+		IdempotencyKey:   uuid.NewString(),
+		PlayTimeLimit:    playTimeLimit,
 	}
 
-	resp, err := c.client.StartGameSession(ctx, req)
+	resp, err := retryMutation(ctx, c.logger, func(ctx context.Context) (*gamev2.StartGameSessionResponse, error) {
+		return c.client.StartGameSession(ctx, req)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to start game session: %w", err)
 	}
 
 	return &SessionInfo{
-		ID:           resp.Session.Id,
-		UserID:       fmt.Sprintf("%d", userID),
-		GameID:       gameID,
-		State:        convertSessionState(resp.Session.Status),
-		CreatedAt:    resp.Session.StartTime.AsTime(),
-		LastActivity: resp.Session.LastActivity.AsTime(),
-		Metadata:     make(map[string]interface{}),
+		ID:             resp.Session.Id,
+		UserID:         fmt.Sprintf("%d", userID),
+		GameID:         gameID,
+		State:          convertSessionState(resp.Session.Status),
+		CreatedAt:      resp.Session.StartTime.AsTime(),
+		LastActivity:   resp.Session.LastActivity.AsTime(),
+		Metadata:       make(map[string]interface{}),
+		PlayTimeEndsAt: optionalTime(resp.Session.PlayTimeEndsAt),
 	}, nil
 }
 
@@ -99,25 +165,29 @@ func (c *GameClient) GetGameSession(ctx context.Context, sessionID string) (*Ses
 	}
 
 	return &SessionInfo{
-		ID:           resp.Session.Id,
-		UserID:       fmt.Sprintf("%d", resp.Session.UserId),
-		GameID:       resp.Session.GameId,
-		State:        convertSessionState(resp.Session.Status),
-		CreatedAt:    resp.Session.StartTime.AsTime(),
-		LastActivity: resp.Session.LastActivity.AsTime(),
-		Metadata:     make(map[string]interface{}),
+		ID:             resp.Session.Id,
+		UserID:         fmt.Sprintf("%d", resp.Session.UserId),
+		GameID:         resp.Session.GameId,
+		State:          convertSessionState(resp.Session.Status),
+		CreatedAt:      resp.Session.StartTime.AsTime(),
+		LastActivity:   resp.Session.LastActivity.AsTime(),
+		Metadata:       make(map[string]interface{}),
+		PlayTimeEndsAt: optionalTime(resp.Session.PlayTimeEndsAt),
 	}, nil
 }
 
 // StopGameSession stops a game session
 func (c *GameClient) StopGameSession(ctx context.Context, sessionID, reason string) error {
 	req := &gamev2.StopGameSessionRequest{
-		SessionId: sessionID,
-		Reason:    reason,
-		Force:     false,
+		SessionId:      sessionID,
+		Reason:         reason,
+		Force:          false,
+		IdempotencyKey: uuid.NewString(),
 	}
 
-	_, err := c.client.StopGameSession(ctx, req)
+	_, err := retryMutation(ctx, c.logger, func(ctx context.Context) (*gamev2.StopGameSessionResponse, error) {
+		return c.client.StopGameSession(ctx, req)
+	})
 	if err != nil {
 		return fmt.Errorf("failed to stop game session: %w", err)
 	}
@@ -125,12 +195,50 @@ func (c *GameClient) StopGameSession(ctx context.Context, sessionID, reason stri
 	return nil
 }
 
+// KillGameSession stops a game session and terminates its game process
+func (c *GameClient) KillGameSession(ctx context.Context, sessionID, reason string) error {
+	req := &gamev2.StopGameSessionRequest{
+		SessionId:      sessionID,
+		Reason:         reason,
+		Force:          true,
+		IdempotencyKey: uuid.NewString(),
+	}
+
+	_, err := retryMutation(ctx, c.logger, func(ctx context.Context) (*gamev2.StopGameSessionResponse, error) {
+		return c.client.StopGameSession(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to kill game session: %w", err)
+	}
+
+	return nil
+}
+
+// SaveGame asks the game service to save the user's running game, which then exits
+func (c *GameClient) SaveGame(ctx context.Context, userID int32, gameID string) error {
+	req := &gamev2.SaveGameRequest{
+		UserId:         userID,
+		GameId:         gameID,
+		IdempotencyKey: uuid.NewString(),
+	}
+
+	_, err := retryMutation(ctx, c.logger, func(ctx context.Context) (*gamev2.SaveGameResponse, error) {
+		return c.client.SaveGame(ctx, req)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save game: %w", err)
+	}
+
+	return nil
+}
+
 // ListGameSessions lists sessions for a user
 func (c *GameClient) ListGameSessions(ctx context.Context, userID int32) ([]*SessionInfo, error) {
 	req := &gamev2.ListGameSessionsRequest{
-		UserId: userID,
-		Limit:  100,
-		Offset: 0,
+		UserId:   userID,
+		Limit:    100,
+		Offset:   0,
+		ViewerId: userID,
 	}
 
 	resp, err := c.client.ListGameSessions(ctx, req)
@@ -154,12 +262,13 @@ func (c *GameClient) ListGameSessions(ctx context.Context, userID int32) ([]*Ses
 	return sessions, nil
 }
 
-// ListGames retrieves the list of available games
+// ListGames retrieves the list of games players can see, limited to those
+// the realm carried by ctx offers. Games in maintenance are included with
+// their status and message.
 func (c *GameClient) ListGames(ctx context.Context) ([]*gamev2.Game, error) {
 	req := &gamev2.ListGamesRequest{
-		EnabledOnly: true, // Only show enabled games
-		Limit:       100,  // Get up to 100 games
-		Offset:      0,
+		Limit:  100, // Get up to 100 games
+		Offset: 0,
 	}
 
 	resp, err := c.client.ListGames(ctx, req)
@@ -167,7 +276,14 @@ func (c *GameClient) ListGames(ctx context.Context) ([]*gamev2.Game, error) {
 		return nil, fmt.Errorf("failed to list games: %w", err)
 	}
 
-	return resp.Games, nil
+	r := realm.FromContext(ctx)
+	games := make([]*gamev2.Game, 0, len(resp.Games))
+	for _, game := range resp.Games {
+		if r.AllowsGame(game.Id) {
+			games = append(games, game)
+		}
+	}
+	return games, nil
 }
 
 // Health checks the health of the game service
@@ -182,6 +298,9 @@ func (c *GameClient) Health(ctx context.Context) (*gamev2.HealthResponse, error)
 
 // IsHealthy checks if the game service is available and healthy
 func (c *GameClient) IsHealthy(ctx context.Context) bool {
+	if unreachable(c.conn) {
+		return false
+	}
 	resp, err := c.Health(ctx)
 	if err != nil {
 		c.logger.Debug("Game service health check failed", "error", err)
@@ -224,10 +343,42 @@ func (c *GameClient) ResizeTerminal(ctx context.Context, sessionID string, width
 
 // GetActiveGameSessions returns all active game sessions for spectating
 func (c *GameClient) GetActiveGameSessions(ctx context.Context) ([]*gamev2.GameSession, error) {
+	all, err := c.ListActiveGameSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hide sessions whose players have disabled spectating
+	sessions := make([]*gamev2.GameSession, 0, len(all))
+	for _, session := range all {
+		if AllowsSpectators(session) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, nil
+}
+
+// ListActiveGameSessions returns every active game session of the realm
+// carried by ctx, including those closed to spectators
+func (c *GameClient) ListActiveGameSessions(ctx context.Context) ([]*gamev2.GameSession, error) {
+	return c.listActiveGameSessions(ctx, "")
+}
+
+// ListHeaviestGameSessions lists the active game sessions with their
+// resource usage, heaviest first by sortBy: "cpu", "rss", "pids" or "io"
+func (c *GameClient) ListHeaviestGameSessions(ctx context.Context, sortBy string) ([]*gamev2.GameSession, error) {
+	return c.listActiveGameSessions(ctx, sortBy)
+}
+
+// listActiveGameSessions lists the active game sessions of the realm carried
+// by ctx, in the order sortBy asks for
+func (c *GameClient) listActiveGameSessions(ctx context.Context, sortBy string) ([]*gamev2.GameSession, error) {
 	req := &gamev2.ListGameSessionsRequest{
 		Status: gamev2.SessionStatus_SESSION_STATUS_ACTIVE,
 		Limit:  100,
 		Offset: 0,
+		SortBy: sortBy,
 	}
 
 	resp, err := c.client.ListGameSessions(ctx, req)
@@ -235,13 +386,114 @@ func (c *GameClient) GetActiveGameSessions(ctx context.Context) ([]*gamev2.GameS
 		return nil, fmt.Errorf("failed to list active game sessions: %w", err)
 	}
 
-	return resp.Sessions, nil
+	r := realm.FromContext(ctx)
+	sessions := make([]*gamev2.GameSession, 0, len(resp.Sessions))
+	for _, session := range resp.Sessions {
+		if r.Owns(session.Username) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+// SubscribeGameSessions calls onUpdate for each change to the spectatable active
+// sessions: ADDED updates for the current sessions followed by SYNCED, then
+// ADDED, UPDATED, REMOVED and IDLE updates as they happen. It blocks until ctx
+// is cancelled or the stream fails.
+func (c *GameClient) SubscribeGameSessions(ctx context.Context, onUpdate func(*gamev2.GameSessionUpdate)) error {
+	return c.subscribeGameSessions(ctx, true, onUpdate)
+}
+
+// SubscribeAllGameSessions is SubscribeGameSessions for every active session,
+// including those closed to spectators
+func (c *GameClient) SubscribeAllGameSessions(ctx context.Context, onUpdate func(*gamev2.GameSessionUpdate)) error {
+	return c.subscribeGameSessions(ctx, false, onUpdate)
+}
+
+func (c *GameClient) subscribeGameSessions(ctx context.Context, spectatableOnly bool, onUpdate func(*gamev2.GameSessionUpdate)) error {
+	req := &gamev2.SubscribeGameSessionsRequest{
+		SpectatableOnly: spectatableOnly,
+	}
+
+	stream, err := c.client.SubscribeGameSessions(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to game sessions: %w", err)
+	}
+	r := realm.FromContext(ctx)
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return fmt.Errorf("game session subscription failed: %w", err)
+		}
+		// Other realms' games are not shown; removals name only the session
+		if update.Session != nil && !r.Owns(update.Session.Username) {
+			continue
+		}
+		onUpdate(update)
+	}
+}
+
+// AllowsSpectators reports whether a game session is open to spectators
+func AllowsSpectators(session *gamev2.GameSession) bool {
+	return session != nil && session.Streaming != nil && session.Streaming.Enabled
+}
+
+// SpectatorCount returns how many spectators a game session has. Game
+// services before spectator limits only list them.
+func SpectatorCount(session *gamev2.GameSession) int {
+	if session.SpectatorCount > 0 {
+		return int(session.SpectatorCount)
+	}
+	return len(session.Spectators)
+}
+
+// SetMaxSpectators limits the spectators of every game session, on top of
+// the limit each game sets; zero leaves it to the games
+func (c *GameClient) SetMaxSpectators(limit int) {
+	c.maxSpectators = max(limit, 0)
+}
+
+// MaxSpectators returns the most spectators a game session allows: the
+// stricter of its game's limit and the server's, or zero for no limit
+func (c *GameClient) MaxSpectators(session *gamev2.GameSession) int {
+	limit := int(session.MaxSpectators)
+	if c != nil && c.maxSpectators > 0 && (limit == 0 || c.maxSpectators < limit) {
+		limit = c.maxSpectators
+	}
+	return limit
+}
+
+// SessionFull reports whether a game session has as many spectators as it
+// allows
+func (c *GameClient) SessionFull(session *gamev2.GameSession) bool {
+	limit := c.MaxSpectators(session)
+	return limit > 0 && SpectatorCount(session) >= limit
+}
+
+// SpectatorsLabel shows how many spectators a game session has, out of how
+// many it allows when there is a limit, e.g. "3/5"
+func (c *GameClient) SpectatorsLabel(session *gamev2.GameSession) string {
+	if limit := c.MaxSpectators(session); limit > 0 {
+		return fmt.Sprintf("%d/%d", SpectatorCount(session), limit)
+	}
+	return strconv.Itoa(SpectatorCount(session))
 }
 
 // GetGameSessionWithSpectators retrieves detailed session information including spectators
 func (c *GameClient) GetGameSessionWithSpectators(ctx context.Context, sessionID string) (*gamev2.GameSession, error) {
+	return c.GetGameSessionAs(ctx, sessionID, 0)
+}
+
+// GetGameSessionAs retrieves detailed session information as seen by
+// viewerID, which includes the recording only if the viewer may see it
+func (c *GameClient) GetGameSessionAs(ctx context.Context, sessionID string, viewerID int32) (*gamev2.GameSession, error) {
 	req := &gamev2.GetGameSessionRequest{
 		SessionId: sessionID,
+		ViewerId:  viewerID,
 	}
 
 	resp, err := c.client.GetGameSession(ctx, req)
@@ -252,12 +504,193 @@ func (c *GameClient) GetGameSessionWithSpectators(ctx context.Context, sessionID
 	return resp.Session, nil
 }
 
-// AddSpectator adds a spectator to a game session
-func (c *GameClient) AddSpectator(ctx context.Context, sessionID string, spectatorUserID int32, spectatorUsername string) error {
+// ListDumplogs retrieves a user's most recent dumplogs, newest first and without content
+func (c *GameClient) ListDumplogs(ctx context.Context, userID int32, limit int) ([]*gamev2.Dumplog, error) {
+	req := &gamev2.ListDumplogsRequest{
+		UserId: userID,
+		Limit:  int32(limit),
+	}
+
+	resp, err := c.client.ListDumplogs(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dumplogs: %w", err)
+	}
+
+	return resp.Dumplogs, nil
+}
+
+// ListSessionHistory retrieves a page of a user's finished sessions, newest
+// first, and how many there are in all
+func (c *GameClient) ListSessionHistory(ctx context.Context, userID int32, offset, limit int) ([]*gamev2.GameSession, int, error) {
+	req := &gamev2.ListSessionHistoryRequest{
+		UserId:   userID,
+		Offset:   int32(offset),
+		Limit:    int32(limit),
+		ViewerId: userID,
+	}
+
+	resp, err := c.client.ListSessionHistory(ctx, req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list session history: %w", err)
+	}
+
+	return resp.Sessions, int(resp.TotalCount), nil
+}
+
+// GetDumplog retrieves a dumplog including its content
+func (c *GameClient) GetDumplog(ctx context.Context, dumplogID string) (*gamev2.Dumplog, error) {
+	req := &gamev2.GetDumplogRequest{
+		DumplogId: dumplogID,
+	}
+
+	resp, err := c.client.GetDumplog(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dumplog: %w", err)
+	}
+
+	return resp.Dumplog, nil
+}
+
+// ScreenshotRenderings are a captured screen in each screenshot format
+type ScreenshotRenderings struct {
+	Cols, Rows       int
+	Text, ANSI, HTML string
+}
+
+// TakeScreenshot stores a screenshot of a session taken by its player or a
+// spectator
+func (c *GameClient) TakeScreenshot(ctx context.Context, sessionID string, userID int32, username string, screen ScreenshotRenderings) (*gamev2.Screenshot, error) {
+	req := &gamev2.TakeScreenshotRequest{
+		SessionId: sessionID,
+		UserId:    userID,
+		Username:  username,
+		Cols:      int32(screen.Cols),
+		Rows:      int32(screen.Rows),
+		Text:      screen.Text,
+		Ansi:      screen.ANSI,
+		Html:      screen.HTML,
+	}
+
+	resp, err := c.client.TakeScreenshot(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to take screenshot: %w", err)
+	}
+
+	return resp.Screenshot, nil
+}
+
+// ShareScreenshot makes a short-lived public link to a screenshot
+func (c *GameClient) ShareScreenshot(ctx context.Context, screenshotID string, userID int32) (*gamev2.Screenshot, error) {
+	req := &gamev2.ShareScreenshotRequest{
+		ScreenshotId: screenshotID,
+		UserId:       userID,
+	}
+
+	resp, err := c.client.ShareScreenshot(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to share screenshot: %w", err)
+	}
+
+	return resp.Screenshot, nil
+}
+
+// AddBookmark bookmarks the present moment of a recorded game for its
+// player or a spectator
+func (c *GameClient) AddBookmark(ctx context.Context, sessionID string, userID int32, username, note string) (*gamev2.Bookmark, error) {
+	req := &gamev2.AddBookmarkRequest{
+		SessionId: sessionID,
+		UserId:    userID,
+		Username:  username,
+		Note:      note,
+	}
+
+	resp, err := c.client.AddBookmark(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add bookmark: %w", err)
+	}
+
+	return resp.Bookmark, nil
+}
+
+// ListBookmarks returns the bookmarks of a session's recording that viewerID
+// may see, in recording order
+func (c *GameClient) ListBookmarks(ctx context.Context, sessionID string, viewerID int32) ([]*gamev2.Bookmark, error) {
+	req := &gamev2.ListBookmarksRequest{
+		SessionId: sessionID,
+		ViewerId:  viewerID,
+	}
+
+	resp, err := c.client.ListBookmarks(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	return resp.Bookmarks, nil
+}
+
+// UpdateStatusLine sends the bottom rows of the player's screen for the
+// game's adapter to read its status line from, returning the session's
+// status line
+func (c *GameClient) UpdateStatusLine(ctx context.Context, sessionID string, userID int32, rows []string) (string, error) {
+	req := &gamev2.UpdateStatusLineRequest{
+		SessionId:  sessionID,
+		UserId:     userID,
+		ScreenRows: rows,
+	}
+
+	resp, err := c.client.UpdateStatusLine(ctx, req)
+	if err != nil {
+		return "", fmt.Errorf("failed to update status line: %w", err)
+	}
+
+	return resp.StatusLine, nil
+}
+
+// MergeUserData moves a user's finished sessions, dumplogs and saves to
+// another user, or with dryRun counts what would move
+func (c *GameClient) MergeUserData(ctx context.Context, fromUserID, toUserID int32, toUsername string, dryRun bool) (*gamev2.MergeUserDataResponse, error) {
+	req := &gamev2.MergeUserDataRequest{
+		FromUserId: fromUserID,
+		ToUserId:   toUserID,
+		ToUsername: toUsername,
+		DryRun:     dryRun,
+	}
+
+	resp, err := c.client.MergeUserData(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge user data: %w", err)
+	}
+
+	return resp, nil
+}
+
+// GetLeaderboard ranks the players of a game. With userIDs only those players
+// are ranked, such as the members of a group.
+func (c *GameClient) GetLeaderboard(ctx context.Context, gameID string, userIDs []int32, sortBy string, limit int) (*gamev2.GetLeaderboardResponse, error) {
+	req := &gamev2.GetLeaderboardRequest{
+		GameId:  gameID,
+		UserIds: userIDs,
+		SortBy:  sortBy,
+		Limit:   int32(limit),
+	}
+
+	resp, err := c.client.GetLeaderboard(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get leaderboard: %w", err)
+	}
+
+	return resp, nil
+}
+
+// AddSpectator adds a spectator to a game session. bypassLimit lets an
+// admin join a session that has as many spectators as it allows.
+func (c *GameClient) AddSpectator(ctx context.Context, sessionID string, spectatorUserID int32, spectatorUsername string, bypassLimit bool) error {
 	req := &gamev2.AddSpectatorRequest{
 		SessionId:         sessionID,
 		SpectatorUserId:   spectatorUserID,
 		SpectatorUsername: spectatorUsername,
+		BypassLimit:       bypassLimit,
+		MaxSpectators:     int32(c.maxSpectators),
 	}
 
 	resp, err := c.client.AddSpectator(ctx, req)
@@ -272,11 +705,13 @@ func (c *GameClient) AddSpectator(ctx context.Context, sessionID string, spectat
 	return nil
 }
 
-// RemoveSpectator removes a spectator from a game session
-func (c *GameClient) RemoveSpectator(ctx context.Context, sessionID string, spectatorUserID int32) error {
+// RemoveSpectator removes a spectator from a game session. reason, when
+// set, says why they left, e.g. "idle".
+func (c *GameClient) RemoveSpectator(ctx context.Context, sessionID string, spectatorUserID int32, reason string) error {
 	req := &gamev2.RemoveSpectatorRequest{
 		SessionId:       sessionID,
 		SpectatorUserId: spectatorUserID,
+		Reason:          reason,
 	}
 
 	resp, err := c.client.RemoveSpectator(ctx, req)
@@ -308,3 +743,44 @@ func convertSessionState(state gamev2.SessionStatus) string {
 		return "created"
 	}
 }
+
+// optionalTime converts a timestamp that may be unset
+func optionalTime(ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+	t := ts.AsTime()
+	return &t
+}
+
+// retryMutation makes a call carrying an idempotency key, retrying it while
+// the game service is unreachable or reports a concurrent update. The
+// request is resent unchanged, so a retry of a call that already took effect
+// gets the original result rather than repeating it.
+func retryMutation[T any](ctx context.Context, logger *slog.Logger, call func(ctx context.Context) (T, error)) (T, error) {
+	var resp T
+	var err error
+	for attempt := 1; attempt <= mutationAttempts; attempt++ {
+		resp, err = call(ctx)
+		code := status.Code(err)
+		if err == nil || (code != codes.Unavailable && code != codes.Aborted) || attempt == mutationAttempts {
+			return resp, err
+		}
+
+		wait := time.Duration(attempt) * mutationBackoff
+		for _, detail := range status.Convert(err).Details() {
+			if retry, ok := detail.(*errdetails.RetryInfo); ok {
+				wait = retry.RetryDelay.AsDuration()
+			}
+		}
+		if logger != nil {
+			logger.Debug("Retrying game service call", "attempt", attempt, "code", code.String(), "wait", wait)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return resp, err
+		}
+	}
+	return resp, err
+}
@@ -8,6 +8,11 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
 )
 
 func TestNewGameClient(t *testing.T) {
@@ -66,7 +71,7 @@ func TestGameClientStartGameSession(t *testing.T) {
 	defer cancel()
 
 	// Test starting a game session
-	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", "nethack", 80, 24)
+	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", nil, "nethack", "", 80, 24, "xterm-256color", "en_US.UTF-8", "127.0.0.1", "", true, nil)
 
 	// This will likely fail without a running game service
 	if err != nil {
@@ -170,7 +175,7 @@ func TestGameClientWithTimeoutContext(t *testing.T) {
 	defer cancel()
 
 	// This should timeout
-	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", "nethack", 80, 24)
+	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", nil, "nethack", "", 80, 24, "xterm-256color", "en_US.UTF-8", "127.0.0.1", "", true, nil)
 
 	assert.Error(t, err)
 	assert.Nil(t, sessionInfo)
@@ -238,6 +243,31 @@ func TestConvertSessionState(t *testing.T) {
 	}
 }
 
+func TestSpectatorLimits(t *testing.T) {
+	c := &GameClient{}
+	session := &gamev2.GameSession{SpectatorCount: 2}
+	assert.Equal(t, "2", c.SpectatorsLabel(session))
+	assert.False(t, c.SessionFull(session), "no limit")
+
+	session.MaxSpectators = 3
+	assert.Equal(t, "2/3", c.SpectatorsLabel(session))
+	assert.False(t, c.SessionFull(session))
+
+	session.SpectatorCount = 3
+	assert.True(t, c.SessionFull(session))
+
+	// The stricter of the game's limit and the server's applies
+	c.SetMaxSpectators(2)
+	assert.Equal(t, 2, c.MaxSpectators(session))
+	c.SetMaxSpectators(10)
+	assert.Equal(t, 3, c.MaxSpectators(session))
+	assert.Equal(t, 10, c.MaxSpectators(&gamev2.GameSession{}))
+
+	// Older game services only list the spectators
+	older := &gamev2.GameSession{Spectators: []*gamev2.SpectatorInfo{{UserId: 2}}}
+	assert.Equal(t, "1/10", c.SpectatorsLabel(older))
+}
+
 // Integration test that runs if services are available
 func TestGameClientIntegration(t *testing.T) {
 	if testing.Short() {
@@ -257,7 +287,7 @@ func TestGameClientIntegration(t *testing.T) {
 	defer cancel()
 
 	// Test full workflow: start -> get -> stop
-	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", "nethack", 80, 24)
+	sessionInfo, err := client.StartGameSession(ctx, 1, "testuser", nil, "nethack", "", 80, 24, "xterm-256color", "en_US.UTF-8", "127.0.0.1", "", true, nil)
 	if err != nil {
 		t.Logf("Failed to start session: %v", err)
 		return
@@ -279,3 +309,38 @@ func TestGameClientIntegration(t *testing.T) {
 		t.Logf("Failed to stop session: %v", err)
 	}
 }
+
+// flakyGameService fails the first calls as unreachable, recording the keys
+type flakyGameService struct {
+	gamev2.GameServiceClient
+	failures int
+	keys     []string
+}
+
+func (f *flakyGameService) StartGameSession(ctx context.Context, req *gamev2.StartGameSessionRequest, opts ...grpc.CallOption) (*gamev2.StartGameSessionResponse, error) {
+	f.keys = append(f.keys, req.IdempotencyKey)
+	if len(f.keys) <= f.failures {
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	return &gamev2.StartGameSessionResponse{Session: &gamev2.GameSession{Id: "session-1"}}, nil
+}
+
+func TestGameClientRetriesWithIdempotencyKey(t *testing.T) {
+	service := &flakyGameService{failures: 2}
+	client := &GameClient{client: service, logger: slog.Default()}
+
+	info, err := client.StartGameSession(context.Background(), 1, "testuser", nil, "nethack", "", 80, 24, "xterm", "", "", "", true, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "session-1", info.ID)
+	require.Len(t, service.keys, 3)
+	assert.NotEmpty(t, service.keys[0])
+	assert.Equal(t, service.keys[0], service.keys[1], "retries reuse the key")
+	assert.Equal(t, service.keys[0], service.keys[2])
+
+	// Attempts are bounded
+	service = &flakyGameService{failures: mutationAttempts}
+	client = &GameClient{client: service, logger: slog.Default()}
+	_, err = client.StartGameSession(context.Background(), 1, "testuser", nil, "nethack", "", 80, 24, "xterm", "", "", "", true, nil)
+	assert.Error(t, err)
+	assert.Len(t, service.keys, mutationAttempts)
+}
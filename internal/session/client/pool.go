@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Pool holds one gRPC connection per downstream service, shared by the
+// clients and handlers that call it. Connections are lazy: nothing is dialed
+// until the first call or Watch, so the session service starts while its
+// downstreams are down and players get the service unavailable banner
+// instead.
+type Pool struct {
+	dialOpts []grpc.DialOption
+	logger   *slog.Logger
+
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+// NewPool creates a pool whose connections use dialOpts
+func NewPool(logger *slog.Logger, dialOpts ...grpc.DialOption) *Pool {
+	return &Pool{
+		dialOpts: append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, dialOpts...),
+		logger:   logger,
+		conns:    make(map[string]*grpc.ClientConn),
+	}
+}
+
+// Conn returns the connection to service, creating it to address on first
+// use with opts on top of the pool's dial options
+func (p *Pool) Conn(service, address string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if conn, ok := p.conns[service]; ok {
+		return conn, nil
+	}
+
+	dialOpts := append(p.dialOpts[:len(p.dialOpts):len(p.dialOpts)], opts...)
+	conn, err := grpc.NewClient(address, dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s service connection: %w", service, err)
+	}
+	p.conns[service] = conn
+	return conn, nil
+}
+
+// States returns the state of each service's connection, such as "READY"
+// or "TRANSIENT_FAILURE"
+func (p *Pool) States() map[string]string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	states := make(map[string]string, len(p.conns))
+	for service, conn := range p.conns {
+		states[service] = conn.GetState().String()
+	}
+	return states
+}
+
+// Watch connects each service and keeps it connected until ctx is done:
+// connections gRPC lets go idle are reconnected, and failing ones are
+// retried with gRPC's backoff. State changes are logged.
+func (p *Pool) Watch(ctx context.Context) {
+	p.mu.Lock()
+	services := make([]string, 0, len(p.conns))
+	for service := range p.conns {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	conns := make([]*grpc.ClientConn, len(services))
+	for i, service := range services {
+		conns[i] = p.conns[service]
+	}
+	p.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for i, conn := range conns {
+		wg.Add(1)
+		go func(service string, conn *grpc.ClientConn) {
+			defer wg.Done()
+			p.watch(ctx, service, conn)
+		}(services[i], conn)
+	}
+	wg.Wait()
+}
+
+// watch keeps one service's connection connected until ctx is done
+func (p *Pool) watch(ctx context.Context, service string, conn *grpc.ClientConn) {
+	state := conn.GetState()
+	failing := false
+	for {
+		switch state {
+		case connectivity.Idle:
+			conn.Connect()
+		case connectivity.Shutdown:
+			return
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+
+		state = conn.GetState()
+		switch {
+		case state == connectivity.Ready:
+			p.logger.Info("Downstream service connected", "downstream", service)
+			failing = false
+		case state == connectivity.TransientFailure && !failing:
+			p.logger.Warn("Downstream service unreachable, reconnecting in the background", "downstream", service)
+			failing = true
+		}
+	}
+}
+
+// Close closes the pool's connections
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var errs []error
+	for service, conn := range p.conns {
+		errs = append(errs, conn.Close())
+		delete(p.conns, service)
+	}
+	return errors.Join(errs...)
+}
+
+// unreachable reports whether conn is failing to connect, so a call would
+// fail without reaching the service
+func unreachable(conn *grpc.ClientConn) bool {
+	return conn != nil && conn.GetState() == connectivity.TransientFailure
+}
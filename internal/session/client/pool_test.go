@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+)
+
+// deadAddress returns a local address nothing listens on
+func deadAddress(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return address
+}
+
+func TestPool(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	address := deadAddress(t)
+	pool := NewPool(logger, grpc.WithConnectParams(grpc.ConnectParams{
+		Backoff:           backoff.Config{BaseDelay: 50 * time.Millisecond, Multiplier: 1, MaxDelay: 50 * time.Millisecond},
+		MinConnectTimeout: time.Second,
+	}))
+	defer pool.Close()
+
+	// Clients are created while the services are down, sharing one connection
+	gameClient, err := NewPooledGameClient(pool, address, logger)
+	require.NoError(t, err)
+	sameGameClient, err := NewPooledGameClient(pool, address, logger)
+	require.NoError(t, err)
+	assert.Same(t, gameClient.conn, sameGameClient.conn)
+	authClient, err := NewPooledAuthClient(pool, address, logger)
+	require.NoError(t, err)
+	assert.NotSame(t, gameClient.conn, authClient.conn)
+
+	// Nothing is dialed until the pool is watched
+	assert.Equal(t, map[string]string{"auth": "IDLE", "game": "IDLE"}, pool.States())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go pool.Watch(ctx)
+	require.Eventually(t, func() bool {
+		return pool.States()["game"] == connectivity.TransientFailure.String()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Health checks fail at once while the connection is failing
+	start := time.Now()
+	assert.False(t, gameClient.IsHealthy(context.Background()))
+	assert.Less(t, time.Since(start), time.Second)
+
+	// The connection is made in the background once the service is up
+	listener, err := net.Listen("tcp", address)
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	go server.Serve(listener)
+	defer server.Stop()
+	require.Eventually(t, func() bool {
+		return pool.States()["game"] == connectivity.Ready.String()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Pooled clients leave the connection to the pool
+	require.NoError(t, gameClient.Close())
+	assert.Equal(t, connectivity.Ready, sameGameClient.conn.GetState())
+	require.NoError(t, pool.Close())
+	assert.Empty(t, pool.States())
+}
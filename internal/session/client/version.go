@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/dungeongate/pkg/buildinfo"
+)
+
+// Version asks the auth service for its build. Auth services that predate
+// the Version RPC answer Unimplemented.
+func (c *AuthClient) Version(ctx context.Context) (buildinfo.Info, error) {
+	resp, err := c.client.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		return buildinfo.Info{}, fmt.Errorf("failed to get auth service version: %w", err)
+	}
+	return buildinfo.FromResponse(resp), nil
+}
+
+// Version asks the game service for its build. Game services that predate
+// the Version RPC answer Unimplemented.
+func (c *GameClient) Version(ctx context.Context) (buildinfo.Info, error) {
+	resp, err := c.client.Version(ctx, &emptypb.Empty{})
+	if err != nil {
+		return buildinfo.Info{}, fmt.Errorf("failed to get game service version: %w", err)
+	}
+	return buildinfo.FromResponse(resp), nil
+}
@@ -1,11 +1,21 @@
 package session
 
-import "time"
+import (
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/dungeongate/internal/session/connection"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/config"
+)
 
 // Config represents the configuration for the Session Service
 type Config struct {
-	// Version information
-	Version string `yaml:"-"`
+	// Version information: Build is the session service's build, reported
+	// at /version and compared with the auth and game services'
+	Version string         `yaml:"-"`
+	Build   buildinfo.Info `yaml:"-"`
 
 	// Service addresses
 	GameService struct {
@@ -16,6 +26,25 @@ type Config struct {
 		Address string `yaml:"address" default:"localhost:8082"`
 	} `yaml:"auth_service"`
 
+	// DialOptions are added when connecting to the game and auth services,
+	// e.g. to reach them in-process in the all-in-one binary
+	DialOptions []grpc.DialOption `yaml:"-"`
+
+	// ServiceToken is sent to the game and auth services
+	ServiceToken string `yaml:"-"`
+
+	// GRPCAuthToken is required from callers of the session gRPC API; empty accepts all
+	GRPCAuthToken string `yaml:"-"`
+
+	// RateLimits limits requests to the session HTTP and gRPC APIs; nil leaves them unlimited
+	RateLimits *config.APIRateLimitConfig `yaml:"-"`
+
+	// Listen replaces the SSH, HTTP and gRPC TCP listeners with unix or systemd sockets; nil keeps TCP
+	Listen *config.ListenConfig `yaml:"-"`
+
+	// Jobs overrides the schedules of the background jobs, by job name
+	Jobs map[string]*config.JobConfig `yaml:"-"`
+
 	// Server configuration
 	SSH struct {
 		Address         string `yaml:"address" default:"0.0.0.0"`
@@ -46,15 +75,47 @@ type Config struct {
 	// Connection settings
 	ConnectionTimeout time.Duration `yaml:"connection_timeout" default:"30s"`
 	IdleTimeout       time.Duration `yaml:"idle_timeout" default:"1h"`
+	IdleWarning       time.Duration `yaml:"idle_warning" default:"1m"`
+
+	// SSH keepalive settings; a zero interval disables keepalive pings
+	KeepaliveInterval time.Duration `yaml:"keepalive_interval" default:"30s"`
+	KeepaliveCountMax int           `yaml:"keepalive_count_max" default:"3"`
+
+	// ProxyProtocol reads client addresses from a load balancer's PROXY header; nil disables it
+	ProxyProtocol *config.ProxyProtocolConfig `yaml:"-"`
+
+	// SSHPolicy restricts the SSH algorithms and authentication attempts; nil keeps the library defaults
+	SSHPolicy *config.SSHPolicyConfig `yaml:"-"`
+	// SSHMOTD templates the banner sent before authentication with live values; nil disables it
+	SSHMOTD *config.SSHMOTDConfig `yaml:"-"`
+
+	// BruteForce blocks addresses that keep failing authentication; nil disables it
+	BruteForce *config.BruteForceConfig `yaml:"-"`
+
+	// GeoIP tags connections with their country and applies country lists; nil disables it
+	GeoIP *config.GeoIPConfig `yaml:"-"`
+
+	// Registration drives the questions and welcome screens for new SSH accounts; nil keeps the plain form
+	Registration *config.RegistrationConfig `yaml:"-"`
+
+	// Realms are the communities hosted besides the default one, each with its own accounts, games and banners
+	Realms []*config.RealmConfig `yaml:"-"`
+
+	// FeatureFlags are the configured feature flags; those set at runtime are read from the auth service
+	FeatureFlags *config.FeatureFlagsConfig `yaml:"-"`
 
 	// Rate limiting
 	MaxConnectionsPerIP int           `yaml:"max_connections_per_ip" default:"10"`
 	RateLimitWindow     time.Duration `yaml:"rate_limit_window" default:"1m"`
 
 	// Terminal settings
-	DefaultTerminalType string `yaml:"default_terminal_type" default:"xterm-256color"`
-	MaxTerminalCols     int    `yaml:"max_terminal_cols" default:"200"`
-	MaxTerminalRows     int    `yaml:"max_terminal_rows" default:"100"`
+	DefaultTerminalType string   `yaml:"default_terminal_type" default:"xterm-256color"`
+	SupportedTerminals  []string `yaml:"supported_terminals"`
+	MaxTerminalCols     int      `yaml:"max_terminal_cols" default:"200"`
+	MaxTerminalRows     int      `yaml:"max_terminal_rows" default:"100"`
+
+	// InputFilter cleans player input per game; nil keeps the default escape key
+	InputFilter *config.InputFilterConfig `yaml:"-"`
 
 	// Streaming settings
 	SpectatorBufferSize int           `yaml:"spectator_buffer_size" default:"1024"`
@@ -71,14 +132,198 @@ type Config struct {
 	// Idle mode settings
 	IdleRetryInterval time.Duration `yaml:"idle_retry_interval" default:"5s"`
 
+	// Spectating settings
+	SpectatorPrompt bool `yaml:"spectator_prompt" default:"false"`
+	MaxSpectators   int  `yaml:"max_spectators" default:"0"` // Per game session, on top of each game's limit; 0 for none
+
+	// SpectatorTimeout stops spectators watching after this long without input; zero never does
+	SpectatorTimeout time.Duration `yaml:"spectator_timeout" default:"0"`
+
+	// EchoLatencySLO flags game sessions whose 95th percentile echo latency exceeds it
+	EchoLatencySLO time.Duration `yaml:"echo_latency_slo" default:"250ms"`
+
+	// WatchdogInterval is how often connection goroutines are sampled for leaks
+	WatchdogInterval time.Duration `yaml:"watchdog_interval" default:"1m"`
+
+	// PlayStateTimeouts disconnects players who stay in a play session state,
+	// such as the terms of service screen, longer than its timeout
+	PlayStateTimeouts map[string]time.Duration `yaml:"-"`
+
+	// Timeouts, from server.timeouts
+	MenuErrorPause   time.Duration `yaml:"menu_error_pause" default:"2s"`
+	ShutdownDrain    time.Duration `yaml:"shutdown_drain" default:"30s"`
+	HTTPReadTimeout  time.Duration `yaml:"http_read" default:"30s"`
+	HTTPWriteTimeout time.Duration `yaml:"http_write" default:"30s"`
+	GRPCDialTimeout  time.Duration `yaml:"grpc_dial" default:"20s"`
+
 	// Menu configuration
 	Menu struct {
 		Banners struct {
-			MainAnon           string `yaml:"main_anon" default:"./assets/banners/main_anon.txt"`
-			MainUser           string `yaml:"main_user" default:"./assets/banners/main_user.txt"`
-			MainAdmin          string `yaml:"main_admin" default:"./assets/banners/main_admin.txt"`
-			WatchMenu          string `yaml:"watch_menu" default:"./assets/banners/watch_menu.txt"`
-			ServiceUnavailable string `yaml:"service_unavailable" default:"./assets/banners/service_unavailable.txt"`
+			MainAnon           string            `yaml:"main_anon" default:"./assets/banners/main_anon.txt"`
+			MainUser           string            `yaml:"main_user" default:"./assets/banners/main_user.txt"`
+			MainAdmin          string            `yaml:"main_admin" default:"./assets/banners/main_admin.txt"`
+			WatchMenu          string            `yaml:"watch_menu" default:"./assets/banners/watch_menu.txt"`
+			ServiceUnavailable string            `yaml:"service_unavailable" default:"./assets/banners/service_unavailable.txt"`
+			ServerFull         string            `yaml:"server_full" default:"./assets/banners/server_full.txt"`
+			Credits            string            `yaml:"credits" default:"./assets/banners/credits.md"`
+			GameNews           map[string]string `yaml:"game_news"`
 		} `yaml:"banners"`
 	} `yaml:"menu"`
 }
+
+// NewConfig converts the session service configuration file format into the
+// service's runtime configuration
+func NewConfig(cfg *config.SessionServiceConfig, build buildinfo.Info) *Config {
+	sessionConfig := &Config{
+		GameService: struct {
+			Address string `yaml:"address" default:"localhost:50051"`
+		}{
+			Address: cfg.Services.GameService,
+		},
+		AuthService: struct {
+			Address string `yaml:"address" default:"localhost:8082"`
+		}{
+			Address: cfg.Services.AuthService,
+		},
+		SSH: struct {
+			Address         string `yaml:"address" default:"0.0.0.0"`
+			Port            int    `yaml:"port" default:"2222"`
+			IdleTimeout     string `yaml:"idle_timeout" default:"1h"`
+			HostKey         string `yaml:"host_key" default:""`
+			PasswordAuth    bool   `yaml:"password_auth" default:"true"`
+			PublicKeyAuth   bool   `yaml:"public_key_auth" default:"false"`
+			AllowAnonymous  bool   `yaml:"allow_anonymous" default:"true"`
+			AllowedUsername string `yaml:"allowed_username" default:"dungeongate"`
+			SSHPassword     string `yaml:"ssh_password" default:""`
+		}{
+			Address:         cfg.SSH.Host,
+			Port:            cfg.SSH.Port,
+			IdleTimeout:     cfg.SSH.IdleTimeout,
+			HostKey:         cfg.SSH.HostKeyPath,
+			PasswordAuth:    cfg.SSH.Auth.PasswordAuth,
+			PublicKeyAuth:   cfg.SSH.Auth.PublicKeyAuth,
+			AllowAnonymous:  cfg.SSH.Auth.AllowAnonymous,
+			AllowedUsername: cfg.SSH.Auth.AllowedUsername,
+			SSHPassword:     cfg.SSH.Auth.SSHPassword,
+		},
+		HTTP: struct {
+			Address string `yaml:"address" default:"0.0.0.0"`
+			Port    int    `yaml:"port" default:"8083"`
+		}{
+			Address: "0.0.0.0",
+			Port:    cfg.Server.Port,
+		},
+		GRPC: struct {
+			Address string `yaml:"address" default:"0.0.0.0"`
+			Port    int    `yaml:"port" default:"9093"`
+		}{
+			Address: "0.0.0.0",
+			Port:    cfg.Server.Port + 1000, // Use different port for gRPC
+		},
+		MaxConnections: 1000,
+		MaxPTYs:        500,
+		Version:        build.Version,
+		Build:          build,
+	}
+
+	if cfg.Server.MaxConnections > 0 {
+		sessionConfig.MaxConnections = cfg.Server.MaxConnections
+	}
+
+	// Set idle retry interval if available
+	if cfg.SessionManagement != nil && cfg.SessionManagement.Heartbeat != nil {
+		if interval, err := time.ParseDuration(cfg.SessionManagement.Heartbeat.IdleRetryInterval); err == nil {
+			sessionConfig.IdleRetryInterval = interval
+		} else {
+			sessionConfig.IdleRetryInterval = 5 * time.Second
+		}
+	} else {
+		sessionConfig.IdleRetryInterval = 5 * time.Second
+	}
+
+	// Close idle and dead SSH connections
+	sessionConfig.IdleTimeout = cfg.SSH.GetIdleTimeoutDuration()
+	sessionConfig.IdleWarning = cfg.SSH.GetIdleWarningDuration()
+	if cfg.SSH.Keepalive != nil && cfg.SSH.Keepalive.Enabled {
+		sessionConfig.KeepaliveInterval = cfg.SSH.Keepalive.GetIntervalDuration()
+		sessionConfig.KeepaliveCountMax = cfg.SSH.Keepalive.CountMax
+	}
+
+	if cfg.SSH.ProxyProtocol != nil && cfg.SSH.ProxyProtocol.Enabled {
+		sessionConfig.ProxyProtocol = cfg.SSH.ProxyProtocol
+	}
+	sessionConfig.SSHPolicy = cfg.SSH.Policy
+	if cfg.SSH.MOTD != nil && cfg.SSH.MOTD.Enabled {
+		sessionConfig.SSHMOTD = cfg.SSH.MOTD
+	}
+
+	if cfg.Security != nil && cfg.Security.BruteForceProtection != nil && cfg.Security.BruteForceProtection.Enabled {
+		sessionConfig.BruteForce = cfg.Security.BruteForceProtection
+	}
+	if cfg.Security != nil && cfg.Security.GeoIP != nil && cfg.Security.GeoIP.Enabled {
+		sessionConfig.GeoIP = cfg.Security.GeoIP
+	}
+	if cfg.User != nil {
+		sessionConfig.Registration = cfg.User.Registration
+	}
+	sessionConfig.Realms = cfg.Realms
+	sessionConfig.FeatureFlags = cfg.FeatureFlags
+
+	// How long menus, shutdown, the HTTP API and dialing other services wait
+	timeouts := cfg.Server.GetTimeouts()
+	sessionConfig.MenuErrorPause = timeouts.GetMenuErrorPause()
+	sessionConfig.ShutdownDrain = timeouts.GetShutdownDrain()
+	sessionConfig.HTTPReadTimeout = timeouts.GetHTTPRead()
+	sessionConfig.HTTPWriteTimeout = timeouts.GetHTTPWrite()
+	sessionConfig.GRPCDialTimeout = timeouts.GetGRPCDial()
+
+	// Service tokens for gRPC between services
+	sessionConfig.ServiceToken = cfg.Services.ServiceToken
+	sessionConfig.GRPCAuthToken = cfg.Server.AuthToken
+	sessionConfig.RateLimits = cfg.Server.RateLimits
+	sessionConfig.Listen = cfg.Server.Listen
+	sessionConfig.Jobs = cfg.Server.Jobs
+
+	if cfg.SessionManagement != nil && cfg.SessionManagement.Latency != nil {
+		sessionConfig.EchoLatencySLO = config.ParseDuration(cfg.SessionManagement.Latency.EchoSLO, connection.DefaultEchoLatencySLO)
+	}
+
+	if cfg.SessionManagement != nil && cfg.SessionManagement.Watchdog != nil {
+		sessionConfig.WatchdogInterval = config.ParseDuration(cfg.SessionManagement.Watchdog.Interval, connection.DefaultWatchdogInterval)
+	}
+
+	if cfg.SessionManagement != nil && cfg.SessionManagement.PlayStates != nil {
+		sessionConfig.PlayStateTimeouts = make(map[string]time.Duration, len(cfg.SessionManagement.PlayStates.Timeouts))
+		for state, timeout := range cfg.SessionManagement.PlayStates.Timeouts {
+			sessionConfig.PlayStateTimeouts[state] = config.ParseDuration(timeout, 0)
+		}
+	}
+
+	// Set spectating configuration if available
+	if cfg.SessionManagement != nil && cfg.SessionManagement.Spectating != nil {
+		sessionConfig.SpectatorPrompt = cfg.SessionManagement.Spectating.PromptAtStart
+		sessionConfig.MaxSpectators = cfg.SessionManagement.Spectating.MaxSpectatorsPerSession
+		sessionConfig.SpectatorTimeout = config.ParseDuration(cfg.SessionManagement.Spectating.SpectatorTimeout, 0)
+	}
+
+	// Set terminal negotiation policy if available
+	if cfg.SSH.Terminal != nil {
+		sessionConfig.SupportedTerminals = cfg.SSH.Terminal.SupportedTerminals
+		sessionConfig.DefaultTerminalType = cfg.SSH.Terminal.DefaultTerminal
+		sessionConfig.InputFilter = cfg.SSH.Terminal.InputFilter
+	}
+
+	// Set banner configuration if available
+	if cfg.Menu != nil && cfg.Menu.Banners != nil {
+		sessionConfig.Menu.Banners.MainAnon = cfg.Menu.Banners.MainAnon
+		sessionConfig.Menu.Banners.MainUser = cfg.Menu.Banners.MainUser
+		sessionConfig.Menu.Banners.MainAdmin = cfg.Menu.Banners.MainAdmin
+		sessionConfig.Menu.Banners.WatchMenu = cfg.Menu.Banners.WatchMenu
+		sessionConfig.Menu.Banners.ServiceUnavailable = cfg.Menu.Banners.ServiceUnavailable
+		sessionConfig.Menu.Banners.ServerFull = cfg.Menu.Banners.ServerFull
+		sessionConfig.Menu.Banners.Credits = cfg.Menu.Banners.Credits
+		sessionConfig.Menu.Banners.GameNews = cfg.Menu.Banners.GameNews
+	}
+
+	return sessionConfig
+}
@@ -0,0 +1,153 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// API key scopes offered to players, as understood by the auth service
+var (
+	readOnlyAPIKeyScopes  = []string{"games:read", "sessions:read", "dumplogs:read", "screenshots:read"}
+	readWriteAPIKeyScopes = []string{"games:read", "games:write", "sessions:read", "sessions:write", "dumplogs:read", "screenshots:read", "screenshots:write"}
+)
+
+// handleAPIKeys lists the player's API keys for the REST API and lets them
+// create and revoke keys
+func (p *MenuChoiceProcessor) handleAPIKeys(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	token := p.getAdminToken(sshConn)
+
+	for {
+		resp, err := p.authManager.authClient.ListAPIKeys(ctx, token)
+		if err != nil || !resp.Success {
+			p.logger.Error("Failed to list API keys", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("\r\nFailed to load your API keys. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== API Keys ===\r\n\r\n"))
+		channel.Write([]byte("API keys let scripts use the game REST API as you.\r\n\r\n"))
+		p.writeAPIKeys(channel, resp.ApiKeys)
+		channel.Write([]byte("\r\n  [c] Create a key\r\n"))
+		channel.Write([]byte("  [x] Revoke a key\r\n"))
+		channel.Write([]byte("  [q] Back\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		switch strings.ToLower(string(buffer[0])) {
+		case "c":
+			err = p.handleCreateAPIKey(ctx, channel, userInfo, token)
+		case "x":
+			err = p.handleRevokeAPIKey(ctx, channel, userInfo, token)
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+	}
+}
+
+// writeAPIKeys lists API keys with their scopes and last use
+func (p *MenuChoiceProcessor) writeAPIKeys(channel ssh.Channel, keys []*authv1.APIKey) {
+	if len(keys) == 0 {
+		channel.Write([]byte("You have no API keys.\r\n"))
+		return
+	}
+
+	channel.Write([]byte(fmt.Sprintf("%4s  %-20s %-12s %-10s %s\r\n", "ID", "Name", "Key", "Last used", "Scopes")))
+	for _, key := range keys {
+		lastUsed := "never"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.AsTime().Format("2006-01-02")
+		}
+		scopes := strings.Join(key.Scopes, ",")
+		if key.RevokedAt != nil {
+			scopes = "revoked " + key.RevokedAt.AsTime().Format("2006-01-02")
+		}
+		channel.Write([]byte(fmt.Sprintf("%4d  %-20s %-12s %-10s %s\r\n",
+			key.Id, key.Name, key.Prefix+"…", lastUsed, scopes)))
+	}
+}
+
+// handleCreateAPIKey creates a key and shows it to the player, once
+func (p *MenuChoiceProcessor) handleCreateAPIKey(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	name, err := p.promptForUsername(ctx, channel, "Key name")
+	if err != nil || name == "" {
+		return err
+	}
+
+	channel.Write([]byte("Allow the key to start and change games and sessions? (y/N): "))
+	write, err := p.authManager.readYesNo(ctx, channel)
+	if err != nil {
+		return err
+	}
+	channel.Write([]byte("\r\n"))
+	scopes := readOnlyAPIKeyScopes
+	if write {
+		scopes = readWriteAPIKeyScopes
+	}
+
+	resp, err := p.authManager.authClient.CreateAPIKey(ctx, token, name, scopes)
+	if err != nil {
+		p.logger.Error("Failed to create API key", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("✓ Created %s\r\n\r\n", name)))
+	channel.Write([]byte(fmt.Sprintf("  %s\r\n\r\n", resp.Key)))
+	channel.Write([]byte("Copy the key now: it will not be shown again.\r\n"))
+	channel.Write([]byte("Send it as an \"X-API-Key\" header or as a bearer token.\r\n"))
+	return nil
+}
+
+// handleRevokeAPIKey revokes a key by ID
+func (p *MenuChoiceProcessor) handleRevokeAPIKey(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	input, err := p.promptForUsername(ctx, channel, "Key ID")
+	if err != nil || input == "" {
+		return err
+	}
+	keyID, err := strconv.Atoi(input)
+	if err != nil {
+		channel.Write([]byte("✗ Key IDs are numbers\r\n"))
+		return nil
+	}
+
+	resp, err := p.authManager.authClient.RevokeAPIKey(ctx, token, int32(keyID))
+	if err != nil {
+		p.logger.Error("Failed to revoke API key", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", resp.Message)))
+	return nil
+}
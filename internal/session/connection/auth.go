@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
-	"time"
 
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/menu"
 	"github.com/dungeongate/internal/session/terminal"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -21,6 +22,8 @@ type SSHAuthHandler struct {
 	envVars         map[string]string // Store environment variables from SSH connection
 	allowedUsername string            // Only allow connections from this username
 	sshPassword     string            // SSH password for the allowed username (config-based)
+	blocker         *IPBlocker        // Blocks addresses that keep failing authentication
+	offered         *offeredKeys      // Public keys offered by handshakes, naming their devices
 }
 
 // NewSSHAuthHandler creates a new SSH auth handler
@@ -31,6 +34,7 @@ func NewSSHAuthHandler(authClient *client.AuthClient, logger *slog.Logger, allow
 		envVars:         make(map[string]string),
 		allowedUsername: allowedUsername,
 		sshPassword:     sshPassword,
+		offered:         &offeredKeys{keys: make(map[string]offeredKey)},
 	}
 }
 
@@ -47,6 +51,27 @@ func (a *SSHAuthHandler) isUsernameAllowed(username string) bool {
 // PasswordCallback handles password authentication
 func (a *SSHAuthHandler) PasswordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
 	username := conn.User()
+	clientIP := getIPFromAddr(conn.RemoteAddr())
+
+	// A blocked address gets no further attempts, unless it is challenged
+	if _, blocked := a.blocker.IsBlocked(clientIP); blocked {
+		if a.blocker.Challenges() {
+			return nil, a.challenge(conn, username, func(challengeToken string) (*ssh.Permissions, error) {
+				return a.passwordLogin(conn, password, challengeToken)
+			})
+		}
+		a.logger.Warn("SSH authentication rejected: address blocked", "username", username, "client_ip", clientIP)
+		return nil, fmt.Errorf("authentication failed")
+	}
+
+	return a.passwordLogin(conn, password, "")
+}
+
+// passwordLogin checks a password login, with the token of the challenge
+// it passed if any
+func (a *SSHAuthHandler) passwordLogin(conn ssh.ConnMetadata, password []byte, challengeToken string) (*ssh.Permissions, error) {
+	username := conn.User()
+	clientIP := getIPFromAddr(conn.RemoteAddr())
 
 	// Check if username is allowed
 	if !a.isUsernameAllowed(username) {
@@ -71,13 +96,15 @@ func (a *SSHAuthHandler) PasswordCallback(conn ssh.ConnMetadata, password []byte
 			return permissions, nil
 		} else {
 			a.logger.Warn("SSH authentication failed: incorrect password", "username", username)
+			a.blocker.RecordFailure(clientIP, FailureSSHAuth)
 			return nil, fmt.Errorf("authentication failed")
 		}
 	}
 
 	// Fallback to DungeonGate auth service authentication
 	ctx := context.Background()
-	resp, err := a.authClient.Login(ctx, username, string(password))
+	device := clientDevice(conn, a.offered.fingerprint(conn))
+	resp, err := a.authClient.LoginWithChallenge(ctx, username, string(password), clientIP, device, challengeToken)
 	if err != nil {
 		a.logger.Warn("DungeonGate auth failed", "username", username, "error", err)
 		return nil, fmt.Errorf("authentication failed")
@@ -88,8 +115,17 @@ func (a *SSHAuthHandler) PasswordCallback(conn ssh.ConnMetadata, password []byte
 		a.logger.Warn("DungeonGate auth failed: empty response", "username", username)
 		return nil, fmt.Errorf("authentication failed")
 	}
+
+	// An account locked out after failed logins is challenged, as the
+	// failures may be an attacker's
+	if resp.ChallengeRequired && challengeToken == "" && a.blocker.Challenges() {
+		return nil, a.challenge(conn, username, func(challengeToken string) (*ssh.Permissions, error) {
+			return a.passwordLogin(conn, password, challengeToken)
+		})
+	}
 	if resp.User == nil {
 		a.logger.Warn("DungeonGate auth failed: empty user in response", "username", username)
+		a.blocker.RecordFailure(clientIP, FailureSSHAuth)
 		return nil, fmt.Errorf("authentication failed")
 	}
 
@@ -124,7 +160,9 @@ func (a *SSHAuthHandler) PublicKeyCallback(conn ssh.ConnMetadata, key ssh.Public
 	}
 
 	// For now, reject public key authentication
-	// In a real implementation, we'd validate the key against stored public keys
+	// In a real implementation, we'd validate the key against stored public keys.
+	// The key still names the device the password login that follows comes from.
+	a.offered.remember(conn, key)
 	a.logger.Debug("Public key authentication attempted", "username", username)
 	return nil, fmt.Errorf("public key authentication not supported")
 }
@@ -136,7 +174,7 @@ func (a *SSHAuthHandler) SetEnvironmentVariable(name, value string) {
 }
 
 // authenticateWithDGAUTH attempts authentication using DGAUTH environment variable
-func (a *SSHAuthHandler) authenticateWithDGAUTH(ctx context.Context) (*ssh.Permissions, error) {
+func (a *SSHAuthHandler) authenticateWithDGAUTH(ctx context.Context, clientIP string, device *authv1.ClientDevice) (*ssh.Permissions, error) {
 	dgauth, exists := a.envVars["DGAUTH"]
 	if !exists {
 		return nil, fmt.Errorf("DGAUTH environment variable not found")
@@ -156,9 +194,14 @@ func (a *SSHAuthHandler) authenticateWithDGAUTH(ctx context.Context) (*ssh.Permi
 		a.logger.Warn("Empty username or password in DGAUTH")
 		return nil, fmt.Errorf("username and password cannot be empty")
 	}
+	username, ok := realmUsername(ctx, username)
+	if !ok {
+		a.logger.Warn("DGAUTH login rejected: account belongs to another realm", "username", username)
+		return nil, fmt.Errorf("authentication failed")
+	}
 
 	// Authenticate with auth service
-	resp, err := a.authClient.Login(ctx, username, password)
+	resp, err := a.authClient.Login(ctx, username, password, clientIP, device)
 	if err != nil {
 		a.logger.Warn("DGAUTH login failed", "username", username, "error", err)
 		return nil, fmt.Errorf("authentication failed")
@@ -171,6 +214,7 @@ func (a *SSHAuthHandler) authenticateWithDGAUTH(ctx context.Context) (*ssh.Permi
 	}
 	if resp.User == nil {
 		a.logger.Warn("DGAUTH login failed: empty user in response", "username", username)
+		a.blocker.RecordFailure(clientIP, FailureLogin)
 		return nil, fmt.Errorf("authentication failed")
 	}
 
@@ -192,6 +236,13 @@ func (a *SSHAuthHandler) authenticateWithDGAUTH(ctx context.Context) (*ssh.Permi
 type UserAuthManager struct {
 	authClient *client.AuthClient
 	logger     *slog.Logger
+	blocker    *IPBlocker
+	geo        *GeoFilter
+
+	// registration configures the registration form and welcome screens; nil keeps the plain form
+	registration *config.RegistrationConfig
+
+	pause *messagePause
 }
 
 // NewUserAuthManager creates a new user auth manager
@@ -238,7 +289,7 @@ func (m *UserAuthManager) HandleLogin(ctx context.Context, channel ssh.Channel,
 	if err != nil {
 		if err.Error() == "user cancelled" {
 			channel.Write([]byte("\r\nLogin cancelled.\r\n"))
-			time.Sleep(1 * time.Second)
+			m.pause.brief()
 			return nil
 		}
 		return err
@@ -250,19 +301,49 @@ func (m *UserAuthManager) HandleLogin(ctx context.Context, channel ssh.Channel,
 	if err != nil {
 		if err.Error() == "user cancelled" {
 			channel.Write([]byte("\r\nLogin cancelled.\r\n"))
-			time.Sleep(1 * time.Second)
+			m.pause.brief()
 			return nil
 		}
 		return err
 	}
 
-	// Attempt login with auth service
-	resp, err := m.authClient.Login(ctx, username, password)
+	// Accounts of other realms cannot log in here
+	clientIP := getIPFromAddr(sshConn.RemoteAddr())
+	username, ok := realmUsername(ctx, username)
+	if !ok {
+		m.logger.Warn("Login rejected: account belongs to another realm", "username", username, "client_ip", clientIP)
+		channel.Write([]byte("\r\nLogin failed. Please check your credentials.\r\n"))
+		m.pause.error()
+		return nil
+	}
+
+	// Attempt login with auth service, past a lockout when the connection
+	// passed a challenge during the handshake
+	var challengeToken string
+	if sshConn.Permissions != nil {
+		challengeToken = sshConn.Permissions.Extensions[challengeTokenExtension]
+		delete(sshConn.Permissions.Extensions, challengeTokenExtension)
+	}
+	resp, err := m.authClient.LoginWithChallenge(ctx, username, password, clientIP, connectionDevice(sshConn), challengeToken)
 	if err != nil {
 		m.logger.Warn("Login failed", "username", username, "error", err)
 		channel.Write([]byte("\r\nLogin failed. Please check your credentials.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		m.pause.error()
+		return nil
+	}
+
+	// Rejected credentials count towards blocking the address
+	if resp != nil && !resp.Success {
+		m.logger.Warn("Login rejected", "username", username, "error_code", resp.ErrorCode, "client_ip", clientIP, "country", m.geo.Country(clientIP))
+		if m.blocker.RecordFailure(clientIP, FailureLogin) {
+			channel.Write([]byte("\r\nToo many failed attempts. Disconnecting.\r\n"))
+			m.pause.brief()
+			sshConn.Close()
+			return nil
+		}
+		channel.Write([]byte("\r\nLogin failed. Please check your credentials.\r\n"))
+		m.pause.error()
 		return nil
 	}
 
@@ -271,7 +352,7 @@ func (m *UserAuthManager) HandleLogin(ctx context.Context, channel ssh.Channel,
 		m.logger.Error("Invalid login response", "username", username)
 		channel.Write([]byte("\r\nLogin failed. Server error.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		m.pause.error()
 		return nil
 	}
 
@@ -284,11 +365,11 @@ func (m *UserAuthManager) HandleLogin(ctx context.Context, channel ssh.Channel,
 	}
 	sshConn.Permissions.Extensions["access_token"] = resp.AccessToken
 
-	m.logger.Info("User logged in successfully", "username", username, "user_id", resp.User.Id)
-	channel.Write([]byte("\r\nLogin successful! Welcome back to the gate, " + resp.User.Username + "...\r\n"))
+	m.logger.Info("User logged in successfully", "username", username, "user_id", resp.User.Id, "client_ip", clientIP, "country", m.geo.Country(clientIP))
+	channel.Write([]byte("\r\nLogin successful! Welcome back to the gate, " + localUsername(ctx, resp.User.Username) + "...\r\n"))
 
 	// Brief pause to show success message
-	time.Sleep(3 * time.Second)
+	m.pause.long()
 
 	return nil
 }
@@ -308,19 +389,27 @@ func (m *UserAuthManager) HandleRegister(ctx context.Context, channel ssh.Channe
 	if err != nil {
 		if err.Error() == "user cancelled" {
 			channel.Write([]byte("\r\nRegistration cancelled.\r\n"))
-			time.Sleep(1 * time.Second)
+			m.pause.brief()
 			return nil
 		}
 		return err
 	}
 
+	// New accounts are created in the connection's realm, and the default
+	// realm cannot take names from the others
+	username, ok := realmUsername(ctx, username)
+	if !ok {
+		channel.Write([]byte("\r\nThat username is not available.\r\n"))
+		return m.handleRegistrationRetry(ctx, channel, "username not available")
+	}
+
 	// Get password
 	channel.Write([]byte("Choose a password: "))
 	password, err := m.readPasswordWithTerminal(ctx, channel)
 	if err != nil {
 		if err.Error() == "user cancelled" {
 			channel.Write([]byte("\r\nRegistration cancelled.\r\n"))
-			time.Sleep(1 * time.Second)
+			m.pause.brief()
 			return nil
 		}
 		return err
@@ -332,7 +421,7 @@ func (m *UserAuthManager) HandleRegister(ctx context.Context, channel ssh.Channe
 	if err != nil {
 		if err.Error() == "user cancelled" {
 			channel.Write([]byte("\r\nRegistration cancelled.\r\n"))
-			time.Sleep(1 * time.Second)
+			m.pause.brief()
 			return nil
 		}
 		return err
@@ -344,15 +433,21 @@ func (m *UserAuthManager) HandleRegister(ctx context.Context, channel ssh.Channe
 		return m.handleRegistrationRetry(ctx, channel, "password mismatch")
 	}
 
-	// Get email (optional)
-	channel.Write([]byte("Email (optional - leave blank to skip): "))
-	email, err := m.readOptionalLineWithTerminal(ctx, channel)
+	// Get email, optional unless the registration config requires it
+	email, err := m.readRegistrationEmail(ctx, channel)
 	if err != nil {
 		return err
 	}
 
+	// Terms are accepted before the account exists, so declining leaves nothing behind
+	termsVersion, accepted, err := m.acceptTerms(ctx, channel)
+	if err != nil || !accepted {
+		return err
+	}
+
 	// Attempt registration with auth service
-	resp, err := m.authClient.Register(ctx, username, password, email)
+	clientIP := getIPFromAddr(sshConn.RemoteAddr())
+	resp, err := m.authClient.Register(ctx, username, password, email, clientIP, termsVersion)
 	if err != nil {
 		m.logger.Warn("Registration failed", "username", username, "error", err)
 		channel.Write([]byte("\r\nRegistration failed. Please try again later.\r\n"))
@@ -362,6 +457,14 @@ func (m *UserAuthManager) HandleRegister(ctx context.Context, channel ssh.Channe
 	if !resp.Success {
 		m.logger.Warn("Registration rejected", "username", username, "error", resp.Error, "error_code", resp.ErrorCode)
 
+		// Repeatedly registering taken usernames is how accounts get enumerated
+		if apierror.Code(resp.ErrorCode) == apierror.UsernameTaken && m.blocker.RecordFailure(clientIP, FailureRegister) {
+			channel.Write([]byte("\r\nToo many failed attempts. Disconnecting.\r\n"))
+			m.pause.brief()
+			sshConn.Close()
+			return nil
+		}
+
 		// Show detailed validation message
 		detailedMessage := m.getDetailedValidationMessage(resp.ErrorCode, resp.Error)
 		channel.Write([]byte("\r\nRegistration failed:\r\n" + detailedMessage))
@@ -377,14 +480,14 @@ func (m *UserAuthManager) HandleRegister(ctx context.Context, channel ssh.Channe
 	}
 	sshConn.Permissions.Extensions["access_token"] = resp.AccessToken
 
-	m.logger.Info("User registered successfully", "username", username, "user_id", resp.User.Id)
-	channel.Write([]byte("\r\nRegistration successful! Welcome, " + resp.User.Username + "!\r\n"))
+	m.logger.Info("User registered successfully", "username", username, "user_id", resp.User.Id, "client_ip", clientIP, "country", m.geo.Country(clientIP))
+	channel.Write([]byte("\r\nRegistration successful! Welcome, " + localUsername(ctx, resp.User.Username) + "!\r\n"))
 	channel.Write([]byte("You are now logged in.\r\n"))
 
 	// Brief pause to show success message
-	time.Sleep(1 * time.Second)
+	m.pause.brief()
 
-	return nil
+	return m.runWelcome(ctx, channel, resp.User, email, sshConn)
 }
 
 // HandleRequiredPasswordChange handles the forced password change for one-time passwords
@@ -475,7 +578,7 @@ func (m *UserAuthManager) HandleRequiredPasswordChange(ctx context.Context, chan
 		m.logger.Info("Password changed successfully for one-time password user", "username", user.Username)
 
 		// Brief pause to show success message
-		time.Sleep(2 * time.Second)
+		m.pause.error()
 
 		return nil, nil // Return to main menu loop to refresh user info
 	}
@@ -486,23 +589,23 @@ func (m *UserAuthManager) getDetailedValidationMessage(errorCode, errorMessage s
 	// For debugging: log the actual error code and message
 	m.logger.Debug("Registration validation error", "error_code", errorCode, "error_message", errorMessage)
 
-	switch errorCode {
-	case "invalid_password":
+	switch apierror.Code(errorCode) {
+	case apierror.InvalidPassword:
 		return "Password validation failed:\r\n" +
 			"  • Password must be at least 6 characters long\r\n" +
 			"  • Please choose a stronger password\r\n"
-	case "username_taken":
+	case apierror.UsernameTaken:
 		return "Username is already taken. Please choose a different username.\r\n"
-	case "invalid_username":
+	case apierror.InvalidUsername:
 		return "Username can only contain letters, numbers, and underscores.\r\n"
-	case "invalid_email":
+	case apierror.InvalidEmail:
 		return "Invalid email format. Please enter a valid email address or leave blank.\r\n"
-	case "invalid_request":
+	case apierror.InvalidRequest:
 		if strings.Contains(errorMessage, "Username") {
 			return "Username and password are required fields.\r\n"
 		}
 		return errorMessage + "\r\n"
-	case "registration_failed":
+	case apierror.RegistrationFailed:
 		// This might be the generic error we're seeing
 		if strings.Contains(errorMessage, "Validation failed") {
 			return "Registration validation failed. Please check your input:\r\n" +
@@ -551,12 +654,12 @@ func (m *UserAuthManager) handleRegistrationRetry(ctx context.Context, channel s
 			case "r":
 				// Retry registration - this will cause the menu to call handleRegister again
 				channel.Write([]byte("\r\n\r\nRetrying registration...\r\n"))
-				time.Sleep(1 * time.Second)
+				m.pause.brief()
 				return fmt.Errorf("retry_register") // Special error to indicate retry
 			case "m":
 				// Return to main menu
 				channel.Write([]byte("\r\n\r\nReturning to main menu...\r\n"))
-				time.Sleep(1 * time.Second)
+				m.pause.brief()
 				return nil
 			case "q":
 				// Quit
@@ -7,9 +7,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/dungeongate/internal/session/banner"
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/pkg/banner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -37,7 +37,7 @@ func TestAuthClientIntegration(t *testing.T) {
 		password := "testpass123"
 		email := "test@example.com"
 
-		resp, err := authClient.Register(ctx, username, password, email)
+		resp, err := authClient.Register(ctx, username, password, email, "127.0.0.1", "")
 
 		if err != nil {
 			t.Logf("Registration failed (expected if no auth service): %v", err)
@@ -52,7 +52,7 @@ func TestAuthClientIntegration(t *testing.T) {
 		assert.Equal(t, email, resp.User.Email)
 
 		// Test login with the same credentials
-		loginResp, err := authClient.Login(ctx, username, password)
+		loginResp, err := authClient.Login(ctx, username, password, "127.0.0.1", "")
 		require.NoError(t, err)
 		require.NotNil(t, loginResp)
 		assert.True(t, loginResp.Success, "Login should succeed")
@@ -66,7 +66,7 @@ func TestAuthClientIntegration(t *testing.T) {
 		email := "dup@example.com"
 
 		// First registration should succeed
-		resp1, err := authClient.Register(ctx, username, password, email)
+		resp1, err := authClient.Register(ctx, username, password, email, "127.0.0.1", "")
 		if err != nil {
 			t.Skip("Auth service not available")
 		}
@@ -74,7 +74,7 @@ func TestAuthClientIntegration(t *testing.T) {
 		require.True(t, resp1.Success)
 
 		// Second registration with same username should fail
-		resp2, err := authClient.Register(ctx, username, password, "different@example.com")
+		resp2, err := authClient.Register(ctx, username, password, "different@example.com", "127.0.0.1", "")
 		require.NoError(t, err) // No error, but response should indicate failure
 		require.NotNil(t, resp2)
 		assert.False(t, resp2.Success, "Duplicate registration should fail")
@@ -83,7 +83,7 @@ func TestAuthClientIntegration(t *testing.T) {
 
 	t.Run("InvalidCredentialsLogin", func(t *testing.T) {
 		// Try to login with non-existent user
-		loginResp, err := authClient.Login(ctx, "nonexistent", "wrongpass")
+		loginResp, err := authClient.Login(ctx, "nonexistent", "wrongpass", "127.0.0.1", "")
 
 		if err != nil {
 			// Service level error is acceptable
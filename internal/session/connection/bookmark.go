@@ -0,0 +1,51 @@
+package connection
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// bookmarkAdded describes a bookmark just dropped
+func bookmarkAdded(bookmark *gamev2.Bookmark) []string {
+	return []string{
+		fmt.Sprintf("Bookmarked %s into the recording.", formatOffset(bookmark.OffsetMs)),
+		"",
+		"Find it again under Last games once the game is over.",
+	}
+}
+
+// bookmarkFailed explains why a bookmark was not dropped
+func bookmarkFailed(err error) []string {
+	switch apierror.CodeOf(err) {
+	case apierror.RateLimited:
+		return []string{"This game has as many bookmarks as it keeps."}
+	case apierror.InvalidRequest:
+		return []string{"This game is not recorded, so there is nothing to bookmark."}
+	}
+	message, _ := friendlyError(err, "The bookmark could not be saved. Please try again later.")
+	return []string{message}
+}
+
+// bookmarkLine describes a bookmark for a list, e.g.
+// " 1. 1:02:03  watcher  that was amazing"
+func bookmarkLine(number int, bookmark *gamev2.Bookmark) string {
+	line := fmt.Sprintf("%2d. %8s  %-12s %s", number, formatOffset(bookmark.OffsetMs), bookmark.CreatedByUsername, bookmark.Note)
+	return strings.TrimRight(line, " ")
+}
+
+// formatOffset formats a position in a recording as h:mm:ss, or m:ss for
+// the first hour
+func formatOffset(ms int64) string {
+	offset := time.Duration(ms) * time.Millisecond
+	hours := int(offset.Hours())
+	minutes := int(offset.Minutes()) % 60
+	seconds := int(offset.Seconds()) % 60
+	if hours > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%d:%02d", minutes, seconds)
+}
@@ -0,0 +1,24 @@
+package connection
+
+import (
+	"testing"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "0:00", formatOffset(0))
+	assert.Equal(t, "1:30", formatOffset(90_500))
+	assert.Equal(t, "59:59", formatOffset(3_599_000))
+	assert.Equal(t, "1:02:03", formatOffset(3_723_000))
+}
+
+func TestBookmarkLine(t *testing.T) {
+	bookmark := &gamev2.Bookmark{OffsetMs: 3_723_000, CreatedByUsername: "watcher", Note: "that was amazing"}
+	assert.Equal(t, " 1.  1:02:03  watcher      that was amazing", bookmarkLine(1, bookmark))
+
+	// Without a note there is no trailing space
+	bookmark = &gamev2.Bookmark{OffsetMs: 90_000, CreatedByUsername: "player"}
+	assert.Equal(t, " 2.     1:30  player", bookmarkLine(2, bookmark))
+}
@@ -0,0 +1,73 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// challengeTokenExtension holds, in a connection's permissions, the token of
+// the challenge an anonymous connection passed, for the menu login that
+// follows
+const challengeTokenExtension = "challenge_token"
+
+// challengeInstruction introduces the challenge to the player
+const challengeInstruction = "Too many failed logins came from your address or for your account.\n" +
+	"Answer this question to continue."
+
+// challenge returns the error that makes a client that tripped brute force
+// protection answer a question from the auth service, over
+// keyboard-interactive authentication, before passed finishes authenticating
+// it with the token of the passed challenge. Wrong answers count towards
+// blocking the address.
+func (a *SSHAuthHandler) challenge(conn ssh.ConnMetadata, username string, passed func(challengeToken string) (*ssh.Permissions, error)) error {
+	clientIP := getIPFromAddr(conn.RemoteAddr())
+	a.logger.Info("Challenging SSH authentication", "username", username, "client_ip", clientIP)
+
+	return &ssh.PartialSuccessError{Next: ssh.ServerAuthCallbacks{
+		KeyboardInteractiveCallback: func(_ ssh.ConnMetadata, client ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			ctx := context.Background()
+			issued, err := a.authClient.IssueChallenge(ctx, clientIP, username)
+			if err != nil || !issued.Success {
+				a.logger.Warn("Failed to issue challenge", "client_ip", clientIP, "error", err)
+				return nil, fmt.Errorf("authentication failed")
+			}
+
+			answers, err := client("", challengeInstruction, []string{issued.Question + " "}, []bool{true})
+			if err != nil || len(answers) != 1 {
+				return nil, fmt.Errorf("authentication failed")
+			}
+
+			answered, err := a.authClient.AnswerChallenge(ctx, issued.ChallengeId, answers[0], clientIP)
+			if err != nil || !answered.Passed {
+				a.logger.Warn("SSH challenge failed", "username", username, "client_ip", clientIP, "error", err)
+				a.blocker.RecordFailure(clientIP, FailureChallenge)
+				return nil, fmt.Errorf("authentication failed")
+			}
+
+			a.logger.Info("SSH challenge passed", "username", username, "client_ip", clientIP)
+			return passed(answered.ChallengeToken)
+		},
+	}}
+}
+
+// NoClientAuthCallback admits anonymous connections, challenging those from
+// blocked addresses first. The token of the passed challenge is kept with
+// the connection so the player can log in past a lockout from the menu.
+func (a *SSHAuthHandler) NoClientAuthCallback(conn ssh.ConnMetadata) (*ssh.Permissions, error) {
+	clientIP := getIPFromAddr(conn.RemoteAddr())
+	if _, blocked := a.blocker.IsBlocked(clientIP); !blocked {
+		return nil, nil
+	}
+	if !a.blocker.Challenges() {
+		a.logger.Warn("SSH connection rejected: address blocked", "client_ip", clientIP)
+		return nil, fmt.Errorf("authentication failed")
+	}
+
+	return nil, a.challenge(conn, "", func(challengeToken string) (*ssh.Permissions, error) {
+		return &ssh.Permissions{
+			Extensions: map[string]string{challengeTokenExtension: challengeToken},
+		}, nil
+	})
+}
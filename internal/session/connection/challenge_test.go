@@ -0,0 +1,48 @@
+package connection
+
+import (
+	"io"
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// addressMetadata is the metadata of a handshake from an address
+type addressMetadata struct {
+	ssh.ConnMetadata
+	addr net.Addr
+}
+
+func (m addressMetadata) RemoteAddr() net.Addr { return m.addr }
+
+func TestNoClientAuthCallback_ChallengesBlockedAddresses(t *testing.T) {
+	blocker, _ := newTestIPBlocker(t, &config.BruteForceConfig{Enabled: true, MaxFailedAttempts: 1, Challenge: true})
+	handler := NewSSHAuthHandler(nil, slog.New(slog.NewTextHandler(io.Discard, nil)), "", "")
+	handler.blocker = blocker
+	conn := addressMetadata{addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 50000}}
+
+	permissions, err := handler.NoClientAuthCallback(conn)
+	assert.NoError(t, err, "addresses that are not blocked are admitted")
+	assert.Nil(t, permissions)
+
+	require.True(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	assert.True(t, blocker.Challenges())
+	assert.False(t, blocker.RefuseConnection("192.0.2.1"), "challenged addresses reach the handshake")
+
+	_, err = handler.NoClientAuthCallback(conn)
+	var partial *ssh.PartialSuccessError
+	require.ErrorAs(t, err, &partial)
+	assert.NotNil(t, partial.Next.KeyboardInteractiveCallback)
+	assert.Nil(t, partial.Next.PasswordCallback, "only the challenge is offered")
+
+	blocker.challenge = false
+	_, err = handler.NoClientAuthCallback(conn)
+	assert.Error(t, err)
+	assert.NotErrorAs(t, err, &partial)
+	assert.True(t, blocker.RefuseConnection("192.0.2.1"))
+}
@@ -0,0 +1,82 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// versionQueryTimeout bounds asking a service for its version, so one that is
+// down does not hold up the credits
+const versionQueryTimeout = 2 * time.Second
+
+// showCredits pages through the credits, followed by the version of each
+// service asked for as the screen opens
+func (p *MenuChoiceProcessor) showCredits(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, terminalRows int) error {
+	lines := append(p.creditLines(ctx, userInfo), "")
+	lines = append(lines, p.serviceVersionLines(ctx)...)
+	return pageLines(ctx, channel, "Credits", lines, terminalRows)
+}
+
+// writeCredits writes the credits as players leave, unless they chose to
+// skip them; they stay on the player's screen after the connection closes
+func (p *MenuChoiceProcessor) writeCredits(ctx context.Context, channel ssh.Channel, userInfo *authv1.User) {
+	channel.Write([]byte("\033[2J\033[H"))
+	for _, line := range p.creditLines(ctx, userInfo) {
+		channel.Write([]byte(line + "\r\n"))
+	}
+}
+
+// creditLines returns the rendered credits, one line each
+func (p *MenuChoiceProcessor) creditLines(ctx context.Context, userInfo *authv1.User) []string {
+	credits, err := p.menuHandler.RenderCredits(ctx, userInfo.GetUsername())
+	if err != nil {
+		p.logger.Warn("Failed to render credits", "error", err)
+		return []string{"DungeonGate - Terminal Game Platform"}
+	}
+	return strings.Split(strings.TrimRight(credits, "\r\n"), "\r\n")
+}
+
+// serviceVersionLines lists the version of the session service and those the
+// auth and game services report
+func (p *MenuChoiceProcessor) serviceVersionLines(ctx context.Context) []string {
+	authVersion := "unavailable"
+	authCtx, cancel := context.WithTimeout(ctx, versionQueryTimeout)
+	if version, err := p.authManager.authClient.ServiceVersion(authCtx); err != nil {
+		p.logger.Debug("Failed to get auth service version", "error", err)
+	} else if version != "" {
+		authVersion = version
+	}
+	cancel()
+
+	// A game service that predates version reporting gives none
+	gameCtx, cancel := context.WithTimeout(ctx, versionQueryTimeout)
+	gameVersion := p.gameIOHandler.gameClient.Capabilities(gameCtx).ServerVersion
+	cancel()
+	if gameVersion == "" {
+		gameVersion = "unknown"
+	}
+
+	return []string{
+		"\033[1mVersions\033[0m",
+		fmt.Sprintf("  Session service  %s", p.menuHandler.Version()),
+		fmt.Sprintf("  Auth service     %s", authVersion),
+		fmt.Sprintf("  Game service     %s", gameVersion),
+	}
+}
+
+// confirmQuit asks a player who wants to be asked whether they really mean
+// to quit. A connection that closes while asking is taken as a yes.
+func confirmQuit(channel ssh.Channel) bool {
+	channel.Write([]byte("\r\nReally quit? [y/N] "))
+	buffer := make([]byte, 1)
+	if _, err := channel.Read(buffer); err != nil {
+		return true
+	}
+	channel.Write([]byte("\r\n"))
+	return buffer[0] == 'y' || buffer[0] == 'Y'
+}
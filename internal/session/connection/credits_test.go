@@ -0,0 +1,34 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteCredits(t *testing.T) {
+	bm := banner.NewBannerManager(&banner.BannerConfig{}, "test")
+	bm.SetOverride(banner.NameCredits, "# Credits\nThanks for playing, $USERNAME\n")
+	p := &MenuChoiceProcessor{
+		menuHandler: menu.NewMenuHandler(bm, nil, nil, slog.Default()),
+		logger:      slog.Default(),
+	}
+
+	channel := &keyChannel{in: &bytes.Buffer{}}
+	p.writeCredits(context.Background(), channel, &authv1.User{Username: "alice"})
+	assert.Equal(t, "\033[2J\033[H\033[1m\033[4mCredits\033[0m\r\nThanks for playing, alice\r\n", channel.out.String())
+}
+
+func TestConfirmQuit(t *testing.T) {
+	assert.True(t, confirmQuit(&keyChannel{in: bytes.NewBufferString("y")}))
+	assert.False(t, confirmQuit(&keyChannel{in: bytes.NewBufferString("n")}))
+	assert.False(t, confirmQuit(&keyChannel{in: bytes.NewBufferString("\r")}))
+	// A connection closing while asking is taken as a yes
+	assert.True(t, confirmQuit(&keyChannel{in: &bytes.Buffer{}}))
+}
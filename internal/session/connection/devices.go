@@ -0,0 +1,241 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// offeredKeyTTL is how long the key a handshake offered is remembered for
+// the handshake to finish
+const offeredKeyTTL = 2 * time.Minute
+
+// keyFingerprintExtension holds, in a connection's permissions, the
+// fingerprint of the public key its client offered
+const keyFingerprintExtension = "key_fingerprint"
+
+// offeredKeys remembers the public key each SSH handshake offered, by
+// session ID, so the password login that follows can tell which device it
+// comes from. Public keys are offered even where they are not accepted.
+type offeredKeys struct {
+	mu   sync.Mutex
+	keys map[string]offeredKey
+}
+
+type offeredKey struct {
+	fingerprint string
+	offeredAt   time.Time
+}
+
+// remember records the key a handshake offered, keeping the first. Keys of
+// handshakes that never finished are dropped once stale.
+func (o *offeredKeys) remember(conn ssh.ConnMetadata, key ssh.PublicKey) {
+	if conn == nil || key == nil {
+		return
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	now := time.Now()
+	for sessionID, offered := range o.keys {
+		if now.Sub(offered.offeredAt) > offeredKeyTTL {
+			delete(o.keys, sessionID)
+		}
+	}
+	sessionID := string(conn.SessionID())
+	if _, ok := o.keys[sessionID]; !ok {
+		o.keys[sessionID] = offeredKey{fingerprint: ssh.FingerprintSHA256(key), offeredAt: now}
+	}
+}
+
+// fingerprint returns the fingerprint of the key a handshake offered, "" when it offered none
+func (o *offeredKeys) fingerprint(conn ssh.ConnMetadata) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.keys[string(conn.SessionID())].fingerprint
+}
+
+// take returns the fingerprint of the key a finished handshake offered and forgets it
+func (o *offeredKeys) take(conn ssh.ConnMetadata) string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	sessionID := string(conn.SessionID())
+	fingerprint := o.keys[sessionID].fingerprint
+	delete(o.keys, sessionID)
+	return fingerprint
+}
+
+// rememberDevice keeps what the handshake told of the client's device with
+// the connection, for logins made from the menu
+func (h *Handler) rememberDevice(sshConn *ssh.ServerConn) {
+	if h.authHandler == nil {
+		return
+	}
+	fingerprint := h.authHandler.offered.take(sshConn)
+	if fingerprint == "" {
+		return
+	}
+	if sshConn.Permissions == nil {
+		sshConn.Permissions = &ssh.Permissions{}
+	}
+	if sshConn.Permissions.Extensions == nil {
+		sshConn.Permissions.Extensions = make(map[string]string)
+	}
+	sshConn.Permissions.Extensions[keyFingerprintExtension] = fingerprint
+}
+
+// clientDevice describes the device a login comes from: the public key its
+// client offered, if any, and its client version string
+func clientDevice(conn ssh.ConnMetadata, keyFingerprint string) *authv1.ClientDevice {
+	return &authv1.ClientDevice{
+		KeyFingerprint: keyFingerprint,
+		ClientVersion:  printable(string(conn.ClientVersion())),
+	}
+}
+
+// connectionDevice describes the device an established connection comes from
+func connectionDevice(sshConn *ssh.ServerConn) *authv1.ClientDevice {
+	var fingerprint string
+	if sshConn.Permissions != nil {
+		fingerprint = sshConn.Permissions.Extensions[keyFingerprintExtension]
+	}
+	return clientDevice(sshConn, fingerprint)
+}
+
+// handleDevices lists the devices the player has logged in from and lets
+// them revoke one, logging it out
+func (p *MenuChoiceProcessor) handleDevices(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	token := p.getAdminToken(sshConn)
+
+	for {
+		resp, err := p.authManager.authClient.ListDevices(ctx, token)
+		if err != nil || !resp.Success {
+			p.logger.Error("Failed to list devices", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("\r\nFailed to load your devices. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Devices ===\r\n\r\n"))
+		channel.Write([]byte("Devices are known by the SSH key they offer, or by their client when they offer none.\r\n"))
+		channel.Write([]byte("You are notified when a new device logs in.\r\n\r\n"))
+		writeDevices(channel, resp.Devices)
+		channel.Write([]byte("\r\n  [x] Revoke a device\r\n"))
+		channel.Write([]byte("  [q] Back\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		switch strings.ToLower(string(buffer[0])) {
+		case "x":
+			err = p.handleRevokeDevice(ctx, channel, userInfo, sshConn, resp.Devices)
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+		if p.getAdminToken(sshConn) == "" {
+			return nil
+		}
+	}
+}
+
+// writeDevices lists devices with when and where they were last seen
+func writeDevices(channel ssh.Channel, devices []*authv1.Device) {
+	if len(devices) == 0 {
+		channel.Write([]byte("No devices have been recorded for you yet.\r\n"))
+		return
+	}
+
+	channel.Write([]byte(fmt.Sprintf("%4s  %-30s %-16s %-10s %s\r\n", "ID", "Client", "Address", "Last seen", "Key")))
+	for _, device := range devices {
+		key := device.KeyFingerprint
+		if key == "" {
+			key = "none offered"
+		}
+		if device.RevokedAt != nil {
+			key = "revoked " + device.RevokedAt.AsTime().Format("2006-01-02")
+		}
+		marker := ""
+		if device.Current {
+			marker = " (this device)"
+		}
+		channel.Write([]byte(fmt.Sprintf("%4d  %-30s %-16s %-10s %s%s\r\n",
+			device.Id, clipDeviceClient(device.ClientVersion), device.LastIp,
+			device.LastSeenAt.AsTime().Format("2006-01-02"), key, marker)))
+	}
+}
+
+// clipDeviceClient shortens a client version string to its column
+func clipDeviceClient(client string) string {
+	if runes := []rune(client); len(runes) > 30 {
+		return string(runes[:29]) + "…"
+	}
+	return client
+}
+
+// handleRevokeDevice revokes a device by ID. Revoking the device in use
+// logs the player out.
+func (p *MenuChoiceProcessor) handleRevokeDevice(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn, devices []*authv1.Device) error {
+	input, err := p.promptForUsername(ctx, channel, "Device ID")
+	if err != nil || input == "" {
+		return err
+	}
+	deviceID, err := strconv.Atoi(input)
+	if err != nil {
+		channel.Write([]byte("✗ Device IDs are numbers\r\n"))
+		return nil
+	}
+
+	current := false
+	for _, device := range devices {
+		if device.Id == int32(deviceID) && device.Current && device.RevokedAt == nil {
+			current = true
+			channel.Write([]byte("This is the device you are using; revoking it logs you out. Continue? (y/N): "))
+			confirmed, err := p.authManager.readYesNo(ctx, channel)
+			if err != nil || !confirmed {
+				return err
+			}
+			channel.Write([]byte("\r\n"))
+		}
+	}
+
+	resp, err := p.authManager.authClient.RevokeDevice(ctx, p.getAdminToken(sshConn), int32(deviceID))
+	if err != nil {
+		p.logger.Error("Failed to revoke device", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", resp.Message)))
+	if current {
+		delete(sshConn.Permissions.Extensions, "access_token")
+		p.logger.Info("User revoked the device in use", "username", userInfo.Username)
+		channel.Write([]byte("You have been logged out.\r\n"))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package connection
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// handshakeMetadata is the metadata of an SSH handshake in progress
+type handshakeMetadata struct {
+	ssh.ConnMetadata
+	sessionID     string
+	clientVersion string
+}
+
+func (m handshakeMetadata) SessionID() []byte     { return []byte(m.sessionID) }
+func (m handshakeMetadata) ClientVersion() []byte { return []byte(m.clientVersion) }
+
+func newTestPublicKey(t *testing.T) ssh.PublicKey {
+	public, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key, err := ssh.NewPublicKey(public)
+	require.NoError(t, err)
+	return key
+}
+
+func TestOfferedKeys(t *testing.T) {
+	offered := &offeredKeys{keys: make(map[string]offeredKey)}
+	first, second := newTestPublicKey(t), newTestPublicKey(t)
+	conn := handshakeMetadata{sessionID: "handshake-a", clientVersion: "SSH-2.0-OpenSSH_9.6\x1b[2J"}
+
+	assert.Empty(t, offered.fingerprint(conn))
+	offered.remember(conn, first)
+	offered.remember(conn, second)
+	assert.Equal(t, ssh.FingerprintSHA256(first), offered.fingerprint(conn), "the first key offered names the device")
+
+	device := clientDevice(conn, offered.fingerprint(conn))
+	assert.Equal(t, ssh.FingerprintSHA256(first), device.KeyFingerprint)
+	assert.Equal(t, "SSH-2.0-OpenSSH_9.6[2J", device.ClientVersion)
+
+	assert.Equal(t, ssh.FingerprintSHA256(first), offered.take(conn))
+	assert.Empty(t, offered.take(conn))
+
+	// Handshakes that never finish are forgotten
+	stale := handshakeMetadata{sessionID: "handshake-b"}
+	offered.remember(stale, first)
+	offered.keys["handshake-b"] = offeredKey{fingerprint: "old", offeredAt: time.Now().Add(-offeredKeyTTL - time.Second)}
+	offered.remember(conn, second)
+	assert.NotContains(t, offered.keys, "handshake-b")
+}
@@ -0,0 +1,382 @@
+package connection
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// APISubsystem is the SSH subsystem serving the line-oriented JSON API
+	// for bots and scripts: `ssh -s host dg-api`
+	APISubsystem = "dg-api"
+	// apiProtocolVersion is announced in the hello event; it changes only
+	// when existing methods change incompatibly
+	apiProtocolVersion = 1
+	// apiMaxLineBytes bounds a request line
+	apiMaxLineBytes = 64 * 1024
+	// apiDefaultCols and apiDefaultRows size games started without a size
+	apiDefaultCols, apiDefaultRows = 80, 24
+)
+
+// apiMethods are the methods of the API, in the order they are announced
+var apiMethods = []string{"list_games", "list_sessions", "start_game", "attach", "get_preferences", "set_preference"}
+
+// apiRequest is a request line. The ID is echoed in the response so clients
+// may match them up; it may be any JSON value.
+type apiRequest struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+}
+
+// apiResponse is a response line, carrying a result or an error
+type apiResponse struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Result any             `json:"result,omitempty"`
+	Error  *apiError       `json:"error,omitempty"`
+}
+
+// apiError is the error of a failed request
+type apiError struct {
+	Code    apierror.Code `json:"code"`
+	Message string        `json:"message"`
+}
+
+// apiHello is the first line sent, announcing the protocol and the user
+type apiHello struct {
+	Event    string   `json:"event"`
+	Protocol int      `json:"protocol"`
+	User     string   `json:"user,omitempty"`
+	Methods  []string `json:"methods"`
+}
+
+// apiGame describes a game in list_games
+type apiGame struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Versions       []string `json:"versions,omitempty"`
+	DefaultVersion string   `json:"default_version,omitempty"`
+}
+
+// apiSession describes a game in progress in list_sessions
+type apiSession struct {
+	ID            string `json:"id"`
+	Username      string `json:"username"`
+	GameID        string `json:"game_id"`
+	StartedAt     string `json:"started_at,omitempty"`
+	Spectators    int    `json:"spectators"`
+	MaxSpectators int    `json:"max_spectators,omitempty"` // Omitted when the game allows any number
+}
+
+// apiStartParams are the parameters of start_game
+type apiStartParams struct {
+	GameID  string `json:"game_id"`
+	Version string `json:"version"`
+	Cols    int    `json:"cols"`
+	Rows    int    `json:"rows"`
+}
+
+// apiAttachParams are the parameters of attach
+type apiAttachParams struct {
+	SessionID string `json:"session_id"`
+	Username  string `json:"username"`
+}
+
+// apiPreferenceParams are the parameters of set_preference
+type apiPreferenceParams struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// apiTerminal is the result of start_game and attach: after it is sent the
+// channel carries the game's terminal until the game ends, and then closes
+type apiTerminal struct {
+	Terminal  bool   `json:"terminal"`
+	GameID    string `json:"game_id,omitempty"`
+	SessionID string `json:"session_id,omitempty"`
+}
+
+// apiConn is a channel serving the API
+type apiConn struct {
+	h          *Handler
+	channel    ssh.Channel
+	sshConn    *ssh.ServerConn
+	connID     string
+	clientTerm ClientTerminal
+	user       *authv1.User
+	// blocked is the screen the user must go through on an interactive
+	// shell before they may play, if any
+	blocked PlayState
+	enc     *json.Encoder
+}
+
+// parseSubsystemPayload returns the name of an SSH subsystem request
+func parseSubsystemPayload(payload []byte) (string, bool) {
+	var req struct{ Name string }
+	if err := ssh.Unmarshal(payload, &req); err != nil {
+		return "", false
+	}
+	return req.Name, true
+}
+
+// serveAPI answers API requests on the channel until the client closes it,
+// or hands the channel to a game
+func (h *Handler) serveAPI(ctx context.Context, channel ssh.Channel, connID string, sshConn *ssh.ServerConn, clientTerm ClientTerminal, loginUsername *string) {
+	c := &apiConn{
+		h:          h,
+		channel:    channel,
+		sshConn:    sshConn,
+		connID:     connID,
+		clientTerm: clientTerm,
+		enc:        json.NewEncoder(channel),
+	}
+	p := &playSession{
+		h:             h,
+		channel:       channel,
+		connID:        connID,
+		sshConn:       sshConn,
+		clientTerm:    clientTerm,
+		loginUsername: loginUsername,
+		userInfo:      h.resolveUser(ctx, sshConn),
+		// The API cannot refuse a login with a message, so extra logins
+		// are kept from the games the user is playing elsewhere
+		admit: func(ctx context.Context, user *authv1.User, logins int) bool {
+			if limit, _ := sessionLimit(user); limit > 0 && logins > limit {
+				markExtraLogin(sshConn, config.MultiLoginDenyGame)
+			}
+			return true
+		},
+	}
+	p.runHeadless(ctx, PlayStateIdentify)
+	if p.userInfo != nil && p.userInfo.Id != "" {
+		c.user = p.userInfo
+	}
+	if p.stopped != PlayStateMenu {
+		c.blocked = p.stopped
+	}
+	h.logger.InfoContext(ctx, "API subsystem started", "connection_id", connID, "user", sshConn.User(), "authenticated", c.user != nil)
+
+	hello := apiHello{Event: "hello", Protocol: apiProtocolVersion, Methods: apiMethods}
+	if c.user != nil {
+		hello.User = c.user.Username
+	}
+	if err := c.enc.Encode(hello); err != nil {
+		return
+	}
+
+	scanner := bufio.NewScanner(channel)
+	scanner.Buffer(make([]byte, 0, 4096), apiMaxLineBytes)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var req apiRequest
+		if err := json.Unmarshal(line, &req); err != nil {
+			c.fail(nil, apierror.InvalidRequest, "request is not valid JSON")
+			continue
+		}
+		if done := c.handle(ctx, &req); done {
+			return
+		}
+	}
+	if errors.Is(scanner.Err(), bufio.ErrTooLong) {
+		c.fail(nil, apierror.InvalidRequest, fmt.Sprintf("request lines are limited to %d bytes", apiMaxLineBytes))
+	}
+}
+
+// handle answers a request, reporting whether the channel was handed to a game
+func (c *apiConn) handle(ctx context.Context, req *apiRequest) bool {
+	switch req.Method {
+	case "list_games":
+		c.listGames(ctx, req)
+	case "list_sessions":
+		c.listSessions(ctx, req)
+	case "start_game":
+		return c.startGame(ctx, req)
+	case "attach":
+		return c.attach(ctx, req)
+	case "get_preferences":
+		c.getPreferences(req)
+	case "set_preference":
+		c.setPreference(ctx, req)
+	default:
+		c.fail(req.ID, apierror.NotFound, fmt.Sprintf("unknown method %q", req.Method))
+	}
+	return false
+}
+
+// listGames answers list_games
+func (c *apiConn) listGames(ctx context.Context, req *apiRequest) {
+	games, err := c.h.gameClient.ListGames(ctx)
+	if err != nil {
+		c.h.logger.ErrorContext(ctx, "Failed to list games for API", "error", err)
+		c.fail(req.ID, apierror.Unavailable, "failed to list games")
+		return
+	}
+	result := make([]apiGame, 0, len(games))
+	for _, game := range games {
+		result = append(result, apiGame{ID: game.Id, Name: game.Name, Versions: game.Versions, DefaultVersion: game.DefaultVersion})
+	}
+	c.reply(req.ID, result)
+}
+
+// listSessions answers list_sessions with the games that can be watched
+func (c *apiConn) listSessions(ctx context.Context, req *apiRequest) {
+	sessions, err := c.h.gameClient.GetActiveGameSessions(ctx)
+	if err != nil {
+		c.h.logger.ErrorContext(ctx, "Failed to list game sessions for API", "error", err)
+		c.fail(req.ID, apierror.Unavailable, "failed to list games in progress")
+		return
+	}
+	result := make([]apiSession, 0, len(sessions))
+	for _, session := range sessions {
+		s := apiSession{ID: session.Id, Username: session.Username, GameID: session.GameId, Spectators: client.SpectatorCount(session), MaxSpectators: c.h.gameClient.MaxSpectators(session)}
+		if session.StartTime != nil {
+			s.StartedAt = session.StartTime.AsTime().UTC().Format(time.RFC3339)
+		}
+		result = append(result, s)
+	}
+	c.reply(req.ID, result)
+}
+
+// startGame answers start_game and hands the channel to the game
+func (c *apiConn) startGame(ctx context.Context, req *apiRequest) bool {
+	var params apiStartParams
+	if !c.params(req, &params) {
+		return false
+	}
+	switch {
+	case c.user == nil:
+		c.fail(req.ID, apierror.Unauthenticated, "log in to play: connect as your user, or set DGAUTH")
+		return false
+	case c.blocked == PlayStatePasswordChange:
+		c.fail(req.ID, apierror.PermissionDenied, "your password must be changed; connect without a command to change it")
+		return false
+	case c.blocked == PlayStateTerms:
+		c.fail(req.ID, apierror.PermissionDenied, "the terms of service must be accepted; connect without a command to review them")
+		return false
+	}
+
+	choice, err := c.h.execPlayChoice(ctx, &execCommand{Action: execActionPlay, Target: params.GameID, Version: params.Version}, c.user)
+	if err != nil {
+		c.fail(req.ID, apierror.CodeOf(err), apierror.MessageOf(err))
+		return false
+	}
+	if err := c.h.menuChoiceProcessor.gameStartRefusal(ctx, c.sshConn, c.user, choice.Value); err != nil {
+		c.fail(req.ID, apierror.CodeOf(err), apierror.MessageOf(err))
+		return false
+	}
+	// Scripts cannot dismiss the news shown before games
+	choice.SkipNews = true
+	cols, rows := params.Cols, params.Rows
+	if cols <= 0 || rows <= 0 {
+		cols, rows = apiDefaultCols, apiDefaultRows
+	}
+
+	c.reply(req.ID, apiTerminal{Terminal: true, GameID: choice.Value})
+	c.h.logger.InfoContext(ctx, "API starting game", "connection_id", c.connID, "username", c.user.Username, "game_id", choice.Value)
+	if err := c.h.menuChoiceProcessor.HandleMenuChoice(ctx, c.channel, choice, c.user, c.connID, c.sshConn.User(), cols, rows, c.clientTerm, c.sshConn); err != nil {
+		c.h.logger.ErrorContext(ctx, "API game failed", "error", err, "game_id", choice.Value, "username", c.user.Username)
+	}
+	return true
+}
+
+// attach answers attach and hands the channel to a game being watched
+func (c *apiConn) attach(ctx context.Context, req *apiRequest) bool {
+	var params apiAttachParams
+	if !c.params(req, &params) {
+		return false
+	}
+	if menu.IsRestricted(c.user) {
+		c.fail(req.ID, apierror.SpectatingNotAllowed, "your account is restricted from watching games")
+		return false
+	}
+
+	sessionID := params.SessionID
+	if sessionID == "" {
+		if params.Username == "" {
+			c.fail(req.ID, apierror.InvalidRequest, "session_id or username is required")
+			return false
+		}
+		choice, err := c.h.execWatchChoice(ctx, &execCommand{Action: execActionWatch, Target: params.Username})
+		if err != nil {
+			c.fail(req.ID, apierror.CodeOf(err), apierror.MessageOf(err))
+			return false
+		}
+		sessionID = choice.Value
+	}
+
+	c.reply(req.ID, apiTerminal{Terminal: true, SessionID: sessionID})
+	c.h.logger.InfoContext(ctx, "API attaching to game", "connection_id", c.connID, "user", c.sshConn.User(), "session_id", sessionID)
+	if err := c.h.spectatingHandler.StartSpectating(ctx, c.channel, c.user, sessionID, c.clientTerm); err != nil {
+		c.h.logger.ErrorContext(ctx, "API spectating failed", "error", err, "session_id", sessionID)
+	}
+	return true
+}
+
+// getPreferences answers get_preferences with the user's menu preferences
+func (c *apiConn) getPreferences(req *apiRequest) {
+	if c.user == nil {
+		c.fail(req.ID, apierror.Unauthenticated, "log in to have preferences: connect as your user, or set DGAUTH")
+		return
+	}
+	c.reply(req.ID, menuPreferenceValues(c.user))
+}
+
+// setPreference answers set_preference, saving one of the user's menu
+// preferences and replying with them all
+func (c *apiConn) setPreference(ctx context.Context, req *apiRequest) {
+	var params apiPreferenceParams
+	if !c.params(req, &params) {
+		return
+	}
+	if c.user == nil {
+		c.fail(req.ID, apierror.Unauthenticated, "log in to have preferences: connect as your user, or set DGAUTH")
+		return
+	}
+	if err := menu.ValidateMenuPreference(params.Key, params.Value); err != nil {
+		c.fail(req.ID, apierror.InvalidRequest, err.Error())
+		return
+	}
+	if err := c.h.menuChoiceProcessor.saveMenuPreference(ctx, c.user, params.Key, params.Value, c.sshConn); err != nil {
+		c.h.logger.ErrorContext(ctx, "Failed to save preference for API", "error", err, "username", c.user.Username, "key", params.Key)
+		c.fail(req.ID, apierror.Unavailable, "failed to save preference")
+		return
+	}
+	c.reply(req.ID, menuPreferenceValues(c.user))
+}
+
+// params decodes the parameters of a request, answering with an error when
+// they are invalid
+func (c *apiConn) params(req *apiRequest, v any) bool {
+	if len(req.Params) == 0 {
+		return true
+	}
+	if err := json.Unmarshal(req.Params, v); err != nil {
+		c.fail(req.ID, apierror.InvalidRequest, fmt.Sprintf("invalid params for %s", req.Method))
+		return false
+	}
+	return true
+}
+
+// reply sends the result of a request
+func (c *apiConn) reply(id json.RawMessage, result any) {
+	c.enc.Encode(apiResponse{ID: id, Result: result})
+}
+
+// fail sends the error of a request
+func (c *apiConn) fail(id json.RawMessage, code apierror.Code, message string) {
+	c.enc.Encode(apiResponse{ID: id, Error: &apiError{Code: code, Message: message}})
+}
@@ -0,0 +1,154 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// newTestAPIConn returns an API connection writing its responses to out
+func newTestAPIConn(user *authv1.User, out *bytes.Buffer) *apiConn {
+	return &apiConn{
+		h:       &Handler{},
+		sshConn: &ssh.ServerConn{},
+		user:    user,
+		enc:     json.NewEncoder(out),
+	}
+}
+
+// apiResponses decodes the response lines written to out
+func apiResponses(t *testing.T, out *bytes.Buffer) []apiResponse {
+	t.Helper()
+	var responses []apiResponse
+	dec := json.NewDecoder(out)
+	for dec.More() {
+		var resp struct {
+			ID    json.RawMessage `json:"id"`
+			Error *apiError       `json:"error"`
+		}
+		require.NoError(t, dec.Decode(&resp))
+		responses = append(responses, apiResponse{ID: resp.ID, Error: resp.Error})
+	}
+	return responses
+}
+
+func TestAPIConn_RefusesBadRequests(t *testing.T) {
+	var out bytes.Buffer
+	c := newTestAPIConn(nil, &out)
+	ctx := context.Background()
+
+	requests := []string{
+		`{"id":1,"method":"reboot"}`,
+		`{"id":"two","method":"start_game","params":{"game_id":"nethack"}}`,
+		`{"id":3,"method":"start_game","params":"nethack"}`,
+		`{"id":4,"method":"attach","params":{}}`,
+	}
+	for _, line := range requests {
+		var req apiRequest
+		require.NoError(t, json.Unmarshal([]byte(line), &req))
+		assert.False(t, c.handle(ctx, &req), line)
+	}
+
+	responses := apiResponses(t, &out)
+	require.Len(t, responses, 4)
+	want := []struct {
+		id   string
+		code apierror.Code
+	}{
+		{`1`, apierror.NotFound},
+		{`"two"`, apierror.Unauthenticated},
+		{`3`, apierror.InvalidRequest},
+		{`4`, apierror.InvalidRequest},
+	}
+	for i, w := range want {
+		assert.JSONEq(t, w.id, string(responses[i].ID))
+		require.NotNil(t, responses[i].Error)
+		assert.Equal(t, w.code, responses[i].Error.Code)
+	}
+}
+
+func TestAPIConn_StartGameChecksLogin(t *testing.T) {
+	var out bytes.Buffer
+	c := newTestAPIConn(&authv1.User{Id: "1", Username: "alice"}, &out)
+	req := &apiRequest{ID: json.RawMessage(`1`), Method: "start_game", Params: json.RawMessage(`{"game_id":"nethack"}`)}
+	c.blocked = PlayStatePasswordChange
+	assert.False(t, c.handle(context.Background(), req))
+
+	c.blocked = PlayStateTerms
+	assert.False(t, c.handle(context.Background(), req))
+
+	responses := apiResponses(t, &out)
+	require.Len(t, responses, 2)
+	for _, resp := range responses {
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, apierror.PermissionDenied, resp.Error.Code)
+	}
+}
+
+func TestAPIConn_RestrictedUsersCannotAttach(t *testing.T) {
+	var out bytes.Buffer
+	c := newTestAPIConn(&authv1.User{Id: "1", Username: "alice", Metadata: map[string]string{"restricted": "true"}}, &out)
+	req := &apiRequest{Method: "attach", Params: json.RawMessage(`{"session_id":"abc"}`)}
+	assert.False(t, c.handle(context.Background(), req))
+
+	responses := apiResponses(t, &out)
+	require.Len(t, responses, 1)
+	assert.Equal(t, apierror.SpectatingNotAllowed, responses[0].Error.Code)
+}
+
+func TestParseSubsystemPayload(t *testing.T) {
+	name, ok := parseSubsystemPayload(ssh.Marshal(struct{ Name string }{APISubsystem}))
+	assert.True(t, ok)
+	assert.Equal(t, "dg-api", name)
+
+	_, ok = parseSubsystemPayload(nil)
+	assert.False(t, ok)
+}
+
+func TestAPIConn_Preferences(t *testing.T) {
+	var out bytes.Buffer
+	c := newTestAPIConn(nil, &out)
+	ctx := context.Background()
+	assert.False(t, c.handle(ctx, &apiRequest{ID: json.RawMessage(`1`), Method: "get_preferences"}))
+
+	c.user = &authv1.User{Id: "1", Username: "alice", Metadata: map[string]string{
+		"pref.default_game": "nethack",
+		"pref.watch_sort":   "idle",
+	}}
+	assert.False(t, c.handle(ctx, &apiRequest{ID: json.RawMessage(`2`), Method: "set_preference", Params: json.RawMessage(`{"key":"watch_sort","value":"loudest"}`)}))
+	assert.False(t, c.handle(ctx, &apiRequest{ID: json.RawMessage(`3`), Method: "set_preference", Params: json.RawMessage(`{"key":"colour","value":"red"}`)}))
+	assert.False(t, c.handle(ctx, &apiRequest{ID: json.RawMessage(`4`), Method: "get_preferences"}))
+
+	dec := json.NewDecoder(&out)
+	var resp struct {
+		ID     json.RawMessage   `json:"id"`
+		Result map[string]string `json:"result"`
+		Error  *apiError         `json:"error"`
+	}
+	for _, code := range []apierror.Code{apierror.Unauthenticated, apierror.InvalidRequest, apierror.InvalidRequest} {
+		require.NoError(t, dec.Decode(&resp))
+		require.NotNil(t, resp.Error)
+		assert.Equal(t, code, resp.Error.Code)
+	}
+	resp.Error = nil
+	require.NoError(t, dec.Decode(&resp))
+	assert.Nil(t, resp.Error)
+	assert.Equal(t, map[string]string{
+		"default_game":         "nethack",
+		"skip_credits":         "false",
+		"confirm_quit":         "false",
+		"watch_sort":           "idle",
+		"auto_reconnect":       "false",
+		"favorite_games":       "",
+		"recent_games":         "",
+		"show_online_status":   "true",
+		"friend_notifications": "false",
+	}, resp.Result)
+}
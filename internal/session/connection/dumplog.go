@@ -0,0 +1,200 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/terminal"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+	"golang.org/x/crypto/ssh"
+)
+
+// historyPageSize is how many finished games the "last games" menu shows
+// at a time
+const historyPageSize = 10
+
+// DumplogHandler lets players browse their finished games and the dumplogs
+// those games left
+type DumplogHandler struct {
+	gameClient *client.GameClient
+	logger     *slog.Logger
+	pause      *messagePause
+}
+
+// NewDumplogHandler creates a new dumplog handler
+func NewDumplogHandler(gameClient *client.GameClient, logger *slog.Logger) *DumplogHandler {
+	return &DumplogHandler{
+		gameClient: gameClient,
+		logger:     logger,
+	}
+}
+
+// ShowHistory lists the user's finished games a page at a time, with how
+// each ended, and shows the bookmarks and pages through the dumplog of the
+// selected game
+func (h *DumplogHandler) ShowHistory(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, terminalRows int) error {
+	userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
+	if err != nil {
+		h.logger.Error("Invalid user ID format", "user_id", userInfo.Id, "error", err)
+		channel.Write([]byte("Invalid user ID. Please contact administrator.\r\n"))
+		h.pause.error()
+		return nil
+	}
+
+	offset := 0
+	for {
+		sessions, total, err := h.gameClient.ListSessionHistory(ctx, int32(userID), offset, historyPageSize)
+		if err != nil {
+			h.logger.Error("Failed to list session history", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("Failed to load your recent games. Please try again later.\r\n"))
+			h.pause.error()
+			return nil
+		}
+
+		channel.Write([]byte("\033[2J\033[H"))
+		channel.Write([]byte("=== Your Last Games ===\r\n\r\n"))
+		if total == 0 {
+			channel.Write([]byte("No finished games yet. Finish a game to see it here.\r\n"))
+			h.pause.error()
+			return nil
+		}
+
+		for i, session := range sessions {
+			channel.Write([]byte(historyLine(i+1, session) + "\r\n"))
+		}
+
+		channel.Write([]byte(fmt.Sprintf("\r\nGames %d-%d of %d. * marks games with a dumplog.\r\n", offset+1, offset+len(sessions), total)))
+		prompt := fmt.Sprintf("Select a game to view (1-%d)", len(sessions))
+		if offset+len(sessions) < total {
+			prompt += ", [n]ext page"
+		}
+		if offset > 0 {
+			prompt += ", [p]revious page"
+		}
+		channel.Write([]byte(prompt + " or 'q' to return: "))
+
+		editor := terminal.NewLineEditor(channel, terminal.InputTypeText)
+		input, err := editor.ReadLine(ctx)
+		if err != nil {
+			return nil
+		}
+		input = strings.ToLower(strings.TrimSpace(input))
+		switch {
+		case input == "" || input == "q":
+			return nil
+		case input == "n" && offset+len(sessions) < total:
+			offset += historyPageSize
+			continue
+		case input == "p" && offset > 0:
+			offset -= historyPageSize
+			if offset < 0 {
+				offset = 0
+			}
+			continue
+		}
+
+		selection, err := strconv.Atoi(input)
+		if err != nil || selection < 1 || selection > len(sessions) {
+			channel.Write([]byte("\r\nInvalid selection.\r\n"))
+			h.pause.brief()
+			continue
+		}
+
+		session := sessions[selection-1]
+		dumplogID := session.DumplogId
+		if !h.showBookmarks(ctx, channel, int32(userID), session) {
+			continue
+		}
+		if dumplogID == "" {
+			channel.Write([]byte("\r\nNo dumplog was kept for that game.\r\n"))
+			h.pause.error()
+			continue
+		}
+		dumplog, err := h.gameClient.GetDumplog(ctx, dumplogID)
+		if err != nil {
+			h.logger.Error("Failed to get dumplog", "error", err, "dumplog_id", dumplogID)
+			channel.Write([]byte("\r\nFailed to load the dumplog.\r\n"))
+			h.pause.error()
+			continue
+		}
+
+		if err := h.pageDumplog(ctx, channel, dumplog, terminalRows); err != nil {
+			return nil
+		}
+	}
+}
+
+// showBookmarks lists the bookmarks dropped in a finished game, if it has
+// any, and reports whether the player asked for its dumplog next
+func (h *DumplogHandler) showBookmarks(ctx context.Context, channel ssh.Channel, userID int32, session *gamev2.GameSession) bool {
+	if session.Recording == nil || !h.gameClient.Supports(ctx, capabilities.Bookmarks) {
+		return true
+	}
+	bookmarks, err := h.gameClient.ListBookmarks(ctx, session.Id, userID)
+	if err != nil {
+		h.logger.Warn("Failed to list bookmarks", "error", err, "session_id", session.Id)
+		return true
+	}
+	if len(bookmarks) == 0 {
+		return true
+	}
+
+	channel.Write([]byte("\033[2J\033[H"))
+	channel.Write([]byte(fmt.Sprintf("=== Bookmarks in %s, %s ===\r\n\r\n", session.GameId, session.StartTime.AsTime().Local().Format("2006-01-02 15:04"))))
+	for i, bookmark := range bookmarks {
+		channel.Write([]byte(bookmarkLine(i+1, bookmark) + "\r\n"))
+	}
+	channel.Write([]byte("\r\nTimes are from the start of the recording.\r\n"))
+	if session.DumplogId == "" {
+		channel.Write([]byte("Press any key to return: "))
+		readKey(channel)
+		return false
+	}
+	channel.Write([]byte("Press d to view the dumplog, any other key to return: "))
+	key, err := readKey(channel)
+	return err == nil && (key == 'd' || key == 'D')
+}
+
+// historyLine describes a finished game for the "last games" menu, e.g.
+// " 1. nethack      2026-10-14 21:03  1h12m  died      killed by a jackal *"
+func historyLine(number int, session *gamev2.GameSession) string {
+	var duration int64
+	if session.StartTime != nil && session.EndTime != nil {
+		duration = int64(session.EndTime.AsTime().Sub(session.StartTime.AsTime()).Seconds())
+	}
+
+	result, detail := "ended", ""
+	switch {
+	case session.Outcome != nil:
+		result = session.Outcome.Result
+		if session.Outcome.Result == "died" {
+			detail = session.Outcome.Death
+		}
+		if session.Outcome.Points > 0 {
+			detail = strings.TrimSpace(fmt.Sprintf("%s (%d points)", detail, session.Outcome.Points))
+		}
+	case session.Status == gamev2.SessionStatus_SESSION_STATUS_FAILED:
+		result = "crashed"
+	}
+
+	line := fmt.Sprintf("%2d. %-12s %s  %6s  %-9s %s", number, session.GameId,
+		session.StartTime.AsTime().Local().Format("2006-01-02 15:04"), formatPlayTime(duration), result, detail)
+	line = strings.TrimRight(line, " ")
+	if session.DumplogId != "" {
+		line += " *"
+	}
+	return line
+}
+
+// pageDumplog shows a dumplog one screen at a time
+func (h *DumplogHandler) pageDumplog(ctx context.Context, channel ssh.Channel, dumplog *gamev2.Dumplog, terminalRows int) error {
+	text := strings.ReplaceAll(string(dumplog.Content), "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	return pageLines(ctx, channel, dumplog.GameId, lines, terminalRows)
+}
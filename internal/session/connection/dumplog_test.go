@@ -0,0 +1,31 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+func TestHistoryLine(t *testing.T) {
+	start := time.Date(2026, 10, 14, 21, 3, 0, 0, time.Local)
+	session := &gamev2.GameSession{
+		GameId:    "nethack",
+		Status:    gamev2.SessionStatus_SESSION_STATUS_ENDED,
+		StartTime: timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(72 * time.Minute)),
+		Outcome:   &gamev2.GameOutcome{Result: "died", Death: "killed by a jackal", Points: 120},
+		DumplogId: "dumplog_1",
+	}
+	assert.Equal(t, " 1. nethack      2026-10-14 21:03   1h12m  died      killed by a jackal (120 points) *", historyLine(1, session))
+
+	// Saved games leave no outcome, and crashed games say so
+	session.Outcome = nil
+	session.DumplogId = ""
+	assert.Equal(t, " 2. nethack      2026-10-14 21:03   1h12m  ended", historyLine(2, session))
+	session.Status = gamev2.SessionStatus_SESSION_STATUS_FAILED
+	assert.Equal(t, " 3. nethack      2026-10-14 21:03   1h12m  crashed", historyLine(3, session))
+}
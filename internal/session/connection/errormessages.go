@@ -0,0 +1,40 @@
+package connection
+
+import (
+	"fmt"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// friendlyMessages explain errors the player can do something about, by the
+// error code the services returned
+var friendlyMessages = map[apierror.Code]string{
+	apierror.ActiveSessionExists:  "You are already playing this game in another session.",
+	apierror.GameNotFound:         "This game is not installed on the server.",
+	apierror.SessionNotFound:      "That game has already ended.",
+	apierror.SessionNotActive:     "That game has already ended.",
+	apierror.SpectatingNotAllowed: "This player does not allow spectators.",
+	apierror.SessionFull:          "This game has as many spectators as it allows. Please try again later.",
+	apierror.ShuttingDown:         "The game server is restarting. Please try again in a moment.",
+	apierror.RateLimited:          "Too many requests. Please wait a moment and try again.",
+	apierror.Unavailable:          "The game server is unavailable. Please try again later.",
+}
+
+// friendlyError returns the message to show the player for err, and whether
+// it says more than fallback. A save the chosen version cannot load, or a
+// game taken out of play, is explained with the reason the game service gave.
+func friendlyError(err error, fallback string) (string, bool) {
+	code := apierror.CodeOf(err)
+	switch code {
+	case apierror.SaveIncompatible:
+		return fmt.Sprintf("Cannot start this version: %s", apierror.MessageOf(err)), true
+	case apierror.GameUnavailable:
+		return fmt.Sprintf("This game is not available right now. %s.", apierror.MessageOf(err)), true
+	case apierror.PlayTimeExceeded:
+		return apierror.MessageOf(err) + ".", true
+	}
+	if message, ok := friendlyMessages[code]; ok {
+		return message, true
+	}
+	return fallback, false
+}
@@ -0,0 +1,211 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"golang.org/x/crypto/ssh"
+)
+
+// execUsage lists the commands accepted on the SSH command line
+const execUsage = "usage: ssh -t <host> <game> [version] | play <game> [version] | watch <user>"
+
+// Exit statuses reported for SSH commands
+const (
+	execStatusOK     = 0
+	execStatusFailed = 1
+	execStatusUsage  = 2
+)
+
+// Quick actions accepted on the SSH command line
+const (
+	execActionPlay  = "play"
+	execActionWatch = "watch"
+)
+
+// execCommand is a quick action given on the SSH command line, such as
+// `ssh -t play@host nethack` or `ssh -t play@host watch alice`
+type execCommand struct {
+	Action  string // execActionPlay or execActionWatch
+	Target  string // Game to play or user to watch
+	Version string // Game version to play; empty for the default
+}
+
+// parseExecPayload returns the command of an SSH exec request
+func parseExecPayload(payload []byte) (string, bool) {
+	var req struct{ Command string }
+	if err := ssh.Unmarshal(payload, &req); err != nil {
+		return "", false
+	}
+	return req.Command, true
+}
+
+// parseExecCommand parses a quick action; a bare game ID plays that game
+func parseExecCommand(command string) (*execCommand, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, errors.New("no command given")
+	}
+
+	switch strings.ToLower(fields[0]) {
+	case execActionWatch:
+		if len(fields) != 2 {
+			return nil, errors.New("watch takes one username")
+		}
+		return &execCommand{Action: execActionWatch, Target: fields[1]}, nil
+	case execActionPlay:
+		fields = fields[1:]
+		if len(fields) == 0 {
+			return nil, errors.New("play takes a game")
+		}
+	}
+	if len(fields) > 2 {
+		return nil, fmt.Errorf("unknown command %q", command)
+	}
+	cmd := &execCommand{Action: execActionPlay, Target: fields[0]}
+	if len(fields) == 2 {
+		cmd.Version = fields[1]
+	}
+	return cmd, nil
+}
+
+// handleExec runs a quick action from the SSH command line instead of
+// showing the menus, and returns the exit status to report
+func (h *Handler) handleExec(ctx context.Context, channel ssh.Channel, command, connID string, sshConn *ssh.ServerConn, terminalCols, terminalRows int, clientTerm ClientTerminal, hasPTY bool, loginUsername *string) uint32 {
+	cmd, err := parseExecCommand(command)
+	if err != nil {
+		execError(channel, "%v\r\n%s", err, execUsage)
+		return execStatusUsage
+	}
+	h.logger.InfoContext(ctx, "SSH command received", "connection_id", connID, "user", sshConn.User(), "action", cmd.Action, "target", cmd.Target)
+
+	// Games and spectating draw full screens, so they need a terminal
+	if !hasPTY {
+		execError(channel, "a terminal is required; connect with ssh -t")
+		return execStatusFailed
+	}
+	// The command runs once the player gets as far as the menus would
+	p := &playSession{
+		h:             h,
+		channel:       channel,
+		connID:        connID,
+		sshConn:       sshConn,
+		cols:          terminalCols,
+		rows:          terminalRows,
+		clientTerm:    clientTerm,
+		loginUsername: loginUsername,
+	}
+	p.runHeadless(ctx, PlayStateHealthCheck)
+	switch p.stopped {
+	case PlayStateMenu:
+	case PlayStateServiceUnavailable:
+		execError(channel, "the server is unavailable, please try again later")
+		return execStatusFailed
+	case PlayStatePasswordChange:
+		execError(channel, "your password must be changed; connect without a command to change it")
+		return execStatusFailed
+	case PlayStateTerms:
+		execError(channel, "the terms of service must be accepted; connect without a command to review them")
+		return execStatusFailed
+	default:
+		// The login was refused
+		return execStatusFailed
+	}
+	userInfo := p.userInfo
+	if userInfo != nil && userInfo.Id == "" {
+		userInfo = nil
+	}
+
+	var choice *menu.MenuChoice
+	if cmd.Action == execActionWatch {
+		choice, err = h.execWatchChoice(ctx, cmd)
+	} else {
+		choice, err = h.execPlayChoice(ctx, cmd, userInfo)
+	}
+	if err != nil {
+		execError(channel, "%v", err)
+		return execStatusFailed
+	}
+
+	if err := h.menuChoiceProcessor.HandleMenuChoice(ctx, channel, choice, userInfo, connID, sshConn.User(), terminalCols, terminalRows, clientTerm, sshConn); err != nil {
+		h.logger.ErrorContext(ctx, "SSH command failed", "error", err, "action", cmd.Action, "target", cmd.Target, "username", sshConn.User())
+		execError(channel, "%s failed, please try again later", cmd.Action)
+		return execStatusFailed
+	}
+	return execStatusOK
+}
+
+// execPlayChoice returns the menu choice starting the game of a play
+// command; its errors carry an apierror code and a message for the player
+func (h *Handler) execPlayChoice(ctx context.Context, cmd *execCommand, userInfo *authv1.User) (*menu.MenuChoice, error) {
+	if userInfo == nil {
+		return nil, apierror.New(apierror.Unauthenticated, "log in to play: connect as your user, or set DGAUTH")
+	}
+
+	games, err := h.gameClient.ListGames(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list games for SSH command", "error", err)
+		return nil, apierror.New(apierror.Unavailable, "failed to list games, please try again later")
+	}
+	game := findExecGame(games, cmd.Target)
+	if game == nil {
+		ids := make([]string, 0, len(games))
+		for _, g := range games {
+			ids = append(ids, g.Id)
+		}
+		return nil, apierror.New(apierror.GameNotFound, fmt.Sprintf("unknown game %q (available: %s)", cmd.Target, strings.Join(ids, ", ")))
+	}
+	if cmd.Version != "" {
+		versions := game.Versions
+		if len(versions) == 0 {
+			versions = []string{game.Version}
+		}
+		if !slices.Contains(versions, cmd.Version) {
+			return nil, apierror.New(apierror.InvalidRequest, fmt.Sprintf("unknown version %q of %s (available: %s)", cmd.Version, game.Id, strings.Join(versions, ", ")))
+		}
+	}
+	return &menu.MenuChoice{Action: "start_game", Value: game.Id, Version: cmd.Version}, nil
+}
+
+// execWatchChoice returns the menu choice spectating the game of a watch
+// command; its errors carry an apierror code and a message for the player
+func (h *Handler) execWatchChoice(ctx context.Context, cmd *execCommand) (*menu.MenuChoice, error) {
+	sessions, err := h.gameClient.GetActiveGameSessions(ctx)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to list game sessions for SSH command", "error", err)
+		return nil, apierror.New(apierror.Unavailable, "failed to list games in progress, please try again later")
+	}
+	for _, session := range sessions {
+		if strings.EqualFold(session.Username, cmd.Target) {
+			return &menu.MenuChoice{Action: "spectate_session", Value: session.Id}, nil
+		}
+	}
+	return nil, apierror.New(apierror.SessionNotFound, fmt.Sprintf("%s is not playing a game that can be watched", cmd.Target))
+}
+
+// findExecGame returns the game with the given ID or short name
+func findExecGame(games []*gamev2.Game, name string) *gamev2.Game {
+	for _, game := range games {
+		if strings.EqualFold(game.Id, name) || (game.ShortName != "" && strings.EqualFold(game.ShortName, name)) {
+			return game
+		}
+	}
+	return nil
+}
+
+// execError reports a failed SSH command on the channel's stderr
+func execError(channel ssh.Channel, format string, args ...any) {
+	fmt.Fprintf(channel.Stderr(), "dungeongate: "+format+"\r\n", args...)
+}
+
+// sendExitStatus reports the exit status of an SSH command to the client
+func sendExitStatus(channel ssh.Channel, status uint32) {
+	channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+}
@@ -0,0 +1,54 @@
+package connection
+
+import (
+	"testing"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestParseExecCommand(t *testing.T) {
+	tests := []struct {
+		command string
+		want    *execCommand
+	}{
+		{"nethack", &execCommand{Action: execActionPlay, Target: "nethack"}},
+		{"  nethack 3.7.0 ", &execCommand{Action: execActionPlay, Target: "nethack", Version: "3.7.0"}},
+		{"play dcss", &execCommand{Action: execActionPlay, Target: "dcss"}},
+		{"PLAY nethack 3.6.7", &execCommand{Action: execActionPlay, Target: "nethack", Version: "3.6.7"}},
+		{"watch alice", &execCommand{Action: execActionWatch, Target: "alice"}},
+	}
+	for _, tt := range tests {
+		got, err := parseExecCommand(tt.command)
+		require.NoError(t, err, tt.command)
+		assert.Equal(t, tt.want, got, tt.command)
+	}
+
+	for _, command := range []string{"", "   ", "play", "watch", "watch alice bob", "rm -rf / now"} {
+		_, err := parseExecCommand(command)
+		assert.Error(t, err, command)
+	}
+}
+
+func TestParseExecPayload(t *testing.T) {
+	command, ok := parseExecPayload(ssh.Marshal(struct{ Command string }{"watch alice"}))
+	assert.True(t, ok)
+	assert.Equal(t, "watch alice", command)
+
+	_, ok = parseExecPayload([]byte{0, 0})
+	assert.False(t, ok)
+}
+
+func TestFindExecGame(t *testing.T) {
+	games := []*gamev2.Game{
+		{Id: "nethack", ShortName: "nh"},
+		{Id: "dcss"},
+	}
+	assert.Equal(t, "nethack", findExecGame(games, "NetHack").Id)
+	assert.Equal(t, "nethack", findExecGame(games, "nh").Id)
+	assert.Equal(t, "dcss", findExecGame(games, "dcss").Id)
+	assert.Nil(t, findExecGame(games, ""))
+	assert.Nil(t, findExecGame(games, "angband"))
+}
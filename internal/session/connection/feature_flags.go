@@ -0,0 +1,34 @@
+package connection
+
+import (
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/featureflags"
+)
+
+// writeFeatureFlags lists the feature flags this session service evaluates,
+// who each is on for, and whether it is on for the admin viewing them
+func (p *MenuChoiceProcessor) writeFeatureFlags(channel ssh.Channel, userInfo *authv1.User) {
+	flags := p.flags.List()
+	if len(flags) == 0 {
+		return
+	}
+
+	subject := featureflags.SubjectOf(userInfo)
+	channel.Write([]byte("\r\nFeature Flags:\r\n"))
+	channel.Write([]byte(fmt.Sprintf("  %-24s %-5s %-36s %s\r\n", "Flag", "You", "On for", "Source")))
+	for _, flag := range flags {
+		you := "off"
+		if featureflags.Evaluate(flag.Name, &flag.FeatureFlag, subject) {
+			you = "on"
+		}
+		source := "config"
+		if flag.Overridden {
+			source = "runtime"
+		}
+		channel.Write([]byte(fmt.Sprintf("  %-24s %-5s %-36s %s\r\n", flag.Name, you, flag.Rollout(), source)))
+	}
+}
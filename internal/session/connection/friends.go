@@ -0,0 +1,172 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+// handleFriends shows the player's friends and what they are doing, and lets
+// them add and remove friends and turn friend alerts on or off
+func (p *MenuChoiceProcessor) handleFriends(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	if userInfo == nil {
+		channel.Write([]byte("Please login first to see your friends.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	token := p.getAdminToken(sshConn)
+	authClient := p.authManager.authClient
+
+	for {
+		resp, err := authClient.ListFriends(ctx, token)
+		if err == nil && !resp.Success {
+			err = fmt.Errorf("%s", resp.Error)
+		}
+		if err != nil {
+			p.logger.Error("Failed to list friends", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("Failed to load your friends. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+
+		// Games are only shown as the watch menu would show them
+		sessions, err := p.gameIOHandler.gameClient.GetActiveGameSessions(ctx)
+		if err != nil {
+			p.logger.Warn("Failed to list active sessions for friends", "error", err, "username", userInfo.Username)
+		}
+
+		alerts := menu.PreferencesOf(userInfo).FriendNotifications
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		for _, line := range friendLines(resp.Friends, p.manager.Online(), sessions, realm.FromContext(ctx)) {
+			channel.Write([]byte(line + "\r\n"))
+		}
+		channel.Write([]byte(fmt.Sprintf("\r\nFriend alerts: %s\r\n\r\n", onOff(alerts, "on", "off"))))
+		channel.Write([]byte("  [a] Add a friend\r\n"))
+		if len(resp.Friends) > 0 {
+			channel.Write([]byte("  [r] Remove a friend\r\n"))
+		}
+		channel.Write([]byte(fmt.Sprintf("  [n] Turn friend alerts %s\r\n", onOff(alerts, "off", "on"))))
+		channel.Write([]byte("  [q] Return to main menu\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		switch strings.ToLower(string(buffer[0])) {
+		case "a":
+			err = p.handleChangeFriend(ctx, channel, userInfo, token, true)
+		case "r":
+			if len(resp.Friends) == 0 {
+				continue
+			}
+			err = p.handleChangeFriend(ctx, channel, userInfo, token, false)
+		case "n":
+			p.setMenuPreference(ctx, channel, userInfo, menu.FriendNotificationsPreference, strconv.FormatBool(!alerts), sshConn)
+			continue
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+	}
+}
+
+// handleChangeFriend asks for a username and adds it to the player's
+// friends, or removes it
+func (p *MenuChoiceProcessor) handleChangeFriend(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string, add bool) error {
+	name, err := p.promptForUsername(ctx, channel, "Friend's username")
+	if err != nil || name == "" {
+		return err
+	}
+	username, ok := realmUsername(ctx, name)
+	if !ok {
+		channel.Write([]byte(fmt.Sprintf("✗ User %s not found\r\n", name)))
+		return nil
+	}
+
+	authClient := p.authManager.authClient
+	change, done := authClient.RemoveFriend, fmt.Sprintf("Removed %s from your friends", name)
+	if add {
+		change, done = authClient.AddFriend, fmt.Sprintf("Added %s to your friends", name)
+	}
+	resp, err := change(ctx, token, username)
+	if err != nil {
+		p.logger.Error("Failed to change friends", "error", err, "username", userInfo.Username, "friend", username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", done)))
+	return nil
+}
+
+// friendLines renders a player's friend list with what each friend is doing:
+// the game they play on any instance, else what they do on this one. Friends
+// who hid their online status show as offline.
+func friendLines(friends []*authv1.Friend, online []OnlineUser, sessions []*gamev2.GameSession, r *realm.Realm) []string {
+	lines := []string{"=== Friends ===", ""}
+	if len(friends) == 0 {
+		return append(lines, "You have no friends yet. Add one with [a].")
+	}
+
+	playing := make(map[string]string, len(sessions))
+	for _, session := range sessions {
+		playing[session.Username] = session.GameId
+	}
+	here := make(map[string]OnlineUser, len(online))
+	hidden := make(map[string]bool)
+	for _, user := range online {
+		switch {
+		case user.Realm != r.GetName():
+		case user.Hidden:
+			hidden[user.Username] = true
+		default:
+			here[user.Username] = user
+		}
+	}
+
+	lines = append(lines, fmt.Sprintf("  %-20s %s", "Friend", "Status"))
+	active := 0
+	for _, friend := range friends {
+		name := r.Local(friend.Username)
+		status := "offline"
+		gameID, isPlaying := playing[friend.Username]
+		user, isHere := here[name]
+		switch {
+		case hidden[name]:
+		case isPlaying:
+			status = ActivityPlaying + " " + gameID
+		case isHere:
+			status = user.Describe()
+		}
+		if status != "offline" {
+			active++
+		}
+		lines = append(lines, fmt.Sprintf("  %-20s %s", name, status))
+	}
+	return append(lines, "", fmt.Sprintf("%d of %d online", active, len(friends)))
+}
@@ -0,0 +1,42 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+func TestFriendLines(t *testing.T) {
+	friends := []*authv1.Friend{{Username: "alice"}, {Username: "bob"}, {Username: "carol"}, {Username: "dave"}}
+	online := []OnlineUser{
+		{Username: "alice", Activity: ActivityWatching, Target: "erin"},
+		{Username: "carol", Activity: ActivityMenu, Hidden: true},
+		{Username: "dave", Realm: "retro", Activity: ActivityMenu},
+	}
+	// bob plays on another instance; carol hid her online status
+	sessions := []*gamev2.GameSession{
+		{Username: "bob", GameId: "nethack"},
+		{Username: "carol", GameId: "dcss"},
+	}
+
+	lines := friendLines(friends, online, sessions, nil)
+	require.Len(t, lines, 9)
+	assert.Contains(t, lines[3], "watching erin")
+	assert.Contains(t, lines[4], "playing nethack")
+	assert.Contains(t, lines[5], "offline", "hidden friends show as offline")
+	assert.Contains(t, lines[6], "offline", "players of other realms are not friends here")
+	assert.Equal(t, "2 of 4 online", lines[8])
+
+	// Friends of a realm are listed by the names its players know
+	retro := &realm.Realm{Name: "retro", StoragePrefix: "retro_"}
+	lines = friendLines([]*authv1.Friend{{Username: "retro_dave"}}, online, nil, retro)
+	assert.Contains(t, lines[3], "dave")
+	assert.Contains(t, lines[3], "menu")
+
+	assert.Equal(t, []string{"=== Friends ===", "", "You have no friends yet. Add one with [a]."}, friendLines(nil, online, sessions, nil))
+}
@@ -2,52 +2,102 @@ package connection
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"io"
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/terminal"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
 	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/termtype"
 	"golang.org/x/crypto/ssh"
 )
 
 // GameIOHandler handles game session I/O and game lifecycle
 type GameIOHandler struct {
-	gameClient *client.GameClient
-	logger     *slog.Logger
+	gameClient      *client.GameClient
+	logger          *slog.Logger
+	spectatorPrompt bool
+
+	// Terminal negotiation policy
+	supportedTerminals []string
+	defaultTerminal    string
+
+	inputFilters *InputFilters
+	latency      *LatencyTracker
+
+	// Games players detached from, by username and game ID
+	detached   map[string]string
+	detachedMu sync.Mutex
+
+	guard *panicGuard
+	pause *messagePause
 }
 
 // NewGameIOHandler creates a new game I/O handler
 func NewGameIOHandler(gameClient *client.GameClient, logger *slog.Logger) *GameIOHandler {
 	return &GameIOHandler{
-		gameClient: gameClient,
-		logger:     logger,
+		gameClient:      gameClient,
+		logger:          logger,
+		defaultTerminal: termtype.Default,
+		detached:        make(map[string]string),
+		guard:           newPanicGuard(logger),
 	}
 }
 
+// SetSpectatorPrompt enables the "Allow spectators for this game?" prompt at game start
+func (h *GameIOHandler) SetSpectatorPrompt(enabled bool) {
+	h.spectatorPrompt = enabled
+}
+
+// SetTerminalPolicy sets the allowlist of terminal types passed through to games
+// and the type used for clients whose terminal cannot be matched
+func (h *GameIOHandler) SetTerminalPolicy(supported []string, defaultTerminal string) {
+	h.supportedTerminals = supported
+	if defaultTerminal != "" {
+		h.defaultTerminal = defaultTerminal
+	}
+}
+
+// SetInputFilters sets how player input is filtered for each game
+func (h *GameIOHandler) SetInputFilters(filters *InputFilters) {
+	h.inputFilters = filters
+}
+
+// NegotiateTerminal maps the client's requested TERM onto a supported terminal type
+func (h *GameIOHandler) NegotiateTerminal(requested string) string {
+	return termtype.Negotiate(requested, h.supportedTerminals, h.defaultTerminal)
+}
+
 // HandleGameIO handles I/O between SSH channel and game session via gRPC streaming
-func (h *GameIOHandler) HandleGameIO(ctx context.Context, channel ssh.Channel, sessionID, connID string) {
-	h.logger.Info("Starting game I/O handling", "session_id", sessionID, "connection_id", connID)
+func (h *GameIOHandler) HandleGameIO(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, gameID, sessionID, connID string, terminalCols, terminalRows int) {
+	h.logger.InfoContext(ctx, "Starting game I/O handling", "session_id", sessionID, "connection_id", connID)
 
 	// Create gRPC stream to Game Service
 	stream, err := h.gameClient.StreamGameIO(ctx)
 	if err != nil {
-		h.logger.Error("Failed to create game I/O stream", "error", err, "session_id", sessionID)
+		h.logger.ErrorContext(ctx, "Failed to create game I/O stream", "error", err, "session_id", sessionID)
 		channel.Write([]byte("Failed to connect to game session\r\n"))
 		return
 	}
 	defer stream.CloseSend()
 
-	h.HandleGameIOWithStream(ctx, channel, sessionID, connID, stream)
+	h.HandleGameIOWithStream(ctx, channel, userInfo, gameID, sessionID, connID, terminalCols, terminalRows, stream)
 }
 
 // HandleGameIOWithStream handles I/O using a pre-established gRPC stream
-func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.Channel, sessionID, connID string, stream gamev2.GameService_StreamGameIOClient) {
-	h.logger.Info("Starting game I/O handling with pre-established stream", "session_id", sessionID, "connection_id", connID)
+func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, gameID, sessionID, connID string, terminalCols, terminalRows int, stream gamev2.GameService_StreamGameIOClient) {
+	h.logger.InfoContext(ctx, "Starting game I/O handling with pre-established stream", "session_id", sessionID, "connection_id", connID)
+	// The player is listed as playing the game until it ends
+	defer presenceOf(ctx).doing(ActivityPlaying, gameID)()
 
 	// Send connect request
 	connectReq := &gamev2.GameIORequest{
@@ -59,7 +109,7 @@ func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.
 	}
 
 	if err := stream.Send(connectReq); err != nil {
-		h.logger.Error("Failed to send connect request", "error", err, "session_id", sessionID)
+		h.logger.ErrorContext(ctx, "Failed to send connect request", "error", err, "session_id", sessionID)
 		channel.Write([]byte("Failed to connect to game session\r\n"))
 		return
 	}
@@ -67,7 +117,7 @@ func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.
 	// Wait for connect response
 	resp, err := stream.Recv()
 	if err != nil {
-		h.logger.Error("Failed to receive connect response", "error", err, "session_id", sessionID)
+		h.logger.ErrorContext(ctx, "Failed to receive connect response", "error", err, "session_id", sessionID)
 		channel.Write([]byte("Failed to connect to game session\r\n"))
 		return
 	}
@@ -79,58 +129,78 @@ func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.
 		if connectResp != nil {
 			errorMsg = connectResp.Error
 		}
-		h.logger.Error("Failed to connect to PTY", "error", errorMsg, "session_id", sessionID)
+		h.logger.ErrorContext(ctx, "Failed to connect to PTY", "error", errorMsg, "session_id", sessionID)
 		channel.Write([]byte(fmt.Sprintf("Failed to connect to game session: %s\r\n", errorMsg)))
 		return
 	}
 
-	h.logger.Info("Successfully connected to PTY", "session_id", sessionID, "pty_id", connectResp.PtyId)
+	h.logger.InfoContext(ctx, "Successfully connected to PTY", "session_id", sessionID, "pty_id", connectResp.PtyId)
 
 	// Set up bidirectional I/O
+	tracked := h.latency.Start(sessionID, userInfo.Username, gameID)
+	defer h.latency.End(tracked)
 	done := make(chan error, 2)
+	filter := h.inputFilters.NewFilter(gameID)
+	output := newHeldOutput(channel, terminalCols, terminalRows)
+	// Set while the game service reports the game is not responding
+	var hung atomic.Bool
+
+	playTimeCtx, stopPlayTime := context.WithCancel(ctx)
+	defer stopPlayTime()
+	go h.watchPlayTime(playTimeCtx, output, userInfo, sessionID)
+	go h.reportStatusLine(playTimeCtx, output, userInfo, sessionID)
 
 	// Goroutine to handle SSH channel -> gRPC stream (user input)
 	go func() {
+		defer h.guard.recover("game_input", sessionID, func() { done <- errPanicked })
+		defer h.guard.track("game_input")()
+
 		buffer := make([]byte, 4096)
 		for {
 			n, err := channel.Read(buffer)
 			if err != nil {
-				h.logger.Debug("SSH channel read error", "error", err, "session_id", sessionID)
+				h.logger.DebugContext(ctx, "SSH channel read error", "error", err, "session_id", sessionID)
 				done <- err
 				return
 			}
 
+			data, escaped := filter.Filter(buffer[:n])
+
 			// Send input to game via gRPC
-			inputReq := &gamev2.GameIORequest{
-				Request: &gamev2.GameIORequest_Input{
-					Input: &gamev2.PTYInput{
-						SessionId: sessionID,
-						Data:      buffer[:n],
-					},
-				},
+			if len(data) > 0 {
+				tracked.Input(time.Now())
+				if err := sendInput(stream, sessionID, data); err != nil {
+					h.logger.ErrorContext(ctx, "Failed to send input to game", "error", err, "session_id", sessionID)
+					done <- err
+					return
+				}
 			}
 
-			if err := stream.Send(inputReq); err != nil {
-				h.logger.Error("Failed to send input to game", "error", err, "session_id", sessionID)
-				done <- err
-				return
+			if escaped {
+				if err := h.handleSessionMenu(ctx, channel, stream, output, filter, userInfo, gameID, sessionID, hung.Load()); err != nil {
+					done <- err
+					return
+				}
 			}
 		}
 	}()
 
 	// Goroutine to handle gRPC stream -> SSH channel (game output)
 	go func() {
+		defer h.guard.recover("game_output", sessionID, func() { done <- errPanicked })
+		defer h.guard.track("game_output")()
+
 		for {
 			resp, err := stream.Recv()
 			if err != nil {
-				h.logger.Debug("gRPC stream receive error", "error", err, "session_id", sessionID)
+				h.logger.DebugContext(ctx, "gRPC stream receive error", "error", err, "session_id", sessionID)
 				// Check if this is EOF or context cancellation (normal game exit)
 				if err == io.EOF || strings.Contains(err.Error(), "context canceled") {
 					// Game ended normally - clear terminal and show message
-					channel.Write([]byte("\033[2J\033[H")) // Clear screen and move cursor to home
-					channel.Write([]byte("\r\n=== Game ended ===\r\n"))
-					channel.Write([]byte("Returning to main menu...\r\n\r\n"))
-					time.Sleep(2 * time.Second)
+					output.Write([]byte("\033[2J\033[H")) // Clear screen and move cursor to home
+					output.Write([]byte("\r\n=== Game ended ===\r\n"))
+					output.Write([]byte("Returning to main menu...\r\n\r\n"))
+					h.pause.error()
 					done <- io.EOF
 				} else {
 					done <- err
@@ -141,46 +211,68 @@ func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.
 			// Handle different response types
 			switch respType := resp.Response.(type) {
 			case *gamev2.GameIOResponse_Output:
-				h.logger.Debug("Received bytes from game", "session_id", sessionID, "bytes", len(respType.Output.Data), "data", string(respType.Output.Data))
+				h.logger.DebugContext(ctx, "Received bytes from game", "session_id", sessionID, "bytes", len(respType.Output.Data), "data", string(respType.Output.Data))
 				// Forward output to SSH channel
-				n, err := channel.Write(respType.Output.Data)
+				n, err := output.Write(respType.Output.Data)
 				if err != nil {
-					h.logger.Debug("Failed to write bytes to SSH channel", "session_id", sessionID, "bytes", len(respType.Output.Data), "error", err)
-					h.logger.Error("Failed to write to SSH channel", "error", err, "session_id", sessionID)
+					h.logger.DebugContext(ctx, "Failed to write bytes to SSH channel", "session_id", sessionID, "bytes", len(respType.Output.Data), "error", err)
+					h.logger.ErrorContext(ctx, "Failed to write to SSH channel", "error", err, "session_id", sessionID)
 					done <- err
 					return
 				} else {
-					h.logger.Debug("Successfully wrote bytes to SSH channel", "session_id", sessionID, "bytes_written", n)
+					tracked.Output(time.Now())
+					h.logger.DebugContext(ctx, "Successfully wrote bytes to SSH channel", "session_id", sessionID, "bytes_written", n)
 				}
 
 			case *gamev2.GameIOResponse_Event:
 				// Handle PTY events
 				event := respType.Event
-				h.logger.Info("Received PTY event", "type", event.Type, "message", event.Message, "session_id", sessionID)
+				h.logger.InfoContext(ctx, "Received PTY event", "type", event.Type, "message", event.Message, "session_id", sessionID)
 
 				// For process exit events, we might want to notify the user
 				if event.Type == gamev2.PTYEventType_PTY_EVENT_PROCESS_EXIT {
-					channel.Write([]byte("\r\n\r\nGame session ended.\r\n"))
+					output.Write([]byte("\r\n\r\nGame session ended.\r\n"))
 					done <- io.EOF
 					return
 				}
 
+				switch event.Type {
+				case gamev2.PTYEventType_PTY_EVENT_DEGRADED:
+					hung.Store(true)
+					escapeKey, _ := filter.EscapeKey()
+					output.notice("Game not responding", []string{
+						"The game has stopped responding.",
+						fmt.Sprintf("Wait for it, or press %s and k to kill it.", terminal.KeyName(escapeKey)),
+					}, hungNoticeDuration)
+				case gamev2.PTYEventType_PTY_EVENT_RECOVERED:
+					hung.Store(false)
+					output.notice("Game not responding", []string{"The game is responding again."}, hungNoticeDuration/2)
+				}
+
 			case *gamev2.GameIOResponse_Disconnected:
 				// PTY disconnected
-				h.logger.Info("PTY disconnected", "session_id", sessionID)
+				h.logger.InfoContext(ctx, "PTY disconnected", "session_id", sessionID, "reason", respType.Disconnected.Reason)
+				if reason := respType.Disconnected.Reason; reason != "" {
+					// The game service ended the stream, e.g. while shutting down.
+					// The game may carry on, so choosing it again resumes it.
+					h.markDetached(userInfo.Username, gameID, sessionID)
+					output.Write([]byte("\033[0m\033(B\017\033[2J\033[H"))
+					output.Write([]byte(reason + "\r\n"))
+					h.pause.error()
+				}
 				done <- io.EOF
 				return
 
 			default:
-				h.logger.Warn("Unknown gRPC response type", "type", fmt.Sprintf("%T", respType), "session_id", sessionID)
+				h.logger.WarnContext(ctx, "Unknown gRPC response type", "type", fmt.Sprintf("%T", respType), "session_id", sessionID)
 			}
 		}
 	}()
 
 	// Wait for either goroutine to finish
 	err = <-done
-	if err != nil && err != io.EOF {
-		h.logger.Error("Game I/O error", "error", err, "session_id", sessionID)
+	if err != nil && err != io.EOF && err != errDetached {
+		h.logger.ErrorContext(ctx, "Game I/O error", "error", err, "session_id", sessionID)
 	}
 
 	// Send disconnect request
@@ -194,11 +286,11 @@ func (h *GameIOHandler) HandleGameIOWithStream(ctx context.Context, channel ssh.
 	}
 	stream.Send(disconnectReq)
 
-	h.logger.Info("Game I/O handling ended", "session_id", sessionID, "connection_id", connID)
+	h.logger.InfoContext(ctx, "Game I/O handling ended", "session_id", sessionID, "connection_id", connID)
 }
 
 // StartGameSession starts a game session
-func (h *GameIOHandler) StartGameSession(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username string, terminalCols, terminalRows int) error {
+func (h *GameIOHandler) StartGameSession(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username string, terminalCols, terminalRows int, clientTerm ClientTerminal) error {
 	// For now, start a NetHack session as default
 	gameID := "nethack"
 
@@ -206,97 +298,173 @@ func (h *GameIOHandler) StartGameSession(ctx context.Context, channel ssh.Channe
 	// Convert string ID to int32 for the Game Service API
 	userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
 	if err != nil {
-		h.logger.Error("Invalid user ID format", "user_id", userInfo.Id, "error", err)
+		h.logger.ErrorContext(ctx, "Invalid user ID format", "user_id", userInfo.Id, "error", err)
 		channel.Write([]byte("Invalid user ID. Please contact administrator.\r\n"))
 		return nil
 	}
-	sessionInfo, err := h.gameClient.StartGameSession(ctx, int32(userID), userInfo.Username, gameID, terminalCols, terminalRows)
+	sessionInfo, err := h.gameClient.StartGameSession(ctx, int32(userID), userInfo.Username, featureflags.SubjectOf(userInfo).Roles, gameID, "", terminalCols, terminalRows, clientTerm.Type, clientTerm.Locale, clientTerm.RemoteIP, userRecordingPrivacy(userInfo), userAllowsSpectators(userInfo), userPlayTimeLimit(userInfo))
 	if err != nil {
-		h.logger.Error("Failed to start game session", "error", err, "username", userInfo.Username)
+		h.logger.ErrorContext(ctx, "Failed to start game session", "error", err, "username", userInfo.Username)
 		// Check if the error is due to game service unavailability
 		if !h.gameClient.IsHealthy(ctx) {
-			h.logger.Info("Game service became unavailable, entering idle mode", "username", userInfo.Username)
+			h.logger.InfoContext(ctx, "Game service became unavailable, entering idle mode", "username", userInfo.Username)
 			return fmt.Errorf("game service unavailable")
 		}
-		channel.Write([]byte("Failed to start game session\r\n"))
+		message, _ := friendlyError(err, "Failed to start game session")
+		channel.Write([]byte(message + "\r\n"))
 		return nil // Return to menu
 	}
 
 	// Successfully started game session
 	sessionID := sessionInfo.ID
-	h.logger.Info("Started game session", "session_id", sessionID, "user", userInfo.Username, "game", gameID)
+	h.logger.InfoContext(ctx, "Started game session", "session_id", sessionID, "user", userInfo.Username, "game", gameID)
 
 	// Handle I/O - since Game Service doesn't have direct I/O methods,
 	// we'll need to implement this differently in a real implementation
-	h.HandleGameIO(ctx, channel, sessionID, connID)
+	h.HandleGameIO(ctx, channel, userInfo, gameID, sessionID, connID, terminalCols, terminalRows)
 
 	return nil
 }
 
-// StartSpecificGameSession starts a game session with a specific game ID
-func (h *GameIOHandler) StartSpecificGameSession(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username, gameID string, terminalCols, terminalRows int) error {
+// StartSpecificGameSession starts a game session with a specific game ID and
+// version; an empty version starts the game's default
+func (h *GameIOHandler) StartSpecificGameSession(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username, gameID, version string, terminalCols, terminalRows int, clientTerm ClientTerminal) error {
 	// Convert string ID to int32 for the Game Service API
 	userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
 	if err != nil {
-		h.logger.Error("Invalid user ID format", "user_id", userInfo.Id, "error", err)
+		h.logger.ErrorContext(ctx, "Invalid user ID format", "user_id", userInfo.Id, "error", err)
 		channel.Write([]byte("Invalid user ID. Please contact administrator.\r\n"))
 		return nil
 	}
 
+	// Resume a game the player detached from if it is still running
+	if sessionID := h.takeDetached(userInfo.Username, gameID); sessionID != "" {
+		if session, err := h.gameClient.GetGameSession(ctx, sessionID); err == nil && session.State == "active" {
+			h.logger.InfoContext(ctx, "Resuming detached game session", "session_id", sessionID, "user", userInfo.Username, "game", gameID)
+			channel.Write([]byte("\r\nResuming your game...\r\n"))
+			if err := h.gameClient.ResizeTerminal(ctx, sessionID, terminalCols, terminalRows); err != nil {
+				h.logger.WarnContext(ctx, "Failed to resize resumed game", "error", err, "session_id", sessionID)
+			}
+			h.HandleGameIO(ctx, channel, userInfo, gameID, sessionID, connID, terminalCols, terminalRows)
+			return nil
+		}
+	}
+
+	// Decide whether this session may be watched before starting it
+	allowSpectators, err := h.resolveAllowSpectators(ctx, channel, userInfo)
+	if err != nil {
+		return err
+	}
+
 	// Create gRPC stream FIRST to avoid race condition
 	stream, err := h.gameClient.StreamGameIO(ctx)
 	if err != nil {
-		h.logger.Error("Failed to create game I/O stream", "error", err, "username", username)
+		h.logger.ErrorContext(ctx, "Failed to create game I/O stream", "error", err, "username", username)
 		channel.Write([]byte("Failed to connect to game session\r\n"))
 		return nil
 	}
 	defer stream.CloseSend()
 
 	// Now start the game session with PTY
-	sessionInfo, err := h.gameClient.StartGameSession(ctx, int32(userID), userInfo.Username, gameID, terminalCols, terminalRows)
+	sessionInfo, err := h.gameClient.StartGameSession(ctx, int32(userID), userInfo.Username, featureflags.SubjectOf(userInfo).Roles, gameID, version, terminalCols, terminalRows, clientTerm.Type, clientTerm.Locale, clientTerm.RemoteIP, userRecordingPrivacy(userInfo), allowSpectators, userPlayTimeLimit(userInfo))
 	if err != nil {
-		h.logger.Error("Failed to start game session", "error", err, "username", userInfo.Username, "game_id", gameID)
+		h.logger.ErrorContext(ctx, "Failed to start game session", "error", err, "username", userInfo.Username, "game_id", gameID, "version", version)
 		// Check if the error is due to game service unavailability
 		if !h.gameClient.IsHealthy(ctx) {
-			h.logger.Info("Game service became unavailable, entering idle mode", "username", userInfo.Username)
+			h.logger.InfoContext(ctx, "Game service became unavailable, entering idle mode", "username", userInfo.Username)
 			return fmt.Errorf("game service unavailable")
 		}
-		channel.Write([]byte("Failed to start game session\r\n"))
+		// Errors the player can act on are worth explaining
+		message, explained := friendlyError(err, "Failed to start game session")
+		channel.Write([]byte(message + "\r\n"))
+		if explained {
+			// Brief pause to let user read the message
+			h.pause.error()
+		}
 		return nil // Return to menu
 	}
 
 	// Successfully started game session
 	sessionID := sessionInfo.ID
-	h.logger.Info("Started game session", "session_id", sessionID, "user", userInfo.Username, "game", gameID)
+	h.logger.InfoContext(ctx, "Started game session", "session_id", sessionID, "user", userInfo.Username, "game", gameID)
 
 	// Handle I/O using the pre-established stream
-	h.HandleGameIOWithStream(ctx, channel, sessionID, connID, stream)
+	h.HandleGameIOWithStream(ctx, channel, userInfo, gameID, sessionID, connID, terminalCols, terminalRows, stream)
 
 	return nil
 }
 
-// ParsePTYRequest parses PTY request payload
-func (h *GameIOHandler) ParsePTYRequest(payload []byte) (int, int) {
-	// PTY request format: term_name (string) + width (uint32) + height (uint32) + ...
-	if len(payload) < 8 {
-		return 80, 24
+// resolveAllowSpectators returns the user's spectating preference, optionally overridden for this session
+func (h *GameIOHandler) resolveAllowSpectators(ctx context.Context, channel ssh.Channel, userInfo *authv1.User) (bool, error) {
+	allow := userAllowsSpectators(userInfo)
+	if !h.spectatorPrompt {
+		return allow, nil
+	}
+
+	// Default answer follows the profile setting
+	if allow {
+		channel.Write([]byte("\r\nAllow spectators for this game? [Y/n] "))
+	} else {
+		channel.Write([]byte("\r\nAllow spectators for this game? [y/N] "))
 	}
 
-	// Skip term name (4 bytes length + string)
+	buffer := make([]byte, 1)
+	for {
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+
+		n, err := channel.Read(buffer)
+		if err != nil {
+			return false, err
+		}
+		if n == 0 {
+			continue
+		}
+
+		switch buffer[0] {
+		case 'y', 'Y':
+			channel.Write([]byte("y\r\n"))
+			return true, nil
+		case 'n', 'N':
+			channel.Write([]byte("n\r\n"))
+			return false, nil
+		case '\r', '\n':
+			channel.Write([]byte("\r\n"))
+			return allow, nil
+		}
+	}
+}
+
+// userAllowsSpectators reports whether the user's profile permits spectators (default true)
+func userAllowsSpectators(userInfo *authv1.User) bool {
+	if userInfo == nil || userInfo.Metadata == nil {
+		return true
+	}
+	return userInfo.Metadata["allow_spectators"] != "false"
+}
+
+// ParsePTYRequest parses PTY request payload, returning the client's terminal type and size
+func (h *GameIOHandler) ParsePTYRequest(payload []byte) (string, int, int) {
+	// PTY request format: term_name (string) + width (uint32) + height (uint32) + ...
 	if len(payload) < 4 {
-		return 80, 24
+		return "", 80, 24
 	}
 
-	termNameLen := int(payload[3])
-	if len(payload) < 4+termNameLen+8 {
-		return 80, 24
+	termNameLen := int(binary.BigEndian.Uint32(payload[0:4]))
+	if termNameLen < 0 || len(payload) < 4+termNameLen+8 {
+		return "", 80, 24
 	}
 
+	termType := string(payload[4 : 4+termNameLen])
 	offset := 4 + termNameLen
-	cols := int(payload[offset])<<24 | int(payload[offset+1])<<16 | int(payload[offset+2])<<8 | int(payload[offset+3])
-	rows := int(payload[offset+4])<<24 | int(payload[offset+5])<<16 | int(payload[offset+6])<<8 | int(payload[offset+7])
+	cols := int(binary.BigEndian.Uint32(payload[offset : offset+4]))
+	rows := int(binary.BigEndian.Uint32(payload[offset+4 : offset+8]))
+	if cols <= 0 || rows <= 0 {
+		cols, rows = 80, 24
+	}
 
-	return cols, rows
+	return termType, cols, rows
 }
 
 // ParseWindowChange parses window change request payload
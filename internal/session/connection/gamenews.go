@@ -0,0 +1,73 @@
+package connection
+
+import (
+	"context"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// GameNewsPreference is the user preference holding whether the news of a
+// game is shown before it starts; "off" hides it
+const GameNewsPreference = "game_news"
+
+// gameNewsOff is the GameNewsPreference value hiding game news
+const gameNewsOff = "off"
+
+// gameNewsHidden reports whether the player turned game news off
+func gameNewsHidden(userInfo *authv1.User) bool {
+	return userInfo != nil && userInfo.Metadata != nil && userInfo.Metadata["pref."+GameNewsPreference] == gameNewsOff
+}
+
+// showGameNews shows the news of a game, such as patch notes or tournament
+// rules, before it starts and waits for a key. Pressing n turns the news off
+// for the player.
+func (p *MenuChoiceProcessor) showGameNews(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, gameID string, sshConn *ssh.ServerConn) error {
+	if gameNewsHidden(userInfo) {
+		return nil
+	}
+	news, ok, err := p.menuHandler.RenderGameNews(ctx, gameID, userInfo.GetUsername())
+	if err != nil {
+		p.logger.Warn("Failed to render game news", "error", err, "game_id", gameID)
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	channel.Write([]byte("\033[2J\033[H")) // Clear screen
+	channel.Write([]byte(news))
+	channel.Write([]byte("\r\nPress any key to continue (n: don't show game news again)..."))
+
+	buffer := make([]byte, 1)
+	if _, err := channel.Read(buffer); err != nil {
+		return err
+	}
+	if buffer[0] == 'n' || buffer[0] == 'N' {
+		p.setGameNews(ctx, channel, userInfo, false, sshConn)
+	}
+	return nil
+}
+
+// setGameNews saves whether the player sees game news
+func (p *MenuChoiceProcessor) setGameNews(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, show bool, sshConn *ssh.ServerConn) {
+	// Showing news is the default, so it is stored as no preference at all
+	value := gameNewsOff
+	if show {
+		value = ""
+	}
+	resp, err := p.authManager.authClient.SetUserPreference(ctx, p.getAdminToken(sshConn), GameNewsPreference, value)
+	if err != nil || !resp.Success {
+		p.logger.Warn("Failed to save game news setting", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nCould not save your game news setting.\r\n"))
+		p.pause.error()
+		return
+	}
+
+	// Keep the menu in step until the next login reloads the user
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["pref."+GameNewsPreference] = value
+	p.logger.Info("Game news setting changed", "username", userInfo.Username, "show", show)
+}
@@ -0,0 +1,63 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// keyChannel is a channel reading keys from in and recording what is written
+type keyChannel struct {
+	ssh.Channel
+	in  *bytes.Buffer
+	out bytes.Buffer
+}
+
+func (c *keyChannel) Read(p []byte) (int, error)  { return c.in.Read(p) }
+func (c *keyChannel) Write(p []byte) (int, error) { return c.out.Write(p) }
+
+func TestShowGameNews(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nethack.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Tournament starts $DATE"), 0644))
+	bm := banner.NewBannerManager(&banner.BannerConfig{GameNews: map[string]string{"nethack": path}}, "test")
+	p := &MenuChoiceProcessor{
+		menuHandler: menu.NewMenuHandler(bm, nil, nil, slog.Default()),
+		logger:      slog.Default(),
+	}
+	user := &authv1.User{Username: "alice"}
+
+	// News waits for a key
+	channel := &keyChannel{in: bytes.NewBufferString(" ")}
+	require.NoError(t, p.showGameNews(context.Background(), channel, user, "nethack", nil))
+	assert.Contains(t, channel.out.String(), "Tournament starts")
+	assert.NotContains(t, channel.out.String(), "$DATE")
+	assert.Contains(t, channel.out.String(), "Press any key")
+	assert.Zero(t, channel.in.Len())
+
+	// Games without news start straight away
+	channel = &keyChannel{in: bytes.NewBufferString(" ")}
+	require.NoError(t, p.showGameNews(context.Background(), channel, user, "crawl", nil))
+	assert.Empty(t, channel.out.String())
+
+	// Admins' runtime news replaces the file
+	bm.SetOverride(banner.NewsName("crawl"), "Crawl patched")
+	channel = &keyChannel{in: bytes.NewBufferString(" ")}
+	require.NoError(t, p.showGameNews(context.Background(), channel, user, "crawl", nil))
+	assert.Contains(t, channel.out.String(), "Crawl patched")
+
+	// Players who turned news off never see it
+	user.Metadata = map[string]string{"pref." + GameNewsPreference: gameNewsOff}
+	channel = &keyChannel{in: bytes.NewBufferString(" ")}
+	require.NoError(t, p.showGameNews(context.Background(), channel, user, "nethack", nil))
+	assert.Empty(t, channel.out.String())
+}
@@ -0,0 +1,141 @@
+package connection
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/geoip"
+)
+
+// GeoRecorder counts connections by country; metrics.Registry implements it
+type GeoRecorder interface {
+	RecordConnectionCountry(country string, accepted bool)
+}
+
+// CountryStats counts the connections from one country on this instance
+type CountryStats struct {
+	Country string
+	Total   int64 // Connections accepted since start
+	Active  int64
+	Refused int64 // Connections refused by the country lists
+}
+
+// GeoFilter looks up the country of new connections, refuses those the
+// country lists deny and keeps per-country counts for admins. A nil
+// GeoFilter accepts everything and knows no countries.
+type GeoFilter struct {
+	resolver *geoip.Resolver
+	policy   *geoip.Policy
+	recorder GeoRecorder
+
+	mu        sync.Mutex
+	countries map[string]*CountryStats
+}
+
+// NewGeoFilter opens the GeoIP database. It returns nil when GeoIP is disabled.
+func NewGeoFilter(cfg *config.GeoIPConfig) (*GeoFilter, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	resolver, err := geoip.Open(cfg.DatabasePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GeoIP database: %w", err)
+	}
+	return &GeoFilter{
+		resolver:  resolver,
+		policy:    geoip.NewPolicy(cfg.AllowCountries, cfg.DenyCountries, cfg.DenyUnknown),
+		countries: make(map[string]*CountryStats),
+	}, nil
+}
+
+// SetRecorder sets where connections are counted by country
+func (g *GeoFilter) SetRecorder(recorder GeoRecorder) {
+	if g != nil {
+		g.recorder = recorder
+	}
+}
+
+// Country returns the country of ip, or "" when GeoIP is disabled
+func (g *GeoFilter) Country(ip string) string {
+	if g == nil {
+		return ""
+	}
+	return g.resolver.Country(ip)
+}
+
+// Admit looks up the country of a new connection from ip and reports whether
+// it may connect. Call Release with the country once an admitted connection
+// closes.
+func (g *GeoFilter) Admit(ip string) (string, bool) {
+	if g == nil {
+		return "", true
+	}
+	country := g.resolver.Country(ip)
+	allowed := g.policy.Allowed(country)
+
+	g.mu.Lock()
+	stats := g.statsFor(country)
+	if allowed {
+		stats.Total++
+		stats.Active++
+	} else {
+		stats.Refused++
+	}
+	g.mu.Unlock()
+
+	if g.recorder != nil {
+		g.recorder.RecordConnectionCountry(country, allowed)
+	}
+	return country, allowed
+}
+
+// Release records that an admitted connection from country has closed
+func (g *GeoFilter) Release(country string) {
+	if g == nil {
+		return
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.statsFor(country).Active--
+}
+
+// Breakdown returns the per-country counts, busiest country first
+func (g *GeoFilter) Breakdown() []CountryStats {
+	if g == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	breakdown := make([]CountryStats, 0, len(g.countries))
+	for _, stats := range g.countries {
+		breakdown = append(breakdown, *stats)
+	}
+	sort.Slice(breakdown, func(i, j int) bool {
+		if breakdown[i].Total != breakdown[j].Total {
+			return breakdown[i].Total > breakdown[j].Total
+		}
+		return breakdown[i].Country < breakdown[j].Country
+	})
+	return breakdown
+}
+
+// Close releases the GeoIP database
+func (g *GeoFilter) Close() error {
+	if g == nil {
+		return nil
+	}
+	return g.resolver.Close()
+}
+
+// statsFor returns the counts for country, creating them. Called with mu held.
+func (g *GeoFilter) statsFor(country string) *CountryStats {
+	stats, ok := g.countries[country]
+	if !ok {
+		stats = &CountryStats{Country: country}
+		g.countries[country] = stats
+	}
+	return stats
+}
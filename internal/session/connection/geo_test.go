@@ -0,0 +1,62 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/geoip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGeoRecorder struct {
+	accepted, denied int
+}
+
+func (r *fakeGeoRecorder) RecordConnectionCountry(country string, accepted bool) {
+	if accepted {
+		r.accepted++
+	} else {
+		r.denied++
+	}
+}
+
+func TestGeoFilter_CountsConnections(t *testing.T) {
+	// Without a database every address is unknown
+	geo := &GeoFilter{policy: geoip.NewPolicy(nil, []string{"XX"}, false), countries: make(map[string]*CountryStats)}
+	recorder := &fakeGeoRecorder{}
+	geo.SetRecorder(recorder)
+
+	country, allowed := geo.Admit("192.0.2.1")
+	assert.True(t, allowed)
+	assert.Equal(t, geoip.Unknown, country)
+	geo.Admit("192.0.2.2")
+	geo.Release(country)
+
+	assert.Equal(t, []CountryStats{{Country: geoip.Unknown, Total: 2, Active: 1}}, geo.Breakdown())
+	assert.Equal(t, 2, recorder.accepted)
+}
+
+func TestGeoFilter_DeniesUnknown(t *testing.T) {
+	geo := &GeoFilter{policy: geoip.NewPolicy(nil, nil, true), countries: make(map[string]*CountryStats)}
+
+	_, allowed := geo.Admit("192.0.2.1")
+	assert.False(t, allowed)
+	assert.Equal(t, []CountryStats{{Country: geoip.Unknown, Refused: 1}}, geo.Breakdown())
+}
+
+func TestGeoFilter_Disabled(t *testing.T) {
+	geo, err := NewGeoFilter(&config.GeoIPConfig{Enabled: false})
+	require.NoError(t, err)
+	assert.Nil(t, geo)
+
+	country, allowed := geo.Admit("192.0.2.1")
+	assert.True(t, allowed)
+	assert.Empty(t, country)
+	assert.Empty(t, geo.Country("192.0.2.1"))
+	assert.Nil(t, geo.Breakdown())
+	geo.Release(country)
+
+	_, err = NewGeoFilter(&config.GeoIPConfig{Enabled: true, DatabasePath: "testdata/missing.mmdb"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,241 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// handleGroups shows the player's group roster and lets them create, join or
+// leave a group, or look at other groups
+func (p *MenuChoiceProcessor) handleGroups(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	if userInfo == nil {
+		channel.Write([]byte("Please login first to join a group.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	token := p.getAdminToken(sshConn)
+	authClient := p.authManager.authClient
+
+	for {
+		resp, err := authClient.GetGroup(ctx, token, "")
+		if err != nil {
+			p.logger.Error("Failed to get group", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("Failed to load your group. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+		group := resp.Group
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Groups ===\r\n\r\n"))
+		if group != nil {
+			p.writeGroupRoster(channel, group)
+			channel.Write([]byte("\r\n  [b] Browse groups\r\n"))
+			channel.Write([]byte("  [v] View a group\r\n"))
+			channel.Write([]byte("  [x] Leave group\r\n"))
+		} else {
+			channel.Write([]byte("You are not in a group.\r\n\r\n"))
+			channel.Write([]byte("  [c] Create a group\r\n"))
+			channel.Write([]byte("  [j] Join a group\r\n"))
+			channel.Write([]byte("  [b] Browse groups\r\n"))
+			channel.Write([]byte("  [v] View a group\r\n"))
+		}
+		channel.Write([]byte("  [q] Return to main menu\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		switch strings.ToLower(string(buffer[0])) {
+		case "c":
+			if group != nil {
+				continue
+			}
+			err = p.handleCreateGroup(ctx, channel, userInfo, token)
+		case "j":
+			if group != nil {
+				continue
+			}
+			err = p.handleJoinGroup(ctx, channel, userInfo, token)
+		case "x":
+			if group == nil {
+				continue
+			}
+			err = p.handleLeaveGroup(ctx, channel, userInfo, token, group.Name)
+		case "b":
+			err = p.handleBrowseGroups(ctx, channel, userInfo, token)
+		case "v":
+			err = p.handleViewGroup(ctx, channel, token)
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+	}
+}
+
+// writeGroupRoster writes a group's details and members
+func (p *MenuChoiceProcessor) writeGroupRoster(channel ssh.Channel, group *authv1.Group) {
+	channel.Write([]byte(fmt.Sprintf("%s (%d members)\r\n", group.Name, group.MemberCount)))
+	if group.Description != "" {
+		channel.Write([]byte(group.Description + "\r\n"))
+	}
+	channel.Write([]byte("\r\n"))
+	for _, member := range group.Members {
+		role := ""
+		if member.Username == group.Owner {
+			role = "owner"
+		}
+		channel.Write([]byte(fmt.Sprintf("  %-20s joined %s  %s\r\n",
+			member.Username, member.JoinedAt.AsTime().Format("2006-01-02"), role)))
+	}
+}
+
+// handleCreateGroup creates a group owned by the player
+func (p *MenuChoiceProcessor) handleCreateGroup(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	name, err := p.promptForUsername(ctx, channel, "Group name")
+	if err != nil || name == "" {
+		return err
+	}
+	description, err := p.promptForUsername(ctx, channel, "Description (optional)")
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.CreateGroup(ctx, token, name, description)
+	if err != nil {
+		p.logger.Error("Failed to create group", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	p.writeGroupResult(channel, resp, fmt.Sprintf("Created %s", name))
+	p.setUserGroup(userInfo, resp)
+	return nil
+}
+
+// handleJoinGroup adds the player to a group
+func (p *MenuChoiceProcessor) handleJoinGroup(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	name, err := p.promptForUsername(ctx, channel, "Group name")
+	if err != nil || name == "" {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.JoinGroup(ctx, token, name)
+	if err != nil {
+		p.logger.Error("Failed to join group", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	p.writeGroupResult(channel, resp, fmt.Sprintf("Joined %s", name))
+	p.setUserGroup(userInfo, resp)
+	return nil
+}
+
+// handleLeaveGroup removes the player from their group after confirmation
+func (p *MenuChoiceProcessor) handleLeaveGroup(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token, name string) error {
+	channel.Write([]byte(fmt.Sprintf("Leave %s? (y/N): ", name)))
+	buffer := make([]byte, 1)
+	if _, err := channel.Read(buffer); err != nil {
+		return err
+	}
+	channel.Write([]byte("\r\n"))
+	if buffer[0] != 'y' && buffer[0] != 'Y' {
+		return fmt.Errorf("user cancelled")
+	}
+
+	resp, err := p.authManager.authClient.LeaveGroup(ctx, token)
+	if err != nil {
+		p.logger.Error("Failed to leave group", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	p.writeGroupResult(channel, resp, fmt.Sprintf("Left %s", name))
+	if resp.Success {
+		delete(userInfo.Metadata, "group")
+	}
+	return nil
+}
+
+// handleBrowseGroups lists every group
+func (p *MenuChoiceProcessor) handleBrowseGroups(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	resp, err := p.authManager.authClient.ListGroups(ctx, token)
+	if err != nil {
+		p.logger.Error("Failed to list groups", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	if len(resp.Groups) == 0 {
+		channel.Write([]byte("No groups yet.\r\n"))
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("%-30s %7s  %s\r\n", "Group", "Members", "Owner")))
+	for _, group := range resp.Groups {
+		channel.Write([]byte(fmt.Sprintf("%-30s %7d  %s\r\n", group.Name, group.MemberCount, group.Owner)))
+	}
+	return nil
+}
+
+// handleViewGroup shows the roster of a group by name
+func (p *MenuChoiceProcessor) handleViewGroup(ctx context.Context, channel ssh.Channel, token string) error {
+	name, err := p.promptForUsername(ctx, channel, "Group name")
+	if err != nil || name == "" {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.GetGroup(ctx, token, name)
+	if err != nil {
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success || resp.Group == nil {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	channel.Write([]byte("\r\n"))
+	p.writeGroupRoster(channel, resp.Group)
+	return nil
+}
+
+// writeGroupResult reports the outcome of a group change
+func (p *MenuChoiceProcessor) writeGroupResult(channel ssh.Channel, resp *authv1.GroupResponse, success string) {
+	if resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", success)))
+	} else {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+	}
+}
+
+// setUserGroup keeps the menu in step with a group change until the next
+// login reloads the user
+func (p *MenuChoiceProcessor) setUserGroup(userInfo *authv1.User, resp *authv1.GroupResponse) {
+	if !resp.Success || resp.Group == nil {
+		return
+	}
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["group"] = resp.Group.Name
+}
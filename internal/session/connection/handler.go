@@ -2,13 +2,20 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
-	"strings"
 	"time"
 
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/proxyproto"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -22,8 +29,18 @@ type Handler struct {
 	serviceHealthChecker *ServiceHealthChecker
 	menuHandler          *menu.MenuHandler
 	authHandler          *SSHAuthHandler
+	gameClient           *client.GameClient
 	logger               *slog.Logger
 	idleRetryInterval    time.Duration
+	guard                *panicGuard
+	pause                *messagePause
+	liveness             LivenessConfig
+	livenessRecorder     LivenessRecorder
+	blocker              *IPBlocker
+	geo                  *GeoFilter
+	realms               *realm.Resolver
+	playTimeouts         map[PlayState]time.Duration
+	playHooks            []PlayStateHook
 }
 
 // NewHandler creates a new connection handler
@@ -33,7 +50,24 @@ func NewHandler(manager *Manager, gameClient *client.GameClient, authClient *cli
 	gameIOHandler := NewGameIOHandler(gameClient, logger)
 	spectatingHandler := NewSpectatingHandler(gameClient, logger)
 	serviceHealthChecker := NewServiceHealthChecker(authClient, gameClient, menuHandler, logger)
-	menuChoiceProcessor := NewMenuChoiceProcessor(authManager, gameIOHandler, spectatingHandler, menuHandler, logger)
+	dumplogHandler := NewDumplogHandler(gameClient, logger)
+	menuChoiceProcessor := NewMenuChoiceProcessor(authManager, gameIOHandler, spectatingHandler, dumplogHandler, menuHandler, logger)
+
+	// One guard for every goroutine of a connection, so SetPanicRecorder reaches them all
+	guard := newPanicGuard(logger)
+	gameIOHandler.guard = guard
+	spectatingHandler.guard = guard
+
+	// One pause for every menu, so SetMenuErrorPause reaches them all
+	pause := newMessagePause()
+	authManager.pause = pause
+	gameIOHandler.pause = pause
+	spectatingHandler.pause = pause
+	dumplogHandler.pause = pause
+	menuChoiceProcessor.pause = pause
+
+	// The who list comes from the players the manager tracks
+	menuChoiceProcessor.manager = manager
 
 	return &Handler{
 		manager:              manager,
@@ -44,36 +78,180 @@ func NewHandler(manager *Manager, gameClient *client.GameClient, authClient *cli
 		serviceHealthChecker: serviceHealthChecker,
 		menuHandler:          menuHandler,
 		authHandler:          authHandler,
+		gameClient:           gameClient,
 		logger:               logger,
 		idleRetryInterval:    idleRetryInterval,
+		guard:                guard,
+		pause:                pause,
+	}
+}
+
+// SetMenuErrorPause sets how long menus show an error before moving on.
+// Brief notices show for half as long and longer messages for half as long again.
+func (h *Handler) SetMenuErrorPause(pause time.Duration) {
+	h.pause.errorPause = pause
+	h.menuHandler.SetErrorPause(pause)
+}
+
+// SetWatchdog sets the watchdog counting the goroutines of each connection subsystem
+func (h *Handler) SetWatchdog(watchdog *Watchdog) {
+	h.guard.watchdog = watchdog
+}
+
+// SetPanicRecorder sets where panics recovered in connection goroutines are counted
+func (h *Handler) SetPanicRecorder(recorder PanicRecorder) {
+	h.guard.recorder = recorder
+}
+
+// SetLiveness sets when idle and unresponsive connections are closed
+func (h *Handler) SetLiveness(config LivenessConfig) {
+	h.liveness = config
+}
+
+// SetLivenessRecorder sets where stale connections closed and idle warnings are counted
+func (h *Handler) SetLivenessRecorder(recorder LivenessRecorder) {
+	h.livenessRecorder = recorder
+}
+
+// SetIPBlocker sets the blocker that refuses addresses which keep failing
+// authentication, and records the failures that feed it
+func (h *Handler) SetIPBlocker(blocker *IPBlocker) {
+	h.blocker = blocker
+	h.authManager.blocker = blocker
+	if h.authHandler != nil {
+		h.authHandler.blocker = blocker
 	}
 }
 
+// SetGeoFilter sets the filter that tags connections with their country and
+// refuses countries the configuration denies
+func (h *Handler) SetGeoFilter(geo *GeoFilter) {
+	h.geo = geo
+	h.authManager.geo = geo
+	h.menuChoiceProcessor.geo = geo
+}
+
+// SetBuild sets the session service's build, compared with the auth and game
+// services' on the server statistics screen
+func (h *Handler) SetBuild(build buildinfo.Info) {
+	h.menuChoiceProcessor.build = build
+}
+
+// SetFeatureFlags sets the feature flags, listed on the server statistics screen
+func (h *Handler) SetFeatureFlags(flags *featureflags.Flags) {
+	h.menuChoiceProcessor.flags = flags
+}
+
+// SetLatencyTracker sets the tracker measuring the echo latency of game sessions
+func (h *Handler) SetLatencyTracker(tracker *LatencyTracker) {
+	h.gameIOHandler.latency = tracker
+}
+
+// SetRegistration sets the questions and welcome screens for players who register
+func (h *Handler) SetRegistration(registration *config.RegistrationConfig) {
+	h.authManager.registration = registration
+}
+
+// SetSpectatorPrompt enables asking players whether to allow spectators when a game starts
+func (h *Handler) SetSpectatorPrompt(enabled bool) {
+	h.gameIOHandler.SetSpectatorPrompt(enabled)
+}
+
+// SetSpectatorTimeout stops spectators watching after timeout without input,
+// warning them warning before
+func (h *Handler) SetSpectatorTimeout(timeout, warning time.Duration) {
+	h.spectatingHandler.SetIdleTimeout(timeout, warning)
+}
+
+// SetSpectatorRecorder sets where idle spectators warned and evicted are counted
+func (h *Handler) SetSpectatorRecorder(recorder SpectatorRecorder) {
+	h.spectatingHandler.SetRecorder(recorder)
+}
+
+// SetTerminalPolicy sets the terminal types passed through to games and the fallback for unknown terminals
+func (h *Handler) SetTerminalPolicy(supported []string, defaultTerminal string) {
+	h.gameIOHandler.SetTerminalPolicy(supported, defaultTerminal)
+}
+
+// SetInputFilters sets how player input is filtered for each game
+func (h *Handler) SetInputFilters(filters *InputFilters) {
+	h.gameIOHandler.SetInputFilters(filters)
+	h.spectatingHandler.SetInputFilters(filters)
+}
+
 // HandleConnection handles an SSH connection
 func (h *Handler) HandleConnection(ctx context.Context, conn net.Conn, config *ssh.ServerConfig) {
+	// Deferred first so the connection is closed and unregistered before the panic is recovered
+	defer h.guard.recover("ssh_connection", conn.RemoteAddr().String(), nil)
+	defer h.guard.track("ssh_connection")()
 	defer conn.Close()
 
+	// Refused before the handshake so blocked addresses cost next to nothing
+	clientIP := getIPFromAddr(conn.RemoteAddr())
+	if h.blocker.RefuseConnection(clientIP) {
+		h.logger.Debug("Refusing connection from blocked address", "client_ip", clientIP)
+		return
+	}
+	country, allowed := h.geo.Admit(clientIP)
+	if !allowed {
+		h.logger.Info("Refusing connection from denied country", "client_ip", clientIP, "country", country)
+		return
+	}
+	defer h.geo.Release(country)
+
 	// Register connection
-	connID := h.manager.RegisterConnection(conn)
-	if connID == "" {
-		h.logger.Warn("Failed to register connection", "remote_addr", conn.RemoteAddr())
+	connID, err := h.manager.Admit(conn)
+	if errors.Is(err, ErrServerFull) {
+		h.refuseServerFull(conn, config)
+		return
+	}
+	if err != nil {
+		h.logger.Warn("Failed to register connection", "remote_addr", conn.RemoteAddr(), "error", err)
 		return
 	}
 	defer h.manager.UnregisterConnection(connID, conn.RemoteAddr())
 
+	// The connection ID doubles as the request ID of every call made for the
+	// connection, so its logs can be found in every service
+	ctx = logging.WithRequestID(ctx, connID)
+
+	// The realm reached decides whose accounts the handshake checks
+	connRealm := h.realms.ForConnection(conn.LocalAddr(), proxyproto.Authority(conn))
+
 	// Perform SSH handshake
-	sshConn, chans, reqs, err := ssh.NewServerConn(conn, config)
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, h.realmServerConfig(config, connRealm))
 	if err != nil {
 		h.logger.Error("Failed SSH handshake", "error", err, "connection_id", connID)
 		return
 	}
 	defer sshConn.Close()
-
-	h.logger.Info("SSH connection established", "connection_id", connID, "user", sshConn.User())
+	h.rememberDevice(sshConn)
+
+	// A user@realm login moves the connection to that realm, whose games,
+	// players and banners are all the menus show from here on
+	connRealm, _ = h.realms.ForLogin(sshConn.User(), connRealm)
+	ctx = realm.WithRealm(ctx, connRealm)
+
+	h.logger.Info("SSH connection established", "connection_id", connID, "user", sshConn.User(), "remote_addr", sshConn.RemoteAddr(), "country", country, "realm", connRealm.GetName())
+
+	// Close the connection once it goes idle or stops answering keepalives
+	liveness := newConnLiveness(h.liveness, h.livenessRecorder, connID, h.logger)
+	livenessCtx, stopLiveness := context.WithCancel(ctx)
+	defer stopLiveness()
+	go func() {
+		defer h.guard.recover("ssh_liveness", connID, nil)
+		defer h.guard.track("ssh_liveness")()
+		liveness.run(livenessCtx, sshConn)
+	}()
 
 	// Handle SSH channels and requests
-	go h.handleRequests(ctx, reqs, connID)
-	h.handleChannels(ctx, chans, connID, sshConn)
+	go func() {
+		// Nothing else reads the connection's requests, so drop it if this panics
+		defer h.guard.recover("ssh_requests", connID, func() { sshConn.Close() })
+		defer h.guard.track("ssh_requests")()
+		h.handleRequests(ctx, reqs, connID)
+	}()
+	h.handleChannels(ctx, chans, connID, sshConn, liveness)
 }
 
 // handleRequests handles SSH requests
@@ -89,7 +267,7 @@ func (h *Handler) handleRequests(ctx context.Context, reqs <-chan *ssh.Request,
 			}
 		case "env":
 			// Handle environment variable setting
-			success := h.handleEnvironmentRequest(req.Payload, connID)
+			_, _, success := h.handleEnvironmentRequest(req.Payload, connID)
 			if req.WantReply {
 				req.Reply(success, nil)
 			}
@@ -103,17 +281,17 @@ func (h *Handler) handleRequests(ctx context.Context, reqs <-chan *ssh.Request,
 }
 
 // handleEnvironmentRequest handles SSH environment variable requests
-func (h *Handler) handleEnvironmentRequest(payload []byte, connID string) bool {
+func (h *Handler) handleEnvironmentRequest(payload []byte, connID string) (string, string, bool) {
 	if len(payload) < 8 {
 		h.logger.Warn("Invalid environment request payload", "connection_id", connID, "payload_length", len(payload))
-		return false
+		return "", "", false
 	}
 
 	// Parse SSH env request format: 4 bytes name length + name + 4 bytes value length + value
 	nameLen := int(payload[0])<<24 | int(payload[1])<<16 | int(payload[2])<<8 | int(payload[3])
 	if len(payload) < 4+nameLen+4 {
 		h.logger.Warn("Invalid environment request: insufficient data for name", "connection_id", connID)
-		return false
+		return "", "", false
 	}
 
 	name := string(payload[4 : 4+nameLen])
@@ -122,7 +300,7 @@ func (h *Handler) handleEnvironmentRequest(payload []byte, connID string) bool {
 	valueLen := int(payload[valueStart])<<24 | int(payload[valueStart+1])<<16 | int(payload[valueStart+2])<<8 | int(payload[valueStart+3])
 	if len(payload) < valueStart+4+valueLen {
 		h.logger.Warn("Invalid environment request: insufficient data for value", "connection_id", connID)
-		return false
+		return "", "", false
 	}
 
 	value := string(payload[valueStart+4 : valueStart+4+valueLen])
@@ -132,17 +310,17 @@ func (h *Handler) handleEnvironmentRequest(payload []byte, connID string) bool {
 	// Store the environment variable in the auth handler
 	h.authHandler.SetEnvironmentVariable(name, value)
 
-	return true
+	return name, value, true
 }
 
 // handleChannels handles SSH channels
-func (h *Handler) handleChannels(ctx context.Context, chans <-chan ssh.NewChannel, connID string, sshConn *ssh.ServerConn) {
+func (h *Handler) handleChannels(ctx context.Context, chans <-chan ssh.NewChannel, connID string, sshConn *ssh.ServerConn, liveness *connLiveness) {
 	for newChannel := range chans {
 		h.logger.Debug("Received SSH channel", "type", newChannel.ChannelType(), "connection_id", connID)
 
 		switch newChannel.ChannelType() {
 		case "session":
-			go h.handleSessionChannel(ctx, newChannel, connID, sshConn)
+			go h.handleSessionChannel(ctx, newChannel, connID, sshConn, liveness)
 		default:
 			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
 		}
@@ -150,39 +328,95 @@ func (h *Handler) handleChannels(ctx context.Context, chans <-chan ssh.NewChanne
 }
 
 // handleSessionChannel handles SSH session channels
-func (h *Handler) handleSessionChannel(ctx context.Context, newChannel ssh.NewChannel, connID string, sshConn *ssh.ServerConn) {
+func (h *Handler) handleSessionChannel(ctx context.Context, newChannel ssh.NewChannel, connID string, sshConn *ssh.ServerConn, liveness *connLiveness) {
+	// Deferred first so the channel's own cleanup closes it before the panic is recovered
+	defer h.guard.recover("ssh_channel", connID, nil)
+	defer h.guard.track("ssh_channel")()
+
 	channel, requests, err := newChannel.Accept()
 	if err != nil {
 		h.logger.Error("Failed to accept channel", "error", err, "connection_id", connID)
 		return
 	}
+	channel, untrack := liveness.track(channel)
+	defer untrack()
+	// Who logs in on the channel and what they do is shown in the who list
+	channelPresence, leavePresence := h.manager.joinPresence()
+	defer leavePresence()
+	ctx = withPresence(ctx, channelPresence)
+	// The API subsystem's output is JSON, so its screen is not cleared on exit
+	var apiMode bool
 	defer func() {
-		// Clear screen on exit
-		channel.Write([]byte("\033[2J")) // Clear screen
-		channel.Write([]byte("\033[H"))  // Move cursor to home
+		if !apiMode {
+			// Clear screen on exit
+			channel.Write([]byte("\033[2J")) // Clear screen
+			channel.Write([]byte("\033[H"))  // Move cursor to home
+		}
 		channel.Close()
 	}()
 
 	// Handle session requests
 	var sessionID string
 	var terminalCols, terminalRows int = 80, 24
+	var hasPTY bool
+	clientTerm := ClientTerminal{Type: h.gameIOHandler.NegotiateTerminal(""), RemoteIP: getIPFromAddr(sshConn.RemoteAddr())}
+
+	// The user this channel counts against the concurrent session limit
+	var loginUsername string
+	defer func() {
+		if loginUsername != "" {
+			h.manager.ReleaseLogin(ctx, loginUsername)
+		}
+	}()
 
 	for req := range requests {
 		switch req.Type {
 		case "pty-req":
 			// Parse PTY request
 			if len(req.Payload) > 0 {
-				terminalCols, terminalRows = h.gameIOHandler.ParsePTYRequest(req.Payload)
+				var requestedTerm string
+				requestedTerm, terminalCols, terminalRows = h.gameIOHandler.ParsePTYRequest(req.Payload)
+				clientTerm.Cols, clientTerm.Rows = terminalCols, terminalRows
+				clientTerm.Type = h.gameIOHandler.NegotiateTerminal(requestedTerm)
+				h.logger.Debug("Negotiated terminal type", "connection_id", connID, "requested", requestedTerm, "term", clientTerm.Type)
 			}
+			hasPTY = true
 			req.Reply(true, nil)
 
 		case "env":
 			// Handle environment variable setting
-			success := h.handleEnvironmentRequest(req.Payload, connID)
+			name, value, success := h.handleEnvironmentRequest(req.Payload, connID)
+			if success {
+				clientTerm.SetEnv(name, value)
+			}
 			if req.WantReply {
 				req.Reply(success, nil)
 			}
 
+		case "exec":
+			// Run a quick action such as `ssh -t host nethack` instead of the menus
+			command, ok := parseExecPayload(req.Payload)
+			req.Reply(ok, nil)
+			if !ok {
+				continue
+			}
+			status := h.handleExec(ctx, channel, command, connID, sshConn, terminalCols, terminalRows, clientTerm, hasPTY, &loginUsername)
+			sendExitStatus(channel, status)
+			return
+
+		case "subsystem":
+			// Serve the JSON API to bots and scripts: `ssh -s host dg-api`
+			name, ok := parseSubsystemPayload(req.Payload)
+			if !ok || name != APISubsystem {
+				req.Reply(false, nil)
+				continue
+			}
+			req.Reply(true, nil)
+			apiMode = true
+			h.serveAPI(ctx, channel, connID, sshConn, clientTerm, &loginUsername)
+			sendExitStatus(channel, execStatusOK)
+			return
+
 		case "shell":
 			// Start shell session
 			req.Reply(true, nil)
@@ -191,116 +425,9 @@ func (h *Handler) handleSessionChannel(ctx context.Context, newChannel ssh.NewCh
 			channel.Write([]byte("\033[2J")) // Clear entire screen
 			channel.Write([]byte("\033[H"))  // Move cursor to home position (1,1)
 
-			// Check service health at connection time
-			servicesHealthy, serviceStatus := h.serviceHealthChecker.CheckServiceHealth(ctx)
-			if !servicesHealthy {
-				h.logger.Warn("Required services unavailable at connection time", "username", sshConn.User(), "services", serviceStatus)
-				err := h.serviceHealthChecker.HandleServiceUnavailable(ctx, channel, connID, sshConn.User())
-				if err != nil && err.Error() == "user quit" {
-					return // User quit or timeout
-				}
-				return // Service unavailable handled
-			}
-
-			// Get user info from auth service
-			userInfo, err := h.authManager.GetUserInfo(ctx, sshConn)
-			if err != nil {
-				h.logger.Debug("No authenticated user info available, checking for DGAUTH", "error", err, "username", sshConn.User())
-				
-				// Try DGAUTH environment-based authentication if not already authenticated
-				permissions, dgauthErr := h.authHandler.authenticateWithDGAUTH(ctx)
-				if dgauthErr == nil && permissions != nil {
-					// DGAUTH authentication successful, update SSH connection permissions
-					if sshConn.Permissions == nil {
-						sshConn.Permissions = permissions
-					} else {
-						// Merge permissions
-						for k, v := range permissions.Extensions {
-							sshConn.Permissions.Extensions[k] = v
-						}
-					}
-					// Try to get user info again with the new auth
-					userInfo, err = h.authManager.GetUserInfo(ctx, sshConn)
-					if err != nil {
-						h.logger.Warn("Failed to get user info after DGAUTH", "error", err)
-						userInfo = nil
-					}
-				} else {
-					h.logger.Debug("DGAUTH authentication not available or failed", "error", dgauthErr)
-					userInfo = nil // Treat as anonymous user
-				}
-			}
-
-			// Main menu loop
-			for {
-				// Refresh user info before showing menu (in case user just logged in)
-				currentUserInfo, err := h.authManager.GetUserInfo(ctx, sshConn)
-				if err == nil && currentUserInfo != nil {
-					userInfo = currentUserInfo // Update user info if available
-				}
-
-				// Show main menu (anonymous or authenticated)
-				var menuChoice *menu.MenuChoice
-				if userInfo == nil || userInfo.Id == "" {
-					// Show anonymous menu
-					menuChoice, err = h.menuHandler.ShowAnonymousMenu(ctx, channel, sshConn.User())
-				} else {
-					// Check if user needs to change password (one-time password)
-					if userInfo.Metadata != nil && userInfo.Metadata["require_password_change"] == "true" {
-						// Force password change for one-time passwords
-						menuChoice, err = h.authManager.HandleRequiredPasswordChange(ctx, channel, userInfo, sshConn)
-						if err != nil {
-							h.logger.Error("Error handling required password change", "error", err, "username", userInfo.Username)
-							continue // Return to menu
-						}
-						if menuChoice != nil && menuChoice.Action == "quit" {
-							return // User chose to quit
-						}
-						// Password changed successfully, refresh user info and continue
-						continue
-					}
-
-					// Show authenticated user menu (or admin menu if user is admin)
-					menuChoice, err = h.menuHandler.ShowUserMenu(ctx, channel, userInfo)
-				}
-
-				if err != nil {
-					// Check if this is a graceful disconnection (EOF) or user quit
-					if strings.Contains(err.Error(), "EOF") || err.Error() == "user quit" {
-						return // Normal disconnect, don't log as error
-					}
-					h.logger.Error("Error in menu handler", "error", err, "username", sshConn.User())
-					if ctx.Err() != nil {
-						return // Context cancelled
-					}
-					continue // Redisplay menu
-				}
-
-				// Handle menu choice
-				if err := h.menuChoiceProcessor.HandleMenuChoice(ctx, channel, menuChoice, userInfo, connID, sshConn.User(), terminalCols, terminalRows, sshConn); err != nil {
-					if err.Error() == "user quit" {
-						return // User chose to quit
-					}
-					// Check for service unavailable error
-					if err.Error() == "game service unavailable" {
-						err := h.serviceHealthChecker.HandleServiceUnavailable(ctx, channel, connID, sshConn.User())
-						if err != nil && err.Error() == "user quit" {
-							return // User quit or timeout
-						}
-						continue // Return to menu after service unavailable handling
-					}
-					h.logger.Error("Error handling menu choice", "error", err, "choice", menuChoice.Action, "username", sshConn.User())
-					if ctx.Err() != nil {
-						return // Context cancelled
-					}
-					continue // Return to menu
-				}
-
-				// If we get here, the menu choice was handled successfully
-				// Some choices (like quit) will have returned from the function
-				// Others (like play) will have started a game session
-				// Continue the loop to show the menu again (unless user quit)
-			}
+			// Run the menus, screens and games until the player quits
+			h.runPlaySession(ctx, channel, connID, sshConn, terminalCols, terminalRows, clientTerm, &loginUsername)
+			return
 
 		case "window-change":
 			// Handle terminal resize
@@ -329,3 +456,36 @@ func (h *Handler) handleSessionChannel(ctx context.Context, newChannel ssh.NewCh
 		// Future: Implement session timeout/cleanup after extended inactivity
 	}
 }
+
+// resolveUser returns the user the connection is authenticated as, trying
+// DGAUTH from the environment when it is not; nil for anonymous connections
+func (h *Handler) resolveUser(ctx context.Context, sshConn *ssh.ServerConn) *authv1.User {
+	userInfo, err := h.authManager.GetUserInfo(ctx, sshConn)
+	if err != nil {
+		h.logger.Debug("No authenticated user info available, checking for DGAUTH", "error", err, "username", sshConn.User())
+
+		// Try DGAUTH environment-based authentication if not already authenticated
+		permissions, dgauthErr := h.authHandler.authenticateWithDGAUTH(ctx, getIPFromAddr(sshConn.RemoteAddr()), connectionDevice(sshConn))
+		if dgauthErr == nil && permissions != nil {
+			// DGAUTH authentication successful, update SSH connection permissions
+			if sshConn.Permissions == nil {
+				sshConn.Permissions = permissions
+			} else {
+				// Merge permissions
+				for k, v := range permissions.Extensions {
+					sshConn.Permissions.Extensions[k] = v
+				}
+			}
+			// Try to get user info again with the new auth
+			userInfo, err = h.authManager.GetUserInfo(ctx, sshConn)
+			if err != nil {
+				h.logger.Warn("Failed to get user info after DGAUTH", "error", err)
+				userInfo = nil
+			}
+		} else {
+			h.logger.Debug("DGAUTH authentication not available or failed", "error", dgauthErr)
+			userInfo = nil // Treat as anonymous user
+		}
+	}
+	return userInfo
+}
@@ -8,9 +8,9 @@ import (
 	"testing"
 	"time"
 
-	"github.com/dungeongate/internal/session/banner"
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/pkg/banner"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
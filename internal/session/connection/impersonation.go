@@ -0,0 +1,151 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// impersonatorTokenExtension holds the admin's own access token while they
+// act as another user; the connection's access token is the user's
+const impersonatorTokenExtension = "impersonator_token"
+
+// defaultImpersonationMinutes is offered when the admin does not choose a duration
+const defaultImpersonationMinutes = 15
+
+// isImpersonating reports whether an admin on the connection is acting as another user
+func isImpersonating(sshConn *ssh.ServerConn) bool {
+	if sshConn == nil || sshConn.Permissions == nil {
+		return false
+	}
+	return sshConn.Permissions.Extensions[impersonatorTokenExtension] != ""
+}
+
+// refuseWhileImpersonating tells the admin that games cannot be played as the
+// impersonated user, and reports whether it did
+func refuseWhileImpersonating(channel ssh.Channel, sshConn *ssh.ServerConn, pause *messagePause) bool {
+	if !isImpersonating(sshConn) {
+		return false
+	}
+	channel.Write([]byte("Games cannot be played while impersonating a user.\r\n"))
+	// Brief pause to let user read the message
+	pause.error()
+	return true
+}
+
+// handleAdminImpersonate switches the connection to a user's account for a
+// limited time so support sees the menus exactly as the user does. The admin
+// must give a reason, which the user is shown afterwards.
+func (p *MenuChoiceProcessor) handleAdminImpersonate(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	if userInfo == nil || !userInfo.IsAdmin {
+		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte("\033[2J\033[H")) // Clear screen
+	channel.Write([]byte("=== Impersonate User ===\r\n\r\n"))
+	channel.Write([]byte("You will see the menus as the user does until the time runs out or you quit.\r\n"))
+	channel.Write([]byte("The user is told who accessed their account and why.\r\n\r\n"))
+
+	targetUsername, err := p.promptForUsername(ctx, channel, "Username")
+	if err != nil || targetUsername == "" {
+		return nil
+	}
+
+	reason, err := p.promptForUsername(ctx, channel, "Reason")
+	if err != nil {
+		return nil
+	}
+	if reason == "" {
+		channel.Write([]byte("A reason is required.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	minutesInput, err := p.promptForUsername(ctx, channel, fmt.Sprintf("Minutes [%d]", defaultImpersonationMinutes))
+	if err != nil {
+		return nil
+	}
+	minutes := defaultImpersonationMinutes
+	if minutesInput != "" {
+		if minutes, err = strconv.Atoi(minutesInput); err != nil || minutes <= 0 {
+			channel.Write([]byte("Invalid number of minutes.\r\n"))
+			p.pause.error()
+			return nil
+		}
+	}
+
+	adminToken := p.getAdminToken(sshConn)
+	resp, err := p.authManager.authClient.ImpersonateUser(ctx, adminToken, targetUsername, reason, time.Duration(minutes)*time.Minute)
+	if err != nil {
+		p.logger.Error("Failed to impersonate user", "error", err, "admin", userInfo.Username, "target", targetUsername)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		p.pause.long()
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		p.pause.long()
+		return nil
+	}
+
+	sshConn.Permissions.Extensions[impersonatorTokenExtension] = adminToken
+	sshConn.Permissions.Extensions["access_token"] = resp.AccessToken
+
+	expiresAt := time.Unix(resp.ExpiresAt, 0)
+	p.logger.Info("Admin started impersonating user",
+		"audit", true,
+		"admin", userInfo.Username,
+		"target", targetUsername,
+		"reason", reason,
+		"expires_at", expiresAt)
+
+	channel.Write([]byte(fmt.Sprintf("\r\n✓ Viewing as %s until %s. Quit from the menu to return to your account.\r\n",
+		targetUsername, expiresAt.Format("15:04"))))
+	p.pause.error()
+	return nil
+}
+
+// endImpersonation returns the connection to the admin's own account
+func (p *MenuChoiceProcessor) endImpersonation(channel ssh.Channel, sshConn *ssh.ServerConn, message string) {
+	adminToken := sshConn.Permissions.Extensions[impersonatorTokenExtension]
+	delete(sshConn.Permissions.Extensions, impersonatorTokenExtension)
+	sshConn.Permissions.Extensions["access_token"] = adminToken
+
+	p.logger.Info("Admin stopped impersonating user", "audit", true, "connection_user", sshConn.User(), "reason", message)
+	channel.Write([]byte(fmt.Sprintf("\r\n%s Returning to your account.\r\n", message)))
+	p.pause.error()
+}
+
+// accountAccessLines describes when support staff used the player's
+// account, newest first. Loading them marks them seen.
+func (p *MenuChoiceProcessor) accountAccessLines(ctx context.Context, userInfo *authv1.User, sshConn *ssh.ServerConn) []string {
+	resp, err := p.authManager.authClient.ListAccountAccess(ctx, p.getAdminToken(sshConn))
+	if err != nil || !resp.Success {
+		p.logger.Warn("Failed to load account access", "error", err, "username", userInfo.Username)
+		return nil
+	}
+	if !isImpersonating(sshConn) {
+		delete(userInfo.Metadata, "unseen_account_access")
+	}
+
+	var lines []string
+	for i, entry := range resp.Entries {
+		if i == 5 {
+			lines = append(lines, fmt.Sprintf("  ... and %d earlier", len(resp.Entries)-i))
+			break
+		}
+		marker := " "
+		if !entry.Seen {
+			marker = "*"
+		}
+		lines = append(lines, fmt.Sprintf(" %s %s  %-12s %s",
+			marker, entry.CreatedAt.AsTime().Local().Format("2006-01-02 15:04"), entry.AdminUsername, entry.Reason))
+	}
+	return lines
+}
@@ -0,0 +1,84 @@
+package connection
+
+import (
+	"fmt"
+
+	"github.com/dungeongate/internal/session/terminal"
+	"github.com/dungeongate/pkg/config"
+)
+
+// InputFilters holds the input filter settings of every game. A game without
+// its own settings uses the global ones.
+type InputFilters struct {
+	defaults terminal.FilterOptions
+	games    map[string]terminal.FilterOptions
+}
+
+// NewInputFilters converts the configured input filters, checking that every
+// key can be parsed. A nil config keeps the default escape key and filters
+// nothing.
+func NewInputFilters(cfg *config.InputFilterConfig) (*InputFilters, error) {
+	filters := &InputFilters{games: make(map[string]terminal.FilterOptions)}
+	if cfg == nil {
+		return filters, nil
+	}
+
+	filters.defaults = filterOptions(cfg)
+	if _, err := terminal.NewInputFilter(filters.defaults); err != nil {
+		return nil, err
+	}
+
+	for gameID, gameCfg := range cfg.Games {
+		if gameCfg == nil {
+			continue
+		}
+		opts := filterOptions(gameCfg)
+		if _, err := terminal.NewInputFilter(opts); err != nil {
+			return nil, fmt.Errorf("game %s: %w", gameID, err)
+		}
+		filters.games[gameID] = opts
+	}
+
+	return filters, nil
+}
+
+// options returns the filter settings of a game
+func (f *InputFilters) options(gameID string) terminal.FilterOptions {
+	if f == nil {
+		return terminal.FilterOptions{}
+	}
+	if opts, ok := f.games[gameID]; ok {
+		return opts
+	}
+	return f.defaults
+}
+
+// NewFilter creates the input filter for one player's session of a game
+func (f *InputFilters) NewFilter(gameID string) *terminal.InputFilter {
+	filter, err := terminal.NewInputFilter(f.options(gameID))
+	if err != nil {
+		// Settings were validated by NewInputFilters
+		filter, _ = terminal.NewInputFilter(terminal.FilterOptions{})
+	}
+	return filter
+}
+
+// StripOSC reports whether OSC sequences are removed for a game
+func (f *InputFilters) StripOSC(gameID string) bool {
+	return f.options(gameID).StripOSC
+}
+
+// filterOptions converts one input filter section
+func filterOptions(cfg *config.InputFilterConfig) terminal.FilterOptions {
+	opts := terminal.FilterOptions{
+		EscapeKey:           cfg.EscapeKey,
+		RedrawKey:           cfg.RedrawKey,
+		StripBracketedPaste: cfg.StripBracketedPaste,
+		MaxPasteBytes:       cfg.MaxPasteBytes,
+		StripOSC:            cfg.StripOSC,
+	}
+	for _, r := range cfg.Rewrite {
+		opts.Rewrite = append(opts.Rewrite, terminal.KeyRewrite{From: r.From, To: r.To})
+	}
+	return opts
+}
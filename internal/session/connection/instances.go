@@ -0,0 +1,41 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// writeSessionInstances shows the load of each session service replica
+// that reported recently, and their sums
+func (p *MenuChoiceProcessor) writeSessionInstances(ctx context.Context, channel ssh.Channel, adminToken string) {
+	resp, err := p.authManager.authClient.ListSessionInstances(ctx, adminToken)
+	if err != nil || !resp.Success || len(resp.Instances) == 0 {
+		if err != nil {
+			p.logger.Warn("Failed to list session instances", "error", err)
+		}
+		return
+	}
+
+	channel.Write([]byte("\r\nSession Instances:\r\n"))
+	channel.Write([]byte(fmt.Sprintf("  %-24s %8s %8s %8s %8s %10s\r\n", "Instance", "Active", "Max", "Games", "Peak", "Reported")))
+	for _, instance := range resp.Instances {
+		channel.Write([]byte(fmt.Sprintf("  %-24s %8d %8d %8d %8d %10s\r\n", clipInstanceID(instance.InstanceId),
+			instance.ConnectionsActive, instance.MaxConnections, instance.GameSessions, instance.ConnectionsPeak,
+			time.Since(instance.ReportedAt.AsTime()).Round(time.Second).String()+" ago")))
+	}
+	if totals := resp.Totals; totals != nil && len(resp.Instances) > 1 {
+		channel.Write([]byte(fmt.Sprintf("  %-24s %8d %8d %8d\r\n", "(cluster)",
+			totals.ConnectionsActive, totals.MaxConnections, totals.GameSessions)))
+	}
+}
+
+// clipInstanceID shortens an instance ID to its column
+func clipInstanceID(id string) string {
+	if runes := []rune(id); len(runes) > 24 {
+		return string(runes[:23]) + "…"
+	}
+	return id
+}
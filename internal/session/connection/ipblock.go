@@ -0,0 +1,264 @@
+package connection
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/proxyproto"
+)
+
+// Reasons an authentication failure is recorded against an address
+const (
+	FailureSSHAuth   = "ssh_auth"
+	FailureLogin     = "login"
+	FailureRegister  = "register"
+	FailureChallenge = "challenge"
+)
+
+// IPBlockRecorder counts blocks imposed and connections refused because of
+// them; metrics.Registry implements it
+type IPBlockRecorder interface {
+	RecordIPBlock(reason string)
+	RecordBlockedConnection()
+}
+
+// IPBlock describes an address currently blocked
+type IPBlock struct {
+	IP     string
+	Until  time.Time
+	Blocks int    // Blocks imposed on the address so far, including this one
+	Reason string // Kind of the failure that triggered the block
+}
+
+// ipOffender is the failure history of one address
+type ipOffender struct {
+	failures     []time.Time
+	blocks       int
+	blockedUntil time.Time
+	lastFailure  time.Time
+	reason       string
+}
+
+// IPBlocker blocks addresses that keep failing authentication, fail2ban
+// style: MaxFailedAttempts failures within FindWindow block the address for
+// LockoutDuration, and each further block doubles that up to
+// MaxLockoutDuration. With Challenge set, blocked addresses are challenged
+// during the SSH handshake rather than refused. A nil IPBlocker blocks
+// nothing.
+type IPBlocker struct {
+	maxFailures int
+	window      time.Duration
+	lockout     time.Duration
+	maxLockout  time.Duration
+	challenge   bool
+	allowlist   []*net.IPNet
+	recorder    IPBlockRecorder
+	logger      *slog.Logger
+	now         func() time.Time
+
+	mu        sync.Mutex
+	offenders map[string]*ipOffender
+	lastPrune time.Time
+}
+
+// NewIPBlocker creates a blocker from the brute force protection settings. It
+// returns nil, blocking nothing, when protection is disabled.
+func NewIPBlocker(cfg *config.BruteForceConfig, logger *slog.Logger) (*IPBlocker, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+	allowlist, err := proxyproto.ParseNetworks(cfg.Allowlist)
+	if err != nil {
+		return nil, fmt.Errorf("invalid brute force allowlist: %w", err)
+	}
+	maxFailures := cfg.MaxFailedAttempts
+	if maxFailures < 1 {
+		maxFailures = 5
+	}
+	return &IPBlocker{
+		maxFailures: maxFailures,
+		window:      cfg.GetFindWindow(),
+		lockout:     cfg.GetLockoutDuration(),
+		maxLockout:  cfg.GetMaxLockoutDuration(),
+		challenge:   cfg.Challenge,
+		allowlist:   allowlist,
+		logger:      logger,
+		now:         time.Now,
+		offenders:   make(map[string]*ipOffender),
+	}, nil
+}
+
+// SetRecorder sets where blocks are counted
+func (b *IPBlocker) SetRecorder(recorder IPBlockRecorder) {
+	if b != nil {
+		b.recorder = recorder
+	}
+}
+
+// IsBlocked reports whether connections from ip are refused, and until when
+func (b *IPBlocker) IsBlocked(ip string) (time.Time, bool) {
+	if b == nil {
+		return time.Time{}, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offender, ok := b.offenders[ip]
+	if !ok || !b.now().Before(offender.blockedUntil) {
+		return time.Time{}, false
+	}
+	return offender.blockedUntil, true
+}
+
+// Challenges reports whether blocked addresses, and accounts locked out
+// after failed logins, are challenged instead of refused
+func (b *IPBlocker) Challenges() bool {
+	return b != nil && b.challenge
+}
+
+// RefuseConnection reports whether a new connection from ip must be closed,
+// counting it when it is. Blocked addresses that are challenged instead are
+// let through to the handshake.
+func (b *IPBlocker) RefuseConnection(ip string) bool {
+	if _, blocked := b.IsBlocked(ip); !blocked || b.challenge {
+		return false
+	}
+	if b.recorder != nil {
+		b.recorder.RecordBlockedConnection()
+	}
+	return true
+}
+
+// RecordFailure records a failed authentication from ip and reports whether
+// the address is now blocked
+func (b *IPBlocker) RecordFailure(ip, reason string) bool {
+	if b == nil || ip == "" || b.isAllowlisted(ip) {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	b.prune(now)
+
+	offender, ok := b.offenders[ip]
+	if !ok {
+		offender = &ipOffender{}
+		b.offenders[ip] = offender
+	}
+	if now.Before(offender.blockedUntil) {
+		return true
+	}
+
+	// Only failures inside the window count towards a block
+	recent := offender.failures[:0]
+	for _, failed := range offender.failures {
+		if now.Sub(failed) < b.window {
+			recent = append(recent, failed)
+		}
+	}
+	offender.failures = append(recent, now)
+	offender.lastFailure = now
+	offender.reason = reason
+
+	if len(offender.failures) < b.maxFailures {
+		return false
+	}
+
+	duration := b.lockout
+	for i := 0; i < offender.blocks && duration < b.maxLockout; i++ {
+		duration *= 2
+	}
+	if duration > b.maxLockout {
+		duration = b.maxLockout
+	}
+	offender.blocks++
+	offender.blockedUntil = now.Add(duration)
+	b.logger.Warn("Blocking address after repeated authentication failures",
+		"ip", ip,
+		"failures", len(offender.failures),
+		"reason", reason,
+		"duration", duration,
+		"block_count", offender.blocks)
+	offender.failures = nil
+	if b.recorder != nil {
+		b.recorder.RecordIPBlock(reason)
+	}
+	return true
+}
+
+// Blocks lists the addresses currently blocked, soonest to expire first
+func (b *IPBlocker) Blocks() []IPBlock {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	var blocks []IPBlock
+	for ip, offender := range b.offenders {
+		if !now.Before(offender.blockedUntil) {
+			continue
+		}
+		blocks = append(blocks, IPBlock{
+			IP:     ip,
+			Until:  offender.blockedUntil,
+			Blocks: offender.blocks,
+			Reason: offender.reason,
+		})
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Until.Before(blocks[j].Until) })
+	return blocks
+}
+
+// Unblock lifts the block on ip and forgets its failures, reporting whether
+// it was blocked
+func (b *IPBlocker) Unblock(ip string) bool {
+	if b == nil {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offender, ok := b.offenders[ip]
+	if !ok {
+		return false
+	}
+	delete(b.offenders, ip)
+	return b.now().Before(offender.blockedUntil)
+}
+
+// isAllowlisted reports whether ip may never be blocked
+func (b *IPBlocker) isAllowlisted(ip string) bool {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return false
+	}
+	for _, network := range b.allowlist {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+// prune forgets addresses that are not blocked and have not failed for
+// longer than the longest block, so escalation only follows repeat offenders.
+// Called with mu held.
+func (b *IPBlocker) prune(now time.Time) {
+	if now.Sub(b.lastPrune) < b.window {
+		return
+	}
+	b.lastPrune = now
+	for ip, offender := range b.offenders {
+		if now.After(offender.blockedUntil) && now.Sub(offender.lastFailure) > b.maxLockout {
+			delete(b.offenders, ip)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package connection
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeIPBlockRecorder struct {
+	blocks  []string
+	refused int
+}
+
+func (r *fakeIPBlockRecorder) RecordIPBlock(reason string) { r.blocks = append(r.blocks, reason) }
+func (r *fakeIPBlockRecorder) RecordBlockedConnection()    { r.refused++ }
+
+func newTestIPBlocker(t *testing.T, cfg *config.BruteForceConfig) (*IPBlocker, *time.Time) {
+	t.Helper()
+	blocker, err := NewIPBlocker(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	require.NotNil(t, blocker)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	blocker.now = func() time.Time { return now }
+	return blocker, &now
+}
+
+func TestIPBlocker_BlocksAfterMaxFailures(t *testing.T) {
+	blocker, now := newTestIPBlocker(t, &config.BruteForceConfig{Enabled: true, MaxFailedAttempts: 3, LockoutDuration: "10m"})
+	recorder := &fakeIPBlockRecorder{}
+	blocker.SetRecorder(recorder)
+
+	assert.False(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	assert.False(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	assert.False(t, blocker.RefuseConnection("192.0.2.1"))
+	assert.True(t, blocker.RecordFailure("192.0.2.1", FailureLogin))
+
+	until, blocked := blocker.IsBlocked("192.0.2.1")
+	assert.True(t, blocked)
+	assert.Equal(t, now.Add(10*time.Minute), until)
+	assert.True(t, blocker.RefuseConnection("192.0.2.1"))
+	assert.False(t, blocker.RefuseConnection("192.0.2.2"))
+	assert.Equal(t, []string{FailureLogin}, recorder.blocks)
+	assert.Equal(t, 1, recorder.refused)
+
+	*now = now.Add(10 * time.Minute)
+	_, blocked = blocker.IsBlocked("192.0.2.1")
+	assert.False(t, blocked)
+}
+
+func TestIPBlocker_OnlyCountsFailuresInWindow(t *testing.T) {
+	blocker, now := newTestIPBlocker(t, &config.BruteForceConfig{Enabled: true, MaxFailedAttempts: 2, FindWindow: "1m"})
+
+	assert.False(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	*now = now.Add(2 * time.Minute)
+	assert.False(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	*now = now.Add(30 * time.Second)
+	assert.True(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+}
+
+func TestIPBlocker_EscalatesRepeatBlocks(t *testing.T) {
+	blocker, now := newTestIPBlocker(t, &config.BruteForceConfig{
+		Enabled:            true,
+		MaxFailedAttempts:  1,
+		LockoutDuration:    "10m",
+		MaxLockoutDuration: "30m",
+	})
+
+	for _, want := range []time.Duration{10 * time.Minute, 20 * time.Minute, 30 * time.Minute, 30 * time.Minute} {
+		require.True(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+		until, blocked := blocker.IsBlocked("192.0.2.1")
+		require.True(t, blocked)
+		assert.Equal(t, want, until.Sub(*now))
+		*now = until
+	}
+
+	blocks := blocker.Blocks()
+	assert.Empty(t, blocks)
+	blocker.RecordFailure("192.0.2.1", FailureRegister)
+	blocks = blocker.Blocks()
+	require.Len(t, blocks, 1)
+	assert.Equal(t, 5, blocks[0].Blocks)
+	assert.Equal(t, FailureRegister, blocks[0].Reason)
+}
+
+func TestIPBlocker_AllowlistAndUnblock(t *testing.T) {
+	blocker, _ := newTestIPBlocker(t, &config.BruteForceConfig{
+		Enabled:           true,
+		MaxFailedAttempts: 1,
+		Allowlist:         []string{"10.0.0.0/8", "2001:db8::1"},
+	})
+
+	assert.False(t, blocker.RecordFailure("10.1.2.3", FailureSSHAuth))
+	assert.False(t, blocker.RecordFailure("2001:db8::1", FailureSSHAuth))
+	assert.Empty(t, blocker.Blocks())
+
+	require.True(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	assert.True(t, blocker.Unblock("192.0.2.1"))
+	assert.False(t, blocker.Unblock("192.0.2.1"))
+	assert.False(t, blocker.RefuseConnection("192.0.2.1"))
+}
+
+func TestIPBlocker_DisabledAndInvalid(t *testing.T) {
+	blocker, err := NewIPBlocker(&config.BruteForceConfig{Enabled: false}, slog.Default())
+	require.NoError(t, err)
+	assert.Nil(t, blocker)
+
+	// A nil blocker blocks nothing
+	assert.False(t, blocker.RecordFailure("192.0.2.1", FailureSSHAuth))
+	assert.False(t, blocker.RefuseConnection("192.0.2.1"))
+	assert.Nil(t, blocker.Blocks())
+	assert.False(t, blocker.Unblock("192.0.2.1"))
+
+	_, err = NewIPBlocker(&config.BruteForceConfig{Enabled: true, Allowlist: []string{"monitoring"}}, slog.Default())
+	assert.Error(t, err)
+}
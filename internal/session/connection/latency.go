@@ -0,0 +1,229 @@
+package connection
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultEchoLatencySLO is the echo latency sessions should stay under
+	// at the 95th percentile when the configuration sets none
+	DefaultEchoLatencySLO = 250 * time.Millisecond
+	// latencyWindow is how many recent echoes a session's percentiles cover
+	latencyWindow = 128
+	// minLatencySamples is how many echoes a session needs before it can be
+	// flagged, so a single slow redraw does not flag it
+	minLatencySamples = 20
+	// maxEchoWait is the longest gap between input and output still counted
+	// as an echo; keys the game does not answer would otherwise be counted
+	// against the next output, however late
+	maxEchoWait = 10 * time.Second
+)
+
+// LatencyRecorder counts echo latencies; metrics.Registry implements it
+type LatencyRecorder interface {
+	RecordEchoLatency(gameID string, latency time.Duration)
+	RecordEchoSLOBreach(gameID string)
+}
+
+// LatencyTracker measures the echo latency of the game sessions on this
+// instance: the time from input arriving over SSH to the first output
+// written back. Sessions whose 95th percentile exceeds the SLO are flagged
+// for admins. A nil LatencyTracker measures nothing.
+type LatencyTracker struct {
+	slo      time.Duration
+	recorder LatencyRecorder
+
+	mu       sync.Mutex
+	sessions map[*SessionLatency]struct{}
+}
+
+// SessionLatency measures the echo latency of one game session
+type SessionLatency struct {
+	tracker   *LatencyTracker
+	sessionID string
+	username  string
+	gameID    string
+	started   time.Time
+
+	mu       sync.Mutex
+	pending  time.Time // Input not yet answered by output
+	samples  [latencyWindow]time.Duration
+	next     int
+	count    int64
+	max      time.Duration
+	breached bool
+}
+
+// LatencyStats describes the echo latency of a game session
+type LatencyStats struct {
+	SessionID string
+	Username  string
+	GameID    string
+	Started   time.Time
+	Samples   int64
+	P50       time.Duration
+	P95       time.Duration
+	Max       time.Duration
+	OverSLO   bool
+}
+
+// NewLatencyTracker creates a tracker flagging sessions whose 95th
+// percentile echo latency exceeds slo, or DefaultEchoLatencySLO
+func NewLatencyTracker(slo time.Duration) *LatencyTracker {
+	if slo <= 0 {
+		slo = DefaultEchoLatencySLO
+	}
+	return &LatencyTracker{slo: slo, sessions: make(map[*SessionLatency]struct{})}
+}
+
+// SetRecorder sets where echo latencies are counted
+func (t *LatencyTracker) SetRecorder(recorder LatencyRecorder) {
+	if t != nil {
+		t.recorder = recorder
+	}
+}
+
+// SLO returns the echo latency sessions should stay under
+func (t *LatencyTracker) SLO() time.Duration {
+	if t == nil {
+		return 0
+	}
+	return t.slo
+}
+
+// Start begins measuring a game session; call End when its I/O ends
+func (t *LatencyTracker) Start(sessionID, username, gameID string) *SessionLatency {
+	if t == nil {
+		return nil
+	}
+	s := &SessionLatency{tracker: t, sessionID: sessionID, username: username, gameID: gameID, started: time.Now()}
+	t.mu.Lock()
+	t.sessions[s] = struct{}{}
+	t.mu.Unlock()
+	return s
+}
+
+// End stops measuring a game session
+func (t *LatencyTracker) End(s *SessionLatency) {
+	if t == nil || s == nil {
+		return
+	}
+	t.mu.Lock()
+	delete(t.sessions, s)
+	t.mu.Unlock()
+}
+
+// Count returns how many game sessions are being measured
+func (t *LatencyTracker) Count() int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.sessions)
+}
+
+// Sessions describes the sessions being measured, those over the SLO first
+func (t *LatencyTracker) Sessions() []LatencyStats {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	stats := make([]LatencyStats, 0, len(t.sessions))
+	for s := range t.sessions {
+		stats = append(stats, s.stats())
+	}
+	t.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].OverSLO != stats[j].OverSLO {
+			return stats[i].OverSLO
+		}
+		return stats[i].P95 > stats[j].P95
+	})
+	return stats
+}
+
+// Input notes input arriving from the player. Only the first input since
+// the last output is timed, so typing ahead does not shorten the echo.
+func (s *SessionLatency) Input(now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.pending.IsZero() {
+		s.pending = now
+	}
+	s.mu.Unlock()
+}
+
+// Output notes output written to the player, completing the echo of any
+// input waiting for it
+func (s *SessionLatency) Output(now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.pending.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	latency := now.Sub(s.pending)
+	s.pending = time.Time{}
+	if latency > maxEchoWait {
+		s.mu.Unlock()
+		return
+	}
+
+	s.samples[s.next] = latency
+	s.next = (s.next + 1) % latencyWindow
+	s.count++
+	if latency > s.max {
+		s.max = latency
+	}
+	newlyBreached := false
+	if !s.breached && s.count >= minLatencySamples && s.percentile(95) > s.tracker.slo {
+		s.breached, newlyBreached = true, true
+	}
+	s.mu.Unlock()
+
+	if recorder := s.tracker.recorder; recorder != nil {
+		recorder.RecordEchoLatency(s.gameID, latency)
+		if newlyBreached {
+			recorder.RecordEchoSLOBreach(s.gameID)
+		}
+	}
+}
+
+// stats describes the session
+func (s *SessionLatency) stats() LatencyStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := LatencyStats{
+		SessionID: s.sessionID,
+		Username:  s.username,
+		GameID:    s.gameID,
+		Started:   s.started,
+		Samples:   s.count,
+		P50:       s.percentile(50),
+		P95:       s.percentile(95),
+		Max:       s.max,
+	}
+	stats.OverSLO = s.count >= minLatencySamples && stats.P95 > s.tracker.slo
+	return stats
+}
+
+// percentile returns the pth percentile of the recent echoes; s.mu must be held
+func (s *SessionLatency) percentile(p int) time.Duration {
+	n := int(min(s.count, latencyWindow))
+	if n == 0 {
+		return 0
+	}
+	recent := make([]time.Duration, n)
+	copy(recent, s.samples[:n])
+	sort.Slice(recent, func(i, j int) bool { return recent[i] < recent[j] })
+	return recent[(n*p-1)/100]
+}
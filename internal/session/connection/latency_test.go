@@ -0,0 +1,102 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeLatencyRecorder struct {
+	echoes, breaches int
+}
+
+func (r *fakeLatencyRecorder) RecordEchoLatency(gameID string, latency time.Duration) {
+	r.echoes++
+}
+
+func (r *fakeLatencyRecorder) RecordEchoSLOBreach(gameID string) {
+	r.breaches++
+}
+
+// echo times an input answered by output after latency
+func echo(s *SessionLatency, now time.Time, latency time.Duration) {
+	s.Input(now)
+	s.Output(now.Add(latency))
+}
+
+func TestLatencyTracker_MeasuresEchoes(t *testing.T) {
+	tracker := NewLatencyTracker(100 * time.Millisecond)
+	recorder := &fakeLatencyRecorder{}
+	tracker.SetRecorder(recorder)
+
+	s := tracker.Start("session-1", "alice", "nethack")
+	now := time.Now()
+	for i := 1; i <= 10; i++ {
+		echo(s, now, time.Duration(i)*time.Millisecond)
+	}
+
+	// Typing ahead times the first key; output without input is not an echo
+	s.Input(now)
+	s.Input(now.Add(40 * time.Millisecond))
+	s.Output(now.Add(50 * time.Millisecond))
+	s.Output(now.Add(time.Second))
+
+	// A key the game never answered is not counted against later output
+	echo(s, now, maxEchoWait+time.Second)
+
+	sessions := tracker.Sessions()
+	require.Len(t, sessions, 1)
+	assert.Equal(t, "alice", sessions[0].Username)
+	assert.Equal(t, int64(11), sessions[0].Samples)
+	assert.Equal(t, 6*time.Millisecond, sessions[0].P50)
+	assert.Equal(t, 50*time.Millisecond, sessions[0].Max)
+	assert.False(t, sessions[0].OverSLO)
+	assert.Equal(t, 11, recorder.echoes)
+
+	tracker.End(s)
+	assert.Empty(t, tracker.Sessions())
+}
+
+func TestLatencyTracker_FlagsSessionsOverSLO(t *testing.T) {
+	tracker := NewLatencyTracker(100 * time.Millisecond)
+	recorder := &fakeLatencyRecorder{}
+	tracker.SetRecorder(recorder)
+
+	fast := tracker.Start("fast", "alice", "nethack")
+	slow := tracker.Start("slow", "bob", "nethack")
+	now := time.Now()
+
+	// Too few echoes to judge
+	for i := 0; i < minLatencySamples-1; i++ {
+		echo(fast, now, 10*time.Millisecond)
+		echo(slow, now, 300*time.Millisecond)
+	}
+	for _, stats := range tracker.Sessions() {
+		assert.False(t, stats.OverSLO)
+	}
+
+	for i := 0; i < 5; i++ {
+		echo(fast, now, 10*time.Millisecond)
+		echo(slow, now, 300*time.Millisecond)
+	}
+	sessions := tracker.Sessions()
+	require.Len(t, sessions, 2)
+	assert.Equal(t, "slow", sessions[0].SessionID)
+	assert.True(t, sessions[0].OverSLO)
+	assert.Equal(t, 300*time.Millisecond, sessions[0].P95)
+	assert.False(t, sessions[1].OverSLO)
+
+	// A breach is reported once per session
+	assert.Equal(t, 1, recorder.breaches)
+}
+
+func TestLatencyTracker_Nil(t *testing.T) {
+	var tracker *LatencyTracker
+	s := tracker.Start("session-1", "alice", "nethack")
+	echo(s, time.Now(), time.Millisecond)
+	tracker.End(s)
+	assert.Empty(t, tracker.Sessions())
+	assert.Zero(t, tracker.SLO())
+}
@@ -0,0 +1,172 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+// leaderboardSize is how many players a leaderboard screen shows
+const leaderboardSize = 15
+
+// handleStatistics shows a game's leaderboard, either across all players or
+// within the player's group
+func (p *MenuChoiceProcessor) handleStatistics(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	gameClient := p.gameIOHandler.gameClient
+
+	games, err := gameClient.ListGames(ctx)
+	if err != nil {
+		p.logger.Error("Failed to list games for statistics", "error", err)
+		channel.Write([]byte("Game statistics are unavailable. Please try again later.\r\n"))
+		p.pause.error()
+		return nil
+	}
+	if len(games) == 0 {
+		channel.Write([]byte("No games are available.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	game := games[0]
+	if len(games) > 1 {
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Game Statistics ===\r\n\r\n"))
+		for i, g := range games {
+			channel.Write([]byte(fmt.Sprintf("  [%d] %s\r\n", i+1, g.Name)))
+		}
+		selection, err := p.promptForUsername(ctx, channel, "\r\nGame")
+		if err != nil || selection == "" {
+			return nil
+		}
+		index, err := strconv.Atoi(selection)
+		if err != nil || index < 1 || index > len(games) {
+			channel.Write([]byte("Invalid selection.\r\n"))
+			p.pause.error()
+			return nil
+		}
+		game = games[index-1]
+	}
+
+	sortBy := "play_time"
+	groupOnly := false
+	buffer := make([]byte, 1)
+	for {
+		var memberIDs []int32
+		groupName := ""
+		if groupOnly {
+			memberIDs, groupName = p.groupMemberIDs(ctx, userInfo, sshConn)
+			if groupName == "" {
+				groupOnly = false
+			}
+		}
+
+		resp, err := gameClient.GetLeaderboard(ctx, game.Id, memberIDs, sortBy, leaderboardSize)
+		if err != nil {
+			p.logger.Error("Failed to get leaderboard", "error", err, "game_id", game.Id)
+			channel.Write([]byte("Failed to load the leaderboard. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+
+		scope := "All players"
+		if groupOnly {
+			scope = "Group " + groupName
+		}
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte(fmt.Sprintf("=== %s Leaderboard ===\r\n", game.Name)))
+		channel.Write([]byte(fmt.Sprintf("%s, %d players, by %s\r\n\r\n", scope, resp.TotalPlayers, strings.ReplaceAll(sortBy, "_", " "))))
+		p.writeLeaderboard(channel, resp.Entries, userInfo)
+
+		channel.Write([]byte("\r\n  [s] Sort by "))
+		if sortBy == "play_time" {
+			channel.Write([]byte("games played\r\n"))
+		} else {
+			channel.Write([]byte("play time\r\n"))
+		}
+		if userInfo != nil && userInfo.Metadata["group"] != "" {
+			if groupOnly {
+				channel.Write([]byte("  [g] Show all players\r\n"))
+			} else {
+				channel.Write([]byte("  [g] Show my group only\r\n"))
+			}
+		}
+		channel.Write([]byte("  [q] Return to main menu\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		switch strings.ToLower(string(buffer[0])) {
+		case "s":
+			if sortBy == "play_time" {
+				sortBy = "games"
+			} else {
+				sortBy = "play_time"
+			}
+		case "g":
+			groupOnly = !groupOnly
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		}
+	}
+}
+
+// writeLeaderboard writes leaderboard rows, marking the player's own
+func (p *MenuChoiceProcessor) writeLeaderboard(channel ssh.Channel, entries []*gamev2.LeaderboardEntry, userInfo *authv1.User) {
+	if len(entries) == 0 {
+		channel.Write([]byte("No games played yet.\r\n"))
+		return
+	}
+
+	channel.Write([]byte(fmt.Sprintf("  %4s  %-20s %6s %10s %10s  %s\r\n", "Rank", "Player", "Games", "Play time", "Longest", "Last played")))
+	for _, entry := range entries {
+		marker := " "
+		if userInfo != nil && entry.Username == userInfo.Username {
+			marker = "*"
+		}
+		lastPlayed := ""
+		if entry.LastPlayed != nil {
+			lastPlayed = entry.LastPlayed.AsTime().Local().Format("2006-01-02")
+		}
+		channel.Write([]byte(fmt.Sprintf("%s %4d  %-20s %6d %10s %10s  %s\r\n",
+			marker, entry.Rank, entry.Username, entry.GamesPlayed,
+			formatPlayTime(entry.TotalPlayTimeSeconds), formatPlayTime(entry.LongestSessionSeconds), lastPlayed)))
+	}
+}
+
+// groupMemberIDs returns the IDs of the player's group members and the group
+// name, or no name when the player is not in a group
+func (p *MenuChoiceProcessor) groupMemberIDs(ctx context.Context, userInfo *authv1.User, sshConn *ssh.ServerConn) ([]int32, string) {
+	if userInfo == nil {
+		return nil, ""
+	}
+	resp, err := p.authManager.authClient.GetGroup(ctx, p.getAdminToken(sshConn), "")
+	if err != nil || !resp.Success || resp.Group == nil {
+		if err != nil {
+			p.logger.Warn("Failed to load group for leaderboard", "error", err, "username", userInfo.Username)
+		}
+		return nil, ""
+	}
+
+	ids := make([]int32, 0, len(resp.Group.Members))
+	for _, member := range resp.Group.Members {
+		id, err := strconv.Atoi(member.UserId)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, int32(id))
+	}
+	return ids, resp.Group.Name
+}
+
+// formatPlayTime renders a duration in seconds as hours and minutes
+func formatPlayTime(seconds int64) string {
+	d := time.Duration(seconds) * time.Second
+	return fmt.Sprintf("%dh%02dm", int(d.Hours()), int(d.Minutes())%60)
+}
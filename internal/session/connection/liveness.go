@@ -0,0 +1,226 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// keepaliveRequest is the global request OpenSSH uses for keepalives. Clients
+// reply to it even though they refuse it, which is all a ping needs.
+const keepaliveRequest = "keepalive@openssh.com"
+
+// Reasons a stale connection is closed, used as metric labels
+const (
+	closeReasonIdle      = "idle_timeout"
+	closeReasonKeepalive = "keepalive_timeout"
+)
+
+// noticeTimeout bounds how long a disconnect notice may take to write, since
+// a stalled client would otherwise keep its connection open
+const noticeTimeout = 2 * time.Second
+
+// LivenessConfig controls how idle and dead SSH connections are closed. Idle
+// here means no input from the player on any channel of the connection; the
+// game service applies its own idle timeout to the games themselves.
+type LivenessConfig struct {
+	IdleTimeout       time.Duration // Zero never closes idle connections
+	IdleWarning       time.Duration // How long before the idle disconnect the player is warned
+	KeepaliveInterval time.Duration // Zero disables keepalive pings
+	KeepaliveCountMax int           // Unanswered pings before the connection is closed
+}
+
+// LivenessRecorder counts stale connections closed and idle warnings sent;
+// metrics.Registry implements it
+type LivenessRecorder interface {
+	RecordSSHStaleClose(reason string)
+	RecordSSHIdleWarning()
+}
+
+// connLiveness watches one SSH connection for player input and keepalive
+// replies, and closes it once it goes idle or stops answering
+type connLiveness struct {
+	config   LivenessConfig
+	recorder LivenessRecorder
+	logger   *slog.Logger
+	connID   string
+	now      func() time.Time
+
+	lastInput atomic.Int64
+
+	mu       sync.Mutex
+	channels map[*activityChannel]struct{}
+}
+
+// newConnLiveness creates the liveness monitor for a new connection
+func newConnLiveness(config LivenessConfig, recorder LivenessRecorder, connID string, logger *slog.Logger) *connLiveness {
+	l := &connLiveness{
+		config:   config,
+		recorder: recorder,
+		logger:   logger,
+		connID:   connID,
+		now:      time.Now,
+		channels: make(map[*activityChannel]struct{}),
+	}
+	l.touch()
+	return l
+}
+
+// touch records player input
+func (l *connLiveness) touch() {
+	l.lastInput.Store(l.now().UnixNano())
+}
+
+// idleFor returns how long the connection has had no input
+func (l *connLiveness) idleFor() time.Duration {
+	return l.now().Sub(time.Unix(0, l.lastInput.Load()))
+}
+
+// track wraps a session channel so reads from it count as input and idle
+// messages reach it. Call the returned function when the channel closes.
+func (l *connLiveness) track(channel ssh.Channel) (ssh.Channel, func()) {
+	tracked := &activityChannel{Channel: channel, liveness: l}
+	l.mu.Lock()
+	l.channels[tracked] = struct{}{}
+	l.mu.Unlock()
+	return tracked, func() {
+		l.mu.Lock()
+		delete(l.channels, tracked)
+		l.mu.Unlock()
+	}
+}
+
+// notify writes a message to every open channel of the connection. The
+// returned channel closes once the message is written.
+func (l *connLiveness) notify(message string) <-chan struct{} {
+	l.mu.Lock()
+	channels := make([]ssh.Channel, 0, len(l.channels))
+	for channel := range l.channels {
+		channels = append(channels, channel.Channel)
+	}
+	l.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, channel := range channels {
+			channel.Write([]byte(message))
+		}
+	}()
+	return done
+}
+
+// run watches the connection until ctx is done or it closes the connection
+func (l *connLiveness) run(ctx context.Context, conn ssh.Conn) {
+	var keepalive <-chan time.Time
+	if l.config.KeepaliveInterval > 0 && l.config.KeepaliveCountMax > 0 {
+		ticker := time.NewTicker(l.config.KeepaliveInterval)
+		defer ticker.Stop()
+		keepalive = ticker.C
+	}
+
+	var idle <-chan time.Time
+	var idleTimer *time.Timer
+	warned := false
+	if l.config.IdleTimeout > 0 {
+		idleTimer = time.NewTimer(l.nextIdleCheck(warned))
+		defer idleTimer.Stop()
+		idle = idleTimer.C
+	}
+
+	replies := make(chan struct{}, 1)
+	unanswered := 0
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-replies:
+			unanswered = 0
+
+		case <-keepalive:
+			if unanswered >= l.config.KeepaliveCountMax {
+				l.logger.Info("Closing unresponsive SSH connection", "connection_id", l.connID, "unanswered_keepalives", unanswered)
+				l.closeStale(conn, closeReasonKeepalive)
+				return
+			}
+			unanswered++
+			go func() {
+				// A dead peer never answers, so the reply is waited for here and
+				// counted against the next tick instead of blocking this loop
+				if _, _, err := conn.SendRequest(keepaliveRequest, true, nil); err == nil {
+					select {
+					case replies <- struct{}{}:
+					default:
+					}
+				}
+			}()
+
+		case <-idle:
+			idleFor := l.idleFor()
+			switch {
+			case idleFor >= l.config.IdleTimeout:
+				l.logger.Info("Closing idle SSH connection", "connection_id", l.connID, "idle_for", idleFor.Round(time.Second))
+				select {
+				case <-l.notify(fmt.Sprintf("\r\n\r\nDisconnected after %s without input.\r\n", l.config.IdleTimeout)):
+				case <-time.After(noticeTimeout):
+				}
+				l.closeStale(conn, closeReasonIdle)
+				return
+			case idleFor >= l.config.IdleTimeout-l.config.IdleWarning && !warned:
+				warned = true
+				l.notify(fmt.Sprintf("\r\n*** No input for %s: you will be disconnected in %s. Press any key to stay connected. ***\r\n",
+					idleFor.Round(time.Second), (l.config.IdleTimeout - idleFor).Round(time.Second)))
+				if l.recorder != nil {
+					l.recorder.RecordSSHIdleWarning()
+				}
+			case idleFor < l.config.IdleTimeout-l.config.IdleWarning:
+				// Input arrived since the warning, so it applies again next time
+				warned = false
+			}
+			idleTimer.Reset(l.nextIdleCheck(warned))
+		}
+	}
+}
+
+// nextIdleCheck returns how long until the connection is due a warning or,
+// once warned, the disconnect, were no input to arrive in the meantime
+func (l *connLiveness) nextIdleCheck(warned bool) time.Duration {
+	deadline := l.config.IdleTimeout
+	if !warned && l.config.IdleWarning > 0 && l.config.IdleWarning < l.config.IdleTimeout {
+		deadline -= l.config.IdleWarning
+	}
+	if wait := deadline - l.idleFor(); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// closeStale counts and closes a connection found idle or unresponsive
+func (l *connLiveness) closeStale(conn ssh.Conn, reason string) {
+	if l.recorder != nil {
+		l.recorder.RecordSSHStaleClose(reason)
+	}
+	conn.Close()
+}
+
+// activityChannel is a session channel whose reads count as player input
+type activityChannel struct {
+	ssh.Channel
+	liveness *connLiveness
+}
+
+// Read reads player input and records it
+func (c *activityChannel) Read(data []byte) (int, error) {
+	n, err := c.Channel.Read(data)
+	if n > 0 {
+		c.liveness.touch()
+	}
+	return n, err
+}
@@ -0,0 +1,148 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+type fakeLivenessConn struct {
+	ssh.Conn
+	answer bool
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeLivenessConn(answer bool) *fakeLivenessConn {
+	return &fakeLivenessConn{answer: answer, closed: make(chan struct{})}
+}
+
+func (c *fakeLivenessConn) SendRequest(name string, wantReply bool, payload []byte) (bool, []byte, error) {
+	if !c.answer {
+		<-c.closed
+		return false, nil, io.EOF
+	}
+	return false, nil, nil
+}
+
+func (c *fakeLivenessConn) Close() error {
+	c.once.Do(func() { close(c.closed) })
+	return nil
+}
+
+type fakeLivenessChannel struct {
+	ssh.Channel
+	mu      sync.Mutex
+	written strings.Builder
+}
+
+func (c *fakeLivenessChannel) Write(data []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.written.Write(data)
+}
+
+func (c *fakeLivenessChannel) output() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.written.String()
+}
+
+type fakeLivenessRecorder struct {
+	mu       sync.Mutex
+	closes   []string
+	warnings int
+}
+
+func (r *fakeLivenessRecorder) RecordSSHStaleClose(reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closes = append(r.closes, reason)
+}
+
+func (r *fakeLivenessRecorder) RecordSSHIdleWarning() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings++
+}
+
+func runLiveness(t *testing.T, config LivenessConfig, conn *fakeLivenessConn, channel ssh.Channel, recorder *fakeLivenessRecorder) (*connLiveness, context.CancelFunc) {
+	t.Helper()
+	liveness := newConnLiveness(config, recorder, "test", slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if channel != nil {
+		liveness.track(channel)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go liveness.run(ctx, conn)
+	return liveness, cancel
+}
+
+func TestLiveness_WarnsThenClosesIdleConnection(t *testing.T) {
+	conn := newFakeLivenessConn(true)
+	channel := &fakeLivenessChannel{}
+	recorder := &fakeLivenessRecorder{}
+	_, cancel := runLiveness(t, LivenessConfig{IdleTimeout: 200 * time.Millisecond, IdleWarning: 100 * time.Millisecond}, conn, channel, recorder)
+	defer cancel()
+
+	select {
+	case <-conn.closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle connection was not closed")
+	}
+	assert.Contains(t, channel.output(), "Press any key to stay connected")
+	assert.Contains(t, channel.output(), "Disconnected after 200ms without input")
+	assert.Equal(t, []string{closeReasonIdle}, recorder.closes)
+	assert.Equal(t, 1, recorder.warnings)
+}
+
+func TestLiveness_InputKeepsConnectionOpen(t *testing.T) {
+	conn := newFakeLivenessConn(true)
+	recorder := &fakeLivenessRecorder{}
+	liveness, cancel := runLiveness(t, LivenessConfig{IdleTimeout: 150 * time.Millisecond}, conn, nil, recorder)
+	defer cancel()
+
+	for i := 0; i < 6; i++ {
+		time.Sleep(50 * time.Millisecond)
+		liveness.touch()
+	}
+	select {
+	case <-conn.closed:
+		t.Fatal("connection with input was closed")
+	default:
+	}
+}
+
+func TestLiveness_ClosesUnresponsiveConnection(t *testing.T) {
+	conn := newFakeLivenessConn(false)
+	recorder := &fakeLivenessRecorder{}
+	_, cancel := runLiveness(t, LivenessConfig{KeepaliveInterval: 20 * time.Millisecond, KeepaliveCountMax: 3}, conn, nil, recorder)
+	defer cancel()
+
+	select {
+	case <-conn.closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("unresponsive connection was not closed")
+	}
+	assert.Equal(t, []string{closeReasonKeepalive}, recorder.closes)
+}
+
+func TestLiveness_AnsweredKeepalivesKeepConnectionOpen(t *testing.T) {
+	conn := newFakeLivenessConn(true)
+	recorder := &fakeLivenessRecorder{}
+	_, cancel := runLiveness(t, LivenessConfig{KeepaliveInterval: 20 * time.Millisecond, KeepaliveCountMax: 2}, conn, nil, recorder)
+	defer cancel()
+
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case <-conn.closed:
+		t.Fatal("responsive connection was closed")
+	default:
+	}
+}
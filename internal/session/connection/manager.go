@@ -2,6 +2,7 @@ package connection
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
 	"strings"
@@ -9,21 +10,57 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/dungeongate/pkg/scheduler"
 	"github.com/google/uuid"
 )
 
+// Reasons Admit refuses a connection
+var (
+	// ErrServerFull means the server already has max_connections connections
+	ErrServerFull = errors.New("server full")
+	// ErrRateLimited means the address has too many connections, or opened
+	// them too quickly
+	ErrRateLimited = errors.New("too many connections from address")
+)
+
+const (
+	// ConnectionCleanupJob is the name of the job forgetting idle addresses
+	ConnectionCleanupJob = "connection_cleanup"
+	// connectionCleanupInterval is how often idle addresses are forgotten
+	connectionCleanupInterval = 5 * time.Minute
+)
+
+// ConnectionRecorder counts connections the manager refuses
+type ConnectionRecorder interface {
+	RecordConnectionRejected(reason string)
+}
+
 // Manager manages connections in a stateless manner
 type Manager struct {
 	maxConnections int
 	logger         *slog.Logger
+	recorder       ConnectionRecorder
 
 	// Atomic counters for stats only
-	activeConnections int64
-	totalConnections  int64
+	activeConnections   int64
+	totalConnections    int64
+	peakConnections     int64
+	rejectedFull        int64
+	rejectedRateLimited int64
 
 	// Rate limiting only (no connection state storage)
 	connectionsByIP sync.Map // map[string]*ipConnectionTracker
 
+	// Logins per user on this instance, for concurrent session limits
+	loginsByUser map[string]*userLoginTracker
+	loginsMu     sync.Mutex
+	loginCounter LoginCounter
+	instanceID   string
+
+	// What the players on this instance are doing, for the who list
+	presences  map[*presence]struct{}
+	presenceMu sync.Mutex
+
 	// NOTE: No connection storage - truly stateless
 	// All connection state is managed by Game Service
 }
@@ -40,19 +77,35 @@ func NewManager(maxConnections int, logger *slog.Logger) *Manager {
 	return &Manager{
 		maxConnections: maxConnections,
 		logger:         logger,
+		loginsByUser:   make(map[string]*userLoginTracker),
+		presences:      make(map[*presence]struct{}),
 	}
 }
 
+// SetRecorder sets where refused connections are counted
+func (m *Manager) SetRecorder(recorder ConnectionRecorder) {
+	m.recorder = recorder
+}
+
 // Start starts the connection manager
 func (m *Manager) Start(ctx context.Context) error {
 	m.logger.Info("Starting connection manager", "max_connections", m.maxConnections)
-
-	// Start cleanup goroutine
-	go m.cleanupLoop(ctx)
-
 	return nil
 }
 
+// AddJobs schedules the manager's background jobs. Its state is the
+// replica's own, so they run on every replica.
+func (m *Manager) AddJobs(jobs *scheduler.Scheduler) error {
+	return jobs.Add(scheduler.Job{
+		Name:     ConnectionCleanupJob,
+		Schedule: scheduler.Every(connectionCleanupInterval),
+		Run: func(context.Context) error {
+			m.cleanup()
+			return nil
+		},
+	})
+}
+
 // Stop stops the connection manager
 func (m *Manager) Stop(ctx context.Context) error {
 	m.logger.Info("Connection manager stopping")
@@ -67,34 +120,62 @@ func (m *Manager) Stop(ctx context.Context) error {
 // RegisterConnection validates and registers a new connection, returns ID
 // Connection state is NOT stored locally - only rate limiting and counters
 func (m *Manager) RegisterConnection(conn net.Conn) string {
-	connID := uuid.New().String()
-
-	// Check connection limits
-	currentConnections := atomic.LoadInt64(&m.activeConnections)
-	if currentConnections >= int64(m.maxConnections) {
-		m.logger.Warn("Max connections reached", "current", currentConnections, "max", m.maxConnections)
+	connID, err := m.Admit(conn)
+	if err != nil {
 		conn.Close()
 		return ""
 	}
+	return connID
+}
+
+// Admit registers a new connection and returns its ID, or the reason it is
+// refused: ErrServerFull or ErrRateLimited. A refused connection is left
+// open, so the caller can tell the client why before closing it.
+func (m *Manager) Admit(conn net.Conn) (string, error) {
+	connID := uuid.New().String()
+
+	// Counted before the check, so concurrent connections cannot all slip
+	// under the limit
+	current := atomic.AddInt64(&m.activeConnections, 1)
+	if current > int64(m.maxConnections) {
+		atomic.AddInt64(&m.activeConnections, -1)
+		atomic.AddInt64(&m.rejectedFull, 1)
+		m.recordRejected("server_full")
+		m.logger.Warn("Max connections reached", "current", current-1, "max", m.maxConnections)
+		return "", ErrServerFull
+	}
 
 	// Rate limiting by IP
 	remoteIP := getIPFromAddr(conn.RemoteAddr())
 	if !m.checkRateLimit(remoteIP) {
+		atomic.AddInt64(&m.activeConnections, -1)
+		atomic.AddInt64(&m.rejectedRateLimited, 1)
+		m.recordRejected("rate_limited")
 		m.logger.Warn("Rate limit exceeded", "ip", remoteIP)
-		conn.Close()
-		return ""
+		return "", ErrRateLimited
 	}
 
-	// Update counters only - no connection state storage
-	atomic.AddInt64(&m.activeConnections, 1)
 	atomic.AddInt64(&m.totalConnections, 1)
+	for {
+		peak := atomic.LoadInt64(&m.peakConnections)
+		if current <= peak || atomic.CompareAndSwapInt64(&m.peakConnections, peak, current) {
+			break
+		}
+	}
 
 	m.logger.Info("Connection registered (stateless)",
 		"connection_id", connID,
 		"remote_addr", conn.RemoteAddr(),
 		"active_count", atomic.LoadInt64(&m.activeConnections))
 
-	return connID
+	return connID, nil
+}
+
+// recordRejected counts a refused connection with the recorder, if set
+func (m *Manager) recordRejected(reason string) {
+	if m.recorder != nil {
+		m.recorder.RecordConnectionRejected(reason)
+	}
 }
 
 // UnregisterConnection decrements counters (stateless)
@@ -129,14 +210,25 @@ func (m *Manager) UnregisterConnection(connID string, remoteAddr net.Addr) {
 type ConnectionStats struct {
 	Active int `json:"active"`
 	Total  int `json:"total"`
+	// Peak is the most connections open at once since the service started
+	Peak int `json:"peak"`
+	Max  int `json:"max"`
+	// RejectedFull and RejectedRateLimited count connections refused
+	// because the server was full or the address had too many
+	RejectedFull        int `json:"rejected_full"`
+	RejectedRateLimited int `json:"rejected_rate_limited"`
 }
 
 // GetStats returns basic connection statistics (counters only)
 // Detailed stats should be queried from Game Service
 func (m *Manager) GetStats() *ConnectionStats {
 	return &ConnectionStats{
-		Active: int(atomic.LoadInt64(&m.activeConnections)),
-		Total:  int(atomic.LoadInt64(&m.totalConnections)),
+		Active:              int(atomic.LoadInt64(&m.activeConnections)),
+		Total:               int(atomic.LoadInt64(&m.totalConnections)),
+		Peak:                int(atomic.LoadInt64(&m.peakConnections)),
+		Max:                 m.maxConnections,
+		RejectedFull:        int(atomic.LoadInt64(&m.rejectedFull)),
+		RejectedRateLimited: int(atomic.LoadInt64(&m.rejectedRateLimited)),
 	}
 }
 
@@ -171,21 +263,6 @@ func (m *Manager) checkRateLimit(remoteIP string) bool {
 	return true
 }
 
-// cleanupLoop periodically cleans up rate limiting data only
-func (m *Manager) cleanupLoop(ctx context.Context) {
-	ticker := time.NewTicker(5 * time.Minute) // Less frequent in stateless mode
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			m.cleanup()
-		}
-	}
-}
-
 // cleanup removes expired IP trackers only (stateless mode)
 func (m *Manager) cleanup() {
 	now := time.Now()
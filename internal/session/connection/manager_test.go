@@ -250,6 +250,47 @@ func TestGetStats(t *testing.T) {
 	manager.Stop(ctx)
 }
 
+type fakeConnectionRecorder struct {
+	rejected []string
+}
+
+func (r *fakeConnectionRecorder) RecordConnectionRejected(reason string) {
+	r.rejected = append(r.rejected, reason)
+}
+
+func TestAdmitServerFull(t *testing.T) {
+	manager := NewManager(2, slog.Default())
+	recorder := &fakeConnectionRecorder{}
+	manager.SetRecorder(recorder)
+
+	conn1 := &mockConn{remoteAddr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 12345}}
+	conn2 := &mockConn{remoteAddr: &net.TCPAddr{IP: net.ParseIP("192.168.1.1"), Port: 12345}}
+	conn3 := &mockConn{remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 12345}}
+
+	connID1, err := manager.Admit(conn1)
+	require.NoError(t, err)
+	_, err = manager.Admit(conn2)
+	require.NoError(t, err)
+
+	// A refused connection is left open for the caller to explain why
+	_, err = manager.Admit(conn3)
+	assert.ErrorIs(t, err, ErrServerFull)
+	assert.False(t, conn3.closed)
+
+	manager.UnregisterConnection(connID1, conn1.RemoteAddr())
+	_, err = manager.Admit(conn3)
+	require.NoError(t, err)
+
+	stats := manager.GetStats()
+	assert.Equal(t, 2, stats.Active)
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 2, stats.Peak)
+	assert.Equal(t, 2, stats.Max)
+	assert.Equal(t, 1, stats.RejectedFull)
+	assert.Equal(t, 0, stats.RejectedRateLimited)
+	assert.Equal(t, []string{"server_full"}, recorder.rejected)
+}
+
 func TestConcurrentOperations(t *testing.T) {
 	logger := slog.Default()
 	manager := NewManager(1000, logger)
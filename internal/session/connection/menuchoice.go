@@ -9,6 +9,9 @@ import (
 
 	"github.com/dungeongate/internal/session/menu"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/featureflags"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -17,8 +20,14 @@ type MenuChoiceProcessor struct {
 	authManager       *UserAuthManager
 	gameIOHandler     *GameIOHandler
 	spectatingHandler *SpectatingHandler
+	dumplogHandler    *DumplogHandler
 	menuHandler       *menu.MenuHandler
 	logger            *slog.Logger
+	geo               *GeoFilter
+	pause             *messagePause
+	manager           *Manager
+	build             buildinfo.Info
+	flags             *featureflags.Flags
 }
 
 // NewMenuChoiceProcessor creates a new menu choice processor
@@ -26,6 +35,7 @@ func NewMenuChoiceProcessor(
 	authManager *UserAuthManager,
 	gameIOHandler *GameIOHandler,
 	spectatingHandler *SpectatingHandler,
+	dumplogHandler *DumplogHandler,
 	menuHandler *menu.MenuHandler,
 	logger *slog.Logger,
 ) *MenuChoiceProcessor {
@@ -33,6 +43,7 @@ func NewMenuChoiceProcessor(
 		authManager:       authManager,
 		gameIOHandler:     gameIOHandler,
 		spectatingHandler: spectatingHandler,
+		dumplogHandler:    dumplogHandler,
 		menuHandler:       menuHandler,
 		logger:            logger,
 	}
@@ -46,21 +57,37 @@ func (p *MenuChoiceProcessor) HandleMenuChoice(
 	userInfo *authv1.User,
 	connID, username string,
 	terminalCols, terminalRows int,
+	clientTerm ClientTerminal,
 	sshConn *ssh.ServerConn,
 ) error {
 	switch choice.Action {
 	case "quit":
+		if isImpersonating(sshConn) {
+			p.endImpersonation(channel, sshConn, "Impersonation ended.")
+			return nil
+		}
+		prefs := menu.PreferencesOf(userInfo)
+		if prefs.ConfirmQuit && !confirmQuit(channel) {
+			return nil
+		}
+		// The credits stay on the player's screen after the connection closes
+		if !prefs.SkipCredits {
+			p.writeCredits(ctx, channel, userInfo)
+		}
 		channel.Write([]byte("Goodbye!\r\n"))
 		return fmt.Errorf("user quit") // This will exit the session
 
 	case "play":
+		if refuseWhileImpersonating(channel, sshConn, p.pause) {
+			return nil
+		}
 		// Show game selection menu for authenticated users
 		if userInfo != nil {
-			return p.handleGameSelection(ctx, channel, userInfo, connID, username, terminalCols, terminalRows, sshConn)
+			return p.handleGameSelection(ctx, channel, userInfo, connID, username, terminalCols, terminalRows, clientTerm, sshConn)
 		} else {
 			channel.Write([]byte("Please login first to play games.\r\n"))
 			// Brief pause to let user read the message
-			time.Sleep(2 * time.Second)
+			p.pause.error()
 			return nil
 		}
 
@@ -79,21 +106,44 @@ func (p *MenuChoiceProcessor) HandleMenuChoice(
 		}
 
 	case "start_game":
+		if err := p.gameStartRefusal(ctx, sshConn, userInfo, choice.Value); err != nil {
+			message := apierror.MessageOf(err)
+			channel.Write([]byte(strings.ToUpper(message[:1]) + message[1:] + ".\r\n"))
+			// Brief pause to let user read the message
+			p.pause.error()
+			return nil
+		}
 		// Start a specific game session with the selected game ID
 		if userInfo != nil {
-			return p.gameIOHandler.StartSpecificGameSession(ctx, channel, userInfo, connID, username, choice.Value, terminalCols, terminalRows)
+			if choice.SetDefault {
+				p.setDefaultGameVersion(ctx, channel, userInfo, choice.Value, choice.Version, sshConn)
+			}
+			if !choice.SkipNews {
+				if err := p.showGameNews(ctx, channel, userInfo, choice.Value, sshConn); err != nil {
+					return err
+				}
+			}
+			p.recordGameLaunch(ctx, userInfo, choice.Value, sshConn)
+			return p.gameIOHandler.StartSpecificGameSession(ctx, channel, userInfo, connID, username, choice.Value, choice.Version, terminalCols, terminalRows, clientTerm)
 		} else {
 			channel.Write([]byte("Please login first to play games.\r\n"))
 			// Brief pause to let user read the message
-			time.Sleep(2 * time.Second)
+			p.pause.error()
 			return nil
 		}
 
-	case "spectate_session":
-		// Start spectating a specific game session
-		return p.spectatingHandler.StartSpectating(ctx, channel, userInfo, choice.Value)
+	case "spectate_session", "watch":
+		if menu.IsRestricted(userInfo) {
+			channel.Write([]byte("Your account is restricted from watching games.\r\n"))
+			// Brief pause to let user read the message
+			p.pause.error()
+			return nil
+		}
+		if choice.Action == "spectate_session" {
+			// Start spectating a specific game session
+			return p.spectatingHandler.StartSpectating(ctx, channel, userInfo, choice.Value, clientTerm)
+		}
 
-	case "watch":
 		// Show the new formatted spectate menu
 		spectateChoice, err := p.menuHandler.ShowSpectateMenu(ctx, channel, userInfo)
 		if err != nil {
@@ -107,55 +157,43 @@ func (p *MenuChoiceProcessor) HandleMenuChoice(
 		}
 
 		// Handle the spectate menu choice
-		return p.HandleMenuChoice(ctx, channel, spectateChoice, userInfo, connID, username, terminalCols, terminalRows, sshConn)
+		return p.HandleMenuChoice(ctx, channel, spectateChoice, userInfo, connID, username, terminalCols, terminalRows, clientTerm, sshConn)
 
 	case "edit_profile":
-		channel.Write([]byte("Profile editing functionality not yet implemented.\r\n"))
-		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
-		return nil
+		return p.handleEditProfile(ctx, channel, userInfo, sshConn)
 
 	case "view_recordings":
 		channel.Write([]byte("Recording viewing functionality not yet implemented.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 
-	case "statistics":
-		channel.Write([]byte("Statistics functionality not yet implemented.\r\n"))
+	case "view_dumplogs":
+		if userInfo != nil {
+			return p.dumplogHandler.ShowHistory(ctx, channel, userInfo, terminalRows)
+		}
+		channel.Write([]byte("Please login first to view your games.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 
+	case "statistics":
+		return p.handleStatistics(ctx, channel, userInfo, sshConn)
+
+	case "groups":
+		return p.handleGroups(ctx, channel, userInfo, sshConn)
+
+	case "friends":
+		return p.handleFriends(ctx, channel, userInfo, sshConn)
+
+	case "notifications":
+		return p.handleNotifications(ctx, channel, userInfo, sshConn)
+
 	case "credit":
-		// Clear screen and show credits with ASCII art
-		channel.Write([]byte("\033[2J\033[H"))
-		channel.Write([]byte("\r\n"))
-
-		// DungeonGate ASCII Art
-		channel.Write([]byte(" ____\r\n"))
-		channel.Write([]byte("|  _ \\ _   _ _ __   __ _  ___  ___  _ __\r\n"))
-		channel.Write([]byte("| | | | | | | ._ \\ / _. |/ _ \\/ _ \\| ._ \\\r\n"))
-		channel.Write([]byte("| |_| | |_| | | | | (_| |  __/ (_) | | | |\r\n"))
-		channel.Write([]byte("|____/ \\__,_|_| |_|\\__, |\\___|\\____| |_| |\r\n"))
-		channel.Write([]byte("        ___        |___/\r\n"))
-		channel.Write([]byte("       / __|  __ _| |_ ___\r\n"))
-		channel.Write([]byte("      | |___ / _. | __/ _ \\\r\n"))
-		channel.Write([]byte("      | |__ | (_| |  ||  _/\r\n"))
-		channel.Write([]byte("      |____/ \\__,_|\\__\\___|\r\n"))
-		channel.Write([]byte("\r\n"))
-
-		// Credits information
-		channel.Write([]byte("=== Credits ===\r\n\r\n"))
-		channel.Write([]byte("DungeonGate - Terminal Game Platform\r\n"))
-		channel.Write([]byte("Developed with <3 and Claude Code\r\n\r\n"))
-		channel.Write([]byte("Directed by Peter Subacz \r\n\r\n"))
-		channel.Write([]byte("Press any key to return to menu...\r\n"))
-
-		// Wait for any key press to return
-		buffer := make([]byte, 1)
-		channel.Read(buffer)
-		return nil
+		return p.showCredits(ctx, channel, userInfo, terminalRows)
+
+	case "who":
+		return p.showWhoIsOnline(ctx, channel, userInfo, terminalRows)
 
 	// Admin Functions
 	case "admin_unlock_user":
@@ -173,30 +211,83 @@ func (p *MenuChoiceProcessor) HandleMenuChoice(
 	case "admin_server_stats":
 		return p.handleAdminServerStats(ctx, channel, userInfo, sshConn)
 
+	case "admin_impersonate_user":
+		return p.handleAdminImpersonate(ctx, channel, userInfo, sshConn)
+
+	// Moderation Functions
+	case "moderation":
+		return p.handleModeration(ctx, channel, userInfo, sshConn)
+
 	default:
 		channel.Write([]byte(fmt.Sprintf("Unknown action: %s\r\n", choice.Action)))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 }
 
-// handleGameSelection shows the game selection menu and handles the choice
-func (p *MenuChoiceProcessor) handleGameSelection(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username string, terminalCols, terminalRows int, sshConn *ssh.ServerConn) error {
-	choice, err := p.menuHandler.ShowGameSelectionMenu(ctx, channel, userInfo.Username)
-	if err != nil {
-		p.logger.Error("Game selection menu failed", "error", err, "username", username)
-		channel.Write([]byte("Failed to display game selection menu.\r\n"))
-		return nil
+// gameStartRefusal returns why the connection may not start gameID, or nil.
+// The menu shows the reason to the player and the API returns it.
+func (p *MenuChoiceProcessor) gameStartRefusal(ctx context.Context, sshConn *ssh.ServerConn, userInfo *authv1.User, gameID string) error {
+	if isImpersonating(sshConn) {
+		return apierror.New(apierror.PermissionDenied, "games cannot be played while impersonating a user")
+	}
+	// A login beyond the user's session limit cannot start a game they are
+	// playing from another connection
+	if extraLoginPolicy(sshConn) != "" && userInfo != nil && activeGameSession(ctx, p.gameIOHandler.gameClient, p.logger, userInfo, gameID) != "" {
+		return apierror.New(apierror.PermissionDenied, "you are playing this game from another connection; it can only be played from one")
 	}
+	return nil
+}
 
-	// If choice is nil, user chose to go back to main menu
-	if choice == nil {
-		return nil
+// handleGameSelection shows the game selection menu and handles the choice.
+// Starring a game shows the menu again.
+func (p *MenuChoiceProcessor) handleGameSelection(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID, username string, terminalCols, terminalRows int, clientTerm ClientTerminal, sshConn *ssh.ServerConn) error {
+	for {
+		choice, err := p.menuHandler.ShowGameSelectionMenu(ctx, channel, userInfo)
+		if err != nil {
+			p.logger.Error("Game selection menu failed", "error", err, "username", username)
+			channel.Write([]byte("Failed to display game selection menu.\r\n"))
+			return nil
+		}
+
+		// If choice is nil, user chose to go back to main menu
+		if choice == nil {
+			return nil
+		}
+
+		if choice.Action == "toggle_favorite" {
+			if userInfo == nil {
+				channel.Write([]byte("Please login first to star games.\r\n"))
+				p.pause.error()
+				continue
+			}
+			p.toggleFavoriteGame(ctx, channel, userInfo, choice.Value, sshConn)
+			channel.Write([]byte("\033[2J\033[H"))
+			continue
+		}
+
+		// Handle the game selection choice
+		return p.HandleMenuChoice(ctx, channel, choice, userInfo, connID, username, terminalCols, terminalRows, clientTerm, sshConn)
+	}
+}
+
+// setDefaultGameVersion saves the version of a game the player starts by default
+func (p *MenuChoiceProcessor) setDefaultGameVersion(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, gameID, version string, sshConn *ssh.ServerConn) {
+	key := menu.GameVersionPreference(gameID)
+	resp, err := p.authManager.authClient.SetUserPreference(ctx, p.getAdminToken(sshConn), key, version)
+	if err != nil || !resp.Success {
+		p.logger.Warn("Failed to save default game version", "error", err, "username", userInfo.Username, "game_id", gameID)
+		channel.Write([]byte("Could not save your default version.\r\n"))
+		return
 	}
 
-	// Handle the game selection choice
-	return p.HandleMenuChoice(ctx, channel, choice, userInfo, connID, username, terminalCols, terminalRows, sshConn)
+	// Keep the menu in step until the next login reloads the user
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["pref."+key] = version
+	p.logger.Info("Default game version changed", "username", userInfo.Username, "game_id", gameID, "version", version)
 }
 
 // Admin Functions Implementation
@@ -317,7 +408,7 @@ func (p *MenuChoiceProcessor) getAdminToken(sshConn *ssh.ServerConn) string {
 func (p *MenuChoiceProcessor) handleAdminUnlockUser(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
 	if userInfo == nil || !userInfo.IsAdmin {
 		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -334,14 +425,14 @@ func (p *MenuChoiceProcessor) handleAdminUnlockUser(ctx context.Context, channel
 
 	if targetUsername == "" {
 		channel.Write([]byte("Username cannot be empty.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
 	adminToken := p.getAdminToken(sshConn)
 	if adminToken == "" {
 		channel.Write([]byte("Error: Unable to get admin authentication token.\r\n"))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -349,7 +440,7 @@ func (p *MenuChoiceProcessor) handleAdminUnlockUser(ctx context.Context, channel
 	if err != nil {
 		p.logger.Error("Failed to unlock user account", "error", err, "admin", userInfo.Username, "target", targetUsername)
 		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -373,7 +464,7 @@ func (p *MenuChoiceProcessor) handleAdminUnlockUser(ctx context.Context, channel
 func (p *MenuChoiceProcessor) handleAdminDeleteUser(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
 	if userInfo == nil || !userInfo.IsAdmin {
 		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -391,7 +482,7 @@ func (p *MenuChoiceProcessor) handleAdminDeleteUser(ctx context.Context, channel
 
 	if targetUsername == "" {
 		channel.Write([]byte("Username cannot be empty.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -407,14 +498,14 @@ func (p *MenuChoiceProcessor) handleAdminDeleteUser(ctx context.Context, channel
 
 	if confirmation != "DELETE" {
 		channel.Write([]byte("Deletion cancelled.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
 	adminToken := p.getAdminToken(sshConn)
 	if adminToken == "" {
 		channel.Write([]byte("Error: Unable to get admin authentication token.\r\n"))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -422,7 +513,7 @@ func (p *MenuChoiceProcessor) handleAdminDeleteUser(ctx context.Context, channel
 	if err != nil {
 		p.logger.Error("Failed to delete user account", "error", err, "admin", userInfo.Username, "target", targetUsername)
 		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -446,7 +537,7 @@ func (p *MenuChoiceProcessor) handleAdminDeleteUser(ctx context.Context, channel
 func (p *MenuChoiceProcessor) handleAdminResetPassword(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
 	if userInfo == nil || !userInfo.IsAdmin {
 		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -463,7 +554,7 @@ func (p *MenuChoiceProcessor) handleAdminResetPassword(ctx context.Context, chan
 
 	if targetUsername == "" {
 		channel.Write([]byte("Username cannot be empty.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -477,14 +568,14 @@ func (p *MenuChoiceProcessor) handleAdminResetPassword(ctx context.Context, chan
 
 	if len(newPassword) < 8 {
 		channel.Write([]byte("Password must be at least 8 characters long.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
 	adminToken := p.getAdminToken(sshConn)
 	if adminToken == "" {
 		channel.Write([]byte("Error: Unable to get admin authentication token.\r\n"))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -492,7 +583,7 @@ func (p *MenuChoiceProcessor) handleAdminResetPassword(ctx context.Context, chan
 	if err != nil {
 		p.logger.Error("Failed to reset user password", "error", err, "admin", userInfo.Username, "target", targetUsername)
 		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -516,7 +607,7 @@ func (p *MenuChoiceProcessor) handleAdminResetPassword(ctx context.Context, chan
 func (p *MenuChoiceProcessor) handleAdminPromoteUser(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
 	if userInfo == nil || !userInfo.IsAdmin {
 		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -533,14 +624,14 @@ func (p *MenuChoiceProcessor) handleAdminPromoteUser(ctx context.Context, channe
 
 	if targetUsername == "" {
 		channel.Write([]byte("Username cannot be empty.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
 	adminToken := p.getAdminToken(sshConn)
 	if adminToken == "" {
 		channel.Write([]byte("Error: Unable to get admin authentication token.\r\n"))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -548,7 +639,7 @@ func (p *MenuChoiceProcessor) handleAdminPromoteUser(ctx context.Context, channe
 	if err != nil {
 		p.logger.Error("Failed to promote user to admin", "error", err, "admin", userInfo.Username, "target", targetUsername)
 		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -572,7 +663,7 @@ func (p *MenuChoiceProcessor) handleAdminPromoteUser(ctx context.Context, channe
 func (p *MenuChoiceProcessor) handleAdminServerStats(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
 	if userInfo == nil || !userInfo.IsAdmin {
 		channel.Write([]byte("Access denied: Admin privileges required.\r\n"))
-		time.Sleep(2 * time.Second)
+		p.pause.error()
 		return nil
 	}
 
@@ -582,7 +673,7 @@ func (p *MenuChoiceProcessor) handleAdminServerStats(ctx context.Context, channe
 	adminToken := p.getAdminToken(sshConn)
 	if adminToken == "" {
 		channel.Write([]byte("Error: Unable to get admin authentication token.\r\n"))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -590,7 +681,7 @@ func (p *MenuChoiceProcessor) handleAdminServerStats(ctx context.Context, channe
 	if err != nil {
 		p.logger.Error("Failed to get server statistics", "error", err, "admin", userInfo.Username)
 		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
-		time.Sleep(3 * time.Second)
+		p.pause.long()
 		return nil
 	}
 
@@ -601,6 +692,12 @@ func (p *MenuChoiceProcessor) handleAdminServerStats(ctx context.Context, channe
 		for key, value := range resp.Stats {
 			channel.Write([]byte(fmt.Sprintf("%-25s: %s\r\n", key, value)))
 		}
+		p.writeSessionInstances(ctx, channel, adminToken)
+		p.writeServiceBuilds(ctx, channel)
+		p.writeFeatureFlags(channel, userInfo)
+		p.writeCountryBreakdown(channel)
+		p.writeEchoLatency(channel)
+		p.writeResourceUsage(ctx, channel)
 
 		p.logger.Info("Admin viewed server statistics", "admin", userInfo.Username)
 	} else {
@@ -612,3 +709,39 @@ func (p *MenuChoiceProcessor) handleAdminServerStats(ctx context.Context, channe
 	channel.Read(buffer)
 	return nil
 }
+
+// writeCountryBreakdown shows the connections this instance has seen per
+// country, when GeoIP is enabled
+func (p *MenuChoiceProcessor) writeCountryBreakdown(channel ssh.Channel) {
+	breakdown := p.geo.Breakdown()
+	if len(breakdown) == 0 {
+		return
+	}
+
+	channel.Write([]byte("\r\nConnections by Country (this server):\r\n"))
+	channel.Write([]byte(fmt.Sprintf("  %-10s %8s %8s %8s\r\n", "Country", "Total", "Active", "Refused")))
+	for _, stats := range breakdown {
+		channel.Write([]byte(fmt.Sprintf("  %-10s %8d %8d %8d\r\n", stats.Country, stats.Total, stats.Active, stats.Refused)))
+	}
+}
+
+// writeEchoLatency shows the echo latency of the game sessions on this
+// instance, marking those over the SLO
+func (p *MenuChoiceProcessor) writeEchoLatency(channel ssh.Channel) {
+	latency := p.gameIOHandler.latency
+	sessions := latency.Sessions()
+	if len(sessions) == 0 {
+		return
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\nEcho Latency (this server, SLO p95 %s):\r\n", latency.SLO())))
+	channel.Write([]byte(fmt.Sprintf("  %-16s %-12s %8s %8s %8s %8s\r\n", "User", "Game", "Samples", "p50", "p95", "Max")))
+	for _, stats := range sessions {
+		flag := ""
+		if stats.OverSLO {
+			flag = "  ! over SLO"
+		}
+		channel.Write([]byte(fmt.Sprintf("  %-16s %-12s %8d %8s %8s %8s%s\r\n", stats.Username, stats.GameID, stats.Samples,
+			stats.P50.Round(time.Millisecond), stats.P95.Round(time.Millisecond), stats.Max.Round(time.Millisecond), flag)))
+	}
+}
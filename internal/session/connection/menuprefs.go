@@ -0,0 +1,237 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+// menuPreferenceValues returns the player's menu preferences as stored, with
+// the defaults for those never set
+func menuPreferenceValues(userInfo *authv1.User) map[string]string {
+	prefs := menu.PreferencesOf(userInfo)
+	return map[string]string{
+		menu.DefaultGamePreference:         prefs.DefaultGame,
+		menu.SkipCreditsPreference:         strconv.FormatBool(prefs.SkipCredits),
+		menu.ConfirmQuitPreference:         strconv.FormatBool(prefs.ConfirmQuit),
+		menu.WatchSortPreference:           prefs.WatchSort,
+		menu.AutoReconnectPreference:       strconv.FormatBool(prefs.AutoReconnect),
+		menu.FavoriteGamesPreference:       strings.Join(prefs.FavoriteGames, ","),
+		menu.RecentGamesPreference:         strings.Join(prefs.RecentGames, ","),
+		menu.ShowOnlineStatusSetting:       strconv.FormatBool(!prefs.HideOnlineStatus),
+		menu.FriendNotificationsPreference: strconv.FormatBool(prefs.FriendNotifications),
+	}
+}
+
+// saveMenuPreference validates and saves one of the player's menu
+// preferences; an empty value restores the default
+func (p *MenuChoiceProcessor) saveMenuPreference(ctx context.Context, userInfo *authv1.User, key, value string, sshConn *ssh.ServerConn) error {
+	if err := menu.ValidateMenuPreference(key, value); err != nil {
+		return err
+	}
+	if key == menu.ShowOnlineStatusSetting {
+		return p.saveOnlineStatus(ctx, userInfo, value != "false", sshConn)
+	}
+	// Defaults are stored as no preference at all
+	if menu.IsDefaultPreference(key, value) {
+		value = ""
+	}
+	resp, err := p.authManager.authClient.SetUserPreference(ctx, p.getAdminToken(sshConn), key, value)
+	if err != nil {
+		return fmt.Errorf("failed to save preference: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to save preference: %s", resp.Error)
+	}
+
+	// Keep the menus in step until the next login reloads the user
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["pref."+key] = value
+	p.logger.Info("Menu preference changed", "username", userInfo.Username, "key", key, "value", value)
+	return nil
+}
+
+// saveOnlineStatus saves whether the player appears in the who list, which
+// is kept in their profile rather than with the preferences
+func (p *MenuChoiceProcessor) saveOnlineStatus(ctx context.Context, userInfo *authv1.User, show bool, sshConn *ssh.ServerConn) error {
+	resp, err := p.authManager.authClient.SetShowOnlineStatus(ctx, p.getAdminToken(sshConn), show)
+	if err != nil {
+		return fmt.Errorf("failed to save online status: %w", err)
+	}
+	if !resp.Success {
+		return fmt.Errorf("failed to save online status: %s", resp.Error)
+	}
+
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata[menu.ShowOnlineStatusSetting] = strconv.FormatBool(show)
+	p.logger.Info("Online status changed", "username", userInfo.Username, "show", show)
+	return nil
+}
+
+// recordGameLaunch puts a game the player is starting first in their launch
+// history. Failing to save it doesn't stop the game starting.
+func (p *MenuChoiceProcessor) recordGameLaunch(ctx context.Context, userInfo *authv1.User, gameID string, sshConn *ssh.ServerConn) {
+	recent := menu.WithRecentGame(menu.PreferencesOf(userInfo).RecentGames, gameID)
+	if err := p.saveMenuPreference(ctx, userInfo, menu.RecentGamesPreference, strings.Join(recent, ","), sshConn); err != nil {
+		p.logger.Warn("Failed to record game launch", "error", err, "username", userInfo.Username, "game_id", gameID)
+	}
+}
+
+// toggleFavoriteGame stars a game in the game menu, or unstars it
+func (p *MenuChoiceProcessor) toggleFavoriteGame(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, gameID string, sshConn *ssh.ServerConn) {
+	favorites := menu.ToggleFavoriteGame(menu.PreferencesOf(userInfo).FavoriteGames, gameID)
+	p.setMenuPreference(ctx, channel, userInfo, menu.FavoriteGamesPreference, strings.Join(favorites, ","), sshConn)
+}
+
+// setMenuPreference saves a menu preference chosen from the profile editor
+func (p *MenuChoiceProcessor) setMenuPreference(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, key, value string, sshConn *ssh.ServerConn) {
+	if err := p.saveMenuPreference(ctx, userInfo, key, value, sshConn); err != nil {
+		p.logger.Warn("Failed to save menu preference", "error", err, "username", userInfo.Username, "key", key)
+		channel.Write([]byte("\r\nCould not save your preference.\r\n"))
+		p.pause.error()
+	}
+}
+
+// handleMenuPreferences shows the player's menu preferences and lets them
+// change each one
+func (p *MenuChoiceProcessor) handleMenuPreferences(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	for {
+		prefs := menu.PreferencesOf(userInfo)
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Menu Preferences ===\r\n\r\n"))
+		defaultGame := "none"
+		if prefs.DefaultGame != "" {
+			defaultGame = prefs.DefaultGame + " (Enter starts it from the game menu)"
+		}
+		channel.Write([]byte(fmt.Sprintf("Default game:     %s\r\n", defaultGame)))
+		channel.Write([]byte(fmt.Sprintf("Credits on exit:  %s\r\n", onOff(!prefs.SkipCredits, "shown", "skipped"))))
+		channel.Write([]byte(fmt.Sprintf("Confirm quit:     %s\r\n", onOff(prefs.ConfirmQuit, "on", "off"))))
+		channel.Write([]byte(fmt.Sprintf("Watch menu order: %s\r\n", menu.WatchSortLabel(prefs.WatchSort))))
+		channel.Write([]byte(fmt.Sprintf("Auto-reconnect:   %s\r\n", onOff(prefs.AutoReconnect, "back into a running game at login", "off"))))
+		channel.Write([]byte(fmt.Sprintf("Online status:    %s\r\n", onOff(prefs.HideOnlineStatus, "hidden from the who list", "shown"))))
+		channel.Write([]byte(fmt.Sprintf("Friend alerts:    %s\r\n\r\n", onOff(prefs.FriendNotifications, "notified when a friend starts a game", "off"))))
+		channel.Write([]byte("  g) Choose default game\r\n"))
+		channel.Write([]byte(fmt.Sprintf("  c) %s credits when leaving\r\n", onOff(prefs.SkipCredits, "Show", "Skip"))))
+		channel.Write([]byte(fmt.Sprintf("  x) %s before quitting\r\n", onOff(prefs.ConfirmQuit, "Don't ask", "Ask"))))
+		channel.Write([]byte("  w) Change watch menu order\r\n"))
+		channel.Write([]byte(fmt.Sprintf("  r) Turn auto-reconnect %s\r\n", onOff(prefs.AutoReconnect, "off", "on"))))
+		channel.Write([]byte(fmt.Sprintf("  o) %s my online status\r\n", onOff(prefs.HideOnlineStatus, "Show", "Hide"))))
+		channel.Write([]byte(fmt.Sprintf("  f) Turn friend alerts %s\r\n", onOff(prefs.FriendNotifications, "off", "on"))))
+		channel.Write([]byte("  q) Back\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+
+		switch buffer[0] {
+		case 'g', 'G':
+			if err := p.chooseDefaultGame(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+		case 'c', 'C':
+			p.setMenuPreference(ctx, channel, userInfo, menu.SkipCreditsPreference, strconv.FormatBool(!prefs.SkipCredits), sshConn)
+		case 'x', 'X':
+			p.setMenuPreference(ctx, channel, userInfo, menu.ConfirmQuitPreference, strconv.FormatBool(!prefs.ConfirmQuit), sshConn)
+		case 'w', 'W':
+			if err := p.chooseWatchSort(ctx, channel, userInfo, prefs.WatchSort, sshConn); err != nil {
+				return err
+			}
+		case 'r', 'R':
+			p.setMenuPreference(ctx, channel, userInfo, menu.AutoReconnectPreference, strconv.FormatBool(!prefs.AutoReconnect), sshConn)
+		case 'o', 'O':
+			p.setMenuPreference(ctx, channel, userInfo, menu.ShowOnlineStatusSetting, strconv.FormatBool(prefs.HideOnlineStatus), sshConn)
+		case 'f', 'F':
+			p.setMenuPreference(ctx, channel, userInfo, menu.FriendNotificationsPreference, strconv.FormatBool(!prefs.FriendNotifications), sshConn)
+		case 'q', 'Q', 3, 4, 27:
+			return nil
+		}
+	}
+}
+
+// chooseDefaultGame asks which game Enter starts from the game menu
+func (p *MenuChoiceProcessor) chooseDefaultGame(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	games, err := p.gameIOHandler.gameClient.ListGames(ctx)
+	if err != nil {
+		p.logger.Error("Failed to list games", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nFailed to load available games. Please try again later.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte("\r\n\r\n"))
+	for i, game := range games {
+		channel.Write([]byte(fmt.Sprintf("  %d) %s\r\n", i+1, game.Name)))
+	}
+	channel.Write([]byte("  0) No default game\r\n\r\n"))
+	input, err := p.promptForUsername(ctx, channel, "Default game")
+	if err != nil {
+		if err.Error() == "user cancelled" {
+			return nil
+		}
+		return err
+	}
+
+	var game *gamev2.Game
+	choice, err := strconv.Atoi(input)
+	switch {
+	case input == "":
+		return nil
+	case err != nil || choice < 0 || choice > len(games):
+		channel.Write([]byte("Invalid choice.\r\n"))
+		p.pause.brief()
+		return nil
+	case choice > 0:
+		game = games[choice-1]
+	}
+	value := ""
+	if game != nil {
+		value = game.Id
+	}
+	p.setMenuPreference(ctx, channel, userInfo, menu.DefaultGamePreference, value, sshConn)
+	return nil
+}
+
+// chooseWatchSort asks how the watch menu is ordered
+func (p *MenuChoiceProcessor) chooseWatchSort(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, current string, sshConn *ssh.ServerConn) error {
+	channel.Write([]byte("\r\n\r\n"))
+	for i, sortBy := range menu.WatchSorts {
+		marker := " "
+		if sortBy == current {
+			marker = "*"
+		}
+		channel.Write([]byte(fmt.Sprintf(" %s%d) %s\r\n", marker, i+1, menu.WatchSortLabel(sortBy))))
+	}
+	channel.Write([]byte("\r\nOrder: "))
+
+	buffer := make([]byte, 1)
+	if _, err := channel.Read(buffer); err != nil {
+		return err
+	}
+	choice := int(buffer[0] - '1')
+	if choice < 0 || choice >= len(menu.WatchSorts) || menu.WatchSorts[choice] == current {
+		return nil
+	}
+	p.setMenuPreference(ctx, channel, userInfo, menu.WatchSortPreference, menu.WatchSorts[choice], sshConn)
+	return nil
+}
+
+// onOff returns on when the setting is on, else off
+func onOff(setting bool, on, off string) string {
+	if setting {
+		return on
+	}
+	return off
+}
@@ -0,0 +1,150 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+	"golang.org/x/crypto/ssh"
+)
+
+// handleMergeAccount merges another account the player owns into the one
+// they are logged in with. The player proves ownership with the other
+// account's password and sees what would move before confirming. The auth
+// service merges the account itself, then the game service moves the games.
+func (p *MenuChoiceProcessor) handleMergeAccount(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	survivorID, err := strconv.ParseInt(userInfo.Id, 10, 32)
+	if err != nil {
+		p.logger.Error("Invalid user ID format", "user_id", userInfo.Id, "error", err)
+		channel.Write([]byte("\r\nInvalid user ID. Please contact administrator.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte("\r\n\r\nMerging moves another account's games, saves and settings into this one.\r\n"))
+	channel.Write([]byte("The other account is closed afterwards and cannot be used to log in.\r\n\r\n"))
+
+	input, err := p.promptForUsername(ctx, channel, "Account to merge")
+	if err != nil || input == "" {
+		return err
+	}
+	// Only accounts of the player's own realm can be merged
+	otherUsername, ok := realmUsername(ctx, input)
+	if !ok {
+		channel.Write([]byte("\r\n✗ No such account.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("Password of %s: ", input)))
+	password, err := p.authManager.readPasswordWithTerminal(ctx, channel)
+	if err != nil {
+		if err.Error() == "user cancelled" {
+			return nil
+		}
+		return err
+	}
+
+	token := p.getAdminToken(sshConn)
+	authClient := p.authManager.authClient
+	gameClient := p.gameIOHandler.gameClient
+
+	preview, err := authClient.MergeAccounts(ctx, token, otherUsername, password, true)
+	if err != nil {
+		p.logger.Error("Failed to preview account merge", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nCould not merge the accounts.\r\n"))
+		p.pause.error()
+		return nil
+	}
+	if !preview.Success {
+		channel.Write([]byte(fmt.Sprintf("\r\n✗ %s\r\n", preview.Error)))
+		p.pause.error()
+		return nil
+	}
+
+	games, err := gameClient.MergeUserData(ctx, preview.MergedUserId, int32(survivorID), userInfo.Username, true)
+	if err != nil {
+		if apierror.CodeOf(err) == apierror.ActiveSessionExists {
+			channel.Write([]byte(fmt.Sprintf("\r\n✗ %s has a game running. Finish it and try again.\r\n", preview.MergedUsername)))
+		} else {
+			p.logger.Error("Failed to preview game data merge", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("\r\nCould not look up the other account's games.\r\n"))
+		}
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\nMerging %s into %s moves:\r\n", preview.MergedUsername, userInfo.Username)))
+	writeMergeSummary(channel, preview.Summary, games)
+	channel.Write([]byte(fmt.Sprintf("\r\nMerge %s into %s? This cannot be undone. (y/N): ", preview.MergedUsername, userInfo.Username)))
+	buffer := make([]byte, 1)
+	if _, err := channel.Read(buffer); err != nil {
+		return err
+	}
+	channel.Write([]byte("\r\n"))
+	if buffer[0] != 'y' && buffer[0] != 'Y' {
+		return nil
+	}
+
+	resp, err := authClient.MergeAccounts(ctx, token, otherUsername, password, false)
+	if err != nil {
+		p.logger.Error("Failed to merge accounts", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nCould not merge the accounts.\r\n"))
+		p.pause.error()
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("\r\n✗ %s\r\n", resp.Error)))
+		p.pause.error()
+		return nil
+	}
+
+	// The accounts are merged; the games follow. Should that fail, the
+	// games stay keyed by the closed account's ID for an admin to move.
+	games, err = gameClient.MergeUserData(ctx, resp.MergedUserId, int32(survivorID), userInfo.Username, false)
+	if err != nil {
+		p.logger.Error("Failed to merge game data after merging accounts",
+			"error", err,
+			"user_id", survivorID,
+			"merged_user_id", resp.MergedUserId)
+		channel.Write([]byte(fmt.Sprintf("\r\n%s was merged, but its games could not be moved.\r\n", resp.MergedUsername)))
+		channel.Write([]byte(fmt.Sprintf("Please contact an administrator and mention user ID %d.\r\n", resp.MergedUserId)))
+		p.pause.long()
+		return nil
+	}
+
+	p.logger.Info("Accounts merged", "username", userInfo.Username, "merged_username", resp.MergedUsername)
+	if resp.Summary.Group != "" && userInfo.Metadata != nil {
+		userInfo.Metadata["group"] = resp.Summary.Group
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\n✓ %s was merged into your account:\r\n", resp.MergedUsername)))
+	writeMergeSummary(channel, resp.Summary, games)
+	channel.Write([]byte("\r\nPress any key to continue..."))
+	channel.Read(buffer)
+	return nil
+}
+
+// writeMergeSummary lists what an account merge moves
+func writeMergeSummary(channel ssh.Channel, summary *authv1.AccountMergeSummary, games *gamev2.MergeUserDataResponse) {
+	channel.Write([]byte(fmt.Sprintf("  %d finished games, %d with recordings\r\n", games.Sessions, games.Recordings)))
+	channel.Write([]byte(fmt.Sprintf("  %d dumplogs\r\n", games.Dumplogs)))
+	channel.Write([]byte(fmt.Sprintf("  %d saved games\r\n", games.Saves)))
+	if games.SkippedSaves > 0 {
+		channel.Write([]byte(fmt.Sprintf("  (%d saved games stay behind: you have your own save for the game, or they are damaged)\r\n", games.SkippedSaves)))
+	}
+	channel.Write([]byte(fmt.Sprintf("  %d settings\r\n", summary.Preferences)))
+	if summary.SkippedPreferences > 0 {
+		channel.Write([]byte(fmt.Sprintf("  (%d settings you already have keep your value)\r\n", summary.SkippedPreferences)))
+	}
+	channel.Write([]byte(fmt.Sprintf("  %d API keys and %d notifications\r\n", summary.ApiKeys, summary.Notifications)))
+	if summary.OwnedGroups > 0 {
+		channel.Write([]byte(fmt.Sprintf("  ownership of %d groups\r\n", summary.OwnedGroups)))
+	}
+	if summary.Group != "" {
+		channel.Write([]byte(fmt.Sprintf("  membership of group %s\r\n", summary.Group)))
+	}
+}
@@ -0,0 +1,253 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// moderationFlags are the flags moderators can set, in menu order
+var moderationFlags = []string{"warned", "restricted", "play_time_exempt"}
+
+// handleModeration shows the moderation menu until the moderator returns to the main menu
+func (p *MenuChoiceProcessor) handleModeration(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	if !menu.IsModerator(userInfo) {
+		channel.Write([]byte("Access denied: Moderator privileges required.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	token := p.getAdminToken(sshConn)
+	if token == "" {
+		channel.Write([]byte("Error: Unable to get moderator authentication token.\r\n"))
+		p.pause.long()
+		return nil
+	}
+
+	for {
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Moderation ===\r\n\r\n"))
+		channel.Write([]byte("  [v] View user notes and flags\r\n"))
+		channel.Write([]byte("  [n] Add note to user\r\n"))
+		channel.Write([]byte("  [f] Set flag on user\r\n"))
+		channel.Write([]byte("  [x] Clear flag from user\r\n"))
+		channel.Write([]byte("  [l] List users by flag\r\n"))
+		channel.Write([]byte("  [q] Return to main menu\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		var err error
+		switch strings.ToLower(string(buffer[0])) {
+		case "v":
+			err = p.handleViewModeration(ctx, channel, userInfo, token)
+		case "n":
+			err = p.handleAddUserNote(ctx, channel, userInfo, token)
+		case "f":
+			err = p.handleSetModerationFlag(ctx, channel, userInfo, token)
+		case "x":
+			err = p.handleClearModerationFlag(ctx, channel, userInfo, token)
+		case "l":
+			err = p.handleListFlaggedUsers(ctx, channel, userInfo, token)
+		case "q", "\x03", "\x04":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+	}
+}
+
+// handleViewModeration shows the notes and flags on a user account
+func (p *MenuChoiceProcessor) handleViewModeration(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	targetUsername, err := p.promptForUsername(ctx, channel, "Username")
+	if err != nil || targetUsername == "" {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.GetUserModeration(ctx, token, targetUsername)
+	if err != nil {
+		p.logger.Error("Failed to get user moderation record", "error", err, "moderator", userInfo.Username, "target", targetUsername)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\nFlags for %s:\r\n", targetUsername)))
+	if len(resp.Flags) == 0 {
+		channel.Write([]byte("  (none)\r\n"))
+	}
+	for _, flag := range resp.Flags {
+		channel.Write([]byte(fmt.Sprintf("  %-13s by %-12s %s  %s\r\n",
+			flag.Flag, flag.SetBy, flag.CreatedAt.AsTime().Format("2006-01-02"), flag.Reason)))
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\nNotes for %s:\r\n", targetUsername)))
+	if len(resp.Notes) == 0 {
+		channel.Write([]byte("  (none)\r\n"))
+	}
+	for _, note := range resp.Notes {
+		channel.Write([]byte(fmt.Sprintf("  %s %-12s %s\r\n",
+			note.CreatedAt.AsTime().Format("2006-01-02 15:04"), note.Author, note.Note)))
+	}
+
+	return nil
+}
+
+// handleAddUserNote attaches a note to a user account
+func (p *MenuChoiceProcessor) handleAddUserNote(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	targetUsername, err := p.promptForUsername(ctx, channel, "Username")
+	if err != nil || targetUsername == "" {
+		return err
+	}
+
+	note, err := p.promptForUsername(ctx, channel, "Note")
+	if err != nil {
+		return err
+	}
+	if note == "" {
+		channel.Write([]byte("Note cannot be empty.\r\n"))
+		return nil
+	}
+
+	resp, err := p.authManager.authClient.AddUserNote(ctx, token, targetUsername, note)
+	if err != nil {
+		p.logger.Error("Failed to add user note", "error", err, "moderator", userInfo.Username, "target", targetUsername)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+
+	p.writeModerationResult(channel, resp)
+	return nil
+}
+
+// handleSetModerationFlag sets a moderation flag on a user account
+func (p *MenuChoiceProcessor) handleSetModerationFlag(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	targetUsername, err := p.promptForUsername(ctx, channel, "Username")
+	if err != nil || targetUsername == "" {
+		return err
+	}
+
+	flag, err := p.promptForModerationFlag(ctx, channel)
+	if err != nil || flag == "" {
+		return err
+	}
+
+	reason, err := p.promptForUsername(ctx, channel, "Reason")
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.SetUserModerationFlag(ctx, token, targetUsername, flag, reason)
+	if err != nil {
+		p.logger.Error("Failed to set moderation flag", "error", err, "moderator", userInfo.Username, "target", targetUsername, "flag", flag)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+
+	p.writeModerationResult(channel, resp)
+	return nil
+}
+
+// handleClearModerationFlag clears a moderation flag from a user account
+func (p *MenuChoiceProcessor) handleClearModerationFlag(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	targetUsername, err := p.promptForUsername(ctx, channel, "Username")
+	if err != nil || targetUsername == "" {
+		return err
+	}
+
+	flag, err := p.promptForModerationFlag(ctx, channel)
+	if err != nil || flag == "" {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.ClearUserModerationFlag(ctx, token, targetUsername, flag)
+	if err != nil {
+		p.logger.Error("Failed to clear moderation flag", "error", err, "moderator", userInfo.Username, "target", targetUsername, "flag", flag)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+
+	p.writeModerationResult(channel, resp)
+	return nil
+}
+
+// handleListFlaggedUsers lists the users with a moderation flag set
+func (p *MenuChoiceProcessor) handleListFlaggedUsers(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	flag, err := p.promptForModerationFlag(ctx, channel)
+	if err != nil || flag == "" {
+		return err
+	}
+
+	resp, err := p.authManager.authClient.ListUsersByModerationFlag(ctx, token, flag)
+	if err != nil {
+		p.logger.Error("Failed to list flagged users", "error", err, "moderator", userInfo.Username, "flag", flag)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+
+	channel.Write([]byte(fmt.Sprintf("\r\nUsers flagged %s:\r\n", flag)))
+	if len(resp.Users) == 0 {
+		channel.Write([]byte("  (none)\r\n"))
+	}
+	for _, flagged := range resp.Users {
+		channel.Write([]byte(fmt.Sprintf("  %-20s by %-12s %s  %s\r\n",
+			flagged.Username, flagged.SetBy, flagged.CreatedAt.AsTime().Format("2006-01-02"), flagged.Reason)))
+	}
+
+	return nil
+}
+
+// promptForModerationFlag asks the moderator to pick a flag by number
+func (p *MenuChoiceProcessor) promptForModerationFlag(ctx context.Context, channel ssh.Channel) (string, error) {
+	for i, flag := range moderationFlags {
+		channel.Write([]byte(fmt.Sprintf("  %d) %s\r\n", i+1, flag)))
+	}
+
+	choice, err := p.promptForUsername(ctx, channel, "Flag")
+	if err != nil {
+		return "", err
+	}
+
+	for i, flag := range moderationFlags {
+		if choice == fmt.Sprintf("%d", i+1) || choice == flag {
+			return flag, nil
+		}
+	}
+
+	channel.Write([]byte("Invalid flag.\r\n"))
+	return "", nil
+}
+
+// writeModerationResult reports the outcome of a moderation action
+func (p *MenuChoiceProcessor) writeModerationResult(channel ssh.Channel, resp *authv1.AdminActionResponse) {
+	if resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", resp.Message)))
+	} else {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+	}
+}
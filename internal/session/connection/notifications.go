@@ -0,0 +1,160 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// notificationPageSize is how many notifications the reading screen shows
+const notificationPageSize = 10
+
+// refreshUnreadNotifications fetches the unread count the main menu badges
+// notifications with. Failures leave the badge off rather than the menu.
+func (p *MenuChoiceProcessor) refreshUnreadNotifications(ctx context.Context, userInfo *authv1.User, sshConn *ssh.ServerConn) {
+	resp, err := p.authManager.authClient.ListNotifications(ctx, p.getAdminToken(sshConn), true, 1)
+	if err != nil || !resp.Success {
+		p.logger.Debug("Failed to count unread notifications", "error", err, "username", userInfo.Username)
+		return
+	}
+	setUnreadNotifications(userInfo, resp.UnreadCount)
+}
+
+// setUnreadNotifications records the unread count on the user for the menu
+func setUnreadNotifications(userInfo *authv1.User, unread int32) {
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["unread_notifications"] = strconv.Itoa(int(unread))
+}
+
+// handleNotifications shows the player's latest notifications and marks them
+// read. Admins may also send notifications from here.
+func (p *MenuChoiceProcessor) handleNotifications(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	token := p.getAdminToken(sshConn)
+
+	for {
+		resp, err := p.authManager.authClient.ListNotifications(ctx, token, false, notificationPageSize)
+		if err != nil || !resp.Success {
+			p.logger.Error("Failed to list notifications", "error", err, "username", userInfo.Username)
+			channel.Write([]byte("\r\nFailed to load your notifications. Please try again later.\r\n"))
+			p.pause.error()
+			return nil
+		}
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Notifications ===\r\n\r\n"))
+		p.writeNotifications(channel, resp.Notifications)
+
+		// Reading the screen reads the notifications, except for support
+		// staff viewing someone else's account
+		if resp.UnreadCount > 0 && !isImpersonating(sshConn) {
+			if marked, err := p.authManager.authClient.MarkNotificationsRead(ctx, token, nil); err == nil && marked.Success {
+				setUnreadNotifications(userInfo, marked.UnreadCount)
+			} else {
+				p.logger.Warn("Failed to mark notifications read", "error", err, "username", userInfo.Username)
+			}
+		}
+
+		if userInfo.IsAdmin {
+			channel.Write([]byte("\r\n  [s] Send a notification\r\n"))
+		}
+		channel.Write([]byte("\r\n  [q] Back\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n\r\n"))
+
+		switch strings.ToLower(string(buffer[0])) {
+		case "s":
+			if !userInfo.IsAdmin {
+				continue
+			}
+			err = p.handleSendNotification(ctx, channel, userInfo, token)
+		case "q", "\x03", "\x04", "\x1b":
+			return nil
+		default:
+			continue
+		}
+
+		if err != nil {
+			if err.Error() == "user cancelled" {
+				continue
+			}
+			return err
+		}
+
+		channel.Write([]byte("\r\nPress any key to continue..."))
+		channel.Read(buffer)
+	}
+}
+
+// writeNotifications lists notifications, newest first, marking unread ones
+func (p *MenuChoiceProcessor) writeNotifications(channel ssh.Channel, notifications []*authv1.Notification) {
+	if len(notifications) == 0 {
+		channel.Write([]byte("You have no notifications.\r\n"))
+		return
+	}
+
+	for _, notification := range notifications {
+		marker := " "
+		if notification.ReadAt == nil {
+			marker = "*"
+		}
+		channel.Write([]byte(fmt.Sprintf(" %s %s  %s\r\n",
+			marker, notification.CreatedAt.AsTime().Local().Format("2006-01-02 15:04"), notification.Title)))
+		if notification.Body != "" {
+			for _, line := range strings.Split(notification.Body, "\n") {
+				channel.Write([]byte(fmt.Sprintf("                     %s\r\n", strings.TrimRight(line, "\r"))))
+			}
+		}
+	}
+}
+
+// handleSendNotification sends an announcement to one player or everyone
+func (p *MenuChoiceProcessor) handleSendNotification(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, token string) error {
+	username, err := p.promptForUsername(ctx, channel, "Username (empty for everyone)")
+	if err != nil {
+		return err
+	}
+	title, err := p.promptForUsername(ctx, channel, "Title")
+	if err != nil || title == "" {
+		return err
+	}
+	body, err := p.promptForUsername(ctx, channel, "Message")
+	if err != nil {
+		return err
+	}
+
+	if username == "" {
+		channel.Write([]byte("Send to every player? (y/N): "))
+		confirmed, err := p.authManager.readYesNo(ctx, channel)
+		if err != nil {
+			return err
+		}
+		channel.Write([]byte("\r\n"))
+		if !confirmed {
+			return nil
+		}
+	}
+
+	resp, err := p.authManager.authClient.SendNotification(ctx, token, username, title, body)
+	if err != nil {
+		p.logger.Error("Failed to send notification", "error", err, "username", userInfo.Username)
+		channel.Write([]byte(fmt.Sprintf("Error: %v\r\n", err)))
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("✗ %s\r\n", resp.Error)))
+		return nil
+	}
+	channel.Write([]byte(fmt.Sprintf("✓ %s\r\n", resp.Message)))
+	return nil
+}
@@ -0,0 +1,83 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dungeongate/internal/session/terminal"
+	"golang.org/x/crypto/ssh"
+)
+
+// pageLines shows text one screen at a time under a status line naming it,
+// until the player quits
+func pageLines(ctx context.Context, channel ssh.Channel, title string, lines []string, terminalRows int) error {
+	// Leave one row for the status line
+	pageSize := terminalRows - 1
+	if pageSize < 5 {
+		pageSize = 23
+	}
+
+	inputHandler := terminal.NewInputHandler(channel)
+	top := 0
+	for {
+		maxTop := len(lines) - pageSize
+		if maxTop < 0 {
+			maxTop = 0
+		}
+		if top > maxTop {
+			top = maxTop
+		}
+		if top < 0 {
+			top = 0
+		}
+
+		channel.Write([]byte("\033[2J\033[H"))
+		end := top + pageSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		for _, line := range lines[top:end] {
+			channel.Write([]byte(line + "\r\n"))
+		}
+		channel.Write([]byte(fmt.Sprintf("\033[7m-- %s %d-%d/%d  [space] next  [b] back  [j/k] scroll  [q] quit --\033[0m",
+			title, top+1, end, len(lines))))
+
+		event, err := inputHandler.ReadInput(ctx)
+		if err != nil {
+			return err
+		}
+
+		switch event.Type {
+		case terminal.EventCharacter:
+			switch event.Character {
+			case ' ', 'f':
+				top += pageSize
+			case 'b':
+				top -= pageSize
+			case 'j':
+				top++
+			case 'k':
+				top--
+			case 'g':
+				top = 0
+			case 'G':
+				top = maxTop
+			case 'q', 'Q':
+				return nil
+			}
+		case terminal.EventKey:
+			switch event.KeyCode {
+			case terminal.KeyDown, terminal.KeyEnter:
+				top++
+			case terminal.KeyUp:
+				top--
+			case terminal.KeyHome:
+				top = 0
+			case terminal.KeyEnd:
+				top = maxTop
+			case terminal.KeyEscape, terminal.KeyCtrlC, terminal.KeyCtrlD:
+				return nil
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package connection
+
+import (
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// messagePause keeps a message on screen long enough to read before the menu
+// redraws over it. Brief notices such as "Login cancelled." show for half the
+// error pause, and messages with more to read for half as long again.
+type messagePause struct {
+	errorPause time.Duration
+}
+
+// newMessagePause creates a pause showing errors for config.DefaultMenuErrorPause
+func newMessagePause() *messagePause {
+	return &messagePause{errorPause: config.DefaultMenuErrorPause}
+}
+
+// duration returns the error pause; a nil pause uses the default
+func (p *messagePause) duration() time.Duration {
+	if p == nil {
+		return config.DefaultMenuErrorPause
+	}
+	return p.errorPause
+}
+
+// error waits for an error or notice to be read
+func (p *messagePause) error() {
+	time.Sleep(p.duration())
+}
+
+// brief waits for a short notice to be read
+func (p *messagePause) brief() {
+	time.Sleep(p.duration() / 2)
+}
+
+// long waits for a message with more to read, such as what to tell an admin
+func (p *messagePause) long() {
+	time.Sleep(p.duration() * 3 / 2)
+}
@@ -0,0 +1,440 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// PlayState is a state of a play session, the menus, screens and games a
+// player goes through on an interactive SSH shell
+type PlayState string
+
+// Play session states. A new flow, such as a second authentication factor,
+// is added as a state with its own step, reached from identify.
+const (
+	PlayStateHealthCheck        PlayState = "health_check"        // Checking the auth and game services are up
+	PlayStateServiceUnavailable PlayState = "service_unavailable" // Showing the service unavailable screen
+	PlayStateIdentify           PlayState = "identify"            // Refreshing who the player is and deciding where they go
+	PlayStatePasswordChange     PlayState = "password_change"     // Forcing a one-time password to be changed
+	PlayStateTerms              PlayState = "terms"               // Waiting for the current terms of service to be accepted
+	PlayStateResume             PlayState = "resume"              // Putting a player back into a game they left running
+	PlayStateMenu               PlayState = "menu"                // Showing the main menu
+	PlayStateAction             PlayState = "action"              // Carrying out a menu choice, such as playing a game
+	PlayStateDone               PlayState = "done"                // The session is over and the channel closes
+)
+
+// playStates are the states a timeout can be set for
+var playStates = []PlayState{
+	PlayStateHealthCheck,
+	PlayStateServiceUnavailable,
+	PlayStateIdentify,
+	PlayStatePasswordChange,
+	PlayStateTerms,
+	PlayStateResume,
+	PlayStateMenu,
+	PlayStateAction,
+}
+
+// PlayTransition is a play session moving from one state to the next
+type PlayTransition struct {
+	ConnID   string
+	Username string
+	From     PlayState
+	To       PlayState
+	Duration time.Duration // Time spent in From
+	TimedOut bool          // From ran out of time, so To is done
+}
+
+// PlayStateHook is called on every play session transition, between the
+// state left and the next. Hooks run on the connection's goroutine, so they
+// must not block.
+type PlayStateHook func(PlayTransition)
+
+// PlayStateRecorder counts play session transitions and states that ran out
+// of time; metrics.Registry implements it
+type PlayStateRecorder interface {
+	RecordPlayStateTransition(from, to string, duration time.Duration)
+	RecordPlayStateTimeout(state string)
+}
+
+// SetPlayStateTimeouts sets how long players may stay in each play session
+// state, by state name. States left out or set to zero never time out.
+func (h *Handler) SetPlayStateTimeouts(timeouts map[string]time.Duration) error {
+	known := make(map[PlayState]bool, len(playStates))
+	for _, state := range playStates {
+		known[state] = true
+	}
+	playTimeouts := make(map[PlayState]time.Duration, len(timeouts))
+	for name, timeout := range timeouts {
+		state := PlayState(name)
+		if !known[state] {
+			return fmt.Errorf("unknown play session state %q", name)
+		}
+		if timeout > 0 {
+			playTimeouts[state] = timeout
+		}
+	}
+	h.playTimeouts = playTimeouts
+	return nil
+}
+
+// AddPlayStateHook adds a hook called on every play session transition
+func (h *Handler) AddPlayStateHook(hook PlayStateHook) {
+	h.playHooks = append(h.playHooks, hook)
+}
+
+// SetPlayStateRecorder sets where play session transitions and timeouts are counted
+func (h *Handler) SetPlayStateRecorder(recorder PlayStateRecorder) {
+	h.AddPlayStateHook(func(t PlayTransition) {
+		recorder.RecordPlayStateTransition(string(t.From), string(t.To), t.Duration)
+		if t.TimedOut {
+			recorder.RecordPlayStateTimeout(string(t.From))
+		}
+	})
+}
+
+// playStep runs one state and returns the state to go to next. ctx is
+// cancelled when the state runs out of time.
+type playStep func(ctx context.Context) PlayState
+
+// playMachine runs a play session's states one after another until done,
+// timing each state and telling the hooks about every transition
+type playMachine struct {
+	steps    map[PlayState]playStep
+	timeouts map[PlayState]time.Duration
+	hooks    []PlayStateHook
+	// expire is called from a timer when a state runs out of time. It must
+	// make the running step return, by closing the channel it reads.
+	expire   func(state PlayState)
+	connID   string
+	username string
+	logger   *slog.Logger
+	now      func() time.Time
+}
+
+// run runs the session from start until a step returns done
+func (m *playMachine) run(ctx context.Context, start PlayState) {
+	for state := start; state != PlayStateDone; {
+		state = m.runState(ctx, state)
+	}
+}
+
+// runState runs one state, bounded by its timeout, and returns the next
+func (m *playMachine) runState(ctx context.Context, state PlayState) PlayState {
+	step, ok := m.steps[state]
+	if !ok {
+		m.logger.Error("Play session reached a state with no step", "connection_id", m.connID, "state", state)
+		return PlayStateDone
+	}
+
+	stateCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var timedOut atomic.Bool
+	if timeout := m.timeouts[state]; timeout > 0 {
+		timer := time.AfterFunc(timeout, func() {
+			timedOut.Store(true)
+			cancel()
+			m.expire(state)
+		})
+		defer timer.Stop()
+	}
+
+	started := m.now()
+	next := step(stateCtx)
+	if timedOut.Load() {
+		next = PlayStateDone
+	}
+	m.transition(PlayTransition{
+		ConnID:   m.connID,
+		Username: m.username,
+		From:     state,
+		To:       next,
+		Duration: m.now().Sub(started),
+		TimedOut: timedOut.Load(),
+	})
+	return next
+}
+
+// transition logs a transition for audit and passes it to the hooks
+func (m *playMachine) transition(t PlayTransition) {
+	m.logger.Debug("Play session transition", "connection_id", t.ConnID, "username", t.Username,
+		"from", t.From, "to", t.To, "duration", t.Duration, "timed_out", t.TimedOut)
+	for _, hook := range m.hooks {
+		hook(t)
+	}
+}
+
+// playSession is the state of one interactive shell shared by its steps
+type playSession struct {
+	h          *Handler
+	channel    ssh.Channel
+	connID     string
+	sshConn    *ssh.ServerConn
+	cols, rows int
+	clientTerm ClientTerminal
+	// The user the channel counts against the concurrent session limit,
+	// released by the channel once the session ends
+	loginUsername *string
+
+	userInfo         *authv1.User
+	reconnectPending bool // Set for each new login, which may go straight back into a game
+	menuChoice       *menu.MenuChoice
+	menusStarted     bool // The services were up when the player connected
+	untrack          func()
+
+	// Headless sessions, run for SSH commands and the API, have no screens.
+	// They stop at the first state that would show one, kept in stopped.
+	headless bool
+	stopped  PlayState
+	// admit applies the multi-login policy to a new login in place of
+	// admitLogin, returning false when the connection must be closed
+	admit func(ctx context.Context, userInfo *authv1.User, logins int) bool
+}
+
+// runPlaySession runs the menus, screens and games of an interactive shell
+// until the player quits or the connection closes
+func (h *Handler) runPlaySession(ctx context.Context, channel ssh.Channel, connID string, sshConn *ssh.ServerConn, cols, rows int, clientTerm ClientTerminal, loginUsername *string) {
+	p := &playSession{
+		h:             h,
+		channel:       channel,
+		connID:        connID,
+		sshConn:       sshConn,
+		cols:          cols,
+		rows:          rows,
+		clientTerm:    clientTerm,
+		loginUsername: loginUsername,
+	}
+	defer func() {
+		if p.untrack != nil {
+			p.untrack()
+		}
+	}()
+
+	p.machine(map[PlayState]playStep{
+		PlayStateHealthCheck:        p.healthCheck,
+		PlayStateServiceUnavailable: p.serviceUnavailable,
+		PlayStateIdentify:           p.identify,
+		PlayStatePasswordChange:     p.passwordChange,
+		PlayStateTerms:              p.terms,
+		PlayStateResume:             p.resume,
+		PlayStateMenu:               p.menu,
+		PlayStateAction:             p.action,
+	}).run(ctx, PlayStateHealthCheck)
+}
+
+// runHeadless runs the states before the menus for a channel with no
+// screens, from start until the first state that would show one. stopped is
+// left empty when the login was refused.
+func (p *playSession) runHeadless(ctx context.Context, start PlayState) {
+	p.headless = true
+	p.machine(map[PlayState]playStep{
+		PlayStateHealthCheck:        p.healthCheck,
+		PlayStateServiceUnavailable: p.stopAt(PlayStateServiceUnavailable),
+		PlayStateIdentify:           p.identify,
+		PlayStatePasswordChange:     p.stopAt(PlayStatePasswordChange),
+		PlayStateTerms:              p.stopAt(PlayStateTerms),
+		PlayStateMenu:               p.stopAt(PlayStateMenu),
+	}).run(ctx, start)
+}
+
+// machine returns a play machine running the session's states
+func (p *playSession) machine(steps map[PlayState]playStep) *playMachine {
+	return &playMachine{
+		steps:    steps,
+		timeouts: p.h.playTimeouts,
+		hooks:    p.h.playHooks,
+		expire:   p.expire,
+		connID:   p.connID,
+		username: p.sshConn.User(),
+		logger:   p.h.logger,
+		now:      time.Now,
+	}
+}
+
+// stopAt returns a step ending a headless session at state
+func (p *playSession) stopAt(state PlayState) playStep {
+	return func(context.Context) PlayState {
+		p.stopped = state
+		return PlayStateDone
+	}
+}
+
+// expire tells the player the state they are in ran out of time and closes
+// the channel, ending the session
+func (p *playSession) expire(state PlayState) {
+	p.h.logger.Info("Play session state timed out", "connection_id", p.connID, "username", p.sshConn.User(), "state", state)
+	written := make(chan struct{})
+	go func() {
+		defer close(written)
+		p.channel.Write([]byte("\r\n\r\nYou have been disconnected for taking too long. Goodbye!\r\n"))
+	}()
+	select {
+	case <-written:
+	case <-time.After(noticeTimeout):
+	}
+	p.channel.Close()
+}
+
+// healthCheck checks the services the menus need before showing any
+func (p *playSession) healthCheck(ctx context.Context) PlayState {
+	healthy, serviceStatus := p.h.serviceHealthChecker.CheckServiceHealth(ctx)
+	if !healthy {
+		p.h.logger.Warn("Required services unavailable at connection time", "username", p.sshConn.User(), "services", serviceStatus)
+		return PlayStateServiceUnavailable
+	}
+
+	// The menus run until the player quits or the connection closes
+	if !p.headless {
+		p.menusStarted = true
+		p.untrack = p.h.guard.track("menu")
+	}
+	p.userInfo = p.h.resolveUser(ctx, p.sshConn)
+	return PlayStateIdentify
+}
+
+// serviceUnavailable shows the service unavailable screen. Players who
+// connected while the services were down are disconnected after it; players
+// who lost them while in the menus go back to the menus.
+func (p *playSession) serviceUnavailable(ctx context.Context) PlayState {
+	err := p.h.serviceHealthChecker.HandleServiceUnavailable(ctx, p.channel, p.connID, p.sshConn.User())
+	if !p.menusStarted || (err != nil && err.Error() == "user quit") {
+		return PlayStateDone
+	}
+	return PlayStateIdentify
+}
+
+// identify refreshes who the player is, which changes as they log in, and
+// sends them to whatever they must do before the menu
+func (p *playSession) identify(ctx context.Context) PlayState {
+	currentUserInfo, err := p.h.authManager.GetUserInfo(ctx, p.sshConn)
+	if err == nil && currentUserInfo != nil {
+		p.userInfo = currentUserInfo
+	} else if isImpersonating(p.sshConn) {
+		// The impersonation token has run out
+		p.h.menuChoiceProcessor.endImpersonation(p.channel, p.sshConn, "Impersonation expired.")
+		return PlayStateIdentify
+	}
+	userInfo := p.userInfo
+
+	// Apply the multi-login policy once per authenticated user
+	// An admin impersonating a user does not count as the user's login
+	if userInfo != nil && userInfo.Id != "" && userInfo.Username != *p.loginUsername && !isImpersonating(p.sshConn) {
+		if *p.loginUsername != "" {
+			p.h.manager.ReleaseLogin(ctx, *p.loginUsername)
+		}
+		*p.loginUsername = userInfo.Username
+		logins := p.h.manager.AcquireLogin(ctx, userInfo.Username)
+		var admitted bool
+		if p.admit != nil {
+			admitted = p.admit(ctx, userInfo, logins)
+		} else {
+			admitted = p.h.admitLogin(ctx, p.channel, userInfo, logins, p.sshConn, p.clientTerm)
+		}
+		if !admitted {
+			return PlayStateDone
+		}
+		p.reconnectPending = !p.headless && extraLoginPolicy(p.sshConn) == ""
+	}
+	// Reloaded users bring changes to their online status preference
+	if !isImpersonating(p.sshConn) {
+		presenceOf(ctx).identify(ctx, userInfo)
+	}
+
+	switch {
+	case userInfo == nil || userInfo.Id == "":
+		return PlayStateMenu
+	case userInfo.Metadata != nil && userInfo.Metadata["require_password_change"] == "true":
+		return PlayStatePasswordChange
+	case termsPending(userInfo, p.sshConn) != "":
+		return PlayStateTerms
+	case p.reconnectPending:
+		return PlayStateResume
+	}
+	return PlayStateMenu
+}
+
+// passwordChange forces a player logged in with a one-time password to
+// choose a new one
+func (p *playSession) passwordChange(ctx context.Context) PlayState {
+	choice, err := p.h.authManager.HandleRequiredPasswordChange(ctx, p.channel, p.userInfo, p.sshConn)
+	if err != nil {
+		p.h.logger.Error("Error handling required password change", "error", err, "username", p.userInfo.Username)
+		return PlayStateIdentify
+	}
+	if choice != nil && choice.Action == "quit" {
+		return PlayStateDone
+	}
+	return PlayStateIdentify
+}
+
+// terms blocks the menu until the current terms of service are accepted
+func (p *playSession) terms(ctx context.Context) PlayState {
+	choice, err := p.h.authManager.HandleRequiredTerms(ctx, p.channel, p.userInfo, p.sshConn)
+	if err != nil {
+		p.h.logger.Error("Error handling required terms acceptance", "error", err, "username", p.userInfo.Username)
+		return PlayStateDone
+	}
+	if choice != nil && choice.Action == "quit" {
+		return PlayStateDone
+	}
+	return PlayStateIdentify
+}
+
+// resume puts players who asked to back into a game they left running
+func (p *playSession) resume(ctx context.Context) PlayState {
+	p.reconnectPending = false
+	if p.h.gameIOHandler.ResumeRunningGame(ctx, p.channel, p.userInfo, p.connID, p.cols, p.rows) {
+		return PlayStateIdentify
+	}
+	return PlayStateMenu
+}
+
+// menu shows the anonymous menu, or the user or admin menu once logged in
+func (p *playSession) menu(ctx context.Context) PlayState {
+	var err error
+	if p.userInfo == nil || p.userInfo.Id == "" {
+		p.menuChoice, err = p.h.menuHandler.ShowAnonymousMenu(ctx, p.channel, p.sshConn.User())
+	} else {
+		p.h.menuChoiceProcessor.refreshUnreadNotifications(ctx, p.userInfo, p.sshConn)
+		p.menuChoice, err = p.h.menuHandler.ShowUserMenu(ctx, p.channel, p.userInfo)
+	}
+	if err != nil {
+		// A graceful disconnection (EOF) or quit is not an error
+		if strings.Contains(err.Error(), "EOF") || err.Error() == "user quit" {
+			return PlayStateDone
+		}
+		p.h.logger.Error("Error in menu handler", "error", err, "username", p.sshConn.User())
+		if ctx.Err() != nil {
+			return PlayStateDone
+		}
+		return PlayStateIdentify
+	}
+	return PlayStateAction
+}
+
+// action carries out the player's menu choice, then returns to the menu
+func (p *playSession) action(ctx context.Context) PlayState {
+	err := p.h.menuChoiceProcessor.HandleMenuChoice(ctx, p.channel, p.menuChoice, p.userInfo, p.connID, p.sshConn.User(), p.cols, p.rows, p.clientTerm, p.sshConn)
+	if err == nil {
+		return PlayStateIdentify
+	}
+	switch err.Error() {
+	case "user quit":
+		return PlayStateDone
+	case "game service unavailable":
+		return PlayStateServiceUnavailable
+	}
+	p.h.logger.Error("Error handling menu choice", "error", err, "choice", p.menuChoice.Action, "username", p.sshConn.User())
+	if ctx.Err() != nil {
+		return PlayStateDone
+	}
+	return PlayStateIdentify
+}
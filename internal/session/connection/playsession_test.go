@@ -0,0 +1,123 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePlayStateRecorder struct {
+	transitions []string
+	timeouts    []string
+}
+
+func (r *fakePlayStateRecorder) RecordPlayStateTransition(from, to string, duration time.Duration) {
+	r.transitions = append(r.transitions, from+">"+to)
+}
+
+func (r *fakePlayStateRecorder) RecordPlayStateTimeout(state string) {
+	r.timeouts = append(r.timeouts, state)
+}
+
+func newTestPlayMachine(steps map[PlayState]playStep, timeouts map[PlayState]time.Duration, hooks ...PlayStateHook) *playMachine {
+	return &playMachine{
+		steps:    steps,
+		timeouts: timeouts,
+		hooks:    hooks,
+		expire:   func(PlayState) {},
+		connID:   "conn-1",
+		username: "player",
+		logger:   slog.New(slog.NewTextHandler(io.Discard, nil)),
+		now:      time.Now,
+	}
+}
+
+func TestPlayMachine(t *testing.T) {
+	var transitions []PlayTransition
+	menus := 0
+	machine := newTestPlayMachine(map[PlayState]playStep{
+		PlayStateIdentify: func(context.Context) PlayState { return PlayStateMenu },
+		PlayStateMenu: func(context.Context) PlayState {
+			menus++
+			if menus == 2 {
+				return PlayStateDone
+			}
+			return PlayStateAction
+		},
+		PlayStateAction: func(context.Context) PlayState { return PlayStateIdentify },
+	}, nil, func(t PlayTransition) { transitions = append(transitions, t) })
+
+	machine.run(context.Background(), PlayStateIdentify)
+
+	var path []PlayState
+	for _, transition := range transitions {
+		assert.Equal(t, "conn-1", transition.ConnID)
+		assert.Equal(t, "player", transition.Username)
+		assert.False(t, transition.TimedOut)
+		path = append(path, transition.From)
+	}
+	assert.Equal(t, []PlayState{PlayStateIdentify, PlayStateMenu, PlayStateAction, PlayStateIdentify, PlayStateMenu}, path)
+	assert.Equal(t, PlayStateDone, transitions[len(transitions)-1].To)
+}
+
+func TestPlayMachine_Timeout(t *testing.T) {
+	recorder := &fakePlayStateRecorder{}
+	h := &Handler{}
+	h.SetPlayStateRecorder(recorder)
+
+	// The step blocks like a screen waiting for input, until expire closes it
+	closed := make(chan struct{})
+	machine := newTestPlayMachine(map[PlayState]playStep{
+		PlayStateIdentify: func(context.Context) PlayState { return PlayStateTerms },
+		PlayStateTerms: func(ctx context.Context) PlayState {
+			<-closed
+			assert.Error(t, ctx.Err(), "the state's context is cancelled")
+			return PlayStateIdentify
+		},
+	}, map[PlayState]time.Duration{PlayStateTerms: 10 * time.Millisecond}, h.playHooks...)
+	var expired PlayState
+	machine.expire = func(state PlayState) {
+		expired = state
+		close(closed)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		machine.run(context.Background(), PlayStateIdentify)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("play session did not end when its state timed out")
+	}
+
+	assert.Equal(t, PlayStateTerms, expired)
+	assert.Equal(t, []string{"identify>terms", "terms>done"}, recorder.transitions, "a state that times out ends the session")
+	assert.Equal(t, []string{"terms"}, recorder.timeouts)
+}
+
+func TestPlayMachine_MissingStep(t *testing.T) {
+	var transitions []PlayTransition
+	machine := newTestPlayMachine(map[PlayState]playStep{
+		PlayStateIdentify: func(context.Context) PlayState { return PlayState("two_factor") },
+	}, nil, func(t PlayTransition) { transitions = append(transitions, t) })
+
+	machine.run(context.Background(), PlayStateIdentify)
+	require.Len(t, transitions, 1)
+	assert.Equal(t, PlayState("two_factor"), transitions[0].To)
+}
+
+func TestSetPlayStateTimeouts(t *testing.T) {
+	h := &Handler{}
+	require.NoError(t, h.SetPlayStateTimeouts(map[string]time.Duration{"terms": time.Minute, "menu": 0}))
+	assert.Equal(t, map[PlayState]time.Duration{PlayStateTerms: time.Minute}, h.playTimeouts, "zero never times out")
+
+	assert.Error(t, h.SetPlayStateTimeouts(map[string]time.Duration{"lobby": time.Minute}))
+	assert.Error(t, h.SetPlayStateTimeouts(map[string]time.Duration{"done": time.Minute}))
+}
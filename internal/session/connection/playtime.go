@@ -0,0 +1,99 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// playTimeNoticeDuration is how long a play-time notice stays over the game
+const playTimeNoticeDuration = 5 * time.Second
+
+// userPlayTimeLimit returns the play-time budget the auth service reported
+// for the user, or nil if they have none
+func userPlayTimeLimit(userInfo *authv1.User) *gamev2.PlayTimeLimit {
+	if userInfo == nil || userInfo.Metadata == nil {
+		return nil
+	}
+	daily, _ := strconv.ParseInt(userInfo.Metadata["play_time_daily"], 10, 64)
+	weekly, _ := strconv.ParseInt(userInfo.Metadata["play_time_weekly"], 10, 64)
+	if daily <= 0 && weekly <= 0 {
+		return nil
+	}
+	return &gamev2.PlayTimeLimit{DailySeconds: daily, WeeklySeconds: weekly}
+}
+
+// userPlayTimeWarning returns how long before the budget runs out the player
+// is warned
+func userPlayTimeWarning(userInfo *authv1.User) time.Duration {
+	if userInfo != nil && userInfo.Metadata != nil {
+		if seconds, err := strconv.Atoi(userInfo.Metadata["play_time_warn"]); err == nil && seconds > 0 {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+	return 10 * time.Minute
+}
+
+// watchPlayTime warns the player shortly before their play-time budget runs
+// out and again once it has. The game carries on either way; saving is up
+// to the player. It returns when ctx is cancelled.
+func (h *GameIOHandler) watchPlayTime(ctx context.Context, output *heldOutput, userInfo *authv1.User, sessionID string) {
+	if userPlayTimeLimit(userInfo) == nil {
+		return
+	}
+	session, err := h.gameClient.GetGameSession(ctx, sessionID)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to look up play time", "error", err, "session_id", sessionID)
+		return
+	}
+	if session.PlayTimeEndsAt == nil {
+		return
+	}
+	endsAt := *session.PlayTimeEndsAt
+
+	warnAt := endsAt.Add(-userPlayTimeWarning(userInfo))
+	if wait := time.Until(warnAt); wait > 0 {
+		if !sleepCtx(ctx, wait) {
+			return
+		}
+	}
+	if left := time.Until(endsAt); left > 0 {
+		output.notice("Play time", []string{
+			fmt.Sprintf("You have %s of play time left.", playTimeLeft(left)),
+			"Please save your game soon.",
+		}, playTimeNoticeDuration)
+		if !sleepCtx(ctx, left) {
+			return
+		}
+	}
+	output.notice("Play time", []string{
+		"Your play time is used up.",
+		"Please save your game now.",
+	}, playTimeNoticeDuration)
+}
+
+// playTimeLeft formats the remaining play time in whole minutes, e.g.
+// "10 minutes"
+func playTimeLeft(left time.Duration) string {
+	minutes := int((left + time.Minute - 1) / time.Minute)
+	if minutes == 1 {
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// sleepCtx waits for d, returning false if ctx is cancelled first
+func sleepCtx(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,33 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserPlayTimeLimit(t *testing.T) {
+	assert.Nil(t, userPlayTimeLimit(nil))
+	assert.Nil(t, userPlayTimeLimit(&authv1.User{Metadata: map[string]string{}}))
+
+	limit := userPlayTimeLimit(&authv1.User{Metadata: map[string]string{
+		"play_time_daily":  "3600",
+		"play_time_weekly": "0",
+		"play_time_warn":   "300",
+	}})
+	require.NotNil(t, limit)
+	assert.Equal(t, int64(3600), limit.DailySeconds)
+	assert.Equal(t, int64(0), limit.WeeklySeconds)
+
+	assert.Equal(t, 5*time.Minute, userPlayTimeWarning(&authv1.User{Metadata: map[string]string{"play_time_warn": "300"}}))
+	assert.Equal(t, 10*time.Minute, userPlayTimeWarning(nil))
+}
+
+func TestPlayTimeLeft(t *testing.T) {
+	assert.Equal(t, "10 minutes", playTimeLeft(10*time.Minute))
+	assert.Equal(t, "1 minute", playTimeLeft(20*time.Second))
+	assert.Equal(t, "2 minutes", playTimeLeft(61*time.Second))
+}
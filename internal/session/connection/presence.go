@@ -0,0 +1,157 @@
+package connection
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+// What players in the who list are doing
+const (
+	ActivityMenu     = "menu"
+	ActivityPlaying  = "playing"
+	ActivityWatching = "watching"
+)
+
+// OnlineUser is a logged-in player connected to this instance, as the who
+// list shows them
+type OnlineUser struct {
+	Username    string    `json:"username"`
+	Realm       string    `json:"realm,omitempty"`
+	Activity    string    `json:"activity"`
+	Target      string    `json:"target,omitempty"` // The game played or the player watched
+	ConnectedAt time.Time `json:"connected_at"`
+	// Hidden players turned show_online_status off; only admins see them
+	Hidden bool `json:"-"`
+}
+
+// Describe returns what the player is doing, such as "playing nethack"
+func (u OnlineUser) Describe() string {
+	if u.Target == "" {
+		return u.Activity
+	}
+	return u.Activity + " " + u.Target
+}
+
+// presence is what the player on one session channel is doing. Channels
+// nobody has logged in on are not listed.
+type presence struct {
+	connectedAt time.Time
+
+	mu       sync.Mutex
+	username string // As stored in the auth service
+	realm    *realm.Realm
+	hidden   bool
+	activity string
+	target   string
+}
+
+// joinPresence starts tracking the player on a new session channel; the
+// returned func stops when the channel closes
+func (m *Manager) joinPresence() (*presence, func()) {
+	p := &presence{connectedAt: time.Now(), activity: ActivityMenu}
+	m.presenceMu.Lock()
+	m.presences[p] = struct{}{}
+	m.presenceMu.Unlock()
+	return p, func() {
+		m.presenceMu.Lock()
+		delete(m.presences, p)
+		m.presenceMu.Unlock()
+	}
+}
+
+// Online returns the logged-in players connected to this instance, by
+// username then by how long they have been connected
+func (m *Manager) Online() []OnlineUser {
+	m.presenceMu.Lock()
+	presences := make([]*presence, 0, len(m.presences))
+	for p := range m.presences {
+		presences = append(presences, p)
+	}
+	m.presenceMu.Unlock()
+
+	users := make([]OnlineUser, 0, len(presences))
+	for _, p := range presences {
+		if user, ok := p.online(); ok {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b OnlineUser) int {
+		if c := strings.Compare(strings.ToLower(a.Username), strings.ToLower(b.Username)); c != 0 {
+			return c
+		}
+		return a.ConnectedAt.Compare(b.ConnectedAt)
+	})
+	return users
+}
+
+// online returns the player as the who list shows them, false before anyone
+// logged in
+func (p *presence) online() (OnlineUser, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.username == "" {
+		return OnlineUser{}, false
+	}
+	target := p.target
+	if p.activity == ActivityWatching {
+		target = p.realm.Local(target)
+	}
+	return OnlineUser{
+		Username:    p.realm.Local(p.username),
+		Realm:       p.realm.GetName(),
+		Activity:    p.activity,
+		Target:      target,
+		ConnectedAt: p.connectedAt,
+		Hidden:      p.hidden,
+	}, true
+}
+
+// identify records who is logged in on the channel, and whether they want to
+// be listed. Called again as the user reloads, so preference changes apply.
+func (p *presence) identify(ctx context.Context, user *authv1.User) {
+	if p == nil || user == nil || user.Id == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.username = user.Username
+	p.realm = realm.FromContext(ctx)
+	p.hidden = menu.PreferencesOf(user).HideOnlineStatus
+}
+
+// doing records what the player started doing, such as playing a game; the
+// returned func puts them back in the menus
+func (p *presence) doing(activity, target string) func() {
+	if p == nil {
+		return func() {}
+	}
+	p.mu.Lock()
+	p.activity, p.target = activity, target
+	p.mu.Unlock()
+	return func() {
+		p.mu.Lock()
+		p.activity, p.target = ActivityMenu, ""
+		p.mu.Unlock()
+	}
+}
+
+type presenceKey struct{}
+
+// withPresence returns a context carrying the presence of a session channel,
+// so the handlers it reaches can record what the player is doing
+func withPresence(ctx context.Context, p *presence) context.Context {
+	return context.WithValue(ctx, presenceKey{}, p)
+}
+
+// presenceOf returns the presence carried by ctx, or nil
+func presenceOf(ctx context.Context) *presence {
+	p, _ := ctx.Value(presenceKey{}).(*presence)
+	return p
+}
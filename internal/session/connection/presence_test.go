@@ -0,0 +1,88 @@
+package connection
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+func TestPresence(t *testing.T) {
+	m := NewManager(10, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ctx := context.Background()
+
+	// Channels nobody logged in on are not listed
+	bob, leaveBob := m.joinPresence()
+	assert.Empty(t, m.Online())
+
+	bob.identify(ctx, &authv1.User{Id: "2", Username: "bob"})
+	alice, leaveAlice := m.joinPresence()
+	alice.identify(ctx, &authv1.User{Id: "1", Username: "alice", Metadata: map[string]string{"show_online_status": "false"}})
+	stopPlaying := bob.doing(ActivityPlaying, "nethack")
+
+	online := m.Online()
+	require.Len(t, online, 2)
+	assert.Equal(t, "alice", online[0].Username)
+	assert.True(t, online[0].Hidden)
+	assert.Equal(t, "menu", online[0].Describe())
+	assert.Equal(t, "bob", online[1].Username)
+	assert.Equal(t, "playing nethack", online[1].Describe())
+
+	stopPlaying()
+	assert.Equal(t, "menu", m.Online()[1].Describe())
+
+	leaveAlice()
+	leaveBob()
+	assert.Empty(t, m.Online())
+
+	// Players of other realms are listed by the names they know
+	retro := &realm.Realm{Name: "retro", StoragePrefix: "retro_"}
+	carol, leaveCarol := m.joinPresence()
+	defer leaveCarol()
+	carol.identify(realm.WithRealm(ctx, retro), &authv1.User{Id: "3", Username: "retro_carol"})
+	carol.doing(ActivityWatching, "retro_dave")
+	online = m.Online()
+	require.Len(t, online, 1)
+	assert.Equal(t, "carol", online[0].Username)
+	assert.Equal(t, "retro", online[0].Realm)
+	assert.Equal(t, "watching dave", online[0].Describe())
+
+	// Handlers reached without a presence record nothing
+	presenceOf(ctx).identify(ctx, &authv1.User{Id: "4", Username: "erin"})
+	presenceOf(ctx).doing(ActivityPlaying, "nethack")()
+	assert.Same(t, carol, presenceOf(withPresence(ctx, carol)))
+}
+
+func TestWhoLines(t *testing.T) {
+	now := time.Now()
+	users := []OnlineUser{
+		{Username: "alice", Activity: ActivityMenu, ConnectedAt: now.Add(-time.Minute), Hidden: true},
+		{Username: "bob", Activity: ActivityPlaying, Target: "nethack", ConnectedAt: now.Add(-90 * time.Second)},
+		{Username: "carol", Realm: "retro", Activity: ActivityMenu, ConnectedAt: now},
+	}
+
+	lines := whoLines(users, "", false, now)
+	assert.Len(t, lines, 6)
+	assert.Contains(t, lines[3], "bob")
+	assert.Contains(t, lines[3], "playing nethack")
+	assert.Contains(t, lines[3], "1m30s")
+	assert.Equal(t, "1 online", lines[5])
+
+	// Admins see hidden players, marked
+	lines = whoLines(users, "", true, now)
+	assert.Contains(t, lines[3], "alice (hidden)")
+	assert.Equal(t, "2 online", lines[len(lines)-1])
+
+	lines = whoLines(users, "retro", false, now)
+	assert.Contains(t, lines[3], "carol")
+
+	lines = whoLines(nil, "", false, now)
+	assert.Equal(t, "  Nobody is logged in.", lines[len(lines)-1])
+}
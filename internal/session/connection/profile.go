@@ -0,0 +1,235 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/capabilities"
+	"golang.org/x/crypto/ssh"
+)
+
+// RecordingPrivacyPreference is the user preference holding whether the
+// player's games are recorded publicly, for the player only, or not at all
+const RecordingPrivacyPreference = "recording"
+
+// Recording privacy settings, as understood by the game service
+const (
+	recordingPublic  = "public"
+	recordingPrivate = "private"
+	recordingOff     = "off"
+)
+
+// recordingPrivacyLabels describes each recording privacy setting
+var recordingPrivacyLabels = map[string]string{
+	recordingPublic:  "recorded, anyone may view",
+	recordingPrivate: "recorded, only you may view",
+	recordingOff:     "not recorded",
+}
+
+// userRecordingPrivacy returns the player's recording privacy setting
+func userRecordingPrivacy(userInfo *authv1.User) string {
+	if userInfo == nil || userInfo.Metadata == nil {
+		return recordingPublic
+	}
+	switch privacy := userInfo.Metadata["pref."+RecordingPrivacyPreference]; privacy {
+	case recordingPrivate, recordingOff:
+		return privacy
+	default:
+		return recordingPublic
+	}
+}
+
+// handleEditProfile shows the player's profile settings and lets them
+// change how their games are recorded, set their menu preferences, manage
+// their API keys and devices, merge in another account or, where the
+// server allows it, change their username
+func (p *MenuChoiceProcessor) handleEditProfile(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	if userInfo == nil {
+		channel.Write([]byte("Please login first to edit your profile.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	accessLines := p.accountAccessLines(ctx, userInfo, sshConn)
+
+	// The recording setting means nothing on a game service that records no games
+	recorded := p.gameIOHandler.gameClient.Supports(ctx, capabilities.Recording)
+
+	for {
+		current := userRecordingPrivacy(userInfo)
+
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte("=== Profile ===\r\n\r\n"))
+		channel.Write([]byte(fmt.Sprintf("Username:  %s\r\n", userInfo.Username)))
+		if recorded {
+			channel.Write([]byte(fmt.Sprintf("Recording: %s\r\n", recordingPrivacyLabels[current])))
+		} else {
+			channel.Write([]byte("Recording: games are not recorded on this server\r\n"))
+		}
+		newsToggle := "Hide"
+		if gameNewsHidden(userInfo) {
+			channel.Write([]byte("Game news: hidden\r\n\r\n"))
+			newsToggle = "Show"
+		} else {
+			channel.Write([]byte("Game news: shown before games start\r\n\r\n"))
+		}
+		if len(accessLines) > 0 {
+			channel.Write([]byte("Support access to your account (* new):\r\n"))
+			for _, line := range accessLines {
+				channel.Write([]byte(line + "\r\n"))
+			}
+			channel.Write([]byte("\r\n"))
+		}
+		if recorded {
+			channel.Write([]byte("Some games are always or never recorded, whatever you choose.\r\n\r\n"))
+			channel.Write([]byte("  p) Record my games for anyone to view\r\n"))
+			channel.Write([]byte("  s) Record my games for me only\r\n"))
+			channel.Write([]byte("  o) Do not record my games\r\n"))
+		}
+		channel.Write([]byte(fmt.Sprintf("  n) %s game news before games start\r\n", newsToggle)))
+		channel.Write([]byte("  e) Menu preferences\r\n"))
+		channel.Write([]byte("  k) API keys for scripts\r\n"))
+		channel.Write([]byte("  d) Devices\r\n"))
+		if usernameSelfService(userInfo) {
+			channel.Write([]byte("  u) Change username\r\n"))
+		}
+		channel.Write([]byte("  m) Merge another account into this one\r\n"))
+		channel.Write([]byte("  q) Back\r\n\r\n"))
+		channel.Write([]byte("Choice: "))
+
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+
+		var privacy string
+		switch buffer[0] {
+		case 'p', 'P':
+			privacy = recordingPublic
+		case 's', 'S':
+			privacy = recordingPrivate
+		case 'o', 'O':
+			privacy = recordingOff
+		case 'n', 'N':
+			p.setGameNews(ctx, channel, userInfo, gameNewsHidden(userInfo), sshConn)
+			continue
+		case 'e', 'E':
+			if err := p.handleMenuPreferences(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+			continue
+		case 'k', 'K':
+			if err := p.handleAPIKeys(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+			continue
+		case 'd', 'D':
+			if err := p.handleDevices(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+			if p.getAdminToken(sshConn) == "" {
+				return nil
+			}
+			continue
+		case 'u', 'U':
+			if !usernameSelfService(userInfo) {
+				continue
+			}
+			if err := p.handleChangeUsername(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+			continue
+		case 'm', 'M':
+			if err := p.handleMergeAccount(ctx, channel, userInfo, sshConn); err != nil {
+				return err
+			}
+			continue
+		case 'q', 'Q', 3, 4, 27:
+			return nil
+		default:
+			continue
+		}
+		if recorded && privacy != current {
+			p.setRecordingPrivacy(ctx, channel, userInfo, privacy, sshConn)
+		}
+	}
+}
+
+// setRecordingPrivacy saves the player's recording privacy setting
+func (p *MenuChoiceProcessor) setRecordingPrivacy(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, privacy string, sshConn *ssh.ServerConn) {
+	// Public is the default, so it is stored as no preference at all
+	value := privacy
+	if privacy == recordingPublic {
+		value = ""
+	}
+	resp, err := p.authManager.authClient.SetUserPreference(ctx, p.getAdminToken(sshConn), RecordingPrivacyPreference, value)
+	if err != nil || !resp.Success {
+		p.logger.Warn("Failed to save recording privacy", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nCould not save your recording setting.\r\n"))
+		p.pause.error()
+		return
+	}
+
+	// Keep the menu in step until the next login reloads the user
+	if userInfo.Metadata == nil {
+		userInfo.Metadata = make(map[string]string)
+	}
+	userInfo.Metadata["pref."+RecordingPrivacyPreference] = value
+	p.logger.Info("Recording privacy changed", "username", userInfo.Username, "recording", privacy)
+}
+
+// usernameSelfService reports whether the player may rename themselves
+func usernameSelfService(userInfo *authv1.User) bool {
+	return userInfo.Metadata != nil && userInfo.Metadata["username_self_service"] == "true"
+}
+
+// handleChangeUsername renames the player's account after they confirm it
+// with their password
+func (p *MenuChoiceProcessor) handleChangeUsername(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, sshConn *ssh.ServerConn) error {
+	channel.Write([]byte("\r\n\r\nYour games, saves and settings stay with your account.\r\n"))
+	channel.Write([]byte("Your old name stays reserved for you for a while after the change.\r\n\r\n"))
+
+	newUsername, err := p.promptForUsername(ctx, channel, "New username")
+	if err != nil || newUsername == "" {
+		return err
+	}
+	// The new name stays within the player's realm
+	newUsername, ok := realmUsername(ctx, newUsername)
+	if !ok {
+		channel.Write([]byte("\r\n✗ That username is not available.\r\n"))
+		p.pause.error()
+		return nil
+	}
+
+	channel.Write([]byte("Password: "))
+	password, err := p.authManager.readPasswordWithTerminal(ctx, channel)
+	if err != nil {
+		if err.Error() == "user cancelled" {
+			return nil
+		}
+		return err
+	}
+
+	resp, err := p.authManager.authClient.ChangeUsername(ctx, p.getAdminToken(sshConn), newUsername, password)
+	if err != nil {
+		p.logger.Error("Failed to change username", "error", err, "username", userInfo.Username)
+		channel.Write([]byte("\r\nCould not change your username.\r\n"))
+		p.pause.error()
+		return nil
+	}
+	if !resp.Success {
+		channel.Write([]byte(fmt.Sprintf("\r\n✗ %s\r\n", resp.Error)))
+		p.pause.error()
+		return nil
+	}
+
+	p.logger.Info("Username changed", "old_username", userInfo.Username, "new_username", resp.User.Username)
+
+	// Keep the menus in step; other players see the new name once the
+	// player next logs in
+	userInfo.Username = resp.User.Username
+	channel.Write([]byte(fmt.Sprintf("\r\n✓ You are now %s. Log in with this name from now on.\r\n", localUsername(ctx, userInfo.Username))))
+	p.pause.error()
+	return nil
+}
@@ -0,0 +1,63 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dungeongate/internal/session/realm"
+	"golang.org/x/crypto/ssh"
+)
+
+// SetRealms sets the realms connections are sorted into
+func (h *Handler) SetRealms(realms *realm.Resolver) {
+	h.realms = realms
+}
+
+// realmConnMetadata presents a login to the authentication callbacks under
+// the name its account has in the auth service
+type realmConnMetadata struct {
+	ssh.ConnMetadata
+	user string
+}
+
+// User returns the qualified username
+func (m realmConnMetadata) User() string {
+	return m.user
+}
+
+// realmServerConfig returns the SSH configuration for a connection that
+// reached connRealm. Passwords are checked against the accounts of the realm
+// the login names, or connRealm for logins naming none, and logins of
+// accounts belonging to another realm are refused.
+func (h *Handler) realmServerConfig(config *ssh.ServerConfig, connRealm *realm.Realm) *ssh.ServerConfig {
+	passwordCallback := config.PasswordCallback
+	if !h.realms.HasRealms() || passwordCallback == nil {
+		return config
+	}
+
+	realmConfig := *config
+	realmConfig.PasswordCallback = func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+		loginRealm, username := h.realms.ForLogin(conn.User(), connRealm)
+		qualified := loginRealm.Qualify(username)
+		if !loginRealm.Owns(qualified) {
+			h.logger.Warn("SSH authentication rejected: account belongs to another realm", "username", conn.User(), "remote_addr", conn.RemoteAddr())
+			return nil, fmt.Errorf("authentication failed")
+		}
+		return passwordCallback(realmConnMetadata{ConnMetadata: conn, user: qualified}, password)
+	}
+	return &realmConfig
+}
+
+// realmUsername returns the name a username typed on a connection has in
+// the auth service, and false when it names an account of another realm
+func realmUsername(ctx context.Context, username string) (string, bool) {
+	r := realm.FromContext(ctx)
+	qualified := r.Qualify(username)
+	return qualified, r.Owns(qualified)
+}
+
+// localUsername returns the name the players of the connection's realm know
+// an account by
+func localUsername(ctx context.Context, username string) string {
+	return realm.FromContext(ctx).Local(username)
+}
@@ -0,0 +1,57 @@
+package connection
+
+import (
+	"log/slog"
+	"net"
+	"testing"
+
+	"github.com/dungeongate/internal/session/realm"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// loginMetadata is the connection metadata of a login
+type loginMetadata struct {
+	ssh.ConnMetadata
+	user string
+}
+
+func (m loginMetadata) User() string         { return m.user }
+func (m loginMetadata) RemoteAddr() net.Addr { return &net.TCPAddr{} }
+
+func TestRealmServerConfig(t *testing.T) {
+	bannerConfig := &banner.BannerConfig{}
+	realms := realm.NewResolver([]*config.RealmConfig{{Name: "retro", Ports: []int{2223}}}, banner.NewBannerManager(bannerConfig, "test"), bannerConfig, "test")
+	h := &Handler{realms: realms, logger: slog.Default()}
+
+	var checked string
+	base := &ssh.ServerConfig{PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+		checked = conn.User()
+		return &ssh.Permissions{}, nil
+	}}
+
+	// Logins on the realm's port are accounts of the realm
+	retroConfig := h.realmServerConfig(base, realms.ForConnection(&net.TCPAddr{Port: 2223}, ""))
+	_, err := retroConfig.PasswordCallback(loginMetadata{user: "alice"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "retro_alice", checked)
+
+	// user@realm logins reach the realm from any port
+	defaultConfig := h.realmServerConfig(base, realms.Default())
+	_, err = defaultConfig.PasswordCallback(loginMetadata{user: "bob@retro"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "retro_bob", checked)
+
+	// The default realm cannot log in to another realm's accounts
+	checked = ""
+	_, err = defaultConfig.PasswordCallback(loginMetadata{user: "retro_bob"}, nil)
+	assert.Error(t, err)
+	assert.Empty(t, checked)
+
+	// Without realms the configuration is used as it is
+	h.realms = realm.NewResolver(nil, nil, bannerConfig, "test")
+	assert.Same(t, base, h.realmServerConfig(base, h.realms.Default()))
+}
@@ -0,0 +1,60 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+	"golang.org/x/crypto/ssh"
+)
+
+// ResumeRunningGame puts a player who asked for it with the auto_reconnect
+// preference straight back into a game they left running, whether they
+// detached from it or lost their connection. It reports whether a game was
+// resumed; without one the player goes to the menu as usual.
+func (h *GameIOHandler) ResumeRunningGame(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, connID string, terminalCols, terminalRows int) bool {
+	if !menu.PreferencesOf(userInfo).AutoReconnect || !h.gameClient.Supports(ctx, capabilities.Resume) {
+		return false
+	}
+	userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
+	if err != nil {
+		return false
+	}
+	sessions, err := h.gameClient.ListActiveGameSessions(ctx)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to look for a game to reconnect to", "error", err, "user", userInfo.Username)
+		return false
+	}
+	session := latestGameOf(sessions, int32(userID))
+	if session == nil {
+		return false
+	}
+
+	h.takeDetached(userInfo.Username, session.GameId)
+	h.logger.InfoContext(ctx, "Reconnecting player to running game", "session_id", session.Id, "user", userInfo.Username, "game", session.GameId)
+	channel.Write([]byte(fmt.Sprintf("\r\nReconnecting to your game of %s...\r\n", session.GameId)))
+	if err := h.gameClient.ResizeTerminal(ctx, session.Id, terminalCols, terminalRows); err != nil {
+		h.logger.WarnContext(ctx, "Failed to resize resumed game", "error", err, "session_id", session.Id)
+	}
+	h.HandleGameIO(ctx, channel, userInfo, session.GameId, session.Id, connID, terminalCols, terminalRows)
+	return true
+}
+
+// latestGameOf returns the running game of a user they played most
+// recently, or nil
+func latestGameOf(sessions []*gamev2.GameSession, userID int32) *gamev2.GameSession {
+	var latest *gamev2.GameSession
+	for _, session := range sessions {
+		if session.UserId != userID {
+			continue
+		}
+		if latest == nil || session.LastActivity.AsTime().After(latest.LastActivity.AsTime()) {
+			latest = session
+		}
+	}
+	return latest
+}
@@ -0,0 +1,23 @@
+package connection
+
+import (
+	"testing"
+	"time"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestLatestGameOf(t *testing.T) {
+	now := time.Now()
+	sessions := []*gamev2.GameSession{
+		{Id: "old", UserId: 1, LastActivity: timestamppb.New(now.Add(-time.Hour))},
+		{Id: "other", UserId: 2, LastActivity: timestamppb.New(now)},
+		{Id: "recent", UserId: 1, LastActivity: timestamppb.New(now.Add(-time.Minute))},
+	}
+
+	assert.Equal(t, "recent", latestGameOf(sessions, 1).Id)
+	assert.Equal(t, "other", latestGameOf(sessions, 2).Id)
+	assert.Nil(t, latestGameOf(sessions, 3))
+}
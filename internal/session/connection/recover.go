@@ -0,0 +1,56 @@
+package connection
+
+import (
+	"errors"
+	"log/slog"
+	"runtime/debug"
+)
+
+// errPanicked ends game I/O when one of its goroutines panicked
+var errPanicked = errors.New("connection goroutine panicked")
+
+// PanicRecorder counts recovered panics; metrics.Registry implements it
+type PanicRecorder interface {
+	RecordPanic(component string)
+}
+
+// panicGuard keeps a panic in one connection's goroutines from taking down
+// the whole session service
+type panicGuard struct {
+	logger   *slog.Logger
+	recorder PanicRecorder
+	watchdog *Watchdog
+}
+
+// newPanicGuard creates a guard that logs recovered panics
+func newPanicGuard(logger *slog.Logger) *panicGuard {
+	return &panicGuard{logger: logger}
+}
+
+// recover must be deferred directly by the goroutine it protects. It logs the
+// panic with its stack, counts it and runs cleanup, which closes whatever the
+// panicking goroutine owned so only that connection or channel ends.
+func (g *panicGuard) recover(component, connID string, cleanup func()) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	g.logger.Error("Recovered panic in connection goroutine",
+		"component", component,
+		"connection_id", connID,
+		"panic", r,
+		"stack", string(debug.Stack()))
+	if g.recorder != nil {
+		g.recorder.RecordPanic(component)
+	}
+	if cleanup != nil {
+		cleanup()
+	}
+}
+
+// track counts a goroutine of component with the watchdog until the
+// returned function is called; defer it next to recover
+func (g *panicGuard) track(component string) func() {
+	return g.watchdog.Track(component)
+}
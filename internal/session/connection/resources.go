@@ -0,0 +1,61 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+// resourceUsageRows is how many of the heaviest sessions server stats show
+const resourceUsageRows = 10
+
+// writeResourceUsage shows the game sessions using the most CPU, when the
+// game service accounts games' resource usage
+func (p *MenuChoiceProcessor) writeResourceUsage(ctx context.Context, channel ssh.Channel) {
+	gameClient := p.gameIOHandler.gameClient
+	if !gameClient.Supports(ctx, capabilities.ResourceUsage) {
+		return
+	}
+	sessions, err := gameClient.ListHeaviestGameSessions(ctx, "cpu")
+	if err != nil {
+		p.logger.Warn("Failed to list game sessions by resource usage", "error", err)
+		return
+	}
+
+	var rows []*gamev2.GameSession
+	for _, session := range sessions {
+		if session.ResourceUsage != nil && len(rows) < resourceUsageRows {
+			rows = append(rows, session)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	channel.Write([]byte("\r\nResource Usage (heaviest games by CPU):\r\n"))
+	channel.Write([]byte(fmt.Sprintf("  %-16s %-12s %9s %9s %5s %9s %9s\r\n", "User", "Game", "CPU", "RSS", "PIDs", "Read", "Written")))
+	for _, session := range rows {
+		usage := session.ResourceUsage
+		channel.Write([]byte(fmt.Sprintf("  %-16s %-12s %8.1fs %9s %5d %9s %9s\r\n", session.Username, session.GameId,
+			usage.CpuSeconds, formatUsageBytes(usage.RssBytes), usage.Pids,
+			formatUsageBytes(usage.IoReadBytes), formatUsageBytes(usage.IoWriteBytes))))
+	}
+}
+
+// formatUsageBytes formats a byte count in binary units
+func formatUsageBytes(n uint64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, prefix := float64(n)/unit, 0
+	for value >= unit && prefix < 3 {
+		value /= unit
+		prefix++
+	}
+	return fmt.Sprintf("%.1f%ciB", value, "KMGT"[prefix])
+}
@@ -0,0 +1,15 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatUsageBytes(t *testing.T) {
+	assert.Equal(t, "512B", formatUsageBytes(512))
+	assert.Equal(t, "1.5KiB", formatUsageBytes(1536))
+	assert.Equal(t, "256.0MiB", formatUsageBytes(256<<20))
+	assert.Equal(t, "2.0GiB", formatUsageBytes(2<<30))
+	assert.Equal(t, "2048.0TiB", formatUsageBytes(2<<50))
+}
@@ -0,0 +1,59 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/terminal"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// screenshotRenderings renders a game screen in each screenshot format
+func screenshotRenderings(screen *terminal.Screen) client.ScreenshotRenderings {
+	cols, rows := screen.Size()
+	return client.ScreenshotRenderings{
+		Cols: cols,
+		Rows: rows,
+		Text: screen.Text(),
+		ANSI: screen.ANSI(),
+		HTML: screen.HTML(),
+	}
+}
+
+// screenshotTaken describes a screenshot just taken, offering a public link
+// with l
+func screenshotTaken(screenshot *gamev2.Screenshot) []string {
+	return []string{
+		fmt.Sprintf("Saved as %s.", screenshot.Id),
+		"",
+		"Press l for a public link to it.",
+	}
+}
+
+// screenshotFailed explains why a screenshot was not taken
+func screenshotFailed(err error) []string {
+	if apierror.CodeOf(err) == apierror.RateLimited {
+		return []string{"This game has as many screenshots as it keeps."}
+	}
+	message, _ := friendlyError(err, "The screenshot could not be saved. Please try again later.")
+	return []string{message}
+}
+
+// shareScreenshot makes a public link to a screenshot and describes it
+func shareScreenshot(ctx context.Context, gameClient *client.GameClient, logger *slog.Logger, screenshotID string, userID int32) []string {
+	screenshot, err := gameClient.ShareScreenshot(ctx, screenshotID, userID)
+	if err != nil {
+		logger.WarnContext(ctx, "Failed to share screenshot", "error", err, "screenshot_id", screenshotID)
+		message, _ := friendlyError(err, "The link could not be made. Please try again later.")
+		return []string{message}
+	}
+
+	lines := []string{"Anyone with this link can see the screenshot"}
+	if expires := screenshot.ShareExpiresAt; expires != nil {
+		lines[0] += fmt.Sprintf(" until %s", expires.AsTime().UTC().Format("2006-01-02 15:04 MST"))
+	}
+	return append(lines, "", screenshot.ShareUrl)
+}
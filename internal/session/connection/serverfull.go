@@ -0,0 +1,79 @@
+package connection
+
+import (
+	"net"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// serverFullTimeout bounds how long a refused connection is held open to
+// show the server full banner
+const serverFullTimeout = 10 * time.Second
+
+// serverFullMessage is shown when the server full banner cannot be rendered
+const serverFullMessage = "The server is full. Please try again in a few minutes.\r\n"
+
+// refuseServerFull tells a connection turned away because the server is full
+// why, rather than dropping it. The handshake skips authentication, since the
+// client is only shown the banner, and the connection is closed once the
+// banner is written or serverFullTimeout passes.
+func (h *Handler) refuseServerFull(conn net.Conn, config *ssh.ServerConfig) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(serverFullTimeout))
+
+	refusal := *config
+	refusal.NoClientAuth = true
+	refusal.NoClientAuthCallback = nil
+	refusal.PasswordCallback = nil
+	refusal.PublicKeyCallback = nil
+	refusal.KeyboardInteractiveCallback = nil
+
+	sshConn, chans, reqs, err := ssh.NewServerConn(conn, &refusal)
+	if err != nil {
+		h.logger.Debug("Failed SSH handshake with refused connection", "error", err, "remote_addr", conn.RemoteAddr())
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		h.writeServerFull(channel, requests)
+		return
+	}
+}
+
+// writeServerFull waits for the client to ask for a shell or command, then
+// writes the server full banner and ends the session
+func (h *Handler) writeServerFull(channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+	for req := range requests {
+		switch req.Type {
+		case "shell", "exec":
+			if req.WantReply {
+				req.Reply(true, nil)
+			}
+			banner, err := h.menuHandler.RenderServerFull()
+			if err != nil {
+				h.logger.Warn("Failed to render server full banner", "error", err)
+				banner = serverFullMessage
+			}
+			channel.Write([]byte(banner))
+			sendExitStatus(channel, 1)
+			return
+		default:
+			// Accept pty-req, env and the like so the client goes on to ask for a shell
+			if req.WantReply {
+				req.Reply(req.Type == "pty-req" || req.Type == "env", nil)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/dungeongate/internal/session/client"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+	"golang.org/x/crypto/ssh"
+)
+
+// extraLoginExtension marks an SSH connection as a login beyond the user's
+// concurrent session limit; its value is the multi-login policy applied
+const extraLoginExtension = "extra_login_policy"
+
+// loginCountTimeout bounds a call to the shared login count
+const loginCountTimeout = 5 * time.Second
+
+// LoginCounter counts a user's logins across the session service replicas
+type LoginCounter interface {
+	CountSessionLogins(ctx context.Context, instanceID, username string, delta int) (int, error)
+}
+
+// userLoginTracker counts a user's logins on this session service instance
+type userLoginTracker struct {
+	count int
+}
+
+// SetLoginCounter sets where logins are counted across the replicas; this
+// replica reports its logins as instanceID. Without one, or when it cannot
+// be reached, only this replica's logins count.
+func (m *Manager) SetLoginCounter(counter LoginCounter, instanceID string) {
+	m.loginCounter = counter
+	m.instanceID = instanceID
+}
+
+// AcquireLogin records a login for a user and returns how many logins the user
+// now has across the replicas, including this one
+func (m *Manager) AcquireLogin(ctx context.Context, username string) int {
+	m.loginsMu.Lock()
+	tracker, ok := m.loginsByUser[username]
+	if !ok {
+		tracker = &userLoginTracker{}
+		m.loginsByUser[username] = tracker
+	}
+	tracker.count++
+	logins := tracker.count
+	m.loginsMu.Unlock()
+
+	if m.loginCounter == nil {
+		return logins
+	}
+	ctx, cancel := context.WithTimeout(ctx, loginCountTimeout)
+	defer cancel()
+	total, err := m.loginCounter.CountSessionLogins(ctx, m.instanceID, username, 1)
+	if err != nil {
+		m.logger.Warn("Failed to count login across replicas", "error", err, "username", username)
+		return logins
+	}
+	return total
+}
+
+// ReleaseLogin forgets a login recorded by AcquireLogin
+func (m *Manager) ReleaseLogin(ctx context.Context, username string) {
+	m.loginsMu.Lock()
+	tracker, ok := m.loginsByUser[username]
+	if !ok {
+		m.loginsMu.Unlock()
+		return
+	}
+	tracker.count--
+	if tracker.count <= 0 {
+		delete(m.loginsByUser, username)
+	}
+	m.loginsMu.Unlock()
+
+	if m.loginCounter == nil {
+		return
+	}
+	// The login usually ends because its connection did
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loginCountTimeout)
+	defer cancel()
+	if _, err := m.loginCounter.CountSessionLogins(ctx, m.instanceID, username, -1); err != nil {
+		m.logger.Warn("Failed to release login across replicas", "error", err, "username", username)
+	}
+}
+
+// sessionLimit returns the concurrent session limit (0 for unlimited) and
+// multi-login policy the auth service reported for a user
+func sessionLimit(user *authv1.User) (int, string) {
+	if user == nil || user.Metadata == nil {
+		return 0, ""
+	}
+	limit, err := strconv.Atoi(user.Metadata["max_concurrent_sessions"])
+	if err != nil {
+		return 0, ""
+	}
+	return limit, user.Metadata["multi_login_policy"]
+}
+
+// extraLoginPolicy returns the multi-login policy applied to the connection, or
+// an empty string when it is within the user's session limit
+func extraLoginPolicy(sshConn *ssh.ServerConn) string {
+	if sshConn == nil || sshConn.Permissions == nil {
+		return ""
+	}
+	return sshConn.Permissions.Extensions[extraLoginExtension]
+}
+
+// admitLogin applies the user's multi-login policy to a newly authenticated
+// login. It returns false when the connection must be closed.
+func (h *Handler) admitLogin(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, logins int, sshConn *ssh.ServerConn, clientTerm ClientTerminal) bool {
+	limit, policy := sessionLimit(userInfo)
+	if limit <= 0 || logins <= limit {
+		return true
+	}
+
+	h.logger.Info("User exceeded concurrent session limit",
+		"username", userInfo.Username,
+		"logins", logins,
+		"limit", limit,
+		"policy", policy)
+
+	if policy == config.MultiLoginDenyLogin || policy == "" {
+		channel.Write([]byte(fmt.Sprintf("\r\nYou are already logged in %d time(s), the limit is %d.\r\n", logins-1, limit)))
+		channel.Write([]byte("Please close your other session and try again.\r\n"))
+		h.pause.error()
+		return false
+	}
+
+	markExtraLogin(sshConn, policy)
+
+	if policy == config.MultiLoginSpectate {
+		if sessionID := activeGameSession(ctx, h.gameClient, h.logger, userInfo, ""); sessionID != "" {
+			channel.Write([]byte("\r\nYou are already playing from another connection, attaching as a spectator...\r\n"))
+			h.pause.brief()
+			if err := h.spectatingHandler.StartSpectating(ctx, channel, userInfo, sessionID, clientTerm); err != nil {
+				h.logger.Debug("Spectating own game ended", "error", err, "username", userInfo.Username)
+			}
+		}
+	}
+
+	return true
+}
+
+// markExtraLogin marks the connection as a login beyond the user's session
+// limit. Extra logins may not play the games the user is already playing;
+// the menu refuses those for this connection.
+func markExtraLogin(sshConn *ssh.ServerConn, policy string) {
+	if sshConn.Permissions == nil {
+		sshConn.Permissions = &ssh.Permissions{}
+	}
+	if sshConn.Permissions.Extensions == nil {
+		sshConn.Permissions.Extensions = make(map[string]string)
+	}
+	sshConn.Permissions.Extensions[extraLoginExtension] = policy
+}
+
+// activeGameSession returns the ID of a session of the game the user is
+// playing, or of any game when gameID is empty, if there is one
+func activeGameSession(ctx context.Context, gameClient *client.GameClient, logger *slog.Logger, userInfo *authv1.User, gameID string) string {
+	userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
+	if err != nil {
+		return ""
+	}
+
+	sessions, err := gameClient.ListGameSessions(ctx, int32(userID))
+	if err != nil {
+		logger.Warn("Failed to list game sessions", "error", err, "username", userInfo.Username)
+		return ""
+	}
+	for _, session := range sessions {
+		if session.State == "active" && (gameID == "" || session.GameID == gameID) {
+			return session.ID
+		}
+	}
+	return ""
+}
@@ -0,0 +1,482 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/terminal"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
+	"golang.org/x/crypto/ssh"
+)
+
+// errDetached ends game I/O when the player detaches from a running game
+var errDetached = errors.New("detached from game")
+
+// sessionMenuAction is the player's choice in the in-session menu
+type sessionMenuAction int
+
+const (
+	sessionMenuResume sessionMenuAction = iota
+	sessionMenuSendEscape
+	sessionMenuDetach
+	sessionMenuSave
+	sessionMenuKill
+)
+
+// heldOutput writes game output to the player and keeps a copy of the game's
+// screen. While the in-session menu is open, output only updates the copy,
+// which repaints the game when the menu closes.
+type heldOutput struct {
+	mu      sync.Mutex
+	channel ssh.Channel
+	screen  *terminal.Screen
+	paused  bool
+}
+
+// newHeldOutput creates the output of a game played at the given terminal size
+func newHeldOutput(channel ssh.Channel, cols, rows int) *heldOutput {
+	return &heldOutput{
+		channel: channel,
+		screen:  terminal.NewScreen(cols, rows),
+	}
+}
+
+// Write forwards game output unless the menu is open
+func (o *heldOutput) Write(data []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.screen.Write(data)
+	if o.paused {
+		return len(data), nil
+	}
+	return o.channel.Write(data)
+}
+
+// pause stops forwarding game output
+func (o *heldOutput) pause() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.paused = true
+}
+
+// overlay repaints the game and draws a box over it
+func (o *heldOutput) overlay(title string, lines []string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	cols, rows := o.screen.Size()
+	o.channel.Write(o.screen.Snapshot())
+	o.channel.Write(renderOverlay(cols, rows, title, lines))
+}
+
+// notice draws a box over the game without pausing it and repaints the game
+// after d. Nothing is shown while the in-session menu is open.
+func (o *heldOutput) notice(title string, lines []string, d time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.paused {
+		return
+	}
+
+	cols, rows := o.screen.Size()
+	o.channel.Write(renderOverlay(cols, rows, title, lines))
+	time.AfterFunc(d, func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		if !o.paused {
+			o.channel.Write(o.screen.Snapshot())
+		}
+	})
+}
+
+// capture renders the game's screen for a screenshot
+func (o *heldOutput) capture() client.ScreenshotRenderings {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return screenshotRenderings(o.screen)
+}
+
+// statusRows returns the bottom rows of the game's screen, where games draw
+// their status lines
+func (o *heldOutput) statusRows(n int) []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.screen.Bottom(n)
+}
+
+// resume repaints the game and forwards its output again
+func (o *heldOutput) resume() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.channel.Write(o.screen.Snapshot())
+	o.paused = false
+}
+
+// renderOverlay draws a box centred on a screen of the given size. The
+// rendition and character set are reset first, since the game may have left
+// line drawing selected.
+func renderOverlay(cols, rows int, title string, lines []string) []byte {
+	width := len(title) + 4
+	for _, line := range lines {
+		if len(line)+4 > width {
+			width = len(line) + 4
+		}
+	}
+	if width > cols {
+		width = cols
+	}
+	if width < 4 {
+		width = 4
+	}
+	height := len(lines) + 4
+	if height > rows && rows >= 4 {
+		height = rows
+		lines = lines[:rows-4]
+	}
+	left := (cols-width)/2 + 1
+	top := (rows-height)/2 + 1
+	inner := width - 4
+
+	row := func(text string) string {
+		if len(text) > inner {
+			text = text[:inner]
+		}
+		return "| " + text + strings.Repeat(" ", inner-len(text)) + " |"
+	}
+	border := "+" + strings.Repeat("-", width-2) + "+"
+
+	var b strings.Builder
+	b.WriteString("\x1b[0m\x1b(B\x0f")
+	body := append([]string{border, row(title), border}, rowsOf(lines, row)...)
+	body = append(body, border)
+	for i, text := range body {
+		fmt.Fprintf(&b, "\x1b[%d;%dH\x1b[7m%s\x1b[0m", top+i, left, text)
+	}
+	return []byte(b.String())
+}
+
+func rowsOf(lines []string, row func(string) string) []string {
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		out[i] = row(line)
+	}
+	return out
+}
+
+// hungNoticeDuration is how long the notice that the game stopped responding
+// stays over the game
+const hungNoticeDuration = 10 * time.Second
+
+// runSessionMenu shows the in-session menu over the paused game and returns
+// the player's choice. A game that is not responding, hung, is offered to be
+// killed first since it can't be saved.
+func (h *GameIOHandler) runSessionMenu(ctx context.Context, channel ssh.Channel, output *heldOutput, filter *terminal.InputFilter, userID int32, username, gameID, sessionID string, hung bool) sessionMenuAction {
+	escapeKey, _ := filter.EscapeKey()
+	escapeName := terminal.KeyName(escapeKey)
+
+	// Detaching needs a game service that keeps games running to resume
+	canDetach := h.gameClient.Supports(ctx, capabilities.Resume)
+	canScreenshot := h.gameClient.Supports(ctx, capabilities.Screenshots)
+	canBookmark := h.gameClient.Supports(ctx, capabilities.Bookmarks)
+	options := []string{
+		"s) Save the game and exit",
+		"w) Who is watching",
+		"i) Session info",
+	}
+	if !hung {
+		options = append(options, "k) Kill the game without saving")
+	}
+	options = append(options,
+		fmt.Sprintf("%s) Send %s to the game", escapeName, escapeName),
+		"",
+		"Any other key returns to the game",
+	)
+	if canScreenshot {
+		options = append([]string{"p) Take a screenshot"}, options...)
+	}
+	if canBookmark {
+		options = append([]string{"b) Bookmark this moment of the recording"}, options...)
+	}
+	if canDetach {
+		options = append([]string{"d) Detach - keep the game running, resume later"}, options...)
+	}
+	title := fmt.Sprintf("DungeonGate - %s", gameID)
+	if hung {
+		title += " (not responding)"
+		options = append([]string{"k) Force-kill the game - it has stopped responding", ""}, options...)
+	}
+
+	for {
+		output.overlay(title, options)
+
+		key, err := readKey(channel)
+		if err != nil {
+			return sessionMenuResume
+		}
+
+		switch key {
+		case 'd', 'D':
+			if !canDetach {
+				return sessionMenuResume
+			}
+			return sessionMenuDetach
+		case 's', 'S':
+			return sessionMenuSave
+		case 'k', 'K':
+			output.overlay("Kill the game?", []string{
+				"The game ends and unsaved progress is lost.",
+				"",
+				"Press y to kill it, any other key to go back",
+			})
+			if confirm, err := readKey(channel); err == nil && (confirm == 'y' || confirm == 'Y') {
+				return sessionMenuKill
+			}
+		case 'w', 'W':
+			output.overlay("Who is watching", h.spectatorLines(ctx, sessionID))
+			if _, err := readKey(channel); err != nil {
+				return sessionMenuResume
+			}
+		case 'i', 'I':
+			output.overlay("Session info", h.sessionInfoLines(ctx, userID, sessionID))
+			if _, err := readKey(channel); err != nil {
+				return sessionMenuResume
+			}
+		case 'p', 'P':
+			if !canScreenshot {
+				return sessionMenuResume
+			}
+			h.takeScreenshot(ctx, channel, output, userID, username, sessionID)
+			return sessionMenuResume
+		case 'b', 'B':
+			if !canBookmark {
+				return sessionMenuResume
+			}
+			h.addBookmark(ctx, channel, output, userID, username, sessionID)
+			return sessionMenuResume
+		case escapeKey:
+			return sessionMenuSendEscape
+		default:
+			return sessionMenuResume
+		}
+	}
+}
+
+// takeScreenshot takes a screenshot of the game for its player, offering a
+// public link to it
+func (h *GameIOHandler) takeScreenshot(ctx context.Context, channel ssh.Channel, output *heldOutput, userID int32, username, sessionID string) {
+	screenshot, err := h.gameClient.TakeScreenshot(ctx, sessionID, userID, username, output.capture())
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to take screenshot", "error", err, "session_id", sessionID)
+		output.overlay("Screenshot", append(screenshotFailed(err), "", "Press any key"))
+		readKey(channel)
+		return
+	}
+	h.logger.InfoContext(ctx, "Player took screenshot", "username", username, "session_id", sessionID, "screenshot_id", screenshot.Id)
+
+	output.overlay("Screenshot saved", append(screenshotTaken(screenshot), "Any other key returns to the game"))
+	if key, err := readKey(channel); err != nil || (key != 'l' && key != 'L') {
+		return
+	}
+	output.overlay("Public link", append(shareScreenshot(ctx, h.gameClient, h.logger, screenshot.Id, userID), "", "Press any key"))
+	readKey(channel)
+}
+
+// addBookmark bookmarks the present moment of the game for its player
+func (h *GameIOHandler) addBookmark(ctx context.Context, channel ssh.Channel, output *heldOutput, userID int32, username, sessionID string) {
+	bookmark, err := h.gameClient.AddBookmark(ctx, sessionID, userID, username, "")
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to add bookmark", "error", err, "session_id", sessionID)
+		output.overlay("Bookmark", append(bookmarkFailed(err), "", "Press any key"))
+		readKey(channel)
+		return
+	}
+	h.logger.InfoContext(ctx, "Player added bookmark", "username", username, "session_id", sessionID, "bookmark_id", bookmark.Id)
+
+	output.overlay("Bookmarked", append(bookmarkAdded(bookmark), "", "Press any key"))
+	readKey(channel)
+}
+
+// spectatorLines lists the players watching a game
+func (h *GameIOHandler) spectatorLines(ctx context.Context, sessionID string) []string {
+	session, err := h.gameClient.GetGameSessionWithSpectators(ctx, sessionID)
+	if err != nil {
+		h.logger.Warn("Failed to get spectators", "error", err, "session_id", sessionID)
+		return []string{"Spectators are unavailable right now.", "", "Press any key"}
+	}
+
+	now := time.Now()
+	var lines []string
+	for _, spectator := range session.Spectators {
+		if !spectator.IsActive {
+			continue
+		}
+		name := printable(spectator.Username)
+		if name == "" {
+			name = "anonymous"
+		}
+		if spectator.JoinTime != nil {
+			name = fmt.Sprintf("%-20s %s", name, now.Sub(spectator.JoinTime.AsTime()).Round(time.Second))
+		}
+		lines = append(lines, name)
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "Nobody is watching.")
+	}
+	return append(lines, "", "Press any key")
+}
+
+// sessionInfoLines describes the running game to its player
+func (h *GameIOHandler) sessionInfoLines(ctx context.Context, userID int32, sessionID string) []string {
+	session, err := h.gameClient.GetGameSessionAs(ctx, sessionID, userID)
+	if err != nil {
+		h.logger.Warn("Failed to get session info", "error", err, "session_id", sessionID)
+		return []string{"Session details are unavailable right now.", "", "Press any key"}
+	}
+
+	activeSpectators := 0
+	for _, spectator := range session.Spectators {
+		if spectator.IsActive {
+			activeSpectators++
+		}
+	}
+
+	lines := []string{
+		fmt.Sprintf("Game:        %s", session.GameId),
+		fmt.Sprintf("Session:     %s", session.Id),
+	}
+	if session.StartTime != nil {
+		lines = append(lines, fmt.Sprintf("Playing for: %s", time.Since(session.StartTime.AsTime()).Round(time.Second)))
+	}
+	if session.TerminalSize != nil {
+		lines = append(lines, fmt.Sprintf("Terminal:    %dx%d", session.TerminalSize.Width, session.TerminalSize.Height))
+	}
+	lines = append(lines, fmt.Sprintf("Spectators:  %d", activeSpectators))
+	switch {
+	case session.Recording != nil && session.Recording.Enabled && session.Recording.Private:
+		lines = append(lines, "Recording:   on, visible to you only")
+	case session.Recording != nil && session.Recording.Enabled:
+		lines = append(lines, "Recording:   on")
+	default:
+		lines = append(lines, "Recording:   off")
+	}
+	return append(lines, "", "Press any key")
+}
+
+// handleSessionMenu pauses game output, runs the in-session menu and carries
+// out the choice. It returns errDetached when the player detaches.
+func (h *GameIOHandler) handleSessionMenu(ctx context.Context, channel ssh.Channel, stream gamev2.GameService_StreamGameIOClient, output *heldOutput, filter *terminal.InputFilter, userInfo *authv1.User, gameID, sessionID string, hung bool) error {
+	output.pause()
+	userID, _ := strconv.ParseInt(userInfo.Id, 10, 32)
+	action := h.runSessionMenu(ctx, channel, output, filter, int32(userID), userInfo.Username, gameID, sessionID, hung)
+
+	switch action {
+	case sessionMenuDetach:
+		// Output stays paused so the stream closing does not draw over the menu
+		h.markDetached(userInfo.Username, gameID, sessionID)
+		h.logger.Info("Player detached from game", "username", userInfo.Username, "session_id", sessionID)
+		channel.Write([]byte("\033[0m\033(B\017\033[2J\033[H"))
+		channel.Write([]byte("Detached. Your game keeps running; choose it again from the menu to resume.\r\n"))
+		h.pause.brief()
+		return errDetached
+
+	case sessionMenuSave:
+		output.resume()
+		h.logger.Info("Player saved game from in-session menu", "username", userInfo.Username, "session_id", sessionID)
+		userID, err := strconv.ParseInt(userInfo.Id, 10, 32)
+		if err == nil {
+			err = h.gameClient.SaveGame(ctx, int32(userID), gameID)
+		}
+		if err != nil {
+			h.logger.Error("Failed to save game", "error", err, "session_id", sessionID)
+		}
+		return nil
+
+	case sessionMenuKill:
+		output.resume()
+		reason := "killed from in-session menu"
+		if hung {
+			reason = "hung game killed from in-session menu"
+		}
+		h.logger.Info("Player killed game from in-session menu", "username", userInfo.Username, "session_id", sessionID, "hung", hung)
+		if err := h.gameClient.KillGameSession(ctx, sessionID, reason); err != nil {
+			h.logger.Error("Failed to kill game session", "error", err, "session_id", sessionID)
+		}
+		return nil
+
+	case sessionMenuSendEscape:
+		output.resume()
+		escapeKey, _ := filter.EscapeKey()
+		return sendInput(stream, sessionID, []byte{escapeKey})
+
+	default:
+		output.resume()
+		if redraw := filter.RedrawKey(); len(redraw) > 0 {
+			return sendInput(stream, sessionID, redraw)
+		}
+		return nil
+	}
+}
+
+// markDetached remembers a game the player detached from so choosing it again resumes it
+func (h *GameIOHandler) markDetached(username, gameID, sessionID string) {
+	h.detachedMu.Lock()
+	defer h.detachedMu.Unlock()
+	h.detached[username+"/"+gameID] = sessionID
+}
+
+// takeDetached returns and forgets a game the player detached from
+func (h *GameIOHandler) takeDetached(username, gameID string) string {
+	h.detachedMu.Lock()
+	defer h.detachedMu.Unlock()
+
+	key := username + "/" + gameID
+	sessionID := h.detached[key]
+	delete(h.detached, key)
+	return sessionID
+}
+
+// sendInput sends player input to the game
+func sendInput(stream gamev2.GameService_StreamGameIOClient, sessionID string, data []byte) error {
+	return stream.Send(&gamev2.GameIORequest{
+		Request: &gamev2.GameIORequest_Input{
+			Input: &gamev2.PTYInput{
+				SessionId: sessionID,
+				Data:      data,
+			},
+		},
+	})
+}
+
+// readKey reads the first byte of the next input from the player
+func readKey(channel ssh.Channel) (byte, error) {
+	buffer := make([]byte, 64)
+	for {
+		n, err := channel.Read(buffer)
+		if err != nil {
+			return 0, err
+		}
+		if n > 0 {
+			return buffer[0], nil
+		}
+	}
+}
+
+// printable drops control characters from text shown in the overlay
+func printable(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return -1
+		}
+		return r
+	}, text)
+}
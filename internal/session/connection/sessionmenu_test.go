@@ -0,0 +1,22 @@
+package connection
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderOverlay(t *testing.T) {
+	out := string(renderOverlay(80, 24, "Menu", []string{"d) Detach", "a much longer line than the title"}))
+
+	// Character set and rendition are reset before drawing
+	assert.True(t, strings.HasPrefix(out, "\x1b[0m\x1b(B\x0f"))
+	assert.Contains(t, out, "| d) Detach                         |")
+	assert.Contains(t, out, "\x1b[10;22H")
+
+	// Boxes wider or taller than the terminal are cut to fit
+	out = string(renderOverlay(10, 5, "Menu", []string{"line one is long", "line two"}))
+	assert.Contains(t, out, "| line o |")
+	assert.NotContains(t, out, "line two")
+}
@@ -7,43 +7,80 @@ import (
 	"log/slog"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/terminal"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
 	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/capabilities"
 	"golang.org/x/crypto/ssh"
 )
 
 // SpectatingHandler handles spectator functionality and session watching
 type SpectatingHandler struct {
-	gameClient *client.GameClient
-	logger     *slog.Logger
+	gameClient   *client.GameClient
+	logger       *slog.Logger
+	inputFilters *InputFilters
+	guard        *panicGuard
+	pause        *messagePause
+
+	// How long the notice shown when a spectated game ends stays up
+	endTimeout time.Duration
+
+	// Spectators idle this long are stopped watching, after a warning
+	idleTimeout time.Duration
+	idleWarning time.Duration
+	recorder    SpectatorRecorder
 }
 
+// defaultSpectateEndTimeout is how long spectators see that a game ended
+// before returning to the menu on their own
+const defaultSpectateEndTimeout = 30 * time.Second
+
 // NewSpectatingHandler creates a new spectating handler
 func NewSpectatingHandler(gameClient *client.GameClient, logger *slog.Logger) *SpectatingHandler {
 	return &SpectatingHandler{
 		gameClient: gameClient,
 		logger:     logger,
+		guard:      newPanicGuard(logger),
+		endTimeout: defaultSpectateEndTimeout,
 	}
 }
 
+// SetInputFilters sets the per-game filters, which decide whether OSC
+// sequences are stripped from spectated output
+func (h *SpectatingHandler) SetInputFilters(filters *InputFilters) {
+	h.inputFilters = filters
+}
+
+// SetIdleTimeout stops spectators watching after timeout without input,
+// warning them warning before. A zero timeout lets them watch idle.
+func (h *SpectatingHandler) SetIdleTimeout(timeout, warning time.Duration) {
+	h.idleTimeout = timeout
+	h.idleWarning = warning
+}
+
+// SetRecorder sets where idle spectators warned and evicted are counted
+func (h *SpectatingHandler) SetRecorder(recorder SpectatorRecorder) {
+	h.recorder = recorder
+}
+
 // HandleWatchMode handles the spectating/watching functionality
-func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Channel, user *authv1.User) error {
+func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Channel, user *authv1.User, clientTerm ClientTerminal) error {
 	if user != nil {
-		h.logger.Info("Entering watch mode", "user_id", user.Id, "username", user.Username)
+		h.logger.InfoContext(ctx, "Entering watch mode", "user_id", user.Id, "username", user.Username)
 	} else {
-		h.logger.Info("Entering watch mode (anonymous user)")
+		h.logger.InfoContext(ctx, "Entering watch mode (anonymous user)")
 	}
 
 	// Get active sessions available for spectating
 	sessions, err := h.gameClient.GetActiveGameSessions(ctx)
 	if err != nil {
-		h.logger.Error("Failed to get active sessions", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to get active sessions", "error", err)
 		channel.Write([]byte("Failed to get active sessions. Please try again later.\r\n"))
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 
@@ -53,9 +90,9 @@ func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Cha
 		// For authenticated users, filter out their own sessions
 		userID, err := strconv.ParseInt(user.Id, 10, 32)
 		if err != nil {
-			h.logger.Error("Invalid user ID format", "user_id", user.Id, "error", err)
+			h.logger.ErrorContext(ctx, "Invalid user ID format", "user_id", user.Id, "error", err)
 			channel.Write([]byte("Invalid user ID. Please contact administrator.\r\n"))
-			time.Sleep(2 * time.Second)
+			h.pause.error()
 			return nil
 		}
 
@@ -71,7 +108,7 @@ func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Cha
 
 	if len(availableSessions) == 0 {
 		channel.Write([]byte("No active sessions available for spectating.\r\n"))
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 
@@ -79,9 +116,15 @@ func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Cha
 	channel.Write([]byte("\033[2J\033[H"))
 	channel.Write([]byte("=== Active Sessions ===\r\n"))
 	for i, session := range availableSessions {
-		spectatorCount := len(session.Spectators)
-		channel.Write([]byte(fmt.Sprintf("%d. %s playing %s (%d spectators)\r\n",
-			i+1, session.Username, session.GameId, spectatorCount)))
+		full := ""
+		if h.gameClient.SessionFull(session) {
+			full = ", full"
+		}
+		channel.Write([]byte(fmt.Sprintf("%d. %s playing %s (%s spectators%s)\r\n",
+			i+1, session.Username, session.GameId, h.gameClient.SpectatorsLabel(session), full)))
+		if session.StatusLine != "" {
+			channel.Write([]byte(fmt.Sprintf("   %s\r\n", session.StatusLine)))
+		}
 	}
 	channel.Write([]byte(fmt.Sprintf("\r\nSelect a session to spectate (1-%d) or 'q' to quit: ", len(availableSessions))))
 
@@ -100,40 +143,65 @@ func (h *SpectatingHandler) HandleWatchMode(ctx context.Context, channel ssh.Cha
 	sessionIndex, err := strconv.Atoi(choice)
 	if err != nil || sessionIndex < 1 || sessionIndex > len(availableSessions) {
 		channel.Write([]byte("Invalid selection.\r\n"))
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 
 	selectedSession := availableSessions[sessionIndex-1]
 
 	// Start spectating the selected session
-	return h.StartSpectating(ctx, channel, user, selectedSession.Id)
+	return h.StartSpectating(ctx, channel, user, selectedSession.Id, clientTerm)
 }
 
 // StartSpectating starts spectating a game session by session ID
-func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Channel, user *authv1.User, sessionID string) error {
+func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Channel, user *authv1.User, sessionID string, clientTerm ClientTerminal) error {
 	// First, get the session details
 	session, err := h.gameClient.GetGameSessionWithSpectators(ctx, sessionID)
 	if err != nil {
-		h.logger.Error("Failed to get session details", "error", err, "session_id", sessionID)
+		h.logger.ErrorContext(ctx, "Failed to get session details", "error", err, "session_id", sessionID)
 		channel.Write([]byte("Failed to get session details. The session may have ended.\r\n"))
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 	if user != nil {
-		h.logger.Info("Starting spectating",
+		h.logger.InfoContext(ctx, "Starting spectating",
 			"spectator_user_id", user.Id,
 			"spectator_username", user.Username,
 			"session_id", session.Id,
 			"session_username", session.Username,
 			"game_id", session.GameId)
 	} else {
-		h.logger.Info("Starting spectating (anonymous user)",
+		h.logger.InfoContext(ctx, "Starting spectating (anonymous user)",
 			"session_id", session.Id,
 			"session_username", session.Username,
 			"game_id", session.GameId)
 	}
 
+	// Spectators share the player's stream, which older game services may not offer
+	if !h.gameClient.Supports(ctx, capabilities.Multiplexing) {
+		h.logger.WarnContext(ctx, "Game service does not support spectating", "session_id", session.Id)
+		channel.Write([]byte("Spectating is not available on this server right now.\r\n"))
+		h.pause.error()
+		return nil
+	}
+
+	// Respect the player's spectating preference, including anonymous viewers
+	if !client.AllowsSpectators(session) {
+		h.logger.InfoContext(ctx, "Spectating rejected by player preference", "session_id", session.Id, "session_username", session.Username)
+		channel.Write([]byte("This player has disabled spectating for this game.\r\n"))
+		h.pause.error()
+		return nil
+	}
+
+	// Turn away spectators from a full game before connecting, but let admins in
+	admin := user != nil && user.IsAdmin
+	if h.gameClient.SessionFull(session) && !admin {
+		h.logger.InfoContext(ctx, "Spectating rejected from full session", "session_id", session.Id, "spectators", h.gameClient.SpectatorsLabel(session))
+		channel.Write([]byte(fmt.Sprintf("%s's game has as many spectators as it allows (%s). Please try again later.\r\n", session.Username, h.gameClient.SpectatorsLabel(session))))
+		h.pause.error()
+		return nil
+	}
+
 	// Add user as spectator to the session (authenticated users only)
 	var userID int32 = 0
 	var username string = "anonymous"
@@ -142,9 +210,9 @@ func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Cha
 		// Convert user ID to int32 for Game Service API
 		userIDParsed, err := strconv.ParseInt(user.Id, 10, 32)
 		if err != nil {
-			h.logger.Error("Invalid user ID format", "user_id", user.Id, "error", err)
+			h.logger.ErrorContext(ctx, "Invalid user ID format", "user_id", user.Id, "error", err)
 			channel.Write([]byte("Invalid user ID. Please contact administrator.\r\n"))
-			time.Sleep(2 * time.Second)
+			h.pause.error()
 			return nil
 		}
 		userID = int32(userIDParsed)
@@ -154,11 +222,12 @@ func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Cha
 	// For authenticated users, add them as a spectator
 	// For anonymous users, we'll skip this step and just watch without being tracked
 	if user != nil {
-		err := h.gameClient.AddSpectator(ctx, session.Id, userID, username)
+		err := h.gameClient.AddSpectator(ctx, session.Id, userID, username, admin)
 		if err != nil {
-			h.logger.Error("Failed to add spectator", "error", err)
-			channel.Write([]byte("Failed to join as spectator. Please try again later.\r\n"))
-			time.Sleep(2 * time.Second)
+			h.logger.ErrorContext(ctx, "Failed to add spectator", "error", err)
+			message, _ := friendlyError(err, "Failed to join as spectator. Please try again later.")
+			channel.Write([]byte(message + "\r\n"))
+			h.pause.error()
 			return nil
 		}
 	}
@@ -166,19 +235,23 @@ func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Cha
 	// Clear screen and show spectating banner
 	channel.Write([]byte("\033[2J\033[H"))
 	channel.Write([]byte(fmt.Sprintf("=== Spectating %s's game ===\r\n", session.Username)))
-	channel.Write([]byte("Press 'q' to quit spectating\r\n"))
+	channel.Write([]byte("Press 'q' to quit spectating, 'r' to match the player's terminal size, 'p' to take a screenshot, 'b' to bookmark the moment\r\n"))
+	cols, rows := clientTerm.Size()
+	if size := session.TerminalSize; size != nil && (int(size.Width) != cols || int(size.Height) != rows) {
+		channel.Write([]byte(fmt.Sprintf("%s plays at %dx%d; the game is fitted to your %dx%d terminal.\r\n", session.Username, size.Width, size.Height, cols, rows)))
+	}
 	channel.Write([]byte("Connecting to game stream...\r\n\r\n"))
 
 	// Create game I/O stream
 	stream, err := h.gameClient.StreamGameIO(ctx)
 	if err != nil {
-		h.logger.Error("Failed to create game I/O stream", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to create game I/O stream", "error", err)
 		channel.Write([]byte("Failed to connect to game stream.\r\n"))
 		// Clean up spectator (authenticated users only)
 		if user != nil {
-			h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID))
+			h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID), "")
 		}
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 	defer stream.CloseSend()
@@ -192,77 +265,123 @@ func (h *SpectatingHandler) StartSpectating(ctx context.Context, channel ssh.Cha
 					Width:  session.TerminalSize.Width, // Use actual game session terminal size
 					Height: session.TerminalSize.Height,
 				},
-				TermType: "xterm",
+				TermType:  "xterm",
+				Spectator: true,
 			},
 		},
 	}
 
 	if err := stream.Send(connectReq); err != nil {
-		h.logger.Error("Failed to send connect request", "error", err)
+		h.logger.ErrorContext(ctx, "Failed to send connect request", "error", err)
 		channel.Write([]byte("Failed to connect to game stream.\r\n"))
 		// Clean up spectator (authenticated users only)
 		if user != nil {
-			h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID))
+			h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID), "")
 		}
-		time.Sleep(2 * time.Second)
+		h.pause.error()
 		return nil
 	}
 
+	// Transcode UTF-8 game output for spectators whose terminal cannot show it
+	var transcoder *terminal.ASCIITranscoder
+	if session.Encoding == "utf-8" && !clientTerm.SupportsUTF8() {
+		h.logger.DebugContext(ctx, "Transcoding spectator output to ASCII", "session_id", session.Id, "term", clientTerm.Type, "locale", clientTerm.Locale)
+		transcoder = terminal.NewASCIITranscoder()
+	}
+
 	// Handle the spectating stream
-	err = h.handleSpectatingStream(ctx, stream, channel, user, session)
+	leaveReason, err := h.handleSpectatingStream(ctx, stream, channel, user, session, transcoder, cols, rows)
 
 	// Clean up spectator when done (authenticated users only)
 	if user != nil {
-		if removeErr := h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID)); removeErr != nil {
-			h.logger.Error("Failed to remove spectator", "error", removeErr)
+		if removeErr := h.gameClient.RemoveSpectator(ctx, session.Id, int32(userID), leaveReason); removeErr != nil {
+			h.logger.ErrorContext(ctx, "Failed to remove spectator", "error", removeErr)
 		}
 	}
 
 	return err
 }
 
-// handleSpectatingStream handles the bidirectional stream for spectating
-func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream gamev2.GameService_StreamGameIOClient, channel ssh.Channel, user *authv1.User, session *gamev2.GameSession) error {
+// handleSpectatingStream handles the bidirectional stream for spectating. It
+// returns why the spectator left when it was not their choice: "idle" for a
+// spectator evicted for idling.
+func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream gamev2.GameService_StreamGameIOClient, channel ssh.Channel, user *authv1.User, session *gamev2.GameSession, transcoder *terminal.ASCIITranscoder, cols, rows int) (string, error) {
+	// The spectator is listed as watching the player until they stop
+	defer presenceOf(ctx).doing(ActivityWatching, session.Username)()
+
 	// Channel for communicating between goroutines
 	done := make(chan error, 2)
 
-	// Goroutine to read from game stream and write to SSH channel
+	// Both the spectator quitting and an idle eviction send on the stream
+	var sendMu sync.Mutex
+	send := func(req *gamev2.GameIORequest) error {
+		sendMu.Lock()
+		defer sendMu.Unlock()
+		return stream.Send(req)
+	}
+	idle := newSpectatorIdle(spectatorTimeout(h.idleTimeout, session), h.idleWarning)
+
+	// Fit the game to the spectator's terminal when it is not the player's size
+	var playerCols, playerRows int
+	if size := session.TerminalSize; size != nil {
+		playerCols, playerRows = int(size.Width), int(size.Height)
+	}
+	view := newSpectatorView(channel, playerCols, playerRows, cols, rows)
+
+	// Keep the player's game from retitling or writing to the spectator's terminal
+	var oscStripper *terminal.OSCStripper
+	if h.inputFilters.StripOSC(session.GameId) {
+		oscStripper = terminal.NewOSCStripper()
+	}
+
+	// Goroutine to read from game stream and write to SSH channel. When the
+	// game or the player goes away it reports why on ended instead of done.
+	ended := make(chan spectateEnd, 1)
 	go func() {
+		var end *spectateEnd
 		defer func() {
+			if end != nil {
+				ended <- *end
+				return
+			}
 			done <- nil
 		}()
+		defer h.guard.recover("spectate_output", session.Id, nil)
+		defer h.guard.track("spectate_output")()
 
 		for {
 			resp, err := stream.Recv()
 			if err != nil {
 				if err == io.EOF || strings.Contains(err.Error(), "context canceled") {
-					h.logger.Info("Game stream closed", "session_id", session.Id)
-					// Game ended - clear terminal and show message for spectators
-					channel.Write([]byte("\033[2J\033[H")) // Clear screen and move cursor to home
-					channel.Write([]byte("\r\n=== Game ended ===\r\n"))
-					channel.Write([]byte("The game you were spectating has ended.\r\n"))
-					channel.Write([]byte("Returning to main menu...\r\n\r\n"))
-					time.Sleep(2 * time.Second)
+					h.logger.InfoContext(ctx, "Game stream closed", "session_id", session.Id)
+					end = &spectateEnd{title: "Game ended", message: "The game you were spectating has ended."}
 					return
 				}
-				h.logger.Error("Failed to receive from game stream", "error", err)
+				h.logger.ErrorContext(ctx, "Failed to receive from game stream", "error", err)
 				return
 			}
 
 			switch response := resp.Response.(type) {
 			case *gamev2.GameIOResponse_Connected:
 				if response.Connected.Success {
-					h.logger.Info("Successfully connected to game stream", "session_id", session.Id)
+					h.logger.InfoContext(ctx, "Successfully connected to game stream", "session_id", session.Id)
 				} else {
-					h.logger.Error("Failed to connect to game stream", "error", response.Connected.Error)
+					h.logger.ErrorContext(ctx, "Failed to connect to game stream", "error", response.Connected.Error)
 					channel.Write([]byte("Failed to connect to game stream.\r\n"))
 					return
 				}
 
 			case *gamev2.GameIOResponse_Output:
-				// Write game output to SSH channel
-				if _, err := channel.Write(response.Output.Data); err != nil {
-					h.logger.Error("Failed to write to SSH channel", "error", err)
+				// Write game output to SSH channel, fitted to the spectator's terminal
+				data := response.Output.Data
+				if oscStripper != nil {
+					data = oscStripper.Strip(data)
+				}
+				if transcoder != nil {
+					data = transcoder.Transcode(data)
+				}
+				if _, err := view.Write(data); err != nil {
+					h.logger.ErrorContext(ctx, "Failed to write to SSH channel", "error", err)
 					return
 				}
 
@@ -270,25 +389,25 @@ func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream g
 				// Handle game events
 				switch response.Event.Type {
 				case gamev2.PTYEventType_PTY_EVENT_PROCESS_EXIT:
-					// Clear terminal and show clean message for spectators
-					channel.Write([]byte("\033[2J\033[H")) // Clear screen and move cursor to home
-					channel.Write([]byte("\r\n=== Game ended ===\r\n"))
-					channel.Write([]byte("The game you were spectating has ended.\r\n"))
-					channel.Write([]byte("Returning to main menu...\r\n\r\n"))
-					time.Sleep(2 * time.Second)
+					end = &spectateEnd{title: "Game ended", message: "The game you were spectating has ended."}
 					return
 				case gamev2.PTYEventType_PTY_EVENT_SESSION_TERMINATED:
-					// Clear terminal and show clean message for spectators
-					channel.Write([]byte("\033[2J\033[H")) // Clear screen and move cursor to home
-					channel.Write([]byte("\r\n=== Session terminated ===\r\n"))
-					channel.Write([]byte("The session you were spectating has been terminated.\r\n"))
-					channel.Write([]byte("Returning to main menu...\r\n\r\n"))
-					time.Sleep(2 * time.Second)
+					end = &spectateEnd{title: "Session terminated", message: "The session you were spectating has been terminated."}
 					return
+				case gamev2.PTYEventType_PTY_EVENT_PLAYER_DISCONNECTED:
+					h.logger.InfoContext(ctx, "Spectated player disconnected", "session_id", session.Id, "session_username", session.Username)
+					end = &spectateEnd{title: "Player disconnected", message: fmt.Sprintf("%s has disconnected from the game you were spectating.", session.Username)}
+					return
+				case gamev2.PTYEventType_PTY_EVENT_ALERT:
+					view.showNotice("Alert", []string{response.Event.Message})
 				}
 
 			case *gamev2.GameIOResponse_Disconnected:
-				h.logger.Info("Disconnected from game stream", "session_id", session.Id)
+				h.logger.InfoContext(ctx, "Disconnected from game stream", "session_id", session.Id)
+				end = &spectateEnd{title: "Game ended", message: "The game you were spectating has ended."}
+				if reason := response.Disconnected.Reason; reason != "" {
+					end.message = reason
+				}
 				return
 			}
 		}
@@ -299,8 +418,11 @@ func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream g
 		defer func() {
 			done <- nil
 		}()
+		defer h.guard.recover("spectate_input", session.Id, nil)
+		defer h.guard.track("spectate_input")()
 
 		buffer := make([]byte, 1024)
+		screenshotID := ""
 		for {
 			select {
 			case <-ctx.Done():
@@ -312,12 +434,22 @@ func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream g
 					if err == io.EOF {
 						return
 					}
-					h.logger.Error("Failed to read from SSH channel", "error", err)
+					h.logger.ErrorContext(ctx, "Failed to read from SSH channel", "error", err)
 					return
 				}
 
-				// Check for quit command
+				// Take the terminal's size from its reply to 'r'
+				idle.touch()
 				input := string(buffer[:n])
+				if cols, rows, rest, ok := parseCursorReport(input); ok {
+					input = rest
+					if view.resize(cols, rows) {
+						h.logger.DebugContext(ctx, "Spectator terminal resized", "session_id", session.Id, "cols", cols, "rows", rows)
+						view.suggestResize(session.Username)
+					}
+				}
+
+				// Check for quit command
 				if strings.Contains(strings.ToLower(input), "q") {
 					// Send disconnect request
 					disconnectReq := &gamev2.GameIORequest{
@@ -328,10 +460,35 @@ func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream g
 							},
 						},
 					}
-					stream.Send(disconnectReq)
+					send(disconnectReq)
 					return
 				}
 
+				// Suggest the player's terminal size, measuring the spectator's
+				// in case it was resized since the pty-req
+				if strings.ContainsAny(input, "rR") {
+					view.measure()
+					view.suggestResize(session.Username)
+					continue
+				}
+
+				// Take a screenshot of the game, then offer a public link to it
+				if strings.ContainsAny(input, "pP") {
+					if id := h.takeScreenshot(ctx, view, user, session); id != "" {
+						screenshotID = id
+					}
+					continue
+				}
+				if strings.ContainsAny(input, "bB") {
+					h.addBookmark(ctx, view, user, session)
+					continue
+				}
+				if screenshotID != "" && strings.ContainsAny(input, "lL") {
+					userID, _ := strconv.ParseInt(user.Id, 10, 32)
+					view.showNotice("Public link", shareScreenshot(ctx, h.gameClient, h.logger, screenshotID, int32(userID)))
+					continue
+				}
+
 				// For spectators, we typically don't forward input to the game
 				// Only the player should be able to control the game
 				// But we could add special spectator commands here if needed
@@ -339,9 +496,137 @@ func (h *SpectatingHandler) handleSpectatingStream(ctx context.Context, stream g
 		}
 	}()
 
+	// Stop spectators who walked away from holding the stream open
+	idleCtx, stopIdle := context.WithCancel(ctx)
+	defer stopIdle()
+	evicted := make(chan struct{})
+	go func() {
+		defer h.guard.recover("spectate_idle", session.Id, nil)
+		defer h.guard.track("spectate_idle")()
+
+		if idle.watch(idleCtx, func(left time.Duration) {
+			channel.Write([]byte(fmt.Sprintf("\r\n*** You will stop watching in %s without input. Press any key to keep watching. ***\r\n", left.Round(time.Second))))
+			if h.recorder != nil {
+				h.recorder.RecordSpectatorIdleWarning()
+			}
+		}) {
+			close(evicted)
+		}
+	}()
+
 	// Wait for either goroutine to finish
-	<-done
-	return nil
+	var end spectateEnd
+	leaveReason := ""
+	select {
+	case <-done:
+		return "", nil
+	case end = <-ended:
+	case <-evicted:
+		h.logger.InfoContext(ctx, "Evicting idle spectator", "session_id", session.Id, "idle_for", idle.idleFor().Round(time.Second))
+		if h.recorder != nil {
+			h.recorder.RecordSpectatorEviction(session.GameId)
+		}
+		send(&gamev2.GameIORequest{
+			Request: &gamev2.GameIORequest_Disconnect{
+				Disconnect: &gamev2.DisconnectPTYRequest{
+					SessionId: session.Id,
+					Reason:    "Spectator idle",
+				},
+			},
+		})
+		leaveReason = spectatorLeaveIdle
+		end = spectateEnd{title: "Stopped watching", message: fmt.Sprintf("You stopped watching %s's game after %s without input.", session.Username, idle.timeout)}
+	}
+
+	// Leave the notice up until the spectator presses q or it times out
+	view.freeze()
+	h.showSpectateEnd(channel, end)
+	timer := time.NewTimer(h.endTimeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return leaveReason, nil
+}
+
+// takeScreenshot saves the game's screen for a signed in spectator and shows
+// how it went, returning the screenshot's ID when it was saved
+func (h *SpectatingHandler) takeScreenshot(ctx context.Context, view *spectatorView, user *authv1.User, session *gamev2.GameSession) string {
+	if user == nil {
+		view.showNotice("Screenshot", []string{"Sign in to take screenshots."})
+		return ""
+	}
+	if !h.gameClient.Supports(ctx, capabilities.Screenshots) {
+		view.showNotice("Screenshot", []string{"Screenshots are not enabled on this server."})
+		return ""
+	}
+
+	userID, err := strconv.ParseInt(user.Id, 10, 32)
+	if err != nil {
+		return ""
+	}
+	screenshot, err := h.gameClient.TakeScreenshot(ctx, session.Id, int32(userID), user.Username, view.capture())
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to take screenshot", "error", err, "session_id", session.Id)
+		view.showNotice("Screenshot", screenshotFailed(err))
+		return ""
+	}
+	view.showNotice("Screenshot saved", screenshotTaken(screenshot))
+	return screenshot.Id
+}
+
+// addBookmark bookmarks the present moment of the game for a signed in
+// spectator and shows how it went
+func (h *SpectatingHandler) addBookmark(ctx context.Context, view *spectatorView, user *authv1.User, session *gamev2.GameSession) {
+	if user == nil {
+		view.showNotice("Bookmark", []string{"Sign in to bookmark moments."})
+		return
+	}
+	if !h.gameClient.Supports(ctx, capabilities.Bookmarks) {
+		view.showNotice("Bookmark", []string{"Bookmarks are not enabled on this server."})
+		return
+	}
+
+	userID, err := strconv.ParseInt(user.Id, 10, 32)
+	if err != nil {
+		return
+	}
+	bookmark, err := h.gameClient.AddBookmark(ctx, session.Id, int32(userID), user.Username, "")
+	if err != nil {
+		h.logger.WarnContext(ctx, "Failed to add bookmark", "error", err, "session_id", session.Id)
+		view.showNotice("Bookmark", bookmarkFailed(err))
+		return
+	}
+	view.showNotice("Bookmarked", bookmarkAdded(bookmark))
+}
+
+// spectatorTimeout returns how long a session's spectators may watch without
+// input: the stricter of the server's timeout and the game's, zero for ever
+func spectatorTimeout(timeout time.Duration, session *gamev2.GameSession) time.Duration {
+	game := time.Duration(session.SpectatorTimeoutSeconds) * time.Second
+	if game > 0 && (timeout == 0 || game < timeout) {
+		return game
+	}
+	return timeout
+}
+
+// spectatorLeaveIdle is the leave reason of spectators evicted for idling
+const spectatorLeaveIdle = "idle"
+
+// spectateEnd is why a spectated game stream ended
+type spectateEnd struct {
+	title   string
+	message string
+}
+
+// showSpectateEnd replaces the frozen game screen with why the stream ended
+func (h *SpectatingHandler) showSpectateEnd(channel ssh.Channel, end spectateEnd) {
+	channel.Write([]byte("\033[0m\033[2J\033[H")) // Reset attributes, clear screen and move cursor to home
+	channel.Write([]byte(fmt.Sprintf("\r\n=== %s — press q to return ===\r\n", end.title)))
+	channel.Write([]byte(end.message + "\r\n\r\n"))
+	channel.Write([]byte(fmt.Sprintf("Returning to the main menu in %d seconds...\r\n", int(h.endTimeout.Seconds()))))
 }
 
 // Terminal input helper methods
@@ -0,0 +1,263 @@
+package connection
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/internal/session/terminal"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
+)
+
+// spectatorStream is a game stream replaying responses, then blocking, and
+// recording the requests sent
+type spectatorStream struct {
+	gamev2.GameService_StreamGameIOClient
+	responses chan *gamev2.GameIOResponse
+
+	mu   sync.Mutex
+	sent []*gamev2.GameIORequest
+}
+
+func (s *spectatorStream) Recv() (*gamev2.GameIOResponse, error) {
+	return <-s.responses, nil
+}
+
+func (s *spectatorStream) Send(req *gamev2.GameIORequest) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sent = append(s.sent, req)
+	return nil
+}
+
+func (s *spectatorStream) requests() []*gamev2.GameIORequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sent
+}
+
+type fakeSpectatorRecorder struct {
+	mu        sync.Mutex
+	warnings  int
+	evictions []string
+}
+
+func (r *fakeSpectatorRecorder) RecordSpectatorIdleWarning() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.warnings++
+}
+
+func (r *fakeSpectatorRecorder) RecordSpectatorEviction(gameID string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.evictions = append(r.evictions, gameID)
+}
+
+// pipeChannel is a channel reading keys from a pipe and recording what is written
+type pipeChannel struct {
+	ssh.Channel
+	in  *io.PipeReader
+	mu  sync.Mutex
+	out bytes.Buffer
+}
+
+func (c *pipeChannel) Read(p []byte) (int, error) { return c.in.Read(p) }
+
+func (c *pipeChannel) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.out.Write(p)
+}
+
+func (c *pipeChannel) String() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.out.String()
+}
+
+func TestHandleSpectatingStream_End(t *testing.T) {
+	session := &gamev2.GameSession{Id: "s1", Username: "alice", GameId: "nethack"}
+	event := func(eventType gamev2.PTYEventType) *gamev2.GameIOResponse {
+		return &gamev2.GameIOResponse{Response: &gamev2.GameIOResponse_Event{Event: &gamev2.PTYEvent{Type: eventType}}}
+	}
+
+	// The player leaving is reported until the spectator presses q
+	h := NewSpectatingHandler(nil, slog.Default())
+	h.endTimeout = time.Minute
+	stream := &spectatorStream{responses: make(chan *gamev2.GameIOResponse, 2)}
+	stream.responses <- &gamev2.GameIOResponse{Response: &gamev2.GameIOResponse_Output{Output: &gamev2.PTYOutput{Data: []byte("@")}}}
+	stream.responses <- event(gamev2.PTYEventType_PTY_EVENT_PLAYER_DISCONNECTED)
+	in, keys := io.Pipe()
+	channel := &pipeChannel{in: in}
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := h.handleSpectatingStream(context.Background(), stream, channel, nil, session, nil, 80, 24)
+		result <- err
+	}()
+	assert.Eventually(t, func() bool {
+		return strings.Contains(channel.String(), "Player disconnected — press q to return")
+	}, time.Second, time.Millisecond)
+	assert.Contains(t, channel.String(), "alice has disconnected")
+	_, err := keys.Write([]byte("q"))
+	require.NoError(t, err)
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("pressing q did not return")
+	}
+
+	// Without a key the spectator returns to the menu on their own
+	h.endTimeout = 10 * time.Millisecond
+	stream = &spectatorStream{responses: make(chan *gamev2.GameIOResponse, 1)}
+	stream.responses <- event(gamev2.PTYEventType_PTY_EVENT_PROCESS_EXIT)
+	in, _ = io.Pipe()
+	channel = &pipeChannel{in: in}
+	reason, err := h.handleSpectatingStream(context.Background(), stream, channel, nil, session, nil, 80, 24)
+	require.NoError(t, err)
+	assert.Empty(t, reason)
+	assert.Contains(t, channel.String(), "Game ended — press q to return")
+}
+
+func TestHandleSpectatingStream_IdleEviction(t *testing.T) {
+	session := &gamev2.GameSession{Id: "s1", Username: "alice", GameId: "nethack"}
+	recorder := &fakeSpectatorRecorder{}
+	h := NewSpectatingHandler(nil, slog.Default())
+	h.endTimeout = 10 * time.Millisecond
+	h.SetIdleTimeout(300*time.Millisecond, 200*time.Millisecond)
+	h.SetRecorder(recorder)
+
+	stream := &spectatorStream{responses: make(chan *gamev2.GameIOResponse)}
+	in, keys := io.Pipe()
+	channel := &pipeChannel{in: in}
+	go func() {
+		// Input after the warning keeps the spectator watching a while longer
+		time.Sleep(150 * time.Millisecond)
+		keys.Write([]byte("x"))
+	}()
+
+	started := time.Now()
+	reason, err := h.handleSpectatingStream(context.Background(), stream, channel, nil, session, nil, 80, 24)
+	require.NoError(t, err)
+	assert.Equal(t, "idle", reason)
+	assert.GreaterOrEqual(t, time.Since(started), 450*time.Millisecond, "input puts the eviction off")
+	assert.Contains(t, channel.String(), "Press any key to keep watching")
+	assert.Contains(t, channel.String(), "Stopped watching — press q to return")
+
+	// The game stream is let go of at once
+	requests := stream.requests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, "Spectator idle", requests[0].GetDisconnect().GetReason())
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	assert.Equal(t, 2, recorder.warnings)
+	assert.Equal(t, []string{"nethack"}, recorder.evictions)
+}
+
+func TestHandleSpectatingStream_AnonymousKeys(t *testing.T) {
+	session := &gamev2.GameSession{Id: "s1", Username: "alice", GameId: "nethack"}
+	h := NewSpectatingHandler(nil, slog.Default())
+	stream := &spectatorStream{responses: make(chan *gamev2.GameIOResponse)}
+	in, keys := io.Pipe()
+	channel := &pipeChannel{in: in}
+
+	result := make(chan error, 1)
+	go func() {
+		_, err := h.handleSpectatingStream(context.Background(), stream, channel, nil, session, nil, 80, 24)
+		result <- err
+	}()
+
+	// Screenshots and bookmarks are kept for signed in spectators only
+	_, err := keys.Write([]byte("p"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(channel.String(), "Sign in to take screenshots.")
+	}, time.Second, time.Millisecond)
+	_, err = keys.Write([]byte("b"))
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(channel.String(), "Sign in to bookmark moments.")
+	}, time.Second, time.Millisecond)
+	_, err = keys.Write([]byte("q"))
+	require.NoError(t, err)
+	select {
+	case err := <-result:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("pressing q did not return")
+	}
+}
+
+func TestSpectatorTimeout(t *testing.T) {
+	session := &gamev2.GameSession{}
+	assert.Equal(t, time.Duration(0), spectatorTimeout(0, session))
+	assert.Equal(t, time.Hour, spectatorTimeout(time.Hour, session))
+
+	// The stricter of the server's timeout and the game's applies
+	session.SpectatorTimeoutSeconds = 30 * 60
+	assert.Equal(t, 30*time.Minute, spectatorTimeout(0, session))
+	assert.Equal(t, 30*time.Minute, spectatorTimeout(time.Hour, session))
+	assert.Equal(t, 10*time.Minute, spectatorTimeout(10*time.Minute, session))
+}
+
+func TestSpectatorIdle_NoTimeout(t *testing.T) {
+	idle := newSpectatorIdle(0, time.Minute)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.False(t, idle.watch(ctx, func(time.Duration) { t.Fatal("warned without a timeout") }))
+}
+
+func TestSpectatorView(t *testing.T) {
+	// Matching terminals see the game's own output
+	channel := &pipeChannel{}
+	view := newSpectatorView(channel, 80, 24, 80, 24)
+	view.Write([]byte("\x1b[24;1HDlvl:1"))
+	assert.Equal(t, "\x1b[24;1HDlvl:1", channel.String())
+
+	// A smaller terminal is repainted with the part of the game around the cursor
+	channel = &pipeChannel{}
+	view = newSpectatorView(channel, 80, 24, 60, 20)
+	view.Write([]byte("\x1b[24;1HDlvl:1"))
+	screen := terminal.NewScreen(60, 20)
+	screen.Write([]byte(channel.String()))
+	assert.Contains(t, string(screen.Snapshot()), "Dlvl:1")
+
+	// The suggestion gives the exact size, and says so once the terminal matches
+	view.suggestResize("alice")
+	assert.Contains(t, channel.String(), "alice plays at 80x24; your terminal is 60x20.")
+	assert.Contains(t, channel.String(), "Resize your terminal to 80 columns by 24 rows")
+	assert.True(t, view.resize(80, 24))
+	assert.False(t, view.resize(80, 24))
+	view.suggestResize("alice")
+	assert.Contains(t, channel.String(), "Your terminal matches alice's at 80x24.")
+
+	// Screenshots are of the player's screen, not the spectator's view of it
+	screenshot := view.capture()
+	assert.Equal(t, 80, screenshot.Cols)
+	assert.Equal(t, 24, screenshot.Rows)
+	assert.Contains(t, screenshot.Text, "Dlvl:1")
+	assert.Contains(t, screenshot.HTML, "Dlvl:1")
+}
+
+func TestParseCursorReport(t *testing.T) {
+	cols, rows, rest, ok := parseCursorReport("r\x1b[30;100Rq")
+	assert.True(t, ok)
+	assert.Equal(t, 100, cols)
+	assert.Equal(t, 30, rows)
+	assert.Equal(t, "rq", rest)
+
+	_, _, rest, ok = parseCursorReport("r")
+	assert.False(t, ok)
+	assert.Equal(t, "r", rest)
+}
@@ -0,0 +1,85 @@
+package connection
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// SpectatorRecorder counts idle spectators warned and evicted;
+// metrics.Registry implements it
+type SpectatorRecorder interface {
+	RecordSpectatorIdleWarning()
+	RecordSpectatorEviction(gameID string)
+}
+
+// spectatorIdle watches a spectator for input, so one who walked away stops
+// holding a stream open once the spectator timeout passes
+type spectatorIdle struct {
+	timeout time.Duration // Zero never evicts
+	warning time.Duration // How long before the eviction the spectator is warned
+	now     func() time.Time
+
+	lastInput atomic.Int64
+}
+
+// newSpectatorIdle starts watching a spectator who just joined
+func newSpectatorIdle(timeout, warning time.Duration) *spectatorIdle {
+	i := &spectatorIdle{timeout: timeout, warning: warning, now: time.Now}
+	i.touch()
+	return i
+}
+
+// touch records spectator input
+func (i *spectatorIdle) touch() {
+	i.lastInput.Store(i.now().UnixNano())
+}
+
+// idleFor returns how long the spectator has sent no input
+func (i *spectatorIdle) idleFor() time.Duration {
+	return i.now().Sub(time.Unix(0, i.lastInput.Load()))
+}
+
+// watch calls warn once the spectator is due a warning, with the time left,
+// and returns true once they have been idle for the timeout. Each spell of
+// idling is warned about once. It returns false when ctx is done first or
+// there is no timeout.
+func (i *spectatorIdle) watch(ctx context.Context, warn func(left time.Duration)) bool {
+	if i.timeout <= 0 {
+		return false
+	}
+	var warnedAt int64 // Input the last warning was about
+	timer := time.NewTimer(i.nextCheck(false))
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-timer.C:
+		}
+
+		lastInput := i.lastInput.Load()
+		idleFor := i.idleFor()
+		if idleFor >= i.timeout {
+			return true
+		}
+		if idleFor >= i.timeout-i.warning && warnedAt != lastInput {
+			warnedAt = lastInput
+			warn(i.timeout - idleFor)
+		}
+		timer.Reset(i.nextCheck(warnedAt == lastInput))
+	}
+}
+
+// nextCheck returns how long until the spectator is due a warning or, once
+// warned, the eviction, were no input to arrive in the meantime
+func (i *spectatorIdle) nextCheck(warned bool) time.Duration {
+	deadline := i.timeout
+	if !warned && i.warning > 0 && i.warning < i.timeout {
+		deadline -= i.warning
+	}
+	if wait := deadline - i.idleFor(); wait > 0 {
+		return wait
+	}
+	return 0
+}
@@ -0,0 +1,184 @@
+package connection
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/terminal"
+	"golang.org/x/crypto/ssh"
+)
+
+// noticeDuration is how long a notice, such as the resize suggestion, stays
+// over the game
+const noticeDuration = 5 * time.Second
+
+// measureTerminal asks the spectator's terminal for its size: the cursor is
+// moved as far down and right as it goes and its position reported
+const measureTerminal = "\x1b7\x1b[999;999H\x1b[6n\x1b8"
+
+// cursorPositionReport matches a terminal's reply to measureTerminal
+var cursorPositionReport = regexp.MustCompile(`\x1b\[(\d+);(\d+)R`)
+
+// spectatorView writes a spectated game to a spectator whose terminal may not
+// be the size of the player's. When the sizes match, output is passed through
+// as it is. Otherwise the output only updates a copy of the player's screen,
+// which is cropped or letterboxed to the spectator's terminal and repainted.
+type spectatorView struct {
+	mu      sync.Mutex
+	channel ssh.Channel
+	screen  *terminal.Screen
+
+	cols, rows int  // spectator's terminal
+	notice     int  // counts notices, so only the last one is taken down
+	held       bool // output only updates the screen, as while a notice is up
+}
+
+// newSpectatorView creates the view of a game played at playerCols x
+// playerRows for a spectator at cols x rows. A game of unknown size is
+// assumed to be the spectator's size.
+func newSpectatorView(channel ssh.Channel, playerCols, playerRows, cols, rows int) *spectatorView {
+	if playerCols <= 0 || playerRows <= 0 {
+		playerCols, playerRows = cols, rows
+	}
+	return &spectatorView{
+		channel: channel,
+		screen:  terminal.NewScreen(playerCols, playerRows),
+		cols:    cols,
+		rows:    rows,
+	}
+}
+
+// fits reports whether the spectator's terminal is the size of the player's
+func (v *spectatorView) fits() bool {
+	cols, rows := v.screen.Size()
+	return v.cols == cols && v.rows == rows
+}
+
+// Write shows game output to the spectator
+func (v *spectatorView) Write(data []byte) (int, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.screen.Write(data)
+	if v.held {
+		return len(data), nil
+	}
+	if v.fits() {
+		return v.channel.Write(data)
+	}
+	if _, err := v.channel.Write(v.screen.View(v.cols, v.rows)); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// measure asks the spectator's terminal for its size. The reply arrives as
+// input, for parseCursorReport.
+func (v *spectatorView) measure() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.channel.Write([]byte(measureTerminal))
+}
+
+// resize records the spectator's terminal size, repainting the game and
+// reporting whether it changed
+func (v *spectatorView) resize(cols, rows int) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if cols == v.cols && rows == v.rows {
+		return false
+	}
+	v.cols, v.rows = cols, rows
+	if !v.held {
+		v.repaint()
+	}
+	return true
+}
+
+// repaint draws the whole game screen; the caller holds mu
+func (v *spectatorView) repaint() {
+	if v.fits() {
+		v.channel.Write(v.screen.Snapshot())
+		return
+	}
+	v.channel.Write([]byte("\x1b[2J"))
+	v.channel.Write(v.screen.View(v.cols, v.rows))
+}
+
+// suggestResize shows the exact size the spectator's terminal needs to be to
+// see the game as the player does, then repaints the game
+func (v *spectatorView) suggestResize(player string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	cols, rows := v.screen.Size()
+	lines := []string{
+		fmt.Sprintf("%s plays at %dx%d; your terminal is %dx%d.", player, cols, rows, v.cols, v.rows),
+		"",
+		fmt.Sprintf("Resize your terminal to %d columns by %d rows", cols, rows),
+		"and press r again.",
+	}
+	if v.fits() {
+		lines = []string{fmt.Sprintf("Your terminal matches %s's at %dx%d.", player, cols, rows)}
+	}
+	v.show("Terminal size", lines)
+}
+
+// showNotice draws a box over the game for a few seconds, then repaints the
+// game
+func (v *spectatorView) showNotice(title string, lines []string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.show(title, lines)
+}
+
+// show draws a notice over the game; the caller holds mu
+func (v *spectatorView) show(title string, lines []string) {
+	v.notice++
+	notice := v.notice
+	v.held = true
+	v.repaint()
+	v.channel.Write(renderOverlay(v.cols, v.rows, title, lines))
+	time.AfterFunc(noticeDuration, func() {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		if v.notice != notice {
+			return // A later notice replaced this one
+		}
+		v.held = false
+		v.repaint()
+	})
+}
+
+// capture renders the player's screen for a screenshot
+func (v *spectatorView) capture() client.ScreenshotRenderings {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return screenshotRenderings(v.screen)
+}
+
+// freeze stops the view drawing over whatever is shown once the game ends,
+// including taking down a notice
+func (v *spectatorView) freeze() {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.notice++
+	v.held = true
+}
+
+// parseCursorReport takes a reply to measureTerminal out of spectator input,
+// returning the terminal size it reports and the input without it
+func parseCursorReport(input string) (cols, rows int, rest string, ok bool) {
+	match := cursorPositionReport.FindStringSubmatchIndex(input)
+	if match == nil {
+		return 0, 0, input, false
+	}
+	rows, _ = strconv.Atoi(input[match[2]:match[3]])
+	cols, _ = strconv.Atoi(input[match[4]:match[5]])
+	return cols, rows, input[:match[0]] + input[match[1]:], cols > 0 && rows > 0
+}
@@ -0,0 +1,50 @@
+package connection
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/capabilities"
+)
+
+// statusLineInterval is how often the bottom of the player's screen is sent
+// to the game service for the game's status line
+const statusLineInterval = 5 * time.Second
+
+// statusLineRows is how many rows at the bottom of the screen are sent, the
+// most the game service reads
+const statusLineRows = 3
+
+// reportStatusLine sends the bottom rows of the player's screen to the game
+// service whenever they change, so the game's adapter can read its status
+// line for the watch menu and listings. It returns when ctx is cancelled.
+func (h *GameIOHandler) reportStatusLine(ctx context.Context, output *heldOutput, userInfo *authv1.User, sessionID string) {
+	if !h.gameClient.Supports(ctx, capabilities.StatusLine) {
+		return
+	}
+	userID, _ := strconv.ParseInt(userInfo.Id, 10, 32)
+
+	ticker := time.NewTicker(statusLineInterval)
+	defer ticker.Stop()
+	var sent []string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		rows := output.statusRows(statusLineRows)
+		if slices.Equal(rows, sent) {
+			continue
+		}
+		if _, err := h.gameClient.UpdateStatusLine(ctx, sessionID, int32(userID), rows); err != nil {
+			h.logger.DebugContext(ctx, "Failed to update status line", "error", err, "session_id", sessionID)
+			continue
+		}
+		sent = rows
+	}
+}
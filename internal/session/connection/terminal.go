@@ -0,0 +1,56 @@
+package connection
+
+import (
+	"github.com/dungeongate/internal/session/terminal"
+	"github.com/dungeongate/pkg/termtype"
+)
+
+// ClientTerminal describes the terminal an SSH client negotiated for its session
+type ClientTerminal struct {
+	Type   string // Negotiated TERM value
+	Locale string // Client locale from LC_ALL, LC_CTYPE or LANG
+
+	// RemoteIP is the client's address, taken from the PROXY header behind a load balancer
+	RemoteIP string
+
+	// Cols and Rows are the size from the client's pty-req, zero without one
+	Cols, Rows int
+
+	localeSource string
+}
+
+// localePriority ranks the locale variables the way the C library does
+var localePriority = map[string]int{"LANG": 1, "LC_CTYPE": 2, "LC_ALL": 3}
+
+// SetEnv records locale information from an SSH env request
+func (t *ClientTerminal) SetEnv(name, value string) {
+	priority, ok := localePriority[name]
+	if !ok || priority < localePriority[t.localeSource] {
+		return
+	}
+	if locale := termtype.SanitizeLocale(value); locale != "" {
+		t.Locale = locale
+		t.localeSource = name
+	}
+}
+
+// Size returns the client's terminal size, 80x24 when it sent no pty-req
+func (t ClientTerminal) Size() (int, int) {
+	if t.Cols <= 0 || t.Rows <= 0 {
+		return 80, 24
+	}
+	return t.Cols, t.Rows
+}
+
+// SupportsUTF8 reports whether the client can display UTF-8 output. Clients that
+// sent no locale are assumed to handle UTF-8 unless their terminal type predates it.
+func (t ClientTerminal) SupportsUTF8() bool {
+	if t.Locale != "" {
+		return terminal.IsUTF8Locale(t.Locale)
+	}
+	switch t.Type {
+	case "vt52", "vt100", "vt102", "vt220", "ansi", "dumb":
+		return false
+	}
+	return true
+}
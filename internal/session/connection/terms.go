@@ -0,0 +1,72 @@
+package connection
+
+import (
+	"context"
+
+	"github.com/dungeongate/internal/session/menu"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// termsPending returns the version of the terms the user must accept before
+// using the menu, or an empty string. Admins impersonating the user are not
+// asked, since they cannot accept on the user's behalf.
+func termsPending(userInfo *authv1.User, sshConn *ssh.ServerConn) string {
+	if userInfo == nil || isImpersonating(sshConn) {
+		return ""
+	}
+	return userInfo.Metadata["terms_pending"]
+}
+
+// showTerms displays terms of service and asks the player to accept them
+func (m *UserAuthManager) showTerms(ctx context.Context, channel ssh.Channel, title, intro, content string) (bool, error) {
+	channel.Write([]byte("\033[2J\033[H")) // Clear screen
+	channel.Write([]byte("=== " + title + " ===\r\n\r\n"))
+	if intro != "" {
+		channel.Write([]byte(intro + "\r\n\r\n"))
+	}
+	channel.Write([]byte(terminalText(content)))
+	channel.Write([]byte("\r\nDo you accept these terms? (y/N): "))
+
+	m.flushInput(channel)
+	return m.readYesNo(ctx, channel)
+}
+
+// HandleRequiredTerms blocks the menu until the player accepts the current
+// terms of service. Declining disconnects them.
+func (m *UserAuthManager) HandleRequiredTerms(ctx context.Context, channel ssh.Channel, user *authv1.User, sshConn *ssh.ServerConn) (*menu.MenuChoice, error) {
+	version := termsPending(user, sshConn)
+
+	resp, err := m.authClient.GetTerms(ctx, version)
+	if err != nil || !resp.Success || resp.Terms == nil {
+		m.logger.Error("Failed to load terms of service", "error", err, "username", user.Username, "version", version)
+		channel.Write([]byte("\r\nThe terms of service could not be loaded. Please try again later.\r\n"))
+		m.pause.error()
+		return &menu.MenuChoice{Action: "quit", Value: ""}, nil
+	}
+
+	accepted, err := m.showTerms(ctx, channel, "Updated Terms of Service",
+		"The terms of service have changed. Please read and accept them to continue.", resp.Terms.Content)
+	if err != nil {
+		return nil, err
+	}
+	if !accepted {
+		m.logger.Info("User declined terms of service", "username", user.Username, "version", version)
+		channel.Write([]byte("\r\nYou must accept the terms to continue. Goodbye.\r\n"))
+		m.pause.error()
+		return &menu.MenuChoice{Action: "quit", Value: ""}, nil
+	}
+
+	acceptResp, err := m.authClient.AcceptTerms(ctx, sshConn.Permissions.Extensions["access_token"], version)
+	if err != nil || !acceptResp.Success {
+		m.logger.Error("Failed to record terms acceptance", "error", err, "username", user.Username, "version", version)
+		channel.Write([]byte("\r\nYour acceptance could not be recorded. Please try again later.\r\n"))
+		m.pause.error()
+		return &menu.MenuChoice{Action: "quit", Value: ""}, nil
+	}
+
+	m.logger.Info("User accepted updated terms of service", "username", user.Username, "version", version)
+	channel.Write([]byte("\r\nThank you.\r\n"))
+	m.pause.brief()
+	return nil, nil
+}
@@ -0,0 +1,41 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/dungeongate/pkg/buildinfo"
+)
+
+// writeServiceBuilds shows the build of this session service and those the
+// auth and game services report, marking those that differ from this one
+func (p *MenuChoiceProcessor) writeServiceBuilds(ctx context.Context, channel ssh.Channel) {
+	builds := []struct {
+		name    string
+		version func(context.Context) (buildinfo.Info, error)
+	}{
+		{"Session (this)", func(context.Context) (buildinfo.Info, error) { return p.build, nil }},
+		{"Auth", p.authManager.authClient.Version},
+		{"Game", p.gameIOHandler.gameClient.Version},
+	}
+
+	channel.Write([]byte("\r\nService Versions:\r\n"))
+	channel.Write([]byte(fmt.Sprintf("  %-16s %-28s %-22s\r\n", "Service", "Version", "Built")))
+	for _, service := range builds {
+		queryCtx, cancel := context.WithTimeout(ctx, versionQueryTimeout)
+		build, err := service.version(queryCtx)
+		cancel()
+		if err != nil {
+			p.logger.Debug("Failed to get service version", "service", service.name, "error", err)
+			channel.Write([]byte(fmt.Sprintf("  %-16s %-28s\r\n", service.name, "unavailable")))
+			continue
+		}
+		flag := ""
+		if buildinfo.Skewed(p.build, build) {
+			flag = "  ! differs from this server"
+		}
+		channel.Write([]byte(fmt.Sprintf("  %-16s %-28s %-22s%s\r\n", service.name, build.String(), build.BuildTime, flag)))
+	}
+}
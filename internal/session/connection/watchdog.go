@@ -0,0 +1,202 @@
+package connection
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/dungeongate/pkg/scheduler"
+)
+
+// DefaultWatchdogInterval is how often the watchdog samples when not configured
+const DefaultWatchdogInterval = time.Minute
+
+// WatchdogJob is the name of the job taking the watchdog's samples
+const WatchdogJob = "connection_watchdog"
+
+// watchdogLeakSamples is how many samples in a row a subsystem must have
+// more goroutines than there are connections before it is reported, so
+// connections closing between counts are not mistaken for leaks
+const watchdogLeakSamples = 3
+
+// WatchdogRecorder receives the counts the watchdog samples
+type WatchdogRecorder interface {
+	RecordConnectionCounts(active, peak int)
+	RecordGoroutines(subsystem string, count int)
+	RecordGoroutineLeak(subsystem string)
+}
+
+// WatchdogSample is one sample of the session service's connections,
+// goroutines and open files
+type WatchdogSample struct {
+	Connections int `json:"connections"`
+	Peak        int `json:"peak_connections"`
+	Goroutines  int `json:"goroutines"`
+	// OpenFiles is the process's open file descriptors, including sockets;
+	// -1 where the platform cannot count them
+	OpenFiles int `json:"open_files"`
+	// Subsystems counts the running goroutines of each connection subsystem,
+	// such as "menu" or "game_output"
+	Subsystems map[string]int `json:"subsystems"`
+	// Leaking lists the subsystems that have had more goroutines than there
+	// are connections for several samples in a row
+	Leaking   []string  `json:"leaking,omitempty"`
+	SampledAt time.Time `json:"sampled_at"`
+}
+
+// Watchdog counts the goroutines each connection subsystem has running and
+// samples the process's goroutines and open files, to catch goroutines that
+// outlive their connection, such as a menu left waiting on a closed channel.
+// Every subsystem runs at most one goroutine per connection, so a subsystem
+// with more goroutines than there are connections is leaking.
+type Watchdog struct {
+	manager  *Manager
+	interval time.Duration
+	logger   *slog.Logger
+	recorder WatchdogRecorder
+
+	mu      sync.Mutex
+	running map[string]int
+	over    map[string]int // Samples in a row each subsystem was over
+	last    *WatchdogSample
+}
+
+// NewWatchdog creates a watchdog sampling every interval; a zero interval
+// uses DefaultWatchdogInterval and a negative one disables sampling, though
+// goroutines are still counted
+func NewWatchdog(manager *Manager, interval time.Duration, logger *slog.Logger) *Watchdog {
+	if interval == 0 {
+		interval = DefaultWatchdogInterval
+	}
+	return &Watchdog{
+		manager:  manager,
+		interval: interval,
+		logger:   logger,
+		running:  make(map[string]int),
+		over:     make(map[string]int),
+	}
+}
+
+// SetRecorder sets where the sampled counts are reported
+func (w *Watchdog) SetRecorder(recorder WatchdogRecorder) {
+	w.recorder = recorder
+}
+
+// Track counts a goroutine of subsystem as running until the returned
+// function is called. It is safe to call on a nil watchdog.
+func (w *Watchdog) Track(subsystem string) func() {
+	if w == nil {
+		return func() {}
+	}
+	w.mu.Lock()
+	w.running[subsystem]++
+	w.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			w.mu.Lock()
+			w.running[subsystem]--
+			w.mu.Unlock()
+		})
+	}
+}
+
+// AddJobs schedules sampling every interval, unless sampling is disabled
+func (w *Watchdog) AddJobs(jobs *scheduler.Scheduler) error {
+	if w.interval < 0 {
+		return nil
+	}
+	return jobs.Add(scheduler.Job{
+		Name:     WatchdogJob,
+		Schedule: scheduler.Every(w.interval),
+		Run: func(context.Context) error {
+			w.Sample()
+			return nil
+		},
+	})
+}
+
+// Sample takes, logs and records a sample, warning about subsystems that
+// keep having more goroutines than there are connections
+func (w *Watchdog) Sample() *WatchdogSample {
+	stats := w.manager.GetStats()
+	sample := &WatchdogSample{
+		Connections: stats.Active,
+		Peak:        stats.Peak,
+		Goroutines:  runtime.NumGoroutine(),
+		OpenFiles:   openFileCount(),
+		Subsystems:  make(map[string]int),
+		SampledAt:   time.Now(),
+	}
+
+	var newlyLeaking []string
+	w.mu.Lock()
+	for subsystem, count := range w.running {
+		sample.Subsystems[subsystem] = count
+		if count <= sample.Connections {
+			w.over[subsystem] = 0
+			continue
+		}
+		w.over[subsystem]++
+		if w.over[subsystem] >= watchdogLeakSamples {
+			sample.Leaking = append(sample.Leaking, subsystem)
+		}
+		// Warned once when a subsystem starts leaking, not on every sample
+		if w.over[subsystem] == watchdogLeakSamples {
+			newlyLeaking = append(newlyLeaking, subsystem)
+		}
+	}
+	w.last = sample
+	w.mu.Unlock()
+	sort.Strings(sample.Leaking)
+	sort.Strings(newlyLeaking)
+
+	w.logger.Debug("Watchdog sample",
+		"connections", sample.Connections,
+		"peak_connections", sample.Peak,
+		"goroutines", sample.Goroutines,
+		"open_files", sample.OpenFiles,
+		"subsystems", sample.Subsystems)
+	for _, subsystem := range newlyLeaking {
+		w.logger.Warn("Goroutines outliving their connections",
+			"subsystem", subsystem,
+			"goroutines", sample.Subsystems[subsystem],
+			"connections", sample.Connections,
+			"total_goroutines", sample.Goroutines,
+			"open_files", sample.OpenFiles)
+	}
+
+	if w.recorder != nil {
+		w.recorder.RecordConnectionCounts(sample.Connections, sample.Peak)
+		for subsystem, count := range sample.Subsystems {
+			w.recorder.RecordGoroutines(subsystem, count)
+		}
+		for _, subsystem := range newlyLeaking {
+			w.recorder.RecordGoroutineLeak(subsystem)
+		}
+	}
+	return sample
+}
+
+// Last returns the most recent sample, or nil before the first
+func (w *Watchdog) Last() *WatchdogSample {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.last
+}
+
+// openFileCount returns the number of file descriptors the process has open,
+// or -1 where /proc is not available
+func openFileCount() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	// The directory being read holds a descriptor of its own
+	return len(entries) - 1
+}
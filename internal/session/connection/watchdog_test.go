@@ -0,0 +1,74 @@
+package connection
+
+import (
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeWatchdogRecorder struct {
+	active, peak int
+	goroutines   map[string]int
+	leaks        []string
+}
+
+func (r *fakeWatchdogRecorder) RecordConnectionCounts(active, peak int) {
+	r.active, r.peak = active, peak
+}
+
+func (r *fakeWatchdogRecorder) RecordGoroutines(subsystem string, count int) {
+	r.goroutines[subsystem] = count
+}
+
+func (r *fakeWatchdogRecorder) RecordGoroutineLeak(subsystem string) {
+	r.leaks = append(r.leaks, subsystem)
+}
+
+func TestWatchdog_ReportsSubsystemOutlivingConnections(t *testing.T) {
+	manager := NewManager(10, slog.Default())
+	watchdog := NewWatchdog(manager, time.Minute, slog.Default())
+	recorder := &fakeWatchdogRecorder{goroutines: make(map[string]int)}
+	watchdog.SetRecorder(recorder)
+
+	conn := &mockConn{remoteAddr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 12345}}
+	connID, err := manager.Admit(conn)
+	require.NoError(t, err)
+
+	doneMenu := watchdog.Track("menu")
+	doneOutput := watchdog.Track("game_output")
+	sample := watchdog.Sample()
+	assert.Equal(t, 1, sample.Connections)
+	assert.Equal(t, 1, sample.Subsystems["menu"])
+	assert.Empty(t, sample.Leaking)
+
+	// The connection closes but its menu keeps running
+	manager.UnregisterConnection(connID, conn.RemoteAddr())
+	doneOutput()
+	doneOutput() // Calling it twice counts once
+	for i := 0; i < watchdogLeakSamples-1; i++ {
+		assert.Empty(t, watchdog.Sample().Leaking)
+	}
+	sample = watchdog.Sample()
+	assert.Equal(t, []string{"menu"}, sample.Leaking)
+	assert.Equal(t, 0, sample.Subsystems["game_output"])
+	assert.Same(t, sample, watchdog.Last())
+
+	// The leak is counted once, not on every sample
+	watchdog.Sample()
+	assert.Equal(t, []string{"menu"}, recorder.leaks)
+	assert.Equal(t, 1, recorder.goroutines["menu"])
+	assert.Equal(t, 0, recorder.active)
+	assert.Equal(t, 1, recorder.peak)
+
+	doneMenu()
+	assert.Empty(t, watchdog.Sample().Leaking)
+}
+
+func TestWatchdog_NilTrack(t *testing.T) {
+	var watchdog *Watchdog
+	assert.NotPanics(t, func() { watchdog.Track("menu")() })
+}
@@ -0,0 +1,240 @@
+package connection
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+	"golang.org/x/crypto/ssh"
+)
+
+// User preferences chosen in the welcome flow
+const (
+	ColorModePreference = "color_mode"
+	ThemePreference     = "theme"
+)
+
+// defaultWelcomeTips is the quick-start page shown when no tips file is configured
+const defaultWelcomeTips = `=== Quick Start ===
+
+  [p] on the main menu starts a game; pick a version if there is more than one.
+  [w] lists games in progress so you can watch other players.
+  [e] edits your profile, including whether your games are recorded.
+  [l] shows the end-of-game dumps of your last games.
+
+  Your game is saved if you disconnect, so you can pick it up next time.
+  Ctrl-D leaves most screens and returns to the menu.
+`
+
+// welcomeConfig returns the welcome flow settings, or nil when it is off
+func (m *UserAuthManager) welcomeConfig() *config.WelcomeConfig {
+	if m.registration == nil || m.registration.Welcome == nil || !m.registration.Welcome.Enabled {
+		return nil
+	}
+	return m.registration.Welcome
+}
+
+// readRegistrationEmail asks for the new player's email address, insisting on
+// one when the registration config requires it
+func (m *UserAuthManager) readRegistrationEmail(ctx context.Context, channel ssh.Channel) (string, error) {
+	if m.registration == nil || !m.registration.RequireEmail {
+		channel.Write([]byte("Email (optional - leave blank to skip): "))
+		return m.readOptionalLineWithTerminal(ctx, channel)
+	}
+
+	for {
+		channel.Write([]byte("Email: "))
+		email, err := m.readOptionalLineWithTerminal(ctx, channel)
+		if err != nil || email != "" {
+			return email, err
+		}
+		channel.Write([]byte("An email address is required.\r\n"))
+	}
+}
+
+// acceptTerms shows the terms of service and asks the player to accept them.
+// It returns the version accepted, empty when there are no terms to accept,
+// and reports whether registration may go on.
+func (m *UserAuthManager) acceptTerms(ctx context.Context, channel ssh.Channel) (string, bool, error) {
+	version, content, err := m.registrationTerms(ctx)
+	if err != nil {
+		m.logger.Error("Failed to load terms of service", "error", err)
+		channel.Write([]byte("\r\nRegistration is unavailable right now. Please try again later.\r\n"))
+		m.pause.error()
+		return "", false, nil
+	}
+	if version == "" {
+		return "", true, nil
+	}
+
+	accepted, err := m.showTerms(ctx, channel, "Terms of Service", "", content)
+	if err != nil {
+		return "", false, err
+	}
+	if !accepted {
+		channel.Write([]byte("\r\nYou must accept the terms to register.\r\n"))
+		m.pause.error()
+		return "", false, nil
+	}
+
+	return version, true, nil
+}
+
+// registrationTerms returns the terms new players accept: the terms published
+// in the auth service when there are any, since players would otherwise be
+// asked for them straight after registering, or else the welcome flow's terms
+// file when the registration config requires terms
+func (m *UserAuthManager) registrationTerms(ctx context.Context) (string, string, error) {
+	resp, err := m.authClient.GetTerms(ctx, "")
+	if err != nil {
+		return "", "", err
+	}
+	if resp.Success && resp.Terms != nil {
+		return resp.Terms.Version, resp.Terms.Content, nil
+	}
+
+	welcome := m.welcomeConfig()
+	if welcome == nil || !m.registration.RequireTerms || welcome.TermsFile == "" {
+		return "", "", nil
+	}
+	terms, err := os.ReadFile(welcome.TermsFile)
+	if err != nil {
+		return "", "", err
+	}
+	return termsVersion(welcome, terms), string(terms), nil
+}
+
+// termsVersion returns the configured version of the terms, or one derived
+// from their content so edited terms are never mistaken for accepted ones
+func termsVersion(welcome *config.WelcomeConfig, terms []byte) string {
+	if welcome.TermsVersion != "" {
+		return welcome.TermsVersion
+	}
+	sum := sha256.Sum256(terms)
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// runWelcome takes a newly registered player through the welcome screens
+// before they reach the menu
+func (m *UserAuthManager) runWelcome(ctx context.Context, channel ssh.Channel, user *authv1.User, email string, sshConn *ssh.ServerConn) error {
+	welcome := m.welcomeConfig()
+	if welcome == nil {
+		return nil
+	}
+
+	if email == "" {
+		channel.Write([]byte("\r\nNo email address was given: if you forget your password, only an admin can reset it.\r\n"))
+		m.pause.error()
+	} else if m.registration.EmailVerification {
+		channel.Write([]byte(fmt.Sprintf("\r\nPlease verify %s when the verification message arrives; until then it cannot be used to recover your account.\r\n", email)))
+		m.pause.error()
+	}
+
+	if welcome.AskPreferences {
+		if err := m.askDisplayPreferences(ctx, channel, user, sshConn); err != nil {
+			return err
+		}
+	}
+
+	if welcome.ShowTips {
+		tips := defaultWelcomeTips
+		if welcome.TipsFile != "" {
+			content, err := os.ReadFile(welcome.TipsFile)
+			if err != nil {
+				m.logger.Warn("Failed to read welcome tips, using the built-in tips", "error", err, "path", welcome.TipsFile)
+			} else {
+				tips = string(content)
+			}
+		}
+		channel.Write([]byte("\033[2J\033[H")) // Clear screen
+		channel.Write([]byte(terminalText(tips)))
+		channel.Write([]byte("\r\nPress any key to continue to the menu..."))
+		buffer := make([]byte, 1)
+		if _, err := channel.Read(buffer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// askDisplayPreferences lets a new player choose their color mode and theme.
+// The defaults, color and dark, are stored as no preference at all.
+func (m *UserAuthManager) askDisplayPreferences(ctx context.Context, channel ssh.Channel, user *authv1.User, sshConn *ssh.ServerConn) error {
+	channel.Write([]byte("\033[2J\033[H")) // Clear screen
+	channel.Write([]byte("=== Terminal Setup ===\r\n\r\n"))
+	channel.Write([]byte("Does your terminal show colors? (Y/n): "))
+	color, err := m.readYesNoDefault(ctx, channel, true)
+	if err != nil {
+		return err
+	}
+	channel.Write([]byte("\r\nDoes your terminal have a dark background? (Y/n): "))
+	dark, err := m.readYesNoDefault(ctx, channel, true)
+	if err != nil {
+		return err
+	}
+	channel.Write([]byte("\r\n"))
+
+	var preferences [][2]string
+	if !color {
+		preferences = append(preferences, [2]string{ColorModePreference, "mono"})
+	}
+	if !dark {
+		preferences = append(preferences, [2]string{ThemePreference, "light"})
+	}
+
+	token := sshConn.Permissions.Extensions["access_token"]
+	for _, preference := range preferences {
+		key, value := preference[0], preference[1]
+		resp, err := m.authClient.SetUserPreference(ctx, token, key, value)
+		if err != nil || !resp.Success {
+			m.logger.Warn("Failed to save welcome preference", "error", err, "username", user.Username, "key", key)
+			channel.Write([]byte("Could not save your terminal settings; you can change them later.\r\n"))
+			m.pause.error()
+			return nil
+		}
+	}
+	return nil
+}
+
+// readYesNo reads a y or n answer; Enter means no
+func (m *UserAuthManager) readYesNo(ctx context.Context, channel ssh.Channel) (bool, error) {
+	return m.readYesNoDefault(ctx, channel, false)
+}
+
+// readYesNoDefault reads a y or n answer; Enter gives the default
+func (m *UserAuthManager) readYesNoDefault(ctx context.Context, channel ssh.Channel, defaultYes bool) (bool, error) {
+	buffer := make([]byte, 1)
+	for {
+		select {
+		case <-ctx.Done():
+			return false, ctx.Err()
+		default:
+		}
+
+		if _, err := channel.Read(buffer); err != nil {
+			return false, err
+		}
+		switch buffer[0] {
+		case 'y', 'Y':
+			channel.Write([]byte("y"))
+			return true, nil
+		case 'n', 'N', 3, 4, 27:
+			channel.Write([]byte("n"))
+			return false, nil
+		case '\r', '\n':
+			return defaultYes, nil
+		}
+	}
+}
+
+// terminalText prepares text from a file for display on the terminal
+func terminalText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\n", "\r\n")
+}
@@ -0,0 +1,34 @@
+package connection
+
+import (
+	"testing"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTermsVersion(t *testing.T) {
+	terms := []byte("Be nice.\n")
+
+	assert.Equal(t, "2024-06", termsVersion(&config.WelcomeConfig{TermsVersion: "2024-06"}, terms))
+
+	derived := termsVersion(&config.WelcomeConfig{}, terms)
+	assert.Regexp(t, `^sha256:[0-9a-f]{12}$`, derived)
+	assert.Equal(t, derived, termsVersion(&config.WelcomeConfig{}, terms))
+	assert.NotEqual(t, derived, termsVersion(&config.WelcomeConfig{}, []byte("Be very nice.\n")))
+}
+
+func TestWelcomeConfig(t *testing.T) {
+	m := &UserAuthManager{}
+	assert.Nil(t, m.welcomeConfig())
+
+	m.registration = &config.RegistrationConfig{Welcome: &config.WelcomeConfig{}}
+	assert.Nil(t, m.welcomeConfig())
+
+	m.registration.Welcome.Enabled = true
+	assert.Same(t, m.registration.Welcome, m.welcomeConfig())
+}
+
+func TestTerminalText(t *testing.T) {
+	assert.Equal(t, "one\r\ntwo\r\n", terminalText("one\ntwo\r\n"))
+}
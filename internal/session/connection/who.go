@@ -0,0 +1,44 @@
+package connection
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dungeongate/internal/session/realm"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"golang.org/x/crypto/ssh"
+)
+
+// showWhoIsOnline pages through the players connected to this instance and
+// what they are doing
+func (p *MenuChoiceProcessor) showWhoIsOnline(ctx context.Context, channel ssh.Channel, userInfo *authv1.User, terminalRows int) error {
+	lines := whoLines(p.manager.Online(), realm.FromContext(ctx).GetName(), userInfo.GetIsAdmin(), time.Now())
+	return pageLines(ctx, channel, "Who is online", lines, terminalRows)
+}
+
+// whoLines renders the who list of a realm. Players who hid their online
+// status are left out, except for admins, who see them marked.
+func whoLines(users []OnlineUser, realmName string, admin bool, now time.Time) []string {
+	lines := []string{
+		"=== Who Is Online ===",
+		"",
+		fmt.Sprintf("  %-20s %-32s %s", "Player", "Doing", "Connected"),
+	}
+	listed := 0
+	for _, user := range users {
+		if user.Realm != realmName || (user.Hidden && !admin) {
+			continue
+		}
+		name := user.Username
+		if user.Hidden {
+			name += " (hidden)"
+		}
+		lines = append(lines, fmt.Sprintf("  %-20s %-32s %s", name, user.Describe(), now.Sub(user.ConnectedAt).Round(time.Second)))
+		listed++
+	}
+	if listed == 0 {
+		return append(lines, "  Nobody is logged in.")
+	}
+	return append(lines, "", fmt.Sprintf("%d online", listed))
+}
@@ -0,0 +1,70 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// gameActivity is how many sessions of a game are being played and watched
+type gameActivity struct {
+	playing  int
+	watching int
+}
+
+// countGameActivity tallies the active sessions and their spectators by game
+func countGameActivity(sessions []*gamev2.GameSession) map[string]gameActivity {
+	activity := make(map[string]gameActivity)
+	for _, session := range sessions {
+		counts := activity[session.GameId]
+		counts.playing++
+		counts.watching += len(session.Spectators)
+		activity[session.GameId] = counts
+	}
+	return activity
+}
+
+// String formats the counts for the game selection menu, e.g.
+// "12 playing, 30 watching"
+func (a gameActivity) String() string {
+	if a.watching == 0 {
+		return fmt.Sprintf("%d playing", a.playing)
+	}
+	return fmt.Sprintf("%d playing, %d watching", a.playing, a.watching)
+}
+
+// followGameActivity calls onChange with the per-game activity each time it
+// changes on the game service, starting from the initial sessions. It blocks
+// until ctx is cancelled.
+func (mh *MenuHandler) followGameActivity(ctx context.Context, initial []*gamev2.GameSession, onChange func(map[string]gameActivity)) {
+	activity := countGameActivity(initial)
+	lists := mh.followSessions(ctx, initial, mh.gameClient.SubscribeAllGameSessions, mh.gameClient.ListActiveGameSessions)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case sessions := <-lists:
+			newActivity := countGameActivity(sessions)
+			if sameGameActivity(newActivity, activity) {
+				continue
+			}
+			activity = newActivity
+			onChange(activity)
+		}
+	}
+}
+
+// sameGameActivity reports whether two activity tallies are equal
+func sameGameActivity(a, b map[string]gameActivity) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for gameID, counts := range a {
+		if b[gameID] != counts {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,29 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+func TestCountGameActivity(t *testing.T) {
+	sessions := []*gamev2.GameSession{
+		{Id: "s1", GameId: "nethack", Spectators: []*gamev2.SpectatorInfo{{}, {}}},
+		{Id: "s2", GameId: "nethack"},
+		{Id: "s3", GameId: "dcss", Spectators: []*gamev2.SpectatorInfo{{}}},
+	}
+
+	activity := countGameActivity(sessions)
+	assert.Equal(t, gameActivity{playing: 2, watching: 2}, activity["nethack"])
+	assert.Equal(t, gameActivity{playing: 1, watching: 1}, activity["dcss"])
+	assert.NotContains(t, activity, "angband")
+
+	assert.Equal(t, "2 playing, 2 watching", activity["nethack"].String())
+	assert.Equal(t, "1 playing", gameActivity{playing: 1}.String())
+
+	assert.True(t, sameGameActivity(activity, countGameActivity(sessions)))
+	assert.False(t, sameGameActivity(activity, countGameActivity(sessions[:2])))
+	assert.Empty(t, countGameActivity(nil))
+}
@@ -5,15 +5,19 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/dungeongate/internal/session/banner"
 	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/realm"
 	"github.com/dungeongate/internal/session/terminal"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
 	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -23,6 +27,9 @@ type MenuHandler struct {
 	gameClient    *client.GameClient
 	authClient    *client.AuthClient
 	logger        *slog.Logger
+
+	// How long errors stay on screen before the menu moves on
+	errorPause time.Duration
 }
 
 // NewMenuHandler creates a new menu handler
@@ -32,13 +39,33 @@ func NewMenuHandler(bannerManager *banner.BannerManager, gameClient *client.Game
 		gameClient:    gameClient,
 		authClient:    authClient,
 		logger:        logger,
+		errorPause:    config.DefaultMenuErrorPause,
 	}
 }
 
+// SetErrorPause sets how long errors stay on screen; invalid choices show for half as long
+func (mh *MenuHandler) SetErrorPause(pause time.Duration) {
+	mh.errorPause = pause
+}
+
+// banners returns the banner manager of the connection's realm
+func (mh *MenuHandler) banners(ctx context.Context) *banner.BannerManager {
+	if r := realm.FromContext(ctx); r != nil && r.Banners != nil {
+		return r.Banners
+	}
+	return mh.bannerManager
+}
+
 // MenuChoice represents a user's menu choice
 type MenuChoice struct {
 	Action string
 	Value  string
+	// Version is the game version picked for start_game, empty for the default
+	Version string
+	// SetDefault makes Version the player's default version of the game
+	SetDefault bool
+	// SkipNews starts the game without showing its news first
+	SkipNews bool
 }
 
 // InputValidator handles menu input validation and error messages
@@ -63,6 +90,45 @@ func (iv *InputValidator) ValidateInput(input string) (bool, string) {
 	return false, errorMsg
 }
 
+// IsModerator reports whether a user may use the moderation tools. Admins are always moderators.
+func IsModerator(user *authv1.User) bool {
+	if user == nil {
+		return false
+	}
+	if user.IsAdmin {
+		return true
+	}
+	for _, role := range user.Roles {
+		if role == "moderator" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRestricted reports whether a moderator has restricted the user to playing their own games
+func IsRestricted(user *authv1.User) bool {
+	return user != nil && user.Metadata["restricted"] == "true"
+}
+
+// ImpersonatedBy returns the admin acting as the user for support, if any
+func ImpersonatedBy(user *authv1.User) string {
+	if user == nil {
+		return ""
+	}
+	return user.Metadata["impersonated_by"]
+}
+
+// notificationLine is the main menu entry for notifications, badged with
+// the unread count the connection last fetched
+func notificationLine(user *authv1.User) string {
+	unread, _ := strconv.Atoi(user.Metadata["unread_notifications"])
+	if unread > 0 {
+		return fmt.Sprintf("\r\n  [n] Notifications (%d unread)\r\n", unread)
+	}
+	return "\r\n  [n] Notifications\r\n"
+}
+
 // handleCtrlD processes Ctrl+D input consistently across all menus
 func handleCtrlD() *MenuChoice {
 	return &MenuChoice{Action: "quit", Value: ""}
@@ -79,7 +145,7 @@ func (mh *MenuHandler) handleInvalidInput(channel ssh.Channel, errorMsg, banner
 	}
 
 	// Brief pause for user to read
-	time.Sleep(1 * time.Second)
+	time.Sleep(mh.errorPause / 2)
 
 	// Clear screen and redisplay menu
 	if _, err := channel.Write([]byte("\033[2J\033[H")); err != nil {
@@ -102,7 +168,7 @@ func (mh *MenuHandler) handleInvalidInput(channel ssh.Channel, errorMsg, banner
 func (mh *MenuHandler) ShowAnonymousMenu(ctx context.Context, channel ssh.Channel, username string) (*MenuChoice, error) {
 	// Create input validator for anonymous menu
 	validator := &InputValidator{
-		ValidOptions: []string{"[L]ogin", "[R]egister", "[W]atch", "[C]redits", "[Q]uit"},
+		ValidOptions: []string{"[L]ogin", "[R]egister", "[W]atch", "[O]nline", "[C]redits", "[Q]uit"},
 		MenuName:     "Anonymous Menu",
 	}
 
@@ -114,7 +180,7 @@ func (mh *MenuHandler) ShowAnonymousMenu(ctx context.Context, channel ssh.Channe
 	}
 
 	// Render the anonymous banner
-	banner, err := mh.bannerManager.RenderMainAnon()
+	banner, err := mh.banners(ctx).RenderMainAnon()
 	if err != nil {
 		mh.logger.Error("Failed to render anonymous banner", "error", err)
 		return nil, fmt.Errorf("failed to render banner: %w", err)
@@ -153,6 +219,8 @@ func (mh *MenuHandler) ShowAnonymousMenu(ctx context.Context, channel ssh.Channe
 				return &MenuChoice{Action: "register", Value: ""}, nil
 			case "w":
 				return &MenuChoice{Action: "watch", Value: ""}, nil
+			case "o":
+				return &MenuChoice{Action: "who", Value: ""}, nil
 			case "c":
 				return &MenuChoice{Action: "credit", Value: ""}, nil
 			case "q":
@@ -185,7 +253,7 @@ func (mh *MenuHandler) ShowUserMenu(ctx context.Context, channel ssh.Channel, us
 
 	// Create input validator for user menu
 	validator := &InputValidator{
-		ValidOptions: []string{"[P]lay", "[W]atch", "[E]dit profile", "[L]ist games", "[R]ecordings", "[S]tatistics", "[C]redits", "[Q]uit"},
+		ValidOptions: []string{"[P]lay", "[W]atch", "[E]dit profile", "[L]ast games", "[R]ecordings", "[S]tatistics", "[T]eams", "[F]riends", "[N]otifications", "[O]nline", "[C]redits", "[Q]uit"},
 		MenuName:     "User Menu",
 	}
 
@@ -197,12 +265,29 @@ func (mh *MenuHandler) ShowUserMenu(ctx context.Context, channel ssh.Channel, us
 	}
 
 	// Render the user banner
-	banner, err := mh.bannerManager.RenderMainUser(user.Username)
+	banner, err := mh.banners(ctx).RenderMainUser(realm.FromContext(ctx).Local(user.Username))
 	if err != nil {
 		mh.logger.Error("Failed to render user banner", "error", err, "username", user.Username)
 		return nil, fmt.Errorf("failed to render banner: %w", err)
 	}
 
+	banner += notificationLine(user)
+
+	// Moderators get the moderation tools on top of the regular user menu
+	moderator := IsModerator(user)
+	if moderator {
+		banner += "  [m] Moderation tools\r\n"
+		validator.ValidOptions = append(validator.ValidOptions, "[M]oderation")
+	}
+
+	// Support access is never silent: the admin is reminded whose menu this
+	// is, and the user learns of it on their next visit
+	if admin := ImpersonatedBy(user); admin != "" {
+		banner = fmt.Sprintf("*** %s viewing as %s - [q] returns to your account ***\r\n", admin, user.Username) + banner
+	} else if user.Metadata["unseen_account_access"] != "" {
+		banner += "\r\n  Support staff accessed your account - see [e] Edit profile.\r\n"
+	}
+
 	// Display the banner
 	_, err = channel.Write([]byte(banner))
 	if err != nil {
@@ -229,6 +314,10 @@ func (mh *MenuHandler) ShowUserMenu(ctx context.Context, channel ssh.Channel, us
 		if event.Type == terminal.EventCharacter {
 			choice := string(event.Character)
 
+			if moderator && strings.ToLower(choice) == "m" {
+				return &MenuChoice{Action: "moderation", Value: ""}, nil
+			}
+
 			switch strings.ToLower(choice) {
 			case "p":
 				return &MenuChoice{Action: "play", Value: ""}, nil
@@ -238,8 +327,18 @@ func (mh *MenuHandler) ShowUserMenu(ctx context.Context, channel ssh.Channel, us
 				return &MenuChoice{Action: "edit_profile", Value: ""}, nil
 			case "r":
 				return &MenuChoice{Action: "view_recordings", Value: ""}, nil
-			case "s":
+			case "l":
+				return &MenuChoice{Action: "view_dumplogs", Value: ""}, nil
+			case "s", "g":
 				return &MenuChoice{Action: "statistics", Value: ""}, nil
+			case "t":
+				return &MenuChoice{Action: "groups", Value: ""}, nil
+			case "f":
+				return &MenuChoice{Action: "friends", Value: ""}, nil
+			case "n":
+				return &MenuChoice{Action: "notifications", Value: ""}, nil
+			case "o":
+				return &MenuChoice{Action: "who", Value: ""}, nil
 			case "c":
 				return &MenuChoice{Action: "credit", Value: ""}, nil
 			case "q":
@@ -267,7 +366,7 @@ func (mh *MenuHandler) ShowUserMenu(ctx context.Context, channel ssh.Channel, us
 func (mh *MenuHandler) ShowAdminMenu(ctx context.Context, channel ssh.Channel, user *authv1.User) (*MenuChoice, error) {
 	// Create input validator for admin menu
 	validator := &InputValidator{
-		ValidOptions: []string{"[P]lay", "[W]atch", "[E]dit profile", "[V]iew recordings", "[G]ame Stats", "[U]nlock User", "[D]elete User", "[R]eset Password", "[A]dd Admin", "[S]erver Statistics", "[C]redits", "[Q]uit"},
+		ValidOptions: []string{"[P]lay", "[W]atch", "[E]dit profile", "[V]iew recordings", "[L]ast games", "[G]ame Stats", "[T]eams", "[F]riends", "[N]otifications", "[O]nline", "[U]nlock User", "[D]elete User", "[R]eset Password", "[A]dd Admin", "[S]erver Statistics", "[M]oderation", "[I]mpersonate User", "[C]redits", "[Q]uit"},
 		MenuName:     "Admin Menu",
 	}
 
@@ -279,11 +378,12 @@ func (mh *MenuHandler) ShowAdminMenu(ctx context.Context, channel ssh.Channel, u
 	}
 
 	// Render the admin banner
-	banner, err := mh.bannerManager.RenderMainAdmin(user.Username)
+	banner, err := mh.banners(ctx).RenderMainAdmin(realm.FromContext(ctx).Local(user.Username))
 	if err != nil {
 		mh.logger.Error("Failed to render admin banner", "error", err, "username", user.Username)
 		return nil, fmt.Errorf("failed to render banner: %w", err)
 	}
+	banner += notificationLine(user)
 
 	// Display the banner
 	_, err = channel.Write([]byte(banner))
@@ -321,8 +421,18 @@ func (mh *MenuHandler) ShowAdminMenu(ctx context.Context, channel ssh.Channel, u
 				return &MenuChoice{Action: "edit_profile", Value: ""}, nil
 			case "v":
 				return &MenuChoice{Action: "view_recordings", Value: ""}, nil
+			case "l":
+				return &MenuChoice{Action: "view_dumplogs", Value: ""}, nil
 			case "g":
 				return &MenuChoice{Action: "statistics", Value: ""}, nil
+			case "t":
+				return &MenuChoice{Action: "groups", Value: ""}, nil
+			case "f":
+				return &MenuChoice{Action: "friends", Value: ""}, nil
+			case "n":
+				return &MenuChoice{Action: "notifications", Value: ""}, nil
+			case "o":
+				return &MenuChoice{Action: "who", Value: ""}, nil
 			case "c":
 				return &MenuChoice{Action: "credit", Value: ""}, nil
 			// Admin-specific functions
@@ -336,6 +446,10 @@ func (mh *MenuHandler) ShowAdminMenu(ctx context.Context, channel ssh.Channel, u
 				return &MenuChoice{Action: "admin_promote_user", Value: ""}, nil
 			case "s":
 				return &MenuChoice{Action: "admin_server_stats", Value: ""}, nil
+			case "m":
+				return &MenuChoice{Action: "moderation", Value: ""}, nil
+			case "i":
+				return &MenuChoice{Action: "admin_impersonate_user", Value: ""}, nil
 			case "q":
 				return handleCtrlD(), nil
 			default:
@@ -363,27 +477,63 @@ func (mh *MenuHandler) RenderServiceUnavailable(username string, remainingMinute
 	return mh.bannerManager.RenderServiceUnavailable(username, remainingMinutes, remainingSeconds, serviceStatus)
 }
 
-// ShowGameSelectionMenu displays the game selection menu and handles input
-func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Channel, username string) (*MenuChoice, error) {
+// RenderServerFull renders the banner shown to connections turned away because the server is full
+func (mh *MenuHandler) RenderServerFull() (string, error) {
+	return mh.bannerManager.RenderServerFull()
+}
+
+// RenderCredits renders the credits screen's markdown of the connection's realm for a user
+func (mh *MenuHandler) RenderCredits(ctx context.Context, username string) (string, error) {
+	return mh.banners(ctx).RenderCredits(realm.FromContext(ctx).Local(username))
+}
+
+// Version returns the version of this session service
+func (mh *MenuHandler) Version() string {
+	return mh.bannerManager.Version()
+}
+
+// RenderGameNews renders the news shown before a game starts in the
+// connection's realm; ok is false when the game has none
+func (mh *MenuHandler) RenderGameNews(ctx context.Context, gameID, username string) (string, bool, error) {
+	return mh.banners(ctx).RenderGameNews(gameID, realm.FromContext(ctx).Local(username))
+}
+
+// ShowGameSelectionMenu displays the game selection menu and handles input.
+// The number of players and spectators of each game is kept current while
+// the menu is open. Games with several installed versions go on to a version
+// picker.
+func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Channel, user *authv1.User) (*MenuChoice, error) {
+	username := user.GetUsername()
+
 	// Get list of available games from Game Service
 	games, err := mh.gameClient.ListGames(ctx)
 	if err != nil {
 		mh.logger.Error("Failed to get available games", "error", err, "username", username)
 		channel.Write([]byte("\r\nFailed to load available games. Please try again later.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		time.Sleep(mh.errorPause)
 		return nil, nil
 	}
 
 	if len(games) == 0 {
 		channel.Write([]byte("\r\nNo games are currently available.\r\n"))
 		// Brief pause to let user read the message
-		time.Sleep(2 * time.Second)
+		time.Sleep(mh.errorPause)
 		return nil, nil
 	}
 
+	// Counts are a nicety; the menu works without them
+	sessions, err := mh.gameClient.ListActiveGameSessions(ctx)
+	if err != nil {
+		mh.logger.Debug("Failed to get game activity", "error", err, "username", username)
+	}
+
+	// The game last played comes first, then the player's favorites
+	prefs := PreferencesOf(user)
+	games = orderGames(games, prefs)
+
 	// Display game selection menu
-	banner := mh.buildGameSelectionBanner(games, username)
+	banner := mh.buildGameSelectionBanner(ctx, games, username, prefs, countGameActivity(sessions))
 	_, err = channel.Write([]byte(banner))
 	if err != nil {
 		if err == io.EOF {
@@ -397,6 +547,36 @@ func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Ch
 	inputHandler := terminal.NewInputHandler(channel)
 	var inputBuffer strings.Builder
 
+	// Redraw the menu as activity changes. mu guards the channel, banner and
+	// inputBuffer between the redraws and the input loop below.
+	var mu sync.Mutex
+	liveCtx, cancelLive := context.WithCancel(ctx)
+	liveDone := make(chan struct{})
+	go func() {
+		defer close(liveDone)
+		mh.followGameActivity(liveCtx, sessions, func(activity map[string]gameActivity) {
+			mu.Lock()
+			defer mu.Unlock()
+			newBanner := mh.buildGameSelectionBanner(ctx, games, username, prefs, activity)
+			if newBanner == banner {
+				return
+			}
+			channel.Write([]byte("\033[2J\033[H"))
+			channel.Write([]byte(newBanner))
+			// Restore any typed input
+			if inputBuffer.Len() > 0 {
+				channel.Write([]byte(inputBuffer.String()))
+			}
+			banner = newBanner
+		})
+	}()
+	// stopLive stops the redraws before anything else uses the screen
+	stopLive := func() {
+		cancelLive()
+		<-liveDone
+	}
+	defer stopLive()
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -416,11 +596,14 @@ func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Ch
 					return nil, nil // Return to main menu
 				}
 
-				// For digits, accumulate input until Enter
-				if char >= '0' && char <= '9' {
+				// For digits, accumulate input until Enter; a leading '*'
+				// stars or unstars the game instead of starting it
+				if (char >= '0' && char <= '9') || (char == '*' && inputBuffer.Len() == 0) {
+					mu.Lock()
 					inputBuffer.WriteRune(char)
 					// Echo the character for visual feedback
 					channel.Write([]byte(string(char)))
+					mu.Unlock()
 				}
 
 			case terminal.EventKey:
@@ -432,18 +615,44 @@ func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Ch
 				}
 
 				if key == terminal.KeyEnter {
+					mu.Lock()
 					choice := strings.TrimSpace(inputBuffer.String())
 					inputBuffer.Reset()
+					mu.Unlock()
 
+					// Enter on its own starts the default or last played game
 					if choice == "" {
-						continue // Ignore empty input
+						if i := indexOfGame(games, prefs.QuickLaunchGame()); i >= 0 {
+							choice = strconv.Itoa(i + 1)
+						} else {
+							continue // Ignore empty input
+						}
+					}
+
+					if star, ok := strings.CutPrefix(choice, "*"); ok {
+						if gameIndex, parseErr := parseGameChoice(star, len(games)); parseErr == nil {
+							stopLive()
+							channel.Write([]byte("\r\n"))
+							return &MenuChoice{Action: "toggle_favorite", Value: games[gameIndex].Id}, nil
+						}
 					}
 
 					// Try to parse game selection number
 					if gameIndex, parseErr := parseGameChoice(choice, len(games)); parseErr == nil {
 						selectedGame := games[gameIndex]
+						if reason, unavailable := gameUnavailableReason(selectedGame); unavailable {
+							mu.Lock()
+							channel.Write([]byte(fmt.Sprintf("\r\n%s cannot be played right now: %s\r\n\r\n", selectedGame.Name, reason)))
+							channel.Write([]byte(banner))
+							mu.Unlock()
+							continue
+						}
+						stopLive()
 						// Clear the line to remove echoed input
 						channel.Write([]byte("\r\n"))
+						if len(selectedGame.Versions) > 1 {
+							return mh.showVersionPicker(ctx, channel, inputHandler, selectedGame, PreferredVersion(user, selectedGame))
+						}
 						return &MenuChoice{
 							Action: "start_game",
 							Value:  selectedGame.Id,
@@ -452,12 +661,15 @@ func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Ch
 						// Invalid choice, show error with helpful options
 						validOptions := fmt.Sprintf("1-%d", len(games))
 						errorMsg := fmt.Sprintf("\r\nInvalid choice '%s'. Valid options: %s, or [Q]uit\r\n\r\n", choice, validOptions)
+						mu.Lock()
 						channel.Write([]byte(errorMsg))
 						// Redisplay the banner
 						channel.Write([]byte(banner))
+						mu.Unlock()
 					}
 				} else if key == terminal.KeyBackspace {
 					// Handle backspace for multi-digit input
+					mu.Lock()
 					if inputBuffer.Len() > 0 {
 						// Remove last character from buffer
 						str := inputBuffer.String()
@@ -466,52 +678,115 @@ func (mh *MenuHandler) ShowGameSelectionMenu(ctx context.Context, channel ssh.Ch
 						// Send backspace sequence to terminal
 						channel.Write([]byte("\b \b"))
 					}
+					mu.Unlock()
 				}
 			}
 		}
 	}
 }
 
-// buildGameSelectionBanner creates the game selection menu display with header and footer
-func (mh *MenuHandler) buildGameSelectionBanner(games []*gamev2.Game, username string) string {
+// indexOfGame returns the index of a game in the menu, or -1
+func indexOfGame(games []*gamev2.Game, gameID string) int {
+	if gameID == "" {
+		return -1
+	}
+	for i, game := range games {
+		if game.Id == gameID {
+			return i
+		}
+	}
+	return -1
+}
+
+// buildGameSelectionBanner creates the game selection menu display with header
+// and footer, showing the activity of games with sessions in progress and
+// marking the player's default, last played and favorite games
+func (mh *MenuHandler) buildGameSelectionBanner(ctx context.Context, games []*gamev2.Game, username string, prefs MenuPreferences, activity map[string]gameActivity) string {
+	banners := mh.banners(ctx)
+
 	// Get template variables for header/footer
-	variables := mh.bannerManager.GetTemplateVariables(username)
+	variables := banners.GetTemplateVariables(realm.FromContext(ctx).Local(username))
 
 	// Get header
-	header := mh.bannerManager.RenderHeader("game_selection", variables)
+	header := banners.RenderHeader("game_selection", variables)
 
 	// Build main content
 	banner := fmt.Sprintf("=== DungeonGate - Game Selection ===\r\n\r\n")
 	banner += fmt.Sprintf("Welcome, %s! Choose a game to play:\r\n\r\n", username)
 
 	for i, game := range games {
+		// Games that cannot be started are greyed out with the reason
+		if reason, unavailable := gameUnavailableReason(game); unavailable {
+			banner += fmt.Sprintf("\033[2m  [%d] %s\r\n", i+1, game.Name)
+			banner += fmt.Sprintf("      Unavailable: %s\033[0m\r\n\r\n", reason)
+			continue
+		}
+
 		status := "Available"
 		if game.Status != gamev2.GameStatus_GAME_STATUS_UNSPECIFIED {
 			status = fmt.Sprintf("Available (%s)", game.Status.String())
 		}
 
-		banner += fmt.Sprintf("  [%d] %s\r\n", i+1, game.Name)
+		name := game.Name
+		if prefs.IsFavorite(game.Id) {
+			name = "* " + name
+		}
+		if game.Id == prefs.DefaultGame {
+			name += " (default)"
+		} else if game.Id == prefs.LastPlayed() {
+			name += " (last played)"
+		}
+		if counts, ok := activity[game.Id]; ok {
+			banner += fmt.Sprintf("  [%d] %s — %s\r\n", i+1, name, counts)
+		} else {
+			banner += fmt.Sprintf("  [%d] %s\r\n", i+1, name)
+		}
 		if game.Description != "" {
 			banner += fmt.Sprintf("      %s\r\n", game.Description)
 		}
 		banner += fmt.Sprintf("      Status: %s\r\n", status)
-		if game.Version != "" {
+		if len(game.Versions) > 1 {
+			banner += fmt.Sprintf("      Versions: %s\r\n", strings.Join(game.Versions, ", "))
+		} else if game.Version != "" {
 			banner += fmt.Sprintf("      Version: %s\r\n", game.Version)
 		}
 		banner += "\r\n"
 	}
 
-	banner += "  [q] Return to main menu\r\n\r\n"
-	banner += "Enter your choice: "
+	banner += "  [*N] Star or unstar game N   [q] Return to main menu\r\n\r\n"
+	if i := indexOfGame(games, prefs.QuickLaunchGame()); i >= 0 {
+		banner += fmt.Sprintf("Enter your choice (Enter plays %s): ", games[i].Name)
+	} else {
+		banner += "Enter your choice: "
+	}
 
 	// Get footer
-	footer := mh.bannerManager.RenderFooter("game_selection", variables)
+	footer := banners.RenderFooter("game_selection", variables)
 
 	// Combine header + banner + footer
 	result := header + banner + footer
 	return result
 }
 
+// gameUnavailableReason returns why a game cannot be started, such as
+// maintenance; unavailable is false for games that can be played
+func gameUnavailableReason(game *gamev2.Game) (reason string, unavailable bool) {
+	switch game.Status {
+	case gamev2.GameStatus_GAME_STATUS_ENABLED, gamev2.GameStatus_GAME_STATUS_UNSPECIFIED:
+		return "", false
+	case gamev2.GameStatus_GAME_STATUS_MAINTENANCE:
+		reason = "down for maintenance"
+	case gamev2.GameStatus_GAME_STATUS_DEPRECATED:
+		reason = "no longer available"
+	default:
+		reason = "disabled"
+	}
+	if game.StatusMessage != "" {
+		reason += " (" + game.StatusMessage + ")"
+	}
+	return reason, true
+}
+
 // parseGameChoice parses the user's game selection input
 func parseGameChoice(input string, maxGames int) (int, error) {
 	// Check for decimal points or other invalid characters
@@ -541,7 +816,7 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 	if err != nil {
 		mh.logger.Error("Failed to get active sessions", "error", err)
 		channel.Write([]byte("Failed to get active sessions. Please try again later.\r\n"))
-		time.Sleep(2 * time.Second)
+		time.Sleep(mh.errorPause)
 		return nil, nil
 	}
 
@@ -551,6 +826,8 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 		// Error already handled in filterUserSessions
 		return nil, nil
 	}
+	sortBy := PreferencesOf(user).WatchSort
+	sortSessions(availableSessions, sortBy)
 
 	if len(availableSessions) == 0 {
 		// Clear screen and show informative message
@@ -583,10 +860,6 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 	// Clear screen initially
 	channel.Write([]byte("\033[2J\033[H"))
 
-	// Create a ticker for updating the display every second
-	updateTicker := time.NewTicker(1 * time.Second)
-	defer updateTicker.Stop()
-
 	// Create context for input handling with timeout
 	inputCtx, inputCancel := context.WithCancel(ctx)
 	defer inputCancel()
@@ -598,8 +871,12 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 	// Start goroutine for handling input
 	go mh.handleSpectateMenuInput(inputCtx, channel, inputChan, errorChan)
 
+	// Session changes and idle time refreshes are pushed by the game service
+	latestSessions := sessions
+	sessionLists := mh.followSessions(inputCtx, sessions, mh.gameClient.SubscribeGameSessions, mh.gameClient.GetActiveGameSessions)
+
 	// Initial display
-	banner := mh.buildSpectateMenuBanner(availableSessions)
+	banner := mh.buildSpectateMenuBanner(availableSessions, sortBy)
 	if _, err := channel.Write([]byte(banner)); err != nil {
 		if err == io.EOF {
 			return &MenuChoice{Action: "quit", Value: ""}, nil
@@ -608,7 +885,6 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 	}
 
 	var inputBuffer strings.Builder
-	lastUpdateTime := time.Now()
 
 	for {
 		select {
@@ -622,53 +898,47 @@ func (mh *MenuHandler) ShowSpectateMenu(ctx context.Context, channel ssh.Channel
 			return nil, err
 
 		case event := <-inputChan:
+			// '.' and ',' step through the orders, redrawing the menu
+			if event.eventType == terminal.EventCharacter && (event.character == '.' || event.character == ',') {
+				sortBy = nextWatchSort(sortBy, event.character == ',')
+				break
+			}
 			// Handle input event
 			choice := mh.processInputEvent(event, &inputBuffer, availableSessions, channel)
 			if choice != nil {
 				return choice, nil
 			}
+			continue
 
-		case <-updateTicker.C:
-			// Update display every second
-			now := time.Now()
-
-			// Only refresh if it's been at least 1 second since last update
-			if now.Sub(lastUpdateTime) >= time.Second {
-				// Get fresh session data every 30 seconds or if session count might have changed
-				if int(now.Unix())%30 == 0 {
-					if freshSessions, err := mh.gameClient.GetActiveGameSessions(ctx); err == nil {
-						newAvailableSessions := mh.filterUserSessions(freshSessions, user)
-						if newAvailableSessions != nil {
-							availableSessions = newAvailableSessions
-						}
-					}
-				}
-
-				// Rebuild and redisplay the banner with updated idle times
-				newBanner := mh.buildSpectateMenuBanner(availableSessions)
-
-				// Only update if the banner actually changed or if idle times need updating
-				if newBanner != banner || mh.hasIdleTimeUpdates(availableSessions) {
-					// Clear screen and redisplay
-					channel.Write([]byte("\033[2J\033[H"))
-					if _, err := channel.Write([]byte(newBanner)); err != nil {
-						if err == io.EOF {
-							return &MenuChoice{Action: "quit", Value: ""}, nil
-						}
-						// Don't return error for write failures during updates
-						continue
-					}
+		case latestSessions = <-sessionLists:
+		}
 
-					// Restore any typed input
-					if inputBuffer.Len() > 0 {
-						channel.Write([]byte(inputBuffer.String()))
-					}
+		sorted := slices.Clone(latestSessions)
+		sortSessions(sorted, sortBy)
+		if newAvailableSessions := mh.filterUserSessions(sorted, user); newAvailableSessions != nil {
+			availableSessions = newAvailableSessions
+		}
 
-					banner = newBanner
-				}
-				lastUpdateTime = now
+		// Redisplay only when the rendered menu changed
+		newBanner := mh.buildSpectateMenuBanner(availableSessions, sortBy)
+		if newBanner == banner {
+			continue
+		}
+		channel.Write([]byte("\033[2J\033[H"))
+		if _, err := channel.Write([]byte(newBanner)); err != nil {
+			if err == io.EOF {
+				return &MenuChoice{Action: "quit", Value: ""}, nil
 			}
+			// Don't return error for write failures during updates
+			continue
 		}
+
+		// Restore any typed input
+		if inputBuffer.Len() > 0 {
+			channel.Write([]byte(inputBuffer.String()))
+		}
+
+		banner = newBanner
 	}
 }
 
@@ -755,13 +1025,6 @@ func (mh *MenuHandler) processInputEvent(event *inputEvent, inputBuffer *strings
 			return nil
 		}
 
-		// Handle sorting (placeholder for now)
-		if char == '.' || char == ',' {
-			// TODO: Change sorting method
-			channel.Write([]byte("\r\nSorting method change not yet implemented.\r\n"))
-			return nil
-		}
-
 		// For letters a-z and A-Z, handle session selection
 		if (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') {
 			var sessionIndex int
@@ -891,7 +1154,7 @@ func (mh *MenuHandler) hasIdleTimeUpdates(sessions []*gamev2.GameSession) bool {
 }
 
 // buildSpectateMenuBanner creates the formatted spectate menu display
-func (mh *MenuHandler) buildSpectateMenuBanner(sessions []*gamev2.GameSession) string {
+func (mh *MenuHandler) buildSpectateMenuBanner(sessions []*gamev2.GameSession, sortBy string) string {
 	var banner strings.Builder
 
 	banner.WriteString("The following games are in progress:\r\n\r\n")
@@ -931,16 +1194,24 @@ func (mh *MenuHandler) buildSpectateMenuBanner(sessions []*gamev2.GameSession) s
 		// Calculate idle time
 		idleTime := mh.calculateIdleTime(session)
 
-		// Spectator count
-		spectatorCount := len(session.Spectators)
+		// Spectator count, out of the game's limit
+		spectators := mh.gameClient.SpectatorsLabel(session)
+		if mh.gameClient.SessionFull(session) {
+			spectators += " (full)"
+		}
 
 		// Format the line with proper spacing
-		banner.WriteString(fmt.Sprintf(" %s) %-15s %-7s %-8s %-19s %-11s %d\r\n",
-			letter, username, gameDisplay, size, startTime, idleTime, spectatorCount))
+		banner.WriteString(fmt.Sprintf(" %s) %-15s %-7s %-8s %-19s %-11s %s\r\n",
+			letter, username, gameDisplay, size, startTime, idleTime, spectators))
+
+		// What the game's screen says of the game, under its player
+		if session.StatusLine != "" {
+			banner.WriteString(fmt.Sprintf("    %s\r\n", session.StatusLine))
+		}
 	}
 
 	// Footer with pagination info and prompt
-	banner.WriteString(fmt.Sprintf("\r\n (1-%d of %d)\r\n\r\n", len(sessions), len(sessions)))
+	banner.WriteString(fmt.Sprintf("\r\n (1-%d of %d)  Sorted by %s\r\n\r\n", len(sessions), len(sessions), WatchSortLabel(sortBy)))
 	banner.WriteString(" Spectate which game? ('?' for help) => ")
 
 	return banner.String()
@@ -14,9 +14,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
-	"github.com/dungeongate/internal/session/banner"
 	authv1 "github.com/dungeongate/pkg/api/auth/v1"
 	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/banner"
 )
 
 // MockSSHChannel implements a minimal ssh.Channel for testing
@@ -316,6 +316,8 @@ func TestShowUserMenu_AllChoices(t *testing.T) {
 		{"R", "view_recordings"},
 		{"s", "statistics"},
 		{"S", "statistics"},
+		{"n", "notifications"},
+		{"N", "notifications"},
 		{"q", "quit"},
 		{"Q", "quit"},
 	}
@@ -406,7 +408,7 @@ func TestBuildGameSelectionBanner(t *testing.T) {
 		},
 	}
 
-	banner := handler.buildGameSelectionBanner(games, "testuser")
+	banner := handler.buildGameSelectionBanner(context.Background(), games, "testuser", MenuPreferences{}, nil)
 
 	// Verify banner contains expected elements
 	assert.Contains(t, banner, "Game Selection")
@@ -421,6 +423,37 @@ func TestBuildGameSelectionBanner(t *testing.T) {
 	// Make sure empty fields don't break the banner
 	assert.NotContains(t, banner, "Description: \r\n")
 	assert.NotContains(t, banner, "Version: \r\n")
+	assert.NotContains(t, banner, "playing")
+
+	banner = handler.buildGameSelectionBanner(context.Background(), games, "testuser", MenuPreferences{}, map[string]gameActivity{"nethack": {playing: 12, watching: 30}})
+	assert.Contains(t, banner, "[1] NetHack — 12 playing, 30 watching")
+	assert.Contains(t, banner, "[2] Dungeon Crawl Stone Soup\r\n")
+
+	// Games in maintenance are greyed out with the reason
+	games[1].Status = gamev2.GameStatus_GAME_STATUS_MAINTENANCE
+	games[1].StatusMessage = "back at 18:00 UTC"
+	banner = handler.buildGameSelectionBanner(context.Background(), games, "testuser", MenuPreferences{}, nil)
+	assert.Contains(t, banner, "\033[2m  [2] Dungeon Crawl Stone Soup\r\n")
+	assert.Contains(t, banner, "Unavailable: down for maintenance (back at 18:00 UTC)\033[0m")
+
+	// Favorites are starred and Enter replays the last game
+	games[1].Status = gamev2.GameStatus_GAME_STATUS_ENABLED
+	banner = handler.buildGameSelectionBanner(context.Background(), games, "testuser", MenuPreferences{
+		FavoriteGames: []string{"dcss"},
+		RecentGames:   []string{"nethack"},
+	}, nil)
+	assert.Contains(t, banner, "[1] NetHack (last played)\r\n")
+	assert.Contains(t, banner, "[2] * Dungeon Crawl Stone Soup\r\n")
+	assert.Contains(t, banner, "Enter your choice (Enter plays NetHack): ")
+}
+
+func TestGameUnavailableReason(t *testing.T) {
+	_, unavailable := gameUnavailableReason(&gamev2.Game{Status: gamev2.GameStatus_GAME_STATUS_ENABLED})
+	assert.False(t, unavailable)
+
+	reason, unavailable := gameUnavailableReason(&gamev2.Game{Status: gamev2.GameStatus_GAME_STATUS_DEPRECATED})
+	assert.True(t, unavailable)
+	assert.Equal(t, "no longer available", reason)
 }
 
 // Benchmark tests
@@ -472,7 +505,7 @@ func TestBuildSpectateMenuBanner(t *testing.T) {
 		},
 	}
 
-	banner := handler.buildSpectateMenuBanner(sessions)
+	banner := handler.buildSpectateMenuBanner(sessions, WatchSortStarted)
 
 	// Verify banner contains expected elements
 	assert.Contains(t, banner, "The following games are in progress:")
@@ -661,7 +694,7 @@ func TestBuildSpectateMenuBannerWithManySessions(t *testing.T) {
 		}
 	}
 
-	banner := handler.buildSpectateMenuBanner(sessions)
+	banner := handler.buildSpectateMenuBanner(sessions, WatchSortStarted)
 
 	// Verify it uses a-z for first 26 sessions
 	assert.Contains(t, banner, "a) user1")
@@ -689,6 +722,6 @@ func BenchmarkBuildGameSelectionBanner(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		handler.buildGameSelectionBanner(games, "testuser")
+		handler.buildGameSelectionBanner(context.Background(), games, "testuser", MenuPreferences{}, nil)
 	}
 }
@@ -0,0 +1,291 @@
+package menu
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// User preferences changing how the menus behave. Like every preference they
+// are loaded with the user at login, as "pref."-prefixed metadata.
+const (
+	// DefaultGamePreference holds the game Enter starts from the game menu
+	DefaultGamePreference = "default_game"
+	// SkipCreditsPreference is "true" to leave without the credits
+	SkipCreditsPreference = "skip_credits"
+	// ConfirmQuitPreference is "true" to be asked before quitting
+	ConfirmQuitPreference = "confirm_quit"
+	// WatchSortPreference holds the order of the watch menu
+	WatchSortPreference = "watch_sort"
+	// AutoReconnectPreference is "true" to go back into a game left
+	// running as soon as the player logs in
+	AutoReconnectPreference = "auto_reconnect"
+	// FavoriteGamesPreference holds the games the player starred, as a
+	// comma-separated list of game IDs
+	FavoriteGamesPreference = "favorite_games"
+	// RecentGamesPreference holds the games the player last started, most
+	// recent first, as a comma-separated list of game IDs
+	RecentGamesPreference = "recent_games"
+	// FriendNotificationsPreference is "true" to be notified when a friend
+	// starts a game
+	FriendNotificationsPreference = "friend_notifications"
+)
+
+// ShowOnlineStatusSetting is "false" to be left out of the who list. It is
+// set alongside the menu preferences but kept in the user's profile, and
+// comes with the user as show_online_status metadata.
+const ShowOnlineStatusSetting = "show_online_status"
+
+// preferenceDefaults are the values of the menu preferences never set, other
+// than empty
+var preferenceDefaults = map[string]string{
+	SkipCreditsPreference:         "false",
+	ConfirmQuitPreference:         "false",
+	WatchSortPreference:           WatchSortStarted,
+	AutoReconnectPreference:       "false",
+	FriendNotificationsPreference: "false",
+}
+
+// maxRecentGames is how many games the launch history keeps
+const maxRecentGames = 10
+
+// maxGameListLength bounds the games in a favorites or history list
+const maxGameListLength = 50
+
+// gameIDPattern matches the game IDs in lists of games
+var gameIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Orders of the watch menu
+const (
+	WatchSortStarted    = "started"
+	WatchSortUsername   = "username"
+	WatchSortGame       = "game"
+	WatchSortIdle       = "idle"
+	WatchSortSpectators = "spectators"
+)
+
+// WatchSorts are the watch menu orders, as cycled with '.' and ','
+var WatchSorts = []string{WatchSortStarted, WatchSortUsername, WatchSortGame, WatchSortIdle, WatchSortSpectators}
+
+// watchSortLabels describes each watch menu order
+var watchSortLabels = map[string]string{
+	WatchSortStarted:    "start time",
+	WatchSortUsername:   "username",
+	WatchSortGame:       "game",
+	WatchSortIdle:       "idle time",
+	WatchSortSpectators: "most watched",
+}
+
+// MenuPreferences are the player's menu preferences
+type MenuPreferences struct {
+	DefaultGame   string
+	SkipCredits   bool
+	ConfirmQuit   bool
+	WatchSort     string
+	AutoReconnect bool
+	FavoriteGames []string
+	// RecentGames are the games last started, most recent first
+	RecentGames []string
+	// HideOnlineStatus leaves the player out of the who list
+	HideOnlineStatus bool
+	// FriendNotifications tells the player when their friends start a game
+	FriendNotifications bool
+}
+
+// PreferencesOf returns the menu preferences of a user; anonymous users and
+// unset or unknown values get the defaults
+func PreferencesOf(user *authv1.User) MenuPreferences {
+	prefs := MenuPreferences{WatchSort: WatchSortStarted}
+	if user == nil || user.Metadata == nil {
+		return prefs
+	}
+	pref := func(key string) string { return user.Metadata["pref."+key] }
+	prefs.DefaultGame = pref(DefaultGamePreference)
+	prefs.SkipCredits = pref(SkipCreditsPreference) == "true"
+	prefs.ConfirmQuit = pref(ConfirmQuitPreference) == "true"
+	prefs.AutoReconnect = pref(AutoReconnectPreference) == "true"
+	prefs.FavoriteGames = gameList(pref(FavoriteGamesPreference))
+	prefs.RecentGames = gameList(pref(RecentGamesPreference))
+	prefs.HideOnlineStatus = user.Metadata[ShowOnlineStatusSetting] == "false"
+	prefs.FriendNotifications = pref(FriendNotificationsPreference) == "true"
+	if sortBy := pref(WatchSortPreference); slices.Contains(WatchSorts, sortBy) {
+		prefs.WatchSort = sortBy
+	}
+	return prefs
+}
+
+// ValidateMenuPreference checks a value for one of the menu preferences. An
+// empty value clears the preference, restoring the default.
+func ValidateMenuPreference(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	switch key {
+	case DefaultGamePreference:
+		return nil
+	case SkipCreditsPreference, ConfirmQuitPreference, AutoReconnectPreference, ShowOnlineStatusSetting, FriendNotificationsPreference:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%s must be true or false", key)
+		}
+		return nil
+	case WatchSortPreference:
+		if !slices.Contains(WatchSorts, value) {
+			return fmt.Errorf("%s must be one of %s", key, strings.Join(WatchSorts, ", "))
+		}
+		return nil
+	case FavoriteGamesPreference, RecentGamesPreference:
+		games := strings.Split(value, ",")
+		if len(games) > maxGameListLength {
+			return fmt.Errorf("%s can list at most %d games", key, maxGameListLength)
+		}
+		for _, gameID := range games {
+			if !gameIDPattern.MatchString(gameID) {
+				return fmt.Errorf("%s must be a comma-separated list of game IDs", key)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown menu preference %q", key)
+	}
+}
+
+// IsDefaultPreference reports whether value is what a menu preference is
+// when never set, so saving it can clear the preference instead
+func IsDefaultPreference(key, value string) bool {
+	return value == preferenceDefaults[key]
+}
+
+// QuickLaunchGame returns the game Enter starts from the game menu: the
+// default game if the player chose one, else the game they last played
+func (p MenuPreferences) QuickLaunchGame() string {
+	if p.DefaultGame != "" {
+		return p.DefaultGame
+	}
+	if len(p.RecentGames) > 0 {
+		return p.RecentGames[0]
+	}
+	return ""
+}
+
+// LastPlayed returns the game the player last started, if any
+func (p MenuPreferences) LastPlayed() string {
+	if len(p.RecentGames) == 0 {
+		return ""
+	}
+	return p.RecentGames[0]
+}
+
+// IsFavorite reports whether the player starred a game
+func (p MenuPreferences) IsFavorite(gameID string) bool {
+	return slices.Contains(p.FavoriteGames, gameID)
+}
+
+// gameList parses a comma-separated list of game IDs
+func gameList(value string) []string {
+	var games []string
+	for _, gameID := range strings.Split(value, ",") {
+		if gameID = strings.TrimSpace(gameID); gameID != "" {
+			games = append(games, gameID)
+		}
+	}
+	return games
+}
+
+// WithRecentGame returns the launch history after a game starts: the game
+// first, then the games played before it, up to maxRecentGames
+func WithRecentGame(recent []string, gameID string) []string {
+	history := []string{gameID}
+	for _, played := range recent {
+		if played != gameID && len(history) < maxRecentGames {
+			history = append(history, played)
+		}
+	}
+	return history
+}
+
+// ToggleFavoriteGame returns the favorites with a game starred, or unstarred
+// if it already was
+func ToggleFavoriteGame(favorites []string, gameID string) []string {
+	if i := slices.Index(favorites, gameID); i >= 0 {
+		return slices.Delete(slices.Clone(favorites), i, i+1)
+	}
+	return append(slices.Clone(favorites), gameID)
+}
+
+// orderGames returns the games in the order of the game menu: the game last
+// played, the player's favorites, the other games they played recently, most
+// recent first, and then the rest as the game service lists them
+func orderGames(games []*gamev2.Game, prefs MenuPreferences) []*gamev2.Game {
+	rank := func(game *gamev2.Game) int {
+		switch i := slices.Index(prefs.RecentGames, game.Id); {
+		case i == 0:
+			return 0
+		case prefs.IsFavorite(game.Id):
+			return 1
+		case i > 0:
+			return 2 + i
+		default:
+			return 2 + maxGameListLength
+		}
+	}
+	ordered := slices.Clone(games)
+	slices.SortStableFunc(ordered, func(a, b *gamev2.Game) int {
+		return rank(a) - rank(b)
+	})
+	return ordered
+}
+
+// WatchSortLabel describes a watch menu order
+func WatchSortLabel(sortBy string) string {
+	return watchSortLabels[sortBy]
+}
+
+// nextWatchSort returns the order after sortBy, or before it going back
+func nextWatchSort(sortBy string, back bool) string {
+	step := 1
+	if back {
+		step = len(WatchSorts) - 1
+	}
+	i := slices.Index(WatchSorts, sortBy)
+	if i < 0 {
+		return WatchSortStarted
+	}
+	return WatchSorts[(i+step)%len(WatchSorts)]
+}
+
+// sortSessions orders the watch menu's sessions. Ties fall back to start
+// time so the letters stay put as the menu redraws.
+func sortSessions(sessions []*gamev2.GameSession, sortBy string) {
+	started := func(i, j int) bool {
+		return sessions[i].StartTime.AsTime().Before(sessions[j].StartTime.AsTime())
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		switch sortBy {
+		case WatchSortUsername:
+			if x, y := strings.ToLower(a.Username), strings.ToLower(b.Username); x != y {
+				return x < y
+			}
+		case WatchSortGame:
+			if a.GameId != b.GameId {
+				return a.GameId < b.GameId
+			}
+		case WatchSortIdle:
+			// Most active first
+			x, y := a.LastActivity.AsTime(), b.LastActivity.AsTime()
+			if !x.Equal(y) {
+				return x.After(y)
+			}
+		case WatchSortSpectators:
+			if len(a.Spectators) != len(b.Spectators) {
+				return len(a.Spectators) > len(b.Spectators)
+			}
+		}
+		return started(i, j)
+	})
+}
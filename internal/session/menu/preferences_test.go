@@ -0,0 +1,145 @@
+package menu
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+func TestPreferencesOf(t *testing.T) {
+	assert.Equal(t, MenuPreferences{WatchSort: WatchSortStarted}, PreferencesOf(nil))
+
+	user := &authv1.User{Metadata: map[string]string{
+		"pref.default_game":         "nethack",
+		"pref.skip_credits":         "true",
+		"pref.confirm_quit":         "false",
+		"pref.watch_sort":           "spectators",
+		"pref.auto_reconnect":       "true",
+		"pref.friend_notifications": "true",
+		"show_online_status":        "false",
+	}}
+	assert.Equal(t, MenuPreferences{
+		DefaultGame:         "nethack",
+		SkipCredits:         true,
+		WatchSort:           WatchSortSpectators,
+		AutoReconnect:       true,
+		HideOnlineStatus:    true,
+		FriendNotifications: true,
+	}, PreferencesOf(user))
+
+	// Orders this build does not know fall back to the default
+	user.Metadata["pref.watch_sort"] = "loudest"
+	assert.Equal(t, WatchSortStarted, PreferencesOf(user).WatchSort)
+}
+
+func TestValidateMenuPreference(t *testing.T) {
+	assert.NoError(t, ValidateMenuPreference(DefaultGamePreference, "nethack"))
+	assert.NoError(t, ValidateMenuPreference(ConfirmQuitPreference, "true"))
+	assert.NoError(t, ValidateMenuPreference(WatchSortPreference, WatchSortIdle))
+	assert.NoError(t, ValidateMenuPreference(AutoReconnectPreference, ""))
+	assert.NoError(t, ValidateMenuPreference(ShowOnlineStatusSetting, "false"))
+	assert.NoError(t, ValidateMenuPreference(FriendNotificationsPreference, "true"))
+
+	assert.Error(t, ValidateMenuPreference(SkipCreditsPreference, "yes"))
+	assert.Error(t, ValidateMenuPreference(WatchSortPreference, "loudest"))
+	assert.Error(t, ValidateMenuPreference("colour", "red"))
+
+	assert.NoError(t, ValidateMenuPreference(FavoriteGamesPreference, "nethack,dcss"))
+	assert.NoError(t, ValidateMenuPreference(RecentGamesPreference, "nethack-3.7"))
+	assert.Error(t, ValidateMenuPreference(FavoriteGamesPreference, "nethack,,dcss"))
+	assert.Error(t, ValidateMenuPreference(RecentGamesPreference, "net hack"))
+}
+
+func TestIsDefaultPreference(t *testing.T) {
+	assert.True(t, IsDefaultPreference(ConfirmQuitPreference, "false"))
+	assert.True(t, IsDefaultPreference(WatchSortPreference, WatchSortStarted))
+	assert.True(t, IsDefaultPreference(DefaultGamePreference, ""))
+	assert.True(t, IsDefaultPreference(FriendNotificationsPreference, "false"))
+
+	assert.False(t, IsDefaultPreference(AutoReconnectPreference, "true"))
+	assert.False(t, IsDefaultPreference(DefaultGamePreference, "nethack"))
+}
+
+func TestGameHistory(t *testing.T) {
+	user := &authv1.User{Metadata: map[string]string{
+		"pref.favorite_games": "dcss, angband",
+		"pref.recent_games":   "nethack,dcss",
+	}}
+	prefs := PreferencesOf(user)
+	assert.Equal(t, []string{"dcss", "angband"}, prefs.FavoriteGames)
+	assert.Equal(t, []string{"nethack", "dcss"}, prefs.RecentGames)
+
+	// Enter replays the last game unless the player chose a default
+	assert.Equal(t, "nethack", prefs.QuickLaunchGame())
+	prefs.DefaultGame = "angband"
+	assert.Equal(t, "angband", prefs.QuickLaunchGame())
+	assert.Empty(t, MenuPreferences{}.QuickLaunchGame())
+
+	assert.Equal(t, []string{"dcss", "nethack"}, WithRecentGame(prefs.RecentGames, "dcss"))
+	assert.Equal(t, []string{"angband", "nethack", "dcss"}, WithRecentGame(prefs.RecentGames, "angband"))
+	recent := []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}
+	assert.Equal(t, append([]string{"k"}, recent[:9]...), WithRecentGame(recent, "k"))
+
+	assert.Equal(t, []string{"angband"}, ToggleFavoriteGame(prefs.FavoriteGames, "dcss"))
+	assert.Equal(t, []string{"dcss", "angband", "nethack"}, ToggleFavoriteGame(prefs.FavoriteGames, "nethack"))
+	assert.Equal(t, []string{"dcss", "angband"}, prefs.FavoriteGames, "the stored list is not changed")
+}
+
+func TestOrderGames(t *testing.T) {
+	var games []*gamev2.Game
+	for _, id := range []string{"angband", "crawl", "dcss", "nethack", "zork"} {
+		games = append(games, &gamev2.Game{Id: id})
+	}
+	order := func(prefs MenuPreferences) []string {
+		var ids []string
+		for _, game := range orderGames(games, prefs) {
+			ids = append(ids, game.Id)
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"angband", "crawl", "dcss", "nethack", "zork"}, order(MenuPreferences{}))
+	assert.Equal(t, []string{"nethack", "zork", "angband", "crawl", "dcss"}, order(MenuPreferences{
+		RecentGames: []string{"nethack", "zork"},
+	}))
+	// Last played, then favorites, then the rest of the history
+	assert.Equal(t, []string{"nethack", "crawl", "zork", "dcss", "angband"}, order(MenuPreferences{
+		FavoriteGames: []string{"zork", "crawl"},
+		RecentGames:   []string{"nethack", "dcss", "zork"},
+	}))
+	assert.Equal(t, "angband", games[0].Id, "the game service's list is not reordered")
+}
+
+func TestNextWatchSort(t *testing.T) {
+	assert.Equal(t, WatchSortUsername, nextWatchSort(WatchSortStarted, false))
+	assert.Equal(t, WatchSortSpectators, nextWatchSort(WatchSortStarted, true))
+	assert.Equal(t, WatchSortStarted, nextWatchSort(WatchSortSpectators, false))
+}
+
+func TestSortSessions(t *testing.T) {
+	now := time.Now()
+	sessions := []*gamev2.GameSession{
+		{Id: "a", Username: "carol", GameId: "nethack", StartTime: timestamppb.New(now.Add(-3 * time.Hour)), LastActivity: timestamppb.New(now.Add(-time.Hour))},
+		{Id: "b", Username: "Alice", GameId: "dcss", StartTime: timestamppb.New(now.Add(-time.Hour)), LastActivity: timestamppb.New(now), Spectators: []*gamev2.SpectatorInfo{{}}},
+		{Id: "c", Username: "bob", GameId: "nethack", StartTime: timestamppb.New(now.Add(-2 * time.Hour)), LastActivity: timestamppb.New(now.Add(-time.Minute))},
+	}
+	order := func(sortBy string) []string {
+		sortSessions(sessions, sortBy)
+		ids := make([]string, len(sessions))
+		for i, session := range sessions {
+			ids[i] = session.Id
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"a", "c", "b"}, order(WatchSortStarted))
+	assert.Equal(t, []string{"b", "c", "a"}, order(WatchSortUsername))
+	assert.Equal(t, []string{"b", "a", "c"}, order(WatchSortGame))
+	assert.Equal(t, []string{"b", "c", "a"}, order(WatchSortIdle))
+	assert.Equal(t, []string{"b", "a", "c"}, order(WatchSortSpectators))
+}
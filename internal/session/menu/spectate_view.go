@@ -0,0 +1,153 @@
+package menu
+
+import (
+	"context"
+	"time"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+// sessionPollInterval is how often the sessions are refetched from game
+// services that cannot push updates
+const sessionPollInterval = 30 * time.Second
+
+// spectateSessionView is the watch menu's local copy of the active sessions,
+// kept current by updates pushed from the game service
+type spectateSessionView struct {
+	sessions map[string]*gamev2.GameSession
+	// syncing collects the sessions reported before SYNCED so sessions that
+	// ended before the subscription started can be dropped
+	syncing map[string]bool
+}
+
+// newSpectateSessionView creates a view seeded with an initial session list
+func newSpectateSessionView(initial []*gamev2.GameSession) *spectateSessionView {
+	v := &spectateSessionView{
+		sessions: make(map[string]*gamev2.GameSession, len(initial)),
+		syncing:  make(map[string]bool),
+	}
+	v.reset(initial)
+	return v
+}
+
+// reset replaces the view with a freshly fetched session list
+func (v *spectateSessionView) reset(sessions []*gamev2.GameSession) {
+	v.sessions = make(map[string]*gamev2.GameSession, len(sessions))
+	for _, session := range sessions {
+		v.sessions[session.Id] = session
+	}
+}
+
+// apply updates the view with a pushed session update
+func (v *spectateSessionView) apply(update *gamev2.GameSessionUpdate) {
+	switch update.Type {
+	case gamev2.SessionUpdateType_SESSION_UPDATE_ADDED,
+		gamev2.SessionUpdateType_SESSION_UPDATE_UPDATED,
+		gamev2.SessionUpdateType_SESSION_UPDATE_IDLE:
+		if update.Session == nil {
+			return
+		}
+		v.sessions[update.SessionId] = update.Session
+		if v.syncing != nil {
+			v.syncing[update.SessionId] = true
+		}
+	case gamev2.SessionUpdateType_SESSION_UPDATE_REMOVED:
+		delete(v.sessions, update.SessionId)
+	case gamev2.SessionUpdateType_SESSION_UPDATE_SYNCED:
+		if v.syncing == nil {
+			return
+		}
+		for id := range v.sessions {
+			if !v.syncing[id] {
+				delete(v.sessions, id)
+			}
+		}
+		v.syncing = nil
+	}
+}
+
+// list returns the sessions in the watch menu order sortBy
+func (v *spectateSessionView) list(sortBy string) []*gamev2.GameSession {
+	sessions := make([]*gamev2.GameSession, 0, len(v.sessions))
+	for _, session := range v.sessions {
+		sessions = append(sessions, session)
+	}
+	sortSessions(sessions, sortBy)
+	return sessions
+}
+
+// followSessions keeps a view of the active sessions, seeded with initial,
+// in step with the game service until ctx is cancelled. Updates are pushed
+// through subscribe; game services that cannot push them are polled instead.
+// After each batch of changes the sessions are sent in start order, and only
+// the latest list waits to be received.
+func (mh *MenuHandler) followSessions(
+	ctx context.Context,
+	initial []*gamev2.GameSession,
+	subscribe func(context.Context, func(*gamev2.GameSessionUpdate)) error,
+	poll func(context.Context) ([]*gamev2.GameSession, error),
+) <-chan []*gamev2.GameSession {
+	lists := make(chan []*gamev2.GameSession, 1)
+	view := newSpectateSessionView(initial)
+
+	updateChan := make(chan *gamev2.GameSessionUpdate, 64)
+	subscribeErrChan := make(chan error, 1)
+	go func() {
+		subscribeErrChan <- subscribe(ctx, func(update *gamev2.GameSessionUpdate) {
+			select {
+			case updateChan <- update:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	go func() {
+		// Only used when the game service cannot push updates
+		var pollChan <-chan time.Time
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+
+			case update := <-updateChan:
+				view.apply(update)
+				// Apply everything already queued so a burst is sent once
+			drain:
+				for {
+					select {
+					case update := <-updateChan:
+						view.apply(update)
+					default:
+						break drain
+					}
+				}
+
+			case err := <-subscribeErrChan:
+				if ctx.Err() != nil {
+					return
+				}
+				mh.logger.Debug("Session subscription unavailable, polling instead", "error", err)
+				pollTicker := time.NewTicker(sessionPollInterval)
+				defer pollTicker.Stop()
+				pollChan = pollTicker.C
+				continue
+
+			case <-pollChan:
+				sessions, err := poll(ctx)
+				if err != nil {
+					continue
+				}
+				view.reset(sessions)
+			}
+
+			// Replace a list not received yet
+			select {
+			case <-lists:
+			default:
+			}
+			lists <- view.list(WatchSortStarted)
+		}
+	}()
+	return lists
+}
@@ -0,0 +1,126 @@
+package menu
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/dungeongate/internal/session/terminal"
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"golang.org/x/crypto/ssh"
+)
+
+// GameVersionPreference returns the user preference key holding the player's
+// default version of a game
+func GameVersionPreference(gameID string) string {
+	return "game_version." + gameID
+}
+
+// PreferredVersion returns the version of a game the player starts by
+// default: their own default if it is still installed, else the server's
+func PreferredVersion(user *authv1.User, game *gamev2.Game) string {
+	if user != nil {
+		preferred := user.Metadata["pref."+GameVersionPreference(game.Id)]
+		for _, version := range game.Versions {
+			if version == preferred {
+				return preferred
+			}
+		}
+	}
+	return game.DefaultVersion
+}
+
+// showVersionPicker asks which installed version of a game to start. Enter
+// starts the preferred version; picking another offers to make it the default.
+func (mh *MenuHandler) showVersionPicker(ctx context.Context, channel ssh.Channel, inputHandler *terminal.InputHandler, game *gamev2.Game, preferred string) (*MenuChoice, error) {
+	picker := buildVersionPicker(game, preferred)
+	channel.Write([]byte(picker))
+
+	var inputBuffer strings.Builder
+	for {
+		event, err := inputHandler.ReadInput(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read user input: %w", err)
+		}
+
+		switch event.Type {
+		case terminal.EventCharacter:
+			char := event.Character
+			if char == 'q' || char == 'Q' || char == 'b' || char == 'B' {
+				return nil, nil
+			}
+			if char >= '0' && char <= '9' {
+				inputBuffer.WriteRune(char)
+				channel.Write([]byte(string(char)))
+			}
+
+		case terminal.EventKey:
+			switch event.KeyCode {
+			case terminal.KeyCtrlD:
+				return handleCtrlD(), nil
+
+			case terminal.KeyBackspace:
+				if inputBuffer.Len() > 0 {
+					str := inputBuffer.String()
+					inputBuffer.Reset()
+					inputBuffer.WriteString(str[:len(str)-1])
+					channel.Write([]byte("\b \b"))
+				}
+
+			case terminal.KeyEnter:
+				input := strings.TrimSpace(inputBuffer.String())
+				inputBuffer.Reset()
+				channel.Write([]byte("\r\n"))
+
+				if input == "" {
+					return &MenuChoice{Action: "start_game", Value: game.Id, Version: preferred}, nil
+				}
+
+				index, err := parseGameChoice(input, len(game.Versions))
+				if err != nil {
+					channel.Write([]byte(fmt.Sprintf("\r\nInvalid choice '%s'. Valid options: 1-%d, or [B]ack\r\n\r\n", input, len(game.Versions))))
+					channel.Write([]byte(picker))
+					continue
+				}
+
+				version := game.Versions[index]
+				choice := &MenuChoice{Action: "start_game", Value: game.Id, Version: version}
+				if version != preferred {
+					channel.Write([]byte(fmt.Sprintf("Make %s %s your default? [y/N] ", game.Name, version)))
+					answer, err := inputHandler.ReadInput(ctx)
+					if err != nil {
+						return nil, fmt.Errorf("failed to read user input: %w", err)
+					}
+					choice.SetDefault = answer.Type == terminal.EventCharacter && (answer.Character == 'y' || answer.Character == 'Y')
+					channel.Write([]byte("\r\n"))
+				}
+				return choice, nil
+			}
+		}
+	}
+}
+
+// buildVersionPicker renders the list of a game's installed versions
+func buildVersionPicker(game *gamev2.Game, preferred string) string {
+	picker := fmt.Sprintf("\033[2J\033[H=== %s - Choose a Version ===\r\n\r\n", game.Name)
+	for i, version := range game.Versions {
+		var notes []string
+		if version == preferred {
+			notes = append(notes, "your default")
+		}
+		if version == game.DefaultVersion && version != preferred {
+			notes = append(notes, "server default")
+		}
+		line := fmt.Sprintf("  [%d] %s %s", i+1, game.Name, version)
+		if len(notes) > 0 {
+			line += " (" + strings.Join(notes, ", ") + ")"
+		}
+		picker += line + "\r\n"
+	}
+	picker += "\r\nSaved games only continue in a compatible version.\r\n\r\n"
+	picker += fmt.Sprintf("  [Enter] Play %s\r\n", preferred)
+	picker += "  [b] Back\r\n\r\n"
+	picker += "Enter your choice: "
+	return picker
+}
@@ -0,0 +1,250 @@
+// Package realm hosts several communities on one deployment. Each realm has
+// its own accounts, games and banners. A connection belongs to the realm of
+// the port or host name it reached, or the one named in a user@realm login,
+// and otherwise to the default realm.
+//
+// Realms share one auth service: a realm's accounts are stored there under
+// its storage prefix, so "alice" in the realm "retro" is "retro_alice" to
+// the auth and game services, and her games, recordings and saves are kept
+// apart from those of the default realm's "alice".
+package realm
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
+)
+
+// Realm is one community on the deployment. A nil Realm, used when no realms
+// are configured, behaves as a default realm owning every account.
+type Realm struct {
+	// Name is empty for the default realm
+	Name string
+	// StoragePrefix starts the names of the realm's accounts in the auth service
+	StoragePrefix string
+	// Banners renders the realm's menus
+	Banners *banner.BannerManager
+
+	games    map[string]bool // Offered games; nil offers every game
+	resolver *Resolver
+}
+
+// GetName returns the realm's name, empty for the default realm
+func (r *Realm) GetName() string {
+	if r == nil {
+		return ""
+	}
+	return r.Name
+}
+
+// IsDefault reports whether r is the default realm
+func (r *Realm) IsDefault() bool {
+	return r == nil || r.Name == ""
+}
+
+// Qualify returns the name a username of the realm has in the auth service
+func (r *Realm) Qualify(username string) string {
+	if r.IsDefault() || username == "" {
+		return username
+	}
+	return r.StoragePrefix + username
+}
+
+// Local returns the name a player of the realm knows an account by,
+// without the storage prefix
+func (r *Realm) Local(username string) string {
+	if r.IsDefault() || !hasPrefixFold(username, r.StoragePrefix) {
+		return username
+	}
+	return username[len(r.StoragePrefix):]
+}
+
+// Owns reports whether an account, named as in the auth service, belongs to
+// the realm. The default realm owns the accounts no other realm does.
+func (r *Realm) Owns(username string) bool {
+	if r == nil {
+		return true
+	}
+	if r.IsDefault() {
+		return r.resolver.owner(username) == nil
+	}
+	return hasPrefixFold(username, r.StoragePrefix)
+}
+
+// AllowsGame reports whether the realm offers a game
+func (r *Realm) AllowsGame(gameID string) bool {
+	return r == nil || r.games == nil || r.games[gameID]
+}
+
+// Resolver finds the realm of each connection
+type Resolver struct {
+	def    *Realm
+	realms []*Realm
+	byName map[string]*Realm
+	byPort map[int]*Realm
+	byHost map[string]*Realm
+	ports  []int
+}
+
+// NewResolver creates the realms of a deployment from validated
+// configuration. The default realm renders its menus with banners; realms
+// configuring banners of their own render them from bannerConfig with their
+// files swapped in, and the rest share the default realm's.
+func NewResolver(configs []*config.RealmConfig, banners *banner.BannerManager, bannerConfig *banner.BannerConfig, version string) *Resolver {
+	r := &Resolver{
+		byName: make(map[string]*Realm),
+		byPort: make(map[int]*Realm),
+		byHost: make(map[string]*Realm),
+	}
+	r.def = &Realm{Banners: banners, resolver: r}
+	for _, cfg := range configs {
+		realm := &Realm{
+			Name:          cfg.Name,
+			StoragePrefix: cfg.GetStoragePrefix(),
+			Banners:       banners,
+			resolver:      r,
+		}
+		if cfg.Banners != nil {
+			realm.Banners = banner.NewBannerManager(realmBannerConfig(bannerConfig, cfg.Banners), version)
+		}
+		if len(cfg.Games) > 0 {
+			realm.games = make(map[string]bool, len(cfg.Games))
+			for _, game := range cfg.Games {
+				realm.games[game] = true
+			}
+		}
+		r.realms = append(r.realms, realm)
+		r.byName[realm.Name] = realm
+		for _, port := range cfg.Ports {
+			r.byPort[port] = realm
+			r.ports = append(r.ports, port)
+		}
+		for _, hostname := range cfg.Hostnames {
+			r.byHost[strings.ToLower(hostname)] = realm
+		}
+	}
+	return r
+}
+
+// Default returns the default realm
+func (r *Resolver) Default() *Realm {
+	if r == nil {
+		return nil
+	}
+	return r.def
+}
+
+// HasRealms reports whether any realms besides the default one are configured
+func (r *Resolver) HasRealms() bool {
+	return r != nil && len(r.realms) > 0
+}
+
+// Ports returns the SSH ports leading to realms
+func (r *Resolver) Ports() []int {
+	if r == nil {
+		return nil
+	}
+	return r.ports
+}
+
+// ForConnection returns the realm of a connection from the host name its
+// PROXY header names, if any, else the port it reached
+func (r *Resolver) ForConnection(localAddr net.Addr, authority string) *Realm {
+	if r == nil {
+		return nil
+	}
+	if realm, ok := r.byHost[strings.ToLower(authority)]; ok && authority != "" {
+		return realm
+	}
+	if tcpAddr, ok := localAddr.(*net.TCPAddr); ok {
+		if realm, ok := r.byPort[tcpAddr.Port]; ok {
+			return realm
+		}
+	}
+	return r.def
+}
+
+// ForLogin splits a login of the form user@realm into the realm it names
+// and the username. Logins naming no known realm are left whole and stay in
+// the connection's realm.
+func (r *Resolver) ForLogin(login string, connRealm *Realm) (*Realm, string) {
+	if r == nil {
+		return connRealm, login
+	}
+	at := strings.LastIndex(login, "@")
+	if at <= 0 {
+		return connRealm, login
+	}
+	if realm, ok := r.byName[strings.ToLower(login[at+1:])]; ok {
+		return realm, login[:at]
+	}
+	return connRealm, login
+}
+
+// owner returns the realm, other than the default one, owning an account
+func (r *Resolver) owner(username string) *Realm {
+	if r == nil {
+		return nil
+	}
+	for _, realm := range r.realms {
+		if hasPrefixFold(username, realm.StoragePrefix) {
+			return realm
+		}
+	}
+	return nil
+}
+
+// realmBannerConfig returns the default banner files with a realm's own in
+// their place
+func realmBannerConfig(base *banner.BannerConfig, own *config.BannersConfig) *banner.BannerConfig {
+	merged := *base
+	for _, file := range []struct {
+		dst *string
+		src string
+	}{
+		{&merged.MainAnon, own.MainAnon},
+		{&merged.MainUser, own.MainUser},
+		{&merged.MainAdmin, own.MainAdmin},
+		{&merged.WatchMenu, own.WatchMenu},
+		{&merged.ServiceUnavailable, own.ServiceUnavailable},
+		{&merged.ServerFull, own.ServerFull},
+		{&merged.Credits, own.Credits},
+	} {
+		if file.src != "" {
+			*file.dst = file.src
+		}
+	}
+	if len(own.GameNews) > 0 {
+		merged.GameNews = make(map[string]string, len(base.GameNews)+len(own.GameNews))
+		for game, path := range base.GameNews {
+			merged.GameNews[game] = path
+		}
+		for game, path := range own.GameNews {
+			merged.GameNews[game] = path
+		}
+	}
+	return &merged
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case as
+// usernames do
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+type contextKey struct{}
+
+// WithRealm returns a context carrying the realm of a connection, so the
+// menus and the calls made for it see only the realm's games and players
+func WithRealm(ctx context.Context, r *Realm) context.Context {
+	return context.WithValue(ctx, contextKey{}, r)
+}
+
+// FromContext returns the realm carried by ctx, or nil
+func FromContext(ctx context.Context) *Realm {
+	r, _ := ctx.Value(contextKey{}).(*Realm)
+	return r
+}
@@ -0,0 +1,110 @@
+package realm
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testResolver() *Resolver {
+	bannerConfig := &banner.BannerConfig{MainAnon: "default.txt", Credits: "credits.md"}
+	banners := banner.NewBannerManager(bannerConfig, "test")
+	return NewResolver([]*config.RealmConfig{
+		{
+			Name:      "retro",
+			Ports:     []int{2223},
+			Hostnames: []string{"Retro.example.org"},
+			Games:     []string{"nethack"},
+			Banners:   &config.BannersConfig{MainAnon: "retro.txt"},
+		},
+		{Name: "modern", Ports: []int{2224}},
+	}, banners, bannerConfig, "test")
+}
+
+func TestResolver_ForConnection(t *testing.T) {
+	r := testResolver()
+
+	assert.Equal(t, "retro", r.ForConnection(&net.TCPAddr{Port: 2223}, "").GetName())
+	assert.Equal(t, "modern", r.ForConnection(&net.TCPAddr{Port: 2224}, "").GetName())
+	assert.True(t, r.ForConnection(&net.TCPAddr{Port: 2222}, "").IsDefault())
+
+	// The host name from the PROXY header is more specific than the port
+	assert.Equal(t, "retro", r.ForConnection(&net.TCPAddr{Port: 2224}, "retro.example.org").GetName())
+	assert.Equal(t, "modern", r.ForConnection(&net.TCPAddr{Port: 2224}, "unknown.example.org").GetName())
+}
+
+func TestResolver_ForLogin(t *testing.T) {
+	r := testResolver()
+	def := r.Default()
+
+	loginRealm, username := r.ForLogin("alice@retro", def)
+	assert.Equal(t, "retro", loginRealm.GetName())
+	assert.Equal(t, "alice", username)
+
+	loginRealm, username = r.ForLogin("alice@nowhere", def)
+	assert.Same(t, def, loginRealm)
+	assert.Equal(t, "alice@nowhere", username)
+
+	loginRealm, username = r.ForLogin("alice", r.ForConnection(&net.TCPAddr{Port: 2224}, ""))
+	assert.Equal(t, "modern", loginRealm.GetName())
+	assert.Equal(t, "alice", username)
+}
+
+func TestRealm_Accounts(t *testing.T) {
+	r := testResolver()
+	retro := r.ForConnection(&net.TCPAddr{Port: 2223}, "")
+	def := r.Default()
+
+	assert.Equal(t, "retro_alice", retro.Qualify("alice"))
+	assert.Equal(t, "alice", retro.Local("retro_alice"))
+	assert.Equal(t, "alice", def.Qualify("alice"))
+
+	assert.True(t, retro.Owns("retro_alice"))
+	assert.True(t, retro.Owns("Retro_alice"))
+	assert.False(t, retro.Owns("alice"))
+	assert.True(t, def.Owns("alice"))
+	assert.False(t, def.Owns("retro_alice"))
+	assert.False(t, def.Owns("modern_bob"))
+
+	// Without realms everything belongs to the one community
+	var none *Realm
+	assert.True(t, none.Owns("retro_alice"))
+	assert.Equal(t, "alice", none.Qualify("alice"))
+}
+
+func TestRealm_Games(t *testing.T) {
+	r := testResolver()
+	retro := r.ForConnection(&net.TCPAddr{Port: 2223}, "")
+	modern := r.ForConnection(&net.TCPAddr{Port: 2224}, "")
+
+	assert.True(t, retro.AllowsGame("nethack"))
+	assert.False(t, retro.AllowsGame("dcss"))
+	assert.True(t, modern.AllowsGame("dcss"))
+	assert.True(t, r.Default().AllowsGame("dcss"))
+}
+
+func TestRealm_Banners(t *testing.T) {
+	r := testResolver()
+	retro := r.ForConnection(&net.TCPAddr{Port: 2223}, "")
+	modern := r.ForConnection(&net.TCPAddr{Port: 2224}, "")
+
+	require.NotNil(t, retro.Banners)
+	assert.NotSame(t, r.Default().Banners, retro.Banners)
+	assert.Same(t, r.Default().Banners, modern.Banners)
+
+	merged := realmBannerConfig(&banner.BannerConfig{MainAnon: "default.txt", Credits: "credits.md"}, &config.BannersConfig{MainAnon: "retro.txt"})
+	assert.Equal(t, "retro.txt", merged.MainAnon)
+	assert.Equal(t, "credits.md", merged.Credits)
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	retro := testResolver().ForConnection(&net.TCPAddr{Port: 2223}, "")
+	assert.Same(t, retro, FromContext(WithRealm(context.Background(), retro)))
+}
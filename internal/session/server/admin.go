@@ -0,0 +1,139 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/connection"
+	sessionv1 "github.com/dungeongate/pkg/api/session/v1"
+	"github.com/dungeongate/pkg/buildinfo"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// adminServer implements SessionAdminService on top of the session service's
+// own state. Callers prove they are admins with an access token that the auth
+// service validates.
+type adminServer struct {
+	sessionv1.UnimplementedSessionAdminServiceServer
+	blocker    *connection.IPBlocker
+	latency    *connection.LatencyTracker
+	authClient *client.AuthClient
+	build      buildinfo.Info
+	logger     *slog.Logger
+}
+
+// RegisterAdmin registers the admin service; call it before Start
+func (g *GRPCServer) RegisterAdmin(blocker *connection.IPBlocker, latency *connection.LatencyTracker, authClient *client.AuthClient, build buildinfo.Info) {
+	sessionv1.RegisterSessionAdminServiceServer(g.server, &adminServer{
+		blocker:    blocker,
+		latency:    latency,
+		authClient: authClient,
+		build:      build,
+		logger:     g.logger,
+	})
+}
+
+// Version returns the build of this session service; like /version, it
+// needs no admin token
+func (a *adminServer) Version(ctx context.Context, req *emptypb.Empty) (*sessionv1.VersionResponse, error) {
+	return &sessionv1.VersionResponse{
+		Service:   a.build.Service,
+		Version:   a.build.Version,
+		BuildTime: a.build.BuildTime,
+		GitCommit: a.build.GitCommit,
+		GoVersion: a.build.GoVersion,
+	}, nil
+}
+
+// ListIPBlocks lists the addresses blocked after repeated authentication failures
+func (a *adminServer) ListIPBlocks(ctx context.Context, req *sessionv1.ListIPBlocksRequest) (*sessionv1.ListIPBlocksResponse, error) {
+	if err := a.requireAdmin(ctx, req.AdminToken); err != nil {
+		return &sessionv1.ListIPBlocksResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	blocks := a.blocker.Blocks()
+	resp := &sessionv1.ListIPBlocksResponse{
+		Success: true,
+		Blocks:  make([]*sessionv1.IPBlock, 0, len(blocks)),
+	}
+	for _, block := range blocks {
+		resp.Blocks = append(resp.Blocks, &sessionv1.IPBlock{
+			Ip:           block.IP,
+			BlockedUntil: timestamppb.New(block.Until),
+			BlockCount:   int32(block.Blocks),
+			Reason:       block.Reason,
+		})
+	}
+	return resp, nil
+}
+
+// UnblockIP lifts the block on an address
+func (a *adminServer) UnblockIP(ctx context.Context, req *sessionv1.UnblockIPRequest) (*sessionv1.UnblockIPResponse, error) {
+	if err := a.requireAdmin(ctx, req.AdminToken); err != nil {
+		return &sessionv1.UnblockIPResponse{Success: false, Error: err.Error()}, nil
+	}
+	if req.Ip == "" {
+		return &sessionv1.UnblockIPResponse{Success: false, Error: "ip is required"}, nil
+	}
+
+	wasBlocked := a.blocker.Unblock(req.Ip)
+	a.logger.Info("Address unblocked by admin", "ip", req.Ip, "was_blocked", wasBlocked)
+	return &sessionv1.UnblockIPResponse{Success: true, WasBlocked: wasBlocked}, nil
+}
+
+// ListSessions lists the game sessions connected to this instance with their
+// echo latency, those over the SLO first
+func (a *adminServer) ListSessions(ctx context.Context, req *sessionv1.ListSessionsRequest) (*sessionv1.ListSessionsResponse, error) {
+	if err := a.requireAdmin(ctx, req.AdminToken); err != nil {
+		return &sessionv1.ListSessionsResponse{Success: false, Error: err.Error()}, nil
+	}
+
+	sessions := a.latency.Sessions()
+	resp := &sessionv1.ListSessionsResponse{
+		Success:   true,
+		Sessions:  make([]*sessionv1.GameSessionLatency, 0, len(sessions)),
+		EchoSloMs: milliseconds(a.latency.SLO()),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &sessionv1.GameSessionLatency{
+			SessionId: session.SessionID,
+			Username:  session.Username,
+			GameId:    session.GameID,
+			StartedAt: timestamppb.New(session.Started),
+			Samples:   int32(session.Samples),
+			P50Ms:     milliseconds(session.P50),
+			P95Ms:     milliseconds(session.P95),
+			MaxMs:     milliseconds(session.Max),
+			OverSlo:   session.OverSLO,
+		})
+	}
+	return resp, nil
+}
+
+// milliseconds returns d in fractional milliseconds
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// requireAdmin checks that token belongs to an admin
+func (a *adminServer) requireAdmin(ctx context.Context, token string) error {
+	if token == "" {
+		return fmt.Errorf("admin token is required")
+	}
+	resp, err := a.authClient.ValidateToken(ctx, token)
+	if err != nil {
+		a.logger.Warn("Failed to validate admin token", "error", err)
+		return fmt.Errorf("failed to validate admin token")
+	}
+	if !resp.Valid || resp.User == nil {
+		return fmt.Errorf("invalid admin token")
+	}
+	if !resp.User.IsAdmin {
+		return fmt.Errorf("admin privileges required")
+	}
+	return nil
+}
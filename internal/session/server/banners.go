@@ -0,0 +1,65 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/dungeongate/pkg/banner"
+)
+
+// watchBanners keeps the banner manager in sync with the runtime templates held
+// by the auth service, reconnecting whenever the watch stream ends
+func (s *SSHServer) watchBanners(ctx context.Context) {
+	retryInterval := s.config.IdleRetryInterval
+	if retryInterval <= 0 {
+		retryInterval = 5 * time.Second
+	}
+
+	for {
+		if err := s.followBanners(ctx, s.config.InstanceID); err != nil && ctx.Err() == nil {
+			s.logger.Debug("Banner watch interrupted, retrying", "error", err, "retry_in", retryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// followBanners applies banner updates from one watch stream until it ends
+func (s *SSHServer) followBanners(ctx context.Context, instanceID string) error {
+	stream, err := s.authClient.WatchBanners(ctx, instanceID)
+	if err != nil {
+		return err
+	}
+
+	// Headers arrive once the auth service has subscribed us. The stream then
+	// starts with every stored template, so anything reset while disconnected
+	// is dropped first.
+	if _, err := stream.Header(); err != nil {
+		return err
+	}
+	s.banners.ClearOverrides()
+
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		if !banner.IsValidName(update.Name) {
+			s.logger.Warn("Ignoring update for unknown banner", "banner", update.Name)
+			continue
+		}
+
+		if update.Cleared {
+			s.banners.ClearOverride(update.Name)
+			s.logger.Info("Banner reset to file", "banner", update.Name)
+		} else {
+			s.banners.SetOverride(update.Name, update.Content)
+			s.logger.Info("Banner template applied", "banner", update.Name, "size", len(update.Content))
+		}
+	}
+}
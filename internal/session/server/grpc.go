@@ -4,8 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
-	"net"
 
+	"github.com/dungeongate/pkg/listener"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -22,11 +22,14 @@ type GRPCServer struct {
 type GRPCConfig struct {
 	Address string
 	Port    int
+	// Listeners replaces the TCP listener when set
+	Listeners *listener.Listeners
 }
 
-// NewGRPCServer creates a new gRPC server
-func NewGRPCServer(config *GRPCConfig, logger *slog.Logger) *GRPCServer {
-	server := grpc.NewServer()
+// NewGRPCServer creates a new gRPC server with the given server options,
+// such as the shared interceptor chain
+func NewGRPCServer(config *GRPCConfig, logger *slog.Logger, opts ...grpc.ServerOption) *GRPCServer {
+	server := grpc.NewServer(opts...)
 
 	// Register health service
 	healthServer := health.NewServer()
@@ -43,15 +46,15 @@ func NewGRPCServer(config *GRPCConfig, logger *slog.Logger) *GRPCServer {
 func (g *GRPCServer) Start(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", g.config.Address, g.config.Port)
 
-	listener, err := net.Listen("tcp", addr)
+	ln, err := g.config.Listeners.Listen(listener.GRPC, addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
 
-	g.logger.Info("gRPC server starting", "address", addr)
+	g.logger.Info("gRPC server starting", "address", ln.Addr().String())
 
 	go func() {
-		if err := g.server.Serve(listener); err != nil {
+		if err := g.server.Serve(ln); err != nil {
 			g.logger.Error("gRPC server error", "error", err)
 		}
 	}()
@@ -6,8 +6,17 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"time"
 
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/connection"
+	"github.com/dungeongate/pkg/apiauth"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/listener"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/ratelimit"
 )
 
 // HTTPServer provides HTTP API for session management
@@ -22,6 +31,25 @@ type HTTPServer struct {
 type HTTPConfig struct {
 	Address string
 	Port    int
+	// RateLimits limits requests per caller when set
+	RateLimits *ratelimit.Policy
+	// Levels are the log levels changed through the admin endpoint
+	Levels *logging.Levels
+	// AdminToken is required as a bearer token by the admin endpoint, which
+	// is not served without one
+	AdminToken string
+	// Listeners replaces the TCP listener when set
+	Listeners *listener.Listeners
+	// Watchdog serves its latest sample at /watchdog when set
+	Watchdog *connection.Watchdog
+	// Downstreams' connection states are reported at /health when set
+	Downstreams *client.Pool
+	// Build is served at /version and reported at /health
+	Build buildinfo.Info
+	// ReadTimeout and WriteTimeout limit reading a request and writing a
+	// response; zero means no limit
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
 }
 
 // NewHTTPServer creates a new HTTP server
@@ -39,19 +67,33 @@ func (h *HTTPServer) Start(ctx context.Context) error {
 
 	// Register handlers
 	mux.HandleFunc("/health", h.healthHandler)
+	mux.Handle(buildinfo.Path, h.config.Build.Handler())
 	mux.HandleFunc("/stats", h.statsHandler)
 	mux.HandleFunc("/connections", h.connectionsHandler)
+	mux.HandleFunc("/watchdog", h.watchdogHandler)
+	mux.HandleFunc("/online", h.onlineHandler)
+	if h.config.AdminToken != "" {
+		mux.Handle(logging.LevelPath, apiauth.RequireServiceToken(h.config.AdminToken, logging.LevelHandler(h.config.Levels, h.logger)))
+	} else {
+		h.logger.Warn("Log level endpoint disabled: server.auth_token is not set")
+	}
 
 	addr := fmt.Sprintf("%s:%d", h.config.Address, h.config.Port)
+	ln, err := h.config.Listeners.Listen(listener.HTTP, addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
 	h.server = &http.Server{
-		Addr:    addr,
-		Handler: mux,
+		Addr:         addr,
+		Handler:      logging.RequestIDMiddleware(h.config.RateLimits.Middleware(mux)),
+		ReadTimeout:  h.config.ReadTimeout,
+		WriteTimeout: h.config.WriteTimeout,
 	}
 
-	h.logger.Info("HTTP server starting", "address", addr)
+	h.logger.Info("HTTP server starting", "address", ln.Addr().String())
 
 	go func() {
-		if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		if err := h.server.Serve(ln); err != nil && err != http.ErrServerClosed {
 			h.logger.Error("HTTP server error", "error", err)
 		}
 	}()
@@ -68,11 +110,25 @@ func (h *HTTPServer) Stop(ctx context.Context) error {
 	return nil
 }
 
-// healthHandler handles health check requests
+// healthHandler handles health check requests. The service is up, and
+// answers 200, while its downstreams are unreachable: players get the
+// service unavailable banner, and the downstreams' states say why.
 func (h *HTTPServer) healthHandler(w http.ResponseWriter, r *http.Request) {
-	response := map[string]string{
-		"status":  "healthy",
-		"service": "session-service",
+	response := map[string]interface{}{
+		"status":     "healthy",
+		"service":    "session-service",
+		"version":    h.config.Build.Version,
+		"build_time": h.config.Build.BuildTime,
+		"git_commit": h.config.Build.GitCommit,
+	}
+	if h.config.Downstreams != nil {
+		states := h.config.Downstreams.States()
+		for _, state := range states {
+			if state == connectivity.TransientFailure.String() {
+				response["status"] = "degraded"
+			}
+		}
+		response["downstreams"] = states
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -111,3 +167,43 @@ func (h *HTTPServer) connectionsHandler(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(response)
 }
+
+// onlineHandler lists the players connected to this instance and what they
+// are doing, leaving out those who hid their online status
+func (h *HTTPServer) onlineHandler(w http.ResponseWriter, r *http.Request) {
+	if h.connManager == nil {
+		http.Error(w, "Connection manager not available", http.StatusInternalServerError)
+		return
+	}
+
+	users := make([]connection.OnlineUser, 0)
+	for _, user := range h.connManager.Online() {
+		if !user.Hidden {
+			users = append(users, user)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"users": users,
+		"count": len(users),
+	})
+}
+
+// watchdogHandler returns the watchdog's latest sample of connections,
+// goroutines and open files
+func (h *HTTPServer) watchdogHandler(w http.ResponseWriter, r *http.Request) {
+	if h.config.Watchdog == nil {
+		http.Error(w, "Watchdog not available", http.StatusNotFound)
+		return
+	}
+
+	sample := h.config.Watchdog.Last()
+	if sample == nil {
+		http.Error(w, "Watchdog has not sampled yet", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(sample)
+}
@@ -0,0 +1,66 @@
+package server
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+
+	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/internal/session/connection"
+)
+
+func TestHealthHandler_Downstreams(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	pool := client.NewPool(logger, grpc.WithConnectParams(grpc.ConnectParams{
+		Backoff:           backoff.Config{BaseDelay: time.Minute, Multiplier: 1, MaxDelay: time.Minute},
+		MinConnectTimeout: time.Second,
+	}))
+	defer pool.Close()
+	gameClient, err := client.NewPooledGameClient(pool, address, logger)
+	require.NoError(t, err)
+	server := NewHTTPServer(&HTTPConfig{Downstreams: pool}, nil, logger)
+
+	health := func() map[string]interface{} {
+		recorder := httptest.NewRecorder()
+		server.healthHandler(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+		require.Equal(t, http.StatusOK, recorder.Code)
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(recorder.Body).Decode(&body))
+		return body
+	}
+
+	body := health()
+	assert.Equal(t, "healthy", body["status"])
+	assert.Equal(t, map[string]interface{}{"game": "IDLE"}, body["downstreams"])
+
+	// A dead downstream degrades the service without failing the health check
+	assert.False(t, gameClient.IsHealthy(t.Context()))
+	body = health()
+	assert.Equal(t, "degraded", body["status"])
+	assert.Equal(t, map[string]interface{}{"game": "TRANSIENT_FAILURE"}, body["downstreams"])
+}
+
+func TestOnlineHandler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := NewHTTPServer(&HTTPConfig{}, connection.NewManager(10, logger), logger)
+
+	recorder := httptest.NewRecorder()
+	server.onlineHandler(recorder, httptest.NewRequest(http.MethodGet, "/online", nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"users": [], "count": 0}`, recorder.Body.String())
+}
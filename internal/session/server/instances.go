@@ -0,0 +1,53 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/scheduler"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// InstanceReportJob is the name of the job reporting this replica's load
+const InstanceReportJob = "instance_report"
+
+// instanceReportInterval is how often the replica reports its load. The auth
+// service stops counting replicas that miss three reports.
+const instanceReportInterval = 30 * time.Second
+
+// addInstanceReport schedules reports of this replica's load to the auth
+// service's shared instance registry, so admins see the whole cluster
+func (s *SSHServer) addInstanceReport(jobs *scheduler.Scheduler) error {
+	return jobs.Add(scheduler.Job{
+		Name:     InstanceReportJob,
+		Schedule: scheduler.Every(instanceReportInterval),
+		Timeout:  5 * time.Second,
+		Run:      s.reportInstance,
+	})
+}
+
+// reportInstance reports this replica's load once
+func (s *SSHServer) reportInstance(ctx context.Context) error {
+	if err := s.authClient.ReportSessionInstance(ctx, s.instance()); err != nil {
+		return fmt.Errorf("failed to report instance load: %w", err)
+	}
+	return nil
+}
+
+// instance describes this replica's load
+func (s *SSHServer) instance() *authv1.SessionInstance {
+	stats := s.connManager.GetStats()
+	return &authv1.SessionInstance{
+		InstanceId:          s.config.InstanceID,
+		Version:             s.config.Version,
+		ConnectionsActive:   int32(stats.Active),
+		ConnectionsTotal:    int64(stats.Total),
+		ConnectionsPeak:     int32(stats.Peak),
+		MaxConnections:      int32(stats.Max),
+		GameSessions:        int32(s.latency.Count()),
+		ConnectionsRejected: int64(stats.RejectedFull + stats.RejectedRateLimited),
+		StartedAt:           timestamppb.New(s.startedAt),
+	}
+}
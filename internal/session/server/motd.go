@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/dungeongate/internal/session/connection"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
+)
+
+// motdCountTimeout bounds the request counting the games in progress
+const motdCountTimeout = 5 * time.Second
+
+// activeGameLister lists the games in progress on the game service
+type activeGameLister interface {
+	ListActiveGameSessions(ctx context.Context) ([]*gamev2.GameSession, error)
+}
+
+// motd renders the banner sent to SSH clients before they authenticate.
+// Handshakes must not wait on the game service, so the count of games in
+// progress is refreshed in the background and the last count is shown.
+type motd struct {
+	config      *config.SSHMOTDConfig
+	banners     *banner.BannerManager
+	connections *connection.Manager
+	lister      activeGameLister
+	refresh     time.Duration
+	logger      *slog.Logger
+
+	mu         sync.Mutex
+	gameCount  int
+	countedAt  time.Time
+	refreshing bool
+}
+
+// newMOTD returns the pre-authentication banner cfg configures; lister may
+// be nil, when the banner shows no games in progress
+func newMOTD(cfg *config.SSHMOTDConfig, banners *banner.BannerManager, connections *connection.Manager, lister activeGameLister, logger *slog.Logger) *motd {
+	return &motd{
+		config:      cfg,
+		banners:     banners,
+		connections: connections,
+		lister:      lister,
+		refresh:     cfg.GetRefreshInterval(),
+		logger:      logger,
+	}
+}
+
+// Banner renders the banner for a connection; it is the SSH server's
+// BannerCallback. A banner that fails to render is not sent.
+func (m *motd) Banner(ssh.ConnMetadata) string {
+	text, err := m.banners.RenderMOTD(banner.MOTDValues{
+		ServerName:  m.config.GetServerName(),
+		LegalNotice: m.config.LegalNotice,
+		Players:     m.connections.GetStats().Active,
+		Games:       m.gamesInProgress(),
+	})
+	if err != nil {
+		m.logger.Warn("Failed to render pre-authentication banner", "error", err)
+		return ""
+	}
+	return text
+}
+
+// gamesInProgress returns the last count of games in progress, starting a
+// refresh when it is older than the refresh interval
+func (m *motd) gamesInProgress() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lister != nil && !m.refreshing && time.Since(m.countedAt) >= m.refresh {
+		m.refreshing = true
+		go m.countGames()
+	}
+	return m.gameCount
+}
+
+// countGames asks the game service how many games are in progress. The last
+// count is kept when it can't answer.
+func (m *motd) countGames() {
+	ctx, cancel := context.WithTimeout(context.Background(), motdCountTimeout)
+	defer cancel()
+	sessions, err := m.lister.ListActiveGameSessions(ctx)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refreshing = false
+	m.countedAt = time.Now()
+	if err != nil {
+		m.logger.Debug("Failed to count games for the pre-authentication banner", "error", err)
+		return
+	}
+	m.gameCount = len(sessions)
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/internal/session/connection"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/config"
+)
+
+// fakeGameLister returns count sessions, or fails while err is set
+type fakeGameLister struct {
+	count atomic.Int32
+	calls atomic.Int32
+	err   error
+}
+
+func (f *fakeGameLister) ListActiveGameSessions(context.Context) ([]*gamev2.GameSession, error) {
+	f.calls.Add(1)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return make([]*gamev2.GameSession, f.count.Load()), nil
+}
+
+func TestMOTD_Banner(t *testing.T) {
+	connections := connection.NewManager(10, slog.Default())
+	client, server := net.Pipe()
+	defer client.Close()
+	defer server.Close()
+	connections.RegisterConnection(server)
+
+	banners := banner.NewBannerManager(&banner.BannerConfig{}, "1.0.0")
+	banners.SetOverride(banner.NameMOTD, "$SERVER_NAME: $PLAYERS connected, $GAMES playing\n$LEGAL_NOTICE")
+	lister := &fakeGameLister{}
+	lister.count.Store(4)
+	m := newMOTD(&config.SSHMOTDConfig{
+		Enabled:         true,
+		LegalNotice:     "Authorized use only.",
+		RefreshInterval: "1h",
+	}, banners, connections, lister, slog.Default())
+
+	// Nothing is counted before the first refresh finishes
+	assert.Equal(t, "DungeonGate: 1 connected, 0 playing\r\nAuthorized use only.\r\n", m.Banner(nil))
+	require.Eventually(t, func() bool {
+		return m.Banner(nil) == "DungeonGate: 1 connected, 4 playing\r\nAuthorized use only.\r\n"
+	}, time.Second, 10*time.Millisecond)
+
+	// The count is kept until the refresh interval passes
+	lister.count.Store(9)
+	m.Banner(nil)
+	assert.Equal(t, int32(1), lister.calls.Load())
+}
+
+func TestMOTD_GameServiceUnavailable(t *testing.T) {
+	banners := banner.NewBannerManager(&banner.BannerConfig{}, "1.0.0")
+	banners.SetOverride(banner.NameMOTD, "$GAMES")
+	lister := &fakeGameLister{err: errors.New("unavailable")}
+	m := newMOTD(&config.SSHMOTDConfig{Enabled: true}, banners, connection.NewManager(10, slog.Default()), lister, slog.Default())
+
+	assert.Equal(t, "0\r\n", m.Banner(nil))
+	require.Eventually(t, func() bool { return lister.calls.Load() == 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "0\r\n", m.Banner(nil))
+
+	// Without a game service no games are shown
+	m = newMOTD(&config.SSHMOTDConfig{Enabled: true}, banners, connection.NewManager(10, slog.Default()), nil, slog.Default())
+	assert.Equal(t, "0\r\n", m.Banner(nil))
+}
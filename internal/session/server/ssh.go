@@ -13,10 +13,18 @@ import (
 	"path/filepath"
 	"time"
 
-	"github.com/dungeongate/internal/session/banner"
 	"github.com/dungeongate/internal/session/client"
 	"github.com/dungeongate/internal/session/connection"
 	"github.com/dungeongate/internal/session/menu"
+	"github.com/dungeongate/internal/session/realm"
+	"github.com/dungeongate/pkg/banner"
+	"github.com/dungeongate/pkg/buildinfo"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/featureflags"
+	"github.com/dungeongate/pkg/listener"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/proxyproto"
+	"github.com/dungeongate/pkg/scheduler"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -24,12 +32,24 @@ import (
 type SSHServer struct {
 	config      *SSHConfig
 	listener    net.Listener
+	realmLns    []net.Listener // Listeners of the ports leading to realms
+	realms      *realm.Resolver
 	sshConfig   *ssh.ServerConfig
 	handler     *connection.Handler
 	connManager *connection.Manager
+	watchdog    *connection.Watchdog
+	jobs        *scheduler.Scheduler
 	gameClient  *client.GameClient
 	authClient  *client.AuthClient
+	banners     *banner.BannerManager
+	flags       *featureflags.Flags
+	latency     *connection.LatencyTracker
+	startedAt   time.Time
 	logger      *slog.Logger
+
+	// Builds of the auth and game services last reported, so skew is
+	// warned about once per build; only monitorServiceHealth uses it
+	dependencyBuilds map[string]buildinfo.Info
 }
 
 // SSHConfig holds SSH server configuration
@@ -38,7 +58,10 @@ type SSHConfig struct {
 	Port                     int
 	HostKey                  string
 	MaxConns                 int
-	IdleTimeout              string
+	IdleTimeout              time.Duration
+	IdleWarning              time.Duration
+	KeepaliveInterval        time.Duration
+	KeepaliveCountMax        int
 	PasswordAuth             bool
 	PublicKeyAuth            bool
 	AllowAnonymous           bool
@@ -49,8 +72,28 @@ type SSHConfig struct {
 	BannerMainAdmin          string
 	BannerWatchMenu          string
 	BannerServiceUnavailable string
+	BannerServerFull         string
+	BannerCredits            string
+	BannerGameNews           map[string]string // News files shown before games start, by game ID
 	IdleRetryInterval        time.Duration
+	WatchdogInterval         time.Duration // Zero uses the default, negative disables sampling
+	MenuErrorPause           time.Duration // How long menus show an error before moving on
+	SpectatorPrompt          bool
+	SpectatorTimeout         time.Duration            // Zero lets spectators watch idle
+	PlayStateTimeouts        map[string]time.Duration // How long players may stay in each play session state
+	SupportedTerminals       []string
+	DefaultTerminalType      string
+	InputFilter              *config.InputFilterConfig
+	ProxyProtocol            *config.ProxyProtocolConfig
+	Policy                   *config.SSHPolicyConfig
+	MOTD                     *config.SSHMOTDConfig // Templated banner sent before authentication, replacing the policy's
+	Realms                   []*config.RealmConfig // Communities hosted besides the default one
+	Listeners                *listener.Listeners   // Replaces the TCP listener when set
+	InstanceID               string                // Names the replica to the auth service and in metrics
 	Version                  string
+	Build                    buildinfo.Info // Compared with the builds of the auth and game services
+	FeatureFlags             *config.FeatureFlagsConfig
+	Jobs                     map[string]*config.JobConfig // server.jobs, overriding the schedules of background jobs
 }
 
 // NewSSHServer creates a new SSH server
@@ -65,24 +108,76 @@ func NewSSHServer(config *SSHConfig, gameClient *client.GameClient, authClient *
 		MainAdmin:          config.BannerMainAdmin,
 		WatchMenu:          config.BannerWatchMenu,
 		ServiceUnavailable: config.BannerServiceUnavailable,
+		ServerFull:         config.BannerServerFull,
+		Credits:            config.BannerCredits,
+		GameNews:           config.BannerGameNews,
+		MOTD:               config.MOTD.GetFile(),
 	}
 	bannerManager := banner.NewBannerManager(bannerConfig, config.Version)
 
 	// Create menu handler
-	menuHandler := menu.NewMenuHandler(bannerManager, gameClient, authClient, logger)
+	menuHandler := menu.NewMenuHandler(bannerManager, gameClient, authClient, logger.With(logging.ComponentKey, "menu"))
 
 	// Create auth handler (needed for environment variable handling)
 	authHandler := connection.NewSSHAuthHandler(authClient, logger, config.AllowedUsername, config.SSHPassword)
 
 	// Create connection handler
-	handler := connection.NewHandler(connManager, gameClient, authClient, menuHandler, logger, config.IdleRetryInterval, authHandler)
+	handler := connection.NewHandler(connManager, gameClient, authClient, menuHandler, logger.With(logging.ComponentKey, "ssh"), config.IdleRetryInterval, authHandler)
+	handler.SetBuild(config.Build)
+	var flagStore featureflags.Store
+	if authClient != nil {
+		flagStore = authClient
+		connManager.SetLoginCounter(authClient, config.InstanceID)
+	}
+	flags := featureflags.New(config.FeatureFlags, flagStore, logger.With(logging.ComponentKey, "featureflags"))
+	handler.SetFeatureFlags(flags)
+	handler.SetSpectatorPrompt(config.SpectatorPrompt)
+	handler.SetSpectatorTimeout(config.SpectatorTimeout, config.IdleWarning)
+	watchdog := connection.NewWatchdog(connManager, config.WatchdogInterval, logger.With(logging.ComponentKey, "watchdog"))
+	handler.SetWatchdog(watchdog)
+	handler.SetMenuErrorPause(config.MenuErrorPause)
+	handler.SetTerminalPolicy(config.SupportedTerminals, config.DefaultTerminalType)
+	handler.SetLiveness(connection.LivenessConfig{
+		IdleTimeout:       config.IdleTimeout,
+		IdleWarning:       config.IdleWarning,
+		KeepaliveInterval: config.KeepaliveInterval,
+		KeepaliveCountMax: config.KeepaliveCountMax,
+	})
+
+	inputFilters, err := connection.NewInputFilters(config.InputFilter)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input filter: %w", err)
+	}
+	handler.SetInputFilters(inputFilters)
+	if err := handler.SetPlayStateTimeouts(config.PlayStateTimeouts); err != nil {
+		return nil, fmt.Errorf("invalid play session timeouts: %w", err)
+	}
+	realms := realm.NewResolver(config.Realms, bannerManager, bannerConfig, config.Version)
+	handler.SetRealms(realms)
 
 	// Create SSH server config
 	sshConfig := &ssh.ServerConfig{
 		NoClientAuth: config.AllowAnonymous,
 	}
 
+	if err := applySSHPolicy(sshConfig, config.Policy); err != nil {
+		return nil, fmt.Errorf("invalid SSH policy: %w", err)
+	}
+	if config.MOTD != nil && config.MOTD.Enabled {
+		if config.Policy != nil && config.Policy.PreAuthBanner != "" {
+			return nil, fmt.Errorf("ssh.motd and ssh.policy.pre_auth_banner cannot both be set")
+		}
+		var lister activeGameLister
+		if gameClient != nil {
+			lister = gameClient
+		}
+		sshConfig.BannerCallback = newMOTD(config.MOTD, bannerManager, connManager, lister, logger.With(logging.ComponentKey, "motd")).Banner
+	}
+
 	// Set authentication callbacks based on configuration
+	if config.AllowAnonymous {
+		sshConfig.NoClientAuthCallback = authHandler.NoClientAuthCallback
+	}
 	if config.PasswordAuth {
 		sshConfig.PasswordCallback = authHandler.PasswordCallback
 	}
@@ -102,57 +197,200 @@ func NewSSHServer(config *SSHConfig, gameClient *client.GameClient, authClient *
 		sshConfig:   sshConfig,
 		handler:     handler,
 		connManager: connManager,
+		watchdog:    watchdog,
 		gameClient:  gameClient,
 		authClient:  authClient,
+		flags:       flags,
+		banners:     bannerManager,
+		realms:      realms,
 		logger:      logger,
 	}
 
+	// Background jobs keep to this replica's own state, so every replica
+	// runs them
+	server.jobs = scheduler.New(config.Jobs, logger)
+	for _, add := range []func(*scheduler.Scheduler) error{connManager.AddJobs, watchdog.AddJobs, server.addInstanceReport} {
+		if err := add(server.jobs); err != nil {
+			return nil, fmt.Errorf("failed to schedule background jobs: %w", err)
+		}
+	}
+
 	return server, nil
 }
 
+// SetPanicRecorder sets where panics recovered in connection goroutines are counted
+func (s *SSHServer) SetPanicRecorder(recorder connection.PanicRecorder) {
+	s.handler.SetPanicRecorder(recorder)
+}
+
+// SetLivenessRecorder sets where stale connections closed and idle warnings are counted
+func (s *SSHServer) SetLivenessRecorder(recorder connection.LivenessRecorder) {
+	s.handler.SetLivenessRecorder(recorder)
+}
+
+// SetSpectatorRecorder sets where idle spectators warned and evicted are counted
+func (s *SSHServer) SetSpectatorRecorder(recorder connection.SpectatorRecorder) {
+	s.handler.SetSpectatorRecorder(recorder)
+}
+
+// SetPlayStateRecorder sets where play session transitions and timeouts are counted
+func (s *SSHServer) SetPlayStateRecorder(recorder connection.PlayStateRecorder) {
+	s.handler.SetPlayStateRecorder(recorder)
+}
+
+// SetConnectionRecorder sets where connections refused by the connection manager are counted
+func (s *SSHServer) SetConnectionRecorder(recorder connection.ConnectionRecorder) {
+	s.connManager.SetRecorder(recorder)
+}
+
+// SetWatchdogRecorder sets where the watchdog's connection and goroutine counts are reported
+func (s *SSHServer) SetWatchdogRecorder(recorder connection.WatchdogRecorder) {
+	s.watchdog.SetRecorder(recorder)
+}
+
+// SetJobRecorder sets where runs of the background jobs are counted
+func (s *SSHServer) SetJobRecorder(recorder scheduler.Recorder) {
+	s.jobs.SetRecorder(recorder)
+}
+
+// ConnectionManager returns the manager counting the server's connections
+func (s *SSHServer) ConnectionManager() *connection.Manager {
+	return s.connManager
+}
+
+// Watchdog returns the watchdog sampling the server's connection goroutines
+func (s *SSHServer) Watchdog() *connection.Watchdog {
+	return s.watchdog
+}
+
+// SetIPBlocker sets the blocker that refuses addresses which keep failing authentication
+func (s *SSHServer) SetIPBlocker(blocker *connection.IPBlocker) {
+	s.handler.SetIPBlocker(blocker)
+}
+
+// SetGeoFilter sets the filter that tags connections with their country
+func (s *SSHServer) SetGeoFilter(geo *connection.GeoFilter) {
+	s.handler.SetGeoFilter(geo)
+}
+
+// SetLatencyTracker sets the tracker measuring the echo latency of game sessions
+func (s *SSHServer) SetLatencyTracker(tracker *connection.LatencyTracker) {
+	s.latency = tracker
+	s.handler.SetLatencyTracker(tracker)
+}
+
+// SetRegistration sets the questions and welcome screens for players who register
+func (s *SSHServer) SetRegistration(registration *config.RegistrationConfig) {
+	s.handler.SetRegistration(registration)
+}
+
 // Start starts the SSH server
 func (s *SSHServer) Start(ctx context.Context) error {
 	addr := fmt.Sprintf("%s:%d", s.config.Address, s.config.Port)
 
-	listener, err := net.Listen("tcp", addr)
+	ln, err := s.config.Listeners.Listen(listener.SSH, addr)
 	if err != nil {
 		return fmt.Errorf("failed to listen on %s: %w", addr, err)
 	}
-	s.listener = listener
 
-	s.logger.Info("SSH server starting", "address", addr)
+	if ln, err = s.proxied(ln); err != nil {
+		return err
+	}
+	s.listener = ln
+
+	s.logger.Info("SSH server starting", "address", ln.Addr().String())
+
+	// Realms reached by their own port are served alongside the default one
+	for _, port := range s.realms.Ports() {
+		addr := fmt.Sprintf("%s:%d", s.config.Address, port)
+		realmLn, err := net.Listen("tcp", addr)
+		if err != nil {
+			s.closeListeners()
+			return fmt.Errorf("failed to listen on %s: %w", addr, err)
+		}
+		if realmLn, err = s.proxied(realmLn); err != nil {
+			s.closeListeners()
+			return err
+		}
+		s.realmLns = append(s.realmLns, realmLn)
+		s.logger.Info("SSH server listening for realm", "address", realmLn.Addr().String())
+	}
 
 	// Start connection manager
 	if err := s.connManager.Start(ctx); err != nil {
 		return fmt.Errorf("failed to start connection manager: %w", err)
 	}
 
+	// Run the background jobs: forgetting idle addresses, sampling for
+	// goroutines outliving their connections and reporting this replica's
+	// load for the cluster statistics
+	s.startedAt = time.Now()
+	go s.jobs.Run(ctx)
+
 	// Start service health monitoring
 	go s.monitorServiceHealth(ctx)
 
+	// Apply banner changes made through the admin API
+	go s.watchBanners(ctx)
+
+	// Reread the feature flags set at runtime
+	go s.flags.Run(ctx)
+
 	// Accept connections
-	go s.acceptConnections(ctx)
+	go s.acceptConnections(ctx, s.listener)
+	for _, realmLn := range s.realmLns {
+		go s.acceptConnections(ctx, realmLn)
+	}
 
 	return nil
 }
 
+// proxied wraps a listener to take client addresses from a load balancer's
+// PROXY header when the PROXY protocol is enabled. The listener is closed if
+// it cannot be wrapped.
+func (s *SSHServer) proxied(ln net.Listener) (net.Listener, error) {
+	pp := s.config.ProxyProtocol
+	if pp == nil || !pp.Enabled {
+		return ln, nil
+	}
+	proxyListener, err := proxyproto.NewListener(ln, pp.TrustedProxies, pp.GetHeaderTimeoutDuration())
+	if err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to enable PROXY protocol: %w", err)
+	}
+	s.logger.Info("PROXY protocol enabled", "address", ln.Addr().String(), "trusted_proxies", pp.TrustedProxies)
+	return proxyListener, nil
+}
+
+// closeListeners closes every listener the server opened
+func (s *SSHServer) closeListeners() error {
+	var err error
+	if s.listener != nil {
+		err = s.listener.Close()
+	}
+	for _, realmLn := range s.realmLns {
+		realmLn.Close()
+	}
+	return err
+}
+
 // Stop stops the SSH server
 func (s *SSHServer) Stop(ctx context.Context) error {
 	if s.listener != nil {
 		s.logger.Info("SSH server stopping")
-		return s.listener.Close()
+		return s.closeListeners()
 	}
 	return nil
 }
 
-// acceptConnections accepts incoming SSH connections
-func (s *SSHServer) acceptConnections(ctx context.Context) {
+// acceptConnections accepts incoming SSH connections on a listener
+func (s *SSHServer) acceptConnections(ctx context.Context, ln net.Listener) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		default:
-			conn, err := s.listener.Accept()
+			conn, err := ln.Accept()
 			if err != nil {
 				s.logger.Error("Failed to accept connection", "error", err)
 				continue
@@ -179,6 +417,26 @@ func generateHostKey() (ssh.Signer, error) {
 	return signer, nil
 }
 
+// applySSHPolicy restricts the algorithms and authentication attempts the
+// server offers; a nil policy keeps the library defaults
+func applySSHPolicy(sshConfig *ssh.ServerConfig, policy *config.SSHPolicyConfig) error {
+	if policy == nil {
+		return nil
+	}
+	if err := policy.Validate(); err != nil {
+		return err
+	}
+	sshConfig.Ciphers = policy.Ciphers
+	sshConfig.MACs = policy.MACs
+	sshConfig.KeyExchanges = policy.KeyExchanges
+	sshConfig.MaxAuthTries = policy.MaxAuthTries
+	if policy.PreAuthBanner != "" {
+		text := policy.PreAuthBanner
+		sshConfig.BannerCallback = func(ssh.ConnMetadata) string { return text }
+	}
+	return nil
+}
+
 // loadOrGenerateHostKey loads an existing host key or generates a new one
 func loadOrGenerateHostKey(hostKeyPath string) (ssh.Signer, error) {
 	// If no path is specified, generate a new key
@@ -243,6 +501,7 @@ func (s *SSHServer) monitorServiceHealth(ctx context.Context) {
 
 	// Initial health check
 	s.logServiceHealth(ctx)
+	s.checkVersionSkew(ctx)
 
 	for {
 		select {
@@ -250,6 +509,7 @@ func (s *SSHServer) monitorServiceHealth(ctx context.Context) {
 			return
 		case <-ticker.C:
 			s.logServiceHealth(ctx)
+			s.checkVersionSkew(ctx)
 		}
 	}
 }
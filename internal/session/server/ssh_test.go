@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dungeongate/internal/session/client"
+	"github.com/dungeongate/pkg/config"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/ssh"
@@ -23,7 +24,7 @@ func TestNewSSHServer(t *testing.T) {
 		Port:          2222,
 		HostKey:       "",
 		MaxConns:      100,
-		IdleTimeout:   "30m",
+		IdleTimeout:   30 * time.Minute,
 		PasswordAuth:  true,
 		PublicKeyAuth: true,
 	}
@@ -63,7 +64,7 @@ func TestSSHServerStartStop(t *testing.T) {
 		Port:        0, // Use port 0 to get a random available port
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create minimal clients
@@ -105,7 +106,7 @@ func TestSSHServerStartPortInUse(t *testing.T) {
 		Port:        0, // Use port 0 to get a random available port
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create minimal clients
@@ -139,7 +140,7 @@ func TestSSHServerStartPortInUse(t *testing.T) {
 		Port:        actualPort,
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	server2, err := NewSSHServer(config2, gameClient, authClient, logger)
@@ -171,7 +172,7 @@ func TestSSHServerWithInvalidConfig(t *testing.T) {
 		Port:        99999, // Invalid port
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	server, err := NewSSHServer(config, gameClient, authClient, logger)
@@ -193,7 +194,7 @@ func TestSSHServerConnectionAcceptance(t *testing.T) {
 		Port:        0,
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create minimal clients
@@ -257,7 +258,7 @@ func TestSSHServerContextCancellation(t *testing.T) {
 		Port:        0,
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create minimal clients
@@ -299,7 +300,7 @@ func TestSSHServerMultipleStartStop(t *testing.T) {
 		Port:        0,
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create minimal clients
@@ -342,7 +343,7 @@ func TestSSHServerIntegration(t *testing.T) {
 		Port:        0,
 		HostKey:     "",
 		MaxConns:    100,
-		IdleTimeout: "30m",
+		IdleTimeout: 30 * time.Minute,
 	}
 
 	// Create clients
@@ -418,7 +419,7 @@ func TestSSHConfigurationRespected(t *testing.T) {
 			Port:           0,
 			HostKey:        "",
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  false,
 			AllowAnonymous: false,
@@ -439,7 +440,7 @@ func TestSSHConfigurationRespected(t *testing.T) {
 			Port:           0,
 			HostKey:        "",
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   false,
 			PublicKeyAuth:  true,
 			AllowAnonymous: false,
@@ -460,7 +461,7 @@ func TestSSHConfigurationRespected(t *testing.T) {
 			Port:           0,
 			HostKey:        "",
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  true,
 			AllowAnonymous: false,
@@ -481,7 +482,7 @@ func TestSSHConfigurationRespected(t *testing.T) {
 			Port:           0,
 			HostKey:        "",
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   false,
 			PublicKeyAuth:  false,
 			AllowAnonymous: true,
@@ -502,7 +503,7 @@ func TestSSHConfigurationRespected(t *testing.T) {
 			Port:           0,
 			HostKey:        "",
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   false,
 			PublicKeyAuth:  false,
 			AllowAnonymous: false,
@@ -539,7 +540,7 @@ func TestHostKeyPathHandling(t *testing.T) {
 			Port:           0,
 			HostKey:        "", // Empty path
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  false,
 			AllowAnonymous: false,
@@ -566,7 +567,7 @@ func TestHostKeyPathHandling(t *testing.T) {
 			Port:           0,
 			HostKey:        hostKeyPath,
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  false,
 			AllowAnonymous: false,
@@ -616,7 +617,7 @@ func TestHostKeyPathHandling(t *testing.T) {
 			Port:           0,
 			HostKey:        hostKeyPath,
 			MaxConns:       100,
-			IdleTimeout:    "30m",
+			IdleTimeout:    30 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  false,
 			AllowAnonymous: false,
@@ -716,7 +717,7 @@ func TestSSHServerConfigurationFields(t *testing.T) {
 			Port:           2345,
 			HostKey:        "/tmp/test_key",
 			MaxConns:       200,
-			IdleTimeout:    "45m",
+			IdleTimeout:    45 * time.Minute,
 			PasswordAuth:   true,
 			PublicKeyAuth:  true,
 			AllowAnonymous: false,
@@ -761,7 +762,7 @@ func TestSSHServerConfigurationFields(t *testing.T) {
 					Port:           0,
 					HostKey:        "",
 					MaxConns:       100,
-					IdleTimeout:    "30m",
+					IdleTimeout:    30 * time.Minute,
 					PasswordAuth:   tc.passwordAuth,
 					PublicKeyAuth:  tc.publicKeyAuth,
 					AllowAnonymous: tc.allowAnonymous,
@@ -794,3 +795,34 @@ func TestSSHServerConfigurationFields(t *testing.T) {
 		}
 	})
 }
+
+func TestApplySSHPolicy(t *testing.T) {
+	sshConfig := &ssh.ServerConfig{}
+	require.NoError(t, applySSHPolicy(sshConfig, nil))
+	assert.Nil(t, sshConfig.Ciphers)
+	assert.Nil(t, sshConfig.BannerCallback)
+
+	policy := &config.SSHPolicyConfig{
+		Ciphers:       []string{"chacha20-poly1305@openssh.com", "aes256-gcm@openssh.com"},
+		MACs:          []string{"hmac-sha2-256-etm@openssh.com"},
+		KeyExchanges:  []string{"curve25519-sha256"},
+		MaxAuthTries:  3,
+		PreAuthBanner: "Authorized use only\r\n",
+	}
+	require.NoError(t, applySSHPolicy(sshConfig, policy))
+	assert.Equal(t, policy.Ciphers, sshConfig.Ciphers)
+	assert.Equal(t, policy.MACs, sshConfig.MACs)
+	assert.Equal(t, policy.KeyExchanges, sshConfig.KeyExchanges)
+	assert.Equal(t, 3, sshConfig.MaxAuthTries)
+	require.NotNil(t, sshConfig.BannerCallback)
+	assert.Equal(t, "Authorized use only\r\n", sshConfig.BannerCallback(nil))
+
+	// Weak algorithms need to be allowed explicitly
+	weak := &config.SSHPolicyConfig{Ciphers: []string{"arcfour256"}}
+	assert.Error(t, applySSHPolicy(&ssh.ServerConfig{}, weak))
+	weak.AllowInsecure = true
+	assert.NoError(t, applySSHPolicy(&ssh.ServerConfig{}, weak))
+
+	assert.Error(t, applySSHPolicy(&ssh.ServerConfig{}, &config.SSHPolicyConfig{MACs: []string{"hmac-md5"}}))
+	assert.Error(t, applySSHPolicy(&ssh.ServerConfig{}, &config.SSHPolicyConfig{MaxAuthTries: -2}))
+}
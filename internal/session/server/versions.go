@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/pkg/buildinfo"
+)
+
+// versionQueryTimeout bounds asking a dependency for its build, so one that
+// is down does not hold up the health checks
+const versionQueryTimeout = 2 * time.Second
+
+// checkVersionSkew asks the auth and game services for their builds and
+// warns when one runs a different build than this session service, once
+// each time the build a service reports changes. Services that predate the
+// Version RPC are taken to run an older build; those that cannot be reached
+// are left to the health checks.
+func (s *SSHServer) checkVersionSkew(ctx context.Context) {
+	if s.dependencyBuilds == nil {
+		s.dependencyBuilds = make(map[string]buildinfo.Info)
+	}
+	dependencies := []struct {
+		name    string
+		version func(context.Context) (buildinfo.Info, error)
+	}{
+		{"auth_service", s.authClient.Version},
+		{"game_service", s.gameClient.Version},
+	}
+
+	for _, dependency := range dependencies {
+		queryCtx, cancel := context.WithTimeout(ctx, versionQueryTimeout)
+		build, err := dependency.version(queryCtx)
+		cancel()
+		if err != nil && status.Code(err) != codes.Unimplemented {
+			s.logger.Debug("Failed to get dependency version", "dependency", dependency.name, "error", err)
+			continue
+		}
+		if last, seen := s.dependencyBuilds[dependency.name]; seen && last == build {
+			continue
+		}
+		s.dependencyBuilds[dependency.name] = build
+
+		if !buildinfo.Skewed(s.config.Build, build) {
+			s.logger.Info("Dependency runs the same build", "dependency", dependency.name, "version", build.String())
+			continue
+		}
+		dependencyVersion := build.String()
+		if build.Version == "" {
+			dependencyVersion = "predates version reporting"
+		}
+		s.logger.Warn("Version skew between session service and dependency",
+			"dependency", dependency.name,
+			"session_version", s.config.Build.String(),
+			"dependency_version", dependencyVersion,
+			"dependency_build_time", build.BuildTime)
+	}
+}
@@ -11,7 +11,11 @@ import (
 	"github.com/dungeongate/internal/session/connection"
 	"github.com/dungeongate/internal/session/server"
 	"github.com/dungeongate/internal/session/streaming"
+	"github.com/dungeongate/pkg/interceptors"
+	"github.com/dungeongate/pkg/listener"
+	"github.com/dungeongate/pkg/logging"
 	"github.com/dungeongate/pkg/metrics"
+	"github.com/dungeongate/pkg/ratelimit"
 )
 
 // Service represents the stateless Session Service
@@ -22,13 +26,15 @@ type Service struct {
 	// Metrics
 	metricsRegistry *metrics.Registry
 
-	// Clients for external services
+	// Clients for external services, sharing the pool's connections
+	pool       *client.Pool
 	gameClient *client.GameClient
 	authClient *client.AuthClient
 
 	// Core components
 	connectionManager *connection.Manager
 	streamingManager  *streaming.Manager
+	geo               *connection.GeoFilter
 
 	// Servers
 	sshServer  *server.SSHServer
@@ -45,29 +51,45 @@ type Service struct {
 func New(cfg *Config, logger *slog.Logger, metricsRegistry *metrics.Registry) (*Service, error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Initialize service clients
-	gameClient, err := client.NewGameClient(cfg.GameService.Address, logger)
+	// Initialize service clients. Their connections are made lazily, so the
+	// service starts while the downstream services are down.
+	dialOptions := append(interceptors.WithServiceToken(cfg.ServiceToken), interceptors.WithRequestID()...)
+	dialOptions = append(dialOptions, interceptors.WithDialTimeout(cfg.GRPCDialTimeout)...)
+	dialOptions = append(dialOptions, cfg.DialOptions...)
+	pool := client.NewPool(logger, dialOptions...)
+	gameClient, err := client.NewPooledGameClient(pool, cfg.GameService.Address, logger)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("failed to create game client: %w", err)
 	}
+	gameClient.SetVersion(cfg.Version)
+	gameClient.SetMaxSpectators(cfg.MaxSpectators)
 
-	authClient, err := client.NewAuthClient(cfg.AuthService.Address, logger)
+	authClient, err := client.NewPooledAuthClient(pool, cfg.AuthService.Address, logger)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("failed to create auth client: %w", err)
 	}
 
 	// Initialize core components
-	connectionManager := connection.NewManager(cfg.MaxConnections, logger)
 	streamingManager := streaming.NewManager(logger, gameClient)
 
+	// Unix and systemd sockets replace the TCP listeners when configured
+	listeners, err := listener.New(cfg.Listen)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to set up listeners: %w", err)
+	}
+
 	// Initialize servers
 	sshConfig := &server.SSHConfig{
 		Address:                  cfg.SSH.Address,
 		Port:                     cfg.SSH.Port,
 		MaxConns:                 cfg.MaxConnections,
-		IdleTimeout:              cfg.SSH.IdleTimeout,
+		IdleTimeout:              cfg.IdleTimeout,
+		IdleWarning:              cfg.IdleWarning,
+		KeepaliveInterval:        cfg.KeepaliveInterval,
+		KeepaliveCountMax:        cfg.KeepaliveCountMax,
 		HostKey:                  cfg.SSH.HostKey,
 		PasswordAuth:             cfg.SSH.PasswordAuth,
 		PublicKeyAuth:            cfg.SSH.PublicKeyAuth,
@@ -79,35 +101,108 @@ func New(cfg *Config, logger *slog.Logger, metricsRegistry *metrics.Registry) (*
 		BannerMainAdmin:          cfg.Menu.Banners.MainAdmin,
 		BannerWatchMenu:          cfg.Menu.Banners.WatchMenu,
 		BannerServiceUnavailable: cfg.Menu.Banners.ServiceUnavailable,
+		BannerServerFull:         cfg.Menu.Banners.ServerFull,
+		BannerCredits:            cfg.Menu.Banners.Credits,
+		BannerGameNews:           cfg.Menu.Banners.GameNews,
 		IdleRetryInterval:        cfg.IdleRetryInterval,
+		WatchdogInterval:         cfg.WatchdogInterval,
+		MenuErrorPause:           cfg.MenuErrorPause,
+		SpectatorPrompt:          cfg.SpectatorPrompt,
+		SpectatorTimeout:         cfg.SpectatorTimeout,
+		PlayStateTimeouts:        cfg.PlayStateTimeouts,
+		SupportedTerminals:       cfg.SupportedTerminals,
+		DefaultTerminalType:      cfg.DefaultTerminalType,
+		InputFilter:              cfg.InputFilter,
+		ProxyProtocol:            cfg.ProxyProtocol,
+		Policy:                   cfg.SSHPolicy,
+		MOTD:                     cfg.SSHMOTD,
+		Realms:                   cfg.Realms,
+		FeatureFlags:             cfg.FeatureFlags,
+		Jobs:                     cfg.Jobs,
+		Listeners:                listeners,
+		InstanceID:               metrics.InstanceID(),
 		Version:                  cfg.Version,
+		Build:                    cfg.Build,
 	}
 	sshServer, err := server.NewSSHServer(sshConfig, gameClient, authClient, logger)
 	if err != nil {
 		cancel()
 		return nil, fmt.Errorf("failed to create SSH server: %w", err)
 	}
+	// The SSH server's manager counts the connections, so it serves the stats
+	connectionManager := sshServer.ConnectionManager()
+	blocker, err := connection.NewIPBlocker(cfg.BruteForce, logger)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create IP blocker: %w", err)
+	}
+	sshServer.SetIPBlocker(blocker)
+	geo, err := connection.NewGeoFilter(cfg.GeoIP)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create GeoIP filter: %w", err)
+	}
+	sshServer.SetGeoFilter(geo)
+	sshServer.SetRegistration(cfg.Registration)
+	latency := connection.NewLatencyTracker(cfg.EchoLatencySLO)
+	sshServer.SetLatencyTracker(latency)
+	if metricsRegistry != nil {
+		sshServer.SetPanicRecorder(metricsRegistry)
+		sshServer.SetLivenessRecorder(metricsRegistry)
+		sshServer.SetSpectatorRecorder(metricsRegistry)
+		sshServer.SetPlayStateRecorder(metricsRegistry)
+		sshServer.SetConnectionRecorder(metricsRegistry)
+		sshServer.SetWatchdogRecorder(metricsRegistry)
+		sshServer.SetJobRecorder(metricsRegistry)
+		blocker.SetRecorder(metricsRegistry)
+		geo.SetRecorder(metricsRegistry)
+		latency.SetRecorder(metricsRegistry)
+	}
+
+	rateLimits, err := ratelimit.NewPolicy(cfg.RateLimits)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create rate limits: %w", err)
+	}
 
 	httpConfig := &server.HTTPConfig{
-		Address: cfg.HTTP.Address,
-		Port:    cfg.HTTP.Port,
+		Address:     cfg.HTTP.Address,
+		Port:        cfg.HTTP.Port,
+		RateLimits:  rateLimits,
+		Levels:      logging.LevelsOf(logger),
+		AdminToken:  cfg.GRPCAuthToken,
+		Listeners:   listeners,
+		Watchdog:    sshServer.Watchdog(),
+		Downstreams: pool,
+		Build:       cfg.Build,
+
+		ReadTimeout:  cfg.HTTPReadTimeout,
+		WriteTimeout: cfg.HTTPWriteTimeout,
 	}
 	httpServer := server.NewHTTPServer(httpConfig, connectionManager, logger)
 
 	grpcConfig := &server.GRPCConfig{
-		Address: cfg.GRPC.Address,
-		Port:    cfg.GRPC.Port,
+		Address:   cfg.GRPC.Address,
+		Port:      cfg.GRPC.Port,
+		Listeners: listeners,
+	}
+	interceptorOptions := interceptors.Options{Logger: logger, AuthToken: cfg.GRPCAuthToken, RateLimits: rateLimits}
+	if metricsRegistry != nil {
+		interceptorOptions.Metrics = metricsRegistry
 	}
-	grpcServer := server.NewGRPCServer(grpcConfig, logger)
+	grpcServer := server.NewGRPCServer(grpcConfig, logger, interceptors.ServerOptions(interceptorOptions)...)
+	grpcServer.RegisterAdmin(blocker, latency, authClient, cfg.Build)
 
 	return &Service{
 		config:            cfg,
 		logger:            logger,
 		metricsRegistry:   metricsRegistry,
+		pool:              pool,
 		gameClient:        gameClient,
 		authClient:        authClient,
 		connectionManager: connectionManager,
 		streamingManager:  streamingManager,
+		geo:               geo,
 		sshServer:         sshServer,
 		httpServer:        httpServer,
 		grpcServer:        grpcServer,
@@ -120,10 +215,12 @@ func New(cfg *Config, logger *slog.Logger, metricsRegistry *metrics.Registry) (*
 func (s *Service) Start() error {
 	s.logger.Info("Starting Session Service")
 
-	// Start connection manager
-	if err := s.connectionManager.Start(s.ctx); err != nil {
-		return fmt.Errorf("failed to start connection manager: %w", err)
-	}
+	// Connect to the downstream services in the background
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.pool.Watch(s.ctx)
+	}()
 
 	// Start streaming manager
 	if err := s.streamingManager.Start(s.ctx); err != nil {
@@ -198,16 +295,16 @@ func (s *Service) Stop() error {
 	select {
 	case <-done:
 		s.logger.Info("Session Service stopped gracefully")
-	case <-time.After(30 * time.Second):
+	case <-time.After(s.config.ShutdownDrain):
 		s.logger.Warn("Session Service shutdown timeout")
 	}
 
-	// Close service clients
-	if err := s.gameClient.Close(); err != nil {
-		s.logger.Error("Error closing game client", "error", err)
+	// Close the connections to the downstream services
+	if err := s.pool.Close(); err != nil {
+		s.logger.Error("Error closing service connections", "error", err)
 	}
-	if err := s.authClient.Close(); err != nil {
-		s.logger.Error("Error closing auth client", "error", err)
+	if err := s.geo.Close(); err != nil {
+		s.logger.Error("Error closing GeoIP database", "error", err)
 	}
 
 	return nil
@@ -219,5 +316,6 @@ func (s *Service) Health() map[string]interface{} {
 		"status":      "healthy",
 		"connections": s.connectionManager.GetStats(),
 		"streaming":   s.streamingManager.GetStats(s.ctx),
+		"downstreams": s.pool.States(),
 	}
 }
@@ -166,7 +166,7 @@ func (m *Manager) AddSpectator(ctx context.Context, streamID, userID string, use
 	}
 
 	// Add spectator via Game Service
-	err = m.gameClient.AddSpectator(ctx, sessionID, userIDInt, username)
+	err = m.gameClient.AddSpectator(ctx, sessionID, userIDInt, username, false)
 	if err != nil {
 		m.logger.Error("Failed to add spectator via Game Service",
 			"spectator_id", spectatorID,
@@ -221,7 +221,7 @@ func (m *Manager) RemoveSpectator(ctx context.Context, spectatorID string) error
 	}
 
 	// Remove spectator via Game Service
-	err = m.gameClient.RemoveSpectator(ctx, sessionID, userIDInt)
+	err = m.gameClient.RemoveSpectator(ctx, sessionID, userIDInt, "")
 	if err != nil {
 		m.logger.Error("Failed to remove spectator via Game Service",
 			"spectator_id", spectatorID,
@@ -0,0 +1,314 @@
+package terminal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// DefaultEscapeKey opens the in-session menu unless a game configures another key
+const DefaultEscapeKey = "^]"
+
+// DefaultRedrawKey is the key the game service also sends to repaint a game
+const DefaultRedrawKey = "^L"
+
+// maxOSCLength bounds how much input an unterminated OSC sequence may swallow
+const maxOSCLength = 512
+
+var (
+	pasteStart = []byte("\x1b[200~")
+	pasteEnd   = []byte("\x1b[201~")
+)
+
+// FilterOptions configures an InputFilter
+type FilterOptions struct {
+	// EscapeKey opens the in-session menu, in caret notation; "none" disables it
+	EscapeKey string
+	// StripBracketedPaste removes bracketed paste markers, which games do not understand
+	StripBracketedPaste bool
+	// MaxPasteBytes drops bracketed paste content beyond this many bytes; 0 is unlimited
+	MaxPasteBytes int
+	// StripOSC removes OSC sequences (window title, clipboard, palette)
+	StripOSC bool
+	// Rewrite replaces key sequences before they reach the game, in caret
+	// notation. Rewrites apply in order, each to the output of the last.
+	Rewrite []KeyRewrite
+	// RedrawKey makes the game repaint its screen after the in-session menu; "none" disables it
+	RedrawKey string
+}
+
+// ParseKey converts a key in caret notation ("^]", "^?", "\e") into its bytes.
+// Other characters stand for themselves.
+func ParseKey(key string) ([]byte, error) {
+	if key == "" {
+		return nil, fmt.Errorf("empty key")
+	}
+
+	var out []byte
+	for i := 0; i < len(key); i++ {
+		switch {
+		case key[i] == '^' && i+1 < len(key):
+			i++
+			c := key[i]
+			switch {
+			case c == '?':
+				out = append(out, 0x7f)
+			case c >= '@' && c <= '_':
+				out = append(out, c-'@')
+			case c >= 'a' && c <= 'z':
+				out = append(out, c-'a'+1)
+			default:
+				return nil, fmt.Errorf("invalid control key ^%c in %q", c, key)
+			}
+		case key[i] == '\\' && i+1 < len(key) && key[i+1] == 'e':
+			i++
+			out = append(out, 0x1b)
+		default:
+			out = append(out, key[i])
+		}
+	}
+	return out, nil
+}
+
+// KeyName renders a single key byte in caret notation for display
+func KeyName(key byte) string {
+	switch {
+	case key == 0x7f:
+		return "^?"
+	case key < 0x20:
+		return "^" + string(rune(key+'@'))
+	default:
+		return string(rune(key))
+	}
+}
+
+// KeyRewrite replaces one key sequence, in caret notation
+type KeyRewrite struct {
+	From string
+	To   string
+}
+
+type rewrite struct {
+	from []byte
+	to   []byte
+}
+
+// InputFilter cleans a player's keystrokes before they reach a game. It
+// strips OSC sequences and bracketed paste markers, caps paste floods,
+// rewrites configured key sequences and watches for the escape key.
+// Sequences split across calls are tracked until complete.
+type InputFilter struct {
+	escapeKey  byte
+	hasEscape  bool
+	stripPaste bool
+	maxPaste   int
+	stripOSC   bool
+	rewrites   []rewrite
+	redrawKey  []byte
+
+	pending  []byte // possible start of a paste marker held from the last call
+	inPaste  bool
+	pasteLen int
+	inOSC    bool
+	oscLen   int
+}
+
+// NewInputFilter creates an input filter, validating the configured keys
+func NewInputFilter(opts FilterOptions) (*InputFilter, error) {
+	f := &InputFilter{
+		stripPaste: opts.StripBracketedPaste,
+		maxPaste:   opts.MaxPasteBytes,
+		stripOSC:   opts.StripOSC,
+	}
+
+	escapeKey := opts.EscapeKey
+	if escapeKey == "" {
+		escapeKey = DefaultEscapeKey
+	}
+	if !strings.EqualFold(escapeKey, "none") {
+		key, err := ParseKey(escapeKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid escape key: %w", err)
+		}
+		if len(key) != 1 {
+			return nil, fmt.Errorf("escape key %q must be a single key", escapeKey)
+		}
+		f.escapeKey = key[0]
+		f.hasEscape = true
+	}
+
+	for _, r := range opts.Rewrite {
+		fromBytes, err := ParseKey(r.From)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rewrite: %w", err)
+		}
+		toBytes, err := ParseKey(r.To)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rewrite of %q: %w", r.From, err)
+		}
+		f.rewrites = append(f.rewrites, rewrite{from: fromBytes, to: toBytes})
+	}
+
+	redrawKey := opts.RedrawKey
+	if redrawKey == "" {
+		redrawKey = DefaultRedrawKey
+	}
+	if !strings.EqualFold(redrawKey, "none") {
+		key, err := ParseKey(redrawKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redraw key: %w", err)
+		}
+		f.redrawKey = key
+	}
+
+	return f, nil
+}
+
+// RedrawKey returns the keys that make the game repaint its screen, if any
+func (f *InputFilter) RedrawKey() []byte {
+	return f.redrawKey
+}
+
+// EscapeKey returns the key that opens the in-session menu, if any
+func (f *InputFilter) EscapeKey() (byte, bool) {
+	return f.escapeKey, f.hasEscape
+}
+
+// Filter cleans the next chunk of input. When the escape key is found it
+// returns the input before it and escaped is true; the rest of the chunk is
+// discarded.
+func (f *InputFilter) Filter(data []byte) (out []byte, escaped bool) {
+	if len(f.pending) > 0 {
+		data = append(f.pending, data...)
+		f.pending = nil
+	}
+
+	out = make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		b := data[i]
+
+		if f.inOSC {
+			i++
+			f.oscLen++
+			if b == 0x07 || f.oscLen > maxOSCLength {
+				f.inOSC = false
+			} else if b == 0x1b && i < len(data) && data[i] == '\\' {
+				i++
+				f.inOSC = false
+			}
+			continue
+		}
+
+		if b == 0x1b {
+			rest := data[i:]
+			switch {
+			case bytes.HasPrefix(rest, pasteStart):
+				f.inPaste, f.pasteLen = true, 0
+				if !f.stripPaste {
+					out = append(out, pasteStart...)
+				}
+				i += len(pasteStart)
+				continue
+			case bytes.HasPrefix(rest, pasteEnd):
+				if f.inPaste && !f.stripPaste {
+					out = append(out, pasteEnd...)
+				}
+				f.inPaste = false
+				i += len(pasteEnd)
+				continue
+			case f.stripOSC && len(rest) > 1 && rest[1] == ']':
+				f.inOSC, f.oscLen = true, 0
+				i += 2
+				continue
+			case len(rest) >= 3 && (bytes.HasPrefix(pasteStart, rest) || bytes.HasPrefix(pasteEnd, rest)):
+				// Hold a marker split across reads; a bare ESC keypress is never held
+				f.pending = append([]byte(nil), rest...)
+				return f.rewrite(out), false
+			}
+		}
+
+		if f.hasEscape && b == f.escapeKey && !f.inPaste {
+			return f.rewrite(out), true
+		}
+
+		if f.inPaste {
+			f.pasteLen++
+			if f.maxPaste > 0 && f.pasteLen > f.maxPaste {
+				i++
+				continue
+			}
+		}
+
+		out = append(out, b)
+		i++
+	}
+
+	return f.rewrite(out), false
+}
+
+// rewrite applies the configured key rewrites in order
+func (f *InputFilter) rewrite(data []byte) []byte {
+	for _, r := range f.rewrites {
+		data = bytes.ReplaceAll(data, r.from, r.to)
+	}
+	return data
+}
+
+// OSCStripper removes OSC sequences (window title, clipboard, palette) from
+// game output so one player's output cannot retitle or write to the clipboard
+// of another's terminal. Sequences split across calls are tracked.
+type OSCStripper struct {
+	inOSC   bool
+	sawESC  bool // ESC inside a sequence, possibly starting its terminator
+	pending bool // ESC at the end of the last call outside a sequence
+}
+
+// NewOSCStripper creates a new OSC stripper
+func NewOSCStripper() *OSCStripper {
+	return &OSCStripper{}
+}
+
+// Strip removes OSC sequences from the next chunk of output
+func (s *OSCStripper) Strip(data []byte) []byte {
+	out := make([]byte, 0, len(data)+1)
+	if s.pending {
+		s.pending = false
+		if len(data) > 0 && data[0] == ']' {
+			s.inOSC = true
+			data = data[1:]
+		} else {
+			out = append(out, 0x1b)
+		}
+	}
+
+	for i := 0; i < len(data); i++ {
+		b := data[i]
+
+		if s.inOSC {
+			switch {
+			case b == 0x07:
+				s.inOSC = false
+			case s.sawESC && b == '\\':
+				s.inOSC, s.sawESC = false, false
+			default:
+				s.sawESC = b == 0x1b
+			}
+			continue
+		}
+
+		if b == 0x1b {
+			if i+1 == len(data) {
+				s.pending = true
+				break
+			}
+			if data[i+1] == ']' {
+				s.inOSC, s.sawESC = true, false
+				i++
+				continue
+			}
+		}
+		out = append(out, b)
+	}
+
+	return out
+}
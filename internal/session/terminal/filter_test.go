@@ -0,0 +1,103 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey("^]")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x1d}, key)
+
+	key, err = ParseKey("^?")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x7f}, key)
+
+	key, err = ParseKey("\\e[A")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("\x1b[A"), key)
+
+	_, err = ParseKey("^1")
+	assert.Error(t, err)
+}
+
+func TestInputFilter_EscapeKey(t *testing.T) {
+	f, err := NewInputFilter(FilterOptions{})
+	require.NoError(t, err)
+
+	out, escaped := f.Filter([]byte("ab\x1dcd"))
+	assert.True(t, escaped)
+	assert.Equal(t, []byte("ab"), out)
+
+	// A pasted escape key does not open the menu
+	out, escaped = f.Filter([]byte("\x1b[200~x\x1dy\x1b[201~"))
+	assert.False(t, escaped)
+	assert.Equal(t, []byte("\x1b[200~x\x1dy\x1b[201~"), out)
+
+	f, err = NewInputFilter(FilterOptions{EscapeKey: "none"})
+	require.NoError(t, err)
+	_, escaped = f.Filter([]byte{0x1d})
+	assert.False(t, escaped)
+
+	_, err = NewInputFilter(FilterOptions{EscapeKey: "ab"})
+	assert.Error(t, err)
+}
+
+func TestInputFilter_BracketedPaste(t *testing.T) {
+	f, err := NewInputFilter(FilterOptions{StripBracketedPaste: true, MaxPasteBytes: 4})
+	require.NoError(t, err)
+
+	out, _ := f.Filter([]byte("a\x1b[200~0123456789\x1b[201~b"))
+	assert.Equal(t, []byte("a0123b"), out)
+
+	// A marker split across reads is held back; a bare ESC is not
+	out, _ = f.Filter([]byte("c\x1b[20"))
+	assert.Equal(t, []byte("c"), out)
+	out, _ = f.Filter([]byte("0~xy\x1b[201~"))
+	assert.Equal(t, []byte("xy"), out)
+
+	out, _ = f.Filter([]byte("\x1b"))
+	assert.Equal(t, []byte("\x1b"), out)
+}
+
+func TestInputFilter_StripOSCAndRewrite(t *testing.T) {
+	f, err := NewInputFilter(FilterOptions{StripOSC: true, Rewrite: []KeyRewrite{{From: "^?", To: "^H"}}})
+	require.NoError(t, err)
+
+	out, _ := f.Filter([]byte("a\x1b]0;pwned\x07b\x1b]52;c;Zm9v\x1b\\c\x7f"))
+	assert.Equal(t, []byte("abc\x08"), out)
+
+	// Arrow keys pass through untouched
+	out, _ = f.Filter([]byte("\x1b[A"))
+	assert.Equal(t, []byte("\x1b[A"), out)
+}
+
+func TestInputFilter_RewriteOrder(t *testing.T) {
+	// Each rewrite sees the output of the one before
+	f, err := NewInputFilter(FilterOptions{Rewrite: []KeyRewrite{{From: "a", To: "b"}, {From: "b", To: "c"}}})
+	require.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		out, _ := f.Filter([]byte("ab"))
+		assert.Equal(t, []byte("cc"), out)
+	}
+
+	f, err = NewInputFilter(FilterOptions{Rewrite: []KeyRewrite{{From: "b", To: "c"}, {From: "a", To: "b"}}})
+	require.NoError(t, err)
+	out, _ := f.Filter([]byte("ab"))
+	assert.Equal(t, []byte("bc"), out)
+}
+
+func TestOSCStripper(t *testing.T) {
+	s := NewOSCStripper()
+
+	assert.Equal(t, []byte("\x1b[Hab"), s.Strip([]byte("\x1b[Ha\x1b]2;title\x07b")))
+
+	// Sequences split across chunks
+	assert.Equal(t, []byte("x"), s.Strip([]byte("x\x1b")))
+	assert.Equal(t, []byte(""), s.Strip([]byte("]0;ti")))
+	assert.Equal(t, []byte(""), s.Strip([]byte("tle\x1b")))
+	assert.Equal(t, []byte("z\x1b[m"), s.Strip([]byte("\\z\x1b[m")))
+}
@@ -0,0 +1,237 @@
+package terminal
+
+import (
+	"fmt"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// Default colours of HTML renderings, as an xterm shows them
+const (
+	htmlForeground = "#e5e5e5"
+	htmlBackground = "#000000"
+)
+
+// ansiPalette is the xterm palette of the 16 basic colours
+var ansiPalette = [16]string{
+	"#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+	"#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+}
+
+// decGraphics maps the DEC special graphics character set to Unicode
+var decGraphics = map[rune]rune{
+	'`': '◆', 'a': '▒', 'b': '␉', 'c': '␌', 'd': '␍', 'e': '␊', 'f': '°', 'g': '±',
+	'h': '␤', 'i': '␋', 'j': '┘', 'k': '┐', 'l': '┌', 'm': '└', 'n': '┼', 'o': '⎺',
+	'p': '⎻', 'q': '─', 'r': '⎼', 's': '⎽', 't': '├', 'u': '┤', 'v': '┴', 'w': '┬',
+	'x': '│', 'y': '≤', 'z': '≥', '{': 'π', '|': '≠', '}': '£', '~': '·', '_': ' ',
+}
+
+// char returns the character a cell shows, with line drawing in Unicode
+func (c cell) char() rune {
+	if c.dec {
+		if r, ok := decGraphics[c.r]; ok {
+			return r
+		}
+	}
+	return c.r
+}
+
+// lines returns the rows of the screen without their trailing default blanks
+// and without the blank rows at the bottom
+func (s *Screen) lines() [][]cell {
+	lines := make([][]cell, len(s.cells))
+	last := 0
+	for y, row := range s.cells {
+		end := len(row)
+		for end > 0 && row[end-1] == blankCell {
+			end--
+		}
+		lines[y] = row[:end]
+		if end > 0 {
+			last = y + 1
+		}
+	}
+	return lines[:last]
+}
+
+// Text returns what the screen shows as plain text, one line per row
+func (s *Screen) Text() string {
+	var b strings.Builder
+	for _, row := range s.lines() {
+		line := make([]rune, len(row))
+		for x, c := range row {
+			line[x] = c.char()
+		}
+		b.WriteString(strings.TrimRight(string(line), " "))
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Bottom returns the bottom n rows of the screen as plain text, top to
+// bottom, where games draw their status lines. Blank rows are kept.
+func (s *Screen) Bottom(n int) []string {
+	if n > len(s.cells) {
+		n = len(s.cells)
+	}
+	rows := make([]string, 0, n)
+	for _, row := range s.cells[len(s.cells)-n:] {
+		line := make([]rune, len(row))
+		for x, c := range row {
+			line[x] = c.char()
+		}
+		rows = append(rows, strings.TrimRight(string(line), " "))
+	}
+	return rows
+}
+
+// ANSI returns what the screen shows as lines of text with their colours and
+// attributes, for showing with cat on a terminal. Line drawing is in Unicode.
+func (s *Screen) ANSI() string {
+	var b strings.Builder
+	for _, row := range s.lines() {
+		attr := ""
+		for _, c := range row {
+			if c.attr != attr {
+				writeSGR(&b, c.attr)
+				attr = c.attr
+			}
+			b.WriteRune(c.char())
+		}
+		if attr != "" {
+			b.WriteString("\x1b[0m")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// HTML returns what the screen shows as a pre element, with colours and
+// attributes as inline styles, for showing in a web page
+func (s *Screen) HTML() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<pre class="dg-screen" style="background:%s;color:%s;display:inline-block;padding:0.5em">`, htmlBackground, htmlForeground)
+	for y, row := range s.cells {
+		for start := 0; start < len(row); {
+			end := start + 1
+			for end < len(row) && row[end].attr == row[start].attr {
+				end++
+			}
+			var text strings.Builder
+			for _, c := range row[start:end] {
+				text.WriteRune(c.char())
+			}
+			if style := htmlStyle(row[start].attr); style != "" {
+				fmt.Fprintf(&b, `<span style="%s">%s</span>`, style, html.EscapeString(text.String()))
+			} else {
+				b.WriteString(html.EscapeString(text.String()))
+			}
+			start = end
+		}
+		if y < len(s.cells)-1 {
+			b.WriteByte('\n')
+		}
+	}
+	b.WriteString("</pre>")
+	return b.String()
+}
+
+// htmlStyle returns the inline style of a rendition, "" for the default one
+func htmlStyle(attr string) string {
+	if attr == "" {
+		return ""
+	}
+	var a sgrState
+	a.apply(parseParams(attr))
+
+	fg, fgSet := cssColor(a.fg)
+	bg, bgSet := cssColor(a.bg)
+	if a.reverse {
+		if !fgSet {
+			fg = htmlForeground
+		}
+		if !bgSet {
+			bg = htmlBackground
+		}
+		fg, bg = bg, fg
+		fgSet, bgSet = true, true
+	}
+	if a.hidden {
+		fg, fgSet = bg, true
+		if !bgSet {
+			fg = htmlBackground
+		}
+	}
+
+	var styles []string
+	if fgSet {
+		styles = append(styles, "color:"+fg)
+	}
+	if bgSet {
+		styles = append(styles, "background:"+bg)
+	}
+	if a.bold {
+		styles = append(styles, "font-weight:bold")
+	}
+	if a.dim {
+		styles = append(styles, "opacity:0.6")
+	}
+	if a.italic {
+		styles = append(styles, "font-style:italic")
+	}
+	if a.underline {
+		styles = append(styles, "text-decoration:underline")
+	}
+	return strings.Join(styles, ";")
+}
+
+// cssColor converts a colour of the rendition to CSS: a basic colour as its
+// SGR parameter, or a 256-colour or true colour specification
+func cssColor(color string) (string, bool) {
+	if color == "" {
+		return "", false
+	}
+	if strings.HasPrefix(color, "8;") {
+		args := parseParams(color[2:])
+		switch {
+		case len(args) == 2 && args[0] == 5:
+			return palette256(args[1]), true
+		case len(args) == 4 && args[0] == 2:
+			return fmt.Sprintf("#%02x%02x%02x", clamp(args[1], 0, 255), clamp(args[2], 0, 255), clamp(args[3], 0, 255)), true
+		}
+		return "", false
+	}
+
+	n, err := strconv.Atoi(color)
+	if err != nil {
+		return "", false
+	}
+	switch {
+	case n >= 30 && n <= 37:
+		return ansiPalette[n-30], true
+	case n >= 40 && n <= 47:
+		return ansiPalette[n-40], true
+	case n >= 90 && n <= 97:
+		return ansiPalette[n-90+8], true
+	case n >= 100 && n <= 107:
+		return ansiPalette[n-100+8], true
+	}
+	return "", false
+}
+
+// palette256 returns a colour of the xterm 256-colour palette
+func palette256(n int) string {
+	n = clamp(n, 0, 255)
+	switch {
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		levels := [6]int{0, 95, 135, 175, 215, 255}
+		n -= 16
+		return fmt.Sprintf("#%02x%02x%02x", levels[n/36], levels[n/6%6], levels[n%6])
+	default:
+		gray := 8 + 10*(n-232)
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
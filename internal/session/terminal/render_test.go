@@ -0,0 +1,50 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScreen_Text(t *testing.T) {
+	s := NewScreen(20, 5)
+	s.Write([]byte("\x1b[1;31mDlvl:1\x1b[0m $:0\r\n"))
+	s.Write([]byte("\x1b(0lqk\x1b(B@"))
+
+	assert.Equal(t, "Dlvl:1 $:0\n┌─┐@\n", s.Text())
+	assert.Equal(t, "\x1b[0;1;31mDlvl:1\x1b[0m $:0\n┌─┐@\n", s.ANSI())
+}
+
+func TestScreen_Bottom(t *testing.T) {
+	s := NewScreen(30, 4)
+	s.Write([]byte("\x1b[3;1HAgent the Stripling\x1b[4;1H\x1b[33mDlvl:1 $:0 HP:16(16)\x1b[0m"))
+
+	assert.Equal(t, []string{"", "Agent the Stripling", "Dlvl:1 $:0 HP:16(16)"}, s.Bottom(3))
+	assert.Len(t, s.Bottom(10), 4)
+}
+
+func TestScreen_HTML(t *testing.T) {
+	s := NewScreen(6, 2)
+	s.Write([]byte("\x1b[31m<@>\x1b[0m&\r\n\x1b[7;38;5;196mx"))
+
+	assert.Equal(t, `<pre class="dg-screen" style="background:#000000;color:#e5e5e5;display:inline-block;padding:0.5em">`+
+		`<span style="color:#cd0000">&lt;@&gt;</span>&amp;  `+"\n"+
+		`<span style="color:#000000;background:#ff0000">x</span>     </pre>`, s.HTML())
+}
+
+func TestCSSColor(t *testing.T) {
+	for color, want := range map[string]string{
+		"32":          "#00cd00",
+		"94":          "#5c5cff",
+		"8;5;16":      "#000000",
+		"8;5;231":     "#ffffff",
+		"8;5;244":     "#808080",
+		"8;2;1;2;255": "#0102ff",
+	} {
+		got, ok := cssColor(color)
+		assert.True(t, ok, color)
+		assert.Equal(t, want, got, color)
+	}
+	_, ok := cssColor("")
+	assert.False(t, ok)
+}
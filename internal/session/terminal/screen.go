@@ -0,0 +1,691 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// cell is one character position on the screen
+type cell struct {
+	r    rune
+	attr string // SGR parameters in effect, "" for the default rendition
+	dec  bool   // drawn with the DEC special graphics character set
+}
+
+var blankCell = cell{r: ' '}
+
+// maxPendingSequence bounds how long an unterminated escape sequence is held
+const maxPendingSequence = 4096
+
+// Screen is a minimal VT100/xterm emulator that tracks what a game has drawn
+// so the screen can be repainted after something else was shown over it. It
+// understands cursor movement, erasing, scrolling regions, insert and delete,
+// SGR attributes and the DEC line drawing character set, which covers what
+// curses-based games emit. Screen is not safe for concurrent use.
+type Screen struct {
+	cols, rows int
+	cells      [][]cell
+
+	x, y        int
+	wrapPending bool
+	attr        sgrState
+	top, bottom int // scrolling region, inclusive
+
+	savedX, savedY int
+	savedAttr      sgrState
+
+	// Character sets: G0 and G1 are true when designated DEC graphics
+	g0DEC, g1DEC bool
+	shifted      bool // SO selected G1
+
+	pending []byte // incomplete escape or UTF-8 sequence from the last write
+}
+
+// NewScreen creates a blank screen of the given size
+func NewScreen(cols, rows int) *Screen {
+	s := &Screen{}
+	s.Resize(cols, rows)
+	return s
+}
+
+// Size returns the screen's columns and rows
+func (s *Screen) Size() (int, int) {
+	return s.cols, s.rows
+}
+
+// Resize changes the screen size, keeping the top-left content
+func (s *Screen) Resize(cols, rows int) {
+	if cols <= 0 {
+		cols = 80
+	}
+	if rows <= 0 {
+		rows = 24
+	}
+
+	cells := make([][]cell, rows)
+	for y := range cells {
+		cells[y] = make([]cell, cols)
+		for x := range cells[y] {
+			if y < len(s.cells) && x < len(s.cells[y]) {
+				cells[y][x] = s.cells[y][x]
+			} else {
+				cells[y][x] = blankCell
+			}
+		}
+	}
+
+	s.cols, s.rows, s.cells = cols, rows, cells
+	s.top, s.bottom = 0, rows-1
+	s.x, s.y = clamp(s.x, 0, cols-1), clamp(s.y, 0, rows-1)
+	s.wrapPending = false
+}
+
+// Write feeds terminal output into the screen
+func (s *Screen) Write(data []byte) (int, error) {
+	n := len(data)
+	if len(s.pending) > 0 {
+		data = append(s.pending, data...)
+		s.pending = nil
+	}
+
+	for i := 0; i < len(data); {
+		b := data[i]
+		switch {
+		case b == 0x1b:
+			consumed, complete := s.escape(data[i:])
+			if !complete {
+				if len(data)-i > maxPendingSequence {
+					// Never terminated; drop the ESC and draw the rest
+					i++
+					continue
+				}
+				s.pending = append([]byte(nil), data[i:]...)
+				return n, nil
+			}
+			i += consumed
+		case b < 0x20 || b == 0x7f:
+			s.control(b)
+			i++
+		case b < utf8.RuneSelf:
+			s.put(rune(b))
+			i++
+		default:
+			if !utf8.FullRune(data[i:]) {
+				s.pending = append([]byte(nil), data[i:]...)
+				return n, nil
+			}
+			r, size := utf8.DecodeRune(data[i:])
+			s.put(r)
+			i += size
+		}
+	}
+	return n, nil
+}
+
+// Snapshot returns the bytes that repaint the screen on a terminal, leaving
+// the cursor, attributes and character set as the game last set them
+func (s *Screen) Snapshot() []byte {
+	var b strings.Builder
+	b.WriteString("\x1b[0m\x1b(B\x0f\x1b[r\x1b[2J")
+
+	attr, dec := "", false
+	for y, row := range s.cells {
+		// Trailing default blanks are already clear
+		end := len(row)
+		for end > 0 && row[end-1] == blankCell {
+			end--
+		}
+		if end == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&b, "\x1b[%d;1H", y+1)
+		for _, c := range row[:end] {
+			if c.attr != attr {
+				writeSGR(&b, c.attr)
+				attr = c.attr
+			}
+			if c.dec != dec {
+				if c.dec {
+					b.WriteString("\x1b(0")
+				} else {
+					b.WriteString("\x1b(B")
+				}
+				dec = c.dec
+			}
+			b.WriteRune(c.r)
+		}
+	}
+
+	// Restore the game's own state
+	if s.top != 0 || s.bottom != s.rows-1 {
+		fmt.Fprintf(&b, "\x1b[%d;%dr", s.top+1, s.bottom+1)
+	}
+	writeSGR(&b, s.attr.String())
+	if s.g0DEC {
+		b.WriteString("\x1b(0")
+	} else {
+		b.WriteString("\x1b(B")
+	}
+	if s.g1DEC {
+		b.WriteString("\x1b)0")
+	}
+	if s.shifted {
+		b.WriteString("\x0e")
+	}
+	fmt.Fprintf(&b, "\x1b[%d;%dH", s.y+1, s.x+1)
+
+	return []byte(b.String())
+}
+
+// View returns the bytes that paint the screen on a terminal of a different
+// size. Each axis the terminal is smaller on is cropped to a window that keeps
+// the cursor in view, and each axis it is larger on is centred with blank
+// margins. Every row of the terminal is repainted, so nothing the game drew
+// at the wrong size is left behind.
+func (s *Screen) View(cols, rows int) []byte {
+	ox, mx := viewOffset(s.x, s.cols, cols)
+	oy, my := viewOffset(s.y, s.rows, rows)
+
+	var b strings.Builder
+	b.WriteString("\x1b[0m\x1b(B\x0f\x1b[r")
+
+	for ty := 0; ty < rows; ty++ {
+		fmt.Fprintf(&b, "\x1b[%d;1H\x1b[K", ty+1)
+		y := ty - my + oy
+		if y < 0 || y >= s.rows {
+			continue
+		}
+
+		row := s.cells[y]
+		start, end := ox, min(s.cols, ox+cols-mx)
+		for end > start && row[end-1] == blankCell {
+			end--
+		}
+		if mx > 0 {
+			fmt.Fprintf(&b, "\x1b[%dC", mx)
+		}
+
+		attr, dec := "", false
+		for _, c := range row[start:end] {
+			if c.attr != attr {
+				writeSGR(&b, c.attr)
+				attr = c.attr
+			}
+			if c.dec != dec {
+				if c.dec {
+					b.WriteString("\x1b(0")
+				} else {
+					b.WriteString("\x1b(B")
+				}
+				dec = c.dec
+			}
+			b.WriteRune(c.r)
+		}
+		if attr != "" {
+			b.WriteString("\x1b[0m")
+		}
+		if dec {
+			b.WriteString("\x1b(B")
+		}
+	}
+
+	fmt.Fprintf(&b, "\x1b[%d;%dH", s.y-oy+my+1, s.x-ox+mx+1)
+	return []byte(b.String())
+}
+
+// viewOffset places a screen dimension of size within a view dimension of
+// view. It returns the first screen position shown, which keeps pos in view
+// when the view is smaller, and the margin before it when the view is larger.
+func viewOffset(pos, size, view int) (offset, margin int) {
+	if view >= size {
+		return 0, (view - size) / 2
+	}
+	if pos >= view {
+		return pos - view + 1, 0
+	}
+	return 0, 0
+}
+
+// writeSGR selects a rendition from the default one
+func writeSGR(b *strings.Builder, attr string) {
+	if attr == "" {
+		b.WriteString("\x1b[0m")
+		return
+	}
+	b.WriteString("\x1b[0;")
+	b.WriteString(attr)
+	b.WriteString("m")
+}
+
+// control handles a C0 control character
+func (s *Screen) control(b byte) {
+	switch b {
+	case '\b':
+		if s.x > 0 {
+			s.x--
+		}
+		s.wrapPending = false
+	case '\t':
+		s.x = clamp((s.x/8+1)*8, 0, s.cols-1)
+		s.wrapPending = false
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\r':
+		s.x = 0
+		s.wrapPending = false
+	case 0x0e: // SO
+		s.shifted = true
+	case 0x0f: // SI
+		s.shifted = false
+	}
+}
+
+// put draws a printable character at the cursor
+func (s *Screen) put(r rune) {
+	if s.wrapPending {
+		s.x = 0
+		s.lineFeed()
+	}
+
+	dec := s.g0DEC
+	if s.shifted {
+		dec = s.g1DEC
+	}
+	s.cells[s.y][s.x] = cell{r: r, attr: s.attr.String(), dec: dec}
+
+	if s.x == s.cols-1 {
+		s.wrapPending = true
+	} else {
+		s.x++
+	}
+}
+
+// lineFeed moves the cursor down, scrolling at the bottom of the region
+func (s *Screen) lineFeed() {
+	s.wrapPending = false
+	if s.y == s.bottom {
+		s.scrollUp(1)
+	} else if s.y < s.rows-1 {
+		s.y++
+	}
+}
+
+// scrollUp scrolls the region up n lines
+func (s *Screen) scrollUp(n int) {
+	for ; n > 0; n-- {
+		copy(s.cells[s.top:s.bottom], s.cells[s.top+1:s.bottom+1])
+		s.cells[s.bottom] = s.blankRow()
+	}
+}
+
+// scrollDown scrolls the region down n lines
+func (s *Screen) scrollDown(n int) {
+	for ; n > 0; n-- {
+		copy(s.cells[s.top+1:s.bottom+1], s.cells[s.top:s.bottom])
+		s.cells[s.top] = s.blankRow()
+	}
+}
+
+// blankRow returns an erased row in the current background
+func (s *Screen) blankRow() []cell {
+	row := make([]cell, s.cols)
+	for x := range row {
+		row[x] = s.blank()
+	}
+	return row
+}
+
+// blank is an erased cell; erasing keeps the current background colour
+func (s *Screen) blank() cell {
+	if s.attr.bg == "" {
+		return blankCell
+	}
+	return cell{r: ' ', attr: colorParam(s.attr.bg, "4")}
+}
+
+// escape handles an escape sequence at the start of data, returning how many
+// bytes it used or false when the sequence is incomplete
+func (s *Screen) escape(data []byte) (int, bool) {
+	if len(data) < 2 {
+		return 0, false
+	}
+
+	switch data[1] {
+	case '[':
+		for i := 2; i < len(data); i++ {
+			if data[i] >= 0x40 && data[i] <= 0x7e {
+				s.csi(string(data[2:i]), data[i])
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case ']', 'P', '_', '^':
+		// OSC, DCS, APC and PM strings change nothing on screen
+		for i := 2; i < len(data); i++ {
+			if data[i] == 0x07 {
+				return i + 1, true
+			}
+			if data[i] == 0x1b && i+1 < len(data) && data[i+1] == '\\' {
+				return i + 2, true
+			}
+		}
+		return 0, false
+	case '(', ')', '*', '+':
+		if len(data) < 3 {
+			return 0, false
+		}
+		dec := data[2] == '0'
+		switch data[1] {
+		case '(':
+			s.g0DEC = dec
+		case ')':
+			s.g1DEC = dec
+		}
+		return 3, true
+	case '#', '%', ' ':
+		if len(data) < 3 {
+			return 0, false
+		}
+		return 3, true
+	case '7':
+		s.saveCursor()
+	case '8':
+		s.restoreCursor()
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.x = 0
+		s.lineFeed()
+	case 'M':
+		s.wrapPending = false
+		if s.y == s.top {
+			s.scrollDown(1)
+		} else if s.y > 0 {
+			s.y--
+		}
+	case 'c':
+		*s = *NewScreen(s.cols, s.rows)
+	}
+	return 2, true
+}
+
+// csi handles a control sequence
+func (s *Screen) csi(params string, final byte) {
+	private := strings.HasPrefix(params, "?") || strings.HasPrefix(params, ">")
+	args := parseParams(strings.TrimLeft(params, "?>="))
+	arg := func(i, def int) int {
+		if i < len(args) && args[i] > 0 {
+			return args[i]
+		}
+		return def
+	}
+
+	s.wrapPending = false
+	switch final {
+	case 'A':
+		s.y = clamp(s.y-arg(0, 1), 0, s.rows-1)
+	case 'B', 'e':
+		s.y = clamp(s.y+arg(0, 1), 0, s.rows-1)
+	case 'C', 'a':
+		s.x = clamp(s.x+arg(0, 1), 0, s.cols-1)
+	case 'D':
+		s.x = clamp(s.x-arg(0, 1), 0, s.cols-1)
+	case 'E':
+		s.x, s.y = 0, clamp(s.y+arg(0, 1), 0, s.rows-1)
+	case 'F':
+		s.x, s.y = 0, clamp(s.y-arg(0, 1), 0, s.rows-1)
+	case 'G', '`':
+		s.x = clamp(arg(0, 1)-1, 0, s.cols-1)
+	case 'd':
+		s.y = clamp(arg(0, 1)-1, 0, s.rows-1)
+	case 'H', 'f':
+		s.y = clamp(arg(0, 1)-1, 0, s.rows-1)
+		s.x = clamp(arg(1, 1)-1, 0, s.cols-1)
+	case 'J':
+		s.eraseDisplay(arg(0, 0))
+	case 'K':
+		s.eraseLine(arg(0, 0))
+	case 'L':
+		if s.y >= s.top && s.y <= s.bottom {
+			top := s.top
+			s.top = s.y
+			s.scrollDown(arg(0, 1))
+			s.top = top
+		}
+	case 'M':
+		if s.y >= s.top && s.y <= s.bottom {
+			top := s.top
+			s.top = s.y
+			s.scrollUp(arg(0, 1))
+			s.top = top
+		}
+	case 'P':
+		n := clamp(arg(0, 1), 0, s.cols-s.x)
+		row := s.cells[s.y]
+		copy(row[s.x:], row[s.x+n:])
+		for x := s.cols - n; x < s.cols; x++ {
+			row[x] = s.blank()
+		}
+	case '@':
+		n := clamp(arg(0, 1), 0, s.cols-s.x)
+		row := s.cells[s.y]
+		copy(row[s.x+n:], row[s.x:s.cols-n])
+		for x := s.x; x < s.x+n; x++ {
+			row[x] = s.blank()
+		}
+	case 'X':
+		for x := s.x; x < s.x+arg(0, 1) && x < s.cols; x++ {
+			s.cells[s.y][x] = s.blank()
+		}
+	case 'S':
+		s.scrollUp(arg(0, 1))
+	case 'T':
+		if !private {
+			s.scrollDown(arg(0, 1))
+		}
+	case 'm':
+		if !private {
+			s.attr.apply(args)
+		}
+	case 'r':
+		if !private {
+			top, bottom := arg(0, 1)-1, arg(1, s.rows)-1
+			if top < bottom && bottom < s.rows {
+				s.top, s.bottom = top, bottom
+				s.x, s.y = 0, 0
+			}
+		}
+	case 's':
+		s.saveCursor()
+	case 'u':
+		s.restoreCursor()
+	case 'h', 'l':
+		// Entering or leaving the alternate screen starts from a blank one
+		if private && (arg(0, 0) == 1049 || arg(0, 0) == 47 || arg(0, 0) == 1047) {
+			if arg(0, 0) == 1049 && final == 'h' {
+				s.saveCursor()
+			}
+			s.eraseDisplay(2)
+			if arg(0, 0) == 1049 && final == 'l' {
+				s.restoreCursor()
+			}
+		}
+	}
+}
+
+// eraseDisplay handles ED
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseLine(0)
+		for y := s.y + 1; y < s.rows; y++ {
+			s.cells[y] = s.blankRow()
+		}
+	case 1:
+		s.eraseLine(1)
+		for y := 0; y < s.y; y++ {
+			s.cells[y] = s.blankRow()
+		}
+	case 2, 3:
+		for y := range s.cells {
+			s.cells[y] = s.blankRow()
+		}
+	}
+}
+
+// eraseLine handles EL
+func (s *Screen) eraseLine(mode int) {
+	from, to := s.x, s.cols
+	switch mode {
+	case 1:
+		from, to = 0, s.x+1
+	case 2:
+		from = 0
+	}
+	for x := from; x < to; x++ {
+		s.cells[s.y][x] = s.blank()
+	}
+}
+
+func (s *Screen) saveCursor() {
+	s.savedX, s.savedY, s.savedAttr = s.x, s.y, s.attr
+}
+
+func (s *Screen) restoreCursor() {
+	s.x, s.y, s.attr = clamp(s.savedX, 0, s.cols-1), clamp(s.savedY, 0, s.rows-1), s.savedAttr
+}
+
+// sgrState is the current graphic rendition
+type sgrState struct {
+	bold, dim, italic, underline, blink, reverse, hidden bool
+	fg, bg                                               string
+}
+
+// apply updates the rendition from SGR parameters
+func (a *sgrState) apply(args []int) {
+	if len(args) == 0 {
+		*a = sgrState{}
+		return
+	}
+
+	for i := 0; i < len(args); i++ {
+		p := args[i]
+		switch {
+		case p == 0:
+			*a = sgrState{}
+		case p == 1:
+			a.bold = true
+		case p == 2:
+			a.dim = true
+		case p == 3:
+			a.italic = true
+		case p == 4:
+			a.underline = true
+		case p == 5:
+			a.blink = true
+		case p == 7:
+			a.reverse = true
+		case p == 8:
+			a.hidden = true
+		case p == 22:
+			a.bold, a.dim = false, false
+		case p == 23:
+			a.italic = false
+		case p == 24:
+			a.underline = false
+		case p == 25:
+			a.blink = false
+		case p == 27:
+			a.reverse = false
+		case p == 28:
+			a.hidden = false
+		case p >= 30 && p <= 37, p >= 90 && p <= 97:
+			a.fg = strconv.Itoa(p)
+		case p == 39:
+			a.fg = ""
+		case p >= 40 && p <= 47, p >= 100 && p <= 107:
+			a.bg = strconv.Itoa(p)
+		case p == 49:
+			a.bg = ""
+		case p == 38 || p == 48:
+			color, used := extendedColor(args[i+1:])
+			i += used
+			if p == 38 {
+				a.fg = color
+			} else {
+				a.bg = color
+			}
+		}
+	}
+}
+
+// extendedColor reads a 256-colour or true colour specification following
+// SGR 38 or 48, returning it with its introducer and how many args it used
+func extendedColor(args []int) (string, int) {
+	switch {
+	case len(args) >= 2 && args[0] == 5:
+		return fmt.Sprintf("8;5;%d", args[1]), 2
+	case len(args) >= 4 && args[0] == 2:
+		return fmt.Sprintf("8;2;%d;%d;%d", args[1], args[2], args[3]), 4
+	}
+	return "", len(args)
+}
+
+// String renders the rendition as SGR parameters
+func (a sgrState) String() string {
+	var parts []string
+	flags := []struct {
+		on   bool
+		code string
+	}{
+		{a.bold, "1"}, {a.dim, "2"}, {a.italic, "3"}, {a.underline, "4"},
+		{a.blink, "5"}, {a.reverse, "7"}, {a.hidden, "8"},
+	}
+	for _, f := range flags {
+		if f.on {
+			parts = append(parts, f.code)
+		}
+	}
+	if a.fg != "" {
+		parts = append(parts, colorParam(a.fg, "3"))
+	}
+	if a.bg != "" {
+		parts = append(parts, colorParam(a.bg, "4"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// colorParam completes an extended colour with its fg/bg introducer
+func colorParam(color, introducer string) string {
+	if strings.HasPrefix(color, "8;") {
+		return introducer + color
+	}
+	return color
+}
+
+// parseParams splits CSI parameters; empty parameters are 0
+func parseParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+	fields := strings.Split(strings.ReplaceAll(params, ":", ";"), ";")
+	args := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, _ := strconv.Atoi(field)
+		args = append(args, n)
+	}
+	return args
+}
+
+func clamp(v, lo, hi int) int {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
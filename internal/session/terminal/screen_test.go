@@ -0,0 +1,83 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// replay feeds a snapshot into a fresh screen of the same size
+func replay(s *Screen) *Screen {
+	cols, rows := s.Size()
+	out := NewScreen(cols, rows)
+	out.Write(s.Snapshot())
+	return out
+}
+
+func TestScreen_Snapshot(t *testing.T) {
+	s := NewScreen(20, 5)
+	s.Write([]byte("\x1b[2J\x1b[H\x1b[1;31mDlvl:1\x1b[0m $:0\r\n"))
+	s.Write([]byte("\x1b[3;5H\x1b(0lqk\x1b(B@"))
+	s.Write([]byte("\x1b[44m"))
+
+	restored := replay(s)
+	assert.Equal(t, s.cells, restored.cells)
+	assert.Equal(t, s.attr, restored.attr)
+	assert.Equal(t, 8, restored.x)
+	assert.Equal(t, 2, restored.y)
+	assert.Equal(t, cell{r: 'D', attr: "1;31"}, restored.cells[0][0])
+	assert.Equal(t, cell{r: 'q', dec: true}, restored.cells[2][5])
+}
+
+func TestScreen_Editing(t *testing.T) {
+	s := NewScreen(10, 3)
+	s.Write([]byte("abcdef\x1b[1;3H\x1b[2P"))
+	assert.Equal(t, 'e', s.cells[0][2].r)
+
+	s.Write([]byte("\x1b[K"))
+	assert.Equal(t, blankCell, s.cells[0][2])
+
+	// Scrolling at the bottom of the screen
+	s.Write([]byte("\x1b[3;1Hlast\nnext"))
+	assert.Equal(t, 'l', s.cells[1][0].r)
+	assert.Equal(t, 'n', s.cells[2][4].r)
+
+	// Sequences split across writes
+	s.Write([]byte("\x1b[1"))
+	s.Write([]byte(";1Hé"[:5]))
+	s.Write([]byte(";1Hé"[5:]))
+	assert.Equal(t, 'é', s.cells[0][0].r)
+}
+
+func TestScreen_Resize(t *testing.T) {
+	s := NewScreen(4, 2)
+	s.Write([]byte("abcd"))
+	s.Resize(2, 3)
+	cols, rows := s.Size()
+	assert.Equal(t, 2, cols)
+	assert.Equal(t, 3, rows)
+	assert.Equal(t, 'b', s.cells[0][1].r)
+}
+
+func TestScreen_View(t *testing.T) {
+	s := NewScreen(20, 6)
+	s.Write([]byte("\x1b[1;1H\x1b[1mtop\x1b[0m\x1b[6;15Hbottom"))
+
+	// A smaller terminal follows the cursor, which is past the window
+	small := NewScreen(10, 4)
+	small.Write(s.View(10, 4))
+	assert.Equal(t, 'b', small.cells[3][4].r)
+	assert.Equal(t, 'm', small.cells[3][9].r)
+	assert.Equal(t, blankCell, small.cells[0][0])
+	assert.Equal(t, 9, small.x)
+	assert.Equal(t, 3, small.y)
+
+	// A larger terminal shows the whole screen centred
+	large := NewScreen(30, 10)
+	large.Write([]byte("leftover"))
+	large.Write(s.View(30, 10))
+	assert.Equal(t, cell{r: 't', attr: "1"}, large.cells[2][5])
+	assert.Equal(t, 'b', large.cells[7][19].r)
+	assert.Equal(t, blankCell, large.cells[0][0])
+	assert.Equal(t, 7, large.y)
+}
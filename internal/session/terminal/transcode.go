@@ -0,0 +1,115 @@
+package terminal
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// asciiFallbacks maps common game glyphs outside the box drawing ranges to
+// ASCII look-alikes
+var asciiFallbacks = map[rune]byte{
+	'·':      '.', // middle dot (floor)
+	'•':      '*', // bullet (boulder, gem)
+	'∙':      '.',
+	'≈':      '}', // water
+	'∩':      '^', // trap
+	'♦':      '*',
+	'♣':      '#', // tree
+	'♠':      '#',
+	'⌠':      '{', // fountain
+	'Ω':      '_', // altar
+	'§':      '#',
+	'¶':      '#',
+	'°':      '*',
+	'←':      '<',
+	'→':      '>',
+	'↑':      '^',
+	'↓':      'v',
+	'…':      '.',
+	'–':      '-',
+	'—':      '-',
+	'‘':      '\'',
+	'’':      '\'',
+	'“':      '"',
+	'”':      '"',
+	'\u00a0': ' ', // no-break space
+}
+
+// ASCIITranscoder converts a UTF-8 byte stream into 7-bit ASCII for terminals
+// that cannot display Unicode. Box drawing characters become +, - and |, other
+// known glyphs become ASCII look-alikes and everything else becomes '?'.
+// Multibyte sequences split across calls are buffered until complete.
+type ASCIITranscoder struct {
+	pending []byte
+}
+
+// NewASCIITranscoder creates a new UTF-8 to ASCII transcoder
+func NewASCIITranscoder() *ASCIITranscoder {
+	return &ASCIITranscoder{}
+}
+
+// Transcode converts the next chunk of the stream
+func (t *ASCIITranscoder) Transcode(data []byte) []byte {
+	if len(t.pending) > 0 {
+		data = append(t.pending, data...)
+		t.pending = nil
+	}
+
+	out := make([]byte, 0, len(data))
+	for i := 0; i < len(data); {
+		b := data[i]
+		if b < utf8.RuneSelf {
+			out = append(out, b)
+			i++
+			continue
+		}
+
+		// Hold back an incomplete sequence at the end of the chunk
+		if !utf8.FullRune(data[i:]) {
+			t.pending = append([]byte(nil), data[i:]...)
+			break
+		}
+
+		r, size := utf8.DecodeRune(data[i:])
+		out = append(out, asciiFor(r))
+		i += size
+	}
+	return out
+}
+
+// asciiFor returns the ASCII replacement for a non-ASCII rune
+func asciiFor(r rune) byte {
+	switch {
+	case r == utf8.RuneError:
+		return '?'
+	case r >= 0x2500 && r <= 0x257F:
+		return boxDrawingFor(r)
+	case r >= 0x2580 && r <= 0x259F: // block elements
+		return '#'
+	}
+	if c, ok := asciiFallbacks[r]; ok {
+		return c
+	}
+	return '?'
+}
+
+// boxDrawingFor maps a box drawing character to +, - or |
+func boxDrawingFor(r rune) byte {
+	const horizontal = "─━┄┅┈┉╌╍═╴╶╸╺╼╾"
+	const vertical = "│┃┆┇┊┋╎╏║╵╷╹╻╽╿"
+	switch {
+	case strings.ContainsRune(horizontal, r):
+		return '-'
+	case strings.ContainsRune(vertical, r):
+		return '|'
+	default:
+		return '+'
+	}
+}
+
+// IsUTF8Locale reports whether a locale name (LANG, LC_ALL or LC_CTYPE value)
+// selects the UTF-8 codeset
+func IsUTF8Locale(locale string) bool {
+	lower := strings.ToLower(locale)
+	return strings.Contains(lower, "utf-8") || strings.Contains(lower, "utf8")
+}
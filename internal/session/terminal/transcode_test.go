@@ -0,0 +1,26 @@
+package terminal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestASCIITranscoder(t *testing.T) {
+	tr := NewASCIITranscoder()
+
+	assert.Equal(t, []byte("\x1b[H+--+\r\n|.@|"), tr.Transcode([]byte("\x1b[H┌──┐\r\n│·@│")))
+	assert.Equal(t, []byte("caf?"), tr.Transcode([]byte("café")))
+
+	// A sequence split across chunks is held back until complete
+	box := []byte("═")
+	assert.Equal(t, []byte("a"), tr.Transcode(append([]byte("a"), box[:1]...)))
+	assert.Equal(t, []byte("-b"), tr.Transcode(append(box[1:], 'b')))
+}
+
+func TestIsUTF8Locale(t *testing.T) {
+	assert.True(t, IsUTF8Locale("en_US.UTF-8"))
+	assert.True(t, IsUTF8Locale("C.utf8"))
+	assert.False(t, IsUTF8Locale("C"))
+	assert.False(t, IsUTF8Locale("de_DE.ISO-8859-1"))
+}
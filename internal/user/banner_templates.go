@@ -0,0 +1,88 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// BannerTemplate is a banner or menu text set at runtime by an admin. Session
+// services render it instead of their banner file.
+type BannerTemplate struct {
+	Name      string    `json:"name" db:"name"`
+	Content   string    `json:"content" db:"content"`
+	UpdatedBy string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ListBannerTemplates returns every stored banner template, by name
+func (s *Service) ListBannerTemplates(ctx context.Context) ([]*BannerTemplate, error) {
+	query := "SELECT name, content, updated_by, updated_at FROM banner_templates ORDER BY name"
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query banner templates: %w", err)
+	}
+	defer rows.Close()
+
+	var templates []*BannerTemplate
+	for rows.Next() {
+		var template BannerTemplate
+		if err := rows.Scan(&template.Name, &template.Content, &template.UpdatedBy, &template.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan banner template: %w", err)
+		}
+		templates = append(templates, &template)
+	}
+
+	return templates, rows.Err()
+}
+
+// GetBannerTemplate returns a stored banner template, or nil if the banner has none
+func (s *Service) GetBannerTemplate(ctx context.Context, name string) (*BannerTemplate, error) {
+	var template BannerTemplate
+	query := "SELECT name, content, updated_by, updated_at FROM banner_templates WHERE name = ?"
+	err := s.db.QueryRowContext(ctx, query, name).Scan(&template.Name, &template.Content, &template.UpdatedBy, &template.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get banner template: %w", err)
+	}
+	return &template, nil
+}
+
+// SaveBannerTemplate stores a banner template, replacing any previous one
+func (s *Service) SaveBannerTemplate(ctx context.Context, name, content, updatedBy string) (*BannerTemplate, error) {
+	query := `
+		INSERT INTO banner_templates (name, content, updated_by)
+		VALUES (?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET
+			content = excluded.content,
+			updated_by = excluded.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := s.db.ExecContext(ctx, query, name, content, updatedBy); err != nil {
+		return nil, fmt.Errorf("failed to save banner template: %w", err)
+	}
+
+	return s.GetBannerTemplate(ctx, name)
+}
+
+// DeleteBannerTemplate removes a stored banner template
+func (s *Service) DeleteBannerTemplate(ctx context.Context, name string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM banner_templates WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete banner template: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("banner %s has no runtime template", name)
+	}
+
+	return nil
+}
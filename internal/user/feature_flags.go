@@ -0,0 +1,93 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// FeatureFlag is a feature flag set at runtime by an admin. Every service
+// evaluates it instead of the configured flag of the same name.
+type FeatureFlag struct {
+	Name      string    `json:"name" db:"name"`
+	UpdatedBy string    `json:"updated_by" db:"updated_by"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+	config.FeatureFlag
+}
+
+// ListFeatureFlags returns every stored feature flag, by name
+func (s *Service) ListFeatureFlags(ctx context.Context) ([]*FeatureFlag, error) {
+	query := `SELECT name, description, enabled, users, roles, percentage, updated_by, updated_at
+		FROM feature_flags ORDER BY name`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*FeatureFlag
+	for rows.Next() {
+		var flag FeatureFlag
+		var users, roles string
+		if err := rows.Scan(&flag.Name, &flag.Description, &flag.Enabled, &users, &roles,
+			&flag.Percentage, &flag.UpdatedBy, &flag.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		flag.Users = splitList(users)
+		flag.Roles = splitList(roles)
+		flags = append(flags, &flag)
+	}
+
+	return flags, rows.Err()
+}
+
+// SaveFeatureFlag stores a feature flag, replacing any previous one
+func (s *Service) SaveFeatureFlag(ctx context.Context, name string, flag *config.FeatureFlag, updatedBy string) error {
+	query := `
+		INSERT INTO feature_flags (name, description, enabled, users, roles, percentage, updated_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET
+			description = excluded.description,
+			enabled = excluded.enabled,
+			users = excluded.users,
+			roles = excluded.roles,
+			percentage = excluded.percentage,
+			updated_by = excluded.updated_by,
+			updated_at = CURRENT_TIMESTAMP
+	`
+	if _, err := s.db.ExecContext(ctx, query, name, flag.Description, flag.Enabled,
+		strings.Join(flag.Users, ","), strings.Join(flag.Roles, ","), flag.Percentage, updatedBy); err != nil {
+		return fmt.Errorf("failed to save feature flag: %w", err)
+	}
+	return nil
+}
+
+// DeleteFeatureFlag removes a stored feature flag
+func (s *Service) DeleteFeatureFlag(ctx context.Context, name string) error {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM feature_flags WHERE name = ?", name)
+	if err != nil {
+		return fmt.Errorf("failed to delete feature flag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("feature flag %s is not set at runtime", name)
+	}
+
+	return nil
+}
+
+// splitList splits a comma-separated list, where empty is no items
+func splitList(list string) []string {
+	if list == "" {
+		return nil
+	}
+	return strings.Split(list, ",")
+}
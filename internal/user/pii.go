@@ -0,0 +1,162 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/encryption"
+)
+
+// PersonalDataMigration counts the users whose personal data
+// EncryptPersonalData encrypted
+type PersonalDataMigration struct {
+	// Scanned is how many users were checked
+	Scanned int
+	// Encrypted is how many users' data was stored unencrypted
+	Encrypted int
+	// Reencrypted is how many users' data was encrypted with an older key
+	Reencrypted int
+}
+
+// newPIIEncryptor returns the encryptor of users' personal data, which
+// leaves it unencrypted unless encryption is configured with keys
+func newPIIEncryptor(cfg *config.EncryptionConfig) (*encryption.Encryptor, error) {
+	if cfg == nil {
+		cfg = &config.EncryptionConfig{}
+	}
+	if cfg.Enabled && len(cfg.Keys) == 0 {
+		return nil, fmt.Errorf("encryption.keys is required when encryption is enabled")
+	}
+	return encryption.New(cfg)
+}
+
+// storedEmail returns an email as it is stored, encrypted when encryption is
+// on, with the blind index it is looked up by
+func (s *Service) storedEmail(email string) (string, sql.NullString, error) {
+	stored, err := s.pii.EncryptString(email)
+	if err != nil {
+		return "", sql.NullString{}, fmt.Errorf("failed to encrypt email: %w", err)
+	}
+	index := s.pii.BlindIndex(email)
+	return stored, sql.NullString{String: index, Valid: index != ""}, nil
+}
+
+// readEmail decrypts the email of a user read from the database
+func (s *Service) readEmail(user *User) error {
+	email, err := s.pii.DecryptString(user.Email)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt email of user %d: %w", user.ID, err)
+	}
+	user.Email = email
+	return nil
+}
+
+// GetUserByEmail retrieves a user by email, ignoring case. Encrypted emails
+// are found by their blind index.
+func (s *Service) GetUserByEmail(ctx context.Context, email string) (*User, error) {
+	if email == "" {
+		return nil, ErrUserNotFound
+	}
+	query := `
+		SELECT id, username, email, password_hash, salt, environment, flags,
+			   created_at, updated_at, last_login, login_count, failed_login_attempts,
+			   account_locked, locked_until, email_verified, is_active, require_password_change
+		FROM users
+		WHERE email_index = ? OR (email_index IS NULL AND LOWER(email) = LOWER(?))
+		ORDER BY id
+		LIMIT 1
+	`
+
+	var user User
+	var lastLogin, lockedUntil sql.NullTime
+
+	err := s.db.QueryRowContext(ctx, query, s.pii.BlindIndex(email), email).Scan(
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Salt,
+		&user.Environment, &user.Flags, &user.CreatedAt, &user.UpdatedAt,
+		&lastLogin, &user.LoginCount, &user.FailedLoginAttempts,
+		&user.AccountLocked, &lockedUntil, &user.EmailVerified, &user.IsActive, &user.RequirePasswordChange,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to query user: %w", err)
+	}
+	if err := s.readEmail(&user); err != nil {
+		return nil, err
+	}
+
+	if lastLogin.Valid {
+		user.LastLogin = &lastLogin.Time
+	}
+	if lockedUntil.Valid {
+		user.LockedUntil = &lockedUntil.Time
+	}
+	return &user, nil
+}
+
+// EncryptPersonalData encrypts the personal data stored before encryption
+// was enabled, and encrypts data encrypted with keys that are no longer
+// primary with the primary key, so those keys can be dropped. With dryRun
+// it only counts the users it would change.
+func (s *Service) EncryptPersonalData(ctx context.Context, dryRun bool) (*PersonalDataMigration, error) {
+	if !s.pii.Enabled() {
+		return nil, fmt.Errorf("encryption is not configured")
+	}
+
+	type storedRow struct {
+		id    int
+		email string
+		index sql.NullString
+	}
+	rows, err := s.db.QueryContext(ctx, `SELECT id, COALESCE(email, ''), email_index FROM users ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query users: %w", err)
+	}
+	var stored []storedRow
+	for rows.Next() {
+		var row storedRow
+		if err := rows.Scan(&row.id, &row.email, &row.index); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		stored = append(stored, row)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read users: %w", err)
+	}
+
+	migration := &PersonalDataMigration{Scanned: len(stored)}
+	for _, row := range stored {
+		email, err := s.pii.DecryptString(row.email)
+		if err != nil {
+			return migration, fmt.Errorf("failed to decrypt email of user %d: %w", row.id, err)
+		}
+		if !s.pii.NeedsEncrypting(row.email) && row.index.String == s.pii.BlindIndex(email) {
+			continue
+		}
+
+		if encryption.IsEncrypted(row.email) {
+			migration.Reencrypted++
+		} else {
+			migration.Encrypted++
+		}
+		if dryRun {
+			continue
+		}
+
+		newEmail, index, err := s.storedEmail(email)
+		if err != nil {
+			return migration, err
+		}
+		// Rows changed since they were read are left for the next run
+		if _, err := s.db.ExecContext(ctx, `UPDATE users SET email = ?, email_index = ? WHERE id = ? AND COALESCE(email, '') = ?`,
+			newEmail, index, row.id, row.email); err != nil {
+			return migration, fmt.Errorf("failed to encrypt email of user %d: %w", row.id, err)
+		}
+	}
+	return migration, nil
+}
@@ -0,0 +1,97 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/database"
+)
+
+func testEncryptionKey(fill byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, 32))
+}
+
+func newPIITestService(t *testing.T, db *database.Connection, encryption *config.EncryptionConfig) *Service {
+	service, err := NewService(db, &config.UserServiceConfig{Encryption: encryption}, config.GetDefaultDevelopmentConfig())
+	require.NoError(t, err)
+	return service
+}
+
+func TestEncryptPersonalData(t *testing.T) {
+	db, err := database.NewConnection(&config.DatabaseConfig{
+		Mode:     config.DatabaseModeEmbedded,
+		Type:     "sqlite",
+		Embedded: &config.EmbeddedDBConfig{Type: "sqlite", Path: t.TempDir() + "/users.db"},
+	})
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := context.Background()
+
+	// A user registered before encryption was enabled
+	plain := newPIITestService(t, db, nil)
+	_, err = plain.RegisterUser(ctx, &RegistrationRequest{
+		Username: "player1", Password: "Secret123!", PasswordConfirm: "Secret123!",
+		Email: "Player1@Example.org", AcceptTerms: true, Source: "ssh",
+	})
+	require.NoError(t, err)
+
+	encrypted := newPIITestService(t, db, &config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k1", Key: testEncryptionKey(1)}},
+		IndexKey: testEncryptionKey(9),
+	})
+
+	// Unencrypted rows are still found and read
+	found, err := encrypted.GetUserByEmail(ctx, "player1@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "player1", found.Username)
+
+	// The default admin user has an email too
+	migration, err := encrypted.EncryptPersonalData(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migration.Encrypted)
+
+	migration, err = encrypted.EncryptPersonalData(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migration.Encrypted)
+	assert.Zero(t, migration.Reencrypted)
+
+	var stored string
+	require.NoError(t, db.QueryRow("SELECT email FROM users WHERE username = ?", "player1").Scan(&stored))
+	assert.NotContains(t, stored, "Example")
+
+	found, err = encrypted.GetUserByEmail(ctx, "player1@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "Player1@Example.org", found.Email)
+
+	// Rotating adds a key, and the migration moves rows onto it
+	rotated := newPIITestService(t, db, &config.EncryptionConfig{
+		Enabled: true,
+		Keys: []config.EncryptionKey{
+			{ID: "k1", Key: testEncryptionKey(1)},
+			{ID: "k2", Key: testEncryptionKey(2)},
+		},
+		IndexKey: testEncryptionKey(9),
+	})
+	migration, err = rotated.EncryptPersonalData(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, migration.Reencrypted)
+
+	dropped := newPIITestService(t, db, &config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k2", Key: testEncryptionKey(2)}},
+		IndexKey: testEncryptionKey(9),
+	})
+	found, err = dropped.GetUserByUsername(ctx, "player1")
+	require.NoError(t, err)
+	assert.Equal(t, "Player1@Example.org", found.Email)
+
+	_, err = dropped.GetUserByEmail(ctx, "nobody@example.org")
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
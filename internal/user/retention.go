@@ -0,0 +1,131 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// userDataTables are the tables holding a user's rows by user_id, deleted
+// with their account
+var userDataTables = []string{
+	"user_profiles",
+	"user_preferences",
+	"user_notes",
+	"user_moderation_flags",
+	"account_access_log",
+	"user_terms_acceptance",
+	"api_keys",
+	"user_devices",
+	"notifications",
+	"username_history",
+	"user_friends",
+}
+
+// InactiveAccounts lists the accounts last logged in to before a time, or
+// created before it and never logged in to. Admin accounts are never
+// inactive.
+func (s *Service) InactiveAccounts(ctx context.Context, before time.Time) ([]*User, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, username, flags
+		FROM users
+		WHERE COALESCE(last_login, created_at) < ? AND (flags & ?) = 0
+		ORDER BY id
+	`, before.UTC(), UserFlagAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query inactive accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		var user User
+		if err := rows.Scan(&user.ID, &user.Username, &user.Flags); err != nil {
+			return nil, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, &user)
+	}
+	return users, rows.Err()
+}
+
+// PurgeAccount deletes a user account with the data kept with it. A group
+// the user owned passes to another member, as when they leave it.
+func (s *Service) PurgeAccount(ctx context.Context, userID int) error {
+	if _, err := s.LeaveGroup(ctx, userID); err != nil && !errors.Is(err, ErrNotInGroup) {
+		return err
+	}
+
+	tx, err := s.db.Transaction(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range userDataTables {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE user_id = ?", table), userID); err != nil {
+			return fmt.Errorf("failed to delete %s of user %d: %w", table, userID, err)
+		}
+	}
+	// Nobody keeps a purged account as a friend
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_friends WHERE friend_id = ?", userID); err != nil {
+		return fmt.Errorf("failed to delete friendships of user %d: %w", userID, err)
+	}
+	result, err := tx.ExecContext(ctx, "DELETE FROM users WHERE id = ?", userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete user %d: %w", userID, err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrUserNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit purge: %w", err)
+	}
+	return nil
+}
+
+// PurgeAuditLogs deletes the moderation and account access log entries made
+// before a time, except those about the users kept, and returns how many
+// there were. With dryRun it only counts them.
+func (s *Service) PurgeAuditLogs(ctx context.Context, before time.Time, keep []string, dryRun bool) (int, error) {
+	// keepClause excludes the entries whose user, named by column, is kept
+	keepClause := func(column string) string {
+		if len(keep) == 0 {
+			return ""
+		}
+		placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(keep)), ", ")
+		return fmt.Sprintf(" AND LOWER(COALESCE(%s, '')) NOT IN (%s)", column, placeholders)
+	}
+	args := []interface{}{before.UTC()}
+	for _, username := range keep {
+		args = append(args, strings.ToLower(username))
+	}
+
+	froms := []string{
+		"FROM moderation_audit_log WHERE created_at < ?" + keepClause("target_username"),
+		"FROM account_access_log WHERE created_at < ?" +
+			keepClause("(SELECT username FROM users WHERE users.id = account_access_log.user_id)"),
+	}
+
+	total := 0
+	for _, from := range froms {
+		if dryRun {
+			var count int
+			if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) "+from, args...).Scan(&count); err != nil {
+				return total, fmt.Errorf("failed to count audit log entries: %w", err)
+			}
+			total += count
+			continue
+		}
+		result, err := s.db.ExecContext(ctx, "DELETE "+from, args...)
+		if err != nil {
+			return total, fmt.Errorf("failed to purge audit log entries: %w", err)
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			total += int(n)
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,150 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SessionInstance is the load a session service replica last reported.
+// Replicas report to the auth service, which keeps them here so every auth
+// replica sees the whole cluster.
+type SessionInstance struct {
+	ID                  string    `json:"instance_id" db:"instance_id"`
+	Version             string    `json:"version" db:"version"`
+	ConnectionsActive   int       `json:"connections_active" db:"connections_active"`
+	ConnectionsTotal    int64     `json:"connections_total" db:"connections_total"`
+	ConnectionsPeak     int       `json:"connections_peak" db:"connections_peak"`
+	MaxConnections      int       `json:"max_connections" db:"max_connections"`
+	GameSessions        int       `json:"game_sessions" db:"game_sessions"`
+	ConnectionsRejected int64     `json:"connections_rejected" db:"connections_rejected"`
+	StartedAt           time.Time `json:"started_at" db:"started_at"`
+	ReportedAt          time.Time `json:"reported_at" db:"reported_at"`
+}
+
+// ReportSessionInstance records the load of a session service replica,
+// replacing what it reported before
+func (s *Service) ReportSessionInstance(ctx context.Context, instance *SessionInstance) error {
+	query := `
+		INSERT INTO session_instances (instance_id, version, connections_active, connections_total,
+			connections_peak, max_connections, game_sessions, connections_rejected, started_at, reported_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (instance_id) DO UPDATE SET
+			version = excluded.version,
+			connections_active = excluded.connections_active,
+			connections_total = excluded.connections_total,
+			connections_peak = excluded.connections_peak,
+			max_connections = excluded.max_connections,
+			game_sessions = excluded.game_sessions,
+			connections_rejected = excluded.connections_rejected,
+			started_at = excluded.started_at,
+			reported_at = excluded.reported_at
+	`
+	_, err := s.db.ExecContext(ctx, query, instance.ID, instance.Version, instance.ConnectionsActive,
+		instance.ConnectionsTotal, instance.ConnectionsPeak, instance.MaxConnections, instance.GameSessions,
+		instance.ConnectionsRejected, instance.StartedAt.UTC(), instance.ReportedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to report session instance: %w", err)
+	}
+
+	// Logins the replica counted before it restarted have ended
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM session_logins WHERE instance_id = ? AND updated_at < ?",
+		instance.ID, instance.StartedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to forget logins of restarted instance: %w", err)
+	}
+	return nil
+}
+
+// ListSessionInstances returns the replicas that reported since the given
+// time, by instance ID
+func (s *Service) ListSessionInstances(ctx context.Context, since time.Time) ([]*SessionInstance, error) {
+	query := `
+		SELECT instance_id, version, connections_active, connections_total, connections_peak,
+			max_connections, game_sessions, connections_rejected, started_at, reported_at
+		FROM session_instances
+		WHERE reported_at >= ?
+		ORDER BY instance_id
+	`
+	rows, err := s.db.QueryContext(ctx, query, since.UTC())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query session instances: %w", err)
+	}
+	defer rows.Close()
+
+	var instances []*SessionInstance
+	for rows.Next() {
+		var instance SessionInstance
+		if err := rows.Scan(&instance.ID, &instance.Version, &instance.ConnectionsActive, &instance.ConnectionsTotal,
+			&instance.ConnectionsPeak, &instance.MaxConnections, &instance.GameSessions, &instance.ConnectionsRejected,
+			&instance.StartedAt, &instance.ReportedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session instance: %w", err)
+		}
+		instances = append(instances, &instance)
+	}
+
+	return instances, rows.Err()
+}
+
+// ForgetSessionInstances removes the replicas that have not reported since
+// the given time, returning how many were removed
+func (s *Service) ForgetSessionInstances(ctx context.Context, before time.Time) (int, error) {
+	result, err := s.db.ExecContext(ctx, "DELETE FROM session_instances WHERE reported_at < ?", before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to forget session instances: %w", err)
+	}
+
+	removed, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if _, err := s.db.ExecContext(ctx, `
+		DELETE FROM session_logins
+		WHERE updated_at < ? AND instance_id NOT IN (SELECT instance_id FROM session_instances)
+	`, before.UTC()); err != nil {
+		return 0, fmt.Errorf("failed to forget logins of session instances: %w", err)
+	}
+	return int(removed), nil
+}
+
+// AddSessionLogins adds delta to a user's logins on a session service
+// replica and returns the user's logins across the replicas. Logins count
+// while their replica reported since liveSince, or changed since then when
+// the replica has not reported yet.
+func (s *Service) AddSessionLogins(ctx context.Context, instanceID, username string, delta int, liveSince time.Time) (int, error) {
+	now := time.Now().UTC()
+	var err error
+	if delta > 0 {
+		_, err = s.db.ExecContext(ctx, `
+			INSERT INTO session_logins (instance_id, username, logins, updated_at)
+			VALUES (?, ?, ?, ?)
+			ON CONFLICT (instance_id, username) DO UPDATE SET
+				logins = session_logins.logins + excluded.logins,
+				updated_at = excluded.updated_at
+		`, instanceID, username, delta, now)
+	} else if delta < 0 {
+		_, err = s.db.ExecContext(ctx, `
+			UPDATE session_logins SET logins = logins + ?, updated_at = ?
+			WHERE instance_id = ? AND username = ?
+		`, delta, now, instanceID, username)
+		if err == nil {
+			_, err = s.db.ExecContext(ctx, "DELETE FROM session_logins WHERE instance_id = ? AND username = ? AND logins <= 0",
+				instanceID, username)
+		}
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count session login: %w", err)
+	}
+
+	var logins int
+	err = s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(logins), 0) FROM session_logins
+		WHERE username = ? AND (updated_at >= ? OR instance_id IN (
+			SELECT instance_id FROM session_instances WHERE reported_at >= ?
+		))
+	`, username, liveSince.UTC(), liveSince.UTC()).Scan(&logins)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count session logins: %w", err)
+	}
+	return logins, nil
+}
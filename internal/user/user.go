@@ -14,11 +14,25 @@ import (
 
 	"time"
 
+	"github.com/dungeongate/pkg/apierror"
 	"github.com/dungeongate/pkg/config"
 	"github.com/dungeongate/pkg/database"
+	"github.com/dungeongate/pkg/encryption"
 	"golang.org/x/crypto/argon2"
 )
 
+var (
+	// ErrUserNotFound is returned when authenticating an unknown username
+	ErrUserNotFound = apierror.New(apierror.UserNotFound, "user not found")
+	// ErrAccountLocked is returned when authenticating a locked account
+	ErrAccountLocked = apierror.New(apierror.AccountLocked, "account is locked")
+	// ErrLockedOut is returned when authenticating an account locked for a
+	// while after too many failed logins
+	ErrLockedOut = apierror.New(apierror.AccountLocked, "account is locked after failed logins")
+	// ErrInvalidCredentials is returned when a password does not match
+	ErrInvalidCredentials = apierror.New(apierror.InvalidCredentials, "invalid credentials")
+)
+
 // UserFlags represents user account flags
 type UserFlags int
 
@@ -83,6 +97,7 @@ type RegistrationRequest struct {
 	Email           string `json:"email,omitempty"`
 	RealName        string `json:"real_name,omitempty"`
 	AcceptTerms     bool   `json:"accept_terms"`
+	TermsVersion    string `json:"terms_version,omitempty"` // Version of the terms accepted, recorded with the account
 	CaptchaResponse string `json:"captcha_response,omitempty"`
 	Source          string `json:"source"` // "ssh", "web", "api"
 	IPAddress       string `json:"ip_address,omitempty"`
@@ -110,14 +125,24 @@ type Service struct {
 	db            *database.Connection
 	config        *config.UserServiceConfig
 	sessionConfig *config.SessionServiceConfig
+	devices       DeviceStore
+	// pii encrypts users' personal data, such as their email
+	pii *encryption.Encryptor
 }
 
 // NewService creates a new user service with enhanced configuration
 func NewService(db *database.Connection, cfg *config.UserServiceConfig, sessionCfg *config.SessionServiceConfig) (*Service, error) {
+	pii, err := newPIIEncryptor(cfg.Encryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encryption: %w", err)
+	}
+
 	service := &Service{
 		db:            db,
 		config:        cfg,
 		sessionConfig: sessionCfg,
+		devices:       &sqlDeviceStore{db: db},
+		pii:           pii,
 	}
 
 	// Initialize database schema
@@ -139,7 +164,8 @@ func (s *Service) initializeSchema() error {
 		`CREATE TABLE IF NOT EXISTS users (
 			id INTEGER PRIMARY KEY AUTOINCREMENT,
 			username VARCHAR(30) UNIQUE NOT NULL,
-			email VARCHAR(80),
+			email TEXT,
+			email_index VARCHAR(64),
 			password_hash VARCHAR(255) NOT NULL,
 			salt VARCHAR(32) NOT NULL,
 			environment TEXT DEFAULT '',
@@ -155,8 +181,202 @@ func (s *Service) initializeSchema() error {
 			is_active BOOLEAN DEFAULT TRUE,
 			require_password_change BOOLEAN DEFAULT FALSE
 		)`,
+		`CREATE TABLE IF NOT EXISTS user_profiles (
+			user_id INTEGER PRIMARY KEY,
+			real_name VARCHAR(100),
+			location VARCHAR(100),
+			website VARCHAR(200),
+			bio TEXT,
+			avatar_url VARCHAR(500),
+			timezone VARCHAR(50) DEFAULT 'UTC',
+			language VARCHAR(10) DEFAULT 'en',
+			theme VARCHAR(20) DEFAULT 'dark',
+			terminal_size VARCHAR(20) DEFAULT '80x24',
+			color_mode VARCHAR(20) DEFAULT 'color',
+			email_notifications BOOLEAN DEFAULT TRUE,
+			public_profile BOOLEAN DEFAULT FALSE,
+			allow_spectators BOOLEAN DEFAULT TRUE,
+			show_online_status BOOLEAN DEFAULT TRUE,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_preferences (
+			user_id INTEGER,
+			key VARCHAR(100),
+			value TEXT,
+			PRIMARY KEY (user_id, key),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_notes (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			author VARCHAR(30) NOT NULL,
+			note TEXT NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_moderation_flags (
+			user_id INTEGER NOT NULL,
+			flag VARCHAR(20) NOT NULL,
+			reason TEXT,
+			set_by VARCHAR(30) NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, flag),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS moderation_audit_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			actor VARCHAR(30) NOT NULL,
+			target_username VARCHAR(30) NOT NULL,
+			action VARCHAR(30) NOT NULL,
+			detail TEXT,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS account_access_log (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			admin_username VARCHAR(30) NOT NULL,
+			reason TEXT NOT NULL,
+			expires_at TIMESTAMP NOT NULL,
+			seen BOOLEAN DEFAULT FALSE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_groups (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name VARCHAR(30) UNIQUE NOT NULL COLLATE NOCASE,
+			description TEXT DEFAULT '',
+			owner_id INTEGER NOT NULL,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (owner_id) REFERENCES users(id)
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_group_members (
+			user_id INTEGER PRIMARY KEY,
+			group_id INTEGER NOT NULL,
+			joined_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (group_id) REFERENCES user_groups(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_friends (
+			user_id INTEGER NOT NULL,
+			friend_id INTEGER NOT NULL,
+			added_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, friend_id),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+			FOREIGN KEY (friend_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS terms_versions (
+			version VARCHAR(50) PRIMARY KEY,
+			content TEXT NOT NULL,
+			published_by VARCHAR(30) NOT NULL,
+			published_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_terms_acceptance (
+			user_id INTEGER NOT NULL,
+			version VARCHAR(50) NOT NULL,
+			accepted_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, version),
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS api_keys (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			name VARCHAR(50) NOT NULL,
+			key_hash VARCHAR(64) UNIQUE NOT NULL,
+			prefix VARCHAR(20) NOT NULL,
+			scopes TEXT NOT NULL,
+			rate_limit INTEGER DEFAULT 0,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			last_used_at TIMESTAMP,
+			revoked_at TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS user_devices (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			key_fingerprint VARCHAR(100) NOT NULL DEFAULT '',
+			client_version VARCHAR(100) NOT NULL DEFAULT '',
+			last_ip VARCHAR(45) NOT NULL DEFAULT '',
+			first_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			revoked_at TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS notifications (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			kind VARCHAR(30) NOT NULL,
+			title VARCHAR(100) NOT NULL,
+			body TEXT NOT NULL DEFAULT '',
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			read_at TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS banner_templates (
+			name VARCHAR(50) PRIMARY KEY,
+			content TEXT NOT NULL,
+			updated_by VARCHAR(30) NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS feature_flags (
+			name VARCHAR(50) PRIMARY KEY,
+			description TEXT NOT NULL DEFAULT '',
+			enabled BOOLEAN NOT NULL DEFAULT FALSE,
+			users TEXT NOT NULL DEFAULT '',
+			roles TEXT NOT NULL DEFAULT '',
+			percentage INTEGER NOT NULL DEFAULT 0,
+			updated_by VARCHAR(30) NOT NULL,
+			updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS username_history (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			user_id INTEGER NOT NULL,
+			old_username VARCHAR(30) NOT NULL,
+			new_username VARCHAR(30) NOT NULL,
+			changed_by VARCHAR(30) NOT NULL,
+			changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+			reserved_until TIMESTAMP,
+			FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS account_merges (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			survivor_id INTEGER NOT NULL,
+			merged_id INTEGER NOT NULL,
+			merged_username VARCHAR(30) NOT NULL,
+			merged_by VARCHAR(30) NOT NULL,
+			verified_by VARCHAR(20) NOT NULL,
+			merged_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS session_instances (
+			instance_id VARCHAR(255) PRIMARY KEY,
+			version VARCHAR(50) NOT NULL DEFAULT '',
+			connections_active INTEGER NOT NULL DEFAULT 0,
+			connections_total INTEGER NOT NULL DEFAULT 0,
+			connections_peak INTEGER NOT NULL DEFAULT 0,
+			max_connections INTEGER NOT NULL DEFAULT 0,
+			game_sessions INTEGER NOT NULL DEFAULT 0,
+			connections_rejected INTEGER NOT NULL DEFAULT 0,
+			started_at TIMESTAMP NOT NULL,
+			reported_at TIMESTAMP NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS session_logins (
+			instance_id VARCHAR(255) NOT NULL,
+			username VARCHAR(30) NOT NULL,
+			logins INTEGER NOT NULL DEFAULT 0,
+			updated_at TIMESTAMP NOT NULL,
+			PRIMARY KEY (instance_id, username)
+		)`,
 		`CREATE INDEX IF NOT EXISTS idx_users_username ON users(username)`,
 		`CREATE INDEX IF NOT EXISTS idx_users_email ON users(email)`,
+		`CREATE INDEX IF NOT EXISTS idx_user_notes_user_id ON user_notes(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_user_moderation_flags_flag ON user_moderation_flags(flag)`,
+		`CREATE INDEX IF NOT EXISTS idx_account_access_log_user_id ON account_access_log(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_user_group_members_group_id ON user_group_members(group_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_user_friends_friend_id ON user_friends(friend_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_api_keys_user_id ON api_keys(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_user_devices_user_id ON user_devices(user_id)`,
+		`CREATE INDEX IF NOT EXISTS idx_notifications_user_id ON notifications(user_id, read_at)`,
+		`CREATE INDEX IF NOT EXISTS idx_username_history_old_username ON username_history(old_username)`,
+		`CREATE INDEX IF NOT EXISTS idx_username_history_user_id ON username_history(user_id)`,
 	}
 
 	for _, query := range queries {
@@ -165,6 +385,25 @@ func (s *Service) initializeSchema() error {
 		}
 	}
 
+	// Columns added to tables created by earlier versions
+	if err := s.ensureColumn("users", "email_index", "VARCHAR(64)"); err != nil {
+		return err
+	}
+	if _, err := s.db.Exec(`CREATE INDEX IF NOT EXISTS idx_users_email_index ON users(email_index)`); err != nil {
+		return fmt.Errorf("failed to execute schema query: %w", err)
+	}
+
+	return nil
+}
+
+// ensureColumn adds a column to a table created before the column was added
+func (s *Service) ensureColumn(table, column, definition string) error {
+	if _, err := s.db.Exec(fmt.Sprintf("SELECT %s FROM %s LIMIT 0", column, table)); err == nil {
+		return nil
+	}
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
@@ -192,6 +431,19 @@ func (s *Service) RegisterUser(ctx context.Context, req *RegistrationRequest) (*
 		}, nil
 	}
 
+	// Check if the username was recently given up by another account
+	if reserved, err := s.usernameReserved(ctx, req.Username, 0); err != nil {
+		return nil, err
+	} else if reserved {
+		return &RegistrationResponse{
+			Success: false,
+			Message: "Username is reserved",
+			Errors: []ValidationError{
+				{Field: "username", Message: "Username was recently given up and is reserved", Code: "USERNAME_RESERVED"},
+			},
+		}, nil
+	}
+
 	// Hash password
 	passwordHash, salt, err := s.hashPassword(req.Password)
 	if err != nil {
@@ -213,15 +465,20 @@ func (s *Service) RegisterUser(ctx context.Context, req *RegistrationRequest) (*
 		EmailVerified: true,
 	}
 
+	email, emailIndex, err := s.storedEmail(user.Email)
+	if err != nil {
+		return nil, err
+	}
+
 	// Insert user into database
 	query := `
-		INSERT INTO users (username, email, password_hash, salt, environment, flags, 
+		INSERT INTO users (username, email, email_index, password_hash, salt, environment, flags, 
 						  created_at, updated_at, is_active, email_verified, require_password_change)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := s.db.ExecContext(ctx, query,
-		user.Username, user.Email, user.PasswordHash, user.Salt, user.Environment,
+		user.Username, email, emailIndex, user.PasswordHash, user.Salt, user.Environment,
 		user.Flags, user.CreatedAt, user.UpdatedAt, user.IsActive, user.EmailVerified, user.RequirePasswordChange)
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert user: %w", err)
@@ -233,6 +490,12 @@ func (s *Service) RegisterUser(ctx context.Context, req *RegistrationRequest) (*
 	}
 	user.ID = int(userID)
 
+	if req.TermsVersion != "" {
+		if err := s.AcceptTerms(ctx, user.ID, req.TermsVersion); err != nil {
+			return nil, err
+		}
+	}
+
 	return &RegistrationResponse{
 		Success: true,
 		User:    user,
@@ -404,6 +667,19 @@ func verifyPassword(password, saltHex, hashHex string) bool {
 
 // AuthenticateUser authenticates a user with enhanced error handling and attempt tracking
 func (s *Service) AuthenticateUser(ctx context.Context, username, password string) (*User, error) {
+	return s.authenticate(ctx, username, password, false)
+}
+
+// AuthenticateUserPastLockout authenticates a user whose account may be
+// locked out after failed logins, for logins that passed a challenge.
+// Accounts locked by admins stay locked.
+func (s *Service) AuthenticateUserPastLockout(ctx context.Context, username, password string) (*User, error) {
+	return s.authenticate(ctx, username, password, true)
+}
+
+// authenticate checks a user's password, past a lockout after failed
+// logins when pastLockout is set
+func (s *Service) authenticate(ctx context.Context, username, password string, pastLockout bool) (*User, error) {
 	query := `
 		SELECT id, username, email, password_hash, salt, environment, flags,
 			   created_at, updated_at, last_login, login_count, failed_login_attempts,
@@ -424,10 +700,13 @@ func (s *Service) AuthenticateUser(ctx context.Context, username, password strin
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("username_not_found")
+			return nil, ErrUserNotFound
 		}
 		return nil, fmt.Errorf("failed to query user: %w", err)
 	}
+	if err := s.readEmail(&user); err != nil {
+		return nil, err
+	}
 
 	// Convert nullable times
 	if lastLogin.Valid {
@@ -438,8 +717,14 @@ func (s *Service) AuthenticateUser(ctx context.Context, username, password strin
 	}
 
 	// Check if account is locked
-	if user.AccountLocked && user.LockedUntil != nil && time.Now().Before(*user.LockedUntil) {
-		return nil, fmt.Errorf("account_locked")
+	if user.IsLocked() {
+		lockedOut := user.LockedUntil != nil && user.FailedLoginAttempts >= s.getMaxFailedAttempts()
+		if !lockedOut {
+			return nil, ErrAccountLocked
+		}
+		if !pastLockout {
+			return nil, ErrLockedOut
+		}
 	}
 
 	// Verify password
@@ -449,7 +734,7 @@ func (s *Service) AuthenticateUser(ctx context.Context, username, password strin
 			// Log error but don't expose it to user
 			fmt.Printf("Error incrementing failed login attempts: %v\n", err)
 		}
-		return nil, fmt.Errorf("invalid_password")
+		return nil, ErrInvalidCredentials
 	}
 
 	// Password is correct - reset failed attempts and unlock account if needed
@@ -505,6 +790,9 @@ func (s *Service) GetUserByID(ctx context.Context, userID int) (*User, error) {
 		}
 		return nil, fmt.Errorf("failed to query user: %w", err)
 	}
+	if err := s.readEmail(&user); err != nil {
+		return nil, err
+	}
 
 	// Convert nullable times
 	if lastLogin.Valid {
@@ -543,6 +831,9 @@ func (s *Service) GetUserByUsername(ctx context.Context, username string) (*User
 		}
 		return nil, fmt.Errorf("failed to query user: %w", err)
 	}
+	if err := s.readEmail(&user); err != nil {
+		return nil, err
+	}
 
 	// Convert nullable times
 	if lastLogin.Valid {
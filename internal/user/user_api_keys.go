@@ -0,0 +1,254 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, telling keys apart from access tokens
+const APIKeyPrefix = "dg_"
+
+// API key scopes, granting read or write access to a REST resource
+const (
+	ScopeGamesRead     = "games:read"
+	ScopeGamesWrite    = "games:write"
+	ScopeSessionsRead  = "sessions:read"
+	ScopeSessionsWrite = "sessions:write"
+	ScopeDumplogsRead  = "dumplogs:read"
+	// Screenshots are read, and shared through public links, with these
+	ScopeScreenshotsRead  = "screenshots:read"
+	ScopeScreenshotsWrite = "screenshots:write"
+)
+
+// APIKeyScopes lists every scope a key may be granted
+var APIKeyScopes = []string{
+	ScopeGamesRead,
+	ScopeGamesWrite,
+	ScopeSessionsRead,
+	ScopeSessionsWrite,
+	ScopeDumplogsRead,
+	ScopeScreenshotsRead,
+	ScopeScreenshotsWrite,
+}
+
+const (
+	// maxAPIKeyNameLength bounds an API key name
+	maxAPIKeyNameLength = 50
+	// maxAPIKeysPerUser bounds the active keys a user may hold
+	maxAPIKeysPerUser = 10
+	// apiKeySecretBytes is the randomness in a key
+	apiKeySecretBytes = 24
+	// apiKeyDisplayLength is how much of a key is kept to recognise it by
+	apiKeyDisplayLength = len(APIKeyPrefix) + 8
+)
+
+// ErrAPIKeyNotFound is returned for API keys that do not exist, belong to
+// someone else or were revoked
+var ErrAPIKeyNotFound = errors.New("API key not found")
+
+// APIKey is a long-lived credential a user creates for scripts and other
+// automation. Only a hash of the key is stored.
+type APIKey struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	Prefix     string     `json:"prefix" db:"prefix"`
+	Scopes     []string   `json:"scopes" db:"scopes"`
+	RateLimit  int        `json:"rate_limit" db:"rate_limit"` // Requests per minute, 0 for the server default
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// HasScope reports whether the key grants a scope
+func (k *APIKey) HasScope(scope string) bool {
+	for _, granted := range k.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateAPIKeyScopes checks that scopes are known and returns them without duplicates
+func ValidateAPIKeyScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("at least one scope is required")
+	}
+
+	seen := make(map[string]bool)
+	var valid []string
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		known := false
+		for _, candidate := range APIKeyScopes {
+			known = known || candidate == scope
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown scope %q", scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			valid = append(valid, scope)
+		}
+	}
+	return valid, nil
+}
+
+// hashAPIKey returns the stored form of a key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey creates an API key for the user. The key itself is returned
+// only here; afterwards it is known by its prefix.
+func (s *Service) CreateAPIKey(ctx context.Context, userID int, name string, scopes []string, rateLimit int) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxAPIKeyNameLength {
+		return nil, "", fmt.Errorf("API key name must be 1 to %d characters", maxAPIKeyNameLength)
+	}
+	scopes, err := ValidateAPIKeyScopes(scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	if rateLimit < 0 {
+		return nil, "", fmt.Errorf("rate limit cannot be negative")
+	}
+
+	var active int
+	if err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM api_keys WHERE user_id = ? AND revoked_at IS NULL`, userID,
+	).Scan(&active); err != nil {
+		return nil, "", fmt.Errorf("failed to count API keys: %w", err)
+	}
+	if active >= maxAPIKeysPerUser {
+		return nil, "", fmt.Errorf("at most %d API keys may be active; revoke one first", maxAPIKeysPerUser)
+	}
+
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := APIKeyPrefix + hex.EncodeToString(secret)
+
+	apiKey := &APIKey{
+		UserID:    userID,
+		Name:      name,
+		Prefix:    key[:apiKeyDisplayLength],
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		CreatedAt: time.Now(),
+	}
+	query := `
+		INSERT INTO api_keys (user_id, name, key_hash, prefix, scopes, rate_limit, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := s.db.ExecContext(ctx, query, userID, apiKey.Name, hashAPIKey(key), apiKey.Prefix,
+		strings.Join(scopes, ","), rateLimit, apiKey.CreatedAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create API key: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get API key ID: %w", err)
+	}
+	apiKey.ID = int(id)
+
+	return apiKey, key, nil
+}
+
+// ListAPIKeys returns the user's API keys, revoked ones included, newest first
+func (s *Service) ListAPIKeys(ctx context.Context, userID int) ([]*APIKey, error) {
+	rows, err := s.db.QueryContext(ctx,
+		apiKeySelect+` WHERE user_id = ? ORDER BY created_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey revokes one of the user's API keys; it stops working at once
+func (s *Service) RevokeAPIKey(ctx context.Context, userID, keyID int) error {
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL`,
+		time.Now(), keyID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// LookupAPIKey returns the active API key matching a key and records its use
+func (s *Service) LookupAPIKey(ctx context.Context, key string) (*APIKey, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrAPIKeyNotFound
+	}
+
+	row := s.db.QueryRowContext(ctx,
+		apiKeySelect+` WHERE key_hash = ? AND revoked_at IS NULL`, hashAPIKey(key))
+	apiKey, err := scanAPIKey(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := s.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = ? WHERE id = ?`, now, apiKey.ID); err != nil {
+		return nil, fmt.Errorf("failed to record API key use: %w", err)
+	}
+	apiKey.LastUsedAt = &now
+	return apiKey, nil
+}
+
+// apiKeySelect selects the columns scanAPIKey reads
+const apiKeySelect = `SELECT id, user_id, name, prefix, scopes, rate_limit, created_at, last_used_at, revoked_at FROM api_keys`
+
+// scanAPIKey reads an API key row
+func scanAPIKey(row interface{ Scan(...interface{}) error }) (*APIKey, error) {
+	var key APIKey
+	var scopes string
+	var lastUsed, revoked sql.NullTime
+	err := row.Scan(&key.ID, &key.UserID, &key.Name, &key.Prefix, &scopes, &key.RateLimit,
+		&key.CreatedAt, &lastUsed, &revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan API key: %w", err)
+	}
+
+	if scopes != "" {
+		key.Scopes = strings.Split(scopes, ",")
+	}
+	if lastUsed.Valid {
+		key.LastUsedAt = &lastUsed.Time
+	}
+	if revoked.Valid {
+		key.RevokedAt = &revoked.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,487 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Roles maps the role names admins grant and revoke to their user flags
+var Roles = map[string]UserFlags{
+	"admin":     UserFlagAdmin,
+	"moderator": UserFlagModerator,
+	"beta":      UserFlagBeta,
+}
+
+// ParseRole validates a role name and returns its user flag
+func ParseRole(name string) (UserFlags, error) {
+	flag, ok := Roles[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return UserFlagNone, fmt.Errorf("unknown role: %s", name)
+	}
+	return flag, nil
+}
+
+// RoleNames returns the names of the roles set in flags, sorted
+func RoleNames(flags UserFlags) []string {
+	var names []string
+	for name, flag := range Roles {
+		if flags&flag != 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IsLocked reports whether the account is locked now. A lock without an end
+// lasts until an admin unlocks the account.
+func (u *User) IsLocked() bool {
+	return u.AccountLocked && (u.LockedUntil == nil || time.Now().Before(*u.LockedUntil))
+}
+
+// UserFilter selects user accounts for bulk administration. Zero fields match
+// every account.
+type UserFilter struct {
+	ModerationFlag  ModerationFlag
+	Role            UserFlags
+	CreatedAfter    time.Time
+	CreatedBefore   time.Time
+	LastLoginAfter  time.Time
+	LastLoginBefore time.Time // Also matches users who never logged in
+	LockedOnly      bool
+}
+
+// matches reports whether a user passes the filter's time and lock checks;
+// the moderation flag and role are matched in SQL
+func (f UserFilter) matches(u *User) bool {
+	if !f.CreatedAfter.IsZero() && u.CreatedAt.Before(f.CreatedAfter) {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && !u.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+	if !f.LastLoginAfter.IsZero() && (u.LastLogin == nil || u.LastLogin.Before(f.LastLoginAfter)) {
+		return false
+	}
+	if !f.LastLoginBefore.IsZero() && u.LastLogin != nil && !u.LastLogin.Before(f.LastLoginBefore) {
+		return false
+	}
+	if f.LockedOnly && !u.IsLocked() {
+		return false
+	}
+	return true
+}
+
+// ListUsers returns the users matching filter ordered by username, and how
+// many matched before paging. A zero limit returns every match.
+func (s *Service) ListUsers(ctx context.Context, filter UserFilter, limit, offset int) ([]*User, int, error) {
+	query := `
+		SELECT id, username, email, password_hash, salt, environment, flags,
+			   created_at, updated_at, last_login, login_count, failed_login_attempts,
+			   account_locked, locked_until, email_verified, is_active, require_password_change
+		FROM users
+		WHERE 1 = 1
+	`
+	var args []interface{}
+	if filter.ModerationFlag != "" {
+		if _, err := ParseModerationFlag(string(filter.ModerationFlag)); err != nil {
+			return nil, 0, err
+		}
+		query += " AND id IN (SELECT user_id FROM user_moderation_flags WHERE flag = ?)"
+		args = append(args, string(filter.ModerationFlag))
+	}
+	if filter.Role != UserFlagNone {
+		query += " AND (flags & ?) != 0"
+		args = append(args, int(filter.Role))
+	}
+	query += " ORDER BY username"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query users: %w", err)
+	}
+	defer rows.Close()
+
+	// Stored timestamps mix formats, so the time filters are applied here
+	// rather than in SQL
+	var matched []*User
+	for rows.Next() {
+		var user User
+		var lastLogin, lockedUntil sql.NullTime
+		if err := rows.Scan(
+			&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Salt,
+			&user.Environment, &user.Flags, &user.CreatedAt, &user.UpdatedAt,
+			&lastLogin, &user.LoginCount, &user.FailedLoginAttempts,
+			&user.AccountLocked, &lockedUntil, &user.EmailVerified, &user.IsActive, &user.RequirePasswordChange,
+		); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		if err := s.readEmail(&user); err != nil {
+			return nil, 0, err
+		}
+		if lastLogin.Valid {
+			user.LastLogin = &lastLogin.Time
+		}
+		if lockedUntil.Valid {
+			user.LockedUntil = &lockedUntil.Time
+		}
+		if filter.matches(&user) {
+			matched = append(matched, &user)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read users: %w", err)
+	}
+
+	total := len(matched)
+	if offset >= total {
+		return nil, total, nil
+	}
+	if offset > 0 {
+		matched = matched[offset:]
+	}
+	if limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+	}
+	return matched, total, nil
+}
+
+// SetAccountLocked locks or unlocks a user account and records it in the
+// moderation audit log. A lock lasts until the given time, or until an admin
+// unlocks the account when until is nil.
+func (s *Service) SetAccountLocked(ctx context.Context, username string, locked bool, until *time.Time, changedBy, reason string) error {
+	if !locked {
+		if err := s.UnlockUserAccount(ctx, username); err != nil {
+			return err
+		}
+		return s.recordModerationAction(ctx, changedBy, username, "unlock", reason)
+	}
+
+	var lockedUntil interface{}
+	if until != nil {
+		lockedUntil = *until
+	}
+
+	query := `
+		UPDATE users
+		SET account_locked = TRUE,
+			locked_until = ?
+		WHERE username = ?
+	`
+	result, err := s.db.ExecContext(ctx, query, lockedUntil, username)
+	if err != nil {
+		return fmt.Errorf("failed to lock user account: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found: %s", username)
+	}
+
+	detail := reason
+	if until != nil {
+		detail = strings.TrimSpace(fmt.Sprintf("until %s %s", until.UTC().Format(time.RFC3339), reason))
+	}
+	return s.recordModerationAction(ctx, changedBy, username, "lock", detail)
+}
+
+// SetUserRole grants or revokes a role. The last admin cannot lose the
+// admin role.
+func (s *Service) SetUserRole(ctx context.Context, username string, role UserFlags, grant bool, changedBy string) error {
+	target, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user not found: %s", username)
+	}
+
+	if !grant && role&UserFlagAdmin != 0 && target.IsAdmin() {
+		adminCount, err := s.getAdminCount(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check admin count: %w", err)
+		}
+		if adminCount <= 1 {
+			return fmt.Errorf("cannot revoke admin from the last admin user")
+		}
+	}
+
+	query := "UPDATE users SET flags = flags | ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+	action := "grant_role"
+	if !grant {
+		query = "UPDATE users SET flags = flags & ~?, updated_at = CURRENT_TIMESTAMP WHERE id = ?"
+		action = "revoke_role"
+	}
+	if _, err := s.db.ExecContext(ctx, query, int(role), target.ID); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	return s.recordModerationAction(ctx, changedBy, username, action, strings.Join(RoleNames(role), ","))
+}
+
+// usersCSVHeader is the column order of exported accounts. Imports accept
+// the same columns in any order, plus password.
+var usersCSVHeader = []string{"username", "email", "roles", "created_at", "last_login", "locked"}
+
+// WriteUsersCSV writes users as CSV with a header row. Password hashes and
+// salts are only written when includeHashes is set.
+func WriteUsersCSV(w io.Writer, users []*User, includeHashes bool) error {
+	header := usersCSVHeader
+	if includeHashes {
+		header = append(append([]string(nil), header...), "password_hash", "salt")
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	for _, user := range users {
+		lastLogin := ""
+		if user.LastLogin != nil {
+			lastLogin = user.LastLogin.UTC().Format(time.RFC3339)
+		}
+		record := []string{
+			user.Username,
+			user.Email,
+			strings.Join(RoleNames(user.Flags), ";"),
+			user.CreatedAt.UTC().Format(time.RFC3339),
+			lastLogin,
+			strconv.FormatBool(user.IsLocked()),
+		}
+		if includeHashes {
+			record = append(record, user.PasswordHash, user.Salt)
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ImportResult is the outcome of importing one CSV row
+type ImportResult struct {
+	Line     int
+	Username string
+	// Err is why the row was not imported, or nil
+	Err error
+	// TemporaryPassword is set when the row had no password; the user must
+	// change it at first login
+	TemporaryPassword string
+}
+
+// importRow is a parsed CSV row ready to insert
+type importRow struct {
+	user     User
+	password string
+}
+
+// ImportUsersCSV creates an account for each row of CSV read from r. The
+// header names the columns: username is required, and email, roles
+// (separated by ';'), locked, password, and password_hash with salt are
+// optional; other columns such as created_at are ignored, so an export can
+// be imported again. Rows without a password or hash get a temporary
+// password. Each row succeeds or fails on its own; with dryRun nothing is
+// created.
+func (s *Service) ImportUsersCSV(ctx context.Context, r io.Reader, importedBy string, dryRun bool) ([]*ImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("CSV is empty")
+		}
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["username"]; !ok {
+		return nil, fmt.Errorf("CSV header has no username column")
+	}
+
+	var results []*ImportResult
+	seen := make(map[string]bool)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := reader.FieldPos(0)
+		if err != nil {
+			results = append(results, &ImportResult{Line: line, Err: err})
+			continue
+		}
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		result := &ImportResult{Line: line, Username: field("username")}
+		results = append(results, result)
+
+		row, err := s.parseImportRow(field)
+		if err == nil && seen[strings.ToLower(row.user.Username)] {
+			err = fmt.Errorf("duplicate username in CSV")
+		}
+		if err == nil {
+			var exists bool
+			if exists, err = s.usernameExists(ctx, row.user.Username); err == nil && exists {
+				err = fmt.Errorf("username already exists")
+			}
+		}
+		if err == nil {
+			var reserved bool
+			if reserved, err = s.usernameReserved(ctx, row.user.Username, 0); err == nil && reserved {
+				err = fmt.Errorf("username is reserved")
+			}
+		}
+		if err != nil {
+			result.Err = err
+			continue
+		}
+		seen[strings.ToLower(row.user.Username)] = true
+		if dryRun {
+			continue
+		}
+
+		if row.user.PasswordHash == "" && row.password == "" {
+			if row.password, err = generateTemporaryPassword(); err != nil {
+				return results, err
+			}
+			result.TemporaryPassword = row.password
+		}
+		if err := s.insertImportedUser(ctx, row); err != nil {
+			result.Err = err
+			result.TemporaryPassword = ""
+			continue
+		}
+		if err := s.recordModerationAction(ctx, importedBy, row.user.Username, "import", ""); err != nil {
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+// parseImportRow validates the fields of an imported account
+func (s *Service) parseImportRow(field func(string) string) (*importRow, error) {
+	row := &importRow{
+		user: User{
+			Username:     field("username"),
+			Email:        field("email"),
+			PasswordHash: field("password_hash"),
+			Salt:         field("salt"),
+		},
+		password: field("password"),
+	}
+
+	if errs := s.validateUsername(row.user.Username); len(errs) > 0 {
+		return nil, errors.New(errs[0].Message)
+	}
+	if errs := s.validateEmail(row.user.Email); len(errs) > 0 {
+		return nil, errors.New(errs[0].Message)
+	}
+
+	for _, name := range strings.Split(field("roles"), ";") {
+		if strings.TrimSpace(name) == "" {
+			continue
+		}
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, err
+		}
+		row.user.Flags |= role
+	}
+
+	if value := field("locked"); value != "" {
+		locked, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid locked value: %s", value)
+		}
+		row.user.AccountLocked = locked
+	}
+
+	switch {
+	case row.user.PasswordHash != "" || row.user.Salt != "":
+		if row.password != "" {
+			return nil, fmt.Errorf("give either password or password_hash, not both")
+		}
+		if !isHex(row.user.PasswordHash) || !isHex(row.user.Salt) {
+			return nil, fmt.Errorf("password_hash and salt must both be set and hex encoded")
+		}
+	case row.password != "":
+		if errs := s.validatePassword(row.password); len(errs) > 0 {
+			return nil, errors.New(errs[0].Message)
+		}
+	}
+	return row, nil
+}
+
+// insertImportedUser creates an imported account. Accounts given a password
+// or a temporary one must change it at first login; imported hashes are kept
+// as they are.
+func (s *Service) insertImportedUser(ctx context.Context, row *importRow) error {
+	user := row.user
+	if row.password != "" {
+		passwordHash, salt, err := s.hashPassword(row.password)
+		if err != nil {
+			return fmt.Errorf("failed to hash password: %w", err)
+		}
+		user.PasswordHash, user.Salt = passwordHash, salt
+		user.RequirePasswordChange = true
+	}
+
+	email, emailIndex, err := s.storedEmail(user.Email)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	query := `
+		INSERT INTO users (username, email, email_index, password_hash, salt, environment, flags,
+						  created_at, updated_at, is_active, email_verified, require_password_change,
+						  account_locked)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	if _, err := s.db.ExecContext(ctx, query,
+		user.Username, email, emailIndex, user.PasswordHash, user.Salt, "",
+		user.Flags, now, now, true, true, user.RequirePasswordChange,
+		user.AccountLocked); err != nil {
+		return fmt.Errorf("failed to insert user: %w", err)
+	}
+	return nil
+}
+
+// generateTemporaryPassword returns a random password for an imported account
+func generateTemporaryPassword() (string, error) {
+	secret := make([]byte, 8)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate temporary password: %w", err)
+	}
+	return hex.EncodeToString(secret), nil
+}
+
+// isHex reports whether value is non-empty and hex encoded
+func isHex(value string) bool {
+	if value == "" {
+		return false
+	}
+	_, err := hex.DecodeString(value)
+	return err == nil
+}
@@ -0,0 +1,214 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/database"
+)
+
+const (
+	// maxClientVersionLength bounds the client version string kept for a device
+	maxClientVersionLength = 100
+	// maxDeviceFingerprintLength bounds a key fingerprint
+	maxDeviceFingerprintLength = 100
+)
+
+// ErrDeviceNotFound is returned for devices that do not exist, belong to
+// someone else or were revoked
+var ErrDeviceNotFound = errors.New("device not found")
+
+// Device is a client a user has logged in from. It is known by the SSH
+// public key it offered, or by its client version string when it offered
+// none.
+type Device struct {
+	ID             int        `json:"id" db:"id"`
+	UserID         int        `json:"user_id" db:"user_id"`
+	KeyFingerprint string     `json:"key_fingerprint,omitempty" db:"key_fingerprint"`
+	ClientVersion  string     `json:"client_version" db:"client_version"`
+	LastIP         string     `json:"last_ip,omitempty" db:"last_ip"`
+	FirstSeenAt    time.Time  `json:"first_seen_at" db:"first_seen_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+// DeviceStore keeps the devices users log in from. The user database is
+// used unless another store is set with SetDeviceStore.
+type DeviceStore interface {
+	// Touch records a login from a device, creating the device when the
+	// user has no active one that matches, and reports whether it did
+	Touch(ctx context.Context, device *Device) (*Device, bool, error)
+	// Get returns one of the user's devices, revoked or not
+	Get(ctx context.Context, userID, deviceID int) (*Device, error)
+	// List returns the user's devices, revoked ones included, most recently
+	// seen first
+	List(ctx context.Context, userID int) ([]*Device, error)
+	// Revoke revokes one of the user's active devices
+	Revoke(ctx context.Context, userID, deviceID int) error
+}
+
+// SetDeviceStore sets where devices are kept
+func (s *Service) SetDeviceStore(store DeviceStore) {
+	s.devices = store
+}
+
+// RecordDevice records a login from a device and reports whether the
+// device is new to the user. Devices are matched by key fingerprint when
+// the client offered a key and by client version string otherwise; a
+// revoked device logging in again is a new device.
+func (s *Service) RecordDevice(ctx context.Context, userID int, keyFingerprint, clientVersion, clientIP string) (*Device, bool, error) {
+	keyFingerprint = strings.TrimSpace(keyFingerprint)
+	clientVersion = strings.TrimSpace(clientVersion)
+	if keyFingerprint == "" && clientVersion == "" {
+		return nil, false, fmt.Errorf("a key fingerprint or client version is required")
+	}
+	if len(keyFingerprint) > maxDeviceFingerprintLength {
+		return nil, false, fmt.Errorf("key fingerprint must be at most %d characters", maxDeviceFingerprintLength)
+	}
+	if len(clientVersion) > maxClientVersionLength {
+		clientVersion = clientVersion[:maxClientVersionLength]
+	}
+
+	return s.devices.Touch(ctx, &Device{
+		UserID:         userID,
+		KeyFingerprint: keyFingerprint,
+		ClientVersion:  clientVersion,
+		LastIP:         clientIP,
+		LastSeenAt:     time.Now(),
+	})
+}
+
+// GetDevice returns one of the user's devices
+func (s *Service) GetDevice(ctx context.Context, userID, deviceID int) (*Device, error) {
+	return s.devices.Get(ctx, userID, deviceID)
+}
+
+// ListDevices returns the user's devices, revoked ones included, most
+// recently seen first
+func (s *Service) ListDevices(ctx context.Context, userID int) ([]*Device, error) {
+	return s.devices.List(ctx, userID)
+}
+
+// RevokeDevice revokes one of the user's devices. Tokens issued to it stop
+// working, and its next login is that of a new device.
+func (s *Service) RevokeDevice(ctx context.Context, userID, deviceID int) error {
+	return s.devices.Revoke(ctx, userID, deviceID)
+}
+
+// sqlDeviceStore keeps devices in the user database
+type sqlDeviceStore struct {
+	db *database.Connection
+}
+
+// Touch records a login from a device
+func (d *sqlDeviceStore) Touch(ctx context.Context, device *Device) (*Device, bool, error) {
+	query := deviceSelect + ` WHERE user_id = ? AND key_fingerprint = ? AND revoked_at IS NULL`
+	args := []interface{}{device.UserID, device.KeyFingerprint}
+	if device.KeyFingerprint == "" {
+		query += ` AND client_version = ?`
+		args = append(args, device.ClientVersion)
+	}
+	known, err := scanDevice(d.db.QueryRowContext(ctx, query+` ORDER BY id DESC`, args...))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, false, err
+	}
+
+	if known != nil {
+		if _, err := d.db.ExecContext(ctx,
+			`UPDATE user_devices SET client_version = ?, last_ip = ?, last_seen_at = ? WHERE id = ?`,
+			device.ClientVersion, device.LastIP, device.LastSeenAt, known.ID); err != nil {
+			return nil, false, fmt.Errorf("failed to record device login: %w", err)
+		}
+		known.ClientVersion = device.ClientVersion
+		known.LastIP = device.LastIP
+		known.LastSeenAt = device.LastSeenAt
+		return known, false, nil
+	}
+
+	created := *device
+	created.FirstSeenAt = device.LastSeenAt
+	result, err := d.db.ExecContext(ctx, `
+		INSERT INTO user_devices (user_id, key_fingerprint, client_version, last_ip, first_seen_at, last_seen_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, created.UserID, created.KeyFingerprint, created.ClientVersion, created.LastIP, created.FirstSeenAt, created.LastSeenAt)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to record device: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get device ID: %w", err)
+	}
+	created.ID = int(id)
+	return &created, true, nil
+}
+
+// Get returns one of the user's devices
+func (d *sqlDeviceStore) Get(ctx context.Context, userID, deviceID int) (*Device, error) {
+	device, err := scanDevice(d.db.QueryRowContext(ctx,
+		deviceSelect+` WHERE id = ? AND user_id = ?`, deviceID, userID))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrDeviceNotFound
+	}
+	return device, err
+}
+
+// List returns the user's devices
+func (d *sqlDeviceStore) List(ctx context.Context, userID int) ([]*Device, error) {
+	rows, err := d.db.QueryContext(ctx,
+		deviceSelect+` WHERE user_id = ? ORDER BY last_seen_at DESC, id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query devices: %w", err)
+	}
+	defer rows.Close()
+
+	var devices []*Device
+	for rows.Next() {
+		device, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		devices = append(devices, device)
+	}
+
+	return devices, rows.Err()
+}
+
+// Revoke revokes one of the user's devices
+func (d *sqlDeviceStore) Revoke(ctx context.Context, userID, deviceID int) error {
+	result, err := d.db.ExecContext(ctx,
+		`UPDATE user_devices SET revoked_at = ? WHERE id = ? AND user_id = ? AND revoked_at IS NULL`,
+		time.Now(), deviceID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke device: %w", err)
+	}
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return ErrDeviceNotFound
+	}
+	return nil
+}
+
+// deviceSelect selects the columns scanDevice reads
+const deviceSelect = `SELECT id, user_id, key_fingerprint, client_version, last_ip, first_seen_at, last_seen_at, revoked_at FROM user_devices`
+
+// scanDevice reads a device row
+func scanDevice(row interface{ Scan(...interface{}) error }) (*Device, error) {
+	var device Device
+	var revoked sql.NullTime
+	err := row.Scan(&device.ID, &device.UserID, &device.KeyFingerprint, &device.ClientVersion,
+		&device.LastIP, &device.FirstSeenAt, &device.LastSeenAt, &revoked)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan device: %w", err)
+	}
+
+	if revoked.Valid {
+		device.RevokedAt = &revoked.Time
+	}
+	return &device, nil
+}
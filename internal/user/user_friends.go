@@ -0,0 +1,129 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// NotificationFriendPlaying tells a player a friend started a game
+	NotificationFriendPlaying = "friend_playing"
+	// FriendNotificationsPreference is "true" for a player who wants to be
+	// told when their friends start a game
+	FriendNotificationsPreference = "friend_notifications"
+	// maxFriendsPerUser bounds a user's friend list
+	maxFriendsPerUser = 100
+)
+
+// ErrNotFriends is returned when removing a friend the user never added
+var ErrNotFriends = errors.New("not on your friend list")
+
+// Friend is a user on another user's friend list. Friendship is one-way:
+// adding someone needs no consent, as they only learn what the who list
+// already shows.
+type Friend struct {
+	UserID   int       `json:"user_id" db:"friend_id"`
+	Username string    `json:"username" db:"username"`
+	AddedAt  time.Time `json:"added_at" db:"added_at"`
+}
+
+// AddFriend puts another user on the user's friend list
+func (s *Service) AddFriend(ctx context.Context, userID int, username string) (*Friend, error) {
+	friend, err := s.GetUserByUsername(ctx, strings.TrimSpace(username))
+	if err != nil || !friend.IsActive {
+		return nil, fmt.Errorf("user %s not found", username)
+	}
+	if friend.ID == userID {
+		return nil, fmt.Errorf("you cannot add yourself as a friend")
+	}
+
+	var count int
+	if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_friends WHERE user_id = ?", userID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to count friends: %w", err)
+	}
+	if count >= maxFriendsPerUser {
+		return nil, fmt.Errorf("friend list is full (%d friends)", maxFriendsPerUser)
+	}
+
+	added := &Friend{UserID: friend.ID, Username: friend.Username, AddedAt: time.Now()}
+	result, err := s.db.ExecContext(ctx,
+		"INSERT INTO user_friends (user_id, friend_id, added_at) VALUES (?, ?, ?) ON CONFLICT(user_id, friend_id) DO NOTHING",
+		userID, friend.ID, added.AddedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add friend: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return nil, fmt.Errorf("%s is already on your friend list", friend.Username)
+	}
+	return added, nil
+}
+
+// RemoveFriend takes a user off the user's friend list
+func (s *Service) RemoveFriend(ctx context.Context, userID int, username string) error {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM user_friends
+		WHERE user_id = ? AND friend_id = (SELECT id FROM users WHERE username = ?)
+	`, userID, strings.TrimSpace(username))
+	if err != nil {
+		return fmt.Errorf("failed to remove friend: %w", err)
+	}
+	if n, err := result.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFriends
+	}
+	return nil
+}
+
+// ListFriends returns the user's friends by username
+func (s *Service) ListFriends(ctx context.Context, userID int) ([]*Friend, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT f.friend_id, u.username, f.added_at
+		FROM user_friends f
+		JOIN users u ON u.id = f.friend_id
+		WHERE f.user_id = ? AND u.is_active = TRUE
+		ORDER BY LOWER(u.username)
+	`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query friends: %w", err)
+	}
+	defer rows.Close()
+
+	var friends []*Friend
+	for rows.Next() {
+		var friend Friend
+		if err := rows.Scan(&friend.UserID, &friend.Username, &friend.AddedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan friend: %w", err)
+		}
+		friends = append(friends, &friend)
+	}
+	return friends, rows.Err()
+}
+
+// NotifyFriendsOf leaves a notification for every active user who has the
+// given user as a friend and turned friend notifications on, returning how
+// many received it
+func (s *Service) NotifyFriendsOf(ctx context.Context, userID int, kind, title, body string) (int, error) {
+	title, body, err := validateNotification(kind, title, body)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, kind, title, body, created_at)
+		SELECT f.user_id, ?, ?, ?, ?
+		FROM user_friends f
+		JOIN users u ON u.id = f.user_id
+		JOIN user_preferences p ON p.user_id = f.user_id AND p.key = ? AND p.value = 'true'
+		WHERE f.friend_id = ? AND u.is_active = TRUE
+	`, kind, title, body, time.Now(), FriendNotificationsPreference, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to notify friends: %w", err)
+	}
+	sent, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notified friends: %w", err)
+	}
+	return int(sent), nil
+}
@@ -0,0 +1,255 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// minGroupNameLength and maxGroupNameLength bound a group name
+	minGroupNameLength = 3
+	maxGroupNameLength = 30
+	// maxGroupDescriptionLength bounds a group description
+	maxGroupDescriptionLength = 200
+)
+
+// groupNamePattern allows letters, digits, spaces, dashes and underscores
+var groupNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9 _-]*$`)
+
+// ErrNotInGroup is returned for group operations by a user who belongs to no group
+var ErrNotInGroup = errors.New("not a member of any group")
+
+// Group is a team of players, such as a clan. Each user belongs to at most
+// one group; the user who created it owns it until they leave.
+type Group struct {
+	ID          int       `json:"id" db:"id"`
+	Name        string    `json:"name" db:"name"`
+	Description string    `json:"description" db:"description"`
+	OwnerID     int       `json:"owner_id" db:"owner_id"`
+	Owner       string    `json:"owner" db:"owner"`
+	MemberCount int       `json:"member_count" db:"member_count"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// GroupMember is a user's membership of a group
+type GroupMember struct {
+	UserID   int       `json:"user_id" db:"user_id"`
+	Username string    `json:"username" db:"username"`
+	JoinedAt time.Time `json:"joined_at" db:"joined_at"`
+}
+
+// ValidateGroupName checks that a group name is usable
+func ValidateGroupName(name string) error {
+	if len(name) < minGroupNameLength || len(name) > maxGroupNameLength {
+		return fmt.Errorf("group name must be %d to %d characters", minGroupNameLength, maxGroupNameLength)
+	}
+	if !groupNamePattern.MatchString(name) {
+		return fmt.Errorf("group name may only contain letters, digits, spaces, dashes and underscores")
+	}
+	return nil
+}
+
+// CreateGroup creates a group owned by the user, who becomes its first member
+func (s *Service) CreateGroup(ctx context.Context, ownerID int, name, description string) (*Group, error) {
+	name = strings.TrimSpace(name)
+	if err := ValidateGroupName(name); err != nil {
+		return nil, err
+	}
+	if len(description) > maxGroupDescriptionLength {
+		return nil, fmt.Errorf("group description is longer than %d characters", maxGroupDescriptionLength)
+	}
+
+	if current, err := s.GetUserGroup(ctx, ownerID); err == nil {
+		return nil, fmt.Errorf("already a member of %s", current.Name)
+	} else if !errors.Is(err, ErrNotInGroup) {
+		return nil, err
+	}
+	if _, err := s.GetGroup(ctx, name); err == nil {
+		return nil, fmt.Errorf("group %s already exists", name)
+	}
+
+	tx, err := s.db.Transaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO user_groups (name, description, owner_id) VALUES (?, ?, ?)", name, description, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create group: %w", err)
+	}
+	groupID, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group ID: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "INSERT INTO user_group_members (user_id, group_id) VALUES (?, ?)", ownerID, groupID); err != nil {
+		return nil, fmt.Errorf("failed to add group owner: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit group: %w", err)
+	}
+
+	return s.GetGroup(ctx, name)
+}
+
+// JoinGroup adds the user to a group. Users must leave their current group first.
+func (s *Service) JoinGroup(ctx context.Context, userID int, name string) (*Group, error) {
+	group, err := s.GetGroup(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if current, err := s.GetUserGroup(ctx, userID); err == nil {
+		return nil, fmt.Errorf("already a member of %s", current.Name)
+	} else if !errors.Is(err, ErrNotInGroup) {
+		return nil, err
+	}
+
+	if _, err := s.db.ExecContext(ctx, "INSERT INTO user_group_members (user_id, group_id) VALUES (?, ?)", userID, group.ID); err != nil {
+		return nil, fmt.Errorf("failed to join group: %w", err)
+	}
+
+	return s.GetGroup(ctx, group.Name)
+}
+
+// LeaveGroup removes the user from their group and returns it. An owner who
+// leaves hands the group to its longest-standing member, and the last member
+// to leave deletes it.
+func (s *Service) LeaveGroup(ctx context.Context, userID int) (*Group, error) {
+	group, err := s.GetUserGroup(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.Transaction(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM user_group_members WHERE user_id = ?", userID); err != nil {
+		return nil, fmt.Errorf("failed to leave group: %w", err)
+	}
+
+	if group.OwnerID == userID {
+		var successor int
+		err := tx.QueryRowContext(ctx, `
+			SELECT m.user_id FROM user_group_members m
+			JOIN users u ON u.id = m.user_id
+			WHERE m.group_id = ?
+			ORDER BY m.joined_at, m.user_id
+			LIMIT 1
+		`, group.ID).Scan(&successor)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			if _, err := tx.ExecContext(ctx, "DELETE FROM user_group_members WHERE group_id = ?", group.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove group members: %w", err)
+			}
+			if _, err := tx.ExecContext(ctx, "DELETE FROM user_groups WHERE id = ?", group.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete group: %w", err)
+			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to find new group owner: %w", err)
+		default:
+			if _, err := tx.ExecContext(ctx, "UPDATE user_groups SET owner_id = ? WHERE id = ?", successor, group.ID); err != nil {
+				return nil, fmt.Errorf("failed to transfer group ownership: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit leaving group: %w", err)
+	}
+	return group, nil
+}
+
+// GetGroup returns a group by name, ignoring case
+func (s *Service) GetGroup(ctx context.Context, name string) (*Group, error) {
+	groups, err := s.queryGroups(ctx, "WHERE g.name = ?", strings.TrimSpace(name))
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("group not found: %s", name)
+	}
+	return groups[0], nil
+}
+
+// GetUserGroup returns the group the user belongs to, or ErrNotInGroup
+func (s *Service) GetUserGroup(ctx context.Context, userID int) (*Group, error) {
+	groups, err := s.queryGroups(ctx, "WHERE g.id = (SELECT group_id FROM user_group_members WHERE user_id = ?)", userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(groups) == 0 {
+		return nil, ErrNotInGroup
+	}
+	return groups[0], nil
+}
+
+// ListGroups returns every group, largest first
+func (s *Service) ListGroups(ctx context.Context) ([]*Group, error) {
+	return s.queryGroups(ctx, "")
+}
+
+// ListGroupMembers returns the members of a group, longest-standing first
+func (s *Service) ListGroupMembers(ctx context.Context, groupID int) ([]*GroupMember, error) {
+	query := `
+		SELECT m.user_id, u.username, m.joined_at
+		FROM user_group_members m
+		JOIN users u ON u.id = m.user_id
+		WHERE m.group_id = ?
+		ORDER BY m.joined_at, m.user_id
+	`
+	rows, err := s.db.QueryContext(ctx, query, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query group members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*GroupMember
+	for rows.Next() {
+		var member GroupMember
+		if err := rows.Scan(&member.UserID, &member.Username, &member.JoinedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan group member: %w", err)
+		}
+		members = append(members, &member)
+	}
+
+	return members, rows.Err()
+}
+
+// queryGroups returns the groups matching a WHERE clause with their owner and member count
+func (s *Service) queryGroups(ctx context.Context, where string, args ...interface{}) ([]*Group, error) {
+	query := `
+		SELECT g.id, g.name, g.description, g.owner_id, COALESCE(o.username, ''), g.created_at,
+			(SELECT COUNT(*) FROM user_group_members m JOIN users u ON u.id = m.user_id WHERE m.group_id = g.id)
+		FROM user_groups g
+		LEFT JOIN users o ON o.id = g.owner_id
+		` + where + `
+		ORDER BY 7 DESC, g.name
+	`
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query groups: %w", err)
+	}
+	defer rows.Close()
+
+	var groups []*Group
+	for rows.Next() {
+		var group Group
+		var description sql.NullString
+		if err := rows.Scan(&group.ID, &group.Name, &description, &group.OwnerID, &group.Owner, &group.CreatedAt, &group.MemberCount); err != nil {
+			return nil, fmt.Errorf("failed to scan group: %w", err)
+		}
+		group.Description = description.String
+		groups = append(groups, &group)
+	}
+
+	return groups, rows.Err()
+}
@@ -0,0 +1,80 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// AccountAccess records an admin impersonating a user for support. Users are
+// shown these entries so support access to their account is never silent.
+type AccountAccess struct {
+	ID            int       `json:"id" db:"id"`
+	UserID        int       `json:"user_id" db:"user_id"`
+	AdminUsername string    `json:"admin_username" db:"admin_username"`
+	Reason        string    `json:"reason" db:"reason"`
+	ExpiresAt     time.Time `json:"expires_at" db:"expires_at"`
+	Seen          bool      `json:"seen" db:"seen"`
+	CreatedAt     time.Time `json:"created_at" db:"created_at"`
+}
+
+// RecordImpersonation records an admin impersonating a user until expiresAt,
+// both in the user's account access log and the moderation audit log
+func (s *Service) RecordImpersonation(ctx context.Context, admin string, target *User, reason string, expiresAt time.Time) error {
+	if reason == "" {
+		return fmt.Errorf("reason cannot be empty")
+	}
+
+	query := "INSERT INTO account_access_log (user_id, admin_username, reason, expires_at) VALUES (?, ?, ?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, target.ID, admin, reason, expiresAt); err != nil {
+		return fmt.Errorf("failed to record account access: %w", err)
+	}
+
+	detail := fmt.Sprintf("until %s: %s", expiresAt.UTC().Format(time.RFC3339), reason)
+	return s.recordModerationAction(ctx, admin, target.Username, "impersonate", detail)
+}
+
+// ListAccountAccess returns the support access to a user's account, newest first
+func (s *Service) ListAccountAccess(ctx context.Context, userID int) ([]*AccountAccess, error) {
+	query := `
+		SELECT id, user_id, admin_username, reason, expires_at, seen, created_at
+		FROM account_access_log
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account access: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*AccountAccess
+	for rows.Next() {
+		var entry AccountAccess
+		if err := rows.Scan(&entry.ID, &entry.UserID, &entry.AdminUsername, &entry.Reason, &entry.ExpiresAt, &entry.Seen, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account access: %w", err)
+		}
+		entries = append(entries, &entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// CountUnseenAccountAccess returns how many support accesses the user has not been shown yet
+func (s *Service) CountUnseenAccountAccess(ctx context.Context, userID int) (int, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM account_access_log WHERE user_id = ? AND seen = FALSE"
+	if err := s.db.QueryRowContext(ctx, query, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count account access: %w", err)
+	}
+	return count, nil
+}
+
+// MarkAccountAccessSeen records that the user has been shown their account access log
+func (s *Service) MarkAccountAccessSeen(ctx context.Context, userID int) error {
+	query := "UPDATE account_access_log SET seen = TRUE WHERE user_id = ? AND seen = FALSE"
+	if _, err := s.db.ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to mark account access seen: %w", err)
+	}
+	return nil
+}
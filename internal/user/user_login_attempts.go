@@ -75,3 +75,25 @@ func (s *Service) getLockDuration() time.Duration {
 	}
 	return 15 * time.Minute // Default to 15 minutes
 }
+
+// UnlockExpiredAccounts clears the locks that have run out, returning how
+// many accounts were unlocked. Logins already ignore expired locks; this
+// keeps the stored state and admin statistics accurate.
+func (s *Service) UnlockExpiredAccounts(ctx context.Context) (int, error) {
+	result, err := s.db.ExecContext(ctx, `
+		UPDATE users
+		SET account_locked = FALSE,
+			locked_until = NULL,
+			failed_login_attempts = 0
+		WHERE account_locked = TRUE AND locked_until IS NOT NULL AND locked_until <= ?
+	`, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to unlock expired accounts: %w", err)
+	}
+
+	unlocked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return int(unlocked), nil
+}
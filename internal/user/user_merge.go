@@ -0,0 +1,180 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Ways ownership of an account being merged can be proven
+const (
+	MergeVerifiedByPassword = "password"
+	MergeVerifiedByToken    = "token"
+)
+
+// MergeRequest describes merging one account into another
+type MergeRequest struct {
+	SurvivorID int
+	MergedID   int
+	// MergedBy is who asked for the merge, for the audit trail
+	MergedBy string
+	// VerifiedBy is how ownership of the merged account was proven
+	VerifiedBy string
+	DryRun     bool
+}
+
+// MergeSummary counts what a merge moves, or in a dry run would move, to
+// the surviving account
+type MergeSummary struct {
+	SurvivorUsername string
+	MergedUsername   string
+
+	Preferences     int
+	APIKeys         int
+	Notifications   int
+	Notes           int
+	ModerationFlags int
+	AccessLog       int
+	OwnedGroups     int
+	// Group is the group the surviving account joins, empty if none
+	Group string
+
+	// SkippedPreferences are the merged account's preferences the surviving
+	// account already sets, which keep the surviving account's value
+	SkippedPreferences int
+}
+
+// mergeMove moves the rows of one table from the merged account to the
+// survivor. With key set, rows are unique per user and key, and those whose
+// key the survivor already has stay behind.
+type mergeMove struct {
+	table  string
+	column string
+	key    string
+	dst    func(*MergeSummary) *int
+}
+
+// mergeMoves are the per-user rows a merge moves
+var mergeMoves = []mergeMove{
+	{table: "user_preferences", column: "user_id", key: "key", dst: func(m *MergeSummary) *int { return &m.Preferences }},
+	{table: "user_moderation_flags", column: "user_id", key: "flag", dst: func(m *MergeSummary) *int { return &m.ModerationFlags }},
+	{table: "api_keys", column: "user_id", dst: func(m *MergeSummary) *int { return &m.APIKeys }},
+	{table: "notifications", column: "user_id", dst: func(m *MergeSummary) *int { return &m.Notifications }},
+	{table: "user_notes", column: "user_id", dst: func(m *MergeSummary) *int { return &m.Notes }},
+	{table: "account_access_log", column: "user_id", dst: func(m *MergeSummary) *int { return &m.AccessLog }},
+	{table: "user_groups", column: "owner_id", dst: func(m *MergeSummary) *int { return &m.OwnedGroups }},
+}
+
+// condition returns the WHERE clause selecting the rows to move, and its
+// arguments
+func (m mergeMove) condition(mergedID, survivorID int) (string, []interface{}) {
+	if m.key == "" {
+		return m.column + " = ?", []interface{}{mergedID}
+	}
+	return fmt.Sprintf("%[1]s = ? AND %[2]s NOT IN (SELECT %[2]s FROM %[3]s WHERE %[1]s = ?)", m.column, m.key, m.table),
+		[]interface{}{mergedID, survivorID}
+}
+
+// MergeAccounts moves the merged account's preferences, API keys,
+// notifications, moderation history and group into the surviving account,
+// then deactivates the merged account so it can no longer log in. The merge
+// is recorded in account_merges and the moderation audit log. Game data is
+// kept by the game service and merged there.
+func (s *Service) MergeAccounts(ctx context.Context, req *MergeRequest) (*MergeSummary, error) {
+	if req.SurvivorID == req.MergedID {
+		return nil, fmt.Errorf("cannot merge an account into itself")
+	}
+
+	var summary *MergeSummary
+	err := s.db.WithTransaction(ctx, func(ctx context.Context) error {
+		survivor, err := s.GetUserByID(ctx, req.SurvivorID)
+		if err != nil {
+			return err
+		}
+		merged, err := s.GetUserByID(ctx, req.MergedID)
+		if err != nil {
+			return err
+		}
+		if !survivor.IsActive || !merged.IsActive {
+			return fmt.Errorf("both accounts must be active")
+		}
+		if merged.IsAdmin() && !survivor.IsAdmin() {
+			return fmt.Errorf("an admin account can only be merged into another admin account")
+		}
+
+		summary = &MergeSummary{SurvivorUsername: survivor.Username, MergedUsername: merged.Username}
+		for _, move := range mergeMoves {
+			where, args := move.condition(merged.ID, survivor.ID)
+			query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s", move.table, where)
+			if err := s.db.QueryRowContext(ctx, query, args...).Scan(move.dst(summary)); err != nil {
+				return fmt.Errorf("failed to count %s to merge: %w", move.table, err)
+			}
+		}
+
+		var preferences int
+		if err := s.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_preferences WHERE user_id = ?", merged.ID).Scan(&preferences); err != nil {
+			return fmt.Errorf("failed to count preferences: %w", err)
+		}
+		summary.SkippedPreferences = preferences - summary.Preferences
+
+		// The survivor joins the merged account's group unless it has its own
+		if _, err := s.GetUserGroup(ctx, survivor.ID); errors.Is(err, ErrNotInGroup) {
+			if group, err := s.GetUserGroup(ctx, merged.ID); err == nil {
+				summary.Group = group.Name
+			} else if !errors.Is(err, ErrNotInGroup) {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+
+		if req.DryRun {
+			return nil
+		}
+
+		for _, move := range mergeMoves {
+			where, args := move.condition(merged.ID, survivor.ID)
+			query := fmt.Sprintf("UPDATE %s SET %s = ? WHERE %s", move.table, move.column, where)
+			if _, err := s.db.ExecContext(ctx, query, append([]interface{}{survivor.ID}, args...)...); err != nil {
+				return fmt.Errorf("failed to merge %s: %w", move.table, err)
+			}
+		}
+		if summary.Group != "" {
+			if _, err := s.db.ExecContext(ctx, "UPDATE user_group_members SET user_id = ? WHERE user_id = ?",
+				survivor.ID, merged.ID); err != nil {
+				return fmt.Errorf("failed to move group membership: %w", err)
+			}
+		}
+		// The merged account leaves any group it still belongs to
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM user_group_members WHERE user_id = ?", merged.ID); err != nil {
+			return fmt.Errorf("failed to leave group: %w", err)
+		}
+
+		now := time.Now()
+		if _, err := s.db.ExecContext(ctx, "UPDATE users SET is_active = FALSE, updated_at = ? WHERE id = ?",
+			now, merged.ID); err != nil {
+			return fmt.Errorf("failed to deactivate merged account: %w", err)
+		}
+
+		query := `
+			INSERT INTO account_merges (survivor_id, merged_id, merged_username, merged_by, verified_by, merged_at)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`
+		if _, err := s.db.ExecContext(ctx, query,
+			survivor.ID, merged.ID, merged.Username, req.MergedBy, req.VerifiedBy, now); err != nil {
+			return fmt.Errorf("failed to record account merge: %w", err)
+		}
+
+		detail := fmt.Sprintf("into %s, ownership verified by %s", survivor.Username, req.VerifiedBy)
+		if err := s.recordModerationAction(ctx, req.MergedBy, merged.Username, "merge", detail); err != nil {
+			return err
+		}
+		detail = fmt.Sprintf("absorbed %s, ownership verified by %s", merged.Username, req.VerifiedBy)
+		return s.recordModerationAction(ctx, req.MergedBy, survivor.Username, "merge", detail)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
@@ -0,0 +1,235 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ModerationFlag is a moderator-set marker on a user account
+type ModerationFlag string
+
+const (
+	// ModerationFlagWarned records that the user has been formally warned
+	ModerationFlagWarned ModerationFlag = "warned"
+	// ModerationFlagRestricted limits the user to playing their own games; they may not spectate
+	ModerationFlagRestricted ModerationFlag = "restricted"
+	// ModerationFlagPlayTimeExempt lifts the user's play-time limits
+	ModerationFlagPlayTimeExempt ModerationFlag = "play_time_exempt"
+)
+
+// ModerationFlags lists every supported moderation flag
+var ModerationFlags = []ModerationFlag{
+	ModerationFlagWarned,
+	ModerationFlagRestricted,
+	ModerationFlagPlayTimeExempt,
+}
+
+// ParseModerationFlag validates a moderation flag name
+func ParseModerationFlag(name string) (ModerationFlag, error) {
+	for _, flag := range ModerationFlags {
+		if string(flag) == name {
+			return flag, nil
+		}
+	}
+	return "", fmt.Errorf("unknown moderation flag: %s", name)
+}
+
+// UserNote is a moderator note attached to a user account
+type UserNote struct {
+	ID        int       `json:"id" db:"id"`
+	UserID    int       `json:"user_id" db:"user_id"`
+	Author    string    `json:"author" db:"author"`
+	Note      string    `json:"note" db:"note"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// UserModerationFlag is a moderation flag set on a user account
+type UserModerationFlag struct {
+	UserID    int            `json:"user_id" db:"user_id"`
+	Username  string         `json:"username" db:"username"`
+	Flag      ModerationFlag `json:"flag" db:"flag"`
+	Reason    string         `json:"reason,omitempty" db:"reason"`
+	SetBy     string         `json:"set_by" db:"set_by"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// IsModerator checks if a user has moderator privileges. Admins are always moderators.
+func (u *User) IsModerator() bool {
+	return (u.Flags&UserFlagModerator) != 0 || u.IsAdmin()
+}
+
+// AddUserNote attaches a moderator note to a user account
+func (s *Service) AddUserNote(ctx context.Context, username, author, note string) error {
+	if note == "" {
+		return fmt.Errorf("note cannot be empty")
+	}
+
+	target, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user not found: %s", username)
+	}
+
+	query := "INSERT INTO user_notes (user_id, author, note) VALUES (?, ?, ?)"
+	if _, err := s.db.ExecContext(ctx, query, target.ID, author, note); err != nil {
+		return fmt.Errorf("failed to add user note: %w", err)
+	}
+
+	return s.recordModerationAction(ctx, author, username, "add_note", note)
+}
+
+// ListUserNotes returns the notes on a user account, newest first
+func (s *Service) ListUserNotes(ctx context.Context, username string) ([]*UserNote, error) {
+	target, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %s", username)
+	}
+
+	query := `
+		SELECT id, user_id, author, note, created_at
+		FROM user_notes
+		WHERE user_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user notes: %w", err)
+	}
+	defer rows.Close()
+
+	var notes []*UserNote
+	for rows.Next() {
+		var note UserNote
+		if err := rows.Scan(&note.ID, &note.UserID, &note.Author, &note.Note, &note.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan user note: %w", err)
+		}
+		notes = append(notes, &note)
+	}
+
+	return notes, rows.Err()
+}
+
+// SetModerationFlag sets a moderation flag on a user account, replacing the
+// reason if the flag is already set
+func (s *Service) SetModerationFlag(ctx context.Context, username string, flag ModerationFlag, setBy, reason string) error {
+	if _, err := ParseModerationFlag(string(flag)); err != nil {
+		return err
+	}
+
+	target, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user not found: %s", username)
+	}
+
+	query := `
+		INSERT INTO user_moderation_flags (user_id, flag, reason, set_by)
+		VALUES (?, ?, ?, ?)
+		ON CONFLICT (user_id, flag) DO UPDATE SET
+			reason = excluded.reason,
+			set_by = excluded.set_by,
+			created_at = CURRENT_TIMESTAMP
+	`
+	if _, err := s.db.ExecContext(ctx, query, target.ID, string(flag), reason, setBy); err != nil {
+		return fmt.Errorf("failed to set moderation flag: %w", err)
+	}
+
+	return s.recordModerationAction(ctx, setBy, username, "set_flag", fmt.Sprintf("%s: %s", flag, reason))
+}
+
+// ClearModerationFlag removes a moderation flag from a user account
+func (s *Service) ClearModerationFlag(ctx context.Context, username string, flag ModerationFlag, clearedBy string) error {
+	target, err := s.GetUserByUsername(ctx, username)
+	if err != nil {
+		return fmt.Errorf("user not found: %s", username)
+	}
+
+	query := "DELETE FROM user_moderation_flags WHERE user_id = ? AND flag = ?"
+	result, err := s.db.ExecContext(ctx, query, target.ID, string(flag))
+	if err != nil {
+		return fmt.Errorf("failed to clear moderation flag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user %s does not have the %s flag", username, flag)
+	}
+
+	return s.recordModerationAction(ctx, clearedBy, username, "clear_flag", string(flag))
+}
+
+// GetModerationFlags returns the moderation flags set on a user account
+func (s *Service) GetModerationFlags(ctx context.Context, userID int) ([]*UserModerationFlag, error) {
+	query := `
+		SELECT f.user_id, u.username, f.flag, f.reason, f.set_by, f.created_at
+		FROM user_moderation_flags f
+		JOIN users u ON u.id = f.user_id
+		WHERE f.user_id = ?
+		ORDER BY f.flag
+	`
+	return s.queryModerationFlags(ctx, query, userID)
+}
+
+// HasModerationFlag reports whether a user has the given moderation flag set
+func (s *Service) HasModerationFlag(ctx context.Context, userID int, flag ModerationFlag) (bool, error) {
+	var count int
+	query := "SELECT COUNT(*) FROM user_moderation_flags WHERE user_id = ? AND flag = ?"
+	if err := s.db.QueryRowContext(ctx, query, userID, string(flag)).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check moderation flag: %w", err)
+	}
+	return count > 0, nil
+}
+
+// ListUsersByModerationFlag returns every user with the given flag set, most recently flagged first
+func (s *Service) ListUsersByModerationFlag(ctx context.Context, flag ModerationFlag) ([]*UserModerationFlag, error) {
+	if _, err := ParseModerationFlag(string(flag)); err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT f.user_id, u.username, f.flag, f.reason, f.set_by, f.created_at
+		FROM user_moderation_flags f
+		JOIN users u ON u.id = f.user_id
+		WHERE f.flag = ?
+		ORDER BY f.created_at DESC, u.username
+	`
+	return s.queryModerationFlags(ctx, query, string(flag))
+}
+
+// queryModerationFlags scans user_moderation_flags rows joined with the username
+func (s *Service) queryModerationFlags(ctx context.Context, query string, args ...interface{}) ([]*UserModerationFlag, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query moderation flags: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []*UserModerationFlag
+	for rows.Next() {
+		var flag UserModerationFlag
+		var reason sql.NullString
+		if err := rows.Scan(&flag.UserID, &flag.Username, &flag.Flag, &reason, &flag.SetBy, &flag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan moderation flag: %w", err)
+		}
+		flag.Reason = reason.String
+		flags = append(flags, &flag)
+	}
+
+	return flags, rows.Err()
+}
+
+// recordModerationAction appends an entry to the moderation audit log
+func (s *Service) recordModerationAction(ctx context.Context, actor, target, action, detail string) error {
+	query := `
+		INSERT INTO moderation_audit_log (actor, target_username, action, detail)
+		VALUES (?, ?, ?, ?)
+	`
+	if _, err := s.db.ExecContext(ctx, query, actor, target, action, detail); err != nil {
+		return fmt.Errorf("failed to record moderation action: %w", err)
+	}
+	return nil
+}
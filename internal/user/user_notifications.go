@@ -0,0 +1,193 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Notification kinds
+const (
+	NotificationGameCrashed  = "game_crashed"
+	NotificationPromoted     = "promoted"
+	NotificationAnnouncement = "announcement"
+	NotificationNewDevice    = "new_device"
+)
+
+const (
+	// maxNotificationTitleLength bounds a notification title
+	maxNotificationTitleLength = 100
+	// maxNotificationBodyLength bounds a notification body
+	maxNotificationBodyLength = 2000
+	// maxNotificationsPerUser is how many notifications are kept per user;
+	// the oldest read ones are dropped beyond it
+	maxNotificationsPerUser = 100
+)
+
+// Notification is a message left for a user, shown the next time they look
+type Notification struct {
+	ID        int        `json:"id" db:"id"`
+	UserID    int        `json:"user_id" db:"user_id"`
+	Kind      string     `json:"kind" db:"kind"`
+	Title     string     `json:"title" db:"title"`
+	Body      string     `json:"body" db:"body"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty" db:"read_at"`
+}
+
+// validateNotification checks and trims a notification's text
+func validateNotification(kind, title, body string) (string, string, error) {
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+	if kind == "" {
+		return "", "", fmt.Errorf("notification kind is required")
+	}
+	if title == "" || len(title) > maxNotificationTitleLength {
+		return "", "", fmt.Errorf("notification title must be 1 to %d characters", maxNotificationTitleLength)
+	}
+	if len(body) > maxNotificationBodyLength {
+		return "", "", fmt.Errorf("notification body must be at most %d characters", maxNotificationBodyLength)
+	}
+	return title, body, nil
+}
+
+// CreateNotification leaves a notification for a user
+func (s *Service) CreateNotification(ctx context.Context, userID int, kind, title, body string) (*Notification, error) {
+	title, body, err := validateNotification(kind, title, body)
+	if err != nil {
+		return nil, err
+	}
+
+	notification := &Notification{
+		UserID:    userID,
+		Kind:      kind,
+		Title:     title,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO notifications (user_id, kind, title, body, created_at) VALUES (?, ?, ?, ?, ?)`,
+		userID, kind, title, body, notification.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification ID: %w", err)
+	}
+	notification.ID = int(id)
+
+	if err := s.pruneNotifications(ctx, userID); err != nil {
+		return nil, err
+	}
+	return notification, nil
+}
+
+// BroadcastNotification leaves a notification for every active user,
+// returning how many received it
+func (s *Service) BroadcastNotification(ctx context.Context, kind, title, body string) (int, error) {
+	title, body, err := validateNotification(kind, title, body)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := s.db.ExecContext(ctx, `
+		INSERT INTO notifications (user_id, kind, title, body, created_at)
+		SELECT id, ?, ?, ?, ? FROM users WHERE is_active = TRUE
+	`, kind, title, body, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to broadcast notification: %w", err)
+	}
+	sent, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notified users: %w", err)
+	}
+	return int(sent), nil
+}
+
+// pruneNotifications drops a user's oldest read notifications beyond the
+// number kept
+func (s *Service) pruneNotifications(ctx context.Context, userID int) error {
+	_, err := s.db.ExecContext(ctx, `
+		DELETE FROM notifications
+		WHERE user_id = ? AND read_at IS NOT NULL AND id NOT IN (
+			SELECT id FROM notifications WHERE user_id = ? ORDER BY id DESC LIMIT ?
+		)
+	`, userID, userID, maxNotificationsPerUser)
+	if err != nil {
+		return fmt.Errorf("failed to prune notifications: %w", err)
+	}
+	return nil
+}
+
+// ListNotifications returns up to limit of the user's notifications, newest
+// first, optionally only the unread ones
+func (s *Service) ListNotifications(ctx context.Context, userID int, unreadOnly bool, limit int) ([]*Notification, error) {
+	if limit <= 0 || limit > maxNotificationsPerUser {
+		limit = maxNotificationsPerUser
+	}
+
+	query := `SELECT id, user_id, kind, title, body, created_at, read_at FROM notifications WHERE user_id = ?`
+	if unreadOnly {
+		query += ` AND read_at IS NULL`
+	}
+	query += ` ORDER BY created_at DESC, id DESC LIMIT ?`
+
+	rows, err := s.db.QueryContext(ctx, query, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*Notification
+	for rows.Next() {
+		var n Notification
+		var readAt sql.NullTime
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Kind, &n.Title, &n.Body, &n.CreatedAt, &readAt); err != nil {
+			return nil, fmt.Errorf("failed to scan notification: %w", err)
+		}
+		if readAt.Valid {
+			n.ReadAt = &readAt.Time
+		}
+		notifications = append(notifications, &n)
+	}
+
+	return notifications, rows.Err()
+}
+
+// CountUnreadNotifications returns how many notifications the user has not read
+func (s *Service) CountUnreadNotifications(ctx context.Context, userID int) (int, error) {
+	var unread int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM notifications WHERE user_id = ? AND read_at IS NULL`, userID,
+	).Scan(&unread)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count notifications: %w", err)
+	}
+	return unread, nil
+}
+
+// MarkNotificationsRead marks the user's notifications with the given IDs as
+// read, or all of them when ids is empty. It returns how many were marked.
+func (s *Service) MarkNotificationsRead(ctx context.Context, userID int, ids []int) (int, error) {
+	query := `UPDATE notifications SET read_at = ? WHERE user_id = ? AND read_at IS NULL`
+	args := []interface{}{time.Now(), userID}
+	if len(ids) > 0 {
+		query += ` AND id IN (?` + strings.Repeat(`, ?`, len(ids)-1) + `)`
+		for _, id := range ids {
+			args = append(args, id)
+		}
+	}
+
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to mark notifications read: %w", err)
+	}
+	marked, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count marked notifications: %w", err)
+	}
+	return int(marked), nil
+}
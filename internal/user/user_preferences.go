@@ -0,0 +1,83 @@
+package user
+
+import (
+	"context"
+	"fmt"
+)
+
+const (
+	// maxPreferenceKeyLength matches the key column of user_preferences
+	maxPreferenceKeyLength = 100
+	// maxPreferenceValueLength bounds a single preference value
+	maxPreferenceValueLength = 1024
+)
+
+// GetPreferences returns all of a user's stored preferences
+func (s *Service) GetPreferences(ctx context.Context, userID int) (map[string]string, error) {
+	query := `SELECT key, value FROM user_preferences WHERE user_id = ?`
+
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query user preferences: %w", err)
+	}
+	defer rows.Close()
+
+	preferences := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan user preference: %w", err)
+		}
+		preferences[key] = value
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read user preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+// SetPreference stores one of a user's preferences; an empty value removes it
+func (s *Service) SetPreference(ctx context.Context, userID int, key, value string) error {
+	if err := ValidatePreferenceKey(key); err != nil {
+		return err
+	}
+	if len(value) > maxPreferenceValueLength {
+		return fmt.Errorf("preference value is longer than %d bytes", maxPreferenceValueLength)
+	}
+
+	if value == "" {
+		if _, err := s.db.ExecContext(ctx, `DELETE FROM user_preferences WHERE user_id = ? AND key = ?`, userID, key); err != nil {
+			return fmt.Errorf("failed to remove user preference: %w", err)
+		}
+		return nil
+	}
+
+	query := `
+		INSERT INTO user_preferences (user_id, key, value)
+		VALUES (?, ?, ?)
+		ON CONFLICT(user_id, key) DO UPDATE SET value = excluded.value
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, key, value); err != nil {
+		return fmt.Errorf("failed to update user preference: %w", err)
+	}
+
+	return nil
+}
+
+// ValidatePreferenceKey checks that a preference key is made of lowercase
+// letters, digits, dots, dashes and underscores, such as game_version.nethack
+func ValidatePreferenceKey(key string) error {
+	if key == "" || len(key) > maxPreferenceKeyLength {
+		return fmt.Errorf("preference key must be 1 to %d characters", maxPreferenceKeyLength)
+	}
+	for _, r := range key {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+		default:
+			return fmt.Errorf("preference key %q contains invalid characters", key)
+		}
+	}
+	return nil
+}
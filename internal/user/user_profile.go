@@ -0,0 +1,89 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// defaultUserProfile returns the profile used for users without a stored profile row
+func defaultUserProfile(userID int) *UserProfile {
+	return &UserProfile{
+		UserID:             userID,
+		Timezone:           "UTC",
+		Language:           "en",
+		Theme:              "dark",
+		TerminalSize:       "80x24",
+		ColorMode:          "color",
+		EmailNotifications: true,
+		PublicProfile:      false,
+		AllowSpectators:    true,
+		ShowOnlineStatus:   true,
+	}
+}
+
+// GetUserProfile retrieves the profile for a user, falling back to defaults if none is stored
+func (s *Service) GetUserProfile(ctx context.Context, userID int) (*UserProfile, error) {
+	query := `
+		SELECT user_id, real_name, location, website, bio, avatar_url, timezone, language,
+			   theme, terminal_size, color_mode, email_notifications, public_profile,
+			   allow_spectators, show_online_status
+		FROM user_profiles
+		WHERE user_id = ?
+	`
+
+	var profile UserProfile
+	var realName, location, website, bio, avatarURL sql.NullString
+
+	err := s.db.QueryRowContext(ctx, query, userID).Scan(
+		&profile.UserID, &realName, &location, &website, &bio, &avatarURL,
+		&profile.Timezone, &profile.Language, &profile.Theme, &profile.TerminalSize,
+		&profile.ColorMode, &profile.EmailNotifications, &profile.PublicProfile,
+		&profile.AllowSpectators, &profile.ShowOnlineStatus,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return defaultUserProfile(userID), nil
+		}
+		return nil, fmt.Errorf("failed to query user profile: %w", err)
+	}
+
+	profile.RealName = realName.String
+	profile.Location = location.String
+	profile.Website = website.String
+	profile.Bio = bio.String
+	profile.AvatarURL = avatarURL.String
+
+	return &profile, nil
+}
+
+// SetShowOnlineStatus updates whether the user appears in the who list and
+// in their friends' notifications
+func (s *Service) SetShowOnlineStatus(ctx context.Context, userID int, show bool) error {
+	query := `
+		INSERT INTO user_profiles (user_id, show_online_status)
+		VALUES (?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET show_online_status = excluded.show_online_status
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, show); err != nil {
+		return fmt.Errorf("failed to update online status setting: %w", err)
+	}
+
+	return nil
+}
+
+// SetAllowSpectators updates whether other users may watch this user's game sessions
+func (s *Service) SetAllowSpectators(ctx context.Context, userID int, allow bool) error {
+	query := `
+		INSERT INTO user_profiles (user_id, allow_spectators)
+		VALUES (?, ?)
+		ON CONFLICT(user_id) DO UPDATE SET allow_spectators = excluded.allow_spectators
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, userID, allow); err != nil {
+		return fmt.Errorf("failed to update spectator setting: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// UsernameChange is one rename in an account's username history
+type UsernameChange struct {
+	OldUsername string
+	NewUsername string
+	ChangedBy   string
+	ChangedAt   time.Time
+	// ReservedUntil is when others may take OldUsername; nil if never reserved
+	ReservedUntil *time.Time
+}
+
+// RenameRequest describes renaming an account
+type RenameRequest struct {
+	UserID      int
+	NewUsername string
+	ChangedBy   string
+	Reason      string
+	// ReuseCooldown is how long the old name stays reserved for this user
+	ReuseCooldown time.Duration
+	// MinInterval is how long since the account's last rename must have
+	// passed; 0 skips the check
+	MinInterval time.Duration
+}
+
+// RenameUser changes an account's username. The user ID, and so everything
+// keyed by it such as game directories and saves, stays the same. The old
+// name is recorded in the username history and reserved for the user for
+// ReuseCooldown. Names are compared case-insensitively, so a user may change
+// only the case of their own name.
+func (s *Service) RenameUser(ctx context.Context, req *RenameRequest) (*User, error) {
+	if errs := s.validateUsername(req.NewUsername); len(errs) > 0 {
+		return nil, errors.New(errs[0].Message)
+	}
+
+	var renamed *User
+	err := s.db.WithTransaction(ctx, func(ctx context.Context) error {
+		target, err := s.GetUserByID(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		if target.Username == req.NewUsername {
+			return fmt.Errorf("username is already %s", req.NewUsername)
+		}
+
+		var count int
+		query := "SELECT COUNT(*) FROM users WHERE LOWER(username) = LOWER(?) AND id != ?"
+		if err := s.db.QueryRowContext(ctx, query, req.NewUsername, req.UserID).Scan(&count); err != nil {
+			return fmt.Errorf("failed to check username existence: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("username %s is already taken", req.NewUsername)
+		}
+
+		reserved, err := s.usernameReserved(ctx, req.NewUsername, req.UserID)
+		if err != nil {
+			return err
+		}
+		if reserved {
+			return fmt.Errorf("username %s was recently given up and is reserved", req.NewUsername)
+		}
+
+		if req.MinInterval > 0 {
+			history, err := s.UsernameHistory(ctx, req.UserID)
+			if err != nil {
+				return err
+			}
+			if len(history) > 0 {
+				if next := history[0].ChangedAt.Add(req.MinInterval); time.Now().Before(next) {
+					return fmt.Errorf("username was changed recently; try again after %s", next.UTC().Format(time.RFC3339))
+				}
+			}
+		}
+
+		now := time.Now()
+		if _, err := s.db.ExecContext(ctx, "UPDATE users SET username = ?, updated_at = ? WHERE id = ?",
+			req.NewUsername, now, req.UserID); err != nil {
+			return fmt.Errorf("failed to rename user: %w", err)
+		}
+
+		var reservedUntil interface{}
+		if req.ReuseCooldown > 0 {
+			reservedUntil = now.Add(req.ReuseCooldown)
+		}
+		query = `
+			INSERT INTO username_history (user_id, old_username, new_username, changed_by, changed_at, reserved_until)
+			VALUES (?, ?, ?, ?, ?, ?)
+		`
+		if _, err := s.db.ExecContext(ctx, query,
+			req.UserID, target.Username, req.NewUsername, req.ChangedBy, now, reservedUntil); err != nil {
+			return fmt.Errorf("failed to record username history: %w", err)
+		}
+
+		detail := strings.TrimSpace(fmt.Sprintf("from %s %s", target.Username, req.Reason))
+		if err := s.recordModerationAction(ctx, req.ChangedBy, req.NewUsername, "rename", detail); err != nil {
+			return err
+		}
+
+		target.Username = req.NewUsername
+		target.UpdatedAt = now
+		renamed = target
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renamed, nil
+}
+
+// UsernameHistory returns an account's renames, newest first
+func (s *Service) UsernameHistory(ctx context.Context, userID int) ([]*UsernameChange, error) {
+	query := `
+		SELECT old_username, new_username, changed_by, changed_at, reserved_until
+		FROM username_history
+		WHERE user_id = ?
+		ORDER BY id DESC
+	`
+	rows, err := s.db.QueryContext(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query username history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []*UsernameChange
+	for rows.Next() {
+		var change UsernameChange
+		var reservedUntil sql.NullTime
+		if err := rows.Scan(&change.OldUsername, &change.NewUsername, &change.ChangedBy,
+			&change.ChangedAt, &reservedUntil); err != nil {
+			return nil, fmt.Errorf("failed to scan username history: %w", err)
+		}
+		if reservedUntil.Valid {
+			change.ReservedUntil = &reservedUntil.Time
+		}
+		history = append(history, &change)
+	}
+	return history, rows.Err()
+}
+
+// usernameReserved reports whether a user other than exceptUserID gave up
+// username recently enough that it is still reserved for them
+func (s *Service) usernameReserved(ctx context.Context, username string, exceptUserID int) (bool, error) {
+	query := `
+		SELECT reserved_until
+		FROM username_history
+		WHERE LOWER(old_username) = LOWER(?) AND user_id != ? AND reserved_until IS NOT NULL
+	`
+	rows, err := s.db.QueryContext(ctx, query, username, exceptUserID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check username reservation: %w", err)
+	}
+	defer rows.Close()
+
+	// Compared here rather than in SQL, as stored timestamp formats vary
+	now := time.Now()
+	for rows.Next() {
+		var until time.Time
+		if err := rows.Scan(&until); err != nil {
+			return false, fmt.Errorf("failed to scan username reservation: %w", err)
+		}
+		if until.After(now) {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
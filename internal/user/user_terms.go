@@ -0,0 +1,165 @@
+package user
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxTermsVersionLength matches the version column of terms_versions and user_terms_acceptance
+const maxTermsVersionLength = 50
+
+// ErrNoTerms is returned when no terms of service have been published
+var ErrNoTerms = errors.New("no terms of service published")
+
+// TermsVersion is a published version of the terms of service. The newest
+// is the one users must have accepted.
+type TermsVersion struct {
+	Version     string    `json:"version" db:"version"`
+	Content     string    `json:"content" db:"content"`
+	PublishedBy string    `json:"published_by" db:"published_by"`
+	PublishedAt time.Time `json:"published_at" db:"published_at"`
+}
+
+// TermsAcceptance records when a user accepted a version of the terms
+type TermsAcceptance struct {
+	Version    string    `json:"version" db:"version"`
+	AcceptedAt time.Time `json:"accepted_at" db:"accepted_at"`
+}
+
+// validateTermsVersion checks that a terms version can be stored
+func validateTermsVersion(version string) error {
+	if version == "" || len(version) > maxTermsVersionLength {
+		return fmt.Errorf("terms version must be 1 to %d characters", maxTermsVersionLength)
+	}
+	return nil
+}
+
+// PublishTerms stores a new version of the terms of service, which becomes
+// the current one. Versions cannot be republished with different content.
+func (s *Service) PublishTerms(ctx context.Context, version, content, publishedBy string) (*TermsVersion, error) {
+	if err := validateTermsVersion(version); err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return nil, fmt.Errorf("terms content is required")
+	}
+
+	terms := &TermsVersion{
+		Version:     version,
+		Content:     content,
+		PublishedBy: publishedBy,
+		PublishedAt: time.Now(),
+	}
+	query := `INSERT INTO terms_versions (version, content, published_by, published_at) VALUES (?, ?, ?, ?)`
+	if _, err := s.db.ExecContext(ctx, query, terms.Version, terms.Content, terms.PublishedBy, terms.PublishedAt); err != nil {
+		if _, getErr := s.GetTerms(ctx, version); getErr == nil {
+			return nil, fmt.Errorf("terms version %s already exists", version)
+		}
+		return nil, fmt.Errorf("failed to publish terms: %w", err)
+	}
+	return terms, nil
+}
+
+// CurrentTerms returns the newest published terms, or ErrNoTerms
+func (s *Service) CurrentTerms(ctx context.Context) (*TermsVersion, error) {
+	return s.queryTerms(ctx, `ORDER BY published_at DESC LIMIT 1`)
+}
+
+// GetTerms returns a published version of the terms, or ErrNoTerms
+func (s *Service) GetTerms(ctx context.Context, version string) (*TermsVersion, error) {
+	return s.queryTerms(ctx, `WHERE version = ?`, version)
+}
+
+// queryTerms returns the first terms version matching a clause
+func (s *Service) queryTerms(ctx context.Context, clause string, args ...interface{}) (*TermsVersion, error) {
+	var terms TermsVersion
+	err := s.db.QueryRowContext(ctx,
+		`SELECT version, content, published_by, published_at FROM terms_versions `+clause, args...,
+	).Scan(&terms.Version, &terms.Content, &terms.PublishedBy, &terms.PublishedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNoTerms
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query terms: %w", err)
+	}
+	return &terms, nil
+}
+
+// AcceptTerms records that the user accepted a version of the terms of
+// service. Accepting the same version again keeps the first acceptance time.
+func (s *Service) AcceptTerms(ctx context.Context, userID int, version string) error {
+	if err := validateTermsVersion(version); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO user_terms_acceptance (user_id, version, accepted_at)
+		VALUES (?, ?, ?)
+		ON CONFLICT(user_id, version) DO NOTHING
+	`
+	if _, err := s.db.ExecContext(ctx, query, userID, version, time.Now()); err != nil {
+		return fmt.Errorf("failed to record terms acceptance: %w", err)
+	}
+	return nil
+}
+
+// TermsAcceptedAt returns when the user accepted a version of the terms, or
+// the zero time if they have not
+func (s *Service) TermsAcceptedAt(ctx context.Context, userID int, version string) (time.Time, error) {
+	var acceptedAt time.Time
+	err := s.db.QueryRowContext(ctx,
+		`SELECT accepted_at FROM user_terms_acceptance WHERE user_id = ? AND version = ?`,
+		userID, version).Scan(&acceptedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to query terms acceptance: %w", err)
+	}
+	return acceptedAt, nil
+}
+
+// ListTermsAcceptances returns every version of the terms the user accepted, newest first
+func (s *Service) ListTermsAcceptances(ctx context.Context, userID int) ([]*TermsAcceptance, error) {
+	rows, err := s.db.QueryContext(ctx,
+		`SELECT version, accepted_at FROM user_terms_acceptance WHERE user_id = ? ORDER BY accepted_at DESC`,
+		userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query terms acceptances: %w", err)
+	}
+	defer rows.Close()
+
+	var acceptances []*TermsAcceptance
+	for rows.Next() {
+		var acceptance TermsAcceptance
+		if err := rows.Scan(&acceptance.Version, &acceptance.AcceptedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan terms acceptance: %w", err)
+		}
+		acceptances = append(acceptances, &acceptance)
+	}
+
+	return acceptances, rows.Err()
+}
+
+// PendingTerms returns the current terms when the user has not accepted
+// them, or nil when there is nothing to accept
+func (s *Service) PendingTerms(ctx context.Context, userID int) (*TermsVersion, error) {
+	current, err := s.CurrentTerms(ctx)
+	if errors.Is(err, ErrNoTerms) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	acceptedAt, err := s.TermsAcceptedAt(ctx, userID, current.Version)
+	if err != nil {
+		return nil, err
+	}
+	if !acceptedAt.IsZero() {
+		return nil, nil
+	}
+	return current, nil
+}
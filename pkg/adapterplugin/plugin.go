@@ -0,0 +1,166 @@
+// Package adapterplugin serves game adapters from outside the game service,
+// so games can be added without forking DungeonGate.
+//
+// A plugin implements adapterv1.GameAdapterPluginServer, embedding
+// adapterv1.UnimplementedGameAdapterPluginServer for the calls it does not
+// need, and hands it to Serve from main:
+//
+//	func main() {
+//		if err := adapterplugin.Serve(&broguePlugin{}); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// The game service launches the plugin binary named by a game's
+// adapter.plugin.command. Serve listens on a Unix socket and tells the game
+// service where with one handshake line on stdout, in the style of
+// hashicorp/go-plugin:
+//
+//	1|1|unix|/tmp/dungeongate-adapter-123/plugin.sock|grpc
+//
+// Anything else the plugin writes should go to stderr, which the game
+// service logs. A plugin run as its own service uses ListenAndServe instead,
+// and the game is configured with adapter.plugin.address.
+package adapterplugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"google.golang.org/grpc"
+
+	adapterv1 "github.com/dungeongate/pkg/api/games/adapter/v1"
+)
+
+const (
+	// CoreProtocolVersion is the version of the handshake itself
+	CoreProtocolVersion = 1
+	// ProtocolVersion is the version of the GameAdapterPlugin service
+	ProtocolVersion = 1
+
+	// MagicCookieKey and MagicCookieValue are set in the environment of
+	// plugins the game service launches. They are not a secret; they stop a
+	// plugin run by hand from waiting for a game service that never comes.
+	MagicCookieKey   = "DUNGEONGATE_ADAPTER_PLUGIN"
+	MagicCookieValue = "8c1b7d2e-game-adapter"
+)
+
+// ErrNotLaunched is returned by Serve when the plugin was not launched by
+// the game service
+var ErrNotLaunched = errors.New("this is a DungeonGate game adapter plugin; it is launched by the game service, not run directly")
+
+// Handshake is the line a launched plugin prints to tell the game service
+// where it listens
+type Handshake struct {
+	CoreProtocol int
+	Protocol     int
+	Network      string
+	Address      string
+}
+
+// String formats the handshake as printed
+func (h Handshake) String() string {
+	return fmt.Sprintf("%d|%d|%s|%s|grpc", h.CoreProtocol, h.Protocol, h.Network, h.Address)
+}
+
+// ParseHandshake parses the line a launched plugin printed, checking that it
+// speaks the protocols this build does
+func ParseHandshake(line string) (Handshake, error) {
+	parts := strings.Split(strings.TrimSpace(line), "|")
+	if len(parts) != 5 {
+		return Handshake{}, fmt.Errorf("malformed plugin handshake %q", line)
+	}
+	core, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Handshake{}, fmt.Errorf("malformed plugin handshake %q", line)
+	}
+	protocol, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return Handshake{}, fmt.Errorf("malformed plugin handshake %q", line)
+	}
+	h := Handshake{CoreProtocol: core, Protocol: protocol, Network: parts[2], Address: parts[3]}
+	switch {
+	case h.CoreProtocol != CoreProtocolVersion:
+		return Handshake{}, fmt.Errorf("plugin speaks handshake version %d, expected %d", h.CoreProtocol, CoreProtocolVersion)
+	case h.Protocol != ProtocolVersion:
+		return Handshake{}, fmt.Errorf("plugin speaks adapter protocol version %d, expected %d", h.Protocol, ProtocolVersion)
+	case h.Network != "unix" && h.Network != "tcp":
+		return Handshake{}, fmt.Errorf("plugin listens on unsupported network %q", h.Network)
+	case parts[4] != "grpc":
+		return Handshake{}, fmt.Errorf("plugin speaks unsupported protocol %q", parts[4])
+	}
+	return h, nil
+}
+
+// Serve serves a plugin launched by the game service until the game service
+// stops it
+func Serve(server adapterv1.GameAdapterPluginServer) error {
+	return serve(server, os.Stdout)
+}
+
+// serve serves a launched plugin, writing the handshake to out
+func serve(server adapterv1.GameAdapterPluginServer, out io.Writer) error {
+	if os.Getenv(MagicCookieKey) != MagicCookieValue {
+		return ErrNotLaunched
+	}
+
+	dir, err := os.MkdirTemp("", "dungeongate-adapter-")
+	if err != nil {
+		return fmt.Errorf("failed to create plugin socket directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	listener, err := net.Listen("unix", filepath.Join(dir, "plugin.sock"))
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+
+	grpcServer := newServer(server)
+	stopOnSignal(grpcServer)
+	handshake := Handshake{CoreProtocol: CoreProtocolVersion, Protocol: ProtocolVersion, Network: "unix", Address: listener.Addr().String()}
+	if _, err := fmt.Fprintln(out, handshake); err != nil {
+		return fmt.Errorf("failed to write handshake: %w", err)
+	}
+	return grpcServer.Serve(listener)
+}
+
+// ListenAndServe serves a plugin run as its own service on a host:port or
+// unix:///path address
+func ListenAndServe(address string, server adapterv1.GameAdapterPluginServer) error {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix://"); ok {
+		network, address = "unix", path
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+	grpcServer := newServer(server)
+	stopOnSignal(grpcServer)
+	return grpcServer.Serve(listener)
+}
+
+// newServer returns a gRPC server serving the plugin
+func newServer(server adapterv1.GameAdapterPluginServer) *grpc.Server {
+	grpcServer := grpc.NewServer()
+	adapterv1.RegisterGameAdapterPluginServer(grpcServer, server)
+	return grpcServer
+}
+
+// stopOnSignal stops the server, finishing calls in flight, when the plugin
+// is interrupted or terminated
+func stopOnSignal(grpcServer *grpc.Server) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		grpcServer.GracefulStop()
+	}()
+}
@@ -0,0 +1,67 @@
+package adapterplugin
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	adapterv1 "github.com/dungeongate/pkg/api/games/adapter/v1"
+)
+
+type statusPlugin struct {
+	adapterv1.UnimplementedGameAdapterPluginServer
+}
+
+func (statusPlugin) ParseStatus(_ context.Context, req *adapterv1.ParseStatusRequest) (*adapterv1.ParseStatusResponse, error) {
+	return &adapterv1.ParseStatusResponse{Status: req.Game.Id + ": " + req.Rows[0]}, nil
+}
+
+func TestParseHandshake(t *testing.T) {
+	handshake, err := ParseHandshake("1|1|unix|/tmp/plugin.sock|grpc\n")
+	require.NoError(t, err)
+	assert.Equal(t, Handshake{CoreProtocol: 1, Protocol: 1, Network: "unix", Address: "/tmp/plugin.sock"}, handshake)
+	assert.Equal(t, "1|1|unix|/tmp/plugin.sock|grpc", handshake.String())
+
+	for _, line := range []string{
+		"",
+		"listening on /tmp/plugin.sock",
+		"2|1|unix|/tmp/plugin.sock|grpc",
+		"1|2|unix|/tmp/plugin.sock|grpc",
+		"1|1|udp|127.0.0.1:1234|grpc",
+		"1|1|tcp|127.0.0.1:1234|netrpc",
+	} {
+		_, err := ParseHandshake(line)
+		assert.Error(t, err, line)
+	}
+}
+
+func TestServe(t *testing.T) {
+	t.Setenv(MagicCookieKey, "")
+	assert.ErrorIs(t, Serve(statusPlugin{}), ErrNotLaunched)
+
+	t.Setenv(MagicCookieKey, MagicCookieValue)
+	t.Setenv("TMPDIR", t.TempDir())
+	reader, writer := io.Pipe()
+	go serve(statusPlugin{}, writer)
+	line, err := bufio.NewReader(reader).ReadString('\n')
+	require.NoError(t, err)
+	handshake, err := ParseHandshake(line)
+	require.NoError(t, err)
+	assert.Equal(t, "unix", handshake.Network)
+
+	conn, err := grpc.NewClient("unix://"+handshake.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	resp, err := adapterv1.NewGameAdapterPluginClient(conn).ParseStatus(context.Background(), &adapterv1.ParseStatusRequest{
+		Game: &adapterv1.Game{Id: "brogue"},
+		Rows: []string{"Depth: 3"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "brogue: Depth: 3", resp.Status)
+}
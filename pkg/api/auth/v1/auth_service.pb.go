@@ -33,6 +33,7 @@ type RegisterRequest struct {
 	ClientIp      string                 `protobuf:"bytes,5,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
 	UserAgent     string                 `protobuf:"bytes,6,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	TermsVersion  string                 `protobuf:"bytes,8,opt,name=terms_version,json=termsVersion,proto3" json:"terms_version,omitempty"` // Version of the terms the user accepted, if any
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -116,6 +117,13 @@ func (x *RegisterRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *RegisterRequest) GetTermsVersion() string {
+	if x != nil {
+		return x.TermsVersion
+	}
+	return ""
+}
+
 // RegisterResponse represents a user registration response
 type RegisterResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -221,15 +229,17 @@ func (x *RegisterResponse) GetUser() *User {
 
 // LoginRequest represents a login request
 type LoginRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
-	ClientId      string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	ClientIp      string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
-	UserAgent     string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Username       string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password       string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	ClientId       string                 `protobuf:"bytes,3,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientIp       string                 `protobuf:"bytes,4,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	UserAgent      string                 `protobuf:"bytes,5,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Metadata       map[string]string      `protobuf:"bytes,6,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Device         *ClientDevice          `protobuf:"bytes,7,opt,name=device,proto3" json:"device,omitempty"`                                       // The device logged in from, recorded when set
+	ChallengeToken string                 `protobuf:"bytes,8,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"` // From a passed challenge; lets an account locked out after failed logins log in
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *LoginRequest) Reset() {
@@ -304,6 +314,20 @@ func (x *LoginRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *LoginRequest) GetDevice() *ClientDevice {
+	if x != nil {
+		return x.Device
+	}
+	return nil
+}
+
+func (x *LoginRequest) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
 // LoginResponse represents a login response
 type LoginResponse struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -320,6 +344,7 @@ type LoginResponse struct {
 	// Rate limiting info
 	RemainingAttempts int32 `protobuf:"varint,9,opt,name=remaining_attempts,json=remainingAttempts,proto3" json:"remaining_attempts,omitempty"`
 	RetryAfterSeconds int64 `protobuf:"varint,10,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"`
+	ChallengeRequired bool  `protobuf:"varint,11,opt,name=challenge_required,json=challengeRequired,proto3" json:"challenge_required,omitempty"` // Locked out after failed logins; a passed challenge lets the account log in
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -424,6 +449,13 @@ func (x *LoginResponse) GetRetryAfterSeconds() int64 {
 	return 0
 }
 
+func (x *LoginResponse) GetChallengeRequired() bool {
+	if x != nil {
+		return x.ChallengeRequired
+	}
+	return false
+}
+
 // LogoutRequest represents a logout request
 type LogoutRequest struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
@@ -1034,29 +1066,30 @@ func (x *ChangePasswordResponse) GetError() string {
 	return ""
 }
 
-// ResetPasswordRequest represents a password reset request
-type ResetPasswordRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	UsernameOrEmail string                 `protobuf:"bytes,1,opt,name=username_or_email,json=usernameOrEmail,proto3" json:"username_or_email,omitempty"`
-	ClientIp        string                 `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// ChangeUsernameRequest represents a request to rename the token user's account
+type ChangeUsernameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	NewUsername   string                 `protobuf:"bytes,2,opt,name=new_username,json=newUsername,proto3" json:"new_username,omitempty"`
+	Password      string                 `protobuf:"bytes,3,opt,name=password,proto3" json:"password,omitempty"` // The current password, confirming the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResetPasswordRequest) Reset() {
-	*x = ResetPasswordRequest{}
+func (x *ChangeUsernameRequest) Reset() {
+	*x = ChangeUsernameRequest{}
 	mi := &file_auth_auth_service_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResetPasswordRequest) String() string {
+func (x *ChangeUsernameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetPasswordRequest) ProtoMessage() {}
+func (*ChangeUsernameRequest) ProtoMessage() {}
 
-func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+func (x *ChangeUsernameRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1068,49 +1101,56 @@ func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
-func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeUsernameRequest.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameRequest) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ResetPasswordRequest) GetUsernameOrEmail() string {
+func (x *ChangeUsernameRequest) GetAccessToken() string {
 	if x != nil {
-		return x.UsernameOrEmail
+		return x.AccessToken
 	}
 	return ""
 }
 
-func (x *ResetPasswordRequest) GetClientIp() string {
+func (x *ChangeUsernameRequest) GetNewUsername() string {
 	if x != nil {
-		return x.ClientIp
+		return x.NewUsername
 	}
 	return ""
 }
 
-// ResetPasswordResponse represents a password reset response
-type ResetPasswordResponse struct {
+func (x *ChangeUsernameRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+// ChangeUsernameResponse represents a username change response
+type ChangeUsernameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"` // The renamed user; existing tokens stay valid
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResetPasswordResponse) Reset() {
-	*x = ResetPasswordResponse{}
+func (x *ChangeUsernameResponse) Reset() {
+	*x = ChangeUsernameResponse{}
 	mi := &file_auth_auth_service_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResetPasswordResponse) String() string {
+func (x *ChangeUsernameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetPasswordResponse) ProtoMessage() {}
+func (*ChangeUsernameResponse) ProtoMessage() {}
 
-func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+func (x *ChangeUsernameResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1122,55 +1162,60 @@ func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
-func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ChangeUsernameResponse.ProtoReflect.Descriptor instead.
+func (*ChangeUsernameResponse) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ResetPasswordResponse) GetSuccess() bool {
+func (x *ChangeUsernameResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ResetPasswordResponse) GetError() string {
+func (x *ChangeUsernameResponse) GetError() string {
 	if x != nil {
 		return x.Error
 	}
 	return ""
 }
 
-func (x *ResetPasswordResponse) GetMessage() string {
+func (x *ChangeUsernameResponse) GetUser() *User {
 	if x != nil {
-		return x.Message
+		return x.User
 	}
-	return ""
+	return nil
 }
 
-// VerifyPasswordResetRequest represents a password reset verification request
-type VerifyPasswordResetRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
-	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// MergeAccountsRequest represents a request to merge another account into the
+// token user's account. Ownership of the other account is proven with either
+// its password or an access token issued to it.
+type MergeAccountsRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken      string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`                  // Token of the surviving account
+	OtherUsername    string                 `protobuf:"bytes,2,opt,name=other_username,json=otherUsername,proto3" json:"other_username,omitempty"`            // The account to merge in
+	OtherPassword    string                 `protobuf:"bytes,3,opt,name=other_password,json=otherPassword,proto3" json:"other_password,omitempty"`            // Its password, or...
+	OtherAccessToken string                 `protobuf:"bytes,4,opt,name=other_access_token,json=otherAccessToken,proto3" json:"other_access_token,omitempty"` // ...an access token issued to it
+	DryRun           bool                   `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`                                // Only report what would move
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
-func (x *VerifyPasswordResetRequest) Reset() {
-	*x = VerifyPasswordResetRequest{}
+func (x *MergeAccountsRequest) Reset() {
+	*x = MergeAccountsRequest{}
 	mi := &file_auth_auth_service_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyPasswordResetRequest) String() string {
+func (x *MergeAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyPasswordResetRequest) ProtoMessage() {}
+func (*MergeAccountsRequest) ProtoMessage() {}
 
-func (x *VerifyPasswordResetRequest) ProtoReflect() protoreflect.Message {
+func (x *MergeAccountsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1182,48 +1227,76 @@ func (x *VerifyPasswordResetRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyPasswordResetRequest.ProtoReflect.Descriptor instead.
-func (*VerifyPasswordResetRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeAccountsRequest.ProtoReflect.Descriptor instead.
+func (*MergeAccountsRequest) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *VerifyPasswordResetRequest) GetResetToken() string {
+func (x *MergeAccountsRequest) GetAccessToken() string {
 	if x != nil {
-		return x.ResetToken
+		return x.AccessToken
 	}
 	return ""
 }
 
-func (x *VerifyPasswordResetRequest) GetNewPassword() string {
+func (x *MergeAccountsRequest) GetOtherUsername() string {
 	if x != nil {
-		return x.NewPassword
+		return x.OtherUsername
 	}
 	return ""
 }
 
-// VerifyPasswordResetResponse represents a password reset verification response
-type VerifyPasswordResetResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *MergeAccountsRequest) GetOtherPassword() string {
+	if x != nil {
+		return x.OtherPassword
+	}
+	return ""
 }
 
-func (x *VerifyPasswordResetResponse) Reset() {
-	*x = VerifyPasswordResetResponse{}
+func (x *MergeAccountsRequest) GetOtherAccessToken() string {
+	if x != nil {
+		return x.OtherAccessToken
+	}
+	return ""
+}
+
+func (x *MergeAccountsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// AccountMergeSummary counts what a merge moves, or would move
+type AccountMergeSummary struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Preferences        int32                  `protobuf:"varint,1,opt,name=preferences,proto3" json:"preferences,omitempty"`
+	ApiKeys            int32                  `protobuf:"varint,2,opt,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	Notifications      int32                  `protobuf:"varint,3,opt,name=notifications,proto3" json:"notifications,omitempty"`
+	Notes              int32                  `protobuf:"varint,4,opt,name=notes,proto3" json:"notes,omitempty"`
+	ModerationFlags    int32                  `protobuf:"varint,5,opt,name=moderation_flags,json=moderationFlags,proto3" json:"moderation_flags,omitempty"`
+	AccessLog          int32                  `protobuf:"varint,6,opt,name=access_log,json=accessLog,proto3" json:"access_log,omitempty"`
+	OwnedGroups        int32                  `protobuf:"varint,7,opt,name=owned_groups,json=ownedGroups,proto3" json:"owned_groups,omitempty"`
+	Group              string                 `protobuf:"bytes,8,opt,name=group,proto3" json:"group,omitempty"`                                                      // Group the surviving account joins, if any
+	SkippedPreferences int32                  `protobuf:"varint,9,opt,name=skipped_preferences,json=skippedPreferences,proto3" json:"skipped_preferences,omitempty"` // Preferences the surviving account already sets
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *AccountMergeSummary) Reset() {
+	*x = AccountMergeSummary{}
 	mi := &file_auth_auth_service_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyPasswordResetResponse) String() string {
+func (x *AccountMergeSummary) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyPasswordResetResponse) ProtoMessage() {}
+func (*AccountMergeSummary) ProtoMessage() {}
 
-func (x *VerifyPasswordResetResponse) ProtoReflect() protoreflect.Message {
+func (x *AccountMergeSummary) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1235,48 +1308,100 @@ func (x *VerifyPasswordResetResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyPasswordResetResponse.ProtoReflect.Descriptor instead.
-func (*VerifyPasswordResetResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AccountMergeSummary.ProtoReflect.Descriptor instead.
+func (*AccountMergeSummary) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *VerifyPasswordResetResponse) GetSuccess() bool {
+func (x *AccountMergeSummary) GetPreferences() int32 {
 	if x != nil {
-		return x.Success
+		return x.Preferences
 	}
-	return false
+	return 0
 }
 
-func (x *VerifyPasswordResetResponse) GetError() string {
+func (x *AccountMergeSummary) GetApiKeys() int32 {
 	if x != nil {
-		return x.Error
+		return x.ApiKeys
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetNotifications() int32 {
+	if x != nil {
+		return x.Notifications
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetNotes() int32 {
+	if x != nil {
+		return x.Notes
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetModerationFlags() int32 {
+	if x != nil {
+		return x.ModerationFlags
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetAccessLog() int32 {
+	if x != nil {
+		return x.AccessLog
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetOwnedGroups() int32 {
+	if x != nil {
+		return x.OwnedGroups
+	}
+	return 0
+}
+
+func (x *AccountMergeSummary) GetGroup() string {
+	if x != nil {
+		return x.Group
 	}
 	return ""
 }
 
-// GetLoginAttemptsRequest represents a request to get login attempts
-type GetLoginAttemptsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
-	ClientIp      string                 `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AccountMergeSummary) GetSkippedPreferences() int32 {
+	if x != nil {
+		return x.SkippedPreferences
+	}
+	return 0
 }
 
-func (x *GetLoginAttemptsRequest) Reset() {
-	*x = GetLoginAttemptsRequest{}
+// MergeAccountsResponse represents an account merge response
+type MergeAccountsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Summary        *AccountMergeSummary   `protobuf:"bytes,3,opt,name=summary,proto3" json:"summary,omitempty"`
+	MergedUserId   int32                  `protobuf:"varint,4,opt,name=merged_user_id,json=mergedUserId,proto3" json:"merged_user_id,omitempty"` // Game data is keyed by user ID and merged by the game service
+	MergedUsername string                 `protobuf:"bytes,5,opt,name=merged_username,json=mergedUsername,proto3" json:"merged_username,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MergeAccountsResponse) Reset() {
+	*x = MergeAccountsResponse{}
 	mi := &file_auth_auth_service_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLoginAttemptsRequest) String() string {
+func (x *MergeAccountsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLoginAttemptsRequest) ProtoMessage() {}
+func (*MergeAccountsResponse) ProtoMessage() {}
 
-func (x *GetLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
+func (x *MergeAccountsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1288,50 +1413,71 @@ func (x *GetLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLoginAttemptsRequest.ProtoReflect.Descriptor instead.
-func (*GetLoginAttemptsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use MergeAccountsResponse.ProtoReflect.Descriptor instead.
+func (*MergeAccountsResponse) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetLoginAttemptsRequest) GetUsername() string {
+func (x *MergeAccountsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Username
+		return x.Success
+	}
+	return false
+}
+
+func (x *MergeAccountsResponse) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *GetLoginAttemptsRequest) GetClientIp() string {
+func (x *MergeAccountsResponse) GetSummary() *AccountMergeSummary {
 	if x != nil {
-		return x.ClientIp
+		return x.Summary
+	}
+	return nil
+}
+
+func (x *MergeAccountsResponse) GetMergedUserId() int32 {
+	if x != nil {
+		return x.MergedUserId
+	}
+	return 0
+}
+
+func (x *MergeAccountsResponse) GetMergedUsername() string {
+	if x != nil {
+		return x.MergedUsername
 	}
 	return ""
 }
 
-// GetLoginAttemptsResponse represents a response with login attempts
-type GetLoginAttemptsResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	FailedAttempts    int32                  `protobuf:"varint,1,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
-	AccountLocked     bool                   `protobuf:"varint,2,opt,name=account_locked,json=accountLocked,proto3" json:"account_locked,omitempty"`
-	LockedUntil       int64                  `protobuf:"varint,3,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
-	RemainingAttempts int32                  `protobuf:"varint,4,opt,name=remaining_attempts,json=remainingAttempts,proto3" json:"remaining_attempts,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+// SetUserPreferenceRequest stores a preference of the token's user. An empty
+// value removes the preference.
+type SetUserPreferenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLoginAttemptsResponse) Reset() {
-	*x = GetLoginAttemptsResponse{}
+func (x *SetUserPreferenceRequest) Reset() {
+	*x = SetUserPreferenceRequest{}
 	mi := &file_auth_auth_service_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLoginAttemptsResponse) String() string {
+func (x *SetUserPreferenceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLoginAttemptsResponse) ProtoMessage() {}
+func (*SetUserPreferenceRequest) ProtoMessage() {}
 
-func (x *GetLoginAttemptsResponse) ProtoReflect() protoreflect.Message {
+func (x *SetUserPreferenceRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1343,63 +1489,55 @@ func (x *GetLoginAttemptsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLoginAttemptsResponse.ProtoReflect.Descriptor instead.
-func (*GetLoginAttemptsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetUserPreferenceRequest.ProtoReflect.Descriptor instead.
+func (*SetUserPreferenceRequest) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *GetLoginAttemptsResponse) GetFailedAttempts() int32 {
-	if x != nil {
-		return x.FailedAttempts
-	}
-	return 0
-}
-
-func (x *GetLoginAttemptsResponse) GetAccountLocked() bool {
+func (x *SetUserPreferenceRequest) GetAccessToken() string {
 	if x != nil {
-		return x.AccountLocked
+		return x.AccessToken
 	}
-	return false
+	return ""
 }
 
-func (x *GetLoginAttemptsResponse) GetLockedUntil() int64 {
+func (x *SetUserPreferenceRequest) GetKey() string {
 	if x != nil {
-		return x.LockedUntil
+		return x.Key
 	}
-	return 0
+	return ""
 }
 
-func (x *GetLoginAttemptsResponse) GetRemainingAttempts() int32 {
+func (x *SetUserPreferenceRequest) GetValue() string {
 	if x != nil {
-		return x.RemainingAttempts
+		return x.Value
 	}
-	return 0
+	return ""
 }
 
-// HealthResponse represents the health check response
-type HealthResponse struct {
+// SetUserPreferenceResponse represents a preference update response
+type SetUserPreferenceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // "healthy", "unhealthy", "degraded"
-	Details       map[string]string      `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthResponse) Reset() {
-	*x = HealthResponse{}
+func (x *SetUserPreferenceResponse) Reset() {
+	*x = SetUserPreferenceResponse{}
 	mi := &file_auth_auth_service_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthResponse) String() string {
+func (x *SetUserPreferenceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthResponse) ProtoMessage() {}
+func (*SetUserPreferenceResponse) ProtoMessage() {}
 
-func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+func (x *SetUserPreferenceResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_auth_auth_service_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1411,67 +1549,6309 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
-func (*HealthResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetUserPreferenceResponse.ProtoReflect.Descriptor instead.
+func (*SetUserPreferenceResponse) Descriptor() ([]byte, []int) {
 	return file_auth_auth_service_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *HealthResponse) GetStatus() string {
+func (x *SetUserPreferenceResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Status
+		return x.Success
+	}
+	return false
+}
+
+func (x *SetUserPreferenceResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// SetShowOnlineStatusRequest sets the show_online_status of the token user's profile
+type SetShowOnlineStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Show          bool                   `protobuf:"varint,2,opt,name=show,proto3" json:"show,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetShowOnlineStatusRequest) Reset() {
+	*x = SetShowOnlineStatusRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetShowOnlineStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetShowOnlineStatusRequest) ProtoMessage() {}
+
+func (x *SetShowOnlineStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetShowOnlineStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetShowOnlineStatusRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetShowOnlineStatusRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *SetShowOnlineStatusRequest) GetShow() bool {
+	if x != nil {
+		return x.Show
+	}
+	return false
+}
+
+// ResetPasswordRequest represents a password reset request
+type ResetPasswordRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UsernameOrEmail string                 `protobuf:"bytes,1,opt,name=username_or_email,json=usernameOrEmail,proto3" json:"username_or_email,omitempty"`
+	ClientIp        string                 `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ResetPasswordRequest) Reset() {
+	*x = ResetPasswordRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordRequest) ProtoMessage() {}
+
+func (x *ResetPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResetPasswordRequest) GetUsernameOrEmail() string {
+	if x != nil {
+		return x.UsernameOrEmail
+	}
+	return ""
+}
+
+func (x *ResetPasswordRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+// ResetPasswordResponse represents a password reset response
+type ResetPasswordResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordResponse) Reset() {
+	*x = ResetPasswordResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordResponse) ProtoMessage() {}
+
+func (x *ResetPasswordResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordResponse.ProtoReflect.Descriptor instead.
+func (*ResetPasswordResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ResetPasswordResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResetPasswordResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ResetPasswordResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// VerifyPasswordResetRequest represents a password reset verification request
+type VerifyPasswordResetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResetToken    string                 `protobuf:"bytes,1,opt,name=reset_token,json=resetToken,proto3" json:"reset_token,omitempty"`
+	NewPassword   string                 `protobuf:"bytes,2,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPasswordResetRequest) Reset() {
+	*x = VerifyPasswordResetRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPasswordResetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPasswordResetRequest) ProtoMessage() {}
+
+func (x *VerifyPasswordResetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPasswordResetRequest.ProtoReflect.Descriptor instead.
+func (*VerifyPasswordResetRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VerifyPasswordResetRequest) GetResetToken() string {
+	if x != nil {
+		return x.ResetToken
+	}
+	return ""
+}
+
+func (x *VerifyPasswordResetRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// VerifyPasswordResetResponse represents a password reset verification response
+type VerifyPasswordResetResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyPasswordResetResponse) Reset() {
+	*x = VerifyPasswordResetResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyPasswordResetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyPasswordResetResponse) ProtoMessage() {}
+
+func (x *VerifyPasswordResetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyPasswordResetResponse.ProtoReflect.Descriptor instead.
+func (*VerifyPasswordResetResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *VerifyPasswordResetResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *VerifyPasswordResetResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// GetLoginAttemptsRequest represents a request to get login attempts
+type GetLoginAttemptsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,2,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLoginAttemptsRequest) Reset() {
+	*x = GetLoginAttemptsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginAttemptsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginAttemptsRequest) ProtoMessage() {}
+
+func (x *GetLoginAttemptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginAttemptsRequest.ProtoReflect.Descriptor instead.
+func (*GetLoginAttemptsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetLoginAttemptsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetLoginAttemptsRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+// GetLoginAttemptsResponse represents a response with login attempts
+type GetLoginAttemptsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	FailedAttempts    int32                  `protobuf:"varint,1,opt,name=failed_attempts,json=failedAttempts,proto3" json:"failed_attempts,omitempty"`
+	AccountLocked     bool                   `protobuf:"varint,2,opt,name=account_locked,json=accountLocked,proto3" json:"account_locked,omitempty"`
+	LockedUntil       int64                  `protobuf:"varint,3,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
+	RemainingAttempts int32                  `protobuf:"varint,4,opt,name=remaining_attempts,json=remainingAttempts,proto3" json:"remaining_attempts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *GetLoginAttemptsResponse) Reset() {
+	*x = GetLoginAttemptsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLoginAttemptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLoginAttemptsResponse) ProtoMessage() {}
+
+func (x *GetLoginAttemptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLoginAttemptsResponse.ProtoReflect.Descriptor instead.
+func (*GetLoginAttemptsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetLoginAttemptsResponse) GetFailedAttempts() int32 {
+	if x != nil {
+		return x.FailedAttempts
+	}
+	return 0
+}
+
+func (x *GetLoginAttemptsResponse) GetAccountLocked() bool {
+	if x != nil {
+		return x.AccountLocked
+	}
+	return false
+}
+
+func (x *GetLoginAttemptsResponse) GetLockedUntil() int64 {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return 0
+}
+
+func (x *GetLoginAttemptsResponse) GetRemainingAttempts() int32 {
+	if x != nil {
+		return x.RemainingAttempts
+	}
+	return 0
+}
+
+// HealthResponse represents the health check response
+type HealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // "healthy", "unhealthy", "degraded"
+	Details       map[string]string      `protobuf:"bytes,2,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+// VersionResponse describes the build of a running service
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	BuildTime     string                 `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	GitCommit     string                 `protobuf:"bytes,4,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	GoVersion     string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *VersionResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+// User represents user information
+type User struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	IsActive        bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
+	IsAdmin         bool                   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	IsAuthenticated bool                   `protobuf:"varint,6,opt,name=is_authenticated,json=isAuthenticated,proto3" json:"is_authenticated,omitempty"`
+	EmailVerified   bool                   `protobuf:"varint,7,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastLogin       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
+	Roles           []string               `protobuf:"bytes,11,rep,name=roles,proto3" json:"roles,omitempty"`
+	Permissions     []string               `protobuf:"bytes,12,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Metadata        map[string]string      `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_auth_auth_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *User) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *User) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *User) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetIsActive() bool {
+	if x != nil {
+		return x.IsActive
+	}
+	return false
+}
+
+func (x *User) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+func (x *User) GetIsAuthenticated() bool {
+	if x != nil {
+		return x.IsAuthenticated
+	}
+	return false
+}
+
+func (x *User) GetEmailVerified() bool {
+	if x != nil {
+		return x.EmailVerified
+	}
+	return false
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *User) GetLastLogin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLogin
+	}
+	return nil
+}
+
+func (x *User) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *User) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *User) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// TokenClaims represents JWT token claims
+type TokenClaims struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
+	Permissions   []string               `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	ClientId      string                 `protobuf:"bytes,6,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	IssuedAt      int64                  `protobuf:"varint,8,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	NotBefore     int64                  `protobuf:"varint,10,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
+	Issuer        string                 `protobuf:"bytes,11,opt,name=issuer,proto3" json:"issuer,omitempty"`
+	Audience      string                 `protobuf:"bytes,12,opt,name=audience,proto3" json:"audience,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TokenClaims) Reset() {
+	*x = TokenClaims{}
+	mi := &file_auth_auth_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenClaims) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenClaims) ProtoMessage() {}
+
+func (x *TokenClaims) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenClaims.ProtoReflect.Descriptor instead.
+func (*TokenClaims) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *TokenClaims) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *TokenClaims) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetIssuedAt() int64 {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return 0
+}
+
+func (x *TokenClaims) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *TokenClaims) GetNotBefore() int64 {
+	if x != nil {
+		return x.NotBefore
+	}
+	return 0
+}
+
+func (x *TokenClaims) GetIssuer() string {
+	if x != nil {
+		return x.Issuer
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetAudience() string {
+	if x != nil {
+		return x.Audience
+	}
+	return ""
+}
+
+func (x *TokenClaims) GetMetadata() map[string]string {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+// AdminActionRequest represents a generic admin action request
+type AdminActionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AdminActionRequest) Reset() {
+	*x = AdminActionRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminActionRequest) ProtoMessage() {}
+
+func (x *AdminActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminActionRequest.ProtoReflect.Descriptor instead.
+func (*AdminActionRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *AdminActionRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *AdminActionRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+// AdminActionResponse represents a generic admin action response
+type AdminActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdminActionResponse) Reset() {
+	*x = AdminActionResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdminActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdminActionResponse) ProtoMessage() {}
+
+func (x *AdminActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdminActionResponse.ProtoReflect.Descriptor instead.
+func (*AdminActionResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AdminActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *AdminActionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AdminActionResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// RenameUserRequest represents an admin rename of a user account
+type RenameUserRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	NewUsername    string                 `protobuf:"bytes,3,opt,name=new_username,json=newUsername,proto3" json:"new_username,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RenameUserRequest) Reset() {
+	*x = RenameUserRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameUserRequest) ProtoMessage() {}
+
+func (x *RenameUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameUserRequest.ProtoReflect.Descriptor instead.
+func (*RenameUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RenameUserRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) GetNewUsername() string {
+	if x != nil {
+		return x.NewUsername
+	}
+	return ""
+}
+
+func (x *RenameUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ResetPasswordAdminRequest represents an admin password reset request
+type ResetPasswordAdminRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	NewPassword    string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ResetPasswordAdminRequest) Reset() {
+	*x = ResetPasswordAdminRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordAdminRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordAdminRequest) ProtoMessage() {}
+
+func (x *ResetPasswordAdminRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordAdminRequest.ProtoReflect.Descriptor instead.
+func (*ResetPasswordAdminRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ResetPasswordAdminRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ResetPasswordAdminRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *ResetPasswordAdminRequest) GetNewPassword() string {
+	if x != nil {
+		return x.NewPassword
+	}
+	return ""
+}
+
+// ServerStatsRequest represents a server statistics request
+type ServerStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatsRequest) Reset() {
+	*x = ServerStatsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatsRequest) ProtoMessage() {}
+
+func (x *ServerStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatsRequest.ProtoReflect.Descriptor instead.
+func (*ServerStatsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ServerStatsRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+// ServerStatsResponse represents a server statistics response
+type ServerStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Stats         map[string]string      `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServerStatsResponse) Reset() {
+	*x = ServerStatsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServerStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServerStatsResponse) ProtoMessage() {}
+
+func (x *ServerStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServerStatsResponse.ProtoReflect.Descriptor instead.
+func (*ServerStatsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ServerStatsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ServerStatsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ServerStatsResponse) GetStats() map[string]string {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
+// ImpersonateUserRequest represents a request to act as a user for support
+type ImpersonateUserRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken      string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername  string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`                                           // Required; shown to the user afterwards
+	DurationSeconds int32                  `protobuf:"varint,4,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Zero uses the default; capped at the maximum
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserRequest) Reset() {
+	*x = ImpersonateUserRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserRequest) ProtoMessage() {}
+
+func (x *ImpersonateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserRequest.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ImpersonateUserRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ImpersonateUserRequest) GetDurationSeconds() int32 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+// ImpersonateUserResponse carries the impersonation token. It has no refresh
+// token and cannot change the user's password or preferences.
+type ImpersonateUserResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	AccessToken   string                 `protobuf:"bytes,3,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImpersonateUserResponse) Reset() {
+	*x = ImpersonateUserResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImpersonateUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImpersonateUserResponse) ProtoMessage() {}
+
+func (x *ImpersonateUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImpersonateUserResponse.ProtoReflect.Descriptor instead.
+func (*ImpersonateUserResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ImpersonateUserResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ImpersonateUserResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ImpersonateUserResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// AccountAccess records support staff using a user's account
+type AccountAccess struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminUsername string                 `protobuf:"bytes,1,opt,name=admin_username,json=adminUsername,proto3" json:"admin_username,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	Seen          bool                   `protobuf:"varint,5,opt,name=seen,proto3" json:"seen,omitempty"` // Whether the user had already been shown this entry
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountAccess) Reset() {
+	*x = AccountAccess{}
+	mi := &file_auth_auth_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountAccess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountAccess) ProtoMessage() {}
+
+func (x *AccountAccess) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountAccess.ProtoReflect.Descriptor instead.
+func (*AccountAccess) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *AccountAccess) GetAdminUsername() string {
+	if x != nil {
+		return x.AdminUsername
+	}
+	return ""
+}
+
+func (x *AccountAccess) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AccountAccess) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AccountAccess) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *AccountAccess) GetSeen() bool {
+	if x != nil {
+		return x.Seen
+	}
+	return false
+}
+
+// ListAccountAccessRequest represents a request for the token user's account access history
+type ListAccountAccessRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountAccessRequest) Reset() {
+	*x = ListAccountAccessRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountAccessRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountAccessRequest) ProtoMessage() {}
+
+func (x *ListAccountAccessRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountAccessRequest.ProtoReflect.Descriptor instead.
+func (*ListAccountAccessRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListAccountAccessRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// ListAccountAccessResponse lists support access to an account, newest first
+type ListAccountAccessResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Entries       []*AccountAccess       `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAccountAccessResponse) Reset() {
+	*x = ListAccountAccessResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAccountAccessResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAccountAccessResponse) ProtoMessage() {}
+
+func (x *ListAccountAccessResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAccountAccessResponse.ProtoReflect.Descriptor instead.
+func (*ListAccountAccessResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListAccountAccessResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAccountAccessResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListAccountAccessResponse) GetEntries() []*AccountAccess {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+// CreateGroupRequest represents a request to create a group
+type CreateGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGroupRequest) Reset() {
+	*x = CreateGroupRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGroupRequest) ProtoMessage() {}
+
+func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateGroupRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateGroupRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// GroupRequest represents a request about a group, by name
+type GroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupRequest) Reset() {
+	*x = GroupRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupRequest) ProtoMessage() {}
+
+func (x *GroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupRequest.ProtoReflect.Descriptor instead.
+func (*GroupRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GroupRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *GroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// GroupMember represents a member of a group
+type GroupMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupMember) Reset() {
+	*x = GroupMember{}
+	mi := &file_auth_auth_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupMember) ProtoMessage() {}
+
+func (x *GroupMember) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupMember.ProtoReflect.Descriptor instead.
+func (*GroupMember) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GroupMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GroupMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GroupMember) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+// Group represents a team of players, such as a clan
+type Group struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Owner         string                 `protobuf:"bytes,3,opt,name=owner,proto3" json:"owner,omitempty"`
+	MemberCount   int32                  `protobuf:"varint,4,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Members       []*GroupMember         `protobuf:"bytes,6,rep,name=members,proto3" json:"members,omitempty"` // Only filled in by GetGroup
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Group) Reset() {
+	*x = Group{}
+	mi := &file_auth_auth_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Group) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Group) ProtoMessage() {}
+
+func (x *Group) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Group.ProtoReflect.Descriptor instead.
+func (*Group) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *Group) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Group) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Group) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *Group) GetMemberCount() int32 {
+	if x != nil {
+		return x.MemberCount
+	}
+	return 0
+}
+
+func (x *Group) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Group) GetMembers() []*GroupMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// GroupResponse represents the result of a group operation
+type GroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Group         *Group                 `protobuf:"bytes,3,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GroupResponse) Reset() {
+	*x = GroupResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupResponse) ProtoMessage() {}
+
+func (x *GroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupResponse.ProtoReflect.Descriptor instead.
+func (*GroupResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GroupResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GroupResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GroupResponse) GetGroup() *Group {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+// ListGroupsResponse lists groups without their members
+type ListGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Groups        []*Group               `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGroupsResponse) Reset() {
+	*x = ListGroupsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGroupsResponse) ProtoMessage() {}
+
+func (x *ListGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListGroupsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListGroupsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListGroupsResponse) GetGroups() []*Group {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+// FriendRequest represents a request about the token user's friends
+type FriendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // The friend added or removed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendRequest) Reset() {
+	*x = FriendRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendRequest) ProtoMessage() {}
+
+func (x *FriendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendRequest.ProtoReflect.Descriptor instead.
+func (*FriendRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *FriendRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *FriendRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// Friend represents a user on another user's friend list
+type Friend struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	AddedAt       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=added_at,json=addedAt,proto3" json:"added_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Friend) Reset() {
+	*x = Friend{}
+	mi := &file_auth_auth_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Friend) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Friend) ProtoMessage() {}
+
+func (x *Friend) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Friend.ProtoReflect.Descriptor instead.
+func (*Friend) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *Friend) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *Friend) GetAddedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AddedAt
+	}
+	return nil
+}
+
+// FriendsResponse returns the token user's friend list after a friend operation
+type FriendsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Friends       []*Friend              `protobuf:"bytes,3,rep,name=friends,proto3" json:"friends,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FriendsResponse) Reset() {
+	*x = FriendsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FriendsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FriendsResponse) ProtoMessage() {}
+
+func (x *FriendsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FriendsResponse.ProtoReflect.Descriptor instead.
+func (*FriendsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *FriendsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *FriendsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *FriendsResponse) GetFriends() []*Friend {
+	if x != nil {
+		return x.Friends
+	}
+	return nil
+}
+
+// TermsRequest asks for a published version of the terms of service
+type TermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"` // Empty returns the current terms
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermsRequest) Reset() {
+	*x = TermsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsRequest) ProtoMessage() {}
+
+func (x *TermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsRequest.ProtoReflect.Descriptor instead.
+func (*TermsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *TermsRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// Terms is a published version of the terms of service
+type Terms struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	PublishedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Terms) Reset() {
+	*x = Terms{}
+	mi := &file_auth_auth_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Terms) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Terms) ProtoMessage() {}
+
+func (x *Terms) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Terms.ProtoReflect.Descriptor instead.
+func (*Terms) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Terms) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Terms) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Terms) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+// TermsResponse returns terms of service
+type TermsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Terms         *Terms                 `protobuf:"bytes,3,opt,name=terms,proto3" json:"terms,omitempty"` // Unset when no terms are published
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermsResponse) Reset() {
+	*x = TermsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsResponse) ProtoMessage() {}
+
+func (x *TermsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsResponse.ProtoReflect.Descriptor instead.
+func (*TermsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *TermsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TermsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TermsResponse) GetTerms() *Terms {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+// PublishTermsRequest publishes a new current version of the terms
+type PublishTermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishTermsRequest) Reset() {
+	*x = PublishTermsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishTermsRequest) ProtoMessage() {}
+
+func (x *PublishTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishTermsRequest.ProtoReflect.Descriptor instead.
+func (*PublishTermsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PublishTermsRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *PublishTermsRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *PublishTermsRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// AcceptTermsRequest records that the token user accepted a version of the terms
+type AcceptTermsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AcceptTermsRequest) Reset() {
+	*x = AcceptTermsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AcceptTermsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AcceptTermsRequest) ProtoMessage() {}
+
+func (x *AcceptTermsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AcceptTermsRequest.ProtoReflect.Descriptor instead.
+func (*AcceptTermsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *AcceptTermsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *AcceptTermsRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// TermsStatusRequest asks for a user's terms acceptance; moderators may name another user
+type TermsStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // Empty means the token user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermsStatusRequest) Reset() {
+	*x = TermsStatusRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsStatusRequest) ProtoMessage() {}
+
+func (x *TermsStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsStatusRequest.ProtoReflect.Descriptor instead.
+func (*TermsStatusRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *TermsStatusRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *TermsStatusRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// TermsAcceptance records when a user accepted a version of the terms
+type TermsAcceptance struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	AcceptedAt    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=accepted_at,json=acceptedAt,proto3" json:"accepted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TermsAcceptance) Reset() {
+	*x = TermsAcceptance{}
+	mi := &file_auth_auth_service_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsAcceptance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsAcceptance) ProtoMessage() {}
+
+func (x *TermsAcceptance) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsAcceptance.ProtoReflect.Descriptor instead.
+func (*TermsAcceptance) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *TermsAcceptance) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *TermsAcceptance) GetAcceptedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AcceptedAt
+	}
+	return nil
+}
+
+// TermsStatusResponse describes whether a user has accepted the current terms
+type TermsStatusResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	CurrentVersion string                 `protobuf:"bytes,3,opt,name=current_version,json=currentVersion,proto3" json:"current_version,omitempty"` // Empty when no terms are published
+	Accepted       bool                   `protobuf:"varint,4,opt,name=accepted,proto3" json:"accepted,omitempty"`                                  // The current version was accepted, or there is none
+	Acceptances    []*TermsAcceptance     `protobuf:"bytes,5,rep,name=acceptances,proto3" json:"acceptances,omitempty"`                             // Newest first
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TermsStatusResponse) Reset() {
+	*x = TermsStatusResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TermsStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TermsStatusResponse) ProtoMessage() {}
+
+func (x *TermsStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TermsStatusResponse.ProtoReflect.Descriptor instead.
+func (*TermsStatusResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *TermsStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *TermsStatusResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *TermsStatusResponse) GetCurrentVersion() string {
+	if x != nil {
+		return x.CurrentVersion
+	}
+	return ""
+}
+
+func (x *TermsStatusResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+func (x *TermsStatusResponse) GetAcceptances() []*TermsAcceptance {
+	if x != nil {
+		return x.Acceptances
+	}
+	return nil
+}
+
+// APIKey describes an API key; the key itself is never returned after creation
+type APIKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Prefix        string                 `protobuf:"bytes,3,opt,name=prefix,proto3" json:"prefix,omitempty"` // The start of the key, to recognise it by
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	RateLimit     int32                  `protobuf:"varint,5,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"` // Requests per minute, 0 for the server default
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"`
+	RevokedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APIKey) Reset() {
+	*x = APIKey{}
+	mi := &file_auth_auth_service_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APIKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APIKey) ProtoMessage() {}
+
+func (x *APIKey) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APIKey.ProtoReflect.Descriptor instead.
+func (*APIKey) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *APIKey) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *APIKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *APIKey) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *APIKey) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *APIKey) GetRateLimit() int32 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *APIKey) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *APIKey) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+// CreateAPIKeyRequest creates an API key for the token user
+type CreateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Scopes        []string               `protobuf:"bytes,3,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	RateLimit     int32                  `protobuf:"varint,4,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyRequest) Reset() {
+	*x = CreateAPIKeyRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyRequest) ProtoMessage() {}
+
+func (x *CreateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *CreateAPIKeyRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *CreateAPIKeyRequest) GetRateLimit() int32 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+// CreateAPIKeyResponse returns a new API key
+type CreateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"` // Shown once; only its hash is stored
+	ApiKey        *APIKey                `protobuf:"bytes,4,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAPIKeyResponse) Reset() {
+	*x = CreateAPIKeyResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAPIKeyResponse) ProtoMessage() {}
+
+func (x *CreateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*CreateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *CreateAPIKeyResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CreateAPIKeyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CreateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+// ListAPIKeysRequest lists the token user's API keys
+type ListAPIKeysRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysRequest) Reset() {
+	*x = ListAPIKeysRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysRequest) ProtoMessage() {}
+
+func (x *ListAPIKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysRequest.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListAPIKeysRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// ListAPIKeysResponse lists API keys, newest first
+type ListAPIKeysResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ApiKeys       []*APIKey              `protobuf:"bytes,3,rep,name=api_keys,json=apiKeys,proto3" json:"api_keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAPIKeysResponse) Reset() {
+	*x = ListAPIKeysResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAPIKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAPIKeysResponse) ProtoMessage() {}
+
+func (x *ListAPIKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAPIKeysResponse.ProtoReflect.Descriptor instead.
+func (*ListAPIKeysResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListAPIKeysResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListAPIKeysResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListAPIKeysResponse) GetApiKeys() []*APIKey {
+	if x != nil {
+		return x.ApiKeys
+	}
+	return nil
+}
+
+// RevokeAPIKeyRequest revokes one of the token user's API keys
+type RevokeAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	KeyId         int32                  `protobuf:"varint,2,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeAPIKeyRequest) Reset() {
+	*x = RevokeAPIKeyRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeAPIKeyRequest) ProtoMessage() {}
+
+func (x *RevokeAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*RevokeAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RevokeAPIKeyRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RevokeAPIKeyRequest) GetKeyId() int32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+// ValidateAPIKeyRequest checks an API key
+type ValidateAPIKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAPIKeyRequest) Reset() {
+	*x = ValidateAPIKeyRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAPIKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAPIKeyRequest) ProtoMessage() {}
+
+func (x *ValidateAPIKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAPIKeyRequest.ProtoReflect.Descriptor instead.
+func (*ValidateAPIKeyRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ValidateAPIKeyRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// ValidateAPIKeyResponse returns the owner and grant of a valid API key
+type ValidateAPIKeyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Valid         bool                   `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	User          *User                  `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	ApiKey        *APIKey                `protobuf:"bytes,4,opt,name=api_key,json=apiKey,proto3" json:"api_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ValidateAPIKeyResponse) Reset() {
+	*x = ValidateAPIKeyResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ValidateAPIKeyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateAPIKeyResponse) ProtoMessage() {}
+
+func (x *ValidateAPIKeyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateAPIKeyResponse.ProtoReflect.Descriptor instead.
+func (*ValidateAPIKeyResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ValidateAPIKeyResponse) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateAPIKeyResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ValidateAPIKeyResponse) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *ValidateAPIKeyResponse) GetApiKey() *APIKey {
+	if x != nil {
+		return x.ApiKey
+	}
+	return nil
+}
+
+// IssueChallengeRequest asks for a challenge for a client
+type IssueChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientIp      string                 `protobuf:"bytes,1,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // The account logged in to, if any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueChallengeRequest) Reset() {
+	*x = IssueChallengeRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueChallengeRequest) ProtoMessage() {}
+
+func (x *IssueChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueChallengeRequest.ProtoReflect.Descriptor instead.
+func (*IssueChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *IssueChallengeRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *IssueChallengeRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+// IssueChallengeResponse poses a challenge
+type IssueChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ChallengeId   string                 `protobuf:"bytes,3,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Question      string                 `protobuf:"bytes,4,opt,name=question,proto3" json:"question,omitempty"`
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueChallengeResponse) Reset() {
+	*x = IssueChallengeResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueChallengeResponse) ProtoMessage() {}
+
+func (x *IssueChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueChallengeResponse.ProtoReflect.Descriptor instead.
+func (*IssueChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *IssueChallengeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *IssueChallengeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *IssueChallengeResponse) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *IssueChallengeResponse) GetQuestion() string {
+	if x != nil {
+		return x.Question
+	}
+	return ""
+}
+
+func (x *IssueChallengeResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// AnswerChallengeRequest answers a challenge; each challenge takes one answer
+type AnswerChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId   string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Answer        string                 `protobuf:"bytes,2,opt,name=answer,proto3" json:"answer,omitempty"`
+	ClientIp      string                 `protobuf:"bytes,3,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"` // Must be the address the challenge was issued to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerChallengeRequest) Reset() {
+	*x = AnswerChallengeRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerChallengeRequest) ProtoMessage() {}
+
+func (x *AnswerChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerChallengeRequest.ProtoReflect.Descriptor instead.
+func (*AnswerChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *AnswerChallengeRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *AnswerChallengeRequest) GetAnswer() string {
+	if x != nil {
+		return x.Answer
+	}
+	return ""
+}
+
+func (x *AnswerChallengeRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+// AnswerChallengeResponse tells whether a challenge was passed
+type AnswerChallengeResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Passed         bool                   `protobuf:"varint,1,opt,name=passed,proto3" json:"passed,omitempty"`
+	Error          string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ChallengeToken string                 `protobuf:"bytes,3,opt,name=challenge_token,json=challengeToken,proto3" json:"challenge_token,omitempty"` // Set when passed, for LoginRequest.challenge_token
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AnswerChallengeResponse) Reset() {
+	*x = AnswerChallengeResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerChallengeResponse) ProtoMessage() {}
+
+func (x *AnswerChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerChallengeResponse.ProtoReflect.Descriptor instead.
+func (*AnswerChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *AnswerChallengeResponse) GetPassed() bool {
+	if x != nil {
+		return x.Passed
+	}
+	return false
+}
+
+func (x *AnswerChallengeResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AnswerChallengeResponse) GetChallengeToken() string {
+	if x != nil {
+		return x.ChallengeToken
+	}
+	return ""
+}
+
+// ClientDevice is what an SSH connection tells of the device it comes from
+type ClientDevice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	KeyFingerprint string                 `protobuf:"bytes,1,opt,name=key_fingerprint,json=keyFingerprint,proto3" json:"key_fingerprint,omitempty"` // SHA256 fingerprint of the public key offered, if any
+	ClientVersion  string                 `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`    // SSH client version string
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClientDevice) Reset() {
+	*x = ClientDevice{}
+	mi := &file_auth_auth_service_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClientDevice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClientDevice) ProtoMessage() {}
+
+func (x *ClientDevice) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClientDevice.ProtoReflect.Descriptor instead.
+func (*ClientDevice) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ClientDevice) GetKeyFingerprint() string {
+	if x != nil {
+		return x.KeyFingerprint
+	}
+	return ""
+}
+
+func (x *ClientDevice) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+// Device describes a device a user has logged in from
+type Device struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	KeyFingerprint string                 `protobuf:"bytes,2,opt,name=key_fingerprint,json=keyFingerprint,proto3" json:"key_fingerprint,omitempty"`
+	ClientVersion  string                 `protobuf:"bytes,3,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"`
+	LastIp         string                 `protobuf:"bytes,4,opt,name=last_ip,json=lastIp,proto3" json:"last_ip,omitempty"`
+	FirstSeenAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	LastSeenAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_seen_at,json=lastSeenAt,proto3" json:"last_seen_at,omitempty"`
+	RevokedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=revoked_at,json=revokedAt,proto3" json:"revoked_at,omitempty"`
+	Current        bool                   `protobuf:"varint,8,opt,name=current,proto3" json:"current,omitempty"` // The device the request's token was issued to
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Device) Reset() {
+	*x = Device{}
+	mi := &file_auth_auth_service_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Device) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Device) ProtoMessage() {}
+
+func (x *Device) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Device.ProtoReflect.Descriptor instead.
+func (*Device) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *Device) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Device) GetKeyFingerprint() string {
+	if x != nil {
+		return x.KeyFingerprint
+	}
+	return ""
+}
+
+func (x *Device) GetClientVersion() string {
+	if x != nil {
+		return x.ClientVersion
+	}
+	return ""
+}
+
+func (x *Device) GetLastIp() string {
+	if x != nil {
+		return x.LastIp
+	}
+	return ""
+}
+
+func (x *Device) GetFirstSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return nil
+}
+
+func (x *Device) GetLastSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeenAt
+	}
+	return nil
+}
+
+func (x *Device) GetRevokedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RevokedAt
+	}
+	return nil
+}
+
+func (x *Device) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+// ListDevicesRequest lists the token user's devices
+type ListDevicesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesRequest) Reset() {
+	*x = ListDevicesRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesRequest) ProtoMessage() {}
+
+func (x *ListDevicesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesRequest.ProtoReflect.Descriptor instead.
+func (*ListDevicesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *ListDevicesRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+// ListDevicesResponse lists devices, most recently seen first
+type ListDevicesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Devices       []*Device              `protobuf:"bytes,3,rep,name=devices,proto3" json:"devices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDevicesResponse) Reset() {
+	*x = ListDevicesResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDevicesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDevicesResponse) ProtoMessage() {}
+
+func (x *ListDevicesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDevicesResponse.ProtoReflect.Descriptor instead.
+func (*ListDevicesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListDevicesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListDevicesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListDevicesResponse) GetDevices() []*Device {
+	if x != nil {
+		return x.Devices
+	}
+	return nil
+}
+
+// RevokeDeviceRequest revokes one of the token user's devices
+type RevokeDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	DeviceId      int32                  `protobuf:"varint,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeDeviceRequest) Reset() {
+	*x = RevokeDeviceRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeDeviceRequest) ProtoMessage() {}
+
+func (x *RevokeDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RevokeDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *RevokeDeviceRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *RevokeDeviceRequest) GetDeviceId() int32 {
+	if x != nil {
+		return x.DeviceId
+	}
+	return 0
+}
+
+// Notification represents a message left for a user
+type Notification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // game_crashed, promoted, announcement or friend_playing
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReadAt        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=read_at,json=readAt,proto3" json:"read_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	mi := &file_auth_auth_service_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *Notification) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Notification) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Notification) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Notification) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *Notification) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Notification) GetReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReadAt
+	}
+	return nil
+}
+
+// ListNotificationsRequest lists the token user's notifications
+type ListNotificationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	UnreadOnly    bool                   `protobuf:"varint,2,opt,name=unread_only,json=unreadOnly,proto3" json:"unread_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // 0 for the most kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsRequest) Reset() {
+	*x = ListNotificationsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsRequest) ProtoMessage() {}
+
+func (x *ListNotificationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsRequest.ProtoReflect.Descriptor instead.
+func (*ListNotificationsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *ListNotificationsRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *ListNotificationsRequest) GetUnreadOnly() bool {
+	if x != nil {
+		return x.UnreadOnly
+	}
+	return false
+}
+
+func (x *ListNotificationsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// ListNotificationsResponse returns a user's notifications, newest first
+type ListNotificationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Notifications []*Notification        `protobuf:"bytes,3,rep,name=notifications,proto3" json:"notifications,omitempty"`
+	UnreadCount   int32                  `protobuf:"varint,4,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNotificationsResponse) Reset() {
+	*x = ListNotificationsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNotificationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNotificationsResponse) ProtoMessage() {}
+
+func (x *ListNotificationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNotificationsResponse.ProtoReflect.Descriptor instead.
+func (*ListNotificationsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *ListNotificationsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListNotificationsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListNotificationsResponse) GetNotifications() []*Notification {
+	if x != nil {
+		return x.Notifications
+	}
+	return nil
+}
+
+func (x *ListNotificationsResponse) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+// MarkNotificationsReadRequest marks the token user's notifications as read
+type MarkNotificationsReadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken   string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	Ids           []int32                `protobuf:"varint,2,rep,packed,name=ids,proto3" json:"ids,omitempty"` // Empty marks every notification read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MarkNotificationsReadRequest) Reset() {
+	*x = MarkNotificationsReadRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MarkNotificationsReadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkNotificationsReadRequest) ProtoMessage() {}
+
+func (x *MarkNotificationsReadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MarkNotificationsReadRequest.ProtoReflect.Descriptor instead.
+func (*MarkNotificationsReadRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *MarkNotificationsReadRequest) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *MarkNotificationsReadRequest) GetIds() []int32 {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+// SendNotificationRequest represents an admin leaving a notification
+type SendNotificationRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"` // Empty sends to every active user
+	Title          string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body           string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SendNotificationRequest) Reset() {
+	*x = SendNotificationRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendNotificationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendNotificationRequest) ProtoMessage() {}
+
+func (x *SendNotificationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendNotificationRequest.ProtoReflect.Descriptor instead.
+func (*SendNotificationRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *SendNotificationRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *SendNotificationRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *SendNotificationRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *SendNotificationRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// NotifyUserRequest represents a service leaving a notification for a user
+type NotifyUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NotifyUserRequest) Reset() {
+	*x = NotifyUserRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NotifyUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NotifyUserRequest) ProtoMessage() {}
+
+func (x *NotifyUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NotifyUserRequest.ProtoReflect.Descriptor instead.
+func (*NotifyUserRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *NotifyUserRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *NotifyUserRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *NotifyUserRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *NotifyUserRequest) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+// AddUserNoteRequest represents a request to attach a note to a user account
+type AddUserNoteRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	Note           string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddUserNoteRequest) Reset() {
+	*x = AddUserNoteRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddUserNoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddUserNoteRequest) ProtoMessage() {}
+
+func (x *AddUserNoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddUserNoteRequest.ProtoReflect.Descriptor instead.
+func (*AddUserNoteRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *AddUserNoteRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *AddUserNoteRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *AddUserNoteRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+// UserNote represents a moderator note on a user account
+type UserNote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Author        string                 `protobuf:"bytes,2,opt,name=author,proto3" json:"author,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserNote) Reset() {
+	*x = UserNote{}
+	mi := &file_auth_auth_service_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserNote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserNote) ProtoMessage() {}
+
+func (x *UserNote) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserNote.ProtoReflect.Descriptor instead.
+func (*UserNote) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *UserNote) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UserNote) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *UserNote) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *UserNote) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// UserModerationFlag represents a moderation flag set on a user account
+type UserModerationFlag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Flag          string                 `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"` // "warned", "restricted", "play_time_exempt"
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	SetBy         string                 `protobuf:"bytes,4,opt,name=set_by,json=setBy,proto3" json:"set_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserModerationFlag) Reset() {
+	*x = UserModerationFlag{}
+	mi := &file_auth_auth_service_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserModerationFlag) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserModerationFlag) ProtoMessage() {}
+
+func (x *UserModerationFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserModerationFlag.ProtoReflect.Descriptor instead.
+func (*UserModerationFlag) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *UserModerationFlag) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserModerationFlag) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *UserModerationFlag) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UserModerationFlag) GetSetBy() string {
+	if x != nil {
+		return x.SetBy
+	}
+	return ""
+}
+
+func (x *UserModerationFlag) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// GetUserModerationResponse represents the moderation record of a user account
+type GetUserModerationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Notes         []*UserNote            `protobuf:"bytes,3,rep,name=notes,proto3" json:"notes,omitempty"`
+	Flags         []*UserModerationFlag  `protobuf:"bytes,4,rep,name=flags,proto3" json:"flags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserModerationResponse) Reset() {
+	*x = GetUserModerationResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserModerationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserModerationResponse) ProtoMessage() {}
+
+func (x *GetUserModerationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserModerationResponse.ProtoReflect.Descriptor instead.
+func (*GetUserModerationResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *GetUserModerationResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetUserModerationResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetUserModerationResponse) GetNotes() []*UserNote {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *GetUserModerationResponse) GetFlags() []*UserModerationFlag {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
+// ModerationFlagRequest represents a request to set or clear a moderation flag
+type ModerationFlagRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
+	Flag           string                 `protobuf:"bytes,3,opt,name=flag,proto3" json:"flag,omitempty"`
+	Reason         string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // Only used when setting a flag
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ModerationFlagRequest) Reset() {
+	*x = ModerationFlagRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModerationFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModerationFlagRequest) ProtoMessage() {}
+
+func (x *ModerationFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModerationFlagRequest.ProtoReflect.Descriptor instead.
+func (*ModerationFlagRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ModerationFlagRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ModerationFlagRequest) GetTargetUsername() string {
+	if x != nil {
+		return x.TargetUsername
+	}
+	return ""
+}
+
+func (x *ModerationFlagRequest) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+func (x *ModerationFlagRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// ListUsersByModerationFlagRequest represents a request to list flagged users
+type ListUsersByModerationFlagRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Flag          string                 `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersByModerationFlagRequest) Reset() {
+	*x = ListUsersByModerationFlagRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersByModerationFlagRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersByModerationFlagRequest) ProtoMessage() {}
+
+func (x *ListUsersByModerationFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersByModerationFlagRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersByModerationFlagRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *ListUsersByModerationFlagRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ListUsersByModerationFlagRequest) GetFlag() string {
+	if x != nil {
+		return x.Flag
+	}
+	return ""
+}
+
+// ListUsersByModerationFlagResponse represents the users with a moderation flag set
+type ListUsersByModerationFlagResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Users         []*UserModerationFlag  `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersByModerationFlagResponse) Reset() {
+	*x = ListUsersByModerationFlagResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersByModerationFlagResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersByModerationFlagResponse) ProtoMessage() {}
+
+func (x *ListUsersByModerationFlagResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[89]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersByModerationFlagResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersByModerationFlagResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *ListUsersByModerationFlagResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListUsersByModerationFlagResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListUsersByModerationFlagResponse) GetUsers() []*UserModerationFlag {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+// BannerRequest represents an admin request about one banner, or all of them
+type BannerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // e.g. "main_anon", "header_global"; unused by ListBanners
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannerRequest) Reset() {
+	*x = BannerRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[90]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannerRequest) ProtoMessage() {}
+
+func (x *BannerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[90]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannerRequest.ProtoReflect.Descriptor instead.
+func (*BannerRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{90}
+}
+
+func (x *BannerRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *BannerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// BannerTemplate represents a banner template stored at runtime
+type BannerTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,3,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannerTemplate) Reset() {
+	*x = BannerTemplate{}
+	mi := &file_auth_auth_service_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannerTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannerTemplate) ProtoMessage() {}
+
+func (x *BannerTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannerTemplate.ProtoReflect.Descriptor instead.
+func (*BannerTemplate) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *BannerTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BannerTemplate) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *BannerTemplate) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *BannerTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// ListBannersResponse represents the banner names and their runtime templates
+type ListBannersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Names         []string               `protobuf:"bytes,3,rep,name=names,proto3" json:"names,omitempty"`
+	Banners       []*BannerTemplate      `protobuf:"bytes,4,rep,name=banners,proto3" json:"banners,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBannersResponse) Reset() {
+	*x = ListBannersResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[92]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBannersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBannersResponse) ProtoMessage() {}
+
+func (x *ListBannersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[92]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBannersResponse.ProtoReflect.Descriptor instead.
+func (*ListBannersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{92}
+}
+
+func (x *ListBannersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListBannersResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListBannersResponse) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *ListBannersResponse) GetBanners() []*BannerTemplate {
+	if x != nil {
+		return x.Banners
+	}
+	return nil
+}
+
+// GetBannerResponse represents the runtime template of a banner
+type GetBannerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Overridden    bool                   `protobuf:"varint,3,opt,name=overridden,proto3" json:"overridden,omitempty"` // False when session services use their banner file
+	Banner        *BannerTemplate        `protobuf:"bytes,4,opt,name=banner,proto3" json:"banner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBannerResponse) Reset() {
+	*x = GetBannerResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[93]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBannerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBannerResponse) ProtoMessage() {}
+
+func (x *GetBannerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[93]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBannerResponse.ProtoReflect.Descriptor instead.
+func (*GetBannerResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{93}
+}
+
+func (x *GetBannerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *GetBannerResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetBannerResponse) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+func (x *GetBannerResponse) GetBanner() *BannerTemplate {
+	if x != nil {
+		return x.Banner
+	}
+	return nil
+}
+
+// UpdateBannerRequest represents a request to replace a banner template
+type UpdateBannerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBannerRequest) Reset() {
+	*x = UpdateBannerRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[94]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBannerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBannerRequest) ProtoMessage() {}
+
+func (x *UpdateBannerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[94]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBannerRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBannerRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{94}
+}
+
+func (x *UpdateBannerRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *UpdateBannerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateBannerRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+// PreviewBannerRequest represents a request to render a banner template
+type PreviewBannerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`                     // Substituted for $USERNAME
+	AsciiOnly     bool                   `protobuf:"varint,5,opt,name=ascii_only,json=asciiOnly,proto3" json:"ascii_only,omitempty"` // Render with the ASCII fallbacks used for non-UTF-8 terminals
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewBannerRequest) Reset() {
+	*x = PreviewBannerRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[95]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewBannerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBannerRequest) ProtoMessage() {}
+
+func (x *PreviewBannerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[95]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBannerRequest.ProtoReflect.Descriptor instead.
+func (*PreviewBannerRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{95}
+}
+
+func (x *PreviewBannerRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *PreviewBannerRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PreviewBannerRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PreviewBannerRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *PreviewBannerRequest) GetAsciiOnly() bool {
+	if x != nil {
+		return x.AsciiOnly
+	}
+	return false
+}
+
+// PreviewBannerResponse represents a rendered banner template
+type PreviewBannerResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Rendered      string                 `protobuf:"bytes,3,opt,name=rendered,proto3" json:"rendered,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewBannerResponse) Reset() {
+	*x = PreviewBannerResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[96]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewBannerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewBannerResponse) ProtoMessage() {}
+
+func (x *PreviewBannerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[96]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewBannerResponse.ProtoReflect.Descriptor instead.
+func (*PreviewBannerResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{96}
+}
+
+func (x *PreviewBannerResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PreviewBannerResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *PreviewBannerResponse) GetRendered() string {
+	if x != nil {
+		return x.Rendered
+	}
+	return ""
+}
+
+// WatchBannersRequest represents a session service subscribing to banner changes
+type WatchBannersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchBannersRequest) Reset() {
+	*x = WatchBannersRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[97]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchBannersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchBannersRequest) ProtoMessage() {}
+
+func (x *WatchBannersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[97]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchBannersRequest.ProtoReflect.Descriptor instead.
+func (*WatchBannersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{97}
+}
+
+func (x *WatchBannersRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+// BannerUpdate represents a banner template change
+type BannerUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Cleared       bool                   `protobuf:"varint,3,opt,name=cleared,proto3" json:"cleared,omitempty"` // The runtime template was removed; use the banner file
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BannerUpdate) Reset() {
+	*x = BannerUpdate{}
+	mi := &file_auth_auth_service_proto_msgTypes[98]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BannerUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BannerUpdate) ProtoMessage() {}
+
+func (x *BannerUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[98]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BannerUpdate.ProtoReflect.Descriptor instead.
+func (*BannerUpdate) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{98}
+}
+
+func (x *BannerUpdate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BannerUpdate) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *BannerUpdate) GetCleared() bool {
+	if x != nil {
+		return x.Cleared
+	}
+	return false
+}
+
+func (x *BannerUpdate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// SessionInstance is the load a session service replica last reported
+type SessionInstance struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId          string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"` // Pod or host name, also the instance_id label of its metrics
+	Version             string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	ConnectionsActive   int32                  `protobuf:"varint,3,opt,name=connections_active,json=connectionsActive,proto3" json:"connections_active,omitempty"`
+	ConnectionsTotal    int64                  `protobuf:"varint,4,opt,name=connections_total,json=connectionsTotal,proto3" json:"connections_total,omitempty"` // Since the replica started
+	ConnectionsPeak     int32                  `protobuf:"varint,5,opt,name=connections_peak,json=connectionsPeak,proto3" json:"connections_peak,omitempty"`
+	MaxConnections      int32                  `protobuf:"varint,6,opt,name=max_connections,json=maxConnections,proto3" json:"max_connections,omitempty"`
+	GameSessions        int32                  `protobuf:"varint,7,opt,name=game_sessions,json=gameSessions,proto3" json:"game_sessions,omitempty"`                      // Game sessions played through the replica
+	ConnectionsRejected int64                  `protobuf:"varint,8,opt,name=connections_rejected,json=connectionsRejected,proto3" json:"connections_rejected,omitempty"` // Refused because the replica was full or rate limited
+	StartedAt           *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	ReportedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=reported_at,json=reportedAt,proto3" json:"reported_at,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SessionInstance) Reset() {
+	*x = SessionInstance{}
+	mi := &file_auth_auth_service_proto_msgTypes[99]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionInstance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionInstance) ProtoMessage() {}
+
+func (x *SessionInstance) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[99]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionInstance.ProtoReflect.Descriptor instead.
+func (*SessionInstance) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{99}
+}
+
+func (x *SessionInstance) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *SessionInstance) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *SessionInstance) GetConnectionsActive() int32 {
+	if x != nil {
+		return x.ConnectionsActive
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetConnectionsTotal() int64 {
+	if x != nil {
+		return x.ConnectionsTotal
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetConnectionsPeak() int32 {
+	if x != nil {
+		return x.ConnectionsPeak
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetMaxConnections() int32 {
+	if x != nil {
+		return x.MaxConnections
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetGameSessions() int32 {
+	if x != nil {
+		return x.GameSessions
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetConnectionsRejected() int64 {
+	if x != nil {
+		return x.ConnectionsRejected
+	}
+	return 0
+}
+
+func (x *SessionInstance) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
+}
+
+func (x *SessionInstance) GetReportedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReportedAt
+	}
+	return nil
+}
+
+// ReportSessionInstanceRequest represents a session service replica reporting its load
+type ReportSessionInstanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instance      *SessionInstance       `protobuf:"bytes,1,opt,name=instance,proto3" json:"instance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReportSessionInstanceRequest) Reset() {
+	*x = ReportSessionInstanceRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReportSessionInstanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReportSessionInstanceRequest) ProtoMessage() {}
+
+func (x *ReportSessionInstanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReportSessionInstanceRequest.ProtoReflect.Descriptor instead.
+func (*ReportSessionInstanceRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *ReportSessionInstanceRequest) GetInstance() *SessionInstance {
+	if x != nil {
+		return x.Instance
+	}
+	return nil
+}
+
+// ListSessionInstancesRequest represents a request for the session service replicas
+type ListSessionInstancesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionInstancesRequest) Reset() {
+	*x = ListSessionInstancesRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[101]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionInstancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionInstancesRequest) ProtoMessage() {}
+
+func (x *ListSessionInstancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[101]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionInstancesRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionInstancesRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{101}
+}
+
+func (x *ListSessionInstancesRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+// ListSessionInstancesResponse lists the replicas by instance ID, with their sums
+type ListSessionInstancesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Instances     []*SessionInstance     `protobuf:"bytes,3,rep,name=instances,proto3" json:"instances,omitempty"`
+	Totals        *SessionInstance       `protobuf:"bytes,4,opt,name=totals,proto3" json:"totals,omitempty"` // Sums over the replicas; max_connections is the cluster's capacity
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionInstancesResponse) Reset() {
+	*x = ListSessionInstancesResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[102]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionInstancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionInstancesResponse) ProtoMessage() {}
+
+func (x *ListSessionInstancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[102]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionInstancesResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionInstancesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{102}
+}
+
+func (x *ListSessionInstancesResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListSessionInstancesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListSessionInstancesResponse) GetInstances() []*SessionInstance {
+	if x != nil {
+		return x.Instances
+	}
+	return nil
+}
+
+func (x *ListSessionInstancesResponse) GetTotals() *SessionInstance {
+	if x != nil {
+		return x.Totals
+	}
+	return nil
+}
+
+// CountSessionLoginsRequest represents a login starting or ending on a session service replica
+type CountSessionLoginsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	InstanceId    string                 `protobuf:"bytes,1,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Delta         int32                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"` // 1 when a login starts, -1 when it ends
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountSessionLoginsRequest) Reset() {
+	*x = CountSessionLoginsRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[103]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountSessionLoginsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountSessionLoginsRequest) ProtoMessage() {}
+
+func (x *CountSessionLoginsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[103]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountSessionLoginsRequest.ProtoReflect.Descriptor instead.
+func (*CountSessionLoginsRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{103}
+}
+
+func (x *CountSessionLoginsRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *CountSessionLoginsRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CountSessionLoginsRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+// CountSessionLoginsResponse holds the user's logins across the replicas
+type CountSessionLoginsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logins        int32                  `protobuf:"varint,1,opt,name=logins,proto3" json:"logins,omitempty"` // Including the login that started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CountSessionLoginsResponse) Reset() {
+	*x = CountSessionLoginsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[104]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CountSessionLoginsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CountSessionLoginsResponse) ProtoMessage() {}
+
+func (x *CountSessionLoginsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[104]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CountSessionLoginsResponse.ProtoReflect.Descriptor instead.
+func (*CountSessionLoginsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{104}
+}
+
+func (x *CountSessionLoginsResponse) GetLogins() int32 {
+	if x != nil {
+		return x.Logins
+	}
+	return 0
+}
+
+// UserFilter selects user accounts; unset fields match every account
+type UserFilter struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	ModerationFlag  string                 `protobuf:"bytes,1,opt,name=moderation_flag,json=moderationFlag,proto3" json:"moderation_flag,omitempty"`
+	Role            string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"` // "admin", "moderator" or "beta"
+	CreatedAfter    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_after,json=createdAfter,proto3" json:"created_after,omitempty"`
+	CreatedBefore   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_before,json=createdBefore,proto3" json:"created_before,omitempty"`
+	LastLoginAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_login_after,json=lastLoginAfter,proto3" json:"last_login_after,omitempty"`
+	LastLoginBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=last_login_before,json=lastLoginBefore,proto3" json:"last_login_before,omitempty"` // Also matches users who never logged in
+	LockedOnly      bool                   `protobuf:"varint,7,opt,name=locked_only,json=lockedOnly,proto3" json:"locked_only,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserFilter) Reset() {
+	*x = UserFilter{}
+	mi := &file_auth_auth_service_proto_msgTypes[105]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserFilter) ProtoMessage() {}
+
+func (x *UserFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[105]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserFilter.ProtoReflect.Descriptor instead.
+func (*UserFilter) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{105}
+}
+
+func (x *UserFilter) GetModerationFlag() string {
+	if x != nil {
+		return x.ModerationFlag
+	}
+	return ""
+}
+
+func (x *UserFilter) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserFilter) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *UserFilter) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+func (x *UserFilter) GetLastLoginAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAfter
+	}
+	return nil
+}
+
+func (x *UserFilter) GetLastLoginBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginBefore
+	}
+	return nil
+}
+
+func (x *UserFilter) GetLockedOnly() bool {
+	if x != nil {
+		return x.LockedOnly
+	}
+	return false
+}
+
+// ListUsersRequest represents a request to list user accounts
+type ListUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Filter        *UserFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"` // 0 returns every matching account
+	Offset        int32                  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[106]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[106]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{106}
+}
+
+func (x *ListUsersRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ListUsersRequest) GetFilter() *UserFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListUsersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// UserSummary represents a user account in an admin listing
+type UserSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Email         string                 `protobuf:"bytes,2,opt,name=email,proto3" json:"email,omitempty"`
+	Roles         []string               `protobuf:"bytes,3,rep,name=roles,proto3" json:"roles,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastLogin     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
+	LoginCount    int32                  `protobuf:"varint,6,opt,name=login_count,json=loginCount,proto3" json:"login_count,omitempty"`
+	Locked        bool                   `protobuf:"varint,7,opt,name=locked,proto3" json:"locked,omitempty"`
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"` // Unset for an indefinite lock
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserSummary) Reset() {
+	*x = UserSummary{}
+	mi := &file_auth_auth_service_proto_msgTypes[107]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSummary) ProtoMessage() {}
+
+func (x *UserSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[107]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSummary.ProtoReflect.Descriptor instead.
+func (*UserSummary) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{107}
+}
+
+func (x *UserSummary) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserSummary) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *UserSummary) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *UserSummary) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserSummary) GetLastLogin() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLogin
+	}
+	return nil
+}
+
+func (x *UserSummary) GetLoginCount() int32 {
+	if x != nil {
+		return x.LoginCount
+	}
+	return 0
+}
+
+func (x *UserSummary) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *UserSummary) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
+// ListUsersResponse represents a page of user accounts
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Users         []*UserSummary         `protobuf:"bytes,3,rep,name=users,proto3" json:"users,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,4,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Matching accounts before paging
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[108]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[108]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{108}
+}
+
+func (x *ListUsersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ListUsersResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ListUsersResponse) GetUsers() []*UserSummary {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// BulkLockRequest represents a request to lock or unlock user accounts
+type BulkLockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Usernames     []string               `protobuf:"bytes,2,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Locked        bool                   `protobuf:"varint,3,opt,name=locked,proto3" json:"locked,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`                              // Recorded in the moderation audit log
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"` // Unset locks until unlocked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkLockRequest) Reset() {
+	*x = BulkLockRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[109]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkLockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkLockRequest) ProtoMessage() {}
+
+func (x *BulkLockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[109]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkLockRequest.ProtoReflect.Descriptor instead.
+func (*BulkLockRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{109}
+}
+
+func (x *BulkLockRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *BulkLockRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *BulkLockRequest) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *BulkLockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BulkLockRequest) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
+// BulkRoleRequest represents a request to grant or revoke a role
+type BulkRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Usernames     []string               `protobuf:"bytes,2,rep,name=usernames,proto3" json:"usernames,omitempty"`
+	Role          string                 `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`    // "admin", "moderator" or "beta"
+	Grant         bool                   `protobuf:"varint,4,opt,name=grant,proto3" json:"grant,omitempty"` // False revokes the role
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkRoleRequest) Reset() {
+	*x = BulkRoleRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[110]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkRoleRequest) ProtoMessage() {}
+
+func (x *BulkRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[110]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkRoleRequest.ProtoReflect.Descriptor instead.
+func (*BulkRoleRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{110}
+}
+
+func (x *BulkRoleRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *BulkRoleRequest) GetUsernames() []string {
+	if x != nil {
+		return x.Usernames
+	}
+	return nil
+}
+
+func (x *BulkRoleRequest) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *BulkRoleRequest) GetGrant() bool {
+	if x != nil {
+		return x.Grant
+	}
+	return false
+}
+
+// BulkActionFailure represents one account a bulk action could not change
+type BulkActionFailure struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkActionFailure) Reset() {
+	*x = BulkActionFailure{}
+	mi := &file_auth_auth_service_proto_msgTypes[111]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkActionFailure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkActionFailure) ProtoMessage() {}
+
+func (x *BulkActionFailure) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[111]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkActionFailure.ProtoReflect.Descriptor instead.
+func (*BulkActionFailure) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{111}
+}
+
+func (x *BulkActionFailure) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *BulkActionFailure) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// BulkAdminActionResponse represents the result of a bulk admin action.
+// Success is true when the action ran, even if some accounts failed.
+type BulkAdminActionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Updated       int32                  `protobuf:"varint,3,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failures      []*BulkActionFailure   `protobuf:"bytes,4,rep,name=failures,proto3" json:"failures,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkAdminActionResponse) Reset() {
+	*x = BulkAdminActionResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[112]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkAdminActionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkAdminActionResponse) ProtoMessage() {}
+
+func (x *BulkAdminActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[112]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkAdminActionResponse.ProtoReflect.Descriptor instead.
+func (*BulkAdminActionResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{112}
+}
+
+func (x *BulkAdminActionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BulkAdminActionResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BulkAdminActionResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *BulkAdminActionResponse) GetFailures() []*BulkActionFailure {
+	if x != nil {
+		return x.Failures
+	}
+	return nil
+}
+
+// ExportUsersRequest represents a request to export user accounts
+type ExportUsersRequest struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken            string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Filter                *UserFilter            `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	IncludePasswordHashes bool                   `protobuf:"varint,3,opt,name=include_password_hashes,json=includePasswordHashes,proto3" json:"include_password_hashes,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *ExportUsersRequest) Reset() {
+	*x = ExportUsersRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[113]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersRequest) ProtoMessage() {}
+
+func (x *ExportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[113]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ExportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{113}
+}
+
+func (x *ExportUsersRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ExportUsersRequest) GetFilter() *UserFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ExportUsersRequest) GetIncludePasswordHashes() bool {
+	if x != nil {
+		return x.IncludePasswordHashes
+	}
+	return false
+}
+
+// ExportUsersResponse represents exported user accounts
+type ExportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Csv           []byte                 `protobuf:"bytes,3,opt,name=csv,proto3" json:"csv,omitempty"`
+	Count         int32                  `protobuf:"varint,4,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportUsersResponse) Reset() {
+	*x = ExportUsersResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[114]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportUsersResponse) ProtoMessage() {}
+
+func (x *ExportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[114]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ExportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{114}
+}
+
+func (x *ExportUsersResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ExportUsersResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *ExportUsersResponse) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+func (x *ExportUsersResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// ImportUsersRequest represents a request to create user accounts from CSV
+type ImportUsersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Csv           []byte                 `protobuf:"bytes,2,opt,name=csv,proto3" json:"csv,omitempty"`
+	DryRun        bool                   `protobuf:"varint,3,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Validate every row without creating accounts
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportUsersRequest) Reset() {
+	*x = ImportUsersRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[115]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportUsersRequest) ProtoMessage() {}
+
+func (x *ImportUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[115]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportUsersRequest.ProtoReflect.Descriptor instead.
+func (*ImportUsersRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{115}
+}
+
+func (x *ImportUsersRequest) GetAdminToken() string {
+	if x != nil {
+		return x.AdminToken
+	}
+	return ""
+}
+
+func (x *ImportUsersRequest) GetCsv() []byte {
+	if x != nil {
+		return x.Csv
+	}
+	return nil
+}
+
+func (x *ImportUsersRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// ImportedUser represents the result of importing one CSV row
+type ImportedUser struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Line              int32                  `protobuf:"varint,1,opt,name=line,proto3" json:"line,omitempty"`
+	Username          string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Error             string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                                                  // Empty when the row was (or, in a dry run, would be) imported
+	TemporaryPassword string                 `protobuf:"bytes,4,opt,name=temporary_password,json=temporaryPassword,proto3" json:"temporary_password,omitempty"` // Set when the row had no password
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ImportedUser) Reset() {
+	*x = ImportedUser{}
+	mi := &file_auth_auth_service_proto_msgTypes[116]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportedUser) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportedUser) ProtoMessage() {}
+
+func (x *ImportedUser) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[116]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportedUser.ProtoReflect.Descriptor instead.
+func (*ImportedUser) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{116}
+}
+
+func (x *ImportedUser) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *ImportedUser) GetUsername() string {
+	if x != nil {
+		return x.Username
 	}
 	return ""
 }
 
-func (x *HealthResponse) GetDetails() map[string]string {
+func (x *ImportedUser) GetError() string {
 	if x != nil {
-		return x.Details
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *HealthResponse) GetTimestamp() *timestamppb.Timestamp {
+func (x *ImportedUser) GetTemporaryPassword() string {
 	if x != nil {
-		return x.Timestamp
+		return x.TemporaryPassword
 	}
-	return nil
+	return ""
 }
 
-// User represents user information
-type User struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Username        string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email           string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	IsActive        bool                   `protobuf:"varint,4,opt,name=is_active,json=isActive,proto3" json:"is_active,omitempty"`
-	IsAdmin         bool                   `protobuf:"varint,5,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
-	IsAuthenticated bool                   `protobuf:"varint,6,opt,name=is_authenticated,json=isAuthenticated,proto3" json:"is_authenticated,omitempty"`
-	EmailVerified   bool                   `protobuf:"varint,7,opt,name=email_verified,json=emailVerified,proto3" json:"email_verified,omitempty"`
-	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LastLogin       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_login,json=lastLogin,proto3" json:"last_login,omitempty"`
-	Roles           []string               `protobuf:"bytes,11,rep,name=roles,proto3" json:"roles,omitempty"`
-	Permissions     []string               `protobuf:"bytes,12,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	Metadata        map[string]string      `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+// ImportUsersResponse represents the result of a CSV import
+type ImportUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Created       int32                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	Users         []*ImportedUser        `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *User) Reset() {
-	*x = User{}
-	mi := &file_auth_auth_service_proto_msgTypes[21]
+func (x *ImportUsersResponse) Reset() {
+	*x = ImportUsersResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[117]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *User) String() string {
+func (x *ImportUsersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*User) ProtoMessage() {}
+func (*ImportUsersResponse) ProtoMessage() {}
 
-func (x *User) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[21]
+func (x *ImportUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[117]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1482,137 +7862,196 @@ func (x *User) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use User.ProtoReflect.Descriptor instead.
-func (*User) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ImportUsersResponse.ProtoReflect.Descriptor instead.
+func (*ImportUsersResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{117}
 }
 
-func (x *User) GetId() string {
+func (x *ImportUsersResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Id
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *User) GetUsername() string {
+func (x *ImportUsersResponse) GetError() string {
 	if x != nil {
-		return x.Username
+		return x.Error
 	}
 	return ""
 }
 
-func (x *User) GetEmail() string {
+func (x *ImportUsersResponse) GetCreated() int32 {
 	if x != nil {
-		return x.Email
+		return x.Created
 	}
-	return ""
+	return 0
 }
 
-func (x *User) GetIsActive() bool {
+func (x *ImportUsersResponse) GetUsers() []*ImportedUser {
 	if x != nil {
-		return x.IsActive
+		return x.Users
 	}
-	return false
+	return nil
 }
 
-func (x *User) GetIsAdmin() bool {
+// EncryptPersonalDataRequest represents a request to encrypt users' personal data
+type EncryptPersonalDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Count the users that need encrypting without changing them
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EncryptPersonalDataRequest) Reset() {
+	*x = EncryptPersonalDataRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[118]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptPersonalDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptPersonalDataRequest) ProtoMessage() {}
+
+func (x *EncryptPersonalDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[118]
 	if x != nil {
-		return x.IsAdmin
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetIsAuthenticated() bool {
+// Deprecated: Use EncryptPersonalDataRequest.ProtoReflect.Descriptor instead.
+func (*EncryptPersonalDataRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{118}
+}
+
+func (x *EncryptPersonalDataRequest) GetAdminToken() string {
 	if x != nil {
-		return x.IsAuthenticated
+		return x.AdminToken
 	}
-	return false
+	return ""
 }
 
-func (x *User) GetEmailVerified() bool {
+func (x *EncryptPersonalDataRequest) GetDryRun() bool {
 	if x != nil {
-		return x.EmailVerified
+		return x.DryRun
 	}
 	return false
 }
 
-func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+// EncryptPersonalDataResponse represents the result of encrypting users' personal data
+type EncryptPersonalDataResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error            string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	UsersScanned     int32                  `protobuf:"varint,3,opt,name=users_scanned,json=usersScanned,proto3" json:"users_scanned,omitempty"`
+	UsersEncrypted   int32                  `protobuf:"varint,4,opt,name=users_encrypted,json=usersEncrypted,proto3" json:"users_encrypted,omitempty"`       // Users whose data was stored unencrypted
+	UsersReencrypted int32                  `protobuf:"varint,5,opt,name=users_reencrypted,json=usersReencrypted,proto3" json:"users_reencrypted,omitempty"` // Users whose data was encrypted with an older key
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *EncryptPersonalDataResponse) Reset() {
+	*x = EncryptPersonalDataResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[119]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EncryptPersonalDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EncryptPersonalDataResponse) ProtoMessage() {}
+
+func (x *EncryptPersonalDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[119]
 	if x != nil {
-		return x.CreatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *User) GetUpdatedAt() *timestamppb.Timestamp {
+// Deprecated: Use EncryptPersonalDataResponse.ProtoReflect.Descriptor instead.
+func (*EncryptPersonalDataResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{119}
+}
+
+func (x *EncryptPersonalDataResponse) GetSuccess() bool {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-func (x *User) GetLastLogin() *timestamppb.Timestamp {
+func (x *EncryptPersonalDataResponse) GetError() string {
 	if x != nil {
-		return x.LastLogin
+		return x.Error
 	}
-	return nil
+	return ""
 }
 
-func (x *User) GetRoles() []string {
+func (x *EncryptPersonalDataResponse) GetUsersScanned() int32 {
 	if x != nil {
-		return x.Roles
+		return x.UsersScanned
 	}
-	return nil
+	return 0
 }
 
-func (x *User) GetPermissions() []string {
+func (x *EncryptPersonalDataResponse) GetUsersEncrypted() int32 {
 	if x != nil {
-		return x.Permissions
+		return x.UsersEncrypted
 	}
-	return nil
+	return 0
 }
 
-func (x *User) GetMetadata() map[string]string {
+func (x *EncryptPersonalDataResponse) GetUsersReencrypted() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.UsersReencrypted
 	}
-	return nil
+	return 0
 }
 
-// TokenClaims represents JWT token claims
-type TokenClaims struct {
+// UsernameChange represents one rename of a user account
+type UsernameChange struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	Email         string                 `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
-	Roles         []string               `protobuf:"bytes,4,rep,name=roles,proto3" json:"roles,omitempty"`
-	Permissions   []string               `protobuf:"bytes,5,rep,name=permissions,proto3" json:"permissions,omitempty"`
-	ClientId      string                 `protobuf:"bytes,6,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
-	SessionId     string                 `protobuf:"bytes,7,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	IssuedAt      int64                  `protobuf:"varint,8,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
-	ExpiresAt     int64                  `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
-	NotBefore     int64                  `protobuf:"varint,10,opt,name=not_before,json=notBefore,proto3" json:"not_before,omitempty"`
-	Issuer        string                 `protobuf:"bytes,11,opt,name=issuer,proto3" json:"issuer,omitempty"`
-	Audience      string                 `protobuf:"bytes,12,opt,name=audience,proto3" json:"audience,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,13,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	OldUsername   string                 `protobuf:"bytes,1,opt,name=old_username,json=oldUsername,proto3" json:"old_username,omitempty"`
+	NewUsername   string                 `protobuf:"bytes,2,opt,name=new_username,json=newUsername,proto3" json:"new_username,omitempty"`
+	ChangedBy     string                 `protobuf:"bytes,3,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	ChangedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	ReservedUntil *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=reserved_until,json=reservedUntil,proto3" json:"reserved_until,omitempty"` // When others may take old_username; unset if never reserved
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TokenClaims) Reset() {
-	*x = TokenClaims{}
-	mi := &file_auth_auth_service_proto_msgTypes[22]
+func (x *UsernameChange) Reset() {
+	*x = UsernameChange{}
+	mi := &file_auth_auth_service_proto_msgTypes[120]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TokenClaims) String() string {
+func (x *UsernameChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TokenClaims) ProtoMessage() {}
+func (*UsernameChange) ProtoMessage() {}
 
-func (x *TokenClaims) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[22]
+func (x *UsernameChange) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[120]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1623,126 +8062,140 @@ func (x *TokenClaims) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TokenClaims.ProtoReflect.Descriptor instead.
-func (*TokenClaims) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use UsernameChange.ProtoReflect.Descriptor instead.
+func (*UsernameChange) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{120}
 }
 
-func (x *TokenClaims) GetUserId() string {
+func (x *UsernameChange) GetOldUsername() string {
 	if x != nil {
-		return x.UserId
+		return x.OldUsername
 	}
 	return ""
 }
 
-func (x *TokenClaims) GetUsername() string {
+func (x *UsernameChange) GetNewUsername() string {
 	if x != nil {
-		return x.Username
+		return x.NewUsername
 	}
 	return ""
 }
 
-func (x *TokenClaims) GetEmail() string {
+func (x *UsernameChange) GetChangedBy() string {
 	if x != nil {
-		return x.Email
+		return x.ChangedBy
 	}
 	return ""
 }
 
-func (x *TokenClaims) GetRoles() []string {
+func (x *UsernameChange) GetChangedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Roles
+		return x.ChangedAt
 	}
 	return nil
 }
 
-func (x *TokenClaims) GetPermissions() []string {
+func (x *UsernameChange) GetReservedUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Permissions
+		return x.ReservedUntil
 	}
 	return nil
 }
 
-func (x *TokenClaims) GetClientId() string {
-	if x != nil {
-		return x.ClientId
-	}
-	return ""
+// GetUsernameHistoryResponse represents the renames of a user account, newest first
+type GetUsernameHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Changes       []*UsernameChange      `protobuf:"bytes,3,rep,name=changes,proto3" json:"changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TokenClaims) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+func (x *GetUsernameHistoryResponse) Reset() {
+	*x = GetUsernameHistoryResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[121]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *TokenClaims) GetIssuedAt() int64 {
-	if x != nil {
-		return x.IssuedAt
-	}
-	return 0
+func (x *GetUsernameHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *TokenClaims) GetExpiresAt() int64 {
+func (*GetUsernameHistoryResponse) ProtoMessage() {}
+
+func (x *GetUsernameHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[121]
 	if x != nil {
-		return x.ExpiresAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *TokenClaims) GetNotBefore() int64 {
-	if x != nil {
-		return x.NotBefore
-	}
-	return 0
+// Deprecated: Use GetUsernameHistoryResponse.ProtoReflect.Descriptor instead.
+func (*GetUsernameHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{121}
 }
 
-func (x *TokenClaims) GetIssuer() string {
+func (x *GetUsernameHistoryResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Issuer
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *TokenClaims) GetAudience() string {
+func (x *GetUsernameHistoryResponse) GetError() string {
 	if x != nil {
-		return x.Audience
+		return x.Error
 	}
 	return ""
 }
 
-func (x *TokenClaims) GetMetadata() map[string]string {
+func (x *GetUsernameHistoryResponse) GetChanges() []*UsernameChange {
 	if x != nil {
-		return x.Metadata
+		return x.Changes
 	}
 	return nil
 }
 
-// AdminActionRequest represents a generic admin action request
-type AdminActionRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
-	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
-}
-
-func (x *AdminActionRequest) Reset() {
-	*x = AdminActionRequest{}
-	mi := &file_auth_auth_service_proto_msgTypes[23]
+// FeatureFlag represents who a feature is on for: everyone when enabled, and
+// otherwise the listed users, users with a listed role and a percentage of
+// signed-in users
+type FeatureFlag struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Enabled       bool                   `protobuf:"varint,3,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Users         []string               `protobuf:"bytes,4,rep,name=users,proto3" json:"users,omitempty"`
+	Roles         []string               `protobuf:"bytes,5,rep,name=roles,proto3" json:"roles,omitempty"`
+	Percentage    int32                  `protobuf:"varint,6,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Overridden    bool                   `protobuf:"varint,7,opt,name=overridden,proto3" json:"overridden,omitempty"` // Stored at runtime rather than configured
+	UpdatedBy     string                 `protobuf:"bytes,8,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureFlag) Reset() {
+	*x = FeatureFlag{}
+	mi := &file_auth_auth_service_proto_msgTypes[122]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminActionRequest) String() string {
+func (x *FeatureFlag) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminActionRequest) ProtoMessage() {}
+func (*FeatureFlag) ProtoMessage() {}
 
-func (x *AdminActionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[23]
+func (x *FeatureFlag) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[122]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1753,50 +8206,99 @@ func (x *AdminActionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminActionRequest.ProtoReflect.Descriptor instead.
-func (*AdminActionRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use FeatureFlag.ProtoReflect.Descriptor instead.
+func (*FeatureFlag) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{122}
 }
 
-func (x *AdminActionRequest) GetAdminToken() string {
+func (x *FeatureFlag) GetName() string {
 	if x != nil {
-		return x.AdminToken
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AdminActionRequest) GetTargetUsername() string {
+func (x *FeatureFlag) GetDescription() string {
 	if x != nil {
-		return x.TargetUsername
+		return x.Description
 	}
 	return ""
 }
 
-// AdminActionResponse represents a generic admin action response
-type AdminActionResponse struct {
+func (x *FeatureFlag) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetUsers() []string {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *FeatureFlag) GetPercentage() int32 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *FeatureFlag) GetOverridden() bool {
+	if x != nil {
+		return x.Overridden
+	}
+	return false
+}
+
+func (x *FeatureFlag) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
+	}
+	return ""
+}
+
+func (x *FeatureFlag) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// FeatureFlagRequest represents a request about feature flags
+type FeatureFlagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`         // Flag to delete; unused by ListFeatureFlags
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"` // Evaluate the flags for this user; used by ListFeatureFlags
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdminActionResponse) Reset() {
-	*x = AdminActionResponse{}
-	mi := &file_auth_auth_service_proto_msgTypes[24]
+func (x *FeatureFlagRequest) Reset() {
+	*x = FeatureFlagRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[123]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdminActionResponse) String() string {
+func (x *FeatureFlagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdminActionResponse) ProtoMessage() {}
+func (*FeatureFlagRequest) ProtoMessage() {}
 
-func (x *AdminActionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[24]
+func (x *FeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[123]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1807,57 +8309,58 @@ func (x *AdminActionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdminActionResponse.ProtoReflect.Descriptor instead.
-func (*AdminActionResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use FeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*FeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{123}
 }
 
-func (x *AdminActionResponse) GetSuccess() bool {
+func (x *FeatureFlagRequest) GetAdminToken() string {
 	if x != nil {
-		return x.Success
+		return x.AdminToken
 	}
-	return false
+	return ""
 }
 
-func (x *AdminActionResponse) GetError() string {
+func (x *FeatureFlagRequest) GetName() string {
 	if x != nil {
-		return x.Error
+		return x.Name
 	}
 	return ""
 }
 
-func (x *AdminActionResponse) GetMessage() string {
+func (x *FeatureFlagRequest) GetUsername() string {
 	if x != nil {
-		return x.Message
+		return x.Username
 	}
 	return ""
 }
 
-// ResetPasswordAdminRequest represents an admin password reset request
-type ResetPasswordAdminRequest struct {
+// ListFeatureFlagsResponse represents the feature flags an auth service evaluates
+type ListFeatureFlagsResponse struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
-	AdminToken     string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
-	TargetUsername string                 `protobuf:"bytes,2,opt,name=target_username,json=targetUsername,proto3" json:"target_username,omitempty"`
-	NewPassword    string                 `protobuf:"bytes,3,opt,name=new_password,json=newPassword,proto3" json:"new_password,omitempty"`
+	Success        bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error          string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Flags          []*FeatureFlag         `protobuf:"bytes,3,rep,name=flags,proto3" json:"flags,omitempty"`
+	EnabledForUser []string               `protobuf:"bytes,4,rep,name=enabled_for_user,json=enabledForUser,proto3" json:"enabled_for_user,omitempty"` // Names of the flags on for the requested user
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
-func (x *ResetPasswordAdminRequest) Reset() {
-	*x = ResetPasswordAdminRequest{}
-	mi := &file_auth_auth_service_proto_msgTypes[25]
+func (x *ListFeatureFlagsResponse) Reset() {
+	*x = ListFeatureFlagsResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[124]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResetPasswordAdminRequest) String() string {
+func (x *ListFeatureFlagsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResetPasswordAdminRequest) ProtoMessage() {}
+func (*ListFeatureFlagsResponse) ProtoMessage() {}
 
-func (x *ResetPasswordAdminRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[25]
+func (x *ListFeatureFlagsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[124]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1868,55 +8371,63 @@ func (x *ResetPasswordAdminRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResetPasswordAdminRequest.ProtoReflect.Descriptor instead.
-func (*ResetPasswordAdminRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ListFeatureFlagsResponse.ProtoReflect.Descriptor instead.
+func (*ListFeatureFlagsResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{124}
 }
 
-func (x *ResetPasswordAdminRequest) GetAdminToken() string {
+func (x *ListFeatureFlagsResponse) GetSuccess() bool {
 	if x != nil {
-		return x.AdminToken
+		return x.Success
 	}
-	return ""
+	return false
 }
 
-func (x *ResetPasswordAdminRequest) GetTargetUsername() string {
+func (x *ListFeatureFlagsResponse) GetError() string {
 	if x != nil {
-		return x.TargetUsername
+		return x.Error
 	}
 	return ""
 }
 
-func (x *ResetPasswordAdminRequest) GetNewPassword() string {
+func (x *ListFeatureFlagsResponse) GetFlags() []*FeatureFlag {
 	if x != nil {
-		return x.NewPassword
+		return x.Flags
 	}
-	return ""
+	return nil
 }
 
-// ServerStatsRequest represents a server statistics request
-type ServerStatsRequest struct {
+func (x *ListFeatureFlagsResponse) GetEnabledForUser() []string {
+	if x != nil {
+		return x.EnabledForUser
+	}
+	return nil
+}
+
+// SetFeatureFlagRequest represents a request to store a feature flag
+type SetFeatureFlagRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AdminToken    string                 `protobuf:"bytes,1,opt,name=admin_token,json=adminToken,proto3" json:"admin_token,omitempty"`
+	Flag          *FeatureFlag           `protobuf:"bytes,2,opt,name=flag,proto3" json:"flag,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServerStatsRequest) Reset() {
-	*x = ServerStatsRequest{}
-	mi := &file_auth_auth_service_proto_msgTypes[26]
+func (x *SetFeatureFlagRequest) Reset() {
+	*x = SetFeatureFlagRequest{}
+	mi := &file_auth_auth_service_proto_msgTypes[125]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatsRequest) String() string {
+func (x *SetFeatureFlagRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatsRequest) ProtoMessage() {}
+func (*SetFeatureFlagRequest) ProtoMessage() {}
 
-func (x *ServerStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[26]
+func (x *SetFeatureFlagRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[125]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1927,43 +8438,48 @@ func (x *ServerStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatsRequest.ProtoReflect.Descriptor instead.
-func (*ServerStatsRequest) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use SetFeatureFlagRequest.ProtoReflect.Descriptor instead.
+func (*SetFeatureFlagRequest) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{125}
 }
 
-func (x *ServerStatsRequest) GetAdminToken() string {
+func (x *SetFeatureFlagRequest) GetAdminToken() string {
 	if x != nil {
 		return x.AdminToken
 	}
 	return ""
 }
 
-// ServerStatsResponse represents a server statistics response
-type ServerStatsResponse struct {
+func (x *SetFeatureFlagRequest) GetFlag() *FeatureFlag {
+	if x != nil {
+		return x.Flag
+	}
+	return nil
+}
+
+// FeatureFlagOverridesResponse represents the feature flags stored at runtime
+type FeatureFlagOverridesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Stats         map[string]string      `protobuf:"bytes,3,rep,name=stats,proto3" json:"stats,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Flags         []*FeatureFlag         `protobuf:"bytes,1,rep,name=flags,proto3" json:"flags,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServerStatsResponse) Reset() {
-	*x = ServerStatsResponse{}
-	mi := &file_auth_auth_service_proto_msgTypes[27]
+func (x *FeatureFlagOverridesResponse) Reset() {
+	*x = FeatureFlagOverridesResponse{}
+	mi := &file_auth_auth_service_proto_msgTypes[126]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServerStatsResponse) String() string {
+func (x *FeatureFlagOverridesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServerStatsResponse) ProtoMessage() {}
+func (*FeatureFlagOverridesResponse) ProtoMessage() {}
 
-func (x *ServerStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_auth_service_proto_msgTypes[27]
+func (x *FeatureFlagOverridesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_auth_service_proto_msgTypes[126]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1974,28 +8490,14 @@ func (x *ServerStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServerStatsResponse.ProtoReflect.Descriptor instead.
-func (*ServerStatsResponse) Descriptor() ([]byte, []int) {
-	return file_auth_auth_service_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *ServerStatsResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *ServerStatsResponse) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
+// Deprecated: Use FeatureFlagOverridesResponse.ProtoReflect.Descriptor instead.
+func (*FeatureFlagOverridesResponse) Descriptor() ([]byte, []int) {
+	return file_auth_auth_service_proto_rawDescGZIP(), []int{126}
 }
 
-func (x *ServerStatsResponse) GetStats() map[string]string {
+func (x *FeatureFlagOverridesResponse) GetFlags() []*FeatureFlag {
 	if x != nil {
-		return x.Stats
+		return x.Flags
 	}
 	return nil
 }
@@ -2004,7 +8506,7 @@ var File_auth_auth_service_proto protoreflect.FileDescriptor
 
 const file_auth_auth_service_proto_rawDesc = "" +
 	"\n" +
-	"\x17auth/auth_service.proto\x12\x13dungeongate.auth.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xc5\x02\n" +
+	"\x17auth/auth_service.proto\x12\x13dungeongate.auth.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xea\x02\n" +
 	"\x0fRegisterRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x14\n" +
@@ -2013,7 +8515,8 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\tclient_ip\x18\x05 \x01(\tR\bclientIp\x12\x1d\n" +
 	"\n" +
 	"user_agent\x18\x06 \x01(\tR\tuserAgent\x12N\n" +
-	"\bmetadata\x18\a \x03(\v22.dungeongate.auth.v1.RegisterRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v22.dungeongate.auth.v1.RegisterRequest.MetadataEntryR\bmetadata\x12#\n" +
+	"\rterms_version\x18\b \x01(\tR\ftermsVersion\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc8\x02\n" +
@@ -2026,7 +8529,7 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x125\n" +
 	"\x17access_token_expires_at\x18\x06 \x01(\x03R\x14accessTokenExpiresAt\x127\n" +
 	"\x18refresh_token_expires_at\x18\a \x01(\x03R\x15refreshTokenExpiresAt\x12-\n" +
-	"\x04user\x18\b \x01(\v2\x19.dungeongate.auth.v1.UserR\x04user\"\xa9\x02\n" +
+	"\x04user\x18\b \x01(\v2\x19.dungeongate.auth.v1.UserR\x04user\"\x8d\x03\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1b\n" +
@@ -2034,10 +8537,12 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\tclient_ip\x18\x04 \x01(\tR\bclientIp\x12\x1d\n" +
 	"\n" +
 	"user_agent\x18\x05 \x01(\tR\tuserAgent\x12K\n" +
-	"\bmetadata\x18\x06 \x03(\v2/.dungeongate.auth.v1.LoginRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x06 \x03(\v2/.dungeongate.auth.v1.LoginRequest.MetadataEntryR\bmetadata\x129\n" +
+	"\x06device\x18\a \x01(\v2!.dungeongate.auth.v1.ClientDeviceR\x06device\x12'\n" +
+	"\x0fchallenge_token\x18\b \x01(\tR\x0echallengeToken\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd3\x03\n" +
 	"\rLoginResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1d\n" +
@@ -2050,7 +8555,8 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\x04user\x18\b \x01(\v2\x19.dungeongate.auth.v1.UserR\x04user\x12-\n" +
 	"\x12remaining_attempts\x18\t \x01(\x05R\x11remainingAttempts\x12.\n" +
 	"\x13retry_after_seconds\x18\n" +
-	" \x01(\x03R\x11retryAfterSeconds\"\xa8\x01\n" +
+	" \x01(\x03R\x11retryAfterSeconds\x12-\n" +
+	"\x12challenge_required\x18\v \x01(\bR\x11challengeRequired\"\xa8\x01\n" +
 	"\rLogoutRequest\x12!\n" +
 	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\x12\x17\n" +
@@ -2091,7 +8597,48 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\fnew_password\x18\x03 \x01(\tR\vnewPassword\"H\n" +
 	"\x16ChangePasswordResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"_\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"y\n" +
+	"\x15ChangeUsernameRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12!\n" +
+	"\fnew_username\x18\x02 \x01(\tR\vnewUsername\x12\x1a\n" +
+	"\bpassword\x18\x03 \x01(\tR\bpassword\"w\n" +
+	"\x16ChangeUsernameResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12-\n" +
+	"\x04user\x18\x03 \x01(\v2\x19.dungeongate.auth.v1.UserR\x04user\"\xce\x01\n" +
+	"\x14MergeAccountsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12%\n" +
+	"\x0eother_username\x18\x02 \x01(\tR\rotherUsername\x12%\n" +
+	"\x0eother_password\x18\x03 \x01(\tR\rotherPassword\x12,\n" +
+	"\x12other_access_token\x18\x04 \x01(\tR\x10otherAccessToken\x12\x17\n" +
+	"\adry_run\x18\x05 \x01(\bR\x06dryRun\"\xc2\x02\n" +
+	"\x13AccountMergeSummary\x12 \n" +
+	"\vpreferences\x18\x01 \x01(\x05R\vpreferences\x12\x19\n" +
+	"\bapi_keys\x18\x02 \x01(\x05R\aapiKeys\x12$\n" +
+	"\rnotifications\x18\x03 \x01(\x05R\rnotifications\x12\x14\n" +
+	"\x05notes\x18\x04 \x01(\x05R\x05notes\x12)\n" +
+	"\x10moderation_flags\x18\x05 \x01(\x05R\x0fmoderationFlags\x12\x1d\n" +
+	"\n" +
+	"access_log\x18\x06 \x01(\x05R\taccessLog\x12!\n" +
+	"\fowned_groups\x18\a \x01(\x05R\vownedGroups\x12\x14\n" +
+	"\x05group\x18\b \x01(\tR\x05group\x12/\n" +
+	"\x13skipped_preferences\x18\t \x01(\x05R\x12skippedPreferences\"\xda\x01\n" +
+	"\x15MergeAccountsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12B\n" +
+	"\asummary\x18\x03 \x01(\v2(.dungeongate.auth.v1.AccountMergeSummaryR\asummary\x12$\n" +
+	"\x0emerged_user_id\x18\x04 \x01(\x05R\fmergedUserId\x12'\n" +
+	"\x0fmerged_username\x18\x05 \x01(\tR\x0emergedUsername\"e\n" +
+	"\x18SetUserPreferenceRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"K\n" +
+	"\x19SetUserPreferenceResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"S\n" +
+	"\x1aSetShowOnlineStatusRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04show\x18\x02 \x01(\bR\x04show\"_\n" +
 	"\x14ResetPasswordRequest\x12*\n" +
 	"\x11username_or_email\x18\x01 \x01(\tR\x0fusernameOrEmail\x12\x1b\n" +
 	"\tclient_ip\x18\x02 \x01(\tR\bclientIp\"a\n" +
@@ -2120,7 +8667,16 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\ttimestamp\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xbd\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x01\n" +
+	"\x0fVersionResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\tR\tbuildTime\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x04 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion\"\xbd\x04\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
@@ -2170,7 +8726,13 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\x13AdminActionResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\"\x88\x01\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x98\x01\n" +
+	"\x11RenameUserRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12!\n" +
+	"\fnew_username\x18\x03 \x01(\tR\vnewUsername\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x88\x01\n" +
 	"\x19ResetPasswordAdminRequest\x12\x1f\n" +
 	"\vadmin_token\x18\x01 \x01(\tR\n" +
 	"adminToken\x12'\n" +
@@ -2186,7 +8748,466 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\n" +
 	"StatsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x012\xcf\f\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa5\x01\n" +
+	"\x16ImpersonateUserRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12)\n" +
+	"\x10duration_seconds\x18\x04 \x01(\x05R\x0fdurationSeconds\"\x8b\x01\n" +
+	"\x17ImpersonateUserResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\faccess_token\x18\x03 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"\xd8\x01\n" +
+	"\rAccountAccess\x12%\n" +
+	"\x0eadmin_username\x18\x01 \x01(\tR\radminUsername\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x12\n" +
+	"\x04seen\x18\x05 \x01(\bR\x04seen\"=\n" +
+	"\x18ListAccountAccessRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"\x89\x01\n" +
+	"\x19ListAccountAccessResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12<\n" +
+	"\aentries\x18\x03 \x03(\v2\".dungeongate.auth.v1.AccountAccessR\aentries\"m\n" +
+	"\x12CreateGroupRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"E\n" +
+	"\fGroupRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"{\n" +
+	"\vGroupMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x127\n" +
+	"\tjoined_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"\xed\x01\n" +
+	"\x05Group\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
+	"\x05owner\x18\x03 \x01(\tR\x05owner\x12!\n" +
+	"\fmember_count\x18\x04 \x01(\x05R\vmemberCount\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12:\n" +
+	"\amembers\x18\x06 \x03(\v2 .dungeongate.auth.v1.GroupMemberR\amembers\"q\n" +
+	"\rGroupResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x120\n" +
+	"\x05group\x18\x03 \x01(\v2\x1a.dungeongate.auth.v1.GroupR\x05group\"x\n" +
+	"\x12ListGroupsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x122\n" +
+	"\x06groups\x18\x03 \x03(\v2\x1a.dungeongate.auth.v1.GroupR\x06groups\"N\n" +
+	"\rFriendRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"[\n" +
+	"\x06Friend\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x125\n" +
+	"\badded_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aaddedAt\"x\n" +
+	"\x0fFriendsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x125\n" +
+	"\afriends\x18\x03 \x03(\v2\x1b.dungeongate.auth.v1.FriendR\afriends\"(\n" +
+	"\fTermsRequest\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\"z\n" +
+	"\x05Terms\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12=\n" +
+	"\fpublished_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\"q\n" +
+	"\rTermsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x120\n" +
+	"\x05terms\x18\x03 \x01(\v2\x1a.dungeongate.auth.v1.TermsR\x05terms\"j\n" +
+	"\x13PublishTermsRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"Q\n" +
+	"\x12AcceptTermsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"S\n" +
+	"\x12TermsStatusRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"h\n" +
+	"\x0fTermsAcceptance\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12;\n" +
+	"\vaccepted_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"acceptedAt\"\xd2\x01\n" +
+	"\x13TermsStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12'\n" +
+	"\x0fcurrent_version\x18\x03 \x01(\tR\x0ecurrentVersion\x12\x1a\n" +
+	"\baccepted\x18\x04 \x01(\bR\baccepted\x12F\n" +
+	"\vacceptances\x18\x05 \x03(\v2$.dungeongate.auth.v1.TermsAcceptanceR\vacceptances\"\xaf\x02\n" +
+	"\x06APIKey\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06prefix\x18\x03 \x01(\tR\x06prefix\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x05 \x01(\x05R\trateLimit\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\"\x83\x01\n" +
+	"\x13CreateAPIKeyRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06scopes\x18\x03 \x03(\tR\x06scopes\x12\x1d\n" +
+	"\n" +
+	"rate_limit\x18\x04 \x01(\x05R\trateLimit\"\x8e\x01\n" +
+	"\x14CreateAPIKeyResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x124\n" +
+	"\aapi_key\x18\x04 \x01(\v2\x1b.dungeongate.auth.v1.APIKeyR\x06apiKey\"7\n" +
+	"\x12ListAPIKeysRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"}\n" +
+	"\x13ListAPIKeysResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
+	"\bapi_keys\x18\x03 \x03(\v2\x1b.dungeongate.auth.v1.APIKeyR\aapiKeys\"O\n" +
+	"\x13RevokeAPIKeyRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x15\n" +
+	"\x06key_id\x18\x02 \x01(\x05R\x05keyId\")\n" +
+	"\x15ValidateAPIKeyRequest\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\"\xa9\x01\n" +
+	"\x16ValidateAPIKeyResponse\x12\x14\n" +
+	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12-\n" +
+	"\x04user\x18\x03 \x01(\v2\x19.dungeongate.auth.v1.UserR\x04user\x124\n" +
+	"\aapi_key\x18\x04 \x01(\v2\x1b.dungeongate.auth.v1.APIKeyR\x06apiKey\"P\n" +
+	"\x15IssueChallengeRequest\x12\x1b\n" +
+	"\tclient_ip\x18\x01 \x01(\tR\bclientIp\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\"\xa6\x01\n" +
+	"\x16IssueChallengeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12!\n" +
+	"\fchallenge_id\x18\x03 \x01(\tR\vchallengeId\x12\x1a\n" +
+	"\bquestion\x18\x04 \x01(\tR\bquestion\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\"p\n" +
+	"\x16AnswerChallengeRequest\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12\x16\n" +
+	"\x06answer\x18\x02 \x01(\tR\x06answer\x12\x1b\n" +
+	"\tclient_ip\x18\x03 \x01(\tR\bclientIp\"p\n" +
+	"\x17AnswerChallengeResponse\x12\x16\n" +
+	"\x06passed\x18\x01 \x01(\bR\x06passed\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12'\n" +
+	"\x0fchallenge_token\x18\x03 \x01(\tR\x0echallengeToken\"^\n" +
+	"\fClientDevice\x12'\n" +
+	"\x0fkey_fingerprint\x18\x01 \x01(\tR\x0ekeyFingerprint\x12%\n" +
+	"\x0eclient_version\x18\x02 \x01(\tR\rclientVersion\"\xd4\x02\n" +
+	"\x06Device\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12'\n" +
+	"\x0fkey_fingerprint\x18\x02 \x01(\tR\x0ekeyFingerprint\x12%\n" +
+	"\x0eclient_version\x18\x03 \x01(\tR\rclientVersion\x12\x17\n" +
+	"\alast_ip\x18\x04 \x01(\tR\x06lastIp\x12>\n" +
+	"\rfirst_seen_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vfirstSeenAt\x12<\n" +
+	"\flast_seen_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastSeenAt\x129\n" +
+	"\n" +
+	"revoked_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\trevokedAt\x12\x18\n" +
+	"\acurrent\x18\b \x01(\bR\acurrent\"7\n" +
+	"\x12ListDevicesRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\"|\n" +
+	"\x13ListDevicesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x125\n" +
+	"\adevices\x18\x03 \x03(\v2\x1b.dungeongate.auth.v1.DeviceR\adevices\"U\n" +
+	"\x13RevokeDeviceRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\x05R\bdeviceId\"\xcc\x01\n" +
+	"\fNotification\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\aread_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x06readAt\"t\n" +
+	"\x18ListNotificationsRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1f\n" +
+	"\vunread_only\x18\x02 \x01(\bR\n" +
+	"unreadOnly\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xb7\x01\n" +
+	"\x19ListNotificationsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12G\n" +
+	"\rnotifications\x18\x03 \x03(\v2!.dungeongate.auth.v1.NotificationR\rnotifications\x12!\n" +
+	"\funread_count\x18\x04 \x01(\x05R\vunreadCount\"S\n" +
+	"\x1cMarkNotificationsReadRequest\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\x05R\x03ids\"\x8d\x01\n" +
+	"\x17SendNotificationRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\"m\n" +
+	"\x11NotifyUserRequest\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05title\x18\x03 \x01(\tR\x05title\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\"r\n" +
+	"\x12AddUserNoteRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\x81\x01\n" +
+	"\bUserNote\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x16\n" +
+	"\x06author\x18\x02 \x01(\tR\x06author\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xae\x01\n" +
+	"\x12UserModerationFlag\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x12\n" +
+	"\x04flag\x18\x02 \x01(\tR\x04flag\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x15\n" +
+	"\x06set_by\x18\x04 \x01(\tR\x05setBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xbf\x01\n" +
+	"\x19GetUserModerationResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x123\n" +
+	"\x05notes\x18\x03 \x03(\v2\x1d.dungeongate.auth.v1.UserNoteR\x05notes\x12=\n" +
+	"\x05flags\x18\x04 \x03(\v2'.dungeongate.auth.v1.UserModerationFlagR\x05flags\"\x8d\x01\n" +
+	"\x15ModerationFlagRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12'\n" +
+	"\x0ftarget_username\x18\x02 \x01(\tR\x0etargetUsername\x12\x12\n" +
+	"\x04flag\x18\x03 \x01(\tR\x04flag\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"W\n" +
+	" ListUsersByModerationFlagRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x12\n" +
+	"\x04flag\x18\x02 \x01(\tR\x04flag\"\x92\x01\n" +
+	"!ListUsersByModerationFlagResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12=\n" +
+	"\x05users\x18\x03 \x03(\v2'.dungeongate.auth.v1.UserModerationFlagR\x05users\"D\n" +
+	"\rBannerRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\x98\x01\n" +
+	"\x0eBannerTemplate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x03 \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x9a\x01\n" +
+	"\x13ListBannersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x14\n" +
+	"\x05names\x18\x03 \x03(\tR\x05names\x12=\n" +
+	"\abanners\x18\x04 \x03(\v2#.dungeongate.auth.v1.BannerTemplateR\abanners\"\xa0\x01\n" +
+	"\x11GetBannerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1e\n" +
+	"\n" +
+	"overridden\x18\x03 \x01(\bR\n" +
+	"overridden\x12;\n" +
+	"\x06banner\x18\x04 \x01(\v2#.dungeongate.auth.v1.BannerTemplateR\x06banner\"d\n" +
+	"\x13UpdateBannerRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\"\xa0\x01\n" +
+	"\x14PreviewBannerRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x1d\n" +
+	"\n" +
+	"ascii_only\x18\x05 \x01(\bR\tasciiOnly\"c\n" +
+	"\x15PreviewBannerResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x1a\n" +
+	"\brendered\x18\x03 \x01(\tR\brendered\"6\n" +
+	"\x13WatchBannersRequest\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\"\x91\x01\n" +
+	"\fBannerUpdate\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x18\n" +
+	"\acleared\x18\x03 \x01(\bR\acleared\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xcc\x03\n" +
+	"\x0fSessionInstance\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12-\n" +
+	"\x12connections_active\x18\x03 \x01(\x05R\x11connectionsActive\x12+\n" +
+	"\x11connections_total\x18\x04 \x01(\x03R\x10connectionsTotal\x12)\n" +
+	"\x10connections_peak\x18\x05 \x01(\x05R\x0fconnectionsPeak\x12'\n" +
+	"\x0fmax_connections\x18\x06 \x01(\x05R\x0emaxConnections\x12#\n" +
+	"\rgame_sessions\x18\a \x01(\x05R\fgameSessions\x121\n" +
+	"\x14connections_rejected\x18\b \x01(\x03R\x13connectionsRejected\x129\n" +
+	"\n" +
+	"started_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tstartedAt\x12;\n" +
+	"\vreported_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reportedAt\"`\n" +
+	"\x1cReportSessionInstanceRequest\x12@\n" +
+	"\binstance\x18\x01 \x01(\v2$.dungeongate.auth.v1.SessionInstanceR\binstance\">\n" +
+	"\x1bListSessionInstancesRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\"\xd0\x01\n" +
+	"\x1cListSessionInstancesResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12B\n" +
+	"\tinstances\x18\x03 \x03(\v2$.dungeongate.auth.v1.SessionInstanceR\tinstances\x12<\n" +
+	"\x06totals\x18\x04 \x01(\v2$.dungeongate.auth.v1.SessionInstanceR\x06totals\"n\n" +
+	"\x19CountSessionLoginsRequest\x12\x1f\n" +
+	"\vinstance_id\x18\x01 \x01(\tR\n" +
+	"instanceId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x05R\x05delta\"4\n" +
+	"\x1aCountSessionLoginsResponse\x12\x16\n" +
+	"\x06logins\x18\x01 \x01(\x05R\x06logins\"\xfc\x02\n" +
+	"\n" +
+	"UserFilter\x12'\n" +
+	"\x0fmoderation_flag\x18\x01 \x01(\tR\x0emoderationFlag\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\x12?\n" +
+	"\rcreated_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\fcreatedAfter\x12A\n" +
+	"\x0ecreated_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rcreatedBefore\x12D\n" +
+	"\x10last_login_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0elastLoginAfter\x12F\n" +
+	"\x11last_login_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastLoginBefore\x12\x1f\n" +
+	"\vlocked_only\x18\a \x01(\bR\n" +
+	"lockedOnly\"\x9a\x01\n" +
+	"\x10ListUsersRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x127\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1f.dungeongate.auth.v1.UserFilterR\x06filter\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x05R\x06offset\"\xc3\x02\n" +
+	"\vUserSummary\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05email\x18\x02 \x01(\tR\x05email\x12\x14\n" +
+	"\x05roles\x18\x03 \x03(\tR\x05roles\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"last_login\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tlastLogin\x12\x1f\n" +
+	"\vlogin_count\x18\x06 \x01(\x05R\n" +
+	"loginCount\x12\x16\n" +
+	"\x06locked\x18\a \x01(\bR\x06locked\x12=\n" +
+	"\flocked_until\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"\x9c\x01\n" +
+	"\x11ListUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
+	"\x05users\x18\x03 \x03(\v2 .dungeongate.auth.v1.UserSummaryR\x05users\x12\x1f\n" +
+	"\vtotal_count\x18\x04 \x01(\x05R\n" +
+	"totalCount\"\xbf\x01\n" +
+	"\x0fBulkLockRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x1c\n" +
+	"\tusernames\x18\x02 \x03(\tR\tusernames\x12\x16\n" +
+	"\x06locked\x18\x03 \x01(\bR\x06locked\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\x12=\n" +
+	"\flocked_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"z\n" +
+	"\x0fBulkRoleRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x1c\n" +
+	"\tusernames\x18\x02 \x03(\tR\tusernames\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12\x14\n" +
+	"\x05grant\x18\x04 \x01(\bR\x05grant\"E\n" +
+	"\x11BulkActionFailure\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"\xa7\x01\n" +
+	"\x17BulkAdminActionResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
+	"\aupdated\x18\x03 \x01(\x05R\aupdated\x12B\n" +
+	"\bfailures\x18\x04 \x03(\v2&.dungeongate.auth.v1.BulkActionFailureR\bfailures\"\xa6\x01\n" +
+	"\x12ExportUsersRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x127\n" +
+	"\x06filter\x18\x02 \x01(\v2\x1f.dungeongate.auth.v1.UserFilterR\x06filter\x126\n" +
+	"\x17include_password_hashes\x18\x03 \x01(\bR\x15includePasswordHashes\"m\n" +
+	"\x13ExportUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x10\n" +
+	"\x03csv\x18\x03 \x01(\fR\x03csv\x12\x14\n" +
+	"\x05count\x18\x04 \x01(\x05R\x05count\"`\n" +
+	"\x12ImportUsersRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x10\n" +
+	"\x03csv\x18\x02 \x01(\fR\x03csv\x12\x17\n" +
+	"\adry_run\x18\x03 \x01(\bR\x06dryRun\"\x83\x01\n" +
+	"\fImportedUser\x12\x12\n" +
+	"\x04line\x18\x01 \x01(\x05R\x04line\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12-\n" +
+	"\x12temporary_password\x18\x04 \x01(\tR\x11temporaryPassword\"\x98\x01\n" +
+	"\x13ImportUsersResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x05R\acreated\x127\n" +
+	"\x05users\x18\x04 \x03(\v2!.dungeongate.auth.v1.ImportedUserR\x05users\"V\n" +
+	"\x1aEncryptPersonalDataRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\"\xc8\x01\n" +
+	"\x1bEncryptPersonalDataResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12#\n" +
+	"\rusers_scanned\x18\x03 \x01(\x05R\fusersScanned\x12'\n" +
+	"\x0fusers_encrypted\x18\x04 \x01(\x05R\x0eusersEncrypted\x12+\n" +
+	"\x11users_reencrypted\x18\x05 \x01(\x05R\x10usersReencrypted\"\xf3\x01\n" +
+	"\x0eUsernameChange\x12!\n" +
+	"\fold_username\x18\x01 \x01(\tR\voldUsername\x12!\n" +
+	"\fnew_username\x18\x02 \x01(\tR\vnewUsername\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x03 \x01(\tR\tchangedBy\x129\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12A\n" +
+	"\x0ereserved_until\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rreservedUntil\"\x8b\x01\n" +
+	"\x1aGetUsernameHistoryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12=\n" +
+	"\achanges\x18\x03 \x03(\v2#.dungeongate.auth.v1.UsernameChangeR\achanges\"\xa3\x02\n" +
+	"\vFeatureFlag\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x18\n" +
+	"\aenabled\x18\x03 \x01(\bR\aenabled\x12\x14\n" +
+	"\x05users\x18\x04 \x03(\tR\x05users\x12\x14\n" +
+	"\x05roles\x18\x05 \x03(\tR\x05roles\x12\x1e\n" +
+	"\n" +
+	"percentage\x18\x06 \x01(\x05R\n" +
+	"percentage\x12\x1e\n" +
+	"\n" +
+	"overridden\x18\a \x01(\bR\n" +
+	"overridden\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\b \x01(\tR\tupdatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"e\n" +
+	"\x12FeatureFlagRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\"\xac\x01\n" +
+	"\x18ListFeatureFlagsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x126\n" +
+	"\x05flags\x18\x03 \x03(\v2 .dungeongate.auth.v1.FeatureFlagR\x05flags\x12(\n" +
+	"\x10enabled_for_user\x18\x04 \x03(\tR\x0eenabledForUser\"n\n" +
+	"\x15SetFeatureFlagRequest\x12\x1f\n" +
+	"\vadmin_token\x18\x01 \x01(\tR\n" +
+	"adminToken\x124\n" +
+	"\x04flag\x18\x02 \x01(\v2 .dungeongate.auth.v1.FeatureFlagR\x04flag\"V\n" +
+	"\x1cFeatureFlagOverridesResponse\x126\n" +
+	"\x05flags\x18\x01 \x03(\v2 .dungeongate.auth.v1.FeatureFlagR\x05flags2\xf3:\n" +
 	"\vAuthService\x12W\n" +
 	"\bRegister\x12$.dungeongate.auth.v1.RegisterRequest\x1a%.dungeongate.auth.v1.RegisterResponse\x12N\n" +
 	"\x05Login\x12!.dungeongate.auth.v1.LoginRequest\x1a\".dungeongate.auth.v1.LoginResponse\x12Q\n" +
@@ -2194,16 +9215,78 @@ const file_auth_auth_service_proto_rawDesc = "" +
 	"\fRefreshToken\x12(.dungeongate.auth.v1.RefreshTokenRequest\x1a).dungeongate.auth.v1.RefreshTokenResponse\x12f\n" +
 	"\rValidateToken\x12).dungeongate.auth.v1.ValidateTokenRequest\x1a*.dungeongate.auth.v1.ValidateTokenResponse\x12`\n" +
 	"\vGetUserInfo\x12'.dungeongate.auth.v1.GetUserInfoRequest\x1a(.dungeongate.auth.v1.GetUserInfoResponse\x12i\n" +
-	"\x0eChangePassword\x12*.dungeongate.auth.v1.ChangePasswordRequest\x1a+.dungeongate.auth.v1.ChangePasswordResponse\x12f\n" +
+	"\x0eChangePassword\x12*.dungeongate.auth.v1.ChangePasswordRequest\x1a+.dungeongate.auth.v1.ChangePasswordResponse\x12i\n" +
+	"\x0eChangeUsername\x12*.dungeongate.auth.v1.ChangeUsernameRequest\x1a+.dungeongate.auth.v1.ChangeUsernameResponse\x12f\n" +
+	"\rMergeAccounts\x12).dungeongate.auth.v1.MergeAccountsRequest\x1a*.dungeongate.auth.v1.MergeAccountsResponse\x12r\n" +
+	"\x11SetUserPreference\x12-.dungeongate.auth.v1.SetUserPreferenceRequest\x1a..dungeongate.auth.v1.SetUserPreferenceResponse\x12v\n" +
+	"\x13SetShowOnlineStatus\x12/.dungeongate.auth.v1.SetShowOnlineStatusRequest\x1a..dungeongate.auth.v1.SetUserPreferenceResponse\x12f\n" +
 	"\rResetPassword\x12).dungeongate.auth.v1.ResetPasswordRequest\x1a*.dungeongate.auth.v1.ResetPasswordResponse\x12x\n" +
 	"\x13VerifyPasswordReset\x12/.dungeongate.auth.v1.VerifyPasswordResetRequest\x1a0.dungeongate.auth.v1.VerifyPasswordResetResponse\x12o\n" +
 	"\x10GetLoginAttempts\x12,.dungeongate.auth.v1.GetLoginAttemptsRequest\x1a-.dungeongate.auth.v1.GetLoginAttemptsResponse\x12E\n" +
-	"\x06Health\x12\x16.google.protobuf.Empty\x1a#.dungeongate.auth.v1.HealthResponse\x12f\n" +
+	"\x06Health\x12\x16.google.protobuf.Empty\x1a#.dungeongate.auth.v1.HealthResponse\x12G\n" +
+	"\aVersion\x12\x16.google.protobuf.Empty\x1a$.dungeongate.auth.v1.VersionResponse\x12f\n" +
 	"\x11UnlockUserAccount\x12'.dungeongate.auth.v1.AdminActionRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12f\n" +
 	"\x11DeleteUserAccount\x12'.dungeongate.auth.v1.AdminActionRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12m\n" +
 	"\x11ResetUserPassword\x12..dungeongate.auth.v1.ResetPasswordAdminRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12g\n" +
-	"\x12PromoteUserToAdmin\x12'.dungeongate.auth.v1.AdminActionRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12h\n" +
-	"\x13GetServerStatistics\x12'.dungeongate.auth.v1.ServerStatsRequest\x1a(.dungeongate.auth.v1.ServerStatsResponseB(Z&github.com/dungeongate/pkg/api/auth/v1b\x06proto3"
+	"\x12PromoteUserToAdmin\x12'.dungeongate.auth.v1.AdminActionRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12^\n" +
+	"\n" +
+	"RenameUser\x12&.dungeongate.auth.v1.RenameUserRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12h\n" +
+	"\x13GetServerStatistics\x12'.dungeongate.auth.v1.ServerStatsRequest\x1a(.dungeongate.auth.v1.ServerStatsResponse\x12l\n" +
+	"\x0fImpersonateUser\x12+.dungeongate.auth.v1.ImpersonateUserRequest\x1a,.dungeongate.auth.v1.ImpersonateUserResponse\x12r\n" +
+	"\x11ListAccountAccess\x12-.dungeongate.auth.v1.ListAccountAccessRequest\x1a..dungeongate.auth.v1.ListAccountAccessResponse\x12Z\n" +
+	"\vCreateGroup\x12'.dungeongate.auth.v1.CreateGroupRequest\x1a\".dungeongate.auth.v1.GroupResponse\x12R\n" +
+	"\tJoinGroup\x12!.dungeongate.auth.v1.GroupRequest\x1a\".dungeongate.auth.v1.GroupResponse\x12S\n" +
+	"\n" +
+	"LeaveGroup\x12!.dungeongate.auth.v1.GroupRequest\x1a\".dungeongate.auth.v1.GroupResponse\x12Q\n" +
+	"\bGetGroup\x12!.dungeongate.auth.v1.GroupRequest\x1a\".dungeongate.auth.v1.GroupResponse\x12X\n" +
+	"\n" +
+	"ListGroups\x12!.dungeongate.auth.v1.GroupRequest\x1a'.dungeongate.auth.v1.ListGroupsResponse\x12U\n" +
+	"\tAddFriend\x12\".dungeongate.auth.v1.FriendRequest\x1a$.dungeongate.auth.v1.FriendsResponse\x12X\n" +
+	"\fRemoveFriend\x12\".dungeongate.auth.v1.FriendRequest\x1a$.dungeongate.auth.v1.FriendsResponse\x12W\n" +
+	"\vListFriends\x12\".dungeongate.auth.v1.FriendRequest\x1a$.dungeongate.auth.v1.FriendsResponse\x12a\n" +
+	"\rNotifyFriends\x12&.dungeongate.auth.v1.NotifyUserRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12Q\n" +
+	"\bGetTerms\x12!.dungeongate.auth.v1.TermsRequest\x1a\".dungeongate.auth.v1.TermsResponse\x12b\n" +
+	"\fPublishTerms\x12(.dungeongate.auth.v1.PublishTermsRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12`\n" +
+	"\vAcceptTerms\x12'.dungeongate.auth.v1.AcceptTermsRequest\x1a(.dungeongate.auth.v1.TermsStatusResponse\x12c\n" +
+	"\x0eGetTermsStatus\x12'.dungeongate.auth.v1.TermsStatusRequest\x1a(.dungeongate.auth.v1.TermsStatusResponse\x12c\n" +
+	"\fCreateAPIKey\x12(.dungeongate.auth.v1.CreateAPIKeyRequest\x1a).dungeongate.auth.v1.CreateAPIKeyResponse\x12`\n" +
+	"\vListAPIKeys\x12'.dungeongate.auth.v1.ListAPIKeysRequest\x1a(.dungeongate.auth.v1.ListAPIKeysResponse\x12b\n" +
+	"\fRevokeAPIKey\x12(.dungeongate.auth.v1.RevokeAPIKeyRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12i\n" +
+	"\x0eValidateAPIKey\x12*.dungeongate.auth.v1.ValidateAPIKeyRequest\x1a+.dungeongate.auth.v1.ValidateAPIKeyResponse\x12i\n" +
+	"\x0eIssueChallenge\x12*.dungeongate.auth.v1.IssueChallengeRequest\x1a+.dungeongate.auth.v1.IssueChallengeResponse\x12l\n" +
+	"\x0fAnswerChallenge\x12+.dungeongate.auth.v1.AnswerChallengeRequest\x1a,.dungeongate.auth.v1.AnswerChallengeResponse\x12`\n" +
+	"\vListDevices\x12'.dungeongate.auth.v1.ListDevicesRequest\x1a(.dungeongate.auth.v1.ListDevicesResponse\x12b\n" +
+	"\fRevokeDevice\x12(.dungeongate.auth.v1.RevokeDeviceRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12r\n" +
+	"\x11ListNotifications\x12-.dungeongate.auth.v1.ListNotificationsRequest\x1a..dungeongate.auth.v1.ListNotificationsResponse\x12z\n" +
+	"\x15MarkNotificationsRead\x121.dungeongate.auth.v1.MarkNotificationsReadRequest\x1a..dungeongate.auth.v1.ListNotificationsResponse\x12j\n" +
+	"\x10SendNotification\x12,.dungeongate.auth.v1.SendNotificationRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12^\n" +
+	"\n" +
+	"NotifyUser\x12&.dungeongate.auth.v1.NotifyUserRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12`\n" +
+	"\vAddUserNote\x12'.dungeongate.auth.v1.AddUserNoteRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12l\n" +
+	"\x11GetUserModeration\x12'.dungeongate.auth.v1.AdminActionRequest\x1a..dungeongate.auth.v1.GetUserModerationResponse\x12m\n" +
+	"\x15SetUserModerationFlag\x12*.dungeongate.auth.v1.ModerationFlagRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12o\n" +
+	"\x17ClearUserModerationFlag\x12*.dungeongate.auth.v1.ModerationFlagRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12\x8a\x01\n" +
+	"\x19ListUsersByModerationFlag\x125.dungeongate.auth.v1.ListUsersByModerationFlagRequest\x1a6.dungeongate.auth.v1.ListUsersByModerationFlagResponse\x12n\n" +
+	"\x12GetUsernameHistory\x12'.dungeongate.auth.v1.AdminActionRequest\x1a/.dungeongate.auth.v1.GetUsernameHistoryResponse\x12[\n" +
+	"\vListBanners\x12\".dungeongate.auth.v1.BannerRequest\x1a(.dungeongate.auth.v1.ListBannersResponse\x12W\n" +
+	"\tGetBanner\x12\".dungeongate.auth.v1.BannerRequest\x1a&.dungeongate.auth.v1.GetBannerResponse\x12b\n" +
+	"\fUpdateBanner\x12(.dungeongate.auth.v1.UpdateBannerRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12[\n" +
+	"\vResetBanner\x12\".dungeongate.auth.v1.BannerRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12f\n" +
+	"\rPreviewBanner\x12).dungeongate.auth.v1.PreviewBannerRequest\x1a*.dungeongate.auth.v1.PreviewBannerResponse\x12]\n" +
+	"\fWatchBanners\x12(.dungeongate.auth.v1.WatchBannersRequest\x1a!.dungeongate.auth.v1.BannerUpdate0\x01\x12b\n" +
+	"\x15ReportSessionInstance\x121.dungeongate.auth.v1.ReportSessionInstanceRequest\x1a\x16.google.protobuf.Empty\x12{\n" +
+	"\x14ListSessionInstances\x120.dungeongate.auth.v1.ListSessionInstancesRequest\x1a1.dungeongate.auth.v1.ListSessionInstancesResponse\x12u\n" +
+	"\x12CountSessionLogins\x12..dungeongate.auth.v1.CountSessionLoginsRequest\x1a/.dungeongate.auth.v1.CountSessionLoginsResponse\x12Z\n" +
+	"\tListUsers\x12%.dungeongate.auth.v1.ListUsersRequest\x1a&.dungeongate.auth.v1.ListUsersResponse\x12g\n" +
+	"\x11SetAccountsLocked\x12$.dungeongate.auth.v1.BulkLockRequest\x1a,.dungeongate.auth.v1.BulkAdminActionResponse\x12b\n" +
+	"\fSetUsersRole\x12$.dungeongate.auth.v1.BulkRoleRequest\x1a,.dungeongate.auth.v1.BulkAdminActionResponse\x12`\n" +
+	"\vExportUsers\x12'.dungeongate.auth.v1.ExportUsersRequest\x1a(.dungeongate.auth.v1.ExportUsersResponse\x12`\n" +
+	"\vImportUsers\x12'.dungeongate.auth.v1.ImportUsersRequest\x1a(.dungeongate.auth.v1.ImportUsersResponse\x12x\n" +
+	"\x13EncryptPersonalData\x12/.dungeongate.auth.v1.EncryptPersonalDataRequest\x1a0.dungeongate.auth.v1.EncryptPersonalDataResponse\x12j\n" +
+	"\x10ListFeatureFlags\x12'.dungeongate.auth.v1.FeatureFlagRequest\x1a-.dungeongate.auth.v1.ListFeatureFlagsResponse\x12f\n" +
+	"\x0eSetFeatureFlag\x12*.dungeongate.auth.v1.SetFeatureFlagRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12f\n" +
+	"\x11DeleteFeatureFlag\x12'.dungeongate.auth.v1.FeatureFlagRequest\x1a(.dungeongate.auth.v1.AdminActionResponse\x12d\n" +
+	"\x17GetFeatureFlagOverrides\x12\x16.google.protobuf.Empty\x1a1.dungeongate.auth.v1.FeatureFlagOverridesResponseB(Z&github.com/dungeongate/pkg/api/auth/v1b\x06proto3"
 
 var (
 	file_auth_auth_service_proto_rawDescOnce sync.Once
@@ -2217,97 +9300,377 @@ func file_auth_auth_service_proto_rawDescGZIP() []byte {
 	return file_auth_auth_service_proto_rawDescData
 }
 
-var file_auth_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
+var file_auth_auth_service_proto_msgTypes = make([]protoimpl.MessageInfo, 133)
 var file_auth_auth_service_proto_goTypes = []any{
-	(*RegisterRequest)(nil),             // 0: dungeongate.auth.v1.RegisterRequest
-	(*RegisterResponse)(nil),            // 1: dungeongate.auth.v1.RegisterResponse
-	(*LoginRequest)(nil),                // 2: dungeongate.auth.v1.LoginRequest
-	(*LoginResponse)(nil),               // 3: dungeongate.auth.v1.LoginResponse
-	(*LogoutRequest)(nil),               // 4: dungeongate.auth.v1.LogoutRequest
-	(*LogoutResponse)(nil),              // 5: dungeongate.auth.v1.LogoutResponse
-	(*RefreshTokenRequest)(nil),         // 6: dungeongate.auth.v1.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),        // 7: dungeongate.auth.v1.RefreshTokenResponse
-	(*ValidateTokenRequest)(nil),        // 8: dungeongate.auth.v1.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil),       // 9: dungeongate.auth.v1.ValidateTokenResponse
-	(*GetUserInfoRequest)(nil),          // 10: dungeongate.auth.v1.GetUserInfoRequest
-	(*GetUserInfoResponse)(nil),         // 11: dungeongate.auth.v1.GetUserInfoResponse
-	(*ChangePasswordRequest)(nil),       // 12: dungeongate.auth.v1.ChangePasswordRequest
-	(*ChangePasswordResponse)(nil),      // 13: dungeongate.auth.v1.ChangePasswordResponse
-	(*ResetPasswordRequest)(nil),        // 14: dungeongate.auth.v1.ResetPasswordRequest
-	(*ResetPasswordResponse)(nil),       // 15: dungeongate.auth.v1.ResetPasswordResponse
-	(*VerifyPasswordResetRequest)(nil),  // 16: dungeongate.auth.v1.VerifyPasswordResetRequest
-	(*VerifyPasswordResetResponse)(nil), // 17: dungeongate.auth.v1.VerifyPasswordResetResponse
-	(*GetLoginAttemptsRequest)(nil),     // 18: dungeongate.auth.v1.GetLoginAttemptsRequest
-	(*GetLoginAttemptsResponse)(nil),    // 19: dungeongate.auth.v1.GetLoginAttemptsResponse
-	(*HealthResponse)(nil),              // 20: dungeongate.auth.v1.HealthResponse
-	(*User)(nil),                        // 21: dungeongate.auth.v1.User
-	(*TokenClaims)(nil),                 // 22: dungeongate.auth.v1.TokenClaims
-	(*AdminActionRequest)(nil),          // 23: dungeongate.auth.v1.AdminActionRequest
-	(*AdminActionResponse)(nil),         // 24: dungeongate.auth.v1.AdminActionResponse
-	(*ResetPasswordAdminRequest)(nil),   // 25: dungeongate.auth.v1.ResetPasswordAdminRequest
-	(*ServerStatsRequest)(nil),          // 26: dungeongate.auth.v1.ServerStatsRequest
-	(*ServerStatsResponse)(nil),         // 27: dungeongate.auth.v1.ServerStatsResponse
-	nil,                                 // 28: dungeongate.auth.v1.RegisterRequest.MetadataEntry
-	nil,                                 // 29: dungeongate.auth.v1.LoginRequest.MetadataEntry
-	nil,                                 // 30: dungeongate.auth.v1.HealthResponse.DetailsEntry
-	nil,                                 // 31: dungeongate.auth.v1.User.MetadataEntry
-	nil,                                 // 32: dungeongate.auth.v1.TokenClaims.MetadataEntry
-	nil,                                 // 33: dungeongate.auth.v1.ServerStatsResponse.StatsEntry
-	(*timestamppb.Timestamp)(nil),       // 34: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),               // 35: google.protobuf.Empty
+	(*RegisterRequest)(nil),                   // 0: dungeongate.auth.v1.RegisterRequest
+	(*RegisterResponse)(nil),                  // 1: dungeongate.auth.v1.RegisterResponse
+	(*LoginRequest)(nil),                      // 2: dungeongate.auth.v1.LoginRequest
+	(*LoginResponse)(nil),                     // 3: dungeongate.auth.v1.LoginResponse
+	(*LogoutRequest)(nil),                     // 4: dungeongate.auth.v1.LogoutRequest
+	(*LogoutResponse)(nil),                    // 5: dungeongate.auth.v1.LogoutResponse
+	(*RefreshTokenRequest)(nil),               // 6: dungeongate.auth.v1.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),              // 7: dungeongate.auth.v1.RefreshTokenResponse
+	(*ValidateTokenRequest)(nil),              // 8: dungeongate.auth.v1.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),             // 9: dungeongate.auth.v1.ValidateTokenResponse
+	(*GetUserInfoRequest)(nil),                // 10: dungeongate.auth.v1.GetUserInfoRequest
+	(*GetUserInfoResponse)(nil),               // 11: dungeongate.auth.v1.GetUserInfoResponse
+	(*ChangePasswordRequest)(nil),             // 12: dungeongate.auth.v1.ChangePasswordRequest
+	(*ChangePasswordResponse)(nil),            // 13: dungeongate.auth.v1.ChangePasswordResponse
+	(*ChangeUsernameRequest)(nil),             // 14: dungeongate.auth.v1.ChangeUsernameRequest
+	(*ChangeUsernameResponse)(nil),            // 15: dungeongate.auth.v1.ChangeUsernameResponse
+	(*MergeAccountsRequest)(nil),              // 16: dungeongate.auth.v1.MergeAccountsRequest
+	(*AccountMergeSummary)(nil),               // 17: dungeongate.auth.v1.AccountMergeSummary
+	(*MergeAccountsResponse)(nil),             // 18: dungeongate.auth.v1.MergeAccountsResponse
+	(*SetUserPreferenceRequest)(nil),          // 19: dungeongate.auth.v1.SetUserPreferenceRequest
+	(*SetUserPreferenceResponse)(nil),         // 20: dungeongate.auth.v1.SetUserPreferenceResponse
+	(*SetShowOnlineStatusRequest)(nil),        // 21: dungeongate.auth.v1.SetShowOnlineStatusRequest
+	(*ResetPasswordRequest)(nil),              // 22: dungeongate.auth.v1.ResetPasswordRequest
+	(*ResetPasswordResponse)(nil),             // 23: dungeongate.auth.v1.ResetPasswordResponse
+	(*VerifyPasswordResetRequest)(nil),        // 24: dungeongate.auth.v1.VerifyPasswordResetRequest
+	(*VerifyPasswordResetResponse)(nil),       // 25: dungeongate.auth.v1.VerifyPasswordResetResponse
+	(*GetLoginAttemptsRequest)(nil),           // 26: dungeongate.auth.v1.GetLoginAttemptsRequest
+	(*GetLoginAttemptsResponse)(nil),          // 27: dungeongate.auth.v1.GetLoginAttemptsResponse
+	(*HealthResponse)(nil),                    // 28: dungeongate.auth.v1.HealthResponse
+	(*VersionResponse)(nil),                   // 29: dungeongate.auth.v1.VersionResponse
+	(*User)(nil),                              // 30: dungeongate.auth.v1.User
+	(*TokenClaims)(nil),                       // 31: dungeongate.auth.v1.TokenClaims
+	(*AdminActionRequest)(nil),                // 32: dungeongate.auth.v1.AdminActionRequest
+	(*AdminActionResponse)(nil),               // 33: dungeongate.auth.v1.AdminActionResponse
+	(*RenameUserRequest)(nil),                 // 34: dungeongate.auth.v1.RenameUserRequest
+	(*ResetPasswordAdminRequest)(nil),         // 35: dungeongate.auth.v1.ResetPasswordAdminRequest
+	(*ServerStatsRequest)(nil),                // 36: dungeongate.auth.v1.ServerStatsRequest
+	(*ServerStatsResponse)(nil),               // 37: dungeongate.auth.v1.ServerStatsResponse
+	(*ImpersonateUserRequest)(nil),            // 38: dungeongate.auth.v1.ImpersonateUserRequest
+	(*ImpersonateUserResponse)(nil),           // 39: dungeongate.auth.v1.ImpersonateUserResponse
+	(*AccountAccess)(nil),                     // 40: dungeongate.auth.v1.AccountAccess
+	(*ListAccountAccessRequest)(nil),          // 41: dungeongate.auth.v1.ListAccountAccessRequest
+	(*ListAccountAccessResponse)(nil),         // 42: dungeongate.auth.v1.ListAccountAccessResponse
+	(*CreateGroupRequest)(nil),                // 43: dungeongate.auth.v1.CreateGroupRequest
+	(*GroupRequest)(nil),                      // 44: dungeongate.auth.v1.GroupRequest
+	(*GroupMember)(nil),                       // 45: dungeongate.auth.v1.GroupMember
+	(*Group)(nil),                             // 46: dungeongate.auth.v1.Group
+	(*GroupResponse)(nil),                     // 47: dungeongate.auth.v1.GroupResponse
+	(*ListGroupsResponse)(nil),                // 48: dungeongate.auth.v1.ListGroupsResponse
+	(*FriendRequest)(nil),                     // 49: dungeongate.auth.v1.FriendRequest
+	(*Friend)(nil),                            // 50: dungeongate.auth.v1.Friend
+	(*FriendsResponse)(nil),                   // 51: dungeongate.auth.v1.FriendsResponse
+	(*TermsRequest)(nil),                      // 52: dungeongate.auth.v1.TermsRequest
+	(*Terms)(nil),                             // 53: dungeongate.auth.v1.Terms
+	(*TermsResponse)(nil),                     // 54: dungeongate.auth.v1.TermsResponse
+	(*PublishTermsRequest)(nil),               // 55: dungeongate.auth.v1.PublishTermsRequest
+	(*AcceptTermsRequest)(nil),                // 56: dungeongate.auth.v1.AcceptTermsRequest
+	(*TermsStatusRequest)(nil),                // 57: dungeongate.auth.v1.TermsStatusRequest
+	(*TermsAcceptance)(nil),                   // 58: dungeongate.auth.v1.TermsAcceptance
+	(*TermsStatusResponse)(nil),               // 59: dungeongate.auth.v1.TermsStatusResponse
+	(*APIKey)(nil),                            // 60: dungeongate.auth.v1.APIKey
+	(*CreateAPIKeyRequest)(nil),               // 61: dungeongate.auth.v1.CreateAPIKeyRequest
+	(*CreateAPIKeyResponse)(nil),              // 62: dungeongate.auth.v1.CreateAPIKeyResponse
+	(*ListAPIKeysRequest)(nil),                // 63: dungeongate.auth.v1.ListAPIKeysRequest
+	(*ListAPIKeysResponse)(nil),               // 64: dungeongate.auth.v1.ListAPIKeysResponse
+	(*RevokeAPIKeyRequest)(nil),               // 65: dungeongate.auth.v1.RevokeAPIKeyRequest
+	(*ValidateAPIKeyRequest)(nil),             // 66: dungeongate.auth.v1.ValidateAPIKeyRequest
+	(*ValidateAPIKeyResponse)(nil),            // 67: dungeongate.auth.v1.ValidateAPIKeyResponse
+	(*IssueChallengeRequest)(nil),             // 68: dungeongate.auth.v1.IssueChallengeRequest
+	(*IssueChallengeResponse)(nil),            // 69: dungeongate.auth.v1.IssueChallengeResponse
+	(*AnswerChallengeRequest)(nil),            // 70: dungeongate.auth.v1.AnswerChallengeRequest
+	(*AnswerChallengeResponse)(nil),           // 71: dungeongate.auth.v1.AnswerChallengeResponse
+	(*ClientDevice)(nil),                      // 72: dungeongate.auth.v1.ClientDevice
+	(*Device)(nil),                            // 73: dungeongate.auth.v1.Device
+	(*ListDevicesRequest)(nil),                // 74: dungeongate.auth.v1.ListDevicesRequest
+	(*ListDevicesResponse)(nil),               // 75: dungeongate.auth.v1.ListDevicesResponse
+	(*RevokeDeviceRequest)(nil),               // 76: dungeongate.auth.v1.RevokeDeviceRequest
+	(*Notification)(nil),                      // 77: dungeongate.auth.v1.Notification
+	(*ListNotificationsRequest)(nil),          // 78: dungeongate.auth.v1.ListNotificationsRequest
+	(*ListNotificationsResponse)(nil),         // 79: dungeongate.auth.v1.ListNotificationsResponse
+	(*MarkNotificationsReadRequest)(nil),      // 80: dungeongate.auth.v1.MarkNotificationsReadRequest
+	(*SendNotificationRequest)(nil),           // 81: dungeongate.auth.v1.SendNotificationRequest
+	(*NotifyUserRequest)(nil),                 // 82: dungeongate.auth.v1.NotifyUserRequest
+	(*AddUserNoteRequest)(nil),                // 83: dungeongate.auth.v1.AddUserNoteRequest
+	(*UserNote)(nil),                          // 84: dungeongate.auth.v1.UserNote
+	(*UserModerationFlag)(nil),                // 85: dungeongate.auth.v1.UserModerationFlag
+	(*GetUserModerationResponse)(nil),         // 86: dungeongate.auth.v1.GetUserModerationResponse
+	(*ModerationFlagRequest)(nil),             // 87: dungeongate.auth.v1.ModerationFlagRequest
+	(*ListUsersByModerationFlagRequest)(nil),  // 88: dungeongate.auth.v1.ListUsersByModerationFlagRequest
+	(*ListUsersByModerationFlagResponse)(nil), // 89: dungeongate.auth.v1.ListUsersByModerationFlagResponse
+	(*BannerRequest)(nil),                     // 90: dungeongate.auth.v1.BannerRequest
+	(*BannerTemplate)(nil),                    // 91: dungeongate.auth.v1.BannerTemplate
+	(*ListBannersResponse)(nil),               // 92: dungeongate.auth.v1.ListBannersResponse
+	(*GetBannerResponse)(nil),                 // 93: dungeongate.auth.v1.GetBannerResponse
+	(*UpdateBannerRequest)(nil),               // 94: dungeongate.auth.v1.UpdateBannerRequest
+	(*PreviewBannerRequest)(nil),              // 95: dungeongate.auth.v1.PreviewBannerRequest
+	(*PreviewBannerResponse)(nil),             // 96: dungeongate.auth.v1.PreviewBannerResponse
+	(*WatchBannersRequest)(nil),               // 97: dungeongate.auth.v1.WatchBannersRequest
+	(*BannerUpdate)(nil),                      // 98: dungeongate.auth.v1.BannerUpdate
+	(*SessionInstance)(nil),                   // 99: dungeongate.auth.v1.SessionInstance
+	(*ReportSessionInstanceRequest)(nil),      // 100: dungeongate.auth.v1.ReportSessionInstanceRequest
+	(*ListSessionInstancesRequest)(nil),       // 101: dungeongate.auth.v1.ListSessionInstancesRequest
+	(*ListSessionInstancesResponse)(nil),      // 102: dungeongate.auth.v1.ListSessionInstancesResponse
+	(*CountSessionLoginsRequest)(nil),         // 103: dungeongate.auth.v1.CountSessionLoginsRequest
+	(*CountSessionLoginsResponse)(nil),        // 104: dungeongate.auth.v1.CountSessionLoginsResponse
+	(*UserFilter)(nil),                        // 105: dungeongate.auth.v1.UserFilter
+	(*ListUsersRequest)(nil),                  // 106: dungeongate.auth.v1.ListUsersRequest
+	(*UserSummary)(nil),                       // 107: dungeongate.auth.v1.UserSummary
+	(*ListUsersResponse)(nil),                 // 108: dungeongate.auth.v1.ListUsersResponse
+	(*BulkLockRequest)(nil),                   // 109: dungeongate.auth.v1.BulkLockRequest
+	(*BulkRoleRequest)(nil),                   // 110: dungeongate.auth.v1.BulkRoleRequest
+	(*BulkActionFailure)(nil),                 // 111: dungeongate.auth.v1.BulkActionFailure
+	(*BulkAdminActionResponse)(nil),           // 112: dungeongate.auth.v1.BulkAdminActionResponse
+	(*ExportUsersRequest)(nil),                // 113: dungeongate.auth.v1.ExportUsersRequest
+	(*ExportUsersResponse)(nil),               // 114: dungeongate.auth.v1.ExportUsersResponse
+	(*ImportUsersRequest)(nil),                // 115: dungeongate.auth.v1.ImportUsersRequest
+	(*ImportedUser)(nil),                      // 116: dungeongate.auth.v1.ImportedUser
+	(*ImportUsersResponse)(nil),               // 117: dungeongate.auth.v1.ImportUsersResponse
+	(*EncryptPersonalDataRequest)(nil),        // 118: dungeongate.auth.v1.EncryptPersonalDataRequest
+	(*EncryptPersonalDataResponse)(nil),       // 119: dungeongate.auth.v1.EncryptPersonalDataResponse
+	(*UsernameChange)(nil),                    // 120: dungeongate.auth.v1.UsernameChange
+	(*GetUsernameHistoryResponse)(nil),        // 121: dungeongate.auth.v1.GetUsernameHistoryResponse
+	(*FeatureFlag)(nil),                       // 122: dungeongate.auth.v1.FeatureFlag
+	(*FeatureFlagRequest)(nil),                // 123: dungeongate.auth.v1.FeatureFlagRequest
+	(*ListFeatureFlagsResponse)(nil),          // 124: dungeongate.auth.v1.ListFeatureFlagsResponse
+	(*SetFeatureFlagRequest)(nil),             // 125: dungeongate.auth.v1.SetFeatureFlagRequest
+	(*FeatureFlagOverridesResponse)(nil),      // 126: dungeongate.auth.v1.FeatureFlagOverridesResponse
+	nil,                                       // 127: dungeongate.auth.v1.RegisterRequest.MetadataEntry
+	nil,                                       // 128: dungeongate.auth.v1.LoginRequest.MetadataEntry
+	nil,                                       // 129: dungeongate.auth.v1.HealthResponse.DetailsEntry
+	nil,                                       // 130: dungeongate.auth.v1.User.MetadataEntry
+	nil,                                       // 131: dungeongate.auth.v1.TokenClaims.MetadataEntry
+	nil,                                       // 132: dungeongate.auth.v1.ServerStatsResponse.StatsEntry
+	(*timestamppb.Timestamp)(nil),             // 133: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                     // 134: google.protobuf.Empty
 }
 var file_auth_auth_service_proto_depIdxs = []int32{
-	28, // 0: dungeongate.auth.v1.RegisterRequest.metadata:type_name -> dungeongate.auth.v1.RegisterRequest.MetadataEntry
-	21, // 1: dungeongate.auth.v1.RegisterResponse.user:type_name -> dungeongate.auth.v1.User
-	29, // 2: dungeongate.auth.v1.LoginRequest.metadata:type_name -> dungeongate.auth.v1.LoginRequest.MetadataEntry
-	21, // 3: dungeongate.auth.v1.LoginResponse.user:type_name -> dungeongate.auth.v1.User
-	21, // 4: dungeongate.auth.v1.ValidateTokenResponse.user:type_name -> dungeongate.auth.v1.User
-	21, // 5: dungeongate.auth.v1.GetUserInfoResponse.user:type_name -> dungeongate.auth.v1.User
-	30, // 6: dungeongate.auth.v1.HealthResponse.details:type_name -> dungeongate.auth.v1.HealthResponse.DetailsEntry
-	34, // 7: dungeongate.auth.v1.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
-	34, // 8: dungeongate.auth.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	34, // 9: dungeongate.auth.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	34, // 10: dungeongate.auth.v1.User.last_login:type_name -> google.protobuf.Timestamp
-	31, // 11: dungeongate.auth.v1.User.metadata:type_name -> dungeongate.auth.v1.User.MetadataEntry
-	32, // 12: dungeongate.auth.v1.TokenClaims.metadata:type_name -> dungeongate.auth.v1.TokenClaims.MetadataEntry
-	33, // 13: dungeongate.auth.v1.ServerStatsResponse.stats:type_name -> dungeongate.auth.v1.ServerStatsResponse.StatsEntry
-	0,  // 14: dungeongate.auth.v1.AuthService.Register:input_type -> dungeongate.auth.v1.RegisterRequest
-	2,  // 15: dungeongate.auth.v1.AuthService.Login:input_type -> dungeongate.auth.v1.LoginRequest
-	4,  // 16: dungeongate.auth.v1.AuthService.Logout:input_type -> dungeongate.auth.v1.LogoutRequest
-	6,  // 17: dungeongate.auth.v1.AuthService.RefreshToken:input_type -> dungeongate.auth.v1.RefreshTokenRequest
-	8,  // 18: dungeongate.auth.v1.AuthService.ValidateToken:input_type -> dungeongate.auth.v1.ValidateTokenRequest
-	10, // 19: dungeongate.auth.v1.AuthService.GetUserInfo:input_type -> dungeongate.auth.v1.GetUserInfoRequest
-	12, // 20: dungeongate.auth.v1.AuthService.ChangePassword:input_type -> dungeongate.auth.v1.ChangePasswordRequest
-	14, // 21: dungeongate.auth.v1.AuthService.ResetPassword:input_type -> dungeongate.auth.v1.ResetPasswordRequest
-	16, // 22: dungeongate.auth.v1.AuthService.VerifyPasswordReset:input_type -> dungeongate.auth.v1.VerifyPasswordResetRequest
-	18, // 23: dungeongate.auth.v1.AuthService.GetLoginAttempts:input_type -> dungeongate.auth.v1.GetLoginAttemptsRequest
-	35, // 24: dungeongate.auth.v1.AuthService.Health:input_type -> google.protobuf.Empty
-	23, // 25: dungeongate.auth.v1.AuthService.UnlockUserAccount:input_type -> dungeongate.auth.v1.AdminActionRequest
-	23, // 26: dungeongate.auth.v1.AuthService.DeleteUserAccount:input_type -> dungeongate.auth.v1.AdminActionRequest
-	25, // 27: dungeongate.auth.v1.AuthService.ResetUserPassword:input_type -> dungeongate.auth.v1.ResetPasswordAdminRequest
-	23, // 28: dungeongate.auth.v1.AuthService.PromoteUserToAdmin:input_type -> dungeongate.auth.v1.AdminActionRequest
-	26, // 29: dungeongate.auth.v1.AuthService.GetServerStatistics:input_type -> dungeongate.auth.v1.ServerStatsRequest
-	1,  // 30: dungeongate.auth.v1.AuthService.Register:output_type -> dungeongate.auth.v1.RegisterResponse
-	3,  // 31: dungeongate.auth.v1.AuthService.Login:output_type -> dungeongate.auth.v1.LoginResponse
-	5,  // 32: dungeongate.auth.v1.AuthService.Logout:output_type -> dungeongate.auth.v1.LogoutResponse
-	7,  // 33: dungeongate.auth.v1.AuthService.RefreshToken:output_type -> dungeongate.auth.v1.RefreshTokenResponse
-	9,  // 34: dungeongate.auth.v1.AuthService.ValidateToken:output_type -> dungeongate.auth.v1.ValidateTokenResponse
-	11, // 35: dungeongate.auth.v1.AuthService.GetUserInfo:output_type -> dungeongate.auth.v1.GetUserInfoResponse
-	13, // 36: dungeongate.auth.v1.AuthService.ChangePassword:output_type -> dungeongate.auth.v1.ChangePasswordResponse
-	15, // 37: dungeongate.auth.v1.AuthService.ResetPassword:output_type -> dungeongate.auth.v1.ResetPasswordResponse
-	17, // 38: dungeongate.auth.v1.AuthService.VerifyPasswordReset:output_type -> dungeongate.auth.v1.VerifyPasswordResetResponse
-	19, // 39: dungeongate.auth.v1.AuthService.GetLoginAttempts:output_type -> dungeongate.auth.v1.GetLoginAttemptsResponse
-	20, // 40: dungeongate.auth.v1.AuthService.Health:output_type -> dungeongate.auth.v1.HealthResponse
-	24, // 41: dungeongate.auth.v1.AuthService.UnlockUserAccount:output_type -> dungeongate.auth.v1.AdminActionResponse
-	24, // 42: dungeongate.auth.v1.AuthService.DeleteUserAccount:output_type -> dungeongate.auth.v1.AdminActionResponse
-	24, // 43: dungeongate.auth.v1.AuthService.ResetUserPassword:output_type -> dungeongate.auth.v1.AdminActionResponse
-	24, // 44: dungeongate.auth.v1.AuthService.PromoteUserToAdmin:output_type -> dungeongate.auth.v1.AdminActionResponse
-	27, // 45: dungeongate.auth.v1.AuthService.GetServerStatistics:output_type -> dungeongate.auth.v1.ServerStatsResponse
-	30, // [30:46] is the sub-list for method output_type
-	14, // [14:30] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	127, // 0: dungeongate.auth.v1.RegisterRequest.metadata:type_name -> dungeongate.auth.v1.RegisterRequest.MetadataEntry
+	30,  // 1: dungeongate.auth.v1.RegisterResponse.user:type_name -> dungeongate.auth.v1.User
+	128, // 2: dungeongate.auth.v1.LoginRequest.metadata:type_name -> dungeongate.auth.v1.LoginRequest.MetadataEntry
+	72,  // 3: dungeongate.auth.v1.LoginRequest.device:type_name -> dungeongate.auth.v1.ClientDevice
+	30,  // 4: dungeongate.auth.v1.LoginResponse.user:type_name -> dungeongate.auth.v1.User
+	30,  // 5: dungeongate.auth.v1.ValidateTokenResponse.user:type_name -> dungeongate.auth.v1.User
+	30,  // 6: dungeongate.auth.v1.GetUserInfoResponse.user:type_name -> dungeongate.auth.v1.User
+	30,  // 7: dungeongate.auth.v1.ChangeUsernameResponse.user:type_name -> dungeongate.auth.v1.User
+	17,  // 8: dungeongate.auth.v1.MergeAccountsResponse.summary:type_name -> dungeongate.auth.v1.AccountMergeSummary
+	129, // 9: dungeongate.auth.v1.HealthResponse.details:type_name -> dungeongate.auth.v1.HealthResponse.DetailsEntry
+	133, // 10: dungeongate.auth.v1.HealthResponse.timestamp:type_name -> google.protobuf.Timestamp
+	133, // 11: dungeongate.auth.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	133, // 12: dungeongate.auth.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	133, // 13: dungeongate.auth.v1.User.last_login:type_name -> google.protobuf.Timestamp
+	130, // 14: dungeongate.auth.v1.User.metadata:type_name -> dungeongate.auth.v1.User.MetadataEntry
+	131, // 15: dungeongate.auth.v1.TokenClaims.metadata:type_name -> dungeongate.auth.v1.TokenClaims.MetadataEntry
+	132, // 16: dungeongate.auth.v1.ServerStatsResponse.stats:type_name -> dungeongate.auth.v1.ServerStatsResponse.StatsEntry
+	133, // 17: dungeongate.auth.v1.AccountAccess.created_at:type_name -> google.protobuf.Timestamp
+	133, // 18: dungeongate.auth.v1.AccountAccess.expires_at:type_name -> google.protobuf.Timestamp
+	40,  // 19: dungeongate.auth.v1.ListAccountAccessResponse.entries:type_name -> dungeongate.auth.v1.AccountAccess
+	133, // 20: dungeongate.auth.v1.GroupMember.joined_at:type_name -> google.protobuf.Timestamp
+	133, // 21: dungeongate.auth.v1.Group.created_at:type_name -> google.protobuf.Timestamp
+	45,  // 22: dungeongate.auth.v1.Group.members:type_name -> dungeongate.auth.v1.GroupMember
+	46,  // 23: dungeongate.auth.v1.GroupResponse.group:type_name -> dungeongate.auth.v1.Group
+	46,  // 24: dungeongate.auth.v1.ListGroupsResponse.groups:type_name -> dungeongate.auth.v1.Group
+	133, // 25: dungeongate.auth.v1.Friend.added_at:type_name -> google.protobuf.Timestamp
+	50,  // 26: dungeongate.auth.v1.FriendsResponse.friends:type_name -> dungeongate.auth.v1.Friend
+	133, // 27: dungeongate.auth.v1.Terms.published_at:type_name -> google.protobuf.Timestamp
+	53,  // 28: dungeongate.auth.v1.TermsResponse.terms:type_name -> dungeongate.auth.v1.Terms
+	133, // 29: dungeongate.auth.v1.TermsAcceptance.accepted_at:type_name -> google.protobuf.Timestamp
+	58,  // 30: dungeongate.auth.v1.TermsStatusResponse.acceptances:type_name -> dungeongate.auth.v1.TermsAcceptance
+	133, // 31: dungeongate.auth.v1.APIKey.created_at:type_name -> google.protobuf.Timestamp
+	133, // 32: dungeongate.auth.v1.APIKey.last_used_at:type_name -> google.protobuf.Timestamp
+	133, // 33: dungeongate.auth.v1.APIKey.revoked_at:type_name -> google.protobuf.Timestamp
+	60,  // 34: dungeongate.auth.v1.CreateAPIKeyResponse.api_key:type_name -> dungeongate.auth.v1.APIKey
+	60,  // 35: dungeongate.auth.v1.ListAPIKeysResponse.api_keys:type_name -> dungeongate.auth.v1.APIKey
+	30,  // 36: dungeongate.auth.v1.ValidateAPIKeyResponse.user:type_name -> dungeongate.auth.v1.User
+	60,  // 37: dungeongate.auth.v1.ValidateAPIKeyResponse.api_key:type_name -> dungeongate.auth.v1.APIKey
+	133, // 38: dungeongate.auth.v1.Device.first_seen_at:type_name -> google.protobuf.Timestamp
+	133, // 39: dungeongate.auth.v1.Device.last_seen_at:type_name -> google.protobuf.Timestamp
+	133, // 40: dungeongate.auth.v1.Device.revoked_at:type_name -> google.protobuf.Timestamp
+	73,  // 41: dungeongate.auth.v1.ListDevicesResponse.devices:type_name -> dungeongate.auth.v1.Device
+	133, // 42: dungeongate.auth.v1.Notification.created_at:type_name -> google.protobuf.Timestamp
+	133, // 43: dungeongate.auth.v1.Notification.read_at:type_name -> google.protobuf.Timestamp
+	77,  // 44: dungeongate.auth.v1.ListNotificationsResponse.notifications:type_name -> dungeongate.auth.v1.Notification
+	133, // 45: dungeongate.auth.v1.UserNote.created_at:type_name -> google.protobuf.Timestamp
+	133, // 46: dungeongate.auth.v1.UserModerationFlag.created_at:type_name -> google.protobuf.Timestamp
+	84,  // 47: dungeongate.auth.v1.GetUserModerationResponse.notes:type_name -> dungeongate.auth.v1.UserNote
+	85,  // 48: dungeongate.auth.v1.GetUserModerationResponse.flags:type_name -> dungeongate.auth.v1.UserModerationFlag
+	85,  // 49: dungeongate.auth.v1.ListUsersByModerationFlagResponse.users:type_name -> dungeongate.auth.v1.UserModerationFlag
+	133, // 50: dungeongate.auth.v1.BannerTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	91,  // 51: dungeongate.auth.v1.ListBannersResponse.banners:type_name -> dungeongate.auth.v1.BannerTemplate
+	91,  // 52: dungeongate.auth.v1.GetBannerResponse.banner:type_name -> dungeongate.auth.v1.BannerTemplate
+	133, // 53: dungeongate.auth.v1.BannerUpdate.updated_at:type_name -> google.protobuf.Timestamp
+	133, // 54: dungeongate.auth.v1.SessionInstance.started_at:type_name -> google.protobuf.Timestamp
+	133, // 55: dungeongate.auth.v1.SessionInstance.reported_at:type_name -> google.protobuf.Timestamp
+	99,  // 56: dungeongate.auth.v1.ReportSessionInstanceRequest.instance:type_name -> dungeongate.auth.v1.SessionInstance
+	99,  // 57: dungeongate.auth.v1.ListSessionInstancesResponse.instances:type_name -> dungeongate.auth.v1.SessionInstance
+	99,  // 58: dungeongate.auth.v1.ListSessionInstancesResponse.totals:type_name -> dungeongate.auth.v1.SessionInstance
+	133, // 59: dungeongate.auth.v1.UserFilter.created_after:type_name -> google.protobuf.Timestamp
+	133, // 60: dungeongate.auth.v1.UserFilter.created_before:type_name -> google.protobuf.Timestamp
+	133, // 61: dungeongate.auth.v1.UserFilter.last_login_after:type_name -> google.protobuf.Timestamp
+	133, // 62: dungeongate.auth.v1.UserFilter.last_login_before:type_name -> google.protobuf.Timestamp
+	105, // 63: dungeongate.auth.v1.ListUsersRequest.filter:type_name -> dungeongate.auth.v1.UserFilter
+	133, // 64: dungeongate.auth.v1.UserSummary.created_at:type_name -> google.protobuf.Timestamp
+	133, // 65: dungeongate.auth.v1.UserSummary.last_login:type_name -> google.protobuf.Timestamp
+	133, // 66: dungeongate.auth.v1.UserSummary.locked_until:type_name -> google.protobuf.Timestamp
+	107, // 67: dungeongate.auth.v1.ListUsersResponse.users:type_name -> dungeongate.auth.v1.UserSummary
+	133, // 68: dungeongate.auth.v1.BulkLockRequest.locked_until:type_name -> google.protobuf.Timestamp
+	111, // 69: dungeongate.auth.v1.BulkAdminActionResponse.failures:type_name -> dungeongate.auth.v1.BulkActionFailure
+	105, // 70: dungeongate.auth.v1.ExportUsersRequest.filter:type_name -> dungeongate.auth.v1.UserFilter
+	116, // 71: dungeongate.auth.v1.ImportUsersResponse.users:type_name -> dungeongate.auth.v1.ImportedUser
+	133, // 72: dungeongate.auth.v1.UsernameChange.changed_at:type_name -> google.protobuf.Timestamp
+	133, // 73: dungeongate.auth.v1.UsernameChange.reserved_until:type_name -> google.protobuf.Timestamp
+	120, // 74: dungeongate.auth.v1.GetUsernameHistoryResponse.changes:type_name -> dungeongate.auth.v1.UsernameChange
+	133, // 75: dungeongate.auth.v1.FeatureFlag.updated_at:type_name -> google.protobuf.Timestamp
+	122, // 76: dungeongate.auth.v1.ListFeatureFlagsResponse.flags:type_name -> dungeongate.auth.v1.FeatureFlag
+	122, // 77: dungeongate.auth.v1.SetFeatureFlagRequest.flag:type_name -> dungeongate.auth.v1.FeatureFlag
+	122, // 78: dungeongate.auth.v1.FeatureFlagOverridesResponse.flags:type_name -> dungeongate.auth.v1.FeatureFlag
+	0,   // 79: dungeongate.auth.v1.AuthService.Register:input_type -> dungeongate.auth.v1.RegisterRequest
+	2,   // 80: dungeongate.auth.v1.AuthService.Login:input_type -> dungeongate.auth.v1.LoginRequest
+	4,   // 81: dungeongate.auth.v1.AuthService.Logout:input_type -> dungeongate.auth.v1.LogoutRequest
+	6,   // 82: dungeongate.auth.v1.AuthService.RefreshToken:input_type -> dungeongate.auth.v1.RefreshTokenRequest
+	8,   // 83: dungeongate.auth.v1.AuthService.ValidateToken:input_type -> dungeongate.auth.v1.ValidateTokenRequest
+	10,  // 84: dungeongate.auth.v1.AuthService.GetUserInfo:input_type -> dungeongate.auth.v1.GetUserInfoRequest
+	12,  // 85: dungeongate.auth.v1.AuthService.ChangePassword:input_type -> dungeongate.auth.v1.ChangePasswordRequest
+	14,  // 86: dungeongate.auth.v1.AuthService.ChangeUsername:input_type -> dungeongate.auth.v1.ChangeUsernameRequest
+	16,  // 87: dungeongate.auth.v1.AuthService.MergeAccounts:input_type -> dungeongate.auth.v1.MergeAccountsRequest
+	19,  // 88: dungeongate.auth.v1.AuthService.SetUserPreference:input_type -> dungeongate.auth.v1.SetUserPreferenceRequest
+	21,  // 89: dungeongate.auth.v1.AuthService.SetShowOnlineStatus:input_type -> dungeongate.auth.v1.SetShowOnlineStatusRequest
+	22,  // 90: dungeongate.auth.v1.AuthService.ResetPassword:input_type -> dungeongate.auth.v1.ResetPasswordRequest
+	24,  // 91: dungeongate.auth.v1.AuthService.VerifyPasswordReset:input_type -> dungeongate.auth.v1.VerifyPasswordResetRequest
+	26,  // 92: dungeongate.auth.v1.AuthService.GetLoginAttempts:input_type -> dungeongate.auth.v1.GetLoginAttemptsRequest
+	134, // 93: dungeongate.auth.v1.AuthService.Health:input_type -> google.protobuf.Empty
+	134, // 94: dungeongate.auth.v1.AuthService.Version:input_type -> google.protobuf.Empty
+	32,  // 95: dungeongate.auth.v1.AuthService.UnlockUserAccount:input_type -> dungeongate.auth.v1.AdminActionRequest
+	32,  // 96: dungeongate.auth.v1.AuthService.DeleteUserAccount:input_type -> dungeongate.auth.v1.AdminActionRequest
+	35,  // 97: dungeongate.auth.v1.AuthService.ResetUserPassword:input_type -> dungeongate.auth.v1.ResetPasswordAdminRequest
+	32,  // 98: dungeongate.auth.v1.AuthService.PromoteUserToAdmin:input_type -> dungeongate.auth.v1.AdminActionRequest
+	34,  // 99: dungeongate.auth.v1.AuthService.RenameUser:input_type -> dungeongate.auth.v1.RenameUserRequest
+	36,  // 100: dungeongate.auth.v1.AuthService.GetServerStatistics:input_type -> dungeongate.auth.v1.ServerStatsRequest
+	38,  // 101: dungeongate.auth.v1.AuthService.ImpersonateUser:input_type -> dungeongate.auth.v1.ImpersonateUserRequest
+	41,  // 102: dungeongate.auth.v1.AuthService.ListAccountAccess:input_type -> dungeongate.auth.v1.ListAccountAccessRequest
+	43,  // 103: dungeongate.auth.v1.AuthService.CreateGroup:input_type -> dungeongate.auth.v1.CreateGroupRequest
+	44,  // 104: dungeongate.auth.v1.AuthService.JoinGroup:input_type -> dungeongate.auth.v1.GroupRequest
+	44,  // 105: dungeongate.auth.v1.AuthService.LeaveGroup:input_type -> dungeongate.auth.v1.GroupRequest
+	44,  // 106: dungeongate.auth.v1.AuthService.GetGroup:input_type -> dungeongate.auth.v1.GroupRequest
+	44,  // 107: dungeongate.auth.v1.AuthService.ListGroups:input_type -> dungeongate.auth.v1.GroupRequest
+	49,  // 108: dungeongate.auth.v1.AuthService.AddFriend:input_type -> dungeongate.auth.v1.FriendRequest
+	49,  // 109: dungeongate.auth.v1.AuthService.RemoveFriend:input_type -> dungeongate.auth.v1.FriendRequest
+	49,  // 110: dungeongate.auth.v1.AuthService.ListFriends:input_type -> dungeongate.auth.v1.FriendRequest
+	82,  // 111: dungeongate.auth.v1.AuthService.NotifyFriends:input_type -> dungeongate.auth.v1.NotifyUserRequest
+	52,  // 112: dungeongate.auth.v1.AuthService.GetTerms:input_type -> dungeongate.auth.v1.TermsRequest
+	55,  // 113: dungeongate.auth.v1.AuthService.PublishTerms:input_type -> dungeongate.auth.v1.PublishTermsRequest
+	56,  // 114: dungeongate.auth.v1.AuthService.AcceptTerms:input_type -> dungeongate.auth.v1.AcceptTermsRequest
+	57,  // 115: dungeongate.auth.v1.AuthService.GetTermsStatus:input_type -> dungeongate.auth.v1.TermsStatusRequest
+	61,  // 116: dungeongate.auth.v1.AuthService.CreateAPIKey:input_type -> dungeongate.auth.v1.CreateAPIKeyRequest
+	63,  // 117: dungeongate.auth.v1.AuthService.ListAPIKeys:input_type -> dungeongate.auth.v1.ListAPIKeysRequest
+	65,  // 118: dungeongate.auth.v1.AuthService.RevokeAPIKey:input_type -> dungeongate.auth.v1.RevokeAPIKeyRequest
+	66,  // 119: dungeongate.auth.v1.AuthService.ValidateAPIKey:input_type -> dungeongate.auth.v1.ValidateAPIKeyRequest
+	68,  // 120: dungeongate.auth.v1.AuthService.IssueChallenge:input_type -> dungeongate.auth.v1.IssueChallengeRequest
+	70,  // 121: dungeongate.auth.v1.AuthService.AnswerChallenge:input_type -> dungeongate.auth.v1.AnswerChallengeRequest
+	74,  // 122: dungeongate.auth.v1.AuthService.ListDevices:input_type -> dungeongate.auth.v1.ListDevicesRequest
+	76,  // 123: dungeongate.auth.v1.AuthService.RevokeDevice:input_type -> dungeongate.auth.v1.RevokeDeviceRequest
+	78,  // 124: dungeongate.auth.v1.AuthService.ListNotifications:input_type -> dungeongate.auth.v1.ListNotificationsRequest
+	80,  // 125: dungeongate.auth.v1.AuthService.MarkNotificationsRead:input_type -> dungeongate.auth.v1.MarkNotificationsReadRequest
+	81,  // 126: dungeongate.auth.v1.AuthService.SendNotification:input_type -> dungeongate.auth.v1.SendNotificationRequest
+	82,  // 127: dungeongate.auth.v1.AuthService.NotifyUser:input_type -> dungeongate.auth.v1.NotifyUserRequest
+	83,  // 128: dungeongate.auth.v1.AuthService.AddUserNote:input_type -> dungeongate.auth.v1.AddUserNoteRequest
+	32,  // 129: dungeongate.auth.v1.AuthService.GetUserModeration:input_type -> dungeongate.auth.v1.AdminActionRequest
+	87,  // 130: dungeongate.auth.v1.AuthService.SetUserModerationFlag:input_type -> dungeongate.auth.v1.ModerationFlagRequest
+	87,  // 131: dungeongate.auth.v1.AuthService.ClearUserModerationFlag:input_type -> dungeongate.auth.v1.ModerationFlagRequest
+	88,  // 132: dungeongate.auth.v1.AuthService.ListUsersByModerationFlag:input_type -> dungeongate.auth.v1.ListUsersByModerationFlagRequest
+	32,  // 133: dungeongate.auth.v1.AuthService.GetUsernameHistory:input_type -> dungeongate.auth.v1.AdminActionRequest
+	90,  // 134: dungeongate.auth.v1.AuthService.ListBanners:input_type -> dungeongate.auth.v1.BannerRequest
+	90,  // 135: dungeongate.auth.v1.AuthService.GetBanner:input_type -> dungeongate.auth.v1.BannerRequest
+	94,  // 136: dungeongate.auth.v1.AuthService.UpdateBanner:input_type -> dungeongate.auth.v1.UpdateBannerRequest
+	90,  // 137: dungeongate.auth.v1.AuthService.ResetBanner:input_type -> dungeongate.auth.v1.BannerRequest
+	95,  // 138: dungeongate.auth.v1.AuthService.PreviewBanner:input_type -> dungeongate.auth.v1.PreviewBannerRequest
+	97,  // 139: dungeongate.auth.v1.AuthService.WatchBanners:input_type -> dungeongate.auth.v1.WatchBannersRequest
+	100, // 140: dungeongate.auth.v1.AuthService.ReportSessionInstance:input_type -> dungeongate.auth.v1.ReportSessionInstanceRequest
+	101, // 141: dungeongate.auth.v1.AuthService.ListSessionInstances:input_type -> dungeongate.auth.v1.ListSessionInstancesRequest
+	103, // 142: dungeongate.auth.v1.AuthService.CountSessionLogins:input_type -> dungeongate.auth.v1.CountSessionLoginsRequest
+	106, // 143: dungeongate.auth.v1.AuthService.ListUsers:input_type -> dungeongate.auth.v1.ListUsersRequest
+	109, // 144: dungeongate.auth.v1.AuthService.SetAccountsLocked:input_type -> dungeongate.auth.v1.BulkLockRequest
+	110, // 145: dungeongate.auth.v1.AuthService.SetUsersRole:input_type -> dungeongate.auth.v1.BulkRoleRequest
+	113, // 146: dungeongate.auth.v1.AuthService.ExportUsers:input_type -> dungeongate.auth.v1.ExportUsersRequest
+	115, // 147: dungeongate.auth.v1.AuthService.ImportUsers:input_type -> dungeongate.auth.v1.ImportUsersRequest
+	118, // 148: dungeongate.auth.v1.AuthService.EncryptPersonalData:input_type -> dungeongate.auth.v1.EncryptPersonalDataRequest
+	123, // 149: dungeongate.auth.v1.AuthService.ListFeatureFlags:input_type -> dungeongate.auth.v1.FeatureFlagRequest
+	125, // 150: dungeongate.auth.v1.AuthService.SetFeatureFlag:input_type -> dungeongate.auth.v1.SetFeatureFlagRequest
+	123, // 151: dungeongate.auth.v1.AuthService.DeleteFeatureFlag:input_type -> dungeongate.auth.v1.FeatureFlagRequest
+	134, // 152: dungeongate.auth.v1.AuthService.GetFeatureFlagOverrides:input_type -> google.protobuf.Empty
+	1,   // 153: dungeongate.auth.v1.AuthService.Register:output_type -> dungeongate.auth.v1.RegisterResponse
+	3,   // 154: dungeongate.auth.v1.AuthService.Login:output_type -> dungeongate.auth.v1.LoginResponse
+	5,   // 155: dungeongate.auth.v1.AuthService.Logout:output_type -> dungeongate.auth.v1.LogoutResponse
+	7,   // 156: dungeongate.auth.v1.AuthService.RefreshToken:output_type -> dungeongate.auth.v1.RefreshTokenResponse
+	9,   // 157: dungeongate.auth.v1.AuthService.ValidateToken:output_type -> dungeongate.auth.v1.ValidateTokenResponse
+	11,  // 158: dungeongate.auth.v1.AuthService.GetUserInfo:output_type -> dungeongate.auth.v1.GetUserInfoResponse
+	13,  // 159: dungeongate.auth.v1.AuthService.ChangePassword:output_type -> dungeongate.auth.v1.ChangePasswordResponse
+	15,  // 160: dungeongate.auth.v1.AuthService.ChangeUsername:output_type -> dungeongate.auth.v1.ChangeUsernameResponse
+	18,  // 161: dungeongate.auth.v1.AuthService.MergeAccounts:output_type -> dungeongate.auth.v1.MergeAccountsResponse
+	20,  // 162: dungeongate.auth.v1.AuthService.SetUserPreference:output_type -> dungeongate.auth.v1.SetUserPreferenceResponse
+	20,  // 163: dungeongate.auth.v1.AuthService.SetShowOnlineStatus:output_type -> dungeongate.auth.v1.SetUserPreferenceResponse
+	23,  // 164: dungeongate.auth.v1.AuthService.ResetPassword:output_type -> dungeongate.auth.v1.ResetPasswordResponse
+	25,  // 165: dungeongate.auth.v1.AuthService.VerifyPasswordReset:output_type -> dungeongate.auth.v1.VerifyPasswordResetResponse
+	27,  // 166: dungeongate.auth.v1.AuthService.GetLoginAttempts:output_type -> dungeongate.auth.v1.GetLoginAttemptsResponse
+	28,  // 167: dungeongate.auth.v1.AuthService.Health:output_type -> dungeongate.auth.v1.HealthResponse
+	29,  // 168: dungeongate.auth.v1.AuthService.Version:output_type -> dungeongate.auth.v1.VersionResponse
+	33,  // 169: dungeongate.auth.v1.AuthService.UnlockUserAccount:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 170: dungeongate.auth.v1.AuthService.DeleteUserAccount:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 171: dungeongate.auth.v1.AuthService.ResetUserPassword:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 172: dungeongate.auth.v1.AuthService.PromoteUserToAdmin:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 173: dungeongate.auth.v1.AuthService.RenameUser:output_type -> dungeongate.auth.v1.AdminActionResponse
+	37,  // 174: dungeongate.auth.v1.AuthService.GetServerStatistics:output_type -> dungeongate.auth.v1.ServerStatsResponse
+	39,  // 175: dungeongate.auth.v1.AuthService.ImpersonateUser:output_type -> dungeongate.auth.v1.ImpersonateUserResponse
+	42,  // 176: dungeongate.auth.v1.AuthService.ListAccountAccess:output_type -> dungeongate.auth.v1.ListAccountAccessResponse
+	47,  // 177: dungeongate.auth.v1.AuthService.CreateGroup:output_type -> dungeongate.auth.v1.GroupResponse
+	47,  // 178: dungeongate.auth.v1.AuthService.JoinGroup:output_type -> dungeongate.auth.v1.GroupResponse
+	47,  // 179: dungeongate.auth.v1.AuthService.LeaveGroup:output_type -> dungeongate.auth.v1.GroupResponse
+	47,  // 180: dungeongate.auth.v1.AuthService.GetGroup:output_type -> dungeongate.auth.v1.GroupResponse
+	48,  // 181: dungeongate.auth.v1.AuthService.ListGroups:output_type -> dungeongate.auth.v1.ListGroupsResponse
+	51,  // 182: dungeongate.auth.v1.AuthService.AddFriend:output_type -> dungeongate.auth.v1.FriendsResponse
+	51,  // 183: dungeongate.auth.v1.AuthService.RemoveFriend:output_type -> dungeongate.auth.v1.FriendsResponse
+	51,  // 184: dungeongate.auth.v1.AuthService.ListFriends:output_type -> dungeongate.auth.v1.FriendsResponse
+	33,  // 185: dungeongate.auth.v1.AuthService.NotifyFriends:output_type -> dungeongate.auth.v1.AdminActionResponse
+	54,  // 186: dungeongate.auth.v1.AuthService.GetTerms:output_type -> dungeongate.auth.v1.TermsResponse
+	33,  // 187: dungeongate.auth.v1.AuthService.PublishTerms:output_type -> dungeongate.auth.v1.AdminActionResponse
+	59,  // 188: dungeongate.auth.v1.AuthService.AcceptTerms:output_type -> dungeongate.auth.v1.TermsStatusResponse
+	59,  // 189: dungeongate.auth.v1.AuthService.GetTermsStatus:output_type -> dungeongate.auth.v1.TermsStatusResponse
+	62,  // 190: dungeongate.auth.v1.AuthService.CreateAPIKey:output_type -> dungeongate.auth.v1.CreateAPIKeyResponse
+	64,  // 191: dungeongate.auth.v1.AuthService.ListAPIKeys:output_type -> dungeongate.auth.v1.ListAPIKeysResponse
+	33,  // 192: dungeongate.auth.v1.AuthService.RevokeAPIKey:output_type -> dungeongate.auth.v1.AdminActionResponse
+	67,  // 193: dungeongate.auth.v1.AuthService.ValidateAPIKey:output_type -> dungeongate.auth.v1.ValidateAPIKeyResponse
+	69,  // 194: dungeongate.auth.v1.AuthService.IssueChallenge:output_type -> dungeongate.auth.v1.IssueChallengeResponse
+	71,  // 195: dungeongate.auth.v1.AuthService.AnswerChallenge:output_type -> dungeongate.auth.v1.AnswerChallengeResponse
+	75,  // 196: dungeongate.auth.v1.AuthService.ListDevices:output_type -> dungeongate.auth.v1.ListDevicesResponse
+	33,  // 197: dungeongate.auth.v1.AuthService.RevokeDevice:output_type -> dungeongate.auth.v1.AdminActionResponse
+	79,  // 198: dungeongate.auth.v1.AuthService.ListNotifications:output_type -> dungeongate.auth.v1.ListNotificationsResponse
+	79,  // 199: dungeongate.auth.v1.AuthService.MarkNotificationsRead:output_type -> dungeongate.auth.v1.ListNotificationsResponse
+	33,  // 200: dungeongate.auth.v1.AuthService.SendNotification:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 201: dungeongate.auth.v1.AuthService.NotifyUser:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 202: dungeongate.auth.v1.AuthService.AddUserNote:output_type -> dungeongate.auth.v1.AdminActionResponse
+	86,  // 203: dungeongate.auth.v1.AuthService.GetUserModeration:output_type -> dungeongate.auth.v1.GetUserModerationResponse
+	33,  // 204: dungeongate.auth.v1.AuthService.SetUserModerationFlag:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 205: dungeongate.auth.v1.AuthService.ClearUserModerationFlag:output_type -> dungeongate.auth.v1.AdminActionResponse
+	89,  // 206: dungeongate.auth.v1.AuthService.ListUsersByModerationFlag:output_type -> dungeongate.auth.v1.ListUsersByModerationFlagResponse
+	121, // 207: dungeongate.auth.v1.AuthService.GetUsernameHistory:output_type -> dungeongate.auth.v1.GetUsernameHistoryResponse
+	92,  // 208: dungeongate.auth.v1.AuthService.ListBanners:output_type -> dungeongate.auth.v1.ListBannersResponse
+	93,  // 209: dungeongate.auth.v1.AuthService.GetBanner:output_type -> dungeongate.auth.v1.GetBannerResponse
+	33,  // 210: dungeongate.auth.v1.AuthService.UpdateBanner:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 211: dungeongate.auth.v1.AuthService.ResetBanner:output_type -> dungeongate.auth.v1.AdminActionResponse
+	96,  // 212: dungeongate.auth.v1.AuthService.PreviewBanner:output_type -> dungeongate.auth.v1.PreviewBannerResponse
+	98,  // 213: dungeongate.auth.v1.AuthService.WatchBanners:output_type -> dungeongate.auth.v1.BannerUpdate
+	134, // 214: dungeongate.auth.v1.AuthService.ReportSessionInstance:output_type -> google.protobuf.Empty
+	102, // 215: dungeongate.auth.v1.AuthService.ListSessionInstances:output_type -> dungeongate.auth.v1.ListSessionInstancesResponse
+	104, // 216: dungeongate.auth.v1.AuthService.CountSessionLogins:output_type -> dungeongate.auth.v1.CountSessionLoginsResponse
+	108, // 217: dungeongate.auth.v1.AuthService.ListUsers:output_type -> dungeongate.auth.v1.ListUsersResponse
+	112, // 218: dungeongate.auth.v1.AuthService.SetAccountsLocked:output_type -> dungeongate.auth.v1.BulkAdminActionResponse
+	112, // 219: dungeongate.auth.v1.AuthService.SetUsersRole:output_type -> dungeongate.auth.v1.BulkAdminActionResponse
+	114, // 220: dungeongate.auth.v1.AuthService.ExportUsers:output_type -> dungeongate.auth.v1.ExportUsersResponse
+	117, // 221: dungeongate.auth.v1.AuthService.ImportUsers:output_type -> dungeongate.auth.v1.ImportUsersResponse
+	119, // 222: dungeongate.auth.v1.AuthService.EncryptPersonalData:output_type -> dungeongate.auth.v1.EncryptPersonalDataResponse
+	124, // 223: dungeongate.auth.v1.AuthService.ListFeatureFlags:output_type -> dungeongate.auth.v1.ListFeatureFlagsResponse
+	33,  // 224: dungeongate.auth.v1.AuthService.SetFeatureFlag:output_type -> dungeongate.auth.v1.AdminActionResponse
+	33,  // 225: dungeongate.auth.v1.AuthService.DeleteFeatureFlag:output_type -> dungeongate.auth.v1.AdminActionResponse
+	126, // 226: dungeongate.auth.v1.AuthService.GetFeatureFlagOverrides:output_type -> dungeongate.auth.v1.FeatureFlagOverridesResponse
+	153, // [153:227] is the sub-list for method output_type
+	79,  // [79:153] is the sub-list for method input_type
+	79,  // [79:79] is the sub-list for extension type_name
+	79,  // [79:79] is the sub-list for extension extendee
+	0,   // [0:79] is the sub-list for field type_name
 }
 
 func init() { file_auth_auth_service_proto_init() }
@@ -2321,7 +9684,7 @@ func file_auth_auth_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_auth_service_proto_rawDesc), len(file_auth_auth_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   34,
+			NumMessages:   133,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,22 +20,80 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName            = "/dungeongate.auth.v1.AuthService/Register"
-	AuthService_Login_FullMethodName               = "/dungeongate.auth.v1.AuthService/Login"
-	AuthService_Logout_FullMethodName              = "/dungeongate.auth.v1.AuthService/Logout"
-	AuthService_RefreshToken_FullMethodName        = "/dungeongate.auth.v1.AuthService/RefreshToken"
-	AuthService_ValidateToken_FullMethodName       = "/dungeongate.auth.v1.AuthService/ValidateToken"
-	AuthService_GetUserInfo_FullMethodName         = "/dungeongate.auth.v1.AuthService/GetUserInfo"
-	AuthService_ChangePassword_FullMethodName      = "/dungeongate.auth.v1.AuthService/ChangePassword"
-	AuthService_ResetPassword_FullMethodName       = "/dungeongate.auth.v1.AuthService/ResetPassword"
-	AuthService_VerifyPasswordReset_FullMethodName = "/dungeongate.auth.v1.AuthService/VerifyPasswordReset"
-	AuthService_GetLoginAttempts_FullMethodName    = "/dungeongate.auth.v1.AuthService/GetLoginAttempts"
-	AuthService_Health_FullMethodName              = "/dungeongate.auth.v1.AuthService/Health"
-	AuthService_UnlockUserAccount_FullMethodName   = "/dungeongate.auth.v1.AuthService/UnlockUserAccount"
-	AuthService_DeleteUserAccount_FullMethodName   = "/dungeongate.auth.v1.AuthService/DeleteUserAccount"
-	AuthService_ResetUserPassword_FullMethodName   = "/dungeongate.auth.v1.AuthService/ResetUserPassword"
-	AuthService_PromoteUserToAdmin_FullMethodName  = "/dungeongate.auth.v1.AuthService/PromoteUserToAdmin"
-	AuthService_GetServerStatistics_FullMethodName = "/dungeongate.auth.v1.AuthService/GetServerStatistics"
+	AuthService_Register_FullMethodName                  = "/dungeongate.auth.v1.AuthService/Register"
+	AuthService_Login_FullMethodName                     = "/dungeongate.auth.v1.AuthService/Login"
+	AuthService_Logout_FullMethodName                    = "/dungeongate.auth.v1.AuthService/Logout"
+	AuthService_RefreshToken_FullMethodName              = "/dungeongate.auth.v1.AuthService/RefreshToken"
+	AuthService_ValidateToken_FullMethodName             = "/dungeongate.auth.v1.AuthService/ValidateToken"
+	AuthService_GetUserInfo_FullMethodName               = "/dungeongate.auth.v1.AuthService/GetUserInfo"
+	AuthService_ChangePassword_FullMethodName            = "/dungeongate.auth.v1.AuthService/ChangePassword"
+	AuthService_ChangeUsername_FullMethodName            = "/dungeongate.auth.v1.AuthService/ChangeUsername"
+	AuthService_MergeAccounts_FullMethodName             = "/dungeongate.auth.v1.AuthService/MergeAccounts"
+	AuthService_SetUserPreference_FullMethodName         = "/dungeongate.auth.v1.AuthService/SetUserPreference"
+	AuthService_SetShowOnlineStatus_FullMethodName       = "/dungeongate.auth.v1.AuthService/SetShowOnlineStatus"
+	AuthService_ResetPassword_FullMethodName             = "/dungeongate.auth.v1.AuthService/ResetPassword"
+	AuthService_VerifyPasswordReset_FullMethodName       = "/dungeongate.auth.v1.AuthService/VerifyPasswordReset"
+	AuthService_GetLoginAttempts_FullMethodName          = "/dungeongate.auth.v1.AuthService/GetLoginAttempts"
+	AuthService_Health_FullMethodName                    = "/dungeongate.auth.v1.AuthService/Health"
+	AuthService_Version_FullMethodName                   = "/dungeongate.auth.v1.AuthService/Version"
+	AuthService_UnlockUserAccount_FullMethodName         = "/dungeongate.auth.v1.AuthService/UnlockUserAccount"
+	AuthService_DeleteUserAccount_FullMethodName         = "/dungeongate.auth.v1.AuthService/DeleteUserAccount"
+	AuthService_ResetUserPassword_FullMethodName         = "/dungeongate.auth.v1.AuthService/ResetUserPassword"
+	AuthService_PromoteUserToAdmin_FullMethodName        = "/dungeongate.auth.v1.AuthService/PromoteUserToAdmin"
+	AuthService_RenameUser_FullMethodName                = "/dungeongate.auth.v1.AuthService/RenameUser"
+	AuthService_GetServerStatistics_FullMethodName       = "/dungeongate.auth.v1.AuthService/GetServerStatistics"
+	AuthService_ImpersonateUser_FullMethodName           = "/dungeongate.auth.v1.AuthService/ImpersonateUser"
+	AuthService_ListAccountAccess_FullMethodName         = "/dungeongate.auth.v1.AuthService/ListAccountAccess"
+	AuthService_CreateGroup_FullMethodName               = "/dungeongate.auth.v1.AuthService/CreateGroup"
+	AuthService_JoinGroup_FullMethodName                 = "/dungeongate.auth.v1.AuthService/JoinGroup"
+	AuthService_LeaveGroup_FullMethodName                = "/dungeongate.auth.v1.AuthService/LeaveGroup"
+	AuthService_GetGroup_FullMethodName                  = "/dungeongate.auth.v1.AuthService/GetGroup"
+	AuthService_ListGroups_FullMethodName                = "/dungeongate.auth.v1.AuthService/ListGroups"
+	AuthService_AddFriend_FullMethodName                 = "/dungeongate.auth.v1.AuthService/AddFriend"
+	AuthService_RemoveFriend_FullMethodName              = "/dungeongate.auth.v1.AuthService/RemoveFriend"
+	AuthService_ListFriends_FullMethodName               = "/dungeongate.auth.v1.AuthService/ListFriends"
+	AuthService_NotifyFriends_FullMethodName             = "/dungeongate.auth.v1.AuthService/NotifyFriends"
+	AuthService_GetTerms_FullMethodName                  = "/dungeongate.auth.v1.AuthService/GetTerms"
+	AuthService_PublishTerms_FullMethodName              = "/dungeongate.auth.v1.AuthService/PublishTerms"
+	AuthService_AcceptTerms_FullMethodName               = "/dungeongate.auth.v1.AuthService/AcceptTerms"
+	AuthService_GetTermsStatus_FullMethodName            = "/dungeongate.auth.v1.AuthService/GetTermsStatus"
+	AuthService_CreateAPIKey_FullMethodName              = "/dungeongate.auth.v1.AuthService/CreateAPIKey"
+	AuthService_ListAPIKeys_FullMethodName               = "/dungeongate.auth.v1.AuthService/ListAPIKeys"
+	AuthService_RevokeAPIKey_FullMethodName              = "/dungeongate.auth.v1.AuthService/RevokeAPIKey"
+	AuthService_ValidateAPIKey_FullMethodName            = "/dungeongate.auth.v1.AuthService/ValidateAPIKey"
+	AuthService_IssueChallenge_FullMethodName            = "/dungeongate.auth.v1.AuthService/IssueChallenge"
+	AuthService_AnswerChallenge_FullMethodName           = "/dungeongate.auth.v1.AuthService/AnswerChallenge"
+	AuthService_ListDevices_FullMethodName               = "/dungeongate.auth.v1.AuthService/ListDevices"
+	AuthService_RevokeDevice_FullMethodName              = "/dungeongate.auth.v1.AuthService/RevokeDevice"
+	AuthService_ListNotifications_FullMethodName         = "/dungeongate.auth.v1.AuthService/ListNotifications"
+	AuthService_MarkNotificationsRead_FullMethodName     = "/dungeongate.auth.v1.AuthService/MarkNotificationsRead"
+	AuthService_SendNotification_FullMethodName          = "/dungeongate.auth.v1.AuthService/SendNotification"
+	AuthService_NotifyUser_FullMethodName                = "/dungeongate.auth.v1.AuthService/NotifyUser"
+	AuthService_AddUserNote_FullMethodName               = "/dungeongate.auth.v1.AuthService/AddUserNote"
+	AuthService_GetUserModeration_FullMethodName         = "/dungeongate.auth.v1.AuthService/GetUserModeration"
+	AuthService_SetUserModerationFlag_FullMethodName     = "/dungeongate.auth.v1.AuthService/SetUserModerationFlag"
+	AuthService_ClearUserModerationFlag_FullMethodName   = "/dungeongate.auth.v1.AuthService/ClearUserModerationFlag"
+	AuthService_ListUsersByModerationFlag_FullMethodName = "/dungeongate.auth.v1.AuthService/ListUsersByModerationFlag"
+	AuthService_GetUsernameHistory_FullMethodName        = "/dungeongate.auth.v1.AuthService/GetUsernameHistory"
+	AuthService_ListBanners_FullMethodName               = "/dungeongate.auth.v1.AuthService/ListBanners"
+	AuthService_GetBanner_FullMethodName                 = "/dungeongate.auth.v1.AuthService/GetBanner"
+	AuthService_UpdateBanner_FullMethodName              = "/dungeongate.auth.v1.AuthService/UpdateBanner"
+	AuthService_ResetBanner_FullMethodName               = "/dungeongate.auth.v1.AuthService/ResetBanner"
+	AuthService_PreviewBanner_FullMethodName             = "/dungeongate.auth.v1.AuthService/PreviewBanner"
+	AuthService_WatchBanners_FullMethodName              = "/dungeongate.auth.v1.AuthService/WatchBanners"
+	AuthService_ReportSessionInstance_FullMethodName     = "/dungeongate.auth.v1.AuthService/ReportSessionInstance"
+	AuthService_ListSessionInstances_FullMethodName      = "/dungeongate.auth.v1.AuthService/ListSessionInstances"
+	AuthService_CountSessionLogins_FullMethodName        = "/dungeongate.auth.v1.AuthService/CountSessionLogins"
+	AuthService_ListUsers_FullMethodName                 = "/dungeongate.auth.v1.AuthService/ListUsers"
+	AuthService_SetAccountsLocked_FullMethodName         = "/dungeongate.auth.v1.AuthService/SetAccountsLocked"
+	AuthService_SetUsersRole_FullMethodName              = "/dungeongate.auth.v1.AuthService/SetUsersRole"
+	AuthService_ExportUsers_FullMethodName               = "/dungeongate.auth.v1.AuthService/ExportUsers"
+	AuthService_ImportUsers_FullMethodName               = "/dungeongate.auth.v1.AuthService/ImportUsers"
+	AuthService_EncryptPersonalData_FullMethodName       = "/dungeongate.auth.v1.AuthService/EncryptPersonalData"
+	AuthService_ListFeatureFlags_FullMethodName          = "/dungeongate.auth.v1.AuthService/ListFeatureFlags"
+	AuthService_SetFeatureFlag_FullMethodName            = "/dungeongate.auth.v1.AuthService/SetFeatureFlag"
+	AuthService_DeleteFeatureFlag_FullMethodName         = "/dungeongate.auth.v1.AuthService/DeleteFeatureFlag"
+	AuthService_GetFeatureFlagOverrides_FullMethodName   = "/dungeongate.auth.v1.AuthService/GetFeatureFlagOverrides"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -58,6 +116,14 @@ type AuthServiceClient interface {
 	GetUserInfo(ctx context.Context, in *GetUserInfoRequest, opts ...grpc.CallOption) (*GetUserInfoResponse, error)
 	// ChangePassword changes a user's password
 	ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error)
+	// ChangeUsername renames the token user's account, when self-service renames are enabled
+	ChangeUsername(ctx context.Context, in *ChangeUsernameRequest, opts ...grpc.CallOption) (*ChangeUsernameResponse, error)
+	// MergeAccounts merges another account the caller owns into the token user's account
+	MergeAccounts(ctx context.Context, in *MergeAccountsRequest, opts ...grpc.CallOption) (*MergeAccountsResponse, error)
+	// SetUserPreference stores one of the user's preferences
+	SetUserPreference(ctx context.Context, in *SetUserPreferenceRequest, opts ...grpc.CallOption) (*SetUserPreferenceResponse, error)
+	// SetShowOnlineStatus sets whether the user appears in the who list and friend alerts
+	SetShowOnlineStatus(ctx context.Context, in *SetShowOnlineStatusRequest, opts ...grpc.CallOption) (*SetUserPreferenceResponse, error)
 	// ResetPassword initiates password reset flow
 	ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error)
 	// VerifyPasswordReset verifies and completes password reset
@@ -66,6 +132,8 @@ type AuthServiceClient interface {
 	GetLoginAttempts(ctx context.Context, in *GetLoginAttemptsRequest, opts ...grpc.CallOption) (*GetLoginAttemptsResponse, error)
 	// Health check
 	Health(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Version reports the build of this service
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
 	// UnlockUserAccount unlocks a user account (admin only)
 	UnlockUserAccount(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
 	// DeleteUserAccount deletes a user account (admin only)
@@ -74,8 +142,115 @@ type AuthServiceClient interface {
 	ResetUserPassword(ctx context.Context, in *ResetPasswordAdminRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
 	// PromoteUserToAdmin promotes a user to admin status (admin only)
 	PromoteUserToAdmin(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// RenameUser renames a user account (admin only)
+	RenameUser(ctx context.Context, in *RenameUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
 	// GetServerStatistics returns server statistics (admin only)
 	GetServerStatistics(ctx context.Context, in *ServerStatsRequest, opts ...grpc.CallOption) (*ServerStatsResponse, error)
+	// ImpersonateUser issues a short-lived token to act as a user for support, recording why (admin only)
+	ImpersonateUser(ctx context.Context, in *ImpersonateUserRequest, opts ...grpc.CallOption) (*ImpersonateUserResponse, error)
+	// ListAccountAccess lists the times support staff used the token user's account
+	ListAccountAccess(ctx context.Context, in *ListAccountAccessRequest, opts ...grpc.CallOption) (*ListAccountAccessResponse, error)
+	// CreateGroup creates a group owned by the token user
+	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	// JoinGroup adds the token user to a group
+	JoinGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	// LeaveGroup removes the token user from their group
+	LeaveGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	// GetGroup returns a group and its roster; without a name, the token user's group
+	GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error)
+	// ListGroups lists every group, largest first
+	ListGroups(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error)
+	// AddFriend puts a user on the token user's friend list
+	AddFriend(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error)
+	// RemoveFriend takes a user off the token user's friend list
+	RemoveFriend(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error)
+	// ListFriends lists the token user's friends
+	ListFriends(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error)
+	// NotifyFriends leaves a notification for the friends of a user who opted in, on behalf of another service
+	NotifyFriends(ctx context.Context, in *NotifyUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// GetTerms returns a published version of the terms of service; no token is needed
+	GetTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*TermsResponse, error)
+	// PublishTerms publishes a new version of the terms that every user must accept (admin only)
+	PublishTerms(ctx context.Context, in *PublishTermsRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// AcceptTerms records that the token user accepted a version of the terms
+	AcceptTerms(ctx context.Context, in *AcceptTermsRequest, opts ...grpc.CallOption) (*TermsStatusResponse, error)
+	// GetTermsStatus reports whether a user accepted the current terms, and when
+	GetTermsStatus(ctx context.Context, in *TermsStatusRequest, opts ...grpc.CallOption) (*TermsStatusResponse, error)
+	// CreateAPIKey creates a scoped API key for the token user; the key is only ever returned here
+	CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists the token user's API keys without the keys themselves
+	ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes one of the token user's API keys
+	RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// ValidateAPIKey returns the user and scopes of an active API key
+	ValidateAPIKey(ctx context.Context, in *ValidateAPIKeyRequest, opts ...grpc.CallOption) (*ValidateAPIKeyResponse, error)
+	// IssueChallenge poses a question for a client that tripped brute force protection
+	IssueChallenge(ctx context.Context, in *IssueChallengeRequest, opts ...grpc.CallOption) (*IssueChallengeResponse, error)
+	// AnswerChallenge checks an answer; a right one returns a token letting a locked out account log in
+	AnswerChallenge(ctx context.Context, in *AnswerChallengeRequest, opts ...grpc.CallOption) (*AnswerChallengeResponse, error)
+	// ListDevices lists the devices the token user has logged in from
+	ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error)
+	// RevokeDevice revokes one of the token user's devices; its tokens stop working
+	RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// ListNotifications lists the token user's notifications and how many are unread
+	ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	// MarkNotificationsRead marks some or all of the token user's notifications as read
+	MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error)
+	// SendNotification leaves a notification for a user, or for every user when none is named (admin only)
+	SendNotification(ctx context.Context, in *SendNotificationRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// NotifyUser leaves a notification for a user on behalf of another service
+	NotifyUser(ctx context.Context, in *NotifyUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// AddUserNote attaches a note to a user account (moderator only)
+	AddUserNote(ctx context.Context, in *AddUserNoteRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// GetUserModeration returns the notes and moderation flags on a user account (moderator only)
+	GetUserModeration(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*GetUserModerationResponse, error)
+	// SetUserModerationFlag sets a moderation flag on a user account (moderator only)
+	SetUserModerationFlag(ctx context.Context, in *ModerationFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// ClearUserModerationFlag clears a moderation flag from a user account (moderator only)
+	ClearUserModerationFlag(ctx context.Context, in *ModerationFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// ListUsersByModerationFlag lists the users with a moderation flag set (moderator only)
+	ListUsersByModerationFlag(ctx context.Context, in *ListUsersByModerationFlagRequest, opts ...grpc.CallOption) (*ListUsersByModerationFlagResponse, error)
+	// GetUsernameHistory lists the past usernames of a user account (moderator only)
+	GetUsernameHistory(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*GetUsernameHistoryResponse, error)
+	// ListBanners lists the banner names and the templates overridden at runtime (admin only)
+	ListBanners(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*ListBannersResponse, error)
+	// GetBanner returns the runtime template of a banner, if any (admin only)
+	GetBanner(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*GetBannerResponse, error)
+	// UpdateBanner validates and stores a banner template and pushes it to session services (admin only)
+	UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// ResetBanner removes a banner's runtime template so session services use their banner file again (admin only)
+	ResetBanner(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// PreviewBanner validates and renders a banner template without storing it (admin only)
+	PreviewBanner(ctx context.Context, in *PreviewBannerRequest, opts ...grpc.CallOption) (*PreviewBannerResponse, error)
+	// WatchBanners streams the current banner templates followed by every change
+	WatchBanners(ctx context.Context, in *WatchBannersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BannerUpdate], error)
+	// ReportSessionInstance records the load of a session service replica in the shared instance registry
+	ReportSessionInstance(ctx context.Context, in *ReportSessionInstanceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// ListSessionInstances lists the session service replicas that reported recently, with cluster totals (admin only)
+	ListSessionInstances(ctx context.Context, in *ListSessionInstancesRequest, opts ...grpc.CallOption) (*ListSessionInstancesResponse, error)
+	// CountSessionLogins records a login starting or ending on a session service replica and returns the user's logins across the replicas
+	CountSessionLogins(ctx context.Context, in *CountSessionLoginsRequest, opts ...grpc.CallOption) (*CountSessionLoginsResponse, error)
+	// ListUsers lists the user accounts matching a filter (admin only)
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
+	// SetAccountsLocked locks or unlocks several user accounts (admin only)
+	SetAccountsLocked(ctx context.Context, in *BulkLockRequest, opts ...grpc.CallOption) (*BulkAdminActionResponse, error)
+	// SetUsersRole grants or revokes a role on several user accounts (admin only)
+	SetUsersRole(ctx context.Context, in *BulkRoleRequest, opts ...grpc.CallOption) (*BulkAdminActionResponse, error)
+	// ExportUsers writes the user accounts matching a filter as CSV (admin only)
+	ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (*ExportUsersResponse, error)
+	// ImportUsers creates user accounts from CSV (admin only)
+	ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersResponse, error)
+	// EncryptPersonalData encrypts the personal data stored unencrypted or
+	// with a key that is no longer primary (admin only)
+	EncryptPersonalData(ctx context.Context, in *EncryptPersonalDataRequest, opts ...grpc.CallOption) (*EncryptPersonalDataResponse, error)
+	// ListFeatureFlags lists the feature flags this auth service evaluates, and which are on for a user (admin only)
+	ListFeatureFlags(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error)
+	// SetFeatureFlag stores a feature flag, replacing any configured flag of its name in every service (admin only)
+	SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// DeleteFeatureFlag removes a stored feature flag so services use their configured flag again (admin only)
+	DeleteFeatureFlag(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error)
+	// GetFeatureFlagOverrides returns the stored feature flags, which services reread periodically
+	GetFeatureFlagOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FeatureFlagOverridesResponse, error)
 }
 
 type authServiceClient struct {
@@ -156,6 +331,46 @@ func (c *authServiceClient) ChangePassword(ctx context.Context, in *ChangePasswo
 	return out, nil
 }
 
+func (c *authServiceClient) ChangeUsername(ctx context.Context, in *ChangeUsernameRequest, opts ...grpc.CallOption) (*ChangeUsernameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChangeUsernameResponse)
+	err := c.cc.Invoke(ctx, AuthService_ChangeUsername_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) MergeAccounts(ctx context.Context, in *MergeAccountsRequest, opts ...grpc.CallOption) (*MergeAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeAccountsResponse)
+	err := c.cc.Invoke(ctx, AuthService_MergeAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetUserPreference(ctx context.Context, in *SetUserPreferenceRequest, opts ...grpc.CallOption) (*SetUserPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserPreferenceResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetUserPreference_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetShowOnlineStatus(ctx context.Context, in *SetShowOnlineStatusRequest, opts ...grpc.CallOption) (*SetUserPreferenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetUserPreferenceResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetShowOnlineStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ResetPasswordResponse)
@@ -196,6 +411,16 @@ func (c *authServiceClient) Health(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *authServiceClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, AuthService_Version_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) UnlockUserAccount(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdminActionResponse)
@@ -236,6 +461,16 @@ func (c *authServiceClient) PromoteUserToAdmin(ctx context.Context, in *AdminAct
 	return out, nil
 }
 
+func (c *authServiceClient) RenameUser(ctx context.Context, in *RenameUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_RenameUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) GetServerStatistics(ctx context.Context, in *ServerStatsRequest, opts ...grpc.CallOption) (*ServerStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ServerStatsResponse)
@@ -246,407 +481,2264 @@ func (c *authServiceClient) GetServerStatistics(ctx context.Context, in *ServerS
 	return out, nil
 }
 
-// AuthServiceServer is the server API for AuthService service.
-// All implementations must embed UnimplementedAuthServiceServer
-// for forward compatibility.
-//
-// AuthService provides authentication and authorization operations
-type AuthServiceServer interface {
-	// Register creates a new user account
-	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
-	// Login authenticates a user and returns tokens
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	// Logout invalidates a user's session
-	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
-	// RefreshToken refreshes an access token using a refresh token
-	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
-	// ValidateToken validates an access token and returns user info
-	ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error)
-	// GetUserInfo gets user information from a valid token
-	GetUserInfo(context.Context, *GetUserInfoRequest) (*GetUserInfoResponse, error)
-	// ChangePassword changes a user's password
-	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
-	// ResetPassword initiates password reset flow
-	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
-	// VerifyPasswordReset verifies and completes password reset
-	VerifyPasswordReset(context.Context, *VerifyPasswordResetRequest) (*VerifyPasswordResetResponse, error)
-	// GetLoginAttempts gets login attempt info for a user
-	GetLoginAttempts(context.Context, *GetLoginAttemptsRequest) (*GetLoginAttemptsResponse, error)
-	// Health check
-	Health(context.Context, *emptypb.Empty) (*HealthResponse, error)
-	// UnlockUserAccount unlocks a user account (admin only)
-	UnlockUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
-	// DeleteUserAccount deletes a user account (admin only)
-	DeleteUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
-	// ResetUserPassword resets a user's password (admin only)
-	ResetUserPassword(context.Context, *ResetPasswordAdminRequest) (*AdminActionResponse, error)
-	// PromoteUserToAdmin promotes a user to admin status (admin only)
-	PromoteUserToAdmin(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
-	// GetServerStatistics returns server statistics (admin only)
-	GetServerStatistics(context.Context, *ServerStatsRequest) (*ServerStatsResponse, error)
-	mustEmbedUnimplementedAuthServiceServer()
+func (c *authServiceClient) ImpersonateUser(ctx context.Context, in *ImpersonateUserRequest, opts ...grpc.CallOption) (*ImpersonateUserResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImpersonateUserResponse)
+	err := c.cc.Invoke(ctx, AuthService_ImpersonateUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedAuthServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedAuthServiceServer struct{}
+func (c *authServiceClient) ListAccountAccess(ctx context.Context, in *ListAccountAccessRequest, opts ...grpc.CallOption) (*ListAccountAccessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAccountAccessResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListAccountAccess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedAuthServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+func (c *authServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+
+func (c *authServiceClient) JoinGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, AuthService_JoinGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+
+func (c *authServiceClient) LeaveGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, AuthService_LeaveGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+
+func (c *authServiceClient) GetGroup(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GroupResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetGroup_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ValidateToken not implemented")
+
+func (c *authServiceClient) ListGroups(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*ListGroupsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGroupsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListGroups_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) GetUserInfo(context.Context, *GetUserInfoRequest) (*GetUserInfoResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
+
+func (c *authServiceClient) AddFriend(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendsResponse)
+	err := c.cc.Invoke(ctx, AuthService_AddFriend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+
+func (c *authServiceClient) RemoveFriend(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendsResponse)
+	err := c.cc.Invoke(ctx, AuthService_RemoveFriend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+
+func (c *authServiceClient) ListFriends(ctx context.Context, in *FriendRequest, opts ...grpc.CallOption) (*FriendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FriendsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListFriends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) VerifyPasswordReset(context.Context, *VerifyPasswordResetRequest) (*VerifyPasswordResetResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method VerifyPasswordReset not implemented")
+
+func (c *authServiceClient) NotifyFriends(ctx context.Context, in *NotifyUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_NotifyFriends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) GetLoginAttempts(context.Context, *GetLoginAttemptsRequest) (*GetLoginAttemptsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetLoginAttempts not implemented")
+
+func (c *authServiceClient) GetTerms(ctx context.Context, in *TermsRequest, opts ...grpc.CallOption) (*TermsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TermsResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) Health(context.Context, *emptypb.Empty) (*HealthResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+
+func (c *authServiceClient) PublishTerms(ctx context.Context, in *PublishTermsRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_PublishTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) UnlockUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UnlockUserAccount not implemented")
+
+func (c *authServiceClient) AcceptTerms(ctx context.Context, in *AcceptTermsRequest, opts ...grpc.CallOption) (*TermsStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TermsStatusResponse)
+	err := c.cc.Invoke(ctx, AuthService_AcceptTerms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) DeleteUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserAccount not implemented")
+
+func (c *authServiceClient) GetTermsStatus(ctx context.Context, in *TermsStatusRequest, opts ...grpc.CallOption) (*TermsStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TermsStatusResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetTermsStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) ResetUserPassword(context.Context, *ResetPasswordAdminRequest) (*AdminActionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ResetUserPassword not implemented")
+
+func (c *authServiceClient) CreateAPIKey(ctx context.Context, in *CreateAPIKeyRequest, opts ...grpc.CallOption) (*CreateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, AuthService_CreateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedAuthServiceServer) PromoteUserToAdmin(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method PromoteUserToAdmin not implemented")
+
+func (c *authServiceClient) ListAPIKeys(ctx context.Context, in *ListAPIKeysRequest, opts ...grpc.CallOption) (*ListAPIKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAPIKeysResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListAPIKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeAPIKey(ctx context.Context, in *RevokeAPIKeyRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ValidateAPIKey(ctx context.Context, in *ValidateAPIKeyRequest, opts ...grpc.CallOption) (*ValidateAPIKeyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ValidateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, AuthService_ValidateAPIKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) IssueChallenge(ctx context.Context, in *IssueChallengeRequest, opts ...grpc.CallOption) (*IssueChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueChallengeResponse)
+	err := c.cc.Invoke(ctx, AuthService_IssueChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AnswerChallenge(ctx context.Context, in *AnswerChallengeRequest, opts ...grpc.CallOption) (*AnswerChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnswerChallengeResponse)
+	err := c.cc.Invoke(ctx, AuthService_AnswerChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListDevices(ctx context.Context, in *ListDevicesRequest, opts ...grpc.CallOption) (*ListDevicesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDevicesResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListDevices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeDevice(ctx context.Context, in *RevokeDeviceRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_RevokeDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListNotifications(ctx context.Context, in *ListNotificationsRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListNotifications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) MarkNotificationsRead(ctx context.Context, in *MarkNotificationsReadRequest, opts ...grpc.CallOption) (*ListNotificationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListNotificationsResponse)
+	err := c.cc.Invoke(ctx, AuthService_MarkNotificationsRead_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SendNotification(ctx context.Context, in *SendNotificationRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_SendNotification_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) NotifyUser(ctx context.Context, in *NotifyUserRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_NotifyUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AddUserNote(ctx context.Context, in *AddUserNoteRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_AddUserNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetUserModeration(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*GetUserModerationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUserModerationResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetUserModeration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetUserModerationFlag(ctx context.Context, in *ModerationFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetUserModerationFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ClearUserModerationFlag(ctx context.Context, in *ModerationFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_ClearUserModerationFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListUsersByModerationFlag(ctx context.Context, in *ListUsersByModerationFlagRequest, opts ...grpc.CallOption) (*ListUsersByModerationFlagResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersByModerationFlagResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListUsersByModerationFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetUsernameHistory(ctx context.Context, in *AdminActionRequest, opts ...grpc.CallOption) (*GetUsernameHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUsernameHistoryResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetUsernameHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListBanners(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*ListBannersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBannersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListBanners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetBanner(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*GetBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBannerResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_UpdateBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResetBanner(ctx context.Context, in *BannerRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_ResetBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) PreviewBanner(ctx context.Context, in *PreviewBannerRequest, opts ...grpc.CallOption) (*PreviewBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewBannerResponse)
+	err := c.cc.Invoke(ctx, AuthService_PreviewBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) WatchBanners(ctx context.Context, in *WatchBannersRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[BannerUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AuthService_ServiceDesc.Streams[0], AuthService_WatchBanners_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchBannersRequest, BannerUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_WatchBannersClient = grpc.ServerStreamingClient[BannerUpdate]
+
+func (c *authServiceClient) ReportSessionInstance(ctx context.Context, in *ReportSessionInstanceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_ReportSessionInstance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListSessionInstances(ctx context.Context, in *ListSessionInstancesRequest, opts ...grpc.CallOption) (*ListSessionInstancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionInstancesResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListSessionInstances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) CountSessionLogins(ctx context.Context, in *CountSessionLoginsRequest, opts ...grpc.CallOption) (*CountSessionLoginsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CountSessionLoginsResponse)
+	err := c.cc.Invoke(ctx, AuthService_CountSessionLogins_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetAccountsLocked(ctx context.Context, in *BulkLockRequest, opts ...grpc.CallOption) (*BulkAdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkAdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetAccountsLocked_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetUsersRole(ctx context.Context, in *BulkRoleRequest, opts ...grpc.CallOption) (*BulkAdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkAdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetUsersRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ExportUsers(ctx context.Context, in *ExportUsersRequest, opts ...grpc.CallOption) (*ExportUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportUsersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ExportUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ImportUsers(ctx context.Context, in *ImportUsersRequest, opts ...grpc.CallOption) (*ImportUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportUsersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ImportUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) EncryptPersonalData(ctx context.Context, in *EncryptPersonalDataRequest, opts ...grpc.CallOption) (*EncryptPersonalDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EncryptPersonalDataResponse)
+	err := c.cc.Invoke(ctx, AuthService_EncryptPersonalData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListFeatureFlags(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*ListFeatureFlagsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListFeatureFlagsResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListFeatureFlags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetFeatureFlag(ctx context.Context, in *SetFeatureFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_SetFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DeleteFeatureFlag(ctx context.Context, in *FeatureFlagRequest, opts ...grpc.CallOption) (*AdminActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdminActionResponse)
+	err := c.cc.Invoke(ctx, AuthService_DeleteFeatureFlag_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetFeatureFlagOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FeatureFlagOverridesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureFlagOverridesResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetFeatureFlagOverrides_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// AuthService provides authentication and authorization operations
+type AuthServiceServer interface {
+	// Register creates a new user account
+	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
+	// Login authenticates a user and returns tokens
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	// Logout invalidates a user's session
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	// RefreshToken refreshes an access token using a refresh token
+	RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error)
+	// ValidateToken validates an access token and returns user info
+	ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error)
+	// GetUserInfo gets user information from a valid token
+	GetUserInfo(context.Context, *GetUserInfoRequest) (*GetUserInfoResponse, error)
+	// ChangePassword changes a user's password
+	ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error)
+	// ChangeUsername renames the token user's account, when self-service renames are enabled
+	ChangeUsername(context.Context, *ChangeUsernameRequest) (*ChangeUsernameResponse, error)
+	// MergeAccounts merges another account the caller owns into the token user's account
+	MergeAccounts(context.Context, *MergeAccountsRequest) (*MergeAccountsResponse, error)
+	// SetUserPreference stores one of the user's preferences
+	SetUserPreference(context.Context, *SetUserPreferenceRequest) (*SetUserPreferenceResponse, error)
+	// SetShowOnlineStatus sets whether the user appears in the who list and friend alerts
+	SetShowOnlineStatus(context.Context, *SetShowOnlineStatusRequest) (*SetUserPreferenceResponse, error)
+	// ResetPassword initiates password reset flow
+	ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error)
+	// VerifyPasswordReset verifies and completes password reset
+	VerifyPasswordReset(context.Context, *VerifyPasswordResetRequest) (*VerifyPasswordResetResponse, error)
+	// GetLoginAttempts gets login attempt info for a user
+	GetLoginAttempts(context.Context, *GetLoginAttemptsRequest) (*GetLoginAttemptsResponse, error)
+	// Health check
+	Health(context.Context, *emptypb.Empty) (*HealthResponse, error)
+	// Version reports the build of this service
+	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
+	// UnlockUserAccount unlocks a user account (admin only)
+	UnlockUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
+	// DeleteUserAccount deletes a user account (admin only)
+	DeleteUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
+	// ResetUserPassword resets a user's password (admin only)
+	ResetUserPassword(context.Context, *ResetPasswordAdminRequest) (*AdminActionResponse, error)
+	// PromoteUserToAdmin promotes a user to admin status (admin only)
+	PromoteUserToAdmin(context.Context, *AdminActionRequest) (*AdminActionResponse, error)
+	// RenameUser renames a user account (admin only)
+	RenameUser(context.Context, *RenameUserRequest) (*AdminActionResponse, error)
+	// GetServerStatistics returns server statistics (admin only)
+	GetServerStatistics(context.Context, *ServerStatsRequest) (*ServerStatsResponse, error)
+	// ImpersonateUser issues a short-lived token to act as a user for support, recording why (admin only)
+	ImpersonateUser(context.Context, *ImpersonateUserRequest) (*ImpersonateUserResponse, error)
+	// ListAccountAccess lists the times support staff used the token user's account
+	ListAccountAccess(context.Context, *ListAccountAccessRequest) (*ListAccountAccessResponse, error)
+	// CreateGroup creates a group owned by the token user
+	CreateGroup(context.Context, *CreateGroupRequest) (*GroupResponse, error)
+	// JoinGroup adds the token user to a group
+	JoinGroup(context.Context, *GroupRequest) (*GroupResponse, error)
+	// LeaveGroup removes the token user from their group
+	LeaveGroup(context.Context, *GroupRequest) (*GroupResponse, error)
+	// GetGroup returns a group and its roster; without a name, the token user's group
+	GetGroup(context.Context, *GroupRequest) (*GroupResponse, error)
+	// ListGroups lists every group, largest first
+	ListGroups(context.Context, *GroupRequest) (*ListGroupsResponse, error)
+	// AddFriend puts a user on the token user's friend list
+	AddFriend(context.Context, *FriendRequest) (*FriendsResponse, error)
+	// RemoveFriend takes a user off the token user's friend list
+	RemoveFriend(context.Context, *FriendRequest) (*FriendsResponse, error)
+	// ListFriends lists the token user's friends
+	ListFriends(context.Context, *FriendRequest) (*FriendsResponse, error)
+	// NotifyFriends leaves a notification for the friends of a user who opted in, on behalf of another service
+	NotifyFriends(context.Context, *NotifyUserRequest) (*AdminActionResponse, error)
+	// GetTerms returns a published version of the terms of service; no token is needed
+	GetTerms(context.Context, *TermsRequest) (*TermsResponse, error)
+	// PublishTerms publishes a new version of the terms that every user must accept (admin only)
+	PublishTerms(context.Context, *PublishTermsRequest) (*AdminActionResponse, error)
+	// AcceptTerms records that the token user accepted a version of the terms
+	AcceptTerms(context.Context, *AcceptTermsRequest) (*TermsStatusResponse, error)
+	// GetTermsStatus reports whether a user accepted the current terms, and when
+	GetTermsStatus(context.Context, *TermsStatusRequest) (*TermsStatusResponse, error)
+	// CreateAPIKey creates a scoped API key for the token user; the key is only ever returned here
+	CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error)
+	// ListAPIKeys lists the token user's API keys without the keys themselves
+	ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error)
+	// RevokeAPIKey revokes one of the token user's API keys
+	RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*AdminActionResponse, error)
+	// ValidateAPIKey returns the user and scopes of an active API key
+	ValidateAPIKey(context.Context, *ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error)
+	// IssueChallenge poses a question for a client that tripped brute force protection
+	IssueChallenge(context.Context, *IssueChallengeRequest) (*IssueChallengeResponse, error)
+	// AnswerChallenge checks an answer; a right one returns a token letting a locked out account log in
+	AnswerChallenge(context.Context, *AnswerChallengeRequest) (*AnswerChallengeResponse, error)
+	// ListDevices lists the devices the token user has logged in from
+	ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error)
+	// RevokeDevice revokes one of the token user's devices; its tokens stop working
+	RevokeDevice(context.Context, *RevokeDeviceRequest) (*AdminActionResponse, error)
+	// ListNotifications lists the token user's notifications and how many are unread
+	ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error)
+	// MarkNotificationsRead marks some or all of the token user's notifications as read
+	MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*ListNotificationsResponse, error)
+	// SendNotification leaves a notification for a user, or for every user when none is named (admin only)
+	SendNotification(context.Context, *SendNotificationRequest) (*AdminActionResponse, error)
+	// NotifyUser leaves a notification for a user on behalf of another service
+	NotifyUser(context.Context, *NotifyUserRequest) (*AdminActionResponse, error)
+	// AddUserNote attaches a note to a user account (moderator only)
+	AddUserNote(context.Context, *AddUserNoteRequest) (*AdminActionResponse, error)
+	// GetUserModeration returns the notes and moderation flags on a user account (moderator only)
+	GetUserModeration(context.Context, *AdminActionRequest) (*GetUserModerationResponse, error)
+	// SetUserModerationFlag sets a moderation flag on a user account (moderator only)
+	SetUserModerationFlag(context.Context, *ModerationFlagRequest) (*AdminActionResponse, error)
+	// ClearUserModerationFlag clears a moderation flag from a user account (moderator only)
+	ClearUserModerationFlag(context.Context, *ModerationFlagRequest) (*AdminActionResponse, error)
+	// ListUsersByModerationFlag lists the users with a moderation flag set (moderator only)
+	ListUsersByModerationFlag(context.Context, *ListUsersByModerationFlagRequest) (*ListUsersByModerationFlagResponse, error)
+	// GetUsernameHistory lists the past usernames of a user account (moderator only)
+	GetUsernameHistory(context.Context, *AdminActionRequest) (*GetUsernameHistoryResponse, error)
+	// ListBanners lists the banner names and the templates overridden at runtime (admin only)
+	ListBanners(context.Context, *BannerRequest) (*ListBannersResponse, error)
+	// GetBanner returns the runtime template of a banner, if any (admin only)
+	GetBanner(context.Context, *BannerRequest) (*GetBannerResponse, error)
+	// UpdateBanner validates and stores a banner template and pushes it to session services (admin only)
+	UpdateBanner(context.Context, *UpdateBannerRequest) (*AdminActionResponse, error)
+	// ResetBanner removes a banner's runtime template so session services use their banner file again (admin only)
+	ResetBanner(context.Context, *BannerRequest) (*AdminActionResponse, error)
+	// PreviewBanner validates and renders a banner template without storing it (admin only)
+	PreviewBanner(context.Context, *PreviewBannerRequest) (*PreviewBannerResponse, error)
+	// WatchBanners streams the current banner templates followed by every change
+	WatchBanners(*WatchBannersRequest, grpc.ServerStreamingServer[BannerUpdate]) error
+	// ReportSessionInstance records the load of a session service replica in the shared instance registry
+	ReportSessionInstance(context.Context, *ReportSessionInstanceRequest) (*emptypb.Empty, error)
+	// ListSessionInstances lists the session service replicas that reported recently, with cluster totals (admin only)
+	ListSessionInstances(context.Context, *ListSessionInstancesRequest) (*ListSessionInstancesResponse, error)
+	// CountSessionLogins records a login starting or ending on a session service replica and returns the user's logins across the replicas
+	CountSessionLogins(context.Context, *CountSessionLoginsRequest) (*CountSessionLoginsResponse, error)
+	// ListUsers lists the user accounts matching a filter (admin only)
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
+	// SetAccountsLocked locks or unlocks several user accounts (admin only)
+	SetAccountsLocked(context.Context, *BulkLockRequest) (*BulkAdminActionResponse, error)
+	// SetUsersRole grants or revokes a role on several user accounts (admin only)
+	SetUsersRole(context.Context, *BulkRoleRequest) (*BulkAdminActionResponse, error)
+	// ExportUsers writes the user accounts matching a filter as CSV (admin only)
+	ExportUsers(context.Context, *ExportUsersRequest) (*ExportUsersResponse, error)
+	// ImportUsers creates user accounts from CSV (admin only)
+	ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersResponse, error)
+	// EncryptPersonalData encrypts the personal data stored unencrypted or
+	// with a key that is no longer primary (admin only)
+	EncryptPersonalData(context.Context, *EncryptPersonalDataRequest) (*EncryptPersonalDataResponse, error)
+	// ListFeatureFlags lists the feature flags this auth service evaluates, and which are on for a user (admin only)
+	ListFeatureFlags(context.Context, *FeatureFlagRequest) (*ListFeatureFlagsResponse, error)
+	// SetFeatureFlag stores a feature flag, replacing any configured flag of its name in every service (admin only)
+	SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*AdminActionResponse, error)
+	// DeleteFeatureFlag removes a stored feature flag so services use their configured flag again (admin only)
+	DeleteFeatureFlag(context.Context, *FeatureFlagRequest) (*AdminActionResponse, error)
+	// GetFeatureFlagOverrides returns the stored feature flags, which services reread periodically
+	GetFeatureFlagOverrides(context.Context, *emptypb.Empty) (*FeatureFlagOverridesResponse, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAuthServiceServer struct{}
+
+func (UnimplementedAuthServiceServer) Register(context.Context, *RegisterRequest) (*RegisterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
+}
+func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedAuthServiceServer) RefreshToken(context.Context, *RefreshTokenRequest) (*RefreshTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshToken not implemented")
+}
+func (UnimplementedAuthServiceServer) ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateToken not implemented")
+}
+func (UnimplementedAuthServiceServer) GetUserInfo(context.Context, *GetUserInfoRequest) (*GetUserInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserInfo not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangePassword(context.Context, *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangePassword not implemented")
+}
+func (UnimplementedAuthServiceServer) ChangeUsername(context.Context, *ChangeUsernameRequest) (*ChangeUsernameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeUsername not implemented")
+}
+func (UnimplementedAuthServiceServer) MergeAccounts(context.Context, *MergeAccountsRequest) (*MergeAccountsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeAccounts not implemented")
+}
+func (UnimplementedAuthServiceServer) SetUserPreference(context.Context, *SetUserPreferenceRequest) (*SetUserPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserPreference not implemented")
+}
+func (UnimplementedAuthServiceServer) SetShowOnlineStatus(context.Context, *SetShowOnlineStatusRequest) (*SetUserPreferenceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetShowOnlineStatus not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyPasswordReset(context.Context, *VerifyPasswordResetRequest) (*VerifyPasswordResetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyPasswordReset not implemented")
+}
+func (UnimplementedAuthServiceServer) GetLoginAttempts(context.Context, *GetLoginAttemptsRequest) (*GetLoginAttemptsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLoginAttempts not implemented")
+}
+func (UnimplementedAuthServiceServer) Health(context.Context, *emptypb.Empty) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedAuthServiceServer) Version(context.Context, *emptypb.Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
+func (UnimplementedAuthServiceServer) UnlockUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnlockUserAccount not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteUserAccount(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUserAccount not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetUserPassword(context.Context, *ResetPasswordAdminRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetUserPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) PromoteUserToAdmin(context.Context, *AdminActionRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteUserToAdmin not implemented")
+}
+func (UnimplementedAuthServiceServer) RenameUser(context.Context, *RenameUserRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenameUser not implemented")
+}
+func (UnimplementedAuthServiceServer) GetServerStatistics(context.Context, *ServerStatsRequest) (*ServerStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServerStatistics not implemented")
+}
+func (UnimplementedAuthServiceServer) ImpersonateUser(context.Context, *ImpersonateUserRequest) (*ImpersonateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImpersonateUser not implemented")
+}
+func (UnimplementedAuthServiceServer) ListAccountAccess(context.Context, *ListAccountAccessRequest) (*ListAccountAccessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAccountAccess not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGroup not implemented")
+}
+func (UnimplementedAuthServiceServer) JoinGroup(context.Context, *GroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinGroup not implemented")
+}
+func (UnimplementedAuthServiceServer) LeaveGroup(context.Context, *GroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveGroup not implemented")
+}
+func (UnimplementedAuthServiceServer) GetGroup(context.Context, *GroupRequest) (*GroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGroup not implemented")
+}
+func (UnimplementedAuthServiceServer) ListGroups(context.Context, *GroupRequest) (*ListGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGroups not implemented")
+}
+func (UnimplementedAuthServiceServer) AddFriend(context.Context, *FriendRequest) (*FriendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFriend not implemented")
+}
+func (UnimplementedAuthServiceServer) RemoveFriend(context.Context, *FriendRequest) (*FriendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFriend not implemented")
+}
+func (UnimplementedAuthServiceServer) ListFriends(context.Context, *FriendRequest) (*FriendsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFriends not implemented")
+}
+func (UnimplementedAuthServiceServer) NotifyFriends(context.Context, *NotifyUserRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyFriends not implemented")
+}
+func (UnimplementedAuthServiceServer) GetTerms(context.Context, *TermsRequest) (*TermsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTerms not implemented")
+}
+func (UnimplementedAuthServiceServer) PublishTerms(context.Context, *PublishTermsRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishTerms not implemented")
+}
+func (UnimplementedAuthServiceServer) AcceptTerms(context.Context, *AcceptTermsRequest) (*TermsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptTerms not implemented")
+}
+func (UnimplementedAuthServiceServer) GetTermsStatus(context.Context, *TermsStatusRequest) (*TermsStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTermsStatus not implemented")
+}
+func (UnimplementedAuthServiceServer) CreateAPIKey(context.Context, *CreateAPIKeyRequest) (*CreateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) ListAPIKeys(context.Context, *ListAPIKeysRequest) (*ListAPIKeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeys not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeAPIKey(context.Context, *RevokeAPIKeyRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) ValidateAPIKey(context.Context, *ValidateAPIKeyRequest) (*ValidateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateAPIKey not implemented")
+}
+func (UnimplementedAuthServiceServer) IssueChallenge(context.Context, *IssueChallengeRequest) (*IssueChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IssueChallenge not implemented")
+}
+func (UnimplementedAuthServiceServer) AnswerChallenge(context.Context, *AnswerChallengeRequest) (*AnswerChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerChallenge not implemented")
+}
+func (UnimplementedAuthServiceServer) ListDevices(context.Context, *ListDevicesRequest) (*ListDevicesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDevices not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeDevice(context.Context, *RevokeDeviceRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeDevice not implemented")
+}
+func (UnimplementedAuthServiceServer) ListNotifications(context.Context, *ListNotificationsRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNotifications not implemented")
+}
+func (UnimplementedAuthServiceServer) MarkNotificationsRead(context.Context, *MarkNotificationsReadRequest) (*ListNotificationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MarkNotificationsRead not implemented")
+}
+func (UnimplementedAuthServiceServer) SendNotification(context.Context, *SendNotificationRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendNotification not implemented")
+}
+func (UnimplementedAuthServiceServer) NotifyUser(context.Context, *NotifyUserRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NotifyUser not implemented")
+}
+func (UnimplementedAuthServiceServer) AddUserNote(context.Context, *AddUserNoteRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddUserNote not implemented")
+}
+func (UnimplementedAuthServiceServer) GetUserModeration(context.Context, *AdminActionRequest) (*GetUserModerationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserModeration not implemented")
+}
+func (UnimplementedAuthServiceServer) SetUserModerationFlag(context.Context, *ModerationFlagRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserModerationFlag not implemented")
+}
+func (UnimplementedAuthServiceServer) ClearUserModerationFlag(context.Context, *ModerationFlagRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClearUserModerationFlag not implemented")
+}
+func (UnimplementedAuthServiceServer) ListUsersByModerationFlag(context.Context, *ListUsersByModerationFlagRequest) (*ListUsersByModerationFlagResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsersByModerationFlag not implemented")
+}
+func (UnimplementedAuthServiceServer) GetUsernameHistory(context.Context, *AdminActionRequest) (*GetUsernameHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsernameHistory not implemented")
+}
+func (UnimplementedAuthServiceServer) ListBanners(context.Context, *BannerRequest) (*ListBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanners not implemented")
+}
+func (UnimplementedAuthServiceServer) GetBanner(context.Context, *BannerRequest) (*GetBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBanner not implemented")
+}
+func (UnimplementedAuthServiceServer) UpdateBanner(context.Context, *UpdateBannerRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBanner not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetBanner(context.Context, *BannerRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetBanner not implemented")
+}
+func (UnimplementedAuthServiceServer) PreviewBanner(context.Context, *PreviewBannerRequest) (*PreviewBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewBanner not implemented")
+}
+func (UnimplementedAuthServiceServer) WatchBanners(*WatchBannersRequest, grpc.ServerStreamingServer[BannerUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchBanners not implemented")
+}
+func (UnimplementedAuthServiceServer) ReportSessionInstance(context.Context, *ReportSessionInstanceRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReportSessionInstance not implemented")
+}
+func (UnimplementedAuthServiceServer) ListSessionInstances(context.Context, *ListSessionInstancesRequest) (*ListSessionInstancesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionInstances not implemented")
+}
+func (UnimplementedAuthServiceServer) CountSessionLogins(context.Context, *CountSessionLoginsRequest) (*CountSessionLoginsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CountSessionLogins not implemented")
+}
+func (UnimplementedAuthServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAuthServiceServer) SetAccountsLocked(context.Context, *BulkLockRequest) (*BulkAdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAccountsLocked not implemented")
+}
+func (UnimplementedAuthServiceServer) SetUsersRole(context.Context, *BulkRoleRequest) (*BulkAdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUsersRole not implemented")
+}
+func (UnimplementedAuthServiceServer) ExportUsers(context.Context, *ExportUsersRequest) (*ExportUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUsers not implemented")
+}
+func (UnimplementedAuthServiceServer) ImportUsers(context.Context, *ImportUsersRequest) (*ImportUsersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportUsers not implemented")
+}
+func (UnimplementedAuthServiceServer) EncryptPersonalData(context.Context, *EncryptPersonalDataRequest) (*EncryptPersonalDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EncryptPersonalData not implemented")
+}
+func (UnimplementedAuthServiceServer) ListFeatureFlags(context.Context, *FeatureFlagRequest) (*ListFeatureFlagsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListFeatureFlags not implemented")
+}
+func (UnimplementedAuthServiceServer) SetFeatureFlag(context.Context, *SetFeatureFlagRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFeatureFlag not implemented")
+}
+func (UnimplementedAuthServiceServer) DeleteFeatureFlag(context.Context, *FeatureFlagRequest) (*AdminActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFeatureFlag not implemented")
+}
+func (UnimplementedAuthServiceServer) GetFeatureFlagOverrides(context.Context, *emptypb.Empty) (*FeatureFlagOverridesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeatureFlagOverrides not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
+// result in compilation errors.
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AuthService_ServiceDesc, srv)
+}
+
+func _AuthService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Register(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Register_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Register(ctx, req.(*RegisterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Login(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Login_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Logout_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RefreshToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RefreshToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ValidateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ValidateToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ValidateToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ValidateToken(ctx, req.(*ValidateTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetUserInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetUserInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetUserInfo(ctx, req.(*GetUserInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangePassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangePassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ChangeUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangeUsernameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ChangeUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ChangeUsername_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ChangeUsername(ctx, req.(*ChangeUsernameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_MergeAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).MergeAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_MergeAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).MergeAccounts(ctx, req.(*MergeAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetUserPreference_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserPreferenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetUserPreference(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetUserPreference_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetUserPreference(ctx, req.(*SetUserPreferenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetShowOnlineStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetShowOnlineStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetShowOnlineStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetShowOnlineStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetShowOnlineStatus(ctx, req.(*SetShowOnlineStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyPasswordResetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyPasswordReset(ctx, req.(*VerifyPasswordResetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetLoginAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLoginAttemptsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetLoginAttempts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetLoginAttempts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetLoginAttempts(ctx, req.(*GetLoginAttemptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Health(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_UnlockUserAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).UnlockUserAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_UnlockUserAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).UnlockUserAccount(ctx, req.(*AdminActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DeleteUserAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DeleteUserAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DeleteUserAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DeleteUserAccount(ctx, req.(*AdminActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResetUserPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordAdminRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetUserPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetUserPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetUserPassword(ctx, req.(*ResetPasswordAdminRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_PromoteUserToAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).PromoteUserToAdmin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_PromoteUserToAdmin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).PromoteUserToAdmin(ctx, req.(*AdminActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RenameUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenameUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RenameUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RenameUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RenameUser(ctx, req.(*RenameUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetServerStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetServerStatistics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetServerStatistics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetServerStatistics(ctx, req.(*ServerStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ImpersonateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImpersonateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ImpersonateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ImpersonateUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ImpersonateUser(ctx, req.(*ImpersonateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListAccountAccess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAccountAccessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAccountAccess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListAccountAccess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAccountAccess(ctx, req.(*ListAccountAccessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateGroup(ctx, req.(*CreateGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_JoinGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).JoinGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_JoinGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).JoinGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_LeaveGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).LeaveGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_LeaveGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).LeaveGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetGroup(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListGroups(ctx, req.(*GroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddFriend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FriendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddFriend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AddFriend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddFriend(ctx, req.(*FriendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RemoveFriend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FriendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RemoveFriend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RemoveFriend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RemoveFriend(ctx, req.(*FriendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FriendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListFriends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListFriends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListFriends(ctx, req.(*FriendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_NotifyFriends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).NotifyFriends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_NotifyFriends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).NotifyFriends(ctx, req.(*NotifyUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetTerms(ctx, req.(*TermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_PublishTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).PublishTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_PublishTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).PublishTerms(ctx, req.(*PublishTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AcceptTerms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptTermsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AcceptTerms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AcceptTerms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AcceptTerms(ctx, req.(*AcceptTermsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetTermsStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TermsStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetTermsStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetTermsStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetTermsStatus(ctx, req.(*TermsStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_CreateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_CreateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).CreateAPIKey(ctx, req.(*CreateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListAPIKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListAPIKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListAPIKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListAPIKeys(ctx, req.(*ListAPIKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeAPIKey(ctx, req.(*RevokeAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ValidateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ValidateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ValidateAPIKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ValidateAPIKey(ctx, req.(*ValidateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_IssueChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).IssueChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_IssueChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).IssueChallenge(ctx, req.(*IssueChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AnswerChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AnswerChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AnswerChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AnswerChallenge(ctx, req.(*AnswerChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListDevices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDevicesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListDevices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListDevices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListDevices(ctx, req.(*ListDevicesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RevokeDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeDevice(ctx, req.(*RevokeDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListNotifications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNotificationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListNotifications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListNotifications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListNotifications(ctx, req.(*ListNotificationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_MarkNotificationsRead_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MarkNotificationsReadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).MarkNotificationsRead(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_MarkNotificationsRead_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).MarkNotificationsRead(ctx, req.(*MarkNotificationsReadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SendNotification_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendNotificationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SendNotification(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SendNotification_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SendNotification(ctx, req.(*SendNotificationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_NotifyUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NotifyUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).NotifyUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_NotifyUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).NotifyUser(ctx, req.(*NotifyUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AddUserNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddUserNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AddUserNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AddUserNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AddUserNote(ctx, req.(*AddUserNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetUserModeration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetUserModeration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetUserModeration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetUserModeration(ctx, req.(*AdminActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetUserModerationFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetUserModerationFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetUserModerationFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetUserModerationFlag(ctx, req.(*ModerationFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ClearUserModerationFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModerationFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ClearUserModerationFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ClearUserModerationFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ClearUserModerationFlag(ctx, req.(*ModerationFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListUsersByModerationFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersByModerationFlagRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListUsersByModerationFlag(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListUsersByModerationFlag_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListUsersByModerationFlag(ctx, req.(*ListUsersByModerationFlagRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthServiceServer) GetServerStatistics(context.Context, *ServerStatsRequest) (*ServerStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetServerStatistics not implemented")
+
+func _AuthService_GetUsernameHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdminActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetUsernameHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetUsernameHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetUsernameHistory(ctx, req.(*AdminActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
-func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to AuthServiceServer will
-// result in compilation errors.
-type UnsafeAuthServiceServer interface {
-	mustEmbedUnimplementedAuthServiceServer()
+func _AuthService_ListBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListBanners(ctx, req.(*BannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
-	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _AuthService_GetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&AuthService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetBanner(ctx, req.(*BannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RegisterRequest)
+func _AuthService_UpdateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBannerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Register(ctx, in)
+		return srv.(AuthServiceServer).UpdateBanner(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Register_FullMethodName,
+		FullMethod: AuthService_UpdateBanner_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Register(ctx, req.(*RegisterRequest))
+		return srv.(AuthServiceServer).UpdateBanner(ctx, req.(*UpdateBannerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
+func _AuthService_ResetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BannerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Login(ctx, in)
+		return srv.(AuthServiceServer).ResetBanner(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Login_FullMethodName,
+		FullMethod: AuthService_ResetBanner_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Login(ctx, req.(*LoginRequest))
+		return srv.(AuthServiceServer).ResetBanner(ctx, req.(*BannerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LogoutRequest)
+func _AuthService_PreviewBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewBannerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Logout(ctx, in)
+		return srv.(AuthServiceServer).PreviewBanner(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Logout_FullMethodName,
+		FullMethod: AuthService_PreviewBanner_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Logout(ctx, req.(*LogoutRequest))
+		return srv.(AuthServiceServer).PreviewBanner(ctx, req.(*PreviewBannerRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_RefreshToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RefreshTokenRequest)
+func _AuthService_WatchBanners_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchBannersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AuthServiceServer).WatchBanners(m, &grpc.GenericServerStream[WatchBannersRequest, BannerUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AuthService_WatchBannersServer = grpc.ServerStreamingServer[BannerUpdate]
+
+func _AuthService_ReportSessionInstance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReportSessionInstanceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).RefreshToken(ctx, in)
+		return srv.(AuthServiceServer).ReportSessionInstance(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_RefreshToken_FullMethodName,
+		FullMethod: AuthService_ReportSessionInstance_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).RefreshToken(ctx, req.(*RefreshTokenRequest))
+		return srv.(AuthServiceServer).ReportSessionInstance(ctx, req.(*ReportSessionInstanceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ValidateToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ValidateTokenRequest)
+func _AuthService_ListSessionInstances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionInstancesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ValidateToken(ctx, in)
+		return srv.(AuthServiceServer).ListSessionInstances(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ValidateToken_FullMethodName,
+		FullMethod: AuthService_ListSessionInstances_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ValidateToken(ctx, req.(*ValidateTokenRequest))
+		return srv.(AuthServiceServer).ListSessionInstances(ctx, req.(*ListSessionInstancesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_GetUserInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetUserInfoRequest)
+func _AuthService_CountSessionLogins_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CountSessionLoginsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetUserInfo(ctx, in)
+		return srv.(AuthServiceServer).CountSessionLogins(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetUserInfo_FullMethodName,
+		FullMethod: AuthService_CountSessionLogins_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetUserInfo(ctx, req.(*GetUserInfoRequest))
+		return srv.(AuthServiceServer).CountSessionLogins(ctx, req.(*CountSessionLoginsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ChangePassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePasswordRequest)
+func _AuthService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ChangePassword(ctx, in)
+		return srv.(AuthServiceServer).ListUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ChangePassword_FullMethodName,
+		FullMethod: AuthService_ListUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ChangePassword(ctx, req.(*ChangePasswordRequest))
+		return srv.(AuthServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResetPasswordRequest)
+func _AuthService_SetAccountsLocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkLockRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ResetPassword(ctx, in)
+		return srv.(AuthServiceServer).SetAccountsLocked(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ResetPassword_FullMethodName,
+		FullMethod: AuthService_SetAccountsLocked_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ResetPassword(ctx, req.(*ResetPasswordRequest))
+		return srv.(AuthServiceServer).SetAccountsLocked(ctx, req.(*BulkLockRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_VerifyPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(VerifyPasswordResetRequest)
+func _AuthService_SetUsersRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkRoleRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).VerifyPasswordReset(ctx, in)
+		return srv.(AuthServiceServer).SetUsersRole(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_VerifyPasswordReset_FullMethodName,
+		FullMethod: AuthService_SetUsersRole_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).VerifyPasswordReset(ctx, req.(*VerifyPasswordResetRequest))
+		return srv.(AuthServiceServer).SetUsersRole(ctx, req.(*BulkRoleRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_GetLoginAttempts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetLoginAttemptsRequest)
+func _AuthService_ExportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetLoginAttempts(ctx, in)
+		return srv.(AuthServiceServer).ExportUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetLoginAttempts_FullMethodName,
+		FullMethod: AuthService_ExportUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetLoginAttempts(ctx, req.(*GetLoginAttemptsRequest))
+		return srv.(AuthServiceServer).ExportUsers(ctx, req.(*ExportUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _AuthService_ImportUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportUsersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).Health(ctx, in)
+		return srv.(AuthServiceServer).ImportUsers(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_Health_FullMethodName,
+		FullMethod: AuthService_ImportUsers_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).Health(ctx, req.(*emptypb.Empty))
+		return srv.(AuthServiceServer).ImportUsers(ctx, req.(*ImportUsersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_UnlockUserAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdminActionRequest)
+func _AuthService_EncryptPersonalData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EncryptPersonalDataRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).UnlockUserAccount(ctx, in)
+		return srv.(AuthServiceServer).EncryptPersonalData(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_UnlockUserAccount_FullMethodName,
+		FullMethod: AuthService_EncryptPersonalData_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).UnlockUserAccount(ctx, req.(*AdminActionRequest))
+		return srv.(AuthServiceServer).EncryptPersonalData(ctx, req.(*EncryptPersonalDataRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_DeleteUserAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdminActionRequest)
+func _AuthService_ListFeatureFlags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeatureFlagRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).DeleteUserAccount(ctx, in)
+		return srv.(AuthServiceServer).ListFeatureFlags(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_DeleteUserAccount_FullMethodName,
+		FullMethod: AuthService_ListFeatureFlags_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).DeleteUserAccount(ctx, req.(*AdminActionRequest))
+		return srv.(AuthServiceServer).ListFeatureFlags(ctx, req.(*FeatureFlagRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_ResetUserPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ResetPasswordAdminRequest)
+func _AuthService_SetFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetFeatureFlagRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).ResetUserPassword(ctx, in)
+		return srv.(AuthServiceServer).SetFeatureFlag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_ResetUserPassword_FullMethodName,
+		FullMethod: AuthService_SetFeatureFlag_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).ResetUserPassword(ctx, req.(*ResetPasswordAdminRequest))
+		return srv.(AuthServiceServer).SetFeatureFlag(ctx, req.(*SetFeatureFlagRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_PromoteUserToAdmin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(AdminActionRequest)
+func _AuthService_DeleteFeatureFlag_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeatureFlagRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).PromoteUserToAdmin(ctx, in)
+		return srv.(AuthServiceServer).DeleteFeatureFlag(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_PromoteUserToAdmin_FullMethodName,
+		FullMethod: AuthService_DeleteFeatureFlag_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).PromoteUserToAdmin(ctx, req.(*AdminActionRequest))
+		return srv.(AuthServiceServer).DeleteFeatureFlag(ctx, req.(*FeatureFlagRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _AuthService_GetServerStatistics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServerStatsRequest)
+func _AuthService_GetFeatureFlagOverrides_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(AuthServiceServer).GetServerStatistics(ctx, in)
+		return srv.(AuthServiceServer).GetFeatureFlagOverrides(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: AuthService_GetServerStatistics_FullMethodName,
+		FullMethod: AuthService_GetFeatureFlagOverrides_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(AuthServiceServer).GetServerStatistics(ctx, req.(*ServerStatsRequest))
+		return srv.(AuthServiceServer).GetFeatureFlagOverrides(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -686,6 +2778,22 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePassword",
 			Handler:    _AuthService_ChangePassword_Handler,
 		},
+		{
+			MethodName: "ChangeUsername",
+			Handler:    _AuthService_ChangeUsername_Handler,
+		},
+		{
+			MethodName: "MergeAccounts",
+			Handler:    _AuthService_MergeAccounts_Handler,
+		},
+		{
+			MethodName: "SetUserPreference",
+			Handler:    _AuthService_SetUserPreference_Handler,
+		},
+		{
+			MethodName: "SetShowOnlineStatus",
+			Handler:    _AuthService_SetShowOnlineStatus_Handler,
+		},
 		{
 			MethodName: "ResetPassword",
 			Handler:    _AuthService_ResetPassword_Handler,
@@ -702,6 +2810,10 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Health",
 			Handler:    _AuthService_Health_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _AuthService_Version_Handler,
+		},
 		{
 			MethodName: "UnlockUserAccount",
 			Handler:    _AuthService_UnlockUserAccount_Handler,
@@ -718,11 +2830,225 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PromoteUserToAdmin",
 			Handler:    _AuthService_PromoteUserToAdmin_Handler,
 		},
+		{
+			MethodName: "RenameUser",
+			Handler:    _AuthService_RenameUser_Handler,
+		},
 		{
 			MethodName: "GetServerStatistics",
 			Handler:    _AuthService_GetServerStatistics_Handler,
 		},
+		{
+			MethodName: "ImpersonateUser",
+			Handler:    _AuthService_ImpersonateUser_Handler,
+		},
+		{
+			MethodName: "ListAccountAccess",
+			Handler:    _AuthService_ListAccountAccess_Handler,
+		},
+		{
+			MethodName: "CreateGroup",
+			Handler:    _AuthService_CreateGroup_Handler,
+		},
+		{
+			MethodName: "JoinGroup",
+			Handler:    _AuthService_JoinGroup_Handler,
+		},
+		{
+			MethodName: "LeaveGroup",
+			Handler:    _AuthService_LeaveGroup_Handler,
+		},
+		{
+			MethodName: "GetGroup",
+			Handler:    _AuthService_GetGroup_Handler,
+		},
+		{
+			MethodName: "ListGroups",
+			Handler:    _AuthService_ListGroups_Handler,
+		},
+		{
+			MethodName: "AddFriend",
+			Handler:    _AuthService_AddFriend_Handler,
+		},
+		{
+			MethodName: "RemoveFriend",
+			Handler:    _AuthService_RemoveFriend_Handler,
+		},
+		{
+			MethodName: "ListFriends",
+			Handler:    _AuthService_ListFriends_Handler,
+		},
+		{
+			MethodName: "NotifyFriends",
+			Handler:    _AuthService_NotifyFriends_Handler,
+		},
+		{
+			MethodName: "GetTerms",
+			Handler:    _AuthService_GetTerms_Handler,
+		},
+		{
+			MethodName: "PublishTerms",
+			Handler:    _AuthService_PublishTerms_Handler,
+		},
+		{
+			MethodName: "AcceptTerms",
+			Handler:    _AuthService_AcceptTerms_Handler,
+		},
+		{
+			MethodName: "GetTermsStatus",
+			Handler:    _AuthService_GetTermsStatus_Handler,
+		},
+		{
+			MethodName: "CreateAPIKey",
+			Handler:    _AuthService_CreateAPIKey_Handler,
+		},
+		{
+			MethodName: "ListAPIKeys",
+			Handler:    _AuthService_ListAPIKeys_Handler,
+		},
+		{
+			MethodName: "RevokeAPIKey",
+			Handler:    _AuthService_RevokeAPIKey_Handler,
+		},
+		{
+			MethodName: "ValidateAPIKey",
+			Handler:    _AuthService_ValidateAPIKey_Handler,
+		},
+		{
+			MethodName: "IssueChallenge",
+			Handler:    _AuthService_IssueChallenge_Handler,
+		},
+		{
+			MethodName: "AnswerChallenge",
+			Handler:    _AuthService_AnswerChallenge_Handler,
+		},
+		{
+			MethodName: "ListDevices",
+			Handler:    _AuthService_ListDevices_Handler,
+		},
+		{
+			MethodName: "RevokeDevice",
+			Handler:    _AuthService_RevokeDevice_Handler,
+		},
+		{
+			MethodName: "ListNotifications",
+			Handler:    _AuthService_ListNotifications_Handler,
+		},
+		{
+			MethodName: "MarkNotificationsRead",
+			Handler:    _AuthService_MarkNotificationsRead_Handler,
+		},
+		{
+			MethodName: "SendNotification",
+			Handler:    _AuthService_SendNotification_Handler,
+		},
+		{
+			MethodName: "NotifyUser",
+			Handler:    _AuthService_NotifyUser_Handler,
+		},
+		{
+			MethodName: "AddUserNote",
+			Handler:    _AuthService_AddUserNote_Handler,
+		},
+		{
+			MethodName: "GetUserModeration",
+			Handler:    _AuthService_GetUserModeration_Handler,
+		},
+		{
+			MethodName: "SetUserModerationFlag",
+			Handler:    _AuthService_SetUserModerationFlag_Handler,
+		},
+		{
+			MethodName: "ClearUserModerationFlag",
+			Handler:    _AuthService_ClearUserModerationFlag_Handler,
+		},
+		{
+			MethodName: "ListUsersByModerationFlag",
+			Handler:    _AuthService_ListUsersByModerationFlag_Handler,
+		},
+		{
+			MethodName: "GetUsernameHistory",
+			Handler:    _AuthService_GetUsernameHistory_Handler,
+		},
+		{
+			MethodName: "ListBanners",
+			Handler:    _AuthService_ListBanners_Handler,
+		},
+		{
+			MethodName: "GetBanner",
+			Handler:    _AuthService_GetBanner_Handler,
+		},
+		{
+			MethodName: "UpdateBanner",
+			Handler:    _AuthService_UpdateBanner_Handler,
+		},
+		{
+			MethodName: "ResetBanner",
+			Handler:    _AuthService_ResetBanner_Handler,
+		},
+		{
+			MethodName: "PreviewBanner",
+			Handler:    _AuthService_PreviewBanner_Handler,
+		},
+		{
+			MethodName: "ReportSessionInstance",
+			Handler:    _AuthService_ReportSessionInstance_Handler,
+		},
+		{
+			MethodName: "ListSessionInstances",
+			Handler:    _AuthService_ListSessionInstances_Handler,
+		},
+		{
+			MethodName: "CountSessionLogins",
+			Handler:    _AuthService_CountSessionLogins_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _AuthService_ListUsers_Handler,
+		},
+		{
+			MethodName: "SetAccountsLocked",
+			Handler:    _AuthService_SetAccountsLocked_Handler,
+		},
+		{
+			MethodName: "SetUsersRole",
+			Handler:    _AuthService_SetUsersRole_Handler,
+		},
+		{
+			MethodName: "ExportUsers",
+			Handler:    _AuthService_ExportUsers_Handler,
+		},
+		{
+			MethodName: "ImportUsers",
+			Handler:    _AuthService_ImportUsers_Handler,
+		},
+		{
+			MethodName: "EncryptPersonalData",
+			Handler:    _AuthService_EncryptPersonalData_Handler,
+		},
+		{
+			MethodName: "ListFeatureFlags",
+			Handler:    _AuthService_ListFeatureFlags_Handler,
+		},
+		{
+			MethodName: "SetFeatureFlag",
+			Handler:    _AuthService_SetFeatureFlag_Handler,
+		},
+		{
+			MethodName: "DeleteFeatureFlag",
+			Handler:    _AuthService_DeleteFeatureFlag_Handler,
+		},
+		{
+			MethodName: "GetFeatureFlagOverrides",
+			Handler:    _AuthService_GetFeatureFlagOverrides_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchBanners",
+			Handler:       _AuthService_WatchBanners_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "auth/auth_service.proto",
 }
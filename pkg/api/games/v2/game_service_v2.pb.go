@@ -23,6 +23,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// GameSource is where a game's definition comes from
+type GameSource int32
+
+const (
+	GameSource_GAME_SOURCE_UNSPECIFIED GameSource = 0
+	GameSource_GAME_SOURCE_CONFIG      GameSource = 1 // The games list of the configuration file
+	GameSource_GAME_SOURCE_DATABASE    GameSource = 2 // Created or overridden through the API
+)
+
+// Enum value maps for GameSource.
+var (
+	GameSource_name = map[int32]string{
+		0: "GAME_SOURCE_UNSPECIFIED",
+		1: "GAME_SOURCE_CONFIG",
+		2: "GAME_SOURCE_DATABASE",
+	}
+	GameSource_value = map[string]int32{
+		"GAME_SOURCE_UNSPECIFIED": 0,
+		"GAME_SOURCE_CONFIG":      1,
+		"GAME_SOURCE_DATABASE":    2,
+	}
+)
+
+func (x GameSource) Enum() *GameSource {
+	p := new(GameSource)
+	*p = x
+	return p
+}
+
+func (x GameSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_games_game_service_v2_proto_enumTypes[0].Descriptor()
+}
+
+func (GameSource) Type() protoreflect.EnumType {
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[0]
+}
+
+func (x GameSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameSource.Descriptor instead.
+func (GameSource) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{0}
+}
+
 // GameStatus represents the status of a game
 type GameStatus int32
 
@@ -63,11 +113,11 @@ func (x GameStatus) String() string {
 }
 
 func (GameStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_games_game_service_v2_proto_enumTypes[0].Descriptor()
+	return file_api_proto_games_game_service_v2_proto_enumTypes[1].Descriptor()
 }
 
 func (GameStatus) Type() protoreflect.EnumType {
-	return &file_api_proto_games_game_service_v2_proto_enumTypes[0]
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[1]
 }
 
 func (x GameStatus) Number() protoreflect.EnumNumber {
@@ -76,7 +126,7 @@ func (x GameStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use GameStatus.Descriptor instead.
 func (GameStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{1}
 }
 
 // SessionStatus represents the status of a game session
@@ -125,11 +175,11 @@ func (x SessionStatus) String() string {
 }
 
 func (SessionStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_games_game_service_v2_proto_enumTypes[1].Descriptor()
+	return file_api_proto_games_game_service_v2_proto_enumTypes[2].Descriptor()
 }
 
 func (SessionStatus) Type() protoreflect.EnumType {
-	return &file_api_proto_games_game_service_v2_proto_enumTypes[1]
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[2]
 }
 
 func (x SessionStatus) Number() protoreflect.EnumNumber {
@@ -138,7 +188,7 @@ func (x SessionStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SessionStatus.Descriptor instead.
 func (SessionStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{1}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{2}
 }
 
 // SaveStatus represents the status of a save file
@@ -181,11 +231,11 @@ func (x SaveStatus) String() string {
 }
 
 func (SaveStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_games_game_service_v2_proto_enumTypes[2].Descriptor()
+	return file_api_proto_games_game_service_v2_proto_enumTypes[3].Descriptor()
 }
 
 func (SaveStatus) Type() protoreflect.EnumType {
-	return &file_api_proto_games_game_service_v2_proto_enumTypes[2]
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[3]
 }
 
 func (x SaveStatus) Number() protoreflect.EnumNumber {
@@ -194,17 +244,79 @@ func (x SaveStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use SaveStatus.Descriptor instead.
 func (SaveStatus) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{2}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{3}
+}
+
+type SessionUpdateType int32
+
+const (
+	SessionUpdateType_SESSION_UPDATE_UNSPECIFIED SessionUpdateType = 0
+	SessionUpdateType_SESSION_UPDATE_ADDED       SessionUpdateType = 1
+	SessionUpdateType_SESSION_UPDATE_UPDATED     SessionUpdateType = 2
+	SessionUpdateType_SESSION_UPDATE_REMOVED     SessionUpdateType = 3
+	SessionUpdateType_SESSION_UPDATE_IDLE        SessionUpdateType = 4
+	SessionUpdateType_SESSION_UPDATE_SYNCED      SessionUpdateType = 5 // The initial set of ADDED updates is complete
+)
+
+// Enum value maps for SessionUpdateType.
+var (
+	SessionUpdateType_name = map[int32]string{
+		0: "SESSION_UPDATE_UNSPECIFIED",
+		1: "SESSION_UPDATE_ADDED",
+		2: "SESSION_UPDATE_UPDATED",
+		3: "SESSION_UPDATE_REMOVED",
+		4: "SESSION_UPDATE_IDLE",
+		5: "SESSION_UPDATE_SYNCED",
+	}
+	SessionUpdateType_value = map[string]int32{
+		"SESSION_UPDATE_UNSPECIFIED": 0,
+		"SESSION_UPDATE_ADDED":       1,
+		"SESSION_UPDATE_UPDATED":     2,
+		"SESSION_UPDATE_REMOVED":     3,
+		"SESSION_UPDATE_IDLE":        4,
+		"SESSION_UPDATE_SYNCED":      5,
+	}
+)
+
+func (x SessionUpdateType) Enum() *SessionUpdateType {
+	p := new(SessionUpdateType)
+	*p = x
+	return p
+}
+
+func (x SessionUpdateType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SessionUpdateType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_games_game_service_v2_proto_enumTypes[4].Descriptor()
+}
+
+func (SessionUpdateType) Type() protoreflect.EnumType {
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[4]
+}
+
+func (x SessionUpdateType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SessionUpdateType.Descriptor instead.
+func (SessionUpdateType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{4}
 }
 
 type PTYEventType int32
 
 const (
-	PTYEventType_PTY_EVENT_UNSPECIFIED        PTYEventType = 0
-	PTYEventType_PTY_EVENT_PROCESS_EXIT       PTYEventType = 1
-	PTYEventType_PTY_EVENT_PROCESS_ERROR      PTYEventType = 2
-	PTYEventType_PTY_EVENT_SESSION_TIMEOUT    PTYEventType = 3
-	PTYEventType_PTY_EVENT_SESSION_TERMINATED PTYEventType = 4
+	PTYEventType_PTY_EVENT_UNSPECIFIED         PTYEventType = 0
+	PTYEventType_PTY_EVENT_PROCESS_EXIT        PTYEventType = 1
+	PTYEventType_PTY_EVENT_PROCESS_ERROR       PTYEventType = 2
+	PTYEventType_PTY_EVENT_SESSION_TIMEOUT     PTYEventType = 3
+	PTYEventType_PTY_EVENT_SESSION_TERMINATED  PTYEventType = 4
+	PTYEventType_PTY_EVENT_PLAYER_DISCONNECTED PTYEventType = 5
+	PTYEventType_PTY_EVENT_ALERT               PTYEventType = 6 // Sent to spectators when the game's status raises an alert; message is the banner, metadata has the rule and status_line
+	PTYEventType_PTY_EVENT_DEGRADED            PTYEventType = 7 // The game stopped responding; metadata has the reason, "silent" or "write_timeout"
+	PTYEventType_PTY_EVENT_RECOVERED           PTYEventType = 8 // A degraded game is responding again
 )
 
 // Enum value maps for PTYEventType.
@@ -215,13 +327,21 @@ var (
 		2: "PTY_EVENT_PROCESS_ERROR",
 		3: "PTY_EVENT_SESSION_TIMEOUT",
 		4: "PTY_EVENT_SESSION_TERMINATED",
+		5: "PTY_EVENT_PLAYER_DISCONNECTED",
+		6: "PTY_EVENT_ALERT",
+		7: "PTY_EVENT_DEGRADED",
+		8: "PTY_EVENT_RECOVERED",
 	}
 	PTYEventType_value = map[string]int32{
-		"PTY_EVENT_UNSPECIFIED":        0,
-		"PTY_EVENT_PROCESS_EXIT":       1,
-		"PTY_EVENT_PROCESS_ERROR":      2,
-		"PTY_EVENT_SESSION_TIMEOUT":    3,
-		"PTY_EVENT_SESSION_TERMINATED": 4,
+		"PTY_EVENT_UNSPECIFIED":         0,
+		"PTY_EVENT_PROCESS_EXIT":        1,
+		"PTY_EVENT_PROCESS_ERROR":       2,
+		"PTY_EVENT_SESSION_TIMEOUT":     3,
+		"PTY_EVENT_SESSION_TERMINATED":  4,
+		"PTY_EVENT_PLAYER_DISCONNECTED": 5,
+		"PTY_EVENT_ALERT":               6,
+		"PTY_EVENT_DEGRADED":            7,
+		"PTY_EVENT_RECOVERED":           8,
 	}
 )
 
@@ -236,11 +356,11 @@ func (x PTYEventType) String() string {
 }
 
 func (PTYEventType) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_games_game_service_v2_proto_enumTypes[3].Descriptor()
+	return file_api_proto_games_game_service_v2_proto_enumTypes[5].Descriptor()
 }
 
 func (PTYEventType) Type() protoreflect.EnumType {
-	return &file_api_proto_games_game_service_v2_proto_enumTypes[3]
+	return &file_api_proto_games_game_service_v2_proto_enumTypes[5]
 }
 
 func (x PTYEventType) Number() protoreflect.EnumNumber {
@@ -249,31 +369,35 @@ func (x PTYEventType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PTYEventType.Descriptor instead.
 func (PTYEventType) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{3}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{5}
 }
 
 // Game represents a game configuration
 type Game struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ShortName     string                 `protobuf:"bytes,3,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Category      string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	Version       string                 `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
-	Difficulty    int32                  `protobuf:"varint,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
-	Status        GameStatus             `protobuf:"varint,9,opt,name=status,proto3,enum=dungeongate.games.v2.GameStatus" json:"status,omitempty"`
-	Binary        *BinaryConfig          `protobuf:"bytes,10,opt,name=binary,proto3" json:"binary,omitempty"`
-	Environment   map[string]string      `protobuf:"bytes,11,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Resources     *ResourceConfig        `protobuf:"bytes,12,opt,name=resources,proto3" json:"resources,omitempty"`
-	Security      *SecurityConfig        `protobuf:"bytes,13,opt,name=security,proto3" json:"security,omitempty"`
-	Networking    *NetworkConfig         `protobuf:"bytes,14,opt,name=networking,proto3" json:"networking,omitempty"`
-	Statistics    *GameStatistics        `protobuf:"bytes,15,opt,name=statistics,proto3" json:"statistics,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ShortName      string                 `protobuf:"bytes,3,opt,name=short_name,json=shortName,proto3" json:"short_name,omitempty"`
+	Description    string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Category       string                 `protobuf:"bytes,5,opt,name=category,proto3" json:"category,omitempty"`
+	Tags           []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Version        string                 `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	Difficulty     int32                  `protobuf:"varint,8,opt,name=difficulty,proto3" json:"difficulty,omitempty"`
+	Status         GameStatus             `protobuf:"varint,9,opt,name=status,proto3,enum=dungeongate.games.v2.GameStatus" json:"status,omitempty"`
+	Binary         *BinaryConfig          `protobuf:"bytes,10,opt,name=binary,proto3" json:"binary,omitempty"`
+	Environment    map[string]string      `protobuf:"bytes,11,rep,name=environment,proto3" json:"environment,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Resources      *ResourceConfig        `protobuf:"bytes,12,opt,name=resources,proto3" json:"resources,omitempty"`
+	Security       *SecurityConfig        `protobuf:"bytes,13,opt,name=security,proto3" json:"security,omitempty"`
+	Networking     *NetworkConfig         `protobuf:"bytes,14,opt,name=networking,proto3" json:"networking,omitempty"`
+	Statistics     *GameStatistics        `protobuf:"bytes,15,opt,name=statistics,proto3" json:"statistics,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Versions       []string               `protobuf:"bytes,18,rep,name=versions,proto3" json:"versions,omitempty"`                                   // Installed versions, the game's own version first
+	DefaultVersion string                 `protobuf:"bytes,19,opt,name=default_version,json=defaultVersion,proto3" json:"default_version,omitempty"` // Version started when none is requested
+	StatusMessage  string                 `protobuf:"bytes,20,opt,name=status_message,json=statusMessage,proto3" json:"status_message,omitempty"`    // Why the game cannot be played, shown to players
+	Source         GameSource             `protobuf:"varint,21,opt,name=source,proto3,enum=dungeongate.games.v2.GameSource" json:"source,omitempty"` // Where the game's definition comes from
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Game) Reset() {
@@ -425,6 +549,34 @@ func (x *Game) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Game) GetVersions() []string {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+func (x *Game) GetDefaultVersion() string {
+	if x != nil {
+		return x.DefaultVersion
+	}
+	return ""
+}
+
+func (x *Game) GetStatusMessage() string {
+	if x != nil {
+		return x.StatusMessage
+	}
+	return ""
+}
+
+func (x *Game) GetSource() GameSource {
+	if x != nil {
+		return x.Source
+	}
+	return GameSource_GAME_SOURCE_UNSPECIFIED
+}
+
 // BinaryConfig defines how to execute a game binary
 type BinaryConfig struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -804,23 +956,33 @@ func (x *GameStatistics) GetRating() float32 {
 
 // GameSession represents an active game session
 type GameSession struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
-	GameId        string                 `protobuf:"bytes,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Status        SessionStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=dungeongate.games.v2.SessionStatus" json:"status,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	LastActivity  *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
-	TerminalSize  *TerminalSize          `protobuf:"bytes,9,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
-	Encoding      string                 `protobuf:"bytes,10,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	ProcessInfo   *ProcessInfo           `protobuf:"bytes,11,opt,name=process_info,json=processInfo,proto3" json:"process_info,omitempty"`
-	Recording     *RecordingInfo         `protobuf:"bytes,12,opt,name=recording,proto3" json:"recording,omitempty"`
-	Streaming     *StreamingInfo         `protobuf:"bytes,13,opt,name=streaming,proto3" json:"streaming,omitempty"`
-	Spectators    []*SpectatorInfo       `protobuf:"bytes,14,rep,name=spectators,proto3" json:"spectators,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                   protoimpl.MessageState `protogen:"open.v1"`
+	Id                      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId                  int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username                string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	GameId                  string                 `protobuf:"bytes,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Status                  SessionStatus          `protobuf:"varint,5,opt,name=status,proto3,enum=dungeongate.games.v2.SessionStatus" json:"status,omitempty"`
+	StartTime               *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime                 *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	LastActivity            *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	TerminalSize            *TerminalSize          `protobuf:"bytes,9,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
+	Encoding                string                 `protobuf:"bytes,10,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	ProcessInfo             *ProcessInfo           `protobuf:"bytes,11,opt,name=process_info,json=processInfo,proto3" json:"process_info,omitempty"`
+	Recording               *RecordingInfo         `protobuf:"bytes,12,opt,name=recording,proto3" json:"recording,omitempty"`
+	Streaming               *StreamingInfo         `protobuf:"bytes,13,opt,name=streaming,proto3" json:"streaming,omitempty"`
+	Spectators              []*SpectatorInfo       `protobuf:"bytes,14,rep,name=spectators,proto3" json:"spectators,omitempty"`
+	GameVersion             string                 `protobuf:"bytes,15,opt,name=game_version,json=gameVersion,proto3" json:"game_version,omitempty"`                                        // Installed version of the game being played
+	ClientIp                string                 `protobuf:"bytes,16,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`                                                 // Player's address as seen by the session service, for admins
+	PlayTimeEndsAt          *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=play_time_ends_at,json=playTimeEndsAt,proto3" json:"play_time_ends_at,omitempty"`                           // When the player's play-time budget runs out; unset without a budget
+	Outcome                 *GameOutcome           `protobuf:"bytes,18,opt,name=outcome,proto3" json:"outcome,omitempty"`                                                                   // How the game ended; unset while running or when the game did not say
+	DumplogId               string                 `protobuf:"bytes,19,opt,name=dumplog_id,json=dumplogId,proto3" json:"dumplog_id,omitempty"`                                              // Dumplog captured when the game ended, if any
+	SpectatorCount          int32                  `protobuf:"varint,20,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`                              // Spectators watching now
+	MaxSpectators           int32                  `protobuf:"varint,21,opt,name=max_spectators,json=maxSpectators,proto3" json:"max_spectators,omitempty"`                                 // Most spectators the game allows; 0 for no limit
+	SpectatorTimeoutSeconds int64                  `protobuf:"varint,22,opt,name=spectator_timeout_seconds,json=spectatorTimeoutSeconds,proto3" json:"spectator_timeout_seconds,omitempty"` // How long the game lets spectators watch without input; 0 for no limit
+	StatusLine              string                 `protobuf:"bytes,23,opt,name=status_line,json=statusLine,proto3" json:"status_line,omitempty"`                                           // What the game's screen says of the game, e.g. "Dlvl:12 HP:45/60 XL:8"; empty when not read
+	ResourceUsage           *ResourceUsage         `protobuf:"bytes,24,opt,name=resource_usage,json=resourceUsage,proto3" json:"resource_usage,omitempty"`                                  // What the game's processes have used; unset when the game is not in a cgroup
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *GameSession) Reset() {
@@ -951,6 +1113,153 @@ func (x *GameSession) GetSpectators() []*SpectatorInfo {
 	return nil
 }
 
+func (x *GameSession) GetGameVersion() string {
+	if x != nil {
+		return x.GameVersion
+	}
+	return ""
+}
+
+func (x *GameSession) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *GameSession) GetPlayTimeEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PlayTimeEndsAt
+	}
+	return nil
+}
+
+func (x *GameSession) GetOutcome() *GameOutcome {
+	if x != nil {
+		return x.Outcome
+	}
+	return nil
+}
+
+func (x *GameSession) GetDumplogId() string {
+	if x != nil {
+		return x.DumplogId
+	}
+	return ""
+}
+
+func (x *GameSession) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *GameSession) GetMaxSpectators() int32 {
+	if x != nil {
+		return x.MaxSpectators
+	}
+	return 0
+}
+
+func (x *GameSession) GetSpectatorTimeoutSeconds() int64 {
+	if x != nil {
+		return x.SpectatorTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *GameSession) GetStatusLine() string {
+	if x != nil {
+		return x.StatusLine
+	}
+	return ""
+}
+
+func (x *GameSession) GetResourceUsage() *ResourceUsage {
+	if x != nil {
+		return x.ResourceUsage
+	}
+	return nil
+}
+
+// GameOutcome is how a finished game ended, from the game's xlogfile
+type GameOutcome struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        string                 `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"` // "died", "quit", "escaped" or "ascended"
+	Death         string                 `protobuf:"bytes,2,opt,name=death,proto3" json:"death,omitempty"`   // The game's description, e.g. "killed by a jackal"
+	Points        int64                  `protobuf:"varint,3,opt,name=points,proto3" json:"points,omitempty"`
+	Turns         int64                  `protobuf:"varint,4,opt,name=turns,proto3" json:"turns,omitempty"`
+	Character     string                 `protobuf:"bytes,5,opt,name=character,proto3" json:"character,omitempty"` // Role, race, gender and alignment, e.g. "Val-Hum-Fem-Law"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameOutcome) Reset() {
+	*x = GameOutcome{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameOutcome) ProtoMessage() {}
+
+func (x *GameOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameOutcome.ProtoReflect.Descriptor instead.
+func (*GameOutcome) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GameOutcome) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GameOutcome) GetDeath() string {
+	if x != nil {
+		return x.Death
+	}
+	return ""
+}
+
+func (x *GameOutcome) GetPoints() int64 {
+	if x != nil {
+		return x.Points
+	}
+	return 0
+}
+
+func (x *GameOutcome) GetTurns() int64 {
+	if x != nil {
+		return x.Turns
+	}
+	return 0
+}
+
+func (x *GameOutcome) GetCharacter() string {
+	if x != nil {
+		return x.Character
+	}
+	return ""
+}
+
 // TerminalSize represents terminal dimensions
 type TerminalSize struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -962,7 +1271,7 @@ type TerminalSize struct {
 
 func (x *TerminalSize) Reset() {
 	*x = TerminalSize{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[7]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -974,7 +1283,7 @@ func (x *TerminalSize) String() string {
 func (*TerminalSize) ProtoMessage() {}
 
 func (x *TerminalSize) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[7]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -987,7 +1296,7 @@ func (x *TerminalSize) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TerminalSize.ProtoReflect.Descriptor instead.
 func (*TerminalSize) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{7}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *TerminalSize) GetWidth() int32 {
@@ -1018,7 +1327,7 @@ type ProcessInfo struct {
 
 func (x *ProcessInfo) Reset() {
 	*x = ProcessInfo{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[8]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1030,7 +1339,7 @@ func (x *ProcessInfo) String() string {
 func (*ProcessInfo) ProtoMessage() {}
 
 func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[8]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,7 +1352,7 @@ func (x *ProcessInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessInfo.ProtoReflect.Descriptor instead.
 func (*ProcessInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{8}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ProcessInfo) GetPid() int32 {
@@ -1081,34 +1390,33 @@ func (x *ProcessInfo) GetSignal() string {
 	return ""
 }
 
-// RecordingInfo contains session recording information
-type RecordingInfo struct {
+// ResourceUsage is read from the cgroup a game's processes run in
+type ResourceUsage struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
-	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
-	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
-	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	FileSize      int64                  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
-	Compressed    bool                   `protobuf:"varint,6,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	CpuSeconds    float64                `protobuf:"fixed64,1,opt,name=cpu_seconds,json=cpuSeconds,proto3" json:"cpu_seconds,omitempty"`
+	RssBytes      uint64                 `protobuf:"varint,2,opt,name=rss_bytes,json=rssBytes,proto3" json:"rss_bytes,omitempty"` // Anonymous memory, what the processes themselves allocated
+	Pids          uint64                 `protobuf:"varint,3,opt,name=pids,proto3" json:"pids,omitempty"`                         // Processes and threads running now
+	IoReadBytes   uint64                 `protobuf:"varint,4,opt,name=io_read_bytes,json=ioReadBytes,proto3" json:"io_read_bytes,omitempty"`
+	IoWriteBytes  uint64                 `protobuf:"varint,5,opt,name=io_write_bytes,json=ioWriteBytes,proto3" json:"io_write_bytes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RecordingInfo) Reset() {
-	*x = RecordingInfo{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[9]
+func (x *ResourceUsage) Reset() {
+	*x = ResourceUsage{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RecordingInfo) String() string {
+func (x *ResourceUsage) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RecordingInfo) ProtoMessage() {}
+func (*ResourceUsage) ProtoMessage() {}
 
-func (x *RecordingInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[9]
+func (x *ResourceUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1119,30 +1427,109 @@ func (x *RecordingInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RecordingInfo.ProtoReflect.Descriptor instead.
-func (*RecordingInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use ResourceUsage.ProtoReflect.Descriptor instead.
+func (*ResourceUsage) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *RecordingInfo) GetEnabled() bool {
+func (x *ResourceUsage) GetCpuSeconds() float64 {
 	if x != nil {
-		return x.Enabled
+		return x.CpuSeconds
 	}
-	return false
+	return 0
 }
 
-func (x *RecordingInfo) GetFilePath() string {
+func (x *ResourceUsage) GetRssBytes() uint64 {
 	if x != nil {
-		return x.FilePath
+		return x.RssBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *RecordingInfo) GetFormat() string {
+func (x *ResourceUsage) GetPids() uint64 {
 	if x != nil {
-		return x.Format
+		return x.Pids
 	}
-	return ""
+	return 0
+}
+
+func (x *ResourceUsage) GetIoReadBytes() uint64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *ResourceUsage) GetIoWriteBytes() uint64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+// RecordingInfo contains session recording information
+type RecordingInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	FilePath      string                 `protobuf:"bytes,2,opt,name=file_path,json=filePath,proto3" json:"file_path,omitempty"`
+	Format        string                 `protobuf:"bytes,3,opt,name=format,proto3" json:"format,omitempty"`
+	StartTime     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	FileSize      int64                  `protobuf:"varint,5,opt,name=file_size,json=fileSize,proto3" json:"file_size,omitempty"`
+	Compressed    bool                   `protobuf:"varint,6,opt,name=compressed,proto3" json:"compressed,omitempty"`
+	Private       bool                   `protobuf:"varint,7,opt,name=private,proto3" json:"private,omitempty"` // Only the player may view the recording
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordingInfo) Reset() {
+	*x = RecordingInfo{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordingInfo) ProtoMessage() {}
+
+func (x *RecordingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordingInfo.ProtoReflect.Descriptor instead.
+func (*RecordingInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RecordingInfo) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *RecordingInfo) GetFilePath() string {
+	if x != nil {
+		return x.FilePath
+	}
+	return ""
+}
+
+func (x *RecordingInfo) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
 }
 
 func (x *RecordingInfo) GetStartTime() *timestamppb.Timestamp {
@@ -1166,6 +1553,13 @@ func (x *RecordingInfo) GetCompressed() bool {
 	return false
 }
 
+func (x *RecordingInfo) GetPrivate() bool {
+	if x != nil {
+		return x.Private
+	}
+	return false
+}
+
 // StreamingInfo contains session streaming information
 type StreamingInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1180,7 +1574,7 @@ type StreamingInfo struct {
 
 func (x *StreamingInfo) Reset() {
 	*x = StreamingInfo{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[10]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1192,7 +1586,7 @@ func (x *StreamingInfo) String() string {
 func (*StreamingInfo) ProtoMessage() {}
 
 func (x *StreamingInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[10]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,7 +1599,7 @@ func (x *StreamingInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamingInfo.ProtoReflect.Descriptor instead.
 func (*StreamingInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{10}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *StreamingInfo) GetEnabled() bool {
@@ -1257,7 +1651,7 @@ type SpectatorInfo struct {
 
 func (x *SpectatorInfo) Reset() {
 	*x = SpectatorInfo{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[11]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1269,7 +1663,7 @@ func (x *SpectatorInfo) String() string {
 func (*SpectatorInfo) ProtoMessage() {}
 
 func (x *SpectatorInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[11]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,7 +1676,7 @@ func (x *SpectatorInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SpectatorInfo.ProtoReflect.Descriptor instead.
 func (*SpectatorInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{11}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *SpectatorInfo) GetUserId() int32 {
@@ -1335,13 +1729,14 @@ type GameSave struct {
 	Backups       []*SaveBackup          `protobuf:"bytes,10,rep,name=backups,proto3" json:"backups,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	VerifiedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=verified_at,json=verifiedAt,proto3" json:"verified_at,omitempty"` // Last checksum verification of the save file
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameSave) Reset() {
 	*x = GameSave{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[12]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1353,7 +1748,7 @@ func (x *GameSave) String() string {
 func (*GameSave) ProtoMessage() {}
 
 func (x *GameSave) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[12]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1366,7 +1761,7 @@ func (x *GameSave) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameSave.ProtoReflect.Descriptor instead.
 func (*GameSave) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{12}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GameSave) GetId() string {
@@ -1453,6 +1848,13 @@ func (x *GameSave) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GameSave) GetVerifiedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.VerifiedAt
+	}
+	return nil
+}
+
 // SaveMetadata contains metadata about a save file
 type SaveMetadata struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -1469,7 +1871,7 @@ type SaveMetadata struct {
 
 func (x *SaveMetadata) Reset() {
 	*x = SaveMetadata{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[13]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1481,7 +1883,7 @@ func (x *SaveMetadata) String() string {
 func (*SaveMetadata) ProtoMessage() {}
 
 func (x *SaveMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[13]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1494,7 +1896,7 @@ func (x *SaveMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveMetadata.ProtoReflect.Descriptor instead.
 func (*SaveMetadata) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{13}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *SaveMetadata) GetGameVersion() string {
@@ -1560,7 +1962,7 @@ type SaveBackup struct {
 
 func (x *SaveBackup) Reset() {
 	*x = SaveBackup{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[14]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1572,7 +1974,7 @@ func (x *SaveBackup) String() string {
 func (*SaveBackup) ProtoMessage() {}
 
 func (x *SaveBackup) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[14]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1585,7 +1987,7 @@ func (x *SaveBackup) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SaveBackup.ProtoReflect.Descriptor instead.
 func (*SaveBackup) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{14}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SaveBackup) GetId() string {
@@ -1623,34 +2025,36 @@ func (x *SaveBackup) GetChecksum() string {
 	return ""
 }
 
-// Game management requests/responses
-type ListGamesRequest struct {
+// Dumplog represents the end-of-game dump captured for a session
+type Dumplog struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
-	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
-	Status        GameStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.GameStatus" json:"status,omitempty"`
-	EnabledOnly   bool                   `protobuf:"varint,4,opt,name=enabled_only,json=enabledOnly,proto3" json:"enabled_only,omitempty"`
-	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	GameId        string                 `protobuf:"bytes,5,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Size          int64                  `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	Content       []byte                 `protobuf:"bytes,7,opt,name=content,proto3" json:"content,omitempty"` // Only populated by GetDumplog
+	CapturedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=captured_at,json=capturedAt,proto3" json:"captured_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGamesRequest) Reset() {
-	*x = ListGamesRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[15]
+func (x *Dumplog) Reset() {
+	*x = Dumplog{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGamesRequest) String() string {
+func (x *Dumplog) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGamesRequest) ProtoMessage() {}
+func (*Dumplog) ProtoMessage() {}
 
-func (x *ListGamesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[15]
+func (x *Dumplog) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1661,76 +2065,105 @@ func (x *ListGamesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGamesRequest.ProtoReflect.Descriptor instead.
-func (*ListGamesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use Dumplog.ProtoReflect.Descriptor instead.
+func (*Dumplog) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *ListGamesRequest) GetCategory() string {
+func (x *Dumplog) GetId() string {
 	if x != nil {
-		return x.Category
+		return x.Id
 	}
 	return ""
 }
 
-func (x *ListGamesRequest) GetTag() string {
+func (x *Dumplog) GetSessionId() string {
 	if x != nil {
-		return x.Tag
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *ListGamesRequest) GetStatus() GameStatus {
+func (x *Dumplog) GetUserId() int32 {
 	if x != nil {
-		return x.Status
+		return x.UserId
 	}
-	return GameStatus_GAME_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *ListGamesRequest) GetEnabledOnly() bool {
+func (x *Dumplog) GetUsername() string {
 	if x != nil {
-		return x.EnabledOnly
+		return x.Username
 	}
-	return false
+	return ""
 }
 
-func (x *ListGamesRequest) GetLimit() int32 {
+func (x *Dumplog) GetGameId() string {
 	if x != nil {
-		return x.Limit
+		return x.GameId
 	}
-	return 0
+	return ""
 }
 
-func (x *ListGamesRequest) GetOffset() int32 {
+func (x *Dumplog) GetSize() int64 {
 	if x != nil {
-		return x.Offset
+		return x.Size
 	}
 	return 0
 }
 
-type ListGamesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Games         []*Game                `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Dumplog) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
 }
 
-func (x *ListGamesResponse) Reset() {
-	*x = ListGamesResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[16]
+func (x *Dumplog) GetCapturedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CapturedAt
+	}
+	return nil
+}
+
+// Screenshot is the screen of a game captured by its player or a spectator
+type Screenshot struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId       string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId          int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Player
+	Username        string                 `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+	GameId          string                 `protobuf:"bytes,5,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	TakenByUserId   int32                  `protobuf:"varint,6,opt,name=taken_by_user_id,json=takenByUserId,proto3" json:"taken_by_user_id,omitempty"` // The player, or a spectator
+	TakenByUsername string                 `protobuf:"bytes,7,opt,name=taken_by_username,json=takenByUsername,proto3" json:"taken_by_username,omitempty"`
+	Cols            int32                  `protobuf:"varint,8,opt,name=cols,proto3" json:"cols,omitempty"`
+	Rows            int32                  `protobuf:"varint,9,opt,name=rows,proto3" json:"rows,omitempty"`
+	Size            int64                  `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"` // Bytes of every rendering
+	TakenAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=taken_at,json=takenAt,proto3" json:"taken_at,omitempty"`
+	Text            string                 `protobuf:"bytes,12,opt,name=text,proto3" json:"text,omitempty"` // Renderings, only populated by GetScreenshot
+	Ansi            string                 `protobuf:"bytes,13,opt,name=ansi,proto3" json:"ansi,omitempty"`
+	Html            string                 `protobuf:"bytes,14,opt,name=html,proto3" json:"html,omitempty"`
+	ShareUrl        string                 `protobuf:"bytes,15,opt,name=share_url,json=shareUrl,proto3" json:"share_url,omitempty"` // Public link, while shared
+	ShareExpiresAt  *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=share_expires_at,json=shareExpiresAt,proto3" json:"share_expires_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Screenshot) Reset() {
+	*x = Screenshot{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGamesResponse) String() string {
+func (x *Screenshot) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGamesResponse) ProtoMessage() {}
+func (*Screenshot) ProtoMessage() {}
 
-func (x *ListGamesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[16]
+func (x *Screenshot) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1741,134 +2174,152 @@ func (x *ListGamesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGamesResponse.ProtoReflect.Descriptor instead.
-func (*ListGamesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Screenshot.ProtoReflect.Descriptor instead.
+func (*Screenshot) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *ListGamesResponse) GetGames() []*Game {
+func (x *Screenshot) GetId() string {
 	if x != nil {
-		return x.Games
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-func (x *ListGamesResponse) GetTotalCount() int32 {
+func (x *Screenshot) GetSessionId() string {
 	if x != nil {
-		return x.TotalCount
+		return x.SessionId
 	}
-	return 0
+	return ""
 }
 
-type GetGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Screenshot) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
 }
 
-func (x *GetGameRequest) Reset() {
-	*x = GetGameRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Screenshot) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
 }
 
-func (x *GetGameRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Screenshot) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
 }
 
-func (*GetGameRequest) ProtoMessage() {}
-
-func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[17]
+func (x *Screenshot) GetTakenByUserId() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.TakenByUserId
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
-func (*GetGameRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{17}
+func (x *Screenshot) GetTakenByUsername() string {
+	if x != nil {
+		return x.TakenByUsername
+	}
+	return ""
 }
 
-func (x *GetGameRequest) GetGameId() string {
+func (x *Screenshot) GetCols() int32 {
 	if x != nil {
-		return x.GameId
+		return x.Cols
 	}
-	return ""
+	return 0
 }
 
-type GetGameResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Screenshot) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
 }
 
-func (x *GetGameResponse) Reset() {
-	*x = GetGameResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[18]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Screenshot) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
 }
 
-func (x *GetGameResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Screenshot) GetTakenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TakenAt
+	}
+	return nil
 }
 
-func (*GetGameResponse) ProtoMessage() {}
+func (x *Screenshot) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
 
-func (x *GetGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[18]
+func (x *Screenshot) GetAnsi() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Ansi
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use GetGameResponse.ProtoReflect.Descriptor instead.
-func (*GetGameResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{18}
+func (x *Screenshot) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
 }
 
-func (x *GetGameResponse) GetGame() *Game {
+func (x *Screenshot) GetShareUrl() string {
 	if x != nil {
-		return x.Game
+		return x.ShareUrl
+	}
+	return ""
+}
+
+func (x *Screenshot) GetShareExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ShareExpiresAt
 	}
 	return nil
 }
 
-type CreateGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Bookmark marks a moment of a recorded game, for jumping to in playback
+type Bookmark struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SessionId         string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId            int32                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`                                // Player
+	CreatedByUserId   int32                  `protobuf:"varint,4,opt,name=created_by_user_id,json=createdByUserId,proto3" json:"created_by_user_id,omitempty"` // The player, or a spectator
+	CreatedByUsername string                 `protobuf:"bytes,5,opt,name=created_by_username,json=createdByUsername,proto3" json:"created_by_username,omitempty"`
+	OffsetMs          int64                  `protobuf:"varint,6,opt,name=offset_ms,json=offsetMs,proto3" json:"offset_ms,omitempty"` // From the start of the recording
+	Note              string                 `protobuf:"bytes,7,opt,name=note,proto3" json:"note,omitempty"`
+	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *CreateGameRequest) Reset() {
-	*x = CreateGameRequest{}
+func (x *Bookmark) Reset() {
+	*x = Bookmark{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameRequest) String() string {
+func (x *Bookmark) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameRequest) ProtoMessage() {}
+func (*Bookmark) ProtoMessage() {}
 
-func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+func (x *Bookmark) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1880,39 +2331,94 @@ func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
-func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use Bookmark.ProtoReflect.Descriptor instead.
+func (*Bookmark) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CreateGameRequest) GetGame() *Game {
+func (x *Bookmark) GetId() string {
 	if x != nil {
-		return x.Game
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Bookmark) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *Bookmark) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Bookmark) GetCreatedByUserId() int32 {
+	if x != nil {
+		return x.CreatedByUserId
+	}
+	return 0
+}
+
+func (x *Bookmark) GetCreatedByUsername() string {
+	if x != nil {
+		return x.CreatedByUsername
+	}
+	return ""
+}
+
+func (x *Bookmark) GetOffsetMs() int64 {
+	if x != nil {
+		return x.OffsetMs
+	}
+	return 0
+}
+
+func (x *Bookmark) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+func (x *Bookmark) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
 	}
 	return nil
 }
 
-type CreateGameResponse struct {
+// Game management requests/responses
+type ListGamesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	Category      string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Tag           string                 `protobuf:"bytes,2,opt,name=tag,proto3" json:"tag,omitempty"`
+	Status        GameStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.GameStatus" json:"status,omitempty"`
+	EnabledOnly   bool                   `protobuf:"varint,4,opt,name=enabled_only,json=enabledOnly,proto3" json:"enabled_only,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGameResponse) Reset() {
-	*x = CreateGameResponse{}
+func (x *ListGamesRequest) Reset() {
+	*x = ListGamesRequest{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameResponse) String() string {
+func (x *ListGamesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameResponse) ProtoMessage() {}
+func (*ListGamesRequest) ProtoMessage() {}
 
-func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+func (x *ListGamesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1924,40 +2430,75 @@ func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
-func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListGamesRequest.ProtoReflect.Descriptor instead.
+func (*ListGamesRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CreateGameResponse) GetGame() *Game {
+func (x *ListGamesRequest) GetCategory() string {
 	if x != nil {
-		return x.Game
+		return x.Category
 	}
-	return nil
+	return ""
 }
 
-type UpdateGameRequest struct {
+func (x *ListGamesRequest) GetTag() string {
+	if x != nil {
+		return x.Tag
+	}
+	return ""
+}
+
+func (x *ListGamesRequest) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *ListGamesRequest) GetEnabledOnly() bool {
+	if x != nil {
+		return x.EnabledOnly
+	}
+	return false
+}
+
+func (x *ListGamesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListGamesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListGamesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Game          *Game                  `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`
+	Games         []*Game                `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateGameRequest) Reset() {
-	*x = UpdateGameRequest{}
+func (x *ListGamesResponse) Reset() {
+	*x = ListGamesResponse{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateGameRequest) String() string {
+func (x *ListGamesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGameRequest) ProtoMessage() {}
+func (*ListGamesResponse) ProtoMessage() {}
 
-func (x *UpdateGameRequest) ProtoReflect() protoreflect.Message {
+func (x *ListGamesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1969,46 +2510,46 @@ func (x *UpdateGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGameRequest.ProtoReflect.Descriptor instead.
-func (*UpdateGameRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListGamesResponse.ProtoReflect.Descriptor instead.
+func (*ListGamesResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *UpdateGameRequest) GetGameId() string {
+func (x *ListGamesResponse) GetGames() []*Game {
 	if x != nil {
-		return x.GameId
+		return x.Games
 	}
-	return ""
+	return nil
 }
 
-func (x *UpdateGameRequest) GetGame() *Game {
+func (x *ListGamesResponse) GetTotalCount() int32 {
 	if x != nil {
-		return x.Game
+		return x.TotalCount
 	}
-	return nil
+	return 0
 }
 
-type UpdateGameResponse struct {
+type GetGameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateGameResponse) Reset() {
-	*x = UpdateGameResponse{}
+func (x *GetGameRequest) Reset() {
+	*x = GetGameRequest{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateGameResponse) String() string {
+func (x *GetGameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateGameResponse) ProtoMessage() {}
+func (*GetGameRequest) ProtoMessage() {}
 
-func (x *UpdateGameResponse) ProtoReflect() protoreflect.Message {
+func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2020,39 +2561,39 @@ func (x *UpdateGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateGameResponse.ProtoReflect.Descriptor instead.
-func (*UpdateGameResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
+func (*GetGameRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *UpdateGameResponse) GetGame() *Game {
+func (x *GetGameRequest) GetGameId() string {
 	if x != nil {
-		return x.Game
+		return x.GameId
 	}
-	return nil
+	return ""
 }
 
-type DeleteGameRequest struct {
+type GetGameResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteGameRequest) Reset() {
-	*x = DeleteGameRequest{}
+func (x *GetGameResponse) Reset() {
+	*x = GetGameResponse{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteGameRequest) String() string {
+func (x *GetGameResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteGameRequest) ProtoMessage() {}
+func (*GetGameResponse) ProtoMessage() {}
 
-func (x *DeleteGameRequest) ProtoReflect() protoreflect.Message {
+func (x *GetGameResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2064,39 +2605,42 @@ func (x *DeleteGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteGameRequest.ProtoReflect.Descriptor instead.
-func (*DeleteGameRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetGameResponse.ProtoReflect.Descriptor instead.
+func (*GetGameResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{23}
 }
 
-func (x *DeleteGameRequest) GetGameId() string {
+func (x *GetGameResponse) GetGame() *Game {
 	if x != nil {
-		return x.GameId
+		return x.Game
 	}
-	return ""
+	return nil
 }
 
-type DeleteGameResponse struct {
+// Games created or updated through the API are kept in the database and take
+// the place of configured games with the same ID
+type CreateGameRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`             // Not accepted; games are created from their definition
+	Definition    string                 `protobuf:"bytes,2,opt,name=definition,proto3" json:"definition,omitempty"` // YAML of one entry of the configuration's games list
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteGameResponse) Reset() {
-	*x = DeleteGameResponse{}
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteGameResponse) String() string {
+func (x *CreateGameRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteGameResponse) ProtoMessage() {}
+func (*CreateGameRequest) ProtoMessage() {}
 
-func (x *DeleteGameResponse) ProtoReflect() protoreflect.Message {
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2108,11 +2652,256 @@ func (x *DeleteGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteGameResponse.ProtoReflect.Descriptor instead.
-func (*DeleteGameResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{24}
 }
 
+func (x *CreateGameRequest) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CreateGameResponse) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+type UpdateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Game          *Game                  `protobuf:"bytes,2,opt,name=game,proto3" json:"game,omitempty"`             // Not accepted; games are updated from their definition
+	Definition    string                 `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition,omitempty"` // YAML of one entry of the configuration's games list
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGameRequest) Reset() {
+	*x = UpdateGameRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGameRequest) ProtoMessage() {}
+
+func (x *UpdateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGameRequest.ProtoReflect.Descriptor instead.
+func (*UpdateGameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *UpdateGameRequest) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *UpdateGameRequest) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+type UpdateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateGameResponse) Reset() {
+	*x = UpdateGameResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateGameResponse) ProtoMessage() {}
+
+func (x *UpdateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateGameResponse.ProtoReflect.Descriptor instead.
+func (*UpdateGameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateGameResponse) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+// Deleting a game removes its database definition; a configured game it
+// overrode comes back
+type DeleteGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGameRequest) Reset() {
+	*x = DeleteGameRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGameRequest) ProtoMessage() {}
+
+func (x *DeleteGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGameRequest.ProtoReflect.Descriptor instead.
+func (*DeleteGameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *DeleteGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type DeleteGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteGameResponse) Reset() {
+	*x = DeleteGameResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteGameResponse) ProtoMessage() {}
+
+func (x *DeleteGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteGameResponse.ProtoReflect.Descriptor instead.
+func (*DeleteGameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{29}
+}
+
 func (x *DeleteGameResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
@@ -2120,35 +2909,2232 @@ func (x *DeleteGameResponse) GetSuccess() bool {
 	return false
 }
 
-// Session management requests/responses
-type StartGameSessionRequest struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	UserId           int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username         string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	GameId           string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	TerminalSize     *TerminalSize          `protobuf:"bytes,4,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
-	EnableRecording  bool                   `protobuf:"varint,5,opt,name=enable_recording,json=enableRecording,proto3" json:"enable_recording,omitempty"`
-	EnableStreaming  bool                   `protobuf:"varint,6,opt,name=enable_streaming,json=enableStreaming,proto3" json:"enable_streaming,omitempty"`
-	EnableEncryption bool                   `protobuf:"varint,7,opt,name=enable_encryption,json=enableEncryption,proto3" json:"enable_encryption,omitempty"`
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+// Export writes the games defined in the database, to commit to the
+// configuration file before deleting them from the database
+type ExportGamesRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	IncludeConfigured bool                   `protobuf:"varint,1,opt,name=include_configured,json=includeConfigured,proto3" json:"include_configured,omitempty"` // Also the configured games the database does not override
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ExportGamesRequest) Reset() {
+	*x = ExportGamesRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGamesRequest) ProtoMessage() {}
+
+func (x *ExportGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGamesRequest.ProtoReflect.Descriptor instead.
+func (*ExportGamesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ExportGamesRequest) GetIncludeConfigured() bool {
+	if x != nil {
+		return x.IncludeConfigured
+	}
+	return false
+}
+
+// ExportGamesResponse has the games as the games list of the configuration file
+type ExportGamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Yaml          string                 `protobuf:"bytes,1,opt,name=yaml,proto3" json:"yaml,omitempty"`
+	Count         int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportGamesResponse) Reset() {
+	*x = ExportGamesResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportGamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportGamesResponse) ProtoMessage() {}
+
+func (x *ExportGamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportGamesResponse.ProtoReflect.Descriptor instead.
+func (*ExportGamesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ExportGamesResponse) GetYaml() string {
+	if x != nil {
+		return x.Yaml
+	}
+	return ""
+}
+
+func (x *ExportGamesResponse) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SetGameStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Status        GameStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=dungeongate.games.v2.GameStatus" json:"status,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // Shown to players while the game is not enabled
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGameStatusRequest) Reset() {
+	*x = SetGameStatusRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGameStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGameStatusRequest) ProtoMessage() {}
+
+func (x *SetGameStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGameStatusRequest.ProtoReflect.Descriptor instead.
+func (*SetGameStatusRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *SetGameStatusRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SetGameStatusRequest) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *SetGameStatusRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type SetGameStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetGameStatusResponse) Reset() {
+	*x = SetGameStatusResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetGameStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetGameStatusResponse) ProtoMessage() {}
+
+func (x *SetGameStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetGameStatusResponse.ProtoReflect.Descriptor instead.
+func (*SetGameStatusResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *SetGameStatusResponse) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+// Session management requests/responses
+type StartGameSessionRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	UserId           int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username         string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	GameId           string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	TerminalSize     *TerminalSize          `protobuf:"bytes,4,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
+	EnableRecording  bool                   `protobuf:"varint,5,opt,name=enable_recording,json=enableRecording,proto3" json:"enable_recording,omitempty"`
+	EnableStreaming  bool                   `protobuf:"varint,6,opt,name=enable_streaming,json=enableStreaming,proto3" json:"enable_streaming,omitempty"`
+	EnableEncryption bool                   `protobuf:"varint,7,opt,name=enable_encryption,json=enableEncryption,proto3" json:"enable_encryption,omitempty"`
+	TermType         string                 `protobuf:"bytes,8,opt,name=term_type,json=termType,proto3" json:"term_type,omitempty"`                          // Client TERM value, already sanitized by the session service
+	Locale           string                 `protobuf:"bytes,9,opt,name=locale,proto3" json:"locale,omitempty"`                                              // Client locale (LANG/LC_ALL), empty to use the game's default
+	Version          string                 `protobuf:"bytes,10,opt,name=version,proto3" json:"version,omitempty"`                                           // Installed game version to start, empty for the game's default
+	ClientIp         string                 `protobuf:"bytes,11,opt,name=client_ip,json=clientIp,proto3" json:"client_ip,omitempty"`                         // Player's address, the real one when behind a PROXY protocol load balancer
+	RecordingPrivacy string                 `protobuf:"bytes,12,opt,name=recording_privacy,json=recordingPrivacy,proto3" json:"recording_privacy,omitempty"` // Player's setting: public (default), private or off; the game's policy may override it
+	IdempotencyKey   string                 `protobuf:"bytes,13,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`       // Client-chosen key; a retry with the same key returns the original session instead of starting another game
+	PlayTimeLimit    *PlayTimeLimit         `protobuf:"bytes,14,opt,name=play_time_limit,json=playTimeLimit,proto3" json:"play_time_limit,omitempty"`        // Player's play-time budget; unset to play without one
+	Roles            []string               `protobuf:"bytes,15,rep,name=roles,proto3" json:"roles,omitempty"`                                               // Player's roles, such as admin or beta, which feature flags may be rolled out to
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *StartGameSessionRequest) Reset() {
+	*x = StartGameSessionRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameSessionRequest) ProtoMessage() {}
+
+func (x *StartGameSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameSessionRequest.ProtoReflect.Descriptor instead.
+func (*StartGameSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *StartGameSessionRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *StartGameSessionRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetTerminalSize() *TerminalSize {
+	if x != nil {
+		return x.TerminalSize
+	}
+	return nil
+}
+
+func (x *StartGameSessionRequest) GetEnableRecording() bool {
+	if x != nil {
+		return x.EnableRecording
+	}
+	return false
+}
+
+func (x *StartGameSessionRequest) GetEnableStreaming() bool {
+	if x != nil {
+		return x.EnableStreaming
+	}
+	return false
+}
+
+func (x *StartGameSessionRequest) GetEnableEncryption() bool {
+	if x != nil {
+		return x.EnableEncryption
+	}
+	return false
+}
+
+func (x *StartGameSessionRequest) GetTermType() string {
+	if x != nil {
+		return x.TermType
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetLocale() string {
+	if x != nil {
+		return x.Locale
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetClientIp() string {
+	if x != nil {
+		return x.ClientIp
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetRecordingPrivacy() string {
+	if x != nil {
+		return x.RecordingPrivacy
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *StartGameSessionRequest) GetPlayTimeLimit() *PlayTimeLimit {
+	if x != nil {
+		return x.PlayTimeLimit
+	}
+	return nil
+}
+
+func (x *StartGameSessionRequest) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// PlayTimeLimit is how long a player may play; zero means no limit
+type PlayTimeLimit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DailySeconds  int64                  `protobuf:"varint,1,opt,name=daily_seconds,json=dailySeconds,proto3" json:"daily_seconds,omitempty"`    // Since midnight, server time
+	WeeklySeconds int64                  `protobuf:"varint,2,opt,name=weekly_seconds,json=weeklySeconds,proto3" json:"weekly_seconds,omitempty"` // Since Monday midnight, server time
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayTimeLimit) Reset() {
+	*x = PlayTimeLimit{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayTimeLimit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayTimeLimit) ProtoMessage() {}
+
+func (x *PlayTimeLimit) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayTimeLimit.ProtoReflect.Descriptor instead.
+func (*PlayTimeLimit) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *PlayTimeLimit) GetDailySeconds() int64 {
+	if x != nil {
+		return x.DailySeconds
+	}
+	return 0
+}
+
+func (x *PlayTimeLimit) GetWeeklySeconds() int64 {
+	if x != nil {
+		return x.WeeklySeconds
+	}
+	return 0
+}
+
+type StartGameSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *GameSession           `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartGameSessionResponse) Reset() {
+	*x = StartGameSessionResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartGameSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartGameSessionResponse) ProtoMessage() {}
+
+func (x *StartGameSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartGameSessionResponse.ProtoReflect.Descriptor instead.
+func (*StartGameSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *StartGameSessionResponse) GetSession() *GameSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type StopGameSessionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	SessionId      string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Reason         string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Force          bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // Client-chosen key; a retry with the same key returns the original result
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *StopGameSessionRequest) Reset() {
+	*x = StopGameSessionRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopGameSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopGameSessionRequest) ProtoMessage() {}
+
+func (x *StopGameSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopGameSessionRequest.ProtoReflect.Descriptor instead.
+func (*StopGameSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *StopGameSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *StopGameSessionRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StopGameSessionRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *StopGameSessionRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type StopGameSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopGameSessionResponse) Reset() {
+	*x = StopGameSessionResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopGameSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopGameSessionResponse) ProtoMessage() {}
+
+func (x *StopGameSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopGameSessionResponse.ProtoReflect.Descriptor instead.
+func (*StopGameSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *StopGameSessionResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type GetGameSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ViewerId      int32                  `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // User asking; private recordings are only shown to their player
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameSessionRequest) Reset() {
+	*x = GetGameSessionRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameSessionRequest) ProtoMessage() {}
+
+func (x *GetGameSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetGameSessionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetGameSessionRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GetGameSessionRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type GetGameSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *GameSession           `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameSessionResponse) Reset() {
+	*x = GetGameSessionResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameSessionResponse) ProtoMessage() {}
+
+func (x *GetGameSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetGameSessionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetGameSessionResponse) GetSession() *GameSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type ListGameSessionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Status        SessionStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.SessionStatus" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	ViewerId      int32                  `protobuf:"varint,6,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // User asking; private recordings are only shown to their player
+	SortBy        string                 `protobuf:"bytes,7,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`        // "cpu", "rss", "pids" or "io" lists the heaviest sessions first; empty keeps the default order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGameSessionsRequest) Reset() {
+	*x = ListGameSessionsRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGameSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGameSessionsRequest) ProtoMessage() {}
+
+func (x *ListGameSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGameSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListGameSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListGameSessionsRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListGameSessionsRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ListGameSessionsRequest) GetStatus() SessionStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SessionStatus_SESSION_STATUS_UNSPECIFIED
+}
+
+func (x *ListGameSessionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListGameSessionsRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListGameSessionsRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+func (x *ListGameSessionsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+type ListGameSessionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*GameSession         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGameSessionsResponse) Reset() {
+	*x = ListGameSessionsResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGameSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGameSessionsResponse) ProtoMessage() {}
+
+func (x *ListGameSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGameSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListGameSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListGameSessionsResponse) GetSessions() []*GameSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListGameSessionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type ListSessionHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // Empty lists every game
+	Since         *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=since,proto3" json:"since,omitempty"`                 // Only sessions started at or after this
+	Until         *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=until,proto3" json:"until,omitempty"`                 // Only sessions started before this
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	ViewerId      int32                  `protobuf:"varint,7,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // User asking; private recordings are only shown to their player
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionHistoryRequest) Reset() {
+	*x = ListSessionHistoryRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionHistoryRequest) ProtoMessage() {}
+
+func (x *ListSessionHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionHistoryRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListSessionHistoryRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSessionHistoryRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ListSessionHistoryRequest) GetSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Since
+	}
+	return nil
+}
+
+func (x *ListSessionHistoryRequest) GetUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Until
+	}
+	return nil
+}
+
+func (x *ListSessionHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSessionHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListSessionHistoryRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type ListSessionHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*GameSession         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"` // Sessions matching the filters before paging
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSessionHistoryResponse) Reset() {
+	*x = ListSessionHistoryResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSessionHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionHistoryResponse) ProtoMessage() {}
+
+func (x *ListSessionHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionHistoryResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListSessionHistoryResponse) GetSessions() []*GameSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+func (x *ListSessionHistoryResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+type SubscribeGameSessionsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	SpectatableOnly     bool                   `protobuf:"varint,1,opt,name=spectatable_only,json=spectatableOnly,proto3" json:"spectatable_only,omitempty"`               // Only report sessions open to spectators
+	IdleIntervalSeconds int32                  `protobuf:"varint,2,opt,name=idle_interval_seconds,json=idleIntervalSeconds,proto3" json:"idle_interval_seconds,omitempty"` // How often idle times are refreshed (0 = server default)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *SubscribeGameSessionsRequest) Reset() {
+	*x = SubscribeGameSessionsRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeGameSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeGameSessionsRequest) ProtoMessage() {}
+
+func (x *SubscribeGameSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeGameSessionsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeGameSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *SubscribeGameSessionsRequest) GetSpectatableOnly() bool {
+	if x != nil {
+		return x.SpectatableOnly
+	}
+	return false
+}
+
+func (x *SubscribeGameSessionsRequest) GetIdleIntervalSeconds() int32 {
+	if x != nil {
+		return x.IdleIntervalSeconds
+	}
+	return 0
+}
+
+type GameSessionUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          SessionUpdateType      `protobuf:"varint,1,opt,name=type,proto3,enum=dungeongate.games.v2.SessionUpdateType" json:"type,omitempty"`
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Session       *GameSession           `protobuf:"bytes,3,opt,name=session,proto3" json:"session,omitempty"` // Unset for REMOVED and SYNCED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameSessionUpdate) Reset() {
+	*x = GameSessionUpdate{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameSessionUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameSessionUpdate) ProtoMessage() {}
+
+func (x *GameSessionUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameSessionUpdate.ProtoReflect.Descriptor instead.
+func (*GameSessionUpdate) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GameSessionUpdate) GetType() SessionUpdateType {
+	if x != nil {
+		return x.Type
+	}
+	return SessionUpdateType_SESSION_UPDATE_UNSPECIFIED
+}
+
+func (x *GameSessionUpdate) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *GameSessionUpdate) GetSession() *GameSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+// Save management requests/responses
+type SaveGameRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	UserId         int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId         string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Data           []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Metadata       *SaveMetadata          `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	IdempotencyKey string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"` // Client-chosen key; a retry with the same key returns the original result
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SaveGameRequest) Reset() {
+	*x = SaveGameRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveGameRequest) ProtoMessage() {}
+
+func (x *SaveGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveGameRequest.ProtoReflect.Descriptor instead.
+func (*SaveGameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SaveGameRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SaveGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *SaveGameRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SaveGameRequest) GetMetadata() *SaveMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *SaveGameRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type SaveGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Save          *GameSave              `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveGameResponse) Reset() {
+	*x = SaveGameResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveGameResponse) ProtoMessage() {}
+
+func (x *SaveGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveGameResponse.ProtoReflect.Descriptor instead.
+func (*SaveGameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *SaveGameResponse) GetSave() *GameSave {
+	if x != nil {
+		return x.Save
+	}
+	return nil
+}
+
+type LoadGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	SaveId        string                 `protobuf:"bytes,3,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadGameRequest) Reset() {
+	*x = LoadGameRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadGameRequest) ProtoMessage() {}
+
+func (x *LoadGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadGameRequest.ProtoReflect.Descriptor instead.
+func (*LoadGameRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *LoadGameRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *LoadGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *LoadGameRequest) GetSaveId() string {
+	if x != nil {
+		return x.SaveId
+	}
+	return ""
+}
+
+type LoadGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Save          *GameSave              `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LoadGameResponse) Reset() {
+	*x = LoadGameResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LoadGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LoadGameResponse) ProtoMessage() {}
+
+func (x *LoadGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LoadGameResponse.ProtoReflect.Descriptor instead.
+func (*LoadGameResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *LoadGameResponse) GetSave() *GameSave {
+	if x != nil {
+		return x.Save
+	}
+	return nil
+}
+
+type DeleteSaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	SaveId        string                 `protobuf:"bytes,3,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSaveRequest) Reset() {
+	*x = DeleteSaveRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSaveRequest) ProtoMessage() {}
+
+func (x *DeleteSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSaveRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSaveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DeleteSaveRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *DeleteSaveRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *DeleteSaveRequest) GetSaveId() string {
+	if x != nil {
+		return x.SaveId
+	}
+	return ""
+}
+
+type DeleteSaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSaveResponse) Reset() {
+	*x = DeleteSaveResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSaveResponse) ProtoMessage() {}
+
+func (x *DeleteSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSaveResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSaveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DeleteSaveResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ListSavesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Status        SaveStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.SaveStatus" json:"status,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavesRequest) Reset() {
+	*x = ListSavesRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavesRequest) ProtoMessage() {}
+
+func (x *ListSavesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavesRequest.ProtoReflect.Descriptor instead.
+func (*ListSavesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ListSavesRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListSavesRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ListSavesRequest) GetStatus() SaveStatus {
+	if x != nil {
+		return x.Status
+	}
+	return SaveStatus_SAVE_STATUS_UNSPECIFIED
+}
+
+func (x *ListSavesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListSavesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListSavesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Saves         []*GameSave            `protobuf:"bytes,1,rep,name=saves,proto3" json:"saves,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSavesResponse) Reset() {
+	*x = ListSavesResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSavesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSavesResponse) ProtoMessage() {}
+
+func (x *ListSavesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSavesResponse.ProtoReflect.Descriptor instead.
+func (*ListSavesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ListSavesResponse) GetSaves() []*GameSave {
+	if x != nil {
+		return x.Saves
+	}
+	return nil
+}
+
+func (x *ListSavesResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// ExportSaveRequest packages a save into a portable bundle for another server
+type ExportSaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SaveId        string                 `protobuf:"bytes,2,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"` // Recorded in the bundle for the importing server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSaveRequest) Reset() {
+	*x = ExportSaveRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSaveRequest) ProtoMessage() {}
+
+func (x *ExportSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSaveRequest.ProtoReflect.Descriptor instead.
+func (*ExportSaveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ExportSaveRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ExportSaveRequest) GetSaveId() string {
+	if x != nil {
+		return x.SaveId
+	}
+	return ""
+}
+
+func (x *ExportSaveRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type ExportSaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundle        []byte                 `protobuf:"bytes,1,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	GameVersion   string                 `protobuf:"bytes,3,opt,name=game_version,json=gameVersion,proto3" json:"game_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportSaveResponse) Reset() {
+	*x = ExportSaveResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportSaveResponse) ProtoMessage() {}
+
+func (x *ExportSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportSaveResponse.ProtoReflect.Descriptor instead.
+func (*ExportSaveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ExportSaveResponse) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ExportSaveResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *ExportSaveResponse) GetGameVersion() string {
+	if x != nil {
+		return x.GameVersion
+	}
+	return ""
+}
+
+// ImportSaveRequest stores a bundle exported by this or another server as the
+// user's save for its game
+type ImportSaveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Bundle        []byte                 `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"` // Matched against the bundle owner from other servers
+	Admin         bool                   `protobuf:"varint,4,opt,name=admin,proto3" json:"admin,omitempty"`      // Set when an admin imports a bundle owned by another user
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSaveRequest) Reset() {
+	*x = ImportSaveRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSaveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSaveRequest) ProtoMessage() {}
+
+func (x *ImportSaveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSaveRequest.ProtoReflect.Descriptor instead.
+func (*ImportSaveRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ImportSaveRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ImportSaveRequest) GetBundle() []byte {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+func (x *ImportSaveRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *ImportSaveRequest) GetAdmin() bool {
+	if x != nil {
+		return x.Admin
+	}
+	return false
+}
+
+type ImportSaveResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Save          *GameSave              `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	SourceServer  string                 `protobuf:"bytes,2,opt,name=source_server,json=sourceServer,proto3" json:"source_server,omitempty"`
+	Signed        bool                   `protobuf:"varint,3,opt,name=signed,proto3" json:"signed,omitempty"` // Whether the bundle was signed by a trusted server
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportSaveResponse) Reset() {
+	*x = ImportSaveResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportSaveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportSaveResponse) ProtoMessage() {}
+
+func (x *ImportSaveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportSaveResponse.ProtoReflect.Descriptor instead.
+func (*ImportSaveResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ImportSaveResponse) GetSave() *GameSave {
+	if x != nil {
+		return x.Save
+	}
+	return nil
+}
+
+func (x *ImportSaveResponse) GetSourceServer() string {
+	if x != nil {
+		return x.SourceServer
+	}
+	return ""
+}
+
+func (x *ImportSaveResponse) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+// MergeUserDataRequest moves a user's finished sessions, dumplogs and saves
+// to another user, after the auth service merged their accounts
+type MergeUserDataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FromUserId    int32                  `protobuf:"varint,1,opt,name=from_user_id,json=fromUserId,proto3" json:"from_user_id,omitempty"`
+	ToUserId      int32                  `protobuf:"varint,2,opt,name=to_user_id,json=toUserId,proto3" json:"to_user_id,omitempty"`
+	ToUsername    string                 `protobuf:"bytes,3,opt,name=to_username,json=toUsername,proto3" json:"to_username,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // Count what would move without moving it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeUserDataRequest) Reset() {
+	*x = MergeUserDataRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUserDataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUserDataRequest) ProtoMessage() {}
+
+func (x *MergeUserDataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUserDataRequest.ProtoReflect.Descriptor instead.
+func (*MergeUserDataRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MergeUserDataRequest) GetFromUserId() int32 {
+	if x != nil {
+		return x.FromUserId
+	}
+	return 0
+}
+
+func (x *MergeUserDataRequest) GetToUserId() int32 {
+	if x != nil {
+		return x.ToUserId
+	}
+	return 0
+}
+
+func (x *MergeUserDataRequest) GetToUsername() string {
+	if x != nil {
+		return x.ToUsername
+	}
+	return ""
+}
+
+func (x *MergeUserDataRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type MergeUserDataResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      int32                  `protobuf:"varint,1,opt,name=sessions,proto3" json:"sessions,omitempty"` // Finished sessions, with their outcomes and recordings
+	Recordings    int32                  `protobuf:"varint,2,opt,name=recordings,proto3" json:"recordings,omitempty"`
+	Dumplogs      int32                  `protobuf:"varint,3,opt,name=dumplogs,proto3" json:"dumplogs,omitempty"`
+	Saves         int32                  `protobuf:"varint,4,opt,name=saves,proto3" json:"saves,omitempty"`
+	SkippedSaves  int32                  `protobuf:"varint,5,opt,name=skipped_saves,json=skippedSaves,proto3" json:"skipped_saves,omitempty"` // Left behind: the other user has a save for the game, or they failed verification
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MergeUserDataResponse) Reset() {
+	*x = MergeUserDataResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MergeUserDataResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MergeUserDataResponse) ProtoMessage() {}
+
+func (x *MergeUserDataResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MergeUserDataResponse.ProtoReflect.Descriptor instead.
+func (*MergeUserDataResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *MergeUserDataResponse) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *MergeUserDataResponse) GetRecordings() int32 {
+	if x != nil {
+		return x.Recordings
+	}
+	return 0
+}
+
+func (x *MergeUserDataResponse) GetDumplogs() int32 {
+	if x != nil {
+		return x.Dumplogs
+	}
+	return 0
+}
+
+func (x *MergeUserDataResponse) GetSaves() int32 {
+	if x != nil {
+		return x.Saves
+	}
+	return 0
+}
+
+func (x *MergeUserDataResponse) GetSkippedSaves() int32 {
+	if x != nil {
+		return x.SkippedSaves
+	}
+	return 0
+}
+
+// PTY streaming messages
+type GameIORequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Request:
+	//
+	//	*GameIORequest_Connect
+	//	*GameIORequest_Input
+	//	*GameIORequest_Disconnect
+	Request       isGameIORequest_Request `protobuf_oneof:"request"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameIORequest) Reset() {
+	*x = GameIORequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameIORequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameIORequest) ProtoMessage() {}
+
+func (x *GameIORequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameIORequest.ProtoReflect.Descriptor instead.
+func (*GameIORequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *GameIORequest) GetRequest() isGameIORequest_Request {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *GameIORequest) GetConnect() *ConnectPTYRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GameIORequest_Connect); ok {
+			return x.Connect
+		}
+	}
+	return nil
+}
+
+func (x *GameIORequest) GetInput() *PTYInput {
+	if x != nil {
+		if x, ok := x.Request.(*GameIORequest_Input); ok {
+			return x.Input
+		}
+	}
+	return nil
+}
+
+func (x *GameIORequest) GetDisconnect() *DisconnectPTYRequest {
+	if x != nil {
+		if x, ok := x.Request.(*GameIORequest_Disconnect); ok {
+			return x.Disconnect
+		}
+	}
+	return nil
+}
+
+type isGameIORequest_Request interface {
+	isGameIORequest_Request()
+}
+
+type GameIORequest_Connect struct {
+	Connect *ConnectPTYRequest `protobuf:"bytes,1,opt,name=connect,proto3,oneof"`
+}
+
+type GameIORequest_Input struct {
+	Input *PTYInput `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+}
+
+type GameIORequest_Disconnect struct {
+	Disconnect *DisconnectPTYRequest `protobuf:"bytes,3,opt,name=disconnect,proto3,oneof"`
+}
+
+func (*GameIORequest_Connect) isGameIORequest_Request() {}
+
+func (*GameIORequest_Input) isGameIORequest_Request() {}
+
+func (*GameIORequest_Disconnect) isGameIORequest_Request() {}
+
+type GameIOResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Response:
+	//
+	//	*GameIOResponse_Connected
+	//	*GameIOResponse_Output
+	//	*GameIOResponse_Event
+	//	*GameIOResponse_Disconnected
+	Response      isGameIOResponse_Response `protobuf_oneof:"response"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameIOResponse) Reset() {
+	*x = GameIOResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameIOResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameIOResponse) ProtoMessage() {}
+
+func (x *GameIOResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameIOResponse.ProtoReflect.Descriptor instead.
+func (*GameIOResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GameIOResponse) GetResponse() isGameIOResponse_Response {
+	if x != nil {
+		return x.Response
+	}
+	return nil
+}
+
+func (x *GameIOResponse) GetConnected() *ConnectPTYResponse {
+	if x != nil {
+		if x, ok := x.Response.(*GameIOResponse_Connected); ok {
+			return x.Connected
+		}
+	}
+	return nil
+}
+
+func (x *GameIOResponse) GetOutput() *PTYOutput {
+	if x != nil {
+		if x, ok := x.Response.(*GameIOResponse_Output); ok {
+			return x.Output
+		}
+	}
+	return nil
+}
+
+func (x *GameIOResponse) GetEvent() *PTYEvent {
+	if x != nil {
+		if x, ok := x.Response.(*GameIOResponse_Event); ok {
+			return x.Event
+		}
+	}
+	return nil
+}
+
+func (x *GameIOResponse) GetDisconnected() *DisconnectPTYResponse {
+	if x != nil {
+		if x, ok := x.Response.(*GameIOResponse_Disconnected); ok {
+			return x.Disconnected
+		}
+	}
+	return nil
+}
+
+type isGameIOResponse_Response interface {
+	isGameIOResponse_Response()
+}
+
+type GameIOResponse_Connected struct {
+	Connected *ConnectPTYResponse `protobuf:"bytes,1,opt,name=connected,proto3,oneof"`
+}
+
+type GameIOResponse_Output struct {
+	Output *PTYOutput `protobuf:"bytes,2,opt,name=output,proto3,oneof"`
+}
+
+type GameIOResponse_Event struct {
+	Event *PTYEvent `protobuf:"bytes,3,opt,name=event,proto3,oneof"`
+}
+
+type GameIOResponse_Disconnected struct {
+	Disconnected *DisconnectPTYResponse `protobuf:"bytes,4,opt,name=disconnected,proto3,oneof"`
+}
+
+func (*GameIOResponse_Connected) isGameIOResponse_Response() {}
+
+func (*GameIOResponse_Output) isGameIOResponse_Response() {}
+
+func (*GameIOResponse_Event) isGameIOResponse_Response() {}
+
+func (*GameIOResponse_Disconnected) isGameIOResponse_Response() {}
+
+type ConnectPTYRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	SessionId    string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	TerminalSize *TerminalSize          `protobuf:"bytes,2,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
+	TermType     string                 `protobuf:"bytes,3,opt,name=term_type,json=termType,proto3" json:"term_type,omitempty"`
+	// Spectator streams only receive output, and are told when the player
+	// disconnects or the game ends
+	Spectator     bool `protobuf:"varint,4,opt,name=spectator,proto3" json:"spectator,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectPTYRequest) Reset() {
+	*x = ConnectPTYRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectPTYRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectPTYRequest) ProtoMessage() {}
+
+func (x *ConnectPTYRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectPTYRequest.ProtoReflect.Descriptor instead.
+func (*ConnectPTYRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ConnectPTYRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *ConnectPTYRequest) GetTerminalSize() *TerminalSize {
+	if x != nil {
+		return x.TerminalSize
+	}
+	return nil
+}
+
+func (x *ConnectPTYRequest) GetTermType() string {
+	if x != nil {
+		return x.TermType
+	}
+	return ""
+}
+
+func (x *ConnectPTYRequest) GetSpectator() bool {
+	if x != nil {
+		return x.Spectator
+	}
+	return false
+}
+
+type ConnectPTYResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	PtyId         string                 `protobuf:"bytes,3,opt,name=pty_id,json=ptyId,proto3" json:"pty_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartGameSessionRequest) Reset() {
-	*x = StartGameSessionRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[25]
+func (x *ConnectPTYResponse) Reset() {
+	*x = ConnectPTYResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartGameSessionRequest) String() string {
+func (x *ConnectPTYResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartGameSessionRequest) ProtoMessage() {}
+func (*ConnectPTYResponse) ProtoMessage() {}
 
-func (x *StartGameSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[25]
+func (x *ConnectPTYResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2159,82 +5145,107 @@ func (x *StartGameSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartGameSessionRequest.ProtoReflect.Descriptor instead.
-func (*StartGameSessionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use ConnectPTYResponse.ProtoReflect.Descriptor instead.
+func (*ConnectPTYResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *StartGameSessionRequest) GetUserId() int32 {
+func (x *ConnectPTYResponse) GetSuccess() bool {
 	if x != nil {
-		return x.UserId
+		return x.Success
 	}
-	return 0
+	return false
 }
 
-func (x *StartGameSessionRequest) GetUsername() string {
+func (x *ConnectPTYResponse) GetError() string {
 	if x != nil {
-		return x.Username
+		return x.Error
 	}
 	return ""
 }
 
-func (x *StartGameSessionRequest) GetGameId() string {
+func (x *ConnectPTYResponse) GetPtyId() string {
 	if x != nil {
-		return x.GameId
+		return x.PtyId
 	}
 	return ""
 }
 
-func (x *StartGameSessionRequest) GetTerminalSize() *TerminalSize {
-	if x != nil {
-		return x.TerminalSize
-	}
-	return nil
+type PTYInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartGameSessionRequest) GetEnableRecording() bool {
+func (x *PTYInput) Reset() {
+	*x = PTYInput{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PTYInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PTYInput) ProtoMessage() {}
+
+func (x *PTYInput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[65]
 	if x != nil {
-		return x.EnableRecording
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *StartGameSessionRequest) GetEnableStreaming() bool {
+// Deprecated: Use PTYInput.ProtoReflect.Descriptor instead.
+func (*PTYInput) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *PTYInput) GetSessionId() string {
 	if x != nil {
-		return x.EnableStreaming
+		return x.SessionId
 	}
-	return false
+	return ""
 }
 
-func (x *StartGameSessionRequest) GetEnableEncryption() bool {
+func (x *PTYInput) GetData() []byte {
 	if x != nil {
-		return x.EnableEncryption
+		return x.Data
 	}
-	return false
+	return nil
 }
 
-type StartGameSessionResponse struct {
+type PTYOutput struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Session       *GameSession           `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StartGameSessionResponse) Reset() {
-	*x = StartGameSessionResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[26]
+func (x *PTYOutput) Reset() {
+	*x = PTYOutput{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StartGameSessionResponse) String() string {
+func (x *PTYOutput) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StartGameSessionResponse) ProtoMessage() {}
+func (*PTYOutput) ProtoMessage() {}
 
-func (x *StartGameSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[26]
+func (x *PTYOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,42 +5256,50 @@ func (x *StartGameSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StartGameSessionResponse.ProtoReflect.Descriptor instead.
-func (*StartGameSessionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use PTYOutput.ProtoReflect.Descriptor instead.
+func (*PTYOutput) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *StartGameSessionResponse) GetSession() *GameSession {
+func (x *PTYOutput) GetSessionId() string {
 	if x != nil {
-		return x.Session
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PTYOutput) GetData() []byte {
+	if x != nil {
+		return x.Data
 	}
 	return nil
 }
 
-type StopGameSessionRequest struct {
+type PTYEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
-	Force         bool                   `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
+	Type          PTYEventType           `protobuf:"varint,2,opt,name=type,proto3,enum=dungeongate.games.v2.PTYEventType" json:"type,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopGameSessionRequest) Reset() {
-	*x = StopGameSessionRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[27]
+func (x *PTYEvent) Reset() {
+	*x = PTYEvent{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopGameSessionRequest) String() string {
+func (x *PTYEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopGameSessionRequest) ProtoMessage() {}
+func (*PTYEvent) ProtoMessage() {}
 
-func (x *StopGameSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[27]
+func (x *PTYEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2291,54 +5310,62 @@ func (x *StopGameSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopGameSessionRequest.ProtoReflect.Descriptor instead.
-func (*StopGameSessionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use PTYEvent.ProtoReflect.Descriptor instead.
+func (*PTYEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *StopGameSessionRequest) GetSessionId() string {
+func (x *PTYEvent) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *StopGameSessionRequest) GetReason() string {
+func (x *PTYEvent) GetType() PTYEventType {
 	if x != nil {
-		return x.Reason
+		return x.Type
+	}
+	return PTYEventType_PTY_EVENT_UNSPECIFIED
+}
+
+func (x *PTYEvent) GetMessage() string {
+	if x != nil {
+		return x.Message
 	}
 	return ""
 }
 
-func (x *StopGameSessionRequest) GetForce() bool {
+func (x *PTYEvent) GetMetadata() map[string]string {
 	if x != nil {
-		return x.Force
+		return x.Metadata
 	}
-	return false
+	return nil
 }
 
-type StopGameSessionResponse struct {
+type DisconnectPTYRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopGameSessionResponse) Reset() {
-	*x = StopGameSessionResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[28]
+func (x *DisconnectPTYRequest) Reset() {
+	*x = DisconnectPTYRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[68]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopGameSessionResponse) String() string {
+func (x *DisconnectPTYRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopGameSessionResponse) ProtoMessage() {}
+func (*DisconnectPTYRequest) ProtoMessage() {}
 
-func (x *StopGameSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[28]
+func (x *DisconnectPTYRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[68]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2349,40 +5376,102 @@ func (x *StopGameSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopGameSessionResponse.ProtoReflect.Descriptor instead.
-func (*StopGameSessionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use DisconnectPTYRequest.ProtoReflect.Descriptor instead.
+func (*DisconnectPTYRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{68}
 }
 
-func (x *StopGameSessionResponse) GetSuccess() bool {
+func (x *DisconnectPTYRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *DisconnectPTYRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type DisconnectPTYResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	// Why the game service ended the stream, e.g. because it is shutting down;
+	// empty when the game ended or the client disconnected
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DisconnectPTYResponse) Reset() {
+	*x = DisconnectPTYResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DisconnectPTYResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DisconnectPTYResponse) ProtoMessage() {}
+
+func (x *DisconnectPTYResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DisconnectPTYResponse.ProtoReflect.Descriptor instead.
+func (*DisconnectPTYResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DisconnectPTYResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-type GetGameSessionRequest struct {
+func (x *DisconnectPTYResponse) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ResizeTerminalRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	NewSize       *TerminalSize          `protobuf:"bytes,2,opt,name=new_size,json=newSize,proto3" json:"new_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGameSessionRequest) Reset() {
-	*x = GetGameSessionRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[29]
+func (x *ResizeTerminalRequest) Reset() {
+	*x = ResizeTerminalRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[70]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGameSessionRequest) String() string {
+func (x *ResizeTerminalRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGameSessionRequest) ProtoMessage() {}
+func (*ResizeTerminalRequest) ProtoMessage() {}
 
-func (x *GetGameSessionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[29]
+func (x *ResizeTerminalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[70]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2393,40 +5482,48 @@ func (x *GetGameSessionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGameSessionRequest.ProtoReflect.Descriptor instead.
-func (*GetGameSessionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use ResizeTerminalRequest.ProtoReflect.Descriptor instead.
+func (*ResizeTerminalRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{70}
 }
 
-func (x *GetGameSessionRequest) GetSessionId() string {
+func (x *ResizeTerminalRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-type GetGameSessionResponse struct {
+func (x *ResizeTerminalRequest) GetNewSize() *TerminalSize {
+	if x != nil {
+		return x.NewSize
+	}
+	return nil
+}
+
+type ResizeTerminalResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Session       *GameSession           `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGameSessionResponse) Reset() {
-	*x = GetGameSessionResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[30]
+func (x *ResizeTerminalResponse) Reset() {
+	*x = ResizeTerminalResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[71]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGameSessionResponse) String() string {
+func (x *ResizeTerminalResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGameSessionResponse) ProtoMessage() {}
+func (*ResizeTerminalResponse) ProtoMessage() {}
 
-func (x *GetGameSessionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[30]
+func (x *ResizeTerminalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[71]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2437,44 +5534,49 @@ func (x *GetGameSessionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGameSessionResponse.ProtoReflect.Descriptor instead.
-func (*GetGameSessionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ResizeTerminalResponse.ProtoReflect.Descriptor instead.
+func (*ResizeTerminalResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{71}
 }
 
-func (x *GetGameSessionResponse) GetSession() *GameSession {
+func (x *ResizeTerminalResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Session
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type ListGameSessionsRequest struct {
+func (x *ResizeTerminalResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type UpdateStatusLineRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Status        SessionStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.SessionStatus" json:"status,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`            // The session's player
+	ScreenRows    []string               `protobuf:"bytes,3,rep,name=screen_rows,json=screenRows,proto3" json:"screen_rows,omitempty"` // Bottom rows of the player's screen as plain text, top to bottom
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGameSessionsRequest) Reset() {
-	*x = ListGameSessionsRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[31]
+func (x *UpdateStatusLineRequest) Reset() {
+	*x = UpdateStatusLineRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGameSessionsRequest) String() string {
+func (x *UpdateStatusLineRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGameSessionsRequest) ProtoMessage() {}
+func (*UpdateStatusLineRequest) ProtoMessage() {}
 
-func (x *ListGameSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[31]
+func (x *UpdateStatusLineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2485,69 +5587,54 @@ func (x *ListGameSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGameSessionsRequest.ProtoReflect.Descriptor instead.
-func (*ListGameSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{31}
-}
-
-func (x *ListGameSessionsRequest) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+// Deprecated: Use UpdateStatusLineRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStatusLineRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *ListGameSessionsRequest) GetGameId() string {
+func (x *UpdateStatusLineRequest) GetSessionId() string {
 	if x != nil {
-		return x.GameId
+		return x.SessionId
 	}
 	return ""
 }
 
-func (x *ListGameSessionsRequest) GetStatus() SessionStatus {
-	if x != nil {
-		return x.Status
-	}
-	return SessionStatus_SESSION_STATUS_UNSPECIFIED
-}
-
-func (x *ListGameSessionsRequest) GetLimit() int32 {
+func (x *UpdateStatusLineRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Limit
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *ListGameSessionsRequest) GetOffset() int32 {
+func (x *UpdateStatusLineRequest) GetScreenRows() []string {
 	if x != nil {
-		return x.Offset
+		return x.ScreenRows
 	}
-	return 0
+	return nil
 }
 
-type ListGameSessionsResponse struct {
+type UpdateStatusLineResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sessions      []*GameSession         `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	StatusLine    string                 `protobuf:"bytes,1,opt,name=status_line,json=statusLine,proto3" json:"status_line,omitempty"` // The session's status line; rows showing no status keep the last one
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGameSessionsResponse) Reset() {
-	*x = ListGameSessionsResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[32]
+func (x *UpdateStatusLineResponse) Reset() {
+	*x = UpdateStatusLineResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGameSessionsResponse) String() string {
+func (x *UpdateStatusLineResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGameSessionsResponse) ProtoMessage() {}
+func (*UpdateStatusLineResponse) ProtoMessage() {}
 
-func (x *ListGameSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[32]
+func (x *UpdateStatusLineResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2558,51 +5645,45 @@ func (x *ListGameSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGameSessionsResponse.ProtoReflect.Descriptor instead.
-func (*ListGameSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{32}
-}
-
-func (x *ListGameSessionsResponse) GetSessions() []*GameSession {
-	if x != nil {
-		return x.Sessions
-	}
-	return nil
+// Deprecated: Use UpdateStatusLineResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStatusLineResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *ListGameSessionsResponse) GetTotalCount() int32 {
+func (x *UpdateStatusLineResponse) GetStatusLine() string {
 	if x != nil {
-		return x.TotalCount
+		return x.StatusLine
 	}
-	return 0
+	return ""
 }
 
-// Save management requests/responses
-type SaveGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	Metadata      *SaveMetadata          `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// Spectator management requests/responses
+type AddSpectatorRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SpectatorUserId   int32                  `protobuf:"varint,2,opt,name=spectator_user_id,json=spectatorUserId,proto3" json:"spectator_user_id,omitempty"`
+	SpectatorUsername string                 `protobuf:"bytes,3,opt,name=spectator_username,json=spectatorUsername,proto3" json:"spectator_username,omitempty"`
+	BypassLimit       bool                   `protobuf:"varint,4,opt,name=bypass_limit,json=bypassLimit,proto3" json:"bypass_limit,omitempty"`       // Join even when the session has as many spectators as it allows, for admins
+	MaxSpectators     int32                  `protobuf:"varint,5,opt,name=max_spectators,json=maxSpectators,proto3" json:"max_spectators,omitempty"` // Session service's limit, applied with the game's when stricter; 0 for none
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *SaveGameRequest) Reset() {
-	*x = SaveGameRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[33]
+func (x *AddSpectatorRequest) Reset() {
+	*x = AddSpectatorRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveGameRequest) String() string {
+func (x *AddSpectatorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveGameRequest) ProtoMessage() {}
+func (*AddSpectatorRequest) ProtoMessage() {}
 
-func (x *SaveGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[33]
+func (x *AddSpectatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2613,61 +5694,70 @@ func (x *SaveGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveGameRequest.ProtoReflect.Descriptor instead.
-func (*SaveGameRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use AddSpectatorRequest.ProtoReflect.Descriptor instead.
+func (*AddSpectatorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *SaveGameRequest) GetUserId() int32 {
+func (x *AddSpectatorRequest) GetSessionId() string {
 	if x != nil {
-		return x.UserId
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *AddSpectatorRequest) GetSpectatorUserId() int32 {
+	if x != nil {
+		return x.SpectatorUserId
 	}
 	return 0
 }
 
-func (x *SaveGameRequest) GetGameId() string {
+func (x *AddSpectatorRequest) GetSpectatorUsername() string {
 	if x != nil {
-		return x.GameId
+		return x.SpectatorUsername
 	}
 	return ""
 }
 
-func (x *SaveGameRequest) GetData() []byte {
+func (x *AddSpectatorRequest) GetBypassLimit() bool {
 	if x != nil {
-		return x.Data
+		return x.BypassLimit
 	}
-	return nil
+	return false
 }
 
-func (x *SaveGameRequest) GetMetadata() *SaveMetadata {
+func (x *AddSpectatorRequest) GetMaxSpectators() int32 {
 	if x != nil {
-		return x.Metadata
+		return x.MaxSpectators
 	}
-	return nil
+	return 0
 }
 
-type SaveGameResponse struct {
+type AddSpectatorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Save          *GameSave              `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	Spectator     *SpectatorInfo         `protobuf:"bytes,3,opt,name=spectator,proto3" json:"spectator,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SaveGameResponse) Reset() {
-	*x = SaveGameResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[34]
+func (x *AddSpectatorResponse) Reset() {
+	*x = AddSpectatorResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SaveGameResponse) String() string {
+func (x *AddSpectatorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SaveGameResponse) ProtoMessage() {}
+func (*AddSpectatorResponse) ProtoMessage() {}
 
-func (x *SaveGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[34]
+func (x *AddSpectatorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2678,42 +5768,56 @@ func (x *SaveGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SaveGameResponse.ProtoReflect.Descriptor instead.
-func (*SaveGameResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use AddSpectatorResponse.ProtoReflect.Descriptor instead.
+func (*AddSpectatorResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *SaveGameResponse) GetSave() *GameSave {
+func (x *AddSpectatorResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Save
+		return x.Success
+	}
+	return false
+}
+
+func (x *AddSpectatorResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *AddSpectatorResponse) GetSpectator() *SpectatorInfo {
+	if x != nil {
+		return x.Spectator
 	}
 	return nil
 }
 
-type LoadGameRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	SaveId        string                 `protobuf:"bytes,3,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type RemoveSpectatorRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	SessionId       string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	SpectatorUserId int32                  `protobuf:"varint,2,opt,name=spectator_user_id,json=spectatorUserId,proto3" json:"spectator_user_id,omitempty"`
+	Reason          string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Why the spectator left, e.g. "idle" when evicted; recorded with the leave event
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *LoadGameRequest) Reset() {
-	*x = LoadGameRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[35]
+func (x *RemoveSpectatorRequest) Reset() {
+	*x = RemoveSpectatorRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadGameRequest) String() string {
+func (x *RemoveSpectatorRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadGameRequest) ProtoMessage() {}
+func (*RemoveSpectatorRequest) ProtoMessage() {}
 
-func (x *LoadGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[35]
+func (x *RemoveSpectatorRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2724,54 +5828,55 @@ func (x *LoadGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadGameRequest.ProtoReflect.Descriptor instead.
-func (*LoadGameRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use RemoveSpectatorRequest.ProtoReflect.Descriptor instead.
+func (*RemoveSpectatorRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *LoadGameRequest) GetUserId() int32 {
+func (x *RemoveSpectatorRequest) GetSessionId() string {
 	if x != nil {
-		return x.UserId
+		return x.SessionId
 	}
-	return 0
+	return ""
 }
 
-func (x *LoadGameRequest) GetGameId() string {
+func (x *RemoveSpectatorRequest) GetSpectatorUserId() int32 {
 	if x != nil {
-		return x.GameId
+		return x.SpectatorUserId
 	}
-	return ""
+	return 0
 }
 
-func (x *LoadGameRequest) GetSaveId() string {
+func (x *RemoveSpectatorRequest) GetReason() string {
 	if x != nil {
-		return x.SaveId
+		return x.Reason
 	}
 	return ""
 }
 
-type LoadGameResponse struct {
+type RemoveSpectatorResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Save          *GameSave              `protobuf:"bytes,1,opt,name=save,proto3" json:"save,omitempty"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LoadGameResponse) Reset() {
-	*x = LoadGameResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[36]
+func (x *RemoveSpectatorResponse) Reset() {
+	*x = RemoveSpectatorResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[77]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LoadGameResponse) String() string {
+func (x *RemoveSpectatorResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LoadGameResponse) ProtoMessage() {}
+func (*RemoveSpectatorResponse) ProtoMessage() {}
 
-func (x *LoadGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[36]
+func (x *RemoveSpectatorResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[77]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2782,42 +5887,50 @@ func (x *LoadGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LoadGameResponse.ProtoReflect.Descriptor instead.
-func (*LoadGameResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use RemoveSpectatorResponse.ProtoReflect.Descriptor instead.
+func (*RemoveSpectatorResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *LoadGameResponse) GetSave() *GameSave {
+func (x *RemoveSpectatorResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Save
+		return x.Success
 	}
-	return nil
+	return false
 }
 
-type DeleteSaveRequest struct {
+func (x *RemoveSpectatorResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+// Dumplog requests/responses
+type ListDumplogsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	SaveId        string                 `protobuf:"bytes,3,opt,name=save_id,json=saveId,proto3" json:"save_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSaveRequest) Reset() {
-	*x = DeleteSaveRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[37]
+func (x *ListDumplogsRequest) Reset() {
+	*x = ListDumplogsRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[78]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSaveRequest) String() string {
+func (x *ListDumplogsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSaveRequest) ProtoMessage() {}
+func (*ListDumplogsRequest) ProtoMessage() {}
 
-func (x *DeleteSaveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[37]
+func (x *ListDumplogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[78]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2828,54 +5941,54 @@ func (x *DeleteSaveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSaveRequest.ProtoReflect.Descriptor instead.
-func (*DeleteSaveRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use ListDumplogsRequest.ProtoReflect.Descriptor instead.
+func (*ListDumplogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{78}
 }
 
-func (x *DeleteSaveRequest) GetUserId() int32 {
+func (x *ListDumplogsRequest) GetUserId() int32 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *DeleteSaveRequest) GetGameId() string {
+func (x *ListDumplogsRequest) GetGameId() string {
 	if x != nil {
 		return x.GameId
 	}
 	return ""
 }
 
-func (x *DeleteSaveRequest) GetSaveId() string {
+func (x *ListDumplogsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.SaveId
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-type DeleteSaveResponse struct {
+type ListDumplogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Dumplogs      []*Dumplog             `protobuf:"bytes,1,rep,name=dumplogs,proto3" json:"dumplogs,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteSaveResponse) Reset() {
-	*x = DeleteSaveResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[38]
+func (x *ListDumplogsResponse) Reset() {
+	*x = ListDumplogsResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteSaveResponse) String() string {
+func (x *ListDumplogsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteSaveResponse) ProtoMessage() {}
+func (*ListDumplogsResponse) ProtoMessage() {}
 
-func (x *DeleteSaveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[38]
+func (x *ListDumplogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2886,44 +5999,40 @@ func (x *DeleteSaveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteSaveResponse.ProtoReflect.Descriptor instead.
-func (*DeleteSaveResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{38}
+// Deprecated: Use ListDumplogsResponse.ProtoReflect.Descriptor instead.
+func (*ListDumplogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *DeleteSaveResponse) GetSuccess() bool {
+func (x *ListDumplogsResponse) GetDumplogs() []*Dumplog {
 	if x != nil {
-		return x.Success
+		return x.Dumplogs
 	}
-	return false
+	return nil
 }
 
-type ListSavesRequest struct {
+type GetDumplogRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	Status        SaveStatus             `protobuf:"varint,3,opt,name=status,proto3,enum=dungeongate.games.v2.SaveStatus" json:"status,omitempty"`
-	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
-	Offset        int32                  `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	DumplogId     string                 `protobuf:"bytes,1,opt,name=dumplog_id,json=dumplogId,proto3" json:"dumplog_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSavesRequest) Reset() {
-	*x = ListSavesRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[39]
+func (x *GetDumplogRequest) Reset() {
+	*x = GetDumplogRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSavesRequest) String() string {
+func (x *GetDumplogRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSavesRequest) ProtoMessage() {}
+func (*GetDumplogRequest) ProtoMessage() {}
 
-func (x *ListSavesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[39]
+func (x *GetDumplogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2934,69 +6043,40 @@ func (x *ListSavesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSavesRequest.ProtoReflect.Descriptor instead.
-func (*ListSavesRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{39}
-}
-
-func (x *ListSavesRequest) GetUserId() int32 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+// Deprecated: Use GetDumplogRequest.ProtoReflect.Descriptor instead.
+func (*GetDumplogRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *ListSavesRequest) GetGameId() string {
+func (x *GetDumplogRequest) GetDumplogId() string {
 	if x != nil {
-		return x.GameId
+		return x.DumplogId
 	}
 	return ""
 }
 
-func (x *ListSavesRequest) GetStatus() SaveStatus {
-	if x != nil {
-		return x.Status
-	}
-	return SaveStatus_SAVE_STATUS_UNSPECIFIED
-}
-
-func (x *ListSavesRequest) GetLimit() int32 {
-	if x != nil {
-		return x.Limit
-	}
-	return 0
-}
-
-func (x *ListSavesRequest) GetOffset() int32 {
-	if x != nil {
-		return x.Offset
-	}
-	return 0
-}
-
-type ListSavesResponse struct {
+type GetDumplogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Saves         []*GameSave            `protobuf:"bytes,1,rep,name=saves,proto3" json:"saves,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Dumplog       *Dumplog               `protobuf:"bytes,1,opt,name=dumplog,proto3" json:"dumplog,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListSavesResponse) Reset() {
-	*x = ListSavesResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[40]
+func (x *GetDumplogResponse) Reset() {
+	*x = GetDumplogResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListSavesResponse) String() string {
+func (x *GetDumplogResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSavesResponse) ProtoMessage() {}
+func (*GetDumplogResponse) ProtoMessage() {}
 
-func (x *ListSavesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[40]
+func (x *GetDumplogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3007,53 +6087,48 @@ func (x *ListSavesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSavesResponse.ProtoReflect.Descriptor instead.
-func (*ListSavesResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{40}
+// Deprecated: Use GetDumplogResponse.ProtoReflect.Descriptor instead.
+func (*GetDumplogResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *ListSavesResponse) GetSaves() []*GameSave {
+func (x *GetDumplogResponse) GetDumplog() *Dumplog {
 	if x != nil {
-		return x.Saves
+		return x.Dumplog
 	}
 	return nil
 }
 
-func (x *ListSavesResponse) GetTotalCount() int32 {
-	if x != nil {
-		return x.TotalCount
-	}
-	return 0
-}
-
-// PTY streaming messages
-type GameIORequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Request:
-	//
-	//	*GameIORequest_Connect
-	//	*GameIORequest_Input
-	//	*GameIORequest_Disconnect
-	Request       isGameIORequest_Request `protobuf_oneof:"request"`
+// Screenshot requests/responses
+type TakeScreenshotRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Who takes it, the player or a spectator
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Cols          int32                  `protobuf:"varint,4,opt,name=cols,proto3" json:"cols,omitempty"`
+	Rows          int32                  `protobuf:"varint,5,opt,name=rows,proto3" json:"rows,omitempty"`
+	Text          string                 `protobuf:"bytes,6,opt,name=text,proto3" json:"text,omitempty"`
+	Ansi          string                 `protobuf:"bytes,7,opt,name=ansi,proto3" json:"ansi,omitempty"`
+	Html          string                 `protobuf:"bytes,8,opt,name=html,proto3" json:"html,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GameIORequest) Reset() {
-	*x = GameIORequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[41]
+func (x *TakeScreenshotRequest) Reset() {
+	*x = TakeScreenshotRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[82]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameIORequest) String() string {
+func (x *TakeScreenshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameIORequest) ProtoMessage() {}
+func (*TakeScreenshotRequest) ProtoMessage() {}
 
-func (x *GameIORequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[41]
+func (x *TakeScreenshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[82]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3064,95 +6139,89 @@ func (x *GameIORequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameIORequest.ProtoReflect.Descriptor instead.
-func (*GameIORequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{41}
+// Deprecated: Use TakeScreenshotRequest.ProtoReflect.Descriptor instead.
+func (*TakeScreenshotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{82}
 }
 
-func (x *GameIORequest) GetRequest() isGameIORequest_Request {
+func (x *TakeScreenshotRequest) GetSessionId() string {
 	if x != nil {
-		return x.Request
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-func (x *GameIORequest) GetConnect() *ConnectPTYRequest {
+func (x *TakeScreenshotRequest) GetUserId() int32 {
 	if x != nil {
-		if x, ok := x.Request.(*GameIORequest_Connect); ok {
-			return x.Connect
-		}
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *GameIORequest) GetInput() *PTYInput {
+func (x *TakeScreenshotRequest) GetUsername() string {
 	if x != nil {
-		if x, ok := x.Request.(*GameIORequest_Input); ok {
-			return x.Input
-		}
+		return x.Username
 	}
-	return nil
+	return ""
 }
 
-func (x *GameIORequest) GetDisconnect() *DisconnectPTYRequest {
+func (x *TakeScreenshotRequest) GetCols() int32 {
 	if x != nil {
-		if x, ok := x.Request.(*GameIORequest_Disconnect); ok {
-			return x.Disconnect
-		}
+		return x.Cols
 	}
-	return nil
+	return 0
 }
 
-type isGameIORequest_Request interface {
-	isGameIORequest_Request()
+func (x *TakeScreenshotRequest) GetRows() int32 {
+	if x != nil {
+		return x.Rows
+	}
+	return 0
 }
 
-type GameIORequest_Connect struct {
-	Connect *ConnectPTYRequest `protobuf:"bytes,1,opt,name=connect,proto3,oneof"`
+func (x *TakeScreenshotRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
 }
 
-type GameIORequest_Input struct {
-	Input *PTYInput `protobuf:"bytes,2,opt,name=input,proto3,oneof"`
+func (x *TakeScreenshotRequest) GetAnsi() string {
+	if x != nil {
+		return x.Ansi
+	}
+	return ""
 }
 
-type GameIORequest_Disconnect struct {
-	Disconnect *DisconnectPTYRequest `protobuf:"bytes,3,opt,name=disconnect,proto3,oneof"`
+func (x *TakeScreenshotRequest) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
 }
 
-func (*GameIORequest_Connect) isGameIORequest_Request() {}
-
-func (*GameIORequest_Input) isGameIORequest_Request() {}
-
-func (*GameIORequest_Disconnect) isGameIORequest_Request() {}
-
-type GameIOResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Response:
-	//
-	//	*GameIOResponse_Connected
-	//	*GameIOResponse_Output
-	//	*GameIOResponse_Event
-	//	*GameIOResponse_Disconnected
-	Response      isGameIOResponse_Response `protobuf_oneof:"response"`
+type TakeScreenshotResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Screenshot    *Screenshot            `protobuf:"bytes,1,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GameIOResponse) Reset() {
-	*x = GameIOResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[42]
+func (x *TakeScreenshotResponse) Reset() {
+	*x = TakeScreenshotResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[83]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameIOResponse) String() string {
+func (x *TakeScreenshotResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameIOResponse) ProtoMessage() {}
+func (*TakeScreenshotResponse) ProtoMessage() {}
 
-func (x *GameIOResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[42]
+func (x *TakeScreenshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[83]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3163,106 +6232,145 @@ func (x *GameIOResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameIOResponse.ProtoReflect.Descriptor instead.
-func (*GameIOResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{42}
+// Deprecated: Use TakeScreenshotResponse.ProtoReflect.Descriptor instead.
+func (*TakeScreenshotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{83}
 }
 
-func (x *GameIOResponse) GetResponse() isGameIOResponse_Response {
+func (x *TakeScreenshotResponse) GetScreenshot() *Screenshot {
 	if x != nil {
-		return x.Response
+		return x.Screenshot
 	}
 	return nil
 }
 
-func (x *GameIOResponse) GetConnected() *ConnectPTYResponse {
+type ListScreenshotsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int32                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`         // Lists the screenshots they took or of their games
+	SessionId     string                 `protobuf:"bytes,2,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"` // Optionally only those of one session
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScreenshotsRequest) Reset() {
+	*x = ListScreenshotsRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScreenshotsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScreenshotsRequest) ProtoMessage() {}
+
+func (x *ListScreenshotsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[84]
 	if x != nil {
-		if x, ok := x.Response.(*GameIOResponse_Connected); ok {
-			return x.Connected
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GameIOResponse) GetOutput() *PTYOutput {
+// Deprecated: Use ListScreenshotsRequest.ProtoReflect.Descriptor instead.
+func (*ListScreenshotsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *ListScreenshotsRequest) GetUserId() int32 {
 	if x != nil {
-		if x, ok := x.Response.(*GameIOResponse_Output); ok {
-			return x.Output
-		}
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-func (x *GameIOResponse) GetEvent() *PTYEvent {
+func (x *ListScreenshotsRequest) GetSessionId() string {
 	if x != nil {
-		if x, ok := x.Response.(*GameIOResponse_Event); ok {
-			return x.Event
-		}
+		return x.SessionId
 	}
-	return nil
+	return ""
 }
 
-func (x *GameIOResponse) GetDisconnected() *DisconnectPTYResponse {
+func (x *ListScreenshotsRequest) GetLimit() int32 {
 	if x != nil {
-		if x, ok := x.Response.(*GameIOResponse_Disconnected); ok {
-			return x.Disconnected
-		}
+		return x.Limit
 	}
-	return nil
+	return 0
 }
 
-type isGameIOResponse_Response interface {
-	isGameIOResponse_Response()
+type ListScreenshotsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Screenshots   []*Screenshot          `protobuf:"bytes,1,rep,name=screenshots,proto3" json:"screenshots,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type GameIOResponse_Connected struct {
-	Connected *ConnectPTYResponse `protobuf:"bytes,1,opt,name=connected,proto3,oneof"`
+func (x *ListScreenshotsResponse) Reset() {
+	*x = ListScreenshotsResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type GameIOResponse_Output struct {
-	Output *PTYOutput `protobuf:"bytes,2,opt,name=output,proto3,oneof"`
+func (x *ListScreenshotsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type GameIOResponse_Event struct {
-	Event *PTYEvent `protobuf:"bytes,3,opt,name=event,proto3,oneof"`
-}
+func (*ListScreenshotsResponse) ProtoMessage() {}
 
-type GameIOResponse_Disconnected struct {
-	Disconnected *DisconnectPTYResponse `protobuf:"bytes,4,opt,name=disconnected,proto3,oneof"`
+func (x *ListScreenshotsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (*GameIOResponse_Connected) isGameIOResponse_Response() {}
-
-func (*GameIOResponse_Output) isGameIOResponse_Response() {}
-
-func (*GameIOResponse_Event) isGameIOResponse_Response() {}
+// Deprecated: Use ListScreenshotsResponse.ProtoReflect.Descriptor instead.
+func (*ListScreenshotsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{85}
+}
 
-func (*GameIOResponse_Disconnected) isGameIOResponse_Response() {}
+func (x *ListScreenshotsResponse) GetScreenshots() []*Screenshot {
+	if x != nil {
+		return x.Screenshots
+	}
+	return nil
+}
 
-type ConnectPTYRequest struct {
+type GetScreenshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	TerminalSize  *TerminalSize          `protobuf:"bytes,2,opt,name=terminal_size,json=terminalSize,proto3" json:"terminal_size,omitempty"`
-	TermType      string                 `protobuf:"bytes,3,opt,name=term_type,json=termType,proto3" json:"term_type,omitempty"`
+	ScreenshotId  string                 `protobuf:"bytes,1,opt,name=screenshot_id,json=screenshotId,proto3" json:"screenshot_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Viewer: the player or whoever took it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectPTYRequest) Reset() {
-	*x = ConnectPTYRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[43]
+func (x *GetScreenshotRequest) Reset() {
+	*x = GetScreenshotRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectPTYRequest) String() string {
+func (x *GetScreenshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectPTYRequest) ProtoMessage() {}
+func (*GetScreenshotRequest) ProtoMessage() {}
 
-func (x *ConnectPTYRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[43]
+func (x *GetScreenshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3273,56 +6381,47 @@ func (x *ConnectPTYRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectPTYRequest.ProtoReflect.Descriptor instead.
-func (*ConnectPTYRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{43}
+// Deprecated: Use GetScreenshotRequest.ProtoReflect.Descriptor instead.
+func (*GetScreenshotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *ConnectPTYRequest) GetSessionId() string {
+func (x *GetScreenshotRequest) GetScreenshotId() string {
 	if x != nil {
-		return x.SessionId
+		return x.ScreenshotId
 	}
 	return ""
 }
 
-func (x *ConnectPTYRequest) GetTerminalSize() *TerminalSize {
-	if x != nil {
-		return x.TerminalSize
-	}
-	return nil
-}
-
-func (x *ConnectPTYRequest) GetTermType() string {
+func (x *GetScreenshotRequest) GetUserId() int32 {
 	if x != nil {
-		return x.TermType
+		return x.UserId
 	}
-	return ""
+	return 0
 }
 
-type ConnectPTYResponse struct {
+type GetScreenshotResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	PtyId         string                 `protobuf:"bytes,3,opt,name=pty_id,json=ptyId,proto3" json:"pty_id,omitempty"`
+	Screenshot    *Screenshot            `protobuf:"bytes,1,opt,name=screenshot,proto3" json:"screenshot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectPTYResponse) Reset() {
-	*x = ConnectPTYResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[44]
+func (x *GetScreenshotResponse) Reset() {
+	*x = GetScreenshotResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[87]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectPTYResponse) String() string {
+func (x *GetScreenshotResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectPTYResponse) ProtoMessage() {}
+func (*GetScreenshotResponse) ProtoMessage() {}
 
-func (x *ConnectPTYResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[44]
+func (x *GetScreenshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[87]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3333,55 +6432,41 @@ func (x *ConnectPTYResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectPTYResponse.ProtoReflect.Descriptor instead.
-func (*ConnectPTYResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{44}
-}
-
-func (x *ConnectPTYResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
-}
-
-func (x *ConnectPTYResponse) GetError() string {
-	if x != nil {
-		return x.Error
-	}
-	return ""
+// Deprecated: Use GetScreenshotResponse.ProtoReflect.Descriptor instead.
+func (*GetScreenshotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{87}
 }
 
-func (x *ConnectPTYResponse) GetPtyId() string {
+func (x *GetScreenshotResponse) GetScreenshot() *Screenshot {
 	if x != nil {
-		return x.PtyId
+		return x.Screenshot
 	}
-	return ""
+	return nil
 }
 
-type PTYInput struct {
+type ShareScreenshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	ScreenshotId  string                 `protobuf:"bytes,1,opt,name=screenshot_id,json=screenshotId,proto3" json:"screenshot_id,omitempty"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // The player or whoever took it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PTYInput) Reset() {
-	*x = PTYInput{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[45]
+func (x *ShareScreenshotRequest) Reset() {
+	*x = ShareScreenshotRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PTYInput) String() string {
+func (x *ShareScreenshotRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PTYInput) ProtoMessage() {}
+func (*ShareScreenshotRequest) ProtoMessage() {}
 
-func (x *PTYInput) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[45]
+func (x *ShareScreenshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3392,48 +6477,47 @@ func (x *PTYInput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PTYInput.ProtoReflect.Descriptor instead.
-func (*PTYInput) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{45}
+// Deprecated: Use ShareScreenshotRequest.ProtoReflect.Descriptor instead.
+func (*ShareScreenshotRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{88}
 }
 
-func (x *PTYInput) GetSessionId() string {
+func (x *ShareScreenshotRequest) GetScreenshotId() string {
 	if x != nil {
-		return x.SessionId
+		return x.ScreenshotId
 	}
 	return ""
 }
 
-func (x *PTYInput) GetData() []byte {
+func (x *ShareScreenshotRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Data
+		return x.UserId
 	}
-	return nil
+	return 0
 }
 
-type PTYOutput struct {
+type ShareScreenshotResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Screenshot    *Screenshot            `protobuf:"bytes,1,opt,name=screenshot,proto3" json:"screenshot,omitempty"` // With the public link and when it expires
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PTYOutput) Reset() {
-	*x = PTYOutput{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[46]
+func (x *ShareScreenshotResponse) Reset() {
+	*x = ShareScreenshotResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PTYOutput) String() string {
+func (x *ShareScreenshotResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PTYOutput) ProtoMessage() {}
+func (*ShareScreenshotResponse) ProtoMessage() {}
 
-func (x *PTYOutput) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[46]
+func (x *ShareScreenshotResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3444,50 +6528,44 @@ func (x *PTYOutput) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PTYOutput.ProtoReflect.Descriptor instead.
-func (*PTYOutput) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{46}
-}
-
-func (x *PTYOutput) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+// Deprecated: Use ShareScreenshotResponse.ProtoReflect.Descriptor instead.
+func (*ShareScreenshotResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *PTYOutput) GetData() []byte {
+func (x *ShareScreenshotResponse) GetScreenshot() *Screenshot {
 	if x != nil {
-		return x.Data
+		return x.Screenshot
 	}
 	return nil
 }
 
-type PTYEvent struct {
+// Bookmark requests/responses
+type AddBookmarkRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Type          PTYEventType           `protobuf:"varint,2,opt,name=type,proto3,enum=dungeongate.games.v2.PTYEventType" json:"type,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
-	Metadata      map[string]string      `protobuf:"bytes,4,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	UserId        int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Who drops it, the player or a spectator
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"` // Optional, up to 80 characters
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PTYEvent) Reset() {
-	*x = PTYEvent{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[47]
+func (x *AddBookmarkRequest) Reset() {
+	*x = AddBookmarkRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PTYEvent) String() string {
+func (x *AddBookmarkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PTYEvent) ProtoMessage() {}
+func (*AddBookmarkRequest) ProtoMessage() {}
 
-func (x *PTYEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[47]
+func (x *AddBookmarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3498,62 +6576,61 @@ func (x *PTYEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PTYEvent.ProtoReflect.Descriptor instead.
-func (*PTYEvent) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{47}
+// Deprecated: Use AddBookmarkRequest.ProtoReflect.Descriptor instead.
+func (*AddBookmarkRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *PTYEvent) GetSessionId() string {
+func (x *AddBookmarkRequest) GetSessionId() string {
 	if x != nil {
 		return x.SessionId
 	}
 	return ""
 }
 
-func (x *PTYEvent) GetType() PTYEventType {
+func (x *AddBookmarkRequest) GetUserId() int32 {
 	if x != nil {
-		return x.Type
+		return x.UserId
 	}
-	return PTYEventType_PTY_EVENT_UNSPECIFIED
+	return 0
 }
 
-func (x *PTYEvent) GetMessage() string {
+func (x *AddBookmarkRequest) GetUsername() string {
 	if x != nil {
-		return x.Message
+		return x.Username
 	}
 	return ""
 }
 
-func (x *PTYEvent) GetMetadata() map[string]string {
+func (x *AddBookmarkRequest) GetNote() string {
 	if x != nil {
-		return x.Metadata
+		return x.Note
 	}
-	return nil
+	return ""
 }
 
-type DisconnectPTYRequest struct {
+type AddBookmarkResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Bookmark      *Bookmark              `protobuf:"bytes,1,opt,name=bookmark,proto3" json:"bookmark,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DisconnectPTYRequest) Reset() {
-	*x = DisconnectPTYRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[48]
+func (x *AddBookmarkResponse) Reset() {
+	*x = AddBookmarkResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DisconnectPTYRequest) String() string {
+func (x *AddBookmarkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectPTYRequest) ProtoMessage() {}
+func (*AddBookmarkResponse) ProtoMessage() {}
 
-func (x *DisconnectPTYRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[48]
+func (x *AddBookmarkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3564,47 +6641,41 @@ func (x *DisconnectPTYRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectPTYRequest.ProtoReflect.Descriptor instead.
-func (*DisconnectPTYRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{48}
-}
-
-func (x *DisconnectPTYRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+// Deprecated: Use AddBookmarkResponse.ProtoReflect.Descriptor instead.
+func (*AddBookmarkResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *DisconnectPTYRequest) GetReason() string {
+func (x *AddBookmarkResponse) GetBookmark() *Bookmark {
 	if x != nil {
-		return x.Reason
+		return x.Bookmark
 	}
-	return ""
+	return nil
 }
 
-type DisconnectPTYResponse struct {
+type ListBookmarksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	ViewerId      int32                  `protobuf:"varint,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // Private recordings' bookmarks are for their player alone
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DisconnectPTYResponse) Reset() {
-	*x = DisconnectPTYResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[49]
+func (x *ListBookmarksRequest) Reset() {
+	*x = ListBookmarksRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DisconnectPTYResponse) String() string {
+func (x *ListBookmarksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DisconnectPTYResponse) ProtoMessage() {}
+func (*ListBookmarksRequest) ProtoMessage() {}
 
-func (x *DisconnectPTYResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[49]
+func (x *ListBookmarksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3615,41 +6686,47 @@ func (x *DisconnectPTYResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DisconnectPTYResponse.ProtoReflect.Descriptor instead.
-func (*DisconnectPTYResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{49}
+// Deprecated: Use ListBookmarksRequest.ProtoReflect.Descriptor instead.
+func (*ListBookmarksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *DisconnectPTYResponse) GetSuccess() bool {
+func (x *ListBookmarksRequest) GetSessionId() string {
 	if x != nil {
-		return x.Success
+		return x.SessionId
 	}
-	return false
+	return ""
 }
 
-type ResizeTerminalRequest struct {
+func (x *ListBookmarksRequest) GetViewerId() int32 {
+	if x != nil {
+		return x.ViewerId
+	}
+	return 0
+}
+
+type ListBookmarksResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	NewSize       *TerminalSize          `protobuf:"bytes,2,opt,name=new_size,json=newSize,proto3" json:"new_size,omitempty"`
+	Bookmarks     []*Bookmark            `protobuf:"bytes,1,rep,name=bookmarks,proto3" json:"bookmarks,omitempty"` // In recording order
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ResizeTerminalRequest) Reset() {
-	*x = ResizeTerminalRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[50]
+func (x *ListBookmarksResponse) Reset() {
+	*x = ListBookmarksResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResizeTerminalRequest) String() string {
+func (x *ListBookmarksResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResizeTerminalRequest) ProtoMessage() {}
+func (*ListBookmarksResponse) ProtoMessage() {}
 
-func (x *ResizeTerminalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[50]
+func (x *ListBookmarksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3660,48 +6737,44 @@ func (x *ResizeTerminalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResizeTerminalRequest.ProtoReflect.Descriptor instead.
-func (*ResizeTerminalRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{50}
-}
-
-func (x *ResizeTerminalRequest) GetSessionId() string {
-	if x != nil {
-		return x.SessionId
-	}
-	return ""
+// Deprecated: Use ListBookmarksResponse.ProtoReflect.Descriptor instead.
+func (*ListBookmarksResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *ResizeTerminalRequest) GetNewSize() *TerminalSize {
+func (x *ListBookmarksResponse) GetBookmarks() []*Bookmark {
 	if x != nil {
-		return x.NewSize
+		return x.Bookmarks
 	}
 	return nil
 }
 
-type ResizeTerminalResponse struct {
+// Leaderboard requests/responses
+type GetLeaderboardRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	UserIds       []int32                `protobuf:"varint,2,rep,packed,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"` // Only rank these players, e.g. a group; empty ranks everyone
+	SortBy        string                 `protobuf:"bytes,3,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`            // "play_time" (default) or "games"
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
-
-func (x *ResizeTerminalResponse) Reset() {
-	*x = ResizeTerminalResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[51]
+
+func (x *GetLeaderboardRequest) Reset() {
+	*x = GetLeaderboardRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ResizeTerminalResponse) String() string {
+func (x *GetLeaderboardRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ResizeTerminalResponse) ProtoMessage() {}
+func (*GetLeaderboardRequest) ProtoMessage() {}
 
-func (x *ResizeTerminalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[51]
+func (x *GetLeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3712,50 +6785,67 @@ func (x *ResizeTerminalResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ResizeTerminalResponse.ProtoReflect.Descriptor instead.
-func (*ResizeTerminalResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{51}
+// Deprecated: Use GetLeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *ResizeTerminalResponse) GetSuccess() bool {
+func (x *GetLeaderboardRequest) GetGameId() string {
 	if x != nil {
-		return x.Success
+		return x.GameId
 	}
-	return false
+	return ""
 }
 
-func (x *ResizeTerminalResponse) GetError() string {
+func (x *GetLeaderboardRequest) GetUserIds() []int32 {
 	if x != nil {
-		return x.Error
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *GetLeaderboardRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
 	}
 	return ""
 }
 
-// Spectator management requests/responses
-type AddSpectatorRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	SessionId         string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	SpectatorUserId   int32                  `protobuf:"varint,2,opt,name=spectator_user_id,json=spectatorUserId,proto3" json:"spectator_user_id,omitempty"`
-	SpectatorUsername string                 `protobuf:"bytes,3,opt,name=spectator_username,json=spectatorUsername,proto3" json:"spectator_username,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *GetLeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
-func (x *AddSpectatorRequest) Reset() {
-	*x = AddSpectatorRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[52]
+type LeaderboardEntry struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Rank                  int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	UserId                int32                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username              string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	GamesPlayed           int32                  `protobuf:"varint,4,opt,name=games_played,json=gamesPlayed,proto3" json:"games_played,omitempty"`
+	TotalPlayTimeSeconds  int64                  `protobuf:"varint,5,opt,name=total_play_time_seconds,json=totalPlayTimeSeconds,proto3" json:"total_play_time_seconds,omitempty"`
+	LongestSessionSeconds int64                  `protobuf:"varint,6,opt,name=longest_session_seconds,json=longestSessionSeconds,proto3" json:"longest_session_seconds,omitempty"`
+	LastPlayed            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_played,json=lastPlayed,proto3" json:"last_played,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddSpectatorRequest) String() string {
+func (x *LeaderboardEntry) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddSpectatorRequest) ProtoMessage() {}
+func (*LeaderboardEntry) ProtoMessage() {}
 
-func (x *AddSpectatorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[52]
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3766,56 +6856,83 @@ func (x *AddSpectatorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddSpectatorRequest.ProtoReflect.Descriptor instead.
-func (*AddSpectatorRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{52}
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *AddSpectatorRequest) GetSessionId() string {
+func (x *LeaderboardEntry) GetRank() int32 {
 	if x != nil {
-		return x.SessionId
+		return x.Rank
 	}
-	return ""
+	return 0
 }
 
-func (x *AddSpectatorRequest) GetSpectatorUserId() int32 {
+func (x *LeaderboardEntry) GetUserId() int32 {
 	if x != nil {
-		return x.SpectatorUserId
+		return x.UserId
 	}
 	return 0
 }
 
-func (x *AddSpectatorRequest) GetSpectatorUsername() string {
+func (x *LeaderboardEntry) GetUsername() string {
 	if x != nil {
-		return x.SpectatorUsername
+		return x.Username
 	}
 	return ""
 }
 
-type AddSpectatorResponse struct {
+func (x *LeaderboardEntry) GetGamesPlayed() int32 {
+	if x != nil {
+		return x.GamesPlayed
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetTotalPlayTimeSeconds() int64 {
+	if x != nil {
+		return x.TotalPlayTimeSeconds
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLongestSessionSeconds() int64 {
+	if x != nil {
+		return x.LongestSessionSeconds
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLastPlayed() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastPlayed
+	}
+	return nil
+}
+
+type GetLeaderboardResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
-	Spectator     *SpectatorInfo         `protobuf:"bytes,3,opt,name=spectator,proto3" json:"spectator,omitempty"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	TotalPlayers  int32                  `protobuf:"varint,2,opt,name=total_players,json=totalPlayers,proto3" json:"total_players,omitempty"` // Players ranked before the limit was applied
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AddSpectatorResponse) Reset() {
-	*x = AddSpectatorResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[53]
+func (x *GetLeaderboardResponse) Reset() {
+	*x = GetLeaderboardResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AddSpectatorResponse) String() string {
+func (x *GetLeaderboardResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AddSpectatorResponse) ProtoMessage() {}
+func (*GetLeaderboardResponse) ProtoMessage() {}
 
-func (x *AddSpectatorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[53]
+func (x *GetLeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3826,55 +6943,48 @@ func (x *AddSpectatorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AddSpectatorResponse.ProtoReflect.Descriptor instead.
-func (*AddSpectatorResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{53}
-}
-
-func (x *AddSpectatorResponse) GetSuccess() bool {
-	if x != nil {
-		return x.Success
-	}
-	return false
+// Deprecated: Use GetLeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*GetLeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *AddSpectatorResponse) GetError() string {
+func (x *GetLeaderboardResponse) GetEntries() []*LeaderboardEntry {
 	if x != nil {
-		return x.Error
+		return x.Entries
 	}
-	return ""
+	return nil
 }
 
-func (x *AddSpectatorResponse) GetSpectator() *SpectatorInfo {
+func (x *GetLeaderboardResponse) GetTotalPlayers() int32 {
 	if x != nil {
-		return x.Spectator
+		return x.TotalPlayers
 	}
-	return nil
+	return 0
 }
 
-type RemoveSpectatorRequest struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	SessionId       string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
-	SpectatorUserId int32                  `protobuf:"varint,2,opt,name=spectator_user_id,json=spectatorUserId,proto3" json:"spectator_user_id,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type GetCapabilitiesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ApiVersion    int32                  `protobuf:"varint,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`         // Newest games API version the caller speaks
+	ClientVersion string                 `protobuf:"bytes,2,opt,name=client_version,json=clientVersion,proto3" json:"client_version,omitempty"` // Caller's build, for logs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveSpectatorRequest) Reset() {
-	*x = RemoveSpectatorRequest{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[54]
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveSpectatorRequest) String() string {
+func (x *GetCapabilitiesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveSpectatorRequest) ProtoMessage() {}
+func (*GetCapabilitiesRequest) ProtoMessage() {}
 
-func (x *RemoveSpectatorRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[54]
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3885,48 +6995,51 @@ func (x *RemoveSpectatorRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveSpectatorRequest.ProtoReflect.Descriptor instead.
-func (*RemoveSpectatorRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{54}
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *RemoveSpectatorRequest) GetSessionId() string {
+func (x *GetCapabilitiesRequest) GetApiVersion() int32 {
 	if x != nil {
-		return x.SessionId
+		return x.ApiVersion
 	}
-	return ""
+	return 0
 }
 
-func (x *RemoveSpectatorRequest) GetSpectatorUserId() int32 {
+func (x *GetCapabilitiesRequest) GetClientVersion() string {
 	if x != nil {
-		return x.SpectatorUserId
+		return x.ClientVersion
 	}
-	return 0
+	return ""
 }
 
-type RemoveSpectatorResponse struct {
+type GetCapabilitiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	ApiVersion    int32                  `protobuf:"varint,1,opt,name=api_version,json=apiVersion,proto3" json:"api_version,omitempty"`            // Version to use: the newest both sides speak
+	MinApiVersion int32                  `protobuf:"varint,2,opt,name=min_api_version,json=minApiVersion,proto3" json:"min_api_version,omitempty"` // Oldest version the game service serves
+	MaxApiVersion int32                  `protobuf:"varint,3,opt,name=max_api_version,json=maxApiVersion,proto3" json:"max_api_version,omitempty"` // Newest version the game service speaks
+	Features      []string               `protobuf:"bytes,4,rep,name=features,proto3" json:"features,omitempty"`                                   // Optional features offered: resume, multiplexing, recording, screenshots, bookmarks, status_line
+	ServerVersion string                 `protobuf:"bytes,5,opt,name=server_version,json=serverVersion,proto3" json:"server_version,omitempty"`    // Game service build
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RemoveSpectatorResponse) Reset() {
-	*x = RemoveSpectatorResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[55]
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RemoveSpectatorResponse) String() string {
+func (x *GetCapabilitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RemoveSpectatorResponse) ProtoMessage() {}
+func (*GetCapabilitiesResponse) ProtoMessage() {}
 
-func (x *RemoveSpectatorResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[55]
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3937,21 +7050,42 @@ func (x *RemoveSpectatorResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RemoveSpectatorResponse.ProtoReflect.Descriptor instead.
-func (*RemoveSpectatorResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{55}
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{98}
 }
 
-func (x *RemoveSpectatorResponse) GetSuccess() bool {
+func (x *GetCapabilitiesResponse) GetApiVersion() int32 {
 	if x != nil {
-		return x.Success
+		return x.ApiVersion
 	}
-	return false
+	return 0
 }
 
-func (x *RemoveSpectatorResponse) GetError() string {
+func (x *GetCapabilitiesResponse) GetMinApiVersion() int32 {
 	if x != nil {
-		return x.Error
+		return x.MinApiVersion
+	}
+	return 0
+}
+
+func (x *GetCapabilitiesResponse) GetMaxApiVersion() int32 {
+	if x != nil {
+		return x.MaxApiVersion
+	}
+	return 0
+}
+
+func (x *GetCapabilitiesResponse) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *GetCapabilitiesResponse) GetServerVersion() string {
+	if x != nil {
+		return x.ServerVersion
 	}
 	return ""
 }
@@ -3967,7 +7101,7 @@ type HealthResponse struct {
 
 func (x *HealthResponse) Reset() {
 	*x = HealthResponse{}
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[56]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -3979,7 +7113,7 @@ func (x *HealthResponse) String() string {
 func (*HealthResponse) ProtoMessage() {}
 
 func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[56]
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3992,7 +7126,7 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
 func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{56}
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *HealthResponse) GetStatus() string {
@@ -4009,11 +7143,88 @@ func (x *HealthResponse) GetDetails() map[string]string {
 	return nil
 }
 
+// VersionResponse describes the build of a running service
+type VersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Service       string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	BuildTime     string                 `protobuf:"bytes,3,opt,name=build_time,json=buildTime,proto3" json:"build_time,omitempty"`
+	GitCommit     string                 `protobuf:"bytes,4,opt,name=git_commit,json=gitCommit,proto3" json:"git_commit,omitempty"`
+	GoVersion     string                 `protobuf:"bytes,5,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VersionResponse) Reset() {
+	*x = VersionResponse{}
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[100]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VersionResponse) ProtoMessage() {}
+
+func (x *VersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_games_game_service_v2_proto_msgTypes[100]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VersionResponse.ProtoReflect.Descriptor instead.
+func (*VersionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_games_game_service_v2_proto_rawDescGZIP(), []int{100}
+}
+
+func (x *VersionResponse) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetBuildTime() string {
+	if x != nil {
+		return x.BuildTime
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGitCommit() string {
+	if x != nil {
+		return x.GitCommit
+	}
+	return ""
+}
+
+func (x *VersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
 var File_api_proto_games_game_service_v2_proto protoreflect.FileDescriptor
 
 const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\n" +
-	"%api/proto/games/game_service_v2.proto\x12\x14dungeongate.games.v2\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xe1\x06\n" +
+	"%api/proto/games/game_service_v2.proto\x12\x14dungeongate.games.v2\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x87\b\n" +
 	"\x04Game\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
@@ -4041,7 +7252,11 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a>\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1a\n" +
+	"\bversions\x18\x12 \x03(\tR\bversions\x12'\n" +
+	"\x0fdefault_version\x18\x13 \x01(\tR\x0edefaultVersion\x12%\n" +
+	"\x0estatus_message\x18\x14 \x01(\tR\rstatusMessage\x128\n" +
+	"\x06source\x18\x15 \x01(\x0e2 .dungeongate.games.v2.GameSourceR\x06source\x1a>\n" +
 	"\x10EnvironmentEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"c\n" +
@@ -4076,7 +7291,7 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\vlast_played\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"lastPlayed\x12'\n" +
 	"\x0fpopularity_rank\x18\a \x01(\x05R\x0epopularityRank\x12\x16\n" +
-	"\x06rating\x18\b \x01(\x02R\x06rating\"\xd1\x05\n" +
+	"\x06rating\x18\b \x01(\x02R\x06rating\"\xad\t\n" +
 	"\vGameSession\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1a\n" +
@@ -4095,7 +7310,25 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\tstreaming\x18\r \x01(\v2#.dungeongate.games.v2.StreamingInfoR\tstreaming\x12C\n" +
 	"\n" +
 	"spectators\x18\x0e \x03(\v2#.dungeongate.games.v2.SpectatorInfoR\n" +
-	"spectators\"<\n" +
+	"spectators\x12!\n" +
+	"\fgame_version\x18\x0f \x01(\tR\vgameVersion\x12\x1b\n" +
+	"\tclient_ip\x18\x10 \x01(\tR\bclientIp\x12E\n" +
+	"\x11play_time_ends_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\x0eplayTimeEndsAt\x12;\n" +
+	"\aoutcome\x18\x12 \x01(\v2!.dungeongate.games.v2.GameOutcomeR\aoutcome\x12\x1d\n" +
+	"\n" +
+	"dumplog_id\x18\x13 \x01(\tR\tdumplogId\x12'\n" +
+	"\x0fspectator_count\x18\x14 \x01(\x05R\x0espectatorCount\x12%\n" +
+	"\x0emax_spectators\x18\x15 \x01(\x05R\rmaxSpectators\x12:\n" +
+	"\x19spectator_timeout_seconds\x18\x16 \x01(\x03R\x17spectatorTimeoutSeconds\x12\x1f\n" +
+	"\vstatus_line\x18\x17 \x01(\tR\n" +
+	"statusLine\x12J\n" +
+	"\x0eresource_usage\x18\x18 \x01(\v2#.dungeongate.games.v2.ResourceUsageR\rresourceUsage\"\x87\x01\n" +
+	"\vGameOutcome\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\x12\x14\n" +
+	"\x05death\x18\x02 \x01(\tR\x05death\x12\x16\n" +
+	"\x06points\x18\x03 \x01(\x03R\x06points\x12\x14\n" +
+	"\x05turns\x18\x04 \x01(\x03R\x05turns\x12\x1c\n" +
+	"\tcharacter\x18\x05 \x01(\tR\tcharacter\"<\n" +
 	"\fTerminalSize\x12\x14\n" +
 	"\x05width\x18\x01 \x01(\x05R\x05width\x12\x16\n" +
 	"\x06height\x18\x02 \x01(\x05R\x06height\"\x92\x01\n" +
@@ -4104,7 +7337,14 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\fcontainer_id\x18\x02 \x01(\tR\vcontainerId\x12\x19\n" +
 	"\bpod_name\x18\x03 \x01(\tR\apodName\x12\x1b\n" +
 	"\texit_code\x18\x04 \x01(\x05R\bexitCode\x12\x16\n" +
-	"\x06signal\x18\x05 \x01(\tR\x06signal\"\xd6\x01\n" +
+	"\x06signal\x18\x05 \x01(\tR\x06signal\"\xab\x01\n" +
+	"\rResourceUsage\x12\x1f\n" +
+	"\vcpu_seconds\x18\x01 \x01(\x01R\n" +
+	"cpuSeconds\x12\x1b\n" +
+	"\trss_bytes\x18\x02 \x01(\x04R\brssBytes\x12\x12\n" +
+	"\x04pids\x18\x03 \x01(\x04R\x04pids\x12\"\n" +
+	"\rio_read_bytes\x18\x04 \x01(\x04R\vioReadBytes\x12$\n" +
+	"\x0eio_write_bytes\x18\x05 \x01(\x04R\fioWriteBytes\"\xf0\x01\n" +
 	"\rRecordingInfo\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1b\n" +
 	"\tfile_path\x18\x02 \x01(\tR\bfilePath\x12\x16\n" +
@@ -4114,7 +7354,8 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\tfile_size\x18\x05 \x01(\x03R\bfileSize\x12\x1e\n" +
 	"\n" +
 	"compressed\x18\x06 \x01(\bR\n" +
-	"compressed\"\xab\x01\n" +
+	"compressed\x12\x18\n" +
+	"\aprivate\x18\a \x01(\bR\aprivate\"\xab\x01\n" +
 	"\rStreamingInfo\x12\x18\n" +
 	"\aenabled\x18\x01 \x01(\bR\aenabled\x12\x1a\n" +
 	"\bprotocol\x18\x02 \x01(\tR\bprotocol\x12\x1c\n" +
@@ -4128,7 +7369,7 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\tjoin_time\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bjoinTime\x12\x1d\n" +
 	"\n" +
 	"bytes_sent\x18\x04 \x01(\x03R\tbytesSent\x12\x1b\n" +
-	"\tis_active\x18\x05 \x01(\bR\bisActive\"\xe2\x03\n" +
+	"\tis_active\x18\x05 \x01(\bR\bisActive\"\x9f\x04\n" +
 	"\bGameSave\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x17\n" +
@@ -4144,7 +7385,9 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xdf\x02\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vverified_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"verifiedAt\"\xdf\x02\n" +
 	"\fSaveMetadata\x12!\n" +
 	"\fgame_version\x18\x01 \x01(\tR\vgameVersion\x12\x1c\n" +
 	"\tcharacter\x18\x02 \x01(\tR\tcharacter\x12\x14\n" +
@@ -4163,7 +7406,49 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
 	"\tfile_size\x18\x04 \x01(\x03R\bfileSize\x12\x1a\n" +
-	"\bchecksum\x18\x05 \x01(\tR\bchecksum\"\xcb\x01\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\"\xf1\x01\n" +
+	"\aDumplog\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x17\n" +
+	"\agame_id\x18\x05 \x01(\tR\x06gameId\x12\x12\n" +
+	"\x04size\x18\x06 \x01(\x03R\x04size\x12\x18\n" +
+	"\acontent\x18\a \x01(\fR\acontent\x12;\n" +
+	"\vcaptured_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"capturedAt\"\xf0\x03\n" +
+	"\n" +
+	"Screenshot\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x04 \x01(\tR\busername\x12\x17\n" +
+	"\agame_id\x18\x05 \x01(\tR\x06gameId\x12'\n" +
+	"\x10taken_by_user_id\x18\x06 \x01(\x05R\rtakenByUserId\x12*\n" +
+	"\x11taken_by_username\x18\a \x01(\tR\x0ftakenByUsername\x12\x12\n" +
+	"\x04cols\x18\b \x01(\x05R\x04cols\x12\x12\n" +
+	"\x04rows\x18\t \x01(\x05R\x04rows\x12\x12\n" +
+	"\x04size\x18\n" +
+	" \x01(\x03R\x04size\x125\n" +
+	"\btaken_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\atakenAt\x12\x12\n" +
+	"\x04text\x18\f \x01(\tR\x04text\x12\x12\n" +
+	"\x04ansi\x18\r \x01(\tR\x04ansi\x12\x12\n" +
+	"\x04html\x18\x0e \x01(\tR\x04html\x12\x1b\n" +
+	"\tshare_url\x18\x0f \x01(\tR\bshareUrl\x12D\n" +
+	"\x10share_expires_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0eshareExpiresAt\"\x9b\x02\n" +
+	"\bBookmark\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x05R\x06userId\x12+\n" +
+	"\x12created_by_user_id\x18\x04 \x01(\x05R\x0fcreatedByUserId\x12.\n" +
+	"\x13created_by_username\x18\x05 \x01(\tR\x11createdByUsername\x12\x1b\n" +
+	"\toffset_ms\x18\x06 \x01(\x03R\boffsetMs\x12\x12\n" +
+	"\x04note\x18\a \x01(\tR\x04note\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xcb\x01\n" +
 	"\x10ListGamesRequest\x12\x1a\n" +
 	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x10\n" +
 	"\x03tag\x18\x02 \x01(\tR\x03tag\x128\n" +
@@ -4178,20 +7463,37 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\x0eGetGameRequest\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\"A\n" +
 	"\x0fGetGameResponse\x12.\n" +
-	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"C\n" +
+	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"c\n" +
 	"\x11CreateGameRequest\x12.\n" +
-	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"D\n" +
+	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x02 \x01(\tR\n" +
+	"definition\"D\n" +
 	"\x12CreateGameResponse\x12.\n" +
-	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"\\\n" +
+	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"|\n" +
 	"\x11UpdateGameRequest\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12.\n" +
-	"\x04game\x18\x02 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"D\n" +
+	"\x04game\x18\x02 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x03 \x01(\tR\n" +
+	"definition\"D\n" +
 	"\x12UpdateGameResponse\x12.\n" +
 	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\",\n" +
 	"\x11DeleteGameRequest\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\".\n" +
 	"\x12DeleteGameResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"\xb3\x02\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"C\n" +
+	"\x12ExportGamesRequest\x12-\n" +
+	"\x12include_configured\x18\x01 \x01(\bR\x11includeConfigured\"?\n" +
+	"\x13ExportGamesResponse\x12\x12\n" +
+	"\x04yaml\x18\x01 \x01(\tR\x04yaml\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\"\x83\x01\n" +
+	"\x14SetGameStatusRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x128\n" +
+	"\x06status\x18\x02 \x01(\x0e2 .dungeongate.games.v2.GameStatusR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"G\n" +
+	"\x15SetGameStatusResponse\x12.\n" +
+	"\x04game\x18\x01 \x01(\v2\x1a.dungeongate.games.v2.GameR\x04game\"\xd8\x04\n" +
 	"\x17StartGameSessionRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x17\n" +
@@ -4199,36 +7501,73 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\rterminal_size\x18\x04 \x01(\v2\".dungeongate.games.v2.TerminalSizeR\fterminalSize\x12)\n" +
 	"\x10enable_recording\x18\x05 \x01(\bR\x0fenableRecording\x12)\n" +
 	"\x10enable_streaming\x18\x06 \x01(\bR\x0fenableStreaming\x12+\n" +
-	"\x11enable_encryption\x18\a \x01(\bR\x10enableEncryption\"W\n" +
+	"\x11enable_encryption\x18\a \x01(\bR\x10enableEncryption\x12\x1b\n" +
+	"\tterm_type\x18\b \x01(\tR\btermType\x12\x16\n" +
+	"\x06locale\x18\t \x01(\tR\x06locale\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\tR\aversion\x12\x1b\n" +
+	"\tclient_ip\x18\v \x01(\tR\bclientIp\x12+\n" +
+	"\x11recording_privacy\x18\f \x01(\tR\x10recordingPrivacy\x12'\n" +
+	"\x0fidempotency_key\x18\r \x01(\tR\x0eidempotencyKey\x12K\n" +
+	"\x0fplay_time_limit\x18\x0e \x01(\v2#.dungeongate.games.v2.PlayTimeLimitR\rplayTimeLimit\x12\x14\n" +
+	"\x05roles\x18\x0f \x03(\tR\x05roles\"[\n" +
+	"\rPlayTimeLimit\x12#\n" +
+	"\rdaily_seconds\x18\x01 \x01(\x03R\fdailySeconds\x12%\n" +
+	"\x0eweekly_seconds\x18\x02 \x01(\x03R\rweeklySeconds\"W\n" +
 	"\x18StartGameSessionResponse\x12;\n" +
-	"\asession\x18\x01 \x01(\v2!.dungeongate.games.v2.GameSessionR\asession\"e\n" +
+	"\asession\x18\x01 \x01(\v2!.dungeongate.games.v2.GameSessionR\asession\"\x8e\x01\n" +
 	"\x16StopGameSessionRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
 	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x14\n" +
-	"\x05force\x18\x03 \x01(\bR\x05force\"3\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"3\n" +
 	"\x17StopGameSessionResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"6\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"S\n" +
 	"\x15GetGameSessionRequest\x12\x1d\n" +
 	"\n" +
-	"session_id\x18\x01 \x01(\tR\tsessionId\"U\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\x05R\bviewerId\"U\n" +
 	"\x16GetGameSessionResponse\x12;\n" +
-	"\asession\x18\x01 \x01(\v2!.dungeongate.games.v2.GameSessionR\asession\"\xb6\x01\n" +
+	"\asession\x18\x01 \x01(\v2!.dungeongate.games.v2.GameSessionR\asession\"\xec\x01\n" +
 	"\x17ListGameSessionsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
 	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12;\n" +
 	"\x06status\x18\x03 \x01(\x0e2#.dungeongate.games.v2.SessionStatusR\x06status\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\"z\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tviewer_id\x18\x06 \x01(\x05R\bviewerId\x12\x17\n" +
+	"\asort_by\x18\a \x01(\tR\x06sortBy\"z\n" +
 	"\x18ListGameSessionsResponse\x12=\n" +
 	"\bsessions\x18\x01 \x03(\v2!.dungeongate.games.v2.GameSessionR\bsessions\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\x97\x01\n" +
+	"totalCount\"\xfc\x01\n" +
+	"\x19ListSessionHistoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x120\n" +
+	"\x05since\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x120\n" +
+	"\x05until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x05until\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x1b\n" +
+	"\tviewer_id\x18\a \x01(\x05R\bviewerId\"|\n" +
+	"\x1aListSessionHistoryResponse\x12=\n" +
+	"\bsessions\x18\x01 \x03(\v2!.dungeongate.games.v2.GameSessionR\bsessions\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\"}\n" +
+	"\x1cSubscribeGameSessionsRequest\x12)\n" +
+	"\x10spectatable_only\x18\x01 \x01(\bR\x0fspectatableOnly\x122\n" +
+	"\x15idle_interval_seconds\x18\x02 \x01(\x05R\x13idleIntervalSeconds\"\xac\x01\n" +
+	"\x11GameSessionUpdate\x12;\n" +
+	"\x04type\x18\x01 \x01(\x0e2'.dungeongate.games.v2.SessionUpdateTypeR\x04type\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12;\n" +
+	"\asession\x18\x03 \x01(\v2!.dungeongate.games.v2.GameSessionR\asession\"\xc0\x01\n" +
 	"\x0fSaveGameRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
 	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12>\n" +
-	"\bmetadata\x18\x04 \x01(\v2\".dungeongate.games.v2.SaveMetadataR\bmetadata\"F\n" +
+	"\bmetadata\x18\x04 \x01(\v2\".dungeongate.games.v2.SaveMetadataR\bmetadata\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"F\n" +
 	"\x10SaveGameResponse\x122\n" +
 	"\x04save\x18\x01 \x01(\v2\x1e.dungeongate.games.v2.GameSaveR\x04save\"\\\n" +
 	"\x0fLoadGameRequest\x12\x17\n" +
@@ -4252,7 +7591,40 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\x11ListSavesResponse\x124\n" +
 	"\x05saves\x18\x01 \x03(\v2\x1e.dungeongate.games.v2.GameSaveR\x05saves\x12\x1f\n" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
-	"totalCount\"\xe5\x01\n" +
+	"totalCount\"a\n" +
+	"\x11ExportSaveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\asave_id\x18\x02 \x01(\tR\x06saveId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\"h\n" +
+	"\x12ExportSaveResponse\x12\x16\n" +
+	"\x06bundle\x18\x01 \x01(\fR\x06bundle\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12!\n" +
+	"\fgame_version\x18\x03 \x01(\tR\vgameVersion\"v\n" +
+	"\x11ImportSaveRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x16\n" +
+	"\x06bundle\x18\x02 \x01(\fR\x06bundle\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x14\n" +
+	"\x05admin\x18\x04 \x01(\bR\x05admin\"\x85\x01\n" +
+	"\x12ImportSaveResponse\x122\n" +
+	"\x04save\x18\x01 \x01(\v2\x1e.dungeongate.games.v2.GameSaveR\x04save\x12#\n" +
+	"\rsource_server\x18\x02 \x01(\tR\fsourceServer\x12\x16\n" +
+	"\x06signed\x18\x03 \x01(\bR\x06signed\"\x90\x01\n" +
+	"\x14MergeUserDataRequest\x12 \n" +
+	"\ffrom_user_id\x18\x01 \x01(\x05R\n" +
+	"fromUserId\x12\x1c\n" +
+	"\n" +
+	"to_user_id\x18\x02 \x01(\x05R\btoUserId\x12\x1f\n" +
+	"\vto_username\x18\x03 \x01(\tR\n" +
+	"toUsername\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"\xaa\x01\n" +
+	"\x15MergeUserDataResponse\x12\x1a\n" +
+	"\bsessions\x18\x01 \x01(\x05R\bsessions\x12\x1e\n" +
+	"\n" +
+	"recordings\x18\x02 \x01(\x05R\n" +
+	"recordings\x12\x1a\n" +
+	"\bdumplogs\x18\x03 \x01(\x05R\bdumplogs\x12\x14\n" +
+	"\x05saves\x18\x04 \x01(\x05R\x05saves\x12#\n" +
+	"\rskipped_saves\x18\x05 \x01(\x05R\fskippedSaves\"\xe5\x01\n" +
 	"\rGameIORequest\x12C\n" +
 	"\aconnect\x18\x01 \x01(\v2'.dungeongate.games.v2.ConnectPTYRequestH\x00R\aconnect\x126\n" +
 	"\x05input\x18\x02 \x01(\v2\x1e.dungeongate.games.v2.PTYInputH\x00R\x05input\x12L\n" +
@@ -4266,12 +7638,13 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\x05event\x18\x03 \x01(\v2\x1e.dungeongate.games.v2.PTYEventH\x00R\x05event\x12Q\n" +
 	"\fdisconnected\x18\x04 \x01(\v2+.dungeongate.games.v2.DisconnectPTYResponseH\x00R\fdisconnectedB\n" +
 	"\n" +
-	"\bresponse\"\x98\x01\n" +
+	"\bresponse\"\xb6\x01\n" +
 	"\x11ConnectPTYRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12G\n" +
 	"\rterminal_size\x18\x02 \x01(\v2\".dungeongate.games.v2.TerminalSizeR\fterminalSize\x12\x1b\n" +
-	"\tterm_type\x18\x03 \x01(\tR\btermType\"[\n" +
+	"\tterm_type\x18\x03 \x01(\tR\btermType\x12\x1c\n" +
+	"\tspectator\x18\x04 \x01(\bR\tspectator\"[\n" +
 	"\x12ConnectPTYResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12\x15\n" +
@@ -4296,38 +7669,153 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\x14DisconnectPTYRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x16\n" +
-	"\x06reason\x18\x02 \x01(\tR\x06reason\"1\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"I\n" +
 	"\x15DisconnectPTYResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"u\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"u\n" +
 	"\x15ResizeTerminalRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12=\n" +
 	"\bnew_size\x18\x02 \x01(\v2\".dungeongate.games.v2.TerminalSizeR\anewSize\"H\n" +
 	"\x16ResizeTerminalResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\x8f\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"r\n" +
+	"\x17UpdateStatusLineRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1f\n" +
+	"\vscreen_rows\x18\x03 \x03(\tR\n" +
+	"screenRows\";\n" +
+	"\x18UpdateStatusLineResponse\x12\x1f\n" +
+	"\vstatus_line\x18\x01 \x01(\tR\n" +
+	"statusLine\"\xd9\x01\n" +
 	"\x13AddSpectatorRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12*\n" +
 	"\x11spectator_user_id\x18\x02 \x01(\x05R\x0fspectatorUserId\x12-\n" +
-	"\x12spectator_username\x18\x03 \x01(\tR\x11spectatorUsername\"\x89\x01\n" +
+	"\x12spectator_username\x18\x03 \x01(\tR\x11spectatorUsername\x12!\n" +
+	"\fbypass_limit\x18\x04 \x01(\bR\vbypassLimit\x12%\n" +
+	"\x0emax_spectators\x18\x05 \x01(\x05R\rmaxSpectators\"\x89\x01\n" +
 	"\x14AddSpectatorResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
 	"\x05error\x18\x02 \x01(\tR\x05error\x12A\n" +
-	"\tspectator\x18\x03 \x01(\v2#.dungeongate.games.v2.SpectatorInfoR\tspectator\"c\n" +
+	"\tspectator\x18\x03 \x01(\v2#.dungeongate.games.v2.SpectatorInfoR\tspectator\"{\n" +
 	"\x16RemoveSpectatorRequest\x12\x1d\n" +
 	"\n" +
 	"session_id\x18\x01 \x01(\tR\tsessionId\x12*\n" +
-	"\x11spectator_user_id\x18\x02 \x01(\x05R\x0fspectatorUserId\"I\n" +
+	"\x11spectator_user_id\x18\x02 \x01(\x05R\x0fspectatorUserId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"I\n" +
 	"\x17RemoveSpectatorResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\"\xb1\x01\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"]\n" +
+	"\x13ListDumplogsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"Q\n" +
+	"\x14ListDumplogsResponse\x129\n" +
+	"\bdumplogs\x18\x01 \x03(\v2\x1d.dungeongate.games.v2.DumplogR\bdumplogs\"2\n" +
+	"\x11GetDumplogRequest\x12\x1d\n" +
+	"\n" +
+	"dumplog_id\x18\x01 \x01(\tR\tdumplogId\"M\n" +
+	"\x12GetDumplogResponse\x127\n" +
+	"\adumplog\x18\x01 \x01(\v2\x1d.dungeongate.games.v2.DumplogR\adumplog\"\xcf\x01\n" +
+	"\x15TakeScreenshotRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x12\n" +
+	"\x04cols\x18\x04 \x01(\x05R\x04cols\x12\x12\n" +
+	"\x04rows\x18\x05 \x01(\x05R\x04rows\x12\x12\n" +
+	"\x04text\x18\x06 \x01(\tR\x04text\x12\x12\n" +
+	"\x04ansi\x18\a \x01(\tR\x04ansi\x12\x12\n" +
+	"\x04html\x18\b \x01(\tR\x04html\"Z\n" +
+	"\x16TakeScreenshotResponse\x12@\n" +
+	"\n" +
+	"screenshot\x18\x01 \x01(\v2 .dungeongate.games.v2.ScreenshotR\n" +
+	"screenshot\"f\n" +
+	"\x16ListScreenshotsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x05R\x06userId\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x02 \x01(\tR\tsessionId\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"]\n" +
+	"\x17ListScreenshotsResponse\x12B\n" +
+	"\vscreenshots\x18\x01 \x03(\v2 .dungeongate.games.v2.ScreenshotR\vscreenshots\"T\n" +
+	"\x14GetScreenshotRequest\x12#\n" +
+	"\rscreenshot_id\x18\x01 \x01(\tR\fscreenshotId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"Y\n" +
+	"\x15GetScreenshotResponse\x12@\n" +
+	"\n" +
+	"screenshot\x18\x01 \x01(\v2 .dungeongate.games.v2.ScreenshotR\n" +
+	"screenshot\"V\n" +
+	"\x16ShareScreenshotRequest\x12#\n" +
+	"\rscreenshot_id\x18\x01 \x01(\tR\fscreenshotId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\"[\n" +
+	"\x17ShareScreenshotResponse\x12@\n" +
+	"\n" +
+	"screenshot\x18\x01 \x01(\v2 .dungeongate.games.v2.ScreenshotR\n" +
+	"screenshot\"|\n" +
+	"\x12AddBookmarkRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"Q\n" +
+	"\x13AddBookmarkResponse\x12:\n" +
+	"\bbookmark\x18\x01 \x01(\v2\x1e.dungeongate.games.v2.BookmarkR\bbookmark\"R\n" +
+	"\x14ListBookmarksRequest\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x1b\n" +
+	"\tviewer_id\x18\x02 \x01(\x05R\bviewerId\"U\n" +
+	"\x15ListBookmarksResponse\x12<\n" +
+	"\tbookmarks\x18\x01 \x03(\v2\x1e.dungeongate.games.v2.BookmarkR\tbookmarks\"z\n" +
+	"\x15GetLeaderboardRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x19\n" +
+	"\buser_ids\x18\x02 \x03(\x05R\auserIds\x12\x17\n" +
+	"\asort_by\x18\x03 \x01(\tR\x06sortBy\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xaa\x02\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x05R\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12!\n" +
+	"\fgames_played\x18\x04 \x01(\x05R\vgamesPlayed\x125\n" +
+	"\x17total_play_time_seconds\x18\x05 \x01(\x03R\x14totalPlayTimeSeconds\x126\n" +
+	"\x17longest_session_seconds\x18\x06 \x01(\x03R\x15longestSessionSeconds\x12;\n" +
+	"\vlast_played\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastPlayed\"\x7f\n" +
+	"\x16GetLeaderboardResponse\x12@\n" +
+	"\aentries\x18\x01 \x03(\v2&.dungeongate.games.v2.LeaderboardEntryR\aentries\x12#\n" +
+	"\rtotal_players\x18\x02 \x01(\x05R\ftotalPlayers\"`\n" +
+	"\x16GetCapabilitiesRequest\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\x05R\n" +
+	"apiVersion\x12%\n" +
+	"\x0eclient_version\x18\x02 \x01(\tR\rclientVersion\"\xcd\x01\n" +
+	"\x17GetCapabilitiesResponse\x12\x1f\n" +
+	"\vapi_version\x18\x01 \x01(\x05R\n" +
+	"apiVersion\x12&\n" +
+	"\x0fmin_api_version\x18\x02 \x01(\x05R\rminApiVersion\x12&\n" +
+	"\x0fmax_api_version\x18\x03 \x01(\x05R\rmaxApiVersion\x12\x1a\n" +
+	"\bfeatures\x18\x04 \x03(\tR\bfeatures\x12%\n" +
+	"\x0eserver_version\x18\x05 \x01(\tR\rserverVersion\"\xb1\x01\n" +
 	"\x0eHealthResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12K\n" +
 	"\adetails\x18\x02 \x03(\v21.dungeongate.games.v2.HealthResponse.DetailsEntryR\adetails\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\x95\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa2\x01\n" +
+	"\x0fVersionResponse\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x1d\n" +
+	"\n" +
+	"build_time\x18\x03 \x01(\tR\tbuildTime\x12\x1d\n" +
+	"\n" +
+	"git_commit\x18\x04 \x01(\tR\tgitCommit\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x05 \x01(\tR\tgoVersion*[\n" +
+	"\n" +
+	"GameSource\x12\x1b\n" +
+	"\x17GAME_SOURCE_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12GAME_SOURCE_CONFIG\x10\x01\x12\x18\n" +
+	"\x14GAME_SOURCE_DATABASE\x10\x02*\x95\x01\n" +
 	"\n" +
 	"GameStatus\x12\x1b\n" +
 	"\x17GAME_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
@@ -4349,13 +7837,24 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\x12SAVE_STATUS_ACTIVE\x10\x01\x12\x17\n" +
 	"\x13SAVE_STATUS_CORRUPT\x10\x02\x12\x18\n" +
 	"\x14SAVE_STATUS_ARCHIVED\x10\x03\x12\x17\n" +
-	"\x13SAVE_STATUS_DELETED\x10\x04*\xa3\x01\n" +
+	"\x13SAVE_STATUS_DELETED\x10\x04*\xb9\x01\n" +
+	"\x11SessionUpdateType\x12\x1e\n" +
+	"\x1aSESSION_UPDATE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14SESSION_UPDATE_ADDED\x10\x01\x12\x1a\n" +
+	"\x16SESSION_UPDATE_UPDATED\x10\x02\x12\x1a\n" +
+	"\x16SESSION_UPDATE_REMOVED\x10\x03\x12\x17\n" +
+	"\x13SESSION_UPDATE_IDLE\x10\x04\x12\x19\n" +
+	"\x15SESSION_UPDATE_SYNCED\x10\x05*\x8c\x02\n" +
 	"\fPTYEventType\x12\x19\n" +
 	"\x15PTY_EVENT_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16PTY_EVENT_PROCESS_EXIT\x10\x01\x12\x1b\n" +
 	"\x17PTY_EVENT_PROCESS_ERROR\x10\x02\x12\x1d\n" +
 	"\x19PTY_EVENT_SESSION_TIMEOUT\x10\x03\x12 \n" +
-	"\x1cPTY_EVENT_SESSION_TERMINATED\x10\x042\x89\x0e\n" +
+	"\x1cPTY_EVENT_SESSION_TERMINATED\x10\x04\x12!\n" +
+	"\x1dPTY_EVENT_PLAYER_DISCONNECTED\x10\x05\x12\x13\n" +
+	"\x0fPTY_EVENT_ALERT\x10\x06\x12\x16\n" +
+	"\x12PTY_EVENT_DEGRADED\x10\a\x12\x17\n" +
+	"\x13PTY_EVENT_RECOVERED\x10\b2\xdb\x1d\n" +
 	"\vGameService\x12\\\n" +
 	"\tListGames\x12&.dungeongate.games.v2.ListGamesRequest\x1a'.dungeongate.games.v2.ListGamesResponse\x12V\n" +
 	"\aGetGame\x12$.dungeongate.games.v2.GetGameRequest\x1a%.dungeongate.games.v2.GetGameResponse\x12_\n" +
@@ -4364,21 +7863,43 @@ const file_api_proto_games_game_service_v2_proto_rawDesc = "" +
 	"\n" +
 	"UpdateGame\x12'.dungeongate.games.v2.UpdateGameRequest\x1a(.dungeongate.games.v2.UpdateGameResponse\x12_\n" +
 	"\n" +
-	"DeleteGame\x12'.dungeongate.games.v2.DeleteGameRequest\x1a(.dungeongate.games.v2.DeleteGameResponse\x12q\n" +
+	"DeleteGame\x12'.dungeongate.games.v2.DeleteGameRequest\x1a(.dungeongate.games.v2.DeleteGameResponse\x12b\n" +
+	"\vExportGames\x12(.dungeongate.games.v2.ExportGamesRequest\x1a).dungeongate.games.v2.ExportGamesResponse\x12h\n" +
+	"\rSetGameStatus\x12*.dungeongate.games.v2.SetGameStatusRequest\x1a+.dungeongate.games.v2.SetGameStatusResponse\x12q\n" +
 	"\x10StartGameSession\x12-.dungeongate.games.v2.StartGameSessionRequest\x1a..dungeongate.games.v2.StartGameSessionResponse\x12n\n" +
 	"\x0fStopGameSession\x12,.dungeongate.games.v2.StopGameSessionRequest\x1a-.dungeongate.games.v2.StopGameSessionResponse\x12k\n" +
 	"\x0eGetGameSession\x12+.dungeongate.games.v2.GetGameSessionRequest\x1a,.dungeongate.games.v2.GetGameSessionResponse\x12q\n" +
-	"\x10ListGameSessions\x12-.dungeongate.games.v2.ListGameSessionsRequest\x1a..dungeongate.games.v2.ListGameSessionsResponse\x12Y\n" +
+	"\x10ListGameSessions\x12-.dungeongate.games.v2.ListGameSessionsRequest\x1a..dungeongate.games.v2.ListGameSessionsResponse\x12v\n" +
+	"\x15SubscribeGameSessions\x122.dungeongate.games.v2.SubscribeGameSessionsRequest\x1a'.dungeongate.games.v2.GameSessionUpdate0\x01\x12w\n" +
+	"\x12ListSessionHistory\x12/.dungeongate.games.v2.ListSessionHistoryRequest\x1a0.dungeongate.games.v2.ListSessionHistoryResponse\x12Y\n" +
 	"\bSaveGame\x12%.dungeongate.games.v2.SaveGameRequest\x1a&.dungeongate.games.v2.SaveGameResponse\x12Y\n" +
 	"\bLoadGame\x12%.dungeongate.games.v2.LoadGameRequest\x1a&.dungeongate.games.v2.LoadGameResponse\x12_\n" +
 	"\n" +
 	"DeleteSave\x12'.dungeongate.games.v2.DeleteSaveRequest\x1a(.dungeongate.games.v2.DeleteSaveResponse\x12\\\n" +
-	"\tListSaves\x12&.dungeongate.games.v2.ListSavesRequest\x1a'.dungeongate.games.v2.ListSavesResponse\x12]\n" +
+	"\tListSaves\x12&.dungeongate.games.v2.ListSavesRequest\x1a'.dungeongate.games.v2.ListSavesResponse\x12_\n" +
+	"\n" +
+	"ExportSave\x12'.dungeongate.games.v2.ExportSaveRequest\x1a(.dungeongate.games.v2.ExportSaveResponse\x12_\n" +
+	"\n" +
+	"ImportSave\x12'.dungeongate.games.v2.ImportSaveRequest\x1a(.dungeongate.games.v2.ImportSaveResponse\x12h\n" +
+	"\rMergeUserData\x12*.dungeongate.games.v2.MergeUserDataRequest\x1a+.dungeongate.games.v2.MergeUserDataResponse\x12]\n" +
 	"\fStreamGameIO\x12#.dungeongate.games.v2.GameIORequest\x1a$.dungeongate.games.v2.GameIOResponse(\x010\x01\x12k\n" +
-	"\x0eResizeTerminal\x12+.dungeongate.games.v2.ResizeTerminalRequest\x1a,.dungeongate.games.v2.ResizeTerminalResponse\x12e\n" +
+	"\x0eResizeTerminal\x12+.dungeongate.games.v2.ResizeTerminalRequest\x1a,.dungeongate.games.v2.ResizeTerminalResponse\x12q\n" +
+	"\x10UpdateStatusLine\x12-.dungeongate.games.v2.UpdateStatusLineRequest\x1a..dungeongate.games.v2.UpdateStatusLineResponse\x12e\n" +
 	"\fAddSpectator\x12).dungeongate.games.v2.AddSpectatorRequest\x1a*.dungeongate.games.v2.AddSpectatorResponse\x12n\n" +
-	"\x0fRemoveSpectator\x12,.dungeongate.games.v2.RemoveSpectatorRequest\x1a-.dungeongate.games.v2.RemoveSpectatorResponse\x12F\n" +
-	"\x06Health\x12\x16.google.protobuf.Empty\x1a$.dungeongate.games.v2.HealthResponseB)Z'github.com/dungeongate/pkg/api/games/v2b\x06proto3"
+	"\x0fRemoveSpectator\x12,.dungeongate.games.v2.RemoveSpectatorRequest\x1a-.dungeongate.games.v2.RemoveSpectatorResponse\x12e\n" +
+	"\fListDumplogs\x12).dungeongate.games.v2.ListDumplogsRequest\x1a*.dungeongate.games.v2.ListDumplogsResponse\x12_\n" +
+	"\n" +
+	"GetDumplog\x12'.dungeongate.games.v2.GetDumplogRequest\x1a(.dungeongate.games.v2.GetDumplogResponse\x12k\n" +
+	"\x0eTakeScreenshot\x12+.dungeongate.games.v2.TakeScreenshotRequest\x1a,.dungeongate.games.v2.TakeScreenshotResponse\x12n\n" +
+	"\x0fListScreenshots\x12,.dungeongate.games.v2.ListScreenshotsRequest\x1a-.dungeongate.games.v2.ListScreenshotsResponse\x12h\n" +
+	"\rGetScreenshot\x12*.dungeongate.games.v2.GetScreenshotRequest\x1a+.dungeongate.games.v2.GetScreenshotResponse\x12n\n" +
+	"\x0fShareScreenshot\x12,.dungeongate.games.v2.ShareScreenshotRequest\x1a-.dungeongate.games.v2.ShareScreenshotResponse\x12b\n" +
+	"\vAddBookmark\x12(.dungeongate.games.v2.AddBookmarkRequest\x1a).dungeongate.games.v2.AddBookmarkResponse\x12h\n" +
+	"\rListBookmarks\x12*.dungeongate.games.v2.ListBookmarksRequest\x1a+.dungeongate.games.v2.ListBookmarksResponse\x12k\n" +
+	"\x0eGetLeaderboard\x12+.dungeongate.games.v2.GetLeaderboardRequest\x1a,.dungeongate.games.v2.GetLeaderboardResponse\x12n\n" +
+	"\x0fGetCapabilities\x12,.dungeongate.games.v2.GetCapabilitiesRequest\x1a-.dungeongate.games.v2.GetCapabilitiesResponse\x12F\n" +
+	"\x06Health\x12\x16.google.protobuf.Empty\x1a$.dungeongate.games.v2.HealthResponse\x12H\n" +
+	"\aVersion\x12\x16.google.protobuf.Empty\x1a%.dungeongate.games.v2.VersionResponseB)Z'github.com/dungeongate/pkg/api/games/v2b\x06proto3"
 
 var (
 	file_api_proto_games_game_service_v2_proto_rawDescOnce sync.Once
@@ -4392,177 +7913,289 @@ func file_api_proto_games_game_service_v2_proto_rawDescGZIP() []byte {
 	return file_api_proto_games_game_service_v2_proto_rawDescData
 }
 
-var file_api_proto_games_game_service_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_api_proto_games_game_service_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
+var file_api_proto_games_game_service_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_api_proto_games_game_service_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 105)
 var file_api_proto_games_game_service_v2_proto_goTypes = []any{
-	(GameStatus)(0),                  // 0: dungeongate.games.v2.GameStatus
-	(SessionStatus)(0),               // 1: dungeongate.games.v2.SessionStatus
-	(SaveStatus)(0),                  // 2: dungeongate.games.v2.SaveStatus
-	(PTYEventType)(0),                // 3: dungeongate.games.v2.PTYEventType
-	(*Game)(nil),                     // 4: dungeongate.games.v2.Game
-	(*BinaryConfig)(nil),             // 5: dungeongate.games.v2.BinaryConfig
-	(*ResourceConfig)(nil),           // 6: dungeongate.games.v2.ResourceConfig
-	(*SecurityConfig)(nil),           // 7: dungeongate.games.v2.SecurityConfig
-	(*NetworkConfig)(nil),            // 8: dungeongate.games.v2.NetworkConfig
-	(*GameStatistics)(nil),           // 9: dungeongate.games.v2.GameStatistics
-	(*GameSession)(nil),              // 10: dungeongate.games.v2.GameSession
-	(*TerminalSize)(nil),             // 11: dungeongate.games.v2.TerminalSize
-	(*ProcessInfo)(nil),              // 12: dungeongate.games.v2.ProcessInfo
-	(*RecordingInfo)(nil),            // 13: dungeongate.games.v2.RecordingInfo
-	(*StreamingInfo)(nil),            // 14: dungeongate.games.v2.StreamingInfo
-	(*SpectatorInfo)(nil),            // 15: dungeongate.games.v2.SpectatorInfo
-	(*GameSave)(nil),                 // 16: dungeongate.games.v2.GameSave
-	(*SaveMetadata)(nil),             // 17: dungeongate.games.v2.SaveMetadata
-	(*SaveBackup)(nil),               // 18: dungeongate.games.v2.SaveBackup
-	(*ListGamesRequest)(nil),         // 19: dungeongate.games.v2.ListGamesRequest
-	(*ListGamesResponse)(nil),        // 20: dungeongate.games.v2.ListGamesResponse
-	(*GetGameRequest)(nil),           // 21: dungeongate.games.v2.GetGameRequest
-	(*GetGameResponse)(nil),          // 22: dungeongate.games.v2.GetGameResponse
-	(*CreateGameRequest)(nil),        // 23: dungeongate.games.v2.CreateGameRequest
-	(*CreateGameResponse)(nil),       // 24: dungeongate.games.v2.CreateGameResponse
-	(*UpdateGameRequest)(nil),        // 25: dungeongate.games.v2.UpdateGameRequest
-	(*UpdateGameResponse)(nil),       // 26: dungeongate.games.v2.UpdateGameResponse
-	(*DeleteGameRequest)(nil),        // 27: dungeongate.games.v2.DeleteGameRequest
-	(*DeleteGameResponse)(nil),       // 28: dungeongate.games.v2.DeleteGameResponse
-	(*StartGameSessionRequest)(nil),  // 29: dungeongate.games.v2.StartGameSessionRequest
-	(*StartGameSessionResponse)(nil), // 30: dungeongate.games.v2.StartGameSessionResponse
-	(*StopGameSessionRequest)(nil),   // 31: dungeongate.games.v2.StopGameSessionRequest
-	(*StopGameSessionResponse)(nil),  // 32: dungeongate.games.v2.StopGameSessionResponse
-	(*GetGameSessionRequest)(nil),    // 33: dungeongate.games.v2.GetGameSessionRequest
-	(*GetGameSessionResponse)(nil),   // 34: dungeongate.games.v2.GetGameSessionResponse
-	(*ListGameSessionsRequest)(nil),  // 35: dungeongate.games.v2.ListGameSessionsRequest
-	(*ListGameSessionsResponse)(nil), // 36: dungeongate.games.v2.ListGameSessionsResponse
-	(*SaveGameRequest)(nil),          // 37: dungeongate.games.v2.SaveGameRequest
-	(*SaveGameResponse)(nil),         // 38: dungeongate.games.v2.SaveGameResponse
-	(*LoadGameRequest)(nil),          // 39: dungeongate.games.v2.LoadGameRequest
-	(*LoadGameResponse)(nil),         // 40: dungeongate.games.v2.LoadGameResponse
-	(*DeleteSaveRequest)(nil),        // 41: dungeongate.games.v2.DeleteSaveRequest
-	(*DeleteSaveResponse)(nil),       // 42: dungeongate.games.v2.DeleteSaveResponse
-	(*ListSavesRequest)(nil),         // 43: dungeongate.games.v2.ListSavesRequest
-	(*ListSavesResponse)(nil),        // 44: dungeongate.games.v2.ListSavesResponse
-	(*GameIORequest)(nil),            // 45: dungeongate.games.v2.GameIORequest
-	(*GameIOResponse)(nil),           // 46: dungeongate.games.v2.GameIOResponse
-	(*ConnectPTYRequest)(nil),        // 47: dungeongate.games.v2.ConnectPTYRequest
-	(*ConnectPTYResponse)(nil),       // 48: dungeongate.games.v2.ConnectPTYResponse
-	(*PTYInput)(nil),                 // 49: dungeongate.games.v2.PTYInput
-	(*PTYOutput)(nil),                // 50: dungeongate.games.v2.PTYOutput
-	(*PTYEvent)(nil),                 // 51: dungeongate.games.v2.PTYEvent
-	(*DisconnectPTYRequest)(nil),     // 52: dungeongate.games.v2.DisconnectPTYRequest
-	(*DisconnectPTYResponse)(nil),    // 53: dungeongate.games.v2.DisconnectPTYResponse
-	(*ResizeTerminalRequest)(nil),    // 54: dungeongate.games.v2.ResizeTerminalRequest
-	(*ResizeTerminalResponse)(nil),   // 55: dungeongate.games.v2.ResizeTerminalResponse
-	(*AddSpectatorRequest)(nil),      // 56: dungeongate.games.v2.AddSpectatorRequest
-	(*AddSpectatorResponse)(nil),     // 57: dungeongate.games.v2.AddSpectatorResponse
-	(*RemoveSpectatorRequest)(nil),   // 58: dungeongate.games.v2.RemoveSpectatorRequest
-	(*RemoveSpectatorResponse)(nil),  // 59: dungeongate.games.v2.RemoveSpectatorResponse
-	(*HealthResponse)(nil),           // 60: dungeongate.games.v2.HealthResponse
-	nil,                              // 61: dungeongate.games.v2.Game.EnvironmentEntry
-	nil,                              // 62: dungeongate.games.v2.SaveMetadata.CustomFieldsEntry
-	nil,                              // 63: dungeongate.games.v2.PTYEvent.MetadataEntry
-	nil,                              // 64: dungeongate.games.v2.HealthResponse.DetailsEntry
-	(*timestamppb.Timestamp)(nil),    // 65: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),            // 66: google.protobuf.Empty
+	(GameSource)(0),                      // 0: dungeongate.games.v2.GameSource
+	(GameStatus)(0),                      // 1: dungeongate.games.v2.GameStatus
+	(SessionStatus)(0),                   // 2: dungeongate.games.v2.SessionStatus
+	(SaveStatus)(0),                      // 3: dungeongate.games.v2.SaveStatus
+	(SessionUpdateType)(0),               // 4: dungeongate.games.v2.SessionUpdateType
+	(PTYEventType)(0),                    // 5: dungeongate.games.v2.PTYEventType
+	(*Game)(nil),                         // 6: dungeongate.games.v2.Game
+	(*BinaryConfig)(nil),                 // 7: dungeongate.games.v2.BinaryConfig
+	(*ResourceConfig)(nil),               // 8: dungeongate.games.v2.ResourceConfig
+	(*SecurityConfig)(nil),               // 9: dungeongate.games.v2.SecurityConfig
+	(*NetworkConfig)(nil),                // 10: dungeongate.games.v2.NetworkConfig
+	(*GameStatistics)(nil),               // 11: dungeongate.games.v2.GameStatistics
+	(*GameSession)(nil),                  // 12: dungeongate.games.v2.GameSession
+	(*GameOutcome)(nil),                  // 13: dungeongate.games.v2.GameOutcome
+	(*TerminalSize)(nil),                 // 14: dungeongate.games.v2.TerminalSize
+	(*ProcessInfo)(nil),                  // 15: dungeongate.games.v2.ProcessInfo
+	(*ResourceUsage)(nil),                // 16: dungeongate.games.v2.ResourceUsage
+	(*RecordingInfo)(nil),                // 17: dungeongate.games.v2.RecordingInfo
+	(*StreamingInfo)(nil),                // 18: dungeongate.games.v2.StreamingInfo
+	(*SpectatorInfo)(nil),                // 19: dungeongate.games.v2.SpectatorInfo
+	(*GameSave)(nil),                     // 20: dungeongate.games.v2.GameSave
+	(*SaveMetadata)(nil),                 // 21: dungeongate.games.v2.SaveMetadata
+	(*SaveBackup)(nil),                   // 22: dungeongate.games.v2.SaveBackup
+	(*Dumplog)(nil),                      // 23: dungeongate.games.v2.Dumplog
+	(*Screenshot)(nil),                   // 24: dungeongate.games.v2.Screenshot
+	(*Bookmark)(nil),                     // 25: dungeongate.games.v2.Bookmark
+	(*ListGamesRequest)(nil),             // 26: dungeongate.games.v2.ListGamesRequest
+	(*ListGamesResponse)(nil),            // 27: dungeongate.games.v2.ListGamesResponse
+	(*GetGameRequest)(nil),               // 28: dungeongate.games.v2.GetGameRequest
+	(*GetGameResponse)(nil),              // 29: dungeongate.games.v2.GetGameResponse
+	(*CreateGameRequest)(nil),            // 30: dungeongate.games.v2.CreateGameRequest
+	(*CreateGameResponse)(nil),           // 31: dungeongate.games.v2.CreateGameResponse
+	(*UpdateGameRequest)(nil),            // 32: dungeongate.games.v2.UpdateGameRequest
+	(*UpdateGameResponse)(nil),           // 33: dungeongate.games.v2.UpdateGameResponse
+	(*DeleteGameRequest)(nil),            // 34: dungeongate.games.v2.DeleteGameRequest
+	(*DeleteGameResponse)(nil),           // 35: dungeongate.games.v2.DeleteGameResponse
+	(*ExportGamesRequest)(nil),           // 36: dungeongate.games.v2.ExportGamesRequest
+	(*ExportGamesResponse)(nil),          // 37: dungeongate.games.v2.ExportGamesResponse
+	(*SetGameStatusRequest)(nil),         // 38: dungeongate.games.v2.SetGameStatusRequest
+	(*SetGameStatusResponse)(nil),        // 39: dungeongate.games.v2.SetGameStatusResponse
+	(*StartGameSessionRequest)(nil),      // 40: dungeongate.games.v2.StartGameSessionRequest
+	(*PlayTimeLimit)(nil),                // 41: dungeongate.games.v2.PlayTimeLimit
+	(*StartGameSessionResponse)(nil),     // 42: dungeongate.games.v2.StartGameSessionResponse
+	(*StopGameSessionRequest)(nil),       // 43: dungeongate.games.v2.StopGameSessionRequest
+	(*StopGameSessionResponse)(nil),      // 44: dungeongate.games.v2.StopGameSessionResponse
+	(*GetGameSessionRequest)(nil),        // 45: dungeongate.games.v2.GetGameSessionRequest
+	(*GetGameSessionResponse)(nil),       // 46: dungeongate.games.v2.GetGameSessionResponse
+	(*ListGameSessionsRequest)(nil),      // 47: dungeongate.games.v2.ListGameSessionsRequest
+	(*ListGameSessionsResponse)(nil),     // 48: dungeongate.games.v2.ListGameSessionsResponse
+	(*ListSessionHistoryRequest)(nil),    // 49: dungeongate.games.v2.ListSessionHistoryRequest
+	(*ListSessionHistoryResponse)(nil),   // 50: dungeongate.games.v2.ListSessionHistoryResponse
+	(*SubscribeGameSessionsRequest)(nil), // 51: dungeongate.games.v2.SubscribeGameSessionsRequest
+	(*GameSessionUpdate)(nil),            // 52: dungeongate.games.v2.GameSessionUpdate
+	(*SaveGameRequest)(nil),              // 53: dungeongate.games.v2.SaveGameRequest
+	(*SaveGameResponse)(nil),             // 54: dungeongate.games.v2.SaveGameResponse
+	(*LoadGameRequest)(nil),              // 55: dungeongate.games.v2.LoadGameRequest
+	(*LoadGameResponse)(nil),             // 56: dungeongate.games.v2.LoadGameResponse
+	(*DeleteSaveRequest)(nil),            // 57: dungeongate.games.v2.DeleteSaveRequest
+	(*DeleteSaveResponse)(nil),           // 58: dungeongate.games.v2.DeleteSaveResponse
+	(*ListSavesRequest)(nil),             // 59: dungeongate.games.v2.ListSavesRequest
+	(*ListSavesResponse)(nil),            // 60: dungeongate.games.v2.ListSavesResponse
+	(*ExportSaveRequest)(nil),            // 61: dungeongate.games.v2.ExportSaveRequest
+	(*ExportSaveResponse)(nil),           // 62: dungeongate.games.v2.ExportSaveResponse
+	(*ImportSaveRequest)(nil),            // 63: dungeongate.games.v2.ImportSaveRequest
+	(*ImportSaveResponse)(nil),           // 64: dungeongate.games.v2.ImportSaveResponse
+	(*MergeUserDataRequest)(nil),         // 65: dungeongate.games.v2.MergeUserDataRequest
+	(*MergeUserDataResponse)(nil),        // 66: dungeongate.games.v2.MergeUserDataResponse
+	(*GameIORequest)(nil),                // 67: dungeongate.games.v2.GameIORequest
+	(*GameIOResponse)(nil),               // 68: dungeongate.games.v2.GameIOResponse
+	(*ConnectPTYRequest)(nil),            // 69: dungeongate.games.v2.ConnectPTYRequest
+	(*ConnectPTYResponse)(nil),           // 70: dungeongate.games.v2.ConnectPTYResponse
+	(*PTYInput)(nil),                     // 71: dungeongate.games.v2.PTYInput
+	(*PTYOutput)(nil),                    // 72: dungeongate.games.v2.PTYOutput
+	(*PTYEvent)(nil),                     // 73: dungeongate.games.v2.PTYEvent
+	(*DisconnectPTYRequest)(nil),         // 74: dungeongate.games.v2.DisconnectPTYRequest
+	(*DisconnectPTYResponse)(nil),        // 75: dungeongate.games.v2.DisconnectPTYResponse
+	(*ResizeTerminalRequest)(nil),        // 76: dungeongate.games.v2.ResizeTerminalRequest
+	(*ResizeTerminalResponse)(nil),       // 77: dungeongate.games.v2.ResizeTerminalResponse
+	(*UpdateStatusLineRequest)(nil),      // 78: dungeongate.games.v2.UpdateStatusLineRequest
+	(*UpdateStatusLineResponse)(nil),     // 79: dungeongate.games.v2.UpdateStatusLineResponse
+	(*AddSpectatorRequest)(nil),          // 80: dungeongate.games.v2.AddSpectatorRequest
+	(*AddSpectatorResponse)(nil),         // 81: dungeongate.games.v2.AddSpectatorResponse
+	(*RemoveSpectatorRequest)(nil),       // 82: dungeongate.games.v2.RemoveSpectatorRequest
+	(*RemoveSpectatorResponse)(nil),      // 83: dungeongate.games.v2.RemoveSpectatorResponse
+	(*ListDumplogsRequest)(nil),          // 84: dungeongate.games.v2.ListDumplogsRequest
+	(*ListDumplogsResponse)(nil),         // 85: dungeongate.games.v2.ListDumplogsResponse
+	(*GetDumplogRequest)(nil),            // 86: dungeongate.games.v2.GetDumplogRequest
+	(*GetDumplogResponse)(nil),           // 87: dungeongate.games.v2.GetDumplogResponse
+	(*TakeScreenshotRequest)(nil),        // 88: dungeongate.games.v2.TakeScreenshotRequest
+	(*TakeScreenshotResponse)(nil),       // 89: dungeongate.games.v2.TakeScreenshotResponse
+	(*ListScreenshotsRequest)(nil),       // 90: dungeongate.games.v2.ListScreenshotsRequest
+	(*ListScreenshotsResponse)(nil),      // 91: dungeongate.games.v2.ListScreenshotsResponse
+	(*GetScreenshotRequest)(nil),         // 92: dungeongate.games.v2.GetScreenshotRequest
+	(*GetScreenshotResponse)(nil),        // 93: dungeongate.games.v2.GetScreenshotResponse
+	(*ShareScreenshotRequest)(nil),       // 94: dungeongate.games.v2.ShareScreenshotRequest
+	(*ShareScreenshotResponse)(nil),      // 95: dungeongate.games.v2.ShareScreenshotResponse
+	(*AddBookmarkRequest)(nil),           // 96: dungeongate.games.v2.AddBookmarkRequest
+	(*AddBookmarkResponse)(nil),          // 97: dungeongate.games.v2.AddBookmarkResponse
+	(*ListBookmarksRequest)(nil),         // 98: dungeongate.games.v2.ListBookmarksRequest
+	(*ListBookmarksResponse)(nil),        // 99: dungeongate.games.v2.ListBookmarksResponse
+	(*GetLeaderboardRequest)(nil),        // 100: dungeongate.games.v2.GetLeaderboardRequest
+	(*LeaderboardEntry)(nil),             // 101: dungeongate.games.v2.LeaderboardEntry
+	(*GetLeaderboardResponse)(nil),       // 102: dungeongate.games.v2.GetLeaderboardResponse
+	(*GetCapabilitiesRequest)(nil),       // 103: dungeongate.games.v2.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil),      // 104: dungeongate.games.v2.GetCapabilitiesResponse
+	(*HealthResponse)(nil),               // 105: dungeongate.games.v2.HealthResponse
+	(*VersionResponse)(nil),              // 106: dungeongate.games.v2.VersionResponse
+	nil,                                  // 107: dungeongate.games.v2.Game.EnvironmentEntry
+	nil,                                  // 108: dungeongate.games.v2.SaveMetadata.CustomFieldsEntry
+	nil,                                  // 109: dungeongate.games.v2.PTYEvent.MetadataEntry
+	nil,                                  // 110: dungeongate.games.v2.HealthResponse.DetailsEntry
+	(*timestamppb.Timestamp)(nil),        // 111: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                // 112: google.protobuf.Empty
 }
 var file_api_proto_games_game_service_v2_proto_depIdxs = []int32{
-	0,  // 0: dungeongate.games.v2.Game.status:type_name -> dungeongate.games.v2.GameStatus
-	5,  // 1: dungeongate.games.v2.Game.binary:type_name -> dungeongate.games.v2.BinaryConfig
-	61, // 2: dungeongate.games.v2.Game.environment:type_name -> dungeongate.games.v2.Game.EnvironmentEntry
-	6,  // 3: dungeongate.games.v2.Game.resources:type_name -> dungeongate.games.v2.ResourceConfig
-	7,  // 4: dungeongate.games.v2.Game.security:type_name -> dungeongate.games.v2.SecurityConfig
-	8,  // 5: dungeongate.games.v2.Game.networking:type_name -> dungeongate.games.v2.NetworkConfig
-	9,  // 6: dungeongate.games.v2.Game.statistics:type_name -> dungeongate.games.v2.GameStatistics
-	65, // 7: dungeongate.games.v2.Game.created_at:type_name -> google.protobuf.Timestamp
-	65, // 8: dungeongate.games.v2.Game.updated_at:type_name -> google.protobuf.Timestamp
-	65, // 9: dungeongate.games.v2.GameStatistics.last_played:type_name -> google.protobuf.Timestamp
-	1,  // 10: dungeongate.games.v2.GameSession.status:type_name -> dungeongate.games.v2.SessionStatus
-	65, // 11: dungeongate.games.v2.GameSession.start_time:type_name -> google.protobuf.Timestamp
-	65, // 12: dungeongate.games.v2.GameSession.end_time:type_name -> google.protobuf.Timestamp
-	65, // 13: dungeongate.games.v2.GameSession.last_activity:type_name -> google.protobuf.Timestamp
-	11, // 14: dungeongate.games.v2.GameSession.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
-	12, // 15: dungeongate.games.v2.GameSession.process_info:type_name -> dungeongate.games.v2.ProcessInfo
-	13, // 16: dungeongate.games.v2.GameSession.recording:type_name -> dungeongate.games.v2.RecordingInfo
-	14, // 17: dungeongate.games.v2.GameSession.streaming:type_name -> dungeongate.games.v2.StreamingInfo
-	15, // 18: dungeongate.games.v2.GameSession.spectators:type_name -> dungeongate.games.v2.SpectatorInfo
-	65, // 19: dungeongate.games.v2.RecordingInfo.start_time:type_name -> google.protobuf.Timestamp
-	65, // 20: dungeongate.games.v2.SpectatorInfo.join_time:type_name -> google.protobuf.Timestamp
-	2,  // 21: dungeongate.games.v2.GameSave.status:type_name -> dungeongate.games.v2.SaveStatus
-	17, // 22: dungeongate.games.v2.GameSave.metadata:type_name -> dungeongate.games.v2.SaveMetadata
-	18, // 23: dungeongate.games.v2.GameSave.backups:type_name -> dungeongate.games.v2.SaveBackup
-	65, // 24: dungeongate.games.v2.GameSave.created_at:type_name -> google.protobuf.Timestamp
-	65, // 25: dungeongate.games.v2.GameSave.updated_at:type_name -> google.protobuf.Timestamp
-	62, // 26: dungeongate.games.v2.SaveMetadata.custom_fields:type_name -> dungeongate.games.v2.SaveMetadata.CustomFieldsEntry
-	65, // 27: dungeongate.games.v2.SaveBackup.created_at:type_name -> google.protobuf.Timestamp
-	0,  // 28: dungeongate.games.v2.ListGamesRequest.status:type_name -> dungeongate.games.v2.GameStatus
-	4,  // 29: dungeongate.games.v2.ListGamesResponse.games:type_name -> dungeongate.games.v2.Game
-	4,  // 30: dungeongate.games.v2.GetGameResponse.game:type_name -> dungeongate.games.v2.Game
-	4,  // 31: dungeongate.games.v2.CreateGameRequest.game:type_name -> dungeongate.games.v2.Game
-	4,  // 32: dungeongate.games.v2.CreateGameResponse.game:type_name -> dungeongate.games.v2.Game
-	4,  // 33: dungeongate.games.v2.UpdateGameRequest.game:type_name -> dungeongate.games.v2.Game
-	4,  // 34: dungeongate.games.v2.UpdateGameResponse.game:type_name -> dungeongate.games.v2.Game
-	11, // 35: dungeongate.games.v2.StartGameSessionRequest.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
-	10, // 36: dungeongate.games.v2.StartGameSessionResponse.session:type_name -> dungeongate.games.v2.GameSession
-	10, // 37: dungeongate.games.v2.GetGameSessionResponse.session:type_name -> dungeongate.games.v2.GameSession
-	1,  // 38: dungeongate.games.v2.ListGameSessionsRequest.status:type_name -> dungeongate.games.v2.SessionStatus
-	10, // 39: dungeongate.games.v2.ListGameSessionsResponse.sessions:type_name -> dungeongate.games.v2.GameSession
-	17, // 40: dungeongate.games.v2.SaveGameRequest.metadata:type_name -> dungeongate.games.v2.SaveMetadata
-	16, // 41: dungeongate.games.v2.SaveGameResponse.save:type_name -> dungeongate.games.v2.GameSave
-	16, // 42: dungeongate.games.v2.LoadGameResponse.save:type_name -> dungeongate.games.v2.GameSave
-	2,  // 43: dungeongate.games.v2.ListSavesRequest.status:type_name -> dungeongate.games.v2.SaveStatus
-	16, // 44: dungeongate.games.v2.ListSavesResponse.saves:type_name -> dungeongate.games.v2.GameSave
-	47, // 45: dungeongate.games.v2.GameIORequest.connect:type_name -> dungeongate.games.v2.ConnectPTYRequest
-	49, // 46: dungeongate.games.v2.GameIORequest.input:type_name -> dungeongate.games.v2.PTYInput
-	52, // 47: dungeongate.games.v2.GameIORequest.disconnect:type_name -> dungeongate.games.v2.DisconnectPTYRequest
-	48, // 48: dungeongate.games.v2.GameIOResponse.connected:type_name -> dungeongate.games.v2.ConnectPTYResponse
-	50, // 49: dungeongate.games.v2.GameIOResponse.output:type_name -> dungeongate.games.v2.PTYOutput
-	51, // 50: dungeongate.games.v2.GameIOResponse.event:type_name -> dungeongate.games.v2.PTYEvent
-	53, // 51: dungeongate.games.v2.GameIOResponse.disconnected:type_name -> dungeongate.games.v2.DisconnectPTYResponse
-	11, // 52: dungeongate.games.v2.ConnectPTYRequest.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
-	3,  // 53: dungeongate.games.v2.PTYEvent.type:type_name -> dungeongate.games.v2.PTYEventType
-	63, // 54: dungeongate.games.v2.PTYEvent.metadata:type_name -> dungeongate.games.v2.PTYEvent.MetadataEntry
-	11, // 55: dungeongate.games.v2.ResizeTerminalRequest.new_size:type_name -> dungeongate.games.v2.TerminalSize
-	15, // 56: dungeongate.games.v2.AddSpectatorResponse.spectator:type_name -> dungeongate.games.v2.SpectatorInfo
-	64, // 57: dungeongate.games.v2.HealthResponse.details:type_name -> dungeongate.games.v2.HealthResponse.DetailsEntry
-	19, // 58: dungeongate.games.v2.GameService.ListGames:input_type -> dungeongate.games.v2.ListGamesRequest
-	21, // 59: dungeongate.games.v2.GameService.GetGame:input_type -> dungeongate.games.v2.GetGameRequest
-	23, // 60: dungeongate.games.v2.GameService.CreateGame:input_type -> dungeongate.games.v2.CreateGameRequest
-	25, // 61: dungeongate.games.v2.GameService.UpdateGame:input_type -> dungeongate.games.v2.UpdateGameRequest
-	27, // 62: dungeongate.games.v2.GameService.DeleteGame:input_type -> dungeongate.games.v2.DeleteGameRequest
-	29, // 63: dungeongate.games.v2.GameService.StartGameSession:input_type -> dungeongate.games.v2.StartGameSessionRequest
-	31, // 64: dungeongate.games.v2.GameService.StopGameSession:input_type -> dungeongate.games.v2.StopGameSessionRequest
-	33, // 65: dungeongate.games.v2.GameService.GetGameSession:input_type -> dungeongate.games.v2.GetGameSessionRequest
-	35, // 66: dungeongate.games.v2.GameService.ListGameSessions:input_type -> dungeongate.games.v2.ListGameSessionsRequest
-	37, // 67: dungeongate.games.v2.GameService.SaveGame:input_type -> dungeongate.games.v2.SaveGameRequest
-	39, // 68: dungeongate.games.v2.GameService.LoadGame:input_type -> dungeongate.games.v2.LoadGameRequest
-	41, // 69: dungeongate.games.v2.GameService.DeleteSave:input_type -> dungeongate.games.v2.DeleteSaveRequest
-	43, // 70: dungeongate.games.v2.GameService.ListSaves:input_type -> dungeongate.games.v2.ListSavesRequest
-	45, // 71: dungeongate.games.v2.GameService.StreamGameIO:input_type -> dungeongate.games.v2.GameIORequest
-	54, // 72: dungeongate.games.v2.GameService.ResizeTerminal:input_type -> dungeongate.games.v2.ResizeTerminalRequest
-	56, // 73: dungeongate.games.v2.GameService.AddSpectator:input_type -> dungeongate.games.v2.AddSpectatorRequest
-	58, // 74: dungeongate.games.v2.GameService.RemoveSpectator:input_type -> dungeongate.games.v2.RemoveSpectatorRequest
-	66, // 75: dungeongate.games.v2.GameService.Health:input_type -> google.protobuf.Empty
-	20, // 76: dungeongate.games.v2.GameService.ListGames:output_type -> dungeongate.games.v2.ListGamesResponse
-	22, // 77: dungeongate.games.v2.GameService.GetGame:output_type -> dungeongate.games.v2.GetGameResponse
-	24, // 78: dungeongate.games.v2.GameService.CreateGame:output_type -> dungeongate.games.v2.CreateGameResponse
-	26, // 79: dungeongate.games.v2.GameService.UpdateGame:output_type -> dungeongate.games.v2.UpdateGameResponse
-	28, // 80: dungeongate.games.v2.GameService.DeleteGame:output_type -> dungeongate.games.v2.DeleteGameResponse
-	30, // 81: dungeongate.games.v2.GameService.StartGameSession:output_type -> dungeongate.games.v2.StartGameSessionResponse
-	32, // 82: dungeongate.games.v2.GameService.StopGameSession:output_type -> dungeongate.games.v2.StopGameSessionResponse
-	34, // 83: dungeongate.games.v2.GameService.GetGameSession:output_type -> dungeongate.games.v2.GetGameSessionResponse
-	36, // 84: dungeongate.games.v2.GameService.ListGameSessions:output_type -> dungeongate.games.v2.ListGameSessionsResponse
-	38, // 85: dungeongate.games.v2.GameService.SaveGame:output_type -> dungeongate.games.v2.SaveGameResponse
-	40, // 86: dungeongate.games.v2.GameService.LoadGame:output_type -> dungeongate.games.v2.LoadGameResponse
-	42, // 87: dungeongate.games.v2.GameService.DeleteSave:output_type -> dungeongate.games.v2.DeleteSaveResponse
-	44, // 88: dungeongate.games.v2.GameService.ListSaves:output_type -> dungeongate.games.v2.ListSavesResponse
-	46, // 89: dungeongate.games.v2.GameService.StreamGameIO:output_type -> dungeongate.games.v2.GameIOResponse
-	55, // 90: dungeongate.games.v2.GameService.ResizeTerminal:output_type -> dungeongate.games.v2.ResizeTerminalResponse
-	57, // 91: dungeongate.games.v2.GameService.AddSpectator:output_type -> dungeongate.games.v2.AddSpectatorResponse
-	59, // 92: dungeongate.games.v2.GameService.RemoveSpectator:output_type -> dungeongate.games.v2.RemoveSpectatorResponse
-	60, // 93: dungeongate.games.v2.GameService.Health:output_type -> dungeongate.games.v2.HealthResponse
-	76, // [76:94] is the sub-list for method output_type
-	58, // [58:76] is the sub-list for method input_type
-	58, // [58:58] is the sub-list for extension type_name
-	58, // [58:58] is the sub-list for extension extendee
-	0,  // [0:58] is the sub-list for field type_name
+	1,   // 0: dungeongate.games.v2.Game.status:type_name -> dungeongate.games.v2.GameStatus
+	7,   // 1: dungeongate.games.v2.Game.binary:type_name -> dungeongate.games.v2.BinaryConfig
+	107, // 2: dungeongate.games.v2.Game.environment:type_name -> dungeongate.games.v2.Game.EnvironmentEntry
+	8,   // 3: dungeongate.games.v2.Game.resources:type_name -> dungeongate.games.v2.ResourceConfig
+	9,   // 4: dungeongate.games.v2.Game.security:type_name -> dungeongate.games.v2.SecurityConfig
+	10,  // 5: dungeongate.games.v2.Game.networking:type_name -> dungeongate.games.v2.NetworkConfig
+	11,  // 6: dungeongate.games.v2.Game.statistics:type_name -> dungeongate.games.v2.GameStatistics
+	111, // 7: dungeongate.games.v2.Game.created_at:type_name -> google.protobuf.Timestamp
+	111, // 8: dungeongate.games.v2.Game.updated_at:type_name -> google.protobuf.Timestamp
+	0,   // 9: dungeongate.games.v2.Game.source:type_name -> dungeongate.games.v2.GameSource
+	111, // 10: dungeongate.games.v2.GameStatistics.last_played:type_name -> google.protobuf.Timestamp
+	2,   // 11: dungeongate.games.v2.GameSession.status:type_name -> dungeongate.games.v2.SessionStatus
+	111, // 12: dungeongate.games.v2.GameSession.start_time:type_name -> google.protobuf.Timestamp
+	111, // 13: dungeongate.games.v2.GameSession.end_time:type_name -> google.protobuf.Timestamp
+	111, // 14: dungeongate.games.v2.GameSession.last_activity:type_name -> google.protobuf.Timestamp
+	14,  // 15: dungeongate.games.v2.GameSession.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
+	15,  // 16: dungeongate.games.v2.GameSession.process_info:type_name -> dungeongate.games.v2.ProcessInfo
+	17,  // 17: dungeongate.games.v2.GameSession.recording:type_name -> dungeongate.games.v2.RecordingInfo
+	18,  // 18: dungeongate.games.v2.GameSession.streaming:type_name -> dungeongate.games.v2.StreamingInfo
+	19,  // 19: dungeongate.games.v2.GameSession.spectators:type_name -> dungeongate.games.v2.SpectatorInfo
+	111, // 20: dungeongate.games.v2.GameSession.play_time_ends_at:type_name -> google.protobuf.Timestamp
+	13,  // 21: dungeongate.games.v2.GameSession.outcome:type_name -> dungeongate.games.v2.GameOutcome
+	16,  // 22: dungeongate.games.v2.GameSession.resource_usage:type_name -> dungeongate.games.v2.ResourceUsage
+	111, // 23: dungeongate.games.v2.RecordingInfo.start_time:type_name -> google.protobuf.Timestamp
+	111, // 24: dungeongate.games.v2.SpectatorInfo.join_time:type_name -> google.protobuf.Timestamp
+	3,   // 25: dungeongate.games.v2.GameSave.status:type_name -> dungeongate.games.v2.SaveStatus
+	21,  // 26: dungeongate.games.v2.GameSave.metadata:type_name -> dungeongate.games.v2.SaveMetadata
+	22,  // 27: dungeongate.games.v2.GameSave.backups:type_name -> dungeongate.games.v2.SaveBackup
+	111, // 28: dungeongate.games.v2.GameSave.created_at:type_name -> google.protobuf.Timestamp
+	111, // 29: dungeongate.games.v2.GameSave.updated_at:type_name -> google.protobuf.Timestamp
+	111, // 30: dungeongate.games.v2.GameSave.verified_at:type_name -> google.protobuf.Timestamp
+	108, // 31: dungeongate.games.v2.SaveMetadata.custom_fields:type_name -> dungeongate.games.v2.SaveMetadata.CustomFieldsEntry
+	111, // 32: dungeongate.games.v2.SaveBackup.created_at:type_name -> google.protobuf.Timestamp
+	111, // 33: dungeongate.games.v2.Dumplog.captured_at:type_name -> google.protobuf.Timestamp
+	111, // 34: dungeongate.games.v2.Screenshot.taken_at:type_name -> google.protobuf.Timestamp
+	111, // 35: dungeongate.games.v2.Screenshot.share_expires_at:type_name -> google.protobuf.Timestamp
+	111, // 36: dungeongate.games.v2.Bookmark.created_at:type_name -> google.protobuf.Timestamp
+	1,   // 37: dungeongate.games.v2.ListGamesRequest.status:type_name -> dungeongate.games.v2.GameStatus
+	6,   // 38: dungeongate.games.v2.ListGamesResponse.games:type_name -> dungeongate.games.v2.Game
+	6,   // 39: dungeongate.games.v2.GetGameResponse.game:type_name -> dungeongate.games.v2.Game
+	6,   // 40: dungeongate.games.v2.CreateGameRequest.game:type_name -> dungeongate.games.v2.Game
+	6,   // 41: dungeongate.games.v2.CreateGameResponse.game:type_name -> dungeongate.games.v2.Game
+	6,   // 42: dungeongate.games.v2.UpdateGameRequest.game:type_name -> dungeongate.games.v2.Game
+	6,   // 43: dungeongate.games.v2.UpdateGameResponse.game:type_name -> dungeongate.games.v2.Game
+	1,   // 44: dungeongate.games.v2.SetGameStatusRequest.status:type_name -> dungeongate.games.v2.GameStatus
+	6,   // 45: dungeongate.games.v2.SetGameStatusResponse.game:type_name -> dungeongate.games.v2.Game
+	14,  // 46: dungeongate.games.v2.StartGameSessionRequest.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
+	41,  // 47: dungeongate.games.v2.StartGameSessionRequest.play_time_limit:type_name -> dungeongate.games.v2.PlayTimeLimit
+	12,  // 48: dungeongate.games.v2.StartGameSessionResponse.session:type_name -> dungeongate.games.v2.GameSession
+	12,  // 49: dungeongate.games.v2.GetGameSessionResponse.session:type_name -> dungeongate.games.v2.GameSession
+	2,   // 50: dungeongate.games.v2.ListGameSessionsRequest.status:type_name -> dungeongate.games.v2.SessionStatus
+	12,  // 51: dungeongate.games.v2.ListGameSessionsResponse.sessions:type_name -> dungeongate.games.v2.GameSession
+	111, // 52: dungeongate.games.v2.ListSessionHistoryRequest.since:type_name -> google.protobuf.Timestamp
+	111, // 53: dungeongate.games.v2.ListSessionHistoryRequest.until:type_name -> google.protobuf.Timestamp
+	12,  // 54: dungeongate.games.v2.ListSessionHistoryResponse.sessions:type_name -> dungeongate.games.v2.GameSession
+	4,   // 55: dungeongate.games.v2.GameSessionUpdate.type:type_name -> dungeongate.games.v2.SessionUpdateType
+	12,  // 56: dungeongate.games.v2.GameSessionUpdate.session:type_name -> dungeongate.games.v2.GameSession
+	21,  // 57: dungeongate.games.v2.SaveGameRequest.metadata:type_name -> dungeongate.games.v2.SaveMetadata
+	20,  // 58: dungeongate.games.v2.SaveGameResponse.save:type_name -> dungeongate.games.v2.GameSave
+	20,  // 59: dungeongate.games.v2.LoadGameResponse.save:type_name -> dungeongate.games.v2.GameSave
+	3,   // 60: dungeongate.games.v2.ListSavesRequest.status:type_name -> dungeongate.games.v2.SaveStatus
+	20,  // 61: dungeongate.games.v2.ListSavesResponse.saves:type_name -> dungeongate.games.v2.GameSave
+	20,  // 62: dungeongate.games.v2.ImportSaveResponse.save:type_name -> dungeongate.games.v2.GameSave
+	69,  // 63: dungeongate.games.v2.GameIORequest.connect:type_name -> dungeongate.games.v2.ConnectPTYRequest
+	71,  // 64: dungeongate.games.v2.GameIORequest.input:type_name -> dungeongate.games.v2.PTYInput
+	74,  // 65: dungeongate.games.v2.GameIORequest.disconnect:type_name -> dungeongate.games.v2.DisconnectPTYRequest
+	70,  // 66: dungeongate.games.v2.GameIOResponse.connected:type_name -> dungeongate.games.v2.ConnectPTYResponse
+	72,  // 67: dungeongate.games.v2.GameIOResponse.output:type_name -> dungeongate.games.v2.PTYOutput
+	73,  // 68: dungeongate.games.v2.GameIOResponse.event:type_name -> dungeongate.games.v2.PTYEvent
+	75,  // 69: dungeongate.games.v2.GameIOResponse.disconnected:type_name -> dungeongate.games.v2.DisconnectPTYResponse
+	14,  // 70: dungeongate.games.v2.ConnectPTYRequest.terminal_size:type_name -> dungeongate.games.v2.TerminalSize
+	5,   // 71: dungeongate.games.v2.PTYEvent.type:type_name -> dungeongate.games.v2.PTYEventType
+	109, // 72: dungeongate.games.v2.PTYEvent.metadata:type_name -> dungeongate.games.v2.PTYEvent.MetadataEntry
+	14,  // 73: dungeongate.games.v2.ResizeTerminalRequest.new_size:type_name -> dungeongate.games.v2.TerminalSize
+	19,  // 74: dungeongate.games.v2.AddSpectatorResponse.spectator:type_name -> dungeongate.games.v2.SpectatorInfo
+	23,  // 75: dungeongate.games.v2.ListDumplogsResponse.dumplogs:type_name -> dungeongate.games.v2.Dumplog
+	23,  // 76: dungeongate.games.v2.GetDumplogResponse.dumplog:type_name -> dungeongate.games.v2.Dumplog
+	24,  // 77: dungeongate.games.v2.TakeScreenshotResponse.screenshot:type_name -> dungeongate.games.v2.Screenshot
+	24,  // 78: dungeongate.games.v2.ListScreenshotsResponse.screenshots:type_name -> dungeongate.games.v2.Screenshot
+	24,  // 79: dungeongate.games.v2.GetScreenshotResponse.screenshot:type_name -> dungeongate.games.v2.Screenshot
+	24,  // 80: dungeongate.games.v2.ShareScreenshotResponse.screenshot:type_name -> dungeongate.games.v2.Screenshot
+	25,  // 81: dungeongate.games.v2.AddBookmarkResponse.bookmark:type_name -> dungeongate.games.v2.Bookmark
+	25,  // 82: dungeongate.games.v2.ListBookmarksResponse.bookmarks:type_name -> dungeongate.games.v2.Bookmark
+	111, // 83: dungeongate.games.v2.LeaderboardEntry.last_played:type_name -> google.protobuf.Timestamp
+	101, // 84: dungeongate.games.v2.GetLeaderboardResponse.entries:type_name -> dungeongate.games.v2.LeaderboardEntry
+	110, // 85: dungeongate.games.v2.HealthResponse.details:type_name -> dungeongate.games.v2.HealthResponse.DetailsEntry
+	26,  // 86: dungeongate.games.v2.GameService.ListGames:input_type -> dungeongate.games.v2.ListGamesRequest
+	28,  // 87: dungeongate.games.v2.GameService.GetGame:input_type -> dungeongate.games.v2.GetGameRequest
+	30,  // 88: dungeongate.games.v2.GameService.CreateGame:input_type -> dungeongate.games.v2.CreateGameRequest
+	32,  // 89: dungeongate.games.v2.GameService.UpdateGame:input_type -> dungeongate.games.v2.UpdateGameRequest
+	34,  // 90: dungeongate.games.v2.GameService.DeleteGame:input_type -> dungeongate.games.v2.DeleteGameRequest
+	36,  // 91: dungeongate.games.v2.GameService.ExportGames:input_type -> dungeongate.games.v2.ExportGamesRequest
+	38,  // 92: dungeongate.games.v2.GameService.SetGameStatus:input_type -> dungeongate.games.v2.SetGameStatusRequest
+	40,  // 93: dungeongate.games.v2.GameService.StartGameSession:input_type -> dungeongate.games.v2.StartGameSessionRequest
+	43,  // 94: dungeongate.games.v2.GameService.StopGameSession:input_type -> dungeongate.games.v2.StopGameSessionRequest
+	45,  // 95: dungeongate.games.v2.GameService.GetGameSession:input_type -> dungeongate.games.v2.GetGameSessionRequest
+	47,  // 96: dungeongate.games.v2.GameService.ListGameSessions:input_type -> dungeongate.games.v2.ListGameSessionsRequest
+	51,  // 97: dungeongate.games.v2.GameService.SubscribeGameSessions:input_type -> dungeongate.games.v2.SubscribeGameSessionsRequest
+	49,  // 98: dungeongate.games.v2.GameService.ListSessionHistory:input_type -> dungeongate.games.v2.ListSessionHistoryRequest
+	53,  // 99: dungeongate.games.v2.GameService.SaveGame:input_type -> dungeongate.games.v2.SaveGameRequest
+	55,  // 100: dungeongate.games.v2.GameService.LoadGame:input_type -> dungeongate.games.v2.LoadGameRequest
+	57,  // 101: dungeongate.games.v2.GameService.DeleteSave:input_type -> dungeongate.games.v2.DeleteSaveRequest
+	59,  // 102: dungeongate.games.v2.GameService.ListSaves:input_type -> dungeongate.games.v2.ListSavesRequest
+	61,  // 103: dungeongate.games.v2.GameService.ExportSave:input_type -> dungeongate.games.v2.ExportSaveRequest
+	63,  // 104: dungeongate.games.v2.GameService.ImportSave:input_type -> dungeongate.games.v2.ImportSaveRequest
+	65,  // 105: dungeongate.games.v2.GameService.MergeUserData:input_type -> dungeongate.games.v2.MergeUserDataRequest
+	67,  // 106: dungeongate.games.v2.GameService.StreamGameIO:input_type -> dungeongate.games.v2.GameIORequest
+	76,  // 107: dungeongate.games.v2.GameService.ResizeTerminal:input_type -> dungeongate.games.v2.ResizeTerminalRequest
+	78,  // 108: dungeongate.games.v2.GameService.UpdateStatusLine:input_type -> dungeongate.games.v2.UpdateStatusLineRequest
+	80,  // 109: dungeongate.games.v2.GameService.AddSpectator:input_type -> dungeongate.games.v2.AddSpectatorRequest
+	82,  // 110: dungeongate.games.v2.GameService.RemoveSpectator:input_type -> dungeongate.games.v2.RemoveSpectatorRequest
+	84,  // 111: dungeongate.games.v2.GameService.ListDumplogs:input_type -> dungeongate.games.v2.ListDumplogsRequest
+	86,  // 112: dungeongate.games.v2.GameService.GetDumplog:input_type -> dungeongate.games.v2.GetDumplogRequest
+	88,  // 113: dungeongate.games.v2.GameService.TakeScreenshot:input_type -> dungeongate.games.v2.TakeScreenshotRequest
+	90,  // 114: dungeongate.games.v2.GameService.ListScreenshots:input_type -> dungeongate.games.v2.ListScreenshotsRequest
+	92,  // 115: dungeongate.games.v2.GameService.GetScreenshot:input_type -> dungeongate.games.v2.GetScreenshotRequest
+	94,  // 116: dungeongate.games.v2.GameService.ShareScreenshot:input_type -> dungeongate.games.v2.ShareScreenshotRequest
+	96,  // 117: dungeongate.games.v2.GameService.AddBookmark:input_type -> dungeongate.games.v2.AddBookmarkRequest
+	98,  // 118: dungeongate.games.v2.GameService.ListBookmarks:input_type -> dungeongate.games.v2.ListBookmarksRequest
+	100, // 119: dungeongate.games.v2.GameService.GetLeaderboard:input_type -> dungeongate.games.v2.GetLeaderboardRequest
+	103, // 120: dungeongate.games.v2.GameService.GetCapabilities:input_type -> dungeongate.games.v2.GetCapabilitiesRequest
+	112, // 121: dungeongate.games.v2.GameService.Health:input_type -> google.protobuf.Empty
+	112, // 122: dungeongate.games.v2.GameService.Version:input_type -> google.protobuf.Empty
+	27,  // 123: dungeongate.games.v2.GameService.ListGames:output_type -> dungeongate.games.v2.ListGamesResponse
+	29,  // 124: dungeongate.games.v2.GameService.GetGame:output_type -> dungeongate.games.v2.GetGameResponse
+	31,  // 125: dungeongate.games.v2.GameService.CreateGame:output_type -> dungeongate.games.v2.CreateGameResponse
+	33,  // 126: dungeongate.games.v2.GameService.UpdateGame:output_type -> dungeongate.games.v2.UpdateGameResponse
+	35,  // 127: dungeongate.games.v2.GameService.DeleteGame:output_type -> dungeongate.games.v2.DeleteGameResponse
+	37,  // 128: dungeongate.games.v2.GameService.ExportGames:output_type -> dungeongate.games.v2.ExportGamesResponse
+	39,  // 129: dungeongate.games.v2.GameService.SetGameStatus:output_type -> dungeongate.games.v2.SetGameStatusResponse
+	42,  // 130: dungeongate.games.v2.GameService.StartGameSession:output_type -> dungeongate.games.v2.StartGameSessionResponse
+	44,  // 131: dungeongate.games.v2.GameService.StopGameSession:output_type -> dungeongate.games.v2.StopGameSessionResponse
+	46,  // 132: dungeongate.games.v2.GameService.GetGameSession:output_type -> dungeongate.games.v2.GetGameSessionResponse
+	48,  // 133: dungeongate.games.v2.GameService.ListGameSessions:output_type -> dungeongate.games.v2.ListGameSessionsResponse
+	52,  // 134: dungeongate.games.v2.GameService.SubscribeGameSessions:output_type -> dungeongate.games.v2.GameSessionUpdate
+	50,  // 135: dungeongate.games.v2.GameService.ListSessionHistory:output_type -> dungeongate.games.v2.ListSessionHistoryResponse
+	54,  // 136: dungeongate.games.v2.GameService.SaveGame:output_type -> dungeongate.games.v2.SaveGameResponse
+	56,  // 137: dungeongate.games.v2.GameService.LoadGame:output_type -> dungeongate.games.v2.LoadGameResponse
+	58,  // 138: dungeongate.games.v2.GameService.DeleteSave:output_type -> dungeongate.games.v2.DeleteSaveResponse
+	60,  // 139: dungeongate.games.v2.GameService.ListSaves:output_type -> dungeongate.games.v2.ListSavesResponse
+	62,  // 140: dungeongate.games.v2.GameService.ExportSave:output_type -> dungeongate.games.v2.ExportSaveResponse
+	64,  // 141: dungeongate.games.v2.GameService.ImportSave:output_type -> dungeongate.games.v2.ImportSaveResponse
+	66,  // 142: dungeongate.games.v2.GameService.MergeUserData:output_type -> dungeongate.games.v2.MergeUserDataResponse
+	68,  // 143: dungeongate.games.v2.GameService.StreamGameIO:output_type -> dungeongate.games.v2.GameIOResponse
+	77,  // 144: dungeongate.games.v2.GameService.ResizeTerminal:output_type -> dungeongate.games.v2.ResizeTerminalResponse
+	79,  // 145: dungeongate.games.v2.GameService.UpdateStatusLine:output_type -> dungeongate.games.v2.UpdateStatusLineResponse
+	81,  // 146: dungeongate.games.v2.GameService.AddSpectator:output_type -> dungeongate.games.v2.AddSpectatorResponse
+	83,  // 147: dungeongate.games.v2.GameService.RemoveSpectator:output_type -> dungeongate.games.v2.RemoveSpectatorResponse
+	85,  // 148: dungeongate.games.v2.GameService.ListDumplogs:output_type -> dungeongate.games.v2.ListDumplogsResponse
+	87,  // 149: dungeongate.games.v2.GameService.GetDumplog:output_type -> dungeongate.games.v2.GetDumplogResponse
+	89,  // 150: dungeongate.games.v2.GameService.TakeScreenshot:output_type -> dungeongate.games.v2.TakeScreenshotResponse
+	91,  // 151: dungeongate.games.v2.GameService.ListScreenshots:output_type -> dungeongate.games.v2.ListScreenshotsResponse
+	93,  // 152: dungeongate.games.v2.GameService.GetScreenshot:output_type -> dungeongate.games.v2.GetScreenshotResponse
+	95,  // 153: dungeongate.games.v2.GameService.ShareScreenshot:output_type -> dungeongate.games.v2.ShareScreenshotResponse
+	97,  // 154: dungeongate.games.v2.GameService.AddBookmark:output_type -> dungeongate.games.v2.AddBookmarkResponse
+	99,  // 155: dungeongate.games.v2.GameService.ListBookmarks:output_type -> dungeongate.games.v2.ListBookmarksResponse
+	102, // 156: dungeongate.games.v2.GameService.GetLeaderboard:output_type -> dungeongate.games.v2.GetLeaderboardResponse
+	104, // 157: dungeongate.games.v2.GameService.GetCapabilities:output_type -> dungeongate.games.v2.GetCapabilitiesResponse
+	105, // 158: dungeongate.games.v2.GameService.Health:output_type -> dungeongate.games.v2.HealthResponse
+	106, // 159: dungeongate.games.v2.GameService.Version:output_type -> dungeongate.games.v2.VersionResponse
+	123, // [123:160] is the sub-list for method output_type
+	86,  // [86:123] is the sub-list for method input_type
+	86,  // [86:86] is the sub-list for extension type_name
+	86,  // [86:86] is the sub-list for extension extendee
+	0,   // [0:86] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_games_game_service_v2_proto_init() }
@@ -4570,12 +8203,12 @@ func file_api_proto_games_game_service_v2_proto_init() {
 	if File_api_proto_games_game_service_v2_proto != nil {
 		return
 	}
-	file_api_proto_games_game_service_v2_proto_msgTypes[41].OneofWrappers = []any{
+	file_api_proto_games_game_service_v2_proto_msgTypes[61].OneofWrappers = []any{
 		(*GameIORequest_Connect)(nil),
 		(*GameIORequest_Input)(nil),
 		(*GameIORequest_Disconnect)(nil),
 	}
-	file_api_proto_games_game_service_v2_proto_msgTypes[42].OneofWrappers = []any{
+	file_api_proto_games_game_service_v2_proto_msgTypes[62].OneofWrappers = []any{
 		(*GameIOResponse_Connected)(nil),
 		(*GameIOResponse_Output)(nil),
 		(*GameIOResponse_Event)(nil),
@@ -4586,8 +8219,8 @@ func file_api_proto_games_game_service_v2_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_games_game_service_v2_proto_rawDesc), len(file_api_proto_games_game_service_v2_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   61,
+			NumEnums:      6,
+			NumMessages:   105,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,24 +20,43 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	GameService_ListGames_FullMethodName        = "/dungeongate.games.v2.GameService/ListGames"
-	GameService_GetGame_FullMethodName          = "/dungeongate.games.v2.GameService/GetGame"
-	GameService_CreateGame_FullMethodName       = "/dungeongate.games.v2.GameService/CreateGame"
-	GameService_UpdateGame_FullMethodName       = "/dungeongate.games.v2.GameService/UpdateGame"
-	GameService_DeleteGame_FullMethodName       = "/dungeongate.games.v2.GameService/DeleteGame"
-	GameService_StartGameSession_FullMethodName = "/dungeongate.games.v2.GameService/StartGameSession"
-	GameService_StopGameSession_FullMethodName  = "/dungeongate.games.v2.GameService/StopGameSession"
-	GameService_GetGameSession_FullMethodName   = "/dungeongate.games.v2.GameService/GetGameSession"
-	GameService_ListGameSessions_FullMethodName = "/dungeongate.games.v2.GameService/ListGameSessions"
-	GameService_SaveGame_FullMethodName         = "/dungeongate.games.v2.GameService/SaveGame"
-	GameService_LoadGame_FullMethodName         = "/dungeongate.games.v2.GameService/LoadGame"
-	GameService_DeleteSave_FullMethodName       = "/dungeongate.games.v2.GameService/DeleteSave"
-	GameService_ListSaves_FullMethodName        = "/dungeongate.games.v2.GameService/ListSaves"
-	GameService_StreamGameIO_FullMethodName     = "/dungeongate.games.v2.GameService/StreamGameIO"
-	GameService_ResizeTerminal_FullMethodName   = "/dungeongate.games.v2.GameService/ResizeTerminal"
-	GameService_AddSpectator_FullMethodName     = "/dungeongate.games.v2.GameService/AddSpectator"
-	GameService_RemoveSpectator_FullMethodName  = "/dungeongate.games.v2.GameService/RemoveSpectator"
-	GameService_Health_FullMethodName           = "/dungeongate.games.v2.GameService/Health"
+	GameService_ListGames_FullMethodName             = "/dungeongate.games.v2.GameService/ListGames"
+	GameService_GetGame_FullMethodName               = "/dungeongate.games.v2.GameService/GetGame"
+	GameService_CreateGame_FullMethodName            = "/dungeongate.games.v2.GameService/CreateGame"
+	GameService_UpdateGame_FullMethodName            = "/dungeongate.games.v2.GameService/UpdateGame"
+	GameService_DeleteGame_FullMethodName            = "/dungeongate.games.v2.GameService/DeleteGame"
+	GameService_ExportGames_FullMethodName           = "/dungeongate.games.v2.GameService/ExportGames"
+	GameService_SetGameStatus_FullMethodName         = "/dungeongate.games.v2.GameService/SetGameStatus"
+	GameService_StartGameSession_FullMethodName      = "/dungeongate.games.v2.GameService/StartGameSession"
+	GameService_StopGameSession_FullMethodName       = "/dungeongate.games.v2.GameService/StopGameSession"
+	GameService_GetGameSession_FullMethodName        = "/dungeongate.games.v2.GameService/GetGameSession"
+	GameService_ListGameSessions_FullMethodName      = "/dungeongate.games.v2.GameService/ListGameSessions"
+	GameService_SubscribeGameSessions_FullMethodName = "/dungeongate.games.v2.GameService/SubscribeGameSessions"
+	GameService_ListSessionHistory_FullMethodName    = "/dungeongate.games.v2.GameService/ListSessionHistory"
+	GameService_SaveGame_FullMethodName              = "/dungeongate.games.v2.GameService/SaveGame"
+	GameService_LoadGame_FullMethodName              = "/dungeongate.games.v2.GameService/LoadGame"
+	GameService_DeleteSave_FullMethodName            = "/dungeongate.games.v2.GameService/DeleteSave"
+	GameService_ListSaves_FullMethodName             = "/dungeongate.games.v2.GameService/ListSaves"
+	GameService_ExportSave_FullMethodName            = "/dungeongate.games.v2.GameService/ExportSave"
+	GameService_ImportSave_FullMethodName            = "/dungeongate.games.v2.GameService/ImportSave"
+	GameService_MergeUserData_FullMethodName         = "/dungeongate.games.v2.GameService/MergeUserData"
+	GameService_StreamGameIO_FullMethodName          = "/dungeongate.games.v2.GameService/StreamGameIO"
+	GameService_ResizeTerminal_FullMethodName        = "/dungeongate.games.v2.GameService/ResizeTerminal"
+	GameService_UpdateStatusLine_FullMethodName      = "/dungeongate.games.v2.GameService/UpdateStatusLine"
+	GameService_AddSpectator_FullMethodName          = "/dungeongate.games.v2.GameService/AddSpectator"
+	GameService_RemoveSpectator_FullMethodName       = "/dungeongate.games.v2.GameService/RemoveSpectator"
+	GameService_ListDumplogs_FullMethodName          = "/dungeongate.games.v2.GameService/ListDumplogs"
+	GameService_GetDumplog_FullMethodName            = "/dungeongate.games.v2.GameService/GetDumplog"
+	GameService_TakeScreenshot_FullMethodName        = "/dungeongate.games.v2.GameService/TakeScreenshot"
+	GameService_ListScreenshots_FullMethodName       = "/dungeongate.games.v2.GameService/ListScreenshots"
+	GameService_GetScreenshot_FullMethodName         = "/dungeongate.games.v2.GameService/GetScreenshot"
+	GameService_ShareScreenshot_FullMethodName       = "/dungeongate.games.v2.GameService/ShareScreenshot"
+	GameService_AddBookmark_FullMethodName           = "/dungeongate.games.v2.GameService/AddBookmark"
+	GameService_ListBookmarks_FullMethodName         = "/dungeongate.games.v2.GameService/ListBookmarks"
+	GameService_GetLeaderboard_FullMethodName        = "/dungeongate.games.v2.GameService/GetLeaderboard"
+	GameService_GetCapabilities_FullMethodName       = "/dungeongate.games.v2.GameService/GetCapabilities"
+	GameService_Health_FullMethodName                = "/dungeongate.games.v2.GameService/Health"
+	GameService_Version_FullMethodName               = "/dungeongate.games.v2.GameService/Version"
 )
 
 // GameServiceClient is the client API for GameService service.
@@ -52,24 +71,57 @@ type GameServiceClient interface {
 	CreateGame(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
 	UpdateGame(ctx context.Context, in *UpdateGameRequest, opts ...grpc.CallOption) (*UpdateGameResponse, error)
 	DeleteGame(ctx context.Context, in *DeleteGameRequest, opts ...grpc.CallOption) (*DeleteGameResponse, error)
+	ExportGames(ctx context.Context, in *ExportGamesRequest, opts ...grpc.CallOption) (*ExportGamesResponse, error)
+	// SetGameStatus takes a game out of play or puts it back. Sessions already
+	// running are left alone.
+	SetGameStatus(ctx context.Context, in *SetGameStatusRequest, opts ...grpc.CallOption) (*SetGameStatusResponse, error)
 	// Session management
 	StartGameSession(ctx context.Context, in *StartGameSessionRequest, opts ...grpc.CallOption) (*StartGameSessionResponse, error)
 	StopGameSession(ctx context.Context, in *StopGameSessionRequest, opts ...grpc.CallOption) (*StopGameSessionResponse, error)
 	GetGameSession(ctx context.Context, in *GetGameSessionRequest, opts ...grpc.CallOption) (*GetGameSessionResponse, error)
 	ListGameSessions(ctx context.Context, in *ListGameSessionsRequest, opts ...grpc.CallOption) (*ListGameSessionsResponse, error)
+	// Streams per-session changes to the active sessions (adds, removes, idle updates)
+	SubscribeGameSessions(ctx context.Context, in *SubscribeGameSessionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameSessionUpdate], error)
+	// Lists a player's finished sessions, newest first
+	ListSessionHistory(ctx context.Context, in *ListSessionHistoryRequest, opts ...grpc.CallOption) (*ListSessionHistoryResponse, error)
 	// Save management
 	SaveGame(ctx context.Context, in *SaveGameRequest, opts ...grpc.CallOption) (*SaveGameResponse, error)
 	LoadGame(ctx context.Context, in *LoadGameRequest, opts ...grpc.CallOption) (*LoadGameResponse, error)
 	DeleteSave(ctx context.Context, in *DeleteSaveRequest, opts ...grpc.CallOption) (*DeleteSaveResponse, error)
 	ListSaves(ctx context.Context, in *ListSavesRequest, opts ...grpc.CallOption) (*ListSavesResponse, error)
+	ExportSave(ctx context.Context, in *ExportSaveRequest, opts ...grpc.CallOption) (*ExportSaveResponse, error)
+	ImportSave(ctx context.Context, in *ImportSaveRequest, opts ...grpc.CallOption) (*ImportSaveResponse, error)
+	// Account merging: moves a user's game data to the account they were merged into
+	MergeUserData(ctx context.Context, in *MergeUserDataRequest, opts ...grpc.CallOption) (*MergeUserDataResponse, error)
 	// PTY streaming for terminal I/O
 	StreamGameIO(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GameIORequest, GameIOResponse], error)
 	ResizeTerminal(ctx context.Context, in *ResizeTerminalRequest, opts ...grpc.CallOption) (*ResizeTerminalResponse, error)
+	// Status line read off the bottom of the player's screen by the game's adapter
+	UpdateStatusLine(ctx context.Context, in *UpdateStatusLineRequest, opts ...grpc.CallOption) (*UpdateStatusLineResponse, error)
 	// Spectator management
 	AddSpectator(ctx context.Context, in *AddSpectatorRequest, opts ...grpc.CallOption) (*AddSpectatorResponse, error)
 	RemoveSpectator(ctx context.Context, in *RemoveSpectatorRequest, opts ...grpc.CallOption) (*RemoveSpectatorResponse, error)
+	// Dumplog management
+	ListDumplogs(ctx context.Context, in *ListDumplogsRequest, opts ...grpc.CallOption) (*ListDumplogsResponse, error)
+	GetDumplog(ctx context.Context, in *GetDumplogRequest, opts ...grpc.CallOption) (*GetDumplogResponse, error)
+	// Screenshots of games, taken by players and spectators to share
+	TakeScreenshot(ctx context.Context, in *TakeScreenshotRequest, opts ...grpc.CallOption) (*TakeScreenshotResponse, error)
+	ListScreenshots(ctx context.Context, in *ListScreenshotsRequest, opts ...grpc.CallOption) (*ListScreenshotsResponse, error)
+	GetScreenshot(ctx context.Context, in *GetScreenshotRequest, opts ...grpc.CallOption) (*GetScreenshotResponse, error)
+	ShareScreenshot(ctx context.Context, in *ShareScreenshotRequest, opts ...grpc.CallOption) (*ShareScreenshotResponse, error)
+	// Bookmarks of moments in recorded games, dropped while playing or watching
+	AddBookmark(ctx context.Context, in *AddBookmarkRequest, opts ...grpc.CallOption) (*AddBookmarkResponse, error)
+	ListBookmarks(ctx context.Context, in *ListBookmarksRequest, opts ...grpc.CallOption) (*ListBookmarksResponse, error)
+	// Leaderboards
+	GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error)
+	// Versioning: the API versions and optional features the game service
+	// supports, so session services can degrade gracefully during upgrades.
+	// Game services that predate it answer Unimplemented and speak version 2.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
 	// Health check
 	Health(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	// Version reports the build of this game service
+	Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error)
 }
 
 type gameServiceClient struct {
@@ -130,6 +182,26 @@ func (c *gameServiceClient) DeleteGame(ctx context.Context, in *DeleteGameReques
 	return out, nil
 }
 
+func (c *gameServiceClient) ExportGames(ctx context.Context, in *ExportGamesRequest, opts ...grpc.CallOption) (*ExportGamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportGamesResponse)
+	err := c.cc.Invoke(ctx, GameService_ExportGames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) SetGameStatus(ctx context.Context, in *SetGameStatusRequest, opts ...grpc.CallOption) (*SetGameStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetGameStatusResponse)
+	err := c.cc.Invoke(ctx, GameService_SetGameStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) StartGameSession(ctx context.Context, in *StartGameSessionRequest, opts ...grpc.CallOption) (*StartGameSessionResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(StartGameSessionResponse)
@@ -170,6 +242,35 @@ func (c *gameServiceClient) ListGameSessions(ctx context.Context, in *ListGameSe
 	return out, nil
 }
 
+func (c *gameServiceClient) SubscribeGameSessions(ctx context.Context, in *SubscribeGameSessionsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameSessionUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[0], GameService_SubscribeGameSessions_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeGameSessionsRequest, GameSessionUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SubscribeGameSessionsClient = grpc.ServerStreamingClient[GameSessionUpdate]
+
+func (c *gameServiceClient) ListSessionHistory(ctx context.Context, in *ListSessionHistoryRequest, opts ...grpc.CallOption) (*ListSessionHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSessionHistoryResponse)
+	err := c.cc.Invoke(ctx, GameService_ListSessionHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) SaveGame(ctx context.Context, in *SaveGameRequest, opts ...grpc.CallOption) (*SaveGameResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SaveGameResponse)
@@ -210,9 +311,39 @@ func (c *gameServiceClient) ListSaves(ctx context.Context, in *ListSavesRequest,
 	return out, nil
 }
 
+func (c *gameServiceClient) ExportSave(ctx context.Context, in *ExportSaveRequest, opts ...grpc.CallOption) (*ExportSaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExportSaveResponse)
+	err := c.cc.Invoke(ctx, GameService_ExportSave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ImportSave(ctx context.Context, in *ImportSaveRequest, opts ...grpc.CallOption) (*ImportSaveResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ImportSaveResponse)
+	err := c.cc.Invoke(ctx, GameService_ImportSave_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) MergeUserData(ctx context.Context, in *MergeUserDataRequest, opts ...grpc.CallOption) (*MergeUserDataResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MergeUserDataResponse)
+	err := c.cc.Invoke(ctx, GameService_MergeUserData_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) StreamGameIO(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[GameIORequest, GameIOResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[0], GameService_StreamGameIO_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[1], GameService_StreamGameIO_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -233,6 +364,16 @@ func (c *gameServiceClient) ResizeTerminal(ctx context.Context, in *ResizeTermin
 	return out, nil
 }
 
+func (c *gameServiceClient) UpdateStatusLine(ctx context.Context, in *UpdateStatusLineRequest, opts ...grpc.CallOption) (*UpdateStatusLineResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateStatusLineResponse)
+	err := c.cc.Invoke(ctx, GameService_UpdateStatusLine_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) AddSpectator(ctx context.Context, in *AddSpectatorRequest, opts ...grpc.CallOption) (*AddSpectatorResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AddSpectatorResponse)
@@ -253,6 +394,106 @@ func (c *gameServiceClient) RemoveSpectator(ctx context.Context, in *RemoveSpect
 	return out, nil
 }
 
+func (c *gameServiceClient) ListDumplogs(ctx context.Context, in *ListDumplogsRequest, opts ...grpc.CallOption) (*ListDumplogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDumplogsResponse)
+	err := c.cc.Invoke(ctx, GameService_ListDumplogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetDumplog(ctx context.Context, in *GetDumplogRequest, opts ...grpc.CallOption) (*GetDumplogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDumplogResponse)
+	err := c.cc.Invoke(ctx, GameService_GetDumplog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) TakeScreenshot(ctx context.Context, in *TakeScreenshotRequest, opts ...grpc.CallOption) (*TakeScreenshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TakeScreenshotResponse)
+	err := c.cc.Invoke(ctx, GameService_TakeScreenshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ListScreenshots(ctx context.Context, in *ListScreenshotsRequest, opts ...grpc.CallOption) (*ListScreenshotsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScreenshotsResponse)
+	err := c.cc.Invoke(ctx, GameService_ListScreenshots_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetScreenshot(ctx context.Context, in *GetScreenshotRequest, opts ...grpc.CallOption) (*GetScreenshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetScreenshotResponse)
+	err := c.cc.Invoke(ctx, GameService_GetScreenshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ShareScreenshot(ctx context.Context, in *ShareScreenshotRequest, opts ...grpc.CallOption) (*ShareScreenshotResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ShareScreenshotResponse)
+	err := c.cc.Invoke(ctx, GameService_ShareScreenshot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) AddBookmark(ctx context.Context, in *AddBookmarkRequest, opts ...grpc.CallOption) (*AddBookmarkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddBookmarkResponse)
+	err := c.cc.Invoke(ctx, GameService_AddBookmark_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) ListBookmarks(ctx context.Context, in *ListBookmarksRequest, opts ...grpc.CallOption) (*ListBookmarksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBookmarksResponse)
+	err := c.cc.Invoke(ctx, GameService_ListBookmarks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetLeaderboard(ctx context.Context, in *GetLeaderboardRequest, opts ...grpc.CallOption) (*GetLeaderboardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetLeaderboardResponse)
+	err := c.cc.Invoke(ctx, GameService_GetLeaderboard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gameServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, GameService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *gameServiceClient) Health(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HealthResponse)
@@ -263,6 +504,16 @@ func (c *gameServiceClient) Health(ctx context.Context, in *emptypb.Empty, opts
 	return out, nil
 }
 
+func (c *gameServiceClient) Version(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*VersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, GameService_Version_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
@@ -275,24 +526,57 @@ type GameServiceServer interface {
 	CreateGame(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
 	UpdateGame(context.Context, *UpdateGameRequest) (*UpdateGameResponse, error)
 	DeleteGame(context.Context, *DeleteGameRequest) (*DeleteGameResponse, error)
+	ExportGames(context.Context, *ExportGamesRequest) (*ExportGamesResponse, error)
+	// SetGameStatus takes a game out of play or puts it back. Sessions already
+	// running are left alone.
+	SetGameStatus(context.Context, *SetGameStatusRequest) (*SetGameStatusResponse, error)
 	// Session management
 	StartGameSession(context.Context, *StartGameSessionRequest) (*StartGameSessionResponse, error)
 	StopGameSession(context.Context, *StopGameSessionRequest) (*StopGameSessionResponse, error)
 	GetGameSession(context.Context, *GetGameSessionRequest) (*GetGameSessionResponse, error)
 	ListGameSessions(context.Context, *ListGameSessionsRequest) (*ListGameSessionsResponse, error)
+	// Streams per-session changes to the active sessions (adds, removes, idle updates)
+	SubscribeGameSessions(*SubscribeGameSessionsRequest, grpc.ServerStreamingServer[GameSessionUpdate]) error
+	// Lists a player's finished sessions, newest first
+	ListSessionHistory(context.Context, *ListSessionHistoryRequest) (*ListSessionHistoryResponse, error)
 	// Save management
 	SaveGame(context.Context, *SaveGameRequest) (*SaveGameResponse, error)
 	LoadGame(context.Context, *LoadGameRequest) (*LoadGameResponse, error)
 	DeleteSave(context.Context, *DeleteSaveRequest) (*DeleteSaveResponse, error)
 	ListSaves(context.Context, *ListSavesRequest) (*ListSavesResponse, error)
+	ExportSave(context.Context, *ExportSaveRequest) (*ExportSaveResponse, error)
+	ImportSave(context.Context, *ImportSaveRequest) (*ImportSaveResponse, error)
+	// Account merging: moves a user's game data to the account they were merged into
+	MergeUserData(context.Context, *MergeUserDataRequest) (*MergeUserDataResponse, error)
 	// PTY streaming for terminal I/O
 	StreamGameIO(grpc.BidiStreamingServer[GameIORequest, GameIOResponse]) error
 	ResizeTerminal(context.Context, *ResizeTerminalRequest) (*ResizeTerminalResponse, error)
+	// Status line read off the bottom of the player's screen by the game's adapter
+	UpdateStatusLine(context.Context, *UpdateStatusLineRequest) (*UpdateStatusLineResponse, error)
 	// Spectator management
 	AddSpectator(context.Context, *AddSpectatorRequest) (*AddSpectatorResponse, error)
 	RemoveSpectator(context.Context, *RemoveSpectatorRequest) (*RemoveSpectatorResponse, error)
+	// Dumplog management
+	ListDumplogs(context.Context, *ListDumplogsRequest) (*ListDumplogsResponse, error)
+	GetDumplog(context.Context, *GetDumplogRequest) (*GetDumplogResponse, error)
+	// Screenshots of games, taken by players and spectators to share
+	TakeScreenshot(context.Context, *TakeScreenshotRequest) (*TakeScreenshotResponse, error)
+	ListScreenshots(context.Context, *ListScreenshotsRequest) (*ListScreenshotsResponse, error)
+	GetScreenshot(context.Context, *GetScreenshotRequest) (*GetScreenshotResponse, error)
+	ShareScreenshot(context.Context, *ShareScreenshotRequest) (*ShareScreenshotResponse, error)
+	// Bookmarks of moments in recorded games, dropped while playing or watching
+	AddBookmark(context.Context, *AddBookmarkRequest) (*AddBookmarkResponse, error)
+	ListBookmarks(context.Context, *ListBookmarksRequest) (*ListBookmarksResponse, error)
+	// Leaderboards
+	GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error)
+	// Versioning: the API versions and optional features the game service
+	// supports, so session services can degrade gracefully during upgrades.
+	// Game services that predate it answer Unimplemented and speak version 2.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
 	// Health check
 	Health(context.Context, *emptypb.Empty) (*HealthResponse, error)
+	// Version reports the build of this game service
+	Version(context.Context, *emptypb.Empty) (*VersionResponse, error)
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -318,6 +602,12 @@ func (UnimplementedGameServiceServer) UpdateGame(context.Context, *UpdateGameReq
 func (UnimplementedGameServiceServer) DeleteGame(context.Context, *DeleteGameRequest) (*DeleteGameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGame not implemented")
 }
+func (UnimplementedGameServiceServer) ExportGames(context.Context, *ExportGamesRequest) (*ExportGamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportGames not implemented")
+}
+func (UnimplementedGameServiceServer) SetGameStatus(context.Context, *SetGameStatusRequest) (*SetGameStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetGameStatus not implemented")
+}
 func (UnimplementedGameServiceServer) StartGameSession(context.Context, *StartGameSessionRequest) (*StartGameSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartGameSession not implemented")
 }
@@ -330,6 +620,12 @@ func (UnimplementedGameServiceServer) GetGameSession(context.Context, *GetGameSe
 func (UnimplementedGameServiceServer) ListGameSessions(context.Context, *ListGameSessionsRequest) (*ListGameSessionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListGameSessions not implemented")
 }
+func (UnimplementedGameServiceServer) SubscribeGameSessions(*SubscribeGameSessionsRequest, grpc.ServerStreamingServer[GameSessionUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeGameSessions not implemented")
+}
+func (UnimplementedGameServiceServer) ListSessionHistory(context.Context, *ListSessionHistoryRequest) (*ListSessionHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessionHistory not implemented")
+}
 func (UnimplementedGameServiceServer) SaveGame(context.Context, *SaveGameRequest) (*SaveGameResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SaveGame not implemented")
 }
@@ -342,21 +638,66 @@ func (UnimplementedGameServiceServer) DeleteSave(context.Context, *DeleteSaveReq
 func (UnimplementedGameServiceServer) ListSaves(context.Context, *ListSavesRequest) (*ListSavesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListSaves not implemented")
 }
+func (UnimplementedGameServiceServer) ExportSave(context.Context, *ExportSaveRequest) (*ExportSaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportSave not implemented")
+}
+func (UnimplementedGameServiceServer) ImportSave(context.Context, *ImportSaveRequest) (*ImportSaveResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ImportSave not implemented")
+}
+func (UnimplementedGameServiceServer) MergeUserData(context.Context, *MergeUserDataRequest) (*MergeUserDataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeUserData not implemented")
+}
 func (UnimplementedGameServiceServer) StreamGameIO(grpc.BidiStreamingServer[GameIORequest, GameIOResponse]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamGameIO not implemented")
 }
 func (UnimplementedGameServiceServer) ResizeTerminal(context.Context, *ResizeTerminalRequest) (*ResizeTerminalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ResizeTerminal not implemented")
 }
+func (UnimplementedGameServiceServer) UpdateStatusLine(context.Context, *UpdateStatusLineRequest) (*UpdateStatusLineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateStatusLine not implemented")
+}
 func (UnimplementedGameServiceServer) AddSpectator(context.Context, *AddSpectatorRequest) (*AddSpectatorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AddSpectator not implemented")
 }
 func (UnimplementedGameServiceServer) RemoveSpectator(context.Context, *RemoveSpectatorRequest) (*RemoveSpectatorResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveSpectator not implemented")
 }
+func (UnimplementedGameServiceServer) ListDumplogs(context.Context, *ListDumplogsRequest) (*ListDumplogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDumplogs not implemented")
+}
+func (UnimplementedGameServiceServer) GetDumplog(context.Context, *GetDumplogRequest) (*GetDumplogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDumplog not implemented")
+}
+func (UnimplementedGameServiceServer) TakeScreenshot(context.Context, *TakeScreenshotRequest) (*TakeScreenshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TakeScreenshot not implemented")
+}
+func (UnimplementedGameServiceServer) ListScreenshots(context.Context, *ListScreenshotsRequest) (*ListScreenshotsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScreenshots not implemented")
+}
+func (UnimplementedGameServiceServer) GetScreenshot(context.Context, *GetScreenshotRequest) (*GetScreenshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScreenshot not implemented")
+}
+func (UnimplementedGameServiceServer) ShareScreenshot(context.Context, *ShareScreenshotRequest) (*ShareScreenshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareScreenshot not implemented")
+}
+func (UnimplementedGameServiceServer) AddBookmark(context.Context, *AddBookmarkRequest) (*AddBookmarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddBookmark not implemented")
+}
+func (UnimplementedGameServiceServer) ListBookmarks(context.Context, *ListBookmarksRequest) (*ListBookmarksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBookmarks not implemented")
+}
+func (UnimplementedGameServiceServer) GetLeaderboard(context.Context, *GetLeaderboardRequest) (*GetLeaderboardResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLeaderboard not implemented")
+}
+func (UnimplementedGameServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCapabilities not implemented")
+}
 func (UnimplementedGameServiceServer) Health(context.Context, *emptypb.Empty) (*HealthResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
 }
+func (UnimplementedGameServiceServer) Version(context.Context, *emptypb.Empty) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -468,6 +809,42 @@ func _GameService_DeleteGame_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_ExportGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ExportGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ExportGames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ExportGames(ctx, req.(*ExportGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_SetGameStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetGameStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).SetGameStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_SetGameStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).SetGameStatus(ctx, req.(*SetGameStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_StartGameSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StartGameSessionRequest)
 	if err := dec(in); err != nil {
@@ -540,6 +917,35 @@ func _GameService_ListGameSessions_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_SubscribeGameSessions_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeGameSessionsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).SubscribeGameSessions(m, &grpc.GenericServerStream[SubscribeGameSessionsRequest, GameSessionUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_SubscribeGameSessionsServer = grpc.ServerStreamingServer[GameSessionUpdate]
+
+func _GameService_ListSessionHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListSessionHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListSessionHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListSessionHistory(ctx, req.(*ListSessionHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_SaveGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SaveGameRequest)
 	if err := dec(in); err != nil {
@@ -612,6 +1018,60 @@ func _GameService_ListSaves_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_ExportSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportSaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ExportSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ExportSave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ExportSave(ctx, req.(*ExportSaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ImportSave_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportSaveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ImportSave(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ImportSave_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ImportSave(ctx, req.(*ImportSaveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_MergeUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeUserDataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).MergeUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_MergeUserData_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).MergeUserData(ctx, req.(*MergeUserDataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_StreamGameIO_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(GameServiceServer).StreamGameIO(&grpc.GenericServerStream[GameIORequest, GameIOResponse]{ServerStream: stream})
 }
@@ -637,6 +1097,24 @@ func _GameService_ResizeTerminal_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_UpdateStatusLine_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateStatusLineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).UpdateStatusLine(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_UpdateStatusLine_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).UpdateStatusLine(ctx, req.(*UpdateStatusLineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_AddSpectator_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AddSpectatorRequest)
 	if err := dec(in); err != nil {
@@ -673,6 +1151,186 @@ func _GameService_RemoveSpectator_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_ListDumplogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDumplogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListDumplogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListDumplogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListDumplogs(ctx, req.(*ListDumplogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetDumplog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDumplogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetDumplog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetDumplog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetDumplog(ctx, req.(*GetDumplogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_TakeScreenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TakeScreenshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).TakeScreenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_TakeScreenshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).TakeScreenshot(ctx, req.(*TakeScreenshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ListScreenshots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScreenshotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListScreenshots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListScreenshots_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListScreenshots(ctx, req.(*ListScreenshotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetScreenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScreenshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetScreenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetScreenshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetScreenshot(ctx, req.(*GetScreenshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ShareScreenshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareScreenshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ShareScreenshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ShareScreenshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ShareScreenshot(ctx, req.(*ShareScreenshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_AddBookmark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddBookmarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).AddBookmark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_AddBookmark_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).AddBookmark(ctx, req.(*AddBookmarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_ListBookmarks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBookmarksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).ListBookmarks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_ListBookmarks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).ListBookmarks(ctx, req.(*ListBookmarksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetLeaderboard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLeaderboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetLeaderboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetLeaderboard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetLeaderboard(ctx, req.(*GetLeaderboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GameService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GameService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -691,6 +1349,24 @@ func _GameService_Health_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GameServiceServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GameService_Version_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GameServiceServer).Version(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -718,6 +1394,14 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteGame",
 			Handler:    _GameService_DeleteGame_Handler,
 		},
+		{
+			MethodName: "ExportGames",
+			Handler:    _GameService_ExportGames_Handler,
+		},
+		{
+			MethodName: "SetGameStatus",
+			Handler:    _GameService_SetGameStatus_Handler,
+		},
 		{
 			MethodName: "StartGameSession",
 			Handler:    _GameService_StartGameSession_Handler,
@@ -734,6 +1418,10 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListGameSessions",
 			Handler:    _GameService_ListGameSessions_Handler,
 		},
+		{
+			MethodName: "ListSessionHistory",
+			Handler:    _GameService_ListSessionHistory_Handler,
+		},
 		{
 			MethodName: "SaveGame",
 			Handler:    _GameService_SaveGame_Handler,
@@ -750,10 +1438,26 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSaves",
 			Handler:    _GameService_ListSaves_Handler,
 		},
+		{
+			MethodName: "ExportSave",
+			Handler:    _GameService_ExportSave_Handler,
+		},
+		{
+			MethodName: "ImportSave",
+			Handler:    _GameService_ImportSave_Handler,
+		},
+		{
+			MethodName: "MergeUserData",
+			Handler:    _GameService_MergeUserData_Handler,
+		},
 		{
 			MethodName: "ResizeTerminal",
 			Handler:    _GameService_ResizeTerminal_Handler,
 		},
+		{
+			MethodName: "UpdateStatusLine",
+			Handler:    _GameService_UpdateStatusLine_Handler,
+		},
 		{
 			MethodName: "AddSpectator",
 			Handler:    _GameService_AddSpectator_Handler,
@@ -762,12 +1466,61 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveSpectator",
 			Handler:    _GameService_RemoveSpectator_Handler,
 		},
+		{
+			MethodName: "ListDumplogs",
+			Handler:    _GameService_ListDumplogs_Handler,
+		},
+		{
+			MethodName: "GetDumplog",
+			Handler:    _GameService_GetDumplog_Handler,
+		},
+		{
+			MethodName: "TakeScreenshot",
+			Handler:    _GameService_TakeScreenshot_Handler,
+		},
+		{
+			MethodName: "ListScreenshots",
+			Handler:    _GameService_ListScreenshots_Handler,
+		},
+		{
+			MethodName: "GetScreenshot",
+			Handler:    _GameService_GetScreenshot_Handler,
+		},
+		{
+			MethodName: "ShareScreenshot",
+			Handler:    _GameService_ShareScreenshot_Handler,
+		},
+		{
+			MethodName: "AddBookmark",
+			Handler:    _GameService_AddBookmark_Handler,
+		},
+		{
+			MethodName: "ListBookmarks",
+			Handler:    _GameService_ListBookmarks_Handler,
+		},
+		{
+			MethodName: "GetLeaderboard",
+			Handler:    _GameService_GetLeaderboard_Handler,
+		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _GameService_GetCapabilities_Handler,
+		},
 		{
 			MethodName: "Health",
 			Handler:    _GameService_Health_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _GameService_Version_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeGameSessions",
+			Handler:       _GameService_SubscribeGameSessions_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "StreamGameIO",
 			Handler:       _GameService_StreamGameIO_Handler,
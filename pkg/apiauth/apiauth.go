@@ -0,0 +1,221 @@
+// Package apiauth authenticates REST API callers against the auth service.
+// Callers send an access token or an API key; API keys carry scopes such as
+// "games:read" and every caller is rate limited.
+package apiauth
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/ratelimit"
+	"google.golang.org/grpc"
+)
+
+const (
+	// apiKeyHeader carries an API key for clients that cannot send bearer tokens
+	apiKeyHeader = "X-API-Key"
+	bearerPrefix = "Bearer "
+	// apiKeyPrefix starts every API key, as issued by the auth service
+	apiKeyPrefix = "dg_"
+	// validateTimeout bounds a call to the auth service
+	validateTimeout = 5 * time.Second
+)
+
+// AdminTokenHeader carries the access token of the admin making an admin
+// request, which sends the service token as its bearer token
+const AdminTokenHeader = "X-Admin-Token"
+
+// Validator checks credentials; authv1.AuthServiceClient implements it
+type Validator interface {
+	ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error)
+	ValidateAPIKey(ctx context.Context, in *authv1.ValidateAPIKeyRequest, opts ...grpc.CallOption) (*authv1.ValidateAPIKeyResponse, error)
+}
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	User *authv1.User
+	// APIKey is the key the caller used, nil for access tokens
+	APIKey *authv1.APIKey
+}
+
+// HasScope reports whether the caller may use a scope. Access tokens carry
+// every scope of their user; API keys only those they were granted.
+func (p *Principal) HasScope(scope string) bool {
+	if p.APIKey == nil {
+		return true
+	}
+	for _, granted := range p.APIKey.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// principalKey is the context key of the request principal
+type principalKey struct{}
+
+// FromContext returns the principal of an authenticated request
+func FromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
+
+// Middleware authenticates, authorizes and rate limits REST requests
+type Middleware struct {
+	validator Validator
+	limiter   *ratelimit.Limiter
+	rateLimit int
+	logger    *slog.Logger
+}
+
+// New creates a middleware. rateLimit is the requests per minute allowed to
+// callers with access tokens and to API keys without a limit of their own.
+func New(validator Validator, rateLimit int, logger *slog.Logger) *Middleware {
+	return &Middleware{
+		validator: validator,
+		limiter:   ratelimit.NewLimiter(),
+		rateLimit: rateLimit,
+		logger:    logger,
+	}
+}
+
+// Require protects a handler for a resource such as "games". Reads need the
+// "<resource>:read" scope and every other method "<resource>:write".
+func (m *Middleware) Require(resource string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := resource + ":write"
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			scope = resource + ":read"
+		}
+
+		principal, err := m.authenticate(r)
+		if err != nil {
+			if apierror.CodeOf(err) == apierror.Unauthenticated {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="dungeongate"`)
+			}
+			apierror.WriteProblem(w, r, err)
+			return
+		}
+
+		if !principal.HasScope(scope) {
+			m.logger.Warn("API request denied for missing scope",
+				"username", principal.User.Username, "key_id", principal.APIKey.GetId(), "scope", scope, "path", r.URL.Path)
+			apierror.WriteProblem(w, r, apierror.New(apierror.PermissionDenied, fmt.Sprintf("API key lacks the %s scope", scope)))
+			return
+		}
+
+		bucket, limit, keyID := "user:"+principal.User.Id, m.rateLimit, ""
+		if principal.APIKey != nil {
+			keyID = strconv.Itoa(int(principal.APIKey.Id))
+			bucket = "key:" + keyID
+			if principal.APIKey.RateLimit > 0 {
+				limit = int(principal.APIKey.RateLimit)
+			}
+		}
+		if ok, wait := m.limiter.Allow(bucket, ratelimit.PerMinute(limit)); !ok {
+			w.Header().Set("Retry-After", ratelimit.RetryAfter(wait))
+			apierror.WriteProblem(w, r, apierror.New(apierror.RateLimited, "Rate limit exceeded"))
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), principalKey{}, principal)
+		ctx = ratelimit.WithIdentity(ctx, principal.User.Id, keyID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// authenticate validates the request credentials, returning the error to
+// refuse the request with when they are missing or invalid
+func (m *Middleware) authenticate(r *http.Request) (*Principal, error) {
+	credential := r.Header.Get(apiKeyHeader)
+	if credential == "" {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if !ok || strings.TrimSpace(bearer) == "" {
+			return nil, apierror.New(apierror.Unauthenticated, "Authentication required")
+		}
+		credential = strings.TrimSpace(bearer)
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), validateTimeout)
+	defer cancel()
+
+	if strings.HasPrefix(credential, apiKeyPrefix) {
+		resp, err := m.validator.ValidateAPIKey(ctx, &authv1.ValidateAPIKeyRequest{Key: credential})
+		if err != nil {
+			m.logger.Error("Failed to validate API key", "error", err)
+			return nil, apierror.New(apierror.Unavailable, "Authentication unavailable")
+		}
+		if !resp.Valid {
+			return nil, apierror.New(apierror.Unauthenticated, "Invalid API key")
+		}
+		return &Principal{User: resp.User, APIKey: resp.ApiKey}, nil
+	}
+
+	resp, err := m.validator.ValidateToken(ctx, &authv1.ValidateTokenRequest{AccessToken: credential})
+	if err != nil {
+		m.logger.Error("Failed to validate access token", "error", err)
+		return nil, apierror.New(apierror.Unavailable, "Authentication unavailable")
+	}
+	if !resp.Valid {
+		return nil, apierror.New(apierror.Unauthenticated, "Invalid or expired access token")
+	}
+	return &Principal{User: resp.User}, nil
+}
+
+// RequireAdmin protects a handler for admins, who send their access token in
+// AdminTokenHeader. API keys are refused, as no scope grants admin access.
+// The handler finds the admin with FromContext.
+func (m *Middleware) RequireAdmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimSpace(r.Header.Get(AdminTokenHeader))
+		if token == "" || strings.HasPrefix(token, apiKeyPrefix) {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unauthenticated, "an admin access token is required in "+AdminTokenHeader))
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), validateTimeout)
+		resp, err := m.validator.ValidateToken(ctx, &authv1.ValidateTokenRequest{AccessToken: token})
+		cancel()
+		if err != nil {
+			m.logger.Error("Failed to validate admin access token", "error", err)
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "Authentication unavailable"))
+			return
+		}
+		if !resp.Valid {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unauthenticated, "Invalid or expired admin access token"))
+			return
+		}
+		if !resp.User.GetIsAdmin() {
+			m.logger.Warn("Admin request denied for non-admin", "username", resp.User.GetUsername(), "path", r.URL.Path)
+			apierror.WriteProblem(w, r, apierror.New(apierror.PermissionDenied, "Admin access required"))
+			return
+		}
+
+		ctx = context.WithValue(r.Context(), principalKey{}, &Principal{User: resp.User})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireServiceToken protects an admin handler with the service token the
+// services share, sent as a bearer token. An empty token refuses every
+// request; services leave admin handlers unregistered without one.
+func RequireServiceToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		presented, ok := strings.CutPrefix(r.Header.Get("Authorization"), bearerPrefix)
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(presented), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="dungeongate"`)
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unauthenticated, "missing or invalid service token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
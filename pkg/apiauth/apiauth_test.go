@@ -0,0 +1,152 @@
+package apiauth
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeValidator accepts one access token, one API key and admin tokens
+type fakeValidator struct {
+	token string
+	key   *authv1.APIKey
+	// admins are further access tokens, of the admin "root"
+	admins map[string]bool
+}
+
+func (f *fakeValidator) ValidateToken(ctx context.Context, in *authv1.ValidateTokenRequest, opts ...grpc.CallOption) (*authv1.ValidateTokenResponse, error) {
+	if f.admins[in.AccessToken] {
+		return &authv1.ValidateTokenResponse{Valid: true, User: &authv1.User{Id: "2", Username: "root", IsAdmin: true}}, nil
+	}
+	if in.AccessToken != f.token {
+		return &authv1.ValidateTokenResponse{Valid: false}, nil
+	}
+	return &authv1.ValidateTokenResponse{Valid: true, User: &authv1.User{Id: "1", Username: "player"}}, nil
+}
+
+func (f *fakeValidator) ValidateAPIKey(ctx context.Context, in *authv1.ValidateAPIKeyRequest, opts ...grpc.CallOption) (*authv1.ValidateAPIKeyResponse, error) {
+	if in.Key != "dg_secret" {
+		return &authv1.ValidateAPIKeyResponse{Valid: false}, nil
+	}
+	return &authv1.ValidateAPIKeyResponse{Valid: true, User: &authv1.User{Id: "1", Username: "player"}, ApiKey: f.key}, nil
+}
+
+func newTestHandler(rateLimit int) http.Handler {
+	validator := &fakeValidator{
+		token: "jwt",
+		key:   &authv1.APIKey{Id: 7, Scopes: []string{"games:read"}},
+	}
+	m := New(validator, rateLimit, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	return m.Require("games", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, ok := FromContext(r.Context())
+		if !ok {
+			http.Error(w, "no principal", http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(principal.User.Username))
+	}))
+}
+
+func serve(handler http.Handler, method string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/api/v1/games", nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRequire_Authentication(t *testing.T) {
+	handler := newTestHandler(60)
+
+	rec := serve(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+
+	rec = serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer wrong"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer jwt"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "player", rec.Body.String())
+
+	rec = serve(handler, http.MethodGet, map[string]string{"X-API-Key": "dg_secret"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer dg_secret"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(handler, http.MethodGet, map[string]string{"X-API-Key": "dg_wrong"})
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestRequire_Scopes(t *testing.T) {
+	handler := newTestHandler(60)
+
+	// The key may only read, the access token may do anything its user can
+	rec := serve(handler, http.MethodPost, map[string]string{"X-API-Key": "dg_secret"})
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	rec = serve(handler, http.MethodPost, map[string]string{"Authorization": "Bearer jwt"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestRequire_RateLimit(t *testing.T) {
+	handler := newTestHandler(2)
+	headers := map[string]string{"X-API-Key": "dg_secret"}
+
+	require.Equal(t, http.StatusOK, serve(handler, http.MethodGet, headers).Code)
+	require.Equal(t, http.StatusOK, serve(handler, http.MethodGet, headers).Code)
+
+	rec := serve(handler, http.MethodGet, headers)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"code":"rate_limited"`)
+
+	// Access tokens have a bucket of their own
+	assert.Equal(t, http.StatusOK, serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer jwt"}).Code)
+}
+
+func TestRequireServiceToken(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handler := RequireServiceToken("secret", ok)
+
+	rec := serve(handler, http.MethodGet, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer wrong"}).Code)
+	assert.Equal(t, http.StatusOK, serve(handler, http.MethodGet, map[string]string{"Authorization": "Bearer secret"}).Code)
+
+	// Without a token every request is refused
+	assert.Equal(t, http.StatusUnauthorized, serve(RequireServiceToken("", ok), http.MethodGet, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(RequireServiceToken("", ok), http.MethodGet, map[string]string{"Authorization": "Bearer "}).Code)
+}
+
+func TestRequireAdmin(t *testing.T) {
+	validator := &fakeValidator{token: "jwt", admins: map[string]bool{"root-jwt": true}}
+	m := New(validator, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := m.RequireAdmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal, _ := FromContext(r.Context())
+		w.Write([]byte(principal.User.Username))
+	}))
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, map[string]string{AdminTokenHeader: "wrong"}).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, http.MethodGet, map[string]string{AdminTokenHeader: "dg_secret"}).Code)
+	assert.Equal(t, http.StatusForbidden, serve(handler, http.MethodGet, map[string]string{AdminTokenHeader: "jwt"}).Code)
+
+	// The admin is who the token belongs to, whatever the request claims
+	rec := serve(handler, http.MethodGet, map[string]string{AdminTokenHeader: "root-jwt"})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "root", rec.Body.String())
+}
@@ -0,0 +1,210 @@
+// Package apierror defines the errors the services return to clients. Each
+// carries a machine-readable Code that is mapped the same way to a gRPC
+// status and to an RFC 9457 problem+json response, so clients such as the SSH
+// menu can tell errors apart without matching their messages.
+package apierror
+
+import (
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+)
+
+// Code identifies a kind of error. Codes are part of the API: clients match
+// on them, so they are never renamed.
+type Code string
+
+// General codes
+const (
+	InvalidRequest   Code = "invalid_request"
+	MethodNotAllowed Code = "method_not_allowed"
+	NotFound         Code = "not_found"
+	AlreadyExists    Code = "already_exists"
+	Conflict         Code = "conflict"
+	Unauthenticated  Code = "unauthenticated"
+	PermissionDenied Code = "permission_denied"
+	RateLimited      Code = "rate_limited"
+	Unavailable      Code = "unavailable"
+	NotImplemented   Code = "not_implemented"
+	Internal         Code = "internal"
+)
+
+// Game service codes
+const (
+	GameNotFound         Code = "game_not_found"
+	GameUnavailable      Code = "game_unavailable"
+	SessionNotFound      Code = "session_not_found"
+	SessionNotActive     Code = "session_not_active"
+	ActiveSessionExists  Code = "active_session_exists"
+	SpectatingNotAllowed Code = "spectating_not_allowed"
+	SessionFull          Code = "session_full"
+	SaveNotFound         Code = "save_not_found"
+	SaveCorrupt          Code = "save_corrupt"
+	SaveIncompatible     Code = "save_incompatible"
+	ShuttingDown         Code = "shutting_down"
+	PlayTimeExceeded     Code = "play_time_exceeded"
+)
+
+// Auth service codes
+const (
+	UserNotFound         Code = "user_not_found"
+	InvalidCredentials   Code = "invalid_credentials"
+	AccountLocked        Code = "account_locked"
+	AuthenticationFailed Code = "authentication_failed"
+	UsernameTaken        Code = "username_taken"
+	InvalidUsername      Code = "invalid_username"
+	InvalidEmail         Code = "invalid_email"
+	InvalidPassword      Code = "invalid_password"
+	RegistrationFailed   Code = "registration_failed"
+)
+
+// mapping is how a code is reported over gRPC and HTTP
+type mapping struct {
+	grpc  codes.Code
+	http  int
+	title string
+}
+
+var mappings = map[Code]mapping{
+	InvalidRequest:   {codes.InvalidArgument, http.StatusBadRequest, "Invalid request"},
+	MethodNotAllowed: {codes.Unimplemented, http.StatusMethodNotAllowed, "Method not allowed"},
+	NotFound:         {codes.NotFound, http.StatusNotFound, "Not found"},
+	AlreadyExists:    {codes.AlreadyExists, http.StatusConflict, "Already exists"},
+	Conflict:         {codes.Aborted, http.StatusConflict, "Modified concurrently"},
+	Unauthenticated:  {codes.Unauthenticated, http.StatusUnauthorized, "Authentication required"},
+	PermissionDenied: {codes.PermissionDenied, http.StatusForbidden, "Permission denied"},
+	RateLimited:      {codes.ResourceExhausted, http.StatusTooManyRequests, "Rate limit exceeded"},
+	Unavailable:      {codes.Unavailable, http.StatusServiceUnavailable, "Service unavailable"},
+	NotImplemented:   {codes.Unimplemented, http.StatusNotImplemented, "Not implemented"},
+	Internal:         {codes.Internal, http.StatusInternalServerError, "Internal error"},
+
+	GameNotFound:         {codes.NotFound, http.StatusNotFound, "Game not found"},
+	GameUnavailable:      {codes.FailedPrecondition, http.StatusConflict, "Game not available"},
+	SessionNotFound:      {codes.NotFound, http.StatusNotFound, "Session not found"},
+	SessionNotActive:     {codes.FailedPrecondition, http.StatusConflict, "Session not active"},
+	ActiveSessionExists:  {codes.AlreadyExists, http.StatusConflict, "Game already running"},
+	SpectatingNotAllowed: {codes.PermissionDenied, http.StatusForbidden, "Spectating not allowed"},
+	SessionFull:          {codes.ResourceExhausted, http.StatusConflict, "Session full"},
+	SaveNotFound:         {codes.NotFound, http.StatusNotFound, "Save not found"},
+	SaveCorrupt:          {codes.DataLoss, http.StatusInternalServerError, "Save corrupt"},
+	SaveIncompatible:     {codes.FailedPrecondition, http.StatusConflict, "Save incompatible"},
+	ShuttingDown:         {codes.Unavailable, http.StatusServiceUnavailable, "Shutting down"},
+	PlayTimeExceeded:     {codes.ResourceExhausted, http.StatusTooManyRequests, "Play time used up"},
+
+	UserNotFound:         {codes.NotFound, http.StatusNotFound, "User not found"},
+	InvalidCredentials:   {codes.Unauthenticated, http.StatusUnauthorized, "Invalid credentials"},
+	AccountLocked:        {codes.PermissionDenied, http.StatusForbidden, "Account locked"},
+	AuthenticationFailed: {codes.Unauthenticated, http.StatusUnauthorized, "Authentication failed"},
+	UsernameTaken:        {codes.AlreadyExists, http.StatusConflict, "Username taken"},
+	InvalidUsername:      {codes.InvalidArgument, http.StatusBadRequest, "Invalid username"},
+	InvalidEmail:         {codes.InvalidArgument, http.StatusBadRequest, "Invalid email"},
+	InvalidPassword:      {codes.InvalidArgument, http.StatusBadRequest, "Invalid password"},
+	RegistrationFailed:   {codes.InvalidArgument, http.StatusBadRequest, "Registration failed"},
+}
+
+// lookup returns the mapping of a code, treating unknown codes as internal
+func (c Code) lookup() mapping {
+	if m, ok := mappings[c]; ok {
+		return m
+	}
+	return mappings[Internal]
+}
+
+// GRPCCode returns the gRPC status code the code is reported with
+func (c Code) GRPCCode() codes.Code {
+	return c.lookup().grpc
+}
+
+// HTTPStatus returns the HTTP status the code is reported with
+func (c Code) HTTPStatus() int {
+	return c.lookup().http
+}
+
+// Title returns a short, human-readable summary of the code
+func (c Code) Title() string {
+	return c.lookup().title
+}
+
+// Coder is implemented by errors that carry a Code
+type Coder interface {
+	ErrorCode() Code
+}
+
+// Error is an error with a Code. Its message is safe to show to clients.
+type Error struct {
+	Code    Code
+	Message string
+	cause   error
+}
+
+// New returns an error with a code and client-facing message. It suits
+// sentinel errors: any error with the same code matches it with errors.Is.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap returns an error with a code and client-facing message, keeping err
+// as its cause for logs and errors.Is
+func Wrap(code Code, err error, message string) *Error {
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+// Error implements error
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+// Unwrap returns the cause of the error
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is an *Error with the same code
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// ErrorCode implements Coder
+func (e *Error) ErrorCode() Code {
+	return e.Code
+}
+
+// CodeOf returns the code of err: that of the first error in its chain with
+// one, the code a gRPC status carries, or Internal. It returns "" for nil.
+func CodeOf(err error) Code {
+	if err == nil {
+		return ""
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.ErrorCode()
+	}
+	if code, ok := statusCode(err); ok {
+		return code
+	}
+	return Internal
+}
+
+// MessageOf returns the client-facing message of err: that of the first
+// error in its chain with a code, that of a gRPC status, or the code's title
+func MessageOf(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Message
+	}
+	var coder Coder
+	if errors.As(err, &coder) {
+		if coded, ok := coder.(error); ok {
+			return coded.Error()
+		}
+	}
+	if message, ok := statusMessage(err); ok {
+		return message
+	}
+	return CodeOf(err).Title()
+}
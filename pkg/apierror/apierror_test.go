@@ -0,0 +1,83 @@
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var errGameNotFound = New(GameNotFound, "game not found")
+
+func TestCodeOf(t *testing.T) {
+	assert.Equal(t, Code(""), CodeOf(nil))
+	assert.Equal(t, GameNotFound, CodeOf(errGameNotFound))
+	assert.Equal(t, GameNotFound, CodeOf(fmt.Errorf("failed to start session: %w", errGameNotFound)))
+	assert.Equal(t, Internal, CodeOf(errors.New("boom")))
+
+	// Codes survive a round trip through a gRPC status
+	assert.Equal(t, GameNotFound, CodeOf(Status(errGameNotFound, "").Err()))
+	// Statuses without one are mapped by their gRPC code
+	assert.Equal(t, NotFound, CodeOf(status.Error(codes.NotFound, "missing")))
+	assert.Equal(t, Internal, CodeOf(status.Error(codes.Unknown, "unknown")))
+
+	// Messages of wrapped statuses lose the context they were wrapped in
+	wrapped := fmt.Errorf("failed to start game session: %w", Status(errGameNotFound, "").Err())
+	assert.Equal(t, GameNotFound, CodeOf(wrapped))
+	assert.Equal(t, "game not found", MessageOf(wrapped))
+}
+
+func TestErrorIs(t *testing.T) {
+	wrapped := Wrap(GameNotFound, errors.New("no such row"), "game not found")
+	assert.ErrorIs(t, wrapped, errGameNotFound)
+	assert.NotErrorIs(t, wrapped, New(SaveNotFound, "save not found"))
+	assert.Equal(t, "game not found: no such row", wrapped.Error())
+	assert.Equal(t, "game not found", MessageOf(fmt.Errorf("context: %w", wrapped)))
+}
+
+func TestStatus(t *testing.T) {
+	st := Status(fmt.Errorf("lookup: %w", errGameNotFound), "failed")
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "game not found", st.Message())
+
+	// Errors without a code do not leak their message
+	st = Status(errors.New("connection refused"), "failed to start game session")
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Equal(t, "failed to start game session", st.Message())
+
+	// Status errors pass through
+	st = Status(status.Error(codes.Unavailable, "draining"), "failed")
+	assert.Equal(t, codes.Unavailable, st.Code())
+	assert.Equal(t, "draining", st.Message())
+}
+
+func TestWriteProblem(t *testing.T) {
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/api/games/rogue", nil)
+	WriteProblem(rec, req, fmt.Errorf("lookup: %w", errGameNotFound))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ProblemContentType, rec.Header().Get("Content-Type"))
+	var problem Problem
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&problem))
+	assert.Equal(t, Problem{
+		Type:     "urn:dungeongate:error:game_not_found",
+		Title:    "Game not found",
+		Status:   http.StatusNotFound,
+		Detail:   "game not found",
+		Instance: "/api/games/rogue",
+		Code:     GameNotFound,
+	}, problem)
+
+	rec = httptest.NewRecorder()
+	WriteProblem(rec, req, errors.New("pq: relation does not exist"))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "pq:")
+}
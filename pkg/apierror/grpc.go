@@ -0,0 +1,82 @@
+package apierror
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is the ErrorInfo domain the codes are reported under
+const Domain = "dungeongate"
+
+// grpcFallbacks is the code of a status that carries none, by gRPC code
+var grpcFallbacks = map[codes.Code]Code{
+	codes.InvalidArgument:   InvalidRequest,
+	codes.NotFound:          NotFound,
+	codes.AlreadyExists:     AlreadyExists,
+	codes.Aborted:           Conflict,
+	codes.Unauthenticated:   Unauthenticated,
+	codes.PermissionDenied:  PermissionDenied,
+	codes.ResourceExhausted: RateLimited,
+	codes.Unavailable:       Unavailable,
+	codes.Unimplemented:     NotImplemented,
+}
+
+// GRPCStatus lets gRPC handlers return an *Error directly
+func (e *Error) GRPCStatus() *status.Status {
+	return withCode(status.New(e.Code.GRPCCode(), e.Message), e.Code)
+}
+
+// Status converts err to a gRPC status. Errors with a code keep their
+// message and carry the code in an ErrorInfo detail; a status error passes
+// through; anything else becomes Internal with the fallback message, so
+// internals do not leak to clients.
+func Status(err error, fallback string) *status.Status {
+	var coder Coder
+	if errors.As(err, &coder) {
+		code := coder.ErrorCode()
+		return withCode(status.New(code.GRPCCode(), MessageOf(err)), code)
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	return withCode(status.New(codes.Internal, fallback), Internal)
+}
+
+// withCode attaches code to a status as an ErrorInfo detail
+func withCode(st *status.Status, code Code) *status.Status {
+	if detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: string(code), Domain: Domain}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// statusMessage returns the message of the gRPC status in err's chain,
+// without the context it was wrapped in
+func statusMessage(err error) (string, bool) {
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return "", false
+	}
+	return grpcErr.GRPCStatus().Message(), true
+}
+
+// statusCode returns the code of a gRPC status error: the one in its
+// ErrorInfo detail, or one derived from the status code
+func statusCode(err error) (Code, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return "", false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.Domain == Domain && info.Reason != "" {
+			return Code(info.Reason), true
+		}
+	}
+	if code, ok := grpcFallbacks[st.Code()]; ok {
+		return code, true
+	}
+	return Internal, true
+}
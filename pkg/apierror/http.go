@@ -0,0 +1,52 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ProblemContentType is the media type of problem responses
+const ProblemContentType = "application/problem+json"
+
+// typePrefix namespaces problem types by code
+const typePrefix = "urn:dungeongate:error:"
+
+// Problem is an RFC 9457 problem details response body, extended with the
+// error code
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	Code     Code   `json:"code"`
+}
+
+// ProblemFor returns the problem describing err. Errors without a code are
+// reported as internal errors without their message.
+func ProblemFor(err error) Problem {
+	code := CodeOf(err)
+	detail := code.Title()
+	if code != Internal {
+		detail = MessageOf(err)
+	}
+	return Problem{
+		Type:   typePrefix + string(code),
+		Title:  code.Title(),
+		Status: code.HTTPStatus(),
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// WriteProblem writes err to w as a problem+json response
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	problem := ProblemFor(err)
+	if r != nil {
+		problem.Instance = r.URL.Path
+	}
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
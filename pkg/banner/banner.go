@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/dungeongate/assets"
 )
 
 // BannerManager handles loading and rendering banners with template variables
@@ -16,6 +19,7 @@ type BannerManager struct {
 	serverStarted time.Time
 	unicode       *UnicodeSupport
 	version       string
+	overrides     map[string]string // Runtime templates by banner name, used instead of files
 	mu            sync.RWMutex
 }
 
@@ -138,6 +142,10 @@ type BannerConfig struct {
 	MainAdmin          string
 	WatchMenu          string
 	ServiceUnavailable string
+	ServerFull         string
+	Credits            string            // Markdown shown by the credits screen
+	MOTD               string            // Sent to SSH clients before they authenticate
+	GameNews           map[string]string // News files shown before games start, by game ID
 
 	// Header and footer configuration
 	Headers HeaderFooterConfig `yaml:"headers"`
@@ -169,6 +177,7 @@ func NewBannerManager(config *BannerConfig, version string) *BannerManager {
 		serverStarted: time.Now(),
 		unicode:       unicode,
 		version:       version,
+		overrides:     make(map[string]string),
 	}
 }
 
@@ -183,7 +192,7 @@ func NewBannerCache(ttl time.Duration, maxSize int) *BannerCache {
 
 // RenderMainAnon renders the main anonymous user banner with header and footer
 func (bm *BannerManager) RenderMainAnon() (string, error) {
-	if bm.config.MainAnon == "" {
+	if bm.config.MainAnon == "" && !bm.HasOverride(NameMainAnon) {
 		return "", fmt.Errorf("main anonymous banner path is not configured")
 	}
 
@@ -193,7 +202,7 @@ func (bm *BannerManager) RenderMainAnon() (string, error) {
 	header := bm.RenderHeader("anonymous", variables)
 
 	// Get main banner content
-	banner, err := bm.renderBanner(bm.config.MainAnon, variables)
+	banner, err := bm.renderNamed(NameMainAnon, bm.config.MainAnon, variables)
 	if err != nil {
 		return "", err
 	}
@@ -208,7 +217,7 @@ func (bm *BannerManager) RenderMainAnon() (string, error) {
 
 // RenderMainUser renders the main authenticated user banner with header and footer
 func (bm *BannerManager) RenderMainUser(username string) (string, error) {
-	if bm.config.MainUser == "" {
+	if bm.config.MainUser == "" && !bm.HasOverride(NameMainUser) {
 		return "", fmt.Errorf("main user banner path is not configured")
 	}
 
@@ -218,7 +227,7 @@ func (bm *BannerManager) RenderMainUser(username string) (string, error) {
 	header := bm.RenderHeader("user", variables)
 
 	// Get main banner content
-	banner, err := bm.renderBanner(bm.config.MainUser, variables)
+	banner, err := bm.renderNamed(NameMainUser, bm.config.MainUser, variables)
 	if err != nil {
 		return "", err
 	}
@@ -233,7 +242,7 @@ func (bm *BannerManager) RenderMainUser(username string) (string, error) {
 
 // RenderMainAdmin renders the main admin menu banner for admin users
 func (bm *BannerManager) RenderMainAdmin(username string) (string, error) {
-	if bm.config.MainAdmin == "" {
+	if bm.config.MainAdmin == "" && !bm.HasOverride(NameMainAdmin) {
 		return "", fmt.Errorf("main admin banner path is not configured (MainAdmin field is empty, config: %+v)", bm.config)
 	}
 
@@ -243,7 +252,7 @@ func (bm *BannerManager) RenderMainAdmin(username string) (string, error) {
 	header := bm.RenderHeader("admin", variables)
 
 	// Get main banner content
-	banner, err := bm.renderBanner(bm.config.MainAdmin, variables)
+	banner, err := bm.renderNamed(NameMainAdmin, bm.config.MainAdmin, variables)
 	if err != nil {
 		return "", err
 	}
@@ -258,7 +267,7 @@ func (bm *BannerManager) RenderMainAdmin(username string) (string, error) {
 
 // RenderWatchMenu renders the watch menu banner
 func (bm *BannerManager) RenderWatchMenu() (string, error) {
-	return bm.renderBanner(bm.config.WatchMenu, map[string]string{
+	return bm.renderNamed(NameWatchMenu, bm.config.WatchMenu, map[string]string{
 		"$SERVERID": "DungeonGate",
 		"$DATE":     time.Now().Format("2006-01-02"),
 		"$TIME":     time.Now().Format("15:04:05"),
@@ -280,7 +289,7 @@ func (bm *BannerManager) RenderServiceUnavailable(username string, remainingMinu
 		filePath = "./assets/banners/service_unavailable.txt"
 	}
 
-	return bm.renderBanner(filePath, map[string]string{
+	return bm.renderNamed(NameServiceUnavailable, filePath, map[string]string{
 		"$SERVERID":       "DungeonGate",
 		"$USERNAME":       username,
 		"$DATE":           time.Now().Format("2006-01-02"),
@@ -290,26 +299,119 @@ func (bm *BannerManager) RenderServiceUnavailable(username string, remainingMinu
 	})
 }
 
+// RenderServerFull renders the banner shown to connections turned away
+// because the server has max_connections players
+func (bm *BannerManager) RenderServerFull() (string, error) {
+	filePath := bm.config.ServerFull
+	if filePath == "" {
+		filePath = "./assets/banners/server_full.txt"
+	}
+	return bm.renderNamed(NameServerFull, filePath, bm.GetTemplateVariables(""))
+}
+
+// RenderCredits renders the credits, written in markdown, from their runtime
+// template or else their file
+func (bm *BannerManager) RenderCredits(username string) (string, error) {
+	content, ok := bm.override(NameCredits)
+	if !ok {
+		filePath := bm.config.Credits
+		if filePath == "" {
+			filePath = "./assets/banners/credits.md"
+		}
+		var err error
+		if content, err = bm.readBanner(NameCredits, filePath); err != nil {
+			return "", err
+		}
+	}
+	return bm.renderContent(RenderMarkdown(content), bm.GetTemplateVariables(username)), nil
+}
+
+// MOTDValues are the live values of the pre-authentication banner
+type MOTDValues struct {
+	ServerName  string
+	LegalNotice string
+	Players     int // Connections to this session service
+	Games       int // Games in progress
+}
+
+// RenderMOTD renders the banner SSH clients are sent before they
+// authenticate. Nobody is logged in yet, so it has no $USERNAME.
+func (bm *BannerManager) RenderMOTD(values MOTDValues) (string, error) {
+	filePath := bm.config.MOTD
+	if filePath == "" {
+		filePath = "./assets/banners/motd.txt"
+	}
+	variables := bm.GetTemplateVariables("")
+	variables["$SERVER_NAME"] = values.ServerName
+	variables["$PLAYERS"] = strconv.Itoa(values.Players)
+	variables["$GAMES"] = strconv.Itoa(values.Games)
+	variables["$LEGAL_NOTICE"] = strings.TrimRight(values.LegalNotice, "\n")
+	return bm.renderNamed(NameMOTD, filePath, variables)
+}
+
+// Version returns the version of this session service
+func (bm *BannerManager) Version() string {
+	return bm.version
+}
+
+// RenderGameNews renders the news shown before a game starts: its runtime
+// template, or else its configured file. ok is false when the game has none.
+func (bm *BannerManager) RenderGameNews(gameID, username string) (news string, ok bool, err error) {
+	name := NewsName(gameID)
+	filePath := bm.config.GameNews[gameID]
+	if filePath == "" && !bm.HasOverride(name) {
+		return "", false, nil
+	}
+
+	news, err = bm.renderNamed(name, filePath, bm.GetTemplateVariables(username))
+	if err != nil {
+		return "", false, err
+	}
+	return news, true, nil
+}
+
 // renderBanner loads a banner file and substitutes template variables
-func (bm *BannerManager) renderBanner(filePath string, variables map[string]string) (string, error) {
+func (bm *BannerManager) renderBanner(name, filePath string, variables map[string]string) (string, error) {
+	content, err := bm.readBanner(name, filePath)
+	if err != nil {
+		return "", err
+	}
+	return bm.renderContent(content, variables), nil
+}
+
+// readBanner loads a banner file. A missing file falls back to the banner's
+// default built into the binary.
+func (bm *BannerManager) readBanner(name, filePath string) (string, error) {
 	// Check if filePath is empty
 	if filePath == "" {
 		return "", fmt.Errorf("banner file path is empty")
 	}
 
-	// Check if file exists
-	if _, err := os.Stat(filePath); os.IsNotExist(err) {
-		return "", fmt.Errorf("banner file not found: %s", filePath)
-	}
-
 	// Read the banner file
 	content, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		defaultContent, ok := assets.Banner(name)
+		if !ok {
+			return "", fmt.Errorf("banner file not found: %s", filePath)
+		}
+		content, err = defaultContent, nil
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to read banner file %s: %w", filePath, err)
 	}
+	return string(content), nil
+}
+
+// renderNamed renders a banner's runtime template if one is set, and its file otherwise
+func (bm *BannerManager) renderNamed(name, filePath string, variables map[string]string) (string, error) {
+	if content, ok := bm.override(name); ok {
+		return bm.renderContent(content, variables), nil
+	}
+	return bm.renderBanner(name, filePath, variables)
+}
 
-	// Convert to string and substitute variables
-	banner := string(content)
+// renderContent substitutes template variables in banner content and prepares it for SSH
+func (bm *BannerManager) renderContent(banner string, variables map[string]string) string {
 	for variable, value := range variables {
 		banner = strings.ReplaceAll(banner, variable, value)
 	}
@@ -325,7 +427,7 @@ func (bm *BannerManager) renderBanner(filePath string, variables map[string]stri
 		banner += "\r\n"
 	}
 
-	return banner, nil
+	return banner
 }
 
 // RenderHeader renders a header for the specified menu type
@@ -333,21 +435,7 @@ func (bm *BannerManager) RenderHeader(menuType string, variables map[string]stri
 	var headerText string
 
 	// Get menu-specific header first, fall back to global
-	switch menuType {
-	case "anonymous":
-		headerText = bm.config.Headers.Anonymous
-	case "user":
-		headerText = bm.config.Headers.User
-	case "game_selection":
-		headerText = bm.config.Headers.GameSelection
-	default:
-		headerText = bm.config.Headers.Global
-	}
-
-	// If no specific header, use global
-	if headerText == "" {
-		headerText = bm.config.Headers.Global
-	}
+	headerText = bm.headerFooterText("header", menuType, bm.config.Headers)
 
 	// If still no header, return empty
 	if headerText == "" {
@@ -376,21 +464,7 @@ func (bm *BannerManager) RenderFooter(menuType string, variables map[string]stri
 	var footerText string
 
 	// Get menu-specific footer first, fall back to global
-	switch menuType {
-	case "anonymous":
-		footerText = bm.config.Footers.Anonymous
-	case "user":
-		footerText = bm.config.Footers.User
-	case "game_selection":
-		footerText = bm.config.Footers.GameSelection
-	default:
-		footerText = bm.config.Footers.Global
-	}
-
-	// If no specific footer, use global
-	if footerText == "" {
-		footerText = bm.config.Footers.Global
-	}
+	footerText = bm.headerFooterText("footer", menuType, bm.config.Footers)
 
 	// If still no footer, return empty
 	if footerText == "" {
@@ -414,6 +488,35 @@ func (bm *BannerManager) RenderFooter(menuType string, variables map[string]stri
 	return footerText
 }
 
+// headerFooterText returns the header or footer text for a menu type. Runtime
+// templates take precedence over configured text at each level.
+func (bm *BannerManager) headerFooterText(kind, menuType string, configured HeaderFooterConfig) string {
+	var text string
+	switch menuType {
+	case "anonymous":
+		text = configured.Anonymous
+	case "user":
+		text = configured.User
+	case "game_selection":
+		text = configured.GameSelection
+	default:
+		menuType = "global"
+		text = configured.Global
+	}
+	if content, ok := bm.override(kind + "_" + menuType); ok {
+		text = content
+	}
+
+	if text == "" {
+		text = configured.Global
+		if content, ok := bm.override(kind + "_global"); ok {
+			text = content
+		}
+	}
+
+	return text
+}
+
 // GetTemplateVariables returns common template variables for banner rendering
 func (bm *BannerManager) GetTemplateVariables(username string) map[string]string {
 	now := time.Now()
@@ -451,7 +554,8 @@ func formatUptime(d time.Duration) string {
 	}
 }
 
-// ValidateBannerFiles checks if all configured banner files exist
+// ValidateBannerFiles checks if all configured banner files exist or have a
+// default built into the binary
 func (bm *BannerManager) ValidateBannerFiles() error {
 	files := []struct {
 		name string
@@ -461,10 +565,16 @@ func (bm *BannerManager) ValidateBannerFiles() error {
 		{"main_user", bm.config.MainUser},
 		{"watch_menu", bm.config.WatchMenu},
 		{"service_unavailable", bm.config.ServiceUnavailable},
+		{"server_full", bm.config.ServerFull},
+		{"credits", bm.config.Credits},
+		{"motd", bm.config.MOTD},
 	}
 
 	for _, file := range files {
 		if _, err := os.Stat(file.path); err != nil {
+			if _, ok := assets.Banner(file.name); ok && os.IsNotExist(err) {
+				continue
+			}
 			if os.IsNotExist(err) {
 				return fmt.Errorf("banner file %s not found: %s", file.name, file.path)
 			}
@@ -484,6 +594,9 @@ func (bm *BannerManager) GetBannerInfo() map[string]fs.FileInfo {
 		"main_user":           bm.config.MainUser,
 		"watch_menu":          bm.config.WatchMenu,
 		"service_unavailable": bm.config.ServiceUnavailable,
+		"server_full":         bm.config.ServerFull,
+		"credits":             bm.config.Credits,
+		"motd":                bm.config.MOTD,
 	}
 
 	for name, path := range files {
@@ -2,11 +2,14 @@ package banner
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/assets"
 )
 
 func TestBannerManager_RenderMainAnon(t *testing.T) {
@@ -108,21 +111,39 @@ func TestBannerManager_EmptyPath(t *testing.T) {
 }
 
 func TestBannerManager_FileNotFound(t *testing.T) {
-	// Create banner manager with non-existent file
+	// Create banner manager with non-existent files
 	config := &BannerConfig{
 		MainAnon:  "/nonexistent/path/banner.txt",
 		MainUser:  "",
-		WatchMenu: "",
+		WatchMenu: "/nonexistent/path/watch.txt",
 	}
 	manager := NewBannerManager(config, "test-version")
 
-	// Test rendering with non-existent file
+	// Banners with a built-in default fall back to it
+	_, ok := assets.Banner(NameMainAnon)
+	require.True(t, ok)
 	result, err := manager.RenderMainAnon()
+	require.NoError(t, err)
+	assert.Contains(t, result, "Connected as: Anonymous\r\n")
+	assert.NotContains(t, result, "$DATE")
+
+	// Banners without one fail
+	result, err = manager.RenderWatchMenu()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "banner file not found")
 	assert.Empty(t, result)
 }
 
+func TestBannerManager_FileOverridesDefault(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main_anon.txt")
+	require.NoError(t, os.WriteFile(path, []byte("Local banner"), 0644))
+	manager := NewBannerManager(&BannerConfig{MainAnon: path}, "test-version")
+
+	result, err := manager.RenderMainAnon()
+	require.NoError(t, err)
+	assert.Equal(t, "Local banner\r\n", result)
+}
+
 func TestBannerManager_VariableSubstitution(t *testing.T) {
 	// Create a temporary banner file with all variables
 	tempFile, err := os.CreateTemp("", "test_variables_*.txt")
@@ -235,3 +256,21 @@ func TestBannerManager_LineEndingConversion(t *testing.T) {
 	assert.Contains(t, result, "Line 3\r\n")
 	assert.NotContains(t, result, "Line 1\n")
 }
+
+func TestBannerManager_RenderMOTD(t *testing.T) {
+	manager := NewBannerManager(&BannerConfig{MOTD: "/nonexistent/motd.txt"}, "1.2.3")
+	values := MOTDValues{ServerName: "Example Dungeon", LegalNotice: "Authorized use only.\nActivity is logged.\n", Players: 7, Games: 3}
+
+	// The built-in default shows every value
+	result, err := manager.RenderMOTD(values)
+	require.NoError(t, err)
+	assert.Contains(t, result, "Example Dungeon - DungeonGate 1.2.3\r\n")
+	assert.Contains(t, result, "7 connected, 3 games in progress")
+	assert.Contains(t, result, "Authorized use only.\r\nActivity is logged.\r\n")
+	assert.NotContains(t, result, "$")
+
+	manager.SetOverride(NameMOTD, "$SERVER_NAME: $PLAYERS/$GAMES")
+	result, err = manager.RenderMOTD(values)
+	require.NoError(t, err)
+	assert.Equal(t, "Example Dungeon: 7/3\r\n", result)
+}
@@ -0,0 +1,68 @@
+package banner
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Terminal attributes used for markdown emphasis
+const (
+	ansiBold      = "\033[1m"
+	ansiUnderline = "\033[4m"
+	ansiReset     = "\033[0m"
+)
+
+var (
+	markdownBold = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownCode = regexp.MustCompile("`([^`]+)`")
+	markdownLink = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+)
+
+// RenderMarkdown turns the markdown of banners such as the credits into
+// terminal text. Headings and **bold** are drawn bold, list items get
+// bullets, links show their address, and fenced code blocks are kept
+// exactly as written, which suits ASCII art.
+func RenderMarkdown(content string) string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+	fenced := false
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			fenced = !fenced
+			continue
+		}
+		if fenced {
+			out = append(out, line)
+			continue
+		}
+		out = append(out, markdownLine(line))
+	}
+	return strings.Join(out, "\n")
+}
+
+// markdownLine renders one line outside a code block
+func markdownLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case strings.HasPrefix(trimmed, "# "):
+		return ansiBold + ansiUnderline + markdownInline(trimmed[2:]) + ansiReset
+	case strings.HasPrefix(trimmed, "#"):
+		return ansiBold + markdownInline(strings.TrimSpace(strings.TrimLeft(trimmed, "#"))) + ansiReset
+	case trimmed == "---" || trimmed == "***":
+		return strings.Repeat("─", 40)
+	case strings.HasPrefix(trimmed, "- "), strings.HasPrefix(trimmed, "* "):
+		indent := line[:len(line)-len(strings.TrimLeft(line, " "))]
+		return indent + "  • " + markdownInline(trimmed[2:])
+	case strings.HasPrefix(trimmed, "> "):
+		return "  │ " + markdownInline(trimmed[2:])
+	default:
+		return markdownInline(line)
+	}
+}
+
+// markdownInline renders emphasis, code and links within a line
+func markdownInline(text string) string {
+	text = markdownBold.ReplaceAllString(text, ansiBold+"$1"+ansiReset)
+	text = markdownCode.ReplaceAllString(text, "$1")
+	return markdownLink.ReplaceAllString(text, "$1 <$2>")
+}
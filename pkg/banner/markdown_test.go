@@ -0,0 +1,38 @@
+package banner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	content := "# Credits\n## Team\n**Alice** wrote `dgctl`\n- see [the site](https://example.org)\n> thanks\n---\n```\n| _ \\ # not a heading\n- not a list\n```\n"
+	assert.Equal(t,
+		"\033[1m\033[4mCredits\033[0m\n"+
+			"\033[1mTeam\033[0m\n"+
+			"\033[1mAlice\033[0m wrote dgctl\n"+
+			"  • see the site <https://example.org>\n"+
+			"  │ thanks\n"+
+			"────────────────────────────────────────\n"+
+			"| _ \\ # not a heading\n"+
+			"- not a list\n",
+		RenderMarkdown(content))
+}
+
+func TestBannerManager_RenderCredits(t *testing.T) {
+	manager := NewBannerManager(&BannerConfig{Credits: "/nonexistent/credits.md"}, "test-version")
+
+	// The built-in credits are used without a file
+	result, err := manager.RenderCredits("alice")
+	require.NoError(t, err)
+	assert.Contains(t, result, "|____/")
+	assert.Contains(t, result, "\033[1mDungeonGate\033[0m - Terminal Game Platform\r\n")
+
+	// Runtime credits take precedence and are markdown too
+	manager.SetOverride(NameCredits, "# Thanks, $USERNAME\nServer $VERSION")
+	result, err = manager.RenderCredits("alice")
+	require.NoError(t, err)
+	assert.Equal(t, "\033[1m\033[4mThanks, alice\033[0m\r\nServer test-version\r\n", result)
+}
@@ -0,0 +1,208 @@
+package banner
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Banner names used for runtime templates
+const (
+	NameMainAnon           = "main_anon"
+	NameMainUser           = "main_user"
+	NameMainAdmin          = "main_admin"
+	NameWatchMenu          = "watch_menu"
+	NameServiceUnavailable = "service_unavailable"
+	NameServerFull         = "server_full"
+	NameCredits            = "credits"
+	NameMOTD               = "motd"
+)
+
+// Names lists every banner and menu text that can be replaced at runtime.
+// Headers and footers are named "header_<menu>" and "footer_<menu>". The
+// news shown before a game starts is named "news_<game>" for any game.
+var Names = []string{
+	NameMainAnon,
+	NameMainUser,
+	NameMainAdmin,
+	NameWatchMenu,
+	NameServiceUnavailable,
+	NameServerFull,
+	NameCredits,
+	NameMOTD,
+	"header_global",
+	"header_anonymous",
+	"header_user",
+	"header_game_selection",
+	"footer_global",
+	"footer_anonymous",
+	"footer_user",
+	"footer_game_selection",
+}
+
+// Variables lists the template variables substituted when banners are rendered
+var Variables = []string{
+	"$SERVERID",
+	"$DATE",
+	"$TIME",
+	"$UPTIME",
+	"$VERSION",
+	"$TIMEZONE",
+	"$USERNAME",
+	"$COUNTDOWN",
+	"$SERVICE_STATUS",
+	"$SERVER_NAME",
+	"$PLAYERS",
+	"$GAMES",
+	"$LEGAL_NOTICE",
+}
+
+// NewsPrefix starts the names of the news shown before a game starts
+const NewsPrefix = "news_"
+
+// MaxTemplateSize is the largest banner template accepted at runtime
+const MaxTemplateSize = 16 * 1024
+
+var variablePattern = regexp.MustCompile(`\$[A-Z][A-Z_]*`)
+
+// newsGamePattern matches the game IDs of news names; names are stored in a
+// 50 character column
+var newsGamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,44}$`)
+
+// NewsName returns the banner name of a game's news
+func NewsName(gameID string) string {
+	return NewsPrefix + gameID
+}
+
+// IsValidName reports whether a banner name can be replaced at runtime
+func IsValidName(name string) bool {
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	if gameID, ok := strings.CutPrefix(name, NewsPrefix); ok {
+		return newsGamePattern.MatchString(gameID)
+	}
+	return false
+}
+
+// ValidateTemplate checks a banner template before it is stored: the name must
+// be known, the content valid UTF-8 of reasonable size without control
+// characters other than tabs, newlines and ANSI escapes, and every $VARIABLE
+// must be one that rendering substitutes.
+func ValidateTemplate(name, content string) error {
+	if !IsValidName(name) {
+		return fmt.Errorf("unknown banner: %s", name)
+	}
+	if len(content) > MaxTemplateSize {
+		return fmt.Errorf("banner template is %d bytes, the limit is %d", len(content), MaxTemplateSize)
+	}
+	if !utf8.ValidString(content) {
+		return fmt.Errorf("banner template is not valid UTF-8")
+	}
+
+	for i, r := range content {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' && r != 0x1b {
+			return fmt.Errorf("banner template contains control character %U at byte %d", r, i)
+		}
+	}
+
+	for _, variable := range variablePattern.FindAllString(content, -1) {
+		if !isKnownVariable(variable) {
+			return fmt.Errorf("unknown template variable %s (known: %s)", variable, strings.Join(Variables, ", "))
+		}
+	}
+
+	return nil
+}
+
+// isKnownVariable reports whether a $VARIABLE token is substituted. The token
+// may run into trailing capitals, e.g. "$USERNAMES" still renders $USERNAME.
+func isKnownVariable(token string) bool {
+	for _, variable := range Variables {
+		if strings.HasPrefix(token, variable) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOverride replaces a banner's file or configured text with a runtime template
+func (bm *BannerManager) SetOverride(name, content string) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.overrides[name] = content
+}
+
+// ClearOverride removes a banner's runtime template
+func (bm *BannerManager) ClearOverride(name string) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	delete(bm.overrides, name)
+}
+
+// ClearOverrides removes every runtime template
+func (bm *BannerManager) ClearOverrides() {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.overrides = make(map[string]string)
+}
+
+// HasOverride reports whether a banner has a runtime template
+func (bm *BannerManager) HasOverride(name string) bool {
+	_, ok := bm.override(name)
+	return ok
+}
+
+// override returns a banner's runtime template
+func (bm *BannerManager) override(name string) (string, bool) {
+	bm.mu.RLock()
+	defer bm.mu.RUnlock()
+	content, ok := bm.overrides[name]
+	return content, ok
+}
+
+// Preview validates a banner template and renders it as a session service
+// would, with sample values for variables that depend on the session
+func Preview(name, content, username string, utf8Enabled bool) (string, error) {
+	if err := ValidateTemplate(name, content); err != nil {
+		return "", err
+	}
+
+	bm := NewBannerManager(&BannerConfig{}, "preview")
+	bm.SetUTF8Support(utf8Enabled)
+	bm.SetOverride(name, content)
+
+	if username == "" {
+		username = "player"
+	}
+	variables := bm.GetTemplateVariables(username)
+
+	switch {
+	case name == NameMainAnon:
+		return bm.RenderMainAnon()
+	case name == NameMainUser:
+		return bm.RenderMainUser(username)
+	case name == NameMainAdmin:
+		return bm.RenderMainAdmin(username)
+	case name == NameWatchMenu:
+		return bm.RenderWatchMenu()
+	case name == NameServiceUnavailable:
+		return bm.RenderServiceUnavailable(username, 4, 30, "game service unavailable")
+	case name == NameServerFull:
+		return bm.RenderServerFull()
+	case name == NameCredits:
+		return bm.RenderCredits(username)
+	case name == NameMOTD:
+		return bm.RenderMOTD(MOTDValues{ServerName: "DungeonGate", LegalNotice: "Authorized use only.", Players: 12, Games: 5})
+	case strings.HasPrefix(name, NewsPrefix):
+		news, _, err := bm.RenderGameNews(strings.TrimPrefix(name, NewsPrefix), username)
+		return news, err
+	case strings.HasPrefix(name, "header_"):
+		return bm.RenderHeader(strings.TrimPrefix(name, "header_"), variables), nil
+	default:
+		return bm.RenderFooter(strings.TrimPrefix(name, "footer_"), variables), nil
+	}
+}
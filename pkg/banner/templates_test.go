@@ -0,0 +1,48 @@
+package banner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTemplate(t *testing.T) {
+	assert.NoError(t, ValidateTemplate(NameMainUser, "Hi $USERNAME, it is $DATE\n\x1b[1mPlay\x1b[0m"))
+	assert.Error(t, ValidateTemplate("welcome", "Hi"))
+	assert.Error(t, ValidateTemplate(NameMainUser, "Hi $PLAYER"))
+	assert.Error(t, ValidateTemplate(NameMainUser, "bell\a"))
+	assert.Error(t, ValidateTemplate(NameMainUser, "\xff"))
+}
+
+func TestBannerManager_Overrides(t *testing.T) {
+	manager := NewBannerManager(&BannerConfig{
+		MainAnon: "/nonexistent/path/banner.txt",
+		Headers:  HeaderFooterConfig{Global: "configured header"},
+	}, "test-version")
+
+	manager.SetOverride(NameMainAnon, "Runtime $SERVERID")
+	manager.SetOverride("header_anonymous", "runtime header")
+
+	result, err := manager.RenderMainAnon()
+	require.NoError(t, err)
+	assert.Equal(t, "runtime header\r\nRuntime DungeonGate\r\n", result)
+	assert.Equal(t, "configured header\r\n", manager.RenderHeader("user", nil))
+
+	manager.ClearOverride(NameMainAnon)
+	result, err = manager.RenderMainAnon()
+	require.NoError(t, err)
+	assert.NotContains(t, result, "Runtime")
+}
+
+func TestGameNewsNames(t *testing.T) {
+	assert.True(t, IsValidName(NewsName("nethack")))
+	assert.True(t, IsValidName("news_nethack-3.7"))
+	assert.False(t, IsValidName("news_"))
+	assert.False(t, IsValidName("news_../etc"))
+	assert.NoError(t, ValidateTemplate(NewsName("nethack"), "Patch notes for $USERNAME"))
+
+	preview, err := Preview(NewsName("nethack"), "Patch notes for $USERNAME", "alice", true)
+	require.NoError(t, err)
+	assert.Equal(t, "Patch notes for alice\r\n", preview)
+}
@@ -0,0 +1,108 @@
+// Package buildinfo describes the build of a running DungeonGate binary, as
+// set with -ldflags at link time, so services can report it through their
+// Version RPCs, /version endpoints and health responses, and session
+// services can notice when they run against a different build of the auth
+// or game service.
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+)
+
+// Path is the HTTP endpoint serving a service's build
+const Path = "/version"
+
+// unknown is what binaries built without -ldflags report for the build time
+// and commit
+const unknown = "unknown"
+
+// Info is the build of a service
+type Info struct {
+	Service   string `json:"service"`
+	Version   string `json:"version"`
+	BuildTime string `json:"build_time"`
+	GitCommit string `json:"git_commit"`
+	GoVersion string `json:"go_version"`
+}
+
+// New describes the build of the running binary
+func New(service, version, buildTime, gitCommit string) Info {
+	return Info{
+		Service:   service,
+		Version:   version,
+		BuildTime: buildTime,
+		GitCommit: gitCommit,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// Response is a Version RPC response, which each service's API generates
+// with the same fields
+type Response interface {
+	GetService() string
+	GetVersion() string
+	GetBuildTime() string
+	GetGitCommit() string
+	GetGoVersion() string
+}
+
+// FromResponse reads the build reported by a Version RPC
+func FromResponse(resp Response) Info {
+	return Info{
+		Service:   resp.GetService(),
+		Version:   resp.GetVersion(),
+		BuildTime: resp.GetBuildTime(),
+		GitCommit: resp.GetGitCommit(),
+		GoVersion: resp.GetGoVersion(),
+	}
+}
+
+// String is the version with the commit it was built from, when known
+func (i Info) String() string {
+	version := i.Version
+	if version == "" {
+		version = unknown
+	}
+	if i.GitCommit == "" || i.GitCommit == unknown {
+		return version
+	}
+	return version + " (" + i.GitCommit + ")"
+}
+
+// Details adds the build to the details of a health response, creating them
+// when nil
+func (i Info) Details(details map[string]string) map[string]string {
+	if details == nil {
+		details = make(map[string]string, 3)
+	}
+	details["version"] = i.Version
+	details["build_time"] = i.BuildTime
+	details["git_commit"] = i.GitCommit
+	return details
+}
+
+// Handler serves the build as JSON at Path
+func (i Info) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(i)
+	})
+}
+
+// Skewed reports whether two services run different builds: different
+// versions, or the same version built from different commits. Commits are
+// only compared when both are known, as development builds have none.
+func Skewed(a, b Info) bool {
+	if a.Version != b.Version {
+		return true
+	}
+	known := func(commit string) bool { return commit != "" && commit != unknown }
+	return known(a.GitCommit) && known(b.GitCommit) && a.GitCommit != b.GitCommit
+}
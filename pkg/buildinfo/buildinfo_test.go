@@ -0,0 +1,77 @@
+package buildinfo
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+)
+
+func TestNew(t *testing.T) {
+	info := New("auth-service", "1.2.0", "2026-01-02T03:04:05Z", "abc1234")
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, "1.2.0 (abc1234)", info.String())
+	assert.Equal(t, "dev", New("auth-service", "dev", "unknown", "unknown").String())
+}
+
+func TestFromResponse(t *testing.T) {
+	info := New("auth-service", "1.2.0", "2026-01-02T03:04:05Z", "abc1234")
+	resp := &authv1.VersionResponse{
+		Service:   info.Service,
+		Version:   info.Version,
+		BuildTime: info.BuildTime,
+		GitCommit: info.GitCommit,
+		GoVersion: info.GoVersion,
+	}
+	assert.Equal(t, info, FromResponse(resp))
+}
+
+func TestDetails(t *testing.T) {
+	info := New("game-service", "1.2.0", "2026-01-02T03:04:05Z", "abc1234")
+	details := info.Details(map[string]string{"service": "game-service"})
+	assert.Equal(t, map[string]string{
+		"service":    "game-service",
+		"version":    "1.2.0",
+		"build_time": "2026-01-02T03:04:05Z",
+		"git_commit": "abc1234",
+	}, details)
+	assert.Len(t, info.Details(nil), 3)
+}
+
+func TestHandler(t *testing.T) {
+	info := New("session-service", "1.2.0", "2026-01-02T03:04:05Z", "abc1234")
+
+	rec := httptest.NewRecorder()
+	info.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var served Info
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&served))
+	assert.Equal(t, info, served)
+
+	rec = httptest.NewRecorder()
+	info.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestSkewed(t *testing.T) {
+	session := New("session-service", "1.2.0", "", "abc1234")
+	for name, tc := range map[string]struct {
+		other  Info
+		skewed bool
+	}{
+		"same build":         {New("game-service", "1.2.0", "", "abc1234"), false},
+		"other version":      {New("game-service", "1.1.0", "", "abc1234"), true},
+		"other commit":       {New("game-service", "1.2.0", "", "def5678"), true},
+		"commit unknown":     {New("game-service", "1.2.0", "", "unknown"), false},
+		"version unreported": {Info{Service: "game-service"}, true},
+	} {
+		assert.Equal(t, tc.skewed, Skewed(session, tc.other), name)
+	}
+}
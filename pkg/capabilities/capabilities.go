@@ -0,0 +1,127 @@
+// Package capabilities negotiates the games API version and the optional
+// features a game service offers, so session services and game services can
+// be upgraded one at a time. A session service asks the game service with
+// GetCapabilities and sends the version they agreed on with every call; a
+// game service serves callers that never asked as APIVersion2.
+package capabilities
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+const (
+	// APIVersion2 is the games API before versioning. Callers that send no
+	// version speak it, and game services that do not implement
+	// GetCapabilities serve it.
+	APIVersion2 = 2
+	// APIVersion3 adds GetCapabilities and the version sent with each call
+	APIVersion3 = 3
+
+	// APIVersion is the newest version this build speaks
+	APIVersion = APIVersion3
+	// MinAPIVersion is the oldest version this build still serves
+	MinAPIVersion = APIVersion2
+)
+
+// MetadataKey is the gRPC metadata key carrying the caller's API version
+const MetadataKey = "x-games-api-version"
+
+// Optional features of a game service
+const (
+	// Resume reattaches players to a game that kept running after they left
+	Resume = "resume"
+	// Multiplexing lets spectators share the player's game I/O stream
+	Multiplexing = "multiplexing"
+	// Recording records games for replay
+	Recording = "recording"
+	// Screenshots stores screenshots of games taken by players and spectators
+	Screenshots = "screenshots"
+	// Bookmarks marks moments of recorded games while playing or watching
+	Bookmarks = "bookmarks"
+	// StatusLine reads each game's status off the player's screen for the
+	// watch menu and listings
+	StatusLine = "status_line"
+	// ResourceUsage accounts each game's CPU, memory, processes and IO in
+	// its cgroup, so sessions can be listed heaviest first
+	ResourceUsage = "resource_usage"
+)
+
+// Set is the features a game service offers
+type Set map[string]bool
+
+// NewSet creates a set of the named features
+func NewSet(features ...string) Set {
+	set := make(Set, len(features))
+	for _, feature := range features {
+		set[feature] = true
+	}
+	return set
+}
+
+// Has reports whether the set includes feature; a nil set includes nothing
+func (s Set) Has(feature string) bool {
+	return s[feature]
+}
+
+// List returns the features in the set, sorted
+func (s Set) List() []string {
+	features := make([]string, 0, len(s))
+	for feature, ok := range s {
+		if ok {
+			features = append(features, feature)
+		}
+	}
+	slices.Sort(features)
+	return features
+}
+
+// Legacy is what a game service that predates GetCapabilities offers: every
+// feature that existed before the games API was versioned
+func Legacy() Set {
+	return NewSet(Resume, Multiplexing, Recording)
+}
+
+// Capabilities is what a caller and a game service agreed on
+type Capabilities struct {
+	// APIVersion is the version both sides speak
+	APIVersion int
+	// Features are the optional features the game service offers
+	Features Set
+	// ServerVersion is the game service's build, empty for legacy services
+	ServerVersion string
+}
+
+// Supports reports whether the game service offers feature; nil
+// capabilities offer nothing
+func (c *Capabilities) Supports(feature string) bool {
+	return c != nil && c.Features.Has(feature)
+}
+
+// Negotiate returns the newest API version spoken by both a caller speaking
+// up to version and a game service serving minVersion to maxVersion. A
+// caller too old for the game service gets an error; one newer than it is
+// served the game service's newest version.
+func Negotiate(version, minVersion, maxVersion int) (int, error) {
+	if version < minVersion {
+		return 0, fmt.Errorf("games API version %d is no longer served; the oldest served is %d", version, minVersion)
+	}
+	return min(version, maxVersion), nil
+}
+
+type contextKey struct{}
+
+// WithAPIVersion returns ctx carrying the API version of the call
+func WithAPIVersion(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, contextKey{}, version)
+}
+
+// APIVersionFrom returns the API version of the call ctx belongs to,
+// APIVersion2 when the caller sent none
+func APIVersionFrom(ctx context.Context) int {
+	if version, ok := ctx.Value(contextKey{}).(int); ok {
+		return version
+	}
+	return APIVersion2
+}
@@ -0,0 +1,43 @@
+package capabilities
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	version, err := Negotiate(APIVersion3, MinAPIVersion, APIVersion3)
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion3, version)
+
+	// A newer caller is served the newest version the game service speaks
+	version, err = Negotiate(APIVersion3+1, MinAPIVersion, APIVersion3)
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion3, version)
+
+	// An older one keeps its version while it is still served
+	version, err = Negotiate(APIVersion2, APIVersion2, APIVersion3)
+	require.NoError(t, err)
+	assert.Equal(t, APIVersion2, version)
+	_, err = Negotiate(APIVersion2, APIVersion3, APIVersion3)
+	assert.Error(t, err)
+}
+
+func TestSet(t *testing.T) {
+	set := NewSet(Recording, Resume)
+	assert.True(t, set.Has(Resume))
+	assert.False(t, set.Has(Multiplexing))
+	assert.Equal(t, []string{Recording, Resume}, set.List())
+
+	var caps *Capabilities
+	assert.False(t, caps.Supports(Resume))
+	assert.True(t, (&Capabilities{Features: Legacy()}).Supports(Multiplexing))
+}
+
+func TestAPIVersionFrom(t *testing.T) {
+	assert.Equal(t, APIVersion2, APIVersionFrom(context.Background()))
+	assert.Equal(t, APIVersion3, APIVersionFrom(WithAPIVersion(context.Background(), APIVersion3)))
+}
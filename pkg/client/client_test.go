@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// fakeAuth answers logins for "player" and counts token refreshes
+type fakeAuth struct {
+	authv1.UnimplementedAuthServiceServer
+	mu        sync.Mutex
+	refreshes int
+	expiresIn time.Duration
+}
+
+func (f *fakeAuth) Login(ctx context.Context, req *authv1.LoginRequest) (*authv1.LoginResponse, error) {
+	if req.Password != "secret" {
+		return &authv1.LoginResponse{Success: false, Error: "Invalid username or password", ErrorCode: string(apierror.InvalidCredentials)}, nil
+	}
+	return &authv1.LoginResponse{
+		Success:              true,
+		AccessToken:          "access-0",
+		RefreshToken:         "refresh",
+		AccessTokenExpiresAt: time.Now().Add(f.expiresIn).Unix(),
+		User:                 &authv1.User{Username: req.Username},
+	}, nil
+}
+
+func (f *fakeAuth) RefreshToken(ctx context.Context, req *authv1.RefreshTokenRequest) (*authv1.RefreshTokenResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refreshes++
+	return &authv1.RefreshTokenResponse{
+		Success:              true,
+		AccessToken:          "access-refreshed",
+		AccessTokenExpiresAt: time.Now().Add(time.Hour).Unix(),
+	}, nil
+}
+
+// fakeGames fails calls and subscriptions as told to
+type fakeGames struct {
+	gamev2.UnimplementedGameServiceServer
+	mu            sync.Mutex
+	unavailable   int
+	calls         int
+	subscriptions int
+}
+
+func (f *fakeGames) ListGames(ctx context.Context, req *gamev2.ListGamesRequest) (*gamev2.ListGamesResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.calls <= f.unavailable {
+		return nil, status.Error(codes.Unavailable, "starting")
+	}
+	return &gamev2.ListGamesResponse{Games: []*gamev2.Game{{Id: "nethack"}}}, nil
+}
+
+func (f *fakeGames) SubscribeGameSessions(req *gamev2.SubscribeGameSessionsRequest, stream gamev2.GameService_SubscribeGameSessionsServer) error {
+	f.mu.Lock()
+	f.subscriptions++
+	first := f.subscriptions == 1
+	f.mu.Unlock()
+
+	stream.Send(&gamev2.GameSessionUpdate{Type: gamev2.SessionUpdateType_SESSION_UPDATE_SYNCED})
+	if first {
+		return status.Error(codes.Unavailable, "restarting")
+	}
+	<-stream.Context().Done()
+	return nil
+}
+
+func startServer(t *testing.T, register func(*grpc.Server)) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+
+	c, err := New(Config{AuthAddress: "localhost:8082"})
+	require.NoError(t, err)
+	defer c.Close()
+	assert.NotNil(t, c.Auth)
+	assert.Nil(t, c.Games)
+
+	_, err = c.ListGames(context.Background())
+	assert.ErrorContains(t, err, "no game service address configured")
+}
+
+func TestClient_LoginAndRefresh(t *testing.T) {
+	auth := &fakeAuth{expiresIn: 30 * time.Second}
+	addr := startServer(t, func(s *grpc.Server) { authv1.RegisterAuthServiceServer(s, auth) })
+	c, err := New(Config{AuthAddress: addr})
+	require.NoError(t, err)
+	defer c.Close()
+	ctx := context.Background()
+
+	_, err = c.AccessToken(ctx)
+	assert.ErrorIs(t, err, ErrNotLoggedIn)
+
+	_, err = c.Login(ctx, "player", "wrong")
+	assert.Equal(t, apierror.InvalidCredentials, apierror.CodeOf(err))
+
+	user, err := c.Login(ctx, "player", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "player", user.Username)
+
+	// The token expires within the refresh margin, so it is refreshed once
+	token, err := c.AccessToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "access-refreshed", token)
+	token, err = c.AccessToken(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "access-refreshed", token)
+	assert.Equal(t, 1, auth.refreshes)
+}
+
+func TestClient_RetriesUnavailable(t *testing.T) {
+	games := &fakeGames{unavailable: 2}
+	addr := startServer(t, func(s *grpc.Server) { gamev2.RegisterGameServiceServer(s, games) })
+	c, err := New(Config{GameAddress: addr})
+	require.NoError(t, err)
+	defer c.Close()
+
+	list, err := c.ListGames(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Equal(t, 3, games.calls)
+
+	games.calls, games.unavailable = 0, DefaultMaxAttempts
+	_, err = c.ListGames(context.Background())
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestClient_WatchGameSessionsResubscribes(t *testing.T) {
+	games := &fakeGames{}
+	addr := startServer(t, func(s *grpc.Server) { gamev2.RegisterGameServiceServer(s, games) })
+	c, err := New(Config{GameAddress: addr})
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	syncs := 0
+	err = c.WatchGameSessions(ctx, true, func(update *gamev2.GameSessionUpdate) {
+		if update.Type == gamev2.SessionUpdateType_SESSION_UPDATE_SYNCED {
+			if syncs++; syncs == 2 {
+				cancel()
+			}
+		}
+	})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, syncs)
+}
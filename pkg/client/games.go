@@ -0,0 +1,109 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	gamev2 "github.com/dungeongate/pkg/api/games/v2"
+)
+
+const (
+	// watchBackoff is how long WatchGameSessions first waits to resubscribe
+	// after its stream fails, doubling up to watchMaxBackoff
+	watchBackoff    = 500 * time.Millisecond
+	watchMaxBackoff = 30 * time.Second
+)
+
+// ListGames lists the enabled games
+func (c *Client) ListGames(ctx context.Context) ([]*gamev2.Game, error) {
+	if err := requireService(c.Games != nil, "game"); err != nil {
+		return nil, err
+	}
+	resp, err := c.Games.ListGames(ctx, &gamev2.ListGamesRequest{EnabledOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list games: %w", err)
+	}
+	return resp.Games, nil
+}
+
+// ListActiveSessions lists the game sessions being played
+func (c *Client) ListActiveSessions(ctx context.Context) ([]*gamev2.GameSession, error) {
+	if err := requireService(c.Games != nil, "game"); err != nil {
+		return nil, err
+	}
+	resp, err := c.Games.ListGameSessions(ctx, &gamev2.ListGameSessionsRequest{Status: gamev2.SessionStatus_SESSION_STATUS_ACTIVE})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list game sessions: %w", err)
+	}
+	return resp.Sessions, nil
+}
+
+// WatchGameSessions calls onUpdate for each change to the active game
+// sessions, only those open to spectators when spectatableOnly is set. Each
+// subscription starts with ADDED updates for the current sessions followed
+// by SYNCED. When the stream fails it resubscribes with backoff, so after
+// each SYNCED the sessions last seen before it that were not added again
+// have ended. It blocks until ctx is cancelled, or the game service rejects
+// the subscription for a reason other than being unavailable.
+func (c *Client) WatchGameSessions(ctx context.Context, spectatableOnly bool, onUpdate func(*gamev2.GameSessionUpdate)) error {
+	if err := requireService(c.Games != nil, "game"); err != nil {
+		return err
+	}
+
+	backoff := watchBackoff
+	for {
+		synced, err := c.watchGameSessions(ctx, spectatableOnly, onUpdate)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryableStream(err) {
+			return fmt.Errorf("game session subscription failed: %w", err)
+		}
+		if synced {
+			backoff = watchBackoff
+		}
+
+		c.logger.Warn("Game session subscription failed, resubscribing", "error", err, "wait", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		backoff = min(backoff*2, watchMaxBackoff)
+	}
+}
+
+// watchGameSessions runs one subscription until it fails, reporting
+// whether it got as far as SYNCED
+func (c *Client) watchGameSessions(ctx context.Context, spectatableOnly bool, onUpdate func(*gamev2.GameSessionUpdate)) (bool, error) {
+	stream, err := c.Games.SubscribeGameSessions(ctx, &gamev2.SubscribeGameSessionsRequest{SpectatableOnly: spectatableOnly})
+	if err != nil {
+		return false, err
+	}
+
+	synced := false
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			return synced, err
+		}
+		if update.Type == gamev2.SessionUpdateType_SESSION_UPDATE_SYNCED {
+			synced = true
+		}
+		onUpdate(update)
+	}
+}
+
+// retryableStream reports whether a stream that failed with err is worth
+// opening again: the service went away or ended it, e.g. when restarting
+func retryableStream(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.Aborted, codes.Internal, codes.Unknown, codes.DeadlineExceeded:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	sessionv1 "github.com/dungeongate/pkg/api/session/v1"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// ServerStatistics returns the server statistics, including the cluster_*
+// sums over the session service replicas. The logged in user must be an
+// admin.
+func (c *Client) ServerStatistics(ctx context.Context) (map[string]string, error) {
+	if err := requireService(c.Auth != nil, "auth"); err != nil {
+		return nil, err
+	}
+	token, err := c.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Auth.GetServerStatistics(ctx, &authv1.ServerStatsRequest{AdminToken: token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server statistics: %w", err)
+	}
+	if !resp.Success {
+		return nil, responseError("", resp.Error, apierror.PermissionDenied)
+	}
+	return resp.Stats, nil
+}
+
+// SessionInstances lists the session service replicas that reported
+// recently, with their totals. The logged in user must be an admin.
+func (c *Client) SessionInstances(ctx context.Context) ([]*authv1.SessionInstance, *authv1.SessionInstance, error) {
+	if err := requireService(c.Auth != nil, "auth"); err != nil {
+		return nil, nil, err
+	}
+	token, err := c.AccessToken(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.Auth.ListSessionInstances(ctx, &authv1.ListSessionInstancesRequest{AdminToken: token})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list session instances: %w", err)
+	}
+	if !resp.Success {
+		return nil, nil, responseError("", resp.Error, apierror.PermissionDenied)
+	}
+	return resp.Instances, resp.Totals, nil
+}
+
+// ConnectedSessions lists the game sessions connected to the session
+// service with their echo latency. The logged in user must be an admin.
+func (c *Client) ConnectedSessions(ctx context.Context) (*sessionv1.ListSessionsResponse, error) {
+	if err := requireService(c.Sessions != nil, "session"); err != nil {
+		return nil, err
+	}
+	token, err := c.AccessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Sessions.ListSessions(ctx, &sessionv1.ListSessionsRequest{AdminToken: token})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list connected sessions: %w", err)
+	}
+	if !resp.Success {
+		return nil, responseError("", resp.Error, apierror.PermissionDenied)
+	}
+	return resp, nil
+}
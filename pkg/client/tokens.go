@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// tokenRefreshMargin is how long before it expires an access token is
+// refreshed, so calls made with it don't race its expiry
+const tokenRefreshMargin = time.Minute
+
+// ErrNotLoggedIn is returned by calls that need an access token before
+// Login or SetTokens
+var ErrNotLoggedIn = errors.New("not logged in")
+
+// tokens are the tokens of the logged in user
+type tokens struct {
+	access           string
+	refresh          string
+	accessExpiresAt  time.Time
+	refreshExpiresAt time.Time
+}
+
+// Login logs in as username and keeps the user's tokens, which AccessToken
+// refreshes from then on. A rejected login is returned as an apierror with
+// the auth service's error code, e.g. apierror.InvalidCredentials.
+func (c *Client) Login(ctx context.Context, username, password string) (*authv1.User, error) {
+	if err := requireService(c.Auth != nil, "auth"); err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Auth.Login(ctx, &authv1.LoginRequest{Username: username, Password: password})
+	if err != nil {
+		return nil, fmt.Errorf("failed to login: %w", err)
+	}
+	if !resp.Success {
+		return nil, responseError(resp.ErrorCode, resp.Error, apierror.AuthenticationFailed)
+	}
+
+	c.mu.Lock()
+	c.tokens = &tokens{
+		access:           resp.AccessToken,
+		refresh:          resp.RefreshToken,
+		accessExpiresAt:  unixTime(resp.AccessTokenExpiresAt),
+		refreshExpiresAt: unixTime(resp.RefreshTokenExpiresAt),
+	}
+	c.mu.Unlock()
+	return resp.User, nil
+}
+
+// SetTokens uses tokens obtained elsewhere, e.g. by a web frontend's own
+// login. expiresAt is when the access token expires; zero if unknown, in
+// which case it is used until a call rejects it.
+func (c *Client) SetTokens(accessToken, refreshToken string, expiresAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.tokens = &tokens{access: accessToken, refresh: refreshToken, accessExpiresAt: expiresAt}
+}
+
+// AccessToken returns the logged in user's access token, for the requests
+// that carry one. It is refreshed first when it is about to expire.
+func (c *Client) AccessToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tokens == nil {
+		return "", ErrNotLoggedIn
+	}
+	if c.tokens.accessExpiresAt.IsZero() || c.now().Add(tokenRefreshMargin).Before(c.tokens.accessExpiresAt) {
+		return c.tokens.access, nil
+	}
+	if c.tokens.refresh == "" {
+		return "", fmt.Errorf("access token expired: %w", ErrNotLoggedIn)
+	}
+	if !c.tokens.refreshExpiresAt.IsZero() && c.now().After(c.tokens.refreshExpiresAt) {
+		c.tokens = nil
+		return "", fmt.Errorf("refresh token expired: %w", ErrNotLoggedIn)
+	}
+	if err := requireService(c.Auth != nil, "auth"); err != nil {
+		return "", err
+	}
+
+	resp, err := c.Auth.RefreshToken(ctx, &authv1.RefreshTokenRequest{RefreshToken: c.tokens.refresh})
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh access token: %w", err)
+	}
+	if !resp.Success {
+		c.tokens = nil
+		return "", fmt.Errorf("failed to refresh access token: %s: %w", resp.Error, ErrNotLoggedIn)
+	}
+
+	c.logger.Debug("Refreshed access token")
+	c.tokens.access = resp.AccessToken
+	c.tokens.accessExpiresAt = unixTime(resp.AccessTokenExpiresAt)
+	if resp.RefreshToken != "" {
+		c.tokens.refresh = resp.RefreshToken
+		c.tokens.refreshExpiresAt = unixTime(resp.RefreshTokenExpiresAt)
+	}
+	return c.tokens.access, nil
+}
+
+// Logout revokes the logged in user's tokens and forgets them
+func (c *Client) Logout(ctx context.Context) error {
+	c.mu.Lock()
+	t := c.tokens
+	c.tokens = nil
+	c.mu.Unlock()
+
+	if t == nil {
+		return nil
+	}
+	if err := requireService(c.Auth != nil, "auth"); err != nil {
+		return err
+	}
+	resp, err := c.Auth.Logout(ctx, &authv1.LogoutRequest{AccessToken: t.access, RefreshToken: t.refresh})
+	if err != nil {
+		return fmt.Errorf("failed to logout: %w", err)
+	}
+	if !resp.Success {
+		return responseError("", resp.Error, apierror.Internal)
+	}
+	return nil
+}
+
+// responseError turns a response's error into an apierror, with fallback
+// as its code when the response carries none
+func responseError(code, message string, fallback apierror.Code) error {
+	if code == "" {
+		code = string(fallback)
+	}
+	return apierror.New(apierror.Code(code), message)
+}
+
+// unixTime converts Unix seconds, zero meaning unknown
+func unixTime(seconds int64) time.Time {
+	if seconds == 0 {
+		return time.Time{}
+	}
+	return time.Unix(seconds, 0)
+}
@@ -4,8 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-
-	"gopkg.in/yaml.v3"
 )
 
 // CommonConfig represents shared configuration across all services
@@ -17,6 +15,11 @@ type CommonConfig struct {
 	Security    *CommonSecurityConfig `yaml:"security"`
 	Server      *CommonServerConfig   `yaml:"server"`
 	Environment *EnvironmentConfig    `yaml:"environment"`
+	// FeatureFlags are used by the services that configure none of their own
+	FeatureFlags *FeatureFlagsConfig `yaml:"feature_flags"`
+
+	// EnvExpansions lists the environment variables expanded while loading
+	EnvExpansions []EnvExpansion `yaml:"-"`
 }
 
 // CommonSecurityConfig represents shared security configuration
@@ -68,12 +71,16 @@ func LoadCommonConfig(configPath string) (*CommonConfig, error) {
 		return nil, fmt.Errorf("failed to read common config file: %w", err)
 	}
 
-	expanded := os.ExpandEnv(string(data))
+	expanded, expansions, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand common config: %w", err)
+	}
 
 	var config CommonConfig
-	if err := yaml.Unmarshal([]byte(expanded), &config); err != nil {
+	if err := decodeStrict([]byte(expanded), configPath, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse common config: %w", err)
 	}
+	config.EnvExpansions = expansions
 
 	return &config, nil
 }
@@ -104,6 +111,11 @@ func MergeWithCommonUser(serviceConfig *UserServiceConfig, commonConfig *CommonC
 		serviceConfig.Health = commonConfig.HealthCheck
 	}
 
+	// Merge feature flags
+	if serviceConfig.FeatureFlags == nil {
+		serviceConfig.FeatureFlags = commonConfig.FeatureFlags
+	}
+
 	// Merge server configuration
 	if serviceConfig.Server != nil && commonConfig.Server != nil {
 		mergeServerConfig(serviceConfig.Server, commonConfig.Server)
@@ -136,6 +148,11 @@ func MergeWithCommon(serviceConfig *GameServiceConfig, commonConfig *CommonConfi
 		serviceConfig.Health = commonConfig.HealthCheck
 	}
 
+	// Merge feature flags
+	if serviceConfig.FeatureFlags == nil {
+		serviceConfig.FeatureFlags = commonConfig.FeatureFlags
+	}
+
 	// Merge server configuration
 	if serviceConfig.Server != nil && commonConfig.Server != nil {
 		mergeServerConfig(serviceConfig.Server, commonConfig.Server)
@@ -153,9 +170,17 @@ func mergeLoggingConfigWithCommon(service *LoggingConfig, common *LoggingConfig)
 	if service.Output == "" {
 		service.Output = common.Output
 	}
-	// Merge file config if service doesn't have one but common does
-	if service.File == nil && common.File != nil {
-		service.File = common.File
+	if service.LevelRevertAfter == "" {
+		service.LevelRevertAfter = common.LevelRevertAfter
+	}
+	// Merge file config field by field, so a service can set just its filename
+	if common.File != nil {
+		if service.File == nil {
+			file := *common.File
+			service.File = &file
+		} else {
+			mergeFileConfig(service.File, common.File)
+		}
 	}
 	// Merge journald config if service doesn't have one but common does
 	if service.Journald == nil && common.Journald != nil {
@@ -163,6 +188,31 @@ func mergeLoggingConfigWithCommon(service *LoggingConfig, common *LoggingConfig)
 	}
 }
 
+// mergeFileConfig fills the file logging settings a service leaves unset
+func mergeFileConfig(service *FileConfig, common *FileConfig) {
+	if service.Directory == "" {
+		service.Directory = common.Directory
+	}
+	if service.Filename == "" {
+		service.Filename = common.Filename
+	}
+	if service.MaxSize == "" {
+		service.MaxSize = common.MaxSize
+	}
+	if service.MaxFiles == 0 {
+		service.MaxFiles = common.MaxFiles
+	}
+	if service.MaxAge == "" {
+		service.MaxAge = common.MaxAge
+	}
+	if !service.Compress {
+		service.Compress = common.Compress
+	}
+	if service.RotateEvery == "" {
+		service.RotateEvery = common.RotateEvery
+	}
+}
+
 // mergeServerConfig merges server configuration
 func mergeServerConfig(service *ServerConfig, common *CommonServerConfig) {
 	if service.Host == "" {
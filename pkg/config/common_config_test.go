@@ -0,0 +1,34 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeLoggingConfig_FileFieldByField(t *testing.T) {
+	common := &LoggingConfig{
+		Level:  "info",
+		Output: "file",
+		File:   &FileConfig{Directory: "/var/log/dungeongate", Filename: "service.log", MaxSize: "100MB", MaxFiles: 10, Compress: true, RotateEvery: "24h"},
+	}
+	service := &LoggingConfig{File: &FileConfig{Filename: "game-service.log"}}
+
+	mergeLoggingConfigWithCommon(service, common)
+
+	assert.Equal(t, "file", service.Output)
+	assert.Equal(t, &FileConfig{
+		Directory:   "/var/log/dungeongate",
+		Filename:    "game-service.log",
+		MaxSize:     "100MB",
+		MaxFiles:    10,
+		Compress:    true,
+		RotateEvery: "24h",
+	}, service.File)
+
+	// A service without file settings gets a copy of the common ones
+	other := &LoggingConfig{}
+	mergeLoggingConfigWithCommon(other, common)
+	other.File.Filename = "auth-service.log"
+	assert.Equal(t, "service.log", common.File.Filename)
+}
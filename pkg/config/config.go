@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -15,6 +17,163 @@ type ServerConfig struct {
 	Host           string `yaml:"host"`
 	Timeout        string `yaml:"timeout"`
 	MaxConnections int    `yaml:"max_connections"`
+	// AuthToken is the bearer token gRPC callers must send; empty accepts
+	// every caller. Health checks never need it.
+	AuthToken string `yaml:"auth_token"`
+	// ShutdownTimeout is how long shutdown waits for game streams to end
+	// before detaching the players still connected (default "30s")
+	ShutdownTimeout string `yaml:"shutdown_timeout"`
+	// RateLimits limits requests to the service's HTTP and gRPC APIs
+	RateLimits *APIRateLimitConfig `yaml:"rate_limits"`
+	// Jobs overrides the schedules of the service's background jobs, by job name
+	Jobs map[string]*JobConfig `yaml:"jobs"`
+	// LeaderElection picks the replica that runs jobs which must run once
+	LeaderElection *LeaderElectionConfig `yaml:"leader_election"`
+	// Listen replaces the service's TCP listeners with unix sockets or
+	// sockets passed by systemd
+	Listen *ListenConfig `yaml:"listen"`
+	// Timeouts tunes how long the service waits, e.g. for players on slow links
+	Timeouts *ServiceTimeoutsConfig `yaml:"timeouts"`
+}
+
+// GetTimeouts returns the server's timeouts; nil uses every default
+func (c *ServerConfig) GetTimeouts() *ServiceTimeoutsConfig {
+	if c == nil {
+		return nil
+	}
+	return c.Timeouts
+}
+
+// Defaults of the settings in ServiceTimeoutsConfig
+const (
+	DefaultMenuErrorPause   = 2 * time.Second
+	DefaultShutdownDrain    = 30 * time.Second
+	DefaultHTTPReadTimeout  = 30 * time.Second
+	DefaultHTTPWriteTimeout = 30 * time.Second
+	DefaultGRPCDialTimeout  = 20 * time.Second
+)
+
+// ServiceTimeoutsConfig sets how long a service waits. Durations use Go
+// syntax, e.g. "500ms" or "1m"; empty settings keep their default.
+type ServiceTimeoutsConfig struct {
+	// MenuErrorPause is how long SSH menus show an error before moving on;
+	// brief notices show for half as long. Session service only (default
+	// "2s", "0s" moves on at once).
+	MenuErrorPause string `yaml:"menu_error_pause"`
+	// ShutdownDrain is how long shutdown waits for connections and game
+	// streams to finish before stopping anyway (default "30s")
+	ShutdownDrain string `yaml:"shutdown_drain"`
+	// HTTPRead and HTTPWrite limit reading a request and writing a response
+	// on the service's HTTP API (default "30s" each)
+	HTTPRead  string `yaml:"http_read"`
+	HTTPWrite string `yaml:"http_write"`
+	// GRPCDial limits each attempt to connect to another service; failed
+	// attempts are retried with backoff (default "20s")
+	GRPCDial string `yaml:"grpc_dial"`
+}
+
+// GetMenuErrorPause returns menu_error_pause, or DefaultMenuErrorPause
+func (c *ServiceTimeoutsConfig) GetMenuErrorPause() time.Duration {
+	if c == nil {
+		return DefaultMenuErrorPause
+	}
+	return ParseDuration(c.MenuErrorPause, DefaultMenuErrorPause)
+}
+
+// GetShutdownDrain returns shutdown_drain, or DefaultShutdownDrain
+func (c *ServiceTimeoutsConfig) GetShutdownDrain() time.Duration {
+	if c == nil {
+		return DefaultShutdownDrain
+	}
+	return ParseDuration(c.ShutdownDrain, DefaultShutdownDrain)
+}
+
+// GetHTTPRead returns http_read, or DefaultHTTPReadTimeout
+func (c *ServiceTimeoutsConfig) GetHTTPRead() time.Duration {
+	if c == nil {
+		return DefaultHTTPReadTimeout
+	}
+	return ParseDuration(c.HTTPRead, DefaultHTTPReadTimeout)
+}
+
+// GetHTTPWrite returns http_write, or DefaultHTTPWriteTimeout
+func (c *ServiceTimeoutsConfig) GetHTTPWrite() time.Duration {
+	if c == nil {
+		return DefaultHTTPWriteTimeout
+	}
+	return ParseDuration(c.HTTPWrite, DefaultHTTPWriteTimeout)
+}
+
+// GetGRPCDial returns grpc_dial, or DefaultGRPCDialTimeout
+func (c *ServiceTimeoutsConfig) GetGRPCDial() time.Duration {
+	if c == nil {
+		return DefaultGRPCDialTimeout
+	}
+	return ParseDuration(c.GRPCDial, DefaultGRPCDialTimeout)
+}
+
+// ListenConfig sets where a service's endpoints listen instead of their TCP
+// ports. Endpoints are "http", "grpc" and, for the session service, "ssh".
+type ListenConfig struct {
+	// UnixSockets maps endpoints to unix socket paths, e.g. for a reverse
+	// proxy on the same host
+	UnixSockets map[string]string `yaml:"unix_sockets"`
+	// SocketMode sets the permissions of the unix sockets (default "0660")
+	SocketMode string `yaml:"socket_mode"`
+	// SystemdActivation takes the sockets systemd passes (LISTEN_FDS). Each
+	// is matched to an endpoint by its FileDescriptorName, e.g. "http".
+	SystemdActivation bool `yaml:"systemd_activation"`
+}
+
+// JobConfig overrides how a background job is scheduled
+type JobConfig struct {
+	// Schedule is a cron expression ("30 4 * * *"), a descriptor such as
+	// "@daily", "@every 15m" or a duration; empty keeps the job's default
+	Schedule string `yaml:"schedule"`
+	// Jitter delays each run by a random duration up to this long
+	Jitter string `yaml:"jitter"`
+	// Disabled stops the job from running
+	Disabled bool `yaml:"disabled"`
+}
+
+// LeaderElectionConfig elects one replica of a service to run its singleton
+// background jobs. Without it every replica runs them.
+type LeaderElectionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Backend holds the lease: "database" (default), a row in the service's
+	// database, or "kubernetes", a coordination.k8s.io Lease
+	Backend string `yaml:"backend"`
+	// Lease names the lease replicas compete for (default "<service>-jobs")
+	Lease string `yaml:"lease"`
+	// Namespace holds the Kubernetes Lease (default the pod's namespace)
+	Namespace string `yaml:"namespace"`
+	// LeaseDuration is how long a lease lasts unrenewed, and so how soon
+	// another replica takes over from a leader that died (default "15s")
+	LeaseDuration string `yaml:"lease_duration"`
+}
+
+// APIRateLimitConfig limits API requests with a token bucket per caller. A
+// request is checked against the rules whose match is the longest prefix of
+// its HTTP path or gRPC method; rules without a match apply to everything
+// else. Health checks are never limited.
+type APIRateLimitConfig struct {
+	Enabled bool             `yaml:"enabled"`
+	Rules   []*RateLimitRule `yaml:"rules"`
+}
+
+// RateLimitRule allows each caller a number of requests per period
+type RateLimitRule struct {
+	// Match is an HTTP path prefix, e.g. "/api/v1/games", or a gRPC method
+	// prefix, e.g. "/dungeongate.auth.v1.AuthService/Login"
+	Match string `yaml:"match"`
+	// By is the caller the limit applies to: "ip" (default), "user" or
+	// "api_key". Callers without a user or API key are limited by address.
+	By       string `yaml:"by"`
+	Requests int    `yaml:"requests"`
+	// Per is the period the requests are spread over (default "1m")
+	Per string `yaml:"per"`
+	// Burst is how many requests may arrive at once (default requests)
+	Burst int `yaml:"burst"`
 }
 
 // LegacyDatabaseConfig represents basic database configuration (legacy compatibility)
@@ -37,6 +196,55 @@ type EncryptionConfig struct {
 	Enabled             bool   `yaml:"enabled"`
 	Algorithm           string `yaml:"algorithm"`
 	KeyRotationInterval string `yaml:"key_rotation_interval"`
+	// Keys are the keys data is encrypted with. Keys that are no longer
+	// primary still decrypt the data encrypted with them until it is
+	// encrypted again.
+	Keys []EncryptionKey `yaml:"keys"`
+	// PrimaryKey is the ID of the key new data is encrypted with (default
+	// the last key)
+	PrimaryKey string `yaml:"primary_key"`
+	// IndexKey is the base64 key of the blind indexes encrypted data is
+	// looked up by. Changing it breaks lookups until the data is encrypted
+	// again.
+	IndexKey string `yaml:"index_key"`
+}
+
+// RetentionConfig sets how long personal data is kept before the retention
+// job purges it. Each service purges the categories it stores: the game
+// service recordings and session_transcripts, the auth service
+// inactive_accounts and audit_logs.
+type RetentionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Interval is how often the retention job runs (default "24h")
+	Interval string `yaml:"interval"`
+	// DryRun reports what would be purged without purging it
+	DryRun bool `yaml:"dry_run"`
+	// Policies override how long a category is kept, as a Go duration or
+	// whole days ("30d") or years ("2y"); "forever" keeps it. Defaults:
+	// recordings 30d, session_transcripts 7d, inactive_accounts 2y and
+	// audit_logs 1y.
+	Policies map[string]string `yaml:"policies"`
+	// LegalHolds exempt users' and sessions' data from purging
+	LegalHolds *LegalHoldConfig `yaml:"legal_holds"`
+	// ReportDirectory, if set, receives a JSON report of every run
+	ReportDirectory string `yaml:"report_directory"`
+}
+
+// LegalHoldConfig names the users and sessions whose data is kept whatever
+// its age
+type LegalHoldConfig struct {
+	// Users are usernames
+	Users []string `yaml:"users"`
+	// Sessions are game session IDs
+	Sessions []string `yaml:"sessions"`
+}
+
+// EncryptionKey is a key data is encrypted with
+type EncryptionKey struct {
+	// ID names the key in the data encrypted with it
+	ID string `yaml:"id"`
+	// Key is 32 random bytes in base64
+	Key string `yaml:"key"`
 }
 
 // LoggingConfig represents logging configuration
@@ -46,6 +254,9 @@ type LoggingConfig struct {
 	Output   string          `yaml:"output"`
 	File     *FileConfig     `yaml:"file,omitempty"`
 	Journald *JournaldConfig `yaml:"journald,omitempty"`
+	// LevelRevertAfter limits how long a log level changed at runtime lasts,
+	// such as "30m"
+	LevelRevertAfter string `yaml:"level_revert_after,omitempty"`
 }
 
 // FileConfig represents file logging configuration
@@ -56,6 +267,8 @@ type FileConfig struct {
 	MaxFiles  int    `yaml:"max_files"`
 	MaxAge    string `yaml:"max_age"`
 	Compress  bool   `yaml:"compress"`
+	// RotateEvery also rotates the file on a schedule, such as "24h"
+	RotateEvery string `yaml:"rotate_every,omitempty"`
 }
 
 // JournaldConfig represents journald logging configuration
@@ -78,6 +291,33 @@ type SessionManagementConfig struct {
 	Monitoring *MonitoringConfig `yaml:"monitoring"`
 	Spectating *SpectatingConfig `yaml:"spectating"`
 	Heartbeat  *HeartbeatConfig  `yaml:"heartbeat"`
+	Latency    *LatencyConfig    `yaml:"latency"`
+	Watchdog   *WatchdogConfig   `yaml:"watchdog"`
+	PlayStates *PlayStatesConfig `yaml:"play_states"`
+}
+
+// PlayStatesConfig bounds how long a player may stay in each state of an SSH
+// play session, such as "terms" or "password_change"
+type PlayStatesConfig struct {
+	// Timeouts by state name; states not listed never time out. A player
+	// still in a state when its timeout passes is disconnected.
+	Timeouts map[string]string `yaml:"timeouts"`
+}
+
+// WatchdogConfig sets how often the session service samples its connections,
+// goroutines and open files to catch goroutines outliving their connections
+type WatchdogConfig struct {
+	// Interval between samples (default "1m"); "-1s" or any negative
+	// duration disables sampling
+	Interval string `yaml:"interval"`
+}
+
+// LatencyConfig sets how quickly players should see games answer their input
+type LatencyConfig struct {
+	// EchoSLO is the echo latency (input received to first output sent)
+	// game sessions should stay under at the 95th percentile; sessions over
+	// it are flagged for admins (default "250ms")
+	EchoSLO string `yaml:"echo_slo"`
 }
 
 // TerminalConfig represents terminal configuration
@@ -108,6 +348,7 @@ type SpectatingConfig struct {
 	Enabled                 bool   `yaml:"enabled"`
 	MaxSpectatorsPerSession int    `yaml:"max_spectators_per_session"`
 	SpectatorTimeout        string `yaml:"spectator_timeout"`
+	PromptAtStart           bool   `yaml:"prompt_at_start"` // Ask players whether to allow spectators for each game
 }
 
 // HeartbeatConfig represents general heartbeat configuration
@@ -134,7 +375,10 @@ func Load(configPath string) (map[string]interface{}, error) {
 	}
 
 	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	expanded, _, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config: %w", err)
+	}
 
 	var config map[string]interface{}
 	if err := yaml.Unmarshal([]byte(expanded), &config); err != nil {
@@ -165,8 +409,15 @@ func GetDefaultTerminalSize() (width, height int) {
 	return 80, 24
 }
 
-// ParseDuration parses duration string with fallback
+// ParseDuration parses duration string with fallback. In addition to the
+// time.ParseDuration units it accepts whole days, e.g. "7d".
 func ParseDuration(durationStr string, fallback time.Duration) time.Duration {
+	if days, ok := strings.CutSuffix(durationStr, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return time.Duration(n) * 24 * time.Hour
+		}
+		return fallback
+	}
 	if duration, err := time.ParseDuration(durationStr); err == nil {
 		return duration
 	}
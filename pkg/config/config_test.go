@@ -0,0 +1,73 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceTimeoutsConfig_Defaults(t *testing.T) {
+	var server *ServerConfig
+	timeouts := server.GetTimeouts()
+	assert.Equal(t, DefaultMenuErrorPause, timeouts.GetMenuErrorPause())
+	assert.Equal(t, DefaultShutdownDrain, timeouts.GetShutdownDrain())
+	assert.Equal(t, DefaultHTTPReadTimeout, timeouts.GetHTTPRead())
+	assert.Equal(t, DefaultHTTPWriteTimeout, timeouts.GetHTTPWrite())
+	assert.Equal(t, DefaultGRPCDialTimeout, timeouts.GetGRPCDial())
+
+	server = &ServerConfig{Timeouts: &ServiceTimeoutsConfig{
+		MenuErrorPause: "0s",
+		ShutdownDrain:  "2m",
+		HTTPRead:       "not a duration",
+		GRPCDial:       "5s",
+	}}
+	timeouts = server.GetTimeouts()
+	assert.Equal(t, time.Duration(0), timeouts.GetMenuErrorPause())
+	assert.Equal(t, 2*time.Minute, timeouts.GetShutdownDrain())
+	assert.Equal(t, DefaultHTTPReadTimeout, timeouts.GetHTTPRead())
+	assert.Equal(t, DefaultHTTPWriteTimeout, timeouts.GetHTTPWrite())
+	assert.Equal(t, 5*time.Second, timeouts.GetGRPCDial())
+}
+
+func TestValidateRealms(t *testing.T) {
+	realms := []*RealmConfig{
+		{Name: "retro", Ports: []int{2223}, Hostnames: []string{"retro.example.org"}},
+		{Name: "speed-run", StoragePrefix: "sr_"},
+	}
+	assert.NoError(t, ValidateRealms(realms, 2222))
+	assert.Equal(t, "retro_", realms[0].GetStoragePrefix())
+	assert.Equal(t, "sr_", realms[1].GetStoragePrefix())
+	assert.Equal(t, "speed_run_", (&RealmConfig{Name: "speed-run"}).GetStoragePrefix())
+
+	for name, realms := range map[string][]*RealmConfig{
+		"invalid name":       {{Name: "Retro"}},
+		"duplicate name":     {{Name: "retro"}, {Name: "retro", StoragePrefix: "r2_"}},
+		"default realm port": {{Name: "retro", Ports: []int{2222}}},
+		"shared port":        {{Name: "retro", Ports: []int{2223}}, {Name: "modern", Ports: []int{2223}}},
+		"shared host name":   {{Name: "retro", Hostnames: []string{"play.example.org"}}, {Name: "modern", Hostnames: []string{"Play.example.org"}}},
+		"invalid prefix":     {{Name: "retro", StoragePrefix: "re-"}},
+		"overlapping prefix": {{Name: "retro"}, {Name: "retro2", StoragePrefix: "retro_x"}},
+		"out of range port":  {{Name: "retro", Ports: []int{70000}}},
+	} {
+		assert.Error(t, ValidateRealms(realms, 2222), name)
+	}
+}
+
+func TestSSHConfig_ValidateBanners(t *testing.T) {
+	cfg := &SSHConfig{
+		Port:           2222,
+		Host:           "localhost",
+		MaxSessions:    10,
+		SessionTimeout: "4h",
+		IdleTimeout:    "30m",
+		Policy:         &SSHPolicyConfig{PreAuthBanner: "Authorized use only\r\n"},
+	}
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MOTD = &SSHMOTDConfig{Enabled: true}
+	assert.Error(t, cfg.Validate())
+
+	cfg.Policy.PreAuthBanner = ""
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,127 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// DungeonGateConfig is the single configuration of the all-in-one dungeongate
+// binary. Each service has a section in the format of its standalone config
+// file; the shared database, logging and feature flag sections fill in any
+// service section that leaves them out.
+type DungeonGateConfig struct {
+	Version  string          `yaml:"version"`
+	Database *DatabaseConfig `yaml:"database"`
+	Logging  *LoggingConfig  `yaml:"logging"`
+	Metrics  *MetricsConfig  `yaml:"metrics"`
+	// FeatureFlags are evaluated by every service that has none of its own
+	FeatureFlags *FeatureFlagsConfig `yaml:"feature_flags"`
+
+	Auth    *UserServiceConfig    `yaml:"auth"`
+	Game    *GameServiceConfig    `yaml:"game"`
+	Session *SessionServiceConfig `yaml:"session"`
+
+	// EnvExpansions lists the environment variables expanded while loading
+	EnvExpansions []EnvExpansion `yaml:"-"`
+}
+
+// LoadDungeonGateConfig loads the all-in-one configuration and applies each
+// service's defaults to its section
+func LoadDungeonGateConfig(configPath string) (*DungeonGateConfig, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	expanded, expansions, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config: %w", err)
+	}
+
+	var config DungeonGateConfig
+	if err := decodeStrict([]byte(expanded), configPath, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	config.EnvExpansions = expansions
+
+	if err := config.fillDefaults(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// DefaultDungeonGateConfig returns the all-in-one configuration used when
+// there is no configuration file, with every section at its defaults. Like
+// configs/dungeongate.yaml, SSH listens on an unprivileged port with a host
+// key of its own under ./data.
+func DefaultDungeonGateConfig() (*DungeonGateConfig, error) {
+	config := &DungeonGateConfig{
+		Session: &SessionServiceConfig{
+			SSH: &SSHConfig{Port: 2222, HostKeyPath: "./data/ssh_keys/host_key"},
+		},
+	}
+	if err := config.fillDefaults(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// fillDefaults fills in the sections config leaves out and applies each
+// service's defaults to its section
+func (config *DungeonGateConfig) fillDefaults() error {
+	if config.Database == nil {
+		config.Database = NewDatabaseConfig()
+	}
+	if config.Logging == nil {
+		config.Logging = &LoggingConfig{Level: "info", Format: "text", Output: "stdout"}
+	}
+	if config.Auth == nil {
+		config.Auth = &UserServiceConfig{}
+	}
+	if config.Game == nil {
+		config.Game = &GameServiceConfig{}
+	}
+	if config.Session == nil {
+		config.Session = &SessionServiceConfig{}
+	}
+
+	// Shared sections
+	if config.Auth.Database == nil {
+		config.Auth.Database = config.Database
+	}
+	if config.Game.Database == nil {
+		config.Game.Database = config.Database
+	}
+	if config.Session.Database == nil {
+		config.Session.Database = config.Database
+	}
+	if config.Auth.Logging == nil {
+		config.Auth.Logging = config.Logging
+	}
+	if config.Game.Logging == nil {
+		config.Game.Logging = config.Logging
+	}
+	if config.Session.Logging == nil {
+		config.Session.Logging = config.Logging
+	}
+	if config.Auth.FeatureFlags == nil {
+		config.Auth.FeatureFlags = config.FeatureFlags
+	}
+	if config.Game.FeatureFlags == nil {
+		config.Game.FeatureFlags = config.FeatureFlags
+	}
+	if config.Session.FeatureFlags == nil {
+		config.Session.FeatureFlags = config.FeatureFlags
+	}
+
+	applyGameDefaults(config.Game)
+	applyDefaults(config.Session)
+
+	if err := config.Auth.Validate(); err != nil {
+		return fmt.Errorf("auth configuration validation failed: %w", err)
+	}
+	if err := config.Game.Validate(); err != nil {
+		return fmt.Errorf("game configuration validation failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadDungeonGateConfig(t *testing.T) {
+	cfg, err := LoadDungeonGateConfig("../../configs/dungeongate.yaml")
+	require.NoError(t, err)
+
+	// Services without their own database or logging use the shared ones
+	assert.Same(t, cfg.Database, cfg.Auth.Database)
+	assert.Same(t, cfg.Database, cfg.Game.Database)
+	assert.Same(t, cfg.Logging, cfg.Session.Logging)
+
+	// Service defaults are applied to each section
+	assert.Equal(t, 2222, cfg.Session.SSH.Port)
+	assert.NotNil(t, cfg.Session.Services)
+	assert.Equal(t, "process", cfg.Game.GameEngine.Mode)
+}
+
+func TestLoadDungeonGateConfig_UnknownSectionField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dungeongate.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("session:\n  sshh:\n    port: 2222\n"), 0644))
+
+	_, err := LoadDungeonGateConfig(path)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sshh")
+}
+
+func TestDefaultDungeonGateConfig(t *testing.T) {
+	cfg, err := DefaultDungeonGateConfig()
+	require.NoError(t, err)
+
+	assert.Same(t, cfg.Database, cfg.Session.Database)
+	assert.Equal(t, 2222, cfg.Session.SSH.Port)
+	assert.Equal(t, "./data/ssh_keys/host_key", cfg.Session.SSH.HostKeyPath)
+}
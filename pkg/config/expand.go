@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// EnvExpansion records one environment variable reference expanded in a config file
+type EnvExpansion struct {
+	File        string
+	Line        int
+	Variable    string
+	UsedDefault bool
+}
+
+// LogEnvExpansions reports each expansion at debug level. Values are not
+// logged since configs commonly pull secrets from the environment.
+func LogEnvExpansions(logger *slog.Logger, expansions []EnvExpansion) {
+	for _, e := range expansions {
+		logger.Debug("Expanded config variable",
+			"file", e.File,
+			"line", e.Line,
+			"variable", e.Variable,
+			"used_default", e.UsedDefault)
+	}
+}
+
+// expandEnv substitutes environment variables in a config file.
+//
+// $VAR and ${VAR} must be set; ${VAR:-default} uses default when VAR is unset
+// or empty. $$ produces a literal $, which is how placeholders meant for the
+// services themselves (e.g. $${USER_ID}) are written. Whole-line comments are
+// left untouched. Every undefined variable is reported, with its line.
+func expandEnv(data, file string) (string, []EnvExpansion, error) {
+	var (
+		out        strings.Builder
+		expansions []EnvExpansion
+		errs       ConfigErrors
+	)
+
+	lines := strings.SplitAfter(data, "\n")
+	for i, line := range lines {
+		lineNo := i + 1
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			out.WriteString(line)
+			continue
+		}
+
+		for pos := 0; pos < len(line); {
+			c := line[pos]
+			if c != '$' || pos+1 >= len(line) {
+				out.WriteByte(c)
+				pos++
+				continue
+			}
+
+			if line[pos+1] == '$' {
+				out.WriteByte('$')
+				pos += 2
+				continue
+			}
+
+			name, def, hasDefault, width := parseEnvReference(line[pos:])
+			switch {
+			case width == 0:
+				// Not a variable reference, e.g. a lone "$" or "$5"
+				out.WriteByte(c)
+				pos++
+				continue
+			case name == "":
+				errs = append(errs, &ConfigError{File: file, Line: lineNo, Column: pos + 1,
+					Message: fmt.Sprintf("malformed variable reference %q", line[pos:pos+width])})
+				pos += width
+				continue
+			}
+
+			value, ok := os.LookupEnv(name)
+			usedDefault := false
+			if hasDefault && value == "" {
+				value, usedDefault = def, true
+			} else if !ok {
+				errs = append(errs, &ConfigError{File: file, Line: lineNo, Column: pos + 1,
+					Message: fmt.Sprintf("environment variable %s is not set (use ${%s:-default} to make it optional)", name, name)})
+			}
+
+			out.WriteString(value)
+			expansions = append(expansions, EnvExpansion{File: file, Line: lineNo, Variable: name, UsedDefault: usedDefault})
+			pos += width
+		}
+	}
+
+	if len(errs) > 0 {
+		return "", nil, errs
+	}
+	return out.String(), expansions, nil
+}
+
+// parseEnvReference parses a reference starting at s[0] == '$'. It returns the
+// variable name, the default for ${VAR:-default}, and how many bytes the
+// reference spans; width is 0 when s does not start a reference. A braced
+// reference with an invalid name returns an empty name and a non-zero width.
+func parseEnvReference(s string) (name, def string, hasDefault bool, width int) {
+	if len(s) < 2 {
+		return "", "", false, 0
+	}
+
+	if s[1] != '{' {
+		n := envNameLength(s[1:])
+		if n == 0 {
+			return "", "", false, 0
+		}
+		return s[1 : 1+n], "", false, 1 + n
+	}
+
+	end := strings.IndexByte(s, '}')
+	if end < 0 {
+		return "", "", false, len(s)
+	}
+	body := s[2:end]
+	width = end + 1
+
+	if idx := strings.Index(body, ":-"); idx >= 0 {
+		name, def, hasDefault = body[:idx], body[idx+2:], true
+	} else {
+		name = body
+	}
+	if name == "" || envNameLength(name) != len(name) {
+		return "", "", false, width
+	}
+	return name, def, hasDefault, width
+}
+
+// envNameLength returns the length of the environment variable name at the
+// start of s
+func envNameLength(s string) int {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' || (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z'):
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return i
+		}
+	}
+	return len(s)
+}
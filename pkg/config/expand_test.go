@@ -0,0 +1,58 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("DG_TEST_HOST", "db.local")
+	t.Setenv("DG_TEST_EMPTY", "")
+
+	data := `host: ${DG_TEST_HOST}
+port: ${DG_TEST_PORT:-5432}
+user: ${DG_TEST_EMPTY:-dungeongate}
+url: postgres://$DG_TEST_HOST/db
+pattern: "/home/$${USERNAME}/save"
+price: "$5"
+# password: ${DG_TEST_UNSET}
+`
+	expanded, expansions, err := expandEnv(data, "test.yaml")
+	require.NoError(t, err)
+
+	assert.Equal(t, `host: db.local
+port: 5432
+user: dungeongate
+url: postgres://db.local/db
+pattern: "/home/${USERNAME}/save"
+price: "$5"
+# password: ${DG_TEST_UNSET}
+`, expanded)
+
+	require.Len(t, expansions, 4)
+	assert.Equal(t, EnvExpansion{File: "test.yaml", Line: 1, Variable: "DG_TEST_HOST"}, expansions[0])
+	assert.Equal(t, EnvExpansion{File: "test.yaml", Line: 2, Variable: "DG_TEST_PORT", UsedDefault: true}, expansions[1])
+	assert.True(t, expansions[2].UsedDefault)
+}
+
+func TestExpandEnv_UndefinedVariables(t *testing.T) {
+	data := `database:
+  password: ${DG_TEST_UNSET_PASSWORD}
+  user: $DG_TEST_UNSET_USER
+  host: ${bad-name}
+`
+	_, _, err := expandEnv(data, "test.yaml")
+	require.Error(t, err)
+
+	var errs ConfigErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 3)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Contains(t, errs[0].Message, "DG_TEST_UNSET_PASSWORD is not set")
+	assert.Equal(t, 3, errs[1].Line)
+	assert.Contains(t, errs[1].Message, "DG_TEST_UNSET_USER is not set")
+	assert.Equal(t, 4, errs[2].Line)
+	assert.Contains(t, errs[2].Message, "malformed variable reference")
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// defaultFeatureFlagRefresh is how often services reread the flags set at
+// runtime when FeatureFlagsConfig.RefreshInterval is unset
+const defaultFeatureFlagRefresh = 30 * time.Second
+
+// featureFlagName is what a feature flag may be called, such as
+// "new_menu_engine"
+var featureFlagName = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// FeatureFlagsConfig turns risky features on for some users before everyone.
+// Flags set at runtime with `dgctl flags` are stored by the auth service and
+// replace the flag of the same name configured here.
+type FeatureFlagsConfig struct {
+	// RefreshInterval is how often flags set at runtime are reread (default 30s)
+	RefreshInterval string `yaml:"refresh_interval"`
+	// AuthService is the auth service gRPC address the game service reads
+	// flags set at runtime from; without it the game service uses only the
+	// flags configured here. The session service asks its own auth service.
+	AuthService string `yaml:"auth_service"`
+	// ServiceToken is sent to the auth service, matching its server.auth_token
+	ServiceToken string `yaml:"service_token"`
+	// Flags are the feature flags, by name
+	Flags map[string]*FeatureFlag `yaml:"flags"`
+}
+
+// FeatureFlag says who a feature is on for. It is on for everyone when
+// Enabled, and otherwise for the listed users, users with one of the listed
+// roles, and the given percentage of signed-in users.
+type FeatureFlag struct {
+	Description string `yaml:"description"`
+	Enabled     bool   `yaml:"enabled"`
+	// Users are usernames the feature is on for
+	Users []string `yaml:"users"`
+	// Roles are roles (admin, moderator, beta) the feature is on for
+	Roles []string `yaml:"roles"`
+	// Percentage of signed-in users the feature is on for, 0 to 100. Each
+	// user stays in or out of a flag's rollout as the percentage grows.
+	Percentage int `yaml:"percentage"`
+}
+
+// GetRefreshInterval returns how often flags set at runtime are reread
+func (c *FeatureFlagsConfig) GetRefreshInterval() time.Duration {
+	if c == nil || c.RefreshInterval == "" {
+		return defaultFeatureFlagRefresh
+	}
+	if d := ParseDuration(c.RefreshInterval, defaultFeatureFlagRefresh); d > 0 {
+		return d
+	}
+	return defaultFeatureFlagRefresh
+}
+
+// Validate checks the names and percentages of the configured flags
+func (c *FeatureFlagsConfig) Validate() error {
+	if c.RefreshInterval != "" {
+		if d, err := time.ParseDuration(c.RefreshInterval); err != nil || d <= 0 {
+			return fmt.Errorf("refresh_interval: %q is not a duration such as 30s or 5m", c.RefreshInterval)
+		}
+	}
+	for name, flag := range c.Flags {
+		if err := ValidateFeatureFlag(name, flag); err != nil {
+			return fmt.Errorf("flags.%w", err)
+		}
+	}
+	return nil
+}
+
+// ValidateFeatureFlag checks a flag's name and percentage
+func ValidateFeatureFlag(name string, flag *FeatureFlag) error {
+	if !featureFlagName.MatchString(name) {
+		return fmt.Errorf("%s: flag names are lowercase letters, digits and underscores, starting with a letter", name)
+	}
+	if flag != nil && (flag.Percentage < 0 || flag.Percentage > 100) {
+		return fmt.Errorf("%s: percentage %d is not between 0 and 100", name, flag.Percentage)
+	}
+	return nil
+}
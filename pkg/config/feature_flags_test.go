@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeatureFlagsConfig_Validate(t *testing.T) {
+	valid := &FeatureFlagsConfig{
+		RefreshInterval: "1m",
+		Flags: map[string]*FeatureFlag{
+			"new_menus": {Roles: []string{"beta"}, Percentage: 10},
+		},
+	}
+	assert.NoError(t, valid.Validate())
+
+	tests := []struct {
+		name string
+		cfg  *FeatureFlagsConfig
+		want string
+	}{
+		{"bad refresh interval", &FeatureFlagsConfig{RefreshInterval: "often"}, "refresh_interval"},
+		{"uppercase name", &FeatureFlagsConfig{Flags: map[string]*FeatureFlag{"NewMenus": {}}}, "flags.NewMenus"},
+		{"percentage over 100", &FeatureFlagsConfig{Flags: map[string]*FeatureFlag{"new_menus": {Percentage: 101}}}, "percentage 101"},
+		{"negative percentage", &FeatureFlagsConfig{Flags: map[string]*FeatureFlag{"new_menus": {Percentage: -1}}}, "percentage -1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.want)
+			}
+		})
+	}
+}
+
+func TestFeatureFlagsConfig_GetRefreshInterval(t *testing.T) {
+	var unset *FeatureFlagsConfig
+	assert.Equal(t, 30*time.Second, unset.GetRefreshInterval())
+	assert.Equal(t, 5*time.Minute, (&FeatureFlagsConfig{RefreshInterval: "5m"}).GetRefreshInterval())
+}
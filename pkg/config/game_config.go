@@ -6,35 +6,136 @@ import (
 	"path/filepath"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // GameServiceConfig represents the game service configuration
 type GameServiceConfig struct {
-	Version     string              `yaml:"version"`
-	InheritFrom string              `yaml:"inherit_from,omitempty"`
-	Server      *ServerConfig       `yaml:"server"`
-	Database    *DatabaseConfig     `yaml:"database"`
-	GameEngine  *GameEngineConfig   `yaml:"game_engine"`
-	Games       []*GameConfig       `yaml:"games"`
-	Kubernetes  *KubernetesConfig   `yaml:"kubernetes"`
-	Storage     *GameStorageConfig  `yaml:"storage"`
-	Logging     *LoggingConfig      `yaml:"logging"`
-	Metrics     *MetricsConfig      `yaml:"metrics"`
-	Health      *HealthConfig       `yaml:"health"`
-	Security    *GameSecurityConfig `yaml:"security"`
+	Version       string               `yaml:"version"`
+	InheritFrom   string               `yaml:"inherit_from,omitempty"`
+	Server        *ServerConfig        `yaml:"server"`
+	APIAuth       *APIAuthConfig       `yaml:"api_auth"`
+	Notifications *NotificationsConfig `yaml:"notifications"`
+	Database      *DatabaseConfig      `yaml:"database"`
+	GameEngine    *GameEngineConfig    `yaml:"game_engine"`
+	Games         []*GameConfig        `yaml:"games"`
+	Kubernetes    *KubernetesConfig    `yaml:"kubernetes"`
+	Storage       *GameStorageConfig   `yaml:"storage"`
+	Logging       *LoggingConfig       `yaml:"logging"`
+	Metrics       *MetricsConfig       `yaml:"metrics"`
+	Health        *HealthConfig        `yaml:"health"`
+	Security      *GameSecurityConfig  `yaml:"security"`
+	IOCapture     *IOCaptureConfig     `yaml:"io_capture"`
+	Screenshots   *ScreenshotsConfig   `yaml:"screenshots"`
+	PublicFeed    *PublicFeedConfig    `yaml:"public_feed"`
+	Retention     *RetentionConfig     `yaml:"retention"`
+	FeatureFlags  *FeatureFlagsConfig  `yaml:"feature_flags"`
+
+	// EnvExpansions lists the environment variables expanded while loading
+	EnvExpansions []EnvExpansion `yaml:"-"`
 }
 
 // GameEngineConfig represents game engine configuration
 type GameEngineConfig struct {
-	Mode             string                  `yaml:"mode"` // "container", "process", "hybrid"
+	Mode             string                  `yaml:"mode"` // "container", "process", "hybrid", "dev"
 	ProcessPool      *ProcessPoolConfig      `yaml:"process_pool"`
 	ContainerRuntime *ContainerRuntimeConfig `yaml:"container_runtime"`
 	Isolation        *IsolationConfig        `yaml:"isolation"`
 	Monitoring       *GameMonitoringConfig   `yaml:"monitoring"`
 	Chroot           *ChrootConfig           `yaml:"chroot"`
 	Resources        *ResourcesConfig        `yaml:"resources"`
+	Handoff          *HandoffConfig          `yaml:"handoff"`
+	HangDetection    *HangDetectionConfig    `yaml:"hang_detection"`
+}
+
+// HandoffConfig lets a restarted game service take over the games of the
+// instance it replaces instead of ending them
+type HandoffConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Socket is the Unix socket instances hand games over on
+	// (default "dungeongate-game-handoff.sock" in the temp directory)
+	Socket string `yaml:"socket"`
+}
+
+// SocketPath returns the handoff socket, or "" when handoff is disabled
+func (c *HandoffConfig) SocketPath() string {
+	if c == nil || !c.Enabled {
+		return ""
+	}
+	if c.Socket != "" {
+		return c.Socket
+	}
+	return filepath.Join(os.TempDir(), "dungeongate-game-handoff.sock")
+}
+
+// HangDetectionConfig marks a game degraded when it stops responding: the
+// player typed but the game printed nothing for Threshold, or it took longer
+// than WriteTimeout to accept input. The player is told and may force-kill
+// the game from the in-session menu.
+type HangDetectionConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Threshold is how long a game may stay silent after input (default "30s")
+	Threshold string `yaml:"threshold"`
+	// WriteTimeout is how long writing input to the game may block (default "10s")
+	WriteTimeout string `yaml:"write_timeout"`
+}
+
+// GetThreshold returns threshold, or 30 seconds when unset or not positive
+func (c *HangDetectionConfig) GetThreshold() time.Duration {
+	if c == nil {
+		return 30 * time.Second
+	}
+	if d := ParseDuration(c.Threshold, 30*time.Second); d > 0 {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// GetWriteTimeout returns write_timeout, or 10 seconds when unset or not positive
+func (c *HangDetectionConfig) GetWriteTimeout() time.Duration {
+	if c == nil {
+		return 10 * time.Second
+	}
+	if d := ParseDuration(c.WriteTimeout, 10*time.Second); d > 0 {
+		return d
+	}
+	return 10 * time.Second
+}
+
+// APIAuthConfig protects the REST API. Callers send an access token from the
+// auth service or an API key, and each key or user is rate limited.
+type APIAuthConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// AuthService is the auth service gRPC address tokens and keys are checked against
+	AuthService string `yaml:"auth_service"`
+	// ServiceToken is sent to the auth service, matching its server.auth_token
+	ServiceToken string `yaml:"service_token"`
+	// RateLimit is the requests per minute allowed to each API key without a
+	// limit of its own, and to each user calling with an access token (default 60)
+	RateLimit int `yaml:"rate_limit"`
+}
+
+// RequestsPerMinute returns the default rate limit
+func (c *APIAuthConfig) RequestsPerMinute() int {
+	if c == nil || c.RateLimit <= 0 {
+		return 60
+	}
+	return c.RateLimit
+}
+
+// NotificationsConfig has the game service leave notifications for players
+// with the auth service, such as when their game crashes
+type NotificationsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// AuthService is the auth service gRPC address notifications are sent to
+	AuthService string `yaml:"auth_service"`
+	// ServiceToken is sent to the auth service, matching its server.auth_token
+	ServiceToken string `yaml:"service_token"`
+}
+
+// IsDevMode reports whether games run in development mode, where process
+// isolation is skipped and game binaries are looked up on PATH
+func (e *GameEngineConfig) IsDevMode() bool {
+	return e != nil && e.Mode == "dev"
 }
 
 // GameConfig represents a specific game configuration
@@ -54,6 +155,63 @@ type GameConfig struct {
 	Resources   *ResourcesConfig    `yaml:"resources"`
 	Container   *ContainerConfig    `yaml:"container"`
 	Networking  *NetworkingConfig   `yaml:"networking"`
+	// Adapter chooses an external adapter for the game instead of a built-in one
+	Adapter *GameAdapterConfig `yaml:"adapter"`
+	// Versions lists further installed versions of the game, which players
+	// pick from the game menu. The top-level binary is the game's Version.
+	Versions []*GameVersionConfig `yaml:"versions"`
+}
+
+// GameAdapterConfig chooses how the game service adapts to a game
+type GameAdapterConfig struct {
+	// Plugin serves the game's adapter from outside the game service
+	Plugin *AdapterPluginConfig `yaml:"plugin"`
+}
+
+// AdapterPluginConfig is an external game adapter serving the
+// GameAdapterPlugin gRPC service. The game service either launches Command,
+// which prints the address it listens on, or dials Address, where a plugin
+// already runs. Games sharing a plugin share its process.
+type AdapterPluginConfig struct {
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+	// Address is a host:port or unix:///path to dial instead of launching Command
+	Address string `yaml:"address"`
+	// Timeout bounds each call to the plugin (default "5s")
+	Timeout string `yaml:"timeout"`
+}
+
+// GetTimeout returns timeout, or 5 seconds when unset or not positive
+func (c *AdapterPluginConfig) GetTimeout() time.Duration {
+	if c == nil {
+		return 5 * time.Second
+	}
+	if d := ParseDuration(c.Timeout, 5*time.Second); d > 0 {
+		return d
+	}
+	return 5 * time.Second
+}
+
+// Validate checks that the plugin is either launched or dialed
+func (c *AdapterPluginConfig) Validate() error {
+	if (c.Command == "") == (c.Address == "") {
+		return fmt.Errorf("adapter plugin needs exactly one of command and address")
+	}
+	if len(c.Args) > 0 && c.Command == "" {
+		return fmt.Errorf("adapter plugin args are only passed to a command")
+	}
+	return nil
+}
+
+// GameVersionConfig represents one more installed version of a game. Unset
+// paths are taken from the game; environment is merged.
+type GameVersionConfig struct {
+	Version     string            `yaml:"version"`
+	Binary      *BinaryConfig     `yaml:"binary"`
+	Paths       *GamePathsConfig  `yaml:"paths"`
+	Environment map[string]string `yaml:"environment"`
+	// Default makes this version the one started for players without a preference
+	Default bool `yaml:"default"`
 }
 
 // BinaryConfig represents binary configuration
@@ -68,14 +226,17 @@ type BinaryConfig struct {
 
 // FilesConfig represents files configuration
 type FilesConfig struct {
-	DataDirectory   string             `yaml:"data_directory"`
-	SaveDirectory   string             `yaml:"save_directory"`
-	ConfigDirectory string             `yaml:"config_directory"`
-	LogDirectory    string             `yaml:"log_directory"`
-	TempDirectory   string             `yaml:"temp_directory"`
-	SharedFiles     []string           `yaml:"shared_files"`
-	UserFiles       []string           `yaml:"user_files"`
-	Permissions     *PermissionsConfig `yaml:"permissions"`
+	DataDirectory   string   `yaml:"data_directory"`
+	SaveDirectory   string   `yaml:"save_directory"`
+	ConfigDirectory string   `yaml:"config_directory"`
+	LogDirectory    string   `yaml:"log_directory"`
+	TempDirectory   string   `yaml:"temp_directory"`
+	SharedFiles     []string `yaml:"shared_files"`
+	// ScoreFiles are created empty in the data directory when missing, owned
+	// by the binary's user and group so the game can write them
+	ScoreFiles  []string           `yaml:"score_files"`
+	UserFiles   []string           `yaml:"user_files"`
+	Permissions *PermissionsConfig `yaml:"permissions"`
 }
 
 // PermissionsConfig represents file permissions
@@ -84,6 +245,7 @@ type PermissionsConfig struct {
 	SaveDirectory string `yaml:"save_directory"`
 	UserFiles     string `yaml:"user_files"`
 	LogFiles      string `yaml:"log_files"`
+	ScoreFiles    string `yaml:"score_files"`
 }
 
 // GamePathsConfig represents game-specific path configuration
@@ -121,6 +283,12 @@ type GameSetupOptions struct {
 	SetPermissions    bool `yaml:"set_permissions"`
 	DetectSystemPaths bool `yaml:"detect_system_paths"`
 	CreateSaveLinks   bool `yaml:"create_save_links"`
+	// Hook is a script run before each session's game starts; the game does
+	// not start when it fails
+	Hook *GameHookConfig `yaml:"hook"`
+	// Upgrade steps run in order, by one replica, when initialize_shared
+	// finds the game's binary or version changed
+	Upgrade []*GameUpgradeStep `yaml:"upgrade"`
 }
 
 // GameCleanupOptions represents game cleanup configuration
@@ -133,6 +301,76 @@ type GameCleanupOptions struct {
 	BackupSaves        bool `yaml:"backup_saves"`
 	CleanupSaveLinks   bool `yaml:"cleanup_save_links"`
 	ValidateCleanup    bool `yaml:"validate_cleanup"`
+	// Hook is a script run after each session's game exits
+	Hook *GameHookConfig `yaml:"hook"`
+}
+
+// GameUpgradeStep is a command migrating a game's shared data, such as its
+// score files, to a new binary or version. It runs in the data directory
+// with a fixed PATH, the old and new versions as DUNGEONGATE_* variables and
+// Environment. A failed upgrade is retried at the next start, so steps must
+// be safe to repeat.
+type GameUpgradeStep struct {
+	Name        string            `yaml:"name"`
+	Command     string            `yaml:"command"`
+	Args        []string          `yaml:"args"`
+	Environment map[string]string `yaml:"environment"`
+	// Timeout after which the step is killed (default "5m")
+	Timeout string `yaml:"timeout"`
+}
+
+// GetTimeout returns timeout, or 5 minutes when unset or not positive
+func (s *GameUpgradeStep) GetTimeout() time.Duration {
+	if d := ParseDuration(s.Timeout, 5*time.Minute); d > 0 {
+		return d
+	}
+	return 5 * time.Minute
+}
+
+// Validate checks that the step is named and names an absolute command
+func (s *GameUpgradeStep) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("upgrade step name is required")
+	}
+	if s.Command == "" {
+		return fmt.Errorf("upgrade step %q command is required", s.Name)
+	}
+	if !filepath.IsAbs(s.Command) {
+		return fmt.Errorf("upgrade step %q command %q must be an absolute path", s.Name, s.Command)
+	}
+	return nil
+}
+
+// GameHookConfig is a script run around a game session, with the game's
+// namespaces and cgroup. It gets a fixed PATH, the session's details as
+// DUNGEONGATE_* variables and Environment, and none of the game service's
+// own environment.
+type GameHookConfig struct {
+	Command          string            `yaml:"command"`
+	Args             []string          `yaml:"args"`
+	WorkingDirectory string            `yaml:"working_directory"`
+	Environment      map[string]string `yaml:"environment"`
+	// Timeout after which the hook is killed (default "30s")
+	Timeout string `yaml:"timeout"`
+}
+
+// GetTimeout returns timeout, or 30 seconds when unset or not positive
+func (h *GameHookConfig) GetTimeout() time.Duration {
+	if d := ParseDuration(h.Timeout, 30*time.Second); d > 0 {
+		return d
+	}
+	return 30 * time.Second
+}
+
+// Validate checks that the hook names an absolute command
+func (h *GameHookConfig) Validate() error {
+	if h.Command == "" {
+		return fmt.Errorf("hook command is required")
+	}
+	if !filepath.IsAbs(h.Command) {
+		return fmt.Errorf("hook command %q must be an absolute path", h.Command)
+	}
+	return nil
 }
 
 // GameSettings represents game-specific settings
@@ -142,14 +380,78 @@ type GameSettings struct {
 	IdleTimeout        string            `yaml:"idle_timeout"`
 	SaveInterval       string            `yaml:"save_interval"`
 	AutoSave           bool              `yaml:"auto_save"`
+	Locale             string            `yaml:"locale"` // Default LANG/LC_ALL for the game, e.g. en_US.UTF-8
 	Spectating         *SpectatingConfig `yaml:"spectating"`
 	Recording          *RecordingConfig  `yaml:"recording"`
+	Dumplog            *DumplogConfig    `yaml:"dumplog"`
+	Xlog               *XlogConfig       `yaml:"xlog"`
+	StatusLine         *StatusLineConfig `yaml:"status_line"`
 	Options            map[string]string `yaml:"options"`
 }
 
+// StatusLineConfig is how a game's status, such as "Dlvl:12 HP:45/60", is
+// read off the bottom rows of its screen for the watch menu and session
+// listings. Games with an adapter that knows their status line, like
+// NetHack, need no pattern.
+type StatusLineConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Rows is how many rows at the bottom of the screen hold the status, at
+	// most 3; the default is 2
+	Rows int `yaml:"rows"`
+	// Pattern is a regular expression matched against the rows, joined by
+	// newlines
+	Pattern string `yaml:"pattern"`
+	// Format builds the status from the pattern's named groups, such as
+	// "Dlvl:${dlvl} HP:${hp}/${hpmax}"; the whole match when empty
+	Format string `yaml:"format"`
+	// Alerts are raised when the game's status meets them, NetHack only
+	Alerts []StatusAlertConfig `yaml:"alerts"`
+}
+
+// StatusAlertConfig is a rule on a NetHack game's status, such as HP below
+// a fifth or entering Gehennom. An alert is raised, shown to the game's
+// spectators and logged as an event when the status starts meeting every
+// condition the rule sets; it is raised again only after the status has
+// stopped meeting it.
+type StatusAlertConfig struct {
+	Name string `yaml:"name"`
+	// HPBelowPercent is met while HP is below this percentage of max HP
+	HPBelowPercent int `yaml:"hp_below_percent"`
+	// DepthAtLeast is met at this dungeon level and deeper
+	DepthAtLeast int `yaml:"depth_at_least"`
+	// Level is a regular expression matched against the level, such as
+	// "Astral Plane" or "Dlvl:12"
+	Level string `yaml:"level"`
+	// Conditions is met while any of these status conditions is shown,
+	// such as Stone or Slime
+	Conditions []string `yaml:"conditions"`
+	// Message is the banner shown, with $PLAYER, $STATUS, $LEVEL, $DEPTH,
+	// $HP, $HPMAX, $XL and $TURN replaced; "$PLAYER: $STATUS" when empty
+	Message string `yaml:"message"`
+}
+
+// XlogConfig locates the game's xlogfile, which records how each game
+// ended for the players' session history
+type XlogConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Path    string `yaml:"path"`
+}
+
+// DumplogConfig represents end-of-game dumplog capture configuration
+type DumplogConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// PathPattern locates the dumplog file; it may use ${USER_ID}, ${USERNAME},
+	// ${SESSION_ID} and ${GAME_ID} placeholders and shell glob wildcards
+	PathPattern string `yaml:"path_pattern"`
+	MaxSizeKB   int    `yaml:"max_size_kb"`
+}
+
 // RecordingConfig represents recording configuration
 type RecordingConfig struct {
-	Enabled       bool   `yaml:"enabled"`
+	Enabled bool `yaml:"enabled"`
+	// Policy is "player" to follow each player's recording privacy setting,
+	// "always" to record every game publicly (e.g. tournaments) or "never"
+	Policy        string `yaml:"policy"`
 	Format        string `yaml:"format"`
 	Compression   string `yaml:"compression"`
 	MaxFileSize   string `yaml:"max_file_size"`
@@ -415,6 +717,11 @@ type NamespaceConfig struct {
 	User    bool `yaml:"user"`
 }
 
+// Enabled reports whether any namespace is requested
+func (n *NamespaceConfig) Enabled() bool {
+	return n.PID || n.Network || n.Mount || n.UTS || n.IPC || n.User
+}
+
 // CgroupConfig represents cgroup configuration
 type CgroupConfig struct {
 	Enabled     bool   `yaml:"enabled"`
@@ -424,6 +731,15 @@ type CgroupConfig struct {
 	PidsLimit   int    `yaml:"pids_limit"`
 }
 
+// GetCgroupPath returns the cgroup v2 directory games' cgroups are created
+// under, /sys/fs/cgroup/dungeongate when unset
+func (c *CgroupConfig) GetCgroupPath() string {
+	if c == nil || c.CgroupPath == "" {
+		return "/sys/fs/cgroup/dungeongate"
+	}
+	return c.CgroupPath
+}
+
 // CapabilityConfig represents capability configuration
 type CapabilityConfig struct {
 	Drop []string `yaml:"drop"`
@@ -480,14 +796,15 @@ type AlertThresholdsConfig struct {
 
 // GameStorageConfig represents game storage configuration
 type GameStorageConfig struct {
-	GameDataPath string          `yaml:"game_data_path"`
-	UserDataPath string          `yaml:"user_data_path"`
-	LogPath      string          `yaml:"log_path"`
-	TempPath     string          `yaml:"temp_path"`
-	BackupPath   string          `yaml:"backup_path"`
-	Volumes      []*VolumeConfig `yaml:"volumes"`
-	Backup       *BackupConfig   `yaml:"backup"`
-	Cleanup      *CleanupConfig  `yaml:"cleanup"`
+	GameDataPath string            `yaml:"game_data_path"`
+	UserDataPath string            `yaml:"user_data_path"`
+	LogPath      string            `yaml:"log_path"`
+	TempPath     string            `yaml:"temp_path"`
+	BackupPath   string            `yaml:"backup_path"`
+	Volumes      []*VolumeConfig   `yaml:"volumes"`
+	Backup       *BackupConfig     `yaml:"backup"`
+	Cleanup      *CleanupConfig    `yaml:"cleanup"`
+	SaveBundles  *SaveBundleConfig `yaml:"save_bundles"`
 }
 
 // BackupConfig represents backup configuration
@@ -503,9 +820,74 @@ type BackupConfig struct {
 type CleanupConfig struct {
 	Enabled            bool   `yaml:"enabled"`
 	Interval           string `yaml:"interval"`
-	MaxAge             string `yaml:"max_age"`
+	MaxAge             string `yaml:"max_age"` // Go duration or whole days, e.g. "7d"
 	DeleteEmptyDirs    bool   `yaml:"delete_empty_dirs"`
 	PreserveRecordings bool   `yaml:"preserve_recordings"`
+	DryRun             bool   `yaml:"dry_run"` // Log and count what would be removed without deleting
+}
+
+// SaveBundleConfig configures portable save bundles for moving saves between servers
+type SaveBundleConfig struct {
+	ServerName    string            `yaml:"server_name"`    // Identifies this server in exported bundles
+	SigningKey    string            `yaml:"signing_key"`    // Signs exported bundles; export is disabled without one
+	TrustedKeys   map[string]string `yaml:"trusted_keys"`   // Signing keys of other servers, by server name
+	AllowUnsigned bool              `yaml:"allow_unsigned"` // Accept bundles not signed by a trusted server
+}
+
+// IOCaptureConfig lets admins capture the terminal I/O of a session for a
+// few minutes, to debug rendering and latency complaints
+type IOCaptureConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Directory holds the capture files (default "captures" under
+	// storage.game_data_path)
+	Directory string `yaml:"directory"`
+	// Key encrypts capture files: 32 bytes, hex or base64 encoded
+	Key string `yaml:"key"`
+	// MaxDuration is the longest a capture may run (default "30m")
+	MaxDuration string `yaml:"max_duration"`
+}
+
+// GetDirectory returns the directory holding capture files
+func (c *IOCaptureConfig) GetDirectory(gameDataPath string) string {
+	if c != nil && c.Directory != "" {
+		return c.Directory
+	}
+	return filepath.Join(gameDataPath, "captures")
+}
+
+// ScreenshotsConfig lets players and spectators capture the screen of a
+// game to share it
+type ScreenshotsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxPerSession is how many screenshots a session keeps (default 20)
+	MaxPerSession int `yaml:"max_per_session"`
+	// ShareTTL is how long a public link to a screenshot works (default "24h")
+	ShareTTL string `yaml:"share_ttl"`
+	// PublicURL is where the game service's HTTP API is reached from outside,
+	// such as "https://dungeongate.example.org"; share links are relative to
+	// it, and only a path when it is not set
+	PublicURL string `yaml:"public_url"`
+}
+
+// PublicFeedConfig serves a public, read-only feed of the games open to
+// spectators for community sites: server-sent events for game starts, ends
+// and alerts, and JSON listings of current games and leaderboards
+type PublicFeedConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// AllowedOrigins are the sites whose pages may read the feed (default
+	// every site, "*")
+	AllowedOrigins []string `yaml:"allowed_origins"`
+	// CacheMaxAge is how long browsers and proxies may cache the current
+	// games listing (default "10s"); leaderboards are cached six times longer
+	CacheMaxAge string `yaml:"cache_max_age"`
+}
+
+// GetCacheMaxAge returns how long the current games listing may be cached
+func (c *PublicFeedConfig) GetCacheMaxAge() time.Duration {
+	if c == nil {
+		return 10 * time.Second
+	}
+	return ParseDuration(c.CacheMaxAge, 10*time.Second)
 }
 
 // GameSecurityConfig represents game security configuration
@@ -550,12 +932,16 @@ func LoadGameServiceConfig(configPath string) (*GameServiceConfig, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	expanded := os.ExpandEnv(string(data))
+	expanded, expansions, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config: %w", err)
+	}
 
 	var config GameServiceConfig
-	if err := yaml.Unmarshal([]byte(expanded), &config); err != nil {
+	if err := decodeStrict([]byte(expanded), configPath, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	config.EnvExpansions = expansions
 
 	// Load and merge common configuration if specified
 	if config.InheritFrom != "" {
@@ -567,6 +953,7 @@ func LoadGameServiceConfig(configPath string) (*GameServiceConfig, error) {
 				return nil, fmt.Errorf("failed to load common config: %w", err)
 			}
 			MergeWithCommon(&config, commonConfig)
+			config.EnvExpansions = append(config.EnvExpansions, commonConfig.EnvExpansions...)
 		}
 	}
 
@@ -840,7 +1227,7 @@ func (cfg *GameServiceConfig) Validate() error {
 
 	// Validate game engine mode
 	switch cfg.GameEngine.Mode {
-	case "container", "process", "hybrid":
+	case "container", "process", "hybrid", "dev":
 		// Valid modes
 	default:
 		return fmt.Errorf("invalid game engine mode: %s", cfg.GameEngine.Mode)
@@ -853,6 +1240,12 @@ func (cfg *GameServiceConfig) Validate() error {
 		}
 	}
 
+	if cfg.FeatureFlags != nil {
+		if err := cfg.FeatureFlags.Validate(); err != nil {
+			return fmt.Errorf("feature_flags.%w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -876,6 +1269,11 @@ func (game *GameConfig) Validate() error {
 		return fmt.Errorf("path validation failed: %w", err)
 	}
 
+	// Validate additional versions
+	if err := game.validateVersions(); err != nil {
+		return fmt.Errorf("version validation failed: %w", err)
+	}
+
 	// Validate setup options
 	if err := game.validateSetupOptions(); err != nil {
 		return fmt.Errorf("setup options validation failed: %w", err)
@@ -886,6 +1284,22 @@ func (game *GameConfig) Validate() error {
 		return fmt.Errorf("cleanup options validation failed: %w", err)
 	}
 
+	// Validate the adapter plugin
+	if game.Adapter != nil && game.Adapter.Plugin != nil {
+		if err := game.Adapter.Plugin.Validate(); err != nil {
+			return err
+		}
+	}
+
+	// Validate recording policy
+	if game.Settings != nil && game.Settings.Recording != nil {
+		switch game.Settings.Recording.Policy {
+		case "", "player", "always", "never":
+		default:
+			return fmt.Errorf("invalid recording policy %q: must be player, always or never", game.Settings.Recording.Policy)
+		}
+	}
+
 	// Validate resource limits
 	if game.Resources != nil {
 		if game.Resources.CPULimit != "" {
@@ -993,12 +1407,14 @@ func GetDefaultNetHackConfig() *GameConfig {
 			LogDirectory:    "/var/log/nethack",
 			TempDirectory:   "/tmp/nethack",
 			SharedFiles:     []string{"nhdat", "license", "recover"},
+			ScoreFiles:      []string{"record", "logfile", "xlogfile", "perm"},
 			UserFiles:       []string{"${USERNAME}.nh", "${USERNAME}.0", "${USERNAME}.bak"},
 			Permissions: &PermissionsConfig{
 				DataDirectory: "0755",
 				SaveDirectory: "0755",
 				UserFiles:     "0644",
 				LogFiles:      "0644",
+				ScoreFiles:    "0664",
 			},
 		},
 		Settings: &GameSettings{
@@ -1114,6 +1530,76 @@ func (game *GameConfig) GetUserPaths() *UserPathsConfig {
 	}
 }
 
+// AvailableVersions returns the installed versions of the game, the top-level
+// version first
+func (game *GameConfig) AvailableVersions() []string {
+	versions := make([]string, 0, len(game.Versions)+1)
+	if game.Version != "" {
+		versions = append(versions, game.Version)
+	}
+	for _, version := range game.Versions {
+		if version != nil && version.Version != game.Version {
+			versions = append(versions, version.Version)
+		}
+	}
+	return versions
+}
+
+// DefaultVersion returns the version started for players without a preference
+func (game *GameConfig) DefaultVersion() string {
+	for _, version := range game.Versions {
+		if version != nil && version.Default {
+			return version.Version
+		}
+	}
+	return game.Version
+}
+
+// HasVersion reports whether a version of the game is installed
+func (game *GameConfig) HasVersion(version string) bool {
+	for _, v := range game.AvailableVersions() {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// ForVersion returns the configuration of one installed version of the game.
+// The top-level version, or an empty one, returns the game itself.
+func (game *GameConfig) ForVersion(version string) (*GameConfig, error) {
+	if version == "" || version == game.Version {
+		return game, nil
+	}
+
+	for _, v := range game.Versions {
+		if v == nil || v.Version != version {
+			continue
+		}
+		versioned := *game
+		versioned.Version = v.Version
+		versioned.Versions = nil
+		if v.Binary != nil {
+			versioned.Binary = v.Binary
+		}
+		if v.Paths != nil {
+			versioned.Paths = v.Paths
+		}
+		if len(v.Environment) > 0 {
+			versioned.Environment = make(map[string]string, len(game.Environment)+len(v.Environment))
+			for key, value := range game.Environment {
+				versioned.Environment[key] = value
+			}
+			for key, value := range v.Environment {
+				versioned.Environment[key] = value
+			}
+		}
+		return &versioned, nil
+	}
+
+	return nil, fmt.Errorf("version %s of game %s is not installed", version, game.ID)
+}
+
 // ShouldAutoDetectPaths returns whether to auto-detect system paths
 func (game *GameConfig) ShouldAutoDetectPaths() bool {
 	return game.Paths != nil && game.Paths.AutoDetect
@@ -1154,6 +1640,34 @@ func (game *GameConfig) GetCleanupOptions() *GameCleanupOptions {
 	}
 }
 
+// validateVersions validates the additional installed versions of a game
+func (game *GameConfig) validateVersions() error {
+	seen := map[string]bool{game.Version: true}
+	defaults := 0
+	for i, version := range game.Versions {
+		if version == nil {
+			return fmt.Errorf("versions[%d] is empty", i)
+		}
+		if version.Version == "" {
+			return fmt.Errorf("versions[%d]: version is required", i)
+		}
+		if seen[version.Version] {
+			return fmt.Errorf("version %s is listed more than once", version.Version)
+		}
+		seen[version.Version] = true
+		if version.Binary == nil || version.Binary.Path == "" {
+			return fmt.Errorf("version %s: binary path is required", version.Version)
+		}
+		if version.Default {
+			defaults++
+		}
+	}
+	if defaults > 1 {
+		return fmt.Errorf("only one version may be the default")
+	}
+	return nil
+}
+
 // validatePaths validates game path configuration
 func (game *GameConfig) validatePaths() error {
 	if game.Paths == nil {
@@ -1269,6 +1783,26 @@ func (game *GameConfig) validateSetupOptions() error {
 			return fmt.Errorf("auto-detection of system paths is not supported for game type '%s'", game.ID)
 		}
 	}
+	if game.Setup != nil && game.Setup.Hook != nil {
+		if err := game.Setup.Hook.Validate(); err != nil {
+			return err
+		}
+	}
+	if game.Setup != nil {
+		names := make(map[string]bool)
+		for _, step := range game.Setup.Upgrade {
+			if step == nil {
+				return fmt.Errorf("upgrade step is empty")
+			}
+			if err := step.Validate(); err != nil {
+				return err
+			}
+			if names[step.Name] {
+				return fmt.Errorf("duplicate upgrade step %q", step.Name)
+			}
+			names[step.Name] = true
+		}
+	}
 
 	return nil
 }
@@ -1285,6 +1819,11 @@ func (game *GameConfig) validateCleanupOptions() error {
 		if !game.Cleanup.PreserveConfig && game.Setup != nil && game.Setup.CopyDefaultConfig {
 			return fmt.Errorf("conflicting configuration: preserve_config is false but copy_default_config is true")
 		}
+		if game.Cleanup.Hook != nil {
+			if err := game.Cleanup.Hook.Validate(); err != nil {
+				return err
+			}
+		}
 	}
 
 	return nil
@@ -1355,6 +1894,11 @@ func (cfg *GameServiceConfig) ValidateAtStartup() error {
 			if err := validateBinaryExists(game.Binary.Path); err != nil {
 				return fmt.Errorf("binary validation failed for game %s: %w", game.ID, err)
 			}
+			for _, version := range game.Versions {
+				if err := validateBinaryExists(version.Binary.Path); err != nil {
+					return fmt.Errorf("binary validation failed for game %s version %s: %w", game.ID, version.Version, err)
+				}
+			}
 		}
 	}
 
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameConfig_Versions(t *testing.T) {
+	game := &GameConfig{
+		ID:          "nethack",
+		Version:     "3.6.7",
+		Binary:      &BinaryConfig{Path: "/usr/games/nethack"},
+		Environment: map[string]string{"TERM": "xterm", "NETHACKOPTIONS": "color"},
+		Versions: []*GameVersionConfig{
+			{
+				Version:     "3.7.0",
+				Binary:      &BinaryConfig{Path: "/opt/nethack-3.7/nethack"},
+				Environment: map[string]string{"NETHACKOPTIONS": "color,statushilites"},
+				Default:     true,
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"3.6.7", "3.7.0"}, game.AvailableVersions())
+	assert.Equal(t, "3.7.0", game.DefaultVersion())
+	assert.True(t, game.HasVersion("3.6.7"))
+	assert.False(t, game.HasVersion("3.4.3"))
+	require.NoError(t, game.validateVersions())
+
+	same, err := game.ForVersion("3.6.7")
+	require.NoError(t, err)
+	assert.Same(t, game, same)
+
+	versioned, err := game.ForVersion("3.7.0")
+	require.NoError(t, err)
+	assert.Equal(t, "3.7.0", versioned.Version)
+	assert.Equal(t, "/opt/nethack-3.7/nethack", versioned.Binary.Path)
+	assert.Equal(t, "xterm", versioned.Environment["TERM"])
+	assert.Equal(t, "color,statushilites", versioned.Environment["NETHACKOPTIONS"])
+	assert.Equal(t, "color", game.Environment["NETHACKOPTIONS"])
+
+	_, err = game.ForVersion("3.4.3")
+	assert.Error(t, err)
+}
+
+func TestGameConfig_ValidateVersions(t *testing.T) {
+	game := &GameConfig{
+		Version: "3.6.7",
+		Versions: []*GameVersionConfig{
+			{Version: "3.6.7", Binary: &BinaryConfig{Path: "/usr/games/nethack"}},
+		},
+	}
+	assert.Error(t, game.validateVersions())
+
+	game.Versions = []*GameVersionConfig{{Version: "3.7.0"}}
+	assert.Error(t, game.validateVersions())
+
+	game.Versions = []*GameVersionConfig{
+		{Version: "3.7.0", Binary: &BinaryConfig{Path: "/a"}, Default: true},
+		{Version: "3.7.1", Binary: &BinaryConfig{Path: "/b"}, Default: true},
+	}
+	assert.Error(t, game.validateVersions())
+}
+
+func TestAdapterPluginConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&AdapterPluginConfig{Command: "/usr/lib/dungeongate/brogue-adapter", Args: []string{"-v"}}).Validate())
+	assert.NoError(t, (&AdapterPluginConfig{Address: "unix:///run/brogue-adapter.sock"}).Validate())
+	assert.Error(t, (&AdapterPluginConfig{}).Validate())
+	assert.Error(t, (&AdapterPluginConfig{Command: "brogue-adapter", Address: "localhost:9000"}).Validate())
+	assert.Error(t, (&AdapterPluginConfig{Address: "localhost:9000", Args: []string{"-v"}}).Validate())
+
+	assert.Equal(t, 5*time.Second, (&AdapterPluginConfig{}).GetTimeout())
+	assert.Equal(t, 2*time.Second, (&AdapterPluginConfig{Timeout: "2s"}).GetTimeout())
+}
+
+func TestGameHookConfig_Validate(t *testing.T) {
+	game := &GameConfig{ID: "brogue", Setup: &GameSetupOptions{Hook: &GameHookConfig{Command: "/usr/lib/brogue/setup.sh"}}}
+	assert.NoError(t, game.validateSetupOptions())
+
+	game.Setup.Hook.Command = "setup.sh"
+	assert.Error(t, game.validateSetupOptions(), "hooks run without the service's PATH")
+
+	game.Cleanup = &GameCleanupOptions{PreserveConfig: true, Hook: &GameHookConfig{}}
+	assert.Error(t, game.validateCleanupOptions())
+
+	assert.Equal(t, 30*time.Second, (&GameHookConfig{}).GetTimeout())
+	assert.Equal(t, time.Minute, (&GameHookConfig{Timeout: "1m"}).GetTimeout())
+}
+
+func TestGameUpgradeStep_Validate(t *testing.T) {
+	step := &GameUpgradeStep{Name: "record", Command: "/usr/lib/nethack/upgrade-record"}
+	game := &GameConfig{ID: "nethack", Setup: &GameSetupOptions{Upgrade: []*GameUpgradeStep{step}}}
+	assert.NoError(t, game.validateSetupOptions())
+
+	game.Setup.Upgrade = append(game.Setup.Upgrade, &GameUpgradeStep{Name: "record", Command: "/bin/true"})
+	assert.ErrorContains(t, game.validateSetupOptions(), "duplicate upgrade step")
+
+	game.Setup.Upgrade = []*GameUpgradeStep{{Name: "record", Command: "upgrade-record"}}
+	assert.Error(t, game.validateSetupOptions(), "steps run without the service's PATH")
+
+	game.Setup.Upgrade = []*GameUpgradeStep{{Command: "/bin/true"}}
+	assert.ErrorContains(t, game.validateSetupOptions(), "name is required")
+
+	assert.Equal(t, 5*time.Minute, step.GetTimeout())
+	assert.Equal(t, time.Minute, (&GameUpgradeStep{Timeout: "1m"}).GetTimeout())
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Games may be defined in the database as well as in the games list of the
+// game service configuration. Admins create and override them through the
+// game service API; a definition in the database takes the place of the
+// configured game with the same ID, and export writes the result back as
+// YAML to commit to the configuration.
+
+// ParseGameDefinition parses a game definition written as one entry of the
+// games list and validates it as loading the configuration does. source
+// names where the definition came from in errors.
+func ParseGameDefinition(data []byte, source string) (*GameConfig, error) {
+	var game GameConfig
+	if err := decodeStrict(data, source, &game); err != nil {
+		return nil, err
+	}
+	if err := game.Validate(); err != nil {
+		return nil, fmt.Errorf("game %s validation failed: %w", game.ID, err)
+	}
+	return &game, nil
+}
+
+// MarshalGameDefinition writes a game definition as YAML, leaving out the
+// settings it does not set
+func MarshalGameDefinition(game *GameConfig) ([]byte, error) {
+	return marshalPruned(game)
+}
+
+// ExportGames writes games as the games list of the game service
+// configuration
+func ExportGames(games []*GameConfig) ([]byte, error) {
+	if len(games) == 0 {
+		return []byte("games: []\n"), nil
+	}
+	return marshalPruned(struct {
+		Games []*GameConfig `yaml:"games"`
+	}{games})
+}
+
+// MergeGameDefinitions returns the configured games with the database
+// definitions in place of those with the same ID, followed by the
+// definitions of games the configuration does not have, by ID
+func MergeGameDefinitions(configured, definitions []*GameConfig) []*GameConfig {
+	byID := make(map[string]*GameConfig, len(definitions))
+	for _, game := range definitions {
+		byID[game.ID] = game
+	}
+
+	merged := make([]*GameConfig, 0, len(configured)+len(definitions))
+	for _, game := range configured {
+		if definition, ok := byID[game.ID]; ok {
+			merged = append(merged, definition)
+			delete(byID, game.ID)
+			continue
+		}
+		merged = append(merged, game)
+	}
+
+	added := make([]*GameConfig, 0, len(byID))
+	for _, game := range byID {
+		added = append(added, game)
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].ID < added[j].ID })
+	return append(merged, added...)
+}
+
+// marshalPruned writes v as YAML without the null values and empty lists
+// of unset settings
+func marshalPruned(v interface{}) ([]byte, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to encode games: %w", err)
+	}
+	pruneNulls(&node)
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode games: %w", err)
+	}
+	return data, nil
+}
+
+// pruneNulls removes the null values and empty lists and mappings from the
+// mappings under node
+func pruneNulls(node *yaml.Node) {
+	for _, child := range node.Content {
+		pruneNulls(child)
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+		if value.Tag == "!!null" || (value.Kind == yaml.SequenceNode || value.Kind == yaml.MappingNode) && len(value.Content) == 0 {
+			continue
+		}
+		content = append(content, node.Content[i], value)
+	}
+	node.Content = content
+}
@@ -0,0 +1,82 @@
+package config
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const crawlDefinition = `
+id: crawl
+name: Dungeon Crawl Stone Soup
+enabled: true
+binary:
+  path: /usr/games/crawl
+  args: ["-name", "${USERNAME}"]
+paths:
+  auto_detect: true
+environment:
+  TERM: xterm-256color
+resources:
+  memory_limit: 256Mi
+`
+
+func TestParseGameDefinition(t *testing.T) {
+	game, err := ParseGameDefinition([]byte(crawlDefinition), "crawl")
+	require.NoError(t, err)
+	assert.Equal(t, "crawl", game.ID)
+	assert.Equal(t, []string{"-name", "${USERNAME}"}, game.Binary.Args)
+
+	// Definitions are checked as strictly as the configuration file
+	_, err = ParseGameDefinition([]byte("id: crawl\nname: Crawl\nbinray:\n  path: /usr/games/crawl\n"), "crawl")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "binray")
+
+	_, err = ParseGameDefinition([]byte("id: crawl\nname: Crawl\npaths:\n  auto_detect: true\n"), "crawl")
+	assert.ErrorContains(t, err, "binary configuration is required")
+
+	_, err = ParseGameDefinition([]byte(crawlDefinition+"settings:\n  recording:\n    policy: sometimes\n"), "crawl")
+	assert.ErrorContains(t, err, "invalid recording policy")
+}
+
+func TestMarshalGameDefinition_RoundTrip(t *testing.T) {
+	game, err := ParseGameDefinition([]byte(crawlDefinition), "crawl")
+	require.NoError(t, err)
+
+	data, err := MarshalGameDefinition(game)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "null", "unset settings are left out")
+
+	again, err := ParseGameDefinition(data, "crawl")
+	require.NoError(t, err)
+	assert.Equal(t, game, again)
+}
+
+func TestMergeGameDefinitions(t *testing.T) {
+	configured := []*GameConfig{{ID: "nethack", Name: "NetHack"}, {ID: "angband", Name: "Angband"}}
+	definitions := []*GameConfig{{ID: "zork", Name: "Zork"}, {ID: "nethack", Name: "NetHack 3.7"}, {ID: "crawl", Name: "Crawl"}}
+
+	merged := MergeGameDefinitions(configured, definitions)
+	var names []string
+	for _, game := range merged {
+		names = append(names, game.Name)
+	}
+	assert.Equal(t, []string{"NetHack 3.7", "Angband", "Crawl", "Zork"}, names)
+}
+
+func TestExportGames(t *testing.T) {
+	game, err := ParseGameDefinition([]byte(crawlDefinition), "crawl")
+	require.NoError(t, err)
+
+	data, err := ExportGames([]*GameConfig{game})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "games:\n"))
+
+	// The export loads as the games of a configuration file
+	var cfg GameServiceConfig
+	require.NoError(t, decodeStrict(data, "export", &cfg))
+	require.Len(t, cfg.Games, 1)
+	assert.Equal(t, game, cfg.Games[0])
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect produced by JSONSchema
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema generates a JSON Schema for a config struct from its yaml tags.
+// Objects reject unknown properties, matching the strict config loader, so
+// editors using the schema flag the same typos the services refuse at startup.
+func JSONSchema(v interface{}, title string) ([]byte, error) {
+	schema := schemaForType(reflect.TypeOf(v), map[reflect.Type]bool{})
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = title
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// ServiceConfigSchemas returns the config types of each service keyed by the
+// schema file name under configs/schema
+func ServiceConfigSchemas() map[string]interface{} {
+	return map[string]interface{}{
+		"auth-service":    UserServiceConfig{},
+		"common":          CommonConfig{},
+		"dungeongate":     DungeonGateConfig{},
+		"game-service":    GameServiceConfig{},
+		"session-service": SessionServiceConfig{},
+	}
+}
+
+// schemaForType builds the schema for a Go type. Types already being expanded
+// higher up are left open to avoid infinite recursion.
+func schemaForType(t reflect.Type, visiting map[reflect.Type]bool) map[string]interface{} {
+	t = derefType(t)
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaForType(t.Elem(), visiting),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem(), visiting),
+		}
+	case reflect.Struct:
+		if visiting[t] {
+			return map[string]interface{}{"type": "object"}
+		}
+		visiting[t] = true
+		defer delete(visiting, t)
+
+		properties := make(map[string]interface{})
+		for name, field := range yamlFields(t) {
+			properties[name] = schemaForType(field.Type, visiting)
+		}
+		return map[string]interface{}{
+			"type":                 "object",
+			"properties":           properties,
+			"additionalProperties": false,
+		}
+	default:
+		// interface{} and anything else accepts any value
+		return map[string]interface{}{}
+	}
+}
@@ -3,14 +3,18 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
-	"gopkg.in/yaml.v3"
+	"golang.org/x/crypto/ssh"
 )
 
 // SessionServiceConfig represents session service configuration
 type SessionServiceConfig struct {
 	Version           string                   `yaml:"version"`
+	InheritFrom       string                   `yaml:"inherit_from,omitempty"`
 	Server            *ServerConfig            `yaml:"server"`
 	SSH               *SSHConfig               `yaml:"ssh"`
 	SessionManagement *SessionManagementConfig `yaml:"session_management"`
@@ -26,21 +30,70 @@ type SessionServiceConfig struct {
 	Auth              *AuthServiceConfig       `yaml:"auth"`
 	User              *UserConfig              `yaml:"user"`
 	Games             []*GameConfig            `yaml:"games"`
+	Realms            []*RealmConfig           `yaml:"realms"`
+	FeatureFlags      *FeatureFlagsConfig      `yaml:"feature_flags"`
+
+	// EnvExpansions lists the environment variables expanded while loading
+	EnvExpansions []EnvExpansion `yaml:"-"`
 }
 
 // SSHConfig represents SSH server configuration
 type SSHConfig struct {
-	Enabled        bool                `yaml:"enabled"`
-	Port           int                 `yaml:"port"`
-	Host           string              `yaml:"host"`
-	HostKeyPath    string              `yaml:"host_key_path"`
-	Banner         string              `yaml:"banner"`
-	MaxSessions    int                 `yaml:"max_sessions"`
-	SessionTimeout string              `yaml:"session_timeout"`
-	IdleTimeout    string              `yaml:"idle_timeout"`
-	Auth           *SSHAuthConfig      `yaml:"auth"`
-	Terminal       *SSHTerminalConfig  `yaml:"terminal"`
-	Keepalive      *SSHKeepaliveConfig `yaml:"keepalive"`
+	Enabled        bool                 `yaml:"enabled"`
+	Port           int                  `yaml:"port"`
+	Host           string               `yaml:"host"`
+	HostKeyPath    string               `yaml:"host_key_path"`
+	Banner         string               `yaml:"banner"`
+	MaxSessions    int                  `yaml:"max_sessions"`
+	SessionTimeout string               `yaml:"session_timeout"`
+	IdleTimeout    string               `yaml:"idle_timeout"` // No input for this long closes the connection; "0" disables
+	IdleWarning    string               `yaml:"idle_warning"` // How long before the idle disconnect players are warned
+	Auth           *SSHAuthConfig       `yaml:"auth"`
+	Terminal       *SSHTerminalConfig   `yaml:"terminal"`
+	Keepalive      *SSHKeepaliveConfig  `yaml:"keepalive"`
+	ProxyProtocol  *ProxyProtocolConfig `yaml:"proxy_protocol"`
+	Policy         *SSHPolicyConfig     `yaml:"policy"`
+	MOTD           *SSHMOTDConfig       `yaml:"motd"`
+}
+
+// SSHMOTDConfig configures the banner SSH clients are sent before they
+// authenticate, templated with live values such as the number of players.
+// It cannot be enabled together with policy.pre_auth_banner, and is separate
+// from the menus players see after logging in.
+type SSHMOTDConfig struct {
+	Enabled         bool   `yaml:"enabled"`
+	File            string `yaml:"file"`             // Banner template; default ./assets/banners/motd.txt, built in
+	ServerName      string `yaml:"server_name"`      // Replaces $SERVER_NAME; default "DungeonGate"
+	LegalNotice     string `yaml:"legal_notice"`     // Replaces $LEGAL_NOTICE, e.g. who may use the server
+	RefreshInterval string `yaml:"refresh_interval"` // How often the count of games in progress is refreshed; default 10s
+}
+
+// GetFile returns the banner template file
+func (c *SSHMOTDConfig) GetFile() string {
+	if c == nil || c.File == "" {
+		return "./assets/banners/motd.txt"
+	}
+	return c.File
+}
+
+// GetServerName returns the name the banner gives the server
+func (c *SSHMOTDConfig) GetServerName() string {
+	if c == nil || c.ServerName == "" {
+		return "DungeonGate"
+	}
+	return c.ServerName
+}
+
+// GetRefreshInterval returns how often the count of games in progress is
+// refreshed
+func (c *SSHMOTDConfig) GetRefreshInterval() time.Duration {
+	if c == nil {
+		return 10 * time.Second
+	}
+	if interval := ParseDuration(c.RefreshInterval, 0); interval > 0 {
+		return interval
+	}
+	return 10 * time.Second
 }
 
 // SSHAuthConfig represents SSH authentication configuration
@@ -54,9 +107,29 @@ type SSHAuthConfig struct {
 
 // SSHTerminalConfig represents SSH terminal configuration
 type SSHTerminalConfig struct {
-	DefaultSize        string   `yaml:"default_size"`
-	MaxSize            string   `yaml:"max_size"`
-	SupportedTerminals []string `yaml:"supported_terminals"`
+	DefaultSize        string             `yaml:"default_size"`
+	MaxSize            string             `yaml:"max_size"`
+	SupportedTerminals []string           `yaml:"supported_terminals"`
+	DefaultTerminal    string             `yaml:"default_terminal"` // Used when the client's TERM cannot be matched
+	InputFilter        *InputFilterConfig `yaml:"input_filter"`
+}
+
+// InputFilterConfig configures how player input is cleaned before it reaches
+// a game. Keys use caret notation, e.g. "^]" or "^?".
+type InputFilterConfig struct {
+	EscapeKey           string                        `yaml:"escape_key"`            // Opens the in-session menu; default "^]", "none" disables
+	RedrawKey           string                        `yaml:"redraw_key"`            // Sent to the game after the in-session menu; default "^L"
+	StripBracketedPaste bool                          `yaml:"strip_bracketed_paste"` // Remove bracketed paste markers
+	MaxPasteBytes       int                           `yaml:"max_paste_bytes"`       // Drop pasted text beyond this size; 0 is unlimited
+	StripOSC            bool                          `yaml:"strip_osc"`             // Remove OSC sequences (title, clipboard) from input and spectated output
+	Rewrite             []KeyRewriteConfig            `yaml:"rewrite"`               // Key sequences replaced before reaching the game, in order
+	Games               map[string]*InputFilterConfig `yaml:"games"`                 // Per-game settings, replacing these for that game
+}
+
+// KeyRewriteConfig replaces one key sequence in player input
+type KeyRewriteConfig struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
 }
 
 // SSHKeepaliveConfig represents SSH keepalive configuration
@@ -66,6 +139,143 @@ type SSHKeepaliveConfig struct {
 	CountMax int    `yaml:"count_max"`
 }
 
+// SSHPolicyConfig restricts the algorithms and authentication the SSH server
+// offers. Empty algorithm lists keep the library defaults, which leave out
+// algorithms with known weaknesses. Compression is not supported, as the SSH
+// library does not implement it.
+type SSHPolicyConfig struct {
+	Ciphers       []string `yaml:"ciphers"`         // Allowed ciphers, in preference order
+	MACs          []string `yaml:"macs"`            // Allowed MACs, in preference order
+	KeyExchanges  []string `yaml:"key_exchanges"`   // Allowed key exchange algorithms, in preference order
+	AllowInsecure bool     `yaml:"allow_insecure"`  // Accept algorithms with known weaknesses in the lists, for old clients
+	MaxAuthTries  int      `yaml:"max_auth_tries"`  // Authentication attempts per connection; default 6, -1 is unlimited
+	PreAuthBanner string   `yaml:"pre_auth_banner"` // Text sent to clients before they authenticate; empty sends none
+}
+
+// Validate checks that the policy names algorithms the SSH server implements
+func (c *SSHPolicyConfig) Validate() error {
+	supported := ssh.SupportedAlgorithms()
+	insecure := ssh.InsecureAlgorithms()
+	lists := []struct {
+		name                string
+		names               []string
+		supported, insecure []string
+	}{
+		{"cipher", c.Ciphers, supported.Ciphers, insecure.Ciphers},
+		{"MAC", c.MACs, supported.MACs, insecure.MACs},
+		{"key exchange", c.KeyExchanges, supported.KeyExchanges, insecure.KeyExchanges},
+	}
+	for _, list := range lists {
+		for _, name := range list.names {
+			switch {
+			case slices.Contains(list.supported, name):
+			case slices.Contains(list.insecure, name):
+				if !c.AllowInsecure {
+					return fmt.Errorf("SSH %s %q has known weaknesses; set allow_insecure to use it", list.name, name)
+				}
+			default:
+				return fmt.Errorf("unsupported SSH %s %q (supported: %s)", list.name, name, strings.Join(list.supported, ", "))
+			}
+		}
+	}
+	if c.MaxAuthTries < -1 {
+		return fmt.Errorf("max auth tries must be -1 (unlimited) or more")
+	}
+	return nil
+}
+
+// ProxyProtocolConfig reads the real client address from the PROXY protocol
+// header (v1 or v2) sent by a TCP load balancer in front of the SSH server
+type ProxyProtocolConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	TrustedProxies []string `yaml:"trusted_proxies"` // Load balancer IPs or CIDR ranges allowed to send the header
+	HeaderTimeout  string   `yaml:"header_timeout"`  // How long a proxy may take to send the header
+}
+
+// GetHeaderTimeoutDuration returns the header timeout as duration
+func (c *ProxyProtocolConfig) GetHeaderTimeoutDuration() time.Duration {
+	if duration, err := time.ParseDuration(c.HeaderTimeout); err == nil {
+		return duration
+	}
+	return 5 * time.Second // Default fallback
+}
+
+// RealmConfig is a community hosted on the same deployment with its own
+// accounts, games and banners. Connections join a realm by the SSH port they
+// reach, the host name a load balancer passes in its PROXY header, or a
+// login of the form user@realm; all others are in the default realm.
+type RealmConfig struct {
+	Name          string         `yaml:"name"`           // Lower case letters, digits and dashes; used in user@realm logins
+	Ports         []int          `yaml:"ports"`          // SSH ports leading to the realm, listened on besides ssh.port
+	Hostnames     []string       `yaml:"hostnames"`      // Host names from the PROXY header leading to the realm
+	Games         []string       `yaml:"games"`          // Game IDs offered in the realm; empty offers every game
+	StoragePrefix string         `yaml:"storage_prefix"` // Prefix of the realm's account names in the auth service; default "<name>_"
+	Banners       *BannersConfig `yaml:"banners"`        // Banners replacing the default ones in the realm
+}
+
+// realmNamePattern matches realm names, which must survive user@realm logins
+var realmNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// storagePrefixPattern matches storage prefixes, which become part of usernames
+var storagePrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_]{1,16}$`)
+
+// GetStoragePrefix returns the prefix of the realm's account names
+func (c *RealmConfig) GetStoragePrefix() string {
+	if c.StoragePrefix != "" {
+		return c.StoragePrefix
+	}
+	return strings.ReplaceAll(c.Name, "-", "_") + "_"
+}
+
+// ValidateRealms checks that every realm can be told apart: by name, port,
+// host name and the prefix of its accounts. sshPort is the port of the
+// default realm.
+func ValidateRealms(realms []*RealmConfig, sshPort int) error {
+	names := make(map[string]bool)
+	ports := map[int]string{sshPort: "the default realm"}
+	hostnames := make(map[string]string)
+	prefixes := make(map[string]string)
+	for _, realm := range realms {
+		if !realmNamePattern.MatchString(realm.Name) {
+			return fmt.Errorf("invalid realm name %q: use up to 32 lower case letters, digits and dashes", realm.Name)
+		}
+		if names[realm.Name] {
+			return fmt.Errorf("realm %q is defined twice", realm.Name)
+		}
+		names[realm.Name] = true
+
+		for _, port := range realm.Ports {
+			if port <= 0 || port > 65535 {
+				return fmt.Errorf("realm %q: invalid port %d", realm.Name, port)
+			}
+			if other, ok := ports[port]; ok {
+				return fmt.Errorf("realm %q: port %d is already used by %s", realm.Name, port, other)
+			}
+			ports[port] = fmt.Sprintf("realm %q", realm.Name)
+		}
+		for _, hostname := range realm.Hostnames {
+			hostname = strings.ToLower(hostname)
+			if other, ok := hostnames[hostname]; ok {
+				return fmt.Errorf("realm %q: host name %q is already used by realm %q", realm.Name, hostname, other)
+			}
+			hostnames[hostname] = realm.Name
+		}
+
+		prefix := realm.GetStoragePrefix()
+		if !storagePrefixPattern.MatchString(prefix) {
+			return fmt.Errorf("realm %q: invalid storage prefix %q: use up to 16 letters, digits and underscores", realm.Name, prefix)
+		}
+		// A prefix starting another would leave accounts in both realms
+		for other, otherRealm := range prefixes {
+			if strings.HasPrefix(strings.ToLower(prefix), strings.ToLower(other)) || strings.HasPrefix(strings.ToLower(other), strings.ToLower(prefix)) {
+				return fmt.Errorf("realm %q: storage prefix %q overlaps %q of realm %q", realm.Name, prefix, other, otherRealm)
+			}
+		}
+		prefixes[prefix] = realm.Name
+	}
+	return nil
+}
+
 // MenuConfig represents menu configuration
 type MenuConfig struct {
 	Banners *BannersConfig `yaml:"banners"`
@@ -79,6 +289,17 @@ type BannersConfig struct {
 	MainAdmin          string `yaml:"main_admin"`
 	WatchMenu          string `yaml:"watch_menu"`
 	ServiceUnavailable string `yaml:"service_unavailable"`
+	// ServerFull is shown to SSH connections turned away because the server
+	// already has server.max_connections connections
+	ServerFull string `yaml:"server_full"`
+	// Credits is the markdown shown by the credits screen, above the
+	// versions of the services. Admins can also set it at runtime as the
+	// banner "credits", which takes precedence.
+	Credits string `yaml:"credits"`
+	// GameNews maps game IDs to the news shown before the game starts, such
+	// as patch notes or tournament rules. Admins can also set it at runtime
+	// as the banner "news_<game>", which takes precedence.
+	GameNews map[string]string `yaml:"game_news"`
 }
 
 // MenuOptions represents menu options configuration
@@ -99,6 +320,9 @@ type ServicesConfig struct {
 	AuthService string `yaml:"auth_service"`
 	UserService string `yaml:"user_service"`
 	GameService string `yaml:"game_service"`
+	// ServiceToken is sent to the auth and game services, matching their
+	// server.auth_token
+	ServiceToken string `yaml:"service_token"`
 }
 
 // StorageConfig represents storage configuration
@@ -124,6 +348,17 @@ type SecurityConfig struct {
 	RateLimiting         *RateLimitingConfig    `yaml:"rate_limiting"`
 	BruteForceProtection *BruteForceConfig      `yaml:"brute_force_protection"`
 	SessionSecurity      *SessionSecurityConfig `yaml:"session_security"`
+	GeoIP                *GeoIPConfig           `yaml:"geoip"`
+}
+
+// GeoIPConfig looks up the country of each new connection in a MaxMind
+// database to label metrics and logs and to allow or deny countries
+type GeoIPConfig struct {
+	Enabled        bool     `yaml:"enabled"`
+	DatabasePath   string   `yaml:"database_path"`   // GeoLite2-Country or GeoIP2-Country .mmdb file
+	AllowCountries []string `yaml:"allow_countries"` // ISO codes; when set, only these countries may connect
+	DenyCountries  []string `yaml:"deny_countries"`  // ISO codes refused when no allow list is set
+	DenyUnknown    bool     `yaml:"deny_unknown"`    // Refuse addresses the database has no country for
 }
 
 // RateLimitingConfig represents rate limiting configuration
@@ -133,11 +368,40 @@ type RateLimitingConfig struct {
 	ConnectionWindow    string `yaml:"connection_window"`
 }
 
-// BruteForceConfig represents brute force protection configuration
+// BruteForceConfig represents brute force protection configuration. Addresses
+// with too many failed logins are blocked, for longer each time they return.
 type BruteForceConfig struct {
-	Enabled           bool   `yaml:"enabled"`
-	MaxFailedAttempts int    `yaml:"max_failed_attempts"`
-	LockoutDuration   string `yaml:"lockout_duration"`
+	Enabled            bool     `yaml:"enabled"`
+	MaxFailedAttempts  int      `yaml:"max_failed_attempts"`
+	LockoutDuration    string   `yaml:"lockout_duration"`     // First block; each further block doubles it
+	MaxLockoutDuration string   `yaml:"max_lockout_duration"` // Longest block, also how long repeat offences are remembered
+	FindWindow         string   `yaml:"find_window"`          // Failures older than this no longer count
+	Allowlist          []string `yaml:"allowlist"`            // IPs or CIDR ranges never blocked, such as monitoring probes
+	Challenge          bool     `yaml:"challenge"`            // Challenge blocked addresses and locked out accounts instead of refusing them
+}
+
+// GetLockoutDuration returns the first block duration
+func (c *BruteForceConfig) GetLockoutDuration() time.Duration {
+	if duration, err := time.ParseDuration(c.LockoutDuration); err == nil && duration > 0 {
+		return duration
+	}
+	return 15 * time.Minute // Default fallback
+}
+
+// GetMaxLockoutDuration returns the longest block duration
+func (c *BruteForceConfig) GetMaxLockoutDuration() time.Duration {
+	if duration, err := time.ParseDuration(c.MaxLockoutDuration); err == nil && duration > 0 {
+		return duration
+	}
+	return 24 * time.Hour // Default fallback
+}
+
+// GetFindWindow returns how long failures count towards a block
+func (c *BruteForceConfig) GetFindWindow() time.Duration {
+	if duration, err := time.ParseDuration(c.FindWindow); err == nil && duration > 0 {
+		return duration
+	}
+	return 10 * time.Minute // Default fallback
 }
 
 // SessionSecurityConfig represents session security configuration
@@ -170,12 +434,16 @@ func LoadSessionServiceConfig(configPath string) (*SessionServiceConfig, error)
 	}
 
 	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	expanded, expansions, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config: %w", err)
+	}
 
 	var config SessionServiceConfig
-	if err := yaml.Unmarshal([]byte(expanded), &config); err != nil {
+	if err := decodeStrict([]byte(expanded), configPath, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	config.EnvExpansions = expansions
 
 	// Apply defaults
 	applyDefaults(&config)
@@ -235,6 +503,9 @@ func applyDefaults(cfg *SessionServiceConfig) {
 	if cfg.SSH.IdleTimeout == "" {
 		cfg.SSH.IdleTimeout = "30m"
 	}
+	if cfg.SSH.IdleWarning == "" {
+		cfg.SSH.IdleWarning = "1m"
+	}
 	if cfg.SSH.Auth == nil {
 		cfg.SSH.Auth = &SSHAuthConfig{
 			PasswordAuth:    true,
@@ -330,6 +601,8 @@ func applyDefaults(cfg *SessionServiceConfig) {
 				MainAdmin:          "./assets/banners/main_admin.txt",
 				WatchMenu:          "./assets/banners/watch_menu.txt",
 				ServiceUnavailable: "./assets/banners/service_unavailable.txt",
+				ServerFull:         "./assets/banners/server_full.txt",
+				Credits:            "./assets/banners/credits.md",
 			},
 			Options: &MenuOptions{
 				Anonymous: []*MenuOption{
@@ -428,6 +701,7 @@ func (c *SessionServiceConfig) GetSSH() *SSHConfig {
 			MaxSessions:    100,
 			SessionTimeout: "4h",
 			IdleTimeout:    "30m",
+			IdleWarning:    "1m",
 			Auth: &SSHAuthConfig{
 				PasswordAuth:    true,
 				PublicKeyAuth:   false,
@@ -454,6 +728,8 @@ func (c *SessionServiceConfig) GetMenu() *MenuConfig {
 				MainAdmin:          "./assets/banners/main_admin.txt",
 				WatchMenu:          "./assets/banners/watch_menu.txt",
 				ServiceUnavailable: "./assets/banners/service_unavailable.txt",
+				ServerFull:         "./assets/banners/server_full.txt",
+				Credits:            "./assets/banners/credits.md",
 			},
 			Options: &MenuOptions{
 				Anonymous: []*MenuOption{
@@ -526,10 +802,42 @@ func (c *SSHConfig) Validate() error {
 	if _, err := time.ParseDuration(c.IdleTimeout); err != nil {
 		return fmt.Errorf("invalid idle timeout format: %w", err)
 	}
+	if c.IdleWarning != "" {
+		if _, err := time.ParseDuration(c.IdleWarning); err != nil {
+			return fmt.Errorf("invalid idle warning format: %w", err)
+		}
+	}
+	if c.Keepalive != nil && c.Keepalive.Enabled {
+		if _, err := time.ParseDuration(c.Keepalive.Interval); err != nil {
+			return fmt.Errorf("invalid keepalive interval format: %w", err)
+		}
+		if c.Keepalive.CountMax < 1 {
+			return fmt.Errorf("keepalive count_max must be at least 1")
+		}
+	}
+	if c.ProxyProtocol != nil && c.ProxyProtocol.Enabled && len(c.ProxyProtocol.TrustedProxies) == 0 {
+		return fmt.Errorf("proxy protocol requires at least one trusted proxy")
+	}
+	if c.Policy != nil {
+		if err := c.Policy.Validate(); err != nil {
+			return fmt.Errorf("invalid SSH policy: %w", err)
+		}
+	}
+	if c.MOTD != nil && c.MOTD.Enabled && c.Policy != nil && c.Policy.PreAuthBanner != "" {
+		return fmt.Errorf("ssh.motd and ssh.policy.pre_auth_banner cannot both be set")
+	}
 
 	return nil
 }
 
+// Validate validates the security configuration
+func (c *SecurityConfig) Validate() error {
+	if c.GeoIP != nil && c.GeoIP.Enabled && c.GeoIP.DatabasePath == "" {
+		return fmt.Errorf("GeoIP requires a database path")
+	}
+	return nil
+}
+
 // ValidateSessionServiceConfig validates the entire session service configuration
 func (c *SessionServiceConfig) Validate() error {
 	if c.Server == nil {
@@ -549,6 +857,19 @@ func (c *SessionServiceConfig) Validate() error {
 	if err := c.SSH.Validate(); err != nil {
 		return fmt.Errorf("SSH configuration validation failed: %w", err)
 	}
+	if c.Security != nil {
+		if err := c.Security.Validate(); err != nil {
+			return fmt.Errorf("security configuration validation failed: %w", err)
+		}
+	}
+	if err := ValidateRealms(c.Realms, c.SSH.Port); err != nil {
+		return fmt.Errorf("realm configuration validation failed: %w", err)
+	}
+	if c.FeatureFlags != nil {
+		if err := c.FeatureFlags.Validate(); err != nil {
+			return fmt.Errorf("feature_flags.%w", err)
+		}
+	}
 
 	// Validate ports don't conflict
 	if c.Server.Port == c.SSH.Port {
@@ -604,6 +925,22 @@ func (c *SSHConfig) GetIdleTimeoutDuration() time.Duration {
 	return 30 * time.Minute // Default fallback
 }
 
+// GetIdleWarningDuration returns how long before an idle disconnect players are warned
+func (c *SSHConfig) GetIdleWarningDuration() time.Duration {
+	if duration, err := time.ParseDuration(c.IdleWarning); err == nil {
+		return duration
+	}
+	return time.Minute // Default fallback
+}
+
+// GetIntervalDuration returns the keepalive interval as duration
+func (c *SSHKeepaliveConfig) GetIntervalDuration() time.Duration {
+	if duration, err := time.ParseDuration(c.Interval); err == nil {
+		return duration
+	}
+	return 30 * time.Second // Default fallback
+}
+
 // GetDefaultTerminalSize returns default terminal size as width and height
 func (c *SSHTerminalConfig) GetDefaultTerminalSize() (width, height int) {
 	width, height, err := ParseTerminalSize(c.DefaultSize)
@@ -651,6 +988,7 @@ func GetDefaultDevelopmentConfig() *SessionServiceConfig {
 			MaxSessions:    10,
 			SessionTimeout: "1h",
 			IdleTimeout:    "15m",
+			IdleWarning:    "1m",
 			Auth: &SSHAuthConfig{
 				PasswordAuth:    true,
 				PublicKeyAuth:   false,
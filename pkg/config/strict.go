@@ -0,0 +1,266 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigError describes a problem at a specific place in a config file
+type ConfigError struct {
+	File    string
+	Line    int
+	Column  int
+	Field   string // Dotted path to the field, e.g. "server.metrics"
+	Message string
+}
+
+// Error formats the error as file:line:column: field: message
+func (e *ConfigError) Error() string {
+	var b strings.Builder
+	if e.File != "" {
+		b.WriteString(e.File)
+		b.WriteString(":")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&b, "%d:", e.Line)
+		if e.Column > 0 {
+			fmt.Fprintf(&b, "%d:", e.Column)
+		}
+	}
+	if b.Len() > 0 {
+		b.WriteString(" ")
+	}
+	if e.Field != "" {
+		b.WriteString(e.Field)
+		b.WriteString(": ")
+	}
+	b.WriteString(e.Message)
+	return b.String()
+}
+
+// ConfigErrors collects every problem found while decoding a config file
+type ConfigErrors []*ConfigError
+
+// Error lists all problems, one per line
+func (e ConfigErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	lines := make([]string, len(e))
+	for i, err := range e {
+		lines[i] = "  " + err.Error()
+	}
+	return fmt.Sprintf("%d problems in config:\n%s", len(e), strings.Join(lines, "\n"))
+}
+
+var (
+	// yamlLinePattern matches the "line N: " prefix yaml.v3 puts on decode errors
+	yamlLinePattern = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	// yamlUnknownFieldPattern matches yaml.v3's KnownFields error
+	yamlUnknownFieldPattern = regexp.MustCompile(`^field (\S+) not found in type \S+$`)
+)
+
+// decodeStrict decodes YAML into out, rejecting fields that out does not
+// declare. Errors carry the file, line, column and field path of each problem,
+// with a suggestion when an unknown field looks like a typo of a known one.
+func decodeStrict(data []byte, file string, out interface{}) error {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return newConfigError(file, err.Error(), nil, nil)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	err := decoder.Decode(out)
+	if err == nil || errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return newConfigError(file, err.Error(), nil, nil)
+	}
+
+	errs := make(ConfigErrors, 0, len(typeErr.Errors))
+	for _, msg := range typeErr.Errors {
+		errs = append(errs, newConfigError(file, msg, &root, reflect.TypeOf(out)))
+	}
+	return errs
+}
+
+// newConfigError turns a yaml.v3 error message into a located ConfigError
+func newConfigError(file, msg string, root *yaml.Node, target reflect.Type) *ConfigError {
+	configErr := &ConfigError{File: file, Message: strings.TrimPrefix(msg, "yaml: ")}
+
+	match := yamlLinePattern.FindStringSubmatch(msg)
+	if match == nil {
+		return configErr
+	}
+	configErr.Line, _ = strconv.Atoi(match[1])
+	configErr.Message = match[2]
+
+	unknownField := ""
+	if m := yamlUnknownFieldPattern.FindStringSubmatch(match[2]); m != nil {
+		unknownField = m[1]
+		configErr.Message = fmt.Sprintf("unknown field %q", unknownField)
+	}
+
+	if root == nil {
+		return configErr
+	}
+	path, column, ok := findYAMLKey(root, configErr.Line, unknownField, nil)
+	if !ok {
+		return configErr
+	}
+	configErr.Field = strings.Join(path, ".")
+	configErr.Column = column
+
+	if unknownField != "" && target != nil {
+		if suggestion := suggestField(target, path[:len(path)-1], unknownField); suggestion != "" {
+			configErr.Message += fmt.Sprintf(" (did you mean %q?)", suggestion)
+		}
+	}
+	return configErr
+}
+
+// findYAMLKey finds the mapping key on the given line, or the key whose value
+// is on that line, and returns its path. When name is set the key must match it.
+func findYAMLKey(node *yaml.Node, line int, name string, path []string) ([]string, int, bool) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			if p, col, ok := findYAMLKey(child, line, name, path); ok {
+				return p, col, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := append(append([]string(nil), path...), key.Value)
+			if key.Line == line && (name == "" || key.Value == name) {
+				return keyPath, key.Column, true
+			}
+			if name == "" && value.Line == line && value.Kind == yaml.ScalarNode {
+				return keyPath, value.Column, true
+			}
+			if p, col, ok := findYAMLKey(value, line, name, keyPath); ok {
+				return p, col, true
+			}
+		}
+	case yaml.SequenceNode:
+		for i, child := range node.Content {
+			itemPath := append(append([]string(nil), path...), fmt.Sprintf("[%d]", i))
+			if name == "" && child.Line == line && child.Kind == yaml.ScalarNode {
+				return itemPath, child.Column, true
+			}
+			if p, col, ok := findYAMLKey(child, line, name, itemPath); ok {
+				return p, col, true
+			}
+		}
+	}
+	return nil, 0, false
+}
+
+// suggestField returns the field of the struct at path that is closest to name,
+// or "" when nothing is close enough to be a likely typo
+func suggestField(target reflect.Type, path []string, name string) string {
+	t := target
+	for _, segment := range path {
+		t = derefType(t)
+		switch t.Kind() {
+		case reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+			if !strings.HasPrefix(segment, "[") {
+				// Map values are keyed by the segment itself
+				continue
+			}
+		case reflect.Struct:
+			field, ok := yamlFields(t)[segment]
+			if !ok {
+				return ""
+			}
+			t = field.Type
+		default:
+			return ""
+		}
+	}
+
+	t = derefType(t)
+	if t.Kind() != reflect.Struct {
+		return ""
+	}
+
+	best, bestDistance := "", len(name)/2+1
+	for known := range yamlFields(t) {
+		if d := levenshtein(name, known); d < bestDistance {
+			best, bestDistance = known, d
+		}
+	}
+	return best
+}
+
+// yamlFields maps the YAML names of a struct's fields to the fields,
+// following inline structs
+func yamlFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, opts, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(opts, "inline") {
+			if inner := derefType(field.Type); inner.Kind() == reflect.Struct {
+				for k, v := range yamlFields(inner) {
+					fields[k] = v
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields[name] = field
+	}
+	return fields
+}
+
+// derefType strips pointer indirection from a type
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// levenshtein returns the edit distance between two strings
+func levenshtein(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateSchemas = flag.Bool("update", false, "regenerate configs/schema from the config types")
+
+func TestDecodeStrict_UnknownFieldLocation(t *testing.T) {
+	data := []byte(`version: "1"
+server:
+  port: 8080
+metrcs:
+  enabled: true
+games:
+  - id: nethack
+    binary:
+      pth: /usr/games/nethack
+`)
+
+	var cfg GameServiceConfig
+	err := decodeStrict(data, "game-service.yaml", &cfg)
+	require.Error(t, err)
+
+	var errs ConfigErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 2)
+
+	assert.Equal(t, "game-service.yaml", errs[0].File)
+	assert.Equal(t, 4, errs[0].Line)
+	assert.Equal(t, 1, errs[0].Column)
+	assert.Equal(t, "metrcs", errs[0].Field)
+	assert.Equal(t, `unknown field "metrcs" (did you mean "metrics"?)`, errs[0].Message)
+
+	assert.Equal(t, 9, errs[1].Line)
+	assert.Equal(t, "games.[0].binary.pth", errs[1].Field)
+	assert.Equal(t, `unknown field "pth" (did you mean "path"?)`, errs[1].Message)
+	assert.Contains(t, err.Error(), "game-service.yaml:4:1: metrcs: unknown field")
+}
+
+func TestDecodeStrict_TypeErrorLocation(t *testing.T) {
+	data := []byte(`server:
+  port: eighty
+`)
+
+	var cfg GameServiceConfig
+	err := decodeStrict(data, "game-service.yaml", &cfg)
+
+	var errs ConfigErrors
+	require.ErrorAs(t, err, &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, "server.port", errs[0].Field)
+}
+
+func TestDecodeStrict_EmptyDocument(t *testing.T) {
+	var cfg GameServiceConfig
+	assert.NoError(t, decodeStrict([]byte(""), "empty.yaml", &cfg))
+}
+
+func TestShippedConfigsLoad(t *testing.T) {
+	_, err := LoadGameServiceConfig("../../configs/game-service.yaml")
+	assert.NoError(t, err)
+	_, err = LoadSessionServiceConfig("../../configs/session-service.yaml")
+	assert.NoError(t, err)
+	_, err = LoadUserServiceConfig("../../configs/auth-service.yaml")
+	assert.NoError(t, err)
+	_, err = LoadCommonConfig("../../configs/common.yaml")
+	assert.NoError(t, err)
+}
+
+// TestServiceConfigSchemasUpToDate fails when a config type changes without
+// regenerating its schema. Run with -update to regenerate.
+func TestServiceConfigSchemasUpToDate(t *testing.T) {
+	for name, cfg := range ServiceConfigSchemas() {
+		generated, err := JSONSchema(cfg, "DungeonGate "+name+" configuration")
+		require.NoError(t, err)
+		generated = append(generated, '\n')
+
+		path := filepath.Join("..", "..", "configs", "schema", name+".schema.json")
+		if *updateSchemas {
+			require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+			require.NoError(t, os.WriteFile(path, generated, 0644))
+			continue
+		}
+
+		existing, err := os.ReadFile(path)
+		require.NoError(t, err, "missing schema, run: go test ./pkg/config -run Schema -update")
+		assert.Equal(t, string(existing), string(generated), "%s is stale, run: go test ./pkg/config -run Schema -update", path)
+	}
+}
@@ -6,8 +6,6 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"gopkg.in/yaml.v3"
 )
 
 // DatabaseMode represents the database operational mode
@@ -127,9 +125,15 @@ type UserServiceConfig struct {
 	Authentication *AuthConfig         `yaml:"auth"`
 	Validation     *ValidationConfig   `yaml:"validation"`
 	Security       *SecurityConfig     `yaml:"security"`
+	Encryption     *EncryptionConfig   `yaml:"encryption"`
+	Retention      *RetentionConfig    `yaml:"retention"`
 	Logging        *LoggingConfig      `yaml:"logging"`
 	Health         *HealthConfig       `yaml:"health"`
 	Metrics        *MetricsConfig      `yaml:"metrics"`
+	FeatureFlags   *FeatureFlagsConfig `yaml:"feature_flags"`
+
+	// EnvExpansions lists the environment variables expanded while loading
+	EnvExpansions []EnvExpansion `yaml:"-"`
 }
 
 // RegistrationConfig represents registration configuration
@@ -144,18 +148,258 @@ type RegistrationConfig struct {
 	Email             *EmailConfig       `yaml:"email"`
 	Captcha           *CaptchaConfig     `yaml:"captcha"`
 	Hooks             *RegistrationHooks `yaml:"hooks"`
+	Welcome           *WelcomeConfig     `yaml:"welcome"`
+}
+
+// WelcomeConfig configures the onboarding screens shown to players who
+// register over SSH. The terms screen follows RegistrationConfig.RequireTerms
+// and the email prompt RegistrationConfig.RequireEmail.
+type WelcomeConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	TermsFile      string `yaml:"terms_file"`      // Terms accepted before the account is created, unless the auth service has published terms
+	TermsVersion   string `yaml:"terms_version"`   // Recorded with each acceptance; change it when the terms change
+	AskPreferences bool   `yaml:"ask_preferences"` // Let new players pick their color mode and theme
+	ShowTips       bool   `yaml:"show_tips"`       // Show a quick-start page before the menu
+	TipsFile       string `yaml:"tips_file"`       // Replaces the built-in quick-start tips
 }
 
 // AuthConfig represents authentication configuration
 type AuthConfig struct {
 	PasswordExpiry        string               `yaml:"password_expiry"`
 	SessionTimeout        string               `yaml:"session_timeout"`
-	MaxConcurrentSessions int                  `yaml:"max_concurrent_sessions"`
+	MaxConcurrentSessions int                  `yaml:"max_concurrent_sessions"` // SSH logins per user; 0 means unlimited
 	RequirePasswordChange bool                 `yaml:"require_password_change"`
 	TwoFactorAuth         *TwoFactorConfig     `yaml:"two_factor_auth"`
 	LoginAttempts         *LoginAttemptsConfig `yaml:"login_attempts"`
 	RootAdminUser         *AdminUserConfig     `yaml:"root_admin_user"`
 	AdminUsers            []AdminUserConfig    `yaml:"admin_users"`
+
+	// MultiLoginPolicy decides what happens to logins beyond MaxConcurrentSessions
+	MultiLoginPolicy string `yaml:"multi_login_policy"`
+	// UserSessionLimits overrides the session limit and policy per username
+	UserSessionLimits map[string]*SessionLimitConfig `yaml:"user_session_limits"`
+
+	// PlayTime limits how long users may play each day and week
+	PlayTime *PlayTimeConfig `yaml:"play_time"`
+
+	// UsernameChange controls renaming accounts
+	UsernameChange *UsernameChangeConfig `yaml:"username_change"`
+}
+
+// Multi-login policies for logins beyond a user's concurrent session limit
+const (
+	// MultiLoginDenyLogin disconnects the extra login
+	MultiLoginDenyLogin = "deny_login"
+	// MultiLoginDenyGame lets the extra login use the menus and play games,
+	// but not the games the user is already playing from another login
+	MultiLoginDenyGame = "deny_game"
+	// MultiLoginSpectate attaches the extra login as a spectator of the user's
+	// running game, and otherwise behaves like MultiLoginDenyGame
+	MultiLoginSpectate = "spectate"
+)
+
+// SessionLimitConfig overrides the concurrent session limit for one user
+type SessionLimitConfig struct {
+	MaxConcurrentSessions int    `yaml:"max_concurrent_sessions"` // 0 inherits the global limit, negative means unlimited
+	MultiLoginPolicy      string `yaml:"multi_login_policy"`      // Empty inherits the global policy
+}
+
+// SessionLimitFor returns the concurrent session limit (0 for unlimited) and
+// multi-login policy that apply to a user
+func (c *AuthConfig) SessionLimitFor(username string) (int, string) {
+	limit, policy := c.MaxConcurrentSessions, c.MultiLoginPolicy
+	if override, ok := c.UserSessionLimits[username]; ok && override != nil {
+		if override.MaxConcurrentSessions != 0 {
+			limit = override.MaxConcurrentSessions
+		}
+		if override.MultiLoginPolicy != "" {
+			policy = override.MultiLoginPolicy
+		}
+	}
+
+	if limit < 0 {
+		limit = 0
+	}
+	if policy == "" {
+		policy = MultiLoginDenyLogin
+	}
+	return limit, policy
+}
+
+// defaultPlayTimeWarning is how long before their play time runs out
+// players are warned when PlayTimeConfig.WarnBefore is unset
+const defaultPlayTimeWarning = 10 * time.Minute
+
+// PlayTimeConfig limits how long users may play, as on a shared or school
+// server. Limits are durations such as "2h"; empty or "0" means no limit.
+// Days start at midnight and weeks on Monday, in the game service's time zone.
+type PlayTimeConfig struct {
+	Daily  string `yaml:"daily"`
+	Weekly string `yaml:"weekly"`
+	// WarnBefore is how long before the limit players are told in their game
+	WarnBefore string `yaml:"warn_before"`
+	// Roles overrides the limits for users with a role (admin, moderator) or
+	// in a group of that name. A user matching several gets the most generous.
+	Roles map[string]*PlayTimeLimitConfig `yaml:"roles"`
+	// Users overrides the limits per username, over any role
+	Users map[string]*PlayTimeLimitConfig `yaml:"users"`
+}
+
+// PlayTimeLimitConfig overrides play-time limits. An empty limit inherits;
+// "0" means unlimited.
+type PlayTimeLimitConfig struct {
+	Daily  string `yaml:"daily"`
+	Weekly string `yaml:"weekly"`
+}
+
+// PlayTimeLimitFor returns the daily and weekly play time allowed to a user
+// with the given roles and groups (0 for unlimited)
+func (c *AuthConfig) PlayTimeLimitFor(username string, roles []string) (daily, weekly time.Duration) {
+	if c.PlayTime == nil {
+		return 0, 0
+	}
+	cfg := c.PlayTime
+	daily, weekly = parsePlayTime(cfg.Daily), parsePlayTime(cfg.Weekly)
+
+	var roleDaily, roleWeekly *time.Duration
+	for _, role := range roles {
+		if override := cfg.Roles[role]; override != nil {
+			roleDaily = morePlayTime(roleDaily, override.Daily)
+			roleWeekly = morePlayTime(roleWeekly, override.Weekly)
+		}
+	}
+	if roleDaily != nil {
+		daily = *roleDaily
+	}
+	if roleWeekly != nil {
+		weekly = *roleWeekly
+	}
+
+	if override := cfg.Users[username]; override != nil {
+		if override.Daily != "" {
+			daily = parsePlayTime(override.Daily)
+		}
+		if override.Weekly != "" {
+			weekly = parsePlayTime(override.Weekly)
+		}
+	}
+	return daily, weekly
+}
+
+// PlayTimeWarning returns how long before their limit players are warned
+func (c *AuthConfig) PlayTimeWarning() time.Duration {
+	if c.PlayTime == nil || c.PlayTime.WarnBefore == "" {
+		return defaultPlayTimeWarning
+	}
+	return parsePlayTime(c.PlayTime.WarnBefore)
+}
+
+// parsePlayTime parses a play-time duration, already validated; empty is 0
+func parsePlayTime(value string) time.Duration {
+	d, _ := time.ParseDuration(value)
+	return d
+}
+
+// morePlayTime returns the more generous of current and value, where 0 is
+// unlimited and an empty value leaves current as it is
+func morePlayTime(current *time.Duration, value string) *time.Duration {
+	if value == "" {
+		return current
+	}
+	d := parsePlayTime(value)
+	if current == nil || (*current != 0 && (d == 0 || d > *current)) {
+		return &d
+	}
+	return current
+}
+
+// validatePlayTime checks the durations of a play-time configuration
+func validatePlayTime(cfg *PlayTimeConfig) error {
+	durations := map[string]string{
+		"daily":       cfg.Daily,
+		"weekly":      cfg.Weekly,
+		"warn_before": cfg.WarnBefore,
+	}
+	for section, limits := range map[string]map[string]*PlayTimeLimitConfig{"roles": cfg.Roles, "users": cfg.Users} {
+		for name, limit := range limits {
+			if limit != nil {
+				durations[section+"."+name+".daily"] = limit.Daily
+				durations[section+"."+name+".weekly"] = limit.Weekly
+			}
+		}
+	}
+
+	for name, value := range durations {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d < 0 {
+			return fmt.Errorf("%s: %q is not a duration such as 2h or 90m", name, value)
+		}
+	}
+	return nil
+}
+
+// Username change defaults, used when UsernameChangeConfig leaves them unset
+const (
+	defaultUsernameReuseCooldown = 30 * 24 * time.Hour
+	defaultUsernameMinInterval   = 30 * 24 * time.Hour
+)
+
+// UsernameChangeConfig controls renaming accounts. Admins can always rename
+// users; players can rename themselves only with SelfService.
+type UsernameChangeConfig struct {
+	SelfService bool `yaml:"self_service"`
+	// ReuseCooldown is how long an abandoned name stays reserved for the user
+	// who gave it up, such as "720h"; "0" frees it at once
+	ReuseCooldown string `yaml:"reuse_cooldown"`
+	// MinInterval is how long players must wait between their own renames
+	MinInterval string `yaml:"min_interval"`
+}
+
+// UsernameSelfService reports whether players may rename themselves
+func (c *AuthConfig) UsernameSelfService() bool {
+	return c.UsernameChange != nil && c.UsernameChange.SelfService
+}
+
+// UsernameReuseCooldown returns how long an abandoned username stays reserved
+func (c *AuthConfig) UsernameReuseCooldown() time.Duration {
+	if c.UsernameChange == nil || c.UsernameChange.ReuseCooldown == "" {
+		return defaultUsernameReuseCooldown
+	}
+	return parsePlayTime(c.UsernameChange.ReuseCooldown)
+}
+
+// UsernameMinInterval returns how long players must wait between renames
+func (c *AuthConfig) UsernameMinInterval() time.Duration {
+	if c.UsernameChange == nil || c.UsernameChange.MinInterval == "" {
+		return defaultUsernameMinInterval
+	}
+	return parsePlayTime(c.UsernameChange.MinInterval)
+}
+
+// validateUsernameChange checks the durations of a username change configuration
+func validateUsernameChange(cfg *UsernameChangeConfig) error {
+	for name, value := range map[string]string{"reuse_cooldown": cfg.ReuseCooldown, "min_interval": cfg.MinInterval} {
+		if value == "" {
+			continue
+		}
+		if d, err := time.ParseDuration(value); err != nil || d < 0 {
+			return fmt.Errorf("%s: %q is not a duration such as 720h", name, value)
+		}
+	}
+	return nil
+}
+
+// validateMultiLoginPolicy checks a multi-login policy name; empty is allowed
+func validateMultiLoginPolicy(policy string) error {
+	switch policy {
+	case "", MultiLoginDenyLogin, MultiLoginDenyGame, MultiLoginSpectate:
+		return nil
+	default:
+		return fmt.Errorf("unknown multi-login policy %q (expected %s, %s or %s)",
+			policy, MultiLoginDenyLogin, MultiLoginDenyGame, MultiLoginSpectate)
+	}
 }
 
 // AdminUserConfig represents configuration for creating admin users
@@ -730,12 +974,16 @@ func LoadUserServiceConfig(configPath string) (*UserServiceConfig, error) {
 	}
 
 	// Expand environment variables
-	expanded := os.ExpandEnv(string(data))
+	expanded, expansions, err := expandEnv(string(data), configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand config: %w", err)
+	}
 
 	var config UserServiceConfig
-	if err := yaml.Unmarshal([]byte(expanded), &config); err != nil {
+	if err := decodeStrict([]byte(expanded), configPath, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config: %w", err)
 	}
+	config.EnvExpansions = expansions
 
 	// Load and merge common configuration if specified
 	if config.InheritFrom != "" {
@@ -747,6 +995,7 @@ func LoadUserServiceConfig(configPath string) (*UserServiceConfig, error) {
 				return nil, fmt.Errorf("failed to load common config: %w", err)
 			}
 			MergeWithCommonUser(&config, commonConfig)
+			config.EnvExpansions = append(config.EnvExpansions, commonConfig.EnvExpansions...)
 		}
 	}
 
@@ -773,6 +1022,36 @@ func (c *UserServiceConfig) Validate() error {
 		return fmt.Errorf("database configuration validation failed: %w", err)
 	}
 
+	if c.Authentication != nil {
+		if err := validateMultiLoginPolicy(c.Authentication.MultiLoginPolicy); err != nil {
+			return fmt.Errorf("auth.multi_login_policy: %w", err)
+		}
+		for username, limit := range c.Authentication.UserSessionLimits {
+			if limit == nil {
+				continue
+			}
+			if err := validateMultiLoginPolicy(limit.MultiLoginPolicy); err != nil {
+				return fmt.Errorf("auth.user_session_limits.%s: %w", username, err)
+			}
+		}
+		if c.Authentication.PlayTime != nil {
+			if err := validatePlayTime(c.Authentication.PlayTime); err != nil {
+				return fmt.Errorf("auth.play_time.%w", err)
+			}
+		}
+		if c.Authentication.UsernameChange != nil {
+			if err := validateUsernameChange(c.Authentication.UsernameChange); err != nil {
+				return fmt.Errorf("auth.username_change.%w", err)
+			}
+		}
+	}
+
+	if c.FeatureFlags != nil {
+		if err := c.FeatureFlags.Validate(); err != nil {
+			return fmt.Errorf("feature_flags.%w", err)
+		}
+	}
+
 	// Validate other sections...
 	return nil
 }
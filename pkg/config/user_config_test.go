@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthConfig_SessionLimitFor(t *testing.T) {
+	cfg := &AuthConfig{
+		MaxConcurrentSessions: 1,
+		MultiLoginPolicy:      MultiLoginSpectate,
+		UserSessionLimits: map[string]*SessionLimitConfig{
+			"streamer": {MaxConcurrentSessions: 3},
+			"admin":    {MaxConcurrentSessions: -1, MultiLoginPolicy: MultiLoginDenyGame},
+		},
+	}
+
+	limit, policy := cfg.SessionLimitFor("player")
+	assert.Equal(t, 1, limit)
+	assert.Equal(t, MultiLoginSpectate, policy)
+
+	limit, policy = cfg.SessionLimitFor("streamer")
+	assert.Equal(t, 3, limit)
+	assert.Equal(t, MultiLoginSpectate, policy)
+
+	limit, policy = cfg.SessionLimitFor("admin")
+	assert.Equal(t, 0, limit)
+	assert.Equal(t, MultiLoginDenyGame, policy)
+
+	_, policy = (&AuthConfig{}).SessionLimitFor("player")
+	assert.Equal(t, MultiLoginDenyLogin, policy)
+}
+
+func TestValidateMultiLoginPolicy(t *testing.T) {
+	assert.NoError(t, validateMultiLoginPolicy(""))
+	assert.NoError(t, validateMultiLoginPolicy(MultiLoginDenyGame))
+	assert.Error(t, validateMultiLoginPolicy("kick_oldest"))
+}
+
+func TestAuthConfig_PlayTimeLimitFor(t *testing.T) {
+	cfg := &AuthConfig{
+		PlayTime: &PlayTimeConfig{
+			Daily:  "2h",
+			Weekly: "10h",
+			Roles: map[string]*PlayTimeLimitConfig{
+				"class-3b":   {Daily: "1h"},
+				"chess-club": {Daily: "90m"},
+				"moderator":  {Daily: "0", Weekly: "0"},
+			},
+			Users: map[string]*PlayTimeLimitConfig{
+				"teacher": {Daily: "0"},
+			},
+		},
+	}
+
+	daily, weekly := cfg.PlayTimeLimitFor("player", nil)
+	assert.Equal(t, 2*time.Hour, daily)
+	assert.Equal(t, 10*time.Hour, weekly)
+
+	// The most generous role wins, and unlimited beats any limit
+	daily, weekly = cfg.PlayTimeLimitFor("pupil", []string{"class-3b", "chess-club"})
+	assert.Equal(t, 90*time.Minute, daily)
+	assert.Equal(t, 10*time.Hour, weekly)
+	daily, weekly = cfg.PlayTimeLimitFor("pupil", []string{"class-3b", "moderator"})
+	assert.Zero(t, daily)
+	assert.Zero(t, weekly)
+
+	// Users override their roles
+	daily, weekly = cfg.PlayTimeLimitFor("teacher", []string{"class-3b"})
+	assert.Zero(t, daily)
+	assert.Equal(t, 10*time.Hour, weekly)
+
+	assert.Equal(t, 10*time.Minute, cfg.PlayTimeWarning())
+	daily, _ = (&AuthConfig{}).PlayTimeLimitFor("player", nil)
+	assert.Zero(t, daily)
+}
+
+func TestValidatePlayTime(t *testing.T) {
+	assert.NoError(t, validatePlayTime(&PlayTimeConfig{Daily: "2h", WarnBefore: "5m"}))
+	assert.ErrorContains(t, validatePlayTime(&PlayTimeConfig{Weekly: "ten hours"}), "weekly")
+	assert.ErrorContains(t, validatePlayTime(&PlayTimeConfig{Users: map[string]*PlayTimeLimitConfig{"kid": {Daily: "-1h"}}}), "users.kid.daily")
+}
@@ -387,8 +387,12 @@ func (c *Connection) Query(query string, args ...interface{}) (*sql.Rows, error)
 	return c.QueryContext(context.Background(), query, args...)
 }
 
-// QueryContext executes a query with context and returns rows
+// QueryContext executes a query with context and returns rows, in the
+// transaction ctx carries if any
 func (c *Connection) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx := c.activeTx(ctx); tx != nil {
+		return tx.tx.QueryContext(ctx, query, args...)
+	}
 	db := c.DB(c.detectQueryType(query))
 	return db.QueryContext(ctx, query, args...)
 }
@@ -398,8 +402,12 @@ func (c *Connection) QueryRow(query string, args ...interface{}) *sql.Row {
 	return c.QueryRowContext(context.Background(), query, args...)
 }
 
-// QueryRowContext executes a query with context and returns a single row
+// QueryRowContext executes a query with context and returns a single row,
+// in the transaction ctx carries if any
 func (c *Connection) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if tx := c.activeTx(ctx); tx != nil {
+		return tx.tx.QueryRowContext(ctx, query, args...)
+	}
 	db := c.DB(c.detectQueryType(query))
 	return db.QueryRowContext(ctx, query, args...)
 }
@@ -409,8 +417,12 @@ func (c *Connection) Exec(query string, args ...interface{}) (sql.Result, error)
 	return c.ExecContext(context.Background(), query, args...)
 }
 
-// ExecContext executes a query with context without returning rows
+// ExecContext executes a query with context without returning rows, in the
+// transaction ctx carries if any
 func (c *Connection) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if tx := c.activeTx(ctx); tx != nil {
+		return tx.tx.ExecContext(ctx, query, args...)
+	}
 	db := c.DB(QueryTypeWrite) // All exec operations go to writer
 	return db.ExecContext(ctx, query, args...)
 }
@@ -420,8 +432,12 @@ func (c *Connection) Prepare(query string) (*sql.Stmt, error) {
 	return c.PrepareContext(context.Background(), query)
 }
 
-// PrepareContext prepares a statement with context
+// PrepareContext prepares a statement with context, in the transaction ctx
+// carries if any
 func (c *Connection) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	if tx := c.activeTx(ctx); tx != nil {
+		return tx.tx.PrepareContext(ctx, query)
+	}
 	queryType := c.detectQueryType(query)
 	db := c.DB(queryType)
 	return db.PrepareContext(ctx, query)
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Tx is a transaction on the writer database, or a savepoint within one
+// when begun inside another transaction. Queries made through the
+// Connection with the context Begin returned run in it.
+type Tx struct {
+	conn      *Connection
+	tx        *sql.Tx
+	parent    *Tx
+	savepoint string
+
+	mu   sync.Mutex
+	done bool
+	// nested counts the savepoints begun inside the transaction, naming them
+	nested int
+}
+
+// txKey is the context key of the transaction in progress
+type txKey struct{}
+
+// TxFromContext returns the transaction ctx carries, or nil
+func TxFromContext(ctx context.Context) *Tx {
+	tx, _ := ctx.Value(txKey{}).(*Tx)
+	return tx
+}
+
+// Begin starts a transaction and returns a context carrying it. Begun with a
+// context already carrying one of this connection's transactions, it sets a
+// savepoint instead, so rolling back undoes only the nested work.
+func (c *Connection) Begin(ctx context.Context) (context.Context, *Tx, error) {
+	if parent := c.activeTx(ctx); parent != nil {
+		return parent.beginSavepoint(ctx)
+	}
+
+	sqlTx, err := c.writer.BeginTx(ctx, nil)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	tx := &Tx{conn: c, tx: sqlTx}
+	return context.WithValue(ctx, txKey{}, tx), tx, nil
+}
+
+// WithTransaction runs fn in a transaction, or a savepoint when ctx already
+// carries one, committing when fn returns nil and rolling back otherwise
+func (c *Connection) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	txCtx, tx, err := c.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := fn(txCtx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// activeTx returns this connection's unfinished transaction carried by ctx
+func (c *Connection) activeTx(ctx context.Context) *Tx {
+	tx := TxFromContext(ctx)
+	if tx == nil || tx.conn != c || tx.isDone() {
+		return nil
+	}
+	return tx
+}
+
+// beginSavepoint sets a savepoint within the transaction
+func (t *Tx) beginSavepoint(ctx context.Context) (context.Context, *Tx, error) {
+	root := t.root()
+	root.mu.Lock()
+	root.nested++
+	name := fmt.Sprintf("sp_%d", root.nested)
+	root.mu.Unlock()
+
+	if _, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return ctx, nil, fmt.Errorf("failed to set savepoint: %w", err)
+	}
+	nested := &Tx{conn: t.conn, tx: t.tx, parent: t, savepoint: name}
+	return context.WithValue(ctx, txKey{}, nested), nested, nil
+}
+
+// Commit commits the transaction, or releases the savepoint so its work
+// becomes part of the enclosing transaction
+func (t *Tx) Commit() error {
+	if !t.finish() {
+		return sql.ErrTxDone
+	}
+	if t.savepoint == "" {
+		if err := t.tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+	if _, err := t.tx.Exec("RELEASE SAVEPOINT " + t.savepoint); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// Rollback undoes the transaction, or the work done since the savepoint.
+// Rolling back a finished transaction does nothing, so it may be deferred.
+func (t *Tx) Rollback() error {
+	if !t.finish() {
+		return nil
+	}
+	if t.savepoint == "" {
+		if err := t.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+			return fmt.Errorf("failed to roll back transaction: %w", err)
+		}
+		return nil
+	}
+	if _, err := t.tx.Exec("ROLLBACK TO SAVEPOINT " + t.savepoint); err != nil {
+		return fmt.Errorf("failed to roll back to savepoint: %w", err)
+	}
+	if _, err := t.tx.Exec("RELEASE SAVEPOINT " + t.savepoint); err != nil {
+		return fmt.Errorf("failed to release savepoint: %w", err)
+	}
+	return nil
+}
+
+// finish marks the transaction done, reporting whether it was still open.
+// A transaction is also done once the one enclosing it is.
+func (t *Tx) finish() bool {
+	if t.parent != nil && t.parent.isDone() {
+		return false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return false
+	}
+	t.done = true
+	return true
+}
+
+// isDone reports whether the transaction, or one enclosing it, has finished
+func (t *Tx) isDone() bool {
+	for tx := t; tx != nil; tx = tx.parent {
+		tx.mu.Lock()
+		done := tx.done
+		tx.mu.Unlock()
+		if done {
+			return true
+		}
+	}
+	return false
+}
+
+// root returns the outermost transaction
+func (t *Tx) root() *Tx {
+	for t.parent != nil {
+		t = t.parent
+	}
+	return t
+}
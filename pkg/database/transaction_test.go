@@ -0,0 +1,133 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func setupTransactionTestDB(t *testing.T) *Connection {
+	db, err := NewConnection(&config.DatabaseConfig{
+		Mode: config.DatabaseModeEmbedded,
+		Type: "sqlite",
+		Embedded: &config.EmbeddedDBConfig{
+			Type: "sqlite",
+			Path: filepath.Join(t.TempDir(), "test.db"),
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE items (name TEXT PRIMARY KEY)`)
+	require.NoError(t, err)
+	return db
+}
+
+func itemNames(t *testing.T, db *Connection) []string {
+	rows, err := db.QueryContext(context.Background(), `SELECT name FROM items ORDER BY name`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		require.NoError(t, rows.Scan(&name))
+		names = append(names, name)
+	}
+	require.NoError(t, rows.Err())
+	return names
+}
+
+func TestTransaction_CommitAndRollback(t *testing.T) {
+	db := setupTransactionTestDB(t)
+	ctx := context.Background()
+
+	txCtx, tx, err := db.Begin(ctx)
+	require.NoError(t, err)
+	assert.Same(t, tx, TxFromContext(txCtx))
+	assert.Nil(t, TxFromContext(ctx))
+
+	_, err = db.ExecContext(txCtx, `INSERT INTO items (name) VALUES (?)`, "kept")
+	require.NoError(t, err)
+	// Queries with the transaction's context see its changes
+	var count int
+	require.NoError(t, db.QueryRowContext(txCtx, `SELECT COUNT(*) FROM items`).Scan(&count))
+	assert.Equal(t, 1, count)
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, tx.Rollback(), "rolling back a committed transaction does nothing")
+	assert.Error(t, tx.Commit())
+
+	txCtx, tx, err = db.Begin(ctx)
+	require.NoError(t, err)
+	_, err = db.ExecContext(txCtx, `INSERT INTO items (name) VALUES (?)`, "dropped")
+	require.NoError(t, err)
+	require.NoError(t, tx.Rollback())
+
+	assert.Equal(t, []string{"kept"}, itemNames(t, db))
+
+	// A finished transaction's context no longer routes queries into it
+	_, err = db.ExecContext(txCtx, `INSERT INTO items (name) VALUES (?)`, "after")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"after", "kept"}, itemNames(t, db))
+}
+
+func TestTransaction_Savepoints(t *testing.T) {
+	db := setupTransactionTestDB(t)
+
+	outerCtx, outer, err := db.Begin(context.Background())
+	require.NoError(t, err)
+	_, err = db.ExecContext(outerCtx, `INSERT INTO items (name) VALUES (?)`, "outer")
+	require.NoError(t, err)
+
+	// A nested transaction rolled back undoes only its own work
+	innerCtx, inner, err := db.Begin(outerCtx)
+	require.NoError(t, err)
+	assert.NotSame(t, outer, inner)
+	_, err = db.ExecContext(innerCtx, `INSERT INTO items (name) VALUES (?)`, "rolled-back")
+	require.NoError(t, err)
+	require.NoError(t, inner.Rollback())
+
+	// A nested transaction committed joins the enclosing one
+	innerCtx, inner, err = db.Begin(outerCtx)
+	require.NoError(t, err)
+	_, err = db.ExecContext(innerCtx, `INSERT INTO items (name) VALUES (?)`, "released")
+	require.NoError(t, err)
+	require.NoError(t, inner.Commit())
+
+	require.NoError(t, outer.Commit())
+	assert.Equal(t, []string{"outer", "released"}, itemNames(t, db))
+}
+
+func TestTransaction_RollbackUndoesCommittedSavepoints(t *testing.T) {
+	db := setupTransactionTestDB(t)
+	ctx := context.Background()
+
+	err := db.WithTransaction(ctx, func(ctx context.Context) error {
+		if _, err := db.ExecContext(ctx, `INSERT INTO items (name) VALUES (?)`, "outer"); err != nil {
+			return err
+		}
+		err := db.WithTransaction(ctx, func(ctx context.Context) error {
+			_, err := db.ExecContext(ctx, `INSERT INTO items (name) VALUES (?)`, "inner")
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		return errors.New("changed my mind")
+	})
+	assert.EqualError(t, err, "changed my mind")
+	assert.Empty(t, itemNames(t, db))
+
+	err = db.WithTransaction(ctx, func(ctx context.Context) error {
+		_, err := db.ExecContext(ctx, `INSERT INTO items (name) VALUES (?)`, "committed")
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"committed"}, itemNames(t, db))
+}
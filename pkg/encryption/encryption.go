@@ -1,35 +1,204 @@
+// Package encryption encrypts personal data before it is stored. Values are
+// encrypted with AES-256-GCM under a named key, which is recorded with them
+// so keys can be rotated: new data is encrypted with the primary key, and
+// the older keys still decrypt what was encrypted with them until it is
+// encrypted again. Blind indexes, keyed hashes of values, let encrypted
+// data be looked up without decrypting it.
 package encryption
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"strings"
+
 	"github.com/dungeongate/pkg/config"
 )
 
+const (
+	// ciphertextPrefix marks encrypted values, followed by the key's ID,
+	// a colon and the nonce and sealed value in base64. Values without it
+	// are plaintext stored before encryption was enabled.
+	ciphertextPrefix = "enc:v1:"
+	// keySize is the size of AES-256 keys
+	keySize = 32
+)
+
+// ErrUnknownKey is returned when decrypting a value encrypted with a key
+// that is not configured
+var ErrUnknownKey = errors.New("value was encrypted with an unknown key")
+
 // Encryptor handles encryption operations
 type Encryptor struct {
 	config *config.EncryptionConfig
-	// Add actual encryption fields here
+	// keys are the configured keys by ID; none when encryption is off
+	keys     map[string]cipher.AEAD
+	primary  string
+	indexKey []byte
 }
 
-// New creates a new encryptor
+// New creates a new encryptor. Without keys configured it leaves data as it
+// is, as when encryption is disabled.
 func New(cfg *config.EncryptionConfig) (*Encryptor, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("encryption configuration is required")
 	}
 
-	return &Encryptor{
-		config: cfg,
-	}, nil
+	e := &Encryptor{config: cfg}
+	if !cfg.Enabled || len(cfg.Keys) == 0 {
+		return e, nil
+	}
+	if cfg.Algorithm != "" && !strings.EqualFold(cfg.Algorithm, "AES-256-GCM") {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", cfg.Algorithm)
+	}
+
+	e.keys = make(map[string]cipher.AEAD, len(cfg.Keys))
+	for _, key := range cfg.Keys {
+		if key.ID == "" || strings.Contains(key.ID, ":") {
+			return nil, fmt.Errorf("encryption key IDs must be set and may not contain ':'")
+		}
+		if _, ok := e.keys[key.ID]; ok {
+			return nil, fmt.Errorf("encryption key %q is configured twice", key.ID)
+		}
+		raw, err := decodeKey(key.Key)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", key.ID, err)
+		}
+		block, err := aes.NewCipher(raw)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", key.ID, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("encryption key %q: %w", key.ID, err)
+		}
+		e.keys[key.ID] = aead
+	}
+
+	e.primary = cfg.Keys[len(cfg.Keys)-1].ID
+	if cfg.PrimaryKey != "" {
+		if _, ok := e.keys[cfg.PrimaryKey]; !ok {
+			return nil, fmt.Errorf("primary encryption key %q is not configured", cfg.PrimaryKey)
+		}
+		e.primary = cfg.PrimaryKey
+	}
+
+	indexKey, err := decodeKey(cfg.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("encryption index key: %w", err)
+	}
+	e.indexKey = indexKey
+	return e, nil
+}
+
+// decodeKey decodes a base64 key of keySize bytes
+func decodeKey(encoded string) ([]byte, error) {
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64: %w", err)
+	}
+	if len(raw) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes, not %d", keySize, len(raw))
+	}
+	return raw, nil
+}
+
+// Enabled reports whether data is encrypted
+func (e *Encryptor) Enabled() bool {
+	return len(e.keys) > 0
 }
 
 // Encrypt encrypts data
 func (e *Encryptor) Encrypt(data []byte) ([]byte, error) {
-	// Implementation would encrypt data
-	return data, nil
+	if !e.Enabled() {
+		return data, nil
+	}
+
+	aead := e.keys[e.primary]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, data, []byte(e.primary))
+	return []byte(ciphertextPrefix + e.primary + ":" + base64.StdEncoding.EncodeToString(sealed)), nil
 }
 
-// Decrypt decrypts data
+// Decrypt decrypts data. Data that was stored unencrypted is returned as it is.
 func (e *Encryptor) Decrypt(data []byte) ([]byte, error) {
-	// Implementation would decrypt data
-	return data, nil
+	rest, ok := strings.CutPrefix(string(data), ciphertextPrefix)
+	if !ok {
+		return data, nil
+	}
+	keyID, encoded, ok := strings.Cut(rest, ":")
+	if !ok {
+		return nil, errors.New("malformed encrypted value")
+	}
+	aead, ok := e.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownKey, keyID)
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return nil, errors.New("malformed encrypted value")
+	}
+	nonce, sealed := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return plain, nil
+}
+
+// EncryptString encrypts a value for a text column. Empty values stay empty.
+func (e *Encryptor) EncryptString(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+	encrypted, err := e.Encrypt([]byte(value))
+	return string(encrypted), err
+}
+
+// DecryptString decrypts a value read from a text column
+func (e *Encryptor) DecryptString(value string) (string, error) {
+	plain, err := e.Decrypt([]byte(value))
+	return string(plain), err
+}
+
+// IsEncrypted reports whether a stored value is encrypted
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, ciphertextPrefix)
+}
+
+// NeedsEncrypting reports whether a stored value should be encrypted again:
+// it is stored unencrypted, or under a key that is no longer primary
+func (e *Encryptor) NeedsEncrypting(value string) bool {
+	if !e.Enabled() || value == "" {
+		return false
+	}
+	rest, ok := strings.CutPrefix(value, ciphertextPrefix)
+	if !ok {
+		return true
+	}
+	keyID, _, _ := strings.Cut(rest, ":")
+	return keyID != e.primary
+}
+
+// BlindIndex returns the keyed hash a value is looked up by, the same for
+// values that differ only in case or surrounding space. It is empty for
+// empty values, and when encryption is off.
+func (e *Encryptor) BlindIndex(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if !e.Enabled() || value == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, e.indexKey)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
 }
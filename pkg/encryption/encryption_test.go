@@ -0,0 +1,112 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func testKey(fill byte) string {
+	return base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{fill}, keySize))
+}
+
+func TestEncryptor_Disabled(t *testing.T) {
+	e, err := New(&config.EncryptionConfig{Enabled: true, Algorithm: "AES-256-GCM"})
+	require.NoError(t, err)
+	assert.False(t, e.Enabled())
+
+	encrypted, err := e.EncryptString("player@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "player@example.org", encrypted)
+	assert.Empty(t, e.BlindIndex("player@example.org"))
+	assert.False(t, e.NeedsEncrypting("player@example.org"))
+}
+
+func TestEncryptor_RoundTrip(t *testing.T) {
+	e, err := New(&config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k1", Key: testKey(1)}},
+		IndexKey: testKey(9),
+	})
+	require.NoError(t, err)
+
+	encrypted, err := e.EncryptString("player@example.org")
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "player")
+
+	again, err := e.EncryptString("player@example.org")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, again, "each encryption uses a new nonce")
+
+	plain, err := e.DecryptString(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "player@example.org", plain)
+
+	// Values stored before encryption was enabled read as they are
+	plain, err = e.DecryptString("old@example.org")
+	require.NoError(t, err)
+	assert.Equal(t, "old@example.org", plain)
+	assert.True(t, e.NeedsEncrypting("old@example.org"))
+	assert.False(t, e.NeedsEncrypting(encrypted))
+
+	empty, err := e.EncryptString("")
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	// Tampering is detected
+	_, err = e.DecryptString(encrypted[:len(encrypted)-4] + "AAAA")
+	assert.Error(t, err)
+}
+
+func TestEncryptor_KeyRotation(t *testing.T) {
+	old, err := New(&config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k1", Key: testKey(1)}},
+		IndexKey: testKey(9),
+	})
+	require.NoError(t, err)
+	encrypted, err := old.EncryptString("player@example.org")
+	require.NoError(t, err)
+
+	rotated, err := New(&config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k1", Key: testKey(1)}, {ID: "k2", Key: testKey(2)}},
+		IndexKey: testKey(9),
+	})
+	require.NoError(t, err)
+	assert.True(t, rotated.NeedsEncrypting(encrypted))
+	plain, err := rotated.DecryptString(encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "player@example.org", plain)
+	assert.Equal(t, old.BlindIndex("player@example.org"), rotated.BlindIndex(" Player@Example.org "),
+		"blind indexes survive key rotation and ignore case")
+
+	// Once the old key is dropped its values no longer decrypt
+	dropped, err := New(&config.EncryptionConfig{
+		Enabled:  true,
+		Keys:     []config.EncryptionKey{{ID: "k2", Key: testKey(2)}},
+		IndexKey: testKey(9),
+	})
+	require.NoError(t, err)
+	_, err = dropped.DecryptString(encrypted)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestNew_InvalidKeys(t *testing.T) {
+	for name, cfg := range map[string]*config.EncryptionConfig{
+		"short key":       {Enabled: true, Keys: []config.EncryptionKey{{ID: "k1", Key: "c2hvcnQ="}}, IndexKey: testKey(9)},
+		"missing index":   {Enabled: true, Keys: []config.EncryptionKey{{ID: "k1", Key: testKey(1)}}},
+		"unknown primary": {Enabled: true, Keys: []config.EncryptionKey{{ID: "k1", Key: testKey(1)}}, PrimaryKey: "k2", IndexKey: testKey(9)},
+		"duplicate IDs":   {Enabled: true, Keys: []config.EncryptionKey{{ID: "k1", Key: testKey(1)}, {ID: "k1", Key: testKey(2)}}, IndexKey: testKey(9)},
+		"algorithm":       {Enabled: true, Algorithm: "DES", Keys: []config.EncryptionKey{{ID: "k1", Key: testKey(1)}}, IndexKey: testKey(9)},
+	} {
+		_, err := New(cfg)
+		assert.Error(t, err, name)
+	}
+}
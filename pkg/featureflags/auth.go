@@ -0,0 +1,71 @@
+package featureflags
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// OverridesClient reads the flags the auth service stores;
+// authv1.AuthServiceClient implements it
+type OverridesClient interface {
+	GetFeatureFlagOverrides(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*authv1.FeatureFlagOverridesResponse, error)
+}
+
+// authStore reads the flags set at runtime from the auth service
+type authStore struct {
+	client OverridesClient
+}
+
+// NewAuthStore returns a store reading the flags set at runtime from the
+// auth service
+func NewAuthStore(client OverridesClient) Store {
+	return &authStore{client: client}
+}
+
+// FeatureFlagOverrides asks the auth service for the flags set at runtime
+func (s *authStore) FeatureFlagOverrides(ctx context.Context) (map[string]*config.FeatureFlag, error) {
+	resp, err := s.client.GetFeatureFlagOverrides(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags from auth service: %w", err)
+	}
+	return OverridesFromProto(resp), nil
+}
+
+// OverridesFromProto returns the flags of an overrides response, by name
+func OverridesFromProto(resp *authv1.FeatureFlagOverridesResponse) map[string]*config.FeatureFlag {
+	overrides := make(map[string]*config.FeatureFlag, len(resp.Flags))
+	for _, pbFlag := range resp.Flags {
+		overrides[pbFlag.Name] = FromProto(pbFlag)
+	}
+	return overrides
+}
+
+// FromProto converts a flag from its proto form
+func FromProto(pbFlag *authv1.FeatureFlag) *config.FeatureFlag {
+	return &config.FeatureFlag{
+		Description: pbFlag.Description,
+		Enabled:     pbFlag.Enabled,
+		Users:       pbFlag.Users,
+		Roles:       pbFlag.Roles,
+		Percentage:  int(pbFlag.Percentage),
+	}
+}
+
+// ToProto converts a flag to its proto form
+func ToProto(flag Flag) *authv1.FeatureFlag {
+	return &authv1.FeatureFlag{
+		Name:        flag.Name,
+		Description: flag.Description,
+		Enabled:     flag.Enabled,
+		Users:       flag.Users,
+		Roles:       flag.Roles,
+		Percentage:  int32(flag.Percentage),
+		Overridden:  flag.Overridden,
+	}
+}
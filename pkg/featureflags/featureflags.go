@@ -0,0 +1,252 @@
+// Package featureflags turns risky features on for some users before
+// everyone, without a redeploy. Each service evaluates the flags configured
+// in its feature_flags section; flags set at runtime are stored by the auth
+// service, replace the configured flag of the same name, and are reread by
+// every service periodically. A flag is on for everyone when enabled, and
+// otherwise for the users it lists, users with a role it lists, and a stable
+// percentage of signed-in users.
+package featureflags
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+// Subject is who a flag is evaluated for. Anonymous users have no user ID
+// and get only the flags that are on for everyone.
+type Subject struct {
+	UserID   string
+	Username string
+	Roles    []string
+}
+
+// SubjectOf returns the subject of a user the auth service described, with
+// admins having the admin role; a nil user is anonymous
+func SubjectOf(user *authv1.User) Subject {
+	if user == nil {
+		return Subject{}
+	}
+	subject := Subject{
+		UserID:   user.Id,
+		Username: user.Username,
+		Roles:    append([]string(nil), user.Roles...),
+	}
+	if user.IsAdmin {
+		subject.Roles = append(subject.Roles, "admin")
+	}
+	return subject
+}
+
+// Store holds the flags set at runtime
+type Store interface {
+	// FeatureFlagOverrides returns the flags set at runtime, by name
+	FeatureFlagOverrides(ctx context.Context) (map[string]*config.FeatureFlag, error)
+}
+
+// Flag is a flag as a service evaluates it
+type Flag struct {
+	Name string
+	config.FeatureFlag
+	// Overridden is set for flags set at runtime, which replace the
+	// configured flag of their name
+	Overridden bool
+}
+
+// Rollout describes who the flag is on for, such as "roles beta; 10% of users"
+func (f Flag) Rollout() string {
+	if f.Enabled {
+		return "everyone"
+	}
+	var parts []string
+	if len(f.Users) > 0 {
+		parts = append(parts, "users "+strings.Join(f.Users, ","))
+	}
+	if len(f.Roles) > 0 {
+		parts = append(parts, "roles "+strings.Join(f.Roles, ","))
+	}
+	if f.Percentage > 0 {
+		parts = append(parts, fmt.Sprintf("%d%% of users", f.Percentage))
+	}
+	if len(parts) == 0 {
+		return "no one"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Flags evaluates the configured flags and those set at runtime. A nil
+// Flags has every flag off.
+type Flags struct {
+	configured map[string]*config.FeatureFlag
+	store      Store
+	interval   time.Duration
+	logger     *slog.Logger
+
+	mu        sync.RWMutex
+	overrides map[string]*config.FeatureFlag
+}
+
+// New returns the flags cfg configures, with the flags set at runtime read
+// from store; a nil store uses only the configured flags
+func New(cfg *config.FeatureFlagsConfig, store Store, logger *slog.Logger) *Flags {
+	f := &Flags{
+		configured: make(map[string]*config.FeatureFlag),
+		store:      store,
+		interval:   cfg.GetRefreshInterval(),
+		logger:     logger,
+	}
+	if cfg != nil {
+		for name, flag := range cfg.Flags {
+			if flag != nil {
+				f.configured[name] = flag
+			}
+		}
+	}
+	return f
+}
+
+// Enabled reports whether the named flag is on for subject. Unknown flags
+// are off.
+func (f *Flags) Enabled(name string, subject Subject) bool {
+	if f == nil {
+		return false
+	}
+	f.mu.RLock()
+	flag, ok := f.overrides[name]
+	f.mu.RUnlock()
+	if !ok {
+		flag = f.configured[name]
+	}
+	return Evaluate(name, flag, subject)
+}
+
+// EnabledFor returns the names of the flags on for subject, sorted
+func (f *Flags) EnabledFor(subject Subject) []string {
+	var names []string
+	for _, flag := range f.List() {
+		if Evaluate(flag.Name, &flag.FeatureFlag, subject) {
+			names = append(names, flag.Name)
+		}
+	}
+	return names
+}
+
+// List returns every flag by name, those set at runtime in place of the
+// configured ones
+func (f *Flags) List() []Flag {
+	if f == nil {
+		return nil
+	}
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	flags := make([]Flag, 0, len(f.configured)+len(f.overrides))
+	for name, flag := range f.configured {
+		if _, overridden := f.overrides[name]; !overridden {
+			flags = append(flags, Flag{Name: name, FeatureFlag: *flag})
+		}
+	}
+	for name, flag := range f.overrides {
+		flags = append(flags, Flag{Name: name, FeatureFlag: *flag, Overridden: true})
+	}
+	slices.SortFunc(flags, func(a, b Flag) int { return strings.Compare(a.Name, b.Name) })
+	return flags
+}
+
+// Refresh rereads the flags set at runtime. On failure the flags read last
+// stay in effect.
+func (f *Flags) Refresh(ctx context.Context) error {
+	if f == nil || f.store == nil {
+		return nil
+	}
+	overrides, err := f.store.FeatureFlagOverrides(ctx)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	changed := !reflect.DeepEqual(f.overrides, overrides)
+	f.overrides = overrides
+	f.mu.Unlock()
+
+	if changed {
+		f.logger.Info("Feature flags set at runtime changed", "overrides", slices.Sorted(maps.Keys(overrides)))
+	}
+	return nil
+}
+
+// Run rereads the flags set at runtime until ctx is done
+func (f *Flags) Run(ctx context.Context) {
+	if f == nil || f.store == nil {
+		return
+	}
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		err := f.Refresh(ctx)
+		switch {
+		case err == nil || ctx.Err() != nil:
+		case status.Code(err) == codes.Unimplemented:
+			// Auth services that predate runtime flags have none to read
+			f.logger.Debug("Auth service does not store feature flags", "error", err)
+		default:
+			f.logger.Warn("Failed to read feature flags set at runtime", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Evaluate reports whether flag, named name, is on for subject
+func Evaluate(name string, flag *config.FeatureFlag, subject Subject) bool {
+	if flag == nil {
+		return false
+	}
+	if flag.Enabled {
+		return true
+	}
+	if subject.UserID == "" && subject.Username == "" {
+		return false
+	}
+	for _, user := range flag.Users {
+		if user == subject.Username || user == subject.UserID {
+			return true
+		}
+	}
+	for _, role := range flag.Roles {
+		if slices.Contains(subject.Roles, role) {
+			return true
+		}
+	}
+	return flag.Percentage > 0 && bucket(name, subject) < flag.Percentage
+}
+
+// bucket places a user in one of 100 buckets for a flag. Users keep their
+// bucket, so raising a flag's percentage only adds users, and each flag
+// buckets users differently so the same users do not get every new feature.
+func bucket(name string, subject Subject) int {
+	id := subject.UserID
+	if id == "" {
+		id = subject.Username
+	}
+	h := fnv.New32a()
+	h.Write([]byte(name + ":" + id))
+	return int(h.Sum32() % 100)
+}
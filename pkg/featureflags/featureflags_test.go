@@ -0,0 +1,133 @@
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/config"
+)
+
+type fakeStore struct {
+	overrides map[string]*config.FeatureFlag
+	err       error
+}
+
+func (s *fakeStore) FeatureFlagOverrides(ctx context.Context) (map[string]*config.FeatureFlag, error) {
+	return s.overrides, s.err
+}
+
+func TestEvaluate(t *testing.T) {
+	alice := Subject{UserID: "1", Username: "alice", Roles: []string{"beta"}}
+	bob := Subject{UserID: "2", Username: "bob"}
+	anonymous := Subject{}
+
+	tests := []struct {
+		name    string
+		flag    *config.FeatureFlag
+		subject Subject
+		want    bool
+	}{
+		{"unknown flag", nil, alice, false},
+		{"enabled", &config.FeatureFlag{Enabled: true}, anonymous, true},
+		{"off", &config.FeatureFlag{}, alice, false},
+		{"listed username", &config.FeatureFlag{Users: []string{"bob"}}, bob, true},
+		{"listed user ID", &config.FeatureFlag{Users: []string{"2"}}, bob, true},
+		{"unlisted user", &config.FeatureFlag{Users: []string{"alice"}}, bob, false},
+		{"role", &config.FeatureFlag{Roles: []string{"beta"}}, alice, true},
+		{"missing role", &config.FeatureFlag{Roles: []string{"beta"}}, bob, false},
+		{"all users", &config.FeatureFlag{Percentage: 100}, bob, true},
+		{"anonymous at 100 percent", &config.FeatureFlag{Percentage: 100}, anonymous, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Evaluate("new_menus", tt.flag, tt.subject))
+		})
+	}
+}
+
+func TestEvaluate_PercentageOnlyAddsUsers(t *testing.T) {
+	var previous map[string]bool
+	for _, percentage := range []int{0, 10, 25, 50, 75, 100} {
+		flag := &config.FeatureFlag{Percentage: percentage}
+		enabled := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			id := fmt.Sprint(i)
+			if Evaluate("new_menus", flag, Subject{UserID: id}) {
+				enabled[id] = true
+			}
+		}
+		for id := range previous {
+			assert.True(t, enabled[id], "user %s left the rollout at %d%%", id, percentage)
+		}
+		// Buckets spread users roughly evenly
+		assert.InDelta(t, percentage*10, len(enabled), 60, "at %d%%", percentage)
+		previous = enabled
+	}
+}
+
+func TestFlags_OverridesReplaceConfig(t *testing.T) {
+	cfg := &config.FeatureFlagsConfig{Flags: map[string]*config.FeatureFlag{
+		"new_menus":  {Roles: []string{"beta"}},
+		"big_scores": {Enabled: true},
+	}}
+	store := &fakeStore{}
+	flags := New(cfg, store, slog.Default())
+	beta := Subject{UserID: "1", Username: "alice", Roles: []string{"beta"}}
+
+	assert.True(t, flags.Enabled("new_menus", beta))
+	assert.False(t, flags.Enabled("unknown", beta))
+
+	store.overrides = map[string]*config.FeatureFlag{"new_menus": {Users: []string{"bob"}}}
+	require.NoError(t, flags.Refresh(context.Background()))
+	assert.False(t, flags.Enabled("new_menus", beta))
+	assert.True(t, flags.Enabled("new_menus", Subject{UserID: "2", Username: "bob"}))
+	assert.Equal(t, []string{"big_scores"}, flags.EnabledFor(beta))
+
+	list := flags.List()
+	require.Len(t, list, 2)
+	assert.Equal(t, "big_scores", list[0].Name)
+	assert.False(t, list[0].Overridden)
+	assert.Equal(t, "new_menus", list[1].Name)
+	assert.True(t, list[1].Overridden)
+
+	// The overrides read last stay in effect when the store fails
+	store.err = errors.New("auth service unavailable")
+	assert.Error(t, flags.Refresh(context.Background()))
+	assert.False(t, flags.Enabled("new_menus", beta))
+
+	// Removing the override returns to the configured flag
+	store.overrides, store.err = nil, nil
+	require.NoError(t, flags.Refresh(context.Background()))
+	assert.True(t, flags.Enabled("new_menus", beta))
+}
+
+func TestFlags_Nil(t *testing.T) {
+	var flags *Flags
+	assert.False(t, flags.Enabled("new_menus", Subject{UserID: "1"}))
+	assert.Empty(t, flags.EnabledFor(Subject{UserID: "1"}))
+	assert.Empty(t, flags.List())
+	assert.NoError(t, flags.Refresh(context.Background()))
+}
+
+func TestFlag_Rollout(t *testing.T) {
+	assert.Equal(t, "everyone", Flag{FeatureFlag: config.FeatureFlag{Enabled: true, Roles: []string{"beta"}}}.Rollout())
+	assert.Equal(t, "no one", Flag{}.Rollout())
+	assert.Equal(t, "users alice,bob; roles beta; 10% of users", Flag{FeatureFlag: config.FeatureFlag{
+		Users:      []string{"alice", "bob"},
+		Roles:      []string{"beta"},
+		Percentage: 10,
+	}}.Rollout())
+}
+
+func TestSubjectOf(t *testing.T) {
+	assert.Equal(t, Subject{}, SubjectOf(nil))
+	assert.Equal(t, Subject{UserID: "7", Username: "alice", Roles: []string{"beta", "admin"}},
+		SubjectOf(&authv1.User{Id: "7", Username: "alice", Roles: []string{"beta"}, IsAdmin: true}))
+}
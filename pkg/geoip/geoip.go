@@ -0,0 +1,118 @@
+// Package geoip looks up the country of client addresses in a MaxMind
+// database (GeoLite2-Country, GeoIP2-Country or City) and decides whether a
+// country may connect.
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Unknown is reported for addresses the database has no country for, such
+// as private and loopback addresses
+const Unknown = "unknown"
+
+// Resolver looks up countries. A nil Resolver reports every address as Unknown.
+type Resolver struct {
+	reader *maxminddb.Reader
+}
+
+// countryRecord is the part of a database record a lookup decodes
+type countryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+}
+
+// Open opens a MaxMind database file
+func Open(path string) (*Resolver, error) {
+	reader, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database %s: %w", path, err)
+	}
+	return &Resolver{reader: reader}, nil
+}
+
+// Country returns the ISO 3166-1 alpha-2 code of the country ip is in, or
+// Unknown
+func (r *Resolver) Country(ip string) string {
+	if r == nil {
+		return Unknown
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return Unknown
+	}
+	var record countryRecord
+	if err := r.reader.Lookup(parsed, &record); err != nil {
+		return Unknown
+	}
+	switch {
+	case record.Country.ISOCode != "":
+		return record.Country.ISOCode
+	case record.RegisteredCountry.ISOCode != "":
+		// Anycast and satellite networks only carry the registered country
+		return record.RegisteredCountry.ISOCode
+	default:
+		return Unknown
+	}
+}
+
+// Close releases the database
+func (r *Resolver) Close() error {
+	if r == nil {
+		return nil
+	}
+	return r.reader.Close()
+}
+
+// Policy decides which countries may connect. With an allow list only its
+// countries may; otherwise every country but those on the deny list may.
+// Unknown addresses are allowed unless DenyUnknown is set, so private
+// networks and health checks keep working.
+type Policy struct {
+	allow       map[string]bool
+	deny        map[string]bool
+	denyUnknown bool
+}
+
+// NewPolicy creates a policy from lists of ISO country codes
+func NewPolicy(allow, deny []string, denyUnknown bool) *Policy {
+	return &Policy{
+		allow:       countrySet(allow),
+		deny:        countrySet(deny),
+		denyUnknown: denyUnknown,
+	}
+}
+
+// Allowed reports whether connections from country are accepted. A nil
+// Policy allows every country.
+func (p *Policy) Allowed(country string) bool {
+	if p == nil {
+		return true
+	}
+	if country == Unknown {
+		return !p.denyUnknown
+	}
+	if len(p.allow) > 0 {
+		return p.allow[country]
+	}
+	return !p.deny[country]
+}
+
+// countrySet normalises country codes to upper case
+func countrySet(countries []string) map[string]bool {
+	set := make(map[string]bool, len(countries))
+	for _, country := range countries {
+		if country = strings.ToUpper(strings.TrimSpace(country)); country != "" {
+			set[country] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,39 @@
+package geoip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_DenyList(t *testing.T) {
+	policy := NewPolicy(nil, []string{"xx", " YY "}, false)
+
+	assert.False(t, policy.Allowed("XX"))
+	assert.False(t, policy.Allowed("YY"))
+	assert.True(t, policy.Allowed("ZZ"))
+	assert.True(t, policy.Allowed(Unknown))
+}
+
+func TestPolicy_AllowList(t *testing.T) {
+	policy := NewPolicy([]string{"XX"}, []string{"XX"}, true)
+
+	// The allow list takes precedence over the deny list
+	assert.True(t, policy.Allowed("XX"))
+	assert.False(t, policy.Allowed("YY"))
+	assert.False(t, policy.Allowed(Unknown))
+}
+
+func TestPolicy_Nil(t *testing.T) {
+	var policy *Policy
+	assert.True(t, policy.Allowed("XX"))
+}
+
+func TestResolver_NilAndMissingDatabase(t *testing.T) {
+	var resolver *Resolver
+	assert.Equal(t, Unknown, resolver.Country("192.0.2.1"))
+	assert.NoError(t, resolver.Close())
+
+	_, err := Open("testdata/missing.mmdb")
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+// Package healthcheck probes a service's own health endpoints, for the
+// `healthcheck` subcommand container probes run in place of curl or grpcurl.
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/listener"
+)
+
+// DefaultTimeout bounds a health check
+const DefaultTimeout = 5 * time.Second
+
+// Target is where a service serves its health endpoints
+type Target struct {
+	// GRPC is the gRPC dial target of the standard health service; empty
+	// skips the gRPC check
+	GRPC string
+	// HTTP is the address serving GET /health; empty skips the HTTP check
+	HTTP string
+	// HTTPSocket is the unix socket serving HTTP, replacing HTTP when set
+	HTTPSocket string
+}
+
+// TargetOf returns the health endpoints of a service listening on all
+// interfaces at the given ports, or on the unix sockets of listen. A
+// grpcPort of 0 skips the gRPC check, for services without the health
+// service.
+func TargetOf(listen *config.ListenConfig, httpPort, grpcPort int) Target {
+	var sockets map[string]string
+	if listen != nil {
+		sockets = listen.UnixSockets
+	}
+
+	var target Target
+	if path := sockets[listener.HTTP]; path != "" {
+		target.HTTPSocket = path
+	} else if httpPort > 0 {
+		target.HTTP = net.JoinHostPort("127.0.0.1", strconv.Itoa(httpPort))
+	}
+	if path := sockets[listener.GRPC]; path != "" && grpcPort > 0 {
+		target.GRPC = "unix:" + path
+	} else if grpcPort > 0 {
+		target.GRPC = net.JoinHostPort("127.0.0.1", strconv.Itoa(grpcPort))
+	}
+	return target
+}
+
+// Check probes the endpoints of target, returning the first that is not
+// healthy
+func Check(ctx context.Context, target Target) error {
+	if target.GRPC == "" && target.HTTP == "" && target.HTTPSocket == "" {
+		return fmt.Errorf("no health endpoints are configured")
+	}
+	if target.GRPC != "" {
+		if err := checkGRPC(ctx, target.GRPC); err != nil {
+			return err
+		}
+	}
+	if target.HTTP != "" || target.HTTPSocket != "" {
+		if err := checkHTTP(ctx, target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkGRPC asks the gRPC health service for the status of the server
+func checkGRPC(ctx context.Context, addr string) error {
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial gRPC %s: %w", addr, err)
+	}
+	defer conn.Close()
+
+	resp, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to check gRPC health at %s: %w", addr, err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("gRPC %s is %s", addr, resp.Status)
+	}
+	return nil
+}
+
+// checkHTTP requests GET /health, which must answer 200
+func checkHTTP(ctx context.Context, target Target) error {
+	addr := target.HTTP
+	transport := &http.Transport{DisableKeepAlives: true}
+	if target.HTTPSocket != "" {
+		addr = "localhost"
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", target.HTTPSocket)
+		}
+	}
+	client := &http.Client{Transport: transport}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://"+addr+"/health", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to check HTTP health: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP health check returned %s", resp.Status)
+	}
+	return nil
+}
+
+// Run checks target for a `healthcheck` subcommand, reporting the result and
+// returning the process exit code: 0 when healthy, 1 otherwise
+func Run(service string, target Target, stdout, stderr io.Writer) int {
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+	defer cancel()
+
+	if err := Check(ctx, target); err != nil {
+		fmt.Fprintf(stderr, "%s is unhealthy: %v\n", service, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s is healthy\n", service)
+	return 0
+}
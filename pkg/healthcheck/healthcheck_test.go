@@ -0,0 +1,106 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// serveHealth serves GET /health with status on ln
+func serveHealth(t *testing.T, ln net.Listener, status int) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(ln)
+	t.Cleanup(func() { server.Close() })
+}
+
+// serveGRPCHealth serves the gRPC health service on ln
+func serveGRPCHealth(t *testing.T, ln net.Listener) *health.Server {
+	server := grpc.NewServer()
+	healthServer := health.NewServer()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go server.Serve(ln)
+	t.Cleanup(server.Stop)
+	return healthServer
+}
+
+func checkCtx(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestTargetOf(t *testing.T) {
+	target := TargetOf(nil, 8081, 0)
+	assert.Equal(t, Target{HTTP: "127.0.0.1:8081"}, target)
+
+	target = TargetOf(&config.ListenConfig{UnixSockets: map[string]string{"grpc": "/run/dg/grpc.sock"}}, 8086, 50051)
+	assert.Equal(t, Target{GRPC: "unix:/run/dg/grpc.sock", HTTP: "127.0.0.1:8086"}, target)
+
+	target = TargetOf(&config.ListenConfig{UnixSockets: map[string]string{"http": "/run/dg/http.sock"}}, 8086, 0)
+	assert.Equal(t, Target{HTTPSocket: "/run/dg/http.sock"}, target)
+}
+
+func TestCheck_Healthy(t *testing.T) {
+	httpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serveHealth(t, httpLn, http.StatusOK)
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serveGRPCHealth(t, grpcLn)
+
+	target := Target{GRPC: grpcLn.Addr().String(), HTTP: httpLn.Addr().String()}
+	assert.NoError(t, Check(checkCtx(t), target))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, Run("game-service", target, &stdout, &stderr))
+	assert.Equal(t, "game-service is healthy\n", stdout.String())
+}
+
+func TestCheck_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.sock")
+	ln, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	serveHealth(t, ln, http.StatusOK)
+
+	assert.NoError(t, Check(checkCtx(t), Target{HTTPSocket: path}))
+}
+
+func TestCheck_Unhealthy(t *testing.T) {
+	httpLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	serveHealth(t, httpLn, http.StatusServiceUnavailable)
+	assert.ErrorContains(t, Check(checkCtx(t), Target{HTTP: httpLn.Addr().String()}), "503")
+
+	grpcLn, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	healthServer := serveGRPCHealth(t, grpcLn)
+	healthServer.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	assert.ErrorContains(t, Check(checkCtx(t), Target{GRPC: grpcLn.Addr().String()}), "NOT_SERVING")
+
+	// Nothing listening
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := closed.Addr().String()
+	closed.Close()
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 1, Run("auth-service", Target{HTTP: addr}, &stdout, &stderr))
+	assert.Contains(t, stderr.String(), "auth-service is unhealthy")
+
+	assert.Error(t, Check(checkCtx(t), Target{}))
+}
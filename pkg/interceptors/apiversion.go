@@ -0,0 +1,79 @@
+package interceptors
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/dungeongate/pkg/capabilities"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryAPIVersion gives each call the games API version the caller sent,
+// APIVersion2 when it sent none, and rejects callers older than minVersion
+func UnaryAPIVersion(minVersion int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := incomingAPIVersion(ctx, minVersion, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAPIVersion gives each stream the games API version the caller sent
+func StreamAPIVersion(minVersion int) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := incomingAPIVersion(ss.Context(), minVersion, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// incomingAPIVersion returns ctx carrying the API version from the call's
+// metadata. Health checks are answered whatever the version.
+func incomingAPIVersion(ctx context.Context, minVersion int, method string) (context.Context, error) {
+	version := capabilities.APIVersion2
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(capabilities.MetadataKey); len(values) > 0 {
+		parsed, err := strconv.Atoi(values[0])
+		if err != nil || parsed <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s %q", capabilities.MetadataKey, values[0])
+		}
+		version = parsed
+	}
+	if version < minVersion && !isHealthCheck(method) {
+		return nil, status.Errorf(codes.FailedPrecondition, "games API version %d is no longer served; upgrade to version %d or later", version, minVersion)
+	}
+	return capabilities.WithAPIVersion(ctx, version), nil
+}
+
+// UnaryClientAPIVersion sends the games API version returned by version
+// with each call, so it can change once negotiated
+func UnaryClientAPIVersion(version func() int) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx = metadata.AppendToOutgoingContext(ctx, capabilities.MetadataKey, strconv.Itoa(version()))
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientAPIVersion sends the games API version with each stream
+func StreamClientAPIVersion(version func() int) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx = metadata.AppendToOutgoingContext(ctx, capabilities.MetadataKey, strconv.Itoa(version()))
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// WithAPIVersion returns the dial options sending the games API version
+// returned by version with every call
+func WithAPIVersion(version func() int) []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientAPIVersion(version)),
+		grpc.WithChainStreamInterceptor(StreamClientAPIVersion(version)),
+	}
+}
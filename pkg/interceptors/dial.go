@@ -0,0 +1,21 @@
+package interceptors
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+)
+
+// WithDialTimeout returns the dial options that limit each attempt to
+// connect to a service to timeout. Failed attempts are retried with the
+// default backoff. A timeout of zero or less adds nothing.
+func WithDialTimeout(timeout time.Duration) []grpc.DialOption {
+	if timeout <= 0 {
+		return nil
+	}
+	return []grpc.DialOption{grpc.WithConnectParams(grpc.ConnectParams{
+		Backoff:           backoff.DefaultConfig,
+		MinConnectTimeout: timeout,
+	})}
+}
@@ -0,0 +1,213 @@
+// Package interceptors provides the gRPC server interceptor chain shared by
+// the DungeonGate services: request IDs, panic recovery, request logging,
+// metrics, service token authentication, rate limiting and games API versions.
+package interceptors
+
+import (
+	"context"
+	"crypto/subtle"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/ratelimit"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsRecorder records finished gRPC calls; metrics.Registry implements it
+type MetricsRecorder interface {
+	RecordGRPCRequest(method, code string, duration time.Duration)
+}
+
+// Options configures the interceptor chain
+type Options struct {
+	Logger *slog.Logger
+	// Metrics records every call when set
+	Metrics MetricsRecorder
+	// AuthToken is the bearer token callers must send; empty accepts all calls
+	AuthToken string
+	// RateLimits limits calls per caller when set
+	RateLimits *ratelimit.Policy
+	// MinAPIVersion is the oldest games API version served. When set, each
+	// call carries the caller's version and older callers are rejected.
+	MinAPIVersion int
+}
+
+// ServerOptions returns the grpc.NewServer options installing the chain.
+// The request ID is set first so everything after logs it. Recovery runs
+// next so a panic anywhere in the chain or the handler becomes an Internal
+// error that is logged and counted.
+func ServerOptions(opts Options) []grpc.ServerOption {
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	unary := []grpc.UnaryServerInterceptor{
+		UnaryRequestID(),
+		UnaryRecovery(logger),
+		UnaryLogging(logger),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		StreamRequestID(),
+		StreamRecovery(logger),
+		StreamLogging(logger),
+	}
+	if opts.Metrics != nil {
+		unary = append(unary, UnaryMetrics(opts.Metrics))
+		stream = append(stream, StreamMetrics(opts.Metrics))
+	}
+	if opts.AuthToken != "" {
+		unary = append(unary, UnaryAuth(opts.AuthToken))
+		stream = append(stream, StreamAuth(opts.AuthToken))
+	}
+	if opts.RateLimits != nil {
+		unary = append(unary, UnaryRateLimit(opts.RateLimits))
+		stream = append(stream, StreamRateLimit(opts.RateLimits))
+	}
+	if opts.MinAPIVersion > 0 {
+		unary = append(unary, UnaryAPIVersion(opts.MinAPIVersion))
+		stream = append(stream, StreamAPIVersion(opts.MinAPIVersion))
+	}
+
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	}
+}
+
+// UnaryRecovery turns a handler panic into an Internal error
+func UnaryRecovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ctx, logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery turns a stream handler panic into an Internal error
+func StreamRecovery(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(ss.Context(), logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// recovered logs a recovered panic and returns the error sent to the caller
+func recovered(ctx context.Context, logger *slog.Logger, method string, r interface{}) error {
+	logger.ErrorContext(ctx, "Panic in gRPC handler",
+		"method", method,
+		"panic", r,
+		"stack", string(debug.Stack()))
+	return status.Error(codes.Internal, "internal error")
+}
+
+// UnaryLogging logs each call with its status and latency
+func UnaryLogging(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(ctx, logger, "gRPC request", info.FullMethod, err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamLogging logs each stream with its status and duration once it ends
+func StreamLogging(logger *slog.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(ss.Context(), logger, "gRPC stream", info.FullMethod, err, time.Since(start))
+		return err
+	}
+}
+
+// logCall logs a finished call. Health checks are logged at debug level since
+// load balancers and the session service poll them constantly.
+func logCall(ctx context.Context, logger *slog.Logger, msg, method string, err error, duration time.Duration) {
+	level := slog.LevelInfo
+	switch {
+	case status.Code(err) == codes.Internal || status.Code(err) == codes.Unknown:
+		level = slog.LevelError
+	case isHealthCheck(method):
+		level = slog.LevelDebug
+	}
+
+	logger.Log(ctx, level, msg,
+		"method", method,
+		"status", status.Code(err).String(),
+		"duration_ms", duration.Milliseconds())
+}
+
+// UnaryMetrics records each call's status and latency
+func UnaryMetrics(recorder MetricsRecorder) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		recorder.RecordGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamMetrics records each stream's status and duration
+func StreamMetrics(recorder MetricsRecorder) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		recorder.RecordGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+		return err
+	}
+}
+
+// UnaryAuth rejects calls without the service token, except health checks
+func UnaryAuth(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := authorize(ctx, token, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth rejects streams without the service token
+func StreamAuth(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := authorize(ss.Context(), token, info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// authorize checks the bearer token in a call's metadata
+func authorize(ctx context.Context, token, method string) error {
+	if isHealthCheck(method) {
+		return nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationHeader) {
+		presented, ok := strings.CutPrefix(value, bearerPrefix)
+		if ok && subtle.ConstantTimeCompare([]byte(presented), []byte(token)) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "missing or invalid service token")
+}
+
+// isHealthCheck reports whether a method is a health check, which must work
+// without credentials
+func isHealthCheck(method string) bool {
+	return strings.HasPrefix(method, "/grpc.health.v1.Health/") || strings.HasSuffix(method, "/Health")
+}
@@ -0,0 +1,198 @@
+package interceptors
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	authv1 "github.com/dungeongate/pkg/api/auth/v1"
+	"github.com/dungeongate/pkg/capabilities"
+	"github.com/dungeongate/pkg/config"
+	"github.com/dungeongate/pkg/logging"
+	"github.com/dungeongate/pkg/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type recordedCall struct {
+	method string
+	code   string
+}
+
+type fakeRecorder struct {
+	calls []recordedCall
+}
+
+func (r *fakeRecorder) RecordGRPCRequest(method, code string, duration time.Duration) {
+	r.calls = append(r.calls, recordedCall{method: method, code: code})
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestUnaryRecovery_ReturnsInternal(t *testing.T) {
+	interceptor := UnaryRecovery(discardLogger())
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Panic"}
+
+	resp, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	assert.Nil(t, resp)
+	assert.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestUnaryMetrics_RecordsStatus(t *testing.T) {
+	recorder := &fakeRecorder{}
+	interceptor := UnaryMetrics(recorder)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+
+	require.Error(t, err)
+	assert.Equal(t, []recordedCall{{method: "/test.Service/Get", code: "NotFound"}}, recorder.calls)
+}
+
+func TestUnaryAuth(t *testing.T) {
+	interceptor := UnaryAuth("secret")
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	_, err := interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	wrong := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer nope"))
+	_, err = interceptor(wrong, nil, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	creds := tokenCredentials{token: "secret"}
+	headers, err := creds.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	valid := metadata.NewIncomingContext(context.Background(), metadata.New(headers))
+	resp, err := interceptor(valid, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", resp)
+
+	health := &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}
+	_, err = interceptor(context.Background(), nil, health, handler)
+	assert.NoError(t, err)
+}
+
+func TestServerOptions_AndServiceToken(t *testing.T) {
+	recorder := &fakeRecorder{}
+	opts := ServerOptions(Options{Logger: discardLogger(), Metrics: recorder})
+	assert.Len(t, opts, 2)
+	assert.Nil(t, WithServiceToken(""))
+	assert.Len(t, WithServiceToken("secret"), 1)
+}
+
+func TestUnaryRateLimit(t *testing.T) {
+	policy, err := ratelimit.NewPolicy(&config.APIRateLimitConfig{
+		Enabled: true,
+		Rules: []*config.RateLimitRule{
+			{Match: "/dungeongate.auth.v1.AuthService/Login", By: "user", Requests: 1, Per: "1h"},
+		},
+	})
+	require.NoError(t, err)
+	interceptor := UnaryRateLimit(policy)
+	info := &grpc.UnaryServerInfo{FullMethod: "/dungeongate.auth.v1.AuthService/Login"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	}
+
+	_, err = interceptor(context.Background(), &authv1.LoginRequest{Username: "alice"}, info, handler)
+	require.NoError(t, err)
+
+	_, err = interceptor(context.Background(), &authv1.LoginRequest{Username: "alice"}, info, handler)
+	st := status.Convert(err)
+	assert.Equal(t, codes.ResourceExhausted, st.Code())
+	require.Len(t, st.Details(), 1)
+	retry, ok := st.Details()[0].(*errdetails.RetryInfo)
+	require.True(t, ok)
+	assert.Greater(t, retry.RetryDelay.AsDuration(), time.Minute)
+
+	// Each user has a bucket of their own, and other methods are unlimited
+	_, err = interceptor(context.Background(), &authv1.LoginRequest{Username: "bob"}, info, handler)
+	assert.NoError(t, err)
+	other := &grpc.UnaryServerInfo{FullMethod: "/dungeongate.auth.v1.AuthService/Register"}
+	_, err = interceptor(context.Background(), &authv1.LoginRequest{Username: "alice"}, other, handler)
+	assert.NoError(t, err)
+}
+
+func TestRequestID_Propagates(t *testing.T) {
+	interceptor := UnaryRequestID()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	var seen string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = logging.RequestID(ctx)
+		return nil, nil
+	}
+
+	// The caller's request ID is kept
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "conn-1234"))
+	_, err := interceptor(incoming, nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "conn-1234", seen)
+
+	// Calls without one, or with an invalid one, get a new ID
+	_, err = interceptor(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	assert.NotEmpty(t, seen)
+	invalid := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "bad\nid"))
+	_, err = interceptor(invalid, nil, info, handler)
+	require.NoError(t, err)
+	assert.NotEqual(t, "bad\nid", seen)
+
+	// Client calls send the request ID of their context
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	ctx := logging.WithRequestID(context.Background(), "conn-1234")
+	require.NoError(t, UnaryClientRequestID()(ctx, "/test.Service/Get", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"conn-1234"}, sent.Get("x-request-id"))
+}
+
+func TestUnaryAPIVersion(t *testing.T) {
+	interceptor := UnaryAPIVersion(capabilities.APIVersion3)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	var version int
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		version = capabilities.APIVersionFrom(ctx)
+		return "ok", nil
+	}
+	withVersion := func(value string) context.Context {
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(capabilities.MetadataKey, value))
+	}
+
+	_, err := interceptor(withVersion("3"), nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, 3, version)
+
+	// Callers that send no version speak version 2
+	_, err = interceptor(context.Background(), nil, info, handler)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	_, err = UnaryAPIVersion(capabilities.APIVersion2)(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	assert.Equal(t, capabilities.APIVersion2, version)
+
+	_, err = interceptor(withVersion("three"), nil, info, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// Health checks are answered for any version
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Health"}, handler)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,85 @@
+package interceptors
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/dungeongate/pkg/ratelimit"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// retryAfterHeader tells rate limited callers how many seconds to wait
+const retryAfterHeader = "retry-after"
+
+// UnaryRateLimit rejects calls over the policy's limits with
+// RESOURCE_EXHAUSTED, except health checks
+func UnaryRateLimit(policy *ratelimit.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := limit(ctx, policy, info.FullMethod, grpcCaller(ctx, req)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit rejects streams over the policy's limits. The stream's
+// first message is not read yet, so streams are limited by peer address.
+func StreamRateLimit(policy *ratelimit.Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := limit(ss.Context(), policy, info.FullMethod, grpcCaller(ss.Context(), nil)); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// limit takes a token for a call, returning the error to reject it with
+func limit(ctx context.Context, policy *ratelimit.Policy, method string, caller ratelimit.Caller) error {
+	if isHealthCheck(method) {
+		return nil
+	}
+	ok, wait := policy.Allow(method, caller)
+	if ok {
+		return nil
+	}
+
+	grpc.SetHeader(ctx, metadata.Pairs(retryAfterHeader, ratelimit.RetryAfter(wait)))
+	st := status.New(codes.ResourceExhausted, "rate limit exceeded")
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait.Round(time.Millisecond))}); err == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+// grpcCaller identifies the caller of a call. Requests relayed for a player
+// carry the player's address and username or token, which are used over the
+// relaying service's own address.
+func grpcCaller(ctx context.Context, req interface{}) ratelimit.Caller {
+	var caller ratelimit.Caller
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		caller.IP = p.Addr.String()
+		if host, _, err := net.SplitHostPort(caller.IP); err == nil {
+			caller.IP = host
+		}
+	}
+	if r, ok := req.(interface{ GetClientIp() string }); ok && r.GetClientIp() != "" {
+		caller.IP = r.GetClientIp()
+	}
+
+	if r, ok := req.(interface{ GetUsername() string }); ok && r.GetUsername() != "" {
+		caller.User = r.GetUsername()
+	} else if r, ok := req.(interface{ GetAccessToken() string }); ok && r.GetAccessToken() != "" {
+		caller.User = "token:" + r.GetAccessToken()
+	}
+	if r, ok := req.(interface{ GetKey() string }); ok && r.GetKey() != "" {
+		caller.APIKey = r.GetKey()
+	}
+	return caller
+}
@@ -0,0 +1,84 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dungeongate/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDHeader carries the request ID in gRPC metadata
+var requestIDHeader = strings.ToLower(logging.RequestIDHeader)
+
+// UnaryRequestID gives each call the caller's request ID, or a new one, so
+// everything logged for it can be correlated across services
+func UnaryRequestID() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(incomingRequestID(ctx), req)
+	}
+}
+
+// StreamRequestID gives each stream the caller's request ID, or a new one
+func StreamRequestID() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: incomingRequestID(ss.Context())})
+	}
+}
+
+// contextStream is a server stream whose context carries values added by
+// an interceptor, such as the request ID
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context implements grpc.ServerStream
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// incomingRequestID returns ctx carrying the request ID from the call's
+// metadata, or a new one when the caller sent none that is valid
+func incomingRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var id string
+	if values := md.Get(requestIDHeader); len(values) > 0 && logging.ValidRequestID(values[0]) {
+		id = values[0]
+	} else {
+		id = logging.NewRequestID()
+	}
+	return logging.WithRequestID(ctx, id)
+}
+
+// UnaryClientRequestID sends the request ID of a call's context with it
+func UnaryClientRequestID() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestID sends the request ID of a stream's context with it
+func StreamClientRequestID() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// outgoingRequestID adds the request ID of ctx to its outgoing metadata
+func outgoingRequestID(ctx context.Context) context.Context {
+	if id := logging.RequestID(ctx); id != "" {
+		return metadata.AppendToOutgoingContext(ctx, requestIDHeader, id)
+	}
+	return ctx
+}
+
+// WithRequestID returns the dial options that propagate request IDs to the
+// services called
+func WithRequestID() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(UnaryClientRequestID()),
+		grpc.WithChainStreamInterceptor(StreamClientRequestID()),
+	}
+}
@@ -0,0 +1,176 @@
+// Package iocapture reads and writes encrypted captures of a game session's
+// terminal I/O: the keystrokes a player sent and the output chunks they were
+// sent, each with the time it passed through the game service. Captures are
+// taken to debug rendering and latency complaints, and hold everything a
+// player typed, so every record is sealed with AES-256-GCM.
+//
+// A capture file is the Magic header followed by records, each a 4-byte
+// big-endian length, a nonce and the sealed event.
+package iocapture
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Magic starts every capture file
+const Magic = "DGIOCAP1"
+
+// KeySize is the size of capture keys
+const KeySize = 32
+
+// maxRecordSize bounds records when reading, so a corrupt length cannot
+// exhaust memory
+const maxRecordSize = 1 << 20
+
+// Direction is which way an event passed through the game service
+type Direction byte
+
+const (
+	// Input is data the player sent to the game
+	Input Direction = 'i'
+	// Output is data the game sent to the player
+	Output Direction = 'o'
+)
+
+// String returns "input" or "output"
+func (d Direction) String() string {
+	switch d {
+	case Input:
+		return "input"
+	case Output:
+		return "output"
+	default:
+		return fmt.Sprintf("direction(%d)", byte(d))
+	}
+}
+
+// Event is one chunk of captured I/O
+type Event struct {
+	Time      time.Time
+	Direction Direction
+	Data      []byte
+}
+
+// ParseKey parses a capture key given as 64 hex digits or base64
+func ParseKey(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if key, err := hex.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	if key, err := base64.StdEncoding.DecodeString(s); err == nil && len(key) == KeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("capture key must be %d bytes, hex or base64 encoded", KeySize)
+}
+
+// newAEAD returns the cipher sealing records with key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("capture key must be %d bytes", KeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// Writer writes events to a capture. It is not safe for concurrent use.
+type Writer struct {
+	w    io.Writer
+	aead cipher.AEAD
+	buf  []byte
+}
+
+// NewWriter starts a capture on w sealed with key
+func NewWriter(w io.Writer, key []byte) (*Writer, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, Magic); err != nil {
+		return nil, fmt.Errorf("failed to write capture header: %w", err)
+	}
+	return &Writer{w: w, aead: aead}, nil
+}
+
+// Write appends an event to the capture
+func (w *Writer) Write(event Event) error {
+	plain := make([]byte, 9+len(event.Data))
+	binary.BigEndian.PutUint64(plain, uint64(event.Time.UnixNano()))
+	plain[8] = byte(event.Direction)
+	copy(plain[9:], event.Data)
+
+	nonceSize := w.aead.NonceSize()
+	size := nonceSize + len(plain) + w.aead.Overhead()
+	w.buf = append(w.buf[:0], make([]byte, 4+nonceSize)...)
+	binary.BigEndian.PutUint32(w.buf, uint32(size))
+	if _, err := rand.Read(w.buf[4:]); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	w.buf = w.aead.Seal(w.buf, w.buf[4:], plain, nil)
+	if _, err := w.w.Write(w.buf); err != nil {
+		return fmt.Errorf("failed to write capture record: %w", err)
+	}
+	return nil
+}
+
+// Reader reads the events of a capture
+type Reader struct {
+	r    *bufio.Reader
+	aead cipher.AEAD
+}
+
+// NewReader opens a capture on r sealed with key
+func NewReader(r io.Reader, key []byte) (*Reader, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	br := bufio.NewReader(r)
+	header := make([]byte, len(Magic))
+	if _, err := io.ReadFull(br, header); err != nil || string(header) != Magic {
+		return nil, errors.New("not a capture file")
+	}
+	return &Reader{r: br, aead: aead}, nil
+}
+
+// Next returns the next event, or io.EOF after the last
+func (r *Reader) Next() (Event, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r.r, length[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return Event{}, fmt.Errorf("truncated capture record: %w", err)
+		}
+		return Event{}, err
+	}
+	size := binary.BigEndian.Uint32(length[:])
+	nonceSize := r.aead.NonceSize()
+	if size > maxRecordSize || int(size) < nonceSize+r.aead.Overhead()+9 {
+		return Event{}, fmt.Errorf("invalid capture record size %d", size)
+	}
+	record := make([]byte, size)
+	if _, err := io.ReadFull(r.r, record); err != nil {
+		return Event{}, fmt.Errorf("truncated capture record: %w", err)
+	}
+	plain, err := r.aead.Open(nil, record[:nonceSize], record[nonceSize:], nil)
+	if err != nil {
+		return Event{}, errors.New("failed to decrypt capture record: wrong key or corrupt file")
+	}
+	return Event{
+		Time:      time.Unix(0, int64(binary.BigEndian.Uint64(plain))),
+		Direction: Direction(plain[8]),
+		Data:      plain[9:],
+	}, nil
+}
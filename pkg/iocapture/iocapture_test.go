@@ -0,0 +1,62 @@
+package iocapture
+
+import (
+	"bytes"
+	"encoding/hex"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, KeySize)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, key)
+	require.NoError(t, err)
+
+	start := time.Unix(1700000000, 123456789)
+	events := []Event{
+		{Time: start, Direction: Input, Data: []byte("k")},
+		{Time: start.Add(35 * time.Millisecond), Direction: Output, Data: []byte("\x1b[2;5Hk")},
+	}
+	for _, event := range events {
+		require.NoError(t, w.Write(event))
+	}
+	assert.NotContains(t, buf.String(), "\x1b[2;5H", "events are encrypted")
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), key)
+	require.NoError(t, err)
+	for _, want := range events {
+		got, err := r.Next()
+		require.NoError(t, err)
+		assert.True(t, want.Time.Equal(got.Time))
+		assert.Equal(t, want.Direction, got.Direction)
+		assert.Equal(t, want.Data, got.Data)
+	}
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+
+	// The wrong key cannot read it
+	r, err = NewReader(bytes.NewReader(buf.Bytes()), bytes.Repeat([]byte{8}, KeySize))
+	require.NoError(t, err)
+	_, err = r.Next()
+	assert.Error(t, err)
+}
+
+func TestParseKey(t *testing.T) {
+	key := bytes.Repeat([]byte{0xab}, KeySize)
+
+	parsed, err := ParseKey(hex.EncodeToString(key))
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	parsed, err = ParseKey("q6urq6urq6urq6urq6urq6urq6urq6urq6urq6urq6s=")
+	require.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseKey("abcd")
+	assert.Error(t, err)
+}
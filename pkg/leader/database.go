@@ -0,0 +1,165 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// createLeaseTable creates the table of leases, one row per lease
+const createLeaseTable = `
+	CREATE TABLE IF NOT EXISTS leader_leases (
+		name VARCHAR(100) PRIMARY KEY,
+		holder VARCHAR(255) NOT NULL,
+		expires_at TIMESTAMP NOT NULL
+	)`
+
+// DatabaseLease holds leadership as a row of the leader_leases table. The
+// holder renews the row every third of the lease duration; any replica may
+// take the row over once it has expired. Replica clocks must roughly agree.
+type DatabaseLease struct {
+	db       *sql.DB
+	name     string
+	identity string
+	duration time.Duration
+	logger   *slog.Logger
+
+	tableReady bool
+
+	mu sync.Mutex
+	// until is when this replica stops considering itself leader unless it
+	// renews the lease; zero while another replica leads
+	until time.Time
+}
+
+// NewDatabaseLease creates an elector competing for the named lease in db
+func NewDatabaseLease(db *sql.DB, name, identity string, duration time.Duration, logger *slog.Logger) *DatabaseLease {
+	return &DatabaseLease{
+		db:       db,
+		name:     name,
+		identity: identity,
+		duration: duration,
+		logger:   logger,
+	}
+}
+
+// IsLeader reports whether this replica holds the lease
+func (l *DatabaseLease) IsLeader() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return time.Now().Before(l.until)
+}
+
+// Run tries to take or renew the lease every third of the lease duration
+// until ctx is cancelled, then gives the lease up so another replica can
+// take over at once
+func (l *DatabaseLease) Run(ctx context.Context) {
+	retry := l.duration / 3
+	ticker := time.NewTicker(retry)
+	defer ticker.Stop()
+
+	for {
+		l.renew(ctx)
+		select {
+		case <-ctx.Done():
+			l.release()
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew takes or renews the lease and records the outcome
+func (l *DatabaseLease) renew(ctx context.Context) {
+	now := time.Now()
+	held, err := l.tryAcquire(ctx, now)
+	if err != nil {
+		// Keep leading until the lease we hold runs out; the database may
+		// be back before then
+		l.logger.Warn("Failed to renew leader lease", "error", err)
+		return
+	}
+
+	l.mu.Lock()
+	wasLeader := now.Before(l.until)
+	if held {
+		// Stop leading a third of a lease before the lease expires, so a
+		// replica taking it over never overlaps with this one
+		l.until = now.Add(l.duration - l.duration/3)
+	} else {
+		l.until = time.Time{}
+	}
+	l.mu.Unlock()
+
+	switch {
+	case held && !wasLeader:
+		l.logger.Info("Acquired leadership")
+	case !held && wasLeader:
+		l.logger.Warn("Lost leadership")
+	}
+}
+
+// tryAcquire renews the lease when this replica holds it, takes it over when
+// it has expired or does not exist yet, and reports whether it now holds it
+func (l *DatabaseLease) tryAcquire(ctx context.Context, now time.Time) (bool, error) {
+	if !l.tableReady {
+		if _, err := l.db.ExecContext(ctx, createLeaseTable); err != nil {
+			return false, fmt.Errorf("failed to create lease table: %w", err)
+		}
+		l.tableReady = true
+	}
+
+	expires := now.Add(l.duration).UTC()
+	result, err := l.db.ExecContext(ctx, `
+		UPDATE leader_leases
+		SET holder = $1, expires_at = $2
+		WHERE name = $3 AND (holder = $1 OR expires_at < $4)
+	`, l.identity, expires, l.name, now.UTC())
+	if err != nil {
+		return false, fmt.Errorf("failed to update lease: %w", err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if updated > 0 {
+		return true, nil
+	}
+
+	_, insertErr := l.db.ExecContext(ctx, `
+		INSERT INTO leader_leases (name, holder, expires_at) VALUES ($1, $2, $3)
+	`, l.name, l.identity, expires)
+	if insertErr == nil {
+		return true, nil
+	}
+
+	// The insert fails when another replica holds the lease; anything else
+	// is an error
+	var holder string
+	if err := l.db.QueryRowContext(ctx, `SELECT holder FROM leader_leases WHERE name = $1`, l.name).Scan(&holder); err != nil {
+		return false, fmt.Errorf("failed to insert lease: %w", insertErr)
+	}
+	return false, nil
+}
+
+// release gives up the lease when this replica holds it
+func (l *DatabaseLease) release() {
+	l.mu.Lock()
+	wasLeader := time.Now().Before(l.until)
+	l.until = time.Time{}
+	l.mu.Unlock()
+	if !wasLeader {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := l.db.ExecContext(ctx, `DELETE FROM leader_leases WHERE name = $1 AND holder = $2`, l.name, l.identity); err != nil {
+		l.logger.Warn("Failed to release leader lease", "error", err)
+		return
+	}
+	l.logger.Info("Released leadership")
+}
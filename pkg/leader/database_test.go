@@ -0,0 +1,142 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "leases.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestDatabaseLease_OneLeader(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	first := NewDatabaseLease(db, "jobs", "replica-1", time.Minute, discardLogger())
+	second := NewDatabaseLease(db, "jobs", "replica-2", time.Minute, discardLogger())
+	other := NewDatabaseLease(db, "other-jobs", "replica-2", time.Minute, discardLogger())
+
+	first.renew(ctx)
+	second.renew(ctx)
+	other.renew(ctx)
+	assert.True(t, first.IsLeader())
+	assert.False(t, second.IsLeader())
+	assert.True(t, other.IsLeader(), "leases are independent")
+
+	// Renewing keeps the lease with its holder
+	first.renew(ctx)
+	second.renew(ctx)
+	assert.True(t, first.IsLeader())
+	assert.False(t, second.IsLeader())
+
+	// Releasing hands the lease over at once
+	first.release()
+	assert.False(t, first.IsLeader())
+	second.renew(ctx)
+	assert.True(t, second.IsLeader())
+	first.renew(ctx)
+	assert.False(t, first.IsLeader())
+}
+
+func TestDatabaseLease_TakesOverExpiredLease(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+
+	first := NewDatabaseLease(db, "jobs", "replica-1", 3*time.Second, discardLogger())
+	second := NewDatabaseLease(db, "jobs", "replica-2", 3*time.Second, discardLogger())
+
+	// The first replica takes the lease, then stops renewing it
+	first.renew(ctx)
+	require.True(t, first.IsLeader())
+	second.renew(ctx)
+	require.False(t, second.IsLeader())
+
+	// It stops leading before the lease expires...
+	time.Sleep(2100 * time.Millisecond)
+	assert.False(t, first.IsLeader())
+	second.renew(ctx)
+	assert.False(t, second.IsLeader())
+
+	// ...and the second replica takes over once it has
+	time.Sleep(1100 * time.Millisecond)
+	second.renew(ctx)
+	assert.True(t, second.IsLeader())
+	first.renew(ctx)
+	assert.False(t, first.IsLeader())
+}
+
+func TestDatabaseLease_RunReleasesOnShutdown(t *testing.T) {
+	db := openTestDB(t)
+	lease := NewDatabaseLease(db, "jobs", "replica-1", time.Minute, discardLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		lease.Run(ctx)
+		close(done)
+	}()
+	require.Eventually(t, lease.IsLeader, time.Second, 10*time.Millisecond)
+
+	cancel()
+	<-done
+	assert.False(t, lease.IsLeader())
+
+	var leases int
+	require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM leader_leases`).Scan(&leases))
+	assert.Zero(t, leases)
+}
+
+func TestNew(t *testing.T) {
+	db := openTestDB(t)
+
+	elector, err := New(nil, db, "jobs", discardLogger())
+	require.NoError(t, err)
+	assert.Nil(t, elector)
+	elector, err = New(&config.LeaderElectionConfig{}, db, "jobs", discardLogger())
+	require.NoError(t, err)
+	assert.Nil(t, elector)
+
+	elector, err = New(&config.LeaderElectionConfig{Enabled: true, LeaseDuration: "30s"}, db, "jobs", discardLogger())
+	require.NoError(t, err)
+	lease, ok := elector.(*DatabaseLease)
+	require.True(t, ok)
+	assert.Equal(t, "jobs", lease.name)
+	assert.Equal(t, 30*time.Second, lease.duration)
+
+	elector, err = New(&config.LeaderElectionConfig{Enabled: true, Lease: "custom"}, db, "jobs", discardLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "custom", elector.(*DatabaseLease).name)
+
+	for _, cfg := range []*config.LeaderElectionConfig{
+		{Enabled: true, Backend: "zookeeper"},
+		{Enabled: true, LeaseDuration: "1s"},
+		{Enabled: true, LeaseDuration: "soon"},
+	} {
+		_, err := New(cfg, db, "jobs", discardLogger())
+		assert.Error(t, err)
+	}
+	_, err = New(&config.LeaderElectionConfig{Enabled: true}, nil, "jobs", discardLogger())
+	assert.Error(t, err)
+}
+
+func TestIdentity(t *testing.T) {
+	assert.NotEqual(t, Identity(), Identity())
+}
@@ -0,0 +1,101 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// namespaceFile holds the namespace of the pod's service account
+const namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// KubernetesLease holds leadership as a coordination.k8s.io Lease. The
+// service account needs get, create and update on leases.
+type KubernetesLease struct {
+	lock     *resourcelock.LeaseLock
+	duration time.Duration
+	logger   *slog.Logger
+	leading  atomic.Bool
+}
+
+// NewKubernetesLease creates an elector competing for the named Lease, in
+// the pod's namespace when namespace is empty. It must run in a pod.
+func NewKubernetesLease(namespace, name, identity string, duration time.Duration, logger *slog.Logger) (*KubernetesLease, error) {
+	kubeConfig, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create in-cluster config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	if namespace == "" {
+		namespace = podNamespace()
+	}
+	return &KubernetesLease{
+		lock: &resourcelock.LeaseLock{
+			LeaseMeta:  metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Client:     clientset.CoordinationV1(),
+			LockConfig: resourcelock.ResourceLockConfig{Identity: identity},
+		},
+		duration: duration,
+		logger:   logger.With("namespace", namespace),
+	}, nil
+}
+
+// IsLeader reports whether this replica holds the Lease
+func (l *KubernetesLease) IsLeader() bool {
+	return l.leading.Load()
+}
+
+// Run campaigns for the Lease until ctx is cancelled, then releases it.
+// After losing the Lease it campaigns again.
+func (l *KubernetesLease) Run(ctx context.Context) {
+	for ctx.Err() == nil {
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock:            l.lock,
+			Name:            l.lock.LeaseMeta.Name,
+			LeaseDuration:   l.duration,
+			RenewDeadline:   l.duration * 2 / 3,
+			RetryPeriod:     l.duration / 6,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(context.Context) {
+					l.leading.Store(true)
+					l.logger.Info("Acquired leadership")
+				},
+				OnStoppedLeading: func() {
+					if l.leading.Swap(false) {
+						l.logger.Warn("Lost leadership")
+					}
+				},
+			},
+		})
+		if err != nil {
+			l.logger.Error("Failed to start leader election", "error", err)
+			return
+		}
+		elector.Run(ctx)
+	}
+}
+
+// podNamespace returns the namespace the pod runs in, or "default"
+func podNamespace() string {
+	if data, err := os.ReadFile(namespaceFile); err == nil {
+		if namespace := strings.TrimSpace(string(data)); namespace != "" {
+			return namespace
+		}
+	}
+	return "default"
+}
@@ -0,0 +1,91 @@
+// Package leader elects one replica of a service to run the background jobs
+// that must run once, such as storage cleanup. Replicas compete for a lease
+// held in the service's database or in Kubernetes; the holder renews it while
+// it runs and another replica takes over once it stops being renewed.
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// Backends that can hold the lease
+const (
+	BackendDatabase   = "database"
+	BackendKubernetes = "kubernetes"
+)
+
+const (
+	// defaultLeaseDuration is how long a lease lasts unrenewed by default
+	defaultLeaseDuration = 15 * time.Second
+	// minLeaseDuration leaves room for the renewals within a lease
+	minLeaseDuration = 3 * time.Second
+)
+
+// Elector campaigns for leadership while it runs. It implements
+// scheduler.Leader, so a scheduler runs singleton jobs only while it leads.
+type Elector interface {
+	// IsLeader reports whether this replica holds the lease
+	IsLeader() bool
+	// Run campaigns until ctx is cancelled, then gives up the lease
+	Run(ctx context.Context)
+}
+
+// New creates the elector server.leader_election asks for, or nil when
+// election is disabled. db holds the lease for the database backend;
+// defaultLease names the lease when the config does not.
+func New(cfg *config.LeaderElectionConfig, db *sql.DB, defaultLease string, logger *slog.Logger) (Elector, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	lease := cfg.Lease
+	if lease == "" {
+		lease = defaultLease
+	}
+	duration := defaultLeaseDuration
+	if cfg.LeaseDuration != "" {
+		var err error
+		if duration, err = time.ParseDuration(cfg.LeaseDuration); err != nil {
+			return nil, fmt.Errorf("invalid leader_election.lease_duration %q: %w", cfg.LeaseDuration, err)
+		}
+	}
+	if duration < minLeaseDuration {
+		return nil, fmt.Errorf("leader_election.lease_duration %s is shorter than %s", duration, minLeaseDuration)
+	}
+
+	identity := Identity()
+	logger = logger.With("lease", lease, "identity", identity)
+
+	switch cfg.Backend {
+	case "", BackendDatabase:
+		if db == nil {
+			return nil, fmt.Errorf("leader election with the database backend needs a database")
+		}
+		return NewDatabaseLease(db, lease, identity, duration, logger), nil
+	case BackendKubernetes:
+		return NewKubernetesLease(cfg.Namespace, lease, identity, duration, logger)
+	default:
+		return nil, fmt.Errorf("unknown leader_election.backend %q: want %s or %s", cfg.Backend, BackendDatabase, BackendKubernetes)
+	}
+}
+
+// Identity names this replica as a lease holder: the host name, which is the
+// pod name in Kubernetes, and a random suffix telling restarts apart
+func Identity() string {
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		host = "unknown"
+	}
+	suffix := make([]byte, 4)
+	_, _ = rand.Read(suffix)
+	return host + "-" + hex.EncodeToString(suffix)
+}
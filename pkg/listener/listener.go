@@ -0,0 +1,153 @@
+// Package listener opens the sockets services serve on: their TCP ports by
+// default, unix sockets for a reverse proxy on the same host, or sockets
+// passed by systemd socket activation.
+package listener
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// Endpoints a service may listen on
+const (
+	HTTP = "http"
+	GRPC = "grpc"
+	SSH  = "ssh"
+)
+
+const (
+	// DefaultSocketMode is the permission of unix sockets when none is set
+	DefaultSocketMode fs.FileMode = 0660
+	// listenFDsStart is the first file descriptor systemd passes
+	listenFDsStart = 3
+)
+
+// Listeners opens the listeners of a service's endpoints. A nil Listeners
+// listens on TCP.
+type Listeners struct {
+	sockets map[string]string
+	mode    fs.FileMode
+
+	mu        sync.Mutex
+	activated map[string]net.Listener
+}
+
+// New creates the listeners configured by cfg, taking the sockets systemd
+// passed when activation is enabled. A nil cfg listens on TCP.
+func New(cfg *config.ListenConfig) (*Listeners, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	for endpoint := range cfg.UnixSockets {
+		if endpoint != HTTP && endpoint != GRPC && endpoint != SSH {
+			return nil, fmt.Errorf("unknown endpoint %q for a unix socket", endpoint)
+		}
+	}
+	l := &Listeners{sockets: cfg.UnixSockets, mode: DefaultSocketMode}
+	if cfg.SocketMode != "" {
+		mode, err := strconv.ParseUint(cfg.SocketMode, 8, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid socket mode %q: %w", cfg.SocketMode, err)
+		}
+		l.mode = fs.FileMode(mode)
+	}
+	if cfg.SystemdActivation {
+		activated, err := systemdListeners()
+		if err != nil {
+			return nil, err
+		}
+		l.activated = activated
+	}
+	return l, nil
+}
+
+// Listen returns the listener of an endpoint: the socket systemd passed for
+// it, its unix socket, or addr on TCP
+func (l *Listeners) Listen(endpoint, addr string) (net.Listener, error) {
+	if l == nil {
+		return net.Listen("tcp", addr)
+	}
+
+	l.mu.Lock()
+	activated, ok := l.activated[endpoint]
+	delete(l.activated, endpoint)
+	l.mu.Unlock()
+	if ok {
+		return activated, nil
+	}
+
+	if path := l.sockets[endpoint]; path != "" {
+		return l.listenUnix(path)
+	}
+	return net.Listen("tcp", addr)
+}
+
+// listenUnix listens on a unix socket, replacing one left by a previous run
+func (l *Listeners) listenUnix(path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&fs.ModeSocket == 0 {
+			return nil, fmt.Errorf("failed to listen on %s: not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket %s: %w", path, err)
+		}
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, l.mode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("failed to set permissions of %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+// systemdListeners takes the sockets systemd passed to the process, by
+// FileDescriptorName. The variables describing them are cleared so games
+// started by the service do not see them.
+func systemdListeners() (map[string]net.Listener, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	pid, err := strconv.Atoi(os.Getenv("LISTEN_PID"))
+	if err != nil || pid != os.Getpid() {
+		return nil, errors.New("systemd socket activation is enabled but no sockets were passed")
+	}
+	count, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || count < 1 {
+		return nil, errors.New("systemd socket activation is enabled but no sockets were passed")
+	}
+	names := strings.Split(os.Getenv("LISTEN_FDNAMES"), ":")
+
+	listeners := make(map[string]net.Listener, count)
+	for i := 0; i < count; i++ {
+		name := ""
+		if i < len(names) {
+			name = names[i]
+		}
+		file := os.NewFile(uintptr(listenFDsStart+i), name)
+		ln, err := net.FileListener(file)
+		file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to use systemd socket %q: %w", name, err)
+		}
+		if _, dup := listeners[name]; dup || name == "" {
+			ln.Close()
+			return nil, fmt.Errorf("systemd sockets need distinct FileDescriptorName settings, got %q", os.Getenv("LISTEN_FDNAMES"))
+		}
+		listeners[name] = ln
+	}
+	return listeners, nil
+}
@@ -0,0 +1,96 @@
+package listener
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestListen_TCPByDefault(t *testing.T) {
+	var listeners *Listeners
+	ln, err := listeners.Listen(HTTP, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+	assert.Equal(t, "tcp", ln.Addr().Network())
+
+	listeners, err = New(nil)
+	require.NoError(t, err)
+	assert.Nil(t, listeners)
+}
+
+func TestListen_UnixSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.sock")
+	listeners, err := New(&config.ListenConfig{UnixSockets: map[string]string{HTTP: path}, SocketMode: "0600"})
+	require.NoError(t, err)
+
+	ln, err := listeners.Listen(HTTP, "127.0.0.1:0")
+	require.NoError(t, err)
+	assert.Equal(t, "unix", ln.Addr().Network())
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	go func() {
+		if conn, err := ln.Accept(); err == nil {
+			conn.Write([]byte("ok"))
+			conn.Close()
+		}
+	}()
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	buf := make([]byte, 2)
+	_, err = conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(buf))
+	conn.Close()
+
+	// Endpoints without a socket stay on TCP
+	grpcLn, err := listeners.Listen(GRPC, "127.0.0.1:0")
+	require.NoError(t, err)
+	assert.Equal(t, "tcp", grpcLn.Addr().Network())
+	grpcLn.Close()
+
+	// A socket left behind by a crashed run is replaced
+	unixLn := ln.(*net.UnixListener)
+	unixLn.SetUnlinkOnClose(false)
+	ln.Close()
+	ln, err = listeners.Listen(HTTP, "")
+	require.NoError(t, err)
+	ln.Close()
+}
+
+func TestListen_RefusesToReplaceFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "http.sock")
+	require.NoError(t, os.WriteFile(path, []byte("data"), 0644))
+	listeners, err := New(&config.ListenConfig{UnixSockets: map[string]string{HTTP: path}})
+	require.NoError(t, err)
+
+	_, err = listeners.Listen(HTTP, "")
+	assert.Error(t, err)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(data))
+}
+
+func TestNew_Validates(t *testing.T) {
+	_, err := New(&config.ListenConfig{UnixSockets: map[string]string{"metrics": "/run/dg.sock"}})
+	assert.Error(t, err)
+
+	_, err = New(&config.ListenConfig{SocketMode: "rw-rw----"})
+	assert.Error(t, err)
+
+	// Sockets passed to another process are not taken
+	t.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()+1))
+	t.Setenv("LISTEN_FDS", "1")
+	_, err = New(&config.ListenConfig{SystemdActivation: true})
+	assert.Error(t, err)
+	_, set := os.LookupEnv("LISTEN_FDS")
+	assert.False(t, set)
+}
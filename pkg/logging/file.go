@@ -0,0 +1,140 @@
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// defaultMaxSize is the size in megabytes a log file is rotated at when the
+// configuration sets none
+const defaultMaxSize = 100
+
+var (
+	// fileSinksMu guards fileSinks
+	fileSinksMu sync.Mutex
+	// fileSinks are the open log files, reopened by Reopen
+	fileSinks []*lumberjack.Logger
+)
+
+// createFileWriter creates a log file writer. The file is rotated when it
+// reaches its maximum size and, with RotateEvery, on a schedule; rotated
+// files are optionally compressed and removed once too many or too old.
+func createFileWriter(config *LogFile) (io.Writer, error) {
+	// Ensure directory exists
+	directory := config.Directory
+	if directory == "" {
+		directory = "."
+	}
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	if config.Filename == "" {
+		return nil, fmt.Errorf("filename is required")
+	}
+
+	// Parse max size
+	maxSize := defaultMaxSize
+	if config.MaxSize != "" {
+		size, err := parseSize(config.MaxSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_size: %w", err)
+		}
+		maxSize = size
+	}
+
+	// Parse max age; none keeps rotated files regardless of age
+	var maxAge int
+	if config.MaxAge != "" {
+		age, err := parseAge(config.MaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_age: %w", err)
+		}
+		maxAge = age
+	}
+
+	var rotateEvery time.Duration
+	if config.RotateEvery != "" {
+		every, err := time.ParseDuration(config.RotateEvery)
+		if err != nil || every <= 0 {
+			return nil, fmt.Errorf("invalid rotate_every: %q", config.RotateEvery)
+		}
+		rotateEvery = every
+	}
+
+	file := &lumberjack.Logger{
+		Filename:   filepath.Join(directory, config.Filename),
+		MaxSize:    maxSize,
+		MaxBackups: config.MaxFiles,
+		MaxAge:     maxAge,
+		Compress:   config.Compress,
+	}
+
+	fileSinksMu.Lock()
+	fileSinks = append(fileSinks, file)
+	fileSinksMu.Unlock()
+
+	if rotateEvery > 0 {
+		go rotateOnSchedule(file, rotateEvery)
+	}
+	return file, nil
+}
+
+// rotateOnSchedule rotates a log file every interval, for the lifetime of
+// the process
+func rotateOnSchedule(file *lumberjack.Logger, every time.Duration) {
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := file.Rotate(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: Failed to rotate log file %s: %v\n", file.Filename, err)
+		}
+	}
+}
+
+// Reopen closes the open log files; each is opened again at its path by the
+// next record written to it. External tools such as logrotate move a log file
+// away and then ask for it to be reopened.
+func Reopen() error {
+	fileSinksMu.Lock()
+	defer fileSinksMu.Unlock()
+
+	var firstErr error
+	for _, file := range fileSinks {
+		if err := file.Close(); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("failed to close log file %s: %w", file.Filename, err)
+		}
+	}
+	return firstErr
+}
+
+// ReopenOnHangup reopens the log files whenever the process receives
+// SIGHUP. It does nothing when no log file is open.
+func ReopenOnHangup(logger *slog.Logger) {
+	fileSinksMu.Lock()
+	open := len(fileSinks)
+	fileSinksMu.Unlock()
+	if open == 0 {
+		return
+	}
+
+	hangups := make(chan os.Signal, 1)
+	signal.Notify(hangups, syscall.SIGHUP)
+	go func() {
+		for range hangups {
+			if err := Reopen(); err != nil {
+				logger.Error("Failed to reopen log files", "error", err)
+				continue
+			}
+			logger.Info("Reopened log files")
+		}
+	}()
+}
@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestFileWriter_ReopenAfterMove(t *testing.T) {
+	dir := t.TempDir()
+	logger := NewLogger("test", FromConfig(&config.LoggingConfig{
+		Output: "file",
+		File:   &config.FileConfig{Directory: dir, Filename: "test.log"},
+	}))
+	path := filepath.Join(dir, "test.log")
+
+	logger.Info("before rotation")
+	// logrotate moves the file away, then asks for it to be reopened
+	require.NoError(t, os.Rename(path, path+".1"))
+	require.NoError(t, Reopen())
+	logger.Info("after rotation")
+
+	moved, err := os.ReadFile(path + ".1")
+	require.NoError(t, err)
+	assert.Contains(t, string(moved), "before rotation")
+	assert.NotContains(t, string(moved), "after rotation")
+	current, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(current), "after rotation")
+}
+
+func TestFileWriter_RotatesOnSchedule(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := createFileWriter(&LogFile{Directory: dir, Filename: "game.log", Compress: true, RotateEvery: "50ms"})
+	require.NoError(t, err)
+
+	_, err = writer.Write([]byte("first\n"))
+	require.NoError(t, err)
+
+	// The rotated file is compressed in the background
+	require.Eventually(t, func() bool {
+		matches, _ := filepath.Glob(filepath.Join(dir, "game-*.log.gz"))
+		return len(matches) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestFileWriter_InvalidConfig(t *testing.T) {
+	_, err := createFileWriter(&LogFile{Directory: t.TempDir()})
+	assert.Error(t, err)
+	_, err = createFileWriter(&LogFile{Directory: t.TempDir(), Filename: "x.log", RotateEvery: "daily"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,64 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// contextHandler is the handler of loggers created by NewLogger. It adds the
+// request ID of the context a record is logged with, and filters records by
+// the level of the logger's component, which can change at runtime.
+type contextHandler struct {
+	handler   slog.Handler
+	levels    *Levels
+	component string
+	// addComponent is set while the component is held back from handler and
+	// added to each record instead, so a logger given a component inside
+	// another, such as "ssh" inside "session-service", logs only the inner one
+	addComponent bool
+}
+
+// Enabled implements slog.Handler
+func (h contextHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	if h.levels == nil {
+		return h.handler.Enabled(ctx, level)
+	}
+	return h.levels.Enabled(h.component, level)
+}
+
+// Handle implements slog.Handler
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if h.addComponent {
+		record.AddAttrs(slog.String(ComponentKey, h.component))
+	}
+	if id := RequestID(ctx); id != "" {
+		record.AddAttrs(slog.String(RequestIDKey, id))
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+// WithAttrs implements slog.Handler, noting the component a logger is for
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	others := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		if attr.Key == ComponentKey {
+			h.component = attr.Value.String()
+			h.addComponent = true
+		} else {
+			others = append(others, attr)
+		}
+	}
+	h.handler = h.handler.WithAttrs(others)
+	return h
+}
+
+// WithGroup implements slog.Handler. The component goes to handler first, so
+// it stays outside the group.
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	if h.addComponent {
+		h.handler = h.handler.WithAttrs([]slog.Attr{slog.String(ComponentKey, h.component)})
+		h.addComponent = false
+	}
+	h.handler = h.handler.WithGroup(name)
+	return h
+}
@@ -0,0 +1,70 @@
+package logging
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+)
+
+// LevelPath is where services serve LevelHandler
+const LevelPath = "/admin/log-level"
+
+// LevelRequest sets the level of a service or one of its components
+type LevelRequest struct {
+	// Component is empty to set the level of the whole service
+	Component string `json:"component,omitempty"`
+	Level     string `json:"level"`
+	// Duration is how long the level lasts, such as "15m"; empty or longer
+	// than the service allows means the longest it allows
+	Duration string `json:"duration,omitempty"`
+}
+
+// LevelHandler serves the levels of a service: GET describes them, PUT sets
+// one from a LevelRequest, and DELETE reverts the level of the component
+// named by the "component" query parameter. Protect it with
+// apiauth.RequireServiceToken.
+func LevelHandler(levels *Levels, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if levels == nil {
+			apierror.WriteProblem(w, r, apierror.New(apierror.Unavailable, "log levels cannot be changed for this service"))
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			var req LevelRequest
+			if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 4096)).Decode(&req); err != nil {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "invalid level request body"))
+				return
+			}
+			level, err := ParseLevel(req.Level)
+			if err != nil {
+				apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, err.Error()))
+				return
+			}
+			var duration time.Duration
+			if req.Duration != "" {
+				if duration, err = time.ParseDuration(req.Duration); err != nil || duration <= 0 {
+					apierror.WriteProblem(w, r, apierror.New(apierror.InvalidRequest, "duration must be a positive duration such as 15m"))
+					return
+				}
+			}
+			expires := levels.Set(req.Component, level, duration)
+			logger.WarnContext(r.Context(), "Log level changed", "target_component", req.Component, "level", levelName(level), "expires_at", expires)
+		case http.MethodDelete:
+			component := r.URL.Query().Get(ComponentKey)
+			levels.Reset(component)
+			logger.InfoContext(r.Context(), "Log level reverted", "target_component", component)
+		default:
+			apierror.WriteProblem(w, r, apierror.New(apierror.MethodNotAllowed, "Method not allowed"))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(levels.Status())
+	})
+}
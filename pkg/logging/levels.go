@@ -0,0 +1,200 @@
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// ComponentKey is the attribute naming the component a logger belongs
+	// to, such as "pty", "ssh" or "menu"; levels can be set per component
+	ComponentKey = "component"
+	// DefaultLevelRevertAfter is how long a level changed at runtime lasts
+	// when the configuration sets no limit
+	DefaultLevelRevertAfter = 30 * time.Minute
+)
+
+// Levels are the log levels of a service: the configured level, and levels
+// set at runtime for the whole service or one component. Levels set at
+// runtime revert on their own, so debug logging is not left on by accident.
+type Levels struct {
+	configured  slog.Level
+	revertAfter time.Duration
+	now         func() time.Time
+
+	mu        sync.Mutex
+	overrides map[string]*levelOverride
+	// current is read on every record, so it is swapped whole on changes
+	current atomic.Pointer[levelSnapshot]
+}
+
+// levelOverride is a level set at runtime for a component, or for the
+// whole service under ""
+type levelOverride struct {
+	level   slog.Level
+	expires time.Time
+	timer   *time.Timer
+}
+
+// levelSnapshot is the effective level of the service and its components
+type levelSnapshot struct {
+	service    slog.Level
+	components map[string]slog.Level
+}
+
+// LevelOverride describes a level set at runtime
+type LevelOverride struct {
+	// Component is empty for the level of the whole service
+	Component string    `json:"component,omitempty"`
+	Level     string    `json:"level"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LevelStatus describes the levels of a service
+type LevelStatus struct {
+	Configured  string          `json:"configured"`
+	RevertAfter string          `json:"revert_after"`
+	Overrides   []LevelOverride `json:"overrides"`
+}
+
+// NewLevels creates the levels of a service logging at configured. Levels
+// set at runtime last at most revertAfter, or DefaultLevelRevertAfter when
+// it is not positive.
+func NewLevels(configured slog.Level, revertAfter time.Duration) *Levels {
+	if revertAfter <= 0 {
+		revertAfter = DefaultLevelRevertAfter
+	}
+	l := &Levels{
+		configured:  configured,
+		revertAfter: revertAfter,
+		now:         time.Now,
+		overrides:   make(map[string]*levelOverride),
+	}
+	l.current.Store(&levelSnapshot{service: configured})
+	return l
+}
+
+// Enabled reports whether a record at level is logged for component
+func (l *Levels) Enabled(component string, level slog.Level) bool {
+	snapshot := l.current.Load()
+	if min, ok := snapshot.components[component]; ok && component != "" {
+		return level >= min
+	}
+	return level >= snapshot.service
+}
+
+// Set sets the level of component, or of the whole service when component
+// is empty, until duration has passed. Durations that are not positive or
+// longer than the configured limit are set to the limit. It returns when
+// the level reverts.
+func (l *Levels) Set(component string, level slog.Level, duration time.Duration) time.Time {
+	if duration <= 0 || duration > l.revertAfter {
+		duration = l.revertAfter
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if existing, ok := l.overrides[component]; ok {
+		existing.timer.Stop()
+	}
+	override := &levelOverride{level: level, expires: l.now().Add(duration)}
+	override.timer = time.AfterFunc(duration, func() { l.expire(component, override) })
+	l.overrides[component] = override
+	l.publish()
+	return override.expires
+}
+
+// Reset reverts component, or the whole service when component is empty,
+// to the configured level
+func (l *Levels) Reset(component string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if existing, ok := l.overrides[component]; ok {
+		existing.timer.Stop()
+		delete(l.overrides, component)
+		l.publish()
+	}
+}
+
+// expire drops an override once its time is up, unless it was replaced
+func (l *Levels) expire(component string, override *levelOverride) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.overrides[component] == override {
+		delete(l.overrides, component)
+		l.publish()
+	}
+}
+
+// publish makes the overrides effective; l.mu must be held
+func (l *Levels) publish() {
+	snapshot := &levelSnapshot{service: l.configured, components: make(map[string]slog.Level, len(l.overrides))}
+	for component, override := range l.overrides {
+		if component == "" {
+			snapshot.service = override.level
+		} else {
+			snapshot.components[component] = override.level
+		}
+	}
+	l.current.Store(snapshot)
+}
+
+// Status describes the configured level and the levels set at runtime
+func (l *Levels) Status() LevelStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	status := LevelStatus{
+		Configured:  levelName(l.configured),
+		RevertAfter: l.revertAfter.String(),
+		Overrides:   make([]LevelOverride, 0, len(l.overrides)),
+	}
+	for component, override := range l.overrides {
+		status.Overrides = append(status.Overrides, LevelOverride{
+			Component: component,
+			Level:     levelName(override.level),
+			ExpiresAt: override.expires,
+		})
+	}
+	sort.Slice(status.Overrides, func(i, j int) bool {
+		return status.Overrides[i].Component < status.Overrides[j].Component
+	})
+	return status
+}
+
+// ParseLevel parses a level name: debug, info, warn or error
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// levelName returns the lower-case name of a level
+func levelName(level slog.Level) string {
+	return strings.ToLower(level.String())
+}
+
+// LevelsOf returns the levels of a logger created by NewLogger, or nil
+func LevelsOf(logger *slog.Logger) *Levels {
+	if h, ok := logger.Handler().(contextHandler); ok {
+		return h.levels
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLevels_ComponentOverride(t *testing.T) {
+	var buf bytes.Buffer
+	levels := NewLevels(slog.LevelInfo, time.Hour)
+	logger := slog.New(contextHandler{
+		handler: slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}),
+		levels:  levels,
+	})
+	pty := logger.With(ComponentKey, "pty")
+	menu := logger.With(ComponentKey, "menu")
+
+	pty.Debug("pty before")
+	levels.Set("pty", slog.LevelDebug, time.Minute)
+	pty.Debug("pty after")
+	menu.Debug("menu after")
+	logger.Debug("service after")
+
+	assert.NotContains(t, buf.String(), "pty before")
+	assert.Contains(t, buf.String(), "pty after")
+	assert.NotContains(t, buf.String(), "menu after")
+	assert.NotContains(t, buf.String(), "service after")
+
+	// A service-wide level applies to components without their own
+	levels.Set("", slog.LevelError, time.Minute)
+	menu.Warn("menu quiet")
+	pty.Debug("pty still loud")
+	assert.NotContains(t, buf.String(), "menu quiet")
+	assert.Contains(t, buf.String(), "pty still loud")
+
+	levels.Reset("pty")
+	levels.Reset("")
+	pty.Debug("pty reset")
+	menu.Info("menu reset")
+	assert.NotContains(t, buf.String(), "pty reset")
+	assert.Contains(t, buf.String(), "menu reset")
+	assert.Empty(t, levels.Status().Overrides)
+}
+
+func TestLevels_Revert(t *testing.T) {
+	levels := NewLevels(slog.LevelInfo, 50*time.Millisecond)
+
+	// Durations are capped at the configured limit
+	expires := levels.Set("ssh", slog.LevelDebug, time.Hour)
+	assert.WithinDuration(t, time.Now().Add(50*time.Millisecond), expires, 25*time.Millisecond)
+	assert.True(t, levels.Enabled("ssh", slog.LevelDebug))
+
+	require.Eventually(t, func() bool { return !levels.Enabled("ssh", slog.LevelDebug) }, time.Second, 5*time.Millisecond)
+	assert.Empty(t, levels.Status().Overrides)
+
+	// Setting a level again restarts its timer
+	levels.Set("ssh", slog.LevelDebug, 30*time.Millisecond)
+	levels.Set("ssh", slog.LevelWarn, 0)
+	time.Sleep(40 * time.Millisecond)
+	status := levels.Status()
+	require.Len(t, status.Overrides, 1)
+	assert.Equal(t, "warn", status.Overrides[0].Level)
+}
+
+func TestLevelHandler(t *testing.T) {
+	levels := NewLevels(slog.LevelInfo, time.Hour)
+	handler := LevelHandler(levels, slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)))
+
+	call := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rec
+	}
+
+	assert.Equal(t, http.StatusBadRequest, call(http.MethodPut, LevelPath, `{"level":"loud"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, call(http.MethodPut, LevelPath, `{"level":"debug","duration":"soon"}`).Code)
+	assert.Equal(t, http.StatusMethodNotAllowed, call(http.MethodPatch, LevelPath, "").Code)
+
+	rec := call(http.MethodPut, LevelPath, `{"component":"pty","level":"debug","duration":"10m"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status LevelStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.Equal(t, "info", status.Configured)
+	require.Len(t, status.Overrides, 1)
+	assert.Equal(t, LevelOverride{Component: "pty", Level: "debug", ExpiresAt: status.Overrides[0].ExpiresAt}, status.Overrides[0])
+	assert.True(t, levels.Enabled("pty", slog.LevelDebug))
+
+	rec = call(http.MethodDelete, LevelPath+"?component=pty", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.False(t, levels.Enabled("pty", slog.LevelDebug))
+}
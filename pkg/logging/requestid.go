@@ -0,0 +1,59 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// RequestIDKey is the attribute request IDs are logged under
+	RequestIDKey = "request_id"
+	// RequestIDHeader carries a request ID over HTTP and, lower-cased, in
+	// gRPC metadata
+	RequestIDHeader = "X-Request-ID"
+)
+
+// validRequestID bounds the request IDs accepted from callers, so a client
+// cannot inject arbitrary text into every log line of a request
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,64}$`)
+
+// requestIDContextKey keys the request ID in a context
+type requestIDContextKey struct{}
+
+// NewRequestID returns a new random request ID
+func NewRequestID() string {
+	return uuid.NewString()
+}
+
+// ValidRequestID reports whether id is acceptable as a caller's request ID
+func ValidRequestID(id string) bool {
+	return validRequestID.MatchString(id)
+}
+
+// WithRequestID returns a context carrying the request ID
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey{}, id)
+}
+
+// RequestID returns the request ID carried by ctx, or ""
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDContextKey{}).(string)
+	return id
+}
+
+// RequestIDMiddleware gives each HTTP request a request ID: the caller's
+// X-Request-ID when valid, otherwise a new one. The ID is echoed in the
+// response and carried by the request context.
+func RequestIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if !ValidRequestID(id) {
+			id = NewRequestID()
+		}
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(WithRequestID(r.Context(), id)))
+	})
+}
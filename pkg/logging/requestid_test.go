@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextHandler_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{handler: slog.NewTextHandler(&buf, nil)}).With("service", "test")
+
+	logger.InfoContext(WithRequestID(context.Background(), "abc-123"), "hello")
+	assert.Contains(t, buf.String(), "request_id=abc-123")
+
+	buf.Reset()
+	logger.Info("no context")
+	assert.NotContains(t, buf.String(), "request_id")
+
+	buf.Reset()
+	ContextLogger(WithRequestID(context.Background(), "abc-123"), logger).Info("contextual")
+	assert.Contains(t, buf.String(), "request_id=abc-123")
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestIDMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/games", nil)
+	req.Header.Set(RequestIDHeader, "client-42")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, "client-42", seen)
+	assert.Equal(t, "client-42", rec.Header().Get(RequestIDHeader))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/games", nil)
+	req.Header.Set(RequestIDHeader, "not a valid id")
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.NotEqual(t, "not a valid id", seen)
+	assert.Equal(t, seen, rec.Header().Get(RequestIDHeader))
+}
+
+func TestContextHandler_OneComponent(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(contextHandler{handler: slog.NewTextHandler(&buf, nil)}).With("service", "dungeongate")
+
+	logger.With(ComponentKey, "session-service").With(ComponentKey, "ssh").Info("hello")
+	assert.Equal(t, 1, strings.Count(buf.String(), "component="))
+	assert.Contains(t, buf.String(), "component=ssh")
+
+	buf.Reset()
+	logger.With(ComponentKey, "pty").WithGroup("game").Info("started", "id", "g1")
+	assert.Contains(t, buf.String(), "component=pty game.id=g1")
+}
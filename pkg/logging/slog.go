@@ -6,10 +6,10 @@ import (
 	"io"
 	"log/slog"
 	"os"
-	"path/filepath"
 	"strings"
+	"time"
 
-	"gopkg.in/natefinch/lumberjack.v2"
+	"github.com/dungeongate/pkg/config"
 )
 
 // Config represents slog-compatible logging configuration
@@ -19,6 +19,8 @@ type Config struct {
 	Output   string       `yaml:"output"` // stdout, stderr, file, journald
 	File     *LogFile     `yaml:"file,omitempty"`
 	Journald *LogJournald `yaml:"journald,omitempty"`
+	// LevelRevertAfter limits how long a level changed at runtime lasts
+	LevelRevertAfter string `yaml:"level_revert_after,omitempty"`
 }
 
 // LogFile represents file logging configuration
@@ -29,6 +31,8 @@ type LogFile struct {
 	MaxFiles  int    `yaml:"max_files"`
 	MaxAge    string `yaml:"max_age"`
 	Compress  bool   `yaml:"compress"`
+	// RotateEvery also rotates the file on a schedule, such as "24h"
+	RotateEvery string `yaml:"rotate_every"`
 }
 
 // LogJournald represents journald logging configuration
@@ -42,11 +46,18 @@ func NewLogger(serviceName string, config Config) *slog.Logger {
 	// Parse log level
 	level := parseLogLevel(config.Level)
 
-	// Create handler options
+	// Create handler options. Records are filtered by the service's levels,
+	// which may be lowered at runtime, so the handler itself passes all.
 	opts := &slog.HandlerOptions{
-		Level: level,
+		Level: slog.LevelDebug,
 	}
 
+	revertAfter, err := time.ParseDuration(config.LevelRevertAfter)
+	if err != nil && config.LevelRevertAfter != "" {
+		fmt.Fprintf(os.Stderr, "Warning: Invalid level_revert_after %q, using %s\n", config.LevelRevertAfter, DefaultLevelRevertAfter)
+	}
+	levels := NewLevels(level, revertAfter)
+
 	// Create writer based on output configuration
 	writer := createWriter(config)
 
@@ -58,8 +69,9 @@ func NewLogger(serviceName string, config Config) *slog.Logger {
 		handler = slog.NewTextHandler(writer, opts)
 	}
 
-	// Create logger with service context
-	logger := slog.New(handler)
+	// Create logger with service context. Records logged with a context
+	// carry its request ID.
+	logger := slog.New(contextHandler{handler: handler, levels: levels})
 	return logger.With("service", serviceName)
 }
 
@@ -79,8 +91,13 @@ func NewLoggerWithContext(serviceName string, config Config, fields map[string]a
 	return logger
 }
 
-// ContextLogger returns logger with context values
+// ContextLogger returns logger with context values, for code that logs
+// without passing the context along
 func ContextLogger(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if requestID := RequestID(ctx); requestID != "" {
+		logger = logger.With(RequestIDKey, requestID)
+	}
+
 	// Extract common context values and add them to the logger
 	if userID := ctx.Value("user_id"); userID != nil {
 		logger = logger.With("user_id", userID)
@@ -151,36 +168,6 @@ func createWriter(config Config) io.Writer {
 	}
 }
 
-// createFileWriter creates a rotating file writer
-func createFileWriter(config *LogFile) (io.Writer, error) {
-	// Ensure directory exists
-	if err := os.MkdirAll(config.Directory, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create log directory: %w", err)
-	}
-
-	// Parse max size
-	maxSize, err := parseSize(config.MaxSize)
-	if err != nil {
-		return nil, fmt.Errorf("invalid max_size: %w", err)
-	}
-
-	// Parse max age
-	maxAge, err := parseAge(config.MaxAge)
-	if err != nil {
-		return nil, fmt.Errorf("invalid max_age: %w", err)
-	}
-
-	filename := filepath.Join(config.Directory, config.Filename)
-
-	return &lumberjack.Logger{
-		Filename:   filename,
-		MaxSize:    maxSize,
-		MaxBackups: config.MaxFiles,
-		MaxAge:     maxAge,
-		Compress:   config.Compress,
-	}, nil
-}
-
 // createJournaldWriter creates a writer for journald
 func createJournaldWriter(config *LogJournald) io.Writer {
 	// For now, return stdout with identifier prefix since full journald integration
@@ -252,6 +239,43 @@ func LegacyConfig() Config {
 	}
 }
 
+// FromConfig converts a service's logging configuration, defaulting to info
+// level text on stdout
+func FromConfig(cfg *config.LoggingConfig) Config {
+	result := Config{Level: "info", Format: "text", Output: "stdout"}
+	if cfg == nil {
+		return result
+	}
+	if cfg.Level != "" {
+		result.Level = cfg.Level
+	}
+	if cfg.Format != "" {
+		result.Format = cfg.Format
+	}
+	if cfg.Output != "" {
+		result.Output = cfg.Output
+	}
+	result.LevelRevertAfter = cfg.LevelRevertAfter
+	if cfg.File != nil {
+		result.File = &LogFile{
+			Directory:   cfg.File.Directory,
+			Filename:    cfg.File.Filename,
+			MaxSize:     cfg.File.MaxSize,
+			MaxFiles:    cfg.File.MaxFiles,
+			MaxAge:      cfg.File.MaxAge,
+			Compress:    cfg.File.Compress,
+			RotateEvery: cfg.File.RotateEvery,
+		}
+	}
+	if cfg.Journald != nil {
+		result.Journald = &LogJournald{
+			Identifier: cfg.Journald.Identifier,
+			Fields:     cfg.Journald.Fields,
+		}
+	}
+	return result
+}
+
 // NewLoggerBasic creates a logger with basic string parameters
 func NewLoggerBasic(serviceName, level, format, output string) *slog.Logger {
 	config := Config{
@@ -19,6 +19,9 @@ type GameServiceMetrics struct {
 	SessionsActive       *prometheus.GaugeVec
 	SessionDuration      *prometheus.HistogramVec
 	SessionCrashes       *prometheus.CounterVec
+	SessionsDegraded     *prometheus.GaugeVec
+	SessionHangs         *prometheus.CounterVec
+	HungSessionKills     *prometheus.CounterVec
 
 	// Save Operations Metrics
 	SaveOperationsTotal   *prometheus.CounterVec
@@ -37,6 +40,11 @@ type GameServiceMetrics struct {
 	GameConfigReloads     *prometheus.CounterVec
 	GameSetupOperations   *prometheus.CounterVec
 	GameCleanupOperations *prometheus.CounterVec
+
+	// Storage Cleanup Metrics
+	StorageCleanupRuns           *prometheus.CounterVec
+	StorageCleanupFilesRemoved   *prometheus.CounterVec
+	StorageCleanupBytesReclaimed *prometheus.CounterVec
 }
 
 // NewGameServiceMetrics creates and registers all Game Service metrics
@@ -101,6 +109,24 @@ func NewGameServiceMetrics(namespace string) *GameServiceMetrics {
 			Name:      "crashes_total",
 			Help:      "Total number of game session crashes",
 		}, []string{"game_id", "crash_reason"}),
+		SessionsDegraded: promauto.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "session",
+			Name:      "degraded",
+			Help:      "Number of game sessions whose game is not responding",
+		}, []string{"game_id"}),
+		SessionHangs: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "session",
+			Name:      "hangs_total",
+			Help:      "Total number of times a game stopped responding, by reason (silent or write_timeout)",
+		}, []string{"game_id", "reason"}),
+		HungSessionKills: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "session",
+			Name:      "hung_kills_total",
+			Help:      "Total number of games force-killed while not responding",
+		}, []string{"game_id"}),
 
 		// Save Operations Metrics
 		SaveOperationsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
@@ -181,5 +207,25 @@ func NewGameServiceMetrics(namespace string) *GameServiceMetrics {
 			Name:      "cleanup_operations_total",
 			Help:      "Total number of game cleanup operations",
 		}, []string{"game_id", "operation", "status"}),
+
+		// Storage Cleanup Metrics
+		StorageCleanupRuns: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "cleanup_runs_total",
+			Help:      "Total number of storage cleanup runs",
+		}, []string{"status", "dry_run"}),
+		StorageCleanupFilesRemoved: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "cleanup_files_removed_total",
+			Help:      "Total number of files removed by storage cleanup (or that would be, in dry-run mode)",
+		}, []string{"category", "dry_run"}),
+		StorageCleanupBytesReclaimed: promauto.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "storage",
+			Name:      "cleanup_bytes_reclaimed_total",
+			Help:      "Total bytes reclaimed by storage cleanup (or that would be, in dry-run mode)",
+		}, []string{"category", "dry_run"}),
 	}
 }
@@ -5,18 +5,43 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
+	"os"
 	"strconv"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/status"
+
+	"github.com/dungeongate/pkg/buildinfo"
 )
 
 // CommonMetrics contains metrics shared by all services
 
+// InstanceLabel labels session service metrics with the replica they come
+// from, so replicas can be told apart and summed
+const InstanceLabel = "instance_id"
+
+// InstanceID names this replica: $DUNGEONGATE_INSTANCE_ID when set, else
+// $POD_NAME, which Kubernetes deployments set from the pod name through the
+// downward API, else the host name
+func InstanceID() string {
+	for _, env := range []string{"DUNGEONGATE_INSTANCE_ID", "POD_NAME"} {
+		if id := os.Getenv(env); id != "" {
+			return id
+		}
+	}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		return hostname
+	}
+	return "unknown"
+}
+
+// instanceFactory registers metrics labelled with the replica they come from
+func instanceFactory(instanceID string) promauto.Factory {
+	return promauto.With(prometheus.WrapRegistererWith(prometheus.Labels{InstanceLabel: instanceID}, prometheus.DefaultRegisterer))
+}
+
 // ServiceMetrics contains general service health metrics
 type ServiceMetrics struct {
 	// General service metrics
@@ -37,37 +62,50 @@ type ServiceMetrics struct {
 	DBQueriesTotal      *prometheus.CounterVec
 	DBQueryDuration     *prometheus.HistogramVec
 	DBErrors            *prometheus.CounterVec
+
+	// Panics recovered without taking the process down
+	PanicsRecovered *prometheus.CounterVec
+
+	// Scheduled job metrics
+	ScheduledJobRuns        *prometheus.CounterVec
+	ScheduledJobDuration    *prometheus.HistogramVec
+	ScheduledJobLastSuccess *prometheus.GaugeVec
 }
 
-// NewServiceMetrics creates and registers all service metrics
-func NewServiceMetrics(namespace string) *ServiceMetrics {
+// NewServiceMetrics creates and registers all service metrics, labelled
+// with the replica they come from when instanceID is set
+func NewServiceMetrics(namespace, instanceID string) *ServiceMetrics {
+	factory := promauto.With(prometheus.DefaultRegisterer)
+	if instanceID != "" {
+		factory = instanceFactory(instanceID)
+	}
 	return &ServiceMetrics{
-		BuildInfo: promauto.NewGaugeVec(prometheus.GaugeOpts{
+		BuildInfo: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "build_info",
 			Help:      "Build information",
 		}, []string{"version", "commit", "build_time"}),
-		StartTime: promauto.NewGauge(prometheus.GaugeOpts{
+		StartTime: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Name:      "start_time_seconds",
 			Help:      "Unix timestamp of service start time",
 		}),
 
 		// HTTP metrics
-		HTTPRequestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		HTTPRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "http",
 			Name:      "requests_total",
 			Help:      "Total number of HTTP requests",
 		}, []string{"method", "path", "status"}),
-		HTTPRequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		HTTPRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "http",
 			Name:      "request_duration_seconds",
 			Help:      "HTTP request duration in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"method", "path"}),
-		HTTPResponseSize: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		HTTPResponseSize: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "http",
 			Name:      "response_size_bytes",
@@ -76,13 +114,13 @@ func NewServiceMetrics(namespace string) *ServiceMetrics {
 		}, []string{"method", "path"}),
 
 		// gRPC metrics
-		GRPCRequestsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		GRPCRequestsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "grpc",
 			Name:      "requests_total",
 			Help:      "Total number of gRPC requests",
 		}, []string{"method", "status"}),
-		GRPCRequestDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		GRPCRequestDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "grpc",
 			Name:      "request_duration_seconds",
@@ -91,31 +129,58 @@ func NewServiceMetrics(namespace string) *ServiceMetrics {
 		}, []string{"method"}),
 
 		// Database metrics
-		DBConnectionsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		DBConnectionsActive: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: "database",
 			Name:      "connections_active",
 			Help:      "Number of active database connections",
 		}),
-		DBQueriesTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		DBQueriesTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "database",
 			Name:      "queries_total",
 			Help:      "Total number of database queries",
 		}, []string{"query_type", "table"}),
-		DBQueryDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		DBQueryDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "database",
 			Name:      "query_duration_seconds",
 			Help:      "Database query duration in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"query_type"}),
-		DBErrors: promauto.NewCounterVec(prometheus.CounterOpts{
+		DBErrors: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "database",
 			Name:      "errors_total",
 			Help:      "Total number of database errors",
 		}, []string{"error_type"}),
+
+		PanicsRecovered: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "panics_recovered_total",
+			Help:      "Total number of panics recovered in connection and game goroutines",
+		}, []string{"component"}),
+
+		// Scheduled job metrics
+		ScheduledJobRuns: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "job_runs_total",
+			Help:      "Total number of scheduled job runs by outcome: success, error, skipped or standby",
+		}, []string{"job", "status"}),
+		ScheduledJobDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "job_duration_seconds",
+			Help:      "Scheduled job run duration in seconds",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+		}, []string{"job"}),
+		ScheduledJobLastSuccess: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "job_last_success_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful run of a scheduled job",
+		}, []string{"job"}),
 	}
 }
 
@@ -123,6 +188,7 @@ func NewServiceMetrics(namespace string) *ServiceMetrics {
 type Registry struct {
 	serviceName    string
 	serviceVersion string
+	instanceID     string
 	buildTime      string
 	gitCommit      string
 	logger         *slog.Logger
@@ -142,22 +208,34 @@ func NewRegistry(serviceName, version, buildTime, gitCommit string, logger *slog
 	reg := &Registry{
 		serviceName:    serviceName,
 		serviceVersion: version,
+		instanceID:     InstanceID(),
 		buildTime:      buildTime,
 		gitCommit:      gitCommit,
 		logger:         logger,
 	}
 
-	// Initialize common service metrics
-	reg.Service = NewServiceMetrics("dungeongate")
+	// Initialize common service metrics. Session services run as several
+	// replicas, so their metrics carry the replica's name.
+	if serviceName == "session-service" {
+		reg.Service = NewServiceMetrics("dungeongate", reg.instanceID)
+	} else {
+		reg.Service = NewServiceMetrics("dungeongate", "")
+	}
 
 	// Initialize service-specific metrics based on service name
 	switch serviceName {
 	case "session-service":
-		reg.SessionService = NewSessionServiceMetrics("dungeongate")
+		reg.SessionService = NewSessionServiceMetrics("dungeongate", reg.instanceID)
 	case "game-service":
 		reg.GameService = NewGameServiceMetrics("dungeongate")
 	case "auth-service":
 		reg.AuthService = NewAuthServiceMetrics("dungeongate")
+	case "dungeongate":
+		// All-in-one binary runs every service in one process. Auth metrics
+		// get their own namespace since some names clash with session metrics.
+		reg.SessionService = NewSessionServiceMetrics("dungeongate", reg.instanceID)
+		reg.GameService = NewGameServiceMetrics("dungeongate")
+		reg.AuthService = NewAuthServiceMetrics("dungeongate_auth")
 	}
 
 	// Set build info
@@ -174,8 +252,9 @@ func (r *Registry) StartMetricsServer(port int) error {
 	mux.HandleFunc("/health", func(w http.ResponseWriter, req *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		w.Write([]byte(`{"status":"healthy","service":"` + r.serviceName + `"}`))
+		w.Write([]byte(`{"status":"healthy","service":"` + r.serviceName + `","version":"` + r.serviceVersion + `"}`))
 	})
+	mux.Handle(buildinfo.Path, buildinfo.New(r.serviceName, r.serviceVersion, r.buildTime, r.gitCommit).Handler())
 
 	r.server = &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
@@ -237,65 +316,187 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-// UnaryServerInterceptor returns a gRPC unary interceptor that instruments requests
-func (r *Registry) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		start := time.Now()
+// RecordGRPCRequest records a finished gRPC call or stream. It is called by
+// the shared interceptor chain in pkg/interceptors.
+func (r *Registry) RecordGRPCRequest(method, code string, duration time.Duration) {
+	r.Service.GRPCRequestsTotal.WithLabelValues(method, code).Inc()
+	r.Service.GRPCRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+}
+
+// RecordPanic counts a panic recovered in the given component, such as an SSH
+// connection handler or a PTY goroutine
+func (r *Registry) RecordPanic(component string) {
+	r.Service.PanicsRecovered.WithLabelValues(component).Inc()
+}
 
-		// Process request
-		resp, err := handler(ctx, req)
+// RecordSessionHang counts a game that stopped responding for the given
+// reason, either silent or write_timeout, until RecordSessionHangEnded
+func (r *Registry) RecordSessionHang(gameID, reason string) {
+	if r.GameService == nil {
+		return
+	}
+	r.GameService.SessionHangs.WithLabelValues(gameID, reason).Inc()
+	r.GameService.SessionsDegraded.WithLabelValues(gameID).Inc()
+}
 
-		// Record metrics
-		duration := time.Since(start)
-		statusCode := "OK"
-		if err != nil {
-			statusCode = status.Code(err).String()
-		}
+// RecordSessionHangEnded stops counting a game as not responding, because it
+// responded again or ended
+func (r *Registry) RecordSessionHangEnded(gameID string) {
+	if r.GameService == nil {
+		return
+	}
+	r.GameService.SessionsDegraded.WithLabelValues(gameID).Dec()
+}
+
+// RecordHungSessionKill counts a game force-killed while not responding
+func (r *Registry) RecordHungSessionKill(gameID string) {
+	if r.GameService == nil {
+		return
+	}
+	r.GameService.HungSessionKills.WithLabelValues(gameID).Inc()
+}
+
+// RecordJobRun counts a scheduled job run. Only runs that happened, successful
+// or not, are timed.
+func (r *Registry) RecordJobRun(job, status string, duration time.Duration) {
+	r.Service.ScheduledJobRuns.WithLabelValues(job, status).Inc()
+	if status == "success" || status == "error" {
+		r.Service.ScheduledJobDuration.WithLabelValues(job).Observe(duration.Seconds())
+	}
+	if status == "success" {
+		r.Service.ScheduledJobLastSuccess.WithLabelValues(job).SetToCurrentTime()
+	}
+}
 
-		// Extract method name from full method path
-		method := info.FullMethod
+// RecordSSHStaleClose counts an SSH connection closed for the given reason,
+// either idle_timeout or keepalive_timeout
+func (r *Registry) RecordSSHStaleClose(reason string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SSHConnectionsClosed.WithLabelValues(reason).Inc()
+}
 
-		r.Service.GRPCRequestsTotal.WithLabelValues(method, statusCode).Inc()
-		r.Service.GRPCRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+// RecordSSHIdleWarning counts a warning sent to an idle SSH connection
+func (r *Registry) RecordSSHIdleWarning() {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SSHIdleWarnings.Inc()
+}
 
-		// Log request with metrics correlation
-		r.logger.Info("gRPC request",
-			"method", method,
-			"status", statusCode,
-			"duration_ms", duration.Milliseconds(),
-		)
+// RecordConnectionRejected counts an SSH connection refused for the given
+// reason, either server_full or rate_limited
+func (r *Registry) RecordConnectionRejected(reason string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SSHConnectionsFailed.WithLabelValues(reason).Inc()
+}
 
-		return resp, err
+// RecordConnectionCounts sets the open and peak SSH connection gauges
+func (r *Registry) RecordConnectionCounts(active, peak int) {
+	if r.SessionService == nil {
+		return
 	}
+	r.SessionService.SSHConnectionsActive.Set(float64(active))
+	r.SessionService.SSHConnectionsPeak.Set(float64(peak))
 }
 
-// StreamServerInterceptor returns a gRPC stream interceptor that instruments streams
-func (r *Registry) StreamServerInterceptor() grpc.StreamServerInterceptor {
-	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
-		start := time.Now()
+// RecordGoroutines sets the running goroutines of an SSH connection subsystem
+func (r *Registry) RecordGoroutines(subsystem string, count int) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.ConnectionGoroutines.WithLabelValues(subsystem).Set(float64(count))
+}
 
-		// Process stream
-		err := handler(srv, ss)
+// RecordGoroutineLeak counts an SSH connection subsystem found leaking goroutines
+func (r *Registry) RecordGoroutineLeak(subsystem string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.ConnectionGoroutineLeaks.WithLabelValues(subsystem).Inc()
+}
 
-		// Record metrics
-		duration := time.Since(start)
-		statusCode := "OK"
-		if err != nil {
-			statusCode = status.Code(err).String()
-		}
+// RecordEchoLatency observes the time a game took to answer player input,
+// from the input arriving over SSH to the first output written back
+func (r *Registry) RecordEchoLatency(gameID string, latency time.Duration) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.EchoLatency.WithLabelValues(gameID).Observe(latency.Seconds())
+}
 
-		method := info.FullMethod
+// RecordEchoSLOBreach counts a game session whose echo latency exceeded the SLO
+func (r *Registry) RecordEchoSLOBreach(gameID string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.EchoSLOBreaches.WithLabelValues(gameID).Inc()
+}
 
-		r.Service.GRPCRequestsTotal.WithLabelValues(method, statusCode).Inc()
-		r.Service.GRPCRequestDuration.WithLabelValues(method).Observe(duration.Seconds())
+// RecordSpectatorIdleWarning counts a warning sent to an idle spectator
+func (r *Registry) RecordSpectatorIdleWarning() {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SpectatorIdleWarnings.Inc()
+}
+
+// RecordSpectatorEviction counts a spectator stopped watching a game for idling
+func (r *Registry) RecordSpectatorEviction(gameID string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SpectatorIdleEvictions.WithLabelValues(gameID).Inc()
+}
+
+// RecordPlayStateTransition counts a play session moving between states and
+// observes how long it spent in the state it left
+func (r *Registry) RecordPlayStateTransition(from, to string, duration time.Duration) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.PlayStateTransitions.WithLabelValues(from, to).Inc()
+	r.SessionService.PlayStateDuration.WithLabelValues(from).Observe(duration.Seconds())
+}
+
+// RecordPlayStateTimeout counts a player disconnected for staying in a play
+// session state longer than its timeout
+func (r *Registry) RecordPlayStateTimeout(state string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.PlayStateTimeouts.WithLabelValues(state).Inc()
+}
+
+// RecordIPBlock counts an address blocked after repeated authentication
+// failures of the given kind
+func (r *Registry) RecordIPBlock(reason string) {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SSHIPBlocksTotal.WithLabelValues(reason).Inc()
+}
 
-		// Log stream with metrics correlation
-		r.logger.Info("gRPC stream",
-			"method", method,
-			"status", statusCode,
-			"duration_ms", duration.Milliseconds(),
-		)
+// RecordBlockedConnection counts an SSH connection refused from a blocked address
+func (r *Registry) RecordBlockedConnection() {
+	if r.SessionService == nil {
+		return
+	}
+	r.SessionService.SSHBlockedConnsTotal.Inc()
+}
 
-		return err
+// RecordConnectionCountry counts an SSH connection from the given country,
+// accepted or denied by the GeoIP country lists
+func (r *Registry) RecordConnectionCountry(country string, accepted bool) {
+	if r.SessionService == nil {
+		return
+	}
+	result := "accepted"
+	if !accepted {
+		result = "denied"
 	}
+	r.SessionService.SSHCountryConnsTotal.WithLabelValues(country, result).Inc()
 }
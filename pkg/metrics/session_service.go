@@ -2,7 +2,6 @@ package metrics
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 // SessionServiceMetrics contains all Session Service related Prometheus metrics
@@ -12,6 +11,13 @@ type SessionServiceMetrics struct {
 	SSHConnectionsActive  prometheus.Gauge
 	SSHConnectionsFailed  *prometheus.CounterVec
 	SSHConnectionDuration *prometheus.HistogramVec
+	SSHConnectionsClosed  *prometheus.CounterVec
+	SSHIdleWarnings       prometheus.Counter
+	SSHConnectionsPeak    prometheus.Gauge
+
+	// Goroutine watchdog metrics
+	ConnectionGoroutines     *prometheus.GaugeVec
+	ConnectionGoroutineLeaks *prometheus.CounterVec
 
 	// SSH Session metrics
 	SSHSessionsTotal     *prometheus.CounterVec
@@ -20,10 +26,17 @@ type SessionServiceMetrics struct {
 	SSHSessionBytesRead  *prometheus.CounterVec
 	SSHSessionBytesWrite *prometheus.CounterVec
 
+	// Game session latency metrics
+	EchoLatency     *prometheus.HistogramVec
+	EchoSLOBreaches *prometheus.CounterVec
+
 	// SSH Authentication metrics
 	SSHAuthAttemptsTotal *prometheus.CounterVec
 	SSHAuthFailuresTotal *prometheus.CounterVec
 	SSHAuthDuration      *prometheus.HistogramVec
+	SSHIPBlocksTotal     *prometheus.CounterVec
+	SSHBlockedConnsTotal prometheus.Counter
+	SSHCountryConnsTotal *prometheus.CounterVec
 
 	// Terminal metrics
 	TerminalSizeChanges *prometheus.CounterVec
@@ -33,100 +46,174 @@ type SessionServiceMetrics struct {
 	SpectatorConnectionsTotal  *prometheus.CounterVec
 	SpectatorConnectionsActive prometheus.Gauge
 	SpectatingSessionsActive   prometheus.Gauge
+	SpectatorIdleWarnings      prometheus.Counter
+	SpectatorIdleEvictions     *prometheus.CounterVec
+
+	// Play session metrics
+	PlayStateTransitions *prometheus.CounterVec
+	PlayStateDuration    *prometheus.HistogramVec
+	PlayStateTimeouts    *prometheus.CounterVec
 }
 
-// NewSessionServiceMetrics creates and registers all Session Service metrics
-func NewSessionServiceMetrics(namespace string) *SessionServiceMetrics {
+// NewSessionServiceMetrics creates and registers all Session Service
+// metrics, labelled with the replica they come from
+func NewSessionServiceMetrics(namespace, instanceID string) *SessionServiceMetrics {
+	factory := instanceFactory(instanceID)
 	return &SessionServiceMetrics{
 		// SSH Connection metrics
-		SSHConnectionsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHConnectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "connections_total",
 			Help:      "Total number of SSH connections",
 		}, []string{"status"}),
-		SSHConnectionsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		SSHConnectionsActive: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "connections_active",
 			Help:      "Number of active SSH connections",
 		}),
-		SSHConnectionsFailed: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHConnectionsFailed: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "connections_failed_total",
 			Help:      "Total number of failed SSH connections",
 		}, []string{"reason"}),
-		SSHConnectionDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		SSHConnectionDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "connection_duration_seconds",
 			Help:      "SSH connection duration in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"status"}),
+		SSHConnectionsClosed: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "connections_closed_stale_total",
+			Help:      "Total number of SSH connections closed as idle or unresponsive",
+		}, []string{"reason"}),
+		SSHIdleWarnings: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "idle_warnings_total",
+			Help:      "Total number of idle disconnect warnings sent to SSH connections",
+		}),
+		SSHConnectionsPeak: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "connections_peak",
+			Help:      "Most SSH connections open at once since the service started",
+		}),
+
+		// Goroutine watchdog metrics
+		ConnectionGoroutines: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: "watchdog",
+			Name:      "goroutines",
+			Help:      "Running goroutines of each SSH connection subsystem",
+		}, []string{"subsystem"}),
+		ConnectionGoroutineLeaks: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "watchdog",
+			Name:      "goroutine_leaks_total",
+			Help:      "Times a connection subsystem was found with more goroutines than there are connections",
+		}, []string{"subsystem"}),
 
 		// SSH Session metrics
-		SSHSessionsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHSessionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "sessions_total",
 			Help:      "Total number of SSH sessions",
 		}, []string{"status"}),
-		SSHSessionsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		SSHSessionsActive: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "sessions_active",
 			Help:      "Number of active SSH sessions",
 		}),
-		SSHSessionDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		SSHSessionDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "session_duration_seconds",
 			Help:      "SSH session duration in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"user_type"}),
-		SSHSessionBytesRead: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHSessionBytesRead: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "session_bytes_read_total",
 			Help:      "Total bytes read from SSH sessions",
 		}, []string{"session_type"}),
-		SSHSessionBytesWrite: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHSessionBytesWrite: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "session_bytes_written_total",
 			Help:      "Total bytes written to SSH sessions",
 		}, []string{"session_type"}),
 
+		// Game session latency metrics
+		EchoLatency: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "game",
+			Name:      "echo_latency_seconds",
+			Help:      "Time from player input arriving over SSH to the first game output written back",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.15, 0.25, 0.5, 1, 2.5, 5, 10},
+		}, []string{"game_id"}),
+		EchoSLOBreaches: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "game",
+			Name:      "echo_slo_breaches_total",
+			Help:      "Total number of game sessions whose 95th percentile echo latency exceeded the SLO",
+		}, []string{"game_id"}),
+
 		// SSH Authentication metrics
-		SSHAuthAttemptsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHAuthAttemptsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "auth_attempts_total",
 			Help:      "Total number of SSH authentication attempts",
 		}, []string{"method", "username"}),
-		SSHAuthFailuresTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		SSHAuthFailuresTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "auth_failures_total",
 			Help:      "Total number of SSH authentication failures",
 		}, []string{"method", "reason"}),
-		SSHAuthDuration: promauto.NewHistogramVec(prometheus.HistogramOpts{
+		SSHAuthDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
 			Namespace: namespace,
 			Subsystem: "ssh",
 			Name:      "auth_duration_seconds",
 			Help:      "SSH authentication duration in seconds",
 			Buckets:   prometheus.DefBuckets,
 		}, []string{"method"}),
+		SSHIPBlocksTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "ip_blocks_total",
+			Help:      "Total number of addresses blocked after repeated authentication failures",
+		}, []string{"reason"}),
+		SSHBlockedConnsTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "blocked_connections_total",
+			Help:      "Total number of SSH connections refused from blocked addresses",
+		}),
+		SSHCountryConnsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "ssh",
+			Name:      "connections_by_country_total",
+			Help:      "Total number of SSH connections by client country",
+		}, []string{"country", "result"}),
 
 		// Terminal metrics
-		TerminalSizeChanges: promauto.NewCounterVec(prometheus.CounterOpts{
+		TerminalSizeChanges: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "terminal",
 			Name:      "size_changes_total",
 			Help:      "Total number of terminal size changes",
 		}, []string{"session_id"}),
-		TerminalTypes: promauto.NewCounterVec(prometheus.CounterOpts{
+		TerminalTypes: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "terminal",
 			Name:      "types_total",
@@ -134,23 +221,56 @@ func NewSessionServiceMetrics(namespace string) *SessionServiceMetrics {
 		}, []string{"type"}),
 
 		// Spectating metrics
-		SpectatorConnectionsTotal: promauto.NewCounterVec(prometheus.CounterOpts{
+		SpectatorConnectionsTotal: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: namespace,
 			Subsystem: "spectator",
 			Name:      "connections_total",
 			Help:      "Total number of spectator connections",
 		}, []string{"status"}),
-		SpectatorConnectionsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		SpectatorConnectionsActive: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: "spectator",
 			Name:      "connections_active",
 			Help:      "Number of active spectator connections",
 		}),
-		SpectatingSessionsActive: promauto.NewGauge(prometheus.GaugeOpts{
+		SpectatingSessionsActive: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: "spectator",
 			Name:      "sessions_active",
 			Help:      "Number of sessions being spectated",
 		}),
+		SpectatorIdleWarnings: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "spectator",
+			Name:      "idle_warnings_total",
+			Help:      "Total number of warnings sent to idle spectators",
+		}),
+		SpectatorIdleEvictions: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "spectator",
+			Name:      "idle_evictions_total",
+			Help:      "Total number of spectators stopped watching for idling",
+		}, []string{"game_id"}),
+
+		// Play session metrics
+		PlayStateTransitions: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "play_session",
+			Name:      "transitions_total",
+			Help:      "Total number of play session state transitions",
+		}, []string{"from", "to"}),
+		PlayStateDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "play_session",
+			Name:      "state_duration_seconds",
+			Help:      "Time players spent in each play session state",
+			Buckets:   []float64{0.1, 1, 5, 15, 60, 300, 900, 3600, 14400},
+		}, []string{"state"}),
+		PlayStateTimeouts: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "play_session",
+			Name:      "state_timeouts_total",
+			Help:      "Total number of players disconnected for staying in a play session state too long",
+		}, []string{"state"}),
 	}
 }
@@ -0,0 +1,30 @@
+// Package platform detects which process isolation features the host
+// operating system provides.
+package platform
+
+import (
+	"os"
+	"runtime"
+)
+
+// Platform describes which process isolation features the host supports
+type Platform struct {
+	OS         string
+	Namespaces bool
+	Cgroups    bool
+}
+
+// Detect reports the isolation features available on this host.
+// Namespaces and cgroups are Linux-only; macOS and Windows get neither.
+func Detect() Platform {
+	platform := Platform{OS: runtime.GOOS}
+	if runtime.GOOS != "linux" {
+		return platform
+	}
+
+	platform.Namespaces = true
+	if info, err := os.Stat("/sys/fs/cgroup"); err == nil && info.IsDir() {
+		platform.Cgroups = true
+	}
+	return platform
+}
@@ -0,0 +1,354 @@
+// Package proxyproto reads the PROXY protocol header that TCP load balancers
+// put in front of a connection, so servers behind them see the real client
+// address. Both the text (v1) and binary (v2) versions are supported.
+//
+// Binary headers may also carry the authority, the host name the client
+// asked the load balancer for (from TLS SNI, for example), which servers use
+// to tell apart the names they are reached by.
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHeaderTimeout bounds how long a trusted proxy may take to send the header
+const DefaultHeaderTimeout = 5 * time.Second
+
+var (
+	v1Prefix    = []byte("PROXY ")
+	v2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+)
+
+// v1MaxLength is the longest v1 header the specification allows, CRLF included
+const v1MaxLength = 107
+
+// pp2TypeAuthority is the v2 TLV holding the host name the client connected to
+const pp2TypeAuthority = 0x02
+
+// Header is what a PROXY header says about the connection it precedes. The
+// addresses are nil for headers that carry none (v1 UNKNOWN, v2 LOCAL or an
+// unsupported family); Authority is empty unless a v2 header carries one.
+type Header struct {
+	Source    net.Addr
+	Dest      net.Addr
+	Authority string
+}
+
+// ErrNoHeader is returned when a trusted proxy sends a connection without a PROXY header
+var ErrNoHeader = errors.New("missing PROXY protocol header")
+
+// Listener accepts connections and, for those coming from a trusted proxy,
+// reads the PROXY header to learn the client address. Connections from any
+// other peer are passed through untouched, so clients that bypass the load
+// balancer cannot claim another address.
+type Listener struct {
+	net.Listener
+	trusted       []*net.IPNet
+	headerTimeout time.Duration
+}
+
+// NewListener wraps a listener. trustedProxies lists the IP addresses or
+// CIDR ranges of the load balancers allowed to send PROXY headers; a zero
+// headerTimeout uses DefaultHeaderTimeout.
+func NewListener(inner net.Listener, trustedProxies []string, headerTimeout time.Duration) (*Listener, error) {
+	trusted, err := ParseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	if len(trusted) == 0 {
+		return nil, errors.New("PROXY protocol requires at least one trusted proxy")
+	}
+	if headerTimeout <= 0 {
+		headerTimeout = DefaultHeaderTimeout
+	}
+	return &Listener{Listener: inner, trusted: trusted, headerTimeout: headerTimeout}, nil
+}
+
+// ParseTrustedProxies parses a list of IP addresses and CIDR ranges
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	trusted, err := ParseNetworks(proxies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid trusted proxy: %w", err)
+	}
+	return trusted, nil
+}
+
+// ParseNetworks parses a list of IP addresses and CIDR ranges; an address
+// is a network of that one address
+func ParseNetworks(entries []string) ([]*net.IPNet, error) {
+	networks := make([]*net.IPNet, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid address %q", entry)
+			}
+			bits := 8 * len(ip.To16())
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 32
+			}
+			networks = append(networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q: %w", entry, err)
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// Accept waits for the next connection. The PROXY header is read on the
+// connection's first use rather than here, so a slow proxy never holds up
+// accepting other connections.
+func (l *Listener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	if !l.isTrusted(conn.RemoteAddr()) {
+		return conn, nil
+	}
+	return &Conn{Conn: conn, reader: bufio.NewReader(conn), headerTimeout: l.headerTimeout}, nil
+}
+
+// isTrusted reports whether addr belongs to a trusted proxy
+func (l *Listener) isTrusted(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	for _, network := range l.trusted {
+		if network.Contains(tcpAddr.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// Conn is a connection from a trusted proxy. RemoteAddr and LocalAddr report
+// the addresses from its PROXY header, or the proxy's own for headers that
+// carry none, such as health checks.
+type Conn struct {
+	net.Conn
+	reader        *bufio.Reader
+	headerTimeout time.Duration
+
+	once   sync.Once
+	header *Header
+	err    error
+}
+
+// readHeader reads the PROXY header the first time it is needed
+func (c *Conn) readHeader() {
+	c.once.Do(func() {
+		c.Conn.SetReadDeadline(time.Now().Add(c.headerTimeout))
+		c.header, c.err = Parse(c.reader)
+		if c.header == nil {
+			c.header = &Header{}
+		}
+		c.Conn.SetReadDeadline(time.Time{})
+	})
+}
+
+// Read reads data following the PROXY header
+func (c *Conn) Read(b []byte) (int, error) {
+	c.readHeader()
+	if c.err != nil {
+		return 0, c.err
+	}
+	return c.reader.Read(b)
+}
+
+// RemoteAddr returns the client address from the PROXY header
+func (c *Conn) RemoteAddr() net.Addr {
+	c.readHeader()
+	if c.header.Source != nil {
+		return c.header.Source
+	}
+	return c.Conn.RemoteAddr()
+}
+
+// LocalAddr returns the address the client connected to, from the PROXY header
+func (c *Conn) LocalAddr() net.Addr {
+	c.readHeader()
+	if c.header.Dest != nil {
+		return c.header.Dest
+	}
+	return c.Conn.LocalAddr()
+}
+
+// Authority returns the host name the client connected to, from the PROXY
+// header, or an empty string when the proxy sent none
+func (c *Conn) Authority() string {
+	c.readHeader()
+	return c.header.Authority
+}
+
+// Authority returns the host name a connection accepted by a Listener was
+// made to, or an empty string for other connections
+func Authority(conn net.Conn) string {
+	if c, ok := conn.(*Conn); ok {
+		return c.Authority()
+	}
+	return ""
+}
+
+// ReadHeader reads a v1 or v2 PROXY header from r. The addresses are nil for
+// headers that carry none (v1 UNKNOWN, v2 LOCAL or an unsupported family).
+func ReadHeader(r *bufio.Reader) (source, dest net.Addr, err error) {
+	header, err := Parse(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	return header.Source, header.Dest, nil
+}
+
+// Parse reads a v1 or v2 PROXY header from r
+func Parse(r *bufio.Reader) (*Header, error) {
+	prefix, err := r.Peek(len(v1Prefix))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PROXY header: %w", err)
+	}
+	if bytes.Equal(prefix, v1Prefix) {
+		source, dest, err := readV1(r)
+		if err != nil {
+			return nil, err
+		}
+		return &Header{Source: source, Dest: dest}, nil
+	}
+	signature, err := r.Peek(len(v2Signature))
+	if err == nil && bytes.Equal(signature, v2Signature) {
+		return readV2(r)
+	}
+	return nil, ErrNoHeader
+}
+
+// readV1 parses a header such as "PROXY TCP4 192.0.2.1 198.51.100.1 56324 2222\r\n"
+func readV1(r *bufio.Reader) (net.Addr, net.Addr, error) {
+	var line []byte
+	for len(line) < v1MaxLength {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read PROXY header: %w", err)
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, nil, errors.New("PROXY v1 header is too long or not terminated")
+	}
+
+	fields := strings.Fields(string(line))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, nil, fmt.Errorf("invalid PROXY v1 header %q", strings.TrimSpace(string(line)))
+	}
+	source, err := parseV1Addr(fields[2], fields[4])
+	if err != nil {
+		return nil, nil, err
+	}
+	dest, err := parseV1Addr(fields[3], fields[5])
+	if err != nil {
+		return nil, nil, err
+	}
+	return source, dest, nil
+}
+
+// parseV1Addr parses an address and port from a v1 header
+func parseV1Addr(host, port string) (*net.TCPAddr, error) {
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid PROXY v1 address %q", host)
+	}
+	p, err := strconv.ParseUint(port, 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PROXY v1 port %q", port)
+	}
+	return &net.TCPAddr{IP: ip, Port: int(p)}, nil
+}
+
+// readV2 parses a binary header: the signature, version and command, address
+// family, the length of what follows, then the addresses and any TLVs
+func readV2(r *bufio.Reader) (*Header, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read PROXY header: %w", err)
+	}
+	if header[12]>>4 != 2 {
+		return nil, fmt.Errorf("unsupported PROXY protocol version %d", header[12]>>4)
+	}
+	command := header[12] & 0x0f
+	family := header[13]
+	body := make([]byte, binary.BigEndian.Uint16(header[14:16]))
+	if _, err := io.ReadFull(r, body); err != nil {
+		return nil, fmt.Errorf("failed to read PROXY header: %w", err)
+	}
+
+	switch command {
+	case 0x0: // LOCAL: the proxy's own connection, such as a health check
+		return &Header{}, nil
+	case 0x1: // PROXY
+	default:
+		return nil, fmt.Errorf("unsupported PROXY v2 command %d", command)
+	}
+
+	var ipLen int
+	switch family {
+	case 0x11: // TCP over IPv4
+		ipLen = net.IPv4len
+	case 0x21: // TCP over IPv6
+		ipLen = net.IPv6len
+	default:
+		return &Header{}, nil
+	}
+	if len(body) < 2*ipLen+4 {
+		return nil, errors.New("PROXY v2 address block is too short")
+	}
+	parsed := &Header{
+		Source: &net.TCPAddr{
+			IP:   net.IP(body[:ipLen]),
+			Port: int(binary.BigEndian.Uint16(body[2*ipLen:])),
+		},
+		Dest: &net.TCPAddr{
+			IP:   net.IP(body[ipLen : 2*ipLen]),
+			Port: int(binary.BigEndian.Uint16(body[2*ipLen+2:])),
+		},
+	}
+	parsed.Authority = readAuthority(body[2*ipLen+4:])
+	return parsed, nil
+}
+
+// readAuthority returns the authority among the TLVs following the
+// addresses. Other TLVs are skipped, as is a malformed tail, since the
+// addresses are still good.
+func readAuthority(tlvs []byte) string {
+	for len(tlvs) >= 3 {
+		kind := tlvs[0]
+		length := int(binary.BigEndian.Uint16(tlvs[1:3]))
+		if len(tlvs) < 3+length {
+			return ""
+		}
+		if kind == pp2TypeAuthority {
+			return string(tlvs[3 : 3+length])
+		}
+		tlvs = tlvs[3+length:]
+	}
+	return ""
+}
@@ -0,0 +1,174 @@
+package proxyproto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func v2Header(command, family byte, addrs []byte) []byte {
+	header := append([]byte{}, v2Signature...)
+	header = append(header, 0x20|command, family)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(addrs)))
+	return append(header, addrs...)
+}
+
+func TestReadHeader_V1(t *testing.T) {
+	r := bufio.NewReader(strings.NewReader("PROXY TCP4 192.0.2.10 198.51.100.1 56324 2222\r\nSSH-2.0-test\r\n"))
+
+	source, dest, err := ReadHeader(r)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.10:56324", source.String())
+	assert.Equal(t, "198.51.100.1:2222", dest.String())
+
+	rest, _ := io.ReadAll(r)
+	assert.Equal(t, "SSH-2.0-test\r\n", string(rest))
+}
+
+func TestReadHeader_V1Unknown(t *testing.T) {
+	source, dest, err := ReadHeader(bufio.NewReader(strings.NewReader("PROXY UNKNOWN\r\n")))
+	require.NoError(t, err)
+	assert.Nil(t, source)
+	assert.Nil(t, dest)
+}
+
+func TestReadHeader_V2(t *testing.T) {
+	addrs := []byte{192, 0, 2, 10, 198, 51, 100, 1}
+	addrs = binary.BigEndian.AppendUint16(addrs, 56324)
+	addrs = binary.BigEndian.AppendUint16(addrs, 2222)
+	r := bufio.NewReader(bytes.NewReader(append(v2Header(0x1, 0x11, addrs), "SSH-2.0-test\r\n"...)))
+
+	source, dest, err := ReadHeader(r)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.10:56324", source.String())
+	assert.Equal(t, "198.51.100.1:2222", dest.String())
+
+	rest, _ := io.ReadAll(r)
+	assert.Equal(t, "SSH-2.0-test\r\n", string(rest))
+}
+
+func TestParse_V2Authority(t *testing.T) {
+	addrs := []byte{192, 0, 2, 10, 198, 51, 100, 1}
+	addrs = binary.BigEndian.AppendUint16(addrs, 56324)
+	addrs = binary.BigEndian.AppendUint16(addrs, 2222)
+	// A NOOP TLV before the authority is skipped
+	addrs = append(addrs, 0x04, 0x00, 0x02, 0, 0)
+	addrs = append(addrs, pp2TypeAuthority, 0x00, 0x10)
+	addrs = append(addrs, "play.example.org"...)
+	r := bufio.NewReader(bytes.NewReader(append(v2Header(0x1, 0x11, addrs), "SSH-2.0-test\r\n"...)))
+
+	header, err := Parse(r)
+	require.NoError(t, err)
+	assert.Equal(t, "192.0.2.10:56324", header.Source.String())
+	assert.Equal(t, "play.example.org", header.Authority)
+
+	rest, _ := io.ReadAll(r)
+	assert.Equal(t, "SSH-2.0-test\r\n", string(rest))
+}
+
+func TestReadHeader_V2IPv6(t *testing.T) {
+	addrs := append(net.ParseIP("2001:db8::10").To16(), net.ParseIP("2001:db8::1").To16()...)
+	addrs = binary.BigEndian.AppendUint16(addrs, 40000)
+	addrs = binary.BigEndian.AppendUint16(addrs, 22)
+
+	source, _, err := ReadHeader(bufio.NewReader(bytes.NewReader(v2Header(0x1, 0x21, addrs))))
+	require.NoError(t, err)
+	assert.Equal(t, "[2001:db8::10]:40000", source.String())
+}
+
+func TestReadHeader_V2Local(t *testing.T) {
+	source, dest, err := ReadHeader(bufio.NewReader(bytes.NewReader(v2Header(0x0, 0x00, nil))))
+	require.NoError(t, err)
+	assert.Nil(t, source)
+	assert.Nil(t, dest)
+}
+
+func TestReadHeader_Missing(t *testing.T) {
+	_, _, err := ReadHeader(bufio.NewReader(strings.NewReader("SSH-2.0-OpenSSH_9.6\r\n")))
+	assert.ErrorIs(t, err, ErrNoHeader)
+
+	_, _, err = ReadHeader(bufio.NewReader(strings.NewReader("PROXY TCP4 not-an-ip 198.51.100.1 1 2\r\n")))
+	assert.Error(t, err)
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	trusted, err := ParseTrustedProxies([]string{"10.0.0.0/8", "192.0.2.1", "::1"})
+	require.NoError(t, err)
+	require.Len(t, trusted, 3)
+	assert.True(t, trusted[0].Contains(net.ParseIP("10.1.2.3")))
+	assert.True(t, trusted[1].Contains(net.ParseIP("192.0.2.1")))
+	assert.False(t, trusted[1].Contains(net.ParseIP("192.0.2.2")))
+	assert.True(t, trusted[2].Contains(net.ParseIP("::1")))
+
+	_, err = ParseTrustedProxies([]string{"example.com"})
+	assert.Error(t, err)
+}
+
+func TestListener(t *testing.T) {
+	inner, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer inner.Close()
+
+	dial := func(payload string) net.Conn {
+		client, err := net.Dial("tcp", inner.Addr().String())
+		require.NoError(t, err)
+		_, err = client.Write([]byte(payload))
+		require.NoError(t, err)
+		return client
+	}
+
+	t.Run("trusted proxy", func(t *testing.T) {
+		listener, err := NewListener(inner, []string{"127.0.0.1"}, time.Second)
+		require.NoError(t, err)
+
+		client := dial("PROXY TCP4 192.0.2.10 127.0.0.1 56324 2222\r\nhello")
+		defer client.Close()
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		assert.Equal(t, "192.0.2.10:56324", conn.RemoteAddr().String())
+		buf := make([]byte, 5)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(buf))
+	})
+
+	t.Run("untrusted peer keeps its address", func(t *testing.T) {
+		listener, err := NewListener(inner, []string{"192.0.2.1"}, time.Second)
+		require.NoError(t, err)
+
+		client := dial("PROXY TCP4 192.0.2.10 127.0.0.1 56324 2222\r\n")
+		defer client.Close()
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		assert.Equal(t, "127.0.0.1", conn.RemoteAddr().(*net.TCPAddr).IP.String())
+	})
+
+	t.Run("trusted proxy without header", func(t *testing.T) {
+		listener, err := NewListener(inner, []string{"127.0.0.0/8"}, time.Second)
+		require.NoError(t, err)
+
+		client := dial("SSH-2.0-OpenSSH_9.6\r\n")
+		defer client.Close()
+		conn, err := listener.Accept()
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Read(make([]byte, 8))
+		assert.ErrorIs(t, err, ErrNoHeader)
+	})
+
+	_, err = NewListener(inner, nil, 0)
+	assert.Error(t, err)
+}
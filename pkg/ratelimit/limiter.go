@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how many calls pass between sweeps of idle buckets
+const sweepInterval = 1024
+
+// Rate is how many requests a caller may make per period. Burst requests
+// may arrive at once; the bucket then refills evenly over the period.
+type Rate struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// PerMinute returns a rate of n requests a minute with a burst of n
+func PerMinute(n int) Rate {
+	return Rate{Requests: n, Per: time.Minute, Burst: n}
+}
+
+// Limiter is a token bucket rate limiter keyed by caller
+type Limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	calls   int
+	now     func() time.Time
+}
+
+// bucket is one caller's tokens
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	// full is when the bucket will have refilled, after which it may be dropped
+	full time.Time
+}
+
+// NewLimiter creates an empty limiter
+func NewLimiter() *Limiter {
+	return &Limiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the caller's bucket. When the bucket is empty it
+// returns false and how long until a token is available. Rates without
+// requests are unlimited.
+func (l *Limiter) Allow(key string, rate Rate) (bool, time.Duration) {
+	if rate.Requests <= 0 || rate.Per <= 0 {
+		return true, 0
+	}
+	capacity := float64(rate.Burst)
+	if rate.Burst <= 0 {
+		capacity = float64(rate.Requests)
+	}
+	perSecond := float64(rate.Requests) / rate.Per.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.calls++
+	if l.calls%sweepInterval == 0 {
+		l.sweep(now)
+	}
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, updated: now}
+		l.buckets[key] = b
+	}
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.updated).Seconds()*perSecond)
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / perSecond * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	b.full = now.Add(time.Duration((capacity - b.tokens) / perSecond * float64(time.Second)))
+	return true, 0
+}
+
+// sweep drops the buckets that have refilled, which a new bucket replaces
+func (l *Limiter) sweep(now time.Time) {
+	for key, b := range l.buckets {
+		if !now.Before(b.full) {
+			delete(l.buckets, key)
+		}
+	}
+}
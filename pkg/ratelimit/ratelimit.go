@@ -0,0 +1,172 @@
+// Package ratelimit limits API requests per caller address, user or API key
+// with token buckets, following the rate limit rules of a service's config.
+// Policy.Middleware applies the rules to HTTP handlers; the interceptors
+// package applies them to gRPC calls.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/apierror"
+	"github.com/dungeongate/pkg/config"
+)
+
+// Callers a rule may limit
+const (
+	ByIP     = "ip"
+	ByUser   = "user"
+	ByAPIKey = "api_key"
+)
+
+// Caller identifies who made a request. User and APIKey are empty for
+// callers who did not authenticate.
+type Caller struct {
+	IP     string
+	User   string
+	APIKey string
+}
+
+// key returns the bucket of the caller for a rule; callers without the
+// identity a rule asks for fall back to their address
+func (c Caller) key(by string) string {
+	switch {
+	case by == ByAPIKey && c.APIKey != "":
+		return "key:" + c.APIKey
+	case by != ByIP && c.User != "":
+		return "user:" + c.User
+	default:
+		return "ip:" + c.IP
+	}
+}
+
+// rule is a compiled config.RateLimitRule
+type rule struct {
+	match string
+	by    string
+	rate  Rate
+}
+
+// Policy applies rate limit rules to requests. A nil policy allows everything.
+type Policy struct {
+	rules   []rule
+	limiter *Limiter
+}
+
+// NewPolicy compiles the rate limit rules of a config. It returns nil when
+// rate limiting is disabled.
+func NewPolicy(cfg *config.APIRateLimitConfig) (*Policy, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	policy := &Policy{limiter: NewLimiter()}
+	for i, r := range cfg.Rules {
+		if r == nil {
+			continue
+		}
+		by := r.By
+		if by == "" {
+			by = ByIP
+		}
+		if by != ByIP && by != ByUser && by != ByAPIKey {
+			return nil, fmt.Errorf("rate limit rule %d: by must be ip, user or api_key, not %q", i+1, r.By)
+		}
+		if r.Requests <= 0 {
+			return nil, fmt.Errorf("rate limit rule %d: requests must be positive", i+1)
+		}
+		per := time.Minute
+		if r.Per != "" {
+			parsed, err := time.ParseDuration(r.Per)
+			if err != nil || parsed <= 0 {
+				return nil, fmt.Errorf("rate limit rule %d: invalid period %q", i+1, r.Per)
+			}
+			per = parsed
+		}
+		policy.rules = append(policy.rules, rule{
+			match: r.Match,
+			by:    by,
+			rate:  Rate{Requests: r.Requests, Per: per, Burst: r.Burst},
+		})
+	}
+	return policy, nil
+}
+
+// Allow takes a token from every bucket of the caller that the rules for a
+// request name apply to. It returns false and the longest wait when any of
+// them is empty.
+func (p *Policy) Allow(name string, caller Caller) (bool, time.Duration) {
+	if p == nil {
+		return true, 0
+	}
+
+	// The rules with the longest matching prefix apply
+	best := -1
+	for _, r := range p.rules {
+		if strings.HasPrefix(name, r.match) && len(r.match) > best {
+			best = len(r.match)
+		}
+	}
+
+	allowed, wait := true, time.Duration(0)
+	for _, r := range p.rules {
+		if len(r.match) != best || !strings.HasPrefix(name, r.match) {
+			continue
+		}
+		if ok, w := p.limiter.Allow(r.match+"|"+caller.key(r.by), r.rate); !ok {
+			allowed = false
+			wait = max(wait, w)
+		}
+	}
+	return allowed, wait
+}
+
+// identityKey is the context key of the authenticated caller
+type identityKey struct{}
+
+// WithIdentity records the user and API key of an authenticated request so
+// the rules limiting users and keys can tell callers apart
+func WithIdentity(ctx context.Context, user, apiKey string) context.Context {
+	return context.WithValue(ctx, identityKey{}, Caller{User: user, APIKey: apiKey})
+}
+
+// HTTPCaller identifies the caller of an HTTP request
+func HTTPCaller(r *http.Request) Caller {
+	caller, _ := r.Context().Value(identityKey{}).(Caller)
+	caller.IP = r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		caller.IP = host
+	}
+	return caller
+}
+
+// RetryAfter returns a wait in whole seconds, as sent in Retry-After
+func RetryAfter(wait time.Duration) string {
+	return strconv.Itoa(int(math.Max(1, math.Ceil(wait.Seconds()))))
+}
+
+// Middleware limits requests to a handler by their path, answering 429 Too
+// Many Requests with Retry-After when a caller is over a limit. Put it inside
+// any authentication middleware so user and API key rules apply. Health
+// checks are never limited.
+func (p *Policy) Middleware(next http.Handler) http.Handler {
+	if p == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			if ok, wait := p.Allow(r.URL.Path, HTTPCaller(r)); !ok {
+				w.Header().Set("Retry-After", RetryAfter(wait))
+				apierror.WriteProblem(w, r, apierror.New(apierror.RateLimited, "Rate limit exceeded"))
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter_Refills(t *testing.T) {
+	limiter := NewLimiter()
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 60; i++ {
+		ok, _ := limiter.Allow("key", PerMinute(60))
+		require.True(t, ok)
+	}
+	ok, wait := limiter.Allow("key", PerMinute(60))
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, wait)
+
+	now = now.Add(time.Second)
+	ok, _ = limiter.Allow("key", PerMinute(60))
+	assert.True(t, ok)
+
+	// Other callers are unaffected
+	ok, _ = limiter.Allow("other", PerMinute(60))
+	assert.True(t, ok)
+}
+
+func TestLimiter_Burst(t *testing.T) {
+	limiter := NewLimiter()
+	rate := Rate{Requests: 60, Per: time.Minute, Burst: 2}
+
+	for i := 0; i < 2; i++ {
+		ok, _ := limiter.Allow("key", rate)
+		require.True(t, ok)
+	}
+	ok, _ := limiter.Allow("key", rate)
+	assert.False(t, ok)
+}
+
+func TestNewPolicy(t *testing.T) {
+	policy, err := NewPolicy(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+	ok, _ := policy.Allow("/anything", Caller{IP: "10.0.0.1"})
+	assert.True(t, ok)
+
+	_, err = NewPolicy(&config.APIRateLimitConfig{Enabled: true, Rules: []*config.RateLimitRule{{By: "session", Requests: 1}}})
+	assert.Error(t, err)
+	_, err = NewPolicy(&config.APIRateLimitConfig{Enabled: true, Rules: []*config.RateLimitRule{{Requests: 1, Per: "soon"}}})
+	assert.Error(t, err)
+}
+
+func TestPolicy_LongestMatchApplies(t *testing.T) {
+	policy, err := NewPolicy(&config.APIRateLimitConfig{
+		Enabled: true,
+		Rules: []*config.RateLimitRule{
+			{Requests: 100},
+			{Match: "/api/v1/games", By: "user", Requests: 1},
+			{Match: "/api/v1/games", By: "ip", Requests: 2},
+		},
+	})
+	require.NoError(t, err)
+
+	alice := Caller{IP: "10.0.0.1", User: "1"}
+	bob := Caller{IP: "10.0.0.1", User: "2"}
+
+	ok, _ := policy.Allow("/api/v1/games", alice)
+	require.True(t, ok)
+	ok, wait := policy.Allow("/api/v1/games", alice)
+	assert.False(t, ok, "alice is over the per-user limit")
+	assert.Greater(t, wait, time.Duration(0))
+
+	// Bob has his own user bucket; the address bucket allowed two calls and
+	// alice's refused one still took from it
+	ok, _ = policy.Allow("/api/v1/games", bob)
+	assert.False(t, ok)
+
+	// Other paths fall back to the catch-all rule
+	ok, _ = policy.Allow("/api/v1/sessions", alice)
+	assert.True(t, ok)
+}
+
+func TestPolicy_Middleware(t *testing.T) {
+	policy, err := NewPolicy(&config.APIRateLimitConfig{
+		Enabled: true,
+		Rules:   []*config.RateLimitRule{{Requests: 1, Per: "1m"}},
+	})
+	require.NoError(t, err)
+	handler := policy.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	serve := func(path, remote string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remote
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusOK, serve("/stats", "10.0.0.1:1000").Code)
+	rec := serve("/stats", "10.0.0.1:1001")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "60", rec.Header().Get("Retry-After"))
+
+	// Other addresses and health checks are unaffected
+	assert.Equal(t, http.StatusOK, serve("/stats", "10.0.0.2:1000").Code)
+	assert.Equal(t, http.StatusOK, serve("/health", "10.0.0.1:1002").Code)
+}
@@ -0,0 +1,244 @@
+// Package retention decides how long personal data is kept. A Policy holds
+// the retention period of each category of data and the legal holds that
+// exempt users and sessions from purging; the services that store the data
+// purge what the policy says has expired, and record it in a Report.
+package retention
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// Categories of data with a retention period
+const (
+	// Recordings are the ttyrec recordings of games
+	Recordings = "recordings"
+	// SessionTranscripts are captures of the terminal I/O of sessions
+	SessionTranscripts = "session_transcripts"
+	// InactiveAccounts are user accounts that were not logged in to
+	InactiveAccounts = "inactive_accounts"
+	// AuditLogs are the moderation and account access logs
+	AuditLogs = "audit_logs"
+)
+
+const (
+	// day and year are the units of periods in days and years
+	day  = 24 * time.Hour
+	year = 365 * day
+	// defaultInterval is how often retention is enforced when
+	// retention.interval is not set
+	defaultInterval = 24 * time.Hour
+	// keepForever is the period of categories that are never purged
+	keepForever = "forever"
+)
+
+// DefaultPeriods are how long each category is kept unless configured
+var DefaultPeriods = map[string]time.Duration{
+	Recordings:         30 * day,
+	SessionTranscripts: 7 * day,
+	InactiveAccounts:   2 * year,
+	AuditLogs:          year,
+}
+
+// Policy is the configured retention of each category of data
+type Policy struct {
+	// periods are how long each category is kept; categories kept forever
+	// are missing
+	periods      map[string]time.Duration
+	interval     time.Duration
+	dryRun       bool
+	heldUsers    map[string]bool
+	heldSessions map[string]bool
+	reportDir    string
+	now          func() time.Time
+}
+
+// NewPolicy returns the policy cfg configures, or nil when retention is not
+// enabled
+func NewPolicy(cfg *config.RetentionConfig) (*Policy, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, nil
+	}
+
+	p := &Policy{
+		periods:      make(map[string]time.Duration, len(DefaultPeriods)),
+		interval:     config.ParseDuration(cfg.Interval, defaultInterval),
+		dryRun:       cfg.DryRun,
+		heldUsers:    make(map[string]bool),
+		heldSessions: make(map[string]bool),
+		reportDir:    cfg.ReportDirectory,
+		now:          time.Now,
+	}
+	for category, period := range DefaultPeriods {
+		p.periods[category] = period
+	}
+	for category, value := range cfg.Policies {
+		if _, ok := DefaultPeriods[category]; !ok {
+			return nil, fmt.Errorf("unknown retention category %q", category)
+		}
+		period, err := parsePeriod(value)
+		if err != nil {
+			return nil, fmt.Errorf("retention.policies.%s: %w", category, err)
+		}
+		if period == 0 {
+			delete(p.periods, category)
+		} else {
+			p.periods[category] = period
+		}
+	}
+
+	if holds := cfg.LegalHolds; holds != nil {
+		for _, username := range holds.Users {
+			p.heldUsers[strings.ToLower(username)] = true
+		}
+		for _, sessionID := range holds.Sessions {
+			p.heldSessions[sessionID] = true
+		}
+	}
+	return p, nil
+}
+
+// parsePeriod parses a retention period: a Go duration, whole days ("30d")
+// or years ("2y"), or "forever", which is returned as zero
+func parsePeriod(value string) (time.Duration, error) {
+	if value == keepForever {
+		return 0, nil
+	}
+	if years, ok := strings.CutSuffix(value, "y"); ok {
+		if n, err := strconv.Atoi(years); err == nil && n > 0 {
+			return time.Duration(n) * year, nil
+		}
+	} else if period := config.ParseDuration(value, -1); period > 0 {
+		return period, nil
+	}
+	return 0, fmt.Errorf("%q is not a positive duration, days, years or %q", value, keepForever)
+}
+
+// Interval is how often retention is enforced
+func (p *Policy) Interval() time.Duration {
+	return p.interval
+}
+
+// Cutoff returns the time before which data of a category has expired, or
+// false when the category is kept forever
+func (p *Policy) Cutoff(category string) (time.Time, bool) {
+	period, ok := p.periods[category]
+	if !ok {
+		return time.Time{}, false
+	}
+	return p.now().Add(-period), true
+}
+
+// UserHeld reports whether a user's data is under legal hold
+func (p *Policy) UserHeld(username string) bool {
+	return p.heldUsers[strings.ToLower(username)]
+}
+
+// SessionHeld reports whether a session's data is under legal hold
+func (p *Policy) SessionHeld(sessionID string) bool {
+	return p.heldSessions[sessionID]
+}
+
+// HeldUsers lists the usernames under legal hold, lowercased
+func (p *Policy) HeldUsers() []string {
+	users := make([]string, 0, len(p.heldUsers))
+	for username := range p.heldUsers {
+		users = append(users, username)
+	}
+	slices.Sort(users)
+	return users
+}
+
+// Report describes what one run of a service's retention job purged, or
+// would have purged in dry-run mode
+type Report struct {
+	Service    string    `json:"service"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	DryRun     bool      `json:"dry_run"`
+	// Purged counts what was purged by category
+	Purged map[string]int `json:"purged"`
+	// Items identify what was purged by category, such as file names or
+	// user IDs, where there are few enough to list
+	Items map[string][]string `json:"items,omitempty"`
+	// Held counts what had expired but was kept for a legal hold
+	Held   map[string]int `json:"held,omitempty"`
+	Errors []string       `json:"errors,omitempty"`
+}
+
+// NewReport starts the report of a run of service's retention job
+func (p *Policy) NewReport(service string) *Report {
+	return &Report{
+		Service:   service,
+		StartedAt: p.now(),
+		DryRun:    p.dryRun,
+		Purged:    make(map[string]int),
+		Items:     make(map[string][]string),
+		Held:      make(map[string]int),
+	}
+}
+
+// Purge records that an item of a category was purged; an empty item is
+// only counted
+func (r *Report) Purge(category, item string) {
+	r.Purged[category]++
+	if item != "" {
+		r.Items[category] = append(r.Items[category], item)
+	}
+}
+
+// PurgeCount records that n items of a category were purged, too many to list
+func (r *Report) PurgeCount(category string, n int) {
+	r.Purged[category] += n
+}
+
+// Hold records that an expired item of a category was kept for a legal hold
+func (r *Report) Hold(category string) {
+	r.Held[category]++
+}
+
+// Fail records a problem that did not stop the run
+func (r *Report) Fail(err error) {
+	r.Errors = append(r.Errors, err.Error())
+}
+
+// Finish completes a report: it logs it, and writes it as JSON to the report
+// directory if one is configured
+func (p *Policy) Finish(report *Report, logger *slog.Logger) error {
+	report.FinishedAt = p.now()
+	for _, problem := range report.Errors {
+		logger.Warn("Retention problem", "error", problem)
+	}
+	logger.Info("Retention enforced",
+		"audit", true,
+		"dry_run", report.DryRun,
+		"purged", report.Purged,
+		"held", report.Held,
+		"errors", len(report.Errors),
+	)
+
+	if p.reportDir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(p.reportDir, 0700); err != nil {
+		return fmt.Errorf("failed to create retention report directory: %w", err)
+	}
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode retention report: %w", err)
+	}
+	name := fmt.Sprintf("retention-%s-%s.json", report.Service, report.StartedAt.UTC().Format("20060102T150405Z"))
+	if err := os.WriteFile(filepath.Join(p.reportDir, name), data, 0600); err != nil {
+		return fmt.Errorf("failed to write retention report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,106 @@
+package retention
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+func TestNewPolicy_Disabled(t *testing.T) {
+	policy, err := NewPolicy(nil)
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+
+	policy, err = NewPolicy(&config.RetentionConfig{Policies: map[string]string{"bogus": "1d"}})
+	require.NoError(t, err)
+	assert.Nil(t, policy)
+}
+
+func TestNewPolicy_Periods(t *testing.T) {
+	now := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	policy, err := NewPolicy(&config.RetentionConfig{
+		Enabled: true,
+		Policies: map[string]string{
+			Recordings:       "90d",
+			InactiveAccounts: "3y",
+			AuditLogs:        "forever",
+		},
+	})
+	require.NoError(t, err)
+	policy.now = func() time.Time { return now }
+
+	cutoff, ok := policy.Cutoff(Recordings)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-90*24*time.Hour), cutoff)
+
+	cutoff, ok = policy.Cutoff(InactiveAccounts)
+	assert.True(t, ok)
+	assert.Equal(t, now.Add(-3*365*24*time.Hour), cutoff)
+
+	cutoff, ok = policy.Cutoff(SessionTranscripts)
+	assert.True(t, ok, "categories that are not configured keep their default")
+	assert.Equal(t, now.Add(-7*24*time.Hour), cutoff)
+
+	_, ok = policy.Cutoff(AuditLogs)
+	assert.False(t, ok)
+	assert.Equal(t, 24*time.Hour, policy.Interval())
+}
+
+func TestNewPolicy_Invalid(t *testing.T) {
+	for name, policies := range map[string]map[string]string{
+		"unknown category": {"screenshots": "1d"},
+		"zero":             {Recordings: "0"},
+		"negative years":   {Recordings: "-1y"},
+		"garbage":          {Recordings: "soon"},
+	} {
+		_, err := NewPolicy(&config.RetentionConfig{Enabled: true, Policies: policies})
+		assert.Error(t, err, name)
+	}
+}
+
+func TestPolicy_LegalHolds(t *testing.T) {
+	policy, err := NewPolicy(&config.RetentionConfig{
+		Enabled:    true,
+		LegalHolds: &config.LegalHoldConfig{Users: []string{"Alice"}, Sessions: []string{"session_1"}},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, policy.UserHeld("alice"))
+	assert.False(t, policy.UserHeld("bob"))
+	assert.True(t, policy.SessionHeld("session_1"))
+	assert.False(t, policy.SessionHeld("session_2"))
+	assert.Equal(t, []string{"alice"}, policy.HeldUsers())
+}
+
+func TestPolicy_FinishWritesReport(t *testing.T) {
+	dir := t.TempDir()
+	policy, err := NewPolicy(&config.RetentionConfig{Enabled: true, DryRun: true, ReportDirectory: dir})
+	require.NoError(t, err)
+
+	report := policy.NewReport("game")
+	report.Purge(Recordings, "session_1.ttyrec")
+	report.Purge(AuditLogs, "")
+	report.Hold(Recordings)
+	require.NoError(t, policy.Finish(report, slog.Default()))
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	require.NoError(t, err)
+
+	var written Report
+	require.NoError(t, json.Unmarshal(data, &written))
+	assert.True(t, written.DryRun)
+	assert.Equal(t, map[string]int{Recordings: 1, AuditLogs: 1}, written.Purged)
+	assert.Equal(t, []string{"session_1.ttyrec"}, written.Items[Recordings])
+	assert.Equal(t, 1, written.Held[Recordings])
+}
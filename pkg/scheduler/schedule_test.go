@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Intervals(t *testing.T) {
+	start := time.Date(2026, 3, 14, 10, 20, 30, 0, time.UTC)
+
+	for _, spec := range []string{"@every 15m", "15m"} {
+		schedule, err := Parse(spec)
+		require.NoError(t, err, spec)
+		assert.Equal(t, start.Add(15*time.Minute), schedule.Next(start), spec)
+	}
+
+	_, err := Parse("@every 10ms")
+	assert.Error(t, err)
+	_, err = Parse("@every soon")
+	assert.Error(t, err)
+	_, err = Parse("")
+	assert.Error(t, err)
+}
+
+func TestParse_Cron(t *testing.T) {
+	// A Saturday
+	start := time.Date(2026, 3, 14, 10, 20, 30, 0, time.UTC)
+
+	tests := []struct {
+		spec string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 3, 14, 10, 21, 0, 0, time.UTC)},
+		{"30 4 * * *", time.Date(2026, 3, 15, 4, 30, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 9-17/4 * * *", time.Date(2026, 3, 14, 13, 0, 0, 0, time.UTC)},
+		{"0,45 10 * * *", time.Date(2026, 3, 14, 10, 45, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 3, 14, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		// Sunday as 0 and as 7
+		{"@weekly", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 1 *", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted, either day matches
+		{"0 12 20 * 1", time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)},
+		// Leap days are found years ahead
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		schedule, err := Parse(tt.spec)
+		require.NoError(t, err, tt.spec)
+		assert.Equal(t, tt.want, schedule.Next(start), tt.spec)
+	}
+}
+
+func TestParse_InvalidCron(t *testing.T) {
+	for _, spec := range []string{
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+		"* * * *",
+		"0 0 31 2 *",
+	} {
+		_, err := Parse(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -0,0 +1,274 @@
+// Package scheduler runs the background jobs of a service, such as storage
+// cleanup, on cron-like schedules. Runs of a job never overlap, may be spread
+// out with jitter, are counted per job, and singleton jobs run only on the
+// replica that holds leadership.
+//
+// Loops that belong to one connection or game, such as keepalives, are not
+// jobs. Three service-wide loops keep their own timers too: leader lease
+// renewal, which singleton jobs depend on; log file rotation, set up before
+// any service schedules its jobs; and rereading feature flags, which starts
+// with a read the flags are needed for at once.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+)
+
+// Run statuses, as recorded per job
+const (
+	StatusSuccess = "success"
+	StatusError   = "error"
+	// StatusSkipped runs were due while the previous run was still going
+	StatusSkipped = "skipped"
+	// StatusStandby runs were due on a replica that is not the leader
+	StatusStandby = "standby"
+)
+
+// Job is a task run on a schedule
+type Job struct {
+	// Name identifies the job in logs, metrics and the server.jobs config
+	Name     string
+	Schedule Schedule
+	// Jitter delays each run by a random duration up to this long, so jobs
+	// on the same schedule and replicas of a service spread out
+	Jitter time.Duration
+	// Timeout bounds a run; zero leaves it unbounded
+	Timeout time.Duration
+	// Singleton jobs run on one replica only, the leader's
+	Singleton bool
+	Run       func(ctx context.Context) error
+}
+
+// Recorder counts job runs; metrics.Registry implements it
+type Recorder interface {
+	RecordJobRun(job, status string, duration time.Duration)
+}
+
+// Leader reports whether this replica should run singleton jobs
+type Leader interface {
+	IsLeader() bool
+}
+
+// Campaigner is a Leader that campaigns for leadership while it runs, such
+// as the electors of package leader
+type Campaigner interface {
+	Leader
+	Run(ctx context.Context)
+}
+
+// entry is a scheduled job and its state
+type entry struct {
+	job     Job
+	running atomic.Bool
+}
+
+// Scheduler runs jobs on their schedules
+type Scheduler struct {
+	overrides map[string]*config.JobConfig
+	logger    *slog.Logger
+	recorder  Recorder
+	leader    Leader
+
+	mu      sync.Mutex
+	entries []*entry
+	runs    sync.WaitGroup
+}
+
+// New creates a scheduler. overrides are the server.jobs settings, by job
+// name, which replace the schedules and jitter jobs are added with.
+func New(overrides map[string]*config.JobConfig, logger *slog.Logger) *Scheduler {
+	return &Scheduler{overrides: overrides, logger: logger}
+}
+
+// SetRecorder sets where job runs are counted
+func (s *Scheduler) SetRecorder(recorder Recorder) {
+	s.recorder = recorder
+}
+
+// SetLeader sets who decides whether singleton jobs run here. Without one
+// this replica runs them, which suits a single replica. A Campaigner
+// campaigns while the scheduler runs.
+func (s *Scheduler) SetLeader(leader Leader) {
+	s.leader = leader
+}
+
+// Add schedules a job, applying its server.jobs settings. Jobs disabled
+// there are left out. Add jobs before calling Run.
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("job needs a name and a function to run")
+	}
+
+	if override := s.overrides[job.Name]; override != nil {
+		if override.Disabled {
+			s.logger.Info("Scheduled job disabled", "job", job.Name)
+			return nil
+		}
+		if override.Schedule != "" {
+			schedule, err := Parse(override.Schedule)
+			if err != nil {
+				return fmt.Errorf("job %s: %w", job.Name, err)
+			}
+			job.Schedule = schedule
+		}
+		if override.Jitter != "" {
+			jitter, err := time.ParseDuration(override.Jitter)
+			if err != nil || jitter < 0 {
+				return fmt.Errorf("job %s: invalid jitter %q", job.Name, override.Jitter)
+			}
+			job.Jitter = jitter
+		}
+	}
+	if job.Schedule == nil {
+		return fmt.Errorf("job %s has no schedule", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.entries {
+		if existing.job.Name == job.Name {
+			return fmt.Errorf("job %s is already scheduled", job.Name)
+		}
+	}
+	s.entries = append(s.entries, &entry{job: job})
+	return nil
+}
+
+// Jobs returns the names of the scheduled jobs
+func (s *Scheduler) Jobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.entries))
+	for _, e := range s.entries {
+		names = append(names, e.job.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Run runs the jobs on their schedules, and the leader's campaign, until ctx
+// is cancelled, then waits for the runs in progress, which see ctx
+// cancelled, to return
+func (s *Scheduler) Run(ctx context.Context) {
+	s.mu.Lock()
+	entries := append([]*entry(nil), s.entries...)
+	s.mu.Unlock()
+
+	for name := range s.overrides {
+		known := false
+		for _, e := range entries {
+			known = known || e.job.Name == name
+		}
+		if !known {
+			s.logger.Warn("server.jobs names a job this service does not have", "job", name)
+		}
+	}
+
+	var loops sync.WaitGroup
+	if campaigner, ok := s.leader.(Campaigner); ok {
+		loops.Add(1)
+		go func() {
+			defer loops.Done()
+			campaigner.Run(ctx)
+		}()
+	}
+	for _, e := range entries {
+		loops.Add(1)
+		go func(e *entry) {
+			defer loops.Done()
+			s.loop(ctx, e)
+		}(e)
+	}
+	loops.Wait()
+	s.runs.Wait()
+}
+
+// loop waits for each run of a job and starts it
+func (s *Scheduler) loop(ctx context.Context, e *entry) {
+	s.logger.Info("Scheduled job started", "job", e.job.Name, "next_run", e.job.Schedule.Next(time.Now()))
+
+	for {
+		next := e.job.Schedule.Next(time.Now())
+		if next.IsZero() {
+			s.logger.Info("Scheduled job has no further runs", "job", e.job.Name)
+			return
+		}
+		if e.job.Jitter > 0 {
+			next = next.Add(rand.N(e.job.Jitter))
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.start(ctx, e)
+	}
+}
+
+// start runs a job in the background unless its previous run is still going
+// or another replica leads
+func (s *Scheduler) start(ctx context.Context, e *entry) {
+	name := e.job.Name
+	if e.job.Singleton && s.leader != nil && !s.leader.IsLeader() {
+		s.logger.Debug("Skipping singleton job on standby replica", "job", name)
+		s.record(name, StatusStandby, 0)
+		return
+	}
+	if !e.running.CompareAndSwap(false, true) {
+		s.logger.Warn("Skipping scheduled job, previous run still going", "job", name)
+		s.record(name, StatusSkipped, 0)
+		return
+	}
+
+	s.runs.Add(1)
+	go func() {
+		defer s.runs.Done()
+		defer e.running.Store(false)
+
+		started := time.Now()
+		err := s.runJob(ctx, e.job)
+		duration := time.Since(started)
+		if err != nil {
+			s.logger.Error("Scheduled job failed", "job", name, "duration", duration, "error", err)
+			s.record(name, StatusError, duration)
+			return
+		}
+		s.logger.Debug("Scheduled job finished", "job", name, "duration", duration)
+		s.record(name, StatusSuccess, duration)
+	}()
+}
+
+// runJob runs a job within its timeout, turning a panic into an error
+func (s *Scheduler) runJob(ctx context.Context, job Job) (err error) {
+	if job.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, job.Timeout)
+		defer cancel()
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+// record counts a run when a recorder is set
+func (s *Scheduler) record(job, status string, duration time.Duration) {
+	if s.recorder != nil {
+		s.recorder.RecordJobRun(job, status, duration)
+	}
+}
@@ -0,0 +1,202 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dungeongate/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRecorder counts job runs by status
+type fakeRecorder struct {
+	mu   sync.Mutex
+	runs map[string]int
+}
+
+func (f *fakeRecorder) RecordJobRun(job, status string, duration time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.runs[job+"/"+status]++
+}
+
+func (f *fakeRecorder) count(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.runs[key]
+}
+
+// fixedLeader is a leader that never changes
+type fixedLeader bool
+
+func (l fixedLeader) IsLeader() bool { return bool(l) }
+
+// tick is a fast schedule for tests, which Parse would refuse
+type tick time.Duration
+
+func (t tick) Next(after time.Time) time.Time { return after.Add(time.Duration(t)) }
+
+func newTestScheduler(overrides map[string]*config.JobConfig) (*Scheduler, *fakeRecorder) {
+	recorder := &fakeRecorder{runs: make(map[string]int)}
+	s := New(overrides, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.SetRecorder(recorder)
+	return s, recorder
+}
+
+func TestScheduler_RunsJobs(t *testing.T) {
+	s, recorder := newTestScheduler(nil)
+
+	var runs atomic.Int32
+	require.NoError(t, s.Add(Job{Name: "count", Schedule: tick(5 * time.Millisecond), Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}}))
+	require.NoError(t, s.Add(Job{Name: "fail", Schedule: tick(5 * time.Millisecond), Run: func(ctx context.Context) error {
+		return errors.New("disk full")
+	}}))
+	require.NoError(t, s.Add(Job{Name: "panic", Schedule: tick(5 * time.Millisecond), Run: func(ctx context.Context) error {
+		panic("bug")
+	}}))
+	assert.Equal(t, []string{"count", "fail", "panic"}, s.Jobs())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return runs.Load() >= 3 && recorder.count("fail/error") > 0 && recorder.count("panic/error") > 0
+	}, 2*time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+	assert.Positive(t, recorder.count("count/success"))
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	s, recorder := newTestScheduler(nil)
+
+	release := make(chan struct{})
+	var running, maxRunning atomic.Int32
+	require.NoError(t, s.Add(Job{Name: "slow", Schedule: tick(2 * time.Millisecond), Run: func(ctx context.Context) error {
+		if n := running.Add(1); n > maxRunning.Load() {
+			maxRunning.Store(n)
+		}
+		defer running.Add(-1)
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return recorder.count("slow/skipped") >= 2 }, 2*time.Second, time.Millisecond)
+	close(release)
+	cancel()
+	<-done
+	assert.Equal(t, int32(1), maxRunning.Load())
+}
+
+func TestScheduler_SingletonJobsFollowLeader(t *testing.T) {
+	s, recorder := newTestScheduler(nil)
+	s.SetLeader(fixedLeader(false))
+
+	var singletonRuns, everywhereRuns atomic.Int32
+	require.NoError(t, s.Add(Job{Name: "singleton", Schedule: tick(2 * time.Millisecond), Singleton: true, Run: func(ctx context.Context) error {
+		singletonRuns.Add(1)
+		return nil
+	}}))
+	require.NoError(t, s.Add(Job{Name: "everywhere", Schedule: tick(2 * time.Millisecond), Run: func(ctx context.Context) error {
+		everywhereRuns.Add(1)
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool {
+		return everywhereRuns.Load() >= 2 && recorder.count("singleton/standby") >= 2
+	}, 2*time.Second, time.Millisecond)
+	cancel()
+	<-done
+	assert.Zero(t, singletonRuns.Load())
+}
+
+func TestScheduler_Overrides(t *testing.T) {
+	s, _ := newTestScheduler(map[string]*config.JobConfig{
+		"cleanup": {Schedule: "30 4 * * *", Jitter: "5m"},
+		"backup":  {Disabled: true},
+		"broken":  {Schedule: "every tuesday"},
+	})
+	run := func(ctx context.Context) error { return nil }
+
+	require.NoError(t, s.Add(Job{Name: "cleanup", Schedule: Every(time.Hour), Run: run}))
+	require.NoError(t, s.Add(Job{Name: "backup", Schedule: Every(time.Hour), Run: run}))
+	assert.Error(t, s.Add(Job{Name: "broken", Schedule: Every(time.Hour), Run: run}))
+	assert.Error(t, s.Add(Job{Name: "cleanup", Schedule: Every(time.Hour), Run: run}), "names are unique")
+	assert.Error(t, s.Add(Job{Name: "unscheduled", Run: run}))
+	assert.Equal(t, []string{"cleanup"}, s.Jobs())
+
+	cleanup := s.entries[0].job
+	assert.Equal(t, 5*time.Minute, cleanup.Jitter)
+	next := cleanup.Schedule.Next(time.Date(2026, 3, 14, 10, 0, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2026, 3, 15, 4, 30, 0, 0, time.Local), next)
+}
+
+// campaigner leads once its campaign has started
+type campaigner struct {
+	leading atomic.Bool
+	stopped atomic.Bool
+}
+
+func (c *campaigner) IsLeader() bool { return c.leading.Load() }
+
+func (c *campaigner) Run(ctx context.Context) {
+	c.leading.Store(true)
+	<-ctx.Done()
+	c.leading.Store(false)
+	c.stopped.Store(true)
+}
+
+func TestScheduler_RunsCampaign(t *testing.T) {
+	s, _ := newTestScheduler(nil)
+	leader := &campaigner{}
+	s.SetLeader(leader)
+
+	var runs atomic.Int32
+	require.NoError(t, s.Add(Job{Name: "singleton", Schedule: tick(2 * time.Millisecond), Singleton: true, Run: func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, 2*time.Second, time.Millisecond)
+	cancel()
+	<-done
+	assert.True(t, leader.stopped.Load(), "Run waits for the campaign to end")
+}
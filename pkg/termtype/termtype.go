@@ -0,0 +1,112 @@
+// Package termtype validates the terminal types and locales clients send and
+// picks the terminal type passed to games. Session services and game
+// services check them the same way.
+package termtype
+
+import (
+	"slices"
+	"strings"
+)
+
+// Default is used when the client did not request a usable terminal type
+const Default = "xterm-256color"
+
+// maxLength bounds the TERM value accepted from a pty-req
+const maxLength = 64
+
+// termFamilies maps terminal name prefixes to compatible, widely available
+// terminfo entries, most capable first. Modern emulators always handle 256
+// colours even when their TERM name does not say so.
+var termFamilies = []struct {
+	prefix     string
+	candidates []string
+	modern     bool
+}{
+	{"xterm-kitty", []string{"xterm-256color", "xterm"}, true},
+	{"xterm-ghostty", []string{"xterm-256color", "xterm"}, true},
+	{"xterm", []string{"xterm-256color", "xterm"}, false},
+	{"tmux", []string{"tmux-256color", "screen-256color", "tmux", "screen"}, false},
+	{"screen", []string{"screen-256color", "screen"}, false},
+	{"rxvt", []string{"rxvt-unicode-256color", "rxvt", "xterm-256color", "xterm"}, false},
+	{"putty", []string{"putty-256color", "putty", "xterm-256color", "xterm"}, false},
+	{"alacritty", []string{"xterm-256color", "xterm"}, true},
+	{"kitty", []string{"xterm-256color", "xterm"}, true},
+	{"wezterm", []string{"xterm-256color", "xterm"}, true},
+	{"foot", []string{"xterm-256color", "xterm"}, true},
+	{"ghostty", []string{"xterm-256color", "xterm"}, true},
+	{"linux", []string{"linux", "vt100"}, false},
+	{"vt", []string{"vt220", "vt100"}, false},
+	{"ansi", []string{"ansi", "vt100"}, false},
+}
+
+// Sanitize validates a client supplied TERM value, returning an empty
+// string when it contains anything other than terminfo name characters
+func Sanitize(term string) string {
+	term = strings.TrimSpace(term)
+	if term == "" || len(term) > maxLength {
+		return ""
+	}
+	for _, r := range term {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-' || r == '_' || r == '.' || r == '+':
+		default:
+			return ""
+		}
+	}
+	return term
+}
+
+// Negotiate picks the terminal type to pass to the game. The requested
+// type is used when it is on the supported list; otherwise the closest
+// supported member of its family is chosen, falling back to fallback for
+// unknown or exotic terminals. An empty supported list accepts any sanitized type.
+func Negotiate(requested string, supported []string, fallback string) string {
+	if fallback == "" {
+		fallback = Default
+	}
+
+	term := Sanitize(requested)
+	if term == "" {
+		return fallback
+	}
+	if len(supported) == 0 || slices.Contains(supported, term) {
+		return term
+	}
+
+	lower := strings.ToLower(term)
+	for _, family := range termFamilies {
+		if !strings.HasPrefix(lower, family.prefix) {
+			continue
+		}
+		for _, candidate := range family.candidates {
+			// Only offer 256 colours if the client can display them
+			if strings.Contains(candidate, "256color") && !family.modern && !strings.Contains(lower, "256color") {
+				continue
+			}
+			if slices.Contains(supported, candidate) {
+				return candidate
+			}
+		}
+	}
+
+	return fallback
+}
+
+// SanitizeLocale validates a client supplied locale name such as en_US.UTF-8,
+// returning an empty string when it is malformed
+func SanitizeLocale(locale string) string {
+	locale = strings.TrimSpace(locale)
+	if locale == "" || len(locale) > maxLength {
+		return ""
+	}
+	for _, r := range locale {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '_' || r == '.' || r == '-' || r == '@':
+		default:
+			return ""
+		}
+	}
+	return locale
+}
@@ -0,0 +1,48 @@
+package termtype
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "xterm-256color", Sanitize("xterm-256color"))
+	assert.Equal(t, "rxvt-unicode", Sanitize(" rxvt-unicode "))
+	assert.Equal(t, "", Sanitize(""))
+	assert.Equal(t, "", Sanitize("xterm;rm -rf /"))
+	assert.Equal(t, "", Sanitize("../../etc/passwd"))
+	assert.Equal(t, "", Sanitize("xterm\x1b[2J"))
+}
+
+func TestNegotiate(t *testing.T) {
+	supported := []string{"xterm", "xterm-256color", "screen", "tmux", "vt100"}
+
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{"supported type is kept", "screen", "screen"},
+		{"empty falls back to default", "", "xterm-256color"},
+		{"invalid falls back to default", "xterm$(id)", "xterm-256color"},
+		{"xterm variant maps to xterm", "xterm-color", "xterm"},
+		{"256 colour variant keeps colours", "xterm-direct256color", "xterm-256color"},
+		{"tmux-256color degrades within family", "tmux-256color", "tmux"},
+		{"modern emulator maps to xterm-256color", "xterm-kitty", "xterm-256color"},
+		{"alacritty maps to xterm-256color", "alacritty", "xterm-256color"},
+		{"vt220 maps to vt100", "vt220", "vt100"},
+		{"unknown terminal uses default", "dumb-teletype", "xterm-256color"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Negotiate(tt.requested, supported, ""))
+		})
+	}
+}
+
+func TestNegotiate_NoAllowlist(t *testing.T) {
+	assert.Equal(t, "wezterm", Negotiate("wezterm", nil, "vt100"))
+	assert.Equal(t, "vt100", Negotiate("bad term", nil, "vt100"))
+}